@@ -5,10 +5,13 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/google/go-github/v53/github"
 	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	segment "github.com/segmentio/analytics-go"
 	"github.com/sirupsen/logrus"
@@ -24,21 +27,54 @@ import (
 
 	"github.com/kubeshop/botkube/internal/analytics"
 	"github.com/kubeshop/botkube/internal/audit"
+	"github.com/kubeshop/botkube/internal/auditlog"
+	"github.com/kubeshop/botkube/internal/authz"
+	"github.com/kubeshop/botkube/internal/autocomplete"
 	"github.com/kubeshop/botkube/internal/command"
 	intconfig "github.com/kubeshop/botkube/internal/config"
+	"github.com/kubeshop/botkube/internal/config/crd"
+	"github.com/kubeshop/botkube/internal/config/gitsync"
+	"github.com/kubeshop/botkube/internal/config/history"
+	"github.com/kubeshop/botkube/internal/config/interpolation"
 	"github.com/kubeshop/botkube/internal/config/reloader"
 	"github.com/kubeshop/botkube/internal/config/remote"
+	"github.com/kubeshop/botkube/internal/config/secret"
+	"github.com/kubeshop/botkube/internal/config/sops"
+	"github.com/kubeshop/botkube/internal/doctor"
+	"github.com/kubeshop/botkube/internal/enrichment"
+	"github.com/kubeshop/botkube/internal/escalation"
+	"github.com/kubeshop/botkube/internal/eventbuffer"
+	"github.com/kubeshop/botkube/internal/featureflag"
+	"github.com/kubeshop/botkube/internal/filter"
 	"github.com/kubeshop/botkube/internal/health"
 	"github.com/kubeshop/botkube/internal/heartbeat"
 	"github.com/kubeshop/botkube/internal/insights"
 	"github.com/kubeshop/botkube/internal/kubex"
+	"github.com/kubeshop/botkube/internal/leader"
+	"github.com/kubeshop/botkube/internal/livewatch"
+	"github.com/kubeshop/botkube/internal/maintenance"
+	"github.com/kubeshop/botkube/internal/metrics"
+	"github.com/kubeshop/botkube/internal/notification"
+	"github.com/kubeshop/botkube/internal/overview"
+	"github.com/kubeshop/botkube/internal/ownership"
+	"github.com/kubeshop/botkube/internal/processing"
+	"github.com/kubeshop/botkube/internal/redaction"
+	"github.com/kubeshop/botkube/internal/reliability"
+	"github.com/kubeshop/botkube/internal/replay"
+	"github.com/kubeshop/botkube/internal/routing"
+	"github.com/kubeshop/botkube/internal/sharding"
 	"github.com/kubeshop/botkube/internal/source"
 	"github.com/kubeshop/botkube/internal/status"
 	"github.com/kubeshop/botkube/internal/storage"
+	"github.com/kubeshop/botkube/internal/ticketing"
+	"github.com/kubeshop/botkube/internal/tracing"
+	"github.com/kubeshop/botkube/internal/upgradecheck"
 	"github.com/kubeshop/botkube/pkg/action"
+	"github.com/kubeshop/botkube/pkg/api"
 	"github.com/kubeshop/botkube/pkg/bot"
 	"github.com/kubeshop/botkube/pkg/bot/interactive"
 	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/config/validation"
 	"github.com/kubeshop/botkube/pkg/controller"
 	"github.com/kubeshop/botkube/pkg/execute"
 	"github.com/kubeshop/botkube/pkg/httpx"
@@ -48,6 +84,7 @@ import (
 	"github.com/kubeshop/botkube/pkg/notifier"
 	"github.com/kubeshop/botkube/pkg/plugin"
 	"github.com/kubeshop/botkube/pkg/sink"
+	"github.com/kubeshop/botkube/pkg/tlsx"
 	"github.com/kubeshop/botkube/pkg/version"
 )
 
@@ -59,49 +96,138 @@ const (
 	printAPIKeyCharCount      = 3
 	reportHeartbeatInterval   = 10
 	reportHeartbeatMaxRetries = 30
+	tracingShutdownTimeout    = 10 * time.Second
+	shutdownNoticeTimeout     = 5 * time.Second
+)
+
+var (
+	// errReloadRequested is returned by run when all components were stopped to apply the changed configuration.
+	errReloadRequested = errors.New("configuration reload requested")
+	// errShutdownRequested is returned by run when all components were gracefully stopped after the termination signal.
+	errShutdownRequested = errors.New("shutdown requested")
 )
 
 func main() {
 	// Set up context
-	ctx := signals.SetupSignalHandler()
-	ctx, cancelCtxFn := context.WithCancel(ctx)
+	// The signal context is cancelled on the termination signal, which starts the graceful shutdown.
+	// Components are stopped with their own context once in-flight events are dispatched. The second signal exits immediately.
+	signalCtx := signals.SetupSignalHandler()
+	ctx, cancelCtxFn := context.WithCancel(context.Background())
 	defer cancelCtxFn()
 
-	err := run(ctx)
-	if errors.Is(err, context.Canceled) {
+	intconfig.RegisterFlags(pflag.CommandLine)
+	pflag.Parse()
+
+	if intconfig.ValidateOnly() {
+		os.Exit(validateConfig(signalCtx))
+	}
+
+	for {
+		err := run(ctx, signalCtx.Done())
+		if errors.Is(err, errReloadRequested) {
+			continue
+		}
+		if errors.Is(err, leader.ErrLeadershipLost) || errors.Is(err, sharding.ErrShardLost) {
+			// all components are stopped, so the replica can stand by again without restarting the process
+			continue
+		}
+		if errors.Is(err, errShutdownRequested) || errors.Is(err, context.Canceled) {
+			return
+		}
+
+		loggerx.ExitOnError(err, "while running application")
 		return
 	}
+}
+
+// validateConfig loads the configuration in the same way as on startup, prints all found issues and returns the exit code.
+func validateConfig(ctx context.Context) int {
+	remoteCfg, remoteCfgEnabled := remote.GetConfig()
+	serverCfg, cfgServerEnabled := remote.GetServerConfig()
+	var deployClient reloader.DeploymentClient
+	switch {
+	case remoteCfgEnabled:
+		deployClient = remote.NewDeploymentClient(remote.NewDefaultGqlClient(remoteCfg))
+	case cfgServerEnabled:
+		deployClient = remote.NewServerClient(serverCfg)
+	}
+
+	interpolationMode, err := intconfig.GetInterpolationMode()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", err.Error())
+		return 1
+	}
+	strictMode, err := intconfig.IsStrictModeEnabled()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", err.Error())
+		return 1
+	}
+
+	gitProvider := gitsync.NewProvider(intconfig.GetProvider(remoteCfgEnabled || cfgServerEnabled, deployClient))
+	configs, _, err := secret.NewProvider(interpolation.NewProvider(sops.NewProvider(gitProvider), interpolationMode)).Configs(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "while loading configuration files: %s\n", err.Error())
+		return 1
+	}
 
-	loggerx.ExitOnError(err, "while running application")
+	result := validation.Configs(configs, strictMode)
+	result.Print(os.Stdout)
+	if result.Err() != nil {
+		return 1
+	}
+	return 0
 }
 
 // run wraps the main logic of the app to be able to properly clean up resources via deferred calls.
-func run(ctx context.Context) (err error) {
+// It returns errReloadRequested once the configuration hot reload is requested and all components are stopped,
+// and errShutdownRequested once the shutdown channel is closed and all components are gracefully stopped.
+func run(ctx context.Context, shutdownCh <-chan struct{}) (err error) {
 	// Load configuration
-	intconfig.RegisterFlags(pflag.CommandLine)
-
 	remoteCfg, remoteCfgEnabled := remote.GetConfig()
+	serverCfg, cfgServerEnabled := remote.GetServerConfig()
 	var (
-		gqlClient    *remote.Gql
-		deployClient *remote.DeploymentClient
+		gqlClient         *remote.Gql
+		deployClient      *remote.DeploymentClient
+		cfgClient         reloader.DeploymentClient
+		remoteSyncEnabled = remoteCfgEnabled || cfgServerEnabled
 	)
 	if remoteCfgEnabled {
 		gqlClient = remote.NewDefaultGqlClient(remoteCfg)
 		deployClient = remote.NewDeploymentClient(gqlClient)
+		cfgClient = deployClient
 	}
 
 	statusReporter := status.GetReporter(remoteCfgEnabled, gqlClient, deployClient, nil)
+	if cfgServerEnabled && !remoteCfgEnabled {
+		serverClient := remote.NewServerClient(serverCfg)
+		cfgClient = serverClient
+		statusReporter = status.NewServerStatusReporter(nil, serverClient)
+	}
 	if err = statusReporter.ReportDeploymentConnectionInit(ctx, ""); err != nil {
 		return fmt.Errorf("while reporting botkube connection initialization %w", err)
 	}
 
-	cfgProvider := intconfig.GetProvider(remoteCfgEnabled, deployClient)
-	configs, cfgVersion, err := cfgProvider.Configs(ctx)
+	interpolationMode, err := intconfig.GetInterpolationMode()
+	if err != nil {
+		return err
+	}
+	strictMode, err := intconfig.IsStrictModeEnabled()
+	if err != nil {
+		return err
+	}
+	gitProvider := gitsync.NewProvider(intconfig.GetProvider(remoteSyncEnabled, cfgClient))
+	// secret references may be interpolated, but secret values are never interpolated
+	secretProvider := secret.NewProvider(interpolation.NewProvider(sops.NewProvider(gitProvider), interpolationMode))
+	var cfgProvider config.Provider = secretProvider
+	if strictMode {
+		cfgProvider = intconfig.NewStrictProvider(cfgProvider)
+	}
+	cfgLayers, cfgVersion, err := config.LayersOf(ctx, cfgProvider)
 	if err != nil {
 		return fmt.Errorf("while loading configuration files: %w", err)
 	}
 
-	conf, confDetails, err := config.LoadWithDefaults(configs)
+	conf, confDetails, err := config.LoadWithDefaults(cfgLayers.Files())
 	if err != nil {
 		return fmt.Errorf("while merging app configuration: %w", err)
 	}
@@ -113,8 +239,27 @@ func run(ctx context.Context) (err error) {
 	if confDetails.ValidateWarnings != nil {
 		logger.Warnf("Configuration validation warnings: %v", confDetails.ValidateWarnings.Error())
 	}
+	// the policy is set before any outbound client is created from the configuration
+	if err := tlsx.SetGlobalPolicy(logger.WithField(componentLogFieldKey, "TLS"), conf.Settings.TLS); err != nil {
+		return fmt.Errorf("while setting global TLS policy: %w", err)
+	}
+	shutdownTracing, err := tracing.Setup(ctx, conf.Settings.Tracing, conf.Settings.ClusterName, version.Short())
+	if err != nil {
+		return fmt.Errorf("while setting up tracing: %w", err)
+	}
+	defer func() {
+		// use separate ctx as parent ctx is already cancelled
+		ctxTimeout, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+		defer cancel()
+		if err := shutdownTracing(ctxTimeout); err != nil {
+			logger.Errorf("while shutting down tracing: %s", err.Error())
+		}
+	}()
+
+	metrics.Configure(conf.Settings.Metrics)
+
 	// Set up analytics reporter
-	analyticsReporter, err := getAnalyticsReporter(conf.Analytics.Disable, logger)
+	analyticsReporter, err := getAnalyticsReporter(conf.Analytics, logger)
 	if err != nil {
 		return fmt.Errorf("while creating analytics reporter: %w", err)
 	}
@@ -139,11 +284,68 @@ func run(ctx context.Context) (err error) {
 		return reportFatalError("while getting K8s clients", err)
 	}
 
+	var crdProvider *crd.Provider
+	if conf.ConfigCRD.Enabled && !remoteSyncEnabled {
+		crdProvider = crd.NewProvider(logger.WithField(componentLogFieldKey, "BotkubeConfig Provider"), cfgProvider, dynamicCli, conf.ConfigCRD)
+		cfgProvider = crdProvider
+
+		cfgLayers, cfgVersion, err = config.LayersOf(ctx, cfgProvider)
+		if err != nil {
+			return reportFatalError("while loading BotkubeConfig resources", err)
+		}
+		conf, _, err = config.LoadWithDefaults(cfgLayers.Files())
+		if err != nil {
+			return reportFatalError("while merging BotkubeConfig resources", err)
+		}
+	}
+
 	// Register current anonymous identity
 	k8sCli, err := kubernetes.NewForConfig(kubeConfig)
 	if err != nil {
 		return reportFatalError("while creating K8s clientset", err)
 	}
+
+	auditLogger, err := auditlog.New(logger.WithField(componentLogFieldKey, "Audit Log"), conf.Settings.AuditLog, conf.Settings.ClusterName)
+	if err != nil {
+		return reportFatalError("while creating audit log", err)
+	}
+	cfgAuditEntry := auditlog.ConfigLoadedEntry(cfgVersion)
+
+	redactor, err := redaction.New(logger.WithField(componentLogFieldKey, "Redactor"), conf.Settings.Redaction, k8sCli)
+	if err != nil {
+		return reportFatalError("while creating redactor", err)
+	}
+
+	var cfgHistory *history.Store
+	if conf.Settings.ConfigHistory.Enabled && !remoteSyncEnabled {
+		cfgHistory = history.NewStore(logger.WithField(componentLogFieldKey, "Config History"), k8sCli, conf.Settings.ConfigHistory)
+		cfgProvider = history.NewProvider(cfgProvider, cfgHistory)
+
+		pinned, isPinned, err := cfgHistory.Pinned(ctx)
+		if err != nil {
+			return reportFatalError("while checking configuration rollback", err)
+		}
+		if isPinned {
+			logger.Warnf("Configuration is rolled back to revision %d. Changes of configuration sources are not applied until the rollback is cancelled.", pinned.Revision)
+			cfgLayers = pinned.Layers
+			conf, _, err = config.LoadWithDefaults(cfgLayers.Files())
+			if err != nil {
+				return reportFatalError("while merging rolled back configuration", err)
+			}
+		}
+
+		entry, recorded, err := cfgHistory.Record(ctx, cfgLayers)
+		if err != nil {
+			// history is not essential to run Botkube
+			logger.Errorf("while recording configuration history: %s", err.Error())
+		} else if recorded {
+			logger.Infof("Recorded configuration revision %d.", entry.Revision)
+			cfgAuditEntry = auditlog.ConfigRevisionEntry(entry.Revision, entry.Trigger, entry.Changes)
+		}
+	}
+	// the configuration is applied by restarting Botkube, so each start records the configuration in use
+	auditLogger.Record(cfgAuditEntry)
+
 	botkubeVersion, k8sVer, err := findVersions(k8sCli)
 	if err = statusReporter.ReportDeploymentConnectionInit(ctx, k8sVer); err != nil {
 		return reportFatalError("while reporting botkube connection initialization", err)
@@ -171,6 +373,14 @@ func run(ctx context.Context) (err error) {
 		multiErr := multierror.New()
 
 		errGroupErr := errGroup.Wait()
+		if errors.Is(errGroupErr, errReloadRequested) || errors.Is(errGroupErr, errShutdownRequested) || errors.Is(errGroupErr, leader.ErrLeadershipLost) || errors.Is(errGroupErr, sharding.ErrShardLost) {
+			err = errGroupErr
+			return
+		}
+		if errors.Is(err, errShutdownRequested) {
+			// shutdown was requested before all components were started, e.g. on a standby replica
+			return
+		}
 
 		if err != nil && !errors.Is(err, context.Canceled) {
 			multiErr = multierror.Append(multiErr, err)
@@ -187,6 +397,16 @@ func run(ctx context.Context) (err error) {
 		err = reportFatalError("while waiting for goroutines to finish gracefully", multiErr.ErrorOrNil())
 	}()
 
+	errGroup.Go(func() error {
+		defer analytics.ReportPanicIfOccurs(logger, analyticsReporter)
+		return auditLogger.Start(ctx)
+	})
+
+	errGroup.Go(func() error {
+		defer analytics.ReportPanicIfOccurs(logger, analyticsReporter)
+		return redactor.Start(ctx)
+	})
+
 	errGroup.Go(func() error {
 		err := analyticsReporter.Run(ctx)
 		if err != nil {
@@ -195,14 +415,35 @@ func run(ctx context.Context) (err error) {
 		return err
 	})
 
+	var autocompleter bot.Autocompleter
+	if conf.Settings.Autocomplete.Enabled {
+		autocompleteSvc, err := autocomplete.New(logger.WithField(componentLogFieldKey, "Autocomplete"), conf.Settings.Autocomplete, kubeConfig)
+		if err != nil {
+			return reportFatalError("while creating autocomplete service", err)
+		}
+		autocompleter = autocompleteSvc
+		errGroup.Go(func() error {
+			defer analytics.ReportPanicIfOccurs(logger, analyticsReporter)
+			return autocompleteSvc.Start(ctx)
+		})
+	}
+
 	schedulerChan := make(chan string)
 	pluginHealthStats := plugin.NewHealthStats(conf.Plugins.RestartPolicy.Threshold)
 	collector := plugin.NewCollector(logger)
 	enabledPluginExecutors, enabledPluginSources := collector.GetAllEnabledAndUsedPlugins(conf)
-	pluginManager := plugin.NewManager(logger, conf.Settings.Log, conf.Plugins, enabledPluginExecutors, enabledPluginSources, schedulerChan, pluginHealthStats)
+	enabledPluginProcessors := collector.GetAllEnabledProcessors(conf)
+	pluginManager := plugin.NewManager(logger, conf.Settings.Log, conf.Plugins, enabledPluginExecutors, enabledPluginSources, enabledPluginProcessors, schedulerChan, pluginHealthStats)
 
 	// Health endpoint
+	featureFlags := featureflag.NewManager(*conf)
 	healthChecker := health.NewChecker(ctx, conf, pluginHealthStats)
+	healthChecker.SetFeatureFlags(featureFlags)
+	diagnostics := doctor.New(logger.WithField(componentLogFieldKey, "Doctor"), *conf, kubeConfig, &healthChecker, pluginManager)
+	healthChecker.SetDoctor(diagnostics)
+	// events can be replayed once source plugins are dispatched
+	replayer := replay.NewService()
+	healthChecker.SetReplay(replayer)
 	healthSrv := healthChecker.NewServer(logger.WithField(componentLogFieldKey, "Health server"), conf.Settings.HealthPort)
 	errGroup.Go(func() error {
 		defer analytics.ReportPanicIfOccurs(logger, analyticsReporter)
@@ -215,8 +456,13 @@ func run(ctx context.Context) (err error) {
 	}
 	defer pluginManager.Shutdown()
 
+	err = pluginManager.ValidateConfigs(ctx, *conf)
+	if err != nil {
+		return fmt.Errorf("while validating plugin configurations: %w", err)
+	}
+
 	// Prometheus metrics
-	metricsSrv := newMetricsServer(logger.WithField(componentLogFieldKey, "Metrics server"), conf.Settings.MetricsPort)
+	metricsSrv := newMetricsServer(logger.WithField(componentLogFieldKey, "Metrics server"), conf.Settings.MetricsPort, pluginManager)
 	errGroup.Go(func() error {
 		defer analytics.ReportPanicIfOccurs(logger, analyticsReporter)
 		return metricsSrv.Serve(ctx)
@@ -225,24 +471,116 @@ func run(ctx context.Context) (err error) {
 	cmdGuard := command.NewCommandGuard(logger.WithField(componentLogFieldKey, "Command Guard"), discoveryCli)
 	// Create executor factory
 	cfgManager := config.NewManager(remoteCfgEnabled, logger.WithField(componentLogFieldKey, "Config manager"), conf.Settings.PersistentConfig, cfgVersion, k8sCli, gqlClient, deployClient)
+	if crdProvider != nil {
+		cfgManager = crd.NewPersistenceManager(cfgManager, dynamicCli)
+	}
+	var cmdAuthorizer execute.CommandAuthorizer
+	if conf.Settings.Authorization.OPA.Enabled {
+		cmdAuthorizer = authz.NewOPAAuthorizer(logger.WithField(componentLogFieldKey, "OPA Authorizer"), conf.Settings.Authorization.OPA)
+	}
+	escalationManager, err := escalation.NewManager(logger.WithField(componentLogFieldKey, "Incident Manager"), conf.Settings.Incidents, conf.Escalations)
+	if err != nil {
+		return reportFatalError("while creating incident manager", err)
+	}
+	ticketManager, err := ticketing.NewManager(logger.WithField(componentLogFieldKey, "Ticket Manager"), conf.Tickets)
+	if err != nil {
+		return reportFatalError("while creating ticket manager", err)
+	}
+	maintenanceManager, err := maintenance.NewManager(logger.WithField(componentLogFieldKey, "Maintenance Manager"), conf.MaintenanceWindows)
+	if err != nil {
+		return reportFatalError("while creating maintenance manager", err)
+	}
+	router := routing.NewRouter(logger.WithField(componentLogFieldKey, "Router"), conf.Routing)
+	notificationManager, err := notification.NewManager(logger.WithField(componentLogFieldKey, "Notification Manager"), *conf, featureFlags)
+	if err != nil {
+		return reportFatalError("while creating notification manager", err)
+	}
+
+	var cfgHistoryStore execute.ConfigHistoryStore
+	if cfgHistory != nil {
+		cfgHistoryStore = cfgHistory
+	}
 	executorFactory, err := execute.NewExecutorFactory(
 		execute.DefaultExecutorFactoryParams{
-			Log:               logger.WithField(componentLogFieldKey, "Executor"),
-			Cfg:               *conf,
-			CfgManager:        cfgManager,
-			AnalyticsReporter: analyticsReporter,
-			CommandGuard:      cmdGuard,
-			PluginManager:     pluginManager,
-			BotKubeVersion:    botkubeVersion,
-			RestCfg:           kubeConfig,
-			AuditReporter:     auditReporter,
-			PluginHealthStats: pluginHealthStats,
+			Log:                logger.WithField(componentLogFieldKey, "Executor"),
+			Cfg:                *conf,
+			CfgManager:         cfgManager,
+			AnalyticsReporter:  analyticsReporter,
+			CommandGuard:       cmdGuard,
+			PluginManager:      pluginManager,
+			BotKubeVersion:     botkubeVersion,
+			RestCfg:            kubeConfig,
+			AuditReporter:      auditReporter,
+			PluginHealthStats:  pluginHealthStats,
+			CommandAuthorizer:  cmdAuthorizer,
+			IncidentManager:    escalationManager,
+			MaintenanceManager: maintenanceManager,
+			FeatureFlags:       featureFlags,
+			CfgLayers:          cfgLayers,
+			CfgCommit:          gitProvider.LoadedCommit(),
+			CfgHistory:         cfgHistoryStore,
+			AuditLogger:        auditLogger,
+			HealthChecker:      &healthChecker,
+			Redactor:           redactor,
+			Doctor:             diagnostics,
+			OverviewCollector:  overview.New(conf.Settings.Overview, kubeConfig),
+			ResourceWatcher:    livewatch.New(conf.Settings.Watch, kubeConfig),
+			UpgradeChecker:     upgradecheck.New(conf.Settings.UpgradeCheck, kubeConfig),
+			EventReplayer:      replayer,
 		},
 	)
 	if err != nil {
 		return reportFatalError("while creating executor factory", err)
 	}
 
+	// Wait for leadership or a shard
+	// Standby replicas have plugins and clients ready, but they don't connect to communication platforms nor start sources.
+	identity, err := os.Hostname()
+	if err != nil {
+		return reportFatalError("while getting replica identity", err)
+	}
+	if conf.Settings.LeaderElection.Enabled {
+		elector, err := leader.New(logger.WithField(componentLogFieldKey, "Leader Elector"), conf.Settings.LeaderElection, k8sCli, identity)
+		if err != nil {
+			return reportFatalError("while creating leader elector", err)
+		}
+		errGroup.Go(func() error {
+			defer analytics.ReportPanicIfOccurs(logger, analyticsReporter)
+			return elector.Start(ctx)
+		})
+
+		logger.Info("Waiting for leadership...")
+		if err := waitForStandby(ctx, elector.Elected(), shutdownCh); err != nil {
+			return err
+		}
+		logger.Info("Elected as the leader.")
+	} else {
+		metrics.SetLeader(true)
+	}
+
+	var shard *sharding.Shard
+	if conf.Settings.Sharding.Enabled {
+		coordinator, err := sharding.NewCoordinator(logger.WithField(componentLogFieldKey, "Shard Coordinator"), conf.Settings.Sharding, k8sCli, identity)
+		if err != nil {
+			return reportFatalError("while creating shard coordinator", err)
+		}
+		errGroup.Go(func() error {
+			defer analytics.ReportPanicIfOccurs(logger, analyticsReporter)
+			return coordinator.Start(ctx)
+		})
+
+		logger.Info("Waiting for a shard...")
+		if err := waitForStandby(ctx, coordinator.Acquired(), shutdownCh); err != nil {
+			return err
+		}
+		acquired := coordinator.Shard()
+		shard = &acquired
+		logger.Infof("Acquired shard %d out of %d.", shard.Index, shard.Count)
+	}
+	// Only source notifications are sharded. Other shards start bots only to send them, so commands are handled once,
+	// and they don't send messages which must be sent once per cluster, such as the help message or the delivery report.
+	runsSingletons := sharding.RunsSingletons(shard)
+
 	var (
 		sinkNotifiers []notifier.Sink
 		bots          = map[string]bot.Bot{}
@@ -267,7 +605,7 @@ func run(ctx context.Context) (err error) {
 			key := fmt.Sprintf("%s-%s", commGroupName, app.IntegrationName())
 			if err != nil {
 				commGroupLogger.WithError(err).Errorf("while creating %s bot", app.IntegrationName())
-				healthChecker.AddNotifier(key, health.NewFailed(health.FailureReasonConnectionError, err.Error()))
+				healthChecker.AddNotifier(key, health.NewFailed(app.Type(), health.FailureReasonConnectionError, err.Error()))
 				return
 			}
 
@@ -276,11 +614,18 @@ func run(ctx context.Context) (err error) {
 			switch platform := app.(type) {
 			case notifier.Sink:
 				sinkNotifiers = append(sinkNotifiers, platform)
+				if starter, ok := platform.(sink.Starter); ok {
+					errGroup.Go(func() error {
+						defer analytics.ReportPanicIfOccurs(commGroupLogger, analyticsReporter)
+						return starter.Start(ctx)
+					})
+				}
 			case bot.Bot:
 				bots[key] = platform
+				start := bot.StartFunc(platform, runsSingletons)
 				errGroup.Go(func() error {
 					defer analytics.ReportPanicIfOccurs(commGroupLogger, analyticsReporter)
-					return platform.Start(ctx)
+					return start(ctx)
 				})
 			}
 		}
@@ -288,7 +633,7 @@ func run(ctx context.Context) (err error) {
 		// Run bots
 		if commGroupCfg.SocketSlack.Enabled {
 			scheduleNotifier(func() (notifier.Platform, error) {
-				return bot.NewSocketSlack(commGroupLogger.WithField(botLogFieldKey, "SocketSlack"), commGroupMeta, commGroupCfg.SocketSlack, executorFactory, analyticsReporter)
+				return bot.NewSocketSlack(commGroupLogger.WithField(botLogFieldKey, "SocketSlack"), commGroupMeta, commGroupCfg.SocketSlack, executorFactory, autocompleter, analyticsReporter)
 			})
 		}
 
@@ -316,6 +661,12 @@ func run(ctx context.Context) (err error) {
 			})
 		}
 
+		if commGroupCfg.NATS.Enabled && commGroupCfg.NATS.Commands.Enabled {
+			scheduleNotifier(func() (notifier.Platform, error) {
+				return bot.NewNATS(commGroupLogger.WithField(botLogFieldKey, "NATS"), commGroupMeta, commGroupCfg.NATS, executorFactory, analyticsReporter)
+			})
+		}
+
 		// Run sinks
 		if commGroupCfg.Elasticsearch.Enabled {
 			scheduleNotifier(func() (notifier.Platform, error) {
@@ -325,7 +676,7 @@ func run(ctx context.Context) (err error) {
 
 		if commGroupCfg.Webhook.Enabled {
 			scheduleNotifier(func() (notifier.Platform, error) {
-				return sink.NewWebhook(commGroupLogger.WithField(sinkLogFieldKey, "Webhook"), commGroupMeta.Index, commGroupCfg.Webhook, analyticsReporter)
+				return sink.NewWebhook(commGroupLogger.WithField(sinkLogFieldKey, "Webhook"), commGroupMeta.Index, commGroupCfg.Webhook, conf.Settings.ClusterName, analyticsReporter)
 			})
 		}
 		if commGroupCfg.PagerDuty.Enabled {
@@ -333,25 +684,83 @@ func run(ctx context.Context) (err error) {
 				return sink.NewPagerDuty(commGroupLogger.WithField(sinkLogFieldKey, "PagerDuty"), commGroupMeta.Index, commGroupCfg.PagerDuty, conf.Settings.ClusterName, analyticsReporter)
 			})
 		}
+		if commGroupCfg.Kafka.Enabled {
+			scheduleNotifier(func() (notifier.Platform, error) {
+				return sink.NewKafka(commGroupLogger.WithField(sinkLogFieldKey, "Kafka"), commGroupMeta.Index, commGroupCfg.Kafka, conf.Settings.ClusterName, analyticsReporter)
+			})
+		}
+		if commGroupCfg.NATS.Enabled && len(commGroupCfg.NATS.Subjects) > 0 {
+			scheduleNotifier(func() (notifier.Platform, error) {
+				return sink.NewNATS(commGroupLogger.WithField(sinkLogFieldKey, "NATS"), commGroupMeta.Index, commGroupCfg.NATS, conf.Settings.ClusterName, analyticsReporter)
+			})
+		}
+		if commGroupCfg.S3Archive.Enabled {
+			scheduleNotifier(func() (notifier.Platform, error) {
+				return sink.NewS3Archive(commGroupLogger.WithField(sinkLogFieldKey, "S3 Archive"), commGroupMeta.Index, commGroupCfg.S3Archive, conf.Settings.ClusterName, analyticsReporter)
+			})
+		}
+		if commGroupCfg.Warehouse.Enabled {
+			scheduleNotifier(func() (notifier.Platform, error) {
+				return sink.NewWarehouse(commGroupLogger.WithField(sinkLogFieldKey, "Warehouse"), commGroupMeta.Index, commGroupCfg.Warehouse, conf.Settings.ClusterName, analyticsReporter)
+			})
+		}
+	}
+
+	// singletonBots receive messages which are sent once per cluster
+	singletonBots := bots
+	if !runsSingletons {
+		singletonBots = map[string]bot.Bot{}
+	}
+
+	loadConfig := func(ctx context.Context) (config.Config, error) {
+		configs, _, err := cfgProvider.Configs(ctx)
+		if err != nil {
+			return config.Config{}, fmt.Errorf("while loading configuration files: %w", err)
+		}
+		newConf, _, err := config.LoadWithDefaults(configs)
+		if err != nil {
+			return config.Config{}, fmt.Errorf("while merging app configuration: %w", err)
+		}
+		if newConf == nil {
+			return config.Config{}, fmt.Errorf("configuration cannot be nil")
+		}
+		return *newConf, nil
 	}
 
+	reloadCh := make(chan struct{}, 1)
 	if conf.ConfigWatcher.Enabled {
-		restarter := reloader.NewRestarter(
+		sendMsgFn := func(msg string) error {
+			return notifier.SendPlaintextMessage(ctx, bot.AsNotifiers(singletonBots), msg)
+		}
+		var restarter reloader.Reloader = reloader.NewRestarter(
 			logger.WithField(componentLogFieldKey, "Restarter"),
 			k8sCli,
 			conf.ConfigWatcher.Deployment,
 			conf.Settings.ClusterName,
-			func(msg string) error {
-				return notifier.SendPlaintextMessage(ctx, bot.AsNotifiers(bots), msg)
-			},
+			sendMsgFn,
 		)
+		if conf.ConfigWatcher.HotReload.Enabled {
+			restarter = reloader.NewInProcessRestarter(
+				logger.WithField(componentLogFieldKey, "Hot Reloader"),
+				*conf,
+				loadConfig,
+				sendMsgFn,
+				func() {
+					select {
+					case reloadCh <- struct{}{}:
+					default:
+					}
+				},
+			)
+		}
 
 		cfgReloader, err := reloader.Get(
-			remoteCfgEnabled,
+			remoteSyncEnabled,
 			logger.WithField(componentLogFieldKey, "Config Reloader"),
-			deployClient,
+			cfgClient,
 			dynamicCli,
 			restarter,
+			router,
 			analyticsReporter,
 			*conf,
 			cfgVersion,
@@ -364,20 +773,70 @@ func run(ctx context.Context) (err error) {
 			defer analytics.ReportPanicIfOccurs(logger, analyticsReporter)
 			return cfgReloader.Do(ctx)
 		})
+
+		if interval := secretProvider.RefreshInterval(); interval > 0 {
+			secretRefresher := secret.NewRefresher(logger.WithField(componentLogFieldKey, "Secret Refresher"), secretProvider, restarter, interval)
+			errGroup.Go(func() error {
+				defer analytics.ReportPanicIfOccurs(logger, analyticsReporter)
+				return secretRefresher.Do(ctx)
+			})
+		}
+
+		if gitProvider.Enabled() {
+			gitSyncer := gitsync.NewSyncer(
+				logger.WithField(componentLogFieldKey, "Git Syncer"),
+				gitProvider,
+				func(ctx context.Context) error {
+					_, err := loadConfig(ctx)
+					return err
+				},
+				restarter,
+				sendMsgFn,
+				conf.GitSync.PollInterval,
+			)
+			errGroup.Go(func() error {
+				defer analytics.ReportPanicIfOccurs(logger, analyticsReporter)
+				return gitSyncer.Do(ctx)
+			})
+
+			if conf.GitSync.Webhook.Enabled {
+				gitWebhookSrv := httpx.NewServer(
+					logger.WithField(componentLogFieldKey, "Git Sync Webhook"),
+					fmt.Sprintf(":%d", conf.GitSync.Webhook.Port),
+					gitsync.NewWebhookHandler(logger.WithField(componentLogFieldKey, "Git Sync Webhook"), conf.GitSync.Webhook.Secret, gitSyncer),
+				)
+				errGroup.Go(func() error {
+					defer analytics.ReportPanicIfOccurs(logger, analyticsReporter)
+					return gitWebhookSrv.Serve(ctx)
+				})
+			}
+		}
 	}
 
 	// Send help message
-	helpDB := storage.NewForHelp(conf.Settings.SystemConfigMap.Namespace, conf.Settings.SystemConfigMap.Name, k8sCli)
-	err = sendHelp(ctx, helpDB, conf.Settings.ClusterName, enabledPluginExecutors, bots)
-	if err != nil {
-		return fmt.Errorf("while sending initial help message: %w", err)
+	if runsSingletons {
+		helpDB := storage.NewForHelp(conf.Settings.SystemConfigMap.Namespace, conf.Settings.SystemConfigMap.Name, k8sCli)
+		err = sendHelp(ctx, helpDB, conf.Settings.ClusterName, enabledPluginExecutors, bots)
+		if err != nil {
+			return fmt.Errorf("while sending initial help message: %w", err)
+		}
+	}
+
+	// Send setup wizard to new channels. Channel settings cannot be changed from chat with the remote configuration.
+	if runsSingletons && conf.Settings.Onboarding.Enabled && !remoteCfgEnabled {
+		onboardingDB := storage.NewForOnboarding(conf.Settings.SystemConfigMap.Namespace, conf.Settings.SystemConfigMap.Name, k8sCli)
+		err = sendSetupWizard(ctx, onboardingDB, *conf, bots)
+		if err != nil {
+			// we ignore error to make sure that the setup wizard does not stop the agent
+			logger.WithError(err).Error("Failed to send setup wizard")
+		}
 	}
 
 	// Start upgrade checker
 	ghCli := github.NewClient(&http.Client{
 		Timeout: 1 * time.Minute,
 	})
-	if conf.Settings.UpgradeNotifier {
+	if runsSingletons && conf.Settings.UpgradeNotifier {
 		upgradeChecker := controller.NewUpgradeChecker(
 			logger.WithField(componentLogFieldKey, "Upgrade Checker"),
 			bots,
@@ -394,15 +853,152 @@ func run(ctx context.Context) (err error) {
 		})
 	}
 
-	actionProvider := action.NewProvider(logger.WithField(componentLogFieldKey, "Action Provider"), conf.Actions, executorFactory)
+	actionProvider, err := action.NewProvider(logger.WithField(componentLogFieldKey, "Action Provider"), conf.Actions, executorFactory)
+	if err != nil {
+		return reportFatalError("while creating action provider", err)
+	}
+
+	processorChain, err := processing.NewChain(logger.WithField(componentLogFieldKey, "Processor Chain"), conf.Processors, pluginManager)
+	if err != nil {
+		return reportFatalError("while creating processor chain", err)
+	}
+
+	ownerResolver, err := ownership.NewResolver(logger.WithField(componentLogFieldKey, "Owner Resolver"), conf.Ownership, kubeConfig)
+	if err != nil {
+		return reportFatalError("while creating owner resolver", err)
+	}
+
+	enricher, err := enrichment.NewEnricher(logger.WithField(componentLogFieldKey, "Enricher"), conf.Settings.ClusterName, conf.Enrichments)
+	if err != nil {
+		return reportFatalError("while creating enricher", err)
+	}
+
+	notificationFilter, err := filter.NewEngine(logger.WithField(componentLogFieldKey, "Notification Filter"), conf.Filters)
+	if err != nil {
+		return reportFatalError("while creating notification filters", err)
+	}
+
+	var notificationBuffer source.NotificationBuffer
+	if conf.Settings.EventBuffer.Enabled {
+		eventBuffer, err := eventbuffer.New(logger.WithField(componentLogFieldKey, "Event Buffer"), conf.Settings.EventBuffer)
+		if err != nil {
+			return reportFatalError("while opening event buffer", err)
+		}
+		defer func() {
+			if err := eventBuffer.Close(); err != nil {
+				logger.Errorf("while closing event buffer: %s", err.Error())
+			}
+		}()
+		notificationBuffer = eventBuffer
+	}
+
+	deliveryTracker := reliability.NewTracker(logger.WithField(componentLogFieldKey, "Delivery Tracker"), conf.Settings.ClusterName, conf.Settings.DeliverySLO, func(ctx context.Context, msg interactive.CoreMessage) error {
+		errs := multierror.New()
+		for key, notifierItem := range bots {
+			if err := notifierItem.SendMessageToAll(ctx, msg); err != nil {
+				errs = multierror.Append(errs, fmt.Errorf("while sending to %s (%s): %w", notifierItem.IntegrationName(), key, err))
+			}
+		}
+		return errs.ErrorOrNil()
+	})
+	if runsSingletons {
+		errGroup.Go(func() error {
+			defer analytics.ReportPanicIfOccurs(logger, analyticsReporter)
+			return deliveryTracker.Start(ctx)
+		})
+	}
 
-	sourcePluginDispatcher := source.NewDispatcher(logger, conf.Settings.ClusterName, bots, sinkNotifiers, pluginManager, actionProvider, analyticsReporter, auditReporter, kubeConfig)
-	scheduler := source.NewScheduler(ctx, logger, conf, sourcePluginDispatcher, schedulerChan)
-	err = scheduler.Start(ctx)
+	sourcePluginDispatcher, err := source.NewDispatcher(source.DispatcherOptions{
+		Log:                logger,
+		ClusterName:        conf.Settings.ClusterName,
+		Pipeline:           conf.Settings.EventPipeline,
+		Notifiers:          bots,
+		SinkNotifiers:      sinkNotifiers,
+		PluginManager:      pluginManager,
+		ActionProvider:     actionProvider,
+		Processors:         processorChain,
+		Owners:             ownerResolver,
+		Enricher:           enricher,
+		Redactor:           redactor,
+		NotificationFilter: notificationFilter,
+		Incidents:          escalationManager,
+		Maintenance:        maintenanceManager,
+		Tickets:            ticketManager,
+		Router:             router,
+		ChannelNotifier:    notificationManager,
+		Reporter:           analyticsReporter,
+		AuditReporter:      auditReporter,
+		AuditLogger:        auditLogger,
+		Buffer:             notificationBuffer,
+		Deliveries:         deliveryTracker,
+		RestCfg:            kubeConfig,
+	})
+	if err != nil {
+		return reportFatalError("while creating source plugin event dispatcher", err)
+	}
+	if shard != nil {
+		if err := sourcePluginDispatcher.SetShard(*shard, conf.Settings.Sharding.Plugins); err != nil {
+			return reportFatalError("while setting shard of source plugin event dispatcher", err)
+		}
+	}
+	sourcePluginDispatcher.Start(ctx)
+	replayer.SetPipeline(sourcePluginDispatcher)
+	// sources are stopped separately on shutdown, so events which are already received can be dispatched
+	sourcesCtx, stopSources := context.WithCancel(ctx)
+	defer stopSources()
+	scheduler := source.NewScheduler(sourcesCtx, logger, conf, sourcePluginDispatcher, schedulerChan)
+	err = scheduler.Start(sourcesCtx)
 	if err != nil {
 		return reportFatalError("while starting source plugin event dispatcher", err)
 	}
 
+	errGroup.Go(func() error {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-reloadCh:
+			logger.Info("Waiting for in-flight notifications before reloading configuration...")
+			drainCtx, cancelDrain := context.WithTimeout(ctx, conf.ConfigWatcher.HotReload.DrainTimeout)
+			defer cancelDrain()
+			if err := sourcePluginDispatcher.Drain(drainCtx); err != nil {
+				logger.WithError(err).Warn("Not all in-flight notifications were processed before reloading configuration")
+			}
+			// returning an error stops all components, so they can be started again with the changed configuration
+			return errReloadRequested
+		case <-shutdownCh:
+		}
+
+		logger.Info("Shutting down gracefully. Stopping sources and waiting for in-flight notifications...")
+		// it closes source plugin streams, so plugins stop informers and other watches
+		stopSources()
+		drainCtx, cancelDrain := context.WithTimeout(ctx, conf.Settings.Shutdown.DrainTimeout)
+		defer cancelDrain()
+		if err := sourcePluginDispatcher.Drain(drainCtx); err != nil {
+			logger.WithError(err).Warn("Not all in-flight notifications were processed before shutdown")
+		}
+
+		if conf.Settings.Shutdown.Notice.Enabled {
+			noticeCtx, cancelNotice := context.WithTimeout(ctx, shutdownNoticeTimeout)
+			defer cancelNotice()
+			sendShutdownNotice(noticeCtx, logger, conf.Settings.Shutdown.Notice.Message, singletonBots)
+		}
+		// returning an error stops all components
+		return errShutdownRequested
+	})
+
+	if crdProvider != nil && conf.ConfigCRD.Webhook.Enabled {
+		webhookSrv := httpx.NewServer(
+			logger.WithField(componentLogFieldKey, "BotkubeConfig Webhook"),
+			fmt.Sprintf(":%d", conf.ConfigCRD.Webhook.Port),
+			crd.NewValidationHandler(logger.WithField(componentLogFieldKey, "BotkubeConfig Webhook"), crdProvider),
+		)
+		errGroup.Go(func() error {
+			defer analytics.ReportPanicIfOccurs(logger, analyticsReporter)
+			certDir := conf.ConfigCRD.Webhook.CertDir
+			return webhookSrv.ServeTLS(ctx, filepath.Join(certDir, "tls.crt"), filepath.Join(certDir, "tls.key"))
+		})
+	}
+
 	if conf.Plugins.IncomingWebhook.Enabled {
 		incomingWebhookSrv := source.NewIncomingWebhookServer(
 			logger.WithField(componentLogFieldKey, "Incoming Webhook Server"),
@@ -421,7 +1017,7 @@ func run(ctx context.Context) (err error) {
 	ctrl := controller.New(
 		logger.WithField(componentLogFieldKey, "Controller"),
 		conf,
-		bots,
+		singletonBots,
 		statusReporter,
 	)
 
@@ -464,25 +1060,39 @@ func run(ctx context.Context) (err error) {
 	return nil
 }
 
-func newMetricsServer(log logrus.FieldLogger, metricsPort string) *httpx.Server {
+// newMetricsServer returns the metrics server, which exposes Botkube metrics together with metrics exported by plugins.
+func newMetricsServer(log logrus.FieldLogger, metricsPort string, pluginMetrics prometheus.Gatherer) *httpx.Server {
 	addr := fmt.Sprintf(":%s", metricsPort)
 	router := mux.NewRouter()
-	router.Handle("/metrics", promhttp.Handler())
+	gatherers := prometheus.Gatherers{prometheus.DefaultGatherer, pluginMetrics}
+	router.Handle("/metrics", promhttp.InstrumentMetricHandler(
+		prometheus.DefaultRegisterer, promhttp.HandlerFor(gatherers, promhttp.HandlerOpts{}),
+	))
 	return httpx.NewServer(log, addr, router)
 }
 
-func getAnalyticsReporter(disableAnalytics bool, logger logrus.FieldLogger) (analytics.Reporter, error) {
-	if disableAnalytics {
+func getAnalyticsReporter(cfg config.Analytics, logger logrus.FieldLogger) (analytics.Reporter, error) {
+	if analytics.IsForceDisabled() {
+		logger.Info("Analytics disabled during the build.")
+		return analytics.NewNoopReporter(), nil
+	}
+
+	if cfg.Disable {
 		logger.Info("Analytics disabled via configuration settings.")
 		return analytics.NewNoopReporter(), nil
 	}
 
+	wrappedLogger := logger.WithField(componentLogFieldKey, "Analytics reporter")
+	if cfg.Backend == config.CollectorAnalyticsBackend {
+		wrappedLogger.Infof("Using self-hosted collector %q...", cfg.Collector.URL)
+		return analytics.NewSegmentReporter(wrappedLogger, analytics.NewCollectorClient(wrappedLogger, cfg.Collector)), nil
+	}
+
 	if analytics.APIKey == "" {
 		logger.Info("Analytics disabled as the API key is missing.")
 		return analytics.NewNoopReporter(), nil
 	}
 
-	wrappedLogger := logger.WithField(componentLogFieldKey, "Analytics reporter")
 	wrappedLogger.Infof("Using API Key starting with %q...", strings.ShortenString(analytics.APIKey, printAPIKeyCharCount))
 	segmentCli, err := segment.NewWithConfig(analytics.APIKey, segment.Config{
 		Logger:  analytics.NewSegmentLoggerAdapter(wrappedLogger),
@@ -562,6 +1172,67 @@ func sendHelp(ctx context.Context, s *storage.Help, clusterName string, executor
 	return s.MarkHelpAsSent(ctx, sent)
 }
 
+// sendSetupWizard posts the setup wizard to channels which haven't got it yet.
+func sendSetupWizard(ctx context.Context, s *storage.Onboarding, conf config.Config, notifiers map[string]bot.Bot) (err error) {
+	onboarded, err := s.GetOnboardedChannels(ctx)
+	if err != nil {
+		return fmt.Errorf("while getting onboarded channels: %w", err)
+	}
+
+	var sent []string
+	defer func() {
+		// channels which already got the wizard are marked even if sending to other channels failed
+		if markErr := s.MarkChannelsAsOnboarded(ctx, sent); markErr != nil && err == nil {
+			err = fmt.Errorf("while marking channels as onboarded: %w", markErr)
+		}
+	}()
+
+	for key, notifierItem := range notifiers {
+		lister, ok := notifierItem.(notifier.ChannelLister)
+		if !ok {
+			continue
+		}
+		for _, ch := range lister.NotificationChannels() {
+			chKey := fmt.Sprintf("%s/%s", key, ch.Alias)
+			if onboarded[chKey] {
+				continue
+			}
+
+			wizard := execute.SetupWizardMessage(conf, ch.Sources, ch.Settings)
+			if err := lister.SendMessageToChannels(ctx, wizard, []string{ch.Name}); err != nil {
+				return fmt.Errorf("while sending setup wizard to %q channel of %s: %w", ch.Alias, notifierItem.IntegrationName(), err)
+			}
+			sent = append(sent, chKey)
+		}
+	}
+	return nil
+}
+
+// waitForStandby blocks until a given channel is closed, e.g. once the replica is elected.
+// It returns errShutdownRequested if the shutdown was requested in the meantime.
+func waitForStandby(ctx context.Context, activeCh <-chan struct{}, shutdownCh <-chan struct{}) error {
+	select {
+	case <-activeCh:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-shutdownCh:
+		return errShutdownRequested
+	}
+}
+
+// sendShutdownNotice sends a given message to all bots, so users know that notifications are paused, e.g. during a rolling upgrade.
+func sendShutdownNotice(ctx context.Context, log logrus.FieldLogger, message string, notifiers map[string]bot.Bot) {
+	msg := interactive.CoreMessage{
+		Message: api.NewPlaintextMessage(message, false),
+	}
+	for key, notifierItem := range notifiers {
+		if err := notifierItem.SendMessageToAll(ctx, msg); err != nil {
+			log.Errorf("while sending shutdown notice for %s (%s): %s", notifierItem.IntegrationName(), key, err.Error())
+		}
+	}
+}
+
 func findVersions(cli *kubernetes.Clientset) (string, string, error) {
 	k8sVer, err := cli.ServerVersion()
 	if err != nil {
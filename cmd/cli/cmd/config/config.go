@@ -14,6 +14,10 @@ func NewCmd() *cobra.Command {
 
 	root.AddCommand(
 		NewGet(),
+		NewHistory(),
+		NewMigrate(),
+		NewRollback(),
+		NewSchema(),
 	)
 	return root
 }
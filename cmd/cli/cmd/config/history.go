@@ -0,0 +1,116 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kubeshop/botkube/internal/cli"
+	"github.com/kubeshop/botkube/internal/cli/analytics"
+	"github.com/kubeshop/botkube/internal/cli/heredoc"
+	"github.com/kubeshop/botkube/internal/cli/printer"
+	"github.com/kubeshop/botkube/internal/config/history"
+	"github.com/kubeshop/botkube/internal/kubex"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+// HistoryOptions holds options to access the configuration history of installed Botkube.
+type HistoryOptions struct {
+	Namespace  string
+	SecretName string
+}
+
+// RegisterFlags registers flags to access the configuration history.
+func (o *HistoryOptions) RegisterFlags(flags *pflag.FlagSet) {
+	flags.StringVarP(&o.Namespace, "namespace", "n", "botkube", "Namespace of Botkube installation")
+	flags.StringVar(&o.SecretName, "secret-name", "botkube-config-history", "Name of the Secret with the configuration history")
+}
+
+func (o *HistoryOptions) store() (*history.Store, *kubex.ConfigWithMeta, error) {
+	k8sCfg, err := kubex.LoadRestConfigWithMetaInformation()
+	if err != nil {
+		return nil, nil, fmt.Errorf("while creating k8s config: %w", err)
+	}
+	cli, err := kubernetes.NewForConfig(k8sCfg.K8s)
+	if err != nil {
+		return nil, nil, fmt.Errorf("while creating k8s client: %w", err)
+	}
+
+	return history.NewStore(loggerx.NewNoop(), cli, config.ConfigHistory{
+		Enabled: true,
+		Secret: config.K8sResourceRef{
+			Name:      o.SecretName,
+			Namespace: o.Namespace,
+		},
+	}), k8sCfg, nil
+}
+
+type revision struct {
+	Revision  int       `json:"revision" yaml:"revision"`
+	AppliedAt time.Time `json:"appliedAt" yaml:"appliedAt"`
+	Trigger   string    `json:"trigger" yaml:"trigger"`
+	Current   bool      `json:"current,omitempty" yaml:"current,omitempty"`
+	Pinned    bool      `json:"pinned,omitempty" yaml:"pinned,omitempty"`
+	Changes   []string  `json:"changes,omitempty" yaml:"changes,omitempty"`
+}
+
+// NewHistory returns a cobra.Command for listing applied Botkube configurations.
+func NewHistory() *cobra.Command {
+	var opts HistoryOptions
+
+	resourcePrinter := printer.NewForResource(os.Stdout, printer.WithJSON(), printer.WithYAML())
+
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "Displays applied Botkube configuration revisions",
+		Long:  "Displays applied Botkube configuration revisions, starting from the current one, together with their changes and triggers. Configuration snapshots are not printed, as they may contain credentials.",
+		Example: heredoc.WithCLIName(`
+			# Show configuration history for currently installed Botkube
+			<cli> config history
+
+			# Show configuration history in JSON format
+			<cli> config history -ojson
+		`, cli.Name),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, _, err := opts.store()
+			if err != nil {
+				return err
+			}
+
+			entries, err := store.List(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("while listing configuration history: %w", err)
+			}
+			pinned, isPinned, err := store.Pinned(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("while checking configuration rollback: %w", err)
+			}
+
+			out := make([]revision, 0, len(entries))
+			for idx, entry := range entries {
+				out = append(out, revision{
+					Revision:  entry.Revision,
+					AppliedAt: entry.AppliedAt,
+					Trigger:   entry.Trigger,
+					Current:   idx == 0,
+					Pinned:    isPinned && entry.Revision == pinned.Revision,
+					Changes:   entry.Changes,
+				})
+			}
+			return resourcePrinter.Print(out)
+		},
+	}
+
+	cmd = analytics.InjectAnalyticsReporting(*cmd, "config history")
+
+	flags := cmd.Flags()
+	opts.RegisterFlags(flags)
+	resourcePrinter.RegisterFlags(flags)
+
+	return cmd
+}
@@ -0,0 +1,98 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/kubeshop/botkube/internal/cli"
+	"github.com/kubeshop/botkube/internal/cli/analytics"
+	"github.com/kubeshop/botkube/internal/cli/heredoc"
+	"github.com/kubeshop/botkube/internal/config/migration"
+)
+
+// MigrateOptions holds options to migrate Botkube configuration files.
+type MigrateOptions struct {
+	Write bool
+}
+
+// RegisterFlags registers flags to migrate configuration files.
+func (o *MigrateOptions) RegisterFlags(flags *pflag.FlagSet) {
+	flags.BoolVarP(&o.Write, "write", "w", false, "Overwrite given files with the migrated configuration instead of only printing the diff")
+}
+
+// NewMigrate returns a cobra.Command for migrating Botkube configuration files to the current configuration layout.
+func NewMigrate() *cobra.Command {
+	var opts MigrateOptions
+
+	cmd := &cobra.Command{
+		Use:     "migrate [FILE]...",
+		Aliases: []string{"migrate-config"},
+		Args:    cobra.MinimumNArgs(1),
+		Short:   "Migrates Botkube configuration files to the current configuration layout",
+		Long:    "Migrates Botkube configuration files written for previous Botkube versions to the current configuration layout. Deprecated properties are moved to their new place, while properties without a replacement are removed. Comments and the order of properties are preserved. The diff of each file is printed together with changes which require manual action.",
+		Example: heredoc.WithCLIName(`
+			# Print the diff between the old and migrated configuration
+			<cli> config migrate config.yaml
+
+			# Migrate configuration files in place
+			<cli> config migrate --write global_config.yaml comm_config.yaml
+		`, cli.Name),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := cmd.OutOrStdout()
+			for _, path := range args {
+				if err := migrateFile(out, path, opts.Write); err != nil {
+					return fmt.Errorf("while migrating %q: %w", path, err)
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd = analytics.InjectAnalyticsReporting(*cmd, "config migrate")
+	opts.RegisterFlags(cmd.Flags())
+
+	return cmd
+}
+
+func migrateFile(out io.Writer, path string, write bool) error {
+	original, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	result, err := migration.Migrate(original)
+	if err != nil {
+		return err
+	}
+
+	diff, err := migration.Diff(path, original, result.Data)
+	if err != nil {
+		return fmt.Errorf("while generating diff: %w", err)
+	}
+	if diff == "" {
+		fmt.Fprintf(out, "%s is up to date\n", path)
+	}
+	fmt.Fprint(out, diff)
+
+	for _, change := range result.Changes {
+		if change.ManualActionRequired {
+			fmt.Fprintf(out, "Manual action required: %s\n", change.String())
+		}
+	}
+
+	if !write || diff == "" {
+		return nil
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, result.Data, info.Mode().Perm()); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "%s migrated\n", path)
+	return nil
+}
@@ -0,0 +1,92 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kubeshop/botkube/internal/cli"
+	"github.com/kubeshop/botkube/internal/cli/analytics"
+	"github.com/kubeshop/botkube/internal/cli/heredoc"
+	"github.com/kubeshop/botkube/internal/cli/printer"
+)
+
+// RollbackOptions holds options to roll back the Botkube configuration.
+type RollbackOptions struct {
+	History HistoryOptions
+	Cancel  bool
+}
+
+// NewRollback returns a cobra.Command for rolling back the Botkube configuration.
+func NewRollback() *cobra.Command {
+	var opts RollbackOptions
+
+	cmd := &cobra.Command{
+		Use:   "rollback [REVISION]",
+		Short: "Rolls back Botkube configuration to a given revision",
+		Long: heredoc.WithCLIName(`
+			Rolls back Botkube configuration to a given revision, or to the previous one if not specified.
+			Use '<cli> config history' to list available revisions. The revision is applied instead of the configuration sources
+			until the rollback is cancelled. Botkube reloads automatically if the Config Watcher is enabled. Otherwise, it must be restarted manually.`, cli.Name),
+		Example: heredoc.WithCLIName(`
+			# Roll back to the previous configuration
+			<cli> config rollback
+
+			# Roll back to a given revision, listed by '<cli> config history'
+			<cli> config rollback 3
+
+			# Cancel the rollback and apply the configuration sources again
+			<cli> config rollback --cancel
+		`, cli.Name),
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			var revision int
+			if len(args) > 0 {
+				revision, err = strconv.Atoi(args[0])
+				if err != nil || revision <= 0 {
+					return fmt.Errorf("invalid revision %q, it must be a positive number", args[0])
+				}
+			}
+
+			status := printer.NewStatus(cmd.ErrOrStderr(), "Rolling back Botkube configuration")
+			defer func() {
+				status.End(err == nil)
+			}()
+
+			store, k8sCfg, err := opts.History.store()
+			if err != nil {
+				return err
+			}
+			by := fmt.Sprintf("%s CLI (Kubernetes context %q)", cli.Name, k8sCfg.CurrentContext)
+
+			if opts.Cancel {
+				cancelled, err := store.CancelRollback(cmd.Context(), by)
+				if err != nil {
+					return fmt.Errorf("while cancelling configuration rollback: %w", err)
+				}
+				if !cancelled {
+					status.Infof("The configuration is not rolled back")
+					return nil
+				}
+				status.Infof("Cancelled the configuration rollback")
+				return nil
+			}
+
+			entry, err := store.Rollback(cmd.Context(), revision, by)
+			if err != nil {
+				return fmt.Errorf("while rolling back configuration: %w", err)
+			}
+			status.Infof("Rolled back the configuration to revision %d", entry.Revision)
+			return nil
+		},
+	}
+
+	cmd = analytics.InjectAnalyticsReporting(*cmd, "config rollback")
+
+	flags := cmd.Flags()
+	opts.History.RegisterFlags(flags)
+	flags.BoolVar(&opts.Cancel, "cancel", false, "Cancels the rollback, so the configuration sources are applied again")
+
+	return cmd
+}
@@ -0,0 +1,80 @@
+package config
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/kubeshop/botkube/internal/cli"
+	"github.com/kubeshop/botkube/internal/cli/analytics"
+	"github.com/kubeshop/botkube/internal/cli/heredoc"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/plugin"
+)
+
+const defaultPluginsIndexURL = "https://storage.googleapis.com/botkube-plugins-latest/plugins-index.yaml"
+
+// SchemaOptions holds options to generate the JSON schema of Botkube configuration.
+type SchemaOptions struct {
+	PluginRepositories map[string]string
+	SkipPlugins        bool
+	Timeout            time.Duration
+}
+
+// RegisterFlags registers flags to generate the JSON schema.
+func (o *SchemaOptions) RegisterFlags(flags *pflag.FlagSet) {
+	flags.StringToStringVar(&o.PluginRepositories, "plugin-repository", map[string]string{"botkube": defaultPluginsIndexURL}, "Plugin repositories whose plugin configuration schemas are included, in the form of {name}={index URL}. Use the same names as in the `plugins.repositories` property.")
+	flags.BoolVar(&o.SkipPlugins, "skip-plugins", false, "Skip plugin configuration schemas, so configuration of all plugins can be any object")
+	flags.DurationVar(&o.Timeout, "timeout", 30*time.Second, "Maximum time to fetch plugin repository indexes and schemas")
+}
+
+// NewSchema returns a cobra.Command for printing the JSON schema of Botkube configuration.
+func NewSchema() *cobra.Command {
+	var opts SchemaOptions
+
+	cmd := &cobra.Command{
+		Use:   "schema",
+		Short: "Prints the JSON schema of Botkube configuration",
+		Long:  "Prints the JSON schema of Botkube configuration, including configuration schemas of plugins from given repositories. Use it in your editor to get completion and catch typos, such as `bindigs`, before deploying the configuration.",
+		Example: heredoc.WithCLIName(`
+			# Save the JSON schema in a file
+			<cli> config schema > botkube-schema.json
+
+			# Include plugins from a custom repository
+			<cli> config schema --plugin-repository botkube=https://example.com/plugins-index.yaml --plugin-repository mycompany=https://example.com/mycompany-index.yaml
+
+			# Generate the schema offline, without plugin configuration schemas
+			<cli> config schema --skip-plugins
+		`, cli.Name),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var plugins config.PluginConfigSchemas
+			if !opts.SkipPlugins {
+				repos := map[string]config.PluginsRepository{}
+				for name, url := range opts.PluginRepositories {
+					repos[name] = config.PluginsRepository{URL: url}
+				}
+
+				var err error
+				plugins, err = plugin.FetchConfigSchemas(cmd.Context(), &http.Client{Timeout: opts.Timeout}, repos)
+				if err != nil {
+					return fmt.Errorf("while fetching plugin configuration schemas: %w", err)
+				}
+			}
+
+			schema, err := config.JSONSchema(plugins)
+			if err != nil {
+				return fmt.Errorf("while generating JSON schema: %w", err)
+			}
+			_, err = fmt.Fprintln(cmd.OutOrStdout(), string(schema))
+			return err
+		},
+	}
+
+	cmd = analytics.InjectAnalyticsReporting(*cmd, "config schema")
+	opts.RegisterFlags(cmd.Flags())
+
+	return cmd
+}
@@ -0,0 +1,18 @@
+package dev
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// NewCmd returns a new cobra.Command subcommand for plugin development tools.
+func NewCmd() *cobra.Command {
+	root := &cobra.Command{
+		Use:   "dev",
+		Short: "This command consists of multiple subcommands which help to develop Botkube plugins",
+	}
+
+	root.AddCommand(
+		NewPreview(),
+	)
+	return root
+}
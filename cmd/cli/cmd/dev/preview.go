@@ -0,0 +1,155 @@
+package dev
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/slack-go/slack"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/kubeshop/botkube/internal/cli"
+	"github.com/kubeshop/botkube/internal/cli/analytics"
+	"github.com/kubeshop/botkube/internal/cli/heredoc"
+	"github.com/kubeshop/botkube/internal/cli/printer"
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/bot"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+)
+
+// PreviewOptions holds options to render and post a message preview.
+type PreviewOptions struct {
+	File      string
+	BotName   string
+	Platforms []string
+
+	SlackToken     string
+	SlackChannel   string
+	DiscordToken   string
+	DiscordChannel string
+}
+
+// NewPreview returns a cobra.Command for rendering plugin messages for communication platforms.
+func NewPreview() *cobra.Command {
+	var opts PreviewOptions
+
+	resourcePrinter := printer.NewForResource(os.Stdout, printer.WithJSON(), printer.WithYAML(), printer.WithDefaultOutputFormat(printer.JSONFormat))
+
+	cmd := &cobra.Command{
+		Use:   "preview",
+		Short: "Renders a plugin message as Slack, MS Teams and Discord payloads",
+		Long: heredoc.WithCLIName(`
+			Renders a plugin message as Slack Block Kit, MS Teams Adaptive Card and Discord payloads, using the same renderers as Botkube.
+
+			The message is read in the JSON or YAML format of the 'api.Message' type returned by plugins. Optionally, the message is posted
+			to a sandbox Slack or Discord channel, so its layout can be checked without redeploying a plugin.
+		`, cli.Name),
+		Example: heredoc.WithCLIName(`
+			# Render Slack blocks for a message
+			<cli> dev preview -f message.yaml --platform slack
+
+			# Render a message returned by a plugin command
+			my-plugin-dev-run | <cli> dev preview -f -
+
+			# Render a message, and post it to a sandbox Slack channel
+			<cli> dev preview -f message.yaml --slack-token "${SLACK_BOT_TOKEN}" --slack-channel sandbox
+		`, cli.Name),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			msg, err := readMessage(cmd.InOrStdin(), opts.File)
+			if err != nil {
+				return err
+			}
+
+			var platforms []bot.PreviewPlatform
+			for _, platform := range opts.Platforms {
+				platforms = append(platforms, bot.PreviewPlatform(platform))
+			}
+			preview, err := bot.RenderPreview(msg, opts.BotName, platforms...)
+			if err != nil {
+				return err
+			}
+
+			if err := opts.post(cmd.Context(), msg, preview); err != nil {
+				return err
+			}
+			return resourcePrinter.Print(preview)
+		},
+	}
+
+	cmd = analytics.InjectAnalyticsReporting(*cmd, "dev preview")
+
+	var defaultPlatforms []string
+	for _, platform := range bot.AllPreviewPlatforms() {
+		defaultPlatforms = append(defaultPlatforms, string(platform))
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&opts.File, "file", "f", "", `Path to the message file in JSON or YAML format. Use "-" to read it from the standard input`)
+	flags.StringVar(&opts.BotName, "bot-name", "@Botkube", "Bot name the placeholder in commands is replaced with")
+	flags.StringSliceVar(&opts.Platforms, "platform", defaultPlatforms, "Platforms to render the message for")
+	flags.StringVar(&opts.SlackToken, "slack-token", "", "Slack bot token used to post the message to a sandbox channel")
+	flags.StringVar(&opts.SlackChannel, "slack-channel", "", "Slack channel the message is posted to")
+	flags.StringVar(&opts.DiscordToken, "discord-token", "", "Discord bot token used to post the message to a sandbox channel")
+	flags.StringVar(&opts.DiscordChannel, "discord-channel", "", "ID of the Discord channel the message is posted to")
+	resourcePrinter.RegisterFlags(flags)
+	_ = cmd.MarkFlagRequired("file")
+	cmd.MarkFlagsRequiredTogether("slack-token", "slack-channel")
+	cmd.MarkFlagsRequiredTogether("discord-token", "discord-channel")
+
+	return cmd
+}
+
+func readMessage(stdin io.Reader, path string) (api.Message, error) {
+	var (
+		raw []byte
+		err error
+	)
+	if path == "-" {
+		raw, err = io.ReadAll(stdin)
+	} else {
+		raw, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return api.Message{}, fmt.Errorf("while reading message: %w", err)
+	}
+
+	// JSON is a subset of YAML, so both formats are decoded in the same way
+	var msg api.Message
+	if err := yaml.Unmarshal(raw, &msg); err != nil {
+		return api.Message{}, fmt.Errorf("while decoding message: %w", err)
+	}
+	if msg.IsEmpty() {
+		return api.Message{}, errors.New("message is empty")
+	}
+	return msg, nil
+}
+
+// post posts the message to sandbox channels configured in options.
+func (o PreviewOptions) post(ctx context.Context, msg api.Message, preview bot.Preview) error {
+	if o.SlackToken != "" {
+		msg.ReplaceBotNamePlaceholder(o.BotName)
+		renderer := bot.NewSlackRenderer()
+		_, _, err := slack.New(o.SlackToken).PostMessageContext(ctx, o.SlackChannel, renderer.RenderInteractiveMessage(interactive.CoreMessage{Message: msg}))
+		if err != nil {
+			return fmt.Errorf("while posting message to Slack: %w", err)
+		}
+	}
+
+	if o.DiscordToken != "" {
+		if preview.Discord == nil {
+			return errors.New("cannot post message to Discord, as it's not rendered for the discord platform")
+		}
+		session, err := discordgo.New("Bot " + o.DiscordToken)
+		if err != nil {
+			return fmt.Errorf("while creating Discord session: %w", err)
+		}
+		if _, err := session.ChannelMessageSendComplex(o.DiscordChannel, preview.Discord); err != nil {
+			return fmt.Errorf("while posting message to Discord: %w", err)
+		}
+	}
+	return nil
+}
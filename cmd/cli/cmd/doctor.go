@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kubeshop/botkube/internal/cli"
+	"github.com/kubeshop/botkube/internal/cli/analytics"
+	"github.com/kubeshop/botkube/internal/cli/heredoc"
+	"github.com/kubeshop/botkube/internal/cli/printer"
+	"github.com/kubeshop/botkube/internal/doctor"
+	"github.com/kubeshop/botkube/internal/kubex"
+)
+
+const doctorEndpointPath = "/doctor"
+
+// DoctorOptions holds options to find the Botkube Pod to run diagnostics in.
+type DoctorOptions struct {
+	Namespace  string
+	Label      string
+	HealthPort int
+}
+
+// NewDoctor returns a cobra.Command for running Botkube diagnostics.
+func NewDoctor() *cobra.Command {
+	var opts DoctorOptions
+
+	resourcePrinter := printer.NewForResource(os.Stdout, printer.WithJSON(), printer.WithYAML())
+
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Runs connectivity and permission checks of installed Botkube",
+		Long: heredoc.WithCLIName(`
+			Runs connectivity and permission checks of installed Botkube, and prints a pass/fail report with remediation hints.
+
+			Checks are run by the Botkube agent, so they use its network access and permissions. The agent checks:
+			  - chat API authentication of communication platforms,
+			  - RBAC permissions for resources watched by Kubernetes sources,
+			  - reachability of plugin repository indexes,
+			  - reachability of webhook endpoints.
+
+			The command exits with an error if any check failed. The same report is returned by the '@Botkube doctor' command.
+		`, cli.Name),
+		Example: heredoc.WithCLIName(`
+			# Run diagnostics of currently installed Botkube
+			<cli> doctor
+
+			# Run diagnostics of Botkube installed in a different namespace, and print the report in JSON format
+			<cli> doctor -n botkube-dev -ojson
+		`, cli.Name),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			status := printer.NewStatus(cmd.ErrOrStderr(), "Running Botkube diagnostics")
+			defer func() {
+				status.End(err == nil)
+			}()
+
+			k8sCfg, err := kubex.LoadRestConfigWithMetaInformation()
+			if err != nil {
+				return fmt.Errorf("while creating k8s config: %w", err)
+			}
+			k8sCli, err := kubernetes.NewForConfig(k8sCfg.K8s)
+			if err != nil {
+				return fmt.Errorf("while creating k8s client: %w", err)
+			}
+
+			pods, err := k8sCli.CoreV1().Pods(opts.Namespace).List(cmd.Context(), metav1.ListOptions{LabelSelector: opts.Label})
+			if err != nil {
+				return fmt.Errorf("while listing Botkube Pods: %w", err)
+			}
+			if len(pods.Items) == 0 {
+				return fmt.Errorf("there are no Pods with label %q in the %q namespace", opts.Label, opts.Namespace)
+			}
+			pod := pods.Items[0]
+
+			status.Step("Running checks in the %q Pod", pod.Name)
+			// the doctor endpoint responds with 503 if any check failed, so the body is decoded regardless of the error
+			raw, reqErr := k8sCli.CoreV1().Pods(pod.Namespace).ProxyGet("http", pod.Name, strconv.Itoa(opts.HealthPort), doctorEndpointPath, nil).DoRaw(cmd.Context())
+			var report doctor.Report
+			if err := json.Unmarshal(raw, &report); err != nil {
+				if reqErr != nil {
+					return fmt.Errorf("while calling the doctor endpoint: %w", reqErr)
+				}
+				return fmt.Errorf("while decoding diagnostics report: %w", err)
+			}
+			status.End(true)
+
+			if err := resourcePrinter.Print(report); err != nil {
+				return err
+			}
+			if failed := report.Failed(); failed > 0 {
+				return fmt.Errorf("%d of %d checks failed", failed, len(report.Results))
+			}
+			return nil
+		},
+	}
+
+	cmd = analytics.InjectAnalyticsReporting(*cmd, "doctor")
+
+	flags := cmd.Flags()
+	flags.StringVarP(&opts.Namespace, "namespace", "n", "botkube", "Namespace of Botkube installation")
+	flags.StringVarP(&opts.Label, "label", "l", "app=botkube", "Label used for identifying the Botkube Pod")
+	flags.IntVar(&opts.HealthPort, "health-port", 2114, "Health port of the Botkube Pod, which serves the diagnostics report")
+	resourcePrinter.RegisterFlags(flags)
+
+	return cmd
+}
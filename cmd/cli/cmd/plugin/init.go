@@ -0,0 +1,96 @@
+package plugin
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"go.szostok.io/version"
+
+	"github.com/kubeshop/botkube/internal/cli"
+	"github.com/kubeshop/botkube/internal/cli/analytics"
+	"github.com/kubeshop/botkube/internal/cli/heredoc"
+	"github.com/kubeshop/botkube/internal/cli/printer"
+	"github.com/kubeshop/botkube/internal/cli/scaffold"
+)
+
+// InitOptions holds options to scaffold a plugin project.
+type InitOptions struct {
+	Type           string
+	Module         string
+	Description    string
+	OutputDir      string
+	BotkubeVersion string
+}
+
+// NewInit returns a cobra.Command for scaffolding a new plugin project.
+func NewInit() *cobra.Command {
+	var opts InitOptions
+
+	cmd := &cobra.Command{
+		Use:   "init NAME",
+		Short: "Scaffolds a new Botkube source or executor plugin project",
+		Long: heredoc.WithCLIName(`
+			Scaffolds a new Botkube source or executor plugin project, which contains:
+			  - plugin source code with gRPC boilerplate and configuration JSON schema,
+			  - Makefile with build, test and index generation targets,
+			  - GoReleaser configuration which builds plugin binaries with names expected by the plugin index,
+			  - plugin index generator,
+			  - e2e tests, which start the plugin binary the same way Botkube does.
+
+			The project is generated in a new or empty directory.
+		`, cli.Name),
+		Example: heredoc.WithCLIName(`
+			# Scaffold an executor plugin in the ./my-executor directory
+			<cli> plugin init my-executor --module github.com/acme/my-executor
+
+			# Scaffold a source plugin in a given directory
+			<cli> plugin init my-source --type source --module github.com/acme/my-source --output-dir ./plugins/my-source
+		`, cli.Name),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			name := args[0]
+			outputDir := opts.OutputDir
+			if outputDir == "" {
+				outputDir = name
+			}
+			module := opts.Module
+			if module == "" {
+				module = name
+			}
+
+			status := printer.NewStatus(cmd.ErrOrStderr(), "Scaffolding Botkube plugin project")
+			defer func() {
+				status.End(err == nil)
+			}()
+
+			status.Step("Generating %s plugin %q in %s", opts.Type, name, filepath.Clean(outputDir))
+			_, err = scaffold.Generate(scaffold.Options{
+				Name:           name,
+				Type:           scaffold.PluginType(opts.Type),
+				Module:         module,
+				Description:    opts.Description,
+				BotkubeVersion: opts.BotkubeVersion,
+				OutputDir:      outputDir,
+			})
+			if err != nil {
+				return err
+			}
+
+			status.InfoWithBody("Next steps:", fmt.Sprintf("  cd %s\n  go mod tidy\n  make test-e2e\n", filepath.Clean(outputDir)))
+			return nil
+		},
+	}
+
+	cmd = analytics.InjectAnalyticsReporting(*cmd, "plugin init")
+
+	flags := cmd.Flags()
+	flags.StringVar(&opts.Type, "type", string(scaffold.ExecutorPluginType), fmt.Sprintf("Plugin type. Allowed values: %s", strings.Join([]string{string(scaffold.ExecutorPluginType), string(scaffold.SourcePluginType)}, ", ")))
+	flags.StringVar(&opts.Module, "module", "", "Go module path of the project. Defaults to the plugin name")
+	flags.StringVar(&opts.Description, "description", "", "Plugin description returned in its metadata")
+	flags.StringVar(&opts.OutputDir, "output-dir", "", "Directory the project is generated in. Defaults to the plugin name")
+	flags.StringVar(&opts.BotkubeVersion, "botkube-version", version.Get().Version, "Version of the Botkube Go module the project depends on")
+
+	return cmd
+}
@@ -0,0 +1,18 @@
+package plugin
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// NewCmd returns a new cobra.Command subcommand for Botkube plugin projects.
+func NewCmd() *cobra.Command {
+	root := &cobra.Command{
+		Use:   "plugin",
+		Short: "This command consists of multiple subcommands which help to create Botkube plugins",
+	}
+
+	root.AddCommand(
+		NewInit(),
+	)
+	return root
+}
@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kubeshop/botkube/internal/cli"
+	"github.com/kubeshop/botkube/internal/cli/analytics"
+	"github.com/kubeshop/botkube/internal/cli/heredoc"
+	"github.com/kubeshop/botkube/internal/cli/printer"
+	"github.com/kubeshop/botkube/internal/kubex"
+	"github.com/kubeshop/botkube/internal/replay"
+)
+
+const replayEndpointPath = "replay"
+
+// ReplayOptions holds options to replay events in the notification pipeline of installed Botkube.
+type ReplayOptions struct {
+	Namespace  string
+	Label      string
+	HealthPort int
+
+	File      string
+	Source    string
+	Synthetic replay.Synthetic
+}
+
+// NewReplay returns a cobra.Command for replaying events in the notification pipeline.
+func NewReplay() *cobra.Command {
+	var opts ReplayOptions
+
+	resourcePrinter := printer.NewForResource(os.Stdout, printer.WithJSON(), printer.WithYAML())
+
+	cmd := &cobra.Command{
+		Use:   "replay",
+		Short: "Reports which channels and sinks would receive given events",
+		Long: heredoc.WithCLIName(`
+			Replays synthetic or recorded events in the notification pipeline of installed Botkube, and reports which channels and sinks would receive them.
+
+			Events are evaluated by the Botkube agent with its current filters, routing rules and owners, but nothing is sent to channels or sinks,
+			so configuration changes can be verified safely. Recorded events are read in the JSON lines format written by the archive sinks.
+			Processor plugins, maintenance windows and incident tracking are skipped.
+
+			The same report for a synthetic event is returned by the '@Botkube replay' command.
+		`, cli.Name),
+		Example: heredoc.WithCLIName(`
+			# Replay a synthetic Pod event for the 'k8s-err-events' source binding
+			<cli> replay --source k8s-err-events --kind Pod --name api --event-namespace prod --reason BackOff
+
+			# Replay events recorded by the S3 archive sink for source bindings they were recorded for
+			<cli> replay -f events.jsonl
+
+			# Replay decompressed recorded events for a different source binding
+			gunzip -c events.jsonl.gz | <cli> replay -f - --source k8s-all-events
+		`, cli.Name),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			reqs, err := opts.requests(cmd.InOrStdin())
+			if err != nil {
+				return err
+			}
+			body, err := json.Marshal(reqs)
+			if err != nil {
+				return fmt.Errorf("while encoding events: %w", err)
+			}
+
+			status := printer.NewStatus(cmd.ErrOrStderr(), "Replaying events")
+			defer func() {
+				status.End(err == nil)
+			}()
+
+			k8sCfg, err := kubex.LoadRestConfigWithMetaInformation()
+			if err != nil {
+				return fmt.Errorf("while creating k8s config: %w", err)
+			}
+			k8sCli, err := kubernetes.NewForConfig(k8sCfg.K8s)
+			if err != nil {
+				return fmt.Errorf("while creating k8s client: %w", err)
+			}
+
+			pods, err := k8sCli.CoreV1().Pods(opts.Namespace).List(cmd.Context(), metav1.ListOptions{LabelSelector: opts.Label})
+			if err != nil {
+				return fmt.Errorf("while listing Botkube Pods: %w", err)
+			}
+			if len(pods.Items) == 0 {
+				return fmt.Errorf("there are no Pods with label %q in the %q namespace", opts.Label, opts.Namespace)
+			}
+			pod := pods.Items[0]
+
+			status.Step("Replaying %d event(s) in the %q Pod", len(reqs), pod.Name)
+			raw, err := k8sCli.CoreV1().RESTClient().Post().
+				Namespace(pod.Namespace).
+				Resource("pods").
+				Name(fmt.Sprintf("http:%s:%s", pod.Name, strconv.Itoa(opts.HealthPort))).
+				SubResource("proxy").
+				Suffix(replayEndpointPath).
+				Body(body).
+				DoRaw(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("while calling the replay endpoint: %w", err)
+			}
+
+			var reports []replay.Report
+			if err := json.Unmarshal(raw, &reports); err != nil {
+				return fmt.Errorf("while decoding replay reports: %w", err)
+			}
+			status.End(true)
+
+			return resourcePrinter.Print(reports)
+		},
+	}
+
+	cmd = analytics.InjectAnalyticsReporting(*cmd, "replay")
+
+	flags := cmd.Flags()
+	flags.StringVarP(&opts.Namespace, "namespace", "n", "botkube", "Namespace of Botkube installation")
+	flags.StringVarP(&opts.Label, "label", "l", "app=botkube", "Label used for identifying the Botkube Pod")
+	flags.IntVar(&opts.HealthPort, "health-port", 2114, "Health port of the Botkube Pod, which serves the replay endpoint")
+	flags.StringVarP(&opts.File, "file", "f", "", `Path to the file with recorded events in the JSON lines format. Use "-" to read them from the standard input`)
+	flags.StringVar(&opts.Source, "source", "", "Source binding events are replayed for. Required for synthetic events. Recorded events are replayed for their first source binding by default")
+	flags.StringVar(&opts.Synthetic.Kind, "kind", "Pod", "Resource kind of the synthetic event")
+	flags.StringVar(&opts.Synthetic.Name, "name", "replay", "Resource name of the synthetic event")
+	flags.StringVar(&opts.Synthetic.Namespace, "event-namespace", "default", "Resource namespace of the synthetic event")
+	flags.StringVar(&opts.Synthetic.Type, "type", "error", "Type of the synthetic event")
+	flags.StringVar(&opts.Synthetic.Reason, "reason", "", "Reason of the synthetic event")
+	flags.StringVar(&opts.Synthetic.Message, "message", "", "Message of the synthetic event")
+	flags.StringToStringVar(&opts.Synthetic.Labels, "labels", nil, "Resource labels of the synthetic event, used by routing rules")
+	resourcePrinter.RegisterFlags(flags)
+
+	return cmd
+}
+
+// requests returns recorded events if the file is set, or the synthetic event otherwise.
+func (o ReplayOptions) requests(stdin io.Reader) ([]replay.Request, error) {
+	if o.File == "" {
+		if o.Source == "" {
+			return nil, errors.New("the source binding is required for synthetic events, use the --source flag")
+		}
+		return []replay.Request{o.Synthetic.Request(o.Source)}, nil
+	}
+
+	in := stdin
+	if o.File != "-" {
+		file, err := os.Open(o.File)
+		if err != nil {
+			return nil, fmt.Errorf("while opening recorded events: %w", err)
+		}
+		defer file.Close()
+		in = file
+	}
+
+	reqs, err := replay.ParseRecords(in, o.Source)
+	if err != nil {
+		return nil, err
+	}
+	if len(reqs) == 0 {
+		return nil, errors.New("there are no recorded events to replay")
+	}
+	return reqs, nil
+}
@@ -5,6 +5,8 @@ import (
 	"go.szostok.io/version/extension"
 
 	"github.com/kubeshop/botkube/cmd/cli/cmd/config"
+	"github.com/kubeshop/botkube/cmd/cli/cmd/dev"
+	"github.com/kubeshop/botkube/cmd/cli/cmd/plugin"
 	"github.com/kubeshop/botkube/cmd/cli/cmd/telemetry"
 	"github.com/kubeshop/botkube/internal/cli"
 	"github.com/kubeshop/botkube/internal/cli/heredoc"
@@ -29,6 +31,12 @@ func NewRoot() *cobra.Command {
 
             $ <cli> install                              # Install Botkube
             $ <cli> uninstall                            # Uninstall Botkube
+            $ <cli> doctor                               # Run connectivity and permission checks
+            $ <cli> replay --source k8s-err-events       # Report which channels would receive an event
+
+        Plugin Development:
+
+            $ <cli> plugin init my-plugin                # Scaffold a new plugin project
 
         Botkube Cloud:
 
@@ -49,7 +57,11 @@ func NewRoot() *cobra.Command {
 		NewDocs(),
 		NewInstall(),
 		NewUninstall(),
+		NewDoctor(),
+		NewReplay(),
 		config.NewCmd(),
+		dev.NewCmd(),
+		plugin.NewCmd(),
 		telemetry.NewCmd(),
 		extension.NewVersionCobraCmd(
 			extension.WithUpgradeNotice(orgName, repoName),
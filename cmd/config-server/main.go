@@ -0,0 +1,41 @@
+package main
+
+import (
+	"errors"
+
+	"github.com/spf13/pflag"
+	"sigs.k8s.io/controller-runtime/pkg/manager/signals"
+
+	"github.com/kubeshop/botkube/internal/config/remote/server"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/httpx"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+// main starts the reference server for the open remote configuration API.
+// See the `docs/remote-config-api` directory for more details.
+func main() {
+	addr := pflag.String("addr", ":8080", "Address to listen on.")
+	dir := pflag.String("config-dir", ".", "Directory with agent configuration files named `{agent-id}.yaml`.")
+	tokensFile := pflag.String("tokens-file", "", "YAML file which maps agent IDs to their bearer tokens.")
+	insecure := pflag.Bool("insecure-skip-auth", false, "Accept requests without authentication. Use only for local development.")
+	pflag.Parse()
+
+	log := loggerx.New(config.Logger{Level: "info"})
+
+	var tokens map[string]string
+	switch {
+	case *tokensFile != "":
+		var err error
+		tokens, err = server.LoadTokens(*tokensFile)
+		loggerx.ExitOnError(err, "while loading agent tokens")
+	case *insecure:
+		log.Warn("Authentication is disabled. Any client can read configuration of all agents.")
+	default:
+		loggerx.ExitOnError(errors.New("the --tokens-file flag is required unless --insecure-skip-auth is set"), "while configuring authentication")
+	}
+
+	srv := server.New(log, *dir, tokens)
+	err := httpx.NewServer(log, *addr, srv.Handler()).Serve(signals.SetupSignalHandler())
+	loggerx.ExitOnError(err, "while running server")
+}
@@ -0,0 +1,19 @@
+package main
+
+import (
+	"github.com/hashicorp/go-plugin"
+
+	"github.com/kubeshop/botkube/internal/executor/node"
+	"github.com/kubeshop/botkube/pkg/api/executor"
+)
+
+// version is set via ldflags by GoReleaser.
+var version = "dev"
+
+func main() {
+	executor.Serve(map[string]plugin.Plugin{
+		node.PluginName: &executor.Plugin{
+			Executor: node.NewExecutor(version),
+		},
+	})
+}
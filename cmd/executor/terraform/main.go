@@ -0,0 +1,19 @@
+package main
+
+import (
+	"github.com/hashicorp/go-plugin"
+
+	"github.com/kubeshop/botkube/internal/executor/terraform"
+	"github.com/kubeshop/botkube/pkg/api/executor"
+)
+
+// version is set via ldflags by GoReleaser.
+var version = "dev"
+
+func main() {
+	executor.Serve(map[string]plugin.Plugin{
+		terraform.PluginName: &executor.Plugin{
+			Executor: terraform.NewExecutor(version, terraform.NewBinaryRunner()),
+		},
+	})
+}
@@ -1,10 +1,15 @@
 package main
 
 import (
+	"context"
 	"flag"
+	"net/http"
 	"os"
+	"path"
 	"path/filepath"
+	"strings"
 
+	"github.com/kubeshop/botkube/pkg/config"
 	"github.com/kubeshop/botkube/pkg/loggerx"
 	"github.com/kubeshop/botkube/pkg/plugin"
 
@@ -21,6 +26,8 @@ func main() {
 		output           = flag.String("output-path", "./plugins-index.yaml", "Defines the local path where index YAML should be saved")
 		pluginNameFilter = flag.String("plugin-name-filter", "", "Defines the plugin name regex for plugins which should be included in the index. Other plugins will be skipped.")
 		useArchive       = flag.Bool("use-archive", true, "If enabled, archives are used instead of binaries for constructing plugin download URLs.")
+		push             = flag.Bool("push", false, "If enabled, plugin binaries are pushed to the OCI registry defined by the 'oci://' URL base path.")
+		indexRef         = flag.String("index-ref", "", "Defines the 'oci://' reference under which the index is pushed, e.g. 'oci://ghcr.io/kubeshop/botkube-plugins/index:v1.0.0'.")
 	)
 
 	flag.Parse()
@@ -47,4 +54,35 @@ func main() {
 	logger.WithField("output", *output).Info("Saving index file...")
 	err = os.WriteFile(*output, raw, filePerm)
 	loggerx.ExitOnError(err, "while saving index file")
+
+	if !*push && *indexRef == "" {
+		return
+	}
+
+	ctx := context.Background()
+	ociCli, err := plugin.NewOCIClient(http.DefaultClient, config.PluginsOCI{})
+	loggerx.ExitOnError(err, "while creating OCI client")
+
+	if *push {
+		for _, entry := range idx.Entries {
+			for _, u := range entry.URLs {
+				if !plugin.IsOCIURL(u.URL) {
+					continue
+				}
+				name := strings.TrimSuffix(path.Base(u.URL), ":"+entry.Version)
+				data, err := os.ReadFile(filepath.Join(absBinsDir, name))
+				loggerx.ExitOnError(err, "while reading plugin binary")
+
+				logger.WithField("ref", u.URL).Info("Pushing plugin binary...")
+				err = ociCli.Push(ctx, u.URL, name, data)
+				loggerx.ExitOnError(err, "while pushing plugin binary")
+			}
+		}
+	}
+
+	if *indexRef != "" {
+		logger.WithField("ref", *indexRef).Info("Pushing index...")
+		err = ociCli.Push(ctx, *indexRef, filepath.Base(*output), raw)
+		loggerx.ExitOnError(err, "while pushing index")
+	}
 }
@@ -55,3 +55,21 @@ Create the name of the service account to use
     {{- true -}}
 {{- end -}}
 {{- end -}}
+
+{{/*
+Check whether the BotkubeConfig validating admission webhook is enabled
+*/}}
+{{- define "botkube.configCRDWebhookEnabled" -}}
+{{ if and .Values.configCRD.enabled .Values.configCRD.webhook.enabled (not (include "botkube.remoteConfigEnabled" $)) }}
+    {{- true -}}
+{{- end -}}
+{{- end -}}
+
+{{/*
+Check whether the Git sync push webhook is enabled
+*/}}
+{{- define "botkube.gitSyncWebhookEnabled" -}}
+{{ if and .Values.gitSync.enabled .Values.gitSync.webhook.enabled .Values.configWatcher.enabled (not (include "botkube.remoteConfigEnabled" $)) }}
+    {{- true -}}
+{{- end -}}
+{{- end -}}
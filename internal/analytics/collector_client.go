@@ -0,0 +1,202 @@
+package analytics
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	segment "github.com/segmentio/analytics-go"
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/httpx"
+)
+
+const (
+	defaultCollectorFlushInterval = 5 * time.Second
+	defaultCollectorMaxBatchSize  = 100
+)
+
+var _ segment.Client = &CollectorClient{}
+
+// CollectorClient sends analytics messages to a self-hosted collector instead of Twilio Segment.
+// Messages are converted to CollectorEvent and POSTed in batches, described by the CollectorBatch type.
+type CollectorClient struct {
+	log     logrus.FieldLogger
+	cfg     config.AnalyticsCollector
+	httpCli *http.Client
+	now     func() time.Time
+
+	mu    sync.Mutex
+	batch []CollectorEvent
+
+	flushCh chan struct{}
+	stopCh  chan struct{}
+	doneCh  chan struct{}
+	once    sync.Once
+}
+
+// NewCollectorClient returns a new CollectorClient instance, which flushes batches in the background until it's closed.
+func NewCollectorClient(log logrus.FieldLogger, cfg config.AnalyticsCollector) *CollectorClient {
+	if cfg.FlushInterval <= 0 {
+		cfg.FlushInterval = defaultCollectorFlushInterval
+	}
+	if cfg.MaxBatchSize <= 0 {
+		cfg.MaxBatchSize = defaultCollectorMaxBatchSize
+	}
+	httpCli := httpx.NewHTTPClient()
+	if cfg.Timeout > 0 {
+		httpCli.Timeout = cfg.Timeout
+	}
+
+	c := &CollectorClient{
+		log:     log,
+		cfg:     cfg,
+		httpCli: httpCli,
+		now:     time.Now,
+		flushCh: make(chan struct{}, 1),
+		stopCh:  make(chan struct{}),
+		doneCh:  make(chan struct{}),
+	}
+	go c.loop()
+	return c
+}
+
+// Enqueue queues a given message. It's sent with the next batch.
+func (c *CollectorClient) Enqueue(msg segment.Message) error {
+	if err := msg.Validate(); err != nil {
+		return err
+	}
+
+	event, err := c.toEvent(msg)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	c.batch = append(c.batch, event)
+	full := len(c.batch) >= c.cfg.MaxBatchSize
+	c.mu.Unlock()
+
+	if full {
+		select {
+		case c.flushCh <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// Close sends queued messages and stops the background flushing.
+func (c *CollectorClient) Close() error {
+	c.once.Do(func() {
+		close(c.stopCh)
+	})
+	<-c.doneCh
+	return nil
+}
+
+func (c *CollectorClient) loop() {
+	defer close(c.doneCh)
+
+	ticker := time.NewTicker(c.cfg.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.stopCh:
+			c.flush()
+			return
+		case <-ticker.C:
+			c.flush()
+		case <-c.flushCh:
+			c.flush()
+		}
+	}
+}
+
+func (c *CollectorClient) flush() {
+	c.mu.Lock()
+	batch := c.batch
+	c.batch = nil
+	c.mu.Unlock()
+
+	if len(batch) == 0 {
+		return
+	}
+
+	// analytics must not affect Botkube, so batches which couldn't be sent are dropped, the same as by the Segment client
+	if err := c.send(batch); err != nil {
+		c.log.WithError(err).Errorf("Failed to send %d analytics events to collector", len(batch))
+	}
+}
+
+func (c *CollectorClient) send(batch []CollectorEvent) error {
+	body, err := json.Marshal(CollectorBatch{Batch: batch, SentAt: c.now()})
+	if err != nil {
+		return fmt.Errorf("while marshaling batch: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.httpCli.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("while creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range c.cfg.Headers {
+		req.Header.Set(key, value)
+	}
+
+	res, err := c.httpCli.Do(req)
+	if err != nil {
+		return fmt.Errorf("while sending request: %w", err)
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, res.Body)
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("incorrect status code: %d", res.StatusCode)
+	}
+	return nil
+}
+
+func (c *CollectorClient) toEvent(msg segment.Message) (CollectorEvent, error) {
+	var out CollectorEvent
+	switch m := msg.(type) {
+	case segment.Identify:
+		out = CollectorEvent{
+			Type:        IdentifyCollectorEventType,
+			MessageID:   m.MessageId,
+			AnonymousID: m.AnonymousId,
+			Timestamp:   m.Timestamp,
+			Traits:      m.Traits,
+		}
+	case segment.Track:
+		out = CollectorEvent{
+			Type:        TrackCollectorEventType,
+			MessageID:   m.MessageId,
+			AnonymousID: m.AnonymousId,
+			Timestamp:   m.Timestamp,
+			Event:       m.Event,
+			Properties:  m.Properties,
+		}
+	default:
+		return CollectorEvent{}, fmt.Errorf("unsupported message type %T", msg)
+	}
+
+	if out.MessageID == "" {
+		out.MessageID = uuid.NewString()
+	}
+	if out.Timestamp.IsZero() {
+		out.Timestamp = c.now()
+	}
+	return out, nil
+}
@@ -0,0 +1,120 @@
+package analytics_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/internal/analytics"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/execute/command"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+func TestCollectorClient(t *testing.T) {
+	// given
+	var (
+		mu      sync.Mutex
+		batches []analytics.CollectorBatch
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		var batch analytics.CollectorBatch
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&batch))
+
+		mu.Lock()
+		defer mu.Unlock()
+		batches = append(batches, batch)
+	}))
+	defer srv.Close()
+
+	timestamp := time.Date(2009, 11, 17, 20, 34, 58, 0, time.UTC)
+	cli := analytics.NewCollectorClient(loggerx.NewNoop(), config.AnalyticsCollector{
+		URL:           srv.URL,
+		Headers:       map[string]string{"Authorization": "Bearer token"},
+		FlushInterval: time.Hour,
+		MaxBatchSize:  2,
+	})
+	cli.SetNow(func() time.Time { return timestamp })
+
+	reporter := analytics.NewSegmentReporter(loggerx.NewNoop(), cli)
+	reporter.SetIdentity(&analytics.Identity{AnonymousID: "cluster-id"})
+
+	// when
+	require.NoError(t, reporter.ReportCommand(analytics.ReportCommandInput{
+		Platform:   config.SocketSlackCommPlatformIntegration,
+		PluginName: "botkube/kubectl",
+		Command:    "kubectl get",
+		Origin:     command.TypedOrigin,
+	}))
+	require.NoError(t, reporter.ReportBotEnabled(config.DiscordCommPlatformIntegration, 1))
+
+	// then the full batch is sent in the background
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(batches) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// when
+	require.NoError(t, reporter.ReportFatalError(assert.AnError))
+	require.NoError(t, cli.Close())
+
+	// then the remaining events are sent on close
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, batches, 2)
+
+	var events []analytics.CollectorEvent
+	for _, batch := range batches {
+		assert.Equal(t, timestamp, batch.SentAt)
+		for _, event := range batch.Batch {
+			assert.NotEmpty(t, event.MessageID)
+			event.MessageID = ""
+			events = append(events, event)
+		}
+	}
+	assert.Equal(t, []analytics.CollectorEvent{
+		{
+			Type:        analytics.TrackCollectorEventType,
+			AnonymousID: "cluster-id",
+			Timestamp:   timestamp,
+			Event:       analytics.CommandExecutedEventName,
+			Properties: map[string]any{
+				"platform": "socketSlack",
+				"command":  "kubectl get",
+				"plugin":   "botkube/kubectl",
+				"origin":   "typed",
+				"filtered": false,
+			},
+		},
+		{
+			Type:        analytics.TrackCollectorEventType,
+			AnonymousID: "cluster-id",
+			Timestamp:   timestamp,
+			Event:       analytics.IntegrationEnabledEventName,
+			Properties: map[string]any{
+				"platform":             "discord",
+				"type":                 "bot",
+				"communicationGroupID": float64(1),
+			},
+		},
+		{
+			Type:        analytics.TrackCollectorEventType,
+			AnonymousID: "cluster-id",
+			Timestamp:   timestamp,
+			Event:       analytics.FatalErrorEventName,
+			Properties: map[string]any{
+				"error": assert.AnError.Error(),
+			},
+		},
+	}, events)
+}
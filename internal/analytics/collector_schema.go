@@ -0,0 +1,55 @@
+package analytics
+
+import "time"
+
+// CollectorEventType is a type of event sent to the self-hosted collector.
+type CollectorEventType string
+
+const (
+	// IdentifyCollectorEventType describes the Botkube installation. It's sent once on startup, with the k8sVersion,
+	// botkubeVersion, workerNodeCount, controlPlaneNodeCount and deploymentID traits.
+	IdentifyCollectorEventType CollectorEventType = "identify"
+	// TrackCollectorEventType describes a single action, identified by the event name.
+	TrackCollectorEventType CollectorEventType = "track"
+)
+
+// Names of track events. Properties of each event are listed next to its name.
+const (
+	// CommandExecutedEventName properties: platform, command (anonymized), plugin, origin, filtered.
+	CommandExecutedEventName = "Command executed"
+	// IntegrationEnabledEventName properties: platform, type (bot or sink), communicationGroupID.
+	IntegrationEnabledEventName = "Integration enabled"
+	// PluginEnabledEventName properties: a map of enabled plugins, with their names, types and anonymized RBAC.
+	PluginEnabledEventName = "Plugin enabled"
+	// FatalErrorEventName properties: error, and unknownIdentity set to true if the identity wasn't registered yet.
+	FatalErrorEventName = "Fatal error"
+	// HeartbeatEventName properties: timeWindowInHours, eventsCount, and sources with handled events per plugin.
+	// It's sent every hour.
+	HeartbeatEventName = "Heartbeat"
+)
+
+// CollectorBatch is the JSON document POSTed to the self-hosted collector.
+type CollectorBatch struct {
+	// Batch holds events in the order they were reported.
+	Batch []CollectorEvent `json:"batch"`
+	// SentAt is the time the batch was sent.
+	SentAt time.Time `json:"sentAt"`
+}
+
+// CollectorEvent is a single analytics event. All values are anonymized before they are reported.
+type CollectorEvent struct {
+	// Type is the event type.
+	Type CollectorEventType `json:"type"`
+	// MessageID uniquely identifies the event, so the collector can deduplicate retried batches.
+	MessageID string `json:"messageId"`
+	// AnonymousID identifies the cluster. It's the UID of the `kube-system` Namespace.
+	AnonymousID string `json:"anonymousId"`
+	// Timestamp is the time the event was reported.
+	Timestamp time.Time `json:"timestamp"`
+	// Event is the name of the track event. It's empty for identify events.
+	Event string `json:"event,omitempty"`
+	// Properties hold details of the track event.
+	Properties map[string]any `json:"properties,omitempty"`
+	// Traits hold details of the identified installation.
+	Traits map[string]any `json:"traits,omitempty"`
+}
@@ -29,3 +29,7 @@ func (r *SegmentReporter) ReportHeartbeatEvent() error {
 func (r *SegmentReporter) HeartbeatProperties() batched.HeartbeatProperties {
 	return r.batchedData.HeartbeatProperties()
 }
+
+func (c *CollectorClient) SetNow(now func() time.Time) {
+	c.now = now
+}
@@ -35,8 +35,17 @@ const (
 var (
 	// APIKey contains the API key for external analytics service. It is set during application build.
 	APIKey string
+
+	// ForceDisable disables analytics regardless of the configuration, if set to "true". It is set during application build,
+	// so organizations can build Botkube which never sends analytics, neither to Segment nor to a self-hosted collector.
+	ForceDisable string
 )
 
+// IsForceDisabled returns true if analytics were disabled during application build.
+func IsForceDisabled() bool {
+	return ForceDisable == "true"
+}
+
 var _ Reporter = &SegmentReporter{}
 
 type BatchedDataStore interface {
@@ -52,7 +61,8 @@ type pluginReport struct {
 	RBAC *config.PolicyRule
 }
 
-// SegmentReporter is a default Reporter implementation that uses Twilio Segment.
+// SegmentReporter is a default Reporter implementation that uses Twilio Segment messages. They are sent either to Segment,
+// or to a self-hosted collector, depending on the given client.
 type SegmentReporter struct {
 	log logrus.FieldLogger
 	cli segment.Client
@@ -95,7 +105,7 @@ func (r *SegmentReporter) RegisterCurrentIdentity(ctx context.Context, k8sCli ku
 // ReportCommand reports a new executed command. The command should be anonymized before using this method.
 // The RegisterCurrentIdentity needs to be called first.
 func (r *SegmentReporter) ReportCommand(in ReportCommandInput) error {
-	return r.reportEvent("Command executed", map[string]interface{}{
+	return r.reportEvent(CommandExecutedEventName, map[string]interface{}{
 		"platform": in.Platform,
 		"command":  in.Command,
 		"plugin":   in.PluginName,
@@ -107,7 +117,7 @@ func (r *SegmentReporter) ReportCommand(in ReportCommandInput) error {
 // ReportBotEnabled reports an enabled bot.
 // The RegisterCurrentIdentity needs to be called first.
 func (r *SegmentReporter) ReportBotEnabled(platform config.CommPlatformIntegration, commGroupIdx int) error {
-	return r.reportEvent("Integration enabled", map[string]interface{}{
+	return r.reportEvent(IntegrationEnabledEventName, map[string]interface{}{
 		"platform":             platform,
 		"type":                 config.BotIntegrationType,
 		"communicationGroupID": commGroupIdx,
@@ -127,13 +137,13 @@ func (r *SegmentReporter) ReportPluginsEnabled(executors map[string]config.Execu
 	for i, key := range sourceKeys {
 		r.generatePluginsReport(i, pluginsConfig, sources[key].Plugins, plugin.TypeSource)
 	}
-	return r.reportEvent("Plugin enabled", pluginsConfig)
+	return r.reportEvent(PluginEnabledEventName, pluginsConfig)
 }
 
 // ReportSinkEnabled reports an enabled sink.
 // The RegisterCurrentIdentity needs to be called first.
 func (r *SegmentReporter) ReportSinkEnabled(platform config.CommPlatformIntegration, commGroupIdx int) error {
-	return r.reportEvent("Integration enabled", map[string]interface{}{
+	return r.reportEvent(IntegrationEnabledEventName, map[string]interface{}{
 		"platform":             platform,
 		"type":                 config.SinkIntegrationType,
 		"communicationGroupID": commGroupIdx,
@@ -192,7 +202,7 @@ func (r *SegmentReporter) ReportFatalError(err error) error {
 		properties["unknownIdentity"] = true
 	}
 
-	eventName := "Fatal error"
+	eventName := FatalErrorEventName
 	err = r.cli.Enqueue(segment.Track{
 		AnonymousId: anonymousID,
 		Event:       eventName,
@@ -256,7 +266,7 @@ func (r *SegmentReporter) reportHeartbeatEvent() error {
 		return fmt.Errorf("while unmarshalling heartbeat properties: %w", err)
 	}
 
-	return r.reportEvent("Heartbeat", props)
+	return r.reportEvent(HeartbeatEventName, props)
 }
 
 func (r *SegmentReporter) reportEvent(event string, properties map[string]interface{}) error {
@@ -401,6 +411,14 @@ func (r *SegmentReporter) getAnonymizedRBAC(rbac *config.PolicyRule) *config.Pol
 			},
 			Prefix: r.anonymizedValue(rbac.Group.Prefix),
 		},
+		ServiceAccount: config.ServiceAccountPolicySubject{
+			Type: rbac.ServiceAccount.Type,
+			Static: config.UserStaticSubject{
+				Value: r.anonymizedValue(rbac.ServiceAccount.Static.Value),
+			},
+			Prefix:    r.anonymizedValue(rbac.ServiceAccount.Prefix),
+			Namespace: r.anonymizedValue(rbac.ServiceAccount.Namespace),
+		},
 	}
 }
 
@@ -206,6 +206,12 @@ func TestSegmentReporter_ReportPluginsEnabled(t *testing.T) {
 								},
 								Prefix: "custom-channel-prefix",
 							},
+							ServiceAccount: config.ServiceAccountPolicySubject{
+								Type:      config.ChannelNamePolicySubjectType,
+								Prefix:    "custom-sa-prefix",
+								Namespace: "botkube-sa",
+								Audiences: []string{"https://kubernetes.default.svc"},
+							},
 						},
 					},
 				},
@@ -0,0 +1,331 @@
+// Package auditlog records structured audit entries of executed commands, interactions, configuration changes,
+// and notification decisions, and writes them to pluggable backends, such as files, Elasticsearch, webhooks, or S3.
+package auditlog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/multierror"
+)
+
+const (
+	defaultFlushInterval = 5 * time.Second
+	// pruneInterval is the period of time between removing entries older than the retention period.
+	pruneInterval = time.Hour
+	// maxPendingEntries limits entries which wait to be written, so unavailable backends don't make Botkube run out of memory.
+	maxPendingEntries = 10000
+	// shutdownTimeout limits writing the last entries once the logger is stopped.
+	shutdownTimeout = 10 * time.Second
+)
+
+// ErrQueryNotSupported is returned if none of enabled backends supports querying entries.
+var ErrQueryNotSupported = errors.New("none of enabled audit log backends supports querying")
+
+// EntryType is a type of audit entry.
+type EntryType string
+
+const (
+	// CommandEntryType is a command typed by a user.
+	CommandEntryType EntryType = "command"
+	// InteractionEntryType is a command triggered by an interactive element, e.g. a button click.
+	InteractionEntryType EntryType = "interaction"
+	// ConfigChangeEntryType is an applied configuration change.
+	ConfigChangeEntryType EntryType = "configChange"
+	// NotificationEntryType is a decision whether a notification is sent.
+	NotificationEntryType EntryType = "notification"
+)
+
+// AllEntryTypes returns all entry types.
+func AllEntryTypes() []EntryType {
+	return []EntryType{CommandEntryType, InteractionEntryType, ConfigChangeEntryType, NotificationEntryType}
+}
+
+// Outcome is a result of an audited action.
+type Outcome string
+
+const (
+	// SuccessOutcome means that a command was executed, or a configuration was applied.
+	SuccessOutcome Outcome = "success"
+	// FailureOutcome means that a command failed.
+	FailureOutcome Outcome = "failure"
+	// DeniedOutcome means that a command was denied by authorization policies.
+	DeniedOutcome Outcome = "denied"
+	// SentOutcome means that a notification was sent.
+	SentOutcome Outcome = "sent"
+	// DroppedOutcome means that a notification was dropped by a processor plugin or a filter.
+	DroppedOutcome Outcome = "dropped"
+	// HeldBackOutcome means that a notification was held back during a maintenance window.
+	HeldBackOutcome Outcome = "heldBack"
+	// SuppressedOutcome means that a notification was suppressed, as the incident is acknowledged.
+	SuppressedOutcome Outcome = "suppressed"
+)
+
+// Entry is a single audit entry.
+type Entry struct {
+	Time        time.Time `json:"time"`
+	Type        EntryType `json:"type"`
+	ClusterName string    `json:"clusterName"`
+	Outcome     Outcome   `json:"outcome"`
+	// Reason describes the outcome, e.g. the error message or the filter which dropped a notification.
+	Reason   string `json:"reason,omitempty"`
+	Platform string `json:"platform,omitempty"`
+	Channel  string `json:"channel,omitempty"`
+	User     string `json:"user,omitempty"`
+	Command  string `json:"command,omitempty"`
+	Plugin   string `json:"plugin,omitempty"`
+	Source   string `json:"source,omitempty"`
+	// Details holds additional type-specific data, e.g. the configuration revision.
+	Details map[string]string `json:"details,omitempty"`
+}
+
+// ConfigLoadedEntry returns an entry of a configuration loaded on start, identified by a given resource version.
+func ConfigLoadedEntry(resourceVersion int) Entry {
+	return Entry{
+		Type:    ConfigChangeEntryType,
+		Outcome: SuccessOutcome,
+		Reason:  "configuration loaded",
+		Details: map[string]string{"resourceVersion": strconv.Itoa(resourceVersion)},
+	}
+}
+
+// ConfigRevisionEntry returns an entry of a recorded configuration revision.
+func ConfigRevisionEntry(revision int, trigger string, changes []string) Entry {
+	return Entry{
+		Type:    ConfigChangeEntryType,
+		Outcome: SuccessOutcome,
+		Reason:  trigger,
+		Details: map[string]string{
+			"revision": strconv.Itoa(revision),
+			"changes":  strings.Join(changes, "; "),
+		},
+	}
+}
+
+// Query selects entries. Empty fields match all entries.
+type Query struct {
+	Type   EntryType
+	User   string
+	Source string
+	Since  time.Time
+	// Limit is the maximum number of returned entries. Zero means no limit.
+	Limit int
+}
+
+// Matches returns true if a given entry matches the query.
+func (q Query) Matches(e Entry) bool {
+	if q.Type != "" && e.Type != q.Type {
+		return false
+	}
+	if q.User != "" && !strings.EqualFold(e.User, q.User) {
+		return false
+	}
+	if q.Source != "" && e.Source != q.Source {
+		return false
+	}
+	if !q.Since.IsZero() && e.Time.Before(q.Since) {
+		return false
+	}
+	return true
+}
+
+// Backend writes audit entries.
+type Backend interface {
+	// Name returns the backend name used in logs.
+	Name() string
+	// Write writes given entries.
+	Write(ctx context.Context, entries []Entry) error
+}
+
+// Querier is implemented by backends which support querying entries.
+type Querier interface {
+	// Query returns entries matching a given query, starting from the newest one.
+	Query(ctx context.Context, q Query) ([]Entry, error)
+}
+
+// Pruner is implemented by backends which support removing old entries.
+type Pruner interface {
+	// Prune removes entries recorded before a given time. Backends may keep older entries, e.g. until the whole day is older.
+	Prune(ctx context.Context, before time.Time) error
+}
+
+// Logger records audit entries, and writes them to backends once the flush interval elapses.
+// Recording entries never blocks, so it's safe to call it while handling events and commands.
+type Logger struct {
+	log           logrus.FieldLogger
+	enabled       bool
+	clusterName   string
+	backends      []Backend
+	flushInterval time.Duration
+	retention     time.Duration
+	now           func() time.Time
+
+	mu      sync.Mutex
+	pending []Entry
+}
+
+// New creates a new Logger instance with backends enabled in a given configuration.
+// If the audit log is disabled, the returned logger drops all entries.
+func New(log logrus.FieldLogger, cfg config.AuditLog, clusterName string) (*Logger, error) {
+	if !cfg.Enabled {
+		return newLogger(log, cfg, clusterName, nil), nil
+	}
+
+	var backends []Backend
+	if cfg.File.Enabled {
+		backends = append(backends, newFileBackend(cfg.File))
+	}
+	if cfg.Elasticsearch.Enabled {
+		backend, err := newElasticsearchBackend(cfg.Elasticsearch)
+		if err != nil {
+			return nil, fmt.Errorf("while creating Elasticsearch backend: %w", err)
+		}
+		backends = append(backends, backend)
+	}
+	if cfg.Webhook.Enabled {
+		backends = append(backends, newWebhookBackend(cfg.Webhook))
+	}
+	if cfg.S3.Enabled {
+		backend, err := newS3Backend(cfg.S3, clusterName)
+		if err != nil {
+			return nil, fmt.Errorf("while creating S3 backend: %w", err)
+		}
+		backends = append(backends, backend)
+	}
+
+	return newLogger(log, cfg, clusterName, backends), nil
+}
+
+func newLogger(log logrus.FieldLogger, cfg config.AuditLog, clusterName string, backends []Backend) *Logger {
+	flushInterval := cfg.FlushInterval
+	if flushInterval == 0 {
+		flushInterval = defaultFlushInterval
+	}
+	return &Logger{
+		log:           log,
+		enabled:       cfg.Enabled && len(backends) > 0,
+		clusterName:   clusterName,
+		backends:      backends,
+		flushInterval: flushInterval,
+		retention:     cfg.Retention,
+		now:           time.Now,
+	}
+}
+
+// Record adds a given entry to the pending ones. The time and cluster name are set if empty.
+func (l *Logger) Record(e Entry) {
+	if !l.enabled {
+		return
+	}
+	if e.Time.IsZero() {
+		e.Time = l.now()
+	}
+	e.Time = e.Time.UTC()
+	if e.ClusterName == "" {
+		e.ClusterName = l.clusterName
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.pending) >= maxPendingEntries {
+		l.log.Warnf("Dropping %s audit entry, as %d entries are waiting to be written.", e.Type, len(l.pending))
+		return
+	}
+	l.pending = append(l.pending, e)
+}
+
+// Start writes pending entries once the flush interval elapses, and removes entries older than the retention period.
+// The last entries are written when the context is cancelled.
+func (l *Logger) Start(ctx context.Context) error {
+	if !l.enabled {
+		return nil
+	}
+
+	flushTicker := time.NewTicker(l.flushInterval)
+	defer flushTicker.Stop()
+	pruneTicker := time.NewTicker(pruneInterval)
+	defer pruneTicker.Stop()
+
+	l.prune(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			flushCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+			defer cancel()
+			l.flush(flushCtx)
+			return nil
+		case <-flushTicker.C:
+			l.flush(ctx)
+		case <-pruneTicker.C:
+			l.prune(ctx)
+		}
+	}
+}
+
+// Query returns entries matching a given query from the first backend which supports querying.
+func (l *Logger) Query(ctx context.Context, q Query) ([]Entry, error) {
+	for _, backend := range l.backends {
+		querier, ok := backend.(Querier)
+		if !ok {
+			continue
+		}
+		entries, err := querier.Query(ctx, q)
+		if err != nil {
+			return nil, fmt.Errorf("while querying %s audit log: %w", backend.Name(), err)
+		}
+		return entries, nil
+	}
+	return nil, ErrQueryNotSupported
+}
+
+// Enabled returns true if entries are recorded.
+func (l *Logger) Enabled() bool {
+	return l.enabled
+}
+
+// flush writes pending entries to all backends. Entries which failed to be written to a given backend are dropped for it.
+func (l *Logger) flush(ctx context.Context) {
+	l.mu.Lock()
+	entries := l.pending
+	l.pending = nil
+	l.mu.Unlock()
+
+	if len(entries) == 0 {
+		return
+	}
+
+	errs := multierror.New()
+	for _, backend := range l.backends {
+		if err := backend.Write(ctx, entries); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("while writing %d entries to %s: %w", len(entries), backend.Name(), err))
+		}
+	}
+	if err := errs.ErrorOrNil(); err != nil {
+		l.log.WithError(err).Error("Failed to write audit entries")
+	}
+}
+
+func (l *Logger) prune(ctx context.Context) {
+	if l.retention == 0 {
+		return
+	}
+
+	before := l.now().Add(-l.retention)
+	for _, backend := range l.backends {
+		pruner, ok := backend.(Pruner)
+		if !ok {
+			continue
+		}
+		if err := pruner.Prune(ctx, before); err != nil {
+			l.log.WithError(err).Errorf("Failed to remove audit entries older than %s from %s", l.retention, backend.Name())
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package auditlog
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+func TestLoggerRecordAndFlush(t *testing.T) {
+	// given
+	backend := &fakeBackend{}
+	logger := newLogger(loggerx.NewNoop(), config.AuditLog{Enabled: true}, "dev", []Backend{backend})
+	fixedTime := time.Date(2024, 1, 31, 10, 0, 0, 0, time.UTC)
+	logger.now = func() time.Time { return fixedTime }
+
+	// when
+	logger.Record(Entry{Type: CommandEntryType, Outcome: SuccessOutcome, Command: "kubectl get pods"})
+	logger.flush(context.Background())
+
+	// then
+	require.Len(t, backend.written, 1)
+	assert.Equal(t, Entry{
+		Time:        fixedTime,
+		Type:        CommandEntryType,
+		ClusterName: "dev",
+		Outcome:     SuccessOutcome,
+		Command:     "kubectl get pods",
+	}, backend.written[0])
+
+	// when
+	logger.flush(context.Background())
+
+	// then
+	assert.Len(t, backend.written, 1)
+}
+
+func TestLoggerDisabled(t *testing.T) {
+	// given
+	backend := &fakeBackend{}
+	logger := newLogger(loggerx.NewNoop(), config.AuditLog{Enabled: false}, "dev", []Backend{backend})
+
+	// when
+	logger.Record(Entry{Type: CommandEntryType})
+	logger.flush(context.Background())
+	_, err := logger.Query(context.Background(), Query{})
+
+	// then
+	assert.False(t, logger.Enabled())
+	assert.Empty(t, backend.written)
+	assert.ErrorIs(t, err, ErrQueryNotSupported)
+}
+
+func TestLoggerFlushFailureDoesNotAffectOtherBackends(t *testing.T) {
+	// given
+	failing := &fakeBackend{err: errors.New("connection refused")}
+	working := &fakeBackend{}
+	logger := newLogger(loggerx.NewNoop(), config.AuditLog{Enabled: true}, "dev", []Backend{failing, working})
+
+	// when
+	logger.Record(Entry{Type: NotificationEntryType, Outcome: SentOutcome})
+	logger.flush(context.Background())
+
+	// then
+	assert.Len(t, working.written, 1)
+}
+
+func TestFileBackend(t *testing.T) {
+	// given
+	ctx := context.Background()
+	backend := newFileBackend(config.AuditLogFile{Directory: t.TempDir()})
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 12, 0, 0, 0, time.UTC) }
+	entries := []Entry{
+		{Time: day(1), Type: CommandEntryType, User: "alice", Command: "kubectl get pods"},
+		{Time: day(2), Type: NotificationEntryType, Source: "k8s-events", Outcome: DroppedOutcome},
+		{Time: day(3), Type: CommandEntryType, User: "Bob", Command: "helm list"},
+		{Time: day(3).Add(time.Hour), Type: CommandEntryType, User: "alice", Command: "kubectl logs"},
+	}
+
+	// when
+	require.NoError(t, backend.Write(ctx, entries))
+	all, err := backend.Query(ctx, Query{})
+	require.NoError(t, err)
+	commands, err := backend.Query(ctx, Query{Type: CommandEntryType, User: "ALICE", Limit: 1})
+	require.NoError(t, err)
+	recent, err := backend.Query(ctx, Query{Since: day(2)})
+	require.NoError(t, err)
+
+	// then
+	assert.Equal(t, []Entry{entries[3], entries[2], entries[1], entries[0]}, all)
+	assert.Equal(t, []Entry{entries[3]}, commands)
+	assert.Equal(t, []Entry{entries[3], entries[2], entries[1]}, recent)
+
+	// when
+	require.NoError(t, backend.Prune(ctx, day(3)))
+	afterPrune, err := backend.Query(ctx, Query{})
+	require.NoError(t, err)
+
+	// then
+	assert.Equal(t, []Entry{entries[3], entries[2]}, afterPrune)
+}
+
+func TestWebhookBackend(t *testing.T) {
+	// given
+	var got webhookPayload
+	var gotHeader string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHeader = r.Header.Get("Authorization")
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	backend := newWebhookBackend(config.AuditLogWebhook{
+		URL:     srv.URL,
+		Headers: map[string]string{"Authorization": "Bearer token"},
+	})
+	entries := []Entry{
+		{Time: time.Date(2024, 1, 31, 10, 0, 0, 0, time.UTC), Type: ConfigChangeEntryType, Outcome: SuccessOutcome},
+	}
+
+	// when
+	err := backend.Write(context.Background(), entries)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer token", gotHeader)
+	assert.Equal(t, entries, got.Entries)
+}
+
+func TestWebhookBackendUnexpectedStatus(t *testing.T) {
+	// given
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+	backend := newWebhookBackend(config.AuditLogWebhook{URL: srv.URL})
+
+	// when
+	err := backend.Write(context.Background(), []Entry{{Type: CommandEntryType}})
+
+	// then
+	assert.ErrorContains(t, err, "got unexpected status code 500")
+}
+
+type fakeBackend struct {
+	err     error
+	written []Entry
+}
+
+func (f *fakeBackend) Name() string {
+	return "fake"
+}
+
+func (f *fakeBackend) Write(_ context.Context, entries []Entry) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.written = append(f.written, entries...)
+	return nil
+}
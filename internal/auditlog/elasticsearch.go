@@ -0,0 +1,229 @@
+package auditlog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/olivere/elastic/v7"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/tlsx"
+)
+
+const (
+	elasticIndexDateLayout                  = "2006.01.02"
+	elasticErrorReasonResourceAlreadyExists = "resource_already_exists_exception"
+)
+
+// elasticIndexMapping makes sure that fields used in queries are not analyzed.
+const elasticIndexMapping = `{
+	"mappings": {
+		"properties": {
+			"time": {"type": "date"},
+			"type": {"type": "keyword"},
+			"clusterName": {"type": "keyword"},
+			"outcome": {"type": "keyword"},
+			"reason": {"type": "text"},
+			"platform": {"type": "keyword"},
+			"channel": {"type": "keyword"},
+			"user": {"type": "keyword"},
+			"command": {"type": "text"},
+			"plugin": {"type": "keyword"},
+			"source": {"type": "keyword"},
+			"details": {"type": "flattened"}
+		}
+	}
+}`
+
+var (
+	_ Backend = &elasticsearchBackend{}
+	_ Querier = &elasticsearchBackend{}
+	_ Pruner  = &elasticsearchBackend{}
+)
+
+// elasticsearchBackend indexes entries in Elasticsearch, one index per day, e.g. `botkube-audit-2024.01.31`.
+type elasticsearchBackend struct {
+	client      *elastic.Client
+	indexPrefix string
+
+	// createdIndices holds names of indices which are known to exist.
+	createdIndices    map[string]struct{}
+	createdIndicesMux sync.Mutex
+}
+
+func newElasticsearchBackend(cfg config.AuditLogElasticsearch) (*elasticsearchBackend, error) {
+	opts := []elastic.ClientOptionFunc{
+		elastic.SetURL(cfg.Server),
+		elastic.SetSniff(false),
+		elastic.SetHealthcheck(false),
+		elastic.SetGzip(true),
+	}
+	if cfg.APIKey != "" {
+		opts = append(opts, elastic.SetHeaders(http.Header{
+			"Authorization": []string{"ApiKey " + cfg.APIKey},
+		}))
+	} else if cfg.Username != "" {
+		opts = append(opts, elastic.SetBasicAuth(cfg.Username, cfg.Password))
+	}
+	if cfg.SkipTLSVerify {
+		tlsCfg, err := tlsx.NewClientConfig(tlsx.ClientOptions{Name: "Elasticsearch audit log", InsecureSkipVerify: true})
+		if err != nil {
+			return nil, fmt.Errorf("while creating Elasticsearch TLS configuration: %w", err)
+		}
+		tr := http.DefaultTransport.(*http.Transport).Clone()
+		tr.TLSClientConfig = tlsCfg
+		opts = append(opts, elastic.SetHttpClient(&http.Client{Transport: tr}))
+	}
+
+	client, err := elastic.NewClient(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("while creating new Elastic client: %w", err)
+	}
+
+	return &elasticsearchBackend{
+		client:         client,
+		indexPrefix:    cfg.IndexPrefix,
+		createdIndices: map[string]struct{}{},
+	}, nil
+}
+
+// Name returns the backend name used in logs.
+func (e *elasticsearchBackend) Name() string {
+	return "Elasticsearch"
+}
+
+// Write indexes entries using the bulk API.
+func (e *elasticsearchBackend) Write(ctx context.Context, entries []Entry) error {
+	bulk := e.client.Bulk()
+	for _, entry := range entries {
+		index := e.indexName(entry.Time)
+		if err := e.ensureIndex(ctx, index); err != nil {
+			return err
+		}
+		bulk.Add(elastic.NewBulkIndexRequest().Index(index).Doc(entry))
+	}
+
+	resp, err := bulk.Do(ctx)
+	if err != nil {
+		return fmt.Errorf("while indexing entries: %w", err)
+	}
+	if failed := resp.Failed(); len(failed) > 0 {
+		reason := "unknown"
+		if failed[0].Error != nil {
+			reason = failed[0].Error.Reason
+		}
+		return fmt.Errorf("while indexing entries: %d of %d failed, e.g. %s", len(failed), len(entries), reason)
+	}
+	return nil
+}
+
+// Query searches all indices with a given prefix.
+func (e *elasticsearchBackend) Query(ctx context.Context, q Query) ([]Entry, error) {
+	query := elastic.NewBoolQuery()
+	if q.Type != "" {
+		query = query.Filter(elastic.NewTermQuery("type", q.Type))
+	}
+	if q.User != "" {
+		query = query.Filter(elastic.NewTermQuery("user", q.User))
+	}
+	if q.Source != "" {
+		query = query.Filter(elastic.NewTermQuery("source", q.Source))
+	}
+	if !q.Since.IsZero() {
+		query = query.Filter(elastic.NewRangeQuery("time").Gte(q.Since))
+	}
+
+	search := e.client.Search(e.indexPrefix+"-*").
+		Query(query).
+		Sort("time", false).
+		IgnoreUnavailable(true).
+		AllowNoIndices(true)
+	if q.Limit > 0 {
+		search = search.Size(q.Limit)
+	}
+
+	resp, err := search.Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("while searching entries: %w", err)
+	}
+
+	var out []Entry
+	for _, hit := range resp.Hits.Hits {
+		var entry Entry
+		if err := json.Unmarshal(hit.Source, &entry); err != nil {
+			return nil, fmt.Errorf("while decoding entry %q: %w", hit.Id, err)
+		}
+		out = append(out, entry)
+	}
+	return out, nil
+}
+
+// Prune deletes indices of days which ended before a given time.
+func (e *elasticsearchBackend) Prune(ctx context.Context, before time.Time) error {
+	names, err := e.client.IndexNames()
+	if err != nil {
+		return fmt.Errorf("while listing indices: %w", err)
+	}
+
+	var toDelete []string
+	for _, name := range names {
+		suffix, ok := strings.CutPrefix(name, e.indexPrefix+"-")
+		if !ok {
+			continue
+		}
+		day, err := time.Parse(elasticIndexDateLayout, suffix)
+		if err != nil {
+			continue
+		}
+		if day.AddDate(0, 0, 1).Before(before) {
+			toDelete = append(toDelete, name)
+		}
+	}
+	if len(toDelete) == 0 {
+		return nil
+	}
+
+	if _, err := e.client.DeleteIndex(toDelete...).Do(ctx); err != nil {
+		return fmt.Errorf("while deleting indices %s: %w", strings.Join(toDelete, ", "), err)
+	}
+
+	e.createdIndicesMux.Lock()
+	defer e.createdIndicesMux.Unlock()
+	for _, name := range toDelete {
+		delete(e.createdIndices, name)
+	}
+	return nil
+}
+
+// ensureIndex creates an index with the mapping if it doesn't exist yet.
+func (e *elasticsearchBackend) ensureIndex(ctx context.Context, name string) error {
+	e.createdIndicesMux.Lock()
+	defer e.createdIndicesMux.Unlock()
+
+	if _, ok := e.createdIndices[name]; ok {
+		return nil
+	}
+
+	exists, err := e.client.IndexExists(name).Do(ctx)
+	if err != nil {
+		return fmt.Errorf("while checking if index %q exists: %w", name, err)
+	}
+	if !exists {
+		_, err := e.client.CreateIndex(name).BodyString(elasticIndexMapping).Do(ctx)
+		if err != nil && elastic.ErrorReason(err) != elasticErrorReasonResourceAlreadyExists {
+			return fmt.Errorf("while creating index %q: %w", name, err)
+		}
+	}
+
+	e.createdIndices[name] = struct{}{}
+	return nil
+}
+
+func (e *elasticsearchBackend) indexName(t time.Time) string {
+	return e.indexPrefix + "-" + t.UTC().Format(elasticIndexDateLayout)
+}
@@ -0,0 +1,206 @@
+package auditlog
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/multierror"
+)
+
+const (
+	fileNamePrefix = "audit-"
+	fileNameSuffix = ".jsonl"
+	fileDateLayout = "2006-01-02"
+	// fileMaxLineSize limits the size of a single entry read from a file.
+	fileMaxLineSize = 1024 * 1024
+)
+
+var (
+	_ Backend = &fileBackend{}
+	_ Querier = &fileBackend{}
+	_ Pruner  = &fileBackend{}
+)
+
+// fileBackend writes entries to JSON Lines files, one per day, e.g. `audit-2024-01-31.jsonl`.
+// Entries are appended, so files can be shipped by log collectors.
+type fileBackend struct {
+	dir string
+	mu  sync.Mutex
+}
+
+func newFileBackend(cfg config.AuditLogFile) *fileBackend {
+	return &fileBackend{dir: cfg.Directory}
+}
+
+// Name returns the backend name used in logs.
+func (f *fileBackend) Name() string {
+	return "file"
+}
+
+// Write appends entries to files of days they were recorded at.
+func (f *fileBackend) Write(_ context.Context, entries []Entry) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := os.MkdirAll(f.dir, 0o700); err != nil {
+		return fmt.Errorf("while creating directory: %w", err)
+	}
+
+	byFile := map[string][]Entry{}
+	for _, e := range entries {
+		name := f.fileName(e.Time)
+		byFile[name] = append(byFile[name], e)
+	}
+
+	errs := multierror.New()
+	for name, fileEntries := range byFile {
+		if err := f.append(name, fileEntries); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("while writing %q: %w", name, err))
+		}
+	}
+	return errs.ErrorOrNil()
+}
+
+func (f *fileBackend) append(name string, entries []Entry) (err error) {
+	file, err := os.OpenFile(filepath.Clean(name), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		deferredErr := file.Close()
+		if deferredErr != nil {
+			err = multierror.Append(err, deferredErr)
+		}
+	}()
+
+	w := bufio.NewWriter(file)
+	enc := json.NewEncoder(w)
+	for _, e := range entries {
+		if err := enc.Encode(e); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+// Query reads files starting from the newest one, until the limit is reached.
+func (f *fileBackend) Query(_ context.Context, q Query) ([]Entry, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	days, err := f.days()
+	if err != nil {
+		return nil, err
+	}
+
+	var out []Entry
+	for i := len(days) - 1; i >= 0; i-- {
+		day := days[i]
+		if !q.Since.IsZero() && day.AddDate(0, 0, 1).Before(q.Since) {
+			break
+		}
+
+		entries, err := f.read(f.fileName(day))
+		if err != nil {
+			return nil, fmt.Errorf("while reading entries from %s: %w", day.Format(fileDateLayout), err)
+		}
+		for j := len(entries) - 1; j >= 0; j-- {
+			if !q.Matches(entries[j]) {
+				continue
+			}
+			out = append(out, entries[j])
+			if q.Limit > 0 && len(out) == q.Limit {
+				return out, nil
+			}
+		}
+	}
+	return out, nil
+}
+
+func (f *fileBackend) read(name string) (_ []Entry, err error) {
+	file, err := os.Open(filepath.Clean(name))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		deferredErr := file.Close()
+		if deferredErr != nil {
+			err = multierror.Append(err, deferredErr)
+		}
+	}()
+
+	var out []Entry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), fileMaxLineSize)
+	for scanner.Scan() {
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			// a partially written line, e.g. once the disk is full, shouldn't make the whole file unreadable
+			continue
+		}
+		out = append(out, e)
+	}
+	return out, scanner.Err()
+}
+
+// Prune removes files of days which ended before a given time.
+func (f *fileBackend) Prune(_ context.Context, before time.Time) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	days, err := f.days()
+	if err != nil {
+		return err
+	}
+
+	errs := multierror.New()
+	for _, day := range days {
+		if !day.AddDate(0, 0, 1).Before(before) {
+			continue
+		}
+		if err := os.Remove(f.fileName(day)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	return errs.ErrorOrNil()
+}
+
+// days returns sorted days for which files exist.
+func (f *fileBackend) days() ([]time.Time, error) {
+	dirEntries, err := os.ReadDir(f.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("while listing files: %w", err)
+	}
+
+	var out []time.Time
+	for _, dirEntry := range dirEntries {
+		name := dirEntry.Name()
+		if dirEntry.IsDir() || !strings.HasPrefix(name, fileNamePrefix) || !strings.HasSuffix(name, fileNameSuffix) {
+			continue
+		}
+		day, err := time.Parse(fileDateLayout, strings.TrimSuffix(strings.TrimPrefix(name, fileNamePrefix), fileNameSuffix))
+		if err != nil {
+			continue
+		}
+		out = append(out, day)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Before(out[j]) })
+	return out, nil
+}
+
+func (f *fileBackend) fileName(t time.Time) string {
+	return filepath.Join(f.dir, fileNamePrefix+t.UTC().Format(fileDateLayout)+fileNameSuffix)
+}
@@ -0,0 +1,150 @@
+package auditlog
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+// s3MaxDeleteObjects is the maximum number of objects deleted in a single request.
+const s3MaxDeleteObjects = 1000
+
+var (
+	_ Backend = &s3Backend{}
+	_ Pruner  = &s3Backend{}
+)
+
+// s3Client writes, lists and deletes objects in an S3-compatible object storage.
+type s3Client interface {
+	PutObjectWithContext(ctx aws.Context, input *s3.PutObjectInput, opts ...request.Option) (*s3.PutObjectOutput, error)
+	ListObjectsV2PagesWithContext(ctx aws.Context, input *s3.ListObjectsV2Input, fn func(*s3.ListObjectsV2Output, bool) bool, opts ...request.Option) error
+	DeleteObjectsWithContext(ctx aws.Context, input *s3.DeleteObjectsInput, opts ...request.Option) (*s3.DeleteObjectsOutput, error)
+}
+
+// s3Backend writes each batch of entries as a JSON Lines object under `{prefix}/{clusterName}/{year}/{month}/{day}/`.
+type s3Backend struct {
+	client      s3Client
+	bucket      string
+	prefix      string
+	clusterName string
+}
+
+func newS3Backend(cfg config.AuditLogS3, clusterName string) (*s3Backend, error) {
+	awsCfg := aws.NewConfig().WithS3ForcePathStyle(cfg.ForcePathStyle)
+	if cfg.Region != "" {
+		awsCfg = awsCfg.WithRegion(cfg.Region)
+	}
+	if cfg.Endpoint != "" {
+		awsCfg = awsCfg.WithEndpoint(cfg.Endpoint)
+	}
+	if cfg.AccessKeyID != "" {
+		awsCfg = awsCfg.WithCredentials(credentials.NewStaticCredentials(cfg.AccessKeyID, cfg.SecretAccessKey, ""))
+	}
+	sess, err := session.NewSession(awsCfg)
+	if err != nil {
+		return nil, fmt.Errorf("while creating AWS session: %w", err)
+	}
+
+	return &s3Backend{
+		client:      s3.New(sess),
+		bucket:      cfg.Bucket,
+		prefix:      cfg.Prefix,
+		clusterName: clusterName,
+	}, nil
+}
+
+// Name returns the backend name used in logs.
+func (s *s3Backend) Name() string {
+	return "S3"
+}
+
+// Write puts all entries in a single object, which is placed under the day of the first entry.
+func (s *s3Backend) Write(ctx context.Context, entries []Entry) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, e := range entries {
+		if err := enc.Encode(e); err != nil {
+			return fmt.Errorf("while encoding entry: %w", err)
+		}
+	}
+
+	key, err := s.objectKey(entries[0].Time)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(s.bucket),
+		Key:         aws.String(key),
+		Body:        bytes.NewReader(buf.Bytes()),
+		ContentType: aws.String("application/x-ndjson"),
+	})
+	if err != nil {
+		return fmt.Errorf("while putting object %q: %w", key, err)
+	}
+	return nil
+}
+
+// Prune deletes objects of a given cluster which were last modified before a given time.
+func (s *s3Backend) Prune(ctx context.Context, before time.Time) error {
+	var toDelete []*s3.ObjectIdentifier
+	err := s.client.ListObjectsV2PagesWithContext(ctx, &s3.ListObjectsV2Input{
+		Bucket: aws.String(s.bucket),
+		Prefix: aws.String(s.clusterPrefix() + "/"),
+	}, func(page *s3.ListObjectsV2Output, _ bool) bool {
+		for _, obj := range page.Contents {
+			if obj.LastModified != nil && obj.LastModified.Before(before) {
+				toDelete = append(toDelete, &s3.ObjectIdentifier{Key: obj.Key})
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return fmt.Errorf("while listing objects: %w", err)
+	}
+
+	for len(toDelete) > 0 {
+		chunk := toDelete
+		if len(chunk) > s3MaxDeleteObjects {
+			chunk = chunk[:s3MaxDeleteObjects]
+		}
+		toDelete = toDelete[len(chunk):]
+
+		_, err := s.client.DeleteObjectsWithContext(ctx, &s3.DeleteObjectsInput{
+			Bucket: aws.String(s.bucket),
+			Delete: &s3.Delete{Objects: chunk, Quiet: aws.Bool(true)},
+		})
+		if err != nil {
+			return fmt.Errorf("while deleting %d objects: %w", len(chunk), err)
+		}
+	}
+	return nil
+}
+
+func (s *s3Backend) objectKey(t time.Time) (string, error) {
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", fmt.Errorf("while generating object name: %w", err)
+	}
+
+	t = t.UTC()
+	name := fmt.Sprintf("%s-%s.jsonl", t.Format("20060102T150405Z"), hex.EncodeToString(suffix))
+	return path.Join(s.clusterPrefix(), t.Format("2006"), t.Format("01"), t.Format("02"), name), nil
+}
+
+func (s *s3Backend) clusterPrefix() string {
+	return path.Join(s.prefix, s.clusterName)
+}
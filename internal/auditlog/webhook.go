@@ -0,0 +1,74 @@
+package auditlog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+const defaultWebhookTimeout = 30 * time.Second
+
+var _ Backend = &webhookBackend{}
+
+// webhookBackend posts batches of entries as JSON, e.g. to a SIEM ingestion endpoint.
+type webhookBackend struct {
+	url     string
+	headers map[string]string
+	client  *http.Client
+}
+
+type webhookPayload struct {
+	Entries []Entry `json:"entries"`
+}
+
+func newWebhookBackend(cfg config.AuditLogWebhook) *webhookBackend {
+	timeout := cfg.Timeout
+	if timeout == 0 {
+		timeout = defaultWebhookTimeout
+	}
+	return &webhookBackend{
+		url:     cfg.URL,
+		headers: cfg.Headers,
+		client:  &http.Client{Timeout: timeout},
+	}
+}
+
+// Name returns the backend name used in logs.
+func (w *webhookBackend) Name() string {
+	return "webhook"
+}
+
+// Write posts all entries in a single request.
+func (w *webhookBackend) Write(ctx context.Context, entries []Entry) error {
+	body, err := json.Marshal(webhookPayload{Entries: entries})
+	if err != nil {
+		return fmt.Errorf("while marshaling payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("while creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, val := range w.headers {
+		req.Header.Set(key, val)
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("while sending request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("got unexpected status code %d: %s", resp.StatusCode, string(respBody))
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package authz
+
+import "strings"
+
+// Input holds the command details evaluated by authorization policies.
+type Input struct {
+	// Command is the command without Botkube flags, such as --cluster-name or --filter.
+	Command string `json:"command"`
+	// Args is the tokenized command.
+	Args []string `json:"args"`
+	// Plugin is the name of the executor plugin handling the command. Empty for built-in commands.
+	Plugin string `json:"plugin,omitempty"`
+	// Verb is the first positional argument after the executor name, e.g. `get` for `kubectl get pods`, or the command name for built-in commands.
+	Verb string `json:"verb"`
+	// Resource is the positional argument after the verb, e.g. `pods` for `kubectl get pods`.
+	Resource string `json:"resource,omitempty"`
+	// Namespace is taken from the `-n` or `--namespace` flag.
+	Namespace string `json:"namespace,omitempty"`
+	// AllNamespaces is set if the `-A` or `--all-namespaces` flag is used.
+	AllNamespaces bool `json:"allNamespaces"`
+	// Ambiguous is set if an unknown flag precedes the verb or resource, so they may not be the ones executed.
+	// For example, `orphan` is taken as the verb of `kubectl --cascade orphan delete deploy x`. Policies should deny such commands.
+	Ambiguous   bool    `json:"ambiguous"`
+	User        User    `json:"user"`
+	Channel     Channel `json:"channel"`
+	Platform    string  `json:"platform"`
+	ClusterName string  `json:"clusterName"`
+}
+
+// User holds details about the user who executed the command.
+type User struct {
+	Mention     string `json:"mention"`
+	DisplayName string `json:"displayName"`
+	// ID and Email are empty if the communication platform doesn't provide them.
+	ID    string `json:"id,omitempty"`
+	Email string `json:"email,omitempty"`
+}
+
+// Channel holds details about the conversation where the command was executed.
+type Channel struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Alias string `json:"alias"`
+}
+
+// Decision is the authorization result.
+type Decision struct {
+	Allowed bool
+	Reason  string
+}
+
+// flagsWithValue holds the common flags that take a separate value, so the value is not treated as a positional argument.
+var flagsWithValue = map[string]struct{}{
+	"-n": {}, "--namespace": {},
+	"-o": {}, "--output": {},
+	"-l": {}, "--selector": {},
+	"-c": {}, "--container": {},
+	"-f": {}, "--filename": {},
+}
+
+// boolFlags holds the common flags that don't take a value, so the following argument is a positional one.
+var boolFlags = map[string]struct{}{
+	"-A": {}, "--all-namespaces": {},
+	"-w": {}, "--watch": {},
+	"-h": {}, "--help": {},
+	"--all": {}, "--show-labels": {}, "--no-headers": {}, "--debug": {},
+}
+
+// Args holds the details parsed from the arguments of a command.
+type Args struct {
+	Verb          string
+	Resource      string
+	Namespace     string
+	AllNamespaces bool
+	// AmbiguousVerb is set if an unknown flag precedes the verb, so the following argument may be its value.
+	// For example, kubectl executes `label` for `kubectl --field-manager get label pod foo a=b`, as `get` is the value of `--field-manager`.
+	AmbiguousVerb bool
+	// AmbiguousResource is set if an unknown flag precedes the resource.
+	AmbiguousResource bool
+}
+
+// Ambiguous returns true if the verb or resource may not be the ones executed.
+func (a Args) Ambiguous() bool {
+	return a.AmbiguousVerb || a.AmbiguousResource
+}
+
+// ParseArgs returns the verb, resource and namespace details from the arguments of a given command.
+// The first argument is skipped if skipExecutorName is set, e.g. `kubectl` for plugin commands.
+func ParseArgs(args []string, skipExecutorName bool) Args {
+	if skipExecutorName && len(args) > 0 {
+		args = args[1:]
+	}
+
+	var (
+		out        Args
+		positional []string
+	)
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--":
+			positional = append(positional, args[i+1:]...)
+			i = len(args)
+		case arg == "-A" || arg == "--all-namespaces":
+			out.AllNamespaces = true
+		case arg == "-n" || arg == "--namespace":
+			if i+1 < len(args) {
+				out.Namespace = args[i+1]
+				i++
+			}
+		case strings.HasPrefix(arg, "--namespace="):
+			out.Namespace = strings.TrimPrefix(arg, "--namespace=")
+		case strings.HasPrefix(arg, "-n") && len(arg) > 2 && !strings.HasPrefix(arg, "--"):
+			out.Namespace = strings.TrimPrefix(strings.TrimPrefix(arg, "-n"), "=")
+		case strings.HasPrefix(arg, "-"):
+			if _, found := flagsWithValue[arg]; found {
+				i++
+				continue
+			}
+			if isUnambiguousFlag(arg) {
+				continue
+			}
+			out.AmbiguousVerb = out.AmbiguousVerb || len(positional) == 0
+			out.AmbiguousResource = out.AmbiguousResource || len(positional) < 2
+		default:
+			positional = append(positional, arg)
+		}
+	}
+
+	if len(positional) > 0 {
+		out.Verb = positional[0]
+	}
+	if len(positional) > 1 {
+		out.Resource = positional[1]
+	}
+	return out
+}
+
+// isUnambiguousFlag returns true if a given flag doesn't take the following argument as its value.
+// That's the case for known boolean flags, and flags with an inline value, such as `--output=json` or `-ojson`.
+func isUnambiguousFlag(arg string) bool {
+	if _, found := boolFlags[arg]; found {
+		return true
+	}
+	if strings.Contains(arg, "=") {
+		return true
+	}
+	if !strings.HasPrefix(arg, "--") && len(arg) > 2 {
+		_, found := flagsWithValue[arg[:2]]
+		return found
+	}
+	return false
+}
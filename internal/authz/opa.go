@@ -0,0 +1,120 @@
+package authz
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+const (
+	defaultOPATimeout = 5 * time.Second
+	failedEvalReason  = "command authorization policy cannot be evaluated"
+)
+
+// OPAAuthorizer evaluates commands against Rego policies served by Open Policy Agent.
+type OPAAuthorizer struct {
+	log     logrus.FieldLogger
+	cfg     config.OPAAuthorization
+	httpCli *http.Client
+}
+
+// NewOPAAuthorizer returns a new OPAAuthorizer instance.
+func NewOPAAuthorizer(log logrus.FieldLogger, cfg config.OPAAuthorization) *OPAAuthorizer {
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultOPATimeout
+	}
+	return &OPAAuthorizer{
+		log:     log,
+		cfg:     cfg,
+		httpCli: &http.Client{Timeout: timeout},
+	}
+}
+
+type opaRequest struct {
+	Input Input `json:"input"`
+}
+
+type opaResponse struct {
+	Result json.RawMessage `json:"result"`
+}
+
+// Authorize evaluates a given command using the OPA Data API. If the policy cannot be evaluated, the command is allowed only if fail-open mode is enabled.
+func (a *OPAAuthorizer) Authorize(ctx context.Context, in Input) Decision {
+	decision, err := a.evaluate(ctx, in)
+	if err != nil {
+		a.log.WithError(err).WithField("command", in.Command).Error("Failed to evaluate command authorization policy")
+		return Decision{Allowed: a.cfg.FailOpen, Reason: failedEvalReason}
+	}
+
+	if !decision.Allowed {
+		a.log.WithFields(logrus.Fields{
+			"command": in.Command,
+			"user":    in.User.DisplayName,
+			"channel": in.Channel.Name,
+			"reason":  decision.Reason,
+		}).Info("Command denied by authorization policy")
+	}
+	return decision
+}
+
+func (a *OPAAuthorizer) evaluate(ctx context.Context, in Input) (Decision, error) {
+	body, err := json.Marshal(opaRequest{Input: in})
+	if err != nil {
+		return Decision{}, fmt.Errorf("while marshaling input: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/v1/data/%s", strings.TrimSuffix(a.cfg.URL, "/"), strings.Trim(a.cfg.Path, "/"))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return Decision{}, fmt.Errorf("while creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := a.httpCli.Do(req)
+	if err != nil {
+		return Decision{}, fmt.Errorf("while calling OPA: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return Decision{}, fmt.Errorf("got unexpected status code from OPA: %d", res.StatusCode)
+	}
+
+	var out opaResponse
+	if err := json.NewDecoder(res.Body).Decode(&out); err != nil {
+		return Decision{}, fmt.Errorf("while decoding OPA response: %w", err)
+	}
+
+	return decisionFromResult(out.Result)
+}
+
+// decisionFromResult converts the policy result. It can be either a boolean, or an object with the `allow` and `reason` fields.
+// An undefined result denies the command.
+func decisionFromResult(result json.RawMessage) (Decision, error) {
+	if len(result) == 0 {
+		return Decision{Allowed: false, Reason: "no authorization policy matched the command"}, nil
+	}
+
+	var allowed bool
+	if err := json.Unmarshal(result, &allowed); err == nil {
+		return Decision{Allowed: allowed}, nil
+	}
+
+	var obj struct {
+		Allow  bool   `json:"allow"`
+		Reason string `json:"reason"`
+	}
+	if err := json.Unmarshal(result, &obj); err != nil {
+		return Decision{}, fmt.Errorf("unsupported policy result %s: expected boolean or object with the 'allow' field", string(result))
+	}
+	return Decision{Allowed: obj.Allow, Reason: obj.Reason}, nil
+}
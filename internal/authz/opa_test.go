@@ -0,0 +1,163 @@
+package authz
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+func TestOPAAuthorizerAuthorize(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		response string
+		failOpen bool
+		expected Decision
+	}{
+		{
+			name:     "boolean result",
+			status:   http.StatusOK,
+			response: `{"result": true}`,
+			expected: Decision{Allowed: true},
+		},
+		{
+			name:     "object result with reason",
+			status:   http.StatusOK,
+			response: `{"result": {"allow": false, "reason": "deletes are not allowed in prod"}}`,
+			expected: Decision{Allowed: false, Reason: "deletes are not allowed in prod"},
+		},
+		{
+			name:     "undefined result",
+			status:   http.StatusOK,
+			response: `{}`,
+			expected: Decision{Allowed: false, Reason: "no authorization policy matched the command"},
+		},
+		{
+			name:     "server error",
+			status:   http.StatusInternalServerError,
+			expected: Decision{Allowed: false, Reason: failedEvalReason},
+		},
+		{
+			name:     "server error in fail-open mode",
+			status:   http.StatusInternalServerError,
+			failOpen: true,
+			expected: Decision{Allowed: true, Reason: failedEvalReason},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			var gotInput Input
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/v1/data/botkube/authz/allow", r.URL.Path)
+
+				var req opaRequest
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+				gotInput = req.Input
+
+				w.WriteHeader(tc.status)
+				_, _ = w.Write([]byte(tc.response))
+			}))
+			defer srv.Close()
+
+			authorizer := NewOPAAuthorizer(loggerx.NewNoop(), config.OPAAuthorization{
+				Enabled:  true,
+				URL:      srv.URL + "/",
+				Path:     "/botkube/authz/allow",
+				FailOpen: tc.failOpen,
+			})
+			in := Input{
+				Command:   "kubectl delete pod api -n prod",
+				Args:      []string{"kubectl", "delete", "pod", "api", "-n", "prod"},
+				Plugin:    "botkube/kubectl",
+				Verb:      "delete",
+				Resource:  "pod",
+				Namespace: "prod",
+				User:      User{Mention: "<@U123>", DisplayName: "alice"},
+				Channel:   Channel{ID: "C123", Name: "ops"},
+			}
+
+			// when
+			got := authorizer.Authorize(context.Background(), in)
+
+			// then
+			assert.Equal(t, tc.expected, got)
+			assert.Equal(t, in, gotInput)
+		})
+	}
+}
+
+func TestParseArgs(t *testing.T) {
+	tests := []struct {
+		name             string
+		args             []string
+		skipExecutorName bool
+		expected         Args
+	}{
+		{
+			name:             "plugin command with flags before resource",
+			args:             []string{"kubectl", "get", "-o", "wide", "pods", "--namespace=prod"},
+			skipExecutorName: true,
+			expected:         Args{Verb: "get", Resource: "pods", Namespace: "prod"},
+		},
+		{
+			name:             "short namespace flag and all namespaces",
+			args:             []string{"kubectl", "-nkube-system", "logs", "deploy/api", "-A"},
+			skipExecutorName: true,
+			expected:         Args{Verb: "logs", Resource: "deploy/api", Namespace: "kube-system", AllNamespaces: true},
+		},
+		{
+			name:     "built-in command",
+			args:     []string{"list", "executors"},
+			expected: Args{Verb: "list", Resource: "executors"},
+		},
+		{
+			name:             "inline flag values and known boolean flags",
+			args:             []string{"kubectl", "-ojson", "--request-timeout=5s", "--watch", "get", "pods", "--sort-by", ".metadata.name"},
+			skipExecutorName: true,
+			expected:         Args{Verb: "get", Resource: "pods"},
+		},
+		{
+			name:             "unknown flag before verb",
+			args:             []string{"kubectl", "--field-manager", "get", "label", "pod", "foo", "a=b"},
+			skipExecutorName: true,
+			expected:         Args{Verb: "get", Resource: "label", AmbiguousVerb: true, AmbiguousResource: true},
+		},
+		{
+			name:             "unknown flag with value before verb",
+			args:             []string{"kubectl", "--cascade", "orphan", "delete", "deploy", "x"},
+			skipExecutorName: true,
+			expected:         Args{Verb: "orphan", Resource: "delete", AmbiguousVerb: true, AmbiguousResource: true},
+		},
+		{
+			name:             "unknown flag before resource",
+			args:             []string{"kubectl", "rollout", "--context", "status", "restart", "deploy/api"},
+			skipExecutorName: true,
+			expected:         Args{Verb: "rollout", Resource: "status", AmbiguousResource: true},
+		},
+		{
+			name:             "arguments after double dash",
+			args:             []string{"kubectl", "exec", "api-0", "--", "--field-manager", "sh"},
+			skipExecutorName: true,
+			expected:         Args{Verb: "exec", Resource: "api-0"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			got := ParseArgs(tc.args, tc.skipExecutorName)
+
+			// then
+			assert.Equal(t, tc.expected, got)
+			assert.Equal(t, tc.expected.AmbiguousVerb || tc.expected.AmbiguousResource, got.Ambiguous())
+		})
+	}
+}
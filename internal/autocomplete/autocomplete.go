@@ -0,0 +1,259 @@
+package autocomplete
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/metadata"
+	"k8s.io/client-go/metadata/metadatainformer"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+const (
+	defaultMaxOptions   = 100
+	defaultResyncPeriod = 30 * time.Minute
+
+	// helmOwnerLabel and helmNameLabel are set by Helm on Secrets which store releases.
+	helmOwnerLabel = "owner"
+	helmNameLabel  = "name"
+)
+
+var (
+	namespacesGVR = schema.GroupVersionResource{Version: "v1", Resource: "namespaces"}
+	podsGVR       = schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+	secretsGVR    = schema.GroupVersionResource{Version: "v1", Resource: "secrets"}
+)
+
+// Option is a single suggestion.
+type Option struct {
+	Name  string
+	Value string
+}
+
+// Service suggests options for interactive pickers based on the command they complete. Suggestions are served
+// from informer caches of resource metadata, so they don't call the Kubernetes API server.
+type Service struct {
+	log          logrus.FieldLogger
+	cfg          config.Autocomplete
+	factory      metadatainformer.SharedInformerFactory
+	discoveryCli discovery.DiscoveryInterface
+
+	namespaces cache.SharedIndexInformer
+	pods       cache.SharedIndexInformer
+	releases   cache.SharedIndexInformer
+
+	mu    sync.RWMutex
+	kinds []string
+}
+
+// New returns a new Service instance. The cache is filled once the service is started.
+func New(log logrus.FieldLogger, cfg config.Autocomplete, restCfg *rest.Config) (*Service, error) {
+	if restCfg == nil {
+		return nil, errors.New("missing Kubernetes client configuration")
+	}
+	if len(cfg.Groups) == 0 {
+		cfg.Groups = []string{config.RBACDefaultGroup}
+	}
+	impersonated := rest.CopyConfig(restCfg)
+	impersonated.Impersonate = rest.ImpersonationConfig{UserName: config.RBACDefaultUser, Groups: cfg.Groups}
+
+	metadataCli, err := metadata.NewForConfig(impersonated)
+	if err != nil {
+		return nil, fmt.Errorf("while creating metadata client: %w", err)
+	}
+	discoveryCli, err := discovery.NewDiscoveryClientForConfig(impersonated)
+	if err != nil {
+		return nil, fmt.Errorf("while creating discovery client: %w", err)
+	}
+	return newService(log, cfg, metadataCli, discoveryCli), nil
+}
+
+func newService(log logrus.FieldLogger, cfg config.Autocomplete, metadataCli metadata.Interface, discoveryCli discovery.DiscoveryInterface) *Service {
+	if cfg.MaxOptions <= 0 {
+		cfg.MaxOptions = defaultMaxOptions
+	}
+	if cfg.ResyncPeriod <= 0 {
+		cfg.ResyncPeriod = defaultResyncPeriod
+	}
+
+	factory := metadatainformer.NewSharedInformerFactory(metadataCli, cfg.ResyncPeriod)
+
+	return &Service{
+		log:          log,
+		cfg:          cfg,
+		factory:      factory,
+		discoveryCli: discoveryCli,
+		namespaces:   factory.ForResource(namespacesGVR).Informer(),
+		pods:         factory.ForResource(podsGVR).Informer(),
+		// releases are cached by a separate informer, as the shared one doesn't support different list options per resource
+		releases: metadatainformer.NewFilteredMetadataInformer(metadataCli, secretsGVR, metav1.NamespaceAll, cfg.ResyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, func(opts *metav1.ListOptions) {
+			opts.LabelSelector = labels.SelectorFromSet(labels.Set{helmOwnerLabel: "helm"}).String()
+		}).Informer(),
+	}
+}
+
+// Start fills the cache and keeps it up to date until the context is canceled.
+func (s *Service) Start(ctx context.Context) error {
+	s.log.Info("Starting autocomplete cache...")
+	s.factory.Start(ctx.Done())
+	go s.releases.Run(ctx.Done())
+
+	s.refreshKinds()
+	s.factory.WaitForCacheSync(ctx.Done())
+	cache.WaitForCacheSync(ctx.Done(), s.releases.HasSynced)
+	s.log.Info("Autocomplete cache synced")
+
+	ticker := time.NewTicker(s.cfg.ResyncPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			s.factory.Shutdown()
+			return nil
+		case <-ticker.C:
+			s.refreshKinds()
+		}
+	}
+}
+
+// Suggest returns options which complete a given command, e.g. namespaces for `kubectl get pods -n`.
+// Options are filtered by the query typed by the user, with options starting with the query first.
+func (s *Service) Suggest(cmd, query string) []Option {
+	var names []string
+	switch cmdCtx := parseContext(cmd); cmdCtx.kind {
+	case suggestNamespaces:
+		names = namesOf(s.namespaces.GetStore().List())
+	case suggestKinds:
+		s.mu.RLock()
+		names = s.kinds
+		s.mu.RUnlock()
+	case suggestPods:
+		names = s.namespacedNames(s.pods, cmdCtx.namespace)
+	case suggestReleases:
+		names = s.releaseNames(cmdCtx.namespace)
+	default:
+		return nil
+	}
+	return filter(names, query, s.cfg.MaxOptions)
+}
+
+func (s *Service) refreshKinds() {
+	lists, err := discovery.ServerPreferredResources(s.discoveryCli)
+	if err != nil && len(lists) == 0 {
+		// partial results are returned when some API groups are unavailable, e.g. metrics
+		s.log.WithError(err).Error("Failed to refresh resource kinds")
+		return
+	}
+
+	set := map[string]struct{}{}
+	for _, list := range lists {
+		for _, res := range list.APIResources {
+			if strings.Contains(res.Name, "/") {
+				// skip subresources
+				continue
+			}
+			set[res.Name] = struct{}{}
+		}
+	}
+	kinds := make([]string, 0, len(set))
+	for name := range set {
+		kinds = append(kinds, name)
+	}
+	sort.Strings(kinds)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.kinds = kinds
+}
+
+// namespacedNames returns names of cached resources from a given namespace, or from all namespaces if it's empty.
+func (s *Service) namespacedNames(informer cache.SharedIndexInformer, namespace string) []string {
+	if namespace == "" {
+		return namesOf(informer.GetStore().List())
+	}
+	items, err := informer.GetIndexer().ByIndex(cache.NamespaceIndex, namespace)
+	if err != nil {
+		return nil
+	}
+	return namesOf(items)
+}
+
+// releaseNames returns names of Helm releases. Each release revision is stored in a separate Secret, so names are deduplicated.
+func (s *Service) releaseNames(namespace string) []string {
+	var items []interface{}
+	if namespace == "" {
+		items = s.releases.GetStore().List()
+	} else {
+		var err error
+		items, err = s.releases.GetIndexer().ByIndex(cache.NamespaceIndex, namespace)
+		if err != nil {
+			return nil
+		}
+	}
+
+	set := map[string]struct{}{}
+	for _, item := range items {
+		obj, ok := item.(metav1.Object)
+		if !ok {
+			continue
+		}
+		if name := obj.GetLabels()[helmNameLabel]; name != "" {
+			set[name] = struct{}{}
+		}
+	}
+	out := make([]string, 0, len(set))
+	for name := range set {
+		out = append(out, name)
+	}
+	return out
+}
+
+func namesOf(items []interface{}) []string {
+	out := make([]string, 0, len(items))
+	for _, item := range items {
+		if obj, ok := item.(metav1.Object); ok {
+			out = append(out, obj.GetName())
+		}
+	}
+	return out
+}
+
+// filter returns names which contain a given query, sorted with names starting with the query first.
+func filter(names []string, query string, limit int) []Option {
+	query = strings.ToLower(strings.TrimSpace(query))
+
+	var prefixed, other []string
+	for _, name := range names {
+		lower := strings.ToLower(name)
+		switch {
+		case strings.HasPrefix(lower, query):
+			prefixed = append(prefixed, name)
+		case strings.Contains(lower, query):
+			other = append(other, name)
+		}
+	}
+	sort.Strings(prefixed)
+	sort.Strings(other)
+
+	var out []Option
+	for _, name := range append(prefixed, other...) {
+		if len(out) == limit {
+			break
+		}
+		out = append(out, Option{Name: name, Value: name})
+	}
+	return out
+}
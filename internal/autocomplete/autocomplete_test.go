@@ -0,0 +1,186 @@
+package autocomplete
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	metadatafake "k8s.io/client-go/metadata/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+func TestServiceSuggest(t *testing.T) {
+	// given
+	svc := fakeService(t, config.Autocomplete{MaxOptions: 3},
+		object("Namespace", "", "default", nil),
+		object("Namespace", "", "prod", nil),
+		object("Namespace", "", "kube-system", nil),
+		object("Pod", "prod", "api-1", nil),
+		object("Pod", "prod", "api-2", nil),
+		object("Pod", "prod", "web-1", nil),
+		object("Pod", "default", "api-3", nil),
+		object("Secret", "prod", "sh.helm.release.v1.api.v1", map[string]string{"owner": "helm", "name": "api"}),
+		object("Secret", "prod", "sh.helm.release.v1.api.v2", map[string]string{"owner": "helm", "name": "api"}),
+		object("Secret", "default", "sh.helm.release.v1.web.v1", map[string]string{"owner": "helm", "name": "web"}),
+		object("Secret", "prod", "api-token", map[string]string{"name": "token"}),
+	)
+
+	tests := []struct {
+		name     string
+		cmd      string
+		query    string
+		expected []string
+	}{
+		{
+			name:     "Namespaces",
+			cmd:      "kubectl get pods -n",
+			expected: []string{"default", "kube-system", "prod"},
+		},
+		{
+			name:     "Namespaces with prefix matches first",
+			cmd:      "helm list --namespace",
+			query:    "S",
+			expected: []string{"kube-system"},
+		},
+		{
+			name:     "Resource kinds",
+			cmd:      "kubectl describe",
+			query:    "de",
+			expected: []string{"deployments", "nodes"},
+		},
+		{
+			name:     "Pods from a given namespace",
+			cmd:      "kubectl -n prod logs",
+			query:    "api",
+			expected: []string{"api-1", "api-2"},
+		},
+		{
+			name:     "Pods limited to max options",
+			cmd:      "kc get pod",
+			expected: []string{"api-1", "api-2", "api-3"},
+		},
+		{
+			name:     "Helm releases",
+			cmd:      "helm status",
+			expected: []string{"api", "web"},
+		},
+		{
+			name:     "Helm releases from a given namespace",
+			cmd:      "helm -n prod get values",
+			expected: []string{"api"},
+		},
+		{
+			name: "Unknown context",
+			cmd:  "kubectl get pods api-1",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			out := svc.Suggest(tc.cmd, tc.query)
+
+			// then
+			var names []string
+			for _, opt := range out {
+				assert.Equal(t, opt.Name, opt.Value)
+				names = append(names, opt.Name)
+			}
+			assert.Equal(t, tc.expected, names)
+		})
+	}
+}
+
+func TestParseContext(t *testing.T) {
+	tests := []struct {
+		name     string
+		cmd      string
+		expected commandContext
+	}{
+		{
+			name:     "Namespace flag",
+			cmd:      "kubectl logs -n",
+			expected: commandContext{kind: suggestNamespaces},
+		},
+		{
+			name:     "Kind after verb",
+			cmd:      "k get",
+			expected: commandContext{kind: suggestKinds},
+		},
+		{
+			name:     "Pod name with namespace",
+			cmd:      "kubectl get --namespace prod po",
+			expected: commandContext{kind: suggestPods, namespace: "prod"},
+		},
+		{
+			name:     "Helm release",
+			cmd:      "helm rollback -n=prod",
+			expected: commandContext{namespace: "prod"},
+		},
+		{
+			name:     "Helm release after command",
+			cmd:      "helm -n=prod rollback",
+			expected: commandContext{kind: suggestReleases, namespace: "prod"},
+		},
+		{
+			name: "Other command",
+			cmd:  "ping",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			out := parseContext(tc.cmd)
+
+			// then
+			assert.Equal(t, tc.expected, out)
+		})
+	}
+}
+
+func fakeService(t *testing.T, cfg config.Autocomplete, objects ...runtime.Object) *Service {
+	t.Helper()
+
+	scheme := metadatafake.NewTestScheme()
+	require.NoError(t, metav1.AddMetaToScheme(scheme))
+	metadataCli := metadatafake.NewSimpleMetadataClient(scheme, objects...)
+	discoveryCli := &fakediscovery.FakeDiscovery{Fake: &k8stesting.Fake{
+		Resources: []*metav1.APIResourceList{
+			{
+				GroupVersion: "v1",
+				APIResources: []metav1.APIResource{{Name: "pods"}, {Name: "pods/log"}, {Name: "nodes"}},
+			},
+			{
+				GroupVersion: "apps/v1",
+				APIResources: []metav1.APIResource{{Name: "deployments"}},
+			},
+		},
+	}}
+
+	svc := newService(loggerx.NewNoop(), cfg, metadataCli, discoveryCli)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go func() {
+		assert.NoError(t, svc.Start(ctx))
+	}()
+	require.Eventually(t, func() bool {
+		svc.mu.RLock()
+		defer svc.mu.RUnlock()
+		return svc.namespaces.HasSynced() && svc.pods.HasSynced() && svc.releases.HasSynced() && len(svc.kinds) > 0
+	}, 5*time.Second, 10*time.Millisecond)
+	return svc
+}
+
+func object(kind, namespace, name string, labels map[string]string) *metav1.PartialObjectMetadata {
+	return &metav1.PartialObjectMetadata{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: kind},
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: labels},
+	}
+}
@@ -0,0 +1,83 @@
+package autocomplete
+
+import (
+	"strings"
+)
+
+type suggestionKind int
+
+const (
+	suggestNothing suggestionKind = iota
+	suggestNamespaces
+	suggestKinds
+	suggestPods
+	suggestReleases
+)
+
+var (
+	kubectlNames = map[string]struct{}{"kubectl": {}, "kc": {}, "k": {}}
+	// kubectlKindVerbs are kubectl commands followed by a resource kind.
+	kubectlKindVerbs = map[string]struct{}{"get": {}, "describe": {}, "delete": {}, "edit": {}, "explain": {}, "label": {}, "annotate": {}}
+	// kubectlPodArgs are tokens followed by a Pod name, such as the Pod kind or commands which take Pods only.
+	kubectlPodArgs = map[string]struct{}{"pod": {}, "pods": {}, "po": {}, "logs": {}, "exec": {}, "attach": {}, "port-forward": {}}
+	// helmReleaseCommands are helm commands followed by a release name.
+	helmReleaseCommands = map[string]struct{}{"status": {}, "history": {}, "hist": {}, "rollback": {}, "uninstall": {}, "upgrade": {}, "test": {}}
+)
+
+// commandContext describes what a given command expects next.
+type commandContext struct {
+	kind suggestionKind
+	// namespace is given with the `-n` or `--namespace` flag. It's empty if the command is not scoped to a namespace.
+	namespace string
+}
+
+// parseContext returns what should be suggested after a given command, based on its last token. For example,
+// `kubectl get pods -n` expects a namespace, while `kubectl -n prod logs` expects a Pod name from the `prod` namespace.
+func parseContext(cmd string) commandContext {
+	tokens := strings.Fields(cmd)
+	if len(tokens) == 0 {
+		return commandContext{}
+	}
+
+	out := commandContext{namespace: namespaceFlag(tokens)}
+	last := strings.ToLower(tokens[len(tokens)-1])
+	if last == "-n" || last == "--namespace" {
+		out.kind = suggestNamespaces
+		return out
+	}
+
+	root := strings.ToLower(tokens[0])
+	switch {
+	case isOneOf(root, kubectlNames) && len(tokens) > 1:
+		if isOneOf(last, kubectlKindVerbs) {
+			out.kind = suggestKinds
+		} else if isOneOf(last, kubectlPodArgs) {
+			out.kind = suggestPods
+		}
+	case root == "helm" && len(tokens) > 1:
+		if isOneOf(last, helmReleaseCommands) || strings.ToLower(tokens[len(tokens)-2]) == "get" {
+			out.kind = suggestReleases
+		}
+	}
+	return out
+}
+
+// namespaceFlag returns the namespace given with the `-n` or `--namespace` flag.
+func namespaceFlag(tokens []string) string {
+	for i, token := range tokens {
+		switch {
+		case (token == "-n" || token == "--namespace") && i+1 < len(tokens):
+			return tokens[i+1]
+		case strings.HasPrefix(token, "--namespace="):
+			return strings.TrimPrefix(token, "--namespace=")
+		case strings.HasPrefix(token, "-n="):
+			return strings.TrimPrefix(token, "-n=")
+		}
+	}
+	return ""
+}
+
+func isOneOf(token string, set map[string]struct{}) bool {
+	_, found := set[token]
+	return found
+}
@@ -0,0 +1,201 @@
+package scaffold
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/format"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+//go:embed all:templates
+var templates embed.FS
+
+const (
+	templatesDir     = "templates"
+	commonDir        = "common"
+	templateExt      = ".tpl"
+	namePlaceholder  = "__name__"
+	defaultGoVersion = "1.21"
+	dirPerm          = 0o755
+	filePerm         = 0o644
+)
+
+// PluginType is a type of scaffolded plugin.
+type PluginType string
+
+const (
+	// ExecutorPluginType scaffolds an executor plugin.
+	ExecutorPluginType PluginType = "executor"
+	// SourcePluginType scaffolds a source plugin.
+	SourcePluginType PluginType = "source"
+)
+
+var pluginNameRegex = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+// Options holds options for scaffolding a plugin project.
+type Options struct {
+	// Name is the plugin name. It's used as the binary and command name.
+	Name string
+	// Type is the plugin type.
+	Type PluginType
+	// Module is the Go module path of the project.
+	Module string
+	// Description is the plugin description returned in its metadata.
+	Description string
+	// BotkubeVersion is the version of the Botkube module the project depends on. It's skipped if it's not a semantic version.
+	BotkubeVersion string
+	// OutputDir is the directory the project is generated in. It must be empty or not exist.
+	OutputDir string
+}
+
+// Validate validates the options.
+func (o Options) Validate() error {
+	var issues []string
+	if !pluginNameRegex.MatchString(o.Name) {
+		issues = append(issues, fmt.Sprintf("name %q must start with a lowercase letter and contain only lowercase letters, digits and dashes", o.Name))
+	}
+	switch o.Type {
+	case ExecutorPluginType, SourcePluginType:
+	default:
+		issues = append(issues, fmt.Sprintf("type %q is not supported, use %s or %s", o.Type, ExecutorPluginType, SourcePluginType))
+	}
+	if o.Module == "" {
+		issues = append(issues, "module cannot be empty")
+	}
+	if o.OutputDir == "" {
+		issues = append(issues, "output directory cannot be empty")
+	}
+	if len(issues) > 0 {
+		return errors.New(strings.Join(issues, "; "))
+	}
+	return nil
+}
+
+type renderData struct {
+	Options
+	StructName string
+	GoVersion  string
+}
+
+// Generate generates a plugin project in the output directory and returns paths of generated files, relative to that directory.
+func Generate(opts Options) ([]string, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	if err := ensureEmptyDir(opts.OutputDir); err != nil {
+		return nil, err
+	}
+
+	if opts.Description == "" {
+		opts.Description = fmt.Sprintf("%s is a Botkube %s plugin.", opts.Name, opts.Type)
+	}
+	if ver, err := semver.StrictNewVersion(strings.TrimPrefix(opts.BotkubeVersion, "v")); err == nil {
+		opts.BotkubeVersion = "v" + ver.String()
+	} else {
+		opts.BotkubeVersion = ""
+	}
+	data := renderData{
+		Options:    opts,
+		StructName: structName(opts.Name),
+		GoVersion:  defaultGoVersion,
+	}
+
+	var generated []string
+	for _, dir := range []string{commonDir, string(opts.Type)} {
+		root := path.Join(templatesDir, dir)
+		err := fs.WalkDir(templates, root, func(tplPath string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+
+			relPath := strings.TrimSuffix(strings.TrimPrefix(tplPath, root+"/"), templateExt)
+			relPath = strings.ReplaceAll(relPath, namePlaceholder, opts.Name)
+
+			out, err := render(tplPath, data)
+			if err != nil {
+				return fmt.Errorf("while rendering %s: %w", relPath, err)
+			}
+
+			dst := filepath.Join(opts.OutputDir, filepath.FromSlash(relPath))
+			if err := os.MkdirAll(filepath.Dir(dst), dirPerm); err != nil {
+				return fmt.Errorf("while creating directory for %s: %w", relPath, err)
+			}
+			if err := os.WriteFile(dst, out, filePerm); err != nil {
+				return fmt.Errorf("while writing %s: %w", relPath, err)
+			}
+			generated = append(generated, relPath)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return generated, nil
+}
+
+func render(tplPath string, data renderData) ([]byte, error) {
+	raw, err := templates.ReadFile(tplPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// custom delimiters don't clash with GoReleaser templates
+	tpl, err := template.New(tplPath).Delims("[[", "]]").Funcs(template.FuncMap{
+		"goString": strconv.Quote,
+		"jsonString": func(in string) (string, error) {
+			out, err := json.Marshal(in)
+			return string(out), err
+		},
+	}).Parse(string(raw))
+	if err != nil {
+		return nil, err
+	}
+
+	var buff bytes.Buffer
+	if err := tpl.Execute(&buff, data); err != nil {
+		return nil, err
+	}
+
+	if !strings.HasSuffix(strings.TrimSuffix(tplPath, templateExt), ".go") {
+		return buff.Bytes(), nil
+	}
+	return format.Source(buff.Bytes())
+}
+
+func ensureEmptyDir(dir string) error {
+	entries, err := os.ReadDir(dir)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return nil
+	case err != nil:
+		return fmt.Errorf("while reading output directory: %w", err)
+	case len(entries) > 0:
+		return fmt.Errorf("output directory %q is not empty", dir)
+	}
+	return nil
+}
+
+// structName converts a dashed plugin name into a Go identifier, e.g. "my-plugin" into "MyPlugin".
+func structName(name string) string {
+	var out strings.Builder
+	for _, part := range strings.Split(name, "-") {
+		if part == "" {
+			continue
+		}
+		out.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return out.String()
+}
@@ -0,0 +1,115 @@
+package scaffold
+
+import (
+	"encoding/json"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	for _, pluginType := range []PluginType{ExecutorPluginType, SourcePluginType} {
+		t.Run(string(pluginType), func(t *testing.T) {
+			// given
+			dir := filepath.Join(t.TempDir(), "my-plugin")
+			opts := Options{
+				Name:           "my-plugin",
+				Type:           pluginType,
+				Module:         "github.com/acme/my-plugin",
+				Description:    `My "quoted" plugin.`,
+				BotkubeVersion: "1.10.0",
+				OutputDir:      dir,
+			}
+
+			// when
+			files, err := Generate(opts)
+
+			// then
+			require.NoError(t, err)
+			assert.ElementsMatch(t, []string{
+				".gitignore",
+				".goreleaser.yaml",
+				"Makefile",
+				"README.md",
+				"go.mod",
+				"hack/gen-plugin-index.go",
+				"test/e2e/harness_test.go",
+				"test/e2e/plugin_test.go",
+				"cmd/my-plugin/main.go",
+				"cmd/my-plugin/config_schema.json",
+			}, files)
+
+			for _, file := range files {
+				raw, err := os.ReadFile(filepath.Join(dir, file))
+				require.NoError(t, err)
+				assert.NotContains(t, string(raw), "[[", "file %s has unrendered template actions", file)
+
+				switch filepath.Ext(file) {
+				case ".go":
+					_, err := parser.ParseFile(token.NewFileSet(), file, raw, parser.AllErrors)
+					assert.NoError(t, err, "file %s is not valid Go source", file)
+				case ".json":
+					var schema map[string]any
+					require.NoError(t, json.Unmarshal(raw, &schema), "file %s is not valid JSON", file)
+					assert.Equal(t, opts.Description, schema["description"])
+				}
+			}
+
+			goMod, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+			require.NoError(t, err)
+			assert.Contains(t, string(goMod), "module github.com/acme/my-plugin")
+			assert.Contains(t, string(goMod), "require github.com/kubeshop/botkube v1.10.0")
+
+			goreleaser, err := os.ReadFile(filepath.Join(dir, ".goreleaser.yaml"))
+			require.NoError(t, err)
+			assert.Contains(t, string(goreleaser), "binary: "+string(pluginType)+"_my-plugin_{{ .Os }}_{{ .Arch }}")
+
+			main, err := os.ReadFile(filepath.Join(dir, "cmd", "my-plugin", "main.go"))
+			require.NoError(t, err)
+			assert.Contains(t, string(main), "MyPlugin")
+		})
+	}
+}
+
+func TestGenerateFailures(t *testing.T) {
+	// given
+	nonEmptyDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(nonEmptyDir, "main.go"), nil, filePerm))
+
+	tests := []struct {
+		name        string
+		opts        Options
+		expErrorMsg string
+	}{
+		{
+			name:        "invalid options",
+			opts:        Options{Name: "My_Plugin", Type: "processor", Module: "acme", OutputDir: t.TempDir()},
+			expErrorMsg: `name "My_Plugin" must start with a lowercase letter and contain only lowercase letters, digits and dashes; type "processor" is not supported, use executor or source`,
+		},
+		{
+			name:        "non-empty output directory",
+			opts:        Options{Name: "my-plugin", Type: ExecutorPluginType, Module: "acme", OutputDir: nonEmptyDir},
+			expErrorMsg: `output directory "` + nonEmptyDir + `" is not empty`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			_, err := Generate(tc.opts)
+
+			// then
+			assert.EqualError(t, err, tc.expErrorMsg)
+		})
+	}
+}
+
+func TestStructName(t *testing.T) {
+	assert.Equal(t, "MyCoolPlugin", structName("my-cool-plugin"))
+	assert.Equal(t, "Echo", structName("echo"))
+	assert.Equal(t, "AB", structName("a--b"))
+}
@@ -0,0 +1,3 @@
+/bin
+/plugin-dist
+/plugins-index.yaml
@@ -0,0 +1,33 @@
+dist: plugin-dist
+
+before:
+  hooks:
+    - go mod download
+
+builds:
+  - id: [[ .Name ]]
+    main: cmd/[[ .Name ]]/main.go
+    binary: [[ .Type ]]_[[ .Name ]]_{{ .Os }}_{{ .Arch }}
+    ldflags:
+      - -s -w -X main.version={{ .Version }}
+
+    no_unique_dist_dir: true
+    env:
+      - CGO_ENABLED=0
+    goos:
+      - linux
+      - darwin
+    goarch:
+      - amd64
+      - arm64
+
+archives:
+  - builds:
+      - [[ .Name ]]
+    id: [[ .Name ]]
+    files:
+      - none*
+    name_template: "{{ .Binary }}"
+
+snapshot:
+  name_template: 'v{{ .Version }}'
@@ -0,0 +1,26 @@
+.DEFAULT_GOAL := build
+.PHONY: build build-single gen-index serve test test-e2e
+
+# Build the plugin binaries for all supported platforms.
+build:
+	goreleaser build -f .goreleaser.yaml --clean --snapshot
+
+# Build the plugin binary only for current GOOS and GOARCH.
+build-single:
+	goreleaser build -f .goreleaser.yaml --clean --snapshot --single-target
+
+# Generate the plugin index, which is consumed by Botkube. Set PLUGIN_DOWNLOAD_URL_BASE_PATH to the URL the binaries are served from.
+gen-index: build
+	go run ./hack/gen-plugin-index.go -binaries-path ./plugin-dist -output-path ./plugins-index.yaml
+
+# Serve the plugin index and binaries locally, e.g. for a Botkube installation on a local cluster.
+serve: gen-index
+	cd ./plugin-dist && cp ../plugins-index.yaml . && python3 -m http.server 8080
+
+# Run unit tests.
+test:
+	go test ./cmd/...
+
+# Run e2e tests, which build the plugin binary and call it the same way Botkube does.
+test-e2e:
+	go test -tags integration -count=1 ./test/e2e/...
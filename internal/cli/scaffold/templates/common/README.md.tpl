@@ -0,0 +1,62 @@
+# [[ .Name ]]
+
+[[ .Description ]]
+
+This project was generated with `botkube plugin init`. It contains a Botkube [[ .Type ]] plugin with:
+
+- `cmd/[[ .Name ]]`: the plugin source code and its configuration JSON schema,
+- `.goreleaser.yaml`: the GoReleaser configuration which builds the plugin binaries,
+- `hack/gen-plugin-index.go`: the generator of the plugin index, which Botkube downloads plugins from,
+- `test/e2e`: e2e tests, which start the plugin binary the same way Botkube does.
+
+## Development
+
+Download the dependencies:
+
+```bash
+go mod tidy
+```
+
+Run the e2e tests:
+
+```bash
+make test-e2e
+```
+
+Build the binaries and generate the plugin index:
+
+```bash
+PLUGIN_DOWNLOAD_URL_BASE_PATH="http://localhost:8080" make gen-index
+```
+
+To serve the index and binaries locally, run `make serve`. Next, add the repository to the Botkube configuration:
+
+```yaml
+plugins:
+  repositories:
+    [[ .Name ]]-repo:
+      url: http://localhost:8080/plugins-index.yaml
+```
+
+and enable the plugin:
+
+[[ if eq .Type "executor" -]]
+```yaml
+executors:
+  [[ .Name ]]:
+    [[ .Name ]]-repo/[[ .Name ]]:
+      enabled: true
+      config:
+        greeting: "Hello"
+```
+[[- else -]]
+```yaml
+sources:
+  [[ .Name ]]:
+    [[ .Name ]]-repo/[[ .Name ]]:
+      enabled: true
+      config:
+        message: "Hello"
+        interval: 1m
+```
+[[- end ]]
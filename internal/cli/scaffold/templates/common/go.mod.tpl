@@ -0,0 +1,7 @@
+module [[ .Module ]]
+
+go [[ .GoVersion ]]
+[[- if .BotkubeVersion ]]
+
+require github.com/kubeshop/botkube [[ .BotkubeVersion ]]
+[[- end ]]
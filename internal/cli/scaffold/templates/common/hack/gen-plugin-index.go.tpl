@@ -0,0 +1,39 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+
+	"github.com/kubeshop/botkube/pkg/loggerx"
+	"github.com/kubeshop/botkube/pkg/plugin"
+)
+
+const filePerm = 0o644
+
+func main() {
+	var (
+		urlBasePath = flag.String("url-base-path", os.Getenv("PLUGIN_DOWNLOAD_URL_BASE_PATH"), "Defines the URL base path for downloading the plugin binaries")
+		binsDir     = flag.String("binaries-path", "./plugin-dist", "Defines the local path to plugins binaries folder")
+		output      = flag.String("output-path", "./plugins-index.yaml", "Defines the local path where index YAML should be saved")
+	)
+	flag.Parse()
+
+	logger := logrus.New()
+
+	absBinsDir, err := filepath.Abs(*binsDir)
+	loggerx.ExitOnError(err, "while resolving an absolute path of binaries folder")
+
+	idx, err := plugin.NewIndexBuilder(logger).Build(absBinsDir, *urlBasePath, "", false, true)
+	loggerx.ExitOnError(err, "while building plugin index")
+
+	raw, err := yaml.Marshal(idx)
+	loggerx.ExitOnError(err, "while marshaling index into YAML format")
+
+	logger.WithField("output", *output).Info("Saving index file...")
+	err = os.WriteFile(*output, raw, filePerm)
+	loggerx.ExitOnError(err, "while saving index file")
+}
@@ -0,0 +1,48 @@
+//go:build integration
+
+package e2e
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	goplugin "github.com/hashicorp/go-plugin"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/api/[[ .Type ]]"
+)
+
+// startPlugin builds the plugin binary and starts it the same way Botkube does. The plugin is killed when the test finishes.
+func startPlugin(t *testing.T) any {
+	t.Helper()
+
+	bin := filepath.Join(t.TempDir(), "[[ .Type ]]_[[ .Name ]]")
+	build := exec.Command("go", "build", "-o", bin, "../../cmd/[[ .Name ]]")
+	build.Stdout, build.Stderr = os.Stdout, os.Stderr
+	require.NoError(t, build.Run(), "while building plugin binary")
+
+	cli := goplugin.NewClient(&goplugin.ClientConfig{
+		Plugins: map[string]goplugin.Plugin{
+			"[[ .Type ]]": &[[ .Type ]].Plugin{},
+		},
+		//nolint:gosec // the binary is built by the test itself
+		Cmd:              exec.Command(bin),
+		AllowedProtocols: []goplugin.Protocol{goplugin.ProtocolGRPC},
+		HandshakeConfig: goplugin.HandshakeConfig{
+			ProtocolVersion:  [[ .Type ]].ProtocolVersion,
+			MagicCookieKey:   api.HandshakeConfig.MagicCookieKey,
+			MagicCookieValue: api.HandshakeConfig.MagicCookieValue,
+		},
+	})
+	t.Cleanup(cli.Kill)
+
+	rpcClient, err := cli.Client()
+	require.NoError(t, err, "while starting plugin")
+
+	raw, err := rpcClient.Dispense("[[ .Type ]]")
+	require.NoError(t, err, "while dispensing plugin")
+	return raw
+}
@@ -0,0 +1,14 @@
+{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "title": "[[ .Name ]]",
+  "description": [[ jsonString .Description ]],
+  "type": "object",
+  "properties": {
+    "greeting": {
+      "description": "Greeting the response starts with",
+      "type": "string",
+      "default": "Hello"
+    }
+  },
+  "required": []
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"strings"
+
+	goplugin "github.com/hashicorp/go-plugin"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/api/executor"
+	"github.com/kubeshop/botkube/pkg/plugin"
+)
+
+var (
+	// version is set via ldflags by GoReleaser.
+	version = "dev"
+
+	//go:embed config_schema.json
+	configJSONSchema string
+)
+
+const (
+	pluginName  = "[[ .Name ]]"
+	description = [[ goString .Description ]]
+)
+
+// Config holds executor configuration.
+type Config struct {
+	Greeting string `yaml:"greeting,omitempty"`
+}
+
+var defaultConfig = Config{
+	Greeting: "Hello",
+}
+
+// [[ .StructName ]]Executor implements Botkube executor plugin.
+type [[ .StructName ]]Executor struct{}
+
+var _ executor.Executor = &[[ .StructName ]]Executor{}
+
+// Metadata returns details about the plugin.
+func (*[[ .StructName ]]Executor) Metadata(context.Context) (api.MetadataOutput, error) {
+	return api.MetadataOutput{
+		Version:     version,
+		Description: description,
+		JSONSchema: api.JSONSchema{
+			Value: configJSONSchema,
+		},
+	}, nil
+}
+
+// Execute returns a greeting with the arguments of a given command.
+func (*[[ .StructName ]]Executor) Execute(_ context.Context, in executor.ExecuteInput) (executor.ExecuteOutput, error) {
+	var cfg Config
+	err := plugin.MergeExecutorConfigsWithDefaults(defaultConfig, in.Configs, &cfg)
+	if err != nil {
+		return executor.ExecuteOutput{}, fmt.Errorf("while merging input configuration: %w", err)
+	}
+
+	args := strings.TrimSpace(strings.TrimPrefix(in.Command, pluginName))
+	return executor.ExecuteOutput{
+		Message: api.NewCodeBlockMessage(fmt.Sprintf("%s! You said: %q", cfg.Greeting, args), true),
+	}, nil
+}
+
+// Help returns help message.
+func (*[[ .StructName ]]Executor) Help(context.Context) (api.Message, error) {
+	return api.NewPlaintextMessage(fmt.Sprintf("Usage: @Botkube %s <text>", pluginName), false), nil
+}
+
+func main() {
+	executor.Serve(map[string]goplugin.Plugin{
+		pluginName: &executor.Plugin{
+			Executor: &[[ .StructName ]]Executor{},
+		},
+	})
+}
@@ -0,0 +1,38 @@
+//go:build integration
+
+package e2e
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/api/executor"
+)
+
+func TestExecutor(t *testing.T) {
+	// given
+	ctx := context.Background()
+	cli := startPlugin(t).(executor.Executor)
+
+	// when
+	meta, err := cli.Metadata(ctx)
+
+	// then
+	require.NoError(t, err)
+	assert.NotEmpty(t, meta.JSONSchema.Value)
+
+	// when
+	out, err := cli.Execute(ctx, executor.ExecuteInput{
+		Command: "[[ .Name ]] world",
+		Configs: []*executor.Config{
+			{RawYAML: []byte("greeting: Hi")},
+		},
+	})
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, `Hi! You said: "world"`, out.Message.BaseBody.CodeBlock)
+}
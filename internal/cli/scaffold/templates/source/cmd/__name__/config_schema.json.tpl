@@ -0,0 +1,19 @@
+{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "title": "[[ .Name ]]",
+  "description": [[ jsonString .Description ]],
+  "type": "object",
+  "properties": {
+    "message": {
+      "description": "Message sent on each tick",
+      "type": "string",
+      "default": "Hello from [[ .Name ]]"
+    },
+    "interval": {
+      "description": "Interval between messages, e.g. 30s or 5m",
+      "type": "string",
+      "default": "1m"
+    }
+  },
+  "required": []
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"time"
+
+	goplugin "github.com/hashicorp/go-plugin"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/api/source"
+	"github.com/kubeshop/botkube/pkg/plugin"
+)
+
+var (
+	// version is set via ldflags by GoReleaser.
+	version = "dev"
+
+	//go:embed config_schema.json
+	configJSONSchema string
+)
+
+const (
+	pluginName  = "[[ .Name ]]"
+	description = [[ goString .Description ]]
+)
+
+// Config holds source configuration.
+type Config struct {
+	Message  string        `yaml:"message,omitempty"`
+	Interval time.Duration `yaml:"interval,omitempty"`
+}
+
+var defaultConfig = Config{
+	Message:  "Hello from [[ .Name ]]",
+	Interval: time.Minute,
+}
+
+// [[ .StructName ]]Source implements Botkube source plugin.
+type [[ .StructName ]]Source struct {
+	source.HandleExternalRequestUnimplemented
+}
+
+var _ source.Source = &[[ .StructName ]]Source{}
+
+// Metadata returns details about the plugin.
+func (*[[ .StructName ]]Source) Metadata(context.Context) (api.MetadataOutput, error) {
+	return api.MetadataOutput{
+		Version:     version,
+		Description: description,
+		JSONSchema: api.JSONSchema{
+			Value: configJSONSchema,
+		},
+	}, nil
+}
+
+// Stream sends a configured message in a given interval, until the context is cancelled.
+func (*[[ .StructName ]]Source) Stream(ctx context.Context, in source.StreamInput) (source.StreamOutput, error) {
+	var cfg Config
+	err := plugin.MergeSourceConfigsWithDefaults(defaultConfig, in.Configs, &cfg)
+	if err != nil {
+		return source.StreamOutput{}, fmt.Errorf("while merging input configuration: %w", err)
+	}
+	if cfg.Interval <= 0 {
+		return source.StreamOutput{}, fmt.Errorf("interval must be positive, got %s", cfg.Interval)
+	}
+
+	out := source.StreamOutput{
+		Event: make(chan source.Event),
+	}
+
+	go func() {
+		ticker := time.NewTicker(cfg.Interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				select {
+				case out.Event <- source.Event{Message: api.NewPlaintextMessage(cfg.Message, false)}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+func main() {
+	source.Serve(map[string]goplugin.Plugin{
+		pluginName: &source.Plugin{
+			Source: &[[ .StructName ]]Source{},
+		},
+	})
+}
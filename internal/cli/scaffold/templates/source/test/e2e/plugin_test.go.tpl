@@ -0,0 +1,47 @@
+//go:build integration
+
+package e2e
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/api/source"
+)
+
+func TestSource(t *testing.T) {
+	// given
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	cli := startPlugin(t).(source.Source)
+
+	// when
+	meta, err := cli.Metadata(ctx)
+
+	// then
+	require.NoError(t, err)
+	assert.NotEmpty(t, meta.JSONSchema.Value)
+
+	// when
+	out, err := cli.Stream(ctx, source.StreamInput{
+		Configs: []*source.Config{
+			{RawYAML: []byte("message: Hi\ninterval: 100ms")},
+		},
+	})
+
+	// then
+	require.NoError(t, err)
+	select {
+	case event := <-out.Event:
+		assert.Equal(t, "Hi", event.Message.BaseBody.Plaintext)
+		if out.Ack != nil {
+			out.Ack()
+		}
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for an event")
+	}
+}
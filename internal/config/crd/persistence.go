@@ -0,0 +1,163 @@
+package crd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+var _ config.PersistenceManager = &PersistenceManager{}
+
+// PersistenceManager persists changes of channels defined in BotkubeConfig resources in these resources, as they
+// override the Botkube configuration. Changes of other channels are persisted by the base manager.
+type PersistenceManager struct {
+	config.PersistenceManager
+	cli dynamic.Interface
+}
+
+// NewPersistenceManager returns a new PersistenceManager instance.
+func NewPersistenceManager(base config.PersistenceManager, cli dynamic.Interface) *PersistenceManager {
+	return &PersistenceManager{PersistenceManager: base, cli: cli}
+}
+
+// PersistSourceBindings persists source bindings for a given channel.
+func (m *PersistenceManager) PersistSourceBindings(ctx context.Context, commGroupName string, platform config.CommPlatformIntegration, channelAlias string, sourceBindings []string) error {
+	ref, ok := parseChannelAlias(channelAlias)
+	if !ok {
+		return m.PersistenceManager.PersistSourceBindings(ctx, commGroupName, platform, channelAlias, sourceBindings)
+	}
+
+	return m.updateChannel(ctx, ref, func(channel map[string]any) error {
+		// sources defined in the same resource are referenced without the namespace prefix
+		sources := make([]string, 0, len(sourceBindings))
+		for _, name := range sourceBindings {
+			sources = append(sources, strings.TrimPrefix(name, ref.namespace+"/"))
+		}
+		return unstructured.SetNestedStringSlice(channel, sources, "bindings", "sources")
+	})
+}
+
+// PersistNotificationsEnabled persists notifications state for a given channel.
+func (m *PersistenceManager) PersistNotificationsEnabled(ctx context.Context, commGroupName string, platform config.CommPlatformIntegration, channelAlias string, enabled bool) error {
+	ref, ok := parseChannelAlias(channelAlias)
+	if !ok {
+		return m.PersistenceManager.PersistNotificationsEnabled(ctx, commGroupName, platform, channelAlias, enabled)
+	}
+
+	return m.updateChannel(ctx, ref, func(channel map[string]any) error {
+		return unstructured.SetNestedField(channel, !enabled, "notification", "disabled")
+	})
+}
+
+// PersistNotificationSettings persists notification settings for a given channel. Empty settings are removed.
+func (m *PersistenceManager) PersistNotificationSettings(ctx context.Context, commGroupName string, platform config.CommPlatformIntegration, channelAlias string, settings config.NotificationSettings) error {
+	ref, ok := parseChannelAlias(channelAlias)
+	if !ok {
+		return m.PersistenceManager.PersistNotificationSettings(ctx, commGroupName, platform, channelAlias, settings)
+	}
+
+	var window string
+	if settings.AggregationWindow > 0 {
+		window = settings.AggregationWindow.String()
+	}
+	fields := []struct {
+		name  string
+		value string
+	}{
+		{name: "profile", value: settings.Profile},
+		{name: "minLevel", value: string(settings.MinLevel)},
+		{name: "filter", value: settings.Filter},
+		{name: "aggregationWindow", value: window},
+		{name: "locale", value: settings.Locale},
+	}
+	return m.updateChannel(ctx, ref, func(channel map[string]any) error {
+		for _, field := range fields {
+			if field.value == "" {
+				unstructured.RemoveNestedField(channel, "notification", field.name)
+				continue
+			}
+			if err := unstructured.SetNestedField(channel, field.value, "notification", field.name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// channelRef references a channel defined in a BotkubeConfig resource.
+type channelRef struct {
+	namespace string
+	// name is the resource name used in the channel alias, so dots are replaced with dashes.
+	name string
+	idx  int
+}
+
+// parseChannelAlias returns the reference to a channel with a given alias, if it's defined in a BotkubeConfig resource.
+func parseChannelAlias(alias string) (channelRef, bool) {
+	ns, rest, ok := strings.Cut(alias, "/")
+	if !ok || ns == "" {
+		return channelRef{}, false
+	}
+	sep := strings.LastIndex(rest, "-")
+	if sep <= 0 {
+		return channelRef{}, false
+	}
+	idx, err := strconv.Atoi(rest[sep+1:])
+	if err != nil || idx < 0 {
+		return channelRef{}, false
+	}
+	return channelRef{namespace: ns, name: rest[:sep], idx: idx}, true
+}
+
+func (m *PersistenceManager) updateChannel(ctx context.Context, ref channelRef, mutate func(channel map[string]any) error) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		obj, err := m.get(ctx, ref)
+		if err != nil {
+			return err
+		}
+
+		channels, found, err := unstructured.NestedSlice(obj.Object, "spec", "channels")
+		if err != nil {
+			return fmt.Errorf("while getting channels of %s %s/%s: %w", Kind, obj.GetNamespace(), obj.GetName(), err)
+		}
+		if !found || ref.idx >= len(channels) {
+			return fmt.Errorf("channel %d not found in %s %s/%s", ref.idx, Kind, obj.GetNamespace(), obj.GetName())
+		}
+		channel, ok := channels[ref.idx].(map[string]any)
+		if !ok {
+			return fmt.Errorf("channel %d of %s %s/%s is not an object", ref.idx, Kind, obj.GetNamespace(), obj.GetName())
+		}
+
+		if err := mutate(channel); err != nil {
+			return fmt.Errorf("while updating channel %d of %s %s/%s: %w", ref.idx, Kind, obj.GetNamespace(), obj.GetName(), err)
+		}
+		channels[ref.idx] = channel
+		if err := unstructured.SetNestedSlice(obj.Object, channels, "spec", "channels"); err != nil {
+			return err
+		}
+
+		_, err = m.cli.Resource(GVR).Namespace(obj.GetNamespace()).Update(ctx, obj, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+func (m *PersistenceManager) get(ctx context.Context, ref channelRef) (*unstructured.Unstructured, error) {
+	list, err := m.cli.Resource(GVR).Namespace(ref.namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("while listing %s resources: %w", Kind, err)
+	}
+	for i := range list.Items {
+		if aliasName(list.Items[i].GetName()) == ref.name {
+			return &list.Items[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%s resource for channel %s/%s-%d not found", Kind, ref.namespace, ref.name, ref.idx)
+}
@@ -0,0 +1,92 @@
+package crd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+type fakePersistenceManager struct {
+	config.PersistenceManager
+	aliases []string
+}
+
+func (f *fakePersistenceManager) PersistNotificationSettings(_ context.Context, _ string, _ config.CommPlatformIntegration, channelAlias string, _ config.NotificationSettings) error {
+	f.aliases = append(f.aliases, channelAlias)
+	return nil
+}
+
+func TestPersistenceManager(t *testing.T) {
+	// given
+	provider := newTestProvider(
+		fixBotkubeConfig("team-a", "alerts.v1", map[string]any{
+			"sources": map[string]any{
+				"k8s-events": map[string]any{
+					"botkube/kubernetes": map[string]any{"enabled": true},
+				},
+			},
+			"channels": []any{
+				map[string]any{
+					"communicationGroup": "default-group",
+					"platform":           "socketSlack",
+					"name":               "team-a",
+					"notification":       map[string]any{"filter": "true"},
+					"bindings":           map[string]any{"sources": []any{"k8s-events"}},
+				},
+			},
+		}),
+	)
+	base := &fakePersistenceManager{}
+	manager := NewPersistenceManager(base, provider.cli)
+	ctx := context.Background()
+
+	// when
+	err := manager.PersistNotificationSettings(ctx, "default-group", config.SocketSlackCommPlatformIntegration, "team-a/alerts-v1-0", config.NotificationSettings{
+		Profile:           config.SecurityFocusedProfile,
+		MinLevel:          config.Error,
+		AggregationWindow: 10 * time.Minute,
+	})
+	require.NoError(t, err)
+	err = manager.PersistSourceBindings(ctx, "default-group", config.SocketSlackCommPlatformIntegration, "team-a/alerts-v1-0", []string{"team-a/k8s-events"})
+	require.NoError(t, err)
+	err = manager.PersistNotificationsEnabled(ctx, "default-group", config.SocketSlackCommPlatformIntegration, "team-a/alerts-v1-0", false)
+	require.NoError(t, err)
+	err = manager.PersistNotificationSettings(ctx, "default-group", config.SocketSlackCommPlatformIntegration, "default", config.NotificationSettings{})
+	require.NoError(t, err)
+
+	// then
+	assert.Equal(t, []string{"default"}, base.aliases)
+
+	files, _, err := provider.Configs(ctx)
+	require.NoError(t, err)
+	cfg, _, err := config.LoadWithDefaults(files)
+	require.NoError(t, err)
+
+	channel := cfg.Communications["default-group"].SocketSlack.Channels["team-a/alerts-v1-0"]
+	assert.Equal(t, config.ChannelNotification{
+		Disabled: true,
+		NotificationSettings: config.NotificationSettings{
+			Profile:           config.SecurityFocusedProfile,
+			MinLevel:          config.Error,
+			AggregationWindow: 10 * time.Minute,
+		},
+	}, channel.Notification)
+	assert.Equal(t, []string{"team-a/k8s-events"}, channel.Bindings.Sources)
+}
+
+func TestPersistenceManagerUnknownResource(t *testing.T) {
+	// given
+	provider := newTestProvider()
+	manager := NewPersistenceManager(&fakePersistenceManager{}, provider.cli)
+
+	// when
+	err := manager.PersistNotificationsEnabled(context.Background(), "default-group", config.SocketSlackCommPlatformIntegration, "team-a/alerts-0", true)
+
+	// then
+	assert.EqualError(t, err, "BotkubeConfig resource for channel team-a/alerts-0 not found")
+}
@@ -0,0 +1,123 @@
+package crd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/exp/slices"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+var _ config.LayeredProvider = &Provider{}
+
+// Provider merges BotkubeConfig resources from all namespaces with the configuration files returned by the base provider.
+type Provider struct {
+	log  logrus.FieldLogger
+	base config.Provider
+	cli  dynamic.Interface
+	cfg  config.ConfigCRD
+}
+
+// NewProvider returns a new Provider instance.
+func NewProvider(log logrus.FieldLogger, base config.Provider, cli dynamic.Interface, cfg config.ConfigCRD) *Provider {
+	return &Provider{log: log, base: base, cli: cli, cfg: cfg}
+}
+
+// Configs returns the base configuration files followed by one file for each BotkubeConfig resource.
+func (p *Provider) Configs(ctx context.Context) (config.YAMLFiles, int, error) {
+	layers, ver, err := p.Layers(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	return layers.Files(), ver, nil
+}
+
+// Layers returns the base configuration layers followed by one layer for each BotkubeConfig resource.
+// Resources are merged in the namespace and name order. Resources which cannot be merged are skipped,
+// so a single invalid resource doesn't break the whole configuration.
+func (p *Provider) Layers(ctx context.Context) (config.Layers, int, error) {
+	layers, ver, err := config.LayersOf(ctx, p.base)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	items, err := p.list(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for _, item := range items {
+		merged, err := p.merge(layers, item)
+		if err != nil {
+			p.log.WithError(err).Warnf("Skipping invalid %s %s/%s", Kind, item.GetNamespace(), item.GetName())
+			continue
+		}
+		layers = merged
+	}
+
+	return layers, ver, nil
+}
+
+// Validate returns an error if a given BotkubeConfig resource cannot be merged with the current configuration.
+func (p *Provider) Validate(ctx context.Context, obj unstructured.Unstructured) error {
+	layers, _, err := config.LayersOf(ctx, p.base)
+	if err != nil {
+		return err
+	}
+
+	items, err := p.list(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		if item.GetNamespace() == obj.GetNamespace() && item.GetName() == obj.GetName() {
+			// it's replaced by the validated resource
+			continue
+		}
+		if merged, err := p.merge(layers, item); err == nil {
+			layers = merged
+		}
+	}
+
+	_, err = p.merge(layers, obj)
+	return err
+}
+
+func (p *Provider) list(ctx context.Context) ([]unstructured.Unstructured, error) {
+	list, err := p.cli.Resource(GVR).Namespace(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("while listing %s resources: %w", Kind, err)
+	}
+
+	items := list.Items
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].GetNamespace() != items[j].GetNamespace() {
+			return items[i].GetNamespace() < items[j].GetNamespace()
+		}
+		return items[i].GetName() < items[j].GetName()
+	})
+	return items, nil
+}
+
+func (p *Provider) merge(layers config.Layers, obj unstructured.Unstructured) (config.Layers, error) {
+	raw, err := render(obj, p.cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	merged := append(slices.Clone(layers), config.Layer{
+		Name: fmt.Sprintf("%s %s/%s", Kind, obj.GetNamespace(), obj.GetName()),
+		Data: raw,
+	})
+	if _, _, err := config.LoadWithDefaults(merged.Files()); err != nil {
+		return nil, err
+	}
+	return merged, nil
+}
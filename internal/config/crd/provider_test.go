@@ -0,0 +1,165 @@
+package crd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/fake"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+type staticProvider config.YAMLFiles
+
+func (s staticProvider) Configs(context.Context) (config.YAMLFiles, int, error) {
+	return config.YAMLFiles(s), 1, nil
+}
+
+func TestProviderConfigs(t *testing.T) {
+	// given
+	provider := newTestProvider(
+		fixBotkubeConfig("team-b", "alerts", map[string]any{
+			"channels": []any{
+				map[string]any{
+					"communicationGroup": "default-group",
+					"platform":           "socketSlack",
+					"name":               "team-b",
+					"bindings":           map[string]any{"sources": []any{"k8s-err-events"}},
+				},
+			},
+		}),
+		fixBotkubeConfig("team-a", "alerts", map[string]any{
+			"sources": map[string]any{
+				"k8s-events": map[string]any{
+					"botkube/kubernetes": map[string]any{
+						"enabled": true,
+						"config": map[string]any{
+							"namespaces": map[string]any{"include": []any{".*"}},
+							"resources": []any{
+								map[string]any{"type": "v1/pods", "namespaces": map[string]any{"include": []any{"kube-system"}}},
+								map[string]any{"type": "v1/services"},
+							},
+						},
+					},
+				},
+			},
+			"channels": []any{
+				map[string]any{
+					"communicationGroup": "default-group",
+					"platform":           "socketSlack",
+					"name":               "team-a",
+					"bindings":           map[string]any{"sources": []any{"k8s-events"}},
+				},
+			},
+		}),
+		// binding to undefined source
+		fixBotkubeConfig("team-c", "invalid", map[string]any{
+			"channels": []any{
+				map[string]any{
+					"communicationGroup": "default-group",
+					"platform":           "socketSlack",
+					"name":               "team-c",
+					"bindings":           map[string]any{"sources": []any{"k8s-events"}},
+				},
+			},
+		}),
+		// RBAC is managed by cluster admins
+		fixBotkubeConfig("team-d", "invalid", map[string]any{
+			"executors": map[string]any{
+				"kubectl": map[string]any{
+					"botkube/kubectl": map[string]any{
+						"enabled": true,
+						"context": map[string]any{"rbac": map[string]any{"group": map[string]any{"type": "Static", "static": map[string]any{"values": []any{"system:masters"}}}}},
+					},
+				},
+			},
+		}),
+	)
+
+	// when
+	files, ver, err := provider.Configs(context.Background())
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, 1, ver)
+	assert.Len(t, files, 3)
+
+	cfg, _, err := config.LoadWithDefaults(files)
+	require.NoError(t, err)
+
+	channels := cfg.Communications["default-group"].SocketSlack.Channels
+	assert.Len(t, channels, 3)
+	assert.Equal(t, []string{"team-a/k8s-events"}, channels["team-a/alerts-0"].Bindings.Sources)
+	assert.Equal(t, []string{"k8s-err-events"}, channels["team-b/alerts-0"].Bindings.Sources)
+	assert.NotContains(t, cfg.Executors, "team-d/kubectl")
+
+	source := cfg.Sources["team-a/k8s-events"].Plugins["botkube/kubernetes"]
+	require.NotNil(t, source.Context.RBAC)
+	assert.Equal(t, []string{"botkube-config:team-a"}, source.Context.RBAC.Group.Static.Values)
+	assert.Equal(t, map[string]any{
+		"namespaces": map[string]any{"include": []any{"team-a"}},
+		"resources": []any{
+			map[string]any{"type": "v1/pods", "namespaces": map[string]any{"include": []any{"team-a"}}},
+			map[string]any{"type": "v1/services"},
+		},
+	}, source.Config)
+
+	// when
+	layers, _, err := provider.Layers(context.Background())
+
+	// then
+	require.NoError(t, err)
+	var names []string
+	for _, layer := range layers {
+		names = append(names, layer.Name)
+	}
+	assert.Equal(t, []string{"configuration #1", "BotkubeConfig team-a/alerts", "BotkubeConfig team-b/alerts"}, names)
+}
+
+func newTestProvider(objs ...runtime.Object) *Provider {
+	base := staticProvider{[]byte(heredoc.Doc(`
+		communications:
+		  default-group:
+		    socketSlack:
+		      enabled: true
+		      botToken: xoxb-token
+		      appToken: xapp-token
+		      channels:
+		        default:
+		          name: general
+		          bindings:
+		            sources: [k8s-err-events]
+		sources:
+		  k8s-err-events:
+		    botkube/kubernetes:
+		      enabled: true
+		plugins:
+		  repositories:
+		    botkube:
+		      url: https://example.com/index.yaml
+	`))}
+
+	cli := fake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{GVR: Kind + "List"}, objs...)
+	return NewProvider(loggerx.NewNoop(), base, cli, config.ConfigCRD{Enabled: true, RBACGroupPrefix: "botkube-config:"})
+}
+
+func fixBotkubeConfig(ns, name string, spec map[string]any) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": GVR.GroupVersion().String(),
+			"kind":       Kind,
+			"metadata": map[string]any{
+				"name":      name,
+				"namespace": ns,
+			},
+			"spec": spec,
+		},
+	}
+}
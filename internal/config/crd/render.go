@@ -0,0 +1,221 @@
+package crd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"golang.org/x/exp/maps"
+	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/multierror"
+)
+
+const kubernetesPluginName = "kubernetes"
+
+// document is the Botkube configuration file rendered from a given BotkubeConfig resource.
+type document struct {
+	Sources        map[string]config.Sources                `yaml:"sources,omitempty"`
+	Executors      map[string]config.Executors              `yaml:"executors,omitempty"`
+	Communications map[string]map[Platform]platformChannels `yaml:"communications,omitempty"`
+}
+
+type platformChannels struct {
+	Channels map[string]any `yaml:"channels"`
+}
+
+// render converts a given BotkubeConfig resource into the Botkube configuration file. Sources and executors are
+// prefixed with the resource namespace, so resources from different namespaces never override each other.
+func render(obj unstructured.Unstructured, cfg config.ConfigCRD) ([]byte, error) {
+	spec, err := specOf(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	ns := obj.GetNamespace()
+	doc := document{
+		Sources:        map[string]config.Sources{},
+		Executors:      map[string]config.Executors{},
+		Communications: map[string]map[Platform]platformChannels{},
+	}
+
+	errs := multierror.New()
+	for _, name := range sortedKeys(spec.Sources) {
+		src := spec.Sources[name]
+		src.Plugins, err = scopePlugins(src.Plugins, ns, cfg)
+		if err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("source %q: %w", name, err))
+		}
+		if err := validateName(name); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("source %q: %w", name, err))
+		}
+		doc.Sources[qualifiedName(ns, name)] = src
+	}
+	for _, name := range sortedKeys(spec.Executors) {
+		exec := spec.Executors[name]
+		exec.Plugins, err = scopePlugins(exec.Plugins, ns, cfg)
+		if err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("executor %q: %w", name, err))
+		}
+		if err := validateName(name); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("executor %q: %w", name, err))
+		}
+		doc.Executors[qualifiedName(ns, name)] = exec
+	}
+
+	for idx, channel := range spec.Channels {
+		bindings := config.BotBindings{
+			Sources:   qualifiedNames(ns, channel.Bindings.Sources, spec.Sources),
+			Executors: qualifiedNames(ns, channel.Bindings.Executors, spec.Executors),
+		}
+
+		var out any
+		switch channel.Platform {
+		case SocketSlackPlatform, CloudSlackPlatform, MattermostPlatform:
+			out = config.ChannelBindingsByName{Name: channel.Name, Notification: channel.Notification, Bindings: bindings}
+		case DiscordPlatform:
+			out = config.ChannelBindingsByID{ID: channel.Name, Notification: channel.Notification, Bindings: bindings}
+		default:
+			errs = multierror.Append(errs, fmt.Errorf("channels[%d]: unsupported platform %q", idx, channel.Platform))
+			continue
+		}
+		if channel.CommunicationGroup == "" || channel.Name == "" {
+			errs = multierror.Append(errs, fmt.Errorf("channels[%d]: communicationGroup and name are required", idx))
+			continue
+		}
+
+		group, ok := doc.Communications[channel.CommunicationGroup]
+		if !ok {
+			group = map[Platform]platformChannels{}
+			doc.Communications[channel.CommunicationGroup] = group
+		}
+		platform, ok := group[channel.Platform]
+		if !ok {
+			platform = platformChannels{Channels: map[string]any{}}
+			group[channel.Platform] = platform
+		}
+		platform.Channels[channelAlias(obj, idx)] = out
+	}
+
+	if err := errs.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+
+	raw, err := yaml.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("while marshaling configuration: %w", err)
+	}
+	return raw, nil
+}
+
+// channelAlias returns the alias of a channel defined at a given index of a given resource.
+func channelAlias(obj unstructured.Unstructured, idx int) string {
+	return fmt.Sprintf("%s/%s-%d", obj.GetNamespace(), aliasName(obj.GetName()), idx)
+}
+
+func aliasName(name string) string {
+	return strings.ReplaceAll(name, ".", "-")
+}
+
+func specOf(obj unstructured.Unstructured) (Spec, error) {
+	rawSpec, _, err := unstructured.NestedMap(obj.Object, "spec")
+	if err != nil {
+		return Spec{}, fmt.Errorf("while getting spec: %w", err)
+	}
+
+	raw, err := yaml.Marshal(rawSpec)
+	if err != nil {
+		return Spec{}, fmt.Errorf("while marshaling spec: %w", err)
+	}
+	var spec Spec
+	if err := yaml.Unmarshal(raw, &spec); err != nil {
+		return Spec{}, fmt.Errorf("while unmarshaling spec: %w", err)
+	}
+	return spec, nil
+}
+
+// scopePlugins limits plugins to a given namespace. Plugins use the RBAC group bound to the namespace, and
+// the Kubernetes source watches only resources from this namespace.
+func scopePlugins(plugins config.Plugins, ns string, cfg config.ConfigCRD) (config.Plugins, error) {
+	out := make(config.Plugins, len(plugins))
+	for key, plugin := range plugins {
+		if plugin.Context.RBAC != nil {
+			return nil, fmt.Errorf("plugin %q: RBAC cannot be configured in %s resources", key, Kind)
+		}
+		if cfg.RBACGroupPrefix != "" {
+			plugin.Context.RBAC = &config.PolicyRule{
+				Group: config.GroupPolicySubject{
+					Type:   config.StaticPolicySubjectType,
+					Static: config.GroupStaticSubject{Values: []string{cfg.RBACGroupPrefix + ns}},
+				},
+			}
+		}
+		if _, name, _, err := config.DecomposePluginKey(key); err == nil && name == kubernetesPluginName {
+			plugin.Config = restrictNamespaces(plugin.Config, ns)
+		}
+		out[key] = plugin
+	}
+	return out, nil
+}
+
+// restrictNamespaces overrides both global and per-resource namespaces of the Kubernetes source configuration.
+func restrictNamespaces(pluginCfg any, ns string) any {
+	cfg, ok := pluginCfg.(map[string]any)
+	if !ok {
+		cfg = map[string]any{}
+	}
+	cfg = maps.Clone(cfg)
+
+	namespaces := map[string]any{"include": []any{ns}}
+	cfg["namespaces"] = namespaces
+
+	resources, ok := cfg["resources"].([]any)
+	if !ok {
+		return cfg
+	}
+	scoped := make([]any, 0, len(resources))
+	for _, item := range resources {
+		resource, ok := item.(map[string]any)
+		if ok {
+			if _, found := resource["namespaces"]; found {
+				resource = maps.Clone(resource)
+				resource["namespaces"] = namespaces
+			}
+			item = resource
+		}
+		scoped = append(scoped, item)
+	}
+	cfg["resources"] = scoped
+	return cfg
+}
+
+func validateName(name string) error {
+	if strings.Contains(name, ".") {
+		return fmt.Errorf("name cannot contain dots")
+	}
+	return nil
+}
+
+// qualifiedNames qualifies names defined in the same resource. Other names refer to the Botkube configuration.
+func qualifiedNames[T any](ns string, names []string, local map[string]T) []string {
+	var out []string
+	for _, name := range names {
+		if _, found := local[name]; found {
+			name = qualifiedName(ns, name)
+		}
+		out = append(out, name)
+	}
+	return out
+}
+
+func qualifiedName(ns, name string) string {
+	return fmt.Sprintf("%s/%s", ns, name)
+}
+
+func sortedKeys[T any](in map[string]T) []string {
+	keys := maps.Keys(in)
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,47 @@
+package crd
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+// GVR is the group, version and resource of the BotkubeConfig custom resources.
+var GVR = schema.GroupVersionResource{Group: "botkube.io", Version: "v1alpha1", Resource: "botkubeconfigs"}
+
+// Kind is the kind of the BotkubeConfig custom resources.
+const Kind = "BotkubeConfig"
+
+// Platform is a communication platform which supports channel bindings.
+type Platform string
+
+const (
+	// SocketSlackPlatform is the Slack platform using the Socket Mode.
+	SocketSlackPlatform Platform = "socketSlack"
+	// CloudSlackPlatform is the Botkube Cloud Slack platform.
+	CloudSlackPlatform Platform = "cloudSlack"
+	// MattermostPlatform is the Mattermost platform.
+	MattermostPlatform Platform = "mattermost"
+	// DiscordPlatform is the Discord platform. Channels are referenced by their IDs.
+	DiscordPlatform Platform = "discord"
+)
+
+// Spec is the specification of a BotkubeConfig resource.
+// Sources and executors are defined the same way as in the Botkube configuration.
+type Spec struct {
+	Sources   map[string]config.Sources   `yaml:"sources"`
+	Executors map[string]config.Executors `yaml:"executors"`
+	Channels  []ChannelBinding            `yaml:"channels"`
+}
+
+// ChannelBinding binds sources and executors to a given channel of an existing communication group.
+type ChannelBinding struct {
+	CommunicationGroup string   `yaml:"communicationGroup"`
+	Platform           Platform `yaml:"platform"`
+	// Name is the channel name. For Discord, it is the channel ID.
+	Name         string                     `yaml:"name"`
+	Notification config.ChannelNotification `yaml:"notification"`
+	// Bindings reference sources and executors defined in the same resource. Other names refer to the ones from
+	// the Botkube configuration.
+	Bindings config.BotBindings `yaml:"bindings"`
+}
@@ -0,0 +1,89 @@
+package crd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// maxReviewSize is the maximum size of the admission review request body.
+const maxReviewSize = 3 << 20 // 3MB, slightly above the etcd object size limit
+
+type resourceValidator interface {
+	Validate(ctx context.Context, obj unstructured.Unstructured) error
+}
+
+// ValidationHandler is the validating admission webhook for BotkubeConfig resources.
+// It rejects resources which cannot be merged with the current Botkube configuration.
+type ValidationHandler struct {
+	log       logrus.FieldLogger
+	validator resourceValidator
+}
+
+// NewValidationHandler returns a new ValidationHandler instance.
+func NewValidationHandler(log logrus.FieldLogger, validator resourceValidator) *ValidationHandler {
+	return &ValidationHandler{log: log, validator: validator}
+}
+
+// ServeHTTP handles the admission review requests.
+func (h *ValidationHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var review admissionv1.AdmissionReview
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxReviewSize)).Decode(&review); err != nil {
+		http.Error(w, fmt.Sprintf("while decoding admission review: %s", err.Error()), http.StatusBadRequest)
+		return
+	}
+	if review.Request == nil {
+		http.Error(w, "admission review request cannot be empty", http.StatusBadRequest)
+		return
+	}
+
+	res := &admissionv1.AdmissionResponse{UID: review.Request.UID, Allowed: true}
+	if err := h.validate(r.Context(), review.Request); err != nil {
+		h.log.WithFields(logrus.Fields{
+			"namespace": review.Request.Namespace,
+			"name":      review.Request.Name,
+		}).WithError(err).Info("Rejecting invalid resource")
+
+		res.Allowed = false
+		res.Result = &metav1.Status{
+			Status:  metav1.StatusFailure,
+			Reason:  metav1.StatusReasonInvalid,
+			Code:    http.StatusUnprocessableEntity,
+			Message: err.Error(),
+		}
+	}
+
+	review.Request = nil
+	review.Response = res
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(review); err != nil {
+		h.log.WithError(err).Error("Cannot write admission review response")
+	}
+}
+
+func (h *ValidationHandler) validate(ctx context.Context, req *admissionv1.AdmissionRequest) error {
+	if req.Operation == admissionv1.Delete {
+		return nil
+	}
+
+	var obj unstructured.Unstructured
+	if err := obj.UnmarshalJSON(req.Object.Raw); err != nil {
+		return fmt.Errorf("while decoding %s: %w", Kind, err)
+	}
+	if obj.GetNamespace() == "" {
+		// namespace may be omitted in the object and taken from the request
+		obj.SetNamespace(req.Namespace)
+	}
+
+	if err := h.validator.Validate(ctx, obj); err != nil {
+		return fmt.Errorf("%s cannot be merged with the Botkube configuration: %w", Kind, err)
+	}
+	return nil
+}
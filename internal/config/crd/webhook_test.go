@@ -0,0 +1,93 @@
+package crd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+func TestValidationHandler(t *testing.T) {
+	tests := []struct {
+		name            string
+		spec            map[string]any
+		expectedAllowed bool
+		expectedMessage string
+	}{
+		{
+			name: "valid resource",
+			spec: map[string]any{
+				"channels": []any{
+					map[string]any{
+						"communicationGroup": "default-group",
+						"platform":           "socketSlack",
+						"name":               "team-a",
+						"bindings":           map[string]any{"sources": []any{"k8s-err-events"}},
+					},
+				},
+			},
+			expectedAllowed: true,
+		},
+		{
+			name: "unsupported platform",
+			spec: map[string]any{
+				"channels": []any{
+					map[string]any{
+						"communicationGroup": "default-group",
+						"platform":           "teams",
+						"name":               "team-a",
+					},
+				},
+			},
+			expectedMessage: "BotkubeConfig cannot be merged with the Botkube configuration: 1 error occurred:\n\t* channels[0]: unsupported platform \"teams\"",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			// the existing resource is replaced by the validated one
+			existing := fixBotkubeConfig("team-a", "alerts", map[string]any{"sources": "invalid"})
+			handler := NewValidationHandler(loggerx.NewNoop(), newTestProvider(existing))
+
+			obj, err := json.Marshal(fixBotkubeConfig("team-a", "alerts", tc.spec))
+			require.NoError(t, err)
+			review, err := json.Marshal(admissionv1.AdmissionReview{
+				Request: &admissionv1.AdmissionRequest{
+					UID:       types.UID("uid"),
+					Operation: admissionv1.Update,
+					Namespace: "team-a",
+					Object:    runtime.RawExtension{Raw: obj},
+				},
+			})
+			require.NoError(t, err)
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/validate", bytes.NewReader(review))
+
+			// when
+			handler.ServeHTTP(rec, req)
+
+			// then
+			require.Equal(t, http.StatusOK, rec.Code)
+
+			var out admissionv1.AdmissionReview
+			require.NoError(t, json.NewDecoder(rec.Body).Decode(&out))
+			require.NotNil(t, out.Response)
+			assert.Equal(t, types.UID("uid"), out.Response.UID)
+			assert.Equal(t, tc.expectedAllowed, out.Response.Allowed)
+			if tc.expectedMessage != "" {
+				require.NotNil(t, out.Response.Result)
+				assert.Equal(t, tc.expectedMessage, out.Response.Result.Message)
+			}
+		})
+	}
+}
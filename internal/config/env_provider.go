@@ -13,6 +13,8 @@ const (
 	EnvProviderConfigPathsEnvKey = "BOTKUBE_CONFIG_PATHS"
 )
 
+var _ config.LayeredProvider = &EnvProvider{}
+
 // EnvProvider environment config source provider
 type EnvProvider struct {
 }
@@ -24,8 +26,17 @@ func NewEnvProvider() *EnvProvider {
 
 // Configs returns list of config file locations
 func (e *EnvProvider) Configs(ctx context.Context) (config.YAMLFiles, int, error) {
+	return e.fsProvider().Configs(ctx)
+}
+
+// Layers returns configuration read from all locations, named after the locations.
+func (e *EnvProvider) Layers(ctx context.Context) (config.Layers, int, error) {
+	return e.fsProvider().Layers(ctx)
+}
+
+func (e *EnvProvider) fsProvider() *FileSystemProvider {
 	envCfgs := os.Getenv(EnvProviderConfigPathsEnvKey)
 	configPaths := strings.Split(envCfgs, ",")
 
-	return NewFileSystemProvider(configPaths).Configs(ctx)
+	return NewFileSystemProvider(configPaths)
 }
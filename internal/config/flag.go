@@ -4,9 +4,22 @@ import (
 	"github.com/spf13/pflag"
 )
 
-var configPathsFlag []string
+var (
+	configPathsFlag         []string
+	validateConfigFlag      bool
+	configInterpolationFlag string
+	configStrictFlag        bool
+)
 
 // RegisterFlags registers config related flags.
 func RegisterFlags(flags *pflag.FlagSet) {
-	flags.StringSliceVarP(&configPathsFlag, "config", "c", nil, "Specify configuration file in YAML format (can specify multiple).")
+	flags.StringSliceVarP(&configPathsFlag, "config", "c", nil, "Specify configuration file in YAML format (can specify multiple). Supports local paths, HTTP(S) URLs and ConfigMaps in the form of configmap://{namespace}/{name}[/{key}]. Later locations override earlier ones.")
+	flags.BoolVar(&validateConfigFlag, "validate-config", false, "Validate the configuration, print found issues and exit without starting Botkube.")
+	flags.StringVar(&configInterpolationFlag, "config-interpolation", "", "Specify how `${ENV_VAR}` and `${file:/path}` references in configuration values are resolved: lenient (default) leaves unresolved references unchanged, strict fails on them, and disabled turns the interpolation off.")
+	flags.BoolVar(&configStrictFlag, "config-strict", false, "Reject configuration with properties which are not a part of the Botkube configuration, such as misspelled `bindigs`, instead of ignoring them.")
+}
+
+// ValidateOnly returns true if only the configuration validation was requested.
+func ValidateOnly() bool {
+	return validateConfigFlag
 }
@@ -7,32 +7,64 @@ import (
 	"path/filepath"
 	"strings"
 
+	"k8s.io/client-go/kubernetes"
+
 	"github.com/kubeshop/botkube/pkg/config"
 )
 
 const specialConfigFileNamePrefix = "_"
 
-// FileSystemProvider allows consumer to pass config files statically
+var _ config.LayeredProvider = &FileSystemProvider{}
+
+// FileSystemProvider allows consumer to pass config locations statically.
+// Apart from local files, it supports HTTP(S) URLs and ConfigMaps in the form of `configmap://{namespace}/{name}[/{key}]`.
 type FileSystemProvider struct {
 	Files []string
+
+	newK8sCliFn func() (kubernetes.Interface, error)
 }
 
 // NewFileSystemProvider initializes new static config source provider
 func NewFileSystemProvider(configs []string) *FileSystemProvider {
-	return &FileSystemProvider{Files: configs}
+	return &FileSystemProvider{Files: configs, newK8sCliFn: newK8sCli}
 }
 
 // Configs returns list of config file locations.
-func (e *FileSystemProvider) Configs(_ context.Context) (config.YAMLFiles, int, error) {
+func (e *FileSystemProvider) Configs(ctx context.Context) (config.YAMLFiles, int, error) {
+	layers, ver, err := e.Layers(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	return layers.Files(), ver, nil
+}
+
+// Layers returns configuration read from all locations, named after the locations.
+// Files with the special name prefix are moved to the end, so they have the highest priority.
+func (e *FileSystemProvider) Layers(ctx context.Context) (config.Layers, int, error) {
 	configPaths := sortCfgFiles(e.Files)
 
-	var out config.YAMLFiles
+	var out config.Layers
 	for _, path := range configPaths {
-		raw, err := os.ReadFile(filepath.Clean(path))
-		if err != nil {
-			return nil, 0, fmt.Errorf("while reading a file: %w", err)
+		switch {
+		case isURL(path):
+			layer, err := readURL(ctx, path)
+			if err != nil {
+				return nil, 0, err
+			}
+			out = append(out, layer)
+		case strings.HasPrefix(path, configMapPrefix):
+			layers, err := e.readConfigMap(ctx, path)
+			if err != nil {
+				return nil, 0, err
+			}
+			out = append(out, layers...)
+		default:
+			raw, err := os.ReadFile(filepath.Clean(path))
+			if err != nil {
+				return nil, 0, fmt.Errorf("while reading a file: %w", err)
+			}
+			out = append(out, config.Layer{Name: path, Data: raw})
 		}
-		out = append(out, raw)
 	}
 
 	return out, 0, nil
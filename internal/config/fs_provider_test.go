@@ -2,11 +2,20 @@ package config
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubeshop/botkube/pkg/config"
 )
 
 func TestStaticProviderSuccess(t *testing.T) {
@@ -22,6 +31,94 @@ func TestStaticProviderSuccess(t *testing.T) {
 	assert.Equal(t, cfgVer, 0)
 }
 
+func TestFileSystemProviderLayers(t *testing.T) {
+	// given
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/config.yaml":
+			assert.Equal(t, "secret", r.URL.Query().Get("token"))
+			_, _ = fmt.Fprint(w, "settings:\n  clusterName: from-url\n")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	cli := fake.NewSimpleClientset(&v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "botkube-config", Namespace: "botkube"},
+		Data: map[string]string{
+			"b.yaml": "b: 2",
+			"a.yaml": "a: 1",
+		},
+	})
+	p := NewFileSystemProvider([]string{
+		"testdata/TestStaticProviderSuccess/config.yaml",
+		"configmap://botkube/botkube-config",
+		srv.URL + "/config.yaml?token=secret",
+		"configmap://botkube/botkube-config/a.yaml",
+	})
+	p.newK8sCliFn = func() (kubernetes.Interface, error) {
+		return cli, nil
+	}
+	file, err := os.ReadFile("testdata/TestStaticProviderSuccess/config.yaml")
+	require.NoError(t, err)
+
+	// when
+	layers, _, err := p.Layers(context.Background())
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, config.Layers{
+		{Name: "testdata/TestStaticProviderSuccess/config.yaml", Data: file},
+		{Name: "configmap://botkube/botkube-config/a.yaml", Data: []byte("a: 1")},
+		{Name: "configmap://botkube/botkube-config/b.yaml", Data: []byte("b: 2")},
+		{Name: srv.URL + "/config.yaml", Data: []byte("settings:\n  clusterName: from-url\n")},
+		{Name: "configmap://botkube/botkube-config/a.yaml", Data: []byte("a: 1")},
+	}, layers)
+}
+
+func TestFileSystemProviderLayersErrors(t *testing.T) {
+	// given
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	tests := map[string]struct {
+		location  string
+		expErrMsg string
+	}{
+		"Unexpected status code": {
+			location:  srv.URL + "/missing.yaml?token=secret",
+			expErrMsg: fmt.Sprintf("while downloading %q: unexpected status code 404", srv.URL+"/missing.yaml"),
+		},
+		"Invalid ConfigMap location": {
+			location:  "configmap://botkube",
+			expErrMsg: `ConfigMap location "configmap://botkube" doesn't follow the configmap://{namespace}/{name}[/{key}] syntax`,
+		},
+		"Missing ConfigMap": {
+			location:  "configmap://botkube/missing",
+			expErrMsg: `while getting ConfigMap "configmap://botkube/missing": configmaps "missing" not found`,
+		},
+	}
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			p := NewFileSystemProvider([]string{test.location})
+			p.newK8sCliFn = func() (kubernetes.Interface, error) {
+				return fake.NewSimpleClientset(), nil
+			}
+
+			// when
+			_, _, err := p.Layers(context.Background())
+
+			// then
+			require.Error(t, err)
+			assert.EqualError(t, err, test.expErrMsg)
+		})
+	}
+}
+
 func TestSortCfgFiles(t *testing.T) {
 	tests := map[string]struct {
 		input    []string
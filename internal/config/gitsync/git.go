@@ -0,0 +1,105 @@
+package gitsync
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+const defaultUsername = "git"
+
+// gitCLI runs the git binary. Credentials are passed in environment variables,
+// so they are not visible in process arguments and are not stored in the repository configuration.
+type gitCLI struct {
+	repository string
+	env        []string
+}
+
+func newGitCLI(cfg config.GitSync) gitCLI {
+	env := append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	if cfg.Auth.Token != "" {
+		username := cfg.Auth.Username
+		if username == "" {
+			username = defaultUsername
+		}
+		credentials := base64.StdEncoding.EncodeToString([]byte(username + ":" + cfg.Auth.Token))
+		env = append(env,
+			"GIT_CONFIG_COUNT=1",
+			"GIT_CONFIG_KEY_0=http.extraHeader",
+			"GIT_CONFIG_VALUE_0=Authorization: Basic "+credentials,
+		)
+	}
+	if cfg.Auth.SSHKeyPath != "" {
+		keyPath := strings.ReplaceAll(cfg.Auth.SSHKeyPath, "'", `'\''`)
+		env = append(env, fmt.Sprintf("GIT_SSH_COMMAND=ssh -i '%s' -o IdentitiesOnly=yes -o StrictHostKeyChecking=accept-new", keyPath))
+	}
+	return gitCLI{repository: cfg.Repository, env: env}
+}
+
+// head returns the SHA of the latest commit on a given branch without fetching it.
+func (g gitCLI) head(ctx context.Context, branch string) (string, error) {
+	out, err := g.run(ctx, "", "ls-remote", g.repository, "refs/heads/"+branch)
+	if err != nil {
+		return "", err
+	}
+	sha, _, found := strings.Cut(out, "\t")
+	if !found || sha == "" {
+		return "", fmt.Errorf("branch %q not found in %q", branch, redactURL(g.repository))
+	}
+	return sha, nil
+}
+
+// checkout fetches the latest commit of a given branch into a given directory and returns its SHA.
+func (g gitCLI) checkout(ctx context.Context, dir, branch string) (string, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("while creating directory %q: %w", dir, err)
+	}
+	if _, err := g.run(ctx, dir, "init", "-q"); err != nil {
+		return "", err
+	}
+	if _, err := g.run(ctx, dir, "fetch", "-q", "--depth", "1", "--no-tags", g.repository, "refs/heads/"+branch); err != nil {
+		return "", err
+	}
+	if _, err := g.run(ctx, dir, "checkout", "-q", "--force", "--detach", "FETCH_HEAD"); err != nil {
+		return "", err
+	}
+	return g.run(ctx, dir, "rev-parse", "HEAD")
+}
+
+func (g gitCLI) run(ctx context.Context, dir string, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	cmd.Env = g.env
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		// the repository URL may contain credentials
+		msg = strings.ReplaceAll(msg, g.repository, redactURL(g.repository))
+		return "", fmt.Errorf("while running git %s: %s", args[0], msg)
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// redactURL removes credentials from HTTPS repository URLs. SSH URLs, such as `git@github.com:org/repo.git`, are returned as they are.
+func redactURL(repository string) string {
+	u, err := url.Parse(repository)
+	if err != nil || u.Scheme == "" {
+		return repository
+	}
+	u.User = nil
+	u.RawQuery = ""
+	return u.String()
+}
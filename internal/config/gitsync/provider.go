@@ -0,0 +1,163 @@
+package gitsync
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+const shortSHALength = 7
+
+var _ config.LayeredProvider = &Provider{}
+
+// Provider merges configuration files from a Git repository with the configuration returned by the base provider.
+// The Git repository is configured in the `gitSync` property of the base configuration.
+type Provider struct {
+	base config.Provider
+
+	mu     sync.Mutex
+	cfg    config.GitSync
+	commit string
+}
+
+// NewProvider returns a new Provider instance.
+func NewProvider(base config.Provider) *Provider {
+	return &Provider{base: base}
+}
+
+// Configs returns the base configuration files followed by configuration files from the Git repository.
+func (p *Provider) Configs(ctx context.Context) (config.YAMLFiles, int, error) {
+	layers, ver, err := p.Layers(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	return layers.Files(), ver, nil
+}
+
+// Layers returns the base configuration layers followed by one layer for each configuration file from the latest commit of the configured branch.
+func (p *Provider) Layers(ctx context.Context) (config.Layers, int, error) {
+	layers, ver, err := config.LayersOf(ctx, p.base)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	base, err := config.MergeWithDefaults(layers.Files())
+	if err != nil {
+		return nil, 0, fmt.Errorf("while reading Git sync configuration: %w", err)
+	}
+	cfg := base.GitSync
+	if !cfg.Enabled {
+		return layers, ver, nil
+	}
+
+	dir := filepath.Join(cfg.CacheDir, repositoryDirName(cfg))
+	commit, err := newGitCLI(cfg).checkout(ctx, dir, cfg.Branch)
+	if err != nil {
+		return nil, 0, fmt.Errorf("while fetching %q branch from %q: %w", cfg.Branch, redactURL(cfg.Repository), err)
+	}
+
+	files, err := readFiles(dir, cfg.Path)
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, file := range files {
+		raw, err := os.ReadFile(filepath.Join(dir, file))
+		if err != nil {
+			return nil, 0, fmt.Errorf("while reading a file: %w", err)
+		}
+		layers = append(layers, config.Layer{
+			Name: fmt.Sprintf("%s@%s:%s", redactURL(cfg.Repository), commit[:shortSHALength], file),
+			Data: raw,
+		})
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.cfg = cfg
+	p.commit = commit
+	return layers, ver, nil
+}
+
+// Enabled returns true if the Git sync was enabled in the last loaded configuration.
+func (p *Provider) Enabled() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.cfg.Enabled
+}
+
+// LoadedCommit returns the SHA of the commit from which the configuration was loaded last time.
+func (p *Provider) LoadedCommit() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.commit
+}
+
+// Head returns the SHA of the latest commit on the configured branch.
+func (p *Provider) Head(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	cfg := p.cfg
+	p.mu.Unlock()
+
+	sha, err := newGitCLI(cfg).head(ctx, cfg.Branch)
+	if err != nil {
+		return "", fmt.Errorf("while checking %q branch of %q: %w", cfg.Branch, redactURL(cfg.Repository), err)
+	}
+	return sha, nil
+}
+
+// readFiles returns paths of YAML files relative to the repository directory. If a given path is a file, it's returned even if it doesn't have the YAML extension.
+func readFiles(repoDir, path string) ([]string, error) {
+	root := filepath.Join(repoDir, path)
+	rel, err := filepath.Rel(repoDir, root)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return nil, fmt.Errorf("path %q points outside of the repository", path)
+	}
+
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, fmt.Errorf("while reading %q path from the repository: %w", path, err)
+	}
+	if !info.IsDir() {
+		return []string{filepath.ToSlash(rel)}, nil
+	}
+
+	var out []string
+	// WalkDir visits files in the lexical order
+	err = filepath.WalkDir(root, func(file string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if ext := filepath.Ext(file); ext != ".yaml" && ext != ".yml" {
+			return nil
+		}
+		rel, err := filepath.Rel(repoDir, file)
+		if err != nil {
+			return err
+		}
+		out = append(out, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("while reading %q path from the repository: %w", path, err)
+	}
+	return out, nil
+}
+
+// repositoryDirName returns a directory name unique for a given repository and branch.
+func repositoryDirName(cfg config.GitSync) string {
+	sum := sha256.Sum256([]byte(cfg.Repository + "#" + cfg.Branch))
+	return fmt.Sprintf("%x", sum[:8])
+}
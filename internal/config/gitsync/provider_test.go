@@ -0,0 +1,264 @@
+package gitsync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+type staticProvider config.YAMLFiles
+
+func (s staticProvider) Configs(context.Context) (config.YAMLFiles, int, error) {
+	return config.YAMLFiles(s), 1, nil
+}
+
+type fakeRestarter struct {
+	calls int
+}
+
+func (f *fakeRestarter) Do(context.Context) error {
+	f.calls++
+	return nil
+}
+
+func TestProviderLayers(t *testing.T) {
+	// given
+	repo := newTestRepo(t)
+	repo.commit(t, map[string]string{
+		"botkube/settings.yaml":      "settings:\n  clusterName: from-git\n",
+		"botkube/sources/k8s.yaml":   "sources: {}\n",
+		"botkube/README.md":          "docs",
+		"other/communications.yaml":  "communications: {}\n",
+		"botkube/_overrides.yml":     "settings:\n  log:\n    level: debug\n",
+		"botkube/nested/ignored.txt": "text",
+	})
+	base := staticProvider{[]byte(heredoc.Docf(`
+		gitSync:
+		  enabled: true
+		  repository: %s
+		  branch: main
+		  path: botkube
+		  cacheDir: %s
+	`, repo.url, t.TempDir()))}
+	provider := NewProvider(base)
+
+	// when
+	layers, ver, err := provider.Layers(context.Background())
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, 1, ver)
+	commit := repo.head(t)
+	assert.Equal(t, commit, provider.LoadedCommit())
+	assert.True(t, provider.Enabled())
+
+	var names []string
+	for _, layer := range layers {
+		names = append(names, layer.Name)
+	}
+	prefix := fmt.Sprintf("%s@%s:", repo.url, commit[:7])
+	assert.Equal(t, []string{
+		"configuration #1",
+		prefix + "botkube/_overrides.yml",
+		prefix + "botkube/settings.yaml",
+		prefix + "botkube/sources/k8s.yaml",
+	}, names)
+	assert.Equal(t, "settings:\n  clusterName: from-git\n", string(layers[2].Data))
+
+	// when a new commit is pushed
+	repo.commit(t, map[string]string{"botkube/settings.yaml": "settings:\n  clusterName: changed\n"})
+	head, err := provider.Head(context.Background())
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, repo.head(t), head)
+	assert.NotEqual(t, commit, head)
+
+	// when
+	layers, _, err = provider.Layers(context.Background())
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, head, provider.LoadedCommit())
+	assert.Equal(t, "settings:\n  clusterName: changed\n", string(layers[2].Data))
+}
+
+func TestProviderLayersDisabled(t *testing.T) {
+	// given
+	base := staticProvider{[]byte("settings:\n  clusterName: test\n")}
+	provider := NewProvider(base)
+
+	// when
+	layers, _, err := provider.Layers(context.Background())
+
+	// then
+	require.NoError(t, err)
+	assert.Len(t, layers, 1)
+	assert.False(t, provider.Enabled())
+	assert.Empty(t, provider.LoadedCommit())
+}
+
+func TestProviderLayersErrors(t *testing.T) {
+	repo := newTestRepo(t)
+	repo.commit(t, map[string]string{"config.yaml": "settings: {}\n"})
+
+	tests := map[string]struct {
+		branch    string
+		path      string
+		expErrMsg string
+	}{
+		"Missing branch": {
+			branch:    "missing",
+			expErrMsg: `while fetching "missing" branch from`,
+		},
+		"Path outside of the repository": {
+			branch:    "main",
+			path:      "../..",
+			expErrMsg: `path "../.." points outside of the repository`,
+		},
+		"Missing path": {
+			branch:    "main",
+			path:      "missing",
+			expErrMsg: `while reading "missing" path from the repository`,
+		},
+	}
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			// given
+			base := staticProvider{[]byte(heredoc.Docf(`
+				gitSync:
+				  enabled: true
+				  repository: %s
+				  branch: %s
+				  path: %q
+				  cacheDir: %s
+			`, repo.url, test.branch, test.path, t.TempDir()))}
+
+			// when
+			_, _, err := NewProvider(base).Layers(context.Background())
+
+			// then
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.expErrMsg)
+		})
+	}
+}
+
+func TestSyncerCheck(t *testing.T) {
+	// given
+	repo := newTestRepo(t)
+	repo.commit(t, map[string]string{"config.yaml": "settings:\n  clusterName: first\n"})
+	base := staticProvider{[]byte(heredoc.Docf(`
+		gitSync:
+		  enabled: true
+		  repository: %s
+		  branch: main
+		  cacheDir: %s
+	`, repo.url, t.TempDir()))}
+	provider := NewProvider(base)
+	_, _, err := provider.Layers(context.Background())
+	require.NoError(t, err)
+
+	var (
+		loadErr error
+		loads   int
+		sent    []string
+	)
+	loadFn := func(ctx context.Context) error {
+		loads++
+		_, _, err := provider.Layers(ctx)
+		require.NoError(t, err)
+		return loadErr
+	}
+	sendMsgFn := func(msg string) error {
+		sent = append(sent, msg)
+		return nil
+	}
+	restarter := &fakeRestarter{}
+	syncer := NewSyncer(loggerx.NewNoop(), provider, loadFn, restarter, sendMsgFn, 0)
+
+	// when nothing changed
+	syncer.check(context.Background())
+
+	// then
+	assert.Zero(t, loads)
+	assert.Zero(t, restarter.calls)
+
+	// when invalid configuration is pushed
+	applied := repo.head(t)
+	repo.commit(t, map[string]string{"config.yaml": "settings:\n  clusterName: [invalid]\n"})
+	loadErr = errors.New("found critical validation errors")
+	syncer.check(context.Background())
+	syncer.check(context.Background())
+
+	// then
+	assert.Equal(t, 1, loads)
+	assert.Zero(t, restarter.calls)
+	require.Len(t, sent, 1)
+	assert.Equal(t, fmt.Sprintf(":warning: Configuration from Git commit `%s` cannot be applied, keeping configuration from commit `%s`: found critical validation errors", repo.head(t)[:7], applied[:7]), sent[0])
+
+	// when the configuration is fixed
+	repo.commit(t, map[string]string{"config.yaml": "settings:\n  clusterName: fixed\n"})
+	loadErr = nil
+	syncer.check(context.Background())
+	syncer.check(context.Background())
+
+	// then
+	assert.Equal(t, 2, loads)
+	assert.Equal(t, 1, restarter.calls)
+}
+
+type testRepo struct {
+	dir string
+	url string
+}
+
+func newTestRepo(t *testing.T) testRepo {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git binary is not available")
+	}
+
+	dir := t.TempDir()
+	repo := testRepo{dir: dir, url: "file://" + filepath.ToSlash(dir)}
+	repo.git(t, "init", "-q", "--initial-branch=main")
+	return repo
+}
+
+func (r testRepo) commit(t *testing.T, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(r.dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+	r.git(t, "add", "-A")
+	r.git(t, "-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "-m", "update")
+}
+
+func (r testRepo) head(t *testing.T) string {
+	t.Helper()
+	return r.git(t, "rev-parse", "HEAD")
+}
+
+func (r testRepo) git(t *testing.T, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = r.dir
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+	return strings.TrimSpace(string(out))
+}
@@ -0,0 +1,111 @@
+package gitsync
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+type restarter interface {
+	Do(ctx context.Context) error
+}
+
+// Syncer checks the configured branch for new commits, and reloads Botkube once the configuration from a new commit is valid.
+type Syncer struct {
+	log       logrus.FieldLogger
+	provider  *Provider
+	loadFn    func(ctx context.Context) error
+	restarter restarter
+	sendMsgFn func(msg string) error
+	interval  time.Duration
+	triggerCh chan struct{}
+
+	applied  string
+	rejected string
+}
+
+// NewSyncer returns a new Syncer instance. The loadFn function loads and validates the whole configuration,
+// and sendMsgFn is used to report configuration which cannot be applied.
+func NewSyncer(log logrus.FieldLogger, provider *Provider, loadFn func(ctx context.Context) error, restarter restarter, sendMsgFn func(msg string) error, interval time.Duration) *Syncer {
+	return &Syncer{
+		log:       log,
+		provider:  provider,
+		loadFn:    loadFn,
+		restarter: restarter,
+		sendMsgFn: sendMsgFn,
+		interval:  interval,
+		triggerCh: make(chan struct{}, 1),
+		applied:   provider.LoadedCommit(),
+	}
+}
+
+// Trigger checks the branch for new commits without waiting for the next poll.
+func (s *Syncer) Trigger() {
+	select {
+	case s.triggerCh <- struct{}{}:
+	default:
+		// check is already scheduled
+	}
+}
+
+// Do checks the branch for new commits until the context is canceled.
+func (s *Syncer) Do(ctx context.Context) error {
+	var tick <-chan time.Time
+	if s.interval > 0 {
+		s.log.Infof("Checking the Git repository for new commits every %s...", s.interval)
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.log.Info("Exiting...")
+			return nil
+		case <-tick:
+		case <-s.triggerCh:
+		}
+
+		s.check(ctx)
+	}
+}
+
+func (s *Syncer) check(ctx context.Context) {
+	head, err := s.provider.Head(ctx)
+	if err != nil {
+		// keep using the applied configuration, the repository might be temporarily unavailable
+		s.log.WithError(err).Warn("Cannot check the Git repository for new commits")
+		return
+	}
+	if head == s.applied || head == s.rejected {
+		return
+	}
+
+	log := s.log.WithField("commit", head)
+	if err := s.loadFn(ctx); err != nil {
+		s.rejected = head
+		log.WithError(err).Error("Configuration from the Git repository is invalid. Keeping the applied configuration...")
+		msg := fmt.Sprintf(":warning: Configuration from Git commit `%s` cannot be applied, keeping configuration from commit `%s`: %s", short(head), short(s.applied), err.Error())
+		if err := s.sendMsgFn(msg); err != nil {
+			log.WithError(err).Error("Cannot send message about invalid configuration")
+		}
+		return
+	}
+
+	log.Info("New commit detected. Reloading configuration...")
+	if err := s.restarter.Do(ctx); err != nil {
+		log.Errorf("while reloading configuration: %s", err.Error())
+		return
+	}
+	s.applied = head
+}
+
+func short(sha string) string {
+	if len(sha) < shortSHALength {
+		return sha
+	}
+	return sha[:shortSHALength]
+}
@@ -0,0 +1,83 @@
+package gitsync
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// maxPayloadSize is the maximum size of the push event payload read to verify its signature.
+	maxPayloadSize     = 25 << 20 // 25MB, the GitHub payload limit
+	githubSignatureHdr = "X-Hub-Signature-256"
+	gitlabTokenHdr     = "X-Gitlab-Token"
+	signaturePrefix    = "sha256="
+)
+
+type trigger interface {
+	Trigger()
+}
+
+// WebhookHandler handles push events sent by Git hosting services and triggers checking the branch for new commits.
+type WebhookHandler struct {
+	log     logrus.FieldLogger
+	secret  string
+	trigger trigger
+}
+
+// NewWebhookHandler returns a new WebhookHandler instance. If the secret is not empty, requests must be signed
+// in the GitHub way, or they must contain the GitLab token.
+func NewWebhookHandler(log logrus.FieldLogger, secret string, trigger trigger) *WebhookHandler {
+	return &WebhookHandler{log: log, secret: secret, trigger: trigger}
+}
+
+// ServeHTTP handles push events. The payload is not parsed, as the branch is always checked for new commits.
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	payload, err := io.ReadAll(io.LimitReader(r.Body, maxPayloadSize))
+	if err != nil {
+		http.Error(w, "cannot read request body", http.StatusBadRequest)
+		return
+	}
+	if !h.verify(r.Header, payload) {
+		h.log.Warn("Rejecting webhook request with invalid signature")
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	h.log.Debug("Push event received")
+	h.trigger.Trigger()
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (h *WebhookHandler) verify(header http.Header, payload []byte) bool {
+	if h.secret == "" {
+		return true
+	}
+
+	if token := header.Get(gitlabTokenHdr); token != "" {
+		return subtle.ConstantTimeCompare([]byte(token), []byte(h.secret)) == 1
+	}
+
+	signature, found := strings.CutPrefix(header.Get(githubSignatureHdr), signaturePrefix)
+	if !found {
+		return false
+	}
+	got, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(h.secret))
+	mac.Write(payload)
+	return hmac.Equal(got, mac.Sum(nil))
+}
@@ -0,0 +1,102 @@
+package gitsync
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+type fakeTrigger struct {
+	calls int
+}
+
+func (f *fakeTrigger) Trigger() {
+	f.calls++
+}
+
+func TestWebhookHandler(t *testing.T) {
+	const (
+		secret  = "webhook-secret"
+		payload = `{"ref":"refs/heads/main"}`
+	)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+	validSignature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	tests := map[string]struct {
+		secret      string
+		method      string
+		headers     map[string]string
+		expStatus   int
+		expTriggers int
+	}{
+		"Valid GitHub signature": {
+			secret:      secret,
+			method:      http.MethodPost,
+			headers:     map[string]string{githubSignatureHdr: validSignature},
+			expStatus:   http.StatusAccepted,
+			expTriggers: 1,
+		},
+		"Valid GitLab token": {
+			secret:      secret,
+			method:      http.MethodPost,
+			headers:     map[string]string{gitlabTokenHdr: secret},
+			expStatus:   http.StatusAccepted,
+			expTriggers: 1,
+		},
+		"No secret configured": {
+			method:      http.MethodPost,
+			expStatus:   http.StatusAccepted,
+			expTriggers: 1,
+		},
+		"Invalid GitHub signature": {
+			secret:    secret,
+			method:    http.MethodPost,
+			headers:   map[string]string{githubSignatureHdr: "sha256=00ff"},
+			expStatus: http.StatusUnauthorized,
+		},
+		"Invalid GitLab token": {
+			secret:    secret,
+			method:    http.MethodPost,
+			headers:   map[string]string{gitlabTokenHdr: "other"},
+			expStatus: http.StatusUnauthorized,
+		},
+		"Missing signature": {
+			secret:    secret,
+			method:    http.MethodPost,
+			expStatus: http.StatusUnauthorized,
+		},
+		"Unsupported method": {
+			method:    http.MethodGet,
+			expStatus: http.StatusMethodNotAllowed,
+		},
+	}
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			// given
+			trigger := &fakeTrigger{}
+			handler := NewWebhookHandler(loggerx.NewNoop(), test.secret, trigger)
+			req := httptest.NewRequest(test.method, "/", strings.NewReader(payload))
+			for key, value := range test.headers {
+				req.Header.Set(key, value)
+			}
+			rec := httptest.NewRecorder()
+
+			// when
+			handler.ServeHTTP(rec, req)
+
+			// then
+			assert.Equal(t, test.expStatus, rec.Code)
+			assert.Equal(t, test.expTriggers, trigger.calls)
+		})
+	}
+}
@@ -9,6 +9,10 @@ import (
 	"github.com/kubeshop/botkube/pkg/config"
 )
 
+const gqlLayerName = "Botkube Cloud"
+
+var _ config.LayeredProvider = &GqlProvider{}
+
 type DeploymentClient interface {
 	GetConfigWithResourceVersion(ctx context.Context) (remote.Deployment, error)
 }
@@ -25,12 +29,21 @@ func NewGqlProvider(dc DeploymentClient) *GqlProvider {
 
 // Configs returns list of config files
 func (g *GqlProvider) Configs(ctx context.Context) (config.YAMLFiles, int, error) {
+	layers, ver, err := g.Layers(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	return layers.Files(), ver, nil
+}
+
+// Layers returns the configuration fetched from Botkube Cloud as a single layer.
+func (g *GqlProvider) Layers(ctx context.Context) (config.Layers, int, error) {
 	deployment, err := g.client.GetConfigWithResourceVersion(ctx)
 	if err != nil {
 		return nil, 0, errors.Wrapf(err, "while getting deployment")
 	}
 
-	return [][]byte{
-		[]byte(deployment.YAMLConfig),
+	return config.Layers{
+		{Name: gqlLayerName, Data: []byte(deployment.YAMLConfig)},
 	}, deployment.ResourceVersion, nil
 }
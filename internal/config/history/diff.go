@@ -0,0 +1,132 @@
+package history
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/knadh/koanf"
+	koanfyaml "github.com/knadh/koanf/parsers/yaml"
+	"github.com/knadh/koanf/providers/rawbytes"
+	"gopkg.in/yaml.v3"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+const (
+	// maxChanges limits the number of changes kept for a single revision.
+	maxChanges = 50
+	// maxValueLen limits the length of values shown in changes.
+	maxValueLen = 60
+)
+
+type effective struct {
+	checksum string
+	// values holds flattened effective configuration with sensitive values redacted.
+	values map[string]string
+}
+
+// effectiveOf merges given layers in the same way as on startup, and returns the effective configuration.
+func effectiveOf(layers config.Layers) (effective, error) {
+	cfg, _, err := config.LoadWithDefaults(layers.Files())
+	if err != nil {
+		return effective{}, err
+	}
+
+	raw, err := yaml.Marshal(cfg)
+	if err != nil {
+		return effective{}, fmt.Errorf("while marshaling configuration: %w", err)
+	}
+	sum := sha256.Sum256(raw)
+
+	redacted, err := yaml.Marshal(config.HideSensitiveInfo(*cfg))
+	if err != nil {
+		return effective{}, fmt.Errorf("while marshaling configuration: %w", err)
+	}
+	k := koanf.New(".")
+	if err := k.Load(rawbytes.Provider(redacted), koanfyaml.Parser()); err != nil {
+		return effective{}, fmt.Errorf("while flattening configuration: %w", err)
+	}
+	values := map[string]string{}
+	for path, value := range k.All() {
+		values[path] = formatValue(value)
+	}
+
+	return effective{checksum: hex.EncodeToString(sum[:]), values: values}, nil
+}
+
+// diff returns added (`+`), removed (`-`) and changed (`~`) values, ordered by their paths.
+func diff(previous, current map[string]string) []string {
+	paths := map[string]struct{}{}
+	for path := range previous {
+		paths[path] = struct{}{}
+	}
+	for path := range current {
+		paths[path] = struct{}{}
+	}
+	sorted := make([]string, 0, len(paths))
+	for path := range paths {
+		sorted = append(sorted, path)
+	}
+	sort.Strings(sorted)
+
+	var out []string
+	for _, path := range sorted {
+		prev, wasSet := previous[path]
+		cur, isSet := current[path]
+		switch {
+		case !wasSet:
+			out = append(out, fmt.Sprintf("+ %s: %s", path, cur))
+		case !isSet:
+			out = append(out, fmt.Sprintf("- %s: %s", path, prev))
+		case prev != cur:
+			out = append(out, fmt.Sprintf("~ %s: %s → %s", path, prev, cur))
+		}
+	}
+
+	if len(out) > maxChanges {
+		more := len(out) - maxChanges
+		out = append(out[:maxChanges], fmt.Sprintf("…and %d more", more))
+	}
+	return out
+}
+
+// changedLayersTrigger describes a change of configuration sources between two revisions.
+func changedLayersTrigger(previous, current config.Layers) string {
+	prevData := map[string]string{}
+	for _, layer := range previous {
+		prevData[layer.Name] = string(layer.Data)
+	}
+
+	var changed []string
+	for _, layer := range current {
+		data, ok := prevData[layer.Name]
+		delete(prevData, layer.Name)
+		if ok && data == string(layer.Data) {
+			continue
+		}
+		changed = append(changed, layer.Name)
+	}
+	for name := range prevData {
+		changed = append(changed, name)
+	}
+	if len(changed) == 0 {
+		return "Changed environment variables or built-in defaults"
+	}
+
+	sort.Strings(changed)
+	return fmt.Sprintf("Changed configuration sources: %s", strings.Join(changed, ", "))
+}
+
+func formatValue(in any) string {
+	out := fmt.Sprintf("%v", in)
+	if s, ok := in.(string); ok {
+		out = fmt.Sprintf("%q", s)
+	}
+	if len([]rune(out)) > maxValueLen {
+		out = string([]rune(out)[:maxValueLen]) + "…"
+	}
+	return out
+}
@@ -0,0 +1,44 @@
+package history
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+var _ config.LayeredProvider = &Provider{}
+
+// Provider returns configuration of the pinned revision if the configuration is rolled back. Otherwise, it returns
+// configuration of the base provider.
+type Provider struct {
+	base  config.Provider
+	store *Store
+}
+
+// NewProvider returns a new Provider instance.
+func NewProvider(base config.Provider, store *Store) *Provider {
+	return &Provider{base: base, store: store}
+}
+
+// Configs returns configuration files of the pinned revision or the base provider.
+func (p *Provider) Configs(ctx context.Context) (config.YAMLFiles, int, error) {
+	layers, ver, err := p.Layers(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	return layers.Files(), ver, nil
+}
+
+// Layers returns configuration layers of the pinned revision or the base provider.
+// The base provider is not called when the configuration is rolled back, so a broken configuration source doesn't block the rollback.
+func (p *Provider) Layers(ctx context.Context) (config.Layers, int, error) {
+	pinned, ok, err := p.store.Pinned(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("while checking configuration rollback: %w", err)
+	}
+	if ok {
+		return pinned.Layers, 0, nil
+	}
+	return config.LayersOf(ctx, p.base)
+}
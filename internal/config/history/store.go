@@ -0,0 +1,376 @@
+package history
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+const (
+	historyDataKey  = "history.json.gz"
+	rollbackDataKey = "rollback.json.gz"
+	// rollbackSecretSuffix is appended to the history Secret name to get the name of the Secret with the pinned revision.
+	rollbackSecretSuffix = "-rollback"
+
+	// The rollback Secret is watched by the config watcher, so pinning a revision or cancelling a rollback reloads Botkube.
+	configWatchLabelKey   = "botkube.io/config-watch"
+	configWatchLabelValue = "true"
+
+	defaultLimit = 10
+	// pendingTriggerTTL limits how long a trigger waits for the configuration change it describes to be applied.
+	pendingTriggerTTL = 15 * time.Minute
+)
+
+var (
+	// ErrRevisionNotFound is returned when a given revision is not kept in the history.
+	ErrRevisionNotFound = errors.New("revision not found in the configuration history")
+	// ErrNoPreviousRevision is returned when there is no revision to roll back to.
+	ErrNoPreviousRevision = errors.New("there is no previous configuration revision")
+	// ErrRevisionApplied is returned when a given revision is already the current configuration.
+	ErrRevisionApplied = errors.New("revision is already applied")
+)
+
+// Entry holds a single applied configuration.
+type Entry struct {
+	Revision  int       `json:"revision"`
+	AppliedAt time.Time `json:"appliedAt"`
+	// Trigger describes who or what changed the configuration.
+	Trigger string `json:"trigger"`
+	// Changes lists changed values compared to the previous revision. Sensitive values are redacted.
+	Changes []string `json:"changes,omitempty"`
+	// Checksum identifies the effective configuration.
+	Checksum string `json:"checksum"`
+	// Layers are configuration sources the configuration was merged from.
+	Layers config.Layers `json:"layers"`
+}
+
+type state struct {
+	// Entries are ordered from the newest one.
+	Entries        []Entry         `json:"entries"`
+	PendingTrigger *pendingTrigger `json:"pendingTrigger,omitempty"`
+}
+
+type pendingTrigger struct {
+	Trigger string    `json:"trigger"`
+	SetAt   time.Time `json:"setAt"`
+}
+
+// Store keeps a bounded history of applied configurations in a Secret, and pins revisions the configuration is rolled back to.
+type Store struct {
+	log       logrus.FieldLogger
+	cli       kubernetes.Interface
+	namespace string
+	name      string
+	limit     int
+	now       func() time.Time
+}
+
+// NewStore returns a new Store instance.
+func NewStore(log logrus.FieldLogger, cli kubernetes.Interface, cfg config.ConfigHistory) *Store {
+	limit := cfg.Limit
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	return &Store{
+		log:       log,
+		cli:       cli,
+		namespace: cfg.Secret.Namespace,
+		name:      cfg.Secret.Name,
+		limit:     limit,
+		now:       time.Now,
+	}
+}
+
+// List returns all kept revisions, ordered from the newest one.
+func (s *Store) List(ctx context.Context) ([]Entry, error) {
+	_, st, err := s.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return st.Entries, nil
+}
+
+// SetTrigger describes the next configuration change, e.g. a command which persisted it. It's used once the change is recorded.
+func (s *Store) SetTrigger(ctx context.Context, trigger string) error {
+	return s.update(ctx, func(st *state) (bool, error) {
+		st.PendingTrigger = &pendingTrigger{Trigger: trigger, SetAt: s.now()}
+		return true, nil
+	})
+}
+
+// Record adds a given configuration to the history, unless the effective configuration didn't change since the latest revision.
+// It returns the recorded entry and true if the configuration was recorded.
+func (s *Store) Record(ctx context.Context, layers config.Layers) (Entry, bool, error) {
+	current, err := effectiveOf(layers)
+	if err != nil {
+		return Entry{}, false, fmt.Errorf("while loading configuration: %w", err)
+	}
+
+	var (
+		out      Entry
+		recorded bool
+	)
+	err = s.update(ctx, func(st *state) (bool, error) {
+		recorded = false
+
+		entry := Entry{
+			Revision:  1,
+			AppliedAt: s.now(),
+			Checksum:  current.checksum,
+			Layers:    layers,
+			Trigger:   "Initial configuration",
+		}
+		if len(st.Entries) > 0 {
+			latest := st.Entries[0]
+			if latest.Checksum == current.checksum {
+				return false, nil
+			}
+
+			entry.Revision = latest.Revision + 1
+			entry.Trigger = changedLayersTrigger(latest.Layers, layers)
+			previous, err := effectiveOf(latest.Layers)
+			if err != nil {
+				s.log.WithError(err).Warnf("Cannot load configuration of revision %d. Skipping changes...", latest.Revision)
+			} else {
+				entry.Changes = diff(previous.values, current.values)
+			}
+		}
+		if st.PendingTrigger != nil && s.now().Sub(st.PendingTrigger.SetAt) <= pendingTriggerTTL {
+			entry.Trigger = st.PendingTrigger.Trigger
+		}
+		st.PendingTrigger = nil
+
+		st.Entries = append([]Entry{entry}, st.Entries...)
+		if len(st.Entries) > s.limit {
+			st.Entries = st.Entries[:s.limit]
+		}
+		out, recorded = entry, true
+		return true, nil
+	})
+	if err != nil {
+		return Entry{}, false, err
+	}
+	return out, recorded, nil
+}
+
+// Rollback pins a given revision, so it's applied instead of the configuration sources until the rollback is cancelled.
+// If the revision is not positive, the revision preceding the current one is pinned. The `by` parameter describes who requested the rollback.
+func (s *Store) Rollback(ctx context.Context, revision int, by string) (Entry, error) {
+	_, st, err := s.load(ctx)
+	if err != nil {
+		return Entry{}, err
+	}
+	target, err := rollbackTarget(st.Entries, revision)
+	if err != nil {
+		return Entry{}, err
+	}
+
+	raw, err := encode(target)
+	if err != nil {
+		return Entry{}, err
+	}
+	err = s.apply(ctx, s.rollbackSecret(raw))
+	if err != nil {
+		return Entry{}, fmt.Errorf("while pinning revision %d: %w", target.Revision, err)
+	}
+
+	err = s.SetTrigger(ctx, fmt.Sprintf("Rollback to revision %d by %s", target.Revision, by))
+	if err != nil {
+		return Entry{}, err
+	}
+	return target, nil
+}
+
+// CancelRollback removes the pinned revision, so the configuration sources are applied again.
+// It returns false if the configuration is not rolled back.
+func (s *Store) CancelRollback(ctx context.Context, by string) (bool, error) {
+	err := s.cli.CoreV1().Secrets(s.namespace).Delete(ctx, s.rollbackName(), metav1.DeleteOptions{})
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("while deleting Secret with the pinned revision: %w", err)
+	}
+
+	err = s.SetTrigger(ctx, fmt.Sprintf("Rollback cancelled by %s", by))
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Pinned returns the revision the configuration is rolled back to. It returns false if the configuration is not rolled back.
+func (s *Store) Pinned(ctx context.Context) (Entry, bool, error) {
+	secret, err := s.cli.CoreV1().Secrets(s.namespace).Get(ctx, s.rollbackName(), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return Entry{}, false, nil
+	}
+	if err != nil {
+		return Entry{}, false, fmt.Errorf("while getting Secret with the pinned revision: %w", err)
+	}
+
+	var out Entry
+	if err := decode(secret.Data[rollbackDataKey], &out); err != nil {
+		return Entry{}, false, fmt.Errorf("while decoding pinned revision: %w", err)
+	}
+	return out, true, nil
+}
+
+func rollbackTarget(entries []Entry, revision int) (Entry, error) {
+	if revision <= 0 {
+		if len(entries) < 2 {
+			return Entry{}, ErrNoPreviousRevision
+		}
+		return entries[1], nil
+	}
+
+	for idx, entry := range entries {
+		if entry.Revision != revision {
+			continue
+		}
+		if idx == 0 || entry.Checksum == entries[0].Checksum {
+			return Entry{}, fmt.Errorf("%w: %d", ErrRevisionApplied, revision)
+		}
+		return entry, nil
+	}
+	return Entry{}, fmt.Errorf("%w: %d", ErrRevisionNotFound, revision)
+}
+
+// load returns the history Secret and its state. The Secret is nil if it doesn't exist yet.
+func (s *Store) load(ctx context.Context) (*v1.Secret, *state, error) {
+	secret, err := s.cli.CoreV1().Secrets(s.namespace).Get(ctx, s.name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, &state{}, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("while getting configuration history Secret: %w", err)
+	}
+
+	st := &state{}
+	if raw := secret.Data[historyDataKey]; len(raw) > 0 {
+		if err := decode(raw, st); err != nil {
+			return nil, nil, fmt.Errorf("while decoding configuration history: %w", err)
+		}
+	}
+	return secret, st, nil
+}
+
+// update modifies the history state with a given function, and saves it if the function reports a change.
+func (s *Store) update(ctx context.Context, fn func(st *state) (bool, error)) error {
+	return retry.OnError(retry.DefaultRetry, isConflict, func() error {
+		secret, st, err := s.load(ctx)
+		if err != nil {
+			return err
+		}
+		changed, err := fn(st)
+		if err != nil || !changed {
+			return err
+		}
+
+		raw, err := encode(st)
+		if err != nil {
+			return err
+		}
+		if secret == nil {
+			_, err = s.cli.CoreV1().Secrets(s.namespace).Create(ctx, &v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: s.name, Namespace: s.namespace},
+				Data:       map[string][]byte{historyDataKey: raw},
+			}, metav1.CreateOptions{})
+			return err
+		}
+
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+		secret.Data[historyDataKey] = raw
+		_, err = s.cli.CoreV1().Secrets(s.namespace).Update(ctx, secret, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// apply creates a given Secret, or replaces the data of the existing one.
+func (s *Store) apply(ctx context.Context, in *v1.Secret) error {
+	return retry.OnError(retry.DefaultRetry, isConflict, func() error {
+		secrets := s.cli.CoreV1().Secrets(s.namespace)
+		existing, err := secrets.Get(ctx, in.Name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			_, err = secrets.Create(ctx, in, metav1.CreateOptions{})
+			return err
+		}
+		if err != nil {
+			return err
+		}
+
+		existing.Labels = in.Labels
+		existing.Data = in.Data
+		_, err = secrets.Update(ctx, existing, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+func (s *Store) rollbackName() string {
+	return s.name + rollbackSecretSuffix
+}
+
+func (s *Store) rollbackSecret(raw []byte) *v1.Secret {
+	return &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      s.rollbackName(),
+			Namespace: s.namespace,
+			Labels: map[string]string{
+				configWatchLabelKey: configWatchLabelValue,
+			},
+		},
+		Data: map[string][]byte{rollbackDataKey: raw},
+	}
+}
+
+func isConflict(err error) bool {
+	return apierrors.IsConflict(err) || apierrors.IsAlreadyExists(err)
+}
+
+// encode marshals a given value to gzipped JSON, as configuration snapshots must fit into the Secret size limit.
+func encode(in any) ([]byte, error) {
+	raw, err := json.Marshal(in)
+	if err != nil {
+		return nil, fmt.Errorf("while marshaling: %w", err)
+	}
+
+	buf := new(bytes.Buffer)
+	w := gzip.NewWriter(buf)
+	if _, err := w.Write(raw); err != nil {
+		return nil, fmt.Errorf("while compressing: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("while compressing: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func decode(in []byte, out any) error {
+	r, err := gzip.NewReader(bytes.NewReader(in))
+	if err != nil {
+		return fmt.Errorf("while decompressing: %w", err)
+	}
+	defer r.Close()
+
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("while decompressing: %w", err)
+	}
+	return json.Unmarshal(raw, out)
+}
@@ -0,0 +1,230 @@
+package history
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+func TestStore_Record(t *testing.T) {
+	// given
+	ctx := context.Background()
+	store := newTestStore(fake.NewSimpleClientset(), 2)
+
+	initial := layersWith("info", "xoxb-initial")
+	changed := layersWith("debug", "xoxb-changed")
+
+	// when
+	first, recorded, err := store.Record(ctx, initial)
+
+	// then
+	require.NoError(t, err)
+	assert.True(t, recorded)
+	assert.Equal(t, 1, first.Revision)
+	assert.Equal(t, "Initial configuration", first.Trigger)
+
+	// when
+	_, recorded, err = store.Record(ctx, initial)
+
+	// then
+	require.NoError(t, err)
+	assert.False(t, recorded)
+
+	// when
+	second, recorded, err := store.Record(ctx, changed)
+
+	// then
+	require.NoError(t, err)
+	assert.True(t, recorded)
+	assert.Equal(t, 2, second.Revision)
+	assert.Equal(t, "Changed configuration sources: comm_config.yaml, global_config.yaml", second.Trigger)
+	assert.Contains(t, second.Changes, `~ settings.log.level: "info" → "debug"`)
+	for _, change := range second.Changes {
+		assert.NotContains(t, change, "xoxb-")
+	}
+
+	// when
+	require.NoError(t, store.SetTrigger(ctx, "`config edit minLevel warn` by @Alice"))
+	third, recorded, err := store.Record(ctx, initial)
+
+	// then
+	require.NoError(t, err)
+	assert.True(t, recorded)
+	assert.Equal(t, "`config edit minLevel warn` by @Alice", third.Trigger)
+
+	entries, err := store.List(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []int{3, 2}, revisionsOf(entries))
+}
+
+func TestStore_RecordIgnoresExpiredTrigger(t *testing.T) {
+	// given
+	ctx := context.Background()
+	store := newTestStore(fake.NewSimpleClientset(), 10)
+	_, _, err := store.Record(ctx, layersWith("info", "xoxb-token"))
+	require.NoError(t, err)
+
+	require.NoError(t, store.SetTrigger(ctx, "`config edit minLevel warn` by @Alice"))
+	store.now = func() time.Time {
+		return fixedNow().Add(time.Hour)
+	}
+
+	// when
+	entry, _, err := store.Record(ctx, layersWith("debug", "xoxb-token"))
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "Changed configuration sources: global_config.yaml", entry.Trigger)
+}
+
+func TestStore_Rollback(t *testing.T) {
+	// given
+	ctx := context.Background()
+	cli := fake.NewSimpleClientset()
+	store := newTestStore(cli, 10)
+	base := staticProvider{layers: layersWith("debug", "xoxb-token")}
+	provider := NewProvider(base, store)
+
+	for _, level := range []string{"info", "warn", "debug"} {
+		_, _, err := store.Record(ctx, layersWith(level, "xoxb-token"))
+		require.NoError(t, err)
+	}
+
+	// when
+	_, err := store.Rollback(ctx, 3, "@Alice")
+
+	// then
+	assert.ErrorIs(t, err, ErrRevisionApplied)
+
+	// when
+	_, err = store.Rollback(ctx, 42, "@Alice")
+
+	// then
+	assert.ErrorIs(t, err, ErrRevisionNotFound)
+
+	// when
+	pinned, err := store.Rollback(ctx, 0, "@Alice")
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, 2, pinned.Revision)
+
+	secret, err := cli.CoreV1().Secrets("botkube").Get(ctx, "botkube-config-history-rollback", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "true", secret.Labels["botkube.io/config-watch"])
+
+	layers, _, err := provider.Layers(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, layersWith("warn", "xoxb-token"), layers)
+
+	entry, recorded, err := store.Record(ctx, layers)
+	require.NoError(t, err)
+	assert.True(t, recorded)
+	assert.Equal(t, "Rollback to revision 2 by @Alice", entry.Trigger)
+
+	// when
+	cancelled, err := store.CancelRollback(ctx, "@Bob")
+
+	// then
+	require.NoError(t, err)
+	assert.True(t, cancelled)
+
+	layers, _, err = provider.Layers(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, base.layers, layers)
+
+	entry, _, err = store.Record(ctx, layers)
+	require.NoError(t, err)
+	assert.Equal(t, "Rollback cancelled by @Bob", entry.Trigger)
+
+	// when
+	cancelled, err = store.CancelRollback(ctx, "@Bob")
+
+	// then
+	require.NoError(t, err)
+	assert.False(t, cancelled)
+}
+
+func TestStore_RollbackWithoutPreviousRevision(t *testing.T) {
+	// given
+	ctx := context.Background()
+	store := newTestStore(fake.NewSimpleClientset(), 10)
+	_, _, err := store.Record(ctx, layersWith("info", "xoxb-token"))
+	require.NoError(t, err)
+
+	// when
+	_, err = store.Rollback(ctx, 0, "@Alice")
+
+	// then
+	assert.ErrorIs(t, err, ErrNoPreviousRevision)
+}
+
+type staticProvider struct {
+	layers config.Layers
+}
+
+func (p staticProvider) Configs(context.Context) (config.YAMLFiles, int, error) {
+	return p.layers.Files(), 1, nil
+}
+
+func (p staticProvider) Layers(context.Context) (config.Layers, int, error) {
+	return p.layers, 1, nil
+}
+
+func newTestStore(cli *fake.Clientset, limit int) *Store {
+	store := NewStore(loggerx.NewNoop(), cli, config.ConfigHistory{
+		Enabled: true,
+		Limit:   limit,
+		Secret: config.K8sResourceRef{
+			Name:      "botkube-config-history",
+			Namespace: "botkube",
+		},
+	})
+	store.now = fixedNow
+	return store
+}
+
+func fixedNow() time.Time {
+	return time.Date(2026, 10, 15, 10, 0, 0, 0, time.UTC)
+}
+
+func layersWith(logLevel, token string) config.Layers {
+	return config.Layers{
+		{
+			Name: "global_config.yaml",
+			Data: []byte(heredoc.Docf(`
+				settings:
+				  clusterName: dev
+				  log:
+				    level: %s
+			`, logLevel)),
+		},
+		{
+			Name: "comm_config.yaml",
+			Data: []byte(heredoc.Docf(`
+				communications:
+				  default-group:
+				    socketSlack:
+				      enabled: false
+				      botToken: %s
+			`, token)),
+		},
+	}
+}
+
+func revisionsOf(entries []Entry) []int {
+	out := make([]int, 0, len(entries))
+	for _, entry := range entries {
+		out = append(out, entry.Revision)
+	}
+	return out
+}
@@ -0,0 +1,251 @@
+package interpolation
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/multierror"
+)
+
+// Mode defines how references which cannot be resolved are handled.
+type Mode string
+
+const (
+	// LenientMode leaves references which cannot be resolved unchanged.
+	LenientMode Mode = "lenient"
+	// StrictMode fails loading the configuration if any reference cannot be resolved.
+	StrictMode Mode = "strict"
+	// DisabledMode disables the interpolation.
+	DisabledMode Mode = "disabled"
+)
+
+const (
+	refStart   = "${"
+	refEnd     = "}"
+	escapedRef = "$${"
+	filePrefix = "file:"
+	envPrefix  = "env:"
+	// defaultSep separates the environment variable name from the value used when it's not set or empty.
+	defaultSep = ":-"
+	yamlStr    = "!!str"
+	// stateFilePrefix is the name prefix of files with the state persisted by Botkube, e.g. `_runtime_state.yaml`.
+	stateFilePrefix = "_"
+)
+
+var envNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ParseMode returns the interpolation mode with a given name. The lenient mode is used if the name is empty.
+func ParseMode(in string) (Mode, error) {
+	switch mode := Mode(strings.ToLower(strings.TrimSpace(in))); mode {
+	case "":
+		return LenientMode, nil
+	case LenientMode, StrictMode, DisabledMode:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unknown interpolation mode %q, use one of: %s, %s, %s", in, LenientMode, StrictMode, DisabledMode)
+	}
+}
+
+var _ config.LayeredProvider = &Provider{}
+
+// Provider replaces `${ENV_VAR}`, `${ENV_VAR:-default}` and `${file:/path}` references in configuration values returned by the base provider.
+// A reference can be escaped as `$${...}`. Files with the state persisted by Botkube are not interpolated, as they contain values provided by chat users.
+type Provider struct {
+	base      config.Provider
+	mode      Mode
+	lookupEnv func(key string) (string, bool)
+	readFile  func(name string) ([]byte, error)
+}
+
+// NewProvider returns a new Provider instance.
+func NewProvider(base config.Provider, mode Mode) *Provider {
+	return &Provider{
+		base:      base,
+		mode:      mode,
+		lookupEnv: os.LookupEnv,
+		readFile:  os.ReadFile,
+	}
+}
+
+// Configs returns the base configuration files with all references replaced.
+func (p *Provider) Configs(ctx context.Context) (config.YAMLFiles, int, error) {
+	layers, ver, err := p.Layers(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	return layers.Files(), ver, nil
+}
+
+// Layers returns the base configuration layers with all references replaced.
+func (p *Provider) Layers(ctx context.Context) (config.Layers, int, error) {
+	layers, ver, err := config.LayersOf(ctx, p.base)
+	if err != nil {
+		return nil, 0, err
+	}
+	if p.mode == DisabledMode {
+		return layers, ver, nil
+	}
+
+	errs := multierror.New()
+	out := make(config.Layers, 0, len(layers))
+	for _, layer := range layers {
+		if isStateFile(layer.Name) {
+			out = append(out, layer)
+			continue
+		}
+		data, err := p.interpolateFile(layer.Data)
+		if err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("while interpolating %q: %w", layer.Name, err))
+			continue
+		}
+		out = append(out, config.Layer{Name: layer.Name, Data: data})
+	}
+	if err := errs.ErrorOrNil(); err != nil {
+		return nil, 0, err
+	}
+
+	return out, ver, nil
+}
+
+func (p *Provider) interpolateFile(file []byte) ([]byte, error) {
+	if !bytes.Contains(file, []byte(refStart)) {
+		return file, nil
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(file, &doc); err != nil {
+		return nil, fmt.Errorf("while unmarshaling configuration: %w", err)
+	}
+
+	errs := multierror.New()
+	walkValues(&doc, func(node *yaml.Node) {
+		if node.Tag != yamlStr || !strings.Contains(node.Value, refStart) {
+			return
+		}
+		value, resolvedWhole, err := p.interpolate(node.Value)
+		if err != nil {
+			errs = multierror.Append(errs, err)
+			return
+		}
+		node.Value = value
+		// a plain scalar which is a single reference gets its type from the resolved value, e.g. `port: ${PORT}` is a number
+		if resolvedWhole && node.Style == 0 {
+			node.Tag = ""
+		}
+	})
+	if err := errs.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+
+	out, err := yaml.Marshal(&doc)
+	if err != nil {
+		return nil, fmt.Errorf("while marshaling configuration: %w", err)
+	}
+	return out, nil
+}
+
+// interpolate replaces all references in a given value. It returns true if the whole value is a single resolved reference.
+func (p *Provider) interpolate(in string) (string, bool, error) {
+	var (
+		out           strings.Builder
+		errs          = multierror.New()
+		resolvedWhole bool
+	)
+	for rest := in; rest != ""; {
+		start := strings.Index(rest, "$")
+		if start < 0 {
+			out.WriteString(rest)
+			break
+		}
+		out.WriteString(rest[:start])
+		rest = rest[start:]
+
+		switch {
+		case strings.HasPrefix(rest, escapedRef):
+			out.WriteString(refStart)
+			rest = rest[len(escapedRef):]
+			continue
+		case !strings.HasPrefix(rest, refStart):
+			out.WriteString("$")
+			rest = rest[1:]
+			continue
+		}
+
+		end := strings.Index(rest, refEnd)
+		if end < 0 {
+			out.WriteString(rest)
+			break
+		}
+		raw := rest[:end+len(refEnd)]
+		rest = rest[end+len(refEnd):]
+
+		value, ok, err := p.resolve(raw[len(refStart) : len(raw)-len(refEnd)])
+		switch {
+		case err != nil && p.mode == StrictMode:
+			errs = multierror.Append(errs, err)
+		case ok && err == nil:
+			out.WriteString(value)
+			resolvedWhole = raw == in
+		default:
+			out.WriteString(raw)
+		}
+	}
+
+	if err := errs.ErrorOrNil(); err != nil {
+		return "", false, err
+	}
+	return out.String(), resolvedWhole, nil
+}
+
+// resolve returns the value of a given reference. It returns false if the expression is not a reference, e.g. `${ .Event.Name }`.
+func (p *Provider) resolve(expr string) (string, bool, error) {
+	if name, found := strings.CutPrefix(expr, filePrefix); found {
+		raw, err := p.readFile(filepath.Clean(name))
+		if err != nil {
+			return "", true, fmt.Errorf("while reading file referenced in %s%s%s: %w", refStart, expr, refEnd, err)
+		}
+		return strings.TrimRight(string(raw), "\r\n"), true, nil
+	}
+
+	name, def, hasDefault := strings.Cut(strings.TrimPrefix(expr, envPrefix), defaultSep)
+	if !envNameRegex.MatchString(name) {
+		return "", false, nil
+	}
+	value, found := p.lookupEnv(name)
+	switch {
+	case hasDefault && value == "":
+		return def, true, nil
+	case !found:
+		return "", true, fmt.Errorf("environment variable %q referenced in %s%s%s is not set", name, refStart, expr, refEnd)
+	}
+	return value, true, nil
+}
+
+// walkValues calls a given function for all scalar values. Mapping keys are skipped.
+func walkValues(node *yaml.Node, fn func(node *yaml.Node)) {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		fn(node)
+	case yaml.MappingNode:
+		for i := 1; i < len(node.Content); i += 2 {
+			walkValues(node.Content[i], fn)
+		}
+	default:
+		for _, child := range node.Content {
+			walkValues(child, fn)
+		}
+	}
+}
+
+func isStateFile(name string) bool {
+	return strings.HasPrefix(path.Base(filepath.ToSlash(name)), stateFilePrefix)
+}
@@ -0,0 +1,177 @@
+package interpolation
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+type staticProvider config.Layers
+
+func (s staticProvider) Configs(context.Context) (config.YAMLFiles, int, error) {
+	return config.Layers(s).Files(), 1, nil
+}
+
+func (s staticProvider) Layers(context.Context) (config.Layers, int, error) {
+	return config.Layers(s), 1, nil
+}
+
+func TestProviderLayers(t *testing.T) {
+	// given
+	provider := newTestProvider(LenientMode, staticProvider{
+		{
+			Name: "/config/global_config.yaml",
+			Data: []byte(heredoc.Doc(`
+				settings:
+				  clusterName: ${CLUSTER_NAME}
+				  metricsPort: ${METRICS_PORT}
+				  healthPort: "${METRICS_PORT}"
+				  kubeconfig: ${KUBECONFIG:-/etc/kubeconfig}
+				executors:
+				  k8s-tools:
+				    botkube/helm:
+				      config:
+				        helmDriver: ${env:HELM_DRIVER}
+				        defaultNamespace: ns-${ENVIRONMENT}-${MISSING}
+				        tokens:
+				          - ${file:/secrets/token}
+				        template: "{{ .Event.Name }} in ${ .Namespace } costs $$5 and $${ENVIRONMENT}"
+				${ENVIRONMENT}: key-is-not-interpolated
+			`)),
+		},
+		{
+			Name: "/config/_runtime_state.yaml",
+			Data: []byte("filter: ${file:/secrets/token}\n"),
+		},
+	})
+
+	// when
+	layers, ver, err := provider.Layers(context.Background())
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, 1, ver)
+	require.Len(t, layers, 2)
+	assert.Equal(t, "/config/global_config.yaml", layers[0].Name)
+	assert.Equal(t, heredoc.Doc(`
+		settings:
+		    clusterName: prod
+		    metricsPort: 2112
+		    healthPort: "2112"
+		    kubeconfig: /etc/kubeconfig
+		executors:
+		    k8s-tools:
+		        botkube/helm:
+		            config:
+		                helmDriver: secret
+		                defaultNamespace: ns-prod-${MISSING}
+		                tokens:
+		                    - s3cr3t
+		                template: "{{ .Event.Name }} in ${ .Namespace } costs $$5 and ${ENVIRONMENT}"
+		${ENVIRONMENT}: key-is-not-interpolated
+	`), string(layers[0].Data))
+	assert.Equal(t, "filter: ${file:/secrets/token}\n", string(layers[1].Data), "state files must not be interpolated")
+
+	// and single references get types from resolved values, unless they are quoted
+	var out struct {
+		Settings map[string]any `yaml:"settings"`
+	}
+	require.NoError(t, yaml.Unmarshal(layers[0].Data, &out))
+	assert.Equal(t, 2112, out.Settings["metricsPort"])
+	assert.Equal(t, "2112", out.Settings["healthPort"])
+}
+
+func TestProviderLayersStrictMode(t *testing.T) {
+	// given
+	provider := newTestProvider(StrictMode, staticProvider{
+		{
+			Name: "global_config.yaml",
+			Data: []byte(heredoc.Doc(`
+				settings:
+				  clusterName: ${CLUSTER_NAME}-${MISSING}
+				  kubeconfig: ${file:/missing}
+				  log:
+				    level: ${LOG_LEVEL:-info}
+			`)),
+		},
+	})
+
+	// when
+	_, _, err := provider.Layers(context.Background())
+
+	// then
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `while interpolating "global_config.yaml"`)
+	assert.Contains(t, err.Error(), `environment variable "MISSING" referenced in ${MISSING} is not set`)
+	assert.Contains(t, err.Error(), `while reading file referenced in ${file:/missing}: file does not exist`)
+	assert.NotContains(t, err.Error(), "LOG_LEVEL")
+}
+
+func TestProviderLayersDisabledMode(t *testing.T) {
+	// given
+	in := staticProvider{{Name: "global_config.yaml", Data: []byte("settings:\n  clusterName: ${CLUSTER_NAME}\n")}}
+	provider := newTestProvider(DisabledMode, in)
+
+	// when
+	layers, _, err := provider.Layers(context.Background())
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, config.Layers(in), layers)
+}
+
+func TestParseMode(t *testing.T) {
+	tests := []struct {
+		in          string
+		expected    Mode
+		expectedErr string
+	}{
+		{in: "", expected: LenientMode},
+		{in: "Strict", expected: StrictMode},
+		{in: "disabled", expected: DisabledMode},
+		{in: "loose", expectedErr: `unknown interpolation mode "loose", use one of: lenient, strict, disabled`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.in, func(t *testing.T) {
+			// when
+			mode, err := ParseMode(tc.in)
+
+			// then
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, mode)
+		})
+	}
+}
+
+func newTestProvider(mode Mode, base config.Provider) *Provider {
+	provider := NewProvider(base, mode)
+	env := map[string]string{
+		"CLUSTER_NAME": "prod",
+		"METRICS_PORT": "2112",
+		"HELM_DRIVER":  "secret",
+		"ENVIRONMENT":  "prod",
+		"KUBECONFIG":   "",
+	}
+	provider.lookupEnv = func(key string) (string, bool) {
+		value, found := env[key]
+		return value, found
+	}
+	provider.readFile = func(name string) ([]byte, error) {
+		if name == "/secrets/token" {
+			return []byte("s3cr3t\n"), nil
+		}
+		return nil, os.ErrNotExist
+	}
+	return provider
+}
@@ -0,0 +1,132 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+const (
+	configMapPrefix = "configmap://"
+	urlTimeout      = 30 * time.Second
+	// maxURLConfigSize protects against loading huge responses into memory.
+	maxURLConfigSize = 10 << 20
+)
+
+var httpCli = &http.Client{Timeout: urlTimeout}
+
+func isURL(path string) bool {
+	return strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://")
+}
+
+// readURL downloads configuration from a given URL. The layer name doesn't contain credentials and query parameters, as they may hold tokens.
+func readURL(ctx context.Context, rawURL string) (config.Layer, error) {
+	name := redactURL(rawURL)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return config.Layer{}, fmt.Errorf("while creating request for %q: %w", name, err)
+	}
+	res, err := httpCli.Do(req)
+	if err != nil {
+		return config.Layer{}, fmt.Errorf("while downloading %q: %w", name, redactURLError(err))
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return config.Layer{}, fmt.Errorf("while downloading %q: unexpected status code %d", name, res.StatusCode)
+	}
+	raw, err := io.ReadAll(io.LimitReader(res.Body, maxURLConfigSize))
+	if err != nil {
+		return config.Layer{}, fmt.Errorf("while reading %q: %w", name, err)
+	}
+	return config.Layer{Name: name, Data: raw}, nil
+}
+
+func redactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "invalid URL"
+	}
+	u.User = nil
+	u.RawQuery = ""
+	u.Fragment = ""
+	return u.String()
+}
+
+// redactURLError removes the URL from a given error, as it's already included in the error message.
+func redactURLError(err error) error {
+	if urlErr, ok := err.(*url.Error); ok {
+		return urlErr.Err
+	}
+	return err
+}
+
+// readConfigMap reads configuration from a ConfigMap in the form of `configmap://{namespace}/{name}[/{key}]`.
+// If the key is not specified, all ConfigMap keys are read in the alphabetical order.
+func (e *FileSystemProvider) readConfigMap(ctx context.Context, location string) (config.Layers, error) {
+	parts := strings.Split(strings.TrimPrefix(location, configMapPrefix), "/")
+	if len(parts) < 2 || len(parts) > 3 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("ConfigMap location %q doesn't follow the %s{namespace}/{name}[/{key}] syntax", location, configMapPrefix)
+	}
+	namespace, name := parts[0], parts[1]
+
+	newK8sCliFn := e.newK8sCliFn
+	if newK8sCliFn == nil {
+		newK8sCliFn = newK8sCli
+	}
+	cli, err := newK8sCliFn()
+	if err != nil {
+		return nil, fmt.Errorf("while creating K8s client to read %q: %w", location, err)
+	}
+	cm, err := cli.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("while getting ConfigMap %q: %w", location, err)
+	}
+
+	if len(parts) == 3 {
+		key := parts[2]
+		data, found := cm.Data[key]
+		if !found {
+			return nil, fmt.Errorf("key %q not found in ConfigMap %s/%s", key, namespace, name)
+		}
+		return config.Layers{{Name: location, Data: []byte(data)}}, nil
+	}
+
+	keys := make([]string, 0, len(cm.Data))
+	for key := range cm.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	out := make(config.Layers, 0, len(keys))
+	for _, key := range keys {
+		out = append(out, config.Layer{
+			Name: fmt.Sprintf("%s%s/%s/%s", configMapPrefix, namespace, name, key),
+			Data: []byte(cm.Data[key]),
+		})
+	}
+	return out, nil
+}
+
+// newK8sCli creates a K8s client before the configuration is loaded, so it uses the KUBECONFIG environment variable
+// or the in-cluster configuration.
+func newK8sCli() (kubernetes.Interface, error) {
+	restCfg, err := clientcmd.BuildConfigFromFlags("", os.Getenv("KUBECONFIG"))
+	if err != nil {
+		return nil, err
+	}
+	return kubernetes.NewForConfig(restCfg)
+}
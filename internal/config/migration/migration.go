@@ -0,0 +1,108 @@
+package migration
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"gopkg.in/yaml.v3"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+const yamlIndent = 2
+
+// Change describes a single modification of a configuration file.
+type Change struct {
+	// Path is the YAML path of the migrated property, e.g. `settings.configWatcher`.
+	Path string
+	// Description describes what was done with the property.
+	Description string
+	// ManualActionRequired is true if the migrated configuration needs to be reviewed or completed manually,
+	// e.g. when a setting no longer has an equivalent.
+	ManualActionRequired bool
+}
+
+// String returns the property path together with the change description.
+func (c Change) String() string {
+	return fmt.Sprintf("%s: %s", c.Path, c.Description)
+}
+
+// Result holds a migrated configuration file.
+type Result struct {
+	// Data is the migrated configuration file. It's the same as the input file if there are no changes.
+	Data []byte
+	// Changes lists all modifications in the order they were applied.
+	Changes []Change
+}
+
+// Migrate upgrades a given configuration file written for a previous Botkube version to the current configuration layout.
+// Deprecated properties are moved to their new place, while properties without a replacement are removed and reported
+// as changes which require manual action. Comments and the order of properties are preserved.
+func Migrate(file []byte) (Result, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(file, &doc); err != nil {
+		return Result{}, fmt.Errorf("while parsing configuration: %w", err)
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return Result{Data: file}, nil
+	}
+
+	root := doc.Content[0]
+	var changes []Change
+	for _, migrate := range rules {
+		changes = append(changes, migrate(root)...)
+	}
+	out := file
+	if len(changes) > 0 {
+		var buff bytes.Buffer
+		enc := yaml.NewEncoder(&buff)
+		enc.SetIndent(yamlIndent)
+		if err := enc.Encode(&doc); err != nil {
+			return Result{}, fmt.Errorf("while encoding migrated configuration: %w", err)
+		}
+		if err := enc.Close(); err != nil {
+			return Result{}, fmt.Errorf("while encoding migrated configuration: %w", err)
+		}
+		out = buff.Bytes()
+	}
+
+	// properties which are still unknown after migration are most likely typos, or settings this migration doesn't know about
+	unknown, err := config.FindUnknownKeys(out)
+	if err != nil {
+		return Result{}, fmt.Errorf("while looking for unknown properties: %w", err)
+	}
+	for _, key := range unknown {
+		changes = append(changes, Change{
+			Path:                 key.Path,
+			Description:          fmt.Sprintf("unknown property (line %d) couldn't be migrated automatically", key.Line),
+			ManualActionRequired: true,
+		})
+	}
+
+	return Result{Data: out, Changes: changes}, nil
+}
+
+// Diff returns a unified diff between the original and migrated configuration file, or an empty string if they are the same.
+func Diff(name string, original, migrated []byte) (string, error) {
+	if bytes.Equal(original, migrated) {
+		return "", nil
+	}
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(original),
+		B:        splitLines(migrated),
+		FromFile: name,
+		ToFile:   name + " (migrated)",
+		Context:  3,
+	})
+}
+
+// splitLines splits a given file into lines, keeping line endings. Unlike difflib.SplitLines, it doesn't add an empty line to files ending with a new line.
+func splitLines(file []byte) []string {
+	lines := strings.SplitAfter(string(file), "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
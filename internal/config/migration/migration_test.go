@@ -0,0 +1,173 @@
+package migration_test
+
+import (
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/internal/config/migration"
+)
+
+func TestMigrate(t *testing.T) {
+	// given
+	file := heredoc.Doc(`
+		communications:
+		  default-group:
+		    slack:
+		      enabled: true
+		      token: xoxb-token
+		      channels:
+		        default:
+		          name: general
+		      notification:
+		        type: short
+		    teams:
+		      enabled: false
+		sources:
+		  k8s-events:
+		    displayName: Kubernetes
+		    kubernetes:
+		      namespaces:
+		        include: [".*"]
+		      event:
+		        reason: BackOff
+		executors:
+		  kubectl-read-only:
+		    # Legacy kubectl executor
+		    kubectl:
+		      enabled: true
+		      namespaces:
+		        include: ["default", "team-a"]
+		        exclude: ["kube-system"]
+		      commands:
+		        verbs: ["get", "logs"]
+		        resources: ["pods"]
+		      defaultNamespace: default
+		filters:
+		  kubernetes:
+		    objectAnnotationChecker: true
+		    nodeEventsChecker: false
+		settings:
+		  clusterName: prod
+		  configWatcher: true
+		  clusterNme: typo
+	`)
+
+	// when
+	result, err := migration.Migrate([]byte(file))
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, heredoc.Doc(`
+		communications:
+		  default-group:
+		    socketSlack:
+		      enabled: true
+		      botToken: xoxb-token
+		      channels:
+		        default:
+		          name: general
+		sources:
+		  k8s-events:
+		    displayName: Kubernetes
+		    botkube/kubernetes:
+		      enabled: true
+		      config:
+		        namespaces:
+		          include: [".*"]
+		        event:
+		          reason:
+		            include:
+		              - BackOff
+		executors:
+		  kubectl-read-only:
+		    # Legacy kubectl executor
+		    botkube/kubectl:
+		      enabled: true
+		      config:
+		        defaultNamespace: default
+		        interactiveBuilder:
+		          allowed:
+		            verbs: ["get", "logs"]
+		            resources: ["pods"]
+		            namespaces: ["default", "team-a"]
+		settings:
+		  clusterName: prod
+		  clusterNme: typo
+		configWatcher:
+		  enabled: true
+	`), string(result.Data))
+
+	var changes []string
+	var manual []string
+	for _, change := range result.Changes {
+		changes = append(changes, change.Path)
+		if change.ManualActionRequired {
+			manual = append(manual, change.Path)
+		}
+	}
+	assert.Equal(t, []string{
+		"settings.configWatcher",
+		"communications.default-group.slack",
+		"communications.default-group.teams",
+		"communications.default-group.socketSlack.notification.type",
+		"sources.k8s-events.kubernetes",
+		"executors.kubectl-read-only.kubectl",
+		"filters.kubernetes.objectAnnotationChecker",
+		"filters.kubernetes.nodeEventsChecker",
+		"settings.clusterNme",
+	}, changes)
+	assert.Equal(t, []string{
+		"communications.default-group.slack",
+		"communications.default-group.teams",
+		"executors.kubectl-read-only.kubectl",
+		"settings.clusterNme",
+	}, manual)
+}
+
+func TestMigrateUpToDateConfig(t *testing.T) {
+	// given
+	file := heredoc.Doc(`
+		# Comments and formatting are kept as they are
+		settings:
+		    clusterName:   prod
+		configWatcher:
+		    enabled: true
+	`)
+
+	// when
+	result, err := migration.Migrate([]byte(file))
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, file, string(result.Data))
+	assert.Empty(t, result.Changes)
+}
+
+func TestDiff(t *testing.T) {
+	// given
+	original := []byte("settings:\n  configWatcher: true\n")
+	migrated := []byte("settings: {}\nconfigWatcher:\n  enabled: true\n")
+
+	// when
+	out, err := migration.Diff("config.yaml", original, migrated)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, heredoc.Doc(`
+		--- config.yaml
+		+++ config.yaml (migrated)
+		@@ -1,2 +1,3 @@
+		-settings:
+		-  configWatcher: true
+		+settings: {}
+		+configWatcher:
+		+  enabled: true
+	`), out)
+
+	out, err = migration.Diff("config.yaml", original, original)
+	require.NoError(t, err)
+	assert.Empty(t, out)
+}
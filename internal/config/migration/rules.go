@@ -0,0 +1,367 @@
+package migration
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	kubernetesSourcePlugin = "botkube/kubernetes"
+	kubectlExecutorPlugin  = "botkube/kubectl"
+)
+
+// namespaceNameRegex matches plain namespace names, as opposed to regular expressions used by the legacy kubectl executor.
+var namespaceNameRegex = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// rule migrates a single deprecated setting in place and returns applied changes.
+type rule func(root *yaml.Node) []Change
+
+// rules are applied in order, so a rule can rely on changes made by the previous ones.
+var rules = []rule{
+	migrateConfigWatcher,
+	migrateLegacySlack,
+	removeLegacyTeams,
+	removeNotificationTypes,
+	migrateKubernetesSources,
+	migrateKubectlExecutors,
+	removeLegacyFilters,
+}
+
+// migrateConfigWatcher moves `settings.configWatcher` to `configWatcher.enabled`.
+func migrateConfigWatcher(root *yaml.Node) []Change {
+	settings, _ := lookup(root, "settings")
+	if value, _ := lookup(settings, "configWatcher"); value == nil || value.Kind != yaml.ScalarNode {
+		return nil
+	}
+
+	key, value := take(settings, "configWatcher")
+	if len(settings.Content) == 0 {
+		take(root, "settings")
+	}
+	watcher, _ := lookup(root, "configWatcher")
+	if watcher == nil {
+		watcher = newMapping()
+		appendPair(root, newScalar("configWatcher"), watcher)
+	}
+	if existing, _ := lookup(watcher, "enabled"); existing != nil {
+		return []Change{{Path: "settings.configWatcher", Description: "removed, as configWatcher.enabled is already set"}}
+	}
+
+	key.Value = "enabled"
+	appendPair(watcher, key, value)
+	return []Change{{Path: "settings.configWatcher", Description: "moved to configWatcher.enabled"}}
+}
+
+// migrateLegacySlack replaces the legacy Slack integration with the Socket Slack one, which uses the same channel settings.
+func migrateLegacySlack(root *yaml.Node) []Change {
+	var out []Change
+	forEachCommGroup(root, func(path string, group *yaml.Node) {
+		slack, _ := lookup(group, "slack")
+		if slack == nil {
+			return
+		}
+		path += ".slack"
+
+		if existing, _ := lookup(group, "socketSlack"); existing != nil {
+			take(group, "slack")
+			out = append(out, Change{
+				Path:                 path,
+				Description:          "removed, as the legacy Slack app is no longer supported and socketSlack is already configured",
+				ManualActionRequired: true,
+			})
+			return
+		}
+
+		_, idx := lookup(group, "slack")
+		group.Content[idx].Value = "socketSlack"
+		if _, tokenIdx := lookup(slack, "token"); tokenIdx >= 0 {
+			slack.Content[tokenIdx].Value = "botToken"
+		}
+		out = append(out, Change{
+			Path:                 path,
+			Description:          "renamed to socketSlack, as the legacy Slack app is no longer supported. Create a Slack app with Socket Mode enabled and set its botToken and appToken",
+			ManualActionRequired: true,
+		})
+	})
+	return out
+}
+
+// removeLegacyTeams removes the legacy Microsoft Teams integration, which was replaced by the Botkube Cloud one.
+func removeLegacyTeams(root *yaml.Node) []Change {
+	var out []Change
+	forEachCommGroup(root, func(path string, group *yaml.Node) {
+		if key, _ := take(group, "teams"); key == nil {
+			return
+		}
+		out = append(out, Change{
+			Path:                 path + ".teams",
+			Description:          "removed, as Microsoft Teams is supported only via Botkube Cloud (cloudTeams)",
+			ManualActionRequired: true,
+		})
+	})
+	return out
+}
+
+// removeNotificationTypes removes the notification type of communication platforms, as short notifications are no longer supported.
+func removeNotificationTypes(root *yaml.Node) []Change {
+	var out []Change
+	forEachCommGroup(root, func(path string, group *yaml.Node) {
+		forEachPair(group, func(platform string, settings *yaml.Node) {
+			notification, _ := lookup(settings, "notification")
+			if key, _ := take(notification, "type"); key == nil {
+				return
+			}
+			if len(notification.Content) == 0 {
+				take(settings, "notification")
+			}
+			out = append(out, Change{
+				Path:        fmt.Sprintf("%s.%s.notification.type", path, platform),
+				Description: "removed, as notification types are no longer supported",
+			})
+		})
+	})
+	return out
+}
+
+// migrateKubernetesSources moves the built-in Kubernetes source configuration to the Kubernetes source plugin.
+func migrateKubernetesSources(root *yaml.Node) []Change {
+	sources, _ := lookup(root, "sources")
+
+	var out []Change
+	forEachPair(sources, func(name string, group *yaml.Node) {
+		old, idx := lookup(group, "kubernetes")
+		if old == nil {
+			return
+		}
+		path := fmt.Sprintf("sources.%s.kubernetes", name)
+		if existing, _ := lookup(group, kubernetesSourcePlugin); existing != nil {
+			out = append(out, Change{
+				Path:                 path,
+				Description:          fmt.Sprintf("not migrated, as %s is already configured", kubernetesSourcePlugin),
+				ManualActionRequired: true,
+			})
+			return
+		}
+
+		migrateEventConstraints(old)
+		if resources, _ := lookup(old, "resources"); resources != nil && resources.Kind == yaml.SequenceNode {
+			for _, resource := range resources.Content {
+				migrateEventConstraints(resource)
+			}
+		}
+
+		group.Content[idx].Value = kubernetesSourcePlugin
+		group.Content[idx+1] = newPluginMapping(newScalarOfType("true", "!!bool"), old)
+		out = append(out, Change{
+			Path:        path,
+			Description: fmt.Sprintf("moved to the configuration of the %s plugin", kubernetesSourcePlugin),
+		})
+	})
+	return out
+}
+
+// migrateEventConstraints converts event reasons and messages given as a single string into include lists.
+func migrateEventConstraints(node *yaml.Node) {
+	event, _ := lookup(node, "event")
+	for _, name := range []string{"reason", "message"} {
+		value, idx := lookup(event, name)
+		if value == nil || value.Kind != yaml.ScalarNode {
+			continue
+		}
+		include := newMapping()
+		appendPair(include, newScalar("include"), &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Content: []*yaml.Node{value}})
+		event.Content[idx+1] = include
+	}
+}
+
+// migrateKubectlExecutors moves the built-in kubectl executor configuration to the kubectl executor plugin.
+// Allowed verbs, resources and namespaces are used by the interactive command builder, while access is now restricted with RBAC.
+func migrateKubectlExecutors(root *yaml.Node) []Change {
+	executors, _ := lookup(root, "executors")
+
+	var out []Change
+	forEachPair(executors, func(name string, group *yaml.Node) {
+		old, idx := lookup(group, "kubectl")
+		if old == nil || old.Kind != yaml.MappingNode {
+			return
+		}
+		path := fmt.Sprintf("executors.%s.kubectl", name)
+		if existing, _ := lookup(group, kubectlExecutorPlugin); existing != nil {
+			out = append(out, Change{
+				Path:                 path,
+				Description:          fmt.Sprintf("not migrated, as %s is already configured", kubectlExecutorPlugin),
+				ManualActionRequired: true,
+			})
+			return
+		}
+
+		enabled, _ := lookup(old, "enabled")
+		if enabled == nil {
+			enabled = newScalarOfType("false", "!!bool")
+		}
+
+		cfg := newMapping()
+		if key, value := take(old, "defaultNamespace"); key != nil {
+			appendPair(cfg, key, value)
+		}
+
+		allowed := newMapping()
+		commands, _ := lookup(old, "commands")
+		for _, field := range []string{"verbs", "resources"} {
+			if key, value := take(commands, field); key != nil {
+				appendPair(allowed, key, value)
+			}
+		}
+
+		var dropped []string
+		namespaces, _ := lookup(old, "namespaces")
+		if include, _ := lookup(namespaces, "include"); include != nil && include.Kind == yaml.SequenceNode {
+			switch {
+			case areNamespaceNames(include.Content):
+				appendPair(allowed, newScalar("namespaces"), include)
+			case !isMatchAll(include.Content):
+				dropped = append(dropped, "namespaces.include")
+			}
+		}
+		if exclude, _ := lookup(namespaces, "exclude"); exclude != nil && len(exclude.Content) > 0 {
+			dropped = append(dropped, "namespaces.exclude")
+		}
+		if restrict, _ := lookup(old, "restrictAccess"); restrict != nil && restrict.Value == "true" {
+			dropped = append(dropped, "restrictAccess")
+		}
+
+		if len(allowed.Content) > 0 {
+			builder := newMapping()
+			appendPair(builder, newScalar("allowed"), allowed)
+			appendPair(cfg, newScalar("interactiveBuilder"), builder)
+		}
+
+		group.Content[idx].Value = kubectlExecutorPlugin
+		group.Content[idx+1] = newPluginMapping(enabled, cfg)
+
+		change := Change{
+			Path:        path,
+			Description: fmt.Sprintf("moved to the configuration of the %s plugin", kubectlExecutorPlugin),
+		}
+		if len(dropped) > 0 {
+			change.Description += fmt.Sprintf(". Dropped %s, as access is now restricted with RBAC. Configure the context.rbac property of the plugin instead", strings.Join(dropped, ", "))
+			change.ManualActionRequired = true
+		}
+		out = append(out, change)
+	})
+	return out
+}
+
+// removeLegacyFilters removes settings of the built-in filters, which are now a part of the Kubernetes source.
+func removeLegacyFilters(root *yaml.Node) []Change {
+	filters, _ := lookup(root, "filters")
+	kubernetes, _ := lookup(filters, "kubernetes")
+
+	var out []Change
+	for _, name := range []string{"objectAnnotationChecker", "nodeEventsChecker"} {
+		if key, _ := take(kubernetes, name); key == nil {
+			continue
+		}
+		out = append(out, Change{
+			Path:        "filters.kubernetes." + name,
+			Description: "removed, as the filter is built into the Kubernetes source",
+		})
+	}
+	if kubernetes != nil && len(kubernetes.Content) == 0 {
+		take(filters, "kubernetes")
+	}
+	if filters != nil && len(filters.Content) == 0 {
+		take(root, "filters")
+	}
+	return out
+}
+
+func forEachCommGroup(root *yaml.Node, fn func(path string, group *yaml.Node)) {
+	communications, _ := lookup(root, "communications")
+	forEachPair(communications, func(name string, group *yaml.Node) {
+		if group.Kind != yaml.MappingNode {
+			return
+		}
+		fn("communications."+name, group)
+	})
+}
+
+func areNamespaceNames(items []*yaml.Node) bool {
+	if len(items) == 0 {
+		return false
+	}
+	for _, item := range items {
+		if !namespaceNameRegex.MatchString(item.Value) {
+			return false
+		}
+	}
+	return true
+}
+
+func isMatchAll(items []*yaml.Node) bool {
+	for _, item := range items {
+		if item.Value == ".*" {
+			return true
+		}
+	}
+	return false
+}
+
+// lookup returns the value of a given key in a mapping node together with the index of the key node, or nil and -1 if there is no such key.
+func lookup(node *yaml.Node, key string) (*yaml.Node, int) {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil, -1
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1], i
+		}
+	}
+	return nil, -1
+}
+
+// take removes a given key from a mapping node and returns the removed key and value nodes, so they can be moved together with their comments.
+func take(node *yaml.Node, key string) (*yaml.Node, *yaml.Node) {
+	_, idx := lookup(node, key)
+	if idx < 0 {
+		return nil, nil
+	}
+	keyNode, value := node.Content[idx], node.Content[idx+1]
+	node.Content = append(node.Content[:idx], node.Content[idx+2:]...)
+	return keyNode, value
+}
+
+func forEachPair(node *yaml.Node, fn func(key string, value *yaml.Node)) {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		fn(node.Content[i].Value, node.Content[i+1])
+	}
+}
+
+func appendPair(node *yaml.Node, key, value *yaml.Node) {
+	node.Content = append(node.Content, key, value)
+}
+
+func newPluginMapping(enabled, cfg *yaml.Node) *yaml.Node {
+	out := newMapping()
+	appendPair(out, newScalar("enabled"), enabled)
+	appendPair(out, newScalar("config"), cfg)
+	return out
+}
+
+func newMapping() *yaml.Node {
+	return &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+}
+
+func newScalar(value string) *yaml.Node {
+	return newScalarOfType(value, "!!str")
+}
+
+func newScalarOfType(value, tag string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: value}
+}
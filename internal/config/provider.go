@@ -1,11 +1,21 @@
 package config
 
 import (
+	"fmt"
 	"os"
+	"strconv"
 
+	"github.com/kubeshop/botkube/internal/config/interpolation"
 	"github.com/kubeshop/botkube/pkg/config"
 )
 
+const (
+	// InterpolationModeEnvKey holds the mode of the configuration interpolation.
+	InterpolationModeEnvKey = "BOTKUBE_CONFIG_INTERPOLATION"
+	// StrictModeEnvKey enables rejecting unknown configuration properties.
+	StrictModeEnvKey = "BOTKUBE_CONFIG_STRICT"
+)
+
 // GetProvider resolves and returns paths for config files.
 // It reads them the 'BOTKUBE_CONFIG_PATHS' env variable. If not found, then it uses '--config' flag.
 func GetProvider(remoteCfgSyncEnabled bool, deployClient DeploymentClient) config.Provider {
@@ -19,3 +29,26 @@ func GetProvider(remoteCfgSyncEnabled bool, deployClient DeploymentClient) confi
 
 	return NewFileSystemProvider(configPathsFlag)
 }
+
+// GetInterpolationMode returns the mode of the configuration interpolation.
+// It reads it from the 'BOTKUBE_CONFIG_INTERPOLATION' env variable. If not found, then it uses '--config-interpolation' flag.
+func GetInterpolationMode() (interpolation.Mode, error) {
+	if mode := os.Getenv(InterpolationModeEnvKey); mode != "" {
+		return interpolation.ParseMode(mode)
+	}
+	return interpolation.ParseMode(configInterpolationFlag)
+}
+
+// IsStrictModeEnabled returns true if configuration with unknown properties should be rejected.
+// It reads it from the 'BOTKUBE_CONFIG_STRICT' env variable. If not found, then it uses '--config-strict' flag.
+func IsStrictModeEnabled() (bool, error) {
+	raw := os.Getenv(StrictModeEnvKey)
+	if raw == "" {
+		return configStrictFlag, nil
+	}
+	enabled, err := strconv.ParseBool(raw)
+	if err != nil {
+		return false, fmt.Errorf("while parsing %s: %w", StrictModeEnvKey, err)
+	}
+	return enabled, nil
+}
@@ -13,12 +13,12 @@ const (
 )
 
 // Get returns Reloader based on remoteCfgEnabled flag.
-func Get(remoteCfgEnabled bool, log logrus.FieldLogger, deployCli DeploymentClient, dynamicCli dynamic.Interface, restarter *Restarter, reporter analytics.Reporter, cfg config.Config, cfgVer int, resVerHolders ...ResourceVersionHolder) (Reloader, error) {
+func Get(remoteCfgEnabled bool, log logrus.FieldLogger, deployCli DeploymentClient, dynamicCli dynamic.Interface, restarter restarter, router RoutingUpdater, reporter analytics.Reporter, cfg config.Config, cfgVer int, resVerHolders ...ResourceVersionHolder) (Reloader, error) {
 	if remoteCfgEnabled {
 		log = log.WithField(typeKey, "remote")
-		return NewRemote(log, deployCli, restarter, cfg, cfgVer, resVerHolders...), nil
+		return NewRemote(log, deployCli, restarter, router, cfg, cfgVer, resVerHolders...), nil
 	}
 
 	log = log.WithField(typeKey, "in-cluster")
-	return NewInClusterConfigReloader(log, dynamicCli, cfg.ConfigWatcher, restarter, reporter)
+	return NewInClusterConfigReloader(log, dynamicCli, cfg.ConfigWatcher, cfg.ConfigCRD, restarter, reporter)
 }
@@ -15,6 +15,7 @@ import (
 	"k8s.io/client-go/tools/cache"
 
 	"github.com/kubeshop/botkube/internal/analytics"
+	"github.com/kubeshop/botkube/internal/config/crd"
 	"github.com/kubeshop/botkube/pkg/config"
 	"github.com/kubeshop/botkube/pkg/formatx"
 )
@@ -23,6 +24,7 @@ const (
 	labelKey   = "botkube.io/config-watch"
 	labelValue = "true"
 	dataKey    = "data"
+	specKey    = "spec"
 )
 
 var (
@@ -49,12 +51,19 @@ type InClusterConfigReloader struct {
 	restarter restarter
 
 	informerFactory dynamicinformer.DynamicSharedInformerFactory
+	// crdInformerFactory watches BotkubeConfig resources in all namespaces. It is nil if they are disabled.
+	crdInformerFactory dynamicinformer.DynamicSharedInformerFactory
 }
 
-func NewInClusterConfigReloader(log logrus.FieldLogger, cli dynamic.Interface, cfg config.CfgWatcher, restarter restarter, reporter analytics.Reporter) (*InClusterConfigReloader, error) {
+func NewInClusterConfigReloader(log logrus.FieldLogger, cli dynamic.Interface, cfg config.CfgWatcher, crdCfg config.ConfigCRD, restarter restarter, reporter analytics.Reporter) (*InClusterConfigReloader, error) {
 	informerResyncPeriod := cfg.InCluster.InformerResyncPeriod
 	informerFactory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(cli, informerResyncPeriod, cfg.Deployment.Namespace, tweakListOptions)
-	return &InClusterConfigReloader{log: log, cli: cli, cfg: cfg, reporter: reporter, restarter: restarter, informerFactory: informerFactory}, nil
+
+	var crdInformerFactory dynamicinformer.DynamicSharedInformerFactory
+	if crdCfg.Enabled {
+		crdInformerFactory = dynamicinformer.NewDynamicSharedInformerFactory(cli, informerResyncPeriod)
+	}
+	return &InClusterConfigReloader{log: log, cli: cli, cfg: cfg, reporter: reporter, restarter: restarter, informerFactory: informerFactory, crdInformerFactory: crdInformerFactory}, nil
 }
 
 func (l *InClusterConfigReloader) Do(ctx context.Context) error {
@@ -69,11 +78,25 @@ func (l *InClusterConfigReloader) Do(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("while adding event handler for secrets: %w", err)
 	}
+	if l.crdInformerFactory != nil {
+		crdEventHandler := newCRDEventHandler(ctx, l.log.WithField("subcomponent", "crdEventHandler"), l.restarter)
+		_, err = l.crdInformerFactory.ForResource(crd.GVR).Informer().AddEventHandler(crdEventHandler)
+		if err != nil {
+			return fmt.Errorf("while adding event handler for %s resources: %w", crd.Kind, err)
+		}
+	}
 
 	l.log.Info("Starting informers...")
 	l.informerFactory.Start(ctx.Done())
+	if l.crdInformerFactory != nil {
+		l.crdInformerFactory.Start(ctx.Done())
+		defer l.crdInformerFactory.Shutdown()
+	}
 	l.log.Info("Waiting for cache sync...")
 	l.informerFactory.WaitForCacheSync(ctx.Done())
+	if l.crdInformerFactory != nil {
+		l.crdInformerFactory.WaitForCacheSync(ctx.Done())
+	}
 	l.log.Info("Cache synced successfully.")
 	defer l.informerFactory.Shutdown()
 
@@ -92,10 +115,20 @@ type genericEventHandler struct {
 	log       logrus.FieldLogger
 	ctx       context.Context
 	restarter restarter
+
+	// contentKey is the top-level field compared on updates.
+	contentKey string
+	// labelRequired is set if only objects with the config watch label are handled.
+	labelRequired bool
 }
 
 func newGenericEventHandler(ctx context.Context, log logrus.FieldLogger, restarter restarter) *genericEventHandler {
-	return &genericEventHandler{log: log, ctx: ctx, restarter: restarter}
+	return &genericEventHandler{log: log, ctx: ctx, restarter: restarter, contentKey: dataKey, labelRequired: true}
+}
+
+// newCRDEventHandler returns handler for BotkubeConfig resources, which are always watched, regardless of the labels.
+func newCRDEventHandler(ctx context.Context, log logrus.FieldLogger, restarter restarter) *genericEventHandler {
+	return &genericEventHandler{log: log, ctx: ctx, restarter: restarter, contentKey: specKey}
 }
 
 func (g *genericEventHandler) OnAdd(obj interface{}, isInInitialList bool) {
@@ -119,7 +152,7 @@ func (g *genericEventHandler) OnAdd(obj interface{}, isInInitialList bool) {
 	// This shouldn't happen at all, as we use FilteredDynamicSharedInformerFactory
 	// which filters out objects without the label. However, fake K8s client doesn't seem to support labelSelector in tweakListOptions - at least in OnAdd events.
 	// Controversial decision here: I decided to handle this case anyway, just in case.
-	if unstrObj.GetLabels()[labelKey] != labelValue {
+	if g.labelRequired && unstrObj.GetLabels()[labelKey] != labelValue {
 		log.Debug("label is not set. Skipping...")
 		return
 	}
@@ -156,8 +189,8 @@ func (g *genericEventHandler) OnUpdate(oldObj, newObj interface{}) {
 
 	log.Debug("Comparing content...")
 	// both Secret and ConfigMap have Data field
-	oldData := unstrOldObj.Object[dataKey]
-	newData := unstrNewObj.Object[dataKey]
+	oldData := unstrOldObj.Object[g.contentKey]
+	newData := unstrNewObj.Object[g.contentKey]
 
 	if reflect.DeepEqual(oldData, newData) {
 		g.log.Debug("Content is the same. Skipping...")
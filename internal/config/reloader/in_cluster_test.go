@@ -229,6 +229,7 @@ func TestInClusterConfigReloader_Do(t *testing.T) {
 				loggerx.NewNoop(),
 				dynamicCli,
 				cfg,
+				config.ConfigCRD{},
 				restarter,
 				analytics.NewNoopReporter(),
 			)
@@ -0,0 +1,103 @@
+package reloader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+const defaultSyncPollInterval = 5 * time.Second
+
+// ConfigLoaderFn loads the latest configuration.
+type ConfigLoaderFn func(ctx context.Context) (config.Config, error)
+
+// InProcessRestarter reloads the configuration without restarting the Deployment.
+// It waits until the changed configuration is propagated to the configuration files, and then requests
+// a graceful restart of all components. An invalid configuration is never applied.
+type InProcessRestarter struct {
+	log          logrus.FieldLogger
+	currentCfg   config.Config
+	syncTimeout  time.Duration
+	pollInterval time.Duration
+	loadFn       ConfigLoaderFn
+	sendMsgFn    SendMessageFn
+	reloadFn     func()
+
+	inProgress atomic.Bool
+}
+
+// NewInProcessRestarter returns new InProcessRestarter. The reloadFn is called once the new configuration is ready to be applied.
+func NewInProcessRestarter(log logrus.FieldLogger, currentCfg config.Config, loadFn ConfigLoaderFn, sendMsgFn SendMessageFn, reloadFn func()) *InProcessRestarter {
+	return &InProcessRestarter{
+		log:          log,
+		currentCfg:   currentCfg,
+		syncTimeout:  currentCfg.ConfigWatcher.HotReload.SyncTimeout,
+		pollInterval: defaultSyncPollInterval,
+		loadFn:       loadFn,
+		sendMsgFn:    sendMsgFn,
+		reloadFn:     reloadFn,
+	}
+}
+
+// Do waits for the changed configuration and requests reloading all components.
+func (r *InProcessRestarter) Do(ctx context.Context) error {
+	if !r.inProgress.CompareAndSwap(false, true) {
+		r.log.Debug("Reload already in progress. Skipping...")
+		return nil
+	}
+	defer r.inProgress.Store(false)
+
+	r.log.Info("Reload requested. Waiting for the changed configuration...")
+	if err := r.waitForChangedConfig(ctx); err != nil {
+		return err
+	}
+
+	err := r.sendMsgFn(fmt.Sprintf(reloadMsgFmt, r.currentCfg.Settings.ClusterName))
+	if err != nil {
+		r.log.Errorf("while sending reload message: %s", err.Error())
+		// continue anyway, this is a non-blocking error
+	}
+
+	r.log.Info("Reloading all components with the changed configuration...")
+	r.reloadFn()
+	return nil
+}
+
+func (r *InProcessRestarter) waitForChangedConfig(ctx context.Context) error {
+	if r.syncTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.syncTimeout)
+		defer cancel()
+	}
+
+	ticker := time.NewTicker(r.pollInterval)
+	defer ticker.Stop()
+
+	var lastErr error
+	for {
+		newCfg, err := r.loadFn(ctx)
+		switch {
+		case err != nil:
+			// ConfigMaps and Secrets are propagated independently, so the configuration may be temporarily invalid
+			lastErr = err
+			r.log.WithError(err).Debug("Cannot load the changed configuration. Retrying...")
+		case !reflect.DeepEqual(newCfg, r.currentCfg):
+			return nil
+		default:
+			lastErr = errors.New("configuration didn't change")
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("while waiting for the changed configuration: %w", lastErr)
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package reloader
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+func TestInProcessRestarter_Do(t *testing.T) {
+	// given
+	currentCfg := config.Config{Settings: config.Settings{ClusterName: "prod"}}
+	currentCfg.ConfigWatcher.HotReload.SyncTimeout = time.Second
+
+	changedCfg := currentCfg
+	changedCfg.Settings.MetricsPort = "2113"
+
+	responses := []struct {
+		cfg config.Config
+		err error
+	}{
+		{cfg: currentCfg},                          // ConfigMap not propagated yet
+		{err: errors.New("invalid configuration")}, // Secret not propagated yet
+		{cfg: changedCfg},
+	}
+
+	var (
+		calls    int
+		msgs     []string
+		reloaded int
+	)
+	restarter := NewInProcessRestarter(loggerx.NewNoop(), currentCfg, func(context.Context) (config.Config, error) {
+		res := responses[calls]
+		calls++
+		return res.cfg, res.err
+	}, func(msg string) error {
+		msgs = append(msgs, msg)
+		return nil
+	}, func() {
+		reloaded++
+	})
+	restarter.pollInterval = time.Millisecond
+
+	// when
+	err := restarter.Do(context.Background())
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, 1, reloaded)
+	assert.Equal(t, []string{":arrows_counterclockwise: Configuration reload requested for cluster 'prod'. Hold on a sec..."}, msgs)
+}
+
+func TestInProcessRestarter_DoTimeout(t *testing.T) {
+	tests := []struct {
+		name        string
+		loadErr     error
+		expectedErr string
+	}{
+		{
+			name:        "configuration didn't change",
+			expectedErr: "while waiting for the changed configuration: configuration didn't change",
+		},
+		{
+			name:        "configuration is invalid",
+			loadErr:     errors.New("invalid configuration"),
+			expectedErr: "while waiting for the changed configuration: invalid configuration",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			currentCfg := config.Config{}
+			currentCfg.ConfigWatcher.HotReload.SyncTimeout = 20 * time.Millisecond
+
+			reloaded := false
+			restarter := NewInProcessRestarter(loggerx.NewNoop(), currentCfg, func(context.Context) (config.Config, error) {
+				return currentCfg, tc.loadErr
+			}, func(string) error {
+				return nil
+			}, func() {
+				reloaded = true
+			})
+			restarter.pollInterval = time.Millisecond
+
+			// when
+			err := restarter.Do(context.Background())
+
+			// then
+			assert.EqualError(t, err, tc.expectedErr)
+			assert.False(t, reloaded)
+		})
+	}
+}
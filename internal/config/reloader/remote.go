@@ -22,8 +22,13 @@ type DeploymentClient interface {
 	GetConfigWithResourceVersion(ctx context.Context) (remote.Deployment, error)
 }
 
+// RoutingUpdater updates the routing configuration without restarting the app.
+type RoutingUpdater interface {
+	Update(cfg config.Routing)
+}
+
 // NewRemote returns new RemoteConfigReloader.
-func NewRemote(log logrus.FieldLogger, deployCli DeploymentClient, restarter *Restarter, cfg config.Config, cfgVer int, resVerHolders ...ResourceVersionHolder) *RemoteConfigReloader {
+func NewRemote(log logrus.FieldLogger, deployCli DeploymentClient, restarter restarter, router RoutingUpdater, cfg config.Config, cfgVer int, resVerHolders ...ResourceVersionHolder) *RemoteConfigReloader {
 	return &RemoteConfigReloader{
 		log:           log,
 		currentCfg:    cfg,
@@ -32,6 +37,7 @@ func NewRemote(log logrus.FieldLogger, deployCli DeploymentClient, restarter *Re
 		deployCli:     deployCli,
 		resVerHolders: resVerHolders,
 		restarter:     restarter,
+		router:        router,
 	}
 }
 
@@ -45,7 +51,8 @@ type RemoteConfigReloader struct {
 	resVersion int
 
 	deployCli DeploymentClient
-	restarter *Restarter
+	restarter restarter
+	router    RoutingUpdater
 }
 
 // Do starts the remote config reloader.
@@ -144,6 +151,9 @@ func (u *RemoteConfigReloader) compareResVer(newResVer int) (bool, error) {
 	return true, nil
 }
 
+// routingConfigPath is the name of the config field with routing rules, which can be updated without restart.
+const routingConfigPath = "Routing"
+
 type configDiff struct {
 	shouldRestart bool
 }
@@ -180,11 +190,22 @@ func (u *RemoteConfigReloader) processNewConfig(newCfgBytes []byte, newResVer in
 	}
 
 	var paths []string
+	routingOnly := true
 	for _, change := range changelog {
 		paths = append(paths, fmt.Sprintf(`- "%s"`, strings.Join(change.Path, ".")))
+		if len(change.Path) == 0 || change.Path[0] != routingConfigPath {
+			routingOnly = false
+		}
 	}
 	u.log.Debugf("detected config changes on paths:\n%s", strings.Join(paths, "\n"))
 
+	if routingOnly && u.router != nil {
+		u.router.Update(newCfg.Routing)
+		u.currentCfg = *newCfg
+		u.log.Infof("Successfully applied routing changes from config version (%d) without restart", newResVer)
+		return configDiff{}, nil
+	}
+
 	// TODO(https://github.com/kubeshop/botkube/issues/1012): check if notifications are enabled and if so, do not restart the app
 
 	u.currentCfg = *newCfg
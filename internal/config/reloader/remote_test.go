@@ -104,6 +104,56 @@ func TestRemote_ProcessConfig(t *testing.T) {
 	}
 }
 
+func TestRemote_ProcessConfigRoutingOnly(t *testing.T) {
+	// given
+	router := &sampleRouter{}
+	remoteReloader := RemoteConfigReloader{
+		log:           loggerx.NewNoop(),
+		interval:      time.Minute,
+		resVerHolders: []ResourceVersionHolder{&sampleResVerHolder{2}},
+		currentCfg:    fixConfig(true),
+		resVersion:    2,
+		router:        router,
+	}
+	newCfg := fixConfigStr(true) + heredoc.Doc(`
+		routing:
+		  enabled: true
+		  rules:
+		    - labels:
+		        team: payments
+		      channels: ["payments-alerts"]
+		`)
+
+	expectedRouting := config.Routing{
+		Enabled: true,
+		Rules: []config.RoutingRule{
+			{
+				Labels:   map[string]string{"team": "payments"},
+				Channels: []string{"payments-alerts"},
+			},
+		},
+	}
+
+	// when
+	cfgDiff, err := remoteReloader.processNewConfig([]byte(newCfg), 3)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, configDiff{}, cfgDiff)
+	assert.Equal(t, 3, remoteReloader.resVersion)
+	assert.Equal(t, expectedRouting, remoteReloader.currentCfg.Routing)
+	require.Len(t, router.updates, 1)
+	assert.Equal(t, expectedRouting, router.updates[0])
+}
+
+type sampleRouter struct {
+	updates []config.Routing
+}
+
+func (s *sampleRouter) Update(cfg config.Routing) {
+	s.updates = append(s.updates, cfg)
+}
+
 type sampleResVerHolder struct {
 	resVer int
 }
@@ -149,18 +199,151 @@ func fixConfig(actionEnabled bool) config.Config {
 				Level: "error",
 			},
 			InformersResyncPeriod: 30 * time.Minute,
+			Execution: config.Execution{
+				InProgressMessageDelay: 5 * time.Second,
+			},
+			Authorization: config.Authorization{
+				OPA: config.OPAAuthorization{
+					Path:    "botkube/authz/allow",
+					Timeout: 5 * time.Second,
+				},
+			},
+			Incidents: config.Incidents{
+				SuppressFor: time.Hour,
+				Retention:   24 * time.Hour,
+			},
+			FloodProtection: config.FloodProtection{
+				MaxNotifications: 30,
+				Window:           time.Minute,
+			},
+			Onboarding: config.Onboarding{
+				Enabled: true,
+			},
 			SystemConfigMap: config.K8sResourceRef{
 				Name:      "botkube-system",
 				Namespace: "botkube",
 			},
+			ConfigHistory: config.ConfigHistory{
+				Limit: 10,
+				Secret: config.K8sResourceRef{
+					Name:      "botkube-config-history",
+					Namespace: "botkube",
+				},
+			},
+			Tracing: config.Tracing{
+				Protocol:    config.GRPCTracingProtocol,
+				SampleRatio: 1,
+				ServiceName: "botkube",
+			},
+			Metrics: config.Metrics{
+				MaxLabelValues: 100,
+			},
+			AuditLog: config.AuditLog{
+				Retention:     720 * time.Hour,
+				FlushInterval: 5 * time.Second,
+				File: config.AuditLogFile{
+					Directory: "/tmp/botkube-audit",
+				},
+				Elasticsearch: config.AuditLogElasticsearch{
+					IndexPrefix: "botkube-audit",
+				},
+				Webhook: config.AuditLogWebhook{
+					Timeout: 30 * time.Second,
+				},
+				S3: config.AuditLogS3{
+					Prefix: "audit",
+				},
+			},
+			Redaction: config.Redaction{
+				Replacement:    "*** REDACTED ***",
+				MinValueLength: 6,
+				Secrets: config.RedactionSecrets{
+					RefreshInterval: 5 * time.Minute,
+				},
+				EnvVars: config.RedactionEnvVars{
+					Enabled: true,
+					Names:   []string{"*TOKEN*", "*PASSWORD*", "*SECRET*", "*API_KEY*"},
+				},
+			},
+			EventPipeline: config.EventPipeline{
+				Ingest: config.PipelineStage{
+					Workers:   4,
+					QueueSize: 1000,
+					Overflow:  config.BlockPipelineOverflowPolicy,
+				},
+				Send: config.PipelineStage{
+					Workers:   16,
+					QueueSize: 1000,
+					Overflow:  config.BlockPipelineOverflowPolicy,
+				},
+			},
+			EventBuffer: config.EventBuffer{
+				Path:          "/var/lib/botkube/event-buffer.db",
+				MaxEntries:    1000,
+				MaxAge:        24 * time.Hour,
+				RetryInterval: 30 * time.Second,
+			},
+			Shutdown: config.Shutdown{
+				DrainTimeout: 20 * time.Second,
+				Notice: config.ShutdownNotice{
+					Message: "Botkube is shutting down. Notifications will be sent again once it's started.",
+				},
+			},
+			LeaderElection: config.LeaderElection{
+				Lease: config.K8sResourceRef{
+					Name:      "botkube-leader",
+					Namespace: "botkube",
+				},
+				LeaseDuration: 15 * time.Second,
+				RenewDeadline: 10 * time.Second,
+				RetryPeriod:   2 * time.Second,
+			},
+			Sharding: config.Sharding{
+				Shards:      1,
+				Assignments: []config.ShardAssignment{},
+				Plugins:     []string{"botkube/kubernetes"},
+				Lease: config.K8sResourceRef{
+					Name:      "botkube-shard",
+					Namespace: "botkube",
+				},
+				LeaseDuration: 15 * time.Second,
+				RenewDeadline: 10 * time.Second,
+				RetryPeriod:   2 * time.Second,
+			},
 		},
 		Plugins: config.PluginManagement{
 			CacheDir: "/tmp",
+			Security: config.PluginSecurity{
+				MTLS: config.PluginMTLS{
+					Mode:         config.AutoPluginMTLSMode,
+					CertValidity: 24 * time.Hour,
+				},
+				AuthTokens: true,
+			},
 		},
 		ConfigWatcher: config.CfgWatcher{
 			Remote: config.RemoteCfgWatcher{
 				PollInterval: 15 * time.Second,
 			},
+			HotReload: config.HotReloadCfgWatcher{
+				SyncTimeout:  3 * time.Minute,
+				DrainTimeout: 30 * time.Second,
+			},
+		},
+		ConfigCRD: config.ConfigCRD{
+			RBACGroupPrefix: "botkube-config:",
+			Webhook: config.ConfigCRDWebhook{
+				Port:    2116,
+				CertDir: "/tmp/botkube-webhook/serving-certs",
+			},
+		},
+		GitSync: config.GitSync{
+			Branch:       "main",
+			PollInterval: time.Minute,
+			Webhook: config.GitSyncWebhook{
+				Port: 2117,
+			},
+			CacheDir: "/tmp/botkube-git",
 		},
 	}
 }
@@ -39,3 +39,34 @@ func GetConfig() (Config, bool) {
 func IsEnabled() bool {
 	return os.Getenv(ProviderIdentifierEnvKey) != ""
 }
+
+const (
+	// ServerURLEnvKey holds the base URL of a self-hosted configuration server.
+	ServerURLEnvKey = "CONFIG_SERVER_URL"
+	// ServerAgentIDEnvKey holds the agent identifier used by a self-hosted configuration server.
+	ServerAgentIDEnvKey = "CONFIG_SERVER_AGENT_ID"
+	// ServerTokenEnvKey holds the bearer token for a self-hosted configuration server.
+	//nolint:gosec // warns us about 'Potential hardcoded credentials' but there is no security issue here
+	ServerTokenEnvKey = "CONFIG_SERVER_TOKEN"
+)
+
+// ServerConfig holds configuration for a self-hosted configuration server.
+type ServerConfig struct {
+	URL     string
+	AgentID string
+	Token   string
+}
+
+// GetServerConfig returns configuration for a self-hosted configuration server if it is set.
+func GetServerConfig() (ServerConfig, bool) {
+	url := os.Getenv(ServerURLEnvKey)
+	if url == "" {
+		return ServerConfig{}, false
+	}
+
+	return ServerConfig{
+		URL:     url,
+		AgentID: os.Getenv(ServerAgentIDEnvKey),
+		Token:   os.Getenv(ServerTokenEnvKey),
+	}, true
+}
@@ -0,0 +1,235 @@
+// Package server implements a reference server for the open remote configuration API described in the
+// `docs/remote-config-api/openapi.yaml` file.
+package server
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+
+	"github.com/kubeshop/botkube/internal/config/remote"
+)
+
+const (
+	agentIDVarName = "agentID"
+	maxStatusBytes = 64 * 1024
+)
+
+var agentIDPattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._-]*$`)
+
+// Server serves configuration of agents from a directory, where each agent has its own `{agentID}.yaml` file.
+// The configuration version is the modification time of the file in milliseconds. Agent statuses are kept in memory.
+type Server struct {
+	log    logrus.FieldLogger
+	dir    string
+	tokens map[string]string
+	now    func() time.Time
+
+	mu       sync.RWMutex
+	statuses map[string]remote.AgentStatus
+}
+
+// New returns a new Server instance. Each agent is authenticated with its own bearer token from tokens, indexed by agent IDs,
+// so an agent can't read configuration of other agents. If tokens are nil, requests are not authenticated.
+func New(log logrus.FieldLogger, dir string, tokens map[string]string) *Server {
+	return &Server{
+		log:      log,
+		dir:      dir,
+		tokens:   tokens,
+		now:      time.Now,
+		statuses: map[string]remote.AgentStatus{},
+	}
+}
+
+// Handler returns the HTTP handler which serves the API.
+func (s *Server) Handler() http.Handler {
+	router := mux.NewRouter()
+	agents := router.PathPrefix(fmt.Sprintf("/v1/agents/{%s}", agentIDVarName)).Subrouter()
+	agents.Use(s.validateAgentID, s.authenticate)
+	agents.HandleFunc("/config", s.getConfig).Methods(http.MethodGet)
+	agents.HandleFunc("/config/version", s.getConfigVersion).Methods(http.MethodGet)
+	agents.HandleFunc("/status", s.reportStatus).Methods(http.MethodPut)
+	agents.HandleFunc("/status", s.getStatus).Methods(http.MethodGet)
+	return router
+}
+
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.tokens == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		// agents without tokens are rejected, as the comparison with an empty token always fails
+		expected := s.tokens[mux.Vars(r)[agentIDVarName]]
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || expected == "" || subtle.ConstantTimeCompare([]byte(token), []byte(expected)) != 1 {
+			s.writeError(w, "Invalid or missing bearer token", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// LoadTokens loads agent tokens from a given YAML file, which maps agent IDs to their tokens.
+func LoadTokens(path string) (map[string]string, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("while reading tokens file: %w", err)
+	}
+
+	tokens := map[string]string{}
+	if err := yaml.Unmarshal(data, &tokens); err != nil {
+		return nil, fmt.Errorf("while unmarshaling tokens file: %w", err)
+	}
+	if len(tokens) == 0 {
+		return nil, errors.New("tokens file doesn't contain any tokens")
+	}
+	for agentID, token := range tokens {
+		if !agentIDPattern.MatchString(agentID) {
+			return nil, fmt.Errorf("agent ID %q must match the %q pattern", agentID, agentIDPattern.String())
+		}
+		if token == "" {
+			return nil, fmt.Errorf("token for %q agent is empty", agentID)
+		}
+	}
+	return tokens, nil
+}
+
+func (s *Server) validateAgentID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !agentIDPattern.MatchString(mux.Vars(r)[agentIDVarName]) {
+			s.writeError(w, fmt.Sprintf("Agent ID must match the %q pattern", agentIDPattern.String()), http.StatusBadRequest)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) getConfig(w http.ResponseWriter, r *http.Request) {
+	agentID := mux.Vars(r)[agentIDVarName]
+	path := s.configPath(agentID)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		s.writeFileError(w, agentID, err)
+		return
+	}
+	ver, err := s.resourceVersion(path)
+	if err != nil {
+		s.writeFileError(w, agentID, err)
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, remote.AgentConfig{
+		ResourceVersion: ver,
+		YAMLConfig:      string(data),
+	})
+}
+
+func (s *Server) getConfigVersion(w http.ResponseWriter, r *http.Request) {
+	agentID := mux.Vars(r)[agentIDVarName]
+	ver, err := s.resourceVersion(s.configPath(agentID))
+	if err != nil {
+		s.writeFileError(w, agentID, err)
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, remote.AgentConfigVersion{ResourceVersion: ver})
+}
+
+func (s *Server) reportStatus(w http.ResponseWriter, r *http.Request) {
+	agentID := mux.Vars(r)[agentIDVarName]
+	if _, err := os.Stat(s.configPath(agentID)); err != nil {
+		s.writeFileError(w, agentID, err)
+		return
+	}
+
+	var status remote.AgentStatus
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxStatusBytes)).Decode(&status); err != nil {
+		s.writeError(w, fmt.Sprintf("Invalid status: %s", err.Error()), http.StatusBadRequest)
+		return
+	}
+	switch status.Phase {
+	case remote.AgentPhaseConnecting, remote.AgentPhaseConnected, remote.AgentPhaseDisconnected, remote.AgentPhaseFailure:
+	default:
+		s.writeError(w, fmt.Sprintf("Unknown phase %q", status.Phase), http.StatusBadRequest)
+		return
+	}
+
+	reportedAt := s.now().UTC()
+	status.ReportedAt = &reportedAt
+
+	s.mu.Lock()
+	s.statuses[agentID] = status
+	s.mu.Unlock()
+
+	s.log.WithFields(logrus.Fields{
+		"agentID":         agentID,
+		"phase":           status.Phase,
+		"resourceVersion": status.ResourceVersion,
+		"message":         status.Message,
+	}).Info("Agent status reported")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) getStatus(w http.ResponseWriter, r *http.Request) {
+	agentID := mux.Vars(r)[agentIDVarName]
+
+	s.mu.RLock()
+	status, ok := s.statuses[agentID]
+	s.mu.RUnlock()
+	if !ok {
+		s.writeError(w, fmt.Sprintf("Agent %q has not reported its status yet", agentID), http.StatusNotFound)
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, status)
+}
+
+func (s *Server) configPath(agentID string) string {
+	return filepath.Join(s.dir, agentID+".yaml")
+}
+
+func (s *Server) resourceVersion(path string) (int, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0, err
+	}
+	return int(info.ModTime().UnixMilli()), nil
+}
+
+func (s *Server) writeFileError(w http.ResponseWriter, agentID string, err error) {
+	if errors.Is(err, fs.ErrNotExist) {
+		s.writeError(w, fmt.Sprintf("Agent %q not found", agentID), http.StatusNotFound)
+		return
+	}
+	s.log.WithError(err).WithField("agentID", agentID).Error("Cannot read agent configuration")
+	s.writeError(w, "Cannot read agent configuration", http.StatusInternalServerError)
+}
+
+func (s *Server) writeError(w http.ResponseWriter, msg string, code int) {
+	s.writeJSON(w, code, remote.ErrorResponse{Message: msg})
+}
+
+func (s *Server) writeJSON(w http.ResponseWriter, code int, in any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(in); err != nil {
+		s.log.Errorf("while writing response: %s", err.Error())
+	}
+}
@@ -0,0 +1,156 @@
+package server_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/internal/config/remote"
+	"github.com/kubeshop/botkube/internal/config/remote/server"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+func TestServer(t *testing.T) {
+	// given
+	dir := t.TempDir()
+	cfgPath := filepath.Join(dir, "dev-cluster.yaml")
+	require.NoError(t, os.WriteFile(cfgPath, []byte("settings:\n  clusterName: dev\n"), 0o600))
+	modTime := time.UnixMilli(1700000000000)
+	require.NoError(t, os.Chtimes(cfgPath, modTime, modTime))
+
+	srv := httptest.NewServer(server.New(loggerx.NewNoop(), dir, map[string]string{"dev-cluster": "token"}).Handler())
+	defer srv.Close()
+
+	client := remote.NewServerClient(remote.ServerConfig{URL: srv.URL + "/", AgentID: "dev-cluster", Token: "token"})
+	ctx := context.Background()
+
+	// when
+	deployment, err := client.GetConfigWithResourceVersion(ctx)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, remote.Deployment{ResourceVersion: 1700000000000, YAMLConfig: "settings:\n  clusterName: dev\n"}, deployment)
+
+	// when
+	ver, err := client.GetResourceVersion(ctx)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, 1700000000000, ver)
+
+	// when
+	err = client.ReportStatus(ctx, remote.AgentStatus{Phase: remote.AgentPhaseConnected, ResourceVersion: ver})
+
+	// then
+	require.NoError(t, err)
+}
+
+func TestServerErrors(t *testing.T) {
+	// given
+	srv := httptest.NewServer(server.New(loggerx.NewNoop(), t.TempDir(), map[string]string{
+		"dev-cluster":  "token",
+		"prod-cluster": "prod-token",
+	}).Handler())
+	defer srv.Close()
+
+	tests := []struct {
+		name        string
+		cfg         remote.ServerConfig
+		status      remote.AgentStatus
+		expectedErr string
+	}{
+		{
+			name:        "invalid token",
+			cfg:         remote.ServerConfig{URL: srv.URL, AgentID: "dev-cluster", Token: "other"},
+			status:      remote.AgentStatus{Phase: remote.AgentPhaseConnected},
+			expectedErr: `while reporting status for "dev-cluster": server responded with 401 status code: Invalid or missing bearer token`,
+		},
+		{
+			name:        "token of other agent",
+			cfg:         remote.ServerConfig{URL: srv.URL, AgentID: "dev-cluster", Token: "prod-token"},
+			status:      remote.AgentStatus{Phase: remote.AgentPhaseConnected},
+			expectedErr: `while reporting status for "dev-cluster": server responded with 401 status code: Invalid or missing bearer token`,
+		},
+		{
+			name:        "agent without token",
+			cfg:         remote.ServerConfig{URL: srv.URL, AgentID: "staging-cluster", Token: "token"},
+			status:      remote.AgentStatus{Phase: remote.AgentPhaseConnected},
+			expectedErr: `while reporting status for "staging-cluster": server responded with 401 status code: Invalid or missing bearer token`,
+		},
+		{
+			name:        "unknown agent",
+			cfg:         remote.ServerConfig{URL: srv.URL, AgentID: "dev-cluster", Token: "token"},
+			status:      remote.AgentStatus{Phase: remote.AgentPhaseConnected},
+			expectedErr: `while reporting status for "dev-cluster": server responded with 404 status code: Agent "dev-cluster" not found`,
+		},
+		{
+			name:        "invalid agent ID",
+			cfg:         remote.ServerConfig{URL: srv.URL, AgentID: ".hidden", Token: "token"},
+			status:      remote.AgentStatus{Phase: remote.AgentPhaseConnected},
+			expectedErr: `while reporting status for ".hidden": server responded with 400 status code: Agent ID must match the "^[a-zA-Z0-9][a-zA-Z0-9._-]*$" pattern`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			err := remote.NewServerClient(tc.cfg).ReportStatus(context.Background(), tc.status)
+
+			// then
+			assert.EqualError(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestLoadTokens(t *testing.T) {
+	tests := []struct {
+		name           string
+		content        string
+		expectedTokens map[string]string
+		expectedErr    string
+	}{
+		{
+			name:           "valid tokens",
+			content:        "dev-cluster: token\nprod-cluster: prod-token\n",
+			expectedTokens: map[string]string{"dev-cluster": "token", "prod-cluster": "prod-token"},
+		},
+		{
+			name:        "no tokens",
+			content:     "",
+			expectedErr: "tokens file doesn't contain any tokens",
+		},
+		{
+			name:        "empty token",
+			content:     "dev-cluster: \"\"\n",
+			expectedErr: `token for "dev-cluster" agent is empty`,
+		},
+		{
+			name:        "invalid agent ID",
+			content:     ".hidden: token\n",
+			expectedErr: `agent ID ".hidden" must match the "^[a-zA-Z0-9][a-zA-Z0-9._-]*$" pattern`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			path := filepath.Join(t.TempDir(), "tokens.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tc.content), 0o600))
+
+			// when
+			tokens, err := server.LoadTokens(path)
+
+			// then
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedTokens, tokens)
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package remote
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// AgentPhase describes the state of an agent reported to a self-hosted configuration server.
+type AgentPhase string
+
+const (
+	// AgentPhaseConnecting is reported when the agent fetched the configuration and is starting.
+	AgentPhaseConnecting AgentPhase = "CONNECTING"
+	// AgentPhaseConnected is reported when the agent started with the configuration of a given version.
+	AgentPhaseConnected AgentPhase = "CONNECTED"
+	// AgentPhaseDisconnected is reported when the agent stopped, e.g. to reload the configuration.
+	AgentPhaseDisconnected AgentPhase = "DISCONNECTED"
+	// AgentPhaseFailure is reported when the agent failed.
+	AgentPhaseFailure AgentPhase = "FAILURE"
+)
+
+// AgentConfig holds the agent configuration returned by a self-hosted configuration server.
+type AgentConfig struct {
+	ResourceVersion int    `json:"resourceVersion"`
+	YAMLConfig      string `json:"yamlConfig"`
+}
+
+// AgentConfigVersion holds the version of the agent configuration.
+type AgentConfigVersion struct {
+	ResourceVersion int `json:"resourceVersion"`
+}
+
+// AgentStatus holds the agent status reported to a self-hosted configuration server.
+type AgentStatus struct {
+	Phase           AgentPhase `json:"phase"`
+	Message         string     `json:"message,omitempty"`
+	ResourceVersion int        `json:"resourceVersion"`
+	BotkubeVersion  string     `json:"botkubeVersion,omitempty"`
+	K8sVersion      string     `json:"k8sVersion,omitempty"`
+	ReportedAt      *time.Time `json:"reportedAt,omitempty"`
+}
+
+// ErrorResponse holds an error returned by a self-hosted configuration server.
+type ErrorResponse struct {
+	Message string `json:"message"`
+}
+
+// ServerClient is a client for a self-hosted configuration server, which implements the open remote configuration API
+// described in the `docs/remote-config-api/openapi.yaml` file.
+type ServerClient struct {
+	httpCli *http.Client
+	baseURL string
+	agentID string
+	token   string
+}
+
+// NewServerClient returns a new ServerClient instance.
+func NewServerClient(cfg ServerConfig) *ServerClient {
+	return &ServerClient{
+		httpCli: &http.Client{Timeout: defaultTimeout},
+		baseURL: strings.TrimSuffix(cfg.URL, "/"),
+		agentID: cfg.AgentID,
+		token:   cfg.Token,
+	}
+}
+
+// AgentID returns the agent identifier.
+func (c *ServerClient) AgentID() string {
+	return c.agentID
+}
+
+// GetConfigWithResourceVersion fetches the agent configuration.
+func (c *ServerClient) GetConfigWithResourceVersion(ctx context.Context) (Deployment, error) {
+	var out AgentConfig
+	if err := c.do(ctx, http.MethodGet, "config", nil, &out); err != nil {
+		return Deployment{}, fmt.Errorf("while getting config with resource version for %q: %w", c.agentID, err)
+	}
+	return Deployment{
+		ResourceVersion: out.ResourceVersion,
+		YAMLConfig:      out.YAMLConfig,
+	}, nil
+}
+
+// GetResourceVersion fetches the version of the agent configuration.
+func (c *ServerClient) GetResourceVersion(ctx context.Context) (int, error) {
+	var out AgentConfigVersion
+	if err := c.do(ctx, http.MethodGet, "config/version", nil, &out); err != nil {
+		return 0, fmt.Errorf("while getting config version for %q: %w", c.agentID, err)
+	}
+	return out.ResourceVersion, nil
+}
+
+// ReportStatus reports the agent status.
+func (c *ServerClient) ReportStatus(ctx context.Context, status AgentStatus) error {
+	if err := c.do(ctx, http.MethodPut, "status", status, nil); err != nil {
+		return fmt.Errorf("while reporting status for %q: %w", c.agentID, err)
+	}
+	return nil
+}
+
+func (c *ServerClient) do(ctx context.Context, method, path string, in, out any) error {
+	var body io.Reader
+	if in != nil {
+		raw, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("while marshaling request: %w", err)
+		}
+		body = bytes.NewReader(raw)
+	}
+
+	endpoint := fmt.Sprintf("%s/v1/agents/%s/%s", c.baseURL, url.PathEscape(c.agentID), path)
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, body)
+	if err != nil {
+		return fmt.Errorf("while creating request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	res, err := c.httpCli.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		var errRes ErrorResponse
+		raw, _ := io.ReadAll(io.LimitReader(res.Body, 4096))
+		if err := json.Unmarshal(raw, &errRes); err != nil || errRes.Message == "" {
+			errRes.Message = strings.TrimSpace(string(raw))
+		}
+		return fmt.Errorf("server responded with %d status code: %s", res.StatusCode, errRes.Message)
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(res.Body).Decode(out); err != nil {
+		return fmt.Errorf("while decoding response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,301 @@
+package secret
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/exp/maps"
+	"gopkg.in/yaml.v3"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/multierror"
+)
+
+const (
+	refPrefix = "secret://"
+	yamlStr   = "!!str"
+)
+
+var _ config.LayeredProvider = &Provider{}
+
+// Provider resolves secret references in the configuration files returned by the base provider.
+// Fetched secrets are cached, so they are fetched again only on Refresh.
+type Provider struct {
+	base       config.Provider
+	newStoreFn func(ctx context.Context, cfg config.SecretProvider) (store, error)
+	now        func() time.Time
+
+	// mu guards all fields below. It is held during calls to external providers, so secrets are never fetched concurrently.
+	mu        sync.Mutex
+	providers config.SecretProviders
+	stores    map[string]storeEntry
+	cache     map[string]cachedSecret
+}
+
+type storeEntry struct {
+	cfg   config.SecretProvider
+	store store
+}
+
+type cachedSecret struct {
+	value     string
+	fetchedAt time.Time
+}
+
+// reference points to a given secret, in the form of `secret://{provider}/{name}#{key}`.
+type reference struct {
+	provider string
+	name     string
+	key      string
+}
+
+// NewProvider returns a new Provider instance.
+func NewProvider(base config.Provider) *Provider {
+	return &Provider{
+		base:       base,
+		newStoreFn: newStore,
+		now:        time.Now,
+		stores:     map[string]storeEntry{},
+		cache:      map[string]cachedSecret{},
+	}
+}
+
+// Configs returns the base configuration files with all secret references replaced with the secret values.
+func (p *Provider) Configs(ctx context.Context) (config.YAMLFiles, int, error) {
+	layers, ver, err := p.Layers(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	return layers.Files(), ver, nil
+}
+
+// Layers returns the base configuration layers with all secret references replaced with the secret values.
+func (p *Provider) Layers(ctx context.Context) (config.Layers, int, error) {
+	layers, ver, err := config.LayersOf(ctx, p.base)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	providers, err := secretProvidersOf(layers.Files())
+	if err != nil {
+		return nil, 0, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.providers = providers
+
+	errs := multierror.New()
+	out := make(config.Layers, 0, len(layers))
+	for _, layer := range layers {
+		resolved, err := p.resolveFile(ctx, layer.Data)
+		if err != nil {
+			errs = multierror.Append(errs, err)
+			continue
+		}
+		out = append(out, config.Layer{Name: layer.Name, Data: resolved})
+	}
+	if err := errs.ErrorOrNil(); err != nil {
+		return nil, 0, fmt.Errorf("while resolving secret references: %w", err)
+	}
+
+	return out, ver, nil
+}
+
+// Refresh fetches again all cached secrets for which the refresh interval of their provider elapsed.
+// It returns true if any secret value changed.
+func (p *Provider) Refresh(ctx context.Context) (bool, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	changed := false
+	errs := multierror.New()
+	for raw, cached := range p.cache {
+		ref, err := parseReference(raw)
+		if err != nil {
+			// cached references are always valid
+			continue
+		}
+		interval := p.providers[ref.provider].RefreshInterval
+		if interval <= 0 || now.Sub(cached.fetchedAt) < interval {
+			continue
+		}
+
+		value, err := p.fetch(ctx, ref)
+		if err != nil {
+			errs = multierror.Append(errs, err)
+			continue
+		}
+		if value != cached.value {
+			changed = true
+		}
+		p.cache[raw] = cachedSecret{value: value, fetchedAt: now}
+	}
+
+	return changed, errs.ErrorOrNil()
+}
+
+// RefreshInterval returns the shortest refresh interval of providers with referenced secrets.
+// It returns zero if no referenced secret needs to be refreshed.
+func (p *Provider) RefreshInterval() time.Duration {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var out time.Duration
+	for raw := range p.cache {
+		ref, err := parseReference(raw)
+		if err != nil {
+			continue
+		}
+		interval := p.providers[ref.provider].RefreshInterval
+		if interval > 0 && (out == 0 || interval < out) {
+			out = interval
+		}
+	}
+	return out
+}
+
+func (p *Provider) resolveFile(ctx context.Context, file []byte) ([]byte, error) {
+	if !bytes.Contains(file, []byte(refPrefix)) {
+		return file, nil
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(file, &doc); err != nil {
+		return nil, fmt.Errorf("while unmarshaling configuration: %w", err)
+	}
+
+	errs := multierror.New()
+	walkScalars(&doc, func(node *yaml.Node) {
+		if node.Tag != yamlStr || !strings.HasPrefix(node.Value, refPrefix) {
+			return
+		}
+		value, err := p.resolve(ctx, node.Value)
+		if err != nil {
+			errs = multierror.Append(errs, err)
+			return
+		}
+		node.Value = value
+	})
+	if err := errs.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+
+	out, err := yaml.Marshal(&doc)
+	if err != nil {
+		return nil, fmt.Errorf("while marshaling configuration: %w", err)
+	}
+	return out, nil
+}
+
+func (p *Provider) resolve(ctx context.Context, raw string) (string, error) {
+	if cached, found := p.cache[raw]; found {
+		return cached.value, nil
+	}
+
+	ref, err := parseReference(raw)
+	if err != nil {
+		return "", err
+	}
+	value, err := p.fetch(ctx, ref)
+	if err != nil {
+		return "", err
+	}
+
+	p.cache[raw] = cachedSecret{value: value, fetchedAt: p.now()}
+	return value, nil
+}
+
+func (p *Provider) fetch(ctx context.Context, ref reference) (string, error) {
+	cfg, found := p.providers[ref.provider]
+	if !found {
+		return "", fmt.Errorf("secret provider %q is not defined", ref.provider)
+	}
+
+	entry, found := p.stores[ref.provider]
+	if !found || !reflect.DeepEqual(entry.cfg, cfg) {
+		st, err := p.newStoreFn(ctx, cfg)
+		if err != nil {
+			return "", fmt.Errorf("while creating secret provider %q: %w", ref.provider, err)
+		}
+		entry = storeEntry{cfg: cfg, store: st}
+		p.stores[ref.provider] = entry
+	}
+
+	raw, err := entry.store.Get(ctx, ref.name)
+	if err != nil {
+		return "", fmt.Errorf("while getting secret %q from %q: %w", ref.name, ref.provider, err)
+	}
+	value, err := valueOf(raw, ref.key)
+	if err != nil {
+		return "", fmt.Errorf("while getting secret %q from %q: %w", ref.name, ref.provider, err)
+	}
+	return value, nil
+}
+
+func parseReference(in string) (reference, error) {
+	provider, name, found := strings.Cut(strings.TrimPrefix(in, refPrefix), "/")
+	if !found || provider == "" || name == "" {
+		return reference{}, fmt.Errorf("secret reference %q doesn't follow the %s{provider}/{name}#{key} syntax", in, refPrefix)
+	}
+
+	name, key, _ := strings.Cut(name, "#")
+	return reference{provider: provider, name: name, key: key}, nil
+}
+
+// valueOf returns the whole secret if key is not specified. Otherwise, the secret must be a JSON object.
+func valueOf(raw, key string) (string, error) {
+	if key == "" {
+		return raw, nil
+	}
+
+	var obj map[string]any
+	if err := json.Unmarshal([]byte(raw), &obj); err != nil {
+		return "", fmt.Errorf("secret is not a JSON object: %w", err)
+	}
+	value, found := obj[key]
+	if !found {
+		return "", fmt.Errorf("key %q not found", key)
+	}
+
+	if str, ok := value.(string); ok {
+		return str, nil
+	}
+	out, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("while marshaling key %q: %w", key, err)
+	}
+	return string(out), nil
+}
+
+// secretProvidersOf returns secret providers merged from all configuration files. Environment variables are not supported.
+func secretProvidersOf(files config.YAMLFiles) (config.SecretProviders, error) {
+	out := config.SecretProviders{}
+	for _, file := range files {
+		var cfg struct {
+			SecretProviders config.SecretProviders `yaml:"secretProviders"`
+		}
+		if err := yaml.Unmarshal(file, &cfg); err != nil {
+			return nil, fmt.Errorf("while unmarshaling secret providers: %w", err)
+		}
+		maps.Copy(out, cfg.SecretProviders)
+	}
+	return out, nil
+}
+
+func walkScalars(node *yaml.Node, fn func(node *yaml.Node)) {
+	if node.Kind == yaml.ScalarNode {
+		fn(node)
+		return
+	}
+	for _, child := range node.Content {
+		walkScalars(child, fn)
+	}
+}
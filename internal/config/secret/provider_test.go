@@ -0,0 +1,170 @@
+package secret
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+type staticProvider config.YAMLFiles
+
+func (s staticProvider) Configs(context.Context) (config.YAMLFiles, int, error) {
+	return config.YAMLFiles(s), 1, nil
+}
+
+type fakeStore struct {
+	secrets map[string]string
+	calls   int
+}
+
+func (f *fakeStore) Get(_ context.Context, name string) (string, error) {
+	f.calls++
+	return f.secrets[name], nil
+}
+
+func TestProviderConfigs(t *testing.T) {
+	// given
+	st := &fakeStore{secrets: map[string]string{
+		"secret/data/slack": `{"botToken":"xoxb-123","appToken":"xapp-456"}`,
+		"es-password":       "p@ss: word",
+	}}
+	provider := newTestProvider(st, staticProvider{
+		[]byte(heredoc.Doc(`
+			secretProviders:
+			  vault:
+			    type: vault
+			    refreshInterval: 5m
+			  aws:
+			    type: awsSecretsManager
+		`)),
+		[]byte(heredoc.Doc(`
+			communications:
+			  default-group:
+			    socketSlack:
+			      botToken: secret://vault/secret/data/slack#botToken
+			      appToken: "secret://vault/secret/data/slack#appToken"
+			    elasticsearch:
+			      password: secret://aws/es-password
+		`)),
+		[]byte("settings:\n  clusterName: prod\n"),
+	})
+
+	// when
+	files, ver, err := provider.Configs(context.Background())
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, 1, ver)
+	require.Len(t, files, 3)
+	assert.Equal(t, heredoc.Doc(`
+		communications:
+		    default-group:
+		        socketSlack:
+		            botToken: xoxb-123
+		            appToken: "xapp-456"
+		        elasticsearch:
+		            password: 'p@ss: word'
+	`), string(files[1]))
+	assert.Equal(t, "settings:\n  clusterName: prod\n", string(files[2]))
+	assert.Equal(t, 3, st.calls)
+	assert.Equal(t, 5*time.Minute, provider.RefreshInterval())
+
+	// when configuration is loaded again
+	_, _, err = provider.Configs(context.Background())
+
+	// then secrets are taken from cache
+	require.NoError(t, err)
+	assert.Equal(t, 3, st.calls)
+}
+
+func TestProviderConfigsErrors(t *testing.T) {
+	// given
+	provider := newTestProvider(&fakeStore{secrets: map[string]string{"plain": "value"}}, staticProvider{
+		[]byte(heredoc.Doc(`
+			secretProviders:
+			  vault:
+			    type: vault
+			settings:
+			  clusterName: secret://vault
+			  kubeconfig: secret://aws/token
+			  saCredentialsPathPrefix: secret://vault/plain#key
+		`)),
+	})
+
+	// when
+	_, _, err := provider.Configs(context.Background())
+
+	// then
+	assert.EqualError(t, err, heredoc.Doc(`
+		while resolving secret references: 3 errors occurred:
+			* secret reference "secret://vault" doesn't follow the secret://{provider}/{name}#{key} syntax
+			* secret provider "aws" is not defined
+			* while getting secret "plain" from "vault": secret is not a JSON object: invalid character 'v' looking for beginning of value`))
+}
+
+func TestProviderRefresh(t *testing.T) {
+	// given
+	now := time.Date(2023, 1, 1, 10, 0, 0, 0, time.UTC)
+	st := &fakeStore{secrets: map[string]string{"token": "xoxb-1"}}
+	provider := newTestProvider(st, staticProvider{
+		[]byte(heredoc.Doc(`
+			secretProviders:
+			  vault:
+			    type: vault
+			    refreshInterval: 1m
+			communications:
+			  default-group:
+			    socketSlack:
+			      botToken: secret://vault/token
+		`)),
+	})
+	provider.now = func() time.Time { return now }
+
+	_, _, err := provider.Configs(context.Background())
+	require.NoError(t, err)
+
+	// when refresh interval didn't elapse
+	now = now.Add(30 * time.Second)
+	changed, err := provider.Refresh(context.Background())
+
+	// then
+	require.NoError(t, err)
+	assert.False(t, changed)
+	assert.Equal(t, 1, st.calls)
+
+	// when secret didn't change
+	now = now.Add(time.Minute)
+	changed, err = provider.Refresh(context.Background())
+
+	// then
+	require.NoError(t, err)
+	assert.False(t, changed)
+	assert.Equal(t, 2, st.calls)
+
+	// when secret is rotated
+	st.secrets["token"] = "xoxb-2"
+	now = now.Add(time.Minute)
+	changed, err = provider.Refresh(context.Background())
+
+	// then
+	require.NoError(t, err)
+	assert.True(t, changed)
+
+	files, _, err := provider.Configs(context.Background())
+	require.NoError(t, err)
+	assert.Contains(t, string(files[0]), "botToken: xoxb-2")
+}
+
+func newTestProvider(st store, base config.Provider) *Provider {
+	provider := NewProvider(base)
+	provider.newStoreFn = func(context.Context, config.SecretProvider) (store, error) {
+		return st, nil
+	}
+	return provider
+}
@@ -0,0 +1,55 @@
+package secret
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+type restarter interface {
+	Do(ctx context.Context) error
+}
+
+// Refresher periodically fetches referenced secrets again, and reloads Botkube once any of them is rotated.
+type Refresher struct {
+	log       logrus.FieldLogger
+	provider  *Provider
+	restarter restarter
+	interval  time.Duration
+}
+
+// NewRefresher returns a new Refresher instance.
+func NewRefresher(log logrus.FieldLogger, provider *Provider, restarter restarter, interval time.Duration) *Refresher {
+	return &Refresher{log: log, provider: provider, restarter: restarter, interval: interval}
+}
+
+// Do refreshes secrets until the context is canceled.
+func (r *Refresher) Do(ctx context.Context) error {
+	r.log.Infof("Refreshing secrets every %s...", r.interval)
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			r.log.Info("Exiting...")
+			return nil
+		case <-ticker.C:
+		}
+
+		changed, err := r.provider.Refresh(ctx)
+		if err != nil {
+			// keep using the previous values, the provider might be temporarily unavailable
+			r.log.WithError(err).Warn("Cannot refresh secrets")
+		}
+		if !changed {
+			continue
+		}
+
+		r.log.Info("Secret rotation detected. Reloading configuration...")
+		if err := r.restarter.Do(ctx); err != nil {
+			r.log.Errorf("while reloading configuration: %s", err.Error())
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package secret
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	gcpsecretmanager "google.golang.org/api/secretmanager/v1"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+// store fetches secrets from a given external secret provider.
+type store interface {
+	Get(ctx context.Context, name string) (string, error)
+}
+
+func newStore(ctx context.Context, cfg config.SecretProvider) (store, error) {
+	switch cfg.Type {
+	case config.VaultSecretProviderType:
+		return newVaultStore(cfg.Vault)
+	case config.AWSSecretsManagerProviderType:
+		return newAWSStore(cfg.AWSSecretsManager)
+	case config.GCPSecretManagerProviderType:
+		return newGCPStore(ctx, cfg.GCPSecretManager)
+	}
+	return nil, fmt.Errorf("unsupported secret provider type %q", cfg.Type)
+}
+
+var _ store = &awsStore{}
+
+// awsStore uses the default AWS credentials chain, which includes IAM roles for service accounts.
+type awsStore struct {
+	cli *secretsmanager.SecretsManager
+}
+
+func newAWSStore(cfg config.AWSSecretsManagerProvider) (*awsStore, error) {
+	sess, err := session.NewSession(&aws.Config{Region: aws.String(cfg.Region)})
+	if err != nil {
+		return nil, fmt.Errorf("while creating AWS session: %w", err)
+	}
+
+	awsCfg := &aws.Config{}
+	if cfg.RoleArn != "" {
+		awsCfg.Credentials = stscreds.NewCredentials(sess, cfg.RoleArn)
+	}
+	return &awsStore{cli: secretsmanager.New(sess, awsCfg)}, nil
+}
+
+// Get returns the latest version of a given secret. The name can be either the secret name or its ARN.
+func (a *awsStore) Get(ctx context.Context, name string) (string, error) {
+	out, err := a.cli.GetSecretValueWithContext(ctx, &secretsmanager.GetSecretValueInput{SecretId: aws.String(name)})
+	if err != nil {
+		return "", err
+	}
+	if out.SecretString != nil {
+		return *out.SecretString, nil
+	}
+	return string(out.SecretBinary), nil
+}
+
+var _ store = &gcpStore{}
+
+// gcpStore uses the Application Default Credentials.
+type gcpStore struct {
+	svc *gcpsecretmanager.Service
+	cfg config.GCPSecretManagerProvider
+}
+
+func newGCPStore(ctx context.Context, cfg config.GCPSecretManagerProvider) (*gcpStore, error) {
+	// the context is used only to create the client, so it mustn't be canceled once Botkube is reloaded
+	svc, err := gcpsecretmanager.NewService(context.WithoutCancel(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("while creating GCP Secret Manager client: %w", err)
+	}
+	return &gcpStore{svc: svc, cfg: cfg}, nil
+}
+
+// Get returns a given secret version. The name can be either the secret name from the configured project,
+// or the full resource name. The latest version is used if not specified.
+func (g *gcpStore) Get(ctx context.Context, name string) (string, error) {
+	if !strings.HasPrefix(name, "projects/") {
+		name = fmt.Sprintf("projects/%s/secrets/%s", g.cfg.Project, name)
+	}
+	if !strings.Contains(name, "/versions/") {
+		name += "/versions/latest"
+	}
+
+	res, err := g.svc.Projects.Secrets.Versions.Access(name).Context(ctx).Do()
+	if err != nil {
+		return "", err
+	}
+	if res.Payload == nil {
+		return "", fmt.Errorf("secret version %q has no payload", name)
+	}
+
+	data, err := base64.StdEncoding.DecodeString(res.Payload.Data)
+	if err != nil {
+		return "", fmt.Errorf("while decoding secret payload: %w", err)
+	}
+	return string(data), nil
+}
@@ -0,0 +1,184 @@
+package secret
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/httpx"
+)
+
+const (
+	defaultVaultKubernetesMountPath = "kubernetes"
+	defaultVaultKubernetesTokenPath = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+	vaultNamespaceHeader            = "X-Vault-Namespace"
+	vaultTokenHeader                = "X-Vault-Token"
+	vaultMaxResponseSize            = 1 << 20 // 1MB
+)
+
+var (
+	_ store = &vaultStore{}
+
+	errVaultForbidden = errors.New("permission denied")
+)
+
+// vaultStore reads secrets from both KV v1 and v2 secrets engines.
+type vaultStore struct {
+	httpCli *http.Client
+	cfg     config.VaultSecretProvider
+
+	mu    sync.Mutex
+	token string
+}
+
+func newVaultStore(cfg config.VaultSecretProvider) (*vaultStore, error) {
+	if cfg.Address == "" {
+		return nil, errors.New("address is required")
+	}
+	if cfg.Token == "" && cfg.KubernetesAuth.Role == "" {
+		return nil, errors.New("either token or Kubernetes auth role is required")
+	}
+	if cfg.KubernetesAuth.MountPath == "" {
+		cfg.KubernetesAuth.MountPath = defaultVaultKubernetesMountPath
+	}
+	if cfg.KubernetesAuth.TokenPath == "" {
+		cfg.KubernetesAuth.TokenPath = defaultVaultKubernetesTokenPath
+	}
+	cfg.Address = strings.TrimSuffix(cfg.Address, "/")
+
+	return &vaultStore{
+		httpCli: httpx.NewHTTPClient(),
+		cfg:     cfg,
+	}, nil
+}
+
+// Get returns data of a given secret as JSON object. For KV v2, the path must contain the `data` segment, e.g. `secret/data/botkube`.
+func (v *vaultStore) Get(ctx context.Context, path string) (string, error) {
+	data, err := v.read(ctx, path)
+	if errors.Is(err, errVaultForbidden) && v.cfg.KubernetesAuth.Role != "" {
+		// the token might have expired, so log in again
+		v.resetToken()
+		data, err = v.read(ctx, path)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	// KV v2 secrets engine nests the secret data together with its metadata
+	if nested, ok := data["data"].(map[string]any); ok {
+		if _, found := data["metadata"]; found {
+			data = nested
+		}
+	}
+
+	out, err := json.Marshal(data)
+	if err != nil {
+		return "", fmt.Errorf("while marshaling secret data: %w", err)
+	}
+	return string(out), nil
+}
+
+func (v *vaultStore) read(ctx context.Context, path string) (map[string]any, error) {
+	token, err := v.authToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var out struct {
+		Data map[string]any `json:"data"`
+	}
+	if err := v.do(ctx, http.MethodGet, "/v1/"+strings.TrimPrefix(path, "/"), token, nil, &out); err != nil {
+		return nil, err
+	}
+	return out.Data, nil
+}
+
+func (v *vaultStore) authToken(ctx context.Context) (string, error) {
+	if v.cfg.KubernetesAuth.Role == "" {
+		return v.cfg.Token, nil
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.token != "" {
+		return v.token, nil
+	}
+
+	jwt, err := os.ReadFile(filepath.Clean(v.cfg.KubernetesAuth.TokenPath))
+	if err != nil {
+		return "", fmt.Errorf("while reading service account token: %w", err)
+	}
+
+	in := map[string]string{
+		"role": v.cfg.KubernetesAuth.Role,
+		"jwt":  strings.TrimSpace(string(jwt)),
+	}
+	var out struct {
+		Auth struct {
+			ClientToken string `json:"client_token"`
+		} `json:"auth"`
+	}
+	path := fmt.Sprintf("/v1/auth/%s/login", strings.Trim(v.cfg.KubernetesAuth.MountPath, "/"))
+	if err := v.do(ctx, http.MethodPost, path, "", in, &out); err != nil {
+		return "", fmt.Errorf("while logging in to Vault: %w", err)
+	}
+
+	v.token = out.Auth.ClientToken
+	return v.token, nil
+}
+
+func (v *vaultStore) resetToken() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.token = ""
+}
+
+func (v *vaultStore) do(ctx context.Context, method, path, token string, in, out any) error {
+	var body io.Reader
+	if in != nil {
+		raw, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("while marshaling request body: %w", err)
+		}
+		body = bytes.NewReader(raw)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, v.cfg.Address+path, body)
+	if err != nil {
+		return fmt.Errorf("while creating request: %w", err)
+	}
+	if token != "" {
+		req.Header.Set(vaultTokenHeader, token)
+	}
+	if v.cfg.Namespace != "" {
+		req.Header.Set(vaultNamespaceHeader, v.cfg.Namespace)
+	}
+
+	res, err := v.httpCli.Do(req)
+	if err != nil {
+		return fmt.Errorf("while sending request: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusForbidden {
+		return errVaultForbidden
+	}
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		raw, _ := io.ReadAll(io.LimitReader(res.Body, 4096))
+		return fmt.Errorf("got unexpected status code %d: %s", res.StatusCode, raw)
+	}
+
+	if err := json.NewDecoder(io.LimitReader(res.Body, vaultMaxResponseSize)).Decode(out); err != nil {
+		return fmt.Errorf("while decoding response body: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package secret
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+func TestVaultStoreGet(t *testing.T) {
+	// given
+	var logins int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "team-a", r.Header.Get(vaultNamespaceHeader))
+
+		switch r.URL.Path {
+		case "/v1/auth/k8s/login":
+			var in map[string]string
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&in))
+			assert.Equal(t, map[string]string{"role": "botkube", "jwt": "sa-token"}, in)
+
+			logins++
+			fmt.Fprintf(w, `{"auth":{"client_token":"token-%d"}}`, logins)
+		case "/v1/secret/data/botkube":
+			// first token is already expired
+			if r.Header.Get(vaultTokenHeader) != "token-2" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			fmt.Fprint(w, `{"data":{"data":{"botToken":"xoxb-123"},"metadata":{"version":3}}}`)
+		case "/v1/kv/botkube":
+			fmt.Fprint(w, `{"data":{"appToken":"xapp-456"}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	tokenPath := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenPath, []byte("sa-token\n"), 0o600))
+
+	st, err := newVaultStore(config.VaultSecretProvider{
+		Address:   srv.URL + "/",
+		Namespace: "team-a",
+		KubernetesAuth: config.VaultKubernetesAuth{
+			Role:      "botkube",
+			MountPath: "k8s",
+			TokenPath: tokenPath,
+		},
+	})
+	require.NoError(t, err)
+
+	// when KV v2 secret is read
+	out, err := st.Get(context.Background(), "secret/data/botkube")
+
+	// then
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"botToken":"xoxb-123"}`, out)
+	assert.Equal(t, 2, logins)
+
+	// when KV v1 secret is read
+	out, err = st.Get(context.Background(), "/kv/botkube")
+
+	// then
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"appToken":"xapp-456"}`, out)
+	assert.Equal(t, 2, logins)
+
+	// when secret doesn't exist
+	_, err = st.Get(context.Background(), "kv/other")
+
+	// then
+	assert.EqualError(t, err, "got unexpected status code 404: ")
+}
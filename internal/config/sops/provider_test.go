@@ -0,0 +1,169 @@
+package sops
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/getsops/sops/v3"
+	"github.com/getsops/sops/v3/aes"
+	sopsage "github.com/getsops/sops/v3/age"
+	"github.com/getsops/sops/v3/cmd/sops/common"
+	sopsyaml "github.com/getsops/sops/v3/stores/yaml"
+	"github.com/getsops/sops/v3/version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+const testPlainFile = `
+settings:
+    clusterName: prod
+    metricsPort: 2112
+    upgradeNotifier: false
+    kubeconfig_unencrypted: /etc/kubeconfig
+    tokens:
+        - a
+        - b
+`
+
+type staticProvider config.Layers
+
+func (s staticProvider) Configs(context.Context) (config.YAMLFiles, int, error) {
+	return config.Layers(s).Files(), 1, nil
+}
+
+func (s staticProvider) Layers(context.Context) (config.Layers, int, error) {
+	return config.Layers(s), 1, nil
+}
+
+func TestProviderLayersAge(t *testing.T) {
+	// given
+	identity := setAgeIdentity(t)
+	file := encryptFile(t, identity.Recipient(), testPlainFile)
+
+	provider := NewProvider(staticProvider{
+		{Name: "global_config.yaml", Data: file},
+		{Name: "_runtime_state.yaml", Data: []byte("actions: {}\n")},
+	})
+
+	// when
+	layers, ver, err := provider.Layers(context.Background())
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, 1, ver)
+	require.Len(t, layers, 2)
+	assert.Equal(t, strings.TrimPrefix(testPlainFile, "\n"), string(layers[0].Data))
+	assert.Equal(t, "actions: {}\n", string(layers[1].Data))
+}
+
+func TestProviderLayersErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		givenFile   func(t *testing.T) []byte
+		expectedErr string
+	}{
+		{
+			name: "unknown identity",
+			givenFile: func(t *testing.T) []byte {
+				other, err := age.GenerateX25519Identity()
+				require.NoError(t, err)
+				return encryptFile(t, other.Recipient(), testPlainFile)
+			},
+			expectedErr: "Error getting data key",
+		},
+		{
+			name: "tampered unencrypted value",
+			givenFile: func(t *testing.T) []byte {
+				file := encryptFile(t, setAgeIdentity(t).Recipient(), testPlainFile)
+				return []byte(strings.Replace(string(file), "/etc/kubeconfig", "/tmp/kubeconfig", 1))
+			},
+			expectedErr: "Failed to verify data integrity",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			setAgeIdentity(t)
+			provider := NewProvider(staticProvider{{Name: "global_config.yaml", Data: tc.givenFile(t)}})
+
+			// when
+			_, _, err := provider.Layers(context.Background())
+
+			// then
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), `while decrypting "global_config.yaml"`)
+			assert.Contains(t, err.Error(), tc.expectedErr)
+		})
+	}
+}
+
+func TestProviderLayersNotEncrypted(t *testing.T) {
+	// given
+	in := staticProvider{
+		{Name: "global_config.yaml", Data: []byte("settings:\n  clusterName: prod\n")},
+		{Name: "plugin_config.yaml", Data: []byte("executors:\n  sops:\n    enabled: true\n")},
+		{Name: "executors.yaml", Data: []byte("sops: {}\n")},
+	}
+	provider := NewProvider(in)
+	provider.decrypt = func([]byte) ([]byte, error) {
+		t.Fatal("not encrypted files must not be decrypted")
+		return nil, nil
+	}
+
+	// when
+	layers, _, err := provider.Layers(context.Background())
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, config.Layers(in), layers)
+}
+
+// setAgeIdentity generates a new age identity and exposes it to SOPS for the duration of the test.
+func setAgeIdentity(t *testing.T) *age.X25519Identity {
+	t.Helper()
+
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv(sopsage.SopsAgeKeyEnv, identity.String())
+	// SOPS tries to read the key file when the variable is set, even if it's empty
+	t.Setenv(sopsage.SopsAgeKeyFileEnv, "")
+	require.NoError(t, os.Unsetenv(sopsage.SopsAgeKeyFileEnv))
+	return identity
+}
+
+// encryptFile encrypts a given YAML file for a given age recipient in the same way as the `sops --encrypt` command does.
+func encryptFile(t *testing.T, recipient *age.X25519Recipient, plain string) []byte {
+	t.Helper()
+
+	store := &sopsyaml.Store{}
+	branches, err := store.LoadPlainFile([]byte(plain))
+	require.NoError(t, err)
+
+	key, err := sopsage.MasterKeyFromRecipient(recipient.String())
+	require.NoError(t, err)
+
+	tree := sops.Tree{
+		Branches: branches,
+		Metadata: sops.Metadata{
+			KeyGroups:         []sops.KeyGroup{{key}},
+			UnencryptedSuffix: "_unencrypted",
+			Version:           version.Version,
+		},
+	}
+	dataKey, errs := tree.GenerateDataKey()
+	require.Empty(t, errs)
+
+	err = common.EncryptTree(common.EncryptTreeOpts{DataKey: dataKey, Tree: &tree, Cipher: aes.NewCipher()})
+	require.NoError(t, err)
+
+	out, err := store.EmitEncryptedFile(tree)
+	require.NoError(t, err)
+	return out
+}
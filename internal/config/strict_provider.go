@@ -0,0 +1,40 @@
+package config
+
+import (
+	"context"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+var _ config.LayeredProvider = &StrictProvider{}
+
+// StrictProvider rejects configuration returned by the base provider if it contains properties which are not a part of the Botkube configuration.
+type StrictProvider struct {
+	base config.Provider
+}
+
+// NewStrictProvider returns a new StrictProvider instance.
+func NewStrictProvider(base config.Provider) *StrictProvider {
+	return &StrictProvider{base: base}
+}
+
+// Configs returns the base configuration files if all their properties are known.
+func (s *StrictProvider) Configs(ctx context.Context) (config.YAMLFiles, int, error) {
+	layers, ver, err := s.Layers(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	return layers.Files(), ver, nil
+}
+
+// Layers returns the base configuration layers if all their properties are known.
+func (s *StrictProvider) Layers(ctx context.Context) (config.Layers, int, error) {
+	layers, ver, err := config.LayersOf(ctx, s.base)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := config.ValidateKnownKeys(layers); err != nil {
+		return nil, 0, err
+	}
+	return layers, ver, nil
+}
@@ -0,0 +1,285 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	authv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kubeshop/botkube/internal/health"
+	k8sconfig "github.com/kubeshop/botkube/internal/source/kubernetes/config"
+	"github.com/kubeshop/botkube/pkg/api/source"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/plugin"
+)
+
+const (
+	platformCheck   = "platform"
+	rbacCheck       = "rbac"
+	repositoryCheck = "repository"
+	webhookCheck    = "webhook"
+
+	kubernetesPluginName = "kubernetes"
+	allNamespaces        = ""
+
+	platformHint      = "Verify the bot token and the app token of the platform, and make sure the app is installed in the workspace with all required scopes. See the Botkube logs for details."
+	rbacMissingHint   = "Set `context.rbac` for the %q plugin in the %q source, so Botkube can impersonate a Kubernetes identity for it."
+	rbacDeniedHint    = "Grant the %s verbs on %q to %s, e.g. with a ClusterRole and a ClusterRoleBinding, or with `rbac.groups` in the Helm chart values."
+	repositoryHint    = "Verify the repository URL, its credentials and TLS settings, and that the cluster has egress access to it. In air-gapped clusters, configure `plugins.mirror`."
+	webhookHint       = "Verify the webhook URL, and that the cluster has egress access to it, e.g. that no NetworkPolicy or proxy blocks it."
+	webhookDetailsFmt = "Endpoint responded with status code %d."
+)
+
+// watchVerbs are verbs required by informers of the Kubernetes source.
+var watchVerbs = []string{"list", "watch"}
+
+// checkPlatforms reports the health of communication platform bots, which fail if the chat API rejects their credentials.
+func (d *Doctor) checkPlatforms() []Result {
+	if d.health == nil {
+		return nil
+	}
+
+	var out []Result
+	for _, component := range d.health.Components().Components {
+		if component.Kind != health.BotComponentKind {
+			continue
+		}
+		res := Result{
+			Check:  platformCheck,
+			Target: component.Name,
+			Status: PassStatus,
+		}
+		switch component.Status {
+		case health.StatusHealthy:
+		case health.StatusUnknown:
+			res.Status = WarnStatus
+			res.Details = "The bot is still connecting."
+		default:
+			res.Status = FailStatus
+			res.Details = strings.TrimSpace(fmt.Sprintf("%s %s", component.Reason, component.LastError))
+			res.Hint = platformHint
+		}
+		out = append(out, res)
+	}
+	return out
+}
+
+// checkRBAC checks whether Kubernetes sources are allowed to list and watch their resources.
+func (d *Doctor) checkRBAC(ctx context.Context) []Result {
+	var out []Result
+	for _, srcName := range sortedKeys(d.cfg.Sources) {
+		plugins := d.cfg.Sources[srcName].Plugins
+		for _, pluginKey := range sortedKeys(plugins) {
+			pluginCfg := plugins[pluginKey]
+			if !pluginCfg.Enabled || !isKubernetesPlugin(pluginKey) {
+				continue
+			}
+			out = append(out, d.checkSourceRBAC(ctx, srcName, pluginKey, pluginCfg)...)
+		}
+	}
+	return out
+}
+
+func (d *Doctor) checkSourceRBAC(ctx context.Context, srcName, pluginKey string, pluginCfg config.Plugin) []Result {
+	user, groups, ok := plugin.ImpersonatedSubjects(pluginCfg.Context, plugin.KubeConfigInput{})
+	if !ok {
+		return []Result{{
+			Check:   rbacCheck,
+			Target:  srcName,
+			Status:  FailStatus,
+			Details: "The plugin doesn't have a kubeconfig, so it cannot watch any resources.",
+			Hint:    fmt.Sprintf(rbacMissingHint, pluginKey, srcName),
+		}}
+	}
+
+	rawYAML, err := yaml.Marshal(pluginCfg.Config)
+	if err != nil {
+		return []Result{warnResult(rbacCheck, srcName, fmt.Errorf("while marshaling plugin configuration: %w", err))}
+	}
+	k8sCfg, err := k8sconfig.MergeConfigs([]*source.Config{{RawYAML: rawYAML}})
+	if err != nil {
+		return []Result{warnResult(rbacCheck, srcName, fmt.Errorf("while parsing plugin configuration: %w", err))}
+	}
+
+	cli, err := d.newK8sCli(user, groups)
+	if err != nil {
+		return []Result{warnResult(rbacCheck, srcName, fmt.Errorf("while creating Kubernetes client: %w", err))}
+	}
+
+	var out []Result
+	for _, resource := range k8sCfg.Resources {
+		target := fmt.Sprintf("%s/%s", srcName, resource.Type)
+		gvr, err := parseResourceType(resource.Type)
+		if err != nil {
+			out = append(out, warnResult(rbacCheck, target, err))
+			continue
+		}
+
+		constraints := resource.Namespaces
+		if len(constraints.Include) == 0 && k8sCfg.Namespaces != nil {
+			constraints = *k8sCfg.Namespaces
+		}
+
+		var denied []string
+		for _, ns := range namespacesToCheck(constraints) {
+			for _, verb := range watchVerbs {
+				allowed, err := selfAccessReview(ctx, cli, gvr, ns, verb)
+				if err != nil {
+					return append(out, warnResult(rbacCheck, target, fmt.Errorf("while reviewing access: %w", err)))
+				}
+				if !allowed {
+					denied = append(denied, deniedScope(verb, ns))
+				}
+			}
+		}
+
+		res := Result{Check: rbacCheck, Target: target, Status: PassStatus}
+		if len(denied) > 0 {
+			res.Status = FailStatus
+			res.Details = fmt.Sprintf("Denied: %s.", strings.Join(denied, ", "))
+			res.Hint = fmt.Sprintf(rbacDeniedHint, strings.Join(watchVerbs, " and "), gvr.GroupResource().String(), subjectsDescription(user, groups))
+		}
+		out = append(out, res)
+	}
+	return out
+}
+
+// checkRepositories checks whether indexes of plugin repositories can be downloaded.
+func (d *Doctor) checkRepositories(ctx context.Context) []Result {
+	if d.repositories == nil {
+		return nil
+	}
+
+	errs := d.repositories.CheckRepositories(ctx)
+	var out []Result
+	for _, repo := range sortedKeys(errs) {
+		res := Result{Check: repositoryCheck, Target: repo, Status: PassStatus}
+		if err := errs[repo]; err != nil {
+			res.Status = FailStatus
+			res.Details = err.Error()
+			res.Hint = repositoryHint
+		}
+		out = append(out, res)
+	}
+	return out
+}
+
+// checkWebhooks checks whether webhook sink endpoints are reachable. Any HTTP response means the endpoint is reachable,
+// as endpoints may reject requests without a valid event.
+func (d *Doctor) checkWebhooks(ctx context.Context) []Result {
+	var out []Result
+	for _, group := range sortedKeys(d.cfg.Communications) {
+		webhook := d.cfg.Communications[group].Webhook
+		if !webhook.Enabled {
+			continue
+		}
+
+		target := fmt.Sprintf("%s/%s", group, config.WebhookCommPlatformIntegration)
+		res := Result{Check: webhookCheck, Target: target, Status: PassStatus}
+		statusCode, err := d.probe(ctx, webhook.URL)
+		switch {
+		case err != nil:
+			res.Status = FailStatus
+			res.Details = err.Error()
+			res.Hint = webhookHint
+		case statusCode >= http.StatusInternalServerError:
+			res.Status = WarnStatus
+			res.Details = fmt.Sprintf(webhookDetailsFmt, statusCode)
+			res.Hint = webhookHint
+		}
+		out = append(out, res)
+	}
+	return out
+}
+
+func (d *Doctor) probe(ctx context.Context, url string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, http.NoBody)
+	if err != nil {
+		return 0, fmt.Errorf("while creating request: %w", err)
+	}
+	res, err := d.httpCli.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("while executing request: %w", err)
+	}
+	defer res.Body.Close()
+	return res.StatusCode, nil
+}
+
+func selfAccessReview(ctx context.Context, cli kubernetes.Interface, gvr schema.GroupVersionResource, namespace, verb string) (bool, error) {
+	review, err := cli.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, &authv1.SelfSubjectAccessReview{
+		Spec: authv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authv1.ResourceAttributes{
+				Namespace: namespace,
+				Verb:      verb,
+				Group:     gvr.Group,
+				Version:   gvr.Version,
+				Resource:  gvr.Resource,
+			},
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return false, err
+	}
+	return review.Status.Allowed, nil
+}
+
+// namespacesToCheck returns namespaces given explicitly, or all namespaces if any of them is a regular expression.
+func namespacesToCheck(constraints k8sconfig.RegexConstraints) []string {
+	if len(constraints.Include) == 0 {
+		return []string{allNamespaces}
+	}
+	for _, ns := range constraints.Include {
+		if regexp.QuoteMeta(ns) != ns {
+			return []string{allNamespaces}
+		}
+	}
+	return constraints.Include
+}
+
+func parseResourceType(in string) (schema.GroupVersionResource, error) {
+	parts := strings.Split(in, "/")
+	switch len(parts) {
+	case 2:
+		return schema.GroupVersionResource{Version: parts[0], Resource: parts[1]}, nil
+	case 3:
+		return schema.GroupVersionResource{Group: parts[0], Version: parts[1], Resource: parts[2]}, nil
+	default:
+		return schema.GroupVersionResource{}, fmt.Errorf("invalid resource type %q: expected 2 or 3 parts when split by %q", in, "/")
+	}
+}
+
+func isKubernetesPlugin(key string) bool {
+	_, name, _, err := config.DecomposePluginKey(key)
+	return err == nil && name == kubernetesPluginName
+}
+
+func deniedScope(verb, namespace string) string {
+	if namespace == allNamespaces {
+		return fmt.Sprintf("%s in all namespaces", verb)
+	}
+	return fmt.Sprintf("%s in %q", verb, namespace)
+}
+
+func subjectsDescription(user string, groups []string) string {
+	out := fmt.Sprintf("user %q", user)
+	if len(groups) > 0 {
+		out += fmt.Sprintf(" or groups %q", strings.Join(groups, ", "))
+	}
+	return out
+}
+
+func warnResult(check, target string, err error) Result {
+	return Result{
+		Check:   check,
+		Target:  target,
+		Status:  WarnStatus,
+		Details: fmt.Sprintf("Check couldn't be completed: %s", err.Error()),
+	}
+}
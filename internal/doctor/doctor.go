@@ -0,0 +1,141 @@
+// Package doctor runs connectivity and permission checks of a Botkube installation, and reports them together with remediation hints.
+package doctor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/kubeshop/botkube/internal/health"
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+const defaultTimeout = 10 * time.Second
+
+// Status is a status of a single check.
+type Status string
+
+const (
+	// PassStatus means the check succeeded.
+	PassStatus Status = "pass"
+	// WarnStatus means the check couldn't be completed, or it found an issue which doesn't break Botkube.
+	WarnStatus Status = "warn"
+	// FailStatus means the check found an issue which breaks a part of Botkube.
+	FailStatus Status = "fail"
+)
+
+// Result is a result of a single check.
+type Result struct {
+	// Check is the name of the check, e.g. `rbac`.
+	Check string `json:"check"`
+	// Target is the checked object, e.g. a resource type or a repository name.
+	Target  string `json:"target"`
+	Status  Status `json:"status"`
+	Details string `json:"details,omitempty"`
+	// Hint describes how to fix a failed check.
+	Hint string `json:"hint,omitempty"`
+}
+
+// Report holds results of all checks.
+type Report struct {
+	Results []Result `json:"results"`
+}
+
+// Failed returns the number of failed checks.
+func (r Report) Failed() int {
+	var out int
+	for _, res := range r.Results {
+		if res.Status == FailStatus {
+			out++
+		}
+	}
+	return out
+}
+
+// ComponentHealthChecker provides the health tree of Botkube components.
+type ComponentHealthChecker interface {
+	Components() health.Component
+}
+
+// RepositoryChecker checks whether plugin repository indexes can be downloaded.
+type RepositoryChecker interface {
+	CheckRepositories(ctx context.Context) map[string]error
+}
+
+// Doctor runs diagnostic checks.
+type Doctor struct {
+	log          logrus.FieldLogger
+	cfg          config.Config
+	health       ComponentHealthChecker
+	repositories RepositoryChecker
+	httpCli      *http.Client
+	// newK8sCli returns a client which impersonates a given user and groups. The Botkube identity is used if the user is empty.
+	newK8sCli func(user string, groups []string) (kubernetes.Interface, error)
+}
+
+// New returns a new Doctor instance. The healthChecker and repositories are optional, and checks which depend on them are skipped if they are not set.
+func New(log logrus.FieldLogger, cfg config.Config, restCfg *rest.Config, healthChecker ComponentHealthChecker, repositories RepositoryChecker) *Doctor {
+	return &Doctor{
+		log:          log,
+		cfg:          cfg,
+		health:       healthChecker,
+		repositories: repositories,
+		httpCli:      &http.Client{Timeout: defaultTimeout},
+		newK8sCli: func(user string, groups []string) (kubernetes.Interface, error) {
+			if restCfg == nil {
+				return nil, errors.New("missing Kubernetes client configuration")
+			}
+			impersonated := rest.CopyConfig(restCfg)
+			if user != "" {
+				impersonated.Impersonate = rest.ImpersonationConfig{UserName: user, Groups: groups}
+			}
+			return kubernetes.NewForConfig(impersonated)
+		},
+	}
+}
+
+// Run runs all checks.
+func (d *Doctor) Run(ctx context.Context) Report {
+	var results []Result
+	results = append(results, d.checkPlatforms()...)
+	results = append(results, d.checkRBAC(ctx)...)
+	results = append(results, d.checkRepositories(ctx)...)
+	results = append(results, d.checkWebhooks(ctx)...)
+	return Report{Results: results}
+}
+
+// ServeHTTP runs all checks, and responds with the report. It responds with 503 if any check failed.
+func (d *Doctor) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	report := d.Run(req.Context())
+
+	statusCode := http.StatusOK
+	if report.Failed() > 0 {
+		statusCode = http.StatusServiceUnavailable
+	}
+
+	respJSON, err := json.Marshal(report)
+	if err != nil {
+		http.Error(resp, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(statusCode)
+	_, _ = resp.Write(respJSON)
+}
+
+func sortedKeys[T any](in map[string]T) []string {
+	var out []string
+	for key := range in {
+		out = append(out, key)
+	}
+	sort.Strings(out)
+	return out
+}
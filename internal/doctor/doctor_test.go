@@ -0,0 +1,157 @@
+package doctor
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/kubeshop/botkube/internal/health"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+func TestDoctorRun(t *testing.T) {
+	// given
+	webhookSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}))
+	defer webhookSrv.Close()
+
+	cfg := config.Config{
+		Sources: map[string]config.Sources{
+			"k8s-events": {
+				Plugins: config.Plugins{
+					"botkube/kubernetes": {
+						Enabled: true,
+						Config: map[string]any{
+							"resources": []map[string]any{
+								{"type": "v1/pods"},
+								{"type": "apps/v1/deployments", "namespaces": map[string]any{"include": []string{"prod"}}},
+							},
+						},
+						Context: config.PluginContext{
+							RBAC: &config.PolicyRule{
+								Group: config.GroupPolicySubject{
+									Type:   config.StaticPolicySubjectType,
+									Prefix: "botkube-plugins-",
+									Static: config.GroupStaticSubject{Values: []string{"read"}},
+								},
+							},
+						},
+					},
+				},
+			},
+			"k8s-no-rbac": {
+				Plugins: config.Plugins{
+					"botkube/kubernetes": {Enabled: true},
+				},
+			},
+		},
+		Communications: map[string]config.Communications{
+			"default-group": {
+				Webhook: config.Webhook{Enabled: true, URL: webhookSrv.URL},
+			},
+			"other-group": {
+				Webhook: config.Webhook{Enabled: true, URL: "http://127.0.0.1:1"},
+			},
+		},
+	}
+
+	var impersonated []string
+	cli := fake.NewSimpleClientset()
+	cli.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authv1.SelfSubjectAccessReview)
+		attrs := review.Spec.ResourceAttributes
+		// deployments can be watched only in the `prod` namespace
+		review.Status.Allowed = attrs.Resource == "pods" || (attrs.Resource == "deployments" && attrs.Namespace == "prod" && attrs.Verb == "list")
+		return true, review, nil
+	})
+
+	d := New(loggerx.NewNoop(), cfg, nil, fakeHealthChecker{root: health.Component{
+		Components: []health.Component{
+			{Name: "default-group/socketSlack", Kind: health.BotComponentKind, Status: health.StatusUnHealthy, Reason: "Connection error", LastError: "invalid_auth"},
+			{Name: "default-group/discord", Kind: health.BotComponentKind, Status: health.StatusHealthy},
+			{Name: "default-group/elasticsearch", Kind: health.SinkComponentKind, Status: health.StatusHealthy},
+		},
+	}}, fakeRepositoryChecker{
+		"botkube": nil,
+		"private": errors.New("incorrect status code: 401"),
+	})
+	d.newK8sCli = func(user string, groups []string) (kubernetes.Interface, error) {
+		impersonated = append([]string{user}, groups...)
+		return cli, nil
+	}
+
+	// when
+	report := d.Run(context.Background())
+
+	// then
+	assert.Equal(t, []string{config.RBACDefaultUser, "botkube-plugins-read"}, impersonated)
+
+	type result struct {
+		Check  string
+		Target string
+		Status Status
+	}
+	var got []result
+	for _, res := range report.Results {
+		got = append(got, result{Check: res.Check, Target: res.Target, Status: res.Status})
+		if res.Status == FailStatus {
+			assert.NotEmpty(t, res.Hint, "failed check %s %s should have a hint", res.Check, res.Target)
+		}
+	}
+	assert.Equal(t, []result{
+		{Check: "platform", Target: "default-group/socketSlack", Status: FailStatus},
+		{Check: "platform", Target: "default-group/discord", Status: PassStatus},
+		{Check: "rbac", Target: "k8s-events/v1/pods", Status: PassStatus},
+		{Check: "rbac", Target: "k8s-events/apps/v1/deployments", Status: FailStatus},
+		{Check: "rbac", Target: "k8s-no-rbac", Status: FailStatus},
+		{Check: "repository", Target: "botkube", Status: PassStatus},
+		{Check: "repository", Target: "private", Status: FailStatus},
+		{Check: "webhook", Target: "default-group/webhook", Status: PassStatus},
+		{Check: "webhook", Target: "other-group/webhook", Status: FailStatus},
+	}, got)
+	assert.Equal(t, 5, report.Failed())
+
+	assert.Equal(t, "Connection error invalid_auth", report.Results[0].Details)
+	assert.Equal(t, `Denied: watch in "prod".`, report.Results[3].Details)
+}
+
+func TestDoctorServeHTTP(t *testing.T) {
+	// given
+	d := New(loggerx.NewNoop(), config.Config{}, nil, nil, fakeRepositoryChecker{
+		"botkube": errors.New("connection refused"),
+	})
+	rec := httptest.NewRecorder()
+
+	// when
+	d.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/doctor", nil))
+
+	// then
+	require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.JSONEq(t, `{"results":[{"check":"repository","target":"botkube","status":"fail","details":"connection refused","hint":"`+repositoryHint+`"}]}`, rec.Body.String())
+}
+
+type fakeHealthChecker struct {
+	root health.Component
+}
+
+func (f fakeHealthChecker) Components() health.Component {
+	return f.root
+}
+
+type fakeRepositoryChecker map[string]error
+
+func (f fakeRepositoryChecker) CheckRepositories(context.Context) map[string]error {
+	return f
+}
@@ -0,0 +1,236 @@
+package enrichment
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	sprig "github.com/go-task/slim-sprig"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/exp/maps"
+	"k8s.io/utils/strings/slices"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/api/source"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/httpx"
+	"github.com/kubeshop/botkube/pkg/multierror"
+)
+
+const (
+	defaultTimeout = 5 * time.Second
+	// maxResponseSize limits the size of the response body read from enrichment endpoints.
+	maxResponseSize = 1 << 20
+)
+
+// Request is the JSON body sent to enrichment endpoints.
+type Request struct {
+	Source      string         `json:"source"`
+	Cluster     string         `json:"cluster"`
+	Event       any            `json:"event"`
+	Object      any            `json:"object,omitempty"`
+	Enrichments map[string]any `json:"enrichments,omitempty"`
+}
+
+type templateData struct {
+	Event       any
+	Object      any
+	TopOwnerRef string
+	Enrichments map[string]any
+	Owner       source.Owner
+}
+
+type hook struct {
+	name    string
+	cfg     config.Enrichment
+	context *template.Template
+}
+
+// Enricher calls external HTTP endpoints to fetch additional data for source notifications.
+type Enricher struct {
+	log         logrus.FieldLogger
+	clusterName string
+	httpCli     *http.Client
+	hooks       []hook
+}
+
+// NewEnricher parses templates of all enabled enrichments and returns a new Enricher instance.
+func NewEnricher(log logrus.FieldLogger, clusterName string, cfg config.Enrichments) (*Enricher, error) {
+	names := maps.Keys(cfg)
+	sort.Strings(names)
+
+	errs := multierror.New()
+	var hooks []hook
+	for _, name := range names {
+		hookCfg := cfg[name]
+		if !hookCfg.Enabled {
+			continue
+		}
+
+		h := hook{name: name, cfg: hookCfg}
+		if hookCfg.Context != "" {
+			tpl, err := template.New(name).Funcs(sprig.TxtFuncMap()).Parse(hookCfg.Context)
+			if err != nil {
+				errs = multierror.Append(errs, fmt.Errorf("while parsing context template for enrichment %q: %w", name, err))
+				continue
+			}
+			h.context = tpl
+		}
+		hooks = append(hooks, h)
+	}
+	if err := errs.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+
+	return &Enricher{
+		log:         log,
+		clusterName: clusterName,
+		httpCli:     httpx.NewHTTPClient(),
+		hooks:       hooks,
+	}, nil
+}
+
+// Enrich calls all enrichments configured for a given source binding in alphabetical order of their names.
+// Responses are stored in the event action context, and rendered context templates are added to the message.
+// Enrichments which fail are skipped, so an unavailable endpoint doesn't stop notifications.
+func (e *Enricher) Enrich(ctx context.Context, event source.Event, sourceName string) source.Event {
+	var (
+		results map[string]any
+		notes   []string
+		owner   source.Owner
+	)
+	if event.ActionContext.Owner != nil {
+		owner = *event.ActionContext.Owner
+	}
+	for _, h := range e.hooks {
+		if len(h.cfg.Sources) > 0 && !slices.Contains(h.cfg.Sources, sourceName) {
+			continue
+		}
+		log := e.log.WithField("enrichment", h.name)
+
+		res, err := e.call(ctx, h, Request{
+			Source:      sourceName,
+			Cluster:     e.clusterName,
+			Event:       event.RawObject,
+			Object:      event.ActionContext.Object,
+			Enrichments: results,
+		})
+		if err != nil {
+			log.WithError(err).Error("Cannot enrich event. Skipping enrichment...")
+			continue
+		}
+
+		if results == nil {
+			results = map[string]any{}
+		}
+		results[h.name] = res
+
+		if h.context == nil {
+			continue
+		}
+		note, err := render(h.context, templateData{
+			Event:       event.RawObject,
+			Object:      event.ActionContext.Object,
+			TopOwnerRef: event.ActionContext.TopOwnerRef,
+			Enrichments: results,
+			Owner:       owner,
+		})
+		if err != nil {
+			log.WithError(err).Error("Cannot render context template. Skipping message modification...")
+			continue
+		}
+		if note != "" {
+			notes = append(notes, note)
+		}
+	}
+
+	if results == nil {
+		return event
+	}
+
+	enrichments := maps.Clone(event.ActionContext.Enrichments)
+	if enrichments == nil {
+		enrichments = map[string]any{}
+	}
+	maps.Copy(enrichments, results)
+	event.ActionContext.Enrichments = enrichments
+	event.Message = addContext(event.Message, notes)
+
+	return event
+}
+
+func (e *Enricher) call(ctx context.Context, h hook, in Request) (any, error) {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return nil, fmt.Errorf("while marshaling request body: %w", err)
+	}
+
+	timeout := h.cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("while creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, val := range h.cfg.Headers {
+		req.Header.Set(key, val)
+	}
+
+	res, err := e.httpCli.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("while sending request: %w", err)
+	}
+	defer res.Body.Close()
+
+	raw, err := io.ReadAll(io.LimitReader(res.Body, maxResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("while reading response body: %w", err)
+	}
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return nil, fmt.Errorf("got unexpected status code %d: %s", res.StatusCode, raw)
+	}
+
+	var out any
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil, fmt.Errorf("while unmarshaling response body: %w", err)
+	}
+	return out, nil
+}
+
+func render(tpl *template.Template, data templateData) (string, error) {
+	var buff strings.Builder
+	if err := tpl.Execute(&buff, data); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(buff.String()), nil
+}
+
+func addContext(msg api.Message, notes []string) api.Message {
+	if len(notes) == 0 {
+		return msg
+	}
+
+	// don't mutate sections shared with the original message
+	msg.Sections = append([]api.Section(nil), msg.Sections...)
+	if len(msg.Sections) == 0 {
+		msg.Sections = append(msg.Sections, api.Section{})
+	}
+	last := &msg.Sections[len(msg.Sections)-1]
+	last.Context = append(api.ContextItems(nil), last.Context...)
+	for _, note := range notes {
+		last.Context = append(last.Context, api.ContextItem{Text: note})
+	}
+	return msg
+}
@@ -0,0 +1,106 @@
+package enrichment
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/api/source"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+func TestEnricherEnrich(t *testing.T) {
+	// given
+	var requests []Request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var in Request
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&in))
+		requests = append(requests, in)
+
+		switch r.URL.Path {
+		case "/catalog":
+			assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+			_, _ = w.Write([]byte(`{"team":"payments"}`))
+		case "/oncall":
+			_, _ = w.Write([]byte(`{"person":"alice"}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer srv.Close()
+
+	enricher, err := NewEnricher(loggerx.NewNoop(), "prod", config.Enrichments{
+		"catalog": {
+			Enabled: true,
+			URL:     srv.URL + "/catalog",
+			Headers: map[string]string{"Authorization": "Bearer token"},
+			Context: "Owner: {{ .Enrichments.catalog.team }}",
+		},
+		"oncall": {
+			Enabled: true,
+			URL:     srv.URL + "/oncall",
+			Context: "On-call: {{ .Enrichments.oncall.person }} ({{ .Enrichments.catalog.team }})",
+		},
+		"broken": {
+			Enabled: true,
+			URL:     srv.URL + "/broken",
+			Context: "Should not be rendered",
+		},
+		"other-source": {
+			Enabled: true,
+			URL:     srv.URL + "/catalog",
+			Sources: []string{"other"},
+		},
+		"disabled": {
+			Enabled: false,
+			URL:     srv.URL + "/catalog",
+		},
+	})
+	require.NoError(t, err)
+
+	event := source.Event{
+		RawObject: map[string]any{"name": "api"},
+		Message: api.Message{Sections: []api.Section{{
+			Base:    api.Base{Header: "Pod api failed"},
+			Context: api.ContextItems{{Text: "Cluster: prod"}},
+		}}},
+	}
+
+	// when
+	out := enricher.Enrich(context.Background(), event, "k8s-err-events")
+
+	// then
+	require.Len(t, requests, 3)
+	assert.Equal(t, Request{Source: "k8s-err-events", Cluster: "prod", Event: map[string]any{"name": "api"}}, requests[0])
+	assert.Equal(t, map[string]any{"catalog": map[string]any{"team": "payments"}}, requests[2].Enrichments)
+
+	assert.Equal(t, map[string]any{
+		"catalog": map[string]any{"team": "payments"},
+		"oncall":  map[string]any{"person": "alice"},
+	}, out.ActionContext.Enrichments)
+	assert.Equal(t, api.ContextItems{
+		{Text: "Cluster: prod"},
+		{Text: "Owner: payments"},
+		{Text: "On-call: alice (payments)"},
+	}, out.Message.Sections[0].Context)
+
+	// original message is not mutated
+	assert.Equal(t, api.ContextItems{{Text: "Cluster: prod"}}, event.Message.Sections[0].Context)
+}
+
+func TestNewEnricherInvalidTemplate(t *testing.T) {
+	// when
+	_, err := NewEnricher(loggerx.NewNoop(), "prod", config.Enrichments{
+		"catalog": {Enabled: true, URL: "http://localhost", Context: "{{ .Enrichments"},
+	})
+
+	// then
+	assert.ErrorContains(t, err, `while parsing context template for enrichment "catalog"`)
+}
@@ -0,0 +1,474 @@
+package escalation
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/utils/strings/slices"
+
+	"github.com/kubeshop/botkube/internal/filter"
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/multierror"
+)
+
+var (
+	// ErrIncidentNotFound is returned when a given incident is not tracked anymore.
+	ErrIncidentNotFound = errors.New("incident not found")
+	// ErrIncidentAlreadyAcknowledged is returned when a given incident was already acknowledged.
+	ErrIncidentAlreadyAcknowledged = errors.New("incident already acknowledged")
+)
+
+// incidentIDBytes is the number of random bytes of incident IDs, so they can't be guessed.
+const incidentIDBytes = 8
+
+// SendFunc sends a given message to channels and sinks bound to given source bindings.
+type SendFunc func(ctx context.Context, msg interactive.CoreMessage, sources []string)
+
+// Notification holds a notification which can be acknowledged and escalated.
+type Notification struct {
+	filter.Input
+	// Header is the notification header used if the message doesn't have sections.
+	Header string
+	// Sources are source bindings used to send the original notification.
+	Sources []string
+	// Interactive is set if the notification is sent to platforms which support interactive buttons.
+	Interactive bool
+}
+
+// Incident holds details of a tracked notification.
+type Incident struct {
+	ID        string
+	Title     string
+	CreatedAt time.Time
+	// Escalations is the number of executed escalation steps.
+	Escalations int
+	// EscalationSteps is the number of escalation steps defined by the matching policy. It's zero if the incident is not escalated.
+	EscalationSteps int
+	AcknowledgedBy  string
+	AcknowledgedAt  time.Time
+	// Header and Message hold the original notification, preferably the interactive one, so it can be updated once acknowledged.
+	Header  string
+	Message api.Message
+}
+
+// IsAcknowledged returns true if the incident was acknowledged.
+func (i Incident) IsAcknowledged() bool {
+	return i.AcknowledgedBy != ""
+}
+
+type timer interface {
+	Stop() bool
+}
+
+type policy struct {
+	name    string
+	cfg     config.EscalationPolicy
+	program *filter.Program
+}
+
+// subscriber holds a notification sent for a given incident. The same event may be dispatched separately
+// for interactive and non-interactive platforms, so an incident has a subscriber for each of them.
+type subscriber struct {
+	notification Notification
+	send         SendFunc
+}
+
+type incident struct {
+	Incident
+
+	key         string
+	policy      *policy
+	subscribers map[bool]subscriber
+	timers      []timer
+}
+
+// Manager tracks notifications which can be acknowledged, and escalates them if they are not acknowledged in time.
+type Manager struct {
+	log       logrus.FieldLogger
+	cfg       config.Incidents
+	policies  []policy
+	now       func() time.Time
+	afterFunc func(d time.Duration, f func()) timer
+
+	mu        sync.Mutex
+	incidents map[string]*incident
+	byKey     map[string]*incident
+}
+
+// NewManager compiles all enabled escalation policies and returns a new Manager instance.
+func NewManager(log logrus.FieldLogger, cfg config.Incidents, escalations config.Escalations) (*Manager, error) {
+	names := make([]string, 0, len(escalations))
+	for name := range escalations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	errs := multierror.New()
+	var policies []policy
+	for _, name := range names {
+		policyCfg := escalations[name]
+		if !policyCfg.Enabled {
+			continue
+		}
+
+		p := policy{name: name, cfg: policyCfg}
+		if policyCfg.Condition != "" {
+			program, err := filter.Compile(policyCfg.Condition)
+			if err != nil {
+				errs = multierror.Append(errs, fmt.Errorf("while compiling condition for escalation policy %q: %w", name, err))
+				continue
+			}
+			p.program = program
+		}
+		policies = append(policies, p)
+	}
+	if err := errs.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+
+	return &Manager{
+		log:      log,
+		cfg:      cfg,
+		policies: policies,
+		now:      time.Now,
+		afterFunc: func(d time.Duration, f func()) timer {
+			return time.AfterFunc(d, f)
+		},
+		incidents: map[string]*incident{},
+		byKey:     map[string]*incident{},
+	}, nil
+}
+
+// Track starts tracking a given notification if it can be acknowledged or it matches any escalation policy.
+// In such case, it returns the message with acknowledgement instructions. Otherwise, the original message is returned.
+// It returns false if the notification shouldn't be sent, as the incident for a given resource is already acknowledged.
+func (m *Manager) Track(ctx context.Context, in Notification, send SendFunc) (api.Message, bool) {
+	key, err := resourceKey(in)
+	if err != nil {
+		m.log.WithError(err).Error("Cannot track notification")
+		return in.Message, true
+	}
+	p, hasPolicy := m.matchingPolicy(in.Input)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.prune()
+
+	if inc, ok := m.byKey[key]; ok {
+		if inc.IsAcknowledged() {
+			m.log.WithField("incident", inc.ID).Debug("Suppressing notification for acknowledged incident")
+			return api.Message{}, false
+		}
+		inc.subscribers[in.Interactive] = subscriber{notification: in, send: send}
+		return withAckSection(in.Message, inc.ID, in.Interactive), true
+	}
+
+	if !hasPolicy && !m.canBeAcknowledged(in.SourceName) {
+		return in.Message, true
+	}
+
+	id, err := newIncidentID()
+	if err != nil {
+		m.log.WithError(err).Error("Cannot track notification")
+		return in.Message, true
+	}
+	inc := &incident{
+		Incident: Incident{
+			ID:        id,
+			Title:     title(in),
+			CreatedAt: m.now(),
+		},
+		key:         key,
+		subscribers: map[bool]subscriber{in.Interactive: {notification: in, send: send}},
+	}
+	if hasPolicy {
+		inc.policy = &p
+		inc.EscalationSteps = len(p.cfg.Steps)
+		for idx := range p.cfg.Steps {
+			idx := idx
+			inc.timers = append(inc.timers, m.afterFunc(p.cfg.Steps[idx].After, func() {
+				m.escalate(ctx, inc.ID, idx)
+			}))
+		}
+	}
+	m.incidents[inc.ID] = inc
+	m.byKey[key] = inc
+
+	m.log.WithField("incident", inc.ID).Debug("Tracking notification")
+	return withAckSection(in.Message, inc.ID, in.Interactive), true
+}
+
+// Acknowledge marks a given incident as acknowledged and stops its escalation. Further notifications about the same resource
+// are suppressed for the configured period of time.
+// Only incidents sent to given source bindings can be acknowledged, other ones are reported as not found.
+func (m *Manager) Acknowledge(id string, sources []string, user string) (Incident, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.prune()
+
+	inc, ok := m.incidents[id]
+	if !ok || !inc.sentTo(sources) {
+		return Incident{}, ErrIncidentNotFound
+	}
+	if inc.IsAcknowledged() {
+		return inc.snapshot(), ErrIncidentAlreadyAcknowledged
+	}
+
+	for _, t := range inc.timers {
+		t.Stop()
+	}
+	inc.AcknowledgedBy = user
+	inc.AcknowledgedAt = m.now()
+
+	m.log.WithField("incident", id).Infof("Incident acknowledged by %s", user)
+	return inc.snapshot(), nil
+}
+
+// List returns incidents sent to given source bindings, sorted by creation time.
+func (m *Manager) List(sources []string) []Incident {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.prune()
+
+	out := make([]Incident, 0, len(m.incidents))
+	for _, inc := range m.incidents {
+		if !inc.sentTo(sources) {
+			continue
+		}
+		out = append(out, inc.snapshot())
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].CreatedAt.Equal(out[j].CreatedAt) {
+			return out[i].CreatedAt.Before(out[j].CreatedAt)
+		}
+		return out[i].ID < out[j].ID
+	})
+	return out
+}
+
+func (m *Manager) canBeAcknowledged(sourceName string) bool {
+	if !m.cfg.Enabled {
+		return false
+	}
+	return len(m.cfg.Sources) == 0 || slices.Contains(m.cfg.Sources, sourceName)
+}
+
+func (m *Manager) matchingPolicy(in filter.Input) (policy, bool) {
+	var vars map[string]any
+	for _, p := range m.policies {
+		if !slices.Contains(p.cfg.Sources, in.SourceName) {
+			continue
+		}
+		if p.program == nil {
+			return p, true
+		}
+
+		if vars == nil {
+			var err error
+			vars, err = filter.Variables(in)
+			if err != nil {
+				m.log.WithError(err).Error("Cannot prepare escalation condition variables")
+				return policy{}, false
+			}
+		}
+
+		matched, err := p.program.EvalBool(vars)
+		if err != nil {
+			m.log.WithError(err).WithField("policy", p.name).Debug("Cannot evaluate escalation condition. Skipping...")
+			continue
+		}
+		if matched {
+			return p, true
+		}
+	}
+	return policy{}, false
+}
+
+func (m *Manager) escalate(ctx context.Context, id string, stepIdx int) {
+	m.mu.Lock()
+	inc, ok := m.incidents[id]
+	if !ok || inc.IsAcknowledged() {
+		m.mu.Unlock()
+		return
+	}
+	if ctx.Err() != nil {
+		// configuration was reloaded or the agent is shutting down
+		m.remove(inc)
+		m.mu.Unlock()
+		return
+	}
+	inc.Escalations = stepIdx + 1
+	p := inc.policy
+	subscribers := make([]subscriber, 0, len(inc.subscribers))
+	for _, sub := range inc.subscribers {
+		subscribers = append(subscribers, sub)
+	}
+	m.mu.Unlock()
+
+	step := p.cfg.Steps[stepIdx]
+	for _, sub := range subscribers {
+		sources := step.RouteTo
+		if len(sources) == 0 {
+			sources = sub.notification.Sources
+		}
+
+		m.log.WithFields(logrus.Fields{
+			"incident": id,
+			"policy":   p.name,
+			"step":     stepIdx + 1,
+			"sources":  sources,
+		}).Info("Escalating notification which was not acknowledged")
+		sub.send(ctx, escalationMessage(id, sub.notification, step, stepIdx, len(p.cfg.Steps)), sources)
+	}
+}
+
+// prune removes incidents which are out of the retention period, and acknowledged incidents after the suppression period.
+// It must be called with the lock held.
+func (m *Manager) prune() {
+	now := m.now()
+	for _, inc := range m.incidents {
+		if inc.IsAcknowledged() {
+			if now.Sub(inc.AcknowledgedAt) >= m.cfg.SuppressFor {
+				m.remove(inc)
+			}
+			continue
+		}
+		if m.cfg.Retention > 0 && now.Sub(inc.CreatedAt) >= m.cfg.Retention {
+			m.remove(inc)
+		}
+	}
+}
+
+// remove stops tracking a given incident. It must be called with the lock held.
+func (m *Manager) remove(inc *incident) {
+	for _, t := range inc.timers {
+		t.Stop()
+	}
+	delete(m.incidents, inc.ID)
+	delete(m.byKey, inc.key)
+}
+
+// sentTo returns true if the incident was sent, or escalated, to any of given source bindings.
+func (i *incident) sentTo(sources []string) bool {
+	for _, sub := range i.subscribers {
+		for _, src := range sub.notification.Sources {
+			if slices.Contains(sources, src) {
+				return true
+			}
+		}
+	}
+	if i.policy == nil {
+		return false
+	}
+	for _, step := range i.policy.cfg.Steps[:i.Escalations] {
+		for _, src := range step.RouteTo {
+			if slices.Contains(sources, src) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (i *incident) snapshot() Incident {
+	out := i.Incident
+	sub, ok := i.subscribers[true]
+	if !ok {
+		sub = i.subscribers[false]
+	}
+	out.Header = sub.notification.Header
+	out.Message = sub.notification.Message
+	return out
+}
+
+func newIncidentID() (string, error) {
+	raw := make([]byte, incidentIDBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("while generating incident ID: %w", err)
+	}
+	return hex.EncodeToString(raw), nil
+}
+
+// resourceKey identifies notifications about the same resource. If the event doesn't describe any resource, the whole event is used.
+func resourceKey(in Notification) (string, error) {
+	raw, err := json.Marshal(in.Event)
+	if err != nil {
+		return "", fmt.Errorf("while marshaling event: %w", err)
+	}
+
+	var resource struct {
+		Kind      string
+		Namespace string
+		Name      string
+	}
+	if err := json.Unmarshal(raw, &resource); err == nil && resource.Kind != "" && resource.Name != "" {
+		return fmt.Sprintf("%s/%s/%s/%s", in.SourceName, resource.Kind, resource.Namespace, resource.Name), nil
+	}
+	return fmt.Sprintf("%s/%s", in.SourceName, raw), nil
+}
+
+func escalationMessage(id string, in Notification, step config.EscalationStep, stepIdx, stepsCount int) interactive.CoreMessage {
+	description := fmt.Sprintf("Not acknowledged for %s (escalation %d/%d).", step.After, stepIdx+1, stepsCount)
+	if len(step.Mentions) > 0 {
+		description = fmt.Sprintf("%s %s", strings.Join(step.Mentions, " "), description)
+	}
+
+	msg := in.Message
+	msg.Sections = append([]api.Section{
+		{
+			Base: api.Base{
+				Header:      fmt.Sprintf(":rotating_light: Escalation: %s", title(in)),
+				Description: description,
+			},
+		},
+	}, msg.Sections...)
+
+	return interactive.CoreMessage{
+		Header:  in.Header,
+		Message: withAckSection(msg, id, in.Interactive),
+	}
+}
+
+func withAckSection(msg api.Message, id string, isInteractive bool) api.Message {
+	section := api.Section{
+		Context: api.ContextItems{
+			{Text: fmt.Sprintf("Incident %s. To acknowledge it, run `%s ack incident %s`.", id, api.MessageBotNamePlaceholder, id)},
+		},
+	}
+	if isInteractive {
+		btnBuilder := api.NewMessageButtonBuilder()
+		section.Buttons = api.Buttons{
+			btnBuilder.ForCommandWithoutDesc("Acknowledge", fmt.Sprintf("ack incident %s", id), api.ButtonStylePrimary),
+		}
+	}
+
+	// don't mutate sections shared with the original message
+	msg.Sections = append(append([]api.Section(nil), msg.Sections...), section)
+	return msg
+}
+
+func title(in Notification) string {
+	for _, section := range in.Message.Sections {
+		if section.Header != "" {
+			return section.Header
+		}
+	}
+	if in.Header != "" {
+		return in.Header
+	}
+	return fmt.Sprintf("Notification from %q source", in.SourceName)
+}
@@ -0,0 +1,217 @@
+package escalation
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/internal/filter"
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+type fakeTimer struct {
+	fn      func()
+	stopped bool
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.stopped = true
+	return true
+}
+
+type sentMessage struct {
+	msg     interactive.CoreMessage
+	sources []string
+}
+
+func TestManagerEscalation(t *testing.T) {
+	// given
+	manager, err := NewManager(loggerx.NewNoop(), config.Incidents{Retention: 24 * time.Hour}, config.Escalations{
+		"critical": {
+			Enabled:   true,
+			Sources:   []string{"k8s-err-events"},
+			Condition: `event.Level == "error"`,
+			Steps: []config.EscalationStep{
+				{After: 10 * time.Minute, Mentions: []string{"<!here>"}},
+				{After: 30 * time.Minute, RouteTo: []string{"pager-duty"}},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	var timers []*fakeTimer
+	manager.afterFunc = func(_ time.Duration, f func()) timer {
+		tm := &fakeTimer{fn: f}
+		timers = append(timers, tm)
+		return tm
+	}
+
+	var sent []sentMessage
+	send := func(_ context.Context, msg interactive.CoreMessage, sources []string) {
+		sent = append(sent, sentMessage{msg: msg, sources: sources})
+	}
+	notification := func(level string) Notification {
+		return Notification{
+			Input: filter.Input{
+				SourceName: "k8s-err-events",
+				Event:      map[string]any{"Level": level, "Name": "api-0"},
+				Message: api.Message{
+					Sections: []api.Section{{Base: api.Base{Header: "Pod error"}}},
+				},
+			},
+			Sources:     []string{"k8s-err-events"},
+			Interactive: true,
+		}
+	}
+
+	// when
+	infoMsg, infoSent := manager.Track(context.Background(), notification("info"), send)
+	errMsg, errSent := manager.Track(context.Background(), notification("error"), send)
+
+	// then
+	assert.True(t, infoSent)
+	assert.True(t, errSent)
+	assert.Len(t, infoMsg.Sections, 1, "not matching notification should not be modified")
+	require.Len(t, errMsg.Sections, 2)
+	id := incidentID(t, errMsg)
+	assert.Len(t, id, 2*incidentIDBytes)
+	assert.Equal(t, api.MessageBotNamePlaceholder+" ack incident "+id, errMsg.Sections[1].Buttons[0].Command)
+	require.Len(t, timers, 2)
+
+	// when
+	timers[0].fn()
+	timers[1].fn()
+
+	// then
+	require.Len(t, sent, 2)
+	assert.Equal(t, []string{"k8s-err-events"}, sent[0].sources)
+	assert.Equal(t, ":rotating_light: Escalation: Pod error", sent[0].msg.Message.Sections[0].Header)
+	assert.Equal(t, "<!here> Not acknowledged for 10m0s (escalation 1/2).", sent[0].msg.Message.Sections[0].Description)
+	assert.Equal(t, []string{"pager-duty"}, sent[1].sources)
+
+	incidents := manager.List([]string{"pager-duty"})
+	require.Len(t, incidents, 1, "escalated incidents should be listed in channels they were routed to")
+	assert.Equal(t, id, incidents[0].ID)
+	assert.Equal(t, 2, incidents[0].Escalations)
+	assert.Equal(t, "Pod error", incidents[0].Title)
+	assert.Empty(t, manager.List([]string{"other"}))
+}
+
+func TestManagerAcknowledge(t *testing.T) {
+	// given
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	manager, err := NewManager(loggerx.NewNoop(), config.Incidents{
+		Enabled:     true,
+		Sources:     []string{"k8s-err-events"},
+		SuppressFor: time.Hour,
+		Retention:   24 * time.Hour,
+	}, config.Escalations{
+		"all": {
+			Enabled: true,
+			Sources: []string{"k8s-err-events"},
+			Steps:   []config.EscalationStep{{After: time.Minute}},
+		},
+	})
+	require.NoError(t, err)
+	manager.now = func() time.Time { return now }
+
+	var timers []*fakeTimer
+	manager.afterFunc = func(_ time.Duration, f func()) timer {
+		tm := &fakeTimer{fn: f}
+		timers = append(timers, tm)
+		return tm
+	}
+
+	sentCount := 0
+	send := func(context.Context, interactive.CoreMessage, []string) { sentCount++ }
+	in := Notification{
+		Input:   filter.Input{SourceName: "k8s-err-events", Event: map[string]any{"Kind": "Pod", "Namespace": "prod", "Name": "api-0", "Reason": "BackOff"}},
+		Header:  "Pod error",
+		Sources: []string{"k8s-err-events"},
+	}
+
+	// when the same event is dispatched for interactive and non-interactive platforms
+	_, sent := manager.Track(context.Background(), in, send)
+	assert.True(t, sent)
+	in.Interactive = true
+	msg, sent := manager.Track(context.Background(), in, send)
+	assert.True(t, sent)
+	id := incidentID(t, msg)
+
+	// then
+	_, err = manager.Acknowledge(id, []string{"other"}, "@mallory")
+	assert.ErrorIs(t, err, ErrIncidentNotFound, "incidents can be acknowledged only in channels they were sent to")
+
+	// when
+	now = now.Add(time.Minute)
+	inc, err := manager.Acknowledge(id, []string{"other", "k8s-err-events"}, "@john")
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "Pod error", inc.Title)
+	assert.Equal(t, "@john", inc.AcknowledgedBy)
+	assert.Equal(t, now, inc.AcknowledgedAt)
+	require.Len(t, timers, 1, "the same event should be tracked as a single incident")
+	assert.True(t, timers[0].stopped)
+
+	timers[0].fn()
+	assert.Zero(t, sentCount)
+
+	_, err = manager.Acknowledge(id, []string{"k8s-err-events"}, "@jane")
+	assert.ErrorIs(t, err, ErrIncidentAlreadyAcknowledged)
+
+	// when another event for the same resource is observed
+	in.Event = map[string]any{"Kind": "Pod", "Namespace": "prod", "Name": "api-0", "Reason": "Unhealthy"}
+	_, sent = manager.Track(context.Background(), in, send)
+
+	// then
+	assert.False(t, sent, "notifications about acknowledged resource should be suppressed")
+
+	// when the suppression period passes
+	now = now.Add(time.Hour)
+	msg, sent = manager.Track(context.Background(), in, send)
+
+	// then
+	assert.True(t, sent)
+	require.Len(t, msg.Sections, 1)
+	assert.NotEqual(t, id, incidentID(t, msg))
+}
+
+func TestManagerNotAcknowledgeableSource(t *testing.T) {
+	// given
+	manager, err := NewManager(loggerx.NewNoop(), config.Incidents{Enabled: true, Sources: []string{"k8s-err-events"}}, nil)
+	require.NoError(t, err)
+
+	in := Notification{
+		Input: filter.Input{
+			SourceName: "k8s-create-events",
+			Event:      map[string]any{"Kind": "Pod", "Name": "api-0"},
+			Message:    api.Message{Sections: []api.Section{{Base: api.Base{Header: "Pod created"}}}},
+		},
+	}
+
+	// when
+	msg, sent := manager.Track(context.Background(), in, nil)
+
+	// then
+	assert.True(t, sent)
+	assert.Equal(t, in.Message, msg)
+	assert.Empty(t, manager.List([]string{"k8s-create-events"}))
+}
+
+func incidentID(t *testing.T, msg api.Message) string {
+	t.Helper()
+	require.NotEmpty(t, msg.Sections)
+	buttons := msg.Sections[len(msg.Sections)-1].Buttons
+	require.Len(t, buttons, 1)
+	prefix := api.MessageBotNamePlaceholder + " ack incident "
+	require.True(t, strings.HasPrefix(buttons[0].Command, prefix))
+	return strings.TrimPrefix(buttons[0].Command, prefix)
+}
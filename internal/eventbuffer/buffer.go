@@ -0,0 +1,327 @@
+// Package eventbuffer provides a disk-backed buffer of notifications which couldn't be sent,
+// so they survive restarts and are replayed in order once the target is reachable again.
+package eventbuffer
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/kubeshop/botkube/internal/metrics"
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+const (
+	defaultRetryInterval = 30 * time.Second
+	openTimeout          = 10 * time.Second
+)
+
+var (
+	// each target has its own bucket with nested buckets for entries in order, and for IDs of entries used for deduplication
+	entriesBucket = []byte("entries")
+	idsBucket     = []byte("ids")
+)
+
+// entry is a buffered payload.
+type entry struct {
+	ID         string          `json:"id"`
+	BufferedAt time.Time       `json:"bufferedAt"`
+	Payload    json.RawMessage `json:"payload"`
+}
+
+// Buffer stores payloads per target, e.g. a communication platform or sink, in a bbolt database.
+type Buffer struct {
+	log           logrus.FieldLogger
+	db            *bolt.DB
+	maxEntries    int
+	maxAge        time.Duration
+	retryInterval time.Duration
+	now           func() time.Time
+}
+
+// New opens the buffer database. Payloads buffered before, e.g. by the previous Botkube run, are kept.
+func New(log logrus.FieldLogger, cfg config.EventBuffer) (*Buffer, error) {
+	if err := os.MkdirAll(filepath.Dir(cfg.Path), 0o700); err != nil {
+		return nil, fmt.Errorf("while creating directory for event buffer: %w", err)
+	}
+	// the timeout prevents from waiting forever for the file lock held by another Botkube instance, e.g. during rolling update
+	db, err := bolt.Open(cfg.Path, 0o600, &bolt.Options{Timeout: openTimeout})
+	if err != nil {
+		return nil, fmt.Errorf("while opening event buffer %q: %w", cfg.Path, err)
+	}
+
+	b := &Buffer{
+		log:           log,
+		db:            db,
+		maxEntries:    cfg.MaxEntries,
+		maxAge:        cfg.MaxAge,
+		retryInterval: cfg.RetryInterval,
+		now:           time.Now,
+	}
+	if b.retryInterval <= 0 {
+		b.retryInterval = defaultRetryInterval
+	}
+
+	metrics.RegisterQueue("event_buffer", b.TotalLen)
+	return b, nil
+}
+
+// Start calls a given function for each target with buffered payloads, once on start, and then periodically until the context is done.
+func (b *Buffer) Start(ctx context.Context, schedule func(target string)) {
+	ticker := time.NewTicker(b.retryInterval)
+	defer ticker.Stop()
+
+	for {
+		targets, err := b.targets()
+		if err != nil {
+			b.log.Errorf("while listing buffered targets: %s", err.Error())
+		}
+		for _, target := range targets {
+			schedule(target)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Append buffers a given payload for a given target. It returns false if the same payload is already buffered.
+// Once the maximum number of entries is reached, the oldest ones are dropped.
+func (b *Buffer) Append(target string, payload []byte) (bool, error) {
+	id := entryID(target, payload)
+	data, err := json.Marshal(entry{ID: id, BufferedAt: b.now(), Payload: payload})
+	if err != nil {
+		return false, fmt.Errorf("while marshaling entry: %w", err)
+	}
+
+	var (
+		appended bool
+		dropped  int
+	)
+	err = b.db.Update(func(tx *bolt.Tx) error {
+		entries, ids, err := targetBuckets(tx, target)
+		if err != nil {
+			return err
+		}
+		if ids.Get([]byte(id)) != nil {
+			return nil
+		}
+
+		seq, err := entries.NextSequence()
+		if err != nil {
+			return fmt.Errorf("while getting sequence: %w", err)
+		}
+		key := seqKey(seq)
+		if err := entries.Put(key, data); err != nil {
+			return fmt.Errorf("while writing entry: %w", err)
+		}
+		if err := ids.Put([]byte(id), key); err != nil {
+			return fmt.Errorf("while writing entry ID: %w", err)
+		}
+		appended = true
+
+		if b.maxEntries <= 0 {
+			return nil
+		}
+		for entries.Stats().KeyN > b.maxEntries {
+			if err := deleteFirst(entries, ids); err != nil {
+				return err
+			}
+			dropped++
+		}
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("while buffering payload for %q: %w", target, err)
+	}
+	if dropped > 0 {
+		b.log.Warnf("Dropped %d oldest buffered payload(s) for %q, as the buffer is full", dropped, target)
+	}
+	return appended, nil
+}
+
+// Len returns the number of payloads buffered for a given target.
+func (b *Buffer) Len(target string) int {
+	var out int
+	err := b.db.View(func(tx *bolt.Tx) error {
+		root := tx.Bucket([]byte(target))
+		if root == nil {
+			return nil
+		}
+		out = root.Bucket(entriesBucket).Stats().KeyN
+		return nil
+	})
+	if err != nil {
+		b.log.Errorf("while counting buffered payloads for %q: %s", target, err.Error())
+	}
+	return out
+}
+
+// TotalLen returns the number of all buffered payloads.
+func (b *Buffer) TotalLen() int {
+	var out int
+	err := b.db.View(func(tx *bolt.Tx) error {
+		return tx.ForEach(func(_ []byte, root *bolt.Bucket) error {
+			out += root.Bucket(entriesBucket).Stats().KeyN
+			return nil
+		})
+	})
+	if err != nil {
+		b.log.Errorf("while counting buffered payloads: %s", err.Error())
+	}
+	return out
+}
+
+// Replay passes payloads buffered for a given target to a given function, in order, and removes them once they are sent.
+// It stops on the first error, so the remaining payloads are replayed later. Payloads older than the maximum age are dropped.
+// It returns the number of sent payloads.
+func (b *Buffer) Replay(target string, send func(payload []byte) error) (int, error) {
+	var sent, expired int
+	defer func() {
+		if expired > 0 {
+			b.log.Warnf("Dropped %d buffered payload(s) for %q, as they are older than %s", expired, target, b.maxAge)
+		}
+	}()
+
+	for {
+		e, key, ok, err := b.first(target)
+		if err != nil {
+			return sent, err
+		}
+		if !ok {
+			return sent, nil
+		}
+
+		if b.maxAge > 0 && b.now().Sub(e.BufferedAt) > b.maxAge {
+			expired++
+		} else if err := send(e.Payload); err != nil {
+			return sent, err
+		} else {
+			sent++
+		}
+
+		if err := b.remove(target, key, e.ID); err != nil {
+			return sent, err
+		}
+	}
+}
+
+// Close closes the buffer database.
+func (b *Buffer) Close() error {
+	return b.db.Close()
+}
+
+func (b *Buffer) targets() ([]string, error) {
+	var out []string
+	err := b.db.View(func(tx *bolt.Tx) error {
+		return tx.ForEach(func(name []byte, root *bolt.Bucket) error {
+			if root.Bucket(entriesBucket).Stats().KeyN > 0 {
+				out = append(out, string(name))
+			}
+			return nil
+		})
+	})
+	return out, err
+}
+
+func (b *Buffer) first(target string) (entry, []byte, bool, error) {
+	var (
+		out entry
+		key []byte
+	)
+	err := b.db.View(func(tx *bolt.Tx) error {
+		root := tx.Bucket([]byte(target))
+		if root == nil {
+			return nil
+		}
+		k, v := root.Bucket(entriesBucket).Cursor().First()
+		if k == nil {
+			return nil
+		}
+		// the returned slices are valid only during the transaction
+		key = append([]byte(nil), k...)
+		return json.Unmarshal(v, &out)
+	})
+	if err != nil {
+		return entry{}, nil, false, fmt.Errorf("while reading buffered payload for %q: %w", target, err)
+	}
+	return out, key, key != nil, nil
+}
+
+func (b *Buffer) remove(target string, key []byte, id string) error {
+	err := b.db.Update(func(tx *bolt.Tx) error {
+		entries, ids, err := targetBuckets(tx, target)
+		if err != nil {
+			return err
+		}
+		if err := entries.Delete(key); err != nil {
+			return err
+		}
+		return ids.Delete([]byte(id))
+	})
+	if err != nil {
+		return fmt.Errorf("while removing buffered payload for %q: %w", target, err)
+	}
+	return nil
+}
+
+func targetBuckets(tx *bolt.Tx, target string) (*bolt.Bucket, *bolt.Bucket, error) {
+	root, err := tx.CreateBucketIfNotExists([]byte(target))
+	if err != nil {
+		return nil, nil, fmt.Errorf("while creating bucket: %w", err)
+	}
+	entries, err := root.CreateBucketIfNotExists(entriesBucket)
+	if err != nil {
+		return nil, nil, fmt.Errorf("while creating entries bucket: %w", err)
+	}
+	ids, err := root.CreateBucketIfNotExists(idsBucket)
+	if err != nil {
+		return nil, nil, fmt.Errorf("while creating IDs bucket: %w", err)
+	}
+	return entries, ids, nil
+}
+
+func deleteFirst(entries, ids *bolt.Bucket) error {
+	k, v := entries.Cursor().First()
+	if k == nil {
+		return nil
+	}
+	var e entry
+	if err := json.Unmarshal(v, &e); err == nil {
+		if err := ids.Delete([]byte(e.ID)); err != nil {
+			return fmt.Errorf("while removing entry ID: %w", err)
+		}
+	}
+	if err := entries.Delete(k); err != nil {
+		return fmt.Errorf("while removing entry: %w", err)
+	}
+	return nil
+}
+
+// entryID identifies a payload, so the same one is not buffered twice for a given target.
+func entryID(target string, payload []byte) string {
+	sum := sha256.New()
+	sum.Write([]byte(target))
+	sum.Write([]byte{0})
+	sum.Write(payload)
+	return hex.EncodeToString(sum.Sum(nil))
+}
+
+// seqKey returns a key which keeps entries sorted in the order they were appended.
+func seqKey(seq uint64) []byte {
+	out := make([]byte, 8)
+	binary.BigEndian.PutUint64(out, seq)
+	return out
+}
@@ -0,0 +1,159 @@
+package eventbuffer
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+func TestBufferReplaysInOrderAfterRestart(t *testing.T) {
+	// given
+	cfg := config.EventBuffer{Enabled: true, Path: filepath.Join(t.TempDir(), "buffer", "events.db")}
+	buffer, err := New(loggerx.NewNoop(), cfg)
+	require.NoError(t, err)
+
+	for _, payload := range []string{`"first"`, `"second"`, `"first"`, `"third"`} {
+		_, err := buffer.Append("bot:slack", []byte(payload))
+		require.NoError(t, err)
+	}
+	_, err = buffer.Append("sink:0:webhook", []byte(`"other"`))
+	require.NoError(t, err)
+	require.NoError(t, buffer.Close())
+
+	// when
+	buffer, err = New(loggerx.NewNoop(), cfg)
+	require.NoError(t, err)
+	defer buffer.Close()
+
+	var sent []string
+	n, err := buffer.Replay("bot:slack", func(payload []byte) error {
+		sent = append(sent, string(payload))
+		return nil
+	})
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+	assert.Equal(t, []string{`"first"`, `"second"`, `"third"`}, sent)
+	assert.Zero(t, buffer.Len("bot:slack"))
+	assert.Equal(t, 1, buffer.TotalLen())
+}
+
+func TestBufferReplayStopsOnError(t *testing.T) {
+	// given
+	buffer, err := New(loggerx.NewNoop(), config.EventBuffer{Enabled: true, Path: filepath.Join(t.TempDir(), "events.db")})
+	require.NoError(t, err)
+	defer buffer.Close()
+
+	for _, payload := range []string{`1`, `2`, `3`} {
+		_, err := buffer.Append("bot:slack", []byte(payload))
+		require.NoError(t, err)
+	}
+
+	// when
+	n, err := buffer.Replay("bot:slack", func(payload []byte) error {
+		if string(payload) == `2` {
+			return errors.New("platform unavailable")
+		}
+		return nil
+	})
+
+	// then
+	assert.EqualError(t, err, "platform unavailable")
+	assert.Equal(t, 1, n)
+	assert.Equal(t, 2, buffer.Len("bot:slack"))
+}
+
+func TestBufferDeduplicates(t *testing.T) {
+	// given
+	buffer, err := New(loggerx.NewNoop(), config.EventBuffer{Enabled: true, Path: filepath.Join(t.TempDir(), "events.db")})
+	require.NoError(t, err)
+	defer buffer.Close()
+
+	// when
+	first, err := buffer.Append("bot:slack", []byte(`"event"`))
+	require.NoError(t, err)
+	second, err := buffer.Append("bot:slack", []byte(`"event"`))
+	require.NoError(t, err)
+	otherTarget, err := buffer.Append("bot:discord", []byte(`"event"`))
+	require.NoError(t, err)
+
+	// then
+	assert.True(t, first)
+	assert.False(t, second)
+	assert.True(t, otherTarget)
+	assert.Equal(t, 2, buffer.TotalLen())
+}
+
+func TestBufferLimits(t *testing.T) {
+	// given
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	buffer, err := New(loggerx.NewNoop(), config.EventBuffer{
+		Enabled:    true,
+		Path:       filepath.Join(t.TempDir(), "events.db"),
+		MaxEntries: 2,
+		MaxAge:     time.Hour,
+	})
+	require.NoError(t, err)
+	defer buffer.Close()
+	buffer.now = func() time.Time { return now }
+
+	for _, payload := range []string{`1`, `2`} {
+		_, err := buffer.Append("bot:slack", []byte(payload))
+		require.NoError(t, err)
+	}
+	now = now.Add(2 * time.Hour)
+	_, err = buffer.Append("bot:slack", []byte(`3`))
+	require.NoError(t, err)
+
+	// when
+	var sent []string
+	_, err = buffer.Replay("bot:slack", func(payload []byte) error {
+		sent = append(sent, string(payload))
+		return nil
+	})
+
+	// then
+	require.NoError(t, err)
+	// `1` is dropped as the buffer is full, and `2` is too old
+	assert.Equal(t, []string{`3`}, sent)
+}
+
+func TestBufferStartSchedulesTargetsWithPayloads(t *testing.T) {
+	// given
+	buffer, err := New(loggerx.NewNoop(), config.EventBuffer{Enabled: true, Path: filepath.Join(t.TempDir(), "events.db")})
+	require.NoError(t, err)
+	defer buffer.Close()
+
+	_, err = buffer.Append("bot:slack", []byte(`1`))
+	require.NoError(t, err)
+	_, err = buffer.Append("bot:discord", []byte(`1`))
+	require.NoError(t, err)
+	_, err = buffer.Replay("bot:discord", func([]byte) error { return nil })
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	scheduled := make(chan string, 10)
+
+	// when
+	go buffer.Start(ctx, func(target string) {
+		scheduled <- target
+		cancel()
+	})
+
+	// then
+	select {
+	case target := <-scheduled:
+		assert.Equal(t, "bot:slack", target)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Target was not scheduled")
+	}
+}
@@ -0,0 +1,107 @@
+package ai
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kubeshop/botkube/pkg/api/executor"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/plugin"
+)
+
+// Provider defines the LLM API flavor.
+type Provider string
+
+const (
+	// OpenAIProvider uses the OpenAI chat completions API.
+	OpenAIProvider Provider = "openai"
+	// AzureProvider uses the Azure OpenAI chat completions API.
+	AzureProvider Provider = "azure"
+	// OllamaProvider uses the OpenAI-compatible API exposed by Ollama.
+	OllamaProvider Provider = "ollama"
+)
+
+// Config holds AI plugin configuration parameters.
+type Config struct {
+	Log     config.Logger `yaml:"log"`
+	LLM     LLM           `yaml:"llm"`
+	Context Context       `yaml:"context"`
+	// SafeCommandPrefixes holds allowed prefixes for suggested commands. Other suggestions are dropped.
+	SafeCommandPrefixes []string `yaml:"safeCommandPrefixes"`
+}
+
+// LLM holds the LLM endpoint configuration.
+type LLM struct {
+	Provider Provider `yaml:"provider"`
+	// BaseURL is the API address, e.g. https://api.openai.com or http://ollama.ollama:11434.
+	BaseURL string `yaml:"baseURL"`
+	// Model is the model name. For Azure, it's the deployment name.
+	Model  string `yaml:"model"`
+	APIKey string `yaml:"apiKey"`
+	// APIVersion is required only by Azure.
+	APIVersion  string        `yaml:"apiVersion"`
+	Temperature float64       `yaml:"temperature"`
+	Timeout     time.Duration `yaml:"timeout"`
+}
+
+// Context holds configuration for assembling the cluster context sent to the LLM.
+type Context struct {
+	DefaultNamespace string `yaml:"defaultNamespace"`
+	MaxEvents        int    `yaml:"maxEvents"`
+	MaxLogLines      int64  `yaml:"maxLogLines"`
+}
+
+// Validate validates the configuration.
+func (c Config) Validate() error {
+	switch c.LLM.Provider {
+	case OpenAIProvider, OllamaProvider:
+	case AzureProvider:
+		if c.LLM.APIVersion == "" {
+			return fmt.Errorf("the apiVersion property is required for %q provider", AzureProvider)
+		}
+	default:
+		return fmt.Errorf("unsupported provider %q, allowed values are %q, %q and %q", c.LLM.Provider, OpenAIProvider, AzureProvider, OllamaProvider)
+	}
+
+	if c.LLM.BaseURL == "" {
+		return fmt.Errorf("the baseURL property cannot be empty")
+	}
+	if c.LLM.Model == "" {
+		return fmt.Errorf("the model property cannot be empty")
+	}
+	if c.LLM.Provider != OllamaProvider && c.LLM.APIKey == "" {
+		return fmt.Errorf("the apiKey property is required for %q provider", c.LLM.Provider)
+	}
+	return nil
+}
+
+// MergeConfigs merges the AI configuration.
+func MergeConfigs(configs []*executor.Config) (Config, error) {
+	defaults := Config{
+		LLM: LLM{
+			Provider: OpenAIProvider,
+			BaseURL:  "https://api.openai.com",
+			Model:    "gpt-4o-mini",
+			Timeout:  60 * time.Second,
+		},
+		Context: Context{
+			DefaultNamespace: "default",
+			MaxEvents:        20,
+			MaxLogLines:      50,
+		},
+		SafeCommandPrefixes: []string{
+			"kubectl get",
+			"kubectl describe",
+			"kubectl logs",
+			"kubectl top",
+			"kubectl events",
+		},
+	}
+
+	var out Config
+	if err := plugin.MergeExecutorConfigsWithDefaults(defaults, configs, &out); err != nil {
+		return Config{}, fmt.Errorf("while merging configuration: %w", err)
+	}
+
+	return out, nil
+}
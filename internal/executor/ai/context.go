@@ -0,0 +1,180 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes"
+)
+
+var (
+	podNamePattern   = regexp.MustCompile(`(?i)\bpod(?:\s+|/)([a-z0-9]([-a-z0-9.]*[a-z0-9])?)`)
+	namespacePattern = regexp.MustCompile(`(?i)(?:^|\s)(?:-n\s+|--namespace[\s=]+|namespace\s+|ns\s+)([a-z0-9]([-a-z0-9]*[a-z0-9])?)`)
+)
+
+// subject holds the Kubernetes objects referenced in the question.
+type subject struct {
+	Namespace string
+	Pod       string
+}
+
+// parseSubject extracts the Pod name and Namespace from a free-form question, e.g. "why is pod foo in namespace bar failing?".
+func parseSubject(question, defaultNamespace string) subject {
+	out := subject{Namespace: defaultNamespace}
+	if match := podNamePattern.FindStringSubmatch(question); len(match) > 1 {
+		out.Pod = strings.TrimSuffix(match[1], ".")
+	}
+	if match := namespacePattern.FindStringSubmatch(question); len(match) > 1 {
+		out.Namespace = match[1]
+	}
+	return out
+}
+
+// clusterContext assembles a textual snapshot of cluster state relevant to a given subject.
+type clusterContext struct {
+	cli kubernetes.Interface
+	cfg Context
+}
+
+// Build returns the context in a plain text form. Failures are described inline, so the LLM is aware of missing data.
+func (c *clusterContext) Build(ctx context.Context, sub subject) string {
+	var out strings.Builder
+
+	if sub.Pod != "" {
+		pod := c.writePodStatus(ctx, &out, sub)
+		c.writePodLogs(ctx, &out, pod)
+	}
+	c.writeEvents(ctx, &out, sub)
+
+	return out.String()
+}
+
+func (c *clusterContext) writePodStatus(ctx context.Context, out *strings.Builder, sub subject) *corev1.Pod {
+	fmt.Fprintf(out, "## Pod %s/%s status\n", sub.Namespace, sub.Pod)
+
+	pod, err := c.cli.CoreV1().Pods(sub.Namespace).Get(ctx, sub.Pod, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		out.WriteString("Pod not found.\n\n")
+		return nil
+	case err != nil:
+		fmt.Fprintf(out, "Cannot get Pod: %s\n\n", err)
+		return nil
+	}
+
+	fmt.Fprintf(out, "Phase: %s\n", pod.Status.Phase)
+	if pod.Status.Reason != "" {
+		fmt.Fprintf(out, "Reason: %s %s\n", pod.Status.Reason, pod.Status.Message)
+	}
+	for _, cond := range pod.Status.Conditions {
+		if cond.Status == corev1.ConditionTrue {
+			continue
+		}
+		fmt.Fprintf(out, "Condition %s=%s: %s %s\n", cond.Type, cond.Status, cond.Reason, cond.Message)
+	}
+	for _, cs := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+		fmt.Fprintf(out, "Container %s: ready=%t restarts=%d%s\n", cs.Name, cs.Ready, cs.RestartCount, describeContainerState(cs))
+	}
+	out.WriteString("\n")
+	return pod
+}
+
+func (c *clusterContext) writePodLogs(ctx context.Context, out *strings.Builder, pod *corev1.Pod) {
+	if pod == nil || c.cfg.MaxLogLines <= 0 {
+		return
+	}
+
+	for _, container := range pod.Spec.Containers {
+		fmt.Fprintf(out, "## Logs of %s container (last %d lines)\n", container.Name, c.cfg.MaxLogLines)
+		tail := c.cfg.MaxLogLines
+		logs, err := c.readLogs(ctx, pod, container.Name, &tail)
+		if err != nil {
+			fmt.Fprintf(out, "Cannot get logs: %s\n\n", err)
+			continue
+		}
+		out.WriteString(logs)
+		out.WriteString("\n\n")
+	}
+}
+
+func (c *clusterContext) readLogs(ctx context.Context, pod *corev1.Pod, container string, tail *int64) (string, error) {
+	stream, err := c.cli.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+		Container: container,
+		TailLines: tail,
+	}).Stream(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer stream.Close()
+
+	raw, err := io.ReadAll(stream)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(raw)), nil
+}
+
+func (c *clusterContext) writeEvents(ctx context.Context, out *strings.Builder, sub subject) {
+	if c.cfg.MaxEvents <= 0 {
+		return
+	}
+
+	opts := metav1.ListOptions{}
+	if sub.Pod != "" {
+		opts.FieldSelector = fields.OneTermEqualSelector("involvedObject.name", sub.Pod).String()
+	}
+
+	fmt.Fprintf(out, "## Recent events in %s namespace\n", sub.Namespace)
+	events, err := c.cli.CoreV1().Events(sub.Namespace).List(ctx, opts)
+	if err != nil {
+		fmt.Fprintf(out, "Cannot list events: %s\n\n", err)
+		return
+	}
+
+	items := events.Items
+	sort.Slice(items, func(i, j int) bool {
+		return eventTime(items[i]).After(eventTime(items[j]).Time)
+	})
+	if len(items) > c.cfg.MaxEvents {
+		items = items[:c.cfg.MaxEvents]
+	}
+	if len(items) == 0 {
+		out.WriteString("No events found.\n\n")
+		return
+	}
+
+	for _, ev := range items {
+		fmt.Fprintf(out, "%s %s %s/%s: %s (x%d)\n", ev.Type, ev.Reason, ev.InvolvedObject.Kind, ev.InvolvedObject.Name, ev.Message, ev.Count)
+	}
+	out.WriteString("\n")
+}
+
+func eventTime(ev corev1.Event) metav1.Time {
+	if !ev.LastTimestamp.IsZero() {
+		return ev.LastTimestamp
+	}
+	if !ev.EventTime.IsZero() {
+		return metav1.NewTime(ev.EventTime.Time)
+	}
+	return ev.CreationTimestamp
+}
+
+func describeContainerState(cs corev1.ContainerStatus) string {
+	switch {
+	case cs.State.Waiting != nil:
+		return fmt.Sprintf(" waiting=%s %s", cs.State.Waiting.Reason, cs.State.Waiting.Message)
+	case cs.State.Terminated != nil:
+		return fmt.Sprintf(" terminated=%s exitCode=%d", cs.State.Terminated.Reason, cs.State.Terminated.ExitCode)
+	case cs.LastTerminationState.Terminated != nil:
+		return fmt.Sprintf(" lastTerminated=%s exitCode=%d", cs.LastTerminationState.Terminated.Reason, cs.LastTerminationState.Terminated.ExitCode)
+	}
+	return ""
+}
@@ -0,0 +1,244 @@
+package ai
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/sirupsen/logrus"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/api/executor"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+	"github.com/kubeshop/botkube/pkg/plugin"
+)
+
+const (
+	// PluginName is the name of the AI Botkube plugin.
+	PluginName  = "ai"
+	description = "Ask questions about your cluster and get an explanation based on recent events, Pod status and logs."
+
+	// maxSuggestedCommands limits the number of rendered command buttons.
+	maxSuggestedCommands = 5
+	// unsafeCommandChars holds characters which allow chaining or redirecting commands.
+	unsafeCommandChars = ";|&`$<>\n"
+)
+
+var (
+	//go:embed config_schema.json
+	configJSONSchema string
+
+	systemPrompt = heredoc.Doc(`
+		You are a Kubernetes expert helping to troubleshoot a cluster from a chat.
+		Answer the question using the provided cluster context. Be concise and specific.
+		If the context is not sufficient, say what is missing.
+		Respond ONLY with a JSON object in the following format:
+		{"explanation": "<markdown explanation>", "commands": [{"command": "<kubectl command>", "description": "<what it shows>"}]}
+		Suggest only read-only kubectl commands that help to investigate the issue further.`)
+)
+
+var _ executor.Executor = &Executor{}
+
+type (
+	llmCompleter interface {
+		Complete(ctx context.Context, cfg LLM, messages []chatMessage) (string, error)
+	}
+	kubeClientProvider func(kubeConfig []byte) (kubernetes.Interface, error)
+)
+
+// Answer holds the parsed LLM response.
+type Answer struct {
+	Explanation string             `json:"explanation"`
+	Commands    []SuggestedCommand `json:"commands"`
+}
+
+// SuggestedCommand holds a command suggested by LLM.
+type SuggestedCommand struct {
+	Command     string `json:"command"`
+	Description string `json:"description"`
+}
+
+// Executor provides functionality for answering questions with LLM.
+type Executor struct {
+	pluginVersion string
+	llm           llmCompleter
+	kubeClient    kubeClientProvider
+}
+
+// NewExecutor returns a new Executor instance.
+func NewExecutor(ver string, llm llmCompleter) *Executor {
+	return &Executor{
+		pluginVersion: ver,
+		llm:           llm,
+		kubeClient:    newKubeClient,
+	}
+}
+
+// Metadata returns details about AI plugin.
+func (e *Executor) Metadata(context.Context) (api.MetadataOutput, error) {
+	return api.MetadataOutput{
+		Version:          e.pluginVersion,
+		Description:      description,
+		DocumentationURL: "https://docs.botkube.io/configuration/executor/ai",
+		JSONSchema: api.JSONSchema{
+			Value: configJSONSchema,
+		},
+	}, nil
+}
+
+// Execute returns an LLM explanation for a given question.
+func (e *Executor) Execute(ctx context.Context, in executor.ExecuteInput) (executor.ExecuteOutput, error) {
+	cfg, err := MergeConfigs(in.Configs)
+	if err != nil {
+		return executor.ExecuteOutput{}, fmt.Errorf("while merging input configs: %w", err)
+	}
+
+	question := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(in.Command), PluginName))
+	if question == "" || question == "help" || question == "--help" || question == "-h" {
+		return executor.ExecuteOutput{Message: help()}, nil
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return executor.ExecuteOutput{}, fmt.Errorf("while validating configuration: %w", err)
+	}
+
+	if err := plugin.ValidateKubeConfigProvided(PluginName, in.Context.KubeConfig); err != nil {
+		return executor.ExecuteOutput{}, err
+	}
+
+	log := loggerx.New(cfg.Log)
+
+	cli, err := e.kubeClient(in.Context.KubeConfig)
+	if err != nil {
+		return executor.ExecuteOutput{}, err
+	}
+
+	sub := parseSubject(question, cfg.Context.DefaultNamespace)
+	log.WithFields(logrus.Fields{
+		"namespace": sub.Namespace,
+		"pod":       sub.Pod,
+	}).Debug("Assembling cluster context...")
+	clusterCtx := (&clusterContext{cli: cli, cfg: cfg.Context}).Build(ctx, sub)
+
+	raw, err := e.llm.Complete(ctx, cfg.LLM, []chatMessage{
+		{Role: "system", Content: systemPrompt},
+		{Role: "user", Content: fmt.Sprintf("Cluster context:\n\n%s\nQuestion: %s", clusterCtx, question)},
+	})
+	if err != nil {
+		return executor.ExecuteOutput{}, fmt.Errorf("while asking LLM: %w", err)
+	}
+
+	answer := ParseAnswer(raw)
+	answer.Commands = filterSafeCommands(answer.Commands, cfg.SafeCommandPrefixes)
+
+	return executor.ExecuteOutput{
+		Message: answerMessage(question, answer),
+	}, nil
+}
+
+// Help returns help message.
+func (*Executor) Help(context.Context) (api.Message, error) {
+	return help(), nil
+}
+
+// ParseAnswer parses the LLM response. If it's not a valid JSON, the whole response is used as the explanation.
+func ParseAnswer(raw string) Answer {
+	trimmed := strings.TrimSpace(raw)
+	trimmed = strings.TrimPrefix(trimmed, "```json")
+	trimmed = strings.TrimPrefix(trimmed, "```")
+	trimmed = strings.TrimSuffix(trimmed, "```")
+
+	var out Answer
+	if err := json.Unmarshal([]byte(strings.TrimSpace(trimmed)), &out); err != nil || out.Explanation == "" {
+		return Answer{Explanation: strings.TrimSpace(raw)}
+	}
+	return out
+}
+
+// filterSafeCommands drops suggestions which don't start with an allowed prefix or try to chain other commands.
+func filterSafeCommands(in []SuggestedCommand, prefixes []string) []SuggestedCommand {
+	var out []SuggestedCommand
+	for _, cmd := range in {
+		normalized := strings.Join(strings.Fields(cmd.Command), " ")
+		if normalized == "" || strings.ContainsAny(normalized, unsafeCommandChars) {
+			continue
+		}
+		if !hasAnyPrefix(normalized, prefixes) {
+			continue
+		}
+		cmd.Command = normalized
+		out = append(out, cmd)
+		if len(out) == maxSuggestedCommands {
+			break
+		}
+	}
+	return out
+}
+
+func hasAnyPrefix(cmd string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		prefix = strings.Join(strings.Fields(prefix), " ")
+		if prefix == "" {
+			continue
+		}
+		if cmd == prefix || strings.HasPrefix(cmd, prefix+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+func answerMessage(question string, answer Answer) api.Message {
+	sections := []api.Section{
+		{
+			Base: api.Base{
+				Header: "AI assistant",
+				Body: api.Body{
+					Plaintext: answer.Explanation,
+				},
+			},
+			Context: api.ContextItems{
+				{Text: fmt.Sprintf("Question: %s", question)},
+			},
+		},
+	}
+
+	if len(answer.Commands) > 0 {
+		btnBuilder := api.NewMessageButtonBuilder()
+		var btns api.Buttons
+		for _, cmd := range answer.Commands {
+			desc := cmd.Command
+			if cmd.Description != "" {
+				desc = fmt.Sprintf("%s: %s", cmd.Description, cmd.Command)
+			}
+			btns = append(btns, btnBuilder.ForCommandWithItalicDesc("Run", desc, cmd.Command))
+		}
+		sections = append(sections, api.Section{
+			Base: api.Base{
+				Description: "Suggested commands",
+			},
+			Buttons: btns,
+		})
+	}
+
+	return api.Message{
+		Sections: sections,
+	}
+}
+
+func newKubeClient(kubeConfig []byte) (kubernetes.Interface, error) {
+	restCfg, err := clientcmd.RESTConfigFromKubeConfig(kubeConfig)
+	if err != nil {
+		return nil, fmt.Errorf("while reading kube config: %w", err)
+	}
+	cli, err := kubernetes.NewForConfig(restCfg)
+	if err != nil {
+		return nil, fmt.Errorf("while creating kube client: %w", err)
+	}
+	return cli, nil
+}
@@ -0,0 +1,150 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/api/executor"
+)
+
+func TestParseSubject(t *testing.T) {
+	tests := []struct {
+		question string
+		expected subject
+	}{
+		{
+			question: "why is pod api-7d9f in namespace prod failing?",
+			expected: subject{Namespace: "prod", Pod: "api-7d9f"},
+		},
+		{
+			question: "what's wrong with pod/web-0 -n shop",
+			expected: subject{Namespace: "shop", Pod: "web-0"},
+		},
+		{
+			question: "why are pods restarting?",
+			expected: subject{Namespace: "default"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.question, func(t *testing.T) {
+			assert.Equal(t, tc.expected, parseSubject(tc.question, "default"))
+		})
+	}
+}
+
+func TestParseAnswer(t *testing.T) {
+	// given
+	raw := "```json\n{\"explanation\": \"Image cannot be pulled.\", \"commands\": [{\"command\": \"kubectl describe pod api -n prod\", \"description\": \"Describe Pod\"}]}\n```"
+
+	// when
+	got := ParseAnswer(raw)
+
+	// then
+	assert.Equal(t, "Image cannot be pulled.", got.Explanation)
+	require.Len(t, got.Commands, 1)
+	assert.Equal(t, "kubectl describe pod api -n prod", got.Commands[0].Command)
+
+	// when not a JSON
+	got = ParseAnswer("Just text")
+
+	// then
+	assert.Equal(t, Answer{Explanation: "Just text"}, got)
+}
+
+func TestFilterSafeCommands(t *testing.T) {
+	// given
+	in := []SuggestedCommand{
+		{Command: "kubectl  get pods -n prod"},
+		{Command: "kubectl delete pod api -n prod"},
+		{Command: "kubectl get pods; kubectl delete ns prod"},
+		{Command: "kubectl getter"},
+		{Command: "kubectl logs api -n prod | grep error"},
+	}
+
+	// when
+	got := filterSafeCommands(in, []string{"kubectl get", "kubectl logs"})
+
+	// then
+	assert.Equal(t, []SuggestedCommand{{Command: "kubectl get pods -n prod"}}, got)
+}
+
+func TestExecutorExecute(t *testing.T) {
+	// given
+	var gotReq chatCompletionRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/chat/completions", r.URL.Path)
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&gotReq))
+
+		content, _ := json.Marshal(Answer{
+			Explanation: "The image tag doesn't exist.",
+			Commands: []SuggestedCommand{
+				{Command: "kubectl describe pod api -n prod", Description: "Describe Pod"},
+				{Command: "kubectl delete pod api -n prod", Description: "Delete Pod"},
+			},
+		})
+		resp := chatCompletionResponse{}
+		resp.Choices = append(resp.Choices, struct {
+			Message chatMessage `json:"message"`
+		}{Message: chatMessage{Role: "assistant", Content: string(content)}})
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer srv.Close()
+
+	cli := fake.NewSimpleClientset(
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "prod"},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodPending,
+				ContainerStatuses: []corev1.ContainerStatus{
+					{Name: "app", State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff"}}},
+				},
+			},
+		},
+		&corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: "api.1", Namespace: "prod"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "api"},
+			Type:           "Warning",
+			Reason:         "Failed",
+			Message:        "Failed to pull image",
+		},
+	)
+
+	exec := NewExecutor("dev", NewLLMClient())
+	exec.kubeClient = func([]byte) (kubernetes.Interface, error) { return cli, nil }
+
+	// when
+	out, err := exec.Execute(context.Background(), executor.ExecuteInput{
+		Command: "ai why is pod api in namespace prod failing?",
+		Configs: []*executor.Config{
+			{RawYAML: []byte("llm:\n  baseURL: " + srv.URL + "\n  apiKey: secret\n")},
+		},
+		Context: executor.ExecuteInputContext{KubeConfig: []byte("kubeconfig")},
+	})
+
+	// then
+	require.NoError(t, err)
+
+	require.Len(t, gotReq.Messages, 2)
+	assert.Equal(t, "gpt-4o-mini", gotReq.Model)
+	assert.Contains(t, gotReq.Messages[1].Content, "waiting=ImagePullBackOff")
+	assert.Contains(t, gotReq.Messages[1].Content, "Warning Failed Pod/api: Failed to pull image")
+	assert.Contains(t, gotReq.Messages[1].Content, "## Logs of app container")
+
+	require.Len(t, out.Message.Sections, 2)
+	assert.Equal(t, "The image tag doesn't exist.", out.Message.Sections[0].Body.Plaintext)
+	require.Len(t, out.Message.Sections[1].Buttons, 1)
+	assert.Equal(t, api.MessageBotNamePlaceholder+" kubectl describe pod api -n prod", out.Message.Sections[1].Buttons[0].Command)
+}
@@ -0,0 +1,38 @@
+package ai
+
+import (
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc"
+
+	"github.com/kubeshop/botkube/pkg/api"
+)
+
+func help() api.Message {
+	btnBuilder := api.NewMessageButtonBuilder()
+	return api.Message{
+		Sections: []api.Section{
+			{
+				Base: api.Base{
+					Header:      "Ask AI about your cluster",
+					Description: description,
+				},
+			},
+			{
+				Base: api.Base{
+					Body: api.Body{
+						CodeBlock: heredoc.Doc(`
+							Usage:
+							  ai <question>
+
+							Mention a Pod and its Namespace to include its status and logs, e.g.:
+							  ai why is pod api-7d9f in namespace prod failing?`),
+					},
+				},
+				Buttons: []api.Button{
+					btnBuilder.ForCommandWithDescCmd("Ask about warnings", fmt.Sprintf("%s what are the recent warnings in namespace default?", PluginName)),
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,125 @@
+package ai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// maxErrBodyLength limits the LLM API error response included in the error message.
+const maxErrBodyLength = 300
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type chatCompletionRequest struct {
+	Model       string        `json:"model,omitempty"`
+	Messages    []chatMessage `json:"messages"`
+	Temperature float64       `json:"temperature"`
+}
+
+type chatCompletionResponse struct {
+	Choices []struct {
+		Message chatMessage `json:"message"`
+	} `json:"choices"`
+}
+
+// LLMClient calls chat completions API compatible with OpenAI.
+type LLMClient struct {
+	httpCli *http.Client
+}
+
+// NewLLMClient returns a new LLMClient instance.
+func NewLLMClient() *LLMClient {
+	return &LLMClient{
+		// timeout is configurable and applied per request, as LLM responses can take a while
+		httpCli: &http.Client{},
+	}
+}
+
+// Complete sends given messages to the configured LLM and returns the response content.
+func (c *LLMClient) Complete(ctx context.Context, cfg LLM, messages []chatMessage) (string, error) {
+	endpoint, err := completionsURL(cfg)
+	if err != nil {
+		return "", err
+	}
+
+	reqBody := chatCompletionRequest{
+		Messages:    messages,
+		Temperature: cfg.Temperature,
+	}
+	if cfg.Provider != AzureProvider { // for Azure, model is part of the URL
+		reqBody.Model = cfg.Model
+	}
+
+	raw, err := json.Marshal(reqBody)
+	if err != nil {
+		return "", fmt.Errorf("while marshaling request: %w", err)
+	}
+
+	if cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.Timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(raw))
+	if err != nil {
+		return "", fmt.Errorf("while creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	switch {
+	case cfg.Provider == AzureProvider:
+		req.Header.Set("api-key", cfg.APIKey)
+	case cfg.APIKey != "":
+		req.Header.Set("Authorization", "Bearer "+cfg.APIKey)
+	}
+
+	res, err := c.httpCli.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("while calling LLM API: %w", err)
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return "", fmt.Errorf("while reading response body: %w", err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		msg := string(body)
+		if len(msg) > maxErrBodyLength {
+			msg = msg[:maxErrBodyLength]
+		}
+		return "", fmt.Errorf("LLM API returned unexpected status code %d: %s", res.StatusCode, msg)
+	}
+
+	var out chatCompletionResponse
+	if err := json.Unmarshal(body, &out); err != nil {
+		return "", fmt.Errorf("while unmarshaling response: %w", err)
+	}
+	if len(out.Choices) == 0 {
+		return "", fmt.Errorf("LLM API returned no choices")
+	}
+
+	return out.Choices[0].Message.Content, nil
+}
+
+func completionsURL(cfg LLM) (string, error) {
+	base := strings.TrimSuffix(cfg.BaseURL, "/")
+	switch cfg.Provider {
+	case AzureProvider:
+		return fmt.Sprintf("%s/openai/deployments/%s/chat/completions?api-version=%s", base, url.PathEscape(cfg.Model), url.QueryEscape(cfg.APIVersion)), nil
+	case OpenAIProvider, OllamaProvider:
+		return base + "/v1/chat/completions", nil
+	default:
+		return "", fmt.Errorf("unsupported provider %q", cfg.Provider)
+	}
+}
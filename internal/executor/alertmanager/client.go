@@ -0,0 +1,151 @@
+package alertmanager
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// maxErrBodyLength limits the Alertmanager API error response included in the error message.
+const maxErrBodyLength = 300
+
+// SilenceState describes the state of a silence.
+type SilenceState string
+
+const (
+	// SilenceStateActive means that the silence mutes matching alerts.
+	SilenceStateActive SilenceState = "active"
+	// SilenceStatePending means that the silence starts in the future.
+	SilenceStatePending SilenceState = "pending"
+	// SilenceStateExpired means that the silence ended or was expired.
+	SilenceStateExpired SilenceState = "expired"
+)
+
+// Silence holds the Alertmanager silence.
+type Silence struct {
+	ID        string    `json:"id,omitempty"`
+	Matchers  []Matcher `json:"matchers"`
+	StartsAt  time.Time `json:"startsAt"`
+	EndsAt    time.Time `json:"endsAt"`
+	CreatedBy string    `json:"createdBy"`
+	Comment   string    `json:"comment"`
+	Status    *struct {
+		State SilenceState `json:"state"`
+	} `json:"status,omitempty"`
+}
+
+// State returns the silence state.
+func (s Silence) State() SilenceState {
+	if s.Status == nil {
+		return ""
+	}
+	return s.Status.State
+}
+
+type postSilenceResponse struct {
+	SilenceID string `json:"silenceID"`
+}
+
+// APIClient calls the Alertmanager API v2.
+type APIClient struct {
+	httpCli *http.Client
+}
+
+// NewAPIClient returns a new APIClient instance.
+func NewAPIClient() *APIClient {
+	return &APIClient{
+		// timeout is configurable and applied per request
+		httpCli: &http.Client{},
+	}
+}
+
+// CreateSilence creates a given silence and returns its ID.
+func (c *APIClient) CreateSilence(ctx context.Context, cfg Config, silence Silence) (string, error) {
+	raw, err := json.Marshal(silence)
+	if err != nil {
+		return "", fmt.Errorf("while marshaling silence: %w", err)
+	}
+
+	var out postSilenceResponse
+	if err := c.do(ctx, cfg, http.MethodPost, "/api/v2/silences", nil, raw, &out); err != nil {
+		return "", err
+	}
+	return out.SilenceID, nil
+}
+
+// ListSilences returns silences matching all given matchers.
+func (c *APIClient) ListSilences(ctx context.Context, cfg Config, matchers []Matcher) ([]Silence, error) {
+	query := url.Values{}
+	for _, m := range matchers {
+		// the filter uses the PromQL-like syntax, so the value is quoted
+		query.Add("filter", fmt.Sprintf("%s%s%q", m.Name, m.operator(), m.Value))
+	}
+
+	var out []Silence
+	if err := c.do(ctx, cfg, http.MethodGet, "/api/v2/silences", query, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ExpireSilence expires a given silence.
+func (c *APIClient) ExpireSilence(ctx context.Context, cfg Config, id string) error {
+	return c.do(ctx, cfg, http.MethodDelete, "/api/v2/silence/"+url.PathEscape(id), nil, nil, nil)
+}
+
+func (c *APIClient) do(ctx context.Context, cfg Config, method, path string, query url.Values, body []byte, dest any) error {
+	endpoint := strings.TrimSuffix(cfg.URL, "/") + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	if cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.Timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("while creating request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for key, val := range cfg.Headers {
+		req.Header.Set(key, val)
+	}
+
+	res, err := c.httpCli.Do(req)
+	if err != nil {
+		return fmt.Errorf("while calling Alertmanager API: %w", err)
+	}
+	defer res.Body.Close()
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return fmt.Errorf("while reading response body: %w", err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		msg := strings.TrimSpace(string(resBody))
+		if len(msg) > maxErrBodyLength {
+			msg = msg[:maxErrBodyLength]
+		}
+		return fmt.Errorf("Alertmanager API returned unexpected status code %d: %s", res.StatusCode, msg)
+	}
+
+	if dest == nil {
+		return nil
+	}
+	if err := json.Unmarshal(resBody, dest); err != nil {
+		return fmt.Errorf("while unmarshaling response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package alertmanager
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kubeshop/botkube/pkg/api/executor"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/plugin"
+)
+
+// Config holds Alertmanager plugin configuration parameters.
+type Config struct {
+	Log config.Logger `yaml:"log"`
+	// URL is the Alertmanager address, e.g. http://alertmanager-operated.monitoring:9093.
+	URL string `yaml:"url"`
+	// Headers are added to all Alertmanager API requests, e.g. to authenticate against a proxy.
+	Headers map[string]string `yaml:"headers,omitempty"`
+	// DefaultDuration is used for silences created without the duration specified.
+	DefaultDuration time.Duration `yaml:"defaultDuration"`
+	// ButtonDurations holds durations offered by the silence buttons attached to alert notifications.
+	ButtonDurations []time.Duration `yaml:"buttonDurations"`
+	Timeout         time.Duration   `yaml:"timeout"`
+}
+
+// Validate validates the configuration.
+func (c Config) Validate() error {
+	if c.URL == "" {
+		return fmt.Errorf("the url property cannot be empty")
+	}
+	if c.DefaultDuration <= 0 {
+		return fmt.Errorf("the defaultDuration property must be positive")
+	}
+	return nil
+}
+
+// MergeConfigs merges the Alertmanager configuration.
+func MergeConfigs(configs []*executor.Config) (Config, error) {
+	defaults := Config{
+		DefaultDuration: 2 * time.Hour,
+		ButtonDurations: []time.Duration{time.Hour, 4 * time.Hour, 24 * time.Hour},
+		Timeout:         30 * time.Second,
+	}
+
+	var out Config
+	if err := plugin.MergeExecutorConfigsWithDefaults(defaults, configs, &out); err != nil {
+		return Config{}, fmt.Errorf("while merging configuration: %w", err)
+	}
+
+	return out, nil
+}
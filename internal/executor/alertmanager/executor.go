@@ -0,0 +1,231 @@
+package alertmanager
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/alexflint/go-arg"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/api/executor"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+	"github.com/kubeshop/botkube/pkg/plugin"
+)
+
+const (
+	// PluginName is the name of the Alertmanager Botkube plugin.
+	PluginName  = "alertmanager"
+	description = "Create, list, and expire Alertmanager silences."
+
+	// maxListedSilences limits the number of listed silences to keep the message readable.
+	maxListedSilences = 15
+)
+
+//go:embed config_schema.json
+var configJSONSchema string
+
+var _ executor.Executor = &Executor{}
+
+type apiClient interface {
+	CreateSilence(ctx context.Context, cfg Config, silence Silence) (string, error)
+	ListSilences(ctx context.Context, cfg Config, matchers []Matcher) ([]Silence, error)
+	ExpireSilence(ctx context.Context, cfg Config, id string) error
+}
+
+// Command defines the supported Alertmanager plugin commands.
+type Command struct {
+	Silence *SilenceCommand `arg:"subcommand:silence"`
+}
+
+// SilenceCommand holds the silence subcommands.
+type SilenceCommand struct {
+	Create *CreateSilenceCommand `arg:"subcommand:create"`
+	List   *ListSilencesCommand  `arg:"subcommand:list"`
+	Expire *ExpireSilenceCommand `arg:"subcommand:expire"`
+}
+
+// CreateSilenceCommand holds the silence create command arguments.
+type CreateSilenceCommand struct {
+	Matchers []string      `arg:"-m,--matcher,separate"`
+	Duration time.Duration `arg:"-d,--duration"`
+	Comment  string        `arg:"-c,--comment"`
+}
+
+// ListSilencesCommand holds the silence list command arguments.
+type ListSilencesCommand struct {
+	Matchers []string `arg:"-m,--matcher,separate"`
+	All      bool     `arg:"--all"`
+}
+
+// ExpireSilenceCommand holds the silence expire command arguments.
+type ExpireSilenceCommand struct {
+	ID string `arg:"positional,required"`
+}
+
+// Executor provides functionality for managing Alertmanager silences.
+type Executor struct {
+	pluginVersion string
+	client        apiClient
+	now           func() time.Time
+}
+
+// NewExecutor returns a new Executor instance.
+func NewExecutor(ver string, client apiClient) *Executor {
+	return &Executor{
+		pluginVersion: ver,
+		client:        client,
+		now:           time.Now,
+	}
+}
+
+// Metadata returns details about Alertmanager plugin.
+func (e *Executor) Metadata(context.Context) (api.MetadataOutput, error) {
+	return api.MetadataOutput{
+		Version:          e.pluginVersion,
+		Description:      description,
+		DocumentationURL: "https://docs.botkube.io/configuration/executor/alertmanager",
+		JSONSchema: api.JSONSchema{
+			Value: configJSONSchema,
+		},
+	}, nil
+}
+
+// Execute returns a given command as response.
+func (e *Executor) Execute(ctx context.Context, in executor.ExecuteInput) (executor.ExecuteOutput, error) {
+	cfg, err := MergeConfigs(in.Configs)
+	if err != nil {
+		return executor.ExecuteOutput{}, fmt.Errorf("while merging input configs: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return executor.ExecuteOutput{}, fmt.Errorf("while validating configuration: %w", err)
+	}
+
+	log := loggerx.New(cfg.Log)
+
+	var cmd Command
+	err = plugin.ParseCommand(PluginName, in.Command, &cmd)
+	switch {
+	case err == nil:
+	case errors.Is(err, arg.ErrHelp):
+		return executor.ExecuteOutput{Message: help()}, nil
+	default:
+		return executor.ExecuteOutput{}, fmt.Errorf("while parsing input command: %w", err)
+	}
+
+	if cmd.Silence == nil {
+		return executor.ExecuteOutput{Message: help()}, nil
+	}
+
+	switch {
+	case cmd.Silence.Create != nil:
+		log.WithField("matchers", cmd.Silence.Create.Matchers).Debug("Creating silence...")
+		return e.create(ctx, cfg, in, *cmd.Silence.Create)
+	case cmd.Silence.List != nil:
+		return e.list(ctx, cfg, in, *cmd.Silence.List)
+	case cmd.Silence.Expire != nil:
+		log.WithField("id", cmd.Silence.Expire.ID).Debug("Expiring silence...")
+		return e.expire(ctx, cfg, in, cmd.Silence.Expire.ID)
+	default:
+		return executor.ExecuteOutput{Message: help()}, nil
+	}
+}
+
+// Help returns help message.
+func (*Executor) Help(context.Context) (api.Message, error) {
+	return help(), nil
+}
+
+func (e *Executor) create(ctx context.Context, cfg Config, in executor.ExecuteInput, cmd CreateSilenceCommand) (executor.ExecuteOutput, error) {
+	if len(cmd.Matchers) == 0 {
+		return executor.ExecuteOutput{}, errors.New("At least one matcher must be specified, e.g. `--matcher alertname=KubePodCrashLooping`.")
+	}
+	matchers, err := parseMatchers(cmd.Matchers)
+	if err != nil {
+		return executor.ExecuteOutput{}, err
+	}
+
+	duration := cmd.Duration
+	if duration == 0 {
+		duration = cfg.DefaultDuration
+	}
+	if duration < 0 {
+		return executor.ExecuteOutput{}, fmt.Errorf("Duration must be positive, got %s.", duration)
+	}
+
+	createdBy := userName(in.Context.Message.User)
+	comment := cmd.Comment
+	if comment == "" {
+		comment = fmt.Sprintf("Created from Botkube by %s.", createdBy)
+	}
+
+	startsAt := e.now().UTC()
+	silence := Silence{
+		Matchers:  matchers,
+		StartsAt:  startsAt,
+		EndsAt:    startsAt.Add(duration),
+		CreatedBy: createdBy,
+		Comment:   comment,
+	}
+	id, err := e.client.CreateSilence(ctx, cfg, silence)
+	if err != nil {
+		return executor.ExecuteOutput{}, fmt.Errorf("while creating silence: %w", err)
+	}
+	silence.ID = id
+
+	return executor.ExecuteOutput{Message: silenceCreatedMessage(in.Context.IsInteractivitySupported, silence, duration)}, nil
+}
+
+func (e *Executor) list(ctx context.Context, cfg Config, in executor.ExecuteInput, cmd ListSilencesCommand) (executor.ExecuteOutput, error) {
+	matchers, err := parseMatchers(cmd.Matchers)
+	if err != nil {
+		return executor.ExecuteOutput{}, err
+	}
+
+	silences, err := e.client.ListSilences(ctx, cfg, matchers)
+	if err != nil {
+		return executor.ExecuteOutput{}, fmt.Errorf("while listing silences: %w", err)
+	}
+
+	if !cmd.All {
+		silences = withoutExpired(silences)
+	}
+
+	return executor.ExecuteOutput{Message: silencesMessage(in.Context.IsInteractivitySupported, silences)}, nil
+}
+
+func (e *Executor) expire(ctx context.Context, cfg Config, in executor.ExecuteInput, id string) (executor.ExecuteOutput, error) {
+	if err := e.client.ExpireSilence(ctx, cfg, id); err != nil {
+		return executor.ExecuteOutput{}, fmt.Errorf("while expiring silence %q: %w", id, err)
+	}
+
+	msg := fmt.Sprintf("Silence %q expired by %s.", id, in.Context.Message.User.Mention)
+	return executor.ExecuteOutput{
+		Message: api.NewPlaintextMessage(msg, false),
+	}, nil
+}
+
+func withoutExpired(in []Silence) []Silence {
+	var out []Silence
+	for _, s := range in {
+		if s.State() == SilenceStateExpired {
+			continue
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
+func userName(user executor.User) string {
+	switch {
+	case user.DisplayName != "":
+		return user.DisplayName
+	case user.Mention != "":
+		return user.Mention
+	default:
+		return "Botkube"
+	}
+}
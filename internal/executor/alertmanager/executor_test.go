@@ -0,0 +1,252 @@
+package alertmanager
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/api/executor"
+	"github.com/kubeshop/botkube/pkg/plugin"
+)
+
+func TestParseMatcher(t *testing.T) {
+	tests := []struct {
+		in       string
+		expected Matcher
+	}{
+		{in: "alertname=KubePodCrashLooping", expected: Matcher{Name: "alertname", Value: "KubePodCrashLooping", IsEqual: true}},
+		{in: "namespace!=kube-system", expected: Matcher{Name: "namespace", Value: "kube-system"}},
+		{in: "pod=~api-.*", expected: Matcher{Name: "pod", Value: "api-.*", IsRegex: true, IsEqual: true}},
+		{in: "severity!~info|none", expected: Matcher{Name: "severity", Value: "info|none", IsRegex: true}},
+		{in: "summary=a=b", expected: Matcher{Name: "summary", Value: "a=b", IsEqual: true}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.in, func(t *testing.T) {
+			// when
+			got, err := ParseMatcher(tc.in)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, got)
+			assert.Equal(t, tc.in, got.String())
+		})
+	}
+}
+
+func TestParseMatcherErrors(t *testing.T) {
+	for _, in := range []string{"alertname", "=value", "name!value"} {
+		t.Run(in, func(t *testing.T) {
+			// when
+			_, err := ParseMatcher(in)
+
+			// then
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestSilenceButtons(t *testing.T) {
+	// given
+	labels := map[string]string{
+		"alertname": "KubePodCrashLooping",
+		"namespace": "prod",
+		"summary":   "Pod 'api' is crash looping",
+	}
+
+	// when
+	btns := SilenceButtons([]time.Duration{time.Hour, 90 * time.Minute}, labels)
+
+	// then
+	require.Len(t, btns, 3)
+	assert.Equal(t, "Silence for 1h", btns[0].Name)
+	assert.Equal(t, api.MessageBotNamePlaceholder+` alertmanager silence create --matcher alertname=KubePodCrashLooping --matcher namespace=prod --matcher 'summary=Pod '\''api'\'' is crash looping' --duration 1h`, btns[0].Command)
+	assert.Equal(t, "Silence for 1h30m", btns[1].Name)
+	assert.Equal(t, "List silences", btns[2].Name)
+
+	// when the button command is executed
+	var cmd Command
+	err := plugin.ParseCommand(PluginName, withoutBotName(btns[0].Command), &cmd)
+
+	// then
+	require.NoError(t, err)
+	require.NotNil(t, cmd.Silence)
+	require.NotNil(t, cmd.Silence.Create)
+	assert.Equal(t, time.Hour, cmd.Silence.Create.Duration)
+	matchers, err := parseMatchers(cmd.Silence.Create.Matchers)
+	require.NoError(t, err)
+	assert.Equal(t, MatchersFromLabels(labels), matchers)
+}
+
+func TestExecutorSilenceCreate(t *testing.T) {
+	// given
+	var got Silence
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/v2/silences", r.URL.Path)
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		_, _ = w.Write([]byte(`{"silenceID": "7a1f"}`))
+	}))
+	defer srv.Close()
+
+	now := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	exec := NewExecutor("dev", NewAPIClient())
+	exec.now = func() time.Time { return now }
+
+	// when
+	out, err := exec.Execute(context.Background(), executor.ExecuteInput{
+		Command: "alertmanager silence create -m alertname=KubePodCrashLooping -m 'pod=~api-.*' --duration 4h",
+		Configs: fixConfigs(srv.URL),
+		Context: executor.ExecuteInputContext{
+			IsInteractivitySupported: true,
+			Message: executor.Message{
+				User: executor.User{Mention: "<@U1>", DisplayName: "Jane"},
+			},
+		},
+	})
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, Silence{
+		Matchers: []Matcher{
+			{Name: "alertname", Value: "KubePodCrashLooping", IsEqual: true},
+			{Name: "pod", Value: "api-.*", IsRegex: true, IsEqual: true},
+		},
+		StartsAt:  now,
+		EndsAt:    now.Add(4 * time.Hour),
+		CreatedBy: "Jane",
+		Comment:   "Created from Botkube by Jane.",
+	}, got)
+
+	require.Len(t, out.Message.Sections, 1)
+	section := out.Message.Sections[0]
+	assert.Equal(t, "Silence created", section.Header)
+	assert.Equal(t, "Alerts matching `alertname=KubePodCrashLooping`, `pod=~api-.*` are silenced for 4h, until Fri, 01 Mar 2024 14:00:00 UTC.", section.Description)
+	assert.Equal(t, api.ContextItems{{Text: "Silence ID: 7a1f"}}, section.Context)
+	require.Len(t, section.Buttons, 1)
+	assert.Equal(t, api.MessageBotNamePlaceholder+" alertmanager silence expire 7a1f", section.Buttons[0].Command)
+}
+
+func TestExecutorSilenceListAndExpire(t *testing.T) {
+	// given
+	var expired string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			assert.Equal(t, []string{`alertname="KubePodCrashLooping"`}, r.URL.Query()["filter"])
+			_, _ = w.Write([]byte(heredoc.Doc(`
+				[
+				  {"id": "1", "matchers": [{"name": "alertname", "value": "KubePodCrashLooping", "isEqual": true}], "endsAt": "2024-03-01T14:00:00Z", "createdBy": "Jane", "status": {"state": "active"}},
+				  {"id": "2", "matchers": [{"name": "alertname", "value": "KubePodCrashLooping", "isEqual": true}], "endsAt": "2024-02-01T14:00:00Z", "createdBy": "John", "status": {"state": "expired"}}
+				]`)))
+		case http.MethodDelete:
+			expired = r.URL.Path
+		}
+	}))
+	defer srv.Close()
+
+	exec := NewExecutor("dev", NewAPIClient())
+	in := executor.ExecuteInput{
+		Command: "alertmanager silence list --matcher alertname=KubePodCrashLooping",
+		Configs: fixConfigs(srv.URL),
+		Context: executor.ExecuteInputContext{
+			IsInteractivitySupported: true,
+			Message: executor.Message{
+				User: executor.User{Mention: "<@U1>"},
+			},
+		},
+	}
+
+	// when
+	out, err := exec.Execute(context.Background(), in)
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, out.Message.Sections, 1)
+	section := out.Message.Sections[0]
+	assert.Equal(t, "`alertname=KubePodCrashLooping`", section.Header)
+	assert.Equal(t, api.TextFields{
+		{Key: "State", Value: "active"},
+		{Key: "Ends at", Value: "Fri, 01 Mar 2024 14:00:00 UTC"},
+		{Key: "Created by", Value: "Jane"},
+	}, section.TextFields)
+	require.Len(t, section.Buttons, 1)
+	assert.Equal(t, api.MessageBotNamePlaceholder+" alertmanager silence expire 1", section.Buttons[0].Command)
+
+	// when
+	in.Command = withoutBotName(section.Buttons[0].Command)
+	out, err = exec.Execute(context.Background(), in)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "/api/v2/silence/1", expired)
+	assert.Equal(t, api.NewPlaintextMessage(`Silence "1" expired by <@U1>.`, false), out.Message)
+}
+
+func TestExecutorSilenceCreateErrors(t *testing.T) {
+	// given
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("silence invalid: invalid label matcher 0"))
+	}))
+	defer srv.Close()
+	exec := NewExecutor("dev", NewAPIClient())
+
+	tests := []struct {
+		name   string
+		cmd    string
+		expErr string
+	}{
+		{
+			name:   "No matchers",
+			cmd:    "alertmanager silence create",
+			expErr: "At least one matcher must be specified, e.g. `--matcher alertname=KubePodCrashLooping`.",
+		},
+		{
+			name:   "Invalid matcher",
+			cmd:    "alertmanager silence create -m alertname",
+			expErr: "invalid matcher \"alertname\", expected format is `name=value`, `name!=value`, `name=~regex` or `name!~regex`",
+		},
+		{
+			name:   "API error",
+			cmd:    "alertmanager silence create -m alertname=",
+			expErr: "while creating silence: Alertmanager API returned unexpected status code 400: silence invalid: invalid label matcher 0",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			_, err := exec.Execute(context.Background(), executor.ExecuteInput{
+				Command: tc.cmd,
+				Configs: fixConfigs(srv.URL),
+			})
+
+			// then
+			assert.EqualError(t, err, tc.expErr)
+		})
+	}
+}
+
+func withoutBotName(cmd string) string {
+	return strings.TrimPrefix(cmd, api.MessageBotNamePlaceholder+" ")
+}
+
+func fixConfigs(url string) []*executor.Config {
+	return []*executor.Config{
+		{
+			RawYAML: []byte(heredoc.Docf(`
+				url: %s
+				headers:
+				  Authorization: Bearer token`, url)),
+		},
+	}
+}
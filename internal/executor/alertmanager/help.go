@@ -0,0 +1,41 @@
+package alertmanager
+
+import (
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc"
+
+	"github.com/kubeshop/botkube/pkg/api"
+)
+
+func help() api.Message {
+	btnBuilder := api.NewMessageButtonBuilder()
+	return api.Message{
+		Sections: []api.Section{
+			{
+				Base: api.Base{
+					Header:      "Manage Alertmanager silences from chat",
+					Description: description,
+				},
+			},
+			{
+				Base: api.Base{
+					Body: api.Body{
+						CodeBlock: heredoc.Doc(`
+							Usage:
+							  alertmanager silence create --matcher <matcher>... [--duration <duration>] [--comment <comment>]
+							  alertmanager silence list [--matcher <matcher>...] [--all]
+							  alertmanager silence expire <id>
+
+							Matchers use the amtool format: name=value, name!=value, name=~regex, or name!~regex.
+							Example:
+							  alertmanager silence create --matcher alertname=KubePodCrashLooping --matcher namespace=prod --duration 2h`),
+					},
+				},
+				Buttons: []api.Button{
+					btnBuilder.ForCommandWithDescCmd("List silences", fmt.Sprintf("%s silence list", PluginName)),
+				},
+			},
+		},
+	}
+}
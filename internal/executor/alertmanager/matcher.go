@@ -0,0 +1,100 @@
+package alertmanager
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Matcher matches alerts by a given label. It's serialized in the Alertmanager API v2 format.
+type Matcher struct {
+	Name    string `json:"name"`
+	Value   string `json:"value"`
+	IsRegex bool   `json:"isRegex"`
+	IsEqual bool   `json:"isEqual"`
+}
+
+// matcherOperators holds supported operators, in the order they are checked.
+var matcherOperators = []struct {
+	op      string
+	isRegex bool
+	isEqual bool
+}{
+	{op: "!=", isEqual: false},
+	{op: "=~", isRegex: true, isEqual: true},
+	{op: "!~", isRegex: true, isEqual: false},
+	{op: "=", isEqual: true},
+}
+
+// ParseMatcher parses a matcher in the `name=value`, `name!=value`, `name=~regex` or `name!~regex` format,
+// which is the same as used by amtool.
+func ParseMatcher(in string) (Matcher, error) {
+	idx := strings.IndexAny(in, "=!")
+	if idx < 1 {
+		return Matcher{}, fmt.Errorf("invalid matcher %q, expected format is `name=value`, `name!=value`, `name=~regex` or `name!~regex`", in)
+	}
+
+	name, rest := strings.TrimSpace(in[:idx]), in[idx:]
+	for _, o := range matcherOperators {
+		if !strings.HasPrefix(rest, o.op) {
+			continue
+		}
+		return Matcher{
+			Name:    name,
+			Value:   strings.TrimPrefix(rest, o.op),
+			IsRegex: o.isRegex,
+			IsEqual: o.isEqual,
+		}, nil
+	}
+	return Matcher{}, fmt.Errorf("invalid operator in matcher %q", in)
+}
+
+// String returns the matcher in the format accepted by ParseMatcher.
+func (m Matcher) String() string {
+	return m.Name + m.operator() + m.Value
+}
+
+func (m Matcher) operator() string {
+	switch {
+	case m.IsRegex && m.IsEqual:
+		return "=~"
+	case m.IsRegex:
+		return "!~"
+	case !m.IsEqual:
+		return "!="
+	default:
+		return "="
+	}
+}
+
+// MatchersFromLabels returns equality matchers for all given alert labels, sorted by label names.
+func MatchersFromLabels(labels map[string]string) []Matcher {
+	out := make([]Matcher, 0, len(labels))
+	for name, value := range labels {
+		out = append(out, Matcher{Name: name, Value: value, IsEqual: true})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Name < out[j].Name
+	})
+	return out
+}
+
+func parseMatchers(in []string) ([]Matcher, error) {
+	out := make([]Matcher, 0, len(in))
+	for _, raw := range in {
+		m, err := ParseMatcher(raw)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, m)
+	}
+	return out, nil
+}
+
+// quoteArg quotes a given command argument, so it's not split by the command parser.
+func quoteArg(in string) string {
+	if in != "" && !strings.ContainsAny(in, " \t\n'\"\\") {
+		return in
+	}
+	return "'" + strings.ReplaceAll(in, "'", `'\''`) + "'"
+}
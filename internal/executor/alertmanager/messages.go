@@ -0,0 +1,140 @@
+package alertmanager
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kubeshop/botkube/pkg/api"
+)
+
+// SilenceButtons returns buttons creating silences with matchers prefilled from given alert labels, one for each given duration.
+// They are meant to be attached to alert notifications, e.g. by the Alertmanager source.
+func SilenceButtons(durations []time.Duration, labels map[string]string) api.Buttons {
+	if len(labels) == 0 {
+		return nil
+	}
+
+	btnBuilder := api.NewMessageButtonBuilder()
+	matchers := MatchersFromLabels(labels)
+
+	var out api.Buttons
+	for _, d := range durations {
+		out = append(out, btnBuilder.ForCommandWithoutDesc(fmt.Sprintf("Silence for %s", formatDuration(d)), createSilenceCmd(matchers, d)))
+	}
+	out = append(out, btnBuilder.ForCommandWithoutDesc("List silences", listSilencesCmd(matchers)))
+	return out
+}
+
+func silenceCreatedMessage(interactive bool, silence Silence, duration time.Duration) api.Message {
+	section := api.Section{
+		Base: api.Base{
+			Header:      "Silence created",
+			Description: fmt.Sprintf("Alerts matching %s are silenced for %s, until %s.", matchersText(silence.Matchers), formatDuration(duration), silence.EndsAt.Format(time.RFC1123)),
+		},
+		Context: api.ContextItems{
+			{Text: fmt.Sprintf("Silence ID: %s", silence.ID)},
+		},
+	}
+
+	expireCmd := expireSilenceCmd(silence.ID)
+	if interactive {
+		section.Buttons = api.Buttons{
+			api.NewMessageButtonBuilder().ForCommandWithoutDesc("Expire silence", expireCmd, api.ButtonStyleDanger),
+		}
+	} else {
+		section.Context = append(section.Context, api.ContextItem{
+			Text: fmt.Sprintf("To expire it, run: %s %s", api.MessageBotNamePlaceholder, expireCmd),
+		})
+	}
+
+	return api.Message{Sections: []api.Section{section}}
+}
+
+func silencesMessage(interactive bool, silences []Silence) api.Message {
+	if len(silences) == 0 {
+		return api.NewPlaintextMessage("No silences found.", false)
+	}
+
+	listed := silences
+	if len(listed) > maxListedSilences {
+		listed = listed[:maxListedSilences]
+	}
+
+	btnBuilder := api.NewMessageButtonBuilder()
+	var sections []api.Section
+	for _, s := range listed {
+		section := api.Section{
+			Base: api.Base{
+				Header: matchersText(s.Matchers),
+			},
+			TextFields: api.TextFields{
+				{Key: "State", Value: string(s.State())},
+				{Key: "Ends at", Value: s.EndsAt.Format(time.RFC1123)},
+				{Key: "Created by", Value: s.CreatedBy},
+			},
+			Context: api.ContextItems{
+				{Text: fmt.Sprintf("Silence ID: %s", s.ID)},
+			},
+		}
+		if s.Comment != "" {
+			section.Description = s.Comment
+		}
+		if interactive && s.State() != SilenceStateExpired {
+			section.Buttons = api.Buttons{
+				btnBuilder.ForCommandWithoutDesc("Expire", expireSilenceCmd(s.ID), api.ButtonStyleDanger),
+			}
+		}
+		sections = append(sections, section)
+	}
+
+	if len(silences) > maxListedSilences {
+		sections = append(sections, api.Section{
+			Context: api.ContextItems{
+				{Text: fmt.Sprintf("... and %d more. Use `--matcher` to narrow down the results.", len(silences)-maxListedSilences)},
+			},
+		})
+	}
+
+	return api.Message{Sections: sections}
+}
+
+func createSilenceCmd(matchers []Matcher, duration time.Duration) string {
+	return fmt.Sprintf("%s silence create %s --duration %s", PluginName, matcherArgs(matchers), formatDuration(duration))
+}
+
+func listSilencesCmd(matchers []Matcher) string {
+	return fmt.Sprintf("%s silence list %s", PluginName, matcherArgs(matchers))
+}
+
+func expireSilenceCmd(id string) string {
+	return fmt.Sprintf("%s silence expire %s", PluginName, quoteArg(id))
+}
+
+func matcherArgs(matchers []Matcher) string {
+	args := make([]string, 0, len(matchers))
+	for _, m := range matchers {
+		args = append(args, "--matcher "+quoteArg(m.String()))
+	}
+	return strings.Join(args, " ")
+}
+
+func matchersText(matchers []Matcher) string {
+	out := make([]string, 0, len(matchers))
+	for _, m := range matchers {
+		out = append(out, fmt.Sprintf("`%s`", m.String()))
+	}
+	return strings.Join(out, ", ")
+}
+
+// formatDuration returns a given duration without redundant zero units, e.g. `2h` instead of `2h0m0s`.
+func formatDuration(d time.Duration) string {
+	out := d.String()
+	if strings.HasSuffix(out, "m0s") {
+		out = strings.TrimSuffix(out, "0s")
+	}
+	if strings.HasSuffix(out, "h0m") {
+		out = strings.TrimSuffix(out, "0m")
+	}
+	return out
+}
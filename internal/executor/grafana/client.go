@@ -0,0 +1,144 @@
+package grafana
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// maxErrBodyLength limits the Grafana API error response included in the error message.
+const maxErrBodyLength = 300
+
+// Annotation holds the Grafana annotation. Times are Unix timestamps in milliseconds.
+type Annotation struct {
+	ID           int64    `json:"id,omitempty"`
+	DashboardUID string   `json:"dashboardUID,omitempty"`
+	PanelID      int64    `json:"panelId,omitempty"`
+	Time         int64    `json:"time,omitempty"`
+	TimeEnd      int64    `json:"timeEnd,omitempty"`
+	Tags         []string `json:"tags,omitempty"`
+	Text         string   `json:"text,omitempty"`
+}
+
+type createAnnotationResponse struct {
+	ID int64 `json:"id"`
+}
+
+// APIClient calls the Grafana HTTP API.
+type APIClient struct {
+	httpCli *http.Client
+}
+
+// NewAPIClient returns a new APIClient instance.
+func NewAPIClient() *APIClient {
+	return &APIClient{
+		// timeout is configurable and applied per request
+		httpCli: &http.Client{},
+	}
+}
+
+// CreateAnnotation creates a given annotation and returns its ID.
+func (c *APIClient) CreateAnnotation(ctx context.Context, cfg Config, annotation Annotation) (int64, error) {
+	var out createAnnotationResponse
+	if err := c.do(ctx, cfg, http.MethodPost, "/api/annotations", nil, annotation, &out); err != nil {
+		return 0, err
+	}
+	return out.ID, nil
+}
+
+// FindLatestAnnotation returns the most recent annotation with all given tags, or nil if there is no such annotation.
+func (c *APIClient) FindLatestAnnotation(ctx context.Context, cfg Config, tags []string) (*Annotation, error) {
+	query := url.Values{
+		"type":  []string{"annotation"},
+		"limit": []string{"1"},
+	}
+	for _, tag := range tags {
+		query.Add("tags", tag)
+	}
+	if cfg.DashboardUID != "" {
+		query.Set("dashboardUID", cfg.DashboardUID)
+	}
+
+	var out []Annotation
+	if err := c.do(ctx, cfg, http.MethodGet, "/api/annotations", query, nil, &out); err != nil {
+		return nil, err
+	}
+	if len(out) == 0 {
+		return nil, nil
+	}
+	return &out[0], nil
+}
+
+// UpdateAnnotation updates non-empty properties of a given annotation.
+func (c *APIClient) UpdateAnnotation(ctx context.Context, cfg Config, annotation Annotation) error {
+	path := "/api/annotations/" + strconv.FormatInt(annotation.ID, 10)
+	annotation.ID = 0
+	return c.do(ctx, cfg, http.MethodPatch, path, nil, annotation, nil)
+}
+
+func (c *APIClient) do(ctx context.Context, cfg Config, method, path string, query url.Values, body any, dest any) error {
+	endpoint := strings.TrimSuffix(cfg.URL, "/") + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		raw, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("while marshaling request: %w", err)
+		}
+		reqBody = bytes.NewReader(raw)
+	}
+
+	if cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.Timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, reqBody)
+	if err != nil {
+		return fmt.Errorf("while creating request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Authorization", "Bearer "+cfg.APIKey)
+	if cfg.OrgID > 0 {
+		req.Header.Set("X-Grafana-Org-Id", strconv.FormatInt(cfg.OrgID, 10))
+	}
+
+	res, err := c.httpCli.Do(req)
+	if err != nil {
+		return fmt.Errorf("while calling Grafana API: %w", err)
+	}
+	defer res.Body.Close()
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return fmt.Errorf("while reading response body: %w", err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		msg := strings.TrimSpace(string(resBody))
+		if len(msg) > maxErrBodyLength {
+			msg = msg[:maxErrBodyLength]
+		}
+		return fmt.Errorf("Grafana API returned unexpected status code %d: %s", res.StatusCode, msg)
+	}
+
+	if dest == nil {
+		return nil
+	}
+	if err := json.Unmarshal(resBody, dest); err != nil {
+		return fmt.Errorf("while unmarshaling response: %w", err)
+	}
+	return nil
+}
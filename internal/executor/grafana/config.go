@@ -0,0 +1,52 @@
+package grafana
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kubeshop/botkube/pkg/api/executor"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/plugin"
+)
+
+// Config holds Grafana plugin configuration parameters.
+type Config struct {
+	Log config.Logger `yaml:"log"`
+	// URL is the Grafana address, e.g. http://grafana.monitoring.
+	URL string `yaml:"url"`
+	// APIKey is the service account token used to authenticate against the Grafana API.
+	APIKey string `yaml:"apiKey"`
+	// OrgID is the ID of the organization the annotations are created in. If empty, the token organization is used.
+	OrgID int64 `yaml:"orgID,omitempty"`
+	// DashboardUID is the UID of the dashboard the annotations are created on. If empty, annotations are created as organization-wide.
+	DashboardUID string `yaml:"dashboardUID,omitempty"`
+	// Tags are added to all created annotations, so they can be filtered out on dashboards.
+	Tags    []string      `yaml:"tags"`
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// Validate validates the configuration.
+func (c Config) Validate() error {
+	if c.URL == "" {
+		return fmt.Errorf("the url property cannot be empty")
+	}
+	if c.APIKey == "" {
+		return fmt.Errorf("the apiKey property cannot be empty")
+	}
+	return nil
+}
+
+// MergeConfigs merges the Grafana configuration.
+func MergeConfigs(configs []*executor.Config) (Config, error) {
+	defaults := Config{
+		Tags:    []string{"botkube"},
+		Timeout: 30 * time.Second,
+	}
+
+	var out Config
+	if err := plugin.MergeExecutorConfigsWithDefaults(defaults, configs, &out); err != nil {
+		return Config{}, fmt.Errorf("while merging configuration: %w", err)
+	}
+
+	return out, nil
+}
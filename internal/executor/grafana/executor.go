@@ -0,0 +1,237 @@
+package grafana
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/alexflint/go-arg"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/api/executor"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+	"github.com/kubeshop/botkube/pkg/plugin"
+)
+
+const (
+	// PluginName is the name of the Grafana Botkube plugin.
+	PluginName  = "grafana"
+	description = "Write Grafana annotations, e.g. from actions triggered by cluster events."
+)
+
+//go:embed config_schema.json
+var configJSONSchema string
+
+var _ executor.Executor = &Executor{}
+
+type apiClient interface {
+	CreateAnnotation(ctx context.Context, cfg Config, annotation Annotation) (int64, error)
+	FindLatestAnnotation(ctx context.Context, cfg Config, tags []string) (*Annotation, error)
+	UpdateAnnotation(ctx context.Context, cfg Config, annotation Annotation) error
+}
+
+// Command defines the supported Grafana plugin commands.
+type Command struct {
+	Annotation *AnnotationCommand `arg:"subcommand:annotation"`
+}
+
+// AnnotationCommand holds the annotation subcommands.
+type AnnotationCommand struct {
+	Create *CreateAnnotationCommand `arg:"subcommand:create"`
+	Start  *CreateAnnotationCommand `arg:"subcommand:start"`
+	End    *EndAnnotationCommand    `arg:"subcommand:end"`
+}
+
+// CreateAnnotationCommand holds the annotation create and start command arguments.
+type CreateAnnotationCommand struct {
+	Text         string   `arg:"--text,required"`
+	Tags         []string `arg:"-t,--tag,separate"`
+	DashboardUID string   `arg:"--dashboard-uid"`
+	PanelID      int64    `arg:"--panel-id"`
+}
+
+// EndAnnotationCommand holds the annotation end command arguments.
+type EndAnnotationCommand struct {
+	Tags []string `arg:"-t,--tag,separate"`
+	Text string   `arg:"--text"`
+}
+
+// Executor provides functionality for writing Grafana annotations.
+type Executor struct {
+	pluginVersion string
+	client        apiClient
+	now           func() time.Time
+}
+
+// NewExecutor returns a new Executor instance.
+func NewExecutor(ver string, client apiClient) *Executor {
+	return &Executor{
+		pluginVersion: ver,
+		client:        client,
+		now:           time.Now,
+	}
+}
+
+// Metadata returns details about Grafana plugin.
+func (e *Executor) Metadata(context.Context) (api.MetadataOutput, error) {
+	return api.MetadataOutput{
+		Version:          e.pluginVersion,
+		Description:      description,
+		DocumentationURL: "https://docs.botkube.io/configuration/executor/grafana",
+		JSONSchema: api.JSONSchema{
+			Value: configJSONSchema,
+		},
+	}, nil
+}
+
+// Execute returns a given command as response.
+func (e *Executor) Execute(ctx context.Context, in executor.ExecuteInput) (executor.ExecuteOutput, error) {
+	cfg, err := MergeConfigs(in.Configs)
+	if err != nil {
+		return executor.ExecuteOutput{}, fmt.Errorf("while merging input configs: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return executor.ExecuteOutput{}, fmt.Errorf("while validating configuration: %w", err)
+	}
+
+	log := loggerx.New(cfg.Log)
+
+	var cmd Command
+	err = plugin.ParseCommand(PluginName, in.Command, &cmd)
+	switch {
+	case err == nil:
+	case errors.Is(err, arg.ErrHelp):
+		return executor.ExecuteOutput{Message: help()}, nil
+	default:
+		return executor.ExecuteOutput{}, fmt.Errorf("while parsing input command: %w", err)
+	}
+
+	if cmd.Annotation == nil {
+		return executor.ExecuteOutput{Message: help()}, nil
+	}
+
+	switch {
+	case cmd.Annotation.Create != nil:
+		log.WithField("tags", cmd.Annotation.Create.Tags).Debug("Creating annotation...")
+		return e.create(ctx, cfg, *cmd.Annotation.Create)
+	case cmd.Annotation.Start != nil:
+		log.WithField("tags", cmd.Annotation.Start.Tags).Debug("Starting annotation...")
+		return e.start(ctx, cfg, *cmd.Annotation.Start)
+	case cmd.Annotation.End != nil:
+		log.WithField("tags", cmd.Annotation.End.Tags).Debug("Ending annotation...")
+		return e.end(ctx, cfg, *cmd.Annotation.End)
+	default:
+		return executor.ExecuteOutput{Message: help()}, nil
+	}
+}
+
+// Help returns help message.
+func (*Executor) Help(context.Context) (api.Message, error) {
+	return help(), nil
+}
+
+func (e *Executor) create(ctx context.Context, cfg Config, cmd CreateAnnotationCommand) (executor.ExecuteOutput, error) {
+	id, err := e.createAnnotation(ctx, cfg, cmd)
+	if err != nil {
+		return executor.ExecuteOutput{}, err
+	}
+
+	return executor.ExecuteOutput{
+		Message: api.NewPlaintextMessage(fmt.Sprintf("Grafana annotation %d created.", id), false),
+	}, nil
+}
+
+// start creates an annotation which is turned into a region once it's ended. Tags identify the annotation to end.
+func (e *Executor) start(ctx context.Context, cfg Config, cmd CreateAnnotationCommand) (executor.ExecuteOutput, error) {
+	if len(cmd.Tags) == 0 {
+		return executor.ExecuteOutput{}, errors.New("At least one tag must be specified, as tags identify the annotation to end, e.g. `--tag incident:INC-42`.")
+	}
+
+	id, err := e.createAnnotation(ctx, cfg, cmd)
+	if err != nil {
+		return executor.ExecuteOutput{}, err
+	}
+
+	msg := fmt.Sprintf("Grafana annotation %d started. To end it, run: %s annotation end %s", id, PluginName, tagArgs(cmd.Tags))
+	return executor.ExecuteOutput{
+		Message: api.NewPlaintextMessage(msg, false),
+	}, nil
+}
+
+func (e *Executor) end(ctx context.Context, cfg Config, cmd EndAnnotationCommand) (executor.ExecuteOutput, error) {
+	if len(cmd.Tags) == 0 {
+		return executor.ExecuteOutput{}, errors.New("At least one tag must be specified to find the annotation to end, e.g. `--tag incident:INC-42`.")
+	}
+
+	tags := withDefaultTags(cfg, cmd.Tags)
+	annotation, err := e.client.FindLatestAnnotation(ctx, cfg, tags)
+	if err != nil {
+		return executor.ExecuteOutput{}, fmt.Errorf("while getting annotation: %w", err)
+	}
+	// an annotation which is already a region has the end time set after the start time
+	if annotation == nil || annotation.TimeEnd > annotation.Time {
+		return executor.ExecuteOutput{}, fmt.Errorf("Started annotation with tags %s not found.", strings.Join(tags, ", "))
+	}
+
+	now := e.now()
+	update := Annotation{
+		ID:      annotation.ID,
+		TimeEnd: now.UnixMilli(),
+	}
+	if cmd.Text != "" {
+		update.Text = annotation.Text + "\n" + cmd.Text
+	}
+	if err := e.client.UpdateAnnotation(ctx, cfg, update); err != nil {
+		return executor.ExecuteOutput{}, fmt.Errorf("while ending annotation %d: %w", annotation.ID, err)
+	}
+
+	duration := now.Sub(time.UnixMilli(annotation.Time)).Round(time.Second)
+	return executor.ExecuteOutput{
+		Message: api.NewPlaintextMessage(fmt.Sprintf("Grafana annotation %d ended after %s.", annotation.ID, duration), false),
+	}, nil
+}
+
+func (e *Executor) createAnnotation(ctx context.Context, cfg Config, cmd CreateAnnotationCommand) (int64, error) {
+	dashboardUID := cmd.DashboardUID
+	if dashboardUID == "" {
+		dashboardUID = cfg.DashboardUID
+	}
+
+	id, err := e.client.CreateAnnotation(ctx, cfg, Annotation{
+		DashboardUID: dashboardUID,
+		PanelID:      cmd.PanelID,
+		Time:         e.now().UnixMilli(),
+		Tags:         withDefaultTags(cfg, cmd.Tags),
+		Text:         cmd.Text,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("while creating annotation: %w", err)
+	}
+	return id, nil
+}
+
+// withDefaultTags returns given tags together with the configured ones, without duplicates.
+func withDefaultTags(cfg Config, tags []string) []string {
+	out := make([]string, 0, len(cfg.Tags)+len(tags))
+	seen := map[string]struct{}{}
+	for _, tag := range append(append([]string{}, cfg.Tags...), tags...) {
+		if _, ok := seen[tag]; ok || tag == "" {
+			continue
+		}
+		seen[tag] = struct{}{}
+		out = append(out, tag)
+	}
+	return out
+}
+
+func tagArgs(tags []string) string {
+	args := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		args = append(args, fmt.Sprintf("--tag %q", tag))
+	}
+	return strings.Join(args, " ")
+}
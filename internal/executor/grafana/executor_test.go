@@ -0,0 +1,183 @@
+package grafana
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/api/executor"
+)
+
+func TestExecutorAnnotationCreate(t *testing.T) {
+	// given
+	var got Annotation
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/annotations", r.URL.Path)
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		assert.Equal(t, "2", r.Header.Get("X-Grafana-Org-Id"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		_, _ = w.Write([]byte(`{"id": 42, "message": "Annotation added"}`))
+	}))
+	defer srv.Close()
+
+	now := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	exec := NewExecutor("dev", NewAPIClient())
+	exec.now = func() time.Time { return now }
+
+	// when
+	out, err := exec.Execute(context.Background(), executor.ExecuteInput{
+		Command: "grafana annotation create --text 'Deployment prod/api updated' --tag deploy --tag botkube --panel-id 4",
+		Configs: fixConfigs(srv.URL),
+	})
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, Annotation{
+		DashboardUID: "cluster-overview",
+		PanelID:      4,
+		Time:         now.UnixMilli(),
+		Tags:         []string{"botkube", "deploy"},
+		Text:         "Deployment prod/api updated",
+	}, got)
+	assert.Equal(t, api.NewPlaintextMessage("Grafana annotation 42 created.", false), out.Message)
+}
+
+func TestExecutorAnnotationStartAndEnd(t *testing.T) {
+	// given
+	started := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	var (
+		created *Annotation
+		patched map[string]any
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost:
+			created = &Annotation{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(created))
+			_, _ = w.Write([]byte(`{"id": 7}`))
+		case r.Method == http.MethodGet:
+			assert.Equal(t, []string{"botkube", "incident:INC-42"}, r.URL.Query()["tags"])
+			assert.Equal(t, "cluster-overview", r.URL.Query().Get("dashboardUID"))
+			raw, err := json.Marshal([]Annotation{*created})
+			require.NoError(t, err)
+			_, _ = w.Write(raw)
+		case r.Method == http.MethodPatch:
+			assert.Equal(t, "/api/annotations/7", r.URL.Path)
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.NoError(t, json.Unmarshal(body, &patched))
+		}
+	}))
+	defer srv.Close()
+
+	exec := NewExecutor("dev", NewAPIClient())
+	exec.now = func() time.Time { return started }
+
+	// when
+	out, err := exec.Execute(context.Background(), executor.ExecuteInput{
+		Command: `grafana annotation start --text "Incident opened" --tag incident:INC-42`,
+		Configs: fixConfigs(srv.URL),
+	})
+
+	// then
+	require.NoError(t, err)
+	require.NotNil(t, created)
+	created.ID = 7
+	assert.Equal(t, `Grafana annotation 7 started. To end it, run: grafana annotation end --tag "incident:INC-42"`, out.Message.BaseBody.Plaintext)
+
+	// given
+	ended := started.Add(25 * time.Minute)
+	exec.now = func() time.Time { return ended }
+
+	// when
+	out, err = exec.Execute(context.Background(), executor.ExecuteInput{
+		Command: `grafana annotation end --tag "incident:INC-42" --text "Incident closed"`,
+		Configs: fixConfigs(srv.URL),
+	})
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"timeEnd": float64(ended.UnixMilli()),
+		"text":    "Incident opened\nIncident closed",
+	}, patched)
+	assert.Equal(t, "Grafana annotation 7 ended after 25m0s.", out.Message.BaseBody.Plaintext)
+
+	// given
+	created.TimeEnd = ended.UnixMilli()
+
+	// when
+	_, err = exec.Execute(context.Background(), executor.ExecuteInput{
+		Command: `grafana annotation end --tag "incident:INC-42"`,
+		Configs: fixConfigs(srv.URL),
+	})
+
+	// then
+	assert.EqualError(t, err, "Started annotation with tags botkube, incident:INC-42 not found.")
+}
+
+func TestExecutorAnnotationErrors(t *testing.T) {
+	// given
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message": "Permission denied"}`))
+	}))
+	defer srv.Close()
+	exec := NewExecutor("dev", NewAPIClient())
+
+	tests := []struct {
+		name   string
+		cmd    string
+		expErr string
+	}{
+		{
+			name:   "Start without tags",
+			cmd:    "grafana annotation start --text 'Incident opened'",
+			expErr: "At least one tag must be specified, as tags identify the annotation to end, e.g. `--tag incident:INC-42`.",
+		},
+		{
+			name:   "End without tags",
+			cmd:    "grafana annotation end",
+			expErr: "At least one tag must be specified to find the annotation to end, e.g. `--tag incident:INC-42`.",
+		},
+		{
+			name:   "API error",
+			cmd:    "grafana annotation create --text 'Deployment updated'",
+			expErr: `while creating annotation: Grafana API returned unexpected status code 403: {"message": "Permission denied"}`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			_, err := exec.Execute(context.Background(), executor.ExecuteInput{
+				Command: tc.cmd,
+				Configs: fixConfigs(srv.URL),
+			})
+
+			// then
+			assert.EqualError(t, err, tc.expErr)
+		})
+	}
+}
+
+func fixConfigs(url string) []*executor.Config {
+	return []*executor.Config{
+		{
+			RawYAML: []byte(heredoc.Docf(`
+				url: %s
+				apiKey: token
+				orgID: 2
+				dashboardUID: cluster-overview`, url)),
+		},
+	}
+}
@@ -0,0 +1,35 @@
+package grafana
+
+import (
+	"github.com/MakeNowJust/heredoc"
+
+	"github.com/kubeshop/botkube/pkg/api"
+)
+
+func help() api.Message {
+	return api.Message{
+		Sections: []api.Section{
+			{
+				Base: api.Base{
+					Header:      "Write Grafana annotations",
+					Description: description,
+				},
+			},
+			{
+				Base: api.Base{
+					Body: api.Body{
+						CodeBlock: heredoc.Doc(`
+							Usage:
+							  grafana annotation create --text <text> [--tag <tag>...] [--dashboard-uid <uid>] [--panel-id <id>]    Create an annotation, e.g. for a started deployment
+							  grafana annotation start --text <text> --tag <tag>... [--dashboard-uid <uid>] [--panel-id <id>]      Start an annotation, e.g. for an opened incident
+							  grafana annotation end --tag <tag>... [--text <text>]                                                 End the latest started annotation with given tags
+
+							Example:
+							  grafana annotation start --text "Incident opened" --tag incident:INC-42
+							  grafana annotation end --tag incident:INC-42 --text "Incident closed"`),
+					},
+				},
+			},
+		},
+	}
+}
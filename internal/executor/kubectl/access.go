@@ -0,0 +1,430 @@
+package kubectl
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/pflag"
+	authnv1 "k8s.io/api/authentication/v1"
+	authzv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/kubernetes"
+	authnclient "k8s.io/client-go/kubernetes/typed/authentication/v1"
+	authzclient "k8s.io/client-go/kubernetes/typed/authorization/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/kubeshop/botkube/pkg/api"
+)
+
+const (
+	// authenticatedGroup is assigned to all authenticated users, so it's not used in suggested role bindings.
+	authenticatedGroup = "system:authenticated"
+	// restMapperTTL is shorter than the minimal lifetime of minted ServiceAccount tokens, so cached mappers use valid credentials.
+	restMapperTTL = 5 * time.Minute
+)
+
+// commandAccess describes a permission required to run a given kubectl command.
+type commandAccess struct {
+	verb        string
+	subresource string
+	// defaultResource is used if the command refers to objects by names only, e.g. `kubectl logs api`.
+	defaultResource string
+	// listWithoutName is set for commands which list objects if their name is not given, e.g. `kubectl get pods`.
+	listWithoutName bool
+}
+
+// commandsAccess holds permissions required by kubectl commands. Other commands aren't verified before they are run.
+var commandsAccess = map[string]commandAccess{
+	"get":      {verb: "get", listWithoutName: true},
+	"describe": {verb: "get", listWithoutName: true},
+	"logs":     {verb: "get", subresource: "log", defaultResource: "pods"},
+	"delete":   {verb: "delete"},
+	"edit":     {verb: "update"},
+	"scale":    {verb: "patch", subresource: "scale"},
+	"label":    {verb: "patch"},
+	"annotate": {verb: "patch"},
+	"cordon":   {verb: "patch", defaultResource: "nodes"},
+	"uncordon": {verb: "patch", defaultResource: "nodes"},
+	"drain":    {verb: "patch", defaultResource: "nodes"},
+	"exec":     {verb: "create", subresource: "exec", defaultResource: "pods"},
+}
+
+// rolloutAccess holds permissions required by `kubectl rollout` subcommands.
+var rolloutAccess = map[string]commandAccess{
+	"status":  {verb: "get"},
+	"history": {verb: "get"},
+	"restart": {verb: "patch"},
+	"pause":   {verb: "patch"},
+	"resume":  {verb: "patch"},
+	"undo":    {verb: "patch"},
+}
+
+// AccessChecker verifies that the identity used by the plugin is allowed to run kubectl commands, so users get a precise message
+// with the missing permission instead of the raw forbidden error. Plugins use impersonated identities, so they are verified with self subject reviews.
+type AccessChecker struct {
+	authz  authzclient.AuthorizationV1Interface
+	authn  authnclient.AuthenticationV1Interface
+	mapper meta.RESTMapper
+}
+
+// NewAccessChecker returns a new AccessChecker instance.
+func NewAccessChecker(authz authzclient.AuthorizationV1Interface, authn authnclient.AuthenticationV1Interface, mapper meta.RESTMapper) *AccessChecker {
+	return &AccessChecker{
+		authz:  authz,
+		authn:  authn,
+		mapper: mapper,
+	}
+}
+
+// newAccessCheckerForKubeconfig returns a new AccessChecker instance for a given kubeconfig.
+func newAccessCheckerForKubeconfig(kubeConfigPath string, mappers *restMapperCache) (*AccessChecker, error) {
+	kubeConfig, err := clientcmd.BuildConfigFromFlags("", kubeConfigPath)
+	if err != nil {
+		return nil, fmt.Errorf("while creating kube config: %w", err)
+	}
+	k8sCli, err := kubernetes.NewForConfig(kubeConfig)
+	if err != nil {
+		return nil, fmt.Errorf("while creating typed k8s client: %w", err)
+	}
+	mapper, err := mappers.Get(kubeConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewAccessChecker(k8sCli.AuthorizationV1(), k8sCli.AuthenticationV1(), mapper), nil
+}
+
+// restMapperCache reuses discovery based RESTMappers, so the API discovery doesn't run for every command. Mappers are cached per API server, and expire, as they keep
+// credentials of the kubeconfig they were created for, which may be short-lived.
+type restMapperCache struct {
+	ttl       time.Duration
+	now       func() time.Time
+	newMapper func(kubeConfig *rest.Config) (meta.RESTMapper, error)
+
+	mu      sync.Mutex
+	entries map[string]restMapperEntry
+}
+
+type restMapperEntry struct {
+	mapper    meta.RESTMapper
+	expiresAt time.Time
+}
+
+func newRESTMapperCache(ttl time.Duration) *restMapperCache {
+	return &restMapperCache{
+		ttl:       ttl,
+		now:       time.Now,
+		newMapper: newRESTMapper,
+		entries:   map[string]restMapperEntry{},
+	}
+}
+
+// Get returns a RESTMapper for the API server of a given kubeconfig.
+func (c *restMapperCache) Get(kubeConfig *rest.Config) (meta.RESTMapper, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	for host, entry := range c.entries {
+		if now.After(entry.expiresAt) {
+			delete(c.entries, host)
+		}
+	}
+	if entry, found := c.entries[kubeConfig.Host]; found {
+		return entry.mapper, nil
+	}
+
+	mapper, err := c.newMapper(kubeConfig)
+	if err != nil {
+		return nil, err
+	}
+	c.entries[kubeConfig.Host] = restMapperEntry{
+		mapper:    mapper,
+		expiresAt: now.Add(c.ttl),
+	}
+	return mapper, nil
+}
+
+// newRESTMapper returns a discovery based RESTMapper, which also resolves resource short names, e.g. `deploy`.
+func newRESTMapper(kubeConfig *rest.Config) (meta.RESTMapper, error) {
+	discoveryCli, err := discovery.NewDiscoveryClientForConfig(kubeConfig)
+	if err != nil {
+		return nil, fmt.Errorf("while creating discovery client: %w", err)
+	}
+	cachedCli := memory.NewMemCacheClient(discoveryCli)
+	return restmapper.NewShortcutExpander(restmapper.NewDeferredDiscoveryRESTMapper(cachedCli), cachedCli, nil), nil
+}
+
+// accessAttributes holds the permission required by a command.
+type accessAttributes struct {
+	authzv1.ResourceAttributes
+	// allNamespaces is set if the command targets all namespaces.
+	allNamespaces bool
+}
+
+// Check returns a message describing the missing permission if the command is not allowed. It returns nil if the command is allowed,
+// or it cannot be verified, e.g. because its resource is unknown. In such case, kubectl reports errors on its own.
+func (c *AccessChecker) Check(ctx context.Context, defaultNamespace, cmd string) (*api.Message, error) {
+	attrs, ok := c.accessAttributesFor(defaultNamespace, cmd)
+	if !ok {
+		return nil, nil
+	}
+
+	review, err := c.authz.SelfSubjectAccessReviews().Create(ctx, &authzv1.SelfSubjectAccessReview{
+		Spec: authzv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &attrs.ResourceAttributes,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("while creating access review: %w", err)
+	}
+	if review.Status.Allowed {
+		return nil, nil
+	}
+
+	// the identity is optional, it's used only to suggest the role binding
+	var user authnv1.UserInfo
+	if self, err := c.authn.SelfSubjectReviews().Create(ctx, &authnv1.SelfSubjectReview{}, metav1.CreateOptions{}); err == nil {
+		user = self.Status.UserInfo
+	}
+
+	msg := missingAccessMessage(attrs, review.Status.Reason, user)
+	return &msg, nil
+}
+
+// accessAttributesFor returns the permission required by a given command. It returns false if the command cannot be verified.
+func (c *AccessChecker) accessAttributesFor(defaultNamespace, cmd string) (accessAttributes, bool) {
+	args := strings.Fields(cmd)
+
+	// `-f` is the follow flag for `kubectl logs` and the filename flag for other commands,
+	// so flags are parsed again once the command is known
+	flags := newAccessCheckFlags(true)
+	if err := flags.set.Parse(args); err != nil || len(flags.set.Args()) == 0 {
+		return accessAttributes{}, false
+	}
+	verb := flags.set.Args()[0]
+	if verb != "logs" {
+		flags = newAccessCheckFlags(false)
+		if err := flags.set.Parse(args); err != nil {
+			return accessAttributes{}, false
+		}
+	}
+	if flags.filename != "" {
+		// resources are defined in files
+		return accessAttributes{}, false
+	}
+
+	positional := flags.set.Args()
+	if dashIdx := flags.set.ArgsLenAtDash(); dashIdx >= 0 {
+		// e.g. the command run with `kubectl exec`
+		positional = positional[:dashIdx]
+	}
+	positional = positional[1:]
+
+	access, ok := commandsAccess[verb]
+	if verb == "rollout" {
+		if len(positional) == 0 {
+			return accessAttributes{}, false
+		}
+		access, ok = rolloutAccess[positional[0]]
+		positional = positional[1:]
+	}
+	if !ok {
+		return accessAttributes{}, false
+	}
+
+	resource, name := access.defaultResource, ""
+	switch {
+	case len(positional) == 0 && resource == "":
+		return accessAttributes{}, false
+	case len(positional) > 0 && strings.Contains(positional[0], "/"):
+		resource, name, _ = strings.Cut(positional[0], "/")
+	case resource != "" && len(positional) > 0:
+		name = positional[0]
+	case resource == "":
+		resource = positional[0]
+		if len(positional) == 2 {
+			name = positional[1]
+		}
+	}
+	if strings.Contains(resource, ",") {
+		// multiple resource types
+		return accessAttributes{}, false
+	}
+	if access.subresource == "log" && resource != access.defaultResource {
+		// logs of other resources, e.g. `deployment/api`, are read from their Pods
+		resource, name = access.defaultResource, ""
+	}
+	if flags.selector != "" {
+		name = ""
+	}
+
+	gvr, err := c.mapper.ResourceFor(schema.GroupVersionResource{Resource: resource})
+	if err != nil {
+		return accessAttributes{}, false
+	}
+	gvk, err := c.mapper.KindFor(gvr)
+	if err != nil {
+		return accessAttributes{}, false
+	}
+	mapping, err := c.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return accessAttributes{}, false
+	}
+
+	requiredVerb := access.verb
+	if access.listWithoutName && name == "" {
+		requiredVerb = "list"
+	}
+	out := accessAttributes{
+		ResourceAttributes: authzv1.ResourceAttributes{
+			Verb:        requiredVerb,
+			Group:       gvr.Group,
+			Resource:    gvr.Resource,
+			Subresource: access.subresource,
+			Name:        name,
+		},
+	}
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		switch {
+		case flags.allNamespaces:
+			out.allNamespaces = true
+		case flags.namespace != "":
+			out.Namespace = flags.namespace
+		default:
+			out.Namespace = defaultNamespace
+		}
+	}
+	return out, true
+}
+
+// accessCheckFlags holds kubectl flags which affect the required permission.
+type accessCheckFlags struct {
+	set           *pflag.FlagSet
+	namespace     string
+	allNamespaces bool
+	selector      string
+	filename      string
+}
+
+func newAccessCheckFlags(follow bool) *accessCheckFlags {
+	out := &accessCheckFlags{
+		set: pflag.NewFlagSet("access-check", pflag.ContinueOnError),
+	}
+	f := out.set
+	// ignore unknown flags errors, e.g. `--sort-by` etc.
+	f.ParseErrorsWhitelist.UnknownFlags = true
+	f.BoolP("help", "h", false, "to make sure that parsing is ignoring the --help,-h flags as there are specially process by pflag")
+	f.StringVarP(&out.namespace, "namespace", "n", "", "")
+	f.BoolVarP(&out.allNamespaces, "all-namespaces", "A", false, "")
+	f.StringVarP(&out.selector, "selector", "l", "", "")
+	if follow {
+		f.BoolP("follow", "f", false, "")
+	} else {
+		f.StringVarP(&out.filename, "filename", "f", "", "")
+	}
+	// boolean flags must be known, otherwise their following arguments are treated as values
+	for _, name := range []string{"all-containers", "show-labels", "no-headers", "force", "ignore-daemonsets", "delete-emptydir-data", "overwrite", "all", "timestamps", "ignore-not-found", "show-kind", "wait"} {
+		f.Bool(name, false, "")
+	}
+	f.BoolP("watch", "w", false, "")
+	f.BoolP("previous", "p", false, "")
+	f.BoolP("stdin", "i", false, "")
+	f.BoolP("tty", "t", false, "")
+	return out
+}
+
+func missingAccessMessage(attrs accessAttributes, reason string, user authnv1.UserInfo) api.Message {
+	resource := attrs.Resource
+	if attrs.Subresource != "" {
+		resource = fmt.Sprintf("%s/%s", resource, attrs.Subresource)
+	}
+	desc := fmt.Sprintf("You lack `%s` on `%s`", attrs.Verb, resource)
+	switch {
+	case attrs.Namespace != "":
+		desc = fmt.Sprintf("%s in the `%s` namespace.", desc, attrs.Namespace)
+	case attrs.allNamespaces:
+		desc = fmt.Sprintf("%s in all namespaces.", desc)
+	default:
+		desc = fmt.Sprintf("%s.", desc)
+	}
+	if reason != "" {
+		desc = fmt.Sprintf("%s Reason: %s.", desc, strings.TrimSuffix(reason, "."))
+	}
+	desc = fmt.Sprintf("%s\nTo grant it, apply the following RBAC resources:", desc)
+
+	return api.Message{
+		Sections: []api.Section{
+			{
+				Base: api.Base{
+					Header:      "Missing permissions",
+					Description: desc,
+					Body: api.Body{
+						CodeBlock: rbacSnippet(attrs, resource, user),
+					},
+				},
+				Context: []api.ContextItem{
+					{
+						Text: "To learn more about `kubectl` RBAC visit https://docs.botkube.io/configuration/executor/kubectl.",
+					},
+				},
+			},
+		},
+	}
+}
+
+// rbacSnippet returns a role with the missing permission, bound to the identity used by the plugin.
+// If the identity is unknown, the role binding is not returned.
+func rbacSnippet(attrs accessAttributes, resource string, user authnv1.UserInfo) string {
+	roleKind, bindingKind, namespace := "Role", "RoleBinding", ""
+	if attrs.Namespace == "" {
+		roleKind, bindingKind = "ClusterRole", "ClusterRoleBinding"
+	} else {
+		namespace = fmt.Sprintf("\n  namespace: %s", attrs.Namespace)
+	}
+	name := fmt.Sprintf("botkube-%s-%s", attrs.Verb, strings.NewReplacer("/", "-", ".", "-").Replace(resource))
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "apiVersion: rbac.authorization.k8s.io/v1\nkind: %s\nmetadata:\n  name: %s%s\n", roleKind, name, namespace)
+	fmt.Fprintf(&out, "rules:\n  - apiGroups: [%q]\n    resources: [%q]\n    verbs: [%q]", attrs.Group, resource, attrs.Verb)
+
+	subjects := bindingSubjects(user)
+	if len(subjects) == 0 {
+		return out.String()
+	}
+	fmt.Fprintf(&out, "\n---\napiVersion: rbac.authorization.k8s.io/v1\nkind: %s\nmetadata:\n  name: %s%s\n", bindingKind, name, namespace)
+	fmt.Fprintf(&out, "roleRef:\n  apiGroup: rbac.authorization.k8s.io\n  kind: %s\n  name: %s\nsubjects:", roleKind, name)
+	for _, subject := range subjects {
+		fmt.Fprintf(&out, "\n  - apiGroup: rbac.authorization.k8s.io\n    kind: %s\n    name: %s", subject.kind, subject.name)
+	}
+	return out.String()
+}
+
+type subject struct {
+	kind string
+	name string
+}
+
+// bindingSubjects returns groups of the identity used by the plugin. If there are no groups, the user is returned.
+func bindingSubjects(user authnv1.UserInfo) []subject {
+	var out []subject
+	for _, group := range user.Groups {
+		if group == authenticatedGroup {
+			continue
+		}
+		out = append(out, subject{kind: "Group", name: group})
+	}
+	if len(out) == 0 && user.Username != "" {
+		out = append(out, subject{kind: "User", name: user.Username})
+	}
+	return out
+}
@@ -0,0 +1,255 @@
+package kubectl
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authnv1 "k8s.io/api/authentication/v1"
+	authzv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/kubeshop/botkube/pkg/api"
+)
+
+func TestAccessCheckerAttributes(t *testing.T) {
+	tests := []struct {
+		name          string
+		givenCommand  string
+		expAttributes accessAttributes
+		expChecked    bool
+	}{
+		{
+			name:          "List in default namespace",
+			givenCommand:  "get pods",
+			expAttributes: accessAttributes{ResourceAttributes: authzv1.ResourceAttributes{Verb: "list", Resource: "pods", Namespace: "default"}},
+			expChecked:    true,
+		},
+		{
+			name:          "Get in given namespace",
+			givenCommand:  "-n prod get deploy api -o yaml",
+			expAttributes: accessAttributes{ResourceAttributes: authzv1.ResourceAttributes{Verb: "get", Group: "apps", Resource: "deployments", Name: "api", Namespace: "prod"}},
+			expChecked:    true,
+		},
+		{
+			name:          "List in all namespaces",
+			givenCommand:  "describe pods -A",
+			expAttributes: accessAttributes{ResourceAttributes: authzv1.ResourceAttributes{Verb: "list", Resource: "pods"}, allNamespaces: true},
+			expChecked:    true,
+		},
+		{
+			name:          "Logs",
+			givenCommand:  "logs -f deploy/api --namespace prod",
+			expAttributes: accessAttributes{ResourceAttributes: authzv1.ResourceAttributes{Verb: "get", Resource: "pods", Subresource: "log", Namespace: "prod"}},
+			expChecked:    true,
+		},
+		{
+			name:          "Exec",
+			givenCommand:  "exec -it api -n prod -- sh -c ls",
+			expAttributes: accessAttributes{ResourceAttributes: authzv1.ResourceAttributes{Verb: "create", Resource: "pods", Subresource: "exec", Name: "api", Namespace: "prod"}},
+			expChecked:    true,
+		},
+		{
+			name:          "Rollout restart",
+			givenCommand:  "rollout restart deployment/api -n prod",
+			expAttributes: accessAttributes{ResourceAttributes: authzv1.ResourceAttributes{Verb: "patch", Group: "apps", Resource: "deployments", Name: "api", Namespace: "prod"}},
+			expChecked:    true,
+		},
+		{
+			name:          "Cluster-scoped resource",
+			givenCommand:  "cordon node-1",
+			expAttributes: accessAttributes{ResourceAttributes: authzv1.ResourceAttributes{Verb: "patch", Resource: "nodes", Name: "node-1"}},
+			expChecked:    true,
+		},
+		{
+			name:         "Resources defined in file",
+			givenCommand: "delete -f manifest.yaml",
+		},
+		{
+			name:         "Multiple resource types",
+			givenCommand: "get pods,deployments",
+		},
+		{
+			name:         "Unknown resource",
+			givenCommand: "get foos",
+		},
+		{
+			name:         "Not verified command",
+			givenCommand: "top pods",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			checker := NewAccessChecker(nil, nil, fixRESTMapper())
+
+			// when
+			attrs, checked := checker.accessAttributesFor("default", tc.givenCommand)
+
+			// then
+			assert.Equal(t, tc.expChecked, checked)
+			assert.Equal(t, tc.expAttributes, attrs)
+		})
+	}
+}
+
+func TestAccessCheckerCheck(t *testing.T) {
+	// given
+	cli := fake.NewSimpleClientset()
+	var gotAttributes *authzv1.ResourceAttributes
+	cli.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authzv1.SelfSubjectAccessReview)
+		gotAttributes = review.Spec.ResourceAttributes
+		review.Status = authzv1.SubjectAccessReviewStatus{
+			Allowed: review.Spec.ResourceAttributes.Verb == "list",
+		}
+		return true, review, nil
+	})
+	cli.PrependReactor("create", "selfsubjectreviews", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, &authnv1.SelfSubjectReview{
+			Status: authnv1.SelfSubjectReviewStatus{
+				UserInfo: authnv1.UserInfo{
+					Username: "botkube-internal-static-user",
+					Groups:   []string{"botkube-plugins-default", "system:authenticated"},
+				},
+			},
+		}, nil
+	})
+	checker := NewAccessChecker(cli.AuthorizationV1(), cli.AuthenticationV1(), fixRESTMapper())
+
+	// when
+	msg, err := checker.Check(context.Background(), "default", "get pods -n prod")
+
+	// then
+	require.NoError(t, err)
+	assert.Nil(t, msg)
+
+	// when
+	msg, err = checker.Check(context.Background(), "default", "logs api -n prod")
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, &authzv1.ResourceAttributes{Verb: "get", Resource: "pods", Subresource: "log", Name: "api", Namespace: "prod"}, gotAttributes)
+	require.NotNil(t, msg)
+	require.Len(t, msg.Sections, 1)
+	assert.Equal(t, "Missing permissions", msg.Sections[0].Header)
+	assert.Equal(t, "You lack `get` on `pods/log` in the `prod` namespace.\nTo grant it, apply the following RBAC resources:", msg.Sections[0].Description)
+	assert.Equal(t, heredoc.Doc(`
+		apiVersion: rbac.authorization.k8s.io/v1
+		kind: Role
+		metadata:
+		  name: botkube-get-pods-log
+		  namespace: prod
+		rules:
+		  - apiGroups: [""]
+		    resources: ["pods/log"]
+		    verbs: ["get"]
+		---
+		apiVersion: rbac.authorization.k8s.io/v1
+		kind: RoleBinding
+		metadata:
+		  name: botkube-get-pods-log
+		  namespace: prod
+		roleRef:
+		  apiGroup: rbac.authorization.k8s.io
+		  kind: Role
+		  name: botkube-get-pods-log
+		subjects:
+		  - apiGroup: rbac.authorization.k8s.io
+		    kind: Group
+		    name: botkube-plugins-default`), msg.Sections[0].Body.CodeBlock)
+}
+
+func TestMissingAccessMessageClusterScoped(t *testing.T) {
+	// given
+	attrs := accessAttributes{ResourceAttributes: authzv1.ResourceAttributes{Verb: "patch", Resource: "nodes", Name: "node-1"}}
+
+	// when
+	msg := missingAccessMessage(attrs, "RBAC: clusterrole not found", authnv1.UserInfo{})
+
+	// then
+	assert.Equal(t, []api.Section{
+		{
+			Base: api.Base{
+				Header:      "Missing permissions",
+				Description: "You lack `patch` on `nodes`. Reason: RBAC: clusterrole not found.\nTo grant it, apply the following RBAC resources:",
+				Body: api.Body{
+					CodeBlock: heredoc.Doc(`
+						apiVersion: rbac.authorization.k8s.io/v1
+						kind: ClusterRole
+						metadata:
+						  name: botkube-patch-nodes
+						rules:
+						  - apiGroups: [""]
+						    resources: ["nodes"]
+						    verbs: ["patch"]`),
+				},
+			},
+			Context: []api.ContextItem{
+				{Text: "To learn more about `kubectl` RBAC visit https://docs.botkube.io/configuration/executor/kubectl."},
+			},
+		},
+	}, msg.Sections)
+}
+
+func TestRESTMapperCache(t *testing.T) {
+	// given
+	now := time.Now()
+	var created []string
+	cache := newRESTMapperCache(time.Minute)
+	cache.now = func() time.Time { return now }
+	cache.newMapper = func(kubeConfig *rest.Config) (meta.RESTMapper, error) {
+		created = append(created, kubeConfig.Host)
+		return fixRESTMapper(), nil
+	}
+
+	// when
+	for _, cfg := range []*rest.Config{
+		{Host: "https://prod.example.com", BearerToken: "token-1"},
+		{Host: "https://prod.example.com", BearerToken: "token-2"},
+		{Host: "https://dev.example.com"},
+	} {
+		_, err := cache.Get(cfg)
+		require.NoError(t, err)
+	}
+
+	// then
+	assert.Equal(t, []string{"https://prod.example.com", "https://dev.example.com"}, created)
+
+	// when
+	now = now.Add(2 * time.Minute)
+	_, err := cache.Get(&rest.Config{Host: "https://prod.example.com"})
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://prod.example.com", "https://dev.example.com", "https://prod.example.com"}, created)
+}
+
+func fixRESTMapper() meta.RESTMapper {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "Pod"}, meta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "Node"}, meta.RESTScopeRoot)
+	mapper.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, meta.RESTScopeNamespace)
+	return &shortcutsMapper{RESTMapper: mapper, shortcuts: map[string]string{"deploy": "deployments"}}
+}
+
+// shortcutsMapper resolves resource short names, like the discovery-based mapper does.
+type shortcutsMapper struct {
+	meta.RESTMapper
+	shortcuts map[string]string
+}
+
+func (m *shortcutsMapper) ResourceFor(in schema.GroupVersionResource) (schema.GroupVersionResource, error) {
+	if resource, ok := m.shortcuts[in.Resource]; ok {
+		in.Resource = resource
+	}
+	return m.RESTMapper.ResourceFor(in)
+}
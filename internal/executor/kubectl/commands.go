@@ -11,7 +11,6 @@ import (
 
 // notSupportedSubcommands defines all explicitly not supported Kubectl plugin commands.
 var notSupportedSubcommands = map[string]struct{}{
-	"edit":         {},
 	"attach":       {},
 	"port-forward": {},
 	"proxy":        {},
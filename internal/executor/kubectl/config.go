@@ -16,6 +16,12 @@ type Config struct {
 	Log                config.Logger  `yaml:"log"`
 	DefaultNamespace   string         `yaml:"defaultNamespace,omitempty"`
 	InteractiveBuilder builder.Config `yaml:"interactiveBuilder,omitempty"`
+	AccessCheck        AccessCheck    `yaml:"accessCheck,omitempty"`
+}
+
+// AccessCheck holds configuration of permissions verification done before running commands.
+type AccessCheck struct {
+	Enabled bool `yaml:"enabled"`
 }
 
 func (c Config) Validate() error {
@@ -33,6 +39,9 @@ func MergeConfigs(configs []*executor.Config) (Config, error) {
 	defaults := Config{
 		DefaultNamespace:   defaultNamespace,
 		InteractiveBuilder: builder.DefaultConfig(),
+		AccessCheck: AccessCheck{
+			Enabled: true,
+		},
 	}
 
 	var out Config
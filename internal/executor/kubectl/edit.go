@@ -0,0 +1,575 @@
+package kubectl
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/sirupsen/logrus"
+	"github.com/slack-go/slack"
+	"github.com/spf13/pflag"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/api/executor"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+	"github.com/kubeshop/botkube/pkg/plugin"
+)
+
+const (
+	editCmdName = "edit"
+	// editManifestInputID identifies the text area with the edited manifest in the message state.
+	editManifestInputID    = "kubectl-edit-manifest"
+	editValidateCallbackID = "edit-validate"
+	editApplyCallbackID    = "edit-apply"
+	editDiscardCallbackID  = "edit-discard"
+	editFieldManager       = "botkube-kubectl-edit"
+	// maxInitialManifestLength is the maximum length of the manifest prefilled in the text area, as Slack rejects longer values.
+	maxInitialManifestLength = 3000
+	editIDBytes              = 8
+	editDirPerms             = 0o700
+	editFilePerms            = 0o600
+)
+
+var defaultEditDir = filepath.Join(os.TempDir(), "botkube-kubectl-edits")
+
+// isEditCommand returns true if a given normalized command is `kubectl edit`.
+func isEditCommand(cmd string) bool {
+	args := strings.Fields(cmd)
+	return len(args) > 0 && args[0] == editCmdName
+}
+
+// edit returns the manifest of a given object for editing. Instead of opening an editor, as `kubectl edit` does,
+// the manifest is edited in the message text area, validated with a server-side dry run, and applied once confirmed.
+func (e *Executor) edit(ctx context.Context, kubeConfigPath string, cfg Config, in executor.ExecuteInput, cmd string) (executor.ExecuteOutput, error) {
+	if !in.Context.IsInteractivitySupported {
+		return executor.ExecuteOutput{}, errors.New("The edit command requires interactive messages, which are not supported by this communication platform.")
+	}
+
+	resource, namespace, name, err := parseEditCommand(cfg.DefaultNamespace, cmd)
+	if err != nil {
+		return executor.ExecuteOutput{}, err
+	}
+
+	editor, err := e.newEditor(kubeConfigPath)
+	if err != nil {
+		return executor.ExecuteOutput{}, fmt.Errorf("while creating editor: %w", err)
+	}
+	target, err := editor.Resolve(resource, namespace, name)
+	if err != nil {
+		return executor.ExecuteOutput{}, err
+	}
+	obj, err := editor.Get(ctx, target)
+	if err != nil {
+		return executor.ExecuteOutput{}, fmt.Errorf("while getting %s: %w", target.displayName(), err)
+	}
+	manifest, err := toYAML(editableObject(obj))
+	if err != nil {
+		return executor.ExecuteOutput{}, err
+	}
+
+	return executor.ExecuteOutput{
+		Message: editMessage(target, manifest),
+	}, nil
+}
+
+// HandleInteraction handles interactions with messages returned by the edit command.
+func (e *Executor) HandleInteraction(ctx context.Context, in executor.InteractionInput) (executor.InteractionOutput, error) {
+	if err := plugin.ValidateKubeConfigProvided(PluginName, in.Context.KubeConfig); err != nil {
+		return executor.InteractionOutput{}, err
+	}
+
+	cfg, err := MergeConfigs(in.Configs)
+	if err != nil {
+		return executor.InteractionOutput{}, fmt.Errorf("while merging input configs: %w", err)
+	}
+	log := loggerx.New(cfg.Log)
+	store := editStore{dir: e.editDir}
+
+	if in.Callback.ID == editDiscardCallbackID {
+		return e.discardEdit(store, in)
+	}
+
+	kubeConfigPath, deleteFn, err := plugin.PersistKubeConfig(ctx, in.Context.KubeConfig)
+	if err != nil {
+		return executor.InteractionOutput{}, fmt.Errorf("while writing kubeconfig file: %w", err)
+	}
+	defer func() {
+		if deleteErr := deleteFn(ctx); deleteErr != nil {
+			log.Errorf("failed to delete kubeconfig file %s: %w", kubeConfigPath, deleteErr)
+		}
+	}()
+
+	editor, err := e.newEditor(kubeConfigPath)
+	if err != nil {
+		return executor.InteractionOutput{}, fmt.Errorf("while creating editor: %w", err)
+	}
+
+	switch in.Callback.ID {
+	case editValidateCallbackID:
+		return e.validateEdit(ctx, editor, store, in)
+	case editApplyCallbackID:
+		return e.applyEdit(ctx, log, editor, store, in)
+	default:
+		return executor.InteractionOutput{}, fmt.Errorf("unknown interaction %q", in.Callback.ID)
+	}
+}
+
+// validateEdit runs a server-side dry run of the edited manifest and returns the resulting changes for confirmation.
+func (e *Executor) validateEdit(ctx context.Context, editor resourceEditor, store editStore, in executor.InteractionInput) (executor.InteractionOutput, error) {
+	target, err := parseEditTarget(in.Callback.Value)
+	if err != nil {
+		return executor.InteractionOutput{}, err
+	}
+	edited, err := parseEditedManifest(target, editedManifest(in.Context.SlackState))
+	if err != nil {
+		return executor.InteractionOutput{}, err
+	}
+
+	current, err := editor.Get(ctx, target)
+	if err != nil {
+		return executor.InteractionOutput{}, fmt.Errorf("while getting %s: %w", target.displayName(), err)
+	}
+	if edited.GetKind() != current.GetKind() {
+		return executor.InteractionOutput{}, fmt.Errorf("The edited manifest must keep the %q kind.", current.GetKind())
+	}
+
+	result, err := editor.Update(ctx, target, edited, true)
+	if err != nil {
+		return executor.InteractionOutput{}, fmt.Errorf("The edited manifest was rejected by the server: %w", err)
+	}
+
+	diff, err := objectsDiff(editableObject(current), editableObject(result))
+	if err != nil {
+		return executor.InteractionOutput{}, err
+	}
+	if diff == "" {
+		return executor.InteractionOutput{
+			Message: api.NewPlaintextMessage(fmt.Sprintf("The edited manifest doesn't change %s.", target.displayName()), false),
+		}, nil
+	}
+
+	manifest, err := toYAML(edited)
+	if err != nil {
+		return executor.InteractionOutput{}, err
+	}
+	id, err := store.save(pendingEdit{
+		Target:   target,
+		Manifest: manifest,
+		Diff:     diff,
+	})
+	if err != nil {
+		return executor.InteractionOutput{}, err
+	}
+
+	return executor.InteractionOutput{
+		Message: confirmEditMessage(target, id, diff),
+	}, nil
+}
+
+// applyEdit updates the object with the exact manifest validated before. The manifest keeps the resource version of the edited object,
+// so the update is rejected if the object was changed in the meantime.
+func (e *Executor) applyEdit(ctx context.Context, log logrus.FieldLogger, editor resourceEditor, store editStore, in executor.InteractionInput) (executor.InteractionOutput, error) {
+	id := in.Callback.Value
+	edit, err := store.get(id)
+	if err != nil {
+		return executor.InteractionOutput{}, err
+	}
+	obj, err := parseEditedManifest(edit.Target, edit.Manifest)
+	if err != nil {
+		return executor.InteractionOutput{}, err
+	}
+
+	user := in.Context.Message.User
+	log.WithFields(logrus.Fields{
+		"object": edit.Target.String(),
+		"editID": id,
+		"user":   user.DisplayName,
+	}).Info("Applying edited manifest...")
+	_, err = editor.Update(ctx, edit.Target, obj, false)
+	if removeErr := store.remove(id); removeErr != nil {
+		log.WithError(removeErr).Warn("Failed to remove applied edit")
+	}
+	if err != nil {
+		return executor.InteractionOutput{}, fmt.Errorf("while updating %s: %w", edit.Target.displayName(), err)
+	}
+
+	return executor.InteractionOutput{
+		Message: appliedEditMessage(edit, user),
+	}, nil
+}
+
+func (e *Executor) discardEdit(store editStore, in executor.InteractionInput) (executor.InteractionOutput, error) {
+	if err := store.remove(in.Callback.Value); err != nil {
+		return executor.InteractionOutput{}, err
+	}
+	msg := fmt.Sprintf("Edit %q discarded by %s.", in.Callback.Value, in.Context.Message.User.Mention)
+	return executor.InteractionOutput{
+		Message: api.NewPlaintextMessage(msg, false),
+	}, nil
+}
+
+// parseEditCommand returns the resource type, namespace and name of the object edited by a given command,
+// e.g. `edit deploy/api -n prod` or `edit deployment api`.
+func parseEditCommand(defaultNamespace, cmd string) (string, string, string, error) {
+	var namespace string
+	f := pflag.NewFlagSet("edit", pflag.ContinueOnError)
+	f.StringVarP(&namespace, "namespace", "n", defaultNamespace, "")
+	if err := f.Parse(strings.Fields(cmd)); err != nil {
+		return "", "", "", fmt.Errorf("while parsing args: %w", err)
+	}
+
+	args := f.Args()[1:]
+	switch {
+	case len(args) == 1 && strings.Contains(args[0], "/"):
+		resource, name, _ := strings.Cut(args[0], "/")
+		return resource, namespace, name, nil
+	case len(args) == 2:
+		return args[0], namespace, args[1], nil
+	default:
+		return "", "", "", errors.New("The edit command requires a single object, e.g. `kubectl edit deployment/api -n prod`.")
+	}
+}
+
+// editTarget identifies the edited object.
+type editTarget struct {
+	Group     string `json:"group"`
+	Version   string `json:"version"`
+	Resource  string `json:"resource"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+}
+
+// GroupVersionResource returns the resource type of the edited object.
+func (t editTarget) GroupVersionResource() schema.GroupVersionResource {
+	return schema.GroupVersionResource{Group: t.Group, Version: t.Version, Resource: t.Resource}
+}
+
+// String returns the target in the `{group}/{version}/{resource}/{namespace}/{name}` format, used as the callback value.
+// It's not quoted, so it's passed safely in interaction commands.
+func (t editTarget) String() string {
+	return strings.Join([]string{t.Group, t.Version, t.Resource, t.Namespace, t.Name}, "/")
+}
+
+func (t editTarget) displayName() string {
+	if t.Namespace == "" {
+		return fmt.Sprintf("%s/%s", t.Resource, t.Name)
+	}
+	return fmt.Sprintf("%s/%s in the %q namespace", t.Resource, t.Name, t.Namespace)
+}
+
+func parseEditTarget(in string) (editTarget, error) {
+	parts := strings.Split(in, "/")
+	if len(parts) != 5 || parts[1] == "" || parts[2] == "" || parts[4] == "" {
+		return editTarget{}, fmt.Errorf("invalid edited object %q", in)
+	}
+	return editTarget{
+		Group:     parts[0],
+		Version:   parts[1],
+		Resource:  parts[2],
+		Namespace: parts[3],
+		Name:      parts[4],
+	}, nil
+}
+
+// dynamicEditor gets and updates objects of any kind.
+type dynamicEditor struct {
+	cli    dynamic.Interface
+	mapper meta.RESTMapper
+}
+
+// newEditorForKubeconfig returns a new dynamicEditor instance for a given kubeconfig.
+func newEditorForKubeconfig(kubeConfigPath string, mappers *restMapperCache) (*dynamicEditor, error) {
+	kubeConfig, err := clientcmd.BuildConfigFromFlags("", kubeConfigPath)
+	if err != nil {
+		return nil, fmt.Errorf("while creating kube config: %w", err)
+	}
+	cli, err := dynamic.NewForConfig(kubeConfig)
+	if err != nil {
+		return nil, fmt.Errorf("while creating dynamic k8s client: %w", err)
+	}
+	mapper, err := mappers.Get(kubeConfig)
+	if err != nil {
+		return nil, err
+	}
+	return &dynamicEditor{cli: cli, mapper: mapper}, nil
+}
+
+// Resolve returns the edited object for a given resource type, which may be a short name, e.g. `deploy`.
+// The namespace is dropped for cluster-scoped resources.
+func (e *dynamicEditor) Resolve(resource, namespace, name string) (editTarget, error) {
+	gvr, err := e.mapper.ResourceFor(schema.ParseGroupResource(resource).WithVersion(""))
+	if err != nil {
+		return editTarget{}, fmt.Errorf("while resolving %q resource type: %w", resource, err)
+	}
+	gvk, err := e.mapper.KindFor(gvr)
+	if err != nil {
+		return editTarget{}, fmt.Errorf("while resolving %q kind: %w", resource, err)
+	}
+	mapping, err := e.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return editTarget{}, fmt.Errorf("while resolving %q mapping: %w", resource, err)
+	}
+	if mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+		namespace = ""
+	}
+
+	return editTarget{
+		Group:     gvr.Group,
+		Version:   gvr.Version,
+		Resource:  gvr.Resource,
+		Namespace: namespace,
+		Name:      name,
+	}, nil
+}
+
+// Get returns a given object.
+func (e *dynamicEditor) Get(ctx context.Context, target editTarget) (*unstructured.Unstructured, error) {
+	return e.cli.Resource(target.GroupVersionResource()).Namespace(target.Namespace).Get(ctx, target.Name, metav1.GetOptions{})
+}
+
+// Update updates a given object. If dryRun is set, the update is only validated by the server, and the resulting object is returned.
+func (e *dynamicEditor) Update(ctx context.Context, target editTarget, obj *unstructured.Unstructured, dryRun bool) (*unstructured.Unstructured, error) {
+	opts := metav1.UpdateOptions{FieldManager: editFieldManager}
+	if dryRun {
+		opts.DryRun = []string{metav1.DryRunAll}
+	}
+	return e.cli.Resource(target.GroupVersionResource()).Namespace(target.Namespace).Update(ctx, obj, opts)
+}
+
+// editableObject returns a copy of a given object without fields managed by the server, so users edit only their own fields.
+// The resource version is kept, so the update is rejected if the object was changed in the meantime.
+func editableObject(obj *unstructured.Unstructured) *unstructured.Unstructured {
+	out := obj.DeepCopy()
+	unstructured.RemoveNestedField(out.Object, "status")
+	for _, field := range []string{"managedFields", "creationTimestamp", "generation", "uid", "selfLink"} {
+		unstructured.RemoveNestedField(out.Object, "metadata", field)
+	}
+
+	annotations := out.GetAnnotations()
+	delete(annotations, corev1.LastAppliedConfigAnnotation)
+	if len(annotations) == 0 {
+		annotations = nil
+	}
+	out.SetAnnotations(annotations)
+	return out
+}
+
+// parseEditedManifest parses the edited manifest. It returns an error if the manifest describes other object than the edited one.
+func parseEditedManifest(target editTarget, manifest string) (*unstructured.Unstructured, error) {
+	if strings.TrimSpace(manifest) == "" {
+		return nil, errors.New("The edited manifest is empty. Edit it in the text area, and validate it again.")
+	}
+
+	// JSON unmarshaling of unstructured objects keeps integers, which would be decoded as floats from YAML
+	raw, err := yaml.YAMLToJSON([]byte(manifest))
+	if err != nil {
+		return nil, fmt.Errorf("while parsing edited manifest: %w", err)
+	}
+	obj := &unstructured.Unstructured{}
+	if err := obj.UnmarshalJSON(raw); err != nil {
+		return nil, fmt.Errorf("while parsing edited manifest: %w", err)
+	}
+
+	gv := target.GroupVersionResource().GroupVersion()
+	if obj.GetAPIVersion() != gv.String() {
+		return nil, fmt.Errorf("The edited manifest must keep the %q API version.", gv.String())
+	}
+	if obj.GetName() != target.Name {
+		return nil, fmt.Errorf("The edited manifest must keep the %q name.", target.Name)
+	}
+	switch obj.GetNamespace() {
+	case target.Namespace:
+	case "":
+		obj.SetNamespace(target.Namespace)
+	default:
+		return nil, fmt.Errorf("The edited manifest must keep the %q namespace.", target.Namespace)
+	}
+	return obj, nil
+}
+
+// editedManifest returns the manifest typed in the edit text area. Action IDs may contain additional flags, such as the cluster name.
+func editedManifest(state *slack.BlockActionStates) string {
+	if state == nil {
+		return ""
+	}
+	for _, blocks := range state.Values {
+		for id, act := range blocks {
+			if strings.Contains(id, editManifestInputID) {
+				return act.Value
+			}
+		}
+	}
+	return ""
+}
+
+func toYAML(obj *unstructured.Unstructured) (string, error) {
+	out, err := yaml.Marshal(obj.Object)
+	if err != nil {
+		return "", fmt.Errorf("while marshaling object: %w", err)
+	}
+	return string(out), nil
+}
+
+// objectsDiff returns the unified diff of two objects. It's empty if objects are equal.
+func objectsDiff(current, edited *unstructured.Unstructured) (string, error) {
+	currentYAML, err := toYAML(current)
+	if err != nil {
+		return "", err
+	}
+	editedYAML, err := toYAML(edited)
+	if err != nil {
+		return "", err
+	}
+
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(currentYAML),
+		B:        difflib.SplitLines(editedYAML),
+		FromFile: "live",
+		ToFile:   "edited",
+		Context:  3,
+	})
+}
+
+// pendingEdit holds a validated manifest awaiting confirmation.
+type pendingEdit struct {
+	Target   editTarget `json:"target"`
+	Manifest string     `json:"manifest"`
+	Diff     string     `json:"diff"`
+}
+
+// editStore keeps validated manifests, so only the exact reviewed changes can be applied.
+type editStore struct {
+	dir string
+}
+
+func (s editStore) path(id string) string {
+	return filepath.Join(s.dir, id+".json")
+}
+
+// save stores a given edit and returns its ID.
+func (s editStore) save(edit pendingEdit) (string, error) {
+	if err := os.MkdirAll(s.dir, editDirPerms); err != nil {
+		return "", fmt.Errorf("while creating edits directory: %w", err)
+	}
+
+	raw := make([]byte, editIDBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("while generating edit ID: %w", err)
+	}
+	id := hex.EncodeToString(raw)
+
+	out, err := json.Marshal(edit)
+	if err != nil {
+		return "", fmt.Errorf("while marshaling edit: %w", err)
+	}
+	if err := os.WriteFile(s.path(id), out, editFilePerms); err != nil {
+		return "", fmt.Errorf("while storing edit: %w", err)
+	}
+	return id, nil
+}
+
+// get returns a given edit. An error is returned if edit doesn't exist.
+func (s editStore) get(id string) (pendingEdit, error) {
+	if id == "" || strings.ContainsAny(id, `/\.`) {
+		return pendingEdit{}, fmt.Errorf("invalid edit ID %q", id)
+	}
+	raw, err := os.ReadFile(s.path(id))
+	if err != nil {
+		return pendingEdit{}, fmt.Errorf("edit %q not found. It was already applied, discarded, or the plugin was restarted. Run the edit command again", id)
+	}
+
+	var out pendingEdit
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return pendingEdit{}, fmt.Errorf("while unmarshaling edit: %w", err)
+	}
+	return out, nil
+}
+
+// remove deletes a given edit.
+func (s editStore) remove(id string) error {
+	if _, err := s.get(id); err != nil {
+		return err
+	}
+	return os.Remove(s.path(id))
+}
+
+func editMessage(target editTarget, manifest string) api.Message {
+	input := api.LabelInput{
+		Command:     editManifestInputID,
+		Text:        "Edited manifest",
+		Placeholder: "Paste the edited manifest",
+		Multiline:   true,
+	}
+	section := api.Section{
+		Base: api.Base{
+			Header:      fmt.Sprintf("Edit %s", target.displayName()),
+			Description: "Edit the manifest and validate it. Changes are verified with a server-side dry run, and shown for confirmation before they are applied.",
+		},
+	}
+	if len(manifest) <= maxInitialManifestLength {
+		input.InitialValue = manifest
+	} else {
+		section.Description = "The manifest is too long to be edited in place. Copy it, paste the edited version and validate it. Changes are verified with a server-side dry run, and shown for confirmation before they are applied."
+		section.Body.CodeBlock = manifest
+	}
+	section.PlaintextInputs = api.LabelInputs{input}
+	section.Buttons = api.Buttons{
+		api.NewMessageButtonBuilder().ForCallback("Validate", api.Callback{ID: editValidateCallbackID, Value: target.String()}, api.ButtonStylePrimary),
+	}
+
+	return api.Message{Sections: []api.Section{section}}
+}
+
+func confirmEditMessage(target editTarget, id, diff string) api.Message {
+	btnBuilder := api.NewMessageButtonBuilder()
+	return api.Message{
+		Sections: []api.Section{
+			{
+				Base: api.Base{
+					Header:      fmt.Sprintf("Changes of %s", target.displayName()),
+					Description: "The edited manifest passed the server-side dry run. Review the changes, and apply them.",
+					Body: api.Body{
+						CodeBlock: diff,
+					},
+				},
+				Buttons: api.Buttons{
+					btnBuilder.ForCallback("Apply", api.Callback{ID: editApplyCallbackID, Value: id}, api.ButtonStyleDanger),
+					btnBuilder.ForCallback("Discard", api.Callback{ID: editDiscardCallbackID, Value: id}),
+				},
+				Context: api.ContextItems{
+					{Text: fmt.Sprintf("Edit ID: %s", id)},
+				},
+			},
+		},
+	}
+}
+
+func appliedEditMessage(edit pendingEdit, user executor.User) api.Message {
+	return api.Message{
+		Sections: []api.Section{
+			{
+				Base: api.Base{
+					Header: fmt.Sprintf("%s updated by %s", edit.Target.displayName(), user.Mention),
+					Body: api.Body{
+						CodeBlock: edit.Diff,
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,379 @@
+package kubectl
+
+import (
+	"context"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/api/executor"
+	"github.com/kubeshop/botkube/pkg/plugin"
+)
+
+func TestEditFlow(t *testing.T) {
+	// given
+	editor := fixEditor(t)
+	exec := fixEditExecutor(t, editor)
+	ctx := context.Background()
+
+	// when
+	out, err := exec.Execute(ctx, executor.ExecuteInput{
+		Command: "kubectl edit deploy/api -n prod",
+		Context: executor.ExecuteInputContext{
+			KubeConfig:               []byte("not empty"),
+			IsInteractivitySupported: true,
+		},
+	})
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, out.Message.Sections, 1)
+	section := out.Message.Sections[0]
+	assert.Equal(t, `Edit deployments/api in the "prod" namespace`, section.Header)
+	assert.Empty(t, section.Body.CodeBlock)
+	require.Len(t, section.PlaintextInputs, 1)
+	assert.True(t, section.PlaintextInputs[0].Multiline)
+	assert.Equal(t, fixDeploymentManifest(2), section.PlaintextInputs[0].InitialValue)
+	require.Len(t, section.Buttons, 1)
+	assert.Equal(t, &api.Callback{ID: editValidateCallbackID, Value: "apps/v1/deployments/prod/api"}, section.Buttons[0].Callback)
+
+	// when
+	validated, err := exec.HandleInteraction(ctx, fixInteractionInput(*section.Buttons[0].Callback, fixDeploymentManifest(3)))
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []bool{true}, editor.updates)
+	require.Len(t, validated.Message.Sections, 1)
+	section = validated.Message.Sections[0]
+	assert.Contains(t, section.Body.CodeBlock, "-  replicas: 2\n+  replicas: 3\n")
+	require.Len(t, section.Buttons, 2)
+	applyCallback := *section.Buttons[0].Callback
+	assert.Equal(t, editApplyCallbackID, applyCallback.ID)
+	assert.Equal(t, api.ButtonStyleDanger, section.Buttons[0].Style)
+
+	// when
+	applied, err := exec.HandleInteraction(ctx, fixInteractionInput(applyCallback, ""))
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []bool{true, false}, editor.updates)
+	assert.Equal(t, `deployments/api in the "prod" namespace updated by <@U123>`, applied.Message.Sections[0].Header)
+
+	obj, err := editor.Get(ctx, editTarget{Group: "apps", Version: "v1", Resource: "deployments", Namespace: "prod", Name: "api"})
+	require.NoError(t, err)
+	replicas, _, err := unstructured.NestedInt64(obj.Object, "spec", "replicas")
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, replicas)
+
+	// when
+	_, err = exec.HandleInteraction(ctx, fixInteractionInput(applyCallback, ""))
+
+	// then
+	assert.ErrorContains(t, err, "It was already applied, discarded, or the plugin was restarted.")
+}
+
+func TestEditDiscard(t *testing.T) {
+	// given
+	editor := fixEditor(t)
+	exec := fixEditExecutor(t, editor)
+	ctx := context.Background()
+
+	validated, err := exec.HandleInteraction(ctx, fixInteractionInput(api.Callback{ID: editValidateCallbackID, Value: "apps/v1/deployments/prod/api"}, fixDeploymentManifest(3)))
+	require.NoError(t, err)
+	discardCallback := *validated.Message.Sections[0].Buttons[1].Callback
+
+	// when
+	out, err := exec.HandleInteraction(ctx, fixInteractionInput(discardCallback, ""))
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, api.NewPlaintextMessage(`Edit "`+discardCallback.Value+`" discarded by <@U123>.`, false), out.Message)
+	assert.Equal(t, []bool{true}, editor.updates)
+
+	_, err = exec.HandleInteraction(ctx, fixInteractionInput(api.Callback{ID: editApplyCallbackID, Value: discardCallback.Value}, ""))
+	assert.ErrorContains(t, err, "not found")
+}
+
+func TestEditValidateErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		givenManifest string
+		expErr        string
+	}{
+		{
+			name:          "Empty manifest",
+			givenManifest: "  ",
+			expErr:        "The edited manifest is empty. Edit it in the text area, and validate it again.",
+		},
+		{
+			name: "Other name",
+			givenManifest: heredoc.Doc(`
+				apiVersion: apps/v1
+				kind: Deployment
+				metadata:
+				  name: other
+				  namespace: prod`),
+			expErr: `The edited manifest must keep the "api" name.`,
+		},
+		{
+			name: "Other namespace",
+			givenManifest: heredoc.Doc(`
+				apiVersion: apps/v1
+				kind: Deployment
+				metadata:
+				  name: api
+				  namespace: dev`),
+			expErr: `The edited manifest must keep the "prod" namespace.`,
+		},
+		{
+			name: "Other API version",
+			givenManifest: heredoc.Doc(`
+				apiVersion: v1
+				kind: Deployment
+				metadata:
+				  name: api`),
+			expErr: `The edited manifest must keep the "apps/v1" API version.`,
+		},
+		{
+			name: "Other kind",
+			givenManifest: heredoc.Doc(`
+				apiVersion: apps/v1
+				kind: StatefulSet
+				metadata:
+				  name: api`),
+			expErr: `The edited manifest must keep the "Deployment" kind.`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			editor := fixEditor(t)
+			exec := fixEditExecutor(t, editor)
+
+			// when
+			_, err := exec.HandleInteraction(context.Background(), fixInteractionInput(api.Callback{ID: editValidateCallbackID, Value: "apps/v1/deployments/prod/api"}, tc.givenManifest))
+
+			// then
+			assert.EqualError(t, err, tc.expErr)
+			assert.Empty(t, editor.updates)
+		})
+	}
+}
+
+func TestEditValidateWithoutChanges(t *testing.T) {
+	// given
+	editor := fixEditor(t)
+	exec := fixEditExecutor(t, editor)
+
+	// when
+	out, err := exec.HandleInteraction(context.Background(), fixInteractionInput(api.Callback{ID: editValidateCallbackID, Value: "apps/v1/deployments/prod/api"}, fixDeploymentManifest(2)))
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, api.NewPlaintextMessage(`The edited manifest doesn't change deployments/api in the "prod" namespace.`, false), out.Message)
+}
+
+func TestEditNotInteractive(t *testing.T) {
+	// given
+	exec := fixEditExecutor(t, fixEditor(t))
+
+	// when
+	_, err := exec.Execute(context.Background(), executor.ExecuteInput{
+		Command: "kubectl edit deploy/api -n prod",
+		Context: executor.ExecuteInputContext{
+			KubeConfig: []byte("not empty"),
+		},
+	})
+
+	// then
+	assert.EqualError(t, err, "The edit command requires interactive messages, which are not supported by this communication platform.")
+}
+
+func TestParseEditCommand(t *testing.T) {
+	tests := []struct {
+		name         string
+		givenCommand string
+		expResource  string
+		expNamespace string
+		expName      string
+		expErr       string
+	}{
+		{
+			name:         "Type and name with slash",
+			givenCommand: "edit deploy/api -n prod",
+			expResource:  "deploy",
+			expNamespace: "prod",
+			expName:      "api",
+		},
+		{
+			name:         "Type and name as separate args",
+			givenCommand: "edit --namespace prod deployment api",
+			expResource:  "deployment",
+			expNamespace: "prod",
+			expName:      "api",
+		},
+		{
+			name:         "Default namespace",
+			givenCommand: "edit node worker-1",
+			expResource:  "node",
+			expNamespace: "default",
+			expName:      "worker-1",
+		},
+		{
+			name:         "Missing name",
+			givenCommand: "edit deployments",
+			expErr:       "The edit command requires a single object, e.g. `kubectl edit deployment/api -n prod`.",
+		},
+		{
+			name:         "Unknown flag",
+			givenCommand: "edit deploy/api -o json",
+			expErr:       "while parsing args: unknown shorthand flag: 'o' in -o",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			resource, namespace, name, err := parseEditCommand("default", tc.givenCommand)
+
+			// then
+			if tc.expErr != "" {
+				assert.EqualError(t, err, tc.expErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expResource, resource)
+			assert.Equal(t, tc.expNamespace, namespace)
+			assert.Equal(t, tc.expName, name)
+		})
+	}
+}
+
+func TestEditableObject(t *testing.T) {
+	// given
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]interface{}{
+			"name":              "cfg",
+			"resourceVersion":   "42",
+			"uid":               "123",
+			"creationTimestamp": "2023-01-01T00:00:00Z",
+			"managedFields":     []interface{}{map[string]interface{}{"manager": "kubectl"}},
+			"annotations": map[string]interface{}{
+				"kubectl.kubernetes.io/last-applied-configuration": "{}",
+			},
+		},
+		"data": map[string]interface{}{"key": "value"},
+	}}
+
+	// when
+	out := editableObject(obj)
+
+	// then
+	assert.Equal(t, map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]interface{}{
+			"name":            "cfg",
+			"resourceVersion": "42",
+		},
+		"data": map[string]interface{}{"key": "value"},
+	}, out.Object)
+	assert.Contains(t, obj.Object["metadata"], "uid")
+}
+
+// recordingEditor records updates, and doesn't persist dry run updates, as the fake client doesn't support them.
+type recordingEditor struct {
+	*dynamicEditor
+	updates []bool
+}
+
+func (e *recordingEditor) Update(ctx context.Context, target editTarget, obj *unstructured.Unstructured, dryRun bool) (*unstructured.Unstructured, error) {
+	e.updates = append(e.updates, dryRun)
+	if dryRun {
+		return obj, nil
+	}
+	return e.dynamicEditor.Update(ctx, target, obj, dryRun)
+}
+
+func fixEditor(t *testing.T) *recordingEditor {
+	t.Helper()
+
+	deploy := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]interface{}{
+			"name":            "api",
+			"namespace":       "prod",
+			"resourceVersion": "42",
+			"uid":             "123",
+			"generation":      int64(1),
+		},
+		"spec": map[string]interface{}{
+			"replicas": int64(2),
+		},
+		"status": map[string]interface{}{
+			"readyReplicas": int64(2),
+		},
+	}}
+	cli := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), deploy)
+
+	return &recordingEditor{
+		dynamicEditor: &dynamicEditor{cli: cli, mapper: fixRESTMapper()},
+	}
+}
+
+func fixEditExecutor(t *testing.T, editor resourceEditor) *Executor {
+	t.Helper()
+
+	exec := NewExecutor("dev", NewMockedBinaryRunner(func(context.Context, string, ...plugin.ExecuteCommandMutation) (plugin.ExecuteCommandOutput, error) {
+		t.Fatal("kubectl shouldn't be called")
+		return plugin.ExecuteCommandOutput{}, nil
+	}))
+	exec.newEditor = func(string) (resourceEditor, error) {
+		return editor, nil
+	}
+	exec.editDir = t.TempDir()
+	return exec
+}
+
+func fixInteractionInput(callback api.Callback, manifest string) executor.InteractionInput {
+	return executor.InteractionInput{
+		Callback: callback,
+		Type:     executor.ButtonClickInteraction,
+		Context: executor.ExecuteInputContext{
+			KubeConfig:               []byte("not empty"),
+			IsInteractivitySupported: true,
+			Message: executor.Message{
+				User: executor.User{Mention: "<@U123>", DisplayName: "Jane"},
+			},
+			SlackState: &slack.BlockActionStates{
+				Values: map[string]map[string]slack.BlockAction{
+					"block": {
+						editManifestInputID + " --cluster-name prod": {Value: manifest},
+					},
+				},
+			},
+		},
+	}
+}
+
+func fixDeploymentManifest(replicas int) string {
+	return heredoc.Docf(`
+		apiVersion: apps/v1
+		kind: Deployment
+		metadata:
+		  name: api
+		  namespace: prod
+		  resourceVersion: "42"
+		spec:
+		  replicas: %d
+	`, replicas)
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/clientcmd"
@@ -41,25 +42,47 @@ var (
 	}
 )
 
-var _ executor.Executor = &Executor{}
+var (
+	_ executor.Executor           = &Executor{}
+	_ executor.InteractionHandler = &Executor{}
+)
 
 type (
 	kcRunner interface {
 		RunKubectlCommand(ctx context.Context, kubeConfigPath, defaultNamespace, cmd string) (string, error)
 	}
+	accessChecker interface {
+		Check(ctx context.Context, defaultNamespace, cmd string) (*api.Message, error)
+	}
+	resourceEditor interface {
+		Resolve(resource, namespace, name string) (editTarget, error)
+		Get(ctx context.Context, target editTarget) (*unstructured.Unstructured, error)
+		Update(ctx context.Context, target editTarget, obj *unstructured.Unstructured, dryRun bool) (*unstructured.Unstructured, error)
+	}
 )
 
 // Executor provides functionality for running Helm CLI.
 type Executor struct {
-	pluginVersion string
-	kcRunner      kcRunner
+	pluginVersion    string
+	kcRunner         kcRunner
+	newAccessChecker func(kubeConfigPath string) (accessChecker, error)
+	newEditor        func(kubeConfigPath string) (resourceEditor, error)
+	editDir          string
 }
 
 // NewExecutor returns a new Executor instance.
 func NewExecutor(ver string, kcRunner kcRunner) *Executor {
+	mappers := newRESTMapperCache(restMapperTTL)
 	return &Executor{
 		pluginVersion: ver,
 		kcRunner:      kcRunner,
+		newAccessChecker: func(kubeConfigPath string) (accessChecker, error) {
+			return newAccessCheckerForKubeconfig(kubeConfigPath, mappers)
+		},
+		newEditor: func(kubeConfigPath string) (resourceEditor, error) {
+			return newEditorForKubeconfig(kubeConfigPath, mappers)
+		},
+		editDir: defaultEditDir,
 	}
 }
 
@@ -131,6 +154,18 @@ func (e *Executor) Execute(ctx context.Context, in executor.ExecuteInput) (execu
 		}, nil
 	}
 
+	if cfg.AccessCheck.Enabled {
+		if msg := e.checkAccess(ctx, log, kubeConfigPath, cfg.DefaultNamespace, cmd); msg != nil {
+			return executor.ExecuteOutput{
+				Message: *msg,
+			}, nil
+		}
+	}
+
+	if isEditCommand(cmd) {
+		return e.edit(ctx, kubeConfigPath, cfg, in, cmd)
+	}
+
 	out, err := scopedKubectlRunner.RunKubectlCommand(ctx, cfg.DefaultNamespace, cmd)
 	if err != nil {
 		return executor.ExecuteOutput{}, err
@@ -145,6 +180,23 @@ func (*Executor) Help(context.Context) (api.Message, error) {
 	return api.NewCodeBlockMessage(help(), true), nil
 }
 
+// checkAccess returns a message describing the missing permission if the command is not allowed.
+// Verification errors are only logged, so the command is still run, and kubectl reports errors on its own.
+func (e *Executor) checkAccess(ctx context.Context, log logrus.FieldLogger, kubeConfigPath, defaultNamespace, cmd string) *api.Message {
+	checker, err := e.newAccessChecker(kubeConfigPath)
+	if err != nil {
+		log.WithError(err).Debug("Cannot create access checker, skipping permissions verification.")
+		return nil
+	}
+
+	msg, err := checker.Check(ctx, defaultNamespace, cmd)
+	if err != nil {
+		log.WithError(err).Debug("Cannot verify permissions, skipping.")
+		return nil
+	}
+	return msg
+}
+
 func getBuilderDependencies(log logrus.FieldLogger, kubeconfig string) (*command.CommandGuard, *kubernetes.Clientset, error) {
 	kubeConfig, err := clientcmd.BuildConfigFromFlags("", kubeconfig)
 	if err != nil {
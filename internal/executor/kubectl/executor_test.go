@@ -140,9 +140,9 @@ func TestNotSupportedCommandsAndFlags(t *testing.T) {
 			expErr:       `The "proxy" command is not supported by the Botkube kubectl plugin.`,
 		},
 		{
-			name:         "Not supported edit",
-			givenCommand: "kubectl       edit     pod/foo",
-			expErr:       `The "edit" command is not supported by the Botkube kubectl plugin.`,
+			name:         "Not supported attach",
+			givenCommand: "kubectl       attach     pod/foo",
+			expErr:       `The "attach" command is not supported by the Botkube kubectl plugin.`,
 		},
 		{
 			name:         "Not supported flags",
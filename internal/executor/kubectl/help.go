@@ -37,6 +37,7 @@ func help() string {
 		  exec            Execute a command in a container
 		  auth            Inspect authorization
 
+		  edit            Edit a resource in the message, validate it with a dry run and apply after confirmation
 		  patch           Update fields of a resource
 		  replace         Replace a resource by file name or stdin
 
@@ -0,0 +1,87 @@
+package node
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	requestIDBytes   = 8
+	requestDirPerms  = 0o700
+	requestFilePerms = 0o600
+)
+
+var defaultRequestDir = filepath.Join(os.TempDir(), "botkube-node-drains")
+
+// drainRequest holds a drain awaiting approval. The requester is stored, so the drain can't be approved by the same user.
+type drainRequest struct {
+	Node             string `json:"node"`
+	Force            bool   `json:"force"`
+	RequesterID      string `json:"requesterID"`
+	RequesterMention string `json:"requesterMention"`
+}
+
+// requestStore keeps drains awaiting approval.
+type requestStore struct {
+	dir string
+}
+
+func (s requestStore) path(id string) string {
+	return filepath.Join(s.dir, id+".json")
+}
+
+// save stores a given request and returns its ID.
+func (s requestStore) save(req drainRequest) (string, error) {
+	if err := os.MkdirAll(s.dir, requestDirPerms); err != nil {
+		return "", fmt.Errorf("while creating drain requests directory: %w", err)
+	}
+
+	raw := make([]byte, requestIDBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("while generating drain request ID: %w", err)
+	}
+	id := hex.EncodeToString(raw)
+
+	out, err := json.Marshal(req)
+	if err != nil {
+		return "", fmt.Errorf("while marshaling drain request: %w", err)
+	}
+	if err := os.WriteFile(s.path(id), out, requestFilePerms); err != nil {
+		return "", fmt.Errorf("while storing drain request: %w", err)
+	}
+	return id, nil
+}
+
+// get returns a given request. An error is returned if request doesn't exist.
+func (s requestStore) get(id string) (drainRequest, error) {
+	if id == "" || strings.ContainsAny(id, `/\.`) {
+		return drainRequest{}, fmt.Errorf("invalid drain request ID %q", id)
+	}
+	raw, err := os.ReadFile(s.path(id))
+	if err != nil {
+		return drainRequest{}, notFoundRequestError(id)
+	}
+
+	var out drainRequest
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return drainRequest{}, fmt.Errorf("while unmarshaling drain request: %w", err)
+	}
+	return out, nil
+}
+
+// remove deletes a given request. Only one of concurrent removals succeeds, so a request is approved once.
+func (s requestStore) remove(id string) error {
+	if err := os.Remove(s.path(id)); err != nil {
+		return notFoundRequestError(id)
+	}
+	return nil
+}
+
+func notFoundRequestError(id string) error {
+	return fmt.Errorf("drain request %q not found. It was already approved, or the plugin was restarted. Run the drain again", id)
+}
@@ -0,0 +1,157 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"golang.org/x/exp/slices"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+// mirrorPodAnnotation is set on static Pods, which can't be evicted, as they are managed by kubelet directly.
+const mirrorPodAnnotation = "kubernetes.io/config.mirror"
+
+// DrainPlan describes Pods evicted by draining a given node, and the results of safety checks.
+type DrainPlan struct {
+	Node string
+	// Evicted holds Pods which are evicted by the drain.
+	Evicted []PodRef
+	// Critical holds evicted Pods which are considered critical workloads.
+	Critical []PodRef
+	// Unmanaged holds Pods without a controller, which are not recreated once evicted. They are evicted only when forced.
+	Unmanaged []PodRef
+	// BlockingPDBs holds PodDisruptionBudgets which currently don't allow any disruption of evicted Pods.
+	BlockingPDBs []string
+	// LimitingPDBs holds PodDisruptionBudgets which don't allow evicting all covered Pods at once,
+	// so evictions are retried until the Pods are rescheduled.
+	LimitingPDBs []string
+	// Skipped is the number of Pods left on the node, e.g. DaemonSet and static Pods.
+	Skipped int
+}
+
+// PodRef identifies a Pod.
+type PodRef struct {
+	Namespace string
+	Name      string
+}
+
+func (r PodRef) String() string {
+	return fmt.Sprintf("%s/%s", r.Namespace, r.Name)
+}
+
+// IsBlocked returns true if the node can't be drained safely.
+func (p DrainPlan) IsBlocked(force bool) bool {
+	return len(p.BlockingPDBs) > 0 || (len(p.Unmanaged) > 0 && !force)
+}
+
+// RequiresApproval returns true if draining the node must be approved.
+func (p DrainPlan) RequiresApproval(approval Approval) bool {
+	if len(p.Critical) > 0 {
+		return true
+	}
+	return approval.WorkloadThreshold > 0 && len(p.Evicted) > approval.WorkloadThreshold
+}
+
+// planDrain lists Pods running on a given node and verifies whether they can be evicted safely.
+func planDrain(ctx context.Context, cli kubernetes.Interface, critical CriticalWorkloads, node string) (DrainPlan, error) {
+	if _, err := cli.CoreV1().Nodes().Get(ctx, node, metav1.GetOptions{}); err != nil {
+		return DrainPlan{}, fmt.Errorf("while getting node %q: %w", node, err)
+	}
+
+	pods, err := cli.CoreV1().Pods("").List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("spec.nodeName", node).String(),
+	})
+	if err != nil {
+		return DrainPlan{}, fmt.Errorf("while listing Pods on node %q: %w", node, err)
+	}
+
+	plan := DrainPlan{Node: node}
+	var evicted []corev1.Pod
+	for _, pod := range pods.Items {
+		if pod.Spec.NodeName != node {
+			// field selectors are not supported by all clients
+			continue
+		}
+		if isSkipped(pod) {
+			plan.Skipped++
+			continue
+		}
+
+		ref := PodRef{Namespace: pod.Namespace, Name: pod.Name}
+		evicted = append(evicted, pod)
+		plan.Evicted = append(plan.Evicted, ref)
+		if metav1.GetControllerOf(&pod) == nil {
+			plan.Unmanaged = append(plan.Unmanaged, ref)
+		}
+		if slices.Contains(critical.Namespaces, pod.Namespace) || slices.Contains(critical.PriorityClasses, pod.Spec.PriorityClassName) {
+			plan.Critical = append(plan.Critical, ref)
+		}
+	}
+
+	if err := plan.checkPDBs(ctx, cli, evicted); err != nil {
+		return DrainPlan{}, err
+	}
+	return plan, nil
+}
+
+// checkPDBs verifies whether PodDisruptionBudgets allow evicting given Pods.
+func (p *DrainPlan) checkPDBs(ctx context.Context, cli kubernetes.Interface, pods []corev1.Pod) error {
+	byNamespace := map[string][]corev1.Pod{}
+	for _, pod := range pods {
+		byNamespace[pod.Namespace] = append(byNamespace[pod.Namespace], pod)
+	}
+
+	namespaces := make([]string, 0, len(byNamespace))
+	for ns := range byNamespace {
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+
+	for _, ns := range namespaces {
+		pdbs, err := cli.PolicyV1().PodDisruptionBudgets(ns).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return fmt.Errorf("while listing PodDisruptionBudgets in %q namespace: %w", ns, err)
+		}
+
+		for _, pdb := range pdbs.Items {
+			selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+			if err != nil || selector.Empty() {
+				continue
+			}
+
+			covered := 0
+			for _, pod := range byNamespace[ns] {
+				if selector.Matches(labels.Set(pod.Labels)) {
+					covered++
+				}
+			}
+
+			allowed := int(pdb.Status.DisruptionsAllowed)
+			switch {
+			case covered == 0:
+			case allowed == 0:
+				p.BlockingPDBs = append(p.BlockingPDBs, fmt.Sprintf("%s/%s doesn't allow any disruption of %d Pods on the node", ns, pdb.Name, covered))
+			case covered > allowed:
+				p.LimitingPDBs = append(p.LimitingPDBs, fmt.Sprintf("%s/%s allows %d of %d Pods on the node to be disrupted at once", ns, pdb.Name, allowed, covered))
+			}
+		}
+	}
+	return nil
+}
+
+// isSkipped returns true for Pods which are not evicted during drain, the same as kubectl does.
+func isSkipped(pod corev1.Pod) bool {
+	if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+		return true
+	}
+	if _, found := pod.Annotations[mirrorPodAnnotation]; found {
+		return true
+	}
+	owner := metav1.GetControllerOf(&pod)
+	return owner != nil && owner.Kind == "DaemonSet"
+}
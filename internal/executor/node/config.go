@@ -0,0 +1,86 @@
+package node
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kubeshop/botkube/pkg/api/executor"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/plugin"
+)
+
+// Config holds Node plugin configuration parameters.
+type Config struct {
+	Log config.Logger `yaml:"log"`
+	// CriticalWorkloads describes Pods which require approval before they are evicted.
+	CriticalWorkloads CriticalWorkloads `yaml:"criticalWorkloads"`
+	Approval          Approval          `yaml:"approval"`
+	// EvictionTimeout is the maximum time of evicting all Pods from the drained node.
+	EvictionTimeout time.Duration `yaml:"evictionTimeout"`
+	// EvictionRetryInterval is the time to wait before retrying evictions rejected because of a PodDisruptionBudget.
+	EvictionRetryInterval time.Duration `yaml:"evictionRetryInterval"`
+}
+
+// CriticalWorkloads holds criteria for critical Pods. A Pod is critical if it matches any of them.
+type CriticalWorkloads struct {
+	Namespaces      []string `yaml:"namespaces"`
+	PriorityClasses []string `yaml:"priorityClasses"`
+}
+
+// Approval holds configuration of the drain approval workflow.
+type Approval struct {
+	// WorkloadThreshold is the number of Pods to evict above which the drain must be approved. Zero disables the threshold.
+	WorkloadThreshold int `yaml:"workloadThreshold"`
+	// Approvers holds platform IDs of users allowed to approve drains, e.g. Slack member IDs. Display names are not matched,
+	// as users can change them. Drains can't be approved by the users who requested them.
+	// If empty, drains which require approval are refused.
+	Approvers []string `yaml:"approvers"`
+}
+
+// IsApprover returns true if a given user is allowed to approve drains.
+func (a Approval) IsApprover(user executor.User) bool {
+	if user.ID == "" {
+		return false
+	}
+	for _, approver := range a.Approvers {
+		if approver == user.ID {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate validates the configuration.
+func (c Config) Validate() error {
+	if c.Approval.WorkloadThreshold < 0 {
+		return fmt.Errorf("the approval.workloadThreshold property cannot be negative")
+	}
+	if c.EvictionTimeout <= 0 {
+		return fmt.Errorf("the evictionTimeout property must be positive")
+	}
+	if c.EvictionRetryInterval <= 0 {
+		return fmt.Errorf("the evictionRetryInterval property must be positive")
+	}
+	return nil
+}
+
+// MergeConfigs merges the Node configuration.
+func MergeConfigs(configs []*executor.Config) (Config, error) {
+	defaults := Config{
+		CriticalWorkloads: CriticalWorkloads{
+			PriorityClasses: []string{"system-cluster-critical", "system-node-critical"},
+		},
+		Approval: Approval{
+			WorkloadThreshold: 10,
+		},
+		EvictionTimeout:       5 * time.Minute,
+		EvictionRetryInterval: 5 * time.Second,
+	}
+
+	var out Config
+	if err := plugin.MergeExecutorConfigsWithDefaults(defaults, configs, &out); err != nil {
+		return Config{}, fmt.Errorf("while merging configuration: %w", err)
+	}
+
+	return out, nil
+}
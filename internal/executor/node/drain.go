@@ -0,0 +1,67 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+// EvictionResult describes the result of evicting a single Pod.
+type EvictionResult struct {
+	Pod PodRef
+	// Retries is the number of evictions rejected because of PodDisruptionBudgets.
+	Retries int
+	Err     error
+}
+
+// setUnschedulable cordons or uncordons a given node.
+func setUnschedulable(ctx context.Context, cli kubernetes.Interface, node string, unschedulable bool) error {
+	patch := fmt.Sprintf(`{"spec":{"unschedulable":%t}}`, unschedulable)
+	_, err := cli.CoreV1().Nodes().Patch(ctx, node, types.MergePatchType, []byte(patch), metav1.PatchOptions{})
+	return err
+}
+
+// evictPods evicts given Pods one by one. Evictions rejected because of PodDisruptionBudgets are retried until the context is done,
+// as budgets allow more disruptions once already evicted Pods are running on other nodes.
+func evictPods(ctx context.Context, cli kubernetes.Interface, retryInterval time.Duration, pods []PodRef) []EvictionResult {
+	out := make([]EvictionResult, 0, len(pods))
+	for _, pod := range pods {
+		out = append(out, evictPod(ctx, cli, retryInterval, pod))
+	}
+	return out
+}
+
+func evictPod(ctx context.Context, cli kubernetes.Interface, retryInterval time.Duration, pod PodRef) EvictionResult {
+	result := EvictionResult{Pod: pod}
+	eviction := &policyv1.Eviction{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      pod.Name,
+			Namespace: pod.Namespace,
+		},
+	}
+
+	for {
+		err := cli.CoreV1().Pods(pod.Namespace).EvictV1(ctx, eviction)
+		switch {
+		case err == nil, apierrors.IsNotFound(err):
+			return result
+		case !apierrors.IsTooManyRequests(err):
+			result.Err = err
+			return result
+		}
+
+		result.Retries++
+		select {
+		case <-ctx.Done():
+			result.Err = fmt.Errorf("eviction timed out, the last attempt was rejected: %w", err)
+			return result
+		case <-time.After(retryInterval):
+		}
+	}
+}
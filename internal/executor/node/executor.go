@@ -0,0 +1,267 @@
+package node
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/alexflint/go-arg"
+	"github.com/sirupsen/logrus"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/api/executor"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+	"github.com/kubeshop/botkube/pkg/plugin"
+)
+
+const (
+	// PluginName is the name of the Node Botkube plugin.
+	PluginName  = "node"
+	description = "Cordon, drain, and uncordon Kubernetes nodes with safety checks."
+)
+
+//go:embed config_schema.json
+var configJSONSchema string
+
+var _ executor.Executor = &Executor{}
+
+// Command defines the supported Node plugin commands.
+type Command struct {
+	Cordon   *NodeCommand    `arg:"subcommand:cordon"`
+	Uncordon *NodeCommand    `arg:"subcommand:uncordon"`
+	Check    *NodeCommand    `arg:"subcommand:check"`
+	Drain    *DrainCommand   `arg:"subcommand:drain"`
+	Approve  *ApproveCommand `arg:"subcommand:approve"`
+}
+
+// NodeCommand holds arguments of commands which target a single node.
+type NodeCommand struct {
+	Name string `arg:"positional,required"`
+}
+
+// DrainCommand holds the drain command arguments.
+type DrainCommand struct {
+	Name string `arg:"positional,required"`
+	// Force evicts also Pods without a controller, which are not recreated.
+	Force bool `arg:"--force"`
+}
+
+// ApproveCommand holds the approve command arguments.
+type ApproveCommand struct {
+	RequestID string `arg:"positional,required"`
+}
+
+// Executor provides functionality for managing Kubernetes nodes lifecycle.
+type Executor struct {
+	pluginVersion string
+	newK8sClient  func(kubeConfig []byte) (kubernetes.Interface, error)
+	requestDir    string
+}
+
+// NewExecutor returns a new Executor instance.
+func NewExecutor(ver string) *Executor {
+	return &Executor{
+		pluginVersion: ver,
+		newK8sClient:  newK8sClient,
+		requestDir:    defaultRequestDir,
+	}
+}
+
+// Metadata returns details about Node plugin.
+func (e *Executor) Metadata(context.Context) (api.MetadataOutput, error) {
+	return api.MetadataOutput{
+		Version:          e.pluginVersion,
+		Description:      description,
+		DocumentationURL: "https://docs.botkube.io/configuration/executor/node",
+		JSONSchema: api.JSONSchema{
+			Value: configJSONSchema,
+		},
+	}, nil
+}
+
+// Execute returns a given command as response.
+func (e *Executor) Execute(ctx context.Context, in executor.ExecuteInput) (executor.ExecuteOutput, error) {
+	if err := plugin.ValidateKubeConfigProvided(PluginName, in.Context.KubeConfig); err != nil {
+		return executor.ExecuteOutput{}, err
+	}
+
+	cfg, err := MergeConfigs(in.Configs)
+	if err != nil {
+		return executor.ExecuteOutput{}, fmt.Errorf("while merging input configs: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return executor.ExecuteOutput{}, fmt.Errorf("while validating configuration: %w", err)
+	}
+
+	log := loggerx.New(cfg.Log)
+
+	var cmd Command
+	err = plugin.ParseCommand(PluginName, in.Command, &cmd)
+	switch {
+	case err == nil:
+	case errors.Is(err, arg.ErrHelp):
+		return executor.ExecuteOutput{Message: help()}, nil
+	default:
+		return executor.ExecuteOutput{}, fmt.Errorf("while parsing input command: %w", err)
+	}
+
+	if cmd.Cordon == nil && cmd.Uncordon == nil && cmd.Check == nil && cmd.Drain == nil && cmd.Approve == nil {
+		return executor.ExecuteOutput{Message: help()}, nil
+	}
+
+	cli, err := e.newK8sClient(in.Context.KubeConfig)
+	if err != nil {
+		return executor.ExecuteOutput{}, err
+	}
+
+	user := in.Context.Message.User
+	switch {
+	case cmd.Cordon != nil:
+		return e.setUnschedulable(ctx, log, cli, user, cmd.Cordon.Name, true)
+	case cmd.Uncordon != nil:
+		return e.setUnschedulable(ctx, log, cli, user, cmd.Uncordon.Name, false)
+	case cmd.Check != nil:
+		plan, err := planDrain(ctx, cli, cfg.CriticalWorkloads, cmd.Check.Name)
+		if err != nil {
+			return executor.ExecuteOutput{}, err
+		}
+		return executor.ExecuteOutput{Message: checkMessage(cfg, plan, in.Context.IsInteractivitySupported)}, nil
+	case cmd.Drain != nil:
+		return e.drain(ctx, log, cfg, cli, in, *cmd.Drain)
+	default:
+		return e.approve(ctx, log, cfg, cli, user, *cmd.Approve)
+	}
+}
+
+// Help returns help message.
+func (*Executor) Help(context.Context) (api.Message, error) {
+	return help(), nil
+}
+
+func (e *Executor) setUnschedulable(ctx context.Context, log logrus.FieldLogger, cli kubernetes.Interface, user executor.User, node string, unschedulable bool) (executor.ExecuteOutput, error) {
+	action := "uncordoned"
+	if unschedulable {
+		action = "cordoned"
+	}
+
+	log.WithFields(logrus.Fields{
+		"node":          node,
+		"unschedulable": unschedulable,
+		"user":          user.DisplayName,
+	}).Info("Updating node...")
+	if err := setUnschedulable(ctx, cli, node, unschedulable); err != nil {
+		return executor.ExecuteOutput{}, fmt.Errorf("while updating node %q: %w", node, err)
+	}
+
+	return executor.ExecuteOutput{
+		Message: api.NewPlaintextMessage(fmt.Sprintf("Node %q %s by %s.", node, action, user.Mention), false),
+	}, nil
+}
+
+// drain cordons a given node and evicts its Pods, once safety checks pass. Draining nodes with critical workloads,
+// or more Pods than the configured threshold, must be approved by one of the approvers first.
+func (e *Executor) drain(ctx context.Context, log logrus.FieldLogger, cfg Config, cli kubernetes.Interface, in executor.ExecuteInput, cmd DrainCommand) (executor.ExecuteOutput, error) {
+	plan, err := planDrain(ctx, cli, cfg.CriticalWorkloads, cmd.Name)
+	if err != nil {
+		return executor.ExecuteOutput{}, err
+	}
+	if plan.IsBlocked(cmd.Force) {
+		return executor.ExecuteOutput{Message: blockedMessage(plan, cmd.Force)}, nil
+	}
+
+	user := in.Context.Message.User
+	if plan.RequiresApproval(cfg.Approval) {
+		if len(cfg.Approval.Approvers) == 0 {
+			return executor.ExecuteOutput{Message: noApproversMessage(cfg, plan)}, nil
+		}
+
+		store := requestStore{dir: e.requestDir}
+		id, err := store.save(drainRequest{
+			Node:             cmd.Name,
+			Force:            cmd.Force,
+			RequesterID:      user.ID,
+			RequesterMention: user.Mention,
+		})
+		if err != nil {
+			return executor.ExecuteOutput{}, err
+		}
+		return executor.ExecuteOutput{Message: approvalMessage(cfg, plan, id, user, in.Context.IsInteractivitySupported)}, nil
+	}
+
+	return e.evict(ctx, log, cfg, cli, plan, cmd.Force, user)
+}
+
+// approve drains a node for a given drain request. The request can't be approved by the user who created it.
+func (e *Executor) approve(ctx context.Context, log logrus.FieldLogger, cfg Config, cli kubernetes.Interface, user executor.User, cmd ApproveCommand) (executor.ExecuteOutput, error) {
+	store := requestStore{dir: e.requestDir}
+	req, err := store.get(cmd.RequestID)
+	if err != nil {
+		return executor.ExecuteOutput{}, err
+	}
+
+	if !cfg.Approval.IsApprover(user) {
+		return executor.ExecuteOutput{}, fmt.Errorf("User %q is not allowed to approve drains. Ask one of the approvers: %s.", user.DisplayName, strings.Join(cfg.Approval.Approvers, ", "))
+	}
+	if req.RequesterID == user.ID {
+		return executor.ExecuteOutput{}, fmt.Errorf("Drain of node %q must be approved by a different user than the one who requested it.", req.Node)
+	}
+	if err := store.remove(cmd.RequestID); err != nil {
+		return executor.ExecuteOutput{}, err
+	}
+
+	// Pods could be rescheduled since the drain was requested
+	plan, err := planDrain(ctx, cli, cfg.CriticalWorkloads, req.Node)
+	if err != nil {
+		return executor.ExecuteOutput{}, err
+	}
+	if plan.IsBlocked(req.Force) {
+		return executor.ExecuteOutput{Message: blockedMessage(plan, req.Force)}, nil
+	}
+
+	log.WithFields(logrus.Fields{
+		"node":      req.Node,
+		"requester": req.RequesterMention,
+		"approver":  user.Mention,
+	}).Info("Drain approved")
+	return e.evict(ctx, log, cfg, cli, plan, req.Force, user)
+}
+
+// evict cordons a node and evicts Pods from a given plan. Results are reported once all evictions are done,
+// or the eviction timeout elapses.
+func (e *Executor) evict(ctx context.Context, log logrus.FieldLogger, cfg Config, cli kubernetes.Interface, plan DrainPlan, force bool, user executor.User) (executor.ExecuteOutput, error) {
+	log.WithFields(logrus.Fields{
+		"node":  plan.Node,
+		"pods":  len(plan.Evicted),
+		"force": force,
+		"user":  user.DisplayName,
+	}).Info("Draining node...")
+	if err := setUnschedulable(ctx, cli, plan.Node, true); err != nil {
+		return executor.ExecuteOutput{}, fmt.Errorf("while cordoning node %q: %w", plan.Node, err)
+	}
+
+	evictCtx, cancel := context.WithTimeout(ctx, cfg.EvictionTimeout)
+	defer cancel()
+	results := evictPods(evictCtx, cli, cfg.EvictionRetryInterval, plan.Evicted)
+
+	return executor.ExecuteOutput{
+		Message:  drainedMessage(plan, user),
+		Messages: evictionReportMessages(plan, results),
+	}, nil
+}
+
+func newK8sClient(kubeConfig []byte) (kubernetes.Interface, error) {
+	restCfg, err := clientcmd.RESTConfigFromKubeConfig(kubeConfig)
+	if err != nil {
+		return nil, fmt.Errorf("while reading kube config: %w", err)
+	}
+	cli, err := kubernetes.NewForConfig(restCfg)
+	if err != nil {
+		return nil, fmt.Errorf("while creating typed k8s client: %w", err)
+	}
+	return cli, nil
+}
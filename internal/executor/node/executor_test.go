@@ -0,0 +1,352 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/api/executor"
+)
+
+func TestCordonAndUncordon(t *testing.T) {
+	// given
+	cli := fake.NewSimpleClientset(fixNode("worker-1"))
+	exec := fixExecutor(cli)
+
+	// when
+	out, err := exec.Execute(context.Background(), fixExecuteInput("node cordon worker-1", ""))
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, api.NewPlaintextMessage(`Node "worker-1" cordoned by <@U123>.`, false), out.Message)
+	assert.True(t, getNode(t, cli, "worker-1").Spec.Unschedulable)
+
+	// when
+	out, err = exec.Execute(context.Background(), fixExecuteInput("node uncordon worker-1", ""))
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, api.NewPlaintextMessage(`Node "worker-1" uncordoned by <@U123>.`, false), out.Message)
+	assert.False(t, getNode(t, cli, "worker-1").Spec.Unschedulable)
+}
+
+func TestPlanDrain(t *testing.T) {
+	// given
+	mirror := fixPod("kube-system", "etcd-worker-1", "worker-1", "")
+	mirror.Annotations = map[string]string{mirrorPodAnnotation: "abc"}
+	completed := fixPod("prod", "migration", "worker-1", "Job")
+	completed.Status.Phase = corev1.PodSucceeded
+	dns := fixPod("kube-system", "coredns", "worker-1", "ReplicaSet")
+	dns.Spec.PriorityClassName = "system-cluster-critical"
+
+	cli := fake.NewSimpleClientset(
+		fixNode("worker-1"),
+		fixPod("prod", "api-1", "worker-1", "ReplicaSet"),
+		fixPod("prod", "api-2", "worker-1", "ReplicaSet"),
+		fixPod("prod", "api-3", "worker-2", "ReplicaSet"),
+		fixPod("prod", "debug", "worker-1", ""),
+		fixPod("monitoring", "node-exporter", "worker-1", "DaemonSet"),
+		fixPod("db", "postgres-0", "worker-1", "StatefulSet"),
+		mirror, completed, dns,
+		fixPDB("prod", "api", 1),
+		fixPDB("db", "postgres", 0),
+	)
+
+	// when
+	plan, err := planDrain(context.Background(), cli, CriticalWorkloads{PriorityClasses: []string{"system-cluster-critical"}}, "worker-1")
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, DrainPlan{
+		Node: "worker-1",
+		Evicted: []PodRef{
+			{Namespace: "db", Name: "postgres-0"},
+			{Namespace: "kube-system", Name: "coredns"},
+			{Namespace: "prod", Name: "api-1"},
+			{Namespace: "prod", Name: "api-2"},
+			{Namespace: "prod", Name: "debug"},
+		},
+		Critical:     []PodRef{{Namespace: "kube-system", Name: "coredns"}},
+		Unmanaged:    []PodRef{{Namespace: "prod", Name: "debug"}},
+		BlockingPDBs: []string{"db/postgres doesn't allow any disruption of 1 Pods on the node"},
+		LimitingPDBs: []string{"prod/api allows 1 of 2 Pods on the node to be disrupted at once"},
+		Skipped:      3,
+	}, plan)
+	assert.True(t, plan.IsBlocked(true))
+	assert.True(t, plan.RequiresApproval(Approval{}))
+}
+
+func TestDrain(t *testing.T) {
+	// given
+	cli := fake.NewSimpleClientset(
+		fixNode("worker-1"),
+		fixPod("prod", "api-1", "worker-1", "ReplicaSet"),
+		fixPod("prod", "api-2", "worker-1", "ReplicaSet"),
+		fixPod("prod", "worker", "worker-1", "ReplicaSet"),
+	)
+	evictions := fixEvictions(cli, map[string]error{
+		"worker": apierrors.NewForbidden(corev1.Resource("pods"), "worker", fmt.Errorf("denied")),
+	}, 2)
+	exec := fixExecutor(cli)
+
+	// when
+	out, err := exec.Execute(context.Background(), fixExecuteInput("node drain worker-1", ""))
+
+	// then
+	require.NoError(t, err)
+	assert.True(t, getNode(t, cli, "worker-1").Spec.Unschedulable)
+	assert.Equal(t, []string{"api-1", "api-1", "api-1", "api-2", "worker"}, *evictions)
+	assert.Equal(t, api.NewPlaintextMessage(`Node "worker-1" cordoned by <@U123>, and evictions of 3 Pods finished.`, false), out.Message)
+	require.Len(t, out.Messages, 2)
+	assert.Equal(t, api.BulletLists{
+		{
+			Title: "Eviction results (3/3)",
+			Items: []string{
+				"prod/api-1 evicted after 2 retries",
+				"prod/api-2 evicted",
+				`prod/worker failed: pods "worker" is forbidden: denied`,
+			},
+		},
+	}, out.Messages[0].Sections[0].BulletLists)
+	assert.Equal(t, api.NewPlaintextMessage("Node \"worker-1\" partially drained: 2 of 3 Pods evicted, 1 failed. The node stays cordoned, run the drain again, or uncordon it with `node uncordon worker-1`.", false), out.Messages[1])
+}
+
+func TestDrainBlocked(t *testing.T) {
+	// given
+	cli := fake.NewSimpleClientset(
+		fixNode("worker-1"),
+		fixPod("prod", "debug", "worker-1", ""),
+	)
+	exec := fixExecutor(cli)
+
+	// when
+	out, err := exec.Execute(context.Background(), fixExecuteInput("node drain worker-1", ""))
+
+	// then
+	require.NoError(t, err)
+	assert.False(t, getNode(t, cli, "worker-1").Spec.Unschedulable)
+	section := out.Message.Sections[0]
+	assert.Equal(t, `Drain of node "worker-1" is blocked. Resolve the issues below, and run the drain again.`, section.Description)
+	assert.Equal(t, "Pods without a controller", section.BulletLists[0].Title)
+	assert.Equal(t, "To evict Pods without a controller, which are not recreated, run the drain with the --force flag.", section.Context[1].Text)
+
+	// when
+	out, err = exec.Execute(context.Background(), fixExecuteInput("node drain worker-1 --force", ""))
+
+	// then
+	require.NoError(t, err)
+	assert.True(t, getNode(t, cli, "worker-1").Spec.Unschedulable)
+	assert.Equal(t, api.NewPlaintextMessage(`Node "worker-1" drained: all 1 Pods evicted.`, false), out.Messages[len(out.Messages)-1])
+}
+
+func TestDrainApproval(t *testing.T) {
+	// given
+	cli := fake.NewSimpleClientset(
+		fixNode("worker-1"),
+		fixPod("prod", "api-1", "worker-1", "ReplicaSet"),
+		fixPod("prod", "api-2", "worker-1", "ReplicaSet"),
+	)
+	fixEvictions(cli, nil, 0)
+	exec := fixExecutor(cli)
+	exec.requestDir = t.TempDir()
+	cfg := heredoc.Doc(`
+		approval:
+		  workloadThreshold: 1
+		  approvers: ["U123", "UALICE"]`)
+
+	// when
+	out, err := exec.Execute(context.Background(), fixExecuteInput("node drain worker-1", cfg))
+
+	// then
+	require.NoError(t, err)
+	assert.False(t, getNode(t, cli, "worker-1").Spec.Unschedulable)
+	section := out.Message.Sections[0]
+	assert.Equal(t, `Drain of node "worker-1" requires approval`, section.Header)
+	assert.Equal(t, "<@U123> requested draining the node, but it runs 2 Pods, above the threshold of 1. One of the approvers, other than the requester, must approve it: U123, UALICE.", section.Description)
+	require.Len(t, section.Buttons, 1)
+	approveCmd := strings.TrimPrefix(section.Buttons[0].Command, api.MessageBotNamePlaceholder+" ")
+	assert.True(t, strings.HasPrefix(approveCmd, "node approve "))
+
+	// when requester approves
+	_, err = exec.Execute(context.Background(), fixExecuteInput(approveCmd, cfg))
+
+	// then
+	assert.EqualError(t, err, `Drain of node "worker-1" must be approved by a different user than the one who requested it.`)
+	assert.False(t, getNode(t, cli, "worker-1").Spec.Unschedulable)
+
+	// when non-approver with the approver display name approves
+	in := fixExecuteInput(approveCmd, cfg)
+	in.Context.Message.User = executor.User{Mention: "<@UMALLORY>", DisplayName: "Alice", ID: "UMALLORY"}
+	_, err = exec.Execute(context.Background(), in)
+
+	// then
+	assert.EqualError(t, err, `User "Alice" is not allowed to approve drains. Ask one of the approvers: U123, UALICE.`)
+	assert.False(t, getNode(t, cli, "worker-1").Spec.Unschedulable)
+
+	// when approver approves
+	in.Context.Message.User = executor.User{Mention: "<@UALICE>", DisplayName: "Alice", ID: "UALICE"}
+	out, err = exec.Execute(context.Background(), in)
+
+	// then
+	require.NoError(t, err)
+	assert.True(t, getNode(t, cli, "worker-1").Spec.Unschedulable)
+	assert.Equal(t, api.NewPlaintextMessage(`Node "worker-1" drained: all 2 Pods evicted.`, false), out.Messages[len(out.Messages)-1])
+
+	// when approved again
+	_, err = exec.Execute(context.Background(), in)
+
+	// then
+	assert.ErrorContains(t, err, "not found")
+}
+
+func TestDrainApprovalWithoutApprovers(t *testing.T) {
+	// given
+	cli := fake.NewSimpleClientset(
+		fixNode("worker-1"),
+		fixPod("prod", "api-1", "worker-1", "ReplicaSet"),
+		fixPod("prod", "api-2", "worker-1", "ReplicaSet"),
+	)
+	exec := fixExecutor(cli)
+	exec.requestDir = t.TempDir()
+
+	// when
+	out, err := exec.Execute(context.Background(), fixExecuteInput("node drain worker-1", "approval: {workloadThreshold: 1}"))
+
+	// then
+	require.NoError(t, err)
+	assert.False(t, getNode(t, cli, "worker-1").Spec.Unschedulable)
+	section := out.Message.Sections[0]
+	assert.Equal(t, "The drain must be approved, as it runs 2 Pods, above the threshold of 1, but no approvers are configured. Add platform IDs of users allowed to approve drains under the `approval.approvers` property.", section.Description)
+	assert.Empty(t, section.Buttons)
+}
+
+func TestEvictionReportMessagesLimit(t *testing.T) {
+	// given
+	results := make([]EvictionResult, 95)
+	for i := range results {
+		results[i] = EvictionResult{Pod: PodRef{Namespace: "prod", Name: fmt.Sprintf("api-%d", i)}}
+	}
+
+	// when
+	msgs := evictionReportMessages(DrainPlan{Node: "worker-1"}, results)
+
+	// then
+	require.Len(t, msgs, maxReportMessages+1)
+	assert.Equal(t, "Eviction results (10/95)", msgs[0].Sections[0].BulletLists[0].Title)
+	assert.Equal(t, "Eviction results (95/95)", msgs[maxReportMessages-1].Sections[0].BulletLists[0].Title)
+}
+
+// fixEvictions deletes evicted Pods, and records eviction attempts. Evictions of the first Pod are rejected a given number of times,
+// as if a PodDisruptionBudget didn't allow them.
+func fixEvictions(cli *fake.Clientset, errs map[string]error, rejections int) *[]string {
+	var attempts []string
+	cli.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "eviction" {
+			return false, nil, nil
+		}
+		eviction := action.(k8stesting.CreateAction).GetObject().(*policyv1.Eviction)
+		attempts = append(attempts, eviction.Name)
+
+		if len(attempts) <= rejections {
+			return true, nil, apierrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 0)
+		}
+		if err := errs[eviction.Name]; err != nil {
+			return true, nil, err
+		}
+		return true, nil, cli.Tracker().Delete(corev1.SchemeGroupVersion.WithResource("pods"), eviction.Namespace, eviction.Name)
+	})
+	return &attempts
+}
+
+func fixExecutor(cli kubernetes.Interface) *Executor {
+	exec := NewExecutor("dev")
+	exec.newK8sClient = func([]byte) (kubernetes.Interface, error) {
+		return cli, nil
+	}
+	return exec
+}
+
+func fixExecuteInput(cmd, cfg string) executor.ExecuteInput {
+	return executor.ExecuteInput{
+		Command: cmd,
+		Configs: []*executor.Config{
+			{RawYAML: []byte("evictionRetryInterval: 1ms")},
+			{RawYAML: []byte(cfg)},
+		},
+		Context: executor.ExecuteInputContext{
+			KubeConfig:               []byte("not empty"),
+			IsInteractivitySupported: true,
+			Message: executor.Message{
+				User: executor.User{Mention: "<@U123>", DisplayName: "Jane", ID: "U123"},
+			},
+		},
+	}
+}
+
+func getNode(t *testing.T, cli kubernetes.Interface, name string) *corev1.Node {
+	t.Helper()
+	node, err := cli.CoreV1().Nodes().Get(context.Background(), name, metav1.GetOptions{})
+	require.NoError(t, err)
+	return node
+}
+
+func fixNode(name string) *corev1.Node {
+	return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}}
+}
+
+func fixPod(namespace, name, node, ownerKind string) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    map[string]string{"app": name[:3]},
+		},
+		Spec: corev1.PodSpec{
+			NodeName: node,
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+		},
+	}
+	if ownerKind != "" {
+		controller := true
+		pod.OwnerReferences = []metav1.OwnerReference{
+			{Kind: ownerKind, Name: name + "-owner", Controller: &controller},
+		}
+	}
+	return pod
+}
+
+func fixPDB(namespace, app string, allowed int32) *policyv1.PodDisruptionBudget {
+	maxUnavailable := intstr.FromInt(1)
+	return &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      app,
+			Namespace: namespace,
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MaxUnavailable: &maxUnavailable,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": app[:3]},
+			},
+		},
+		Status: policyv1.PodDisruptionBudgetStatus{
+			DisruptionsAllowed: allowed,
+		},
+	}
+}
@@ -0,0 +1,37 @@
+package node
+
+import (
+	"github.com/MakeNowJust/heredoc"
+
+	"github.com/kubeshop/botkube/pkg/api"
+)
+
+func help() api.Message {
+	return api.Message{
+		Sections: []api.Section{
+			{
+				Base: api.Base{
+					Header:      "Manage Kubernetes nodes from chat",
+					Description: description,
+				},
+			},
+			{
+				Base: api.Base{
+					Body: api.Body{
+						CodeBlock: heredoc.Doc(`
+							Usage:
+							  node cordon <name>
+							  node uncordon <name>
+							  node check <name>
+							  node drain <name> [--force]
+							  node approve <request-id>
+
+							Before draining, PodDisruptionBudgets and Pods without a controller are checked. Use --force to evict such Pods.
+							Draining nodes with critical workloads, or with more Pods than the configured threshold, requires approval
+							of one of the configured approvers, other than the requester.`),
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,183 @@
+package node
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/api/executor"
+)
+
+const (
+	// maxListedPods limits the number of listed Pods to keep the message readable.
+	maxListedPods = 15
+	// maxReportMessages limits the number of eviction report messages, as only 15 messages can be returned for a single command.
+	maxReportMessages = 10
+	// minPodsPerReportMessage is the number of evictions reported in a single message for nodes with a few Pods.
+	minPodsPerReportMessage = 5
+)
+
+func checkMessage(cfg Config, plan DrainPlan, interactive bool) api.Message {
+	section := planSection(plan)
+	switch {
+	case plan.IsBlocked(false):
+		section.Description = "Drain is blocked. Resolve the issues below, and check the node again."
+	case plan.RequiresApproval(cfg.Approval) && len(cfg.Approval.Approvers) == 0:
+		section.Description = "Drain requires approval, but no approvers are configured."
+	case plan.RequiresApproval(cfg.Approval):
+		section.Description = "Node can be drained after approval."
+	default:
+		section.Description = "Node can be drained."
+	}
+
+	drainCmd := fmt.Sprintf("%s drain %s", PluginName, plan.Node)
+	if !plan.IsBlocked(false) && interactive {
+		section.Buttons = api.Buttons{
+			api.NewMessageButtonBuilder().ForCommandWithoutDesc("Drain", drainCmd, api.ButtonStyleDanger),
+		}
+	}
+	return api.Message{Sections: []api.Section{section}}
+}
+
+func blockedMessage(plan DrainPlan, force bool) api.Message {
+	section := planSection(plan)
+	section.Description = fmt.Sprintf("Drain of node %q is blocked. Resolve the issues below, and run the drain again.", plan.Node)
+	if len(plan.BlockingPDBs) == 0 && !force {
+		section.Context = append(section.Context, api.ContextItem{
+			Text: "To evict Pods without a controller, which are not recreated, run the drain with the --force flag.",
+		})
+	}
+	return api.Message{Sections: []api.Section{section}}
+}
+
+func approvalMessage(cfg Config, plan DrainPlan, requestID string, requester executor.User, interactive bool) api.Message {
+	section := planSection(plan)
+	section.Header = fmt.Sprintf("Drain of node %q requires approval", plan.Node)
+	section.Description = fmt.Sprintf("%s requested draining the node, but %s. One of the approvers, other than the requester, must approve it: %s.",
+		requester.Mention, approvalReasons(cfg, plan), strings.Join(cfg.Approval.Approvers, ", "))
+
+	approveCmd := fmt.Sprintf("%s approve %s", PluginName, requestID)
+	if interactive {
+		section.Buttons = api.Buttons{
+			api.NewMessageButtonBuilder().ForCommand("Approve and drain", approveCmd, approveCmd, api.ButtonStyleDanger),
+		}
+	} else {
+		section.Context = append(section.Context, api.ContextItem{
+			Text: fmt.Sprintf("To approve, run: %s %s", api.MessageBotNamePlaceholder, approveCmd),
+		})
+	}
+	return api.Message{Sections: []api.Section{section}}
+}
+
+func noApproversMessage(cfg Config, plan DrainPlan) api.Message {
+	section := planSection(plan)
+	section.Header = fmt.Sprintf("Drain of node %q requires approval", plan.Node)
+	section.Description = fmt.Sprintf("The drain must be approved, as %s, but no approvers are configured. Add platform IDs of users allowed to approve drains under the `approval.approvers` property.",
+		approvalReasons(cfg, plan))
+	return api.Message{Sections: []api.Section{section}}
+}
+
+func approvalReasons(cfg Config, plan DrainPlan) string {
+	var reasons []string
+	if len(plan.Critical) > 0 {
+		reasons = append(reasons, fmt.Sprintf("it runs %d critical workloads", len(plan.Critical)))
+	}
+	if threshold := cfg.Approval.WorkloadThreshold; threshold > 0 && len(plan.Evicted) > threshold {
+		reasons = append(reasons, fmt.Sprintf("it runs %d Pods, above the threshold of %d", len(plan.Evicted), threshold))
+	}
+	return strings.Join(reasons, " and ")
+}
+
+// planSection describes Pods evicted by the drain, and the results of safety checks.
+func planSection(plan DrainPlan) api.Section {
+	section := api.Section{
+		Base: api.Base{
+			Header: fmt.Sprintf("Drain check for node %q", plan.Node),
+		},
+		Context: api.ContextItems{
+			{Text: fmt.Sprintf("%d Pods are evicted, and %d DaemonSet, static, or completed Pods are left on the node.", len(plan.Evicted), plan.Skipped)},
+		},
+	}
+
+	lists := []api.BulletList{
+		{Title: "Blocking PodDisruptionBudgets", Items: plan.BlockingPDBs},
+		{Title: "Pods without a controller", Items: podItems(plan.Unmanaged)},
+		{Title: "Critical workloads", Items: podItems(plan.Critical)},
+		{Title: "PodDisruptionBudgets limiting evictions", Items: plan.LimitingPDBs},
+		{Title: "Evicted Pods", Items: podItems(plan.Evicted)},
+	}
+	for _, list := range lists {
+		if len(list.Items) > 0 {
+			section.BulletLists = append(section.BulletLists, list)
+		}
+	}
+	return section
+}
+
+func drainedMessage(plan DrainPlan, user executor.User) api.Message {
+	return api.NewPlaintextMessage(fmt.Sprintf("Node %q cordoned by %s, and evictions of %d Pods finished.", plan.Node, user.Mention, len(plan.Evicted)), false)
+}
+
+// evictionReportMessages returns messages describing eviction results, followed by the drain summary.
+// Evictions are grouped, so the number of messages is limited regardless of the number of Pods.
+func evictionReportMessages(plan DrainPlan, results []EvictionResult) []api.Message {
+	size := (len(results) + maxReportMessages - 1) / maxReportMessages
+	if size < minPodsPerReportMessage {
+		size = minPodsPerReportMessage
+	}
+
+	var (
+		out    []api.Message
+		failed int
+	)
+	for start := 0; start < len(results); start += size {
+		end := start + size
+		if end > len(results) {
+			end = len(results)
+		}
+
+		var items []string
+		for _, result := range results[start:end] {
+			switch {
+			case result.Err != nil:
+				failed++
+				items = append(items, fmt.Sprintf("%s failed: %s", result.Pod, result.Err))
+			case result.Retries > 0:
+				items = append(items, fmt.Sprintf("%s evicted after %d retries", result.Pod, result.Retries))
+			default:
+				items = append(items, fmt.Sprintf("%s evicted", result.Pod))
+			}
+		}
+		out = append(out, api.Message{
+			Sections: []api.Section{
+				{
+					BulletLists: api.BulletLists{
+						{
+							Title: fmt.Sprintf("Eviction results (%d/%d)", end, len(results)),
+							Items: items,
+						},
+					},
+				},
+			},
+		})
+	}
+
+	summary := fmt.Sprintf("Node %q drained: all %d Pods evicted.", plan.Node, len(results))
+	if failed > 0 {
+		summary = fmt.Sprintf("Node %q partially drained: %d of %d Pods evicted, %d failed. The node stays cordoned, run the drain again, or uncordon it with `%s uncordon %s`.",
+			plan.Node, len(results)-failed, len(results), failed, PluginName, plan.Node)
+	}
+	return append(out, api.NewPlaintextMessage(summary, false))
+}
+
+func podItems(pods []PodRef) []string {
+	items := make([]string, 0, len(pods))
+	for idx, pod := range pods {
+		if idx == maxListedPods {
+			items = append(items, fmt.Sprintf("... and %d more", len(pods)-maxListedPods))
+			break
+		}
+		items = append(items, pod.String())
+	}
+	return items
+}
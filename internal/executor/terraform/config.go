@@ -0,0 +1,109 @@
+package terraform
+
+import (
+	"fmt"
+
+	"github.com/kubeshop/botkube/pkg/api/executor"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/plugin"
+)
+
+// Binary defines the CLI flavor used to run plans.
+type Binary string
+
+const (
+	// TerraformBinary uses the HashiCorp Terraform CLI.
+	TerraformBinary Binary = "terraform"
+	// OpenTofuBinary uses the OpenTofu CLI.
+	OpenTofuBinary Binary = "tofu"
+)
+
+// Config holds Terraform plugin configuration parameters.
+type Config struct {
+	Log        config.Logger        `yaml:"log"`
+	Binary     Binary               `yaml:"binary"`
+	PlanDir    string               `yaml:"planDir"`
+	Workspaces map[string]Workspace `yaml:"workspaces"`
+	Apply      Apply                `yaml:"apply"`
+}
+
+// Workspace describes a single Terraform root module that can be planned from chat.
+type Workspace struct {
+	// Dir is a path to the root module, e.g. a mounted volume or a git-sync checkout.
+	Dir string `yaml:"dir"`
+	// Name is the Terraform workspace selected via TF_WORKSPACE. If empty, the default one is used.
+	Name string `yaml:"name,omitempty"`
+	// Env holds additional environment variables passed to the CLI.
+	Env map[string]string `yaml:"env,omitempty"`
+	// SecretRefs holds references to Kubernetes Secrets which data keys are exported as environment variables.
+	// Use it to provide remote backend and provider credentials.
+	SecretRefs []SecretRef `yaml:"secretRefs,omitempty"`
+}
+
+// SecretRef holds a reference to a Kubernetes Secret.
+type SecretRef struct {
+	Name      string `yaml:"name"`
+	Namespace string `yaml:"namespace"`
+}
+
+// Apply holds configuration for the apply approval workflow.
+type Apply struct {
+	// Enabled allows running apply for previously generated plans.
+	Enabled bool `yaml:"enabled"`
+	// Approvers holds platform IDs of users allowed to approve the apply, e.g. Slack member IDs.
+	// Display names are not matched, as users can change them. A plan can't be approved by the user who requested it.
+	Approvers []string `yaml:"approvers"`
+}
+
+// IsApprover returns true if a given user is allowed to approve the apply.
+func (a Apply) IsApprover(user executor.User) bool {
+	if user.ID == "" {
+		return false
+	}
+	for _, approver := range a.Approvers {
+		if approver == user.ID {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate validates the configuration.
+func (c Config) Validate() error {
+	switch c.Binary {
+	case TerraformBinary, OpenTofuBinary:
+	default:
+		return fmt.Errorf("unsupported binary %q, allowed values are %q and %q", c.Binary, TerraformBinary, OpenTofuBinary)
+	}
+
+	for name, ws := range c.Workspaces {
+		if ws.Dir == "" {
+			return fmt.Errorf("the dir property for %q workspace cannot be empty", name)
+		}
+		for _, ref := range ws.SecretRefs {
+			if ref.Name == "" || ref.Namespace == "" {
+				return fmt.Errorf("the secretRefs for %q workspace must have both name and namespace specified", name)
+			}
+		}
+	}
+
+	if c.Apply.Enabled && len(c.Apply.Approvers) == 0 {
+		return fmt.Errorf("at least one approver must be specified when apply is enabled")
+	}
+	return nil
+}
+
+// MergeConfigs merges the Terraform configuration.
+func MergeConfigs(configs []*executor.Config) (Config, error) {
+	defaults := Config{
+		Binary:  TerraformBinary,
+		PlanDir: defaultPlanDir,
+	}
+
+	var out Config
+	if err := plugin.MergeExecutorConfigsWithDefaults(defaults, configs, &out); err != nil {
+		return Config{}, fmt.Errorf("while merging configuration: %w", err)
+	}
+
+	return out, nil
+}
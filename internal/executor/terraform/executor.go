@@ -0,0 +1,326 @@
+package terraform
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/alexflint/go-arg"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/api/executor"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+	"github.com/kubeshop/botkube/pkg/plugin"
+)
+
+const (
+	// PluginName is the name of the Terraform Botkube plugin.
+	PluginName       = "terraform"
+	description      = "Run Terraform or OpenTofu plans for configured workspaces and apply them after approval."
+	terraformVersion = "1.6.6"
+	openTofuVersion  = "1.6.0"
+	defaultPlanDir   = "/tmp/botkube-terraform"
+	dirPerms         = 0o775
+
+	// maxResourceChanges limits the number of listed resource changes to keep the message readable.
+	maxResourceChanges = 15
+)
+
+var (
+	//go:embed config_schema.json
+	configJSONSchema string
+
+	tfBinaryDownloadLinks = map[string]string{
+		"darwin/amd64": fmt.Sprintf("https://releases.hashicorp.com/terraform/%[1]s/terraform_%[1]s_darwin_amd64.zip", terraformVersion),
+		"darwin/arm64": fmt.Sprintf("https://releases.hashicorp.com/terraform/%[1]s/terraform_%[1]s_darwin_arm64.zip", terraformVersion),
+		"linux/amd64":  fmt.Sprintf("https://releases.hashicorp.com/terraform/%[1]s/terraform_%[1]s_linux_amd64.zip", terraformVersion),
+		"linux/arm64":  fmt.Sprintf("https://releases.hashicorp.com/terraform/%[1]s/terraform_%[1]s_linux_arm64.zip", terraformVersion),
+	}
+	tofuBinaryDownloadLinks = map[string]string{
+		"darwin/amd64": fmt.Sprintf("https://github.com/opentofu/opentofu/releases/download/v%[1]s/tofu_%[1]s_darwin_amd64.zip", openTofuVersion),
+		"darwin/arm64": fmt.Sprintf("https://github.com/opentofu/opentofu/releases/download/v%[1]s/tofu_%[1]s_darwin_arm64.zip", openTofuVersion),
+		"linux/amd64":  fmt.Sprintf("https://github.com/opentofu/opentofu/releases/download/v%[1]s/tofu_%[1]s_linux_amd64.zip", openTofuVersion),
+		"linux/arm64":  fmt.Sprintf("https://github.com/opentofu/opentofu/releases/download/v%[1]s/tofu_%[1]s_linux_arm64.zip", openTofuVersion),
+	}
+)
+
+var _ executor.Executor = &Executor{}
+
+type (
+	tfRunner interface {
+		Run(ctx context.Context, bin Binary, workDir string, envs map[string]string, args string) (string, error)
+	}
+	secretGetter func(ctx context.Context, kubeConfig []byte, ref SecretRef) (map[string][]byte, error)
+)
+
+// Command defines the supported Terraform plugin commands.
+type Command struct {
+	Workspaces *struct{}       `arg:"subcommand:workspaces"`
+	Plan       *PlanCommand    `arg:"subcommand:plan"`
+	Apply      *ApplyCommand   `arg:"subcommand:apply"`
+	Discard    *DiscardCommand `arg:"subcommand:discard"`
+}
+
+// PlanCommand holds the plan command arguments.
+type PlanCommand struct {
+	Workspace string `arg:"positional,required"`
+}
+
+// ApplyCommand holds the apply command arguments.
+type ApplyCommand struct {
+	Workspace string `arg:"positional,required"`
+	PlanID    string `arg:"--plan-id,required"`
+	Approve   bool   `arg:"--approve"`
+}
+
+// DiscardCommand holds the discard command arguments.
+type DiscardCommand struct {
+	Workspace string `arg:"positional,required"`
+	PlanID    string `arg:"--plan-id,required"`
+}
+
+// Executor provides functionality for running Terraform and OpenTofu CLI.
+type Executor struct {
+	pluginVersion string
+	runner        tfRunner
+	getSecret     secretGetter
+}
+
+// NewExecutor returns a new Executor instance.
+func NewExecutor(ver string, runner tfRunner) *Executor {
+	return &Executor{
+		pluginVersion: ver,
+		runner:        runner,
+		getSecret:     getSecretData,
+	}
+}
+
+// Metadata returns details about Terraform plugin.
+func (e *Executor) Metadata(context.Context) (api.MetadataOutput, error) {
+	return api.MetadataOutput{
+		Version:          e.pluginVersion,
+		Description:      description,
+		DocumentationURL: "https://docs.botkube.io/configuration/executor/terraform",
+		JSONSchema: api.JSONSchema{
+			Value: configJSONSchema,
+		},
+		Dependencies: map[string]api.Dependency{
+			string(TerraformBinary): {
+				URLs: tfBinaryDownloadLinks,
+			},
+			string(OpenTofuBinary): {
+				URLs: tofuBinaryDownloadLinks,
+			},
+		},
+	}, nil
+}
+
+// Execute returns a given command as response.
+func (e *Executor) Execute(ctx context.Context, in executor.ExecuteInput) (executor.ExecuteOutput, error) {
+	cfg, err := MergeConfigs(in.Configs)
+	if err != nil {
+		return executor.ExecuteOutput{}, fmt.Errorf("while merging input configs: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return executor.ExecuteOutput{}, fmt.Errorf("while validating configuration: %w", err)
+	}
+
+	log := loggerx.New(cfg.Log)
+
+	var cmd Command
+	err = plugin.ParseCommand(PluginName, in.Command, &cmd)
+	switch {
+	case err == nil:
+	case errors.Is(err, arg.ErrHelp):
+		return executor.ExecuteOutput{Message: help()}, nil
+	default:
+		return executor.ExecuteOutput{}, fmt.Errorf("while parsing input command: %w", err)
+	}
+
+	store := planStore{dir: cfg.PlanDir}
+	switch {
+	case cmd.Plan != nil:
+		return e.plan(ctx, log, cfg, store, in, cmd.Plan.Workspace)
+	case cmd.Apply != nil:
+		return e.apply(ctx, log, cfg, store, in, *cmd.Apply)
+	case cmd.Discard != nil:
+		return e.discard(cfg, store, in, *cmd.Discard)
+	case cmd.Workspaces != nil:
+		return executor.ExecuteOutput{Message: workspacesMessage(cfg)}, nil
+	default:
+		return executor.ExecuteOutput{Message: help()}, nil
+	}
+}
+
+// Help returns help message.
+func (*Executor) Help(context.Context) (api.Message, error) {
+	return help(), nil
+}
+
+func (e *Executor) plan(ctx context.Context, log logrus.FieldLogger, cfg Config, store planStore, in executor.ExecuteInput, wsName string) (executor.ExecuteOutput, error) {
+	ws, envs, err := e.prepareWorkspace(ctx, cfg, in, wsName)
+	if err != nil {
+		return executor.ExecuteOutput{}, err
+	}
+
+	log.WithField("workspace", wsName).Debug("Initializing working directory...")
+	if _, err := e.runner.Run(ctx, cfg.Binary, ws.Dir, envs, "init -input=false -no-color"); err != nil {
+		return executor.ExecuteOutput{}, fmt.Errorf("while initializing %q workspace: %w", wsName, err)
+	}
+
+	tmpPlan, err := store.tmpPath(wsName)
+	if err != nil {
+		return executor.ExecuteOutput{}, err
+	}
+	defer os.Remove(tmpPlan) // no-op when the plan was committed
+
+	log.WithField("workspace", wsName).Debug("Running plan...")
+	out, err := e.runner.Run(ctx, cfg.Binary, ws.Dir, envs, fmt.Sprintf("plan -input=false -no-color -out=%s", tmpPlan))
+	if err != nil {
+		return executor.ExecuteOutput{}, fmt.Errorf("while running plan for %q workspace: %w", wsName, err)
+	}
+
+	summary := ParsePlanSummary(out)
+	if !summary.HasChanges() {
+		return executor.ExecuteOutput{Message: planMessage(cfg, in.Context.IsInteractivitySupported, wsName, "", summary)}, nil
+	}
+
+	user := in.Context.Message.User
+	id, err := store.commit(wsName, tmpPlan, planRequest{
+		RequesterID:      user.ID,
+		RequesterMention: user.Mention,
+	})
+	if err != nil {
+		return executor.ExecuteOutput{}, err
+	}
+
+	return executor.ExecuteOutput{Message: planMessage(cfg, in.Context.IsInteractivitySupported, wsName, id, summary)}, nil
+}
+
+func (e *Executor) apply(ctx context.Context, log logrus.FieldLogger, cfg Config, store planStore, in executor.ExecuteInput, cmd ApplyCommand) (executor.ExecuteOutput, error) {
+	if !cfg.Apply.Enabled {
+		return executor.ExecuteOutput{}, errors.New("Applying plans is disabled. Enable it in the plugin configuration under `apply.enabled` property.")
+	}
+
+	planPath, err := store.get(cmd.Workspace, cmd.PlanID)
+	if err != nil {
+		return executor.ExecuteOutput{}, err
+	}
+
+	user := in.Context.Message.User
+	if !cmd.Approve {
+		return executor.ExecuteOutput{Message: approvalMessage(cfg, cmd, user)}, nil
+	}
+
+	if !cfg.Apply.IsApprover(user) {
+		return executor.ExecuteOutput{}, fmt.Errorf("User %q is not allowed to approve applying plans. Ask one of the approvers: %s.", user.DisplayName, strings.Join(cfg.Apply.Approvers, ", "))
+	}
+	req, err := store.request(cmd.Workspace, cmd.PlanID)
+	if err != nil {
+		return executor.ExecuteOutput{}, err
+	}
+	if req.RequesterID == user.ID {
+		return executor.ExecuteOutput{}, fmt.Errorf("Plan %q must be approved by a different user than the one who requested it.", cmd.PlanID)
+	}
+
+	ws, envs, err := e.prepareWorkspace(ctx, cfg, in, cmd.Workspace)
+	if err != nil {
+		return executor.ExecuteOutput{}, err
+	}
+
+	log.WithFields(logrus.Fields{
+		"workspace": cmd.Workspace,
+		"planID":    cmd.PlanID,
+		"requester": req.RequesterMention,
+		"approver":  user.DisplayName,
+	}).Info("Applying approved plan...")
+	out, err := e.runner.Run(ctx, cfg.Binary, ws.Dir, envs, fmt.Sprintf("apply -input=false -no-color %s", planPath))
+	if removeErr := store.remove(cmd.Workspace, cmd.PlanID); removeErr != nil {
+		log.WithError(removeErr).Warn("Failed to remove applied plan")
+	}
+	if err != nil {
+		return executor.ExecuteOutput{}, fmt.Errorf("while applying plan %q for %q workspace: %w", cmd.PlanID, cmd.Workspace, err)
+	}
+
+	return executor.ExecuteOutput{
+		Message: api.NewCodeBlockMessage(out, true),
+	}, nil
+}
+
+func (e *Executor) discard(cfg Config, store planStore, in executor.ExecuteInput, cmd DiscardCommand) (executor.ExecuteOutput, error) {
+	if _, found := cfg.Workspaces[cmd.Workspace]; !found {
+		return executor.ExecuteOutput{}, unknownWorkspaceErr(cmd.Workspace)
+	}
+	if err := store.remove(cmd.Workspace, cmd.PlanID); err != nil {
+		return executor.ExecuteOutput{}, err
+	}
+	msg := fmt.Sprintf("Plan %q for %q workspace discarded by %s.", cmd.PlanID, cmd.Workspace, in.Context.Message.User.Mention)
+	return executor.ExecuteOutput{
+		Message: api.NewPlaintextMessage(msg, false),
+	}, nil
+}
+
+func (e *Executor) prepareWorkspace(ctx context.Context, cfg Config, in executor.ExecuteInput, name string) (Workspace, map[string]string, error) {
+	ws, found := cfg.Workspaces[name]
+	if !found {
+		return Workspace{}, nil, unknownWorkspaceErr(name)
+	}
+
+	envs := map[string]string{
+		"TF_IN_AUTOMATION": "true",
+	}
+	if ws.Name != "" {
+		envs["TF_WORKSPACE"] = ws.Name
+	}
+
+	if len(ws.SecretRefs) > 0 {
+		if err := plugin.ValidateKubeConfigProvided(PluginName, in.Context.KubeConfig); err != nil {
+			return Workspace{}, nil, err
+		}
+	}
+	for _, ref := range ws.SecretRefs {
+		data, err := e.getSecret(ctx, in.Context.KubeConfig, ref)
+		if err != nil {
+			return Workspace{}, nil, fmt.Errorf("while getting credentials from %s/%s Secret: %w", ref.Namespace, ref.Name, err)
+		}
+		for key, val := range data {
+			envs[key] = string(val)
+		}
+	}
+
+	// explicit envs have the highest priority
+	for key, val := range ws.Env {
+		envs[key] = val
+	}
+
+	return ws, envs, nil
+}
+
+func unknownWorkspaceErr(name string) error {
+	return fmt.Errorf("Workspace %q is not configured. Run `%s workspaces` to list available ones.", name, PluginName)
+}
+
+func getSecretData(ctx context.Context, kubeConfig []byte, ref SecretRef) (map[string][]byte, error) {
+	restCfg, err := clientcmd.RESTConfigFromKubeConfig(kubeConfig)
+	if err != nil {
+		return nil, fmt.Errorf("while reading kube config: %w", err)
+	}
+	cli, err := kubernetes.NewForConfig(restCfg)
+	if err != nil {
+		return nil, fmt.Errorf("while creating typed k8s client: %w", err)
+	}
+
+	secret, err := cli.CoreV1().Secrets(ref.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return secret.Data, nil
+}
@@ -0,0 +1,205 @@
+package terraform
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/api/executor"
+)
+
+const planOutput = `
+Terraform will perform the following actions:
+
+  # aws_instance.web will be created
+  + resource "aws_instance" "web" {}
+
+  # aws_s3_bucket.logs must be replaced
+-/+ resource "aws_s3_bucket" "logs" {}
+
+Plan: 1 to add, 0 to change, 1 to destroy.
+`
+
+func TestParsePlanSummary(t *testing.T) {
+	// when
+	summary := ParsePlanSummary(planOutput)
+
+	// then
+	assert.True(t, summary.HasChanges())
+	assert.Equal(t, "Plan: 1 to add, 0 to change, 1 to destroy.", summary.Headline())
+	assert.Equal(t, []string{"aws_instance.web created", "aws_s3_bucket.logs replaced"}, summary.ResourceChanges)
+}
+
+func TestParsePlanSummaryNoChanges(t *testing.T) {
+	// when
+	summary := ParsePlanSummary("No changes. Your infrastructure matches the configuration.")
+
+	// then
+	assert.False(t, summary.HasChanges())
+	assert.Empty(t, summary.ResourceChanges)
+}
+
+func TestExecutorPlanAndApproveApply(t *testing.T) {
+	// given
+	planDir := t.TempDir()
+	runner := &fakeRunner{planOut: planOutput}
+	exec := NewExecutor("dev", runner)
+	exec.getSecret = func(context.Context, []byte, SecretRef) (map[string][]byte, error) {
+		return map[string][]byte{"AWS_ACCESS_KEY_ID": []byte("key")}, nil
+	}
+
+	cfg := []*executor.Config{
+		{
+			RawYAML: []byte(heredoc.Docf(`
+				planDir: %s
+				workspaces:
+				  prod:
+				    dir: /infra/prod
+				    secretRefs:
+				      - name: aws-creds
+				        namespace: infra
+				apply:
+				  enabled: true
+				  approvers: ["UALICE"]
+			`, planDir)),
+		},
+	}
+
+	input := func(cmd, user, id string) executor.ExecuteInput {
+		return executor.ExecuteInput{
+			Command: cmd,
+			Configs: cfg,
+			Context: executor.ExecuteInputContext{
+				IsInteractivitySupported: true,
+				KubeConfig:               []byte("kubeconfig"),
+				Message: executor.Message{
+					User: executor.User{Mention: fmt.Sprintf("<@%s>", id), DisplayName: user, ID: id},
+				},
+			},
+		}
+	}
+
+	// when
+	out, err := exec.Execute(context.Background(), input("terraform plan prod", "bob", "UBOB"))
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, out.Message.Sections, 1)
+	assert.Equal(t, "key", runner.lastEnvs["AWS_ACCESS_KEY_ID"])
+	require.Len(t, out.Message.Sections[0].Buttons, 2)
+	applyCmd := strings.TrimPrefix(out.Message.Sections[0].Buttons[0].Command, api.MessageBotNamePlaceholder+" ")
+	assert.True(t, strings.HasPrefix(applyCmd, "terraform apply prod --plan-id "))
+
+	// when apply is requested, approval is needed
+	out, err = exec.Execute(context.Background(), input(applyCmd, "bob", "UBOB"))
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, out.Message.Sections, 1)
+	approveCmd := strings.TrimPrefix(out.Message.Sections[0].Buttons[0].Command, api.MessageBotNamePlaceholder+" ")
+	assert.True(t, strings.HasSuffix(approveCmd, "--approve"))
+
+	// when non-approver approves
+	_, err = exec.Execute(context.Background(), input(approveCmd, "bob", "UBOB"))
+
+	// then
+	assert.EqualError(t, err, `User "bob" is not allowed to approve applying plans. Ask one of the approvers: UALICE.`)
+
+	// when non-approver with the approver display name approves
+	_, err = exec.Execute(context.Background(), input(approveCmd, "alice", "UMALLORY"))
+
+	// then
+	assert.EqualError(t, err, `User "alice" is not allowed to approve applying plans. Ask one of the approvers: UALICE.`)
+
+	// when approver approves
+	out, err = exec.Execute(context.Background(), input(approveCmd, "alice", "UALICE"))
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "Apply complete!", out.Message.BaseBody.CodeBlock)
+	assert.True(t, strings.HasPrefix(runner.lastArgs, "apply -input=false -no-color "+planDir))
+
+	// when plan is applied again
+	_, err = exec.Execute(context.Background(), input(approveCmd, "alice", "UALICE"))
+
+	// then
+	assert.ErrorContains(t, err, "not found")
+}
+
+func TestExecutorApplyRejectsApprovalByRequester(t *testing.T) {
+	// given
+	exec := NewExecutor("dev", &fakeRunner{planOut: planOutput})
+	cfg := []*executor.Config{
+		{
+			RawYAML: []byte(heredoc.Docf(`
+				planDir: %s
+				workspaces:
+				  prod:
+				    dir: /infra/prod
+				apply:
+				  enabled: true
+				  approvers: ["UALICE", "UCAROL"]
+			`, t.TempDir())),
+		},
+	}
+	input := func(cmd, id string) executor.ExecuteInput {
+		return executor.ExecuteInput{
+			Command: cmd,
+			Configs: cfg,
+			Context: executor.ExecuteInputContext{
+				IsInteractivitySupported: true,
+				Message: executor.Message{
+					User: executor.User{Mention: fmt.Sprintf("<@%s>", id), DisplayName: id, ID: id},
+				},
+			},
+		}
+	}
+
+	out, err := exec.Execute(context.Background(), input("terraform plan prod", "UALICE"))
+	require.NoError(t, err)
+	require.Len(t, out.Message.Sections, 1)
+	applyCmd := strings.TrimPrefix(out.Message.Sections[0].Buttons[0].Command, api.MessageBotNamePlaceholder+" ")
+	approveCmd := applyCmd + " --approve"
+
+	// when requester approves
+	_, err = exec.Execute(context.Background(), input(approveCmd, "UALICE"))
+
+	// then
+	assert.ErrorContains(t, err, "must be approved by a different user than the one who requested it")
+
+	// when other approver approves
+	out, err = exec.Execute(context.Background(), input(approveCmd, "UCAROL"))
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "Apply complete!", out.Message.BaseBody.CodeBlock)
+}
+
+type fakeRunner struct {
+	planOut  string
+	lastArgs string
+	lastEnvs map[string]string
+}
+
+func (f *fakeRunner) Run(_ context.Context, _ Binary, _ string, envs map[string]string, args string) (string, error) {
+	f.lastArgs = args
+	f.lastEnvs = envs
+	switch {
+	case strings.HasPrefix(args, "plan"):
+		out := strings.TrimPrefix(args[strings.Index(args, "-out="):], "-out=")
+		if err := os.WriteFile(out, []byte("binary plan"), 0o600); err != nil {
+			return "", err
+		}
+		return f.planOut, nil
+	case strings.HasPrefix(args, "apply"):
+		return "Apply complete!", nil
+	}
+	return "", nil
+}
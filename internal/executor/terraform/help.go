@@ -0,0 +1,38 @@
+package terraform
+
+import (
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc"
+
+	"github.com/kubeshop/botkube/pkg/api"
+)
+
+func help() api.Message {
+	btnBuilder := api.NewMessageButtonBuilder()
+	return api.Message{
+		Sections: []api.Section{
+			{
+				Base: api.Base{
+					Header:      "Run Terraform and OpenTofu plans from chat",
+					Description: description,
+				},
+			},
+			{
+				Base: api.Base{
+					Body: api.Body{
+						CodeBlock: heredoc.Doc(`
+							Usage:
+							  terraform workspaces                            List configured workspaces
+							  terraform plan <workspace>                      Run plan and post its summary
+							  terraform apply <workspace> --plan-id <id>      Request approval for applying a given plan
+							  terraform discard <workspace> --plan-id <id>    Discard a given plan`),
+					},
+				},
+				Buttons: []api.Button{
+					btnBuilder.ForCommandWithDescCmd("List workspaces", fmt.Sprintf("%s workspaces", PluginName)),
+				},
+			},
+		},
+	}
+}
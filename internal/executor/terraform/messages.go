@@ -0,0 +1,102 @@
+package terraform
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/api/executor"
+	"github.com/kubeshop/botkube/pkg/maputil"
+)
+
+func planMessage(cfg Config, interactive bool, workspace, planID string, summary PlanSummary) api.Message {
+	section := api.Section{
+		Base: api.Base{
+			Header:      fmt.Sprintf("%s plan for %q workspace", cfg.Binary, workspace),
+			Description: summary.Headline(),
+		},
+	}
+
+	changes := summary.ResourceChanges
+	if len(changes) > maxResourceChanges {
+		changes = append(changes[:maxResourceChanges:maxResourceChanges], fmt.Sprintf("... and %d more", len(summary.ResourceChanges)-maxResourceChanges))
+	}
+	if len(changes) > 0 {
+		section.BulletLists = api.BulletLists{
+			{
+				Title: "Resource changes",
+				Items: changes,
+			},
+		}
+	}
+
+	if planID == "" {
+		return api.Message{Sections: []api.Section{section}}
+	}
+
+	section.Context = api.ContextItems{
+		{Text: fmt.Sprintf("Plan ID: %s", planID)},
+	}
+
+	if cfg.Apply.Enabled {
+		btnBuilder := api.NewMessageButtonBuilder()
+		applyCmd := fmt.Sprintf("%s apply %s --plan-id %s", PluginName, workspace, planID)
+		discardCmd := fmt.Sprintf("%s discard %s --plan-id %s", PluginName, workspace, planID)
+		if interactive {
+			section.Buttons = api.Buttons{
+				btnBuilder.ForCommandWithoutDesc("Request apply", applyCmd, api.ButtonStylePrimary),
+				btnBuilder.ForCommandWithoutDesc("Discard", discardCmd),
+			}
+		} else {
+			section.Context = append(section.Context, api.ContextItem{
+				Text: fmt.Sprintf("To request apply, run: %s %s", api.MessageBotNamePlaceholder, applyCmd),
+			})
+		}
+	}
+
+	return api.Message{Sections: []api.Section{section}}
+}
+
+func approvalMessage(cfg Config, cmd ApplyCommand, requester executor.User) api.Message {
+	btnBuilder := api.NewMessageButtonBuilder()
+	approveCmd := fmt.Sprintf("%s apply %s --plan-id %s --approve", PluginName, cmd.Workspace, cmd.PlanID)
+	discardCmd := fmt.Sprintf("%s discard %s --plan-id %s", PluginName, cmd.Workspace, cmd.PlanID)
+
+	return api.Message{
+		Sections: []api.Section{
+			{
+				Base: api.Base{
+					Header:      fmt.Sprintf("Apply requested for %q workspace", cmd.Workspace),
+					Description: fmt.Sprintf("%s requested applying plan %q. One of the approvers, other than the plan requester, must confirm it: %s.", requester.Mention, cmd.PlanID, strings.Join(cfg.Apply.Approvers, ", ")),
+				},
+				Buttons: api.Buttons{
+					btnBuilder.ForCommand("Approve and apply", approveCmd, approveCmd, api.ButtonStyleDanger),
+					btnBuilder.ForCommandWithoutDesc("Reject", discardCmd),
+				},
+			},
+		},
+	}
+}
+
+func workspacesMessage(cfg Config) api.Message {
+	if len(cfg.Workspaces) == 0 {
+		return api.NewPlaintextMessage("No workspaces configured.", false)
+	}
+
+	btnBuilder := api.NewMessageButtonBuilder()
+	var btns api.Buttons
+	for _, name := range maputil.SortKeys(cfg.Workspaces) {
+		btns = append(btns, btnBuilder.ForCommandWithDescCmd(fmt.Sprintf("Plan %s", name), fmt.Sprintf("%s plan %s", PluginName, name)))
+	}
+
+	return api.Message{
+		Sections: []api.Section{
+			{
+				Base: api.Base{
+					Header: "Configured workspaces",
+				},
+				Buttons: btns,
+			},
+		},
+	}
+}
@@ -0,0 +1,176 @@
+package terraform
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const (
+	planIDLength = 12
+	planFileExt  = ".tfplan"
+	// planRequestExt is the extension of files holding details of plan requests, stored next to plans.
+	planRequestExt = ".json"
+	planFilePerms  = 0o600
+	noChangesText  = "No changes."
+)
+
+var (
+	planSummaryPattern    = regexp.MustCompile(`Plan: (\d+) to add, (\d+) to change, (\d+) to destroy\.`)
+	resourceChangePattern = regexp.MustCompile(`^\s*# (\S+) (?:will be|must be) (.+)$`)
+)
+
+// PlanSummary holds the most important details extracted from the plan output.
+type PlanSummary struct {
+	NoChanges       bool
+	Add             string
+	Change          string
+	Destroy         string
+	ResourceChanges []string
+}
+
+// HasChanges returns true if plan introduces any changes.
+func (p PlanSummary) HasChanges() bool {
+	return !p.NoChanges && (p.Add != "0" || p.Change != "0" || p.Destroy != "0")
+}
+
+// Headline returns a one-line description of the plan.
+func (p PlanSummary) Headline() string {
+	if !p.HasChanges() {
+		return "No changes. Your infrastructure matches the configuration."
+	}
+	return fmt.Sprintf("Plan: %s to add, %s to change, %s to destroy.", p.Add, p.Change, p.Destroy)
+}
+
+// ParsePlanSummary extracts summary from the `plan -no-color` output.
+func ParsePlanSummary(out string) PlanSummary {
+	summary := PlanSummary{
+		Add:     "0",
+		Change:  "0",
+		Destroy: "0",
+	}
+
+	for _, line := range strings.Split(out, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), noChangesText) {
+			summary.NoChanges = true
+			continue
+		}
+
+		if matches := planSummaryPattern.FindStringSubmatch(line); len(matches) == 4 {
+			summary.Add, summary.Change, summary.Destroy = matches[1], matches[2], matches[3]
+			continue
+		}
+
+		if matches := resourceChangePattern.FindStringSubmatch(line); len(matches) == 3 {
+			summary.ResourceChanges = append(summary.ResourceChanges, fmt.Sprintf("%s %s", matches[1], matches[2]))
+		}
+	}
+
+	return summary
+}
+
+// planRequest holds details of a stored plan. The requester is stored, so the plan can't be approved by the same user.
+type planRequest struct {
+	RequesterID      string `json:"requesterID"`
+	RequesterMention string `json:"requesterMention"`
+}
+
+// planStore keeps generated plan files, so only the exact reviewed plan can be applied.
+type planStore struct {
+	dir string
+}
+
+func (s planStore) path(workspace, id string) string {
+	return filepath.Join(s.dir, workspace, id+planFileExt)
+}
+
+func (s planStore) requestPath(workspace, id string) string {
+	return filepath.Join(s.dir, workspace, id+planRequestExt)
+}
+
+// tmpPath returns a path for a plan that is being generated.
+func (s planStore) tmpPath(workspace string) (string, error) {
+	dir := filepath.Join(s.dir, workspace)
+	if err := os.MkdirAll(dir, dirPerms); err != nil {
+		return "", fmt.Errorf("while creating plan directory: %w", err)
+	}
+	file, err := os.CreateTemp(dir, "plan-*.tmp")
+	if err != nil {
+		return "", fmt.Errorf("while creating plan file: %w", err)
+	}
+	defer file.Close()
+	return file.Name(), nil
+}
+
+// commit renames the generated plan using its checksum, stores the plan request next to it, and returns the plan ID.
+func (s planStore) commit(workspace, tmpPath string, req planRequest) (string, error) {
+	raw, err := os.ReadFile(filepath.Clean(tmpPath))
+	if err != nil {
+		return "", fmt.Errorf("while reading plan file: %w", err)
+	}
+	sum := sha256.Sum256(raw)
+	id := hex.EncodeToString(sum[:])[:planIDLength]
+
+	out, err := json.Marshal(req)
+	if err != nil {
+		return "", fmt.Errorf("while marshaling plan request: %w", err)
+	}
+	// the request is stored first, so a plan is never available without its requester
+	if err := os.WriteFile(s.requestPath(workspace, id), out, planFilePerms); err != nil {
+		return "", fmt.Errorf("while storing plan request: %w", err)
+	}
+	if err := os.Rename(tmpPath, s.path(workspace, id)); err != nil {
+		return "", fmt.Errorf("while storing plan file: %w", err)
+	}
+	return id, nil
+}
+
+// get returns the path to a given plan file. An error is returned if plan doesn't exist.
+func (s planStore) get(workspace, id string) (string, error) {
+	if id == "" || strings.ContainsAny(id, `/\.`) {
+		return "", fmt.Errorf("invalid plan ID %q", id)
+	}
+	path := s.path(workspace, id)
+	if _, err := os.Stat(path); err != nil {
+		return "", fmt.Errorf("plan %q for %q workspace not found. It was already applied, discarded, or the plugin was restarted. Run a new plan", id, workspace)
+	}
+	return path, nil
+}
+
+// request returns the request of a given plan.
+func (s planStore) request(workspace, id string) (planRequest, error) {
+	if _, err := s.get(workspace, id); err != nil {
+		return planRequest{}, err
+	}
+	raw, err := os.ReadFile(s.requestPath(workspace, id))
+	if err != nil {
+		return planRequest{}, fmt.Errorf("while reading plan request: %w", err)
+	}
+
+	var out planRequest
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return planRequest{}, fmt.Errorf("while unmarshaling plan request: %w", err)
+	}
+	return out, nil
+}
+
+// remove deletes a given plan file together with its request.
+func (s planStore) remove(workspace, id string) error {
+	path, err := s.get(workspace, id)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil {
+		return err
+	}
+	if err := os.Remove(s.requestPath(workspace, id)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("while removing plan request: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package terraform
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kubeshop/botkube/pkg/plugin"
+)
+
+// BinaryRunner runs a Terraform or OpenTofu binary.
+type BinaryRunner struct {
+	executeCommand func(ctx context.Context, rawCmd string, mutators ...plugin.ExecuteCommandMutation) (plugin.ExecuteCommandOutput, error)
+}
+
+// NewBinaryRunner returns a new BinaryRunner instance.
+func NewBinaryRunner() *BinaryRunner {
+	return &BinaryRunner{
+		executeCommand: plugin.ExecuteCommand,
+	}
+}
+
+// Run runs a given CLI command in a given working directory.
+func (r *BinaryRunner) Run(ctx context.Context, bin Binary, workDir string, envs map[string]string, args string) (string, error) {
+	runCmd := fmt.Sprintf("%s %s", bin, args)
+	out, err := r.executeCommand(ctx, runCmd,
+		plugin.ExecuteCommandWorkingDir(workDir),
+		plugin.ExecuteCommandEnvs(envs),
+		plugin.ExecuteClearColorCodes(),
+	)
+	if err != nil {
+		return "", err
+	}
+
+	return out.CombinedOutput(), nil
+}
@@ -0,0 +1,237 @@
+package featureflag
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+// Flag is the name of a feature flag.
+type Flag string
+
+const (
+	// Aggregation enables collecting notifications during the aggregation window and sending them as a single message.
+	Aggregation Flag = "aggregation"
+	// AIExecutor enables the AI executor plugin.
+	AIExecutor Flag = "aiExecutor"
+)
+
+// Origin describes where the state of a given flag is defined.
+type Origin string
+
+const (
+	// DefaultOrigin is used for flags which are not set anywhere.
+	DefaultOrigin Origin = "default"
+	// GlobalOrigin is used for flags set globally.
+	GlobalOrigin Origin = "global"
+	// ChannelOrigin is used for flags set for a given channel.
+	ChannelOrigin Origin = "channel"
+)
+
+// Definition describes a feature flag.
+type Definition struct {
+	Name        Flag
+	Description string
+	// Default is the state of the flag if it's not set globally nor for a channel.
+	Default bool
+	// ExecutorPlugin is the name of the executor plugin gated by the flag, e.g. `ai`. It's empty if the flag doesn't gate any plugin.
+	ExecutorPlugin string
+}
+
+var definitions = []Definition{
+	{
+		Name:        Aggregation,
+		Description: "Collect notifications during the aggregation window and send them as a single message",
+		Default:     true,
+	},
+	{
+		Name:           AIExecutor,
+		Description:    "Run commands of the AI executor plugin",
+		Default:        true,
+		ExecutorPlugin: "ai",
+	},
+}
+
+// Definitions returns definitions of all known feature flags, sorted by names.
+func Definitions() []Definition {
+	out := append([]Definition(nil), definitions...)
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Name < out[j].Name
+	})
+	return out
+}
+
+// Names returns names of all known feature flags, sorted.
+func Names() []string {
+	var out []string
+	for _, def := range Definitions() {
+		out = append(out, string(def.Name))
+	}
+	return out
+}
+
+// Lookup returns the definition of a feature flag with a given name, which is matched case-insensitively.
+func Lookup(name string) (Definition, bool) {
+	for _, def := range definitions {
+		if strings.EqualFold(string(def.Name), name) {
+			return def, true
+		}
+	}
+	return Definition{}, false
+}
+
+// ForExecutorPlugin returns the flag which gates a given executor plugin, e.g. `botkube/ai`. The version suffix is ignored.
+func ForExecutorPlugin(fullPluginName string) (Flag, bool) {
+	name, _, _ := strings.Cut(fullPluginName, "@")
+	if idx := strings.LastIndex(name, "/"); idx >= 0 {
+		name = name[idx+1:]
+	}
+	for _, def := range definitions {
+		if def.ExecutorPlugin != "" && def.ExecutorPlugin == name {
+			return def.Name, true
+		}
+	}
+	return "", false
+}
+
+// Status holds the state of a feature flag.
+type Status struct {
+	Definition
+	Enabled bool
+	Origin  Origin
+}
+
+// Manager holds states of feature flags defined in the configuration, which can be changed at runtime.
+// Runtime changes are kept in memory, so they are reverted once Botkube restarts.
+type Manager struct {
+	mu       sync.RWMutex
+	global   map[Flag]bool
+	channels map[string]map[Flag]bool
+}
+
+// NewManager returns a new Manager instance with flags set globally under `settings.featureFlags`, and for channels.
+// Unknown flags are ignored, as they are reported by the configuration validation.
+func NewManager(cfg config.Config) *Manager {
+	m := &Manager{
+		global:   toFlags(cfg.Settings.FeatureFlags),
+		channels: map[string]map[Flag]bool{},
+	}
+	for _, item := range ChannelFlags(cfg) {
+		if len(item.Flags) == 0 {
+			continue
+		}
+		m.channels[item.Alias] = toFlags(item.Flags)
+	}
+	return m
+}
+
+// Enabled returns true if a given flag is enabled for a channel with a given alias. Flags set for the channel take precedence
+// over the ones set globally. A nil Manager returns default states.
+func (m *Manager) Enabled(flag Flag, channelAlias string) bool {
+	enabled, _ := m.state(flag, channelAlias)
+	return enabled
+}
+
+// Set changes the state of a given flag for a channel with a given alias, or globally if the alias is empty.
+func (m *Manager) Set(flag Flag, channelAlias string, enabled bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if channelAlias == "" {
+		m.global[flag] = enabled
+		return
+	}
+	if m.channels[channelAlias] == nil {
+		m.channels[channelAlias] = map[Flag]bool{}
+	}
+	m.channels[channelAlias][flag] = enabled
+}
+
+// Statuses returns states of all known flags for a channel with a given alias, or global states if the alias is empty.
+func (m *Manager) Statuses(channelAlias string) []Status {
+	var out []Status
+	for _, def := range Definitions() {
+		enabled, origin := m.state(def.Name, channelAlias)
+		out = append(out, Status{Definition: def, Enabled: enabled, Origin: origin})
+	}
+	return out
+}
+
+// Global returns global states of all known flags, indexed by their names.
+func (m *Manager) Global() map[string]bool {
+	out := map[string]bool{}
+	for _, status := range m.Statuses("") {
+		out[string(status.Name)] = status.Enabled
+	}
+	return out
+}
+
+func (m *Manager) state(flag Flag, channelAlias string) (bool, Origin) {
+	def, _ := Lookup(string(flag))
+	if m == nil {
+		return def.Default, DefaultOrigin
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if enabled, ok := m.channels[channelAlias][flag]; ok && channelAlias != "" {
+		return enabled, ChannelOrigin
+	}
+	if enabled, ok := m.global[flag]; ok {
+		return enabled, GlobalOrigin
+	}
+	return def.Default, DefaultOrigin
+}
+
+// DefinedFlags holds feature flags set for a given channel.
+type DefinedFlags struct {
+	// Path is the YAML path of the flags, e.g. `communications.default-group.socketSlack.channels.default.featureFlags`.
+	Path string
+	// Alias is the channel alias used in the configuration.
+	Alias string
+	Flags map[string]bool
+}
+
+// ChannelFlags returns feature flags set for all channels, ordered by their paths.
+func ChannelFlags(cfg config.Config) []DefinedFlags {
+	var out []DefinedFlags
+	add := func(path, alias string, flags map[string]bool) {
+		out = append(out, DefinedFlags{Path: path + ".featureFlags", Alias: alias, Flags: flags})
+	}
+	for group, comm := range cfg.Communications {
+		for alias, ch := range comm.SocketSlack.Channels {
+			add(fmt.Sprintf("communications.%s.socketSlack.channels.%s", group, alias), alias, ch.FeatureFlags)
+		}
+		for alias, ch := range comm.CloudSlack.Channels {
+			add(fmt.Sprintf("communications.%s.cloudSlack.channels.%s", group, alias), alias, ch.FeatureFlags)
+		}
+		for alias, ch := range comm.Mattermost.Channels {
+			add(fmt.Sprintf("communications.%s.mattermost.channels.%s", group, alias), alias, ch.FeatureFlags)
+		}
+		for alias, ch := range comm.Discord.Channels {
+			add(fmt.Sprintf("communications.%s.discord.channels.%s", group, alias), alias, ch.FeatureFlags)
+		}
+		for idx, team := range comm.CloudTeams.Teams {
+			for alias, ch := range team.Channels {
+				add(fmt.Sprintf("communications.%s.cloudTeams.teams[%d].channels.%s", group, idx, alias), alias, ch.FeatureFlags)
+			}
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Path < out[j].Path
+	})
+	return out
+}
+
+func toFlags(in map[string]bool) map[Flag]bool {
+	out := map[Flag]bool{}
+	for name, enabled := range in {
+		if def, ok := Lookup(name); ok {
+			out[def.Name] = enabled
+		}
+	}
+	return out
+}
@@ -0,0 +1,89 @@
+package featureflag_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kubeshop/botkube/internal/featureflag"
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+func TestManager(t *testing.T) {
+	// given
+	cfg := config.Config{
+		Settings: config.Settings{
+			FeatureFlags: map[string]bool{
+				"aggregation": false,
+				"unknown":     true,
+			},
+		},
+		Communications: map[string]config.Communications{
+			"default-group": {
+				SocketSlack: config.SocketSlack{
+					Channels: config.IdentifiableMap[config.ChannelBindingsByName]{
+						"dev": {
+							Name:         "dev",
+							FeatureFlags: map[string]bool{"Aggregation": true, "aiExecutor": false},
+						},
+						"prod": {Name: "prod"},
+					},
+				},
+			},
+		},
+	}
+	m := featureflag.NewManager(cfg)
+
+	// then
+	assert.True(t, m.Enabled(featureflag.Aggregation, "dev"))
+	assert.False(t, m.Enabled(featureflag.Aggregation, "prod"))
+	assert.False(t, m.Enabled(featureflag.AIExecutor, "dev"))
+	assert.True(t, m.Enabled(featureflag.AIExecutor, "prod"))
+	assert.Equal(t, map[string]bool{"aggregation": false, "aiExecutor": true}, m.Global())
+
+	// when flags are changed at runtime
+	m.Set(featureflag.AIExecutor, "", false)
+	m.Set(featureflag.Aggregation, "prod", true)
+
+	// then
+	assert.Equal(t, []featureflag.Status{
+		{Definition: mustLookup(t, "aggregation"), Enabled: true, Origin: featureflag.ChannelOrigin},
+		{Definition: mustLookup(t, "aiExecutor"), Enabled: false, Origin: featureflag.GlobalOrigin},
+	}, m.Statuses("prod"))
+	assert.Equal(t, []featureflag.Status{
+		{Definition: mustLookup(t, "aggregation"), Enabled: false, Origin: featureflag.GlobalOrigin},
+		{Definition: mustLookup(t, "aiExecutor"), Enabled: false, Origin: featureflag.GlobalOrigin},
+	}, m.Statuses(""))
+}
+
+func TestNilManager(t *testing.T) {
+	var m *featureflag.Manager
+	assert.True(t, m.Enabled(featureflag.Aggregation, "dev"))
+	assert.Equal(t, featureflag.DefaultOrigin, m.Statuses("dev")[0].Origin)
+}
+
+func TestForExecutorPlugin(t *testing.T) {
+	tests := []struct {
+		plugin       string
+		expectedFlag featureflag.Flag
+		expectedOK   bool
+	}{
+		{plugin: "botkube/ai", expectedFlag: featureflag.AIExecutor, expectedOK: true},
+		{plugin: "mycompany/ai@v1.0.0", expectedFlag: featureflag.AIExecutor, expectedOK: true},
+		{plugin: "botkube/kubectl", expectedOK: false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.plugin, func(t *testing.T) {
+			flag, ok := featureflag.ForExecutorPlugin(tc.plugin)
+			assert.Equal(t, tc.expectedOK, ok)
+			assert.Equal(t, tc.expectedFlag, flag)
+		})
+	}
+}
+
+func mustLookup(t *testing.T, name string) featureflag.Definition {
+	t.Helper()
+	def, ok := featureflag.Lookup(name)
+	assert.True(t, ok)
+	return def
+}
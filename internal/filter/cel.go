@@ -0,0 +1,106 @@
+package filter
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/ext"
+)
+
+// InputVariables are variables prepared for a given Input by the Variables function.
+var InputVariables = []string{"event", "message", "source", "cluster"}
+
+// ActionVariables are variables available in action conditions.
+var ActionVariables = []string{"event", "object", "enrichments", "owner", "topOwnerRef"}
+
+var (
+	envsMu sync.Mutex
+	envs   = map[string]*cel.Env{}
+)
+
+// Program is a compiled Common Expression Language (CEL) expression.
+//
+// Besides the standard CEL functions and macros, the string extensions are available, e.g. `lowerAscii` or `upperAscii`.
+// Numbers of different types can be compared, as numbers in the event data are converted through JSON to doubles.
+type Program struct {
+	expr    string
+	program cel.Program
+}
+
+// Compile compiles a given expression which uses the filter variables: `event`, `message`, `source` and `cluster`.
+func Compile(expr string) (*Program, error) {
+	return CompileWithVariables(expr, InputVariables...)
+}
+
+// CompileWithVariables compiles a given expression which uses given variables. Variables are dynamically typed.
+func CompileWithVariables(expr string, names ...string) (*Program, error) {
+	env, err := envFor(names)
+	if err != nil {
+		return nil, err
+	}
+
+	ast, iss := env.Compile(expr)
+	if iss.Err() != nil {
+		return nil, compileError(iss)
+	}
+	program, err := env.Program(ast)
+	if err != nil {
+		return nil, err
+	}
+	return &Program{expr: expr, program: program}, nil
+}
+
+// EvalBool evaluates the expression with given variables and ensures that the result is a boolean.
+func (p *Program) EvalBool(vars map[string]any) (bool, error) {
+	out, _, err := p.program.Eval(vars)
+	if err != nil {
+		return false, err
+	}
+	b, ok := out.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("expression %q returned %s instead of bool", p.expr, out.Type().TypeName())
+	}
+	return b, nil
+}
+
+// String returns the source expression.
+func (p *Program) String() string {
+	return p.expr
+}
+
+// envFor returns a CEL environment with given variables. Environments are cached, as creating them is expensive.
+func envFor(names []string) (*cel.Env, error) {
+	key := strings.Join(names, ",")
+
+	envsMu.Lock()
+	defer envsMu.Unlock()
+
+	if env, ok := envs[key]; ok {
+		return env, nil
+	}
+
+	opts := []cel.EnvOption{
+		ext.Strings(),
+		cel.CrossTypeNumericComparisons(true),
+	}
+	for _, name := range names {
+		opts = append(opts, cel.Variable(name, cel.DynType))
+	}
+	env, err := cel.NewEnv(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("while creating CEL environment: %w", err)
+	}
+	envs[key] = env
+	return env, nil
+}
+
+// compileError returns the first CEL issue in a single line, so it can be displayed in validation issues and chat messages.
+func compileError(iss *cel.Issues) error {
+	errs := iss.Errors()
+	if len(errs) == 0 {
+		return iss.Err()
+	}
+	return fmt.Errorf("%s at position %d", errs[0].Message, errs[0].Location.Column())
+}
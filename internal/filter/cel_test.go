@@ -0,0 +1,109 @@
+package filter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgramEvalBool(t *testing.T) {
+	vars := map[string]any{
+		"event": map[string]any{
+			"Kind":      "Pod",
+			"Namespace": "prod-eu",
+			"Count":     float64(3),
+			"Messages":  []any{"Back-off restarting failed container", "OOMKilled"},
+			"Labels":    map[string]any{"team": "payments"},
+		},
+		"cluster": "prod",
+	}
+
+	tests := []struct {
+		expr     string
+		expected bool
+	}{
+		{expr: `event.Kind == "Pod" && event.Namespace.startsWith("prod-")`, expected: true},
+		{expr: `event.Kind in ["Node", "Deployment"]`, expected: false},
+		{expr: `event.Count >= 3 && event.Count < 10`, expected: true},
+		{expr: `event.Messages.exists(m, m.contains("OOM"))`, expected: true},
+		{expr: `event.Messages.all(m, m.size() > 10)`, expected: false},
+		{expr: `size(event.Messages.filter(m, m.matches("^Back-off"))) == 1`, expected: true},
+		{expr: `event.Labels["team"] == 'payments' && has(event.Labels.team)`, expected: true},
+		{expr: `(has(event.Reason) ? event.Reason : "none") == "none"`, expected: true},
+		{expr: `"team" in event.Labels && !("owner" in event.Labels)`, expected: true},
+		{expr: `cluster + "/" + event.Namespace.upperAscii() == "prod/PROD-EU"`, expected: true},
+		{expr: `int("2") * 3 + 1 - 10 % 4 == 5`, expected: true},
+		{expr: `event.Messages.map(m, size(m)) == [36, 9]`, expected: true},
+		{expr: `event.Reason == "BackOff" || event.Kind == "Pod"`, expected: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.expr, func(t *testing.T) {
+			prog, err := Compile(tc.expr)
+			require.NoError(t, err)
+
+			got, err := prog.EvalBool(vars)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+func TestProgramEvalBoolErrors(t *testing.T) {
+	vars := map[string]any{"event": map[string]any{"Kind": "Pod"}}
+
+	tests := []struct {
+		expr   string
+		expErr string
+	}{
+		{expr: `event.Reason == "BackOff"`, expErr: "no such key: Reason"},
+		{expr: `event.Kind`, expErr: `expression "event.Kind" returned string instead of bool`},
+		{expr: `event.Kind > 1`, expErr: "no such overload"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.expr, func(t *testing.T) {
+			prog, err := Compile(tc.expr)
+			require.NoError(t, err)
+
+			_, err = prog.EvalBool(vars)
+			assert.ErrorContains(t, err, tc.expErr)
+		})
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	tests := []struct {
+		expr   string
+		expErr string
+	}{
+		{expr: `event.Kind == "Pod`, expErr: "Syntax error"},
+		{expr: `event.Kind == `, expErr: "Syntax error"},
+		{expr: `event.Kind.capitalize()`, expErr: "undeclared reference to 'capitalize'"},
+		{expr: `foo == 1`, expErr: "undeclared reference to 'foo'"},
+		{expr: `object.kind == "Pod"`, expErr: "undeclared reference to 'object'"},
+		{expr: `event.Kind == "Pod" )`, expErr: "Syntax error"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.expr, func(t *testing.T) {
+			_, err := Compile(tc.expr)
+			assert.ErrorContains(t, err, tc.expErr)
+		})
+	}
+}
+
+func TestCompileWithVariables(t *testing.T) {
+	// given
+	vars := map[string]any{
+		"object":      map[string]any{"metadata": map[string]any{"labels": map[string]any{"app": "api"}}},
+		"topOwnerRef": "Deployment/prod/api",
+	}
+
+	// when
+	prog, err := CompileWithVariables(`object.metadata.labels.app == "api" && topOwnerRef.startsWith("Deployment/")`, ActionVariables...)
+	require.NoError(t, err)
+	got, err := prog.EvalBool(vars)
+
+	// then
+	require.NoError(t, err)
+	assert.True(t, got)
+}
@@ -0,0 +1,186 @@
+package filter
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/multierror"
+)
+
+// Input holds a source notification evaluated by filters.
+type Input struct {
+	SourceName        string
+	SourceDisplayName string
+	PluginName        string
+	ClusterName       string
+	Event             any
+	Message           api.Message
+}
+
+// Result holds the outcome of filters evaluation.
+type Result struct {
+	// Drop is set if the notification shouldn't be sent.
+	Drop bool
+	// RouteTo holds source bindings of channels which receive the notification instead of the evaluated one. It's empty if the notification is not rerouted.
+	RouteTo []string
+	// Header holds the message header if it was modified and the message doesn't have sections.
+	Header string
+	// Message holds the notification message with all modifications applied.
+	Message api.Message
+	// Matched holds names of filters which matched the notification, in the evaluation order.
+	Matched []string
+}
+
+type compiledFilter struct {
+	cfg     config.Filter
+	program *Program
+}
+
+// Engine evaluates filters for source notifications.
+type Engine struct {
+	log     logrus.FieldLogger
+	filters map[string]compiledFilter
+}
+
+// NewEngine compiles all enabled filters and returns a new Engine instance.
+func NewEngine(log logrus.FieldLogger, cfg config.Filters) (*Engine, error) {
+	names := make([]string, 0, len(cfg))
+	for name := range cfg {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	errs := multierror.New()
+	filters := map[string]compiledFilter{}
+	for _, name := range names {
+		filterCfg := cfg[name]
+		if !filterCfg.Enabled {
+			continue
+		}
+		program, err := Compile(filterCfg.Expression)
+		if err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("while compiling expression for filter %q: %w", name, err))
+			continue
+		}
+		filters[name] = compiledFilter{cfg: filterCfg, program: program}
+	}
+	if err := errs.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+
+	return &Engine{log: log, filters: filters}, nil
+}
+
+// Apply evaluates given filters, e.g. bound to a channel, for a given notification. Filters are evaluated in alphabetical order of their names.
+// Modifications are accumulated, the last matching reroute filter decides about the target source bindings,
+// and the first matching drop filter stops the evaluation.
+// Disabled filters are skipped. If a given filter cannot be evaluated, it's skipped too, so the notification is not lost.
+func (e *Engine) Apply(in Input, names []string) Result {
+	res := Result{Message: in.Message}
+
+	var filters []string
+	for _, name := range names {
+		if _, ok := e.filters[name]; ok {
+			filters = append(filters, name)
+		}
+	}
+	if len(filters) == 0 {
+		return res
+	}
+	sort.Strings(filters)
+
+	vars, err := Variables(in)
+	if err != nil {
+		e.log.WithError(err).Error("Cannot prepare filter variables. Skipping filters...")
+		return res
+	}
+
+	for _, name := range filters {
+		f := e.filters[name]
+		log := e.log.WithField("filter", name)
+		matched, err := f.program.EvalBool(vars)
+		if err != nil {
+			log.WithError(err).Warn("Cannot evaluate filter expression. Skipping...")
+			continue
+		}
+		if !matched {
+			continue
+		}
+
+		log.WithField("action", f.cfg.Action).Debug("Filter matched")
+		res.Matched = append(res.Matched, name)
+		switch f.cfg.Action {
+		case config.DropFilterAction:
+			res.Drop = true
+			return res
+		case config.RerouteFilterAction:
+			res.RouteTo = f.cfg.RouteTo
+		case config.ModifyFilterAction:
+			res.Header, res.Message = modify(res.Header, res.Message, f.cfg.Modify)
+		}
+	}
+	return res
+}
+
+// Variables converts the input into CEL variables. The event and message are converted through JSON,
+// so they are accessible the same way as in the JSON representation.
+func Variables(in Input) (map[string]any, error) {
+	event, err := toGeneric(in.Event)
+	if err != nil {
+		return nil, fmt.Errorf("while converting event: %w", err)
+	}
+	msg, err := toGeneric(in.Message)
+	if err != nil {
+		return nil, fmt.Errorf("while converting message: %w", err)
+	}
+
+	return map[string]any{
+		"event":   event,
+		"message": msg,
+		"source": map[string]any{
+			"name":        in.SourceName,
+			"displayName": in.SourceDisplayName,
+			"plugin":      in.PluginName,
+		},
+		"cluster": in.ClusterName,
+	}, nil
+}
+
+func toGeneric(in any) (any, error) {
+	raw, err := json.Marshal(in)
+	if err != nil {
+		return nil, err
+	}
+	var out any
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func modify(header string, msg api.Message, mod config.FilterModification) (string, api.Message) {
+	// don't mutate sections shared with the original message
+	msg.Sections = append([]api.Section(nil), msg.Sections...)
+
+	if mod.Header != "" {
+		if len(msg.Sections) > 0 {
+			msg.Sections[0].Header = mod.Header
+		} else {
+			header = mod.Header
+		}
+	}
+
+	if mod.Context != "" {
+		if len(msg.Sections) == 0 {
+			msg.Sections = append(msg.Sections, api.Section{})
+		}
+		last := &msg.Sections[len(msg.Sections)-1]
+		last.Context = append(append(api.ContextItems(nil), last.Context...), api.ContextItem{Text: mod.Context})
+	}
+	return header, msg
+}
@@ -0,0 +1,96 @@
+package filter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+func TestEngineApply(t *testing.T) {
+	// given
+	engine, err := NewEngine(loggerx.NewNoop(), config.Filters{
+		"a-label-prod": {
+			Enabled:    true,
+			Expression: `event.Namespace.startsWith("prod-")`,
+			Action:     config.ModifyFilterAction,
+			Modify:     config.FilterModification{Header: ":rotating_light: Production issue", Context: "Owner: SRE"},
+		},
+		"b-route-critical": {
+			Enabled:    true,
+			Expression: `event.Level == "error" && source.name == "k8s-err-events"`,
+			Action:     config.RerouteFilterAction,
+			RouteTo:    []string{"critical"},
+		},
+		"c-drop-kube-system": {
+			Enabled:    true,
+			Expression: `event.Namespace == "kube-system"`,
+			Action:     config.DropFilterAction,
+		},
+		"d-disabled": {
+			Enabled:    false,
+			Expression: `true`,
+			Action:     config.DropFilterAction,
+		},
+		"e-not-bound": {
+			Enabled:    true,
+			Expression: `true`,
+			Action:     config.DropFilterAction,
+		},
+		"f-invalid-field": {
+			Enabled:    true,
+			Expression: `event.Unknown == "foo"`,
+			Action:     config.DropFilterAction,
+		},
+	})
+	require.NoError(t, err)
+
+	msg := api.Message{
+		Sections: []api.Section{
+			{Base: api.Base{Header: "Pod error"}},
+		},
+	}
+	in := func(ns string) Input {
+		return Input{
+			SourceName: "k8s-err-events",
+			Event: struct {
+				Namespace string
+				Level     string
+			}{Namespace: ns, Level: "error"},
+			Message: msg,
+		}
+	}
+
+	// channel bindings are not sorted, and can reference disabled filters
+	bound := []string{"f-invalid-field", "d-disabled", "c-drop-kube-system", "b-route-critical", "a-label-prod"}
+
+	// when
+	prodRes := engine.Apply(in("prod-eu"), bound)
+	systemRes := engine.Apply(in("kube-system"), bound)
+	unboundRes := engine.Apply(in("kube-system"), nil)
+
+	// then
+	assert.False(t, prodRes.Drop)
+	assert.Equal(t, []string{"critical"}, prodRes.RouteTo)
+	assert.Equal(t, ":rotating_light: Production issue", prodRes.Message.Sections[0].Header)
+	assert.Equal(t, api.ContextItems{{Text: "Owner: SRE"}}, prodRes.Message.Sections[0].Context)
+	assert.Equal(t, "Pod error", msg.Sections[0].Header, "original message should not be modified")
+	assert.Equal(t, []string{"a-label-prod", "b-route-critical"}, prodRes.Matched)
+
+	assert.True(t, systemRes.Drop)
+	assert.Equal(t, []string{"b-route-critical", "c-drop-kube-system"}, systemRes.Matched)
+
+	assert.Equal(t, Result{Message: msg}, unboundRes)
+}
+
+func TestNewEngineInvalidExpression(t *testing.T) {
+	_, err := NewEngine(loggerx.NewNoop(), config.Filters{
+		"broken": {Enabled: true, Expression: `event.Kind ==`, Action: config.DropFilterAction},
+	})
+
+	assert.ErrorContains(t, err, `while compiling expression for filter "broken": Syntax error: mismatched input '<EOF>'`)
+}
@@ -0,0 +1,208 @@
+package health
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/plugin"
+)
+
+// ComponentKind is a kind of Botkube component.
+type ComponentKind string
+
+const (
+	// AgentComponentKind is the root component, which aggregates statuses of all other components.
+	AgentComponentKind ComponentKind = "agent"
+	// BotComponentKind is a communication platform bot, e.g. Slack.
+	BotComponentKind ComponentKind = "bot"
+	// SinkComponentKind is a communication platform sink, e.g. Elasticsearch.
+	SinkComponentKind ComponentKind = "sink"
+	// SourceComponentKind is a source configuration, e.g. `k8s-err-events`.
+	SourceComponentKind ComponentKind = "source"
+	// ExecutorComponentKind is an executor configuration, e.g. `k8s-default-tools`.
+	ExecutorComponentKind ComponentKind = "executor"
+	// ProcessorComponentKind is a processor configuration.
+	ProcessorComponentKind ComponentKind = "processor"
+	// PluginComponentKind is a plugin used by a source, executor or processor configuration.
+	PluginComponentKind ComponentKind = "plugin"
+)
+
+// Component describes the health of a single Botkube component. Components which consist of other ones aggregate their statuses.
+type Component struct {
+	Name   string            `json:"name"`
+	Kind   ComponentKind     `json:"kind"`
+	Status PlatformStatusMsg `json:"status"`
+	// Reason describes the status, e.g. why the component is unhealthy.
+	Reason string `json:"reason,omitempty"`
+	// LastError is the last error reported by the component. It may be set also for healthy components, which recovered from a failure.
+	LastError          string      `json:"lastError,omitempty"`
+	Restarts           string      `json:"restarts,omitempty"`
+	LastTransitionTime string      `json:"lastTransitionTime,omitempty"`
+	Components         []Component `json:"components,omitempty"`
+}
+
+// Readiness describes whether Botkube is ready to handle events and commands.
+type Readiness struct {
+	Ready bool `json:"ready"`
+	// Reason describes why Botkube is not ready.
+	Reason string `json:"reason,omitempty"`
+}
+
+// integrationTyper is implemented by notifiers which know whether they are bots or sinks.
+type integrationTyper interface {
+	Type() config.IntegrationType
+}
+
+// Components returns the health tree of all Botkube components.
+func (h *Checker) Components() Component {
+	var children []Component
+	children = append(children, h.notifierComponents()...)
+	if h.config != nil {
+		children = append(children, h.pluginConfigComponents(SourceComponentKind, enabledPlugins(h.config.Sources))...)
+		children = append(children, h.pluginConfigComponents(ExecutorComponentKind, enabledPlugins(h.config.Executors))...)
+		children = append(children, h.pluginConfigComponents(ProcessorComponentKind, enabledPlugins(h.config.Processors))...)
+	}
+
+	root := Component{
+		Name:       "botkube",
+		Kind:       AgentComponentKind,
+		Status:     aggregateStatus(children),
+		Components: children,
+	}
+	if !h.applicationStarted {
+		root.Status = StatusUnHealthy
+		root.Reason = "Botkube is starting"
+	}
+	return root
+}
+
+// Readiness returns whether Botkube is started, and at least one communication platform is healthy.
+func (h *Checker) Readiness() Readiness {
+	if !h.applicationStarted {
+		return Readiness{Reason: "Botkube is starting"}
+	}
+	if len(h.notifiers) == 0 {
+		return Readiness{Reason: "no communication platforms are configured"}
+	}
+	for _, n := range h.notifiers {
+		if n.GetStatus().Status == StatusHealthy {
+			return Readiness{Ready: true}
+		}
+	}
+	return Readiness{Reason: "none of communication platforms is healthy"}
+}
+
+func (h *Checker) notifierComponents() []Component {
+	out := make([]Component, 0, len(h.notifiers))
+	for key, n := range h.notifiers {
+		status := n.GetStatus()
+		kind := BotComponentKind
+		if typed, ok := n.(integrationTyper); ok && typed.Type() == config.SinkIntegrationType {
+			kind = SinkComponentKind
+		}
+		componentStatus := status.Status
+		if componentStatus == "" {
+			componentStatus = StatusUnknown
+		}
+		out = append(out, Component{
+			Name:      key,
+			Kind:      kind,
+			Status:    componentStatus,
+			Reason:    string(status.Reason),
+			LastError: status.ErrorMsg,
+			Restarts:  status.Restarts,
+		})
+	}
+	sortComponents(out)
+	return out
+}
+
+// pluginConfigComponents returns components of given configurations, e.g. sources, together with their enabled plugins.
+func (h *Checker) pluginConfigComponents(kind ComponentKind, pluginsByCfg map[string][]string) []Component {
+	pluginHealth := map[string]plugin.PluginHealth{}
+	for _, p := range h.pluginHealthStats.List() {
+		pluginHealth[p.Name] = p
+	}
+
+	out := make([]Component, 0, len(pluginsByCfg))
+	for cfgName, pluginNames := range pluginsByCfg {
+		plugins := make([]Component, 0, len(pluginNames))
+		for _, name := range pluginNames {
+			plugins = append(plugins, pluginComponent(name, pluginHealth[name]))
+		}
+		sortComponents(plugins)
+		out = append(out, Component{
+			Name:       cfgName,
+			Kind:       kind,
+			Status:     aggregateStatus(plugins),
+			Components: plugins,
+		})
+	}
+	sortComponents(out)
+	return out
+}
+
+func pluginComponent(name string, stats plugin.PluginHealth) Component {
+	out := Component{
+		Name:               name,
+		Kind:               PluginComponentKind,
+		Status:             StatusHealthy,
+		LastError:          stats.LastError,
+		LastTransitionTime: stats.LastTransitionTime,
+	}
+	if stats.Name == "" {
+		// not started yet, or the health isn't tracked, e.g. in tests
+		return out
+	}
+
+	out.Restarts = fmt.Sprintf("%d/%d", stats.Restarts, stats.Threshold)
+	if !stats.IsRunning() {
+		out.Status = StatusUnHealthy
+		out.Reason = stats.Status
+	}
+	return out
+}
+
+// aggregateStatus returns Healthy if all components are healthy, Unhealthy if all of them are unhealthy, and Degraded otherwise.
+func aggregateStatus(components []Component) PlatformStatusMsg {
+	var unhealthy, degraded int
+	for _, c := range components {
+		switch c.Status {
+		case StatusUnHealthy:
+			unhealthy++
+		case StatusDegraded:
+			degraded++
+		}
+	}
+	switch {
+	case unhealthy == 0 && degraded == 0:
+		return StatusHealthy
+	case unhealthy == len(components):
+		return StatusUnHealthy
+	default:
+		return StatusDegraded
+	}
+}
+
+func sortComponents(in []Component) {
+	sort.Slice(in, func(i, j int) bool {
+		if in[i].Kind != in[j].Kind {
+			return in[i].Kind < in[j].Kind
+		}
+		return in[i].Name < in[j].Name
+	})
+}
+
+// enabledPlugins returns names of enabled plugins indexed by names of given configurations.
+func enabledPlugins[T interface{ GetPlugins() config.Plugins }](cfgs map[string]T) map[string][]string {
+	out := map[string][]string{}
+	for cfgName, cfg := range cfgs {
+		for name, p := range cfg.GetPlugins() {
+			if p.Enabled {
+				out[cfgName] = append(out[cfgName], name)
+			}
+		}
+	}
+	return out
+}
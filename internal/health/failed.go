@@ -1,18 +1,22 @@
 package health
 
+import "github.com/kubeshop/botkube/pkg/config"
+
 // Failed represents failed platform.
 type Failed struct {
-	status        PlatformStatusMsg
-	failureReason FailureReasonMsg
-	errorMsg      string
+	integrationType config.IntegrationType
+	status          PlatformStatusMsg
+	failureReason   FailureReasonMsg
+	errorMsg        string
 }
 
 // NewFailed creates a new Failed instance.
-func NewFailed(failureReason FailureReasonMsg, errorMsg string) *Failed {
+func NewFailed(integrationType config.IntegrationType, failureReason FailureReasonMsg, errorMsg string) *Failed {
 	return &Failed{
-		status:        StatusUnHealthy,
-		failureReason: failureReason,
-		errorMsg:      errorMsg,
+		integrationType: integrationType,
+		status:          StatusUnHealthy,
+		failureReason:   failureReason,
+		errorMsg:        errorMsg,
 	}
 }
 
@@ -25,3 +29,8 @@ func (b *Failed) GetStatus() PlatformStatus {
 		ErrorMsg: b.errorMsg,
 	}
 }
+
+// Type returns the integration type of the failed platform.
+func (b *Failed) Type() config.IntegrationType {
+	return b.integrationType
+}
@@ -9,13 +9,17 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/sirupsen/logrus"
 
+	"github.com/kubeshop/botkube/internal/featureflag"
 	"github.com/kubeshop/botkube/pkg/config"
 	"github.com/kubeshop/botkube/pkg/httpx"
 	"github.com/kubeshop/botkube/pkg/plugin"
 )
 
 const (
-	healthEndpointName = "/healthz"
+	healthEndpointName    = "/healthz"
+	readinessEndpointName = "/readyz"
+	doctorEndpointName    = "/doctor"
+	replayEndpointName    = "/replay"
 )
 
 // Notifier represents notifier interface
@@ -30,6 +34,9 @@ type Checker struct {
 	config             *config.Config
 	pluginHealthStats  *plugin.HealthStats
 	notifiers          map[string]Notifier
+	featureFlags       *featureflag.Manager
+	doctor             http.Handler
+	replay             http.Handler
 }
 
 // NewChecker create new health checker.
@@ -72,14 +79,70 @@ func (h *Checker) ServeHTTP(resp http.ResponseWriter, _ *http.Request) {
 	_, _ = fmt.Fprint(resp, string(respJSon))
 }
 
+// serveReadiness serves the readiness on the readiness endpoint. It responds with 503 until Botkube is started,
+// and at least one communication platform is healthy.
+func (h *Checker) serveReadiness(resp http.ResponseWriter, _ *http.Request) {
+	readiness := h.Readiness()
+	statusCode := http.StatusOK
+	if !readiness.Ready {
+		statusCode = http.StatusServiceUnavailable
+	}
+
+	respJSON, err := json.Marshal(readiness)
+	if err != nil {
+		http.Error(resp, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(statusCode)
+	_, _ = resp.Write(respJSON)
+}
+
 // NewServer creates http server for health checker.
 func (h *Checker) NewServer(log logrus.FieldLogger, port string) *httpx.Server {
 	addr := fmt.Sprintf(":%s", port)
 	router := mux.NewRouter()
 	router.Handle(healthEndpointName, h)
+	router.HandleFunc(readinessEndpointName, h.serveReadiness)
+	router.HandleFunc(doctorEndpointName, h.serveDoctor)
+	router.HandleFunc(replayEndpointName, h.serveReplay)
 	return httpx.NewServer(log, addr, router)
 }
 
+// SetFeatureFlags sets the feature flags manager used to report global states of feature flags.
+func (h *Checker) SetFeatureFlags(flags *featureflag.Manager) {
+	h.featureFlags = flags
+}
+
+// SetDoctor sets the handler which serves the diagnostics report on the doctor endpoint.
+func (h *Checker) SetDoctor(doctor http.Handler) {
+	h.doctor = doctor
+}
+
+// serveDoctor serves the diagnostics report. It responds with 404 until the doctor is set.
+func (h *Checker) serveDoctor(resp http.ResponseWriter, req *http.Request) {
+	if h.doctor == nil {
+		http.NotFound(resp, req)
+		return
+	}
+	h.doctor.ServeHTTP(resp, req)
+}
+
+// SetReplay sets the handler which replays events on the replay endpoint.
+func (h *Checker) SetReplay(replay http.Handler) {
+	h.replay = replay
+}
+
+// serveReplay replays events in the notification pipeline. It responds with 404 until the handler is set.
+func (h *Checker) serveReplay(resp http.ResponseWriter, req *http.Request) {
+	if h.replay == nil {
+		http.NotFound(resp, req)
+		return
+	}
+	h.replay.ServeHTTP(resp, req)
+}
+
 // AddNotifier add platform bot instance
 func (h *Checker) AddNotifier(key string, notifier Notifier) {
 	h.notifiers[key] = notifier
@@ -89,13 +152,18 @@ func (h *Checker) GetStatus() *Status {
 	pluginsStats := make(map[string]PluginStatus)
 	h.collectSourcePluginsStatuses(pluginsStats)
 	h.collectExecutorPluginsStatuses(pluginsStats)
+	h.collectProcessorPluginsStatuses(pluginsStats)
 
+	components := h.Components()
 	return &Status{
 		Botkube: BotStatus{
 			Status: h.getBotkubeStatus(),
 		},
-		Plugins:   pluginsStats,
-		Platforms: h.getPlatformsStatus(),
+		Plugins:      pluginsStats,
+		Platforms:    h.getPlatformsStatus(),
+		FeatureFlags: h.getFeatureFlags(),
+		Components:   &components,
+		Readiness:    h.Readiness(),
 	}
 }
 
@@ -121,6 +189,17 @@ func (h *Checker) collectExecutorPluginsStatuses(plugins map[string]PluginStatus
 	}
 }
 
+func (h *Checker) collectProcessorPluginsStatuses(plugins map[string]PluginStatus) {
+	if h.config == nil {
+		return
+	}
+	for pluginConfigName, processorValues := range h.config.Processors {
+		for pluginName, pluginValues := range processorValues.GetPlugins() {
+			h.collectPluginStatus(plugins, pluginConfigName, pluginName, pluginValues.Enabled)
+		}
+	}
+}
+
 func (h *Checker) collectPluginStatus(plugins map[string]PluginStatus, pluginConfigName string, pluginName string, enabled bool) {
 	status, restarts, threshold, _ := h.pluginHealthStats.GetStats(pluginName)
 	plugins[pluginConfigName] = PluginStatus{
@@ -137,6 +216,13 @@ func (h *Checker) getBotkubeStatus() BotkubeStatus {
 	return BotkubeStatusUnhealthy
 }
 
+func (h *Checker) getFeatureFlags() map[string]bool {
+	if h.featureFlags == nil {
+		return nil
+	}
+	return h.featureFlags.Global()
+}
+
 func (h *Checker) getPlatformsStatus() platformStatuses {
 	defaultStatuses := platformStatuses{}
 	if h.notifiers != nil {
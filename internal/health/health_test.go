@@ -3,14 +3,18 @@ package health
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/kubeshop/botkube/internal/featureflag"
 	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/plugin"
 )
 
 func TestServeHTTPUnavailable(t *testing.T) {
@@ -61,3 +65,153 @@ func TestServeHTTPOK(t *testing.T) {
 	assert.Equal(t, BotkubeStatusHealthy, resp.Botkube.Status)
 	assert.Equal(t, resp.Botkube.Status, expectedStatus.Botkube.Status)
 }
+
+func TestGetStatusFeatureFlags(t *testing.T) {
+	// given
+	cfg := &config.Config{
+		Settings: config.Settings{
+			FeatureFlags: map[string]bool{"aiExecutor": false},
+		},
+	}
+	checker := NewChecker(context.TODO(), cfg, nil)
+	checker.SetFeatureFlags(featureflag.NewManager(*cfg))
+
+	// when
+	status := checker.GetStatus()
+
+	// then
+	assert.Equal(t, map[string]bool{"aggregation": true, "aiExecutor": false}, status.FeatureFlags)
+}
+
+func TestComponents(t *testing.T) {
+	// given
+	cfg := &config.Config{
+		Sources: map[string]config.Sources{
+			"k8s-events": {Plugins: config.Plugins{
+				"botkube/kubernetes": {Enabled: true},
+				"botkube/prometheus": {Enabled: false},
+			}},
+		},
+		Executors: map[string]config.Executors{
+			"helm": {Plugins: config.Plugins{"botkube/helm": {Enabled: true}}},
+		},
+	}
+	stats := plugin.NewHealthStats(2)
+	stats.Increment("botkube/helm")
+	stats.MarkDeactivated("botkube/helm", time.Time{})
+	stats.SetLastError("botkube/helm", errors.New("plugin is not responding"))
+
+	checker := NewChecker(context.TODO(), cfg, stats)
+	checker.AddNotifier("slack", &fakeNotifier{status: PlatformStatus{Status: StatusHealthy}, typ: config.BotIntegrationType})
+	checker.AddNotifier("elasticsearch", &fakeNotifier{
+		status: PlatformStatus{Status: StatusUnHealthy, Reason: FailureReasonConnectionError, ErrorMsg: "connection refused"},
+		typ:    config.SinkIntegrationType,
+	})
+	checker.MarkAsReady()
+
+	// when
+	root := checker.Components()
+
+	// then
+	helmPlugin := stats.List()[0]
+	assert.Equal(t, Component{
+		Name:   "botkube",
+		Kind:   AgentComponentKind,
+		Status: StatusDegraded,
+		Components: []Component{
+			{Name: "slack", Kind: BotComponentKind, Status: StatusHealthy},
+			{Name: "elasticsearch", Kind: SinkComponentKind, Status: StatusUnHealthy, Reason: string(FailureReasonConnectionError), LastError: "connection refused"},
+			{Name: "k8s-events", Kind: SourceComponentKind, Status: StatusHealthy, Components: []Component{
+				{Name: "botkube/kubernetes", Kind: PluginComponentKind, Status: StatusHealthy},
+			}},
+			{Name: "helm", Kind: ExecutorComponentKind, Status: StatusUnHealthy, Components: []Component{
+				{
+					Name:               "botkube/helm",
+					Kind:               PluginComponentKind,
+					Status:             StatusUnHealthy,
+					Reason:             helmPlugin.Status,
+					LastError:          "plugin is not responding",
+					Restarts:           "1/2",
+					LastTransitionTime: helmPlugin.LastTransitionTime,
+				},
+			}},
+		},
+	}, root)
+}
+
+func TestReadiness(t *testing.T) {
+	tests := []struct {
+		name      string
+		started   bool
+		notifiers map[string]Notifier
+		expected  Readiness
+	}{
+		{
+			name:     "not started",
+			expected: Readiness{Reason: "Botkube is starting"},
+		},
+		{
+			name:     "no communication platforms",
+			started:  true,
+			expected: Readiness{Reason: "no communication platforms are configured"},
+		},
+		{
+			name:    "all communication platforms unhealthy",
+			started: true,
+			notifiers: map[string]Notifier{
+				"slack": &fakeNotifier{status: PlatformStatus{Status: StatusUnHealthy}},
+			},
+			expected: Readiness{Reason: "none of communication platforms is healthy"},
+		},
+		{
+			name:    "at least one communication platform healthy",
+			started: true,
+			notifiers: map[string]Notifier{
+				"slack":   &fakeNotifier{status: PlatformStatus{Status: StatusUnHealthy}},
+				"discord": &fakeNotifier{status: PlatformStatus{Status: StatusHealthy}},
+			},
+			expected: Readiness{Ready: true},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			checker := NewChecker(context.TODO(), &config.Config{}, nil)
+			for key, n := range tc.notifiers {
+				checker.AddNotifier(key, n)
+			}
+			if tc.started {
+				checker.MarkAsReady()
+			}
+			req := httptest.NewRequest(http.MethodGet, readinessEndpointName, nil)
+			rr := httptest.NewRecorder()
+
+			// when
+			checker.serveReadiness(rr, req)
+
+			// then
+			expectedCode := http.StatusServiceUnavailable
+			if tc.expected.Ready {
+				expectedCode = http.StatusOK
+			}
+			assert.Equal(t, expectedCode, rr.Code)
+
+			var resp Readiness
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+			assert.Equal(t, tc.expected, resp)
+		})
+	}
+}
+
+type fakeNotifier struct {
+	status PlatformStatus
+	typ    config.IntegrationType
+}
+
+func (f *fakeNotifier) GetStatus() PlatformStatus {
+	return f.status
+}
+
+func (f *fakeNotifier) Type() config.IntegrationType {
+	return f.typ
+}
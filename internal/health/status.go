@@ -12,6 +12,8 @@ const (
 	StatusUnknown   PlatformStatusMsg = "Unknown"
 	StatusHealthy   PlatformStatusMsg = "Healthy"
 	StatusUnHealthy PlatformStatusMsg = "Unhealthy"
+	// StatusDegraded is reported by components which consist of other ones, if some of them are unhealthy.
+	StatusDegraded PlatformStatusMsg = "Degraded"
 )
 
 const (
@@ -33,6 +35,11 @@ type Status struct {
 	Botkube   BotStatus               `json:"botkube"`
 	Plugins   map[string]PluginStatus `json:"plugins,omitempty"`
 	Platforms platformStatuses        `json:"platforms,omitempty"`
+	// FeatureFlags holds global states of feature flags, indexed by their names.
+	FeatureFlags map[string]bool `json:"featureFlags,omitempty"`
+	// Components holds the health tree of all Botkube components.
+	Components *Component `json:"components,omitempty"`
+	Readiness  Readiness  `json:"readiness"`
 }
 
 type platformStatuses map[string]PlatformStatus
@@ -0,0 +1,109 @@
+// Package identity resolves chat users to Kubernetes users and groups, which are impersonated by executors.
+package identity
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+// ChatUser holds details about a chat user known by a communication platform. Not all platforms provide all details.
+// Display names are not part of it, as users can change them freely, so they can't identify anyone.
+type ChatUser struct {
+	ID string
+	// Email must be verified by the communication platform.
+	Email string
+}
+
+// Identity is a Kubernetes user together with its groups.
+type Identity struct {
+	User   string
+	Groups []string
+}
+
+// Resolver resolves chat users according to the identity mapping configuration.
+type Resolver struct {
+	users map[string]config.KubernetesIdentity
+	oidc  config.OIDCIdentityMapping
+	// groupsByEmail holds OIDC groups indexed by lowercase emails of their members.
+	groupsByEmail map[string][]string
+}
+
+// NewResolver returns a new Resolver instance.
+func NewResolver(cfg config.IdentityMapping) *Resolver {
+	users := map[string]config.KubernetesIdentity{}
+	for key, id := range cfg.Users {
+		users[strings.ToLower(key)] = id
+	}
+
+	groupsByEmail := map[string][]string{}
+	for group, members := range cfg.OIDC.Groups {
+		for _, email := range members {
+			email = strings.ToLower(email)
+			groupsByEmail[email] = append(groupsByEmail[email], group)
+		}
+	}
+	for email := range groupsByEmail {
+		sort.Strings(groupsByEmail[email])
+	}
+
+	return &Resolver{
+		users:         users,
+		oidc:          cfg.OIDC,
+		groupsByEmail: groupsByEmail,
+	}
+}
+
+// Resolve returns the Kubernetes identity of a given chat user. Static users are matched by the ID and email,
+// in that order. Otherwise, if the OIDC mapping is enabled, the identity is built from the user email.
+// It returns false if the user cannot be resolved.
+func (r *Resolver) Resolve(user ChatUser) (Identity, bool) {
+	for _, key := range []string{user.ID, user.Email} {
+		if key == "" {
+			continue
+		}
+		id, found := r.users[strings.ToLower(key)]
+		if found && id.User != "" {
+			return Identity{User: id.User, Groups: id.Groups}, true
+		}
+	}
+
+	return r.resolveOIDC(user.Email)
+}
+
+func (r *Resolver) resolveOIDC(email string) (Identity, bool) {
+	if !r.oidc.Enabled || email == "" {
+		return Identity{}, false
+	}
+
+	email = strings.ToLower(email)
+	if !r.isAllowedDomain(email) {
+		return Identity{}, false
+	}
+
+	var groups []string
+	for _, group := range r.groupsByEmail[email] {
+		groups = append(groups, r.oidc.GroupsPrefix+group)
+	}
+	return Identity{
+		User:   r.oidc.UsernamePrefix + email,
+		Groups: groups,
+	}, true
+}
+
+func (r *Resolver) isAllowedDomain(email string) bool {
+	if len(r.oidc.AllowedDomains) == 0 {
+		return true
+	}
+	_, domain, found := strings.Cut(email, "@")
+	if !found {
+		return false
+	}
+	for _, allowed := range r.oidc.AllowedDomains {
+		if strings.EqualFold(domain, allowed) {
+			return true
+		}
+	}
+	return false
+}
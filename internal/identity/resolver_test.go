@@ -0,0 +1,79 @@
+package identity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+func TestResolve(t *testing.T) {
+	// given
+	resolver := NewResolver(config.IdentityMapping{
+		Users: map[string]config.KubernetesIdentity{
+			"U0123":           {User: "alice", Groups: []string{"sre"}},
+			"Bob@Example.com": {User: "bob"},
+		},
+		OIDC: config.OIDCIdentityMapping{
+			Enabled:        true,
+			UsernamePrefix: "oidc:",
+			GroupsPrefix:   "oidc:",
+			Groups: map[string][]string{
+				"developers": {"carol@example.com"},
+				"admins":     {"Carol@example.com", "dave@example.com"},
+			},
+			AllowedDomains: []string{"example.com"},
+		},
+	})
+
+	tests := []struct {
+		name          string
+		user          ChatUser
+		expected      Identity
+		expectedFound bool
+	}{
+		{
+			name:          "static user by ID",
+			user:          ChatUser{ID: "U0123", Email: "carol@example.com"},
+			expected:      Identity{User: "alice", Groups: []string{"sre"}},
+			expectedFound: true,
+		},
+		{
+			name:          "static user by email",
+			user:          ChatUser{ID: "U0456", Email: "bob@example.com"},
+			expected:      Identity{User: "bob"},
+			expectedFound: true,
+		},
+		{
+			name:          "OIDC user with groups",
+			user:          ChatUser{ID: "U0789", Email: "Carol@Example.com"},
+			expected:      Identity{User: "oidc:carol@example.com", Groups: []string{"oidc:admins", "oidc:developers"}},
+			expectedFound: true,
+		},
+		{
+			name:          "OIDC user without groups",
+			user:          ChatUser{Email: "erin@example.com"},
+			expected:      Identity{User: "oidc:erin@example.com"},
+			expectedFound: true,
+		},
+		{
+			name: "OIDC user from not allowed domain",
+			user: ChatUser{Email: "mallory@evil.com"},
+		},
+		{
+			name: "user without email",
+			user: ChatUser{ID: "U0999"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			id, found := resolver.Resolve(tc.user)
+
+			// then
+			assert.Equal(t, tc.expectedFound, found)
+			assert.Equal(t, tc.expected, id)
+		})
+	}
+}
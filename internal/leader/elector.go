@@ -0,0 +1,99 @@
+// Package leader provides the Lease-based leader election, so only one of multiple Botkube replicas
+// handles events and chat traffic, while others stand by to take over.
+package leader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+
+	"github.com/kubeshop/botkube/internal/metrics"
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+// ErrLeadershipLost is returned by Elector.Start once the leadership couldn't be renewed, e.g. during a network partition.
+var ErrLeadershipLost = errors.New("leadership lost")
+
+// Elector elects the leader using a Kubernetes Lease.
+type Elector struct {
+	log      logrus.FieldLogger
+	identity string
+	elector  *leaderelection.LeaderElector
+
+	electedOnce sync.Once
+	elected     chan struct{}
+}
+
+// New returns a new Elector. The identity must be unique across replicas, e.g. the Pod name.
+func New(log logrus.FieldLogger, cfg config.LeaderElection, cli kubernetes.Interface, identity string) (*Elector, error) {
+	e := &Elector{
+		log:      log,
+		identity: identity,
+		elected:  make(chan struct{}),
+	}
+
+	var err error
+	e.elector, err = leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+		Lock: &resourcelock.LeaseLock{
+			LeaseMeta: metav1.ObjectMeta{
+				Name:      cfg.Lease.Name,
+				Namespace: cfg.Lease.Namespace,
+			},
+			Client: cli.CoordinationV1(),
+			LockConfig: resourcelock.ResourceLockConfig{
+				Identity: identity,
+			},
+		},
+		LeaseDuration: cfg.LeaseDuration,
+		RenewDeadline: cfg.RenewDeadline,
+		RetryPeriod:   cfg.RetryPeriod,
+		// the Lease is released once Botkube is stopped, so a standby replica takes over without waiting for the Lease to expire
+		ReleaseOnCancel: true,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(context.Context) {
+				metrics.SetLeader(true)
+				e.electedOnce.Do(func() { close(e.elected) })
+			},
+			OnStoppedLeading: func() {
+				metrics.SetLeader(false)
+			},
+			OnNewLeader: func(identity string) {
+				if identity == e.identity {
+					return
+				}
+				e.log.Infof("Replica %q is the leader.", identity)
+			},
+		},
+		Name: cfg.Lease.Name,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("while creating leader elector: %w", err)
+	}
+	return e, nil
+}
+
+// Start takes part in the election until the context is done. Once elected, the leadership is renewed.
+// It returns ErrLeadershipLost if the leadership was lost before the context was done.
+func (e *Elector) Start(ctx context.Context) error {
+	e.log.Infof("Starting leader election as %q...", e.identity)
+	metrics.SetLeader(false)
+	e.elector.Run(ctx)
+
+	if ctx.Err() != nil {
+		return nil
+	}
+	e.log.Warn("Leadership lost. All components are stopped to stand by again.")
+	return ErrLeadershipLost
+}
+
+// Elected returns a channel which is closed once the replica is elected.
+func (e *Elector) Elected() <-chan struct{} {
+	return e.elected
+}
@@ -0,0 +1,86 @@
+package leader
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+func TestElectorTakesOverOnceLeaderIsStopped(t *testing.T) {
+	// given
+	cli := fake.NewSimpleClientset()
+	cfg := config.LeaderElection{
+		Enabled:       true,
+		Lease:         config.K8sResourceRef{Name: "botkube-leader", Namespace: "botkube"},
+		LeaseDuration: 2 * time.Second,
+		RenewDeadline: time.Second,
+		RetryPeriod:   100 * time.Millisecond,
+	}
+
+	first, err := New(loggerx.NewNoop(), cfg, cli, "botkube-0")
+	require.NoError(t, err)
+	second, err := New(loggerx.NewNoop(), cfg, cli, "botkube-1")
+	require.NoError(t, err)
+
+	firstCtx, stopFirst := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() { firstErr <- first.Start(firstCtx) }()
+	waitForElection(t, first)
+
+	secondCtx, stopSecond := context.WithCancel(context.Background())
+	defer stopSecond()
+	go func() { _ = second.Start(secondCtx) }()
+
+	select {
+	case <-second.Elected():
+		t.Fatal("standby replica was elected while the leader is running")
+	case <-time.After(500 * time.Millisecond):
+	}
+
+	// when
+	stopFirst()
+
+	// then
+	require.NoError(t, <-firstErr)
+	waitForElection(t, second)
+
+	lease, err := cli.CoordinationV1().Leases("botkube").Get(context.Background(), "botkube-leader", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.NotNil(t, lease.Spec.HolderIdentity)
+	assert.Equal(t, "botkube-1", *lease.Spec.HolderIdentity)
+}
+
+func TestNewFailsOnInvalidDurations(t *testing.T) {
+	// given
+	cfg := config.LeaderElection{
+		Enabled:       true,
+		Lease:         config.K8sResourceRef{Name: "botkube-leader", Namespace: "botkube"},
+		LeaseDuration: 10 * time.Second,
+		RenewDeadline: 15 * time.Second,
+		RetryPeriod:   2 * time.Second,
+	}
+
+	// when
+	_, err := New(loggerx.NewNoop(), cfg, fake.NewSimpleClientset(), "botkube-0")
+
+	// then
+	assert.EqualError(t, err, "while creating leader elector: leaseDuration must be greater than renewDeadline")
+}
+
+func waitForElection(t *testing.T, e *Elector) {
+	t.Helper()
+
+	select {
+	case <-e.Elected():
+	case <-time.After(5 * time.Second):
+		t.Fatalf("replica %q was not elected", e.identity)
+	}
+}
@@ -0,0 +1,165 @@
+package livewatch
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+)
+
+var kinds = []kind{
+	{
+		name:    "deployment",
+		aliases: []string{"deployments", "deploy"},
+		get: func(ctx context.Context, cli kubernetes.Interface, namespace, name string) (runtime.Object, error) {
+			return cli.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		},
+		watch: func(ctx context.Context, cli kubernetes.Interface, namespace string, opts metav1.ListOptions) (watch.Interface, error) {
+			return cli.AppsV1().Deployments(namespace).Watch(ctx, opts)
+		},
+		status: func(obj runtime.Object) Status {
+			d, ok := obj.(*appsv1.Deployment)
+			if !ok {
+				return Status{}
+			}
+			var conditions []string
+			for _, c := range d.Status.Conditions {
+				conditions = append(conditions, formatCondition(string(c.Type), string(c.Status), c.Reason))
+			}
+			return Status{
+				Fields: []Field{
+					{Key: "Ready", Value: fmt.Sprintf("%d/%d", d.Status.ReadyReplicas, replicas(d.Spec.Replicas))},
+					{Key: "Up-to-date", Value: fmt.Sprintf("%d", d.Status.UpdatedReplicas)},
+					{Key: "Available", Value: fmt.Sprintf("%d", d.Status.AvailableReplicas)},
+				},
+				Conditions: conditions,
+				Images:     images(d.Spec.Template.Spec),
+			}
+		},
+	},
+	{
+		name:    "statefulset",
+		aliases: []string{"statefulsets", "sts"},
+		get: func(ctx context.Context, cli kubernetes.Interface, namespace, name string) (runtime.Object, error) {
+			return cli.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		},
+		watch: func(ctx context.Context, cli kubernetes.Interface, namespace string, opts metav1.ListOptions) (watch.Interface, error) {
+			return cli.AppsV1().StatefulSets(namespace).Watch(ctx, opts)
+		},
+		status: func(obj runtime.Object) Status {
+			s, ok := obj.(*appsv1.StatefulSet)
+			if !ok {
+				return Status{}
+			}
+			var conditions []string
+			for _, c := range s.Status.Conditions {
+				conditions = append(conditions, formatCondition(string(c.Type), string(c.Status), c.Reason))
+			}
+			return Status{
+				Fields: []Field{
+					{Key: "Ready", Value: fmt.Sprintf("%d/%d", s.Status.ReadyReplicas, replicas(s.Spec.Replicas))},
+					{Key: "Up-to-date", Value: fmt.Sprintf("%d", s.Status.UpdatedReplicas)},
+					{Key: "Available", Value: fmt.Sprintf("%d", s.Status.AvailableReplicas)},
+				},
+				Conditions: conditions,
+				Images:     images(s.Spec.Template.Spec),
+			}
+		},
+	},
+	{
+		name:    "daemonset",
+		aliases: []string{"daemonsets", "ds"},
+		get: func(ctx context.Context, cli kubernetes.Interface, namespace, name string) (runtime.Object, error) {
+			return cli.AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		},
+		watch: func(ctx context.Context, cli kubernetes.Interface, namespace string, opts metav1.ListOptions) (watch.Interface, error) {
+			return cli.AppsV1().DaemonSets(namespace).Watch(ctx, opts)
+		},
+		status: func(obj runtime.Object) Status {
+			d, ok := obj.(*appsv1.DaemonSet)
+			if !ok {
+				return Status{}
+			}
+			var conditions []string
+			for _, c := range d.Status.Conditions {
+				conditions = append(conditions, formatCondition(string(c.Type), string(c.Status), c.Reason))
+			}
+			return Status{
+				Fields: []Field{
+					{Key: "Ready", Value: fmt.Sprintf("%d/%d", d.Status.NumberReady, d.Status.DesiredNumberScheduled)},
+					{Key: "Up-to-date", Value: fmt.Sprintf("%d", d.Status.UpdatedNumberScheduled)},
+					{Key: "Available", Value: fmt.Sprintf("%d", d.Status.NumberAvailable)},
+				},
+				Conditions: conditions,
+				Images:     images(d.Spec.Template.Spec),
+			}
+		},
+	},
+	{
+		name:    "pod",
+		aliases: []string{"pods", "po"},
+		get: func(ctx context.Context, cli kubernetes.Interface, namespace, name string) (runtime.Object, error) {
+			return cli.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+		},
+		watch: func(ctx context.Context, cli kubernetes.Interface, namespace string, opts metav1.ListOptions) (watch.Interface, error) {
+			return cli.CoreV1().Pods(namespace).Watch(ctx, opts)
+		},
+		status: func(obj runtime.Object) Status {
+			p, ok := obj.(*corev1.Pod)
+			if !ok {
+				return Status{}
+			}
+			var (
+				ready    int
+				restarts int32
+			)
+			for _, s := range p.Status.ContainerStatuses {
+				if s.Ready {
+					ready++
+				}
+				restarts += s.RestartCount
+			}
+			var conditions []string
+			for _, c := range p.Status.Conditions {
+				conditions = append(conditions, formatCondition(string(c.Type), string(c.Status), c.Reason))
+			}
+			return Status{
+				Fields: []Field{
+					{Key: "Phase", Value: string(p.Status.Phase)},
+					{Key: "Ready", Value: fmt.Sprintf("%d/%d", ready, len(p.Spec.Containers))},
+					{Key: "Restarts", Value: fmt.Sprintf("%d", restarts)},
+				},
+				Conditions: conditions,
+				Images:     images(p.Spec),
+			}
+		},
+	},
+}
+
+func formatCondition(condType, status, reason string) string {
+	if reason == "" {
+		return fmt.Sprintf("%s=%s", condType, status)
+	}
+	return fmt.Sprintf("%s=%s (%s)", condType, status, reason)
+}
+
+// replicas returns the desired number of replicas, which defaults to 1 if it's not set.
+func replicas(in *int32) int32 {
+	if in == nil {
+		return 1
+	}
+	return *in
+}
+
+func images(spec corev1.PodSpec) []string {
+	var out []string
+	for _, c := range spec.Containers {
+		out = append(out, fmt.Sprintf("%s: %s", c.Name, c.Image))
+	}
+	return out
+}
@@ -0,0 +1,269 @@
+package livewatch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+const (
+	defaultDuration       = 10 * time.Minute
+	defaultMaxDuration    = time.Hour
+	defaultUpdateInterval = 5 * time.Second
+	rewatchDelay          = time.Second
+)
+
+// ErrNotFound is returned when a watched resource doesn't exist.
+var ErrNotFound = errors.New("resource not found")
+
+// Ref identifies a watched resource.
+type Ref struct {
+	// Kind is the canonical kind name, e.g. `deployment`.
+	Kind      string
+	Namespace string
+	Name      string
+}
+
+// String returns the reference in the `kind/name` form.
+func (r Ref) String() string {
+	return fmt.Sprintf("%s/%s", r.Kind, r.Name)
+}
+
+// Status describes the state of a watched resource.
+type Status struct {
+	// Deleted is true if the resource was deleted. Other fields are empty in such case.
+	Deleted bool
+	// Fields hold kind-specific details, such as replicas, in a stable order.
+	Fields []Field
+	// Conditions are formatted as `Type=Status`, followed by the reason if it's set.
+	Conditions []string
+	Images     []string
+}
+
+// Field is a single status detail.
+type Field struct {
+	Key   string
+	Value string
+}
+
+// ParseRef parses a resource given in the `kind/name` form. Kinds may be specified with their plural and short names,
+// the same as in kubectl.
+func ParseRef(in, namespace string) (Ref, error) {
+	kindName, name, found := strings.Cut(in, "/")
+	if !found || name == "" {
+		return Ref{}, fmt.Errorf("resource %q must be given in the kind/name form, e.g. deployment/foo", in)
+	}
+	k, found := findKind(kindName)
+	if !found {
+		return Ref{}, fmt.Errorf("kind %q is not supported, use one of: %s", kindName, strings.Join(KindNames(), ", "))
+	}
+	if namespace == "" {
+		namespace = metav1.NamespaceDefault
+	}
+	return Ref{Kind: k.name, Namespace: namespace, Name: name}, nil
+}
+
+// Watcher watches single resources and reports their status on every change.
+type Watcher struct {
+	cfg config.Watch
+
+	// newK8sCli returns a client which impersonates configured groups.
+	newK8sCli func() (kubernetes.Interface, error)
+}
+
+// New returns a new Watcher instance.
+func New(cfg config.Watch, restCfg *rest.Config) *Watcher {
+	if cfg.Duration <= 0 {
+		cfg.Duration = defaultDuration
+	}
+	if cfg.MaxDuration <= 0 {
+		cfg.MaxDuration = defaultMaxDuration
+	}
+	if cfg.UpdateInterval <= 0 {
+		cfg.UpdateInterval = defaultUpdateInterval
+	}
+	if len(cfg.Groups) == 0 {
+		cfg.Groups = []string{config.RBACDefaultGroup}
+	}
+
+	return &Watcher{
+		cfg: cfg,
+		newK8sCli: func() (kubernetes.Interface, error) {
+			if restCfg == nil {
+				return nil, errors.New("missing Kubernetes client configuration")
+			}
+			impersonated := rest.CopyConfig(restCfg)
+			impersonated.Impersonate = rest.ImpersonationConfig{UserName: config.RBACDefaultUser, Groups: cfg.Groups}
+			return kubernetes.NewForConfig(impersonated)
+		},
+	}
+}
+
+// Duration returns the watch duration. The requested one is used if it's set, but it cannot exceed the configured maximum.
+func (w *Watcher) Duration(requested time.Duration) (time.Duration, error) {
+	if requested <= 0 {
+		return w.cfg.Duration, nil
+	}
+	if requested > w.cfg.MaxDuration {
+		return 0, fmt.Errorf("duration cannot be longer than %s", w.cfg.MaxDuration)
+	}
+	return requested, nil
+}
+
+// UpdateInterval returns the minimum time between status updates.
+func (w *Watcher) UpdateInterval() time.Duration {
+	return w.cfg.UpdateInterval
+}
+
+// Status returns the current status of a given resource. It returns ErrNotFound if the resource doesn't exist.
+func (w *Watcher) Status(ctx context.Context, ref Ref) (Status, error) {
+	k, found := findKind(ref.Kind)
+	if !found {
+		return Status{}, fmt.Errorf("kind %q is not supported", ref.Kind)
+	}
+	cli, err := w.newK8sCli()
+	if err != nil {
+		return Status{}, fmt.Errorf("while creating Kubernetes client: %w", err)
+	}
+
+	obj, err := k.get(ctx, cli, ref.Namespace, ref.Name)
+	switch {
+	case err == nil:
+	case apierrors.IsNotFound(err):
+		return Status{}, ErrNotFound
+	default:
+		return Status{}, fmt.Errorf("while getting %s: %w", ref, err)
+	}
+	return k.status(obj), nil
+}
+
+// Watch calls onChange with the status of a given resource on every change, until the context is done or the resource is deleted.
+// Watches closed by the API server are reopened.
+func (w *Watcher) Watch(ctx context.Context, ref Ref, onChange func(Status)) error {
+	k, found := findKind(ref.Kind)
+	if !found {
+		return fmt.Errorf("kind %q is not supported", ref.Kind)
+	}
+	cli, err := w.newK8sCli()
+	if err != nil {
+		return fmt.Errorf("while creating Kubernetes client: %w", err)
+	}
+
+	var resourceVersion string
+	for {
+		watcher, err := k.watch(ctx, cli, ref.Namespace, metav1.ListOptions{
+			FieldSelector:   fields.OneTermEqualSelector("metadata.name", ref.Name).String(),
+			ResourceVersion: resourceVersion,
+		})
+		if err != nil {
+			return fmt.Errorf("while watching %s: %w", ref, err)
+		}
+
+		deleted, err := w.consume(ctx, watcher, k, ref, &resourceVersion, onChange)
+		watcher.Stop()
+		if err != nil || deleted {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(rewatchDelay):
+		}
+	}
+}
+
+// consume handles events until the watch is closed. It returns true if the resource was deleted.
+func (w *Watcher) consume(ctx context.Context, watcher watch.Interface, k kind, ref Ref, resourceVersion *string, onChange func(Status)) (bool, error) {
+	for {
+		select {
+		case <-ctx.Done():
+			return false, nil
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				return false, nil
+			}
+
+			switch event.Type {
+			case watch.Added, watch.Modified:
+				if meta, ok := event.Object.(metav1.Object); ok {
+					if meta.GetName() != ref.Name {
+						// the field selector may be ignored by fake clients
+						continue
+					}
+					*resourceVersion = meta.GetResourceVersion()
+				}
+				onChange(k.status(event.Object))
+			case watch.Deleted:
+				if meta, ok := event.Object.(metav1.Object); ok && meta.GetName() != ref.Name {
+					continue
+				}
+				onChange(Status{Deleted: true})
+				return true, nil
+			case watch.Error:
+				if status, ok := event.Object.(*metav1.Status); ok && status.Code == http.StatusGone {
+					// the resource version is too old, so the watch is reopened from the current state
+					*resourceVersion = ""
+					return false, nil
+				}
+				return false, fmt.Errorf("while watching %s: %v", ref, event.Object)
+			}
+		}
+	}
+}
+
+// kind describes how to get, watch and describe resources of a given kind.
+type kind struct {
+	name    string
+	aliases []string
+	get     func(ctx context.Context, cli kubernetes.Interface, namespace, name string) (runtime.Object, error)
+	watch   func(ctx context.Context, cli kubernetes.Interface, namespace string, opts metav1.ListOptions) (watch.Interface, error)
+	status  func(obj runtime.Object) Status
+}
+
+// KindNames returns canonical names of supported kinds.
+func KindNames() []string {
+	var out []string
+	for _, k := range kinds {
+		out = append(out, k.name)
+	}
+	return out
+}
+
+// KindAliases returns all names supported kinds can be specified with.
+func KindAliases() []string {
+	var out []string
+	for _, k := range kinds {
+		out = append(out, k.name)
+		out = append(out, k.aliases...)
+	}
+	return out
+}
+
+func findKind(name string) (kind, bool) {
+	name = strings.ToLower(name)
+	for _, k := range kinds {
+		if k.name == name {
+			return k, true
+		}
+		for _, alias := range k.aliases {
+			if alias == name {
+				return k, true
+			}
+		}
+	}
+	return kind{}, false
+}
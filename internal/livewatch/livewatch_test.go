@@ -0,0 +1,187 @@
+package livewatch
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+func TestParseRef(t *testing.T) {
+	tests := []struct {
+		name        string
+		in          string
+		namespace   string
+		expected    Ref
+		expectedErr string
+	}{
+		{
+			name:     "Short kind name",
+			in:       "deploy/api",
+			expected: Ref{Kind: "deployment", Namespace: "default", Name: "api"},
+		},
+		{
+			name:      "Plural kind name with namespace",
+			in:        "Pods/api-1",
+			namespace: "prod",
+			expected:  Ref{Kind: "pod", Namespace: "prod", Name: "api-1"},
+		},
+		{
+			name:        "Missing name",
+			in:          "deployment",
+			expectedErr: `resource "deployment" must be given in the kind/name form, e.g. deployment/foo`,
+		},
+		{
+			name:        "Unsupported kind",
+			in:          "service/api",
+			expectedErr: `kind "service" is not supported, use one of: deployment, statefulset, daemonset, pod`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			ref, err := ParseRef(tc.in, tc.namespace)
+
+			// then
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, ref)
+		})
+	}
+}
+
+func TestWatcherDuration(t *testing.T) {
+	// given
+	watcher := New(config.Watch{}, nil)
+
+	// when
+	def, err := watcher.Duration(0)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, 10*time.Minute, def)
+
+	// when
+	_, err = watcher.Duration(2 * time.Hour)
+
+	// then
+	assert.EqualError(t, err, "duration cannot be longer than 1h0m0s")
+}
+
+func TestWatcherStatus(t *testing.T) {
+	// given
+	cli := fake.NewSimpleClientset(deployment("api", 1, "api:v1"))
+	watcher := New(config.Watch{}, nil)
+	watcher.newK8sCli = func() (kubernetes.Interface, error) { return cli, nil }
+
+	// when
+	status, err := watcher.Status(context.Background(), Ref{Kind: "deployment", Namespace: "prod", Name: "api"})
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, Status{
+		Fields: []Field{
+			{Key: "Ready", Value: "1/3"},
+			{Key: "Up-to-date", Value: "3"},
+			{Key: "Available", Value: "1"},
+		},
+		Conditions: []string{"Available=False (MinimumReplicasUnavailable)"},
+		Images:     []string{"app: api:v1"},
+	}, status)
+
+	// when
+	_, err = watcher.Status(context.Background(), Ref{Kind: "deployment", Namespace: "prod", Name: "unknown"})
+
+	// then
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestWatcherWatch(t *testing.T) {
+	// given
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cli := fake.NewSimpleClientset(deployment("api", 1, "api:v1"))
+	watcher := New(config.Watch{}, nil)
+	watcher.newK8sCli = func() (kubernetes.Interface, error) { return cli, nil }
+
+	statuses := make(chan Status, 10)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- watcher.Watch(ctx, Ref{Kind: "deployment", Namespace: "prod", Name: "api"}, func(s Status) {
+			statuses <- s
+		})
+	}()
+
+	// when
+	deployments := cli.AppsV1().Deployments("prod")
+	require.Eventually(t, func() bool {
+		// the watch is opened asynchronously, so the object is updated until its event is received
+		_, err := deployments.Update(ctx, deployment("api", 2, "api:v1"), metav1.UpdateOptions{})
+		require.NoError(t, err)
+		select {
+		case s := <-statuses:
+			return s.Fields[0].Value == "2/3"
+		case <-time.After(100 * time.Millisecond):
+			return false
+		}
+	}, 4*time.Second, 10*time.Millisecond)
+
+	_, err := deployments.Create(ctx, deployment("other", 0, "other:v1"), metav1.CreateOptions{})
+	require.NoError(t, err)
+	_, err = deployments.Update(ctx, deployment("api", 3, "api:v2"), metav1.UpdateOptions{})
+	require.NoError(t, err)
+	require.NoError(t, deployments.Delete(ctx, "api", metav1.DeleteOptions{}))
+
+	// then
+	updated := <-statuses
+	for updated.Fields[0].Value == "2/3" {
+		// skip events of repeated updates
+		updated = <-statuses
+	}
+	assert.Equal(t, "3/3", updated.Fields[0].Value)
+	assert.Equal(t, []string{"app: api:v2"}, updated.Images)
+
+	assert.Equal(t, Status{Deleted: true}, <-statuses)
+	require.NoError(t, <-errCh)
+}
+
+func deployment(name string, ready int32, image string) *appsv1.Deployment {
+	replicas := int32(3)
+	available := corev1.ConditionFalse
+	reason := "MinimumReplicasUnavailable"
+	if ready == replicas {
+		available, reason = corev1.ConditionTrue, "MinimumReplicasAvailable"
+	}
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "prod", Name: name},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "app", Image: image}},
+				},
+			},
+		},
+		Status: appsv1.DeploymentStatus{
+			ReadyReplicas:     ready,
+			UpdatedReplicas:   replicas,
+			AvailableReplicas: ready,
+			Conditions: []appsv1.DeploymentCondition{
+				{Type: appsv1.DeploymentAvailable, Status: available, Reason: reason},
+			},
+		},
+	}
+}
@@ -0,0 +1,521 @@
+package maintenance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/strings/slices"
+
+	"github.com/kubeshop/botkube/internal/filter"
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/multierror"
+	"github.com/kubeshop/botkube/pkg/sliceutil"
+)
+
+const (
+	adHocWindowNamePrefix = "ad-hoc"
+	// maxDigestItems limits the number of distinct notifications listed in a digest.
+	maxDigestItems = 20
+)
+
+// SendFunc sends a given message to channels and sinks bound to given source bindings.
+type SendFunc func(ctx context.Context, msg interactive.CoreMessage, sources []string)
+
+// Notification holds a notification which may be held back by a maintenance window.
+type Notification struct {
+	filter.Input
+	// Object is the full object the event is about, if provided by the source.
+	Object any
+	// Header is the notification header used if the message doesn't have sections.
+	Header string
+	// Sources are source bindings used to send the notification.
+	Sources []string
+	// Interactive is set if the notification is sent to platforms which support interactive buttons.
+	Interactive bool
+}
+
+// Window describes an active maintenance window.
+type Window struct {
+	Name      string
+	Mode      config.MaintenanceMode
+	StartedAt time.Time
+	EndsAt    time.Time
+	// Sources are source bindings covered by the window. If empty, all source bindings are covered.
+	Sources []string
+	// StartedBy is set for windows started with the `maintenance start` command.
+	StartedBy string
+}
+
+// IsAdHoc returns true if the window was started with the `maintenance start` command.
+func (w Window) IsAdHoc() bool {
+	return w.StartedBy != ""
+}
+
+type timer interface {
+	Stop() bool
+}
+
+// scheduledWindow is a maintenance window defined in the configuration.
+type scheduledWindow struct {
+	name     string
+	cfg      config.MaintenanceWindow
+	schedule *schedule
+	selector labels.Selector
+}
+
+// digest collects notifications held back during a single occurrence of a maintenance window.
+// The same event may be dispatched separately for interactive and non-interactive platforms, so there is a recipient for each of them.
+type digest struct {
+	window     Window
+	ctx        context.Context
+	recipients map[bool]*recipient
+	timer      timer
+}
+
+type recipient struct {
+	send    SendFunc
+	sources []string
+	items   []digestItem
+	total   int
+}
+
+type digestItem struct {
+	title string
+	count int
+}
+
+// Manager holds back notifications during maintenance windows.
+type Manager struct {
+	log       logrus.FieldLogger
+	windows   []scheduledWindow
+	now       func() time.Time
+	afterFunc func(d time.Duration, f func()) timer
+
+	mu      sync.Mutex
+	nextID  int
+	adHoc   map[string]Window
+	digests map[string]*digest
+}
+
+// NewManager parses all enabled maintenance windows and returns a new Manager instance.
+func NewManager(log logrus.FieldLogger, windows config.MaintenanceWindows) (*Manager, error) {
+	names := make([]string, 0, len(windows))
+	for name := range windows {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	errs := multierror.New()
+	var scheduled []scheduledWindow
+	for _, name := range names {
+		cfg := windows[name]
+		if !cfg.Enabled {
+			continue
+		}
+
+		loc := time.UTC
+		if cfg.Timezone != "" {
+			var err error
+			loc, err = time.LoadLocation(cfg.Timezone)
+			if err != nil {
+				errs = multierror.Append(errs, fmt.Errorf("while loading time zone for maintenance window %q: %w", name, err))
+				continue
+			}
+		}
+
+		sched, err := parseSchedule(cfg.Schedule, loc)
+		if err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("while parsing schedule for maintenance window %q: %w", name, err))
+			continue
+		}
+
+		var selector labels.Selector
+		if cfg.Selector != "" {
+			selector, err = labels.Parse(cfg.Selector)
+			if err != nil {
+				errs = multierror.Append(errs, fmt.Errorf("while parsing selector for maintenance window %q: %w", name, err))
+				continue
+			}
+		}
+
+		if cfg.Mode == "" {
+			cfg.Mode = config.MaintenanceModeSuppress
+		}
+		scheduled = append(scheduled, scheduledWindow{name: name, cfg: cfg, schedule: sched, selector: selector})
+	}
+	if err := errs.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+
+	return &Manager{
+		log:     log,
+		windows: scheduled,
+		now:     time.Now,
+		afterFunc: func(d time.Duration, f func()) timer {
+			return time.AfterFunc(d, f)
+		},
+		adHoc:   map[string]Window{},
+		digests: map[string]*digest{},
+	}, nil
+}
+
+// Apply returns source bindings to which a given notification should be sent. Source bindings covered by active maintenance
+// windows are removed. In the digest mode, the held back notification is summarized once the window ends.
+func (m *Manager) Apply(ctx context.Context, in Notification, send SendFunc) []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	var obj *objectMeta
+	remaining := in.Sources
+	for _, w := range m.activeWindows(now) {
+		if len(remaining) == 0 {
+			break
+		}
+
+		held := remaining
+		if len(w.Sources) > 0 {
+			held = intersection(remaining, w.Sources)
+			if len(held) == 0 {
+				continue
+			}
+		}
+
+		if !w.IsAdHoc() {
+			if obj == nil {
+				obj = objectMetaFor(in)
+			}
+			matched, err := m.scheduledWindow(w.Name).matches(*obj)
+			if err != nil {
+				m.log.WithError(err).WithField("window", w.Name).Error("Cannot match notification with maintenance window")
+				continue
+			}
+			if !matched {
+				continue
+			}
+		}
+
+		m.log.WithFields(logrus.Fields{
+			"window":  w.Name,
+			"sources": held,
+		}).Debug("Holding back notification during maintenance window")
+		remaining = difference(remaining, held)
+		if w.Mode == config.MaintenanceModeDigest {
+			m.record(ctx, w, in, held, send)
+		}
+	}
+
+	return remaining
+}
+
+// Start starts an ad-hoc maintenance window for given source bindings.
+func (m *Manager) Start(duration time.Duration, sources []string, mode config.MaintenanceMode, user string) Window {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.nextID++
+	now := m.now()
+	w := Window{
+		Name:      fmt.Sprintf("%s-%d", adHocWindowNamePrefix, m.nextID),
+		Mode:      mode,
+		StartedAt: now,
+		EndsAt:    now.Add(duration),
+		Sources:   sources,
+		StartedBy: user,
+	}
+	m.adHoc[w.Name] = w
+
+	m.log.WithField("window", w.Name).Infof("Maintenance window started by %s", user)
+	return w
+}
+
+// Stop ends ad-hoc maintenance windows which cover any of given source bindings, and sends their digests.
+func (m *Manager) Stop(sources []string) []Window {
+	m.mu.Lock()
+	var (
+		stopped []Window
+		flush   []*digest
+	)
+	for name, w := range m.adHoc {
+		if !sliceutil.Intersect(w.Sources, sources) {
+			continue
+		}
+		delete(m.adHoc, name)
+		stopped = append(stopped, w)
+
+		key := digestKey(w)
+		if d, ok := m.digests[key]; ok {
+			d.timer.Stop()
+			delete(m.digests, key)
+			flush = append(flush, d)
+		}
+	}
+	m.mu.Unlock()
+
+	for _, d := range flush {
+		m.sendDigest(d, m.now())
+	}
+	sortWindows(stopped)
+	return stopped
+}
+
+// Active returns all active maintenance windows sorted by end time.
+func (m *Manager) Active() []Window {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.activeWindows(m.now())
+}
+
+// activeWindows returns active scheduled and ad-hoc windows. It must be called with the lock held.
+func (m *Manager) activeWindows(now time.Time) []Window {
+	var out []Window
+	for _, w := range m.windows {
+		start, ok := w.schedule.next(now.Add(-w.cfg.Duration))
+		if !ok || start.After(now) {
+			continue
+		}
+		end := start.Add(w.cfg.Duration)
+		if !now.Before(end) {
+			continue
+		}
+		out = append(out, Window{
+			Name:      w.name,
+			Mode:      w.cfg.Mode,
+			StartedAt: start,
+			EndsAt:    end,
+			Sources:   w.cfg.Sources,
+		})
+	}
+
+	for name, w := range m.adHoc {
+		if !now.Before(w.EndsAt) {
+			delete(m.adHoc, name)
+			continue
+		}
+		out = append(out, w)
+	}
+
+	sortWindows(out)
+	return out
+}
+
+func (m *Manager) scheduledWindow(name string) scheduledWindow {
+	for _, w := range m.windows {
+		if w.name == name {
+			return w
+		}
+	}
+	return scheduledWindow{}
+}
+
+// record adds a given notification to the digest of a given window. It must be called with the lock held.
+func (m *Manager) record(ctx context.Context, w Window, in Notification, sources []string, send SendFunc) {
+	key := digestKey(w)
+	d, ok := m.digests[key]
+	if !ok {
+		d = &digest{
+			window:     w,
+			ctx:        ctx,
+			recipients: map[bool]*recipient{},
+		}
+		d.timer = m.afterFunc(w.EndsAt.Sub(m.now()), func() {
+			m.mu.Lock()
+			_, ok := m.digests[key]
+			delete(m.digests, key)
+			m.mu.Unlock()
+			if ok {
+				m.sendDigest(d, w.EndsAt)
+			}
+		})
+		m.digests[key] = d
+	}
+
+	r, ok := d.recipients[in.Interactive]
+	if !ok {
+		r = &recipient{}
+		d.recipients[in.Interactive] = r
+	}
+	r.send = send
+	r.sources = union(r.sources, sources)
+	r.add(title(in))
+}
+
+func (m *Manager) sendDigest(d *digest, endedAt time.Time) {
+	if d.ctx.Err() != nil {
+		// configuration was reloaded or the agent is shutting down
+		return
+	}
+
+	for _, r := range d.recipients {
+		m.log.WithFields(logrus.Fields{
+			"window":  d.window.Name,
+			"sources": r.sources,
+		}).Info("Sending maintenance window digest")
+		r.send(d.ctx, digestMessage(d.window, endedAt, r), r.sources)
+	}
+}
+
+func (r *recipient) add(title string) {
+	r.total++
+	for i := range r.items {
+		if r.items[i].title == title {
+			r.items[i].count++
+			return
+		}
+	}
+	r.items = append(r.items, digestItem{title: title, count: 1})
+}
+
+func (w scheduledWindow) matches(obj objectMeta) (bool, error) {
+	if w.cfg.Namespaces.AreConstraintsDefined() {
+		if obj.Namespace == "" {
+			return false, nil
+		}
+		allowed, err := w.cfg.Namespaces.IsAllowed(obj.Namespace)
+		if err != nil || !allowed {
+			return false, err
+		}
+	}
+
+	if w.selector != nil {
+		if obj.Labels == nil {
+			return false, nil
+		}
+		if !w.selector.Matches(labels.Set(obj.Labels)) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+type objectMeta struct {
+	Namespace string
+	Labels    map[string]string
+}
+
+// objectMetaFor returns the namespace and labels of the object the notification is about. The labels are nil if the source
+// doesn't provide the full object.
+func objectMetaFor(in Notification) *objectMeta {
+	out := &objectMeta{}
+
+	var obj struct {
+		Metadata struct {
+			Namespace string            `json:"namespace"`
+			Labels    map[string]string `json:"labels"`
+		} `json:"metadata"`
+	}
+	if raw, err := json.Marshal(in.Object); err == nil && json.Unmarshal(raw, &obj) == nil {
+		out.Namespace = obj.Metadata.Namespace
+		out.Labels = obj.Metadata.Labels
+		if in.Object != nil && out.Labels == nil {
+			out.Labels = map[string]string{}
+		}
+	}
+
+	if out.Namespace == "" {
+		var event struct {
+			Namespace string
+		}
+		if raw, err := json.Marshal(in.Event); err == nil && json.Unmarshal(raw, &event) == nil {
+			out.Namespace = event.Namespace
+		}
+	}
+	return out
+}
+
+func digestKey(w Window) string {
+	return fmt.Sprintf("%s/%d", w.Name, w.StartedAt.Unix())
+}
+
+func digestMessage(w Window, endedAt time.Time, r *recipient) interactive.CoreMessage {
+	var body strings.Builder
+	for idx, item := range r.items {
+		if idx == maxDigestItems {
+			fmt.Fprintf(&body, "…and %d more\n", len(r.items)-maxDigestItems)
+			break
+		}
+		fmt.Fprintf(&body, "• %s", item.title)
+		if item.count > 1 {
+			fmt.Fprintf(&body, " (%d times)", item.count)
+		}
+		body.WriteString("\n")
+	}
+
+	return interactive.CoreMessage{
+		Header: fmt.Sprintf("Maintenance window %q ended", w.Name),
+		Message: api.Message{
+			Sections: []api.Section{
+				{
+					Base: api.Base{
+						Header:      fmt.Sprintf(":wrench: Maintenance window %q ended", w.Name),
+						Description: fmt.Sprintf("%d notification(s) were held back between %s and %s:", r.total, w.StartedAt.UTC().Format(time.RFC3339), endedAt.UTC().Format(time.RFC3339)),
+						Body: api.Body{
+							Plaintext: body.String(),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func title(in Notification) string {
+	for _, section := range in.Message.Sections {
+		if section.Header != "" {
+			return section.Header
+		}
+	}
+	if in.Header != "" {
+		return in.Header
+	}
+	return fmt.Sprintf("Notification from %q source", in.SourceName)
+}
+
+func sortWindows(windows []Window) {
+	sort.Slice(windows, func(i, j int) bool {
+		if windows[i].EndsAt.Equal(windows[j].EndsAt) {
+			return windows[i].Name < windows[j].Name
+		}
+		return windows[i].EndsAt.Before(windows[j].EndsAt)
+	})
+}
+
+func intersection(a, b []string) []string {
+	var out []string
+	for _, item := range a {
+		if slices.Contains(b, item) {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+func difference(a, b []string) []string {
+	var out []string
+	for _, item := range a {
+		if !slices.Contains(b, item) {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+func union(a, b []string) []string {
+	out := append([]string(nil), a...)
+	for _, item := range b {
+		if !slices.Contains(out, item) {
+			out = append(out, item)
+		}
+	}
+	return out
+}
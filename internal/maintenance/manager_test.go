@@ -0,0 +1,226 @@
+package maintenance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/internal/filter"
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+type fakeTimer struct {
+	fn      func()
+	stopped bool
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.stopped = true
+	return true
+}
+
+type sentMessage struct {
+	msg     interactive.CoreMessage
+	sources []string
+}
+
+func TestScheduleNext(t *testing.T) {
+	// 2023-03-15 is Wednesday
+	from := time.Date(2023, 3, 15, 10, 30, 15, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		expr     string
+		expected time.Time
+	}{
+		{
+			name:     "every minute",
+			expr:     "* * * * *",
+			expected: time.Date(2023, 3, 15, 10, 31, 0, 0, time.UTC),
+		},
+		{
+			name:     "daily descriptor",
+			expr:     "@daily",
+			expected: time.Date(2023, 3, 16, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "weekends at 2 AM",
+			expr:     "0 2 * * 6,7",
+			expected: time.Date(2023, 3, 18, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "every 15 minutes during working hours",
+			expr:     "*/15 9-17 * * 1-5",
+			expected: time.Date(2023, 3, 15, 10, 45, 0, 0, time.UTC),
+		},
+		{
+			name:     "first day of the next quarter",
+			expr:     "0 0 1 1/3 *",
+			expected: time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "day of month or day of week",
+			expr:     "0 0 20 * 5",
+			expected: time.Date(2023, 3, 17, 0, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			sched, err := parseSchedule(tc.expr, time.UTC)
+			require.NoError(t, err)
+
+			// when
+			next, ok := sched.next(from)
+
+			// then
+			require.True(t, ok)
+			assert.Equal(t, tc.expected, next)
+		})
+	}
+}
+
+func TestParseScheduleErrors(t *testing.T) {
+	for _, expr := range []string{"* * * *", "60 * * * *", "* * 0 * *", "5-1 * * * *", "*/0 * * * *", "a * * * *"} {
+		_, err := parseSchedule(expr, time.UTC)
+		assert.Error(t, err, expr)
+	}
+}
+
+func TestManagerApplyScheduledWindow(t *testing.T) {
+	// given
+	manager, err := NewManager(loggerx.NewNoop(), config.MaintenanceWindows{
+		"nightly-upgrades": {
+			Enabled:  true,
+			Schedule: "0 2 * * *",
+			Duration: 2 * time.Hour,
+			Mode:     config.MaintenanceModeDigest,
+			Namespaces: config.RegexConstraints{
+				Include: []string{"prod-.*"},
+			},
+			Selector: "team=payments",
+			Sources:  []string{"k8s-err-events"},
+		},
+	})
+	require.NoError(t, err)
+
+	now := time.Date(2023, 3, 15, 2, 30, 0, 0, time.UTC)
+	manager.now = func() time.Time { return now }
+	var timers []*fakeTimer
+	manager.afterFunc = func(_ time.Duration, f func()) timer {
+		tm := &fakeTimer{fn: f}
+		timers = append(timers, tm)
+		return tm
+	}
+
+	var sent []sentMessage
+	send := func(_ context.Context, msg interactive.CoreMessage, sources []string) {
+		sent = append(sent, sentMessage{msg: msg, sources: sources})
+	}
+	notification := func(namespace, team string) Notification {
+		return Notification{
+			Input: filter.Input{
+				SourceName: "k8s-err-events",
+				Event:      map[string]any{"Kind": "Pod", "Name": "api", "Namespace": namespace},
+				Message: api.Message{
+					Sections: []api.Section{{Base: api.Base{Header: "Pod api failed"}}},
+				},
+			},
+			Object: map[string]any{
+				"metadata": map[string]any{
+					"namespace": namespace,
+					"labels":    map[string]any{"team": team},
+				},
+			},
+			Sources: []string{"k8s-err-events", "k8s-all-events"},
+		}
+	}
+
+	// when
+	matching := manager.Apply(context.Background(), notification("prod-eu", "payments"), send)
+	otherTeam := manager.Apply(context.Background(), notification("prod-eu", "search"), send)
+	otherNamespace := manager.Apply(context.Background(), notification("dev", "payments"), send)
+	_ = manager.Apply(context.Background(), notification("prod-us", "payments"), send)
+
+	// then
+	assert.Equal(t, []string{"k8s-all-events"}, matching)
+	assert.Equal(t, []string{"k8s-err-events", "k8s-all-events"}, otherTeam)
+	assert.Equal(t, []string{"k8s-err-events", "k8s-all-events"}, otherNamespace)
+
+	active := manager.Active()
+	require.Len(t, active, 1)
+	assert.Equal(t, time.Date(2023, 3, 15, 4, 0, 0, 0, time.UTC), active[0].EndsAt)
+
+	// when the window ends
+	require.Len(t, timers, 1)
+	timers[0].fn()
+
+	// then
+	require.Len(t, sent, 1)
+	assert.Equal(t, []string{"k8s-err-events"}, sent[0].sources)
+	section := sent[0].msg.Sections[0]
+	assert.Equal(t, `:wrench: Maintenance window "nightly-upgrades" ended`, section.Header)
+	assert.Contains(t, section.Description, "2 notification(s) were held back")
+	assert.Equal(t, "• Pod api failed (2 times)\n", section.Body.Plaintext)
+
+	// when the window is not active anymore
+	now = time.Date(2023, 3, 15, 4, 0, 0, 0, time.UTC)
+	assert.Empty(t, manager.Active())
+	assert.Equal(t, []string{"k8s-err-events", "k8s-all-events"}, manager.Apply(context.Background(), notification("prod-eu", "payments"), send))
+}
+
+func TestManagerAdHocWindow(t *testing.T) {
+	// given
+	manager, err := NewManager(loggerx.NewNoop(), nil)
+	require.NoError(t, err)
+
+	now := time.Date(2023, 3, 15, 10, 0, 0, 0, time.UTC)
+	manager.now = func() time.Time { return now }
+
+	in := Notification{
+		Input: filter.Input{
+			SourceName: "k8s-err-events",
+			Event:      map[string]any{"Kind": "Pod", "Name": "api"},
+		},
+		Sources: []string{"k8s-err-events"},
+	}
+	send := func(context.Context, interactive.CoreMessage, []string) {
+		t.Fatal("message shouldn't be sent")
+	}
+
+	// when
+	w := manager.Start(2*time.Hour, []string{"k8s-err-events"}, config.MaintenanceModeSuppress, "@Joe")
+
+	// then
+	assert.Equal(t, "ad-hoc-1", w.Name)
+	assert.True(t, w.IsAdHoc())
+	assert.Empty(t, manager.Apply(context.Background(), in, send))
+
+	// when
+	stopped := manager.Stop([]string{"k8s-all-events"})
+
+	// then
+	assert.Empty(t, stopped)
+
+	// when
+	stopped = manager.Stop([]string{"k8s-err-events"})
+
+	// then
+	require.Len(t, stopped, 1)
+	assert.Equal(t, "ad-hoc-1", stopped[0].Name)
+	assert.Equal(t, []string{"k8s-err-events"}, manager.Apply(context.Background(), in, send))
+
+	// when the window expires
+	manager.Start(time.Hour, []string{"k8s-err-events"}, config.MaintenanceModeSuppress, "@Joe")
+	now = now.Add(time.Hour)
+
+	// then
+	assert.Empty(t, manager.Active())
+	assert.Equal(t, []string{"k8s-err-events"}, manager.Apply(context.Background(), in, send))
+}
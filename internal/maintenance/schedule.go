@@ -0,0 +1,179 @@
+package maintenance
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxLookup limits how far in the future the next activation is searched for, so impossible schedules, e.g. `0 0 30 2 *`, don't loop forever.
+const maxLookup = 5 * 366 * 24 * time.Hour
+
+var descriptors = map[string]string{
+	"@hourly":  "0 * * * *",
+	"@daily":   "0 0 * * *",
+	"@weekly":  "0 0 * * 0",
+	"@monthly": "0 0 1 * *",
+}
+
+type bounds struct {
+	min, max int
+}
+
+var (
+	minuteBounds = bounds{0, 59}
+	hourBounds   = bounds{0, 23}
+	domBounds    = bounds{1, 31}
+	monthBounds  = bounds{1, 12}
+	dowBounds    = bounds{0, 7}
+)
+
+// schedule is a parsed cron expression with the standard five fields: minute, hour, day of month, month and day of week.
+type schedule struct {
+	minute, hour, dom, month, dow uint64
+	// domStar and dowStar are set if the day fields are not restricted. If both are restricted,
+	// a day matches if any of them matches, the same as in the standard cron.
+	domStar, dowStar bool
+	loc              *time.Location
+}
+
+// parseSchedule parses a given cron expression. Activation times are evaluated in a given location.
+func parseSchedule(expr string, loc *time.Location) (*schedule, error) {
+	expr = strings.TrimSpace(expr)
+	if descriptor, ok := descriptors[expr]; ok {
+		expr = descriptor
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("expected 5 fields, got %d in %q", len(fields), expr)
+	}
+
+	s := &schedule{
+		domStar: fields[2] == "*",
+		dowStar: fields[4] == "*",
+		loc:     loc,
+	}
+	for _, f := range []struct {
+		name   string
+		value  string
+		bounds bounds
+		out    *uint64
+	}{
+		{name: "minute", value: fields[0], bounds: minuteBounds, out: &s.minute},
+		{name: "hour", value: fields[1], bounds: hourBounds, out: &s.hour},
+		{name: "day of month", value: fields[2], bounds: domBounds, out: &s.dom},
+		{name: "month", value: fields[3], bounds: monthBounds, out: &s.month},
+		{name: "day of week", value: fields[4], bounds: dowBounds, out: &s.dow},
+	} {
+		bits, err := parseField(f.value, f.bounds)
+		if err != nil {
+			return nil, fmt.Errorf("while parsing %s field: %w", f.name, err)
+		}
+		*f.out = bits
+	}
+
+	// both 0 and 7 mean Sunday
+	if s.dow&(1<<7) > 0 {
+		s.dow |= 1
+	}
+	return s, nil
+}
+
+// parseField parses a comma-separated list of `*`, `N`, `N-M` items with an optional `/step` suffix.
+func parseField(field string, b bounds) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(field, ",") {
+		rng, stepStr, hasStep := strings.Cut(item, "/")
+		step := 1
+		if hasStep {
+			var err error
+			step, err = strconv.Atoi(stepStr)
+			if err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step %q", stepStr)
+			}
+		}
+
+		start, end := b.min, b.max
+		switch {
+		case rng == "*":
+		case strings.Contains(rng, "-"):
+			from, to, _ := strings.Cut(rng, "-")
+			var err error
+			if start, err = parseValue(from, b); err != nil {
+				return 0, err
+			}
+			if end, err = parseValue(to, b); err != nil {
+				return 0, err
+			}
+			if start > end {
+				return 0, fmt.Errorf("invalid range %q", rng)
+			}
+		default:
+			var err error
+			if start, err = parseValue(rng, b); err != nil {
+				return 0, err
+			}
+			if !hasStep {
+				end = start
+			}
+		}
+
+		for i := start; i <= end; i += step {
+			bits |= 1 << uint(i)
+		}
+	}
+	return bits, nil
+}
+
+func parseValue(in string, b bounds) (int, error) {
+	val, err := strconv.Atoi(in)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", in)
+	}
+	if val < b.min || val > b.max {
+		return 0, fmt.Errorf("value %d out of range [%d, %d]", val, b.min, b.max)
+	}
+	return val, nil
+}
+
+// next returns the first activation time which is equal to or after a given time. It returns false if there is no such time.
+func (s *schedule) next(t time.Time) (time.Time, bool) {
+	origLoc := t.Location()
+	t = t.In(s.loc)
+	if t.Truncate(time.Minute) != t {
+		t = t.Truncate(time.Minute).Add(time.Minute)
+	}
+	limit := t.Add(maxLookup)
+
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, s.loc)
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, s.loc)
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, s.loc)
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t.In(origLoc), true
+	}
+	return time.Time{}, false
+}
+
+func (s *schedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) > 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) > 0
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
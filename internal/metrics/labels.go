@@ -0,0 +1,61 @@
+package metrics
+
+import (
+	"sync"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+// otherLabelValue replaces label values which exceed the limit of distinct values.
+const otherLabelValue = "other"
+
+var labels = &labelLimiter{}
+
+// labelLimiter keeps the number of distinct label values bounded, e.g. once channels are created dynamically.
+// Values seen first are kept, so existing time series are stable.
+type labelLimiter struct {
+	mu        sync.Mutex
+	maxValues int
+	disabled  map[config.MetricsLabel]struct{}
+	seen      map[config.MetricsLabel]map[string]struct{}
+}
+
+func (l *labelLimiter) configure(cfg config.Metrics) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.maxValues = cfg.MaxLabelValues
+	l.disabled = map[config.MetricsLabel]struct{}{}
+	for _, label := range cfg.DisabledLabels {
+		l.disabled[label] = struct{}{}
+	}
+}
+
+func (l *labelLimiter) value(label config.MetricsLabel, val string) string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, ok := l.disabled[label]; ok {
+		return ""
+	}
+	if l.maxValues == 0 {
+		return val
+	}
+
+	if l.seen == nil {
+		l.seen = map[config.MetricsLabel]map[string]struct{}{}
+	}
+	values, ok := l.seen[label]
+	if !ok {
+		values = map[string]struct{}{}
+		l.seen[label] = values
+	}
+	if _, ok := values[val]; ok {
+		return val
+	}
+	if len(values) >= l.maxValues {
+		return otherLabelValue
+	}
+	values[val] = struct{}{}
+	return val
+}
@@ -0,0 +1,270 @@
+// Package metrics exports Prometheus metrics of events, notifications and commands handled by Botkube.
+// Metrics are registered in the default registry, which is exposed on the metrics port.
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+const namespace = "botkube"
+
+// DropReason describes why an event was not sent.
+type DropReason string
+
+const (
+	// ProcessorDropReason means that a processor plugin vetoed the event.
+	ProcessorDropReason DropReason = "processor"
+	// MaintenanceDropReason means that the event was held back during a maintenance window.
+	MaintenanceDropReason DropReason = "maintenance"
+	// OverflowDropReason means that the event was dropped, as the event pipeline queue was full.
+	OverflowDropReason DropReason = "overflow"
+)
+
+// Command execution statuses.
+const (
+	successStatus = "success"
+	failureStatus = "failure"
+)
+
+var (
+	eventsReceivedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "source",
+		Name:      "events_received_total",
+		Help:      "Total number of events received from sources.",
+	}, []string{"source"})
+
+	eventsDroppedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "source",
+		Name:      "events_dropped_total",
+		Help:      "Total number of events which were not sent, by the reason.",
+	}, []string{"source", "reason"})
+
+	eventsDeduplicatedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "source",
+		Name:      "events_deduplicated_total",
+		Help:      "Total number of events which were not sent, as they are about an already acknowledged incident.",
+	}, []string{"source"})
+
+	messagesSentTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "notifier",
+		Name:      "messages_sent_total",
+		Help:      "Total number of messages successfully sent to communication platforms and sinks.",
+	}, []string{"platform", "channel"})
+
+	messagesFailedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "notifier",
+		Name:      "messages_failed_total",
+		Help:      "Total number of messages which failed to be sent to communication platforms and sinks.",
+	}, []string{"platform", "channel"})
+
+	messageSendDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "notifier",
+		Name:      "message_send_duration_seconds",
+		Help:      "Duration of sending a message, including rendering it.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"platform", "channel"})
+
+	deliveriesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "notifier",
+		Name:      "deliveries_total",
+		Help:      "Total number of notifications delivered by communication platforms and sinks, by the status.",
+	}, []string{"platform", "status"})
+
+	deliveriesWithinTargetTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "notifier",
+		Name:      "deliveries_within_target_total",
+		Help:      "Total number of notifications delivered successfully within the delivery latency target.",
+	}, []string{"platform"})
+
+	deliveryLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "notifier",
+		Name:      "delivery_latency_seconds",
+		Help:      "Time from receiving an event from a source to delivering the notification.",
+		Buckets:   []float64{.5, 1, 2.5, 5, 10, 15, 30, 60, 120, 300, 900},
+	}, []string{"platform"})
+
+	deliveryLatencyTarget = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "notifier",
+		Name:      "delivery_latency_target_seconds",
+		Help:      "Time in which notifications should be delivered according to the delivery objective.",
+	})
+
+	deliveryObjective = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "notifier",
+		Name:      "delivery_objective_ratio",
+		Help:      "Ratio of notifications which should be delivered successfully within the latency target.",
+	})
+
+	commandsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "executor",
+		Name:      "commands_total",
+		Help:      "Total number of executed commands, by the status.",
+	}, []string{"executor", "status"})
+
+	commandDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "executor",
+		Name:      "command_duration_seconds",
+		Help:      "Duration of executing a command.",
+		Buckets:   []float64{.05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60, 120},
+	}, []string{"executor"})
+
+	pipelineWorkers = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "pipeline",
+		Name:      "workers",
+		Help:      "Number of workers of an event pipeline stage.",
+	}, []string{"stage"})
+
+	pipelineBusyWorkers = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "pipeline",
+		Name:      "busy_workers",
+		Help:      "Number of workers of an event pipeline stage which are handling items. The stage is saturated once all workers are busy.",
+	}, []string{"stage"})
+
+	pipelineQueueWait = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "pipeline",
+		Name:      "queue_wait_duration_seconds",
+		Help:      "Duration of waiting in an event pipeline queue.",
+		Buckets:   []float64{.001, .01, .05, .1, .5, 1, 5, 15, 60},
+	}, []string{"stage"})
+
+	pipelineOverflowsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "pipeline",
+		Name:      "overflows_total",
+		Help:      "Total number of items submitted to a full event pipeline queue, by the applied overflow policy.",
+	}, []string{"stage", "policy"})
+
+	leader = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "leader",
+		Help:      "Whether the replica is the elected leader, which handles events and chat traffic. It's 1 if the leader election is disabled.",
+	})
+
+	queues = newQueueCollector()
+)
+
+func init() {
+	prometheus.MustRegister(queues)
+}
+
+// Configure sets up label cardinality limits of all metrics.
+func Configure(cfg config.Metrics) {
+	labels.configure(cfg)
+}
+
+// EventReceived records an event received from a given source binding.
+func EventReceived(source string) {
+	eventsReceivedTotal.WithLabelValues(labels.value(config.SourceMetricsLabel, source)).Inc()
+}
+
+// EventDropped records an event from a given source binding which was not sent.
+func EventDropped(source string, reason DropReason) {
+	eventsDroppedTotal.WithLabelValues(labels.value(config.SourceMetricsLabel, source), string(reason)).Inc()
+}
+
+// EventDeduplicated records an event from a given source binding which was suppressed as a duplicate.
+func EventDeduplicated(source string) {
+	eventsDeduplicatedTotal.WithLabelValues(labels.value(config.SourceMetricsLabel, source)).Inc()
+}
+
+// ObserveMessageSend records sending a message started at a given time. The channel is empty for sinks.
+// The error is passed as a pointer, so the function can be deferred with a named return value.
+func ObserveMessageSend(platform config.CommPlatformIntegration, channel string, start time.Time, err *error) {
+	platformVal := labels.value(config.PlatformMetricsLabel, string(platform))
+	channelVal := labels.value(config.ChannelMetricsLabel, channel)
+
+	messageSendDuration.WithLabelValues(platformVal, channelVal).Observe(time.Since(start).Seconds())
+	if err != nil && *err != nil {
+		messagesFailedTotal.WithLabelValues(platformVal, channelVal).Inc()
+		return
+	}
+	messagesSentTotal.WithLabelValues(platformVal, channelVal).Inc()
+}
+
+// ObserveDelivery records delivering a notification with a given latency. Failed deliveries are not within the target.
+func ObserveDelivery(platform config.CommPlatformIntegration, latency time.Duration, withinTarget bool, err error) {
+	platformVal := labels.value(config.PlatformMetricsLabel, string(platform))
+
+	if err != nil {
+		deliveriesTotal.WithLabelValues(platformVal, failureStatus).Inc()
+		return
+	}
+	deliveriesTotal.WithLabelValues(platformVal, successStatus).Inc()
+	deliveryLatency.WithLabelValues(platformVal).Observe(latency.Seconds())
+	if withinTarget {
+		deliveriesWithinTargetTotal.WithLabelValues(platformVal).Inc()
+	}
+}
+
+// SetDeliveryObjective records the delivery objective, so it can be used in alerting and recording rules.
+func SetDeliveryObjective(latencyTarget time.Duration, objective float64) {
+	deliveryLatencyTarget.Set(latencyTarget.Seconds())
+	deliveryObjective.Set(objective / 100)
+}
+
+// ObserveCommand records executing a command by a given executor, started at a given time.
+func ObserveCommand(executor string, start time.Time, err error) {
+	executorVal := labels.value(config.ExecutorMetricsLabel, executor)
+
+	commandDuration.WithLabelValues(executorVal).Observe(time.Since(start).Seconds())
+	status := successStatus
+	if err != nil {
+		status = failureStatus
+	}
+	commandsTotal.WithLabelValues(executorVal, status).Inc()
+}
+
+// SetPipelineWorkers records the number of workers of a given event pipeline stage.
+func SetPipelineWorkers(stage string, workers int) {
+	pipelineWorkers.WithLabelValues(stage).Set(float64(workers))
+}
+
+// PipelineWorkerBusy records that a worker of a given event pipeline stage started handling an item, which waited in the queue since a given time.
+// The returned function records that the worker is idle again.
+func PipelineWorkerBusy(stage string, queuedAt time.Time) func() {
+	pipelineQueueWait.WithLabelValues(stage).Observe(time.Since(queuedAt).Seconds())
+	busy := pipelineBusyWorkers.WithLabelValues(stage)
+	busy.Inc()
+	return busy.Dec
+}
+
+// PipelineOverflow records an item submitted to a full queue of a given event pipeline stage.
+func PipelineOverflow(stage string, policy config.PipelineOverflowPolicy) {
+	pipelineOverflowsTotal.WithLabelValues(stage, string(policy)).Inc()
+}
+
+// SetLeader records whether the replica is the elected leader.
+func SetLeader(isLeader bool) {
+	if isLeader {
+		leader.Set(1)
+		return
+	}
+	leader.Set(0)
+}
+
+// RegisterQueue registers a queue, which depth is reported on each scrape.
+// Registering a queue with the same name again replaces the previous one, e.g. once a bot is recreated.
+func RegisterQueue(name string, depth func() int) {
+	queues.register(name, depth)
+}
@@ -0,0 +1,106 @@
+package metrics
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+func TestLabelLimiter(t *testing.T) {
+	// given
+	limiter := &labelLimiter{}
+	limiter.configure(config.Metrics{
+		MaxLabelValues: 2,
+		DisabledLabels: []config.MetricsLabel{config.ChannelMetricsLabel},
+	})
+
+	// when
+	got := []string{
+		limiter.value(config.SourceMetricsLabel, "k8s-events"),
+		limiter.value(config.SourceMetricsLabel, "prometheus"),
+		limiter.value(config.SourceMetricsLabel, "argocd"),
+		limiter.value(config.SourceMetricsLabel, "k8s-events"),
+		limiter.value(config.PlatformMetricsLabel, "socketSlack"),
+		limiter.value(config.ChannelMetricsLabel, "general"),
+	}
+
+	// then
+	assert.Equal(t, []string{"k8s-events", "prometheus", "other", "k8s-events", "socketSlack", ""}, got)
+}
+
+func TestLabelLimiterNoLimit(t *testing.T) {
+	// given
+	limiter := &labelLimiter{}
+	limiter.configure(config.Metrics{MaxLabelValues: 0})
+
+	// when
+	for i := 0; i < 200; i++ {
+		limiter.value(config.ChannelMetricsLabel, time.Duration(i).String())
+	}
+	got := limiter.value(config.ChannelMetricsLabel, "general")
+
+	// then
+	assert.Equal(t, "general", got)
+}
+
+func TestObserveMessageSend(t *testing.T) {
+	// given
+	platform := config.CommPlatformIntegration("testPlatform")
+	sendErr := errors.New("channel not found")
+	var noErr error
+
+	// when
+	ObserveMessageSend(platform, "general", time.Now(), &noErr)
+	ObserveMessageSend(platform, "general", time.Now(), nil)
+	ObserveMessageSend(platform, "general", time.Now(), &sendErr)
+
+	// then
+	assert.Equal(t, 2.0, testutil.ToFloat64(messagesSentTotal.WithLabelValues("testPlatform", "general")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(messagesFailedTotal.WithLabelValues("testPlatform", "general")))
+}
+
+func TestObserveDelivery(t *testing.T) {
+	// given
+	platform := config.CommPlatformIntegration("deliveryPlatform")
+
+	// when
+	ObserveDelivery(platform, 2*time.Second, true, nil)
+	ObserveDelivery(platform, time.Minute, false, nil)
+	ObserveDelivery(platform, time.Second, false, errors.New("channel not found"))
+	SetDeliveryObjective(30*time.Second, 99.5)
+
+	// then
+	assert.Equal(t, 2.0, testutil.ToFloat64(deliveriesTotal.WithLabelValues("deliveryPlatform", successStatus)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(deliveriesTotal.WithLabelValues("deliveryPlatform", failureStatus)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(deliveriesWithinTargetTotal.WithLabelValues("deliveryPlatform")))
+	assert.Equal(t, 30.0, testutil.ToFloat64(deliveryLatencyTarget))
+	assert.Equal(t, 0.995, testutil.ToFloat64(deliveryObjective))
+}
+
+func TestObserveCommand(t *testing.T) {
+	// when
+	ObserveCommand("botkube/test", time.Now(), nil)
+	ObserveCommand("botkube/test", time.Now(), errors.New("timeout"))
+
+	// then
+	assert.Equal(t, 1.0, testutil.ToFloat64(commandsTotal.WithLabelValues("botkube/test", successStatus)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(commandsTotal.WithLabelValues("botkube/test", failureStatus)))
+}
+
+func TestQueueDepth(t *testing.T) {
+	// given
+	collector := newQueueCollector()
+	collector.register("socketSlack/default", func() int { return 3 })
+	collector.register("socketSlack/default", func() int { return 5 })
+
+	// when
+	got := testutil.ToFloat64(collector)
+
+	// then
+	assert.Equal(t, 5.0, got)
+}
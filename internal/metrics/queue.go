@@ -0,0 +1,45 @@
+package metrics
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var _ prometheus.Collector = &queueCollector{}
+
+// queueCollector reports depths of registered queues, e.g. messages received from communication platforms which wait to be handled.
+type queueCollector struct {
+	desc *prometheus.Desc
+
+	mu     sync.RWMutex
+	depths map[string]func() int
+}
+
+func newQueueCollector() *queueCollector {
+	return &queueCollector{
+		desc:   prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "queue_depth"), "Number of items waiting in a queue.", []string{"queue"}, nil),
+		depths: map[string]func() int{},
+	}
+}
+
+func (c *queueCollector) register(name string, depth func() int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.depths[name] = depth
+}
+
+// Describe implements the prometheus.Collector interface.
+func (c *queueCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+// Collect implements the prometheus.Collector interface.
+func (c *queueCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for name, depth := range c.depths {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(depth()), name)
+	}
+}
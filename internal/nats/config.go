@@ -0,0 +1,90 @@
+// Package nats configures connections to NATS servers made with the official NATS client.
+package nats
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/tlsx"
+)
+
+const (
+	defaultName    = "botkube"
+	defaultTimeout = 10 * time.Second
+)
+
+// Config holds the connection configuration.
+type Config struct {
+	// Servers are URLs of servers, e.g. `nats://nats:4222`. Servers are tried in the given order.
+	Servers []string
+	// Options are options of the NATS client.
+	Options []nats.Option
+	// Timeout limits connecting to servers, and waiting for acknowledgements.
+	Timeout time.Duration
+}
+
+// NewConfig returns the connection configuration for the NATS integration.
+func NewConfig(cfg config.NATS) (Config, error) {
+	name := cfg.Name
+	if name == "" {
+		name = defaultName
+	}
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	out := Config{
+		Servers: cfg.Servers,
+		Timeout: timeout,
+		Options: []nats.Option{
+			nats.Name(name),
+			nats.Timeout(timeout),
+			nats.DontRandomize(),
+		},
+	}
+	if cfg.Username != "" || cfg.Password != "" {
+		out.Options = append(out.Options, nats.UserInfo(cfg.Username, cfg.Password))
+	}
+	if cfg.Token != "" {
+		out.Options = append(out.Options, nats.Token(cfg.Token))
+	}
+	if !cfg.TLS.Enabled {
+		return out, nil
+	}
+
+	tlsCfg, err := newTLSConfig(cfg.TLS)
+	if err != nil {
+		return Config{}, fmt.Errorf("while creating NATS TLS configuration: %w", err)
+	}
+	out.Options = append(out.Options, nats.Secure(tlsCfg))
+	return out, nil
+}
+
+// Connect connects to the first available server. Additional options, e.g. connection event handlers, are applied after the configured ones.
+func Connect(cfg Config, opts ...nats.Option) (*nats.Conn, error) {
+	if len(cfg.Servers) == 0 {
+		return nil, errors.New("at least one server is required")
+	}
+	all := make([]nats.Option, 0, len(cfg.Options)+len(opts))
+	all = append(all, cfg.Options...)
+	return nats.Connect(strings.Join(cfg.Servers, ","), append(all, opts...)...)
+}
+
+func newTLSConfig(cfg config.NATSTLS) (*tls.Config, error) {
+	return tlsx.NewClientConfig(tlsx.ClientOptions{
+		Name:               "NATS",
+		CAFile:             cfg.CAFile,
+		CertFile:           cfg.CertFile,
+		KeyFile:            cfg.KeyFile,
+		MinVersion:         cfg.MinVersion,
+		CipherSuites:       cfg.CipherSuites,
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+	})
+}
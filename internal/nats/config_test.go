@@ -0,0 +1,110 @@
+package nats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+func TestNewConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		given    config.NATS
+		expected func(opts *nats.Options)
+	}{
+		{
+			name:  "defaults",
+			given: config.NATS{Servers: []string{"nats://nats:4222"}},
+			expected: func(opts *nats.Options) {
+				opts.Name = "botkube"
+				opts.Timeout = 10 * time.Second
+			},
+		},
+		{
+			name: "user and password",
+			given: config.NATS{
+				Servers:  []string{"nats://nats:4222"},
+				Name:     "prod",
+				Username: "botkube",
+				Password: "secret",
+				Timeout:  time.Second,
+			},
+			expected: func(opts *nats.Options) {
+				opts.Name = "prod"
+				opts.Timeout = time.Second
+				opts.User = "botkube"
+				opts.Password = "secret"
+			},
+		},
+		{
+			name:  "token",
+			given: config.NATS{Servers: []string{"nats://nats:4222"}, Token: "token"},
+			expected: func(opts *nats.Options) {
+				opts.Name = "botkube"
+				opts.Timeout = 10 * time.Second
+				opts.Token = "token"
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			cfg, err := NewConfig(tc.given)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tc.given.Servers, cfg.Servers)
+
+			expected := nats.GetDefaultOptions()
+			expected.NoRandomize = true
+			tc.expected(&expected)
+			assert.Equal(t, expected.Timeout, cfg.Timeout)
+
+			got := nats.GetDefaultOptions()
+			for _, opt := range cfg.Options {
+				require.NoError(t, opt(&got))
+			}
+			assert.Equal(t, expected.Name, got.Name)
+			assert.Equal(t, expected.Timeout, got.Timeout)
+			assert.Equal(t, expected.NoRandomize, got.NoRandomize)
+			assert.Equal(t, expected.User, got.User)
+			assert.Equal(t, expected.Password, got.Password)
+			assert.Equal(t, expected.Token, got.Token)
+			assert.False(t, got.Secure)
+		})
+	}
+}
+
+func TestNewConfigTLS(t *testing.T) {
+	// given
+	in := config.NATS{
+		Servers: []string{"tls://nats:4222"},
+		TLS:     config.NATSTLS{Enabled: true, MinVersion: "1.3"},
+	}
+
+	// when
+	cfg, err := NewConfig(in)
+
+	// then
+	require.NoError(t, err)
+
+	got := nats.GetDefaultOptions()
+	for _, opt := range cfg.Options {
+		require.NoError(t, opt(&got))
+	}
+	assert.True(t, got.Secure)
+	require.NotNil(t, got.TLSConfig)
+}
+
+func TestConnectWithoutServers(t *testing.T) {
+	// when
+	_, err := Connect(Config{})
+
+	// then
+	assert.EqualError(t, err, "at least one server is required")
+}
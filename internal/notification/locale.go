@@ -0,0 +1,81 @@
+package notification
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-playground/locales"
+	"github.com/go-playground/locales/cs"
+	"github.com/go-playground/locales/da"
+	"github.com/go-playground/locales/de"
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/en_GB"
+	"github.com/go-playground/locales/en_US"
+	"github.com/go-playground/locales/es"
+	"github.com/go-playground/locales/fi"
+	"github.com/go-playground/locales/fr"
+	"github.com/go-playground/locales/it"
+	"github.com/go-playground/locales/ja"
+	"github.com/go-playground/locales/ko"
+	"github.com/go-playground/locales/nb"
+	"github.com/go-playground/locales/nl"
+	"github.com/go-playground/locales/pl"
+	"github.com/go-playground/locales/pt"
+	"github.com/go-playground/locales/pt_BR"
+	"github.com/go-playground/locales/sv"
+	"github.com/go-playground/locales/tr"
+	"github.com/go-playground/locales/uk"
+	"github.com/go-playground/locales/zh"
+	"golang.org/x/exp/maps"
+)
+
+// translators holds supported locales.
+var translators = map[string]func() locales.Translator{
+	"cs":    cs.New,
+	"da":    da.New,
+	"de":    de.New,
+	"en":    en.New,
+	"en_GB": en_GB.New,
+	"en_US": en_US.New,
+	"es":    es.New,
+	"fi":    fi.New,
+	"fr":    fr.New,
+	"it":    it.New,
+	"ja":    ja.New,
+	"ko":    ko.New,
+	"nb":    nb.New,
+	"nl":    nl.New,
+	"pl":    pl.New,
+	"pt":    pt.New,
+	"pt_BR": pt_BR.New,
+	"sv":    sv.New,
+	"tr":    tr.New,
+	"uk":    uk.New,
+	"zh":    zh.New,
+}
+
+// SupportedLocales returns names of all supported locales.
+func SupportedLocales() []string {
+	out := maps.Keys(translators)
+	sort.Strings(out)
+	return out
+}
+
+// IsLocaleSupported returns true if a given locale can be used to format notifications.
+func IsLocaleSupported(locale string) bool {
+	_, ok := translators[locale]
+	return ok
+}
+
+// formatTime returns a given time formatted according to a given locale. Times are always shown in UTC,
+// as channel members may be in different time zones.
+func formatTime(locale string, t time.Time) string {
+	newFn, ok := translators[locale]
+	if !ok {
+		return t.UTC().Format(time.RFC3339)
+	}
+	tr := newFn()
+	t = t.UTC()
+	return fmt.Sprintf("%s %s UTC", tr.FmtDateMedium(t), tr.FmtTimeShort(t))
+}
@@ -0,0 +1,745 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/internal/featureflag"
+	"github.com/kubeshop/botkube/internal/filter"
+	"github.com/kubeshop/botkube/internal/redaction"
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/multierror"
+)
+
+// maxDigestItems limits the number of distinct notifications listed in an aggregated message.
+const maxDigestItems = 20
+
+// levelSeverity orders event levels, so they can be compared with the minimal level.
+var levelSeverity = map[config.Level]int{
+	config.Debug:    0,
+	config.Info:     1,
+	config.Warn:     2,
+	config.Error:    3,
+	config.Critical: 4,
+}
+
+// SendFunc sends a given message to given channels. Channels are identified by their aliases or names used in the configuration.
+type SendFunc func(ctx context.Context, msg interactive.CoreMessage, channels []string) error
+
+// Notification holds a notification which is sent to channels according to their notification settings.
+type Notification struct {
+	filter.Input
+	// Recipient identifies the bot which sends the notification, so notifications aggregated for channels of different bots are not mixed up.
+	Recipient string
+}
+
+// Channel holds a channel to which notifications can be sent.
+type Channel struct {
+	// Name is the alias or name of the channel passed to SendFunc.
+	Name string
+	// Alias is the channel alias used in the configuration. It's used to check feature flags set for the channel.
+	Alias string
+	// Settings are the notification settings defined for the channel.
+	Settings config.NotificationSettings
+}
+
+type timer interface {
+	Stop() bool
+}
+
+// batch collects notifications aggregated for a single channel.
+type batch struct {
+	ctx      context.Context
+	send     SendFunc
+	channel  string
+	settings config.NotificationSettings
+	started  time.Time
+	first    interactive.CoreMessage
+	items    []digestItem
+	total    int
+	timer    timer
+}
+
+// storm tracks the notification rate of a single channel. Once the rate exceeds the flood protection threshold,
+// notifications are collected in the digest until the rate subsides.
+type storm struct {
+	windowStart time.Time
+	count       int
+	digest      *batch
+}
+
+type digestItem struct {
+	title string
+	count int
+}
+
+// Manager sends notifications to channels according to notification settings inherited from the global, source binding and channel levels.
+type Manager struct {
+	log       logrus.FieldLogger
+	cfg       config.Config
+	flags     *featureflag.Manager
+	defined   bool
+	now       func() time.Time
+	afterFunc func(d time.Duration, f func()) timer
+
+	mu             sync.Mutex
+	programs       map[string]*filter.Program
+	quietHours     map[string]QuietHours
+	contentFilters map[string]*redaction.ContentFilter
+	batches        map[string]*batch
+	storms         map[string]*storm
+	// quietDigests collects notifications held during quiet hours of channels with the digest mode.
+	quietDigests map[string]*batch
+}
+
+// NewManager compiles all notification filters and content filters, checks all locales, and returns a new Manager instance.
+// The flags are optional. If not set, default states of feature flags are used.
+func NewManager(log logrus.FieldLogger, cfg config.Config, flags *featureflag.Manager) (*Manager, error) {
+	errs := multierror.New()
+	programs := map[string]*filter.Program{}
+	quietHours := map[string]QuietHours{}
+	contentFilters := map[string]*redaction.ContentFilter{}
+	for _, item := range AllSettings(cfg) {
+		if !item.Enabled {
+			continue
+		}
+		if expr := item.Settings.Filter; expr != "" {
+			program, err := filter.Compile(expr)
+			if err != nil {
+				errs = multierror.Append(errs, fmt.Errorf("while compiling filter for %q: %w", item.Path, err))
+				continue
+			}
+			programs[expr] = program
+		}
+		if locale := item.Settings.Locale; locale != "" && !IsLocaleSupported(locale) {
+			errs = multierror.Append(errs, fmt.Errorf("locale %q used in %q is not supported, use one of: %s", locale, item.Path, strings.Join(SupportedLocales(), ", ")))
+		}
+		if in := item.Settings.QuietHours; in != "" {
+			parsed, err := ParseQuietHours(in)
+			if err != nil {
+				errs = multierror.Append(errs, fmt.Errorf("while parsing quiet hours for %q: %w", item.Path, err))
+				continue
+			}
+			quietHours[in] = parsed
+		}
+		if name := item.Settings.ContentFilter; name != "" && contentFilters[name] == nil {
+			filterCfg, ok := cfg.Settings.ContentFilters[name]
+			if !ok {
+				errs = multierror.Append(errs, fmt.Errorf("content filter %q used in %q is not defined", name, item.Path))
+				continue
+			}
+			contentFilter, err := redaction.NewContentFilter(filterCfg)
+			if err != nil {
+				errs = multierror.Append(errs, fmt.Errorf("while compiling content filter %q: %w", name, err))
+				continue
+			}
+			contentFilters[name] = contentFilter
+		}
+	}
+	if err := errs.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+
+	return &Manager{
+		log:   log,
+		cfg:   cfg,
+		flags: flags,
+		// flood protection is applied per channel, so settings must be resolved for each channel
+		defined: IsDefined(cfg) || cfg.Settings.FloodProtection.Enabled,
+		now:     time.Now,
+		afterFunc: func(d time.Duration, f func()) timer {
+			return time.AfterFunc(d, f)
+		},
+		programs:       programs,
+		quietHours:     quietHours,
+		contentFilters: contentFilters,
+		batches:        map[string]*batch{},
+		storms:         map[string]*storm{},
+		quietDigests:   map[string]*batch{},
+	}, nil
+}
+
+// IsDefined returns true if any notification settings are defined. Otherwise, notifications can be sent to all channels bound to source bindings at once.
+func (m *Manager) IsDefined() bool {
+	return m.defined
+}
+
+// Effective returns notification settings merged for a given source binding and channel.
+func (m *Manager) Effective(sourceName string, channel config.NotificationSettings) Effective {
+	return Resolve(m.cfg, sourceName, channel)
+}
+
+// Send sends a given message to channels whose effective settings allow it. Channels with the same locale and content filter get the message at once.
+// Messages for channels with an aggregation window, or flooded with notifications, are collected and sent later. It returns errors of messages sent immediately.
+func (m *Manager) Send(ctx context.Context, in Notification, msg interactive.CoreMessage, channels []Channel, send SendFunc) error {
+	var (
+		vars    map[string]any
+		varsErr error
+	)
+	varsFn := func() (map[string]any, error) {
+		if vars == nil && varsErr == nil {
+			vars, varsErr = filter.Variables(in.Input)
+		}
+		return vars, varsErr
+	}
+
+	type immediateGroup struct {
+		msg      interactive.CoreMessage
+		locale   string
+		channels []string
+	}
+	immediate := map[string]*immediateGroup{}
+	for _, ch := range channels {
+		settings := m.Effective(in.SourceName, ch.Settings).NotificationSettings
+
+		log := m.log.WithFields(logrus.Fields{
+			"sourceName": in.SourceName,
+			"channel":    ch.Name,
+		})
+		if !m.matches(log, settings, varsFn) {
+			log.Debug("Notification skipped because of channel notification settings")
+			continue
+		}
+		chMsg, allowed := m.filterContent(log, settings, msg)
+		if !allowed {
+			log.Debug("Notification skipped because of channel content filter")
+			continue
+		}
+		if quietHours, quiet := m.isQuiet(log, settings, varsFn); quiet {
+			if settings.QuietHoursMode == config.QuietHoursModeDigest {
+				log.Debug("Notification held until the end of channel quiet hours")
+				m.holdQuiet(ctx, in, chMsg, ch.Name, settings, quietHours, send)
+				continue
+			}
+			log.Debug("Notification skipped because of channel quiet hours")
+			continue
+		}
+
+		if m.summarizeStorm(ctx, in, chMsg, ch.Name, settings, send) {
+			continue
+		}
+
+		if settings.AggregationWindow > 0 && m.flags.Enabled(featureflag.Aggregation, ch.Alias) {
+			m.aggregate(ctx, in, chMsg, ch.Name, settings, send)
+			continue
+		}
+
+		key := fmt.Sprintf("%s/%s", settings.Locale, settings.ContentFilter)
+		group, ok := immediate[key]
+		if !ok {
+			group = &immediateGroup{msg: chMsg, locale: settings.Locale}
+			immediate[key] = group
+		}
+		group.channels = append(group.channels, ch.Name)
+	}
+
+	errs := multierror.New()
+	keys := make([]string, 0, len(immediate))
+	for key := range immediate {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		group := immediate[key]
+		if err := send(ctx, localize(group.msg, group.locale, m.now()), group.channels); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	return errs.ErrorOrNil()
+}
+
+// Flush sends all aggregated notifications, event storm summaries and quiet hours digests without waiting for their windows to elapse.
+// It's used to not lose notifications when the configuration is reloaded.
+func (m *Manager) Flush() {
+	m.mu.Lock()
+	batches := make([]*batch, 0, len(m.batches))
+	for key, b := range m.batches {
+		b.timer.Stop()
+		delete(m.batches, key)
+		batches = append(batches, b)
+	}
+	var digests []*batch
+	for key, s := range m.storms {
+		delete(m.storms, key)
+		if s.digest == nil {
+			continue
+		}
+		s.digest.timer.Stop()
+		digests = append(digests, s.digest)
+	}
+	quietDigests := make([]*batch, 0, len(m.quietDigests))
+	for key, b := range m.quietDigests {
+		b.timer.Stop()
+		delete(m.quietDigests, key)
+		quietDigests = append(quietDigests, b)
+	}
+	m.mu.Unlock()
+
+	sortByChannel := func(in []*batch) {
+		sort.Slice(in, func(i, j int) bool {
+			return in[i].channel < in[j].channel
+		})
+	}
+	sortByChannel(batches)
+	for _, b := range batches {
+		m.sendBatch(b)
+	}
+	sortByChannel(digests)
+	for _, b := range digests {
+		m.sendStormDigest(b)
+	}
+	sortByChannel(quietDigests)
+	for _, b := range quietDigests {
+		m.sendQuietDigest(b)
+	}
+}
+
+// matches returns true if a notification meets the minimal level and filter of given settings.
+func (m *Manager) matches(log logrus.FieldLogger, settings config.NotificationSettings, varsFn func() (map[string]any, error)) bool {
+	if settings.MinLevel == "" && settings.Filter == "" {
+		return true
+	}
+
+	vars, err := varsFn()
+	if err != nil {
+		log.WithError(err).Error("Cannot prepare filter variables. Sending notification...")
+		return true
+	}
+
+	if settings.MinLevel != "" {
+		if level, ok := eventLevel(vars); ok && levelSeverity[level] < levelSeverity[settings.MinLevel] {
+			return false
+		}
+	}
+
+	if settings.Filter != "" {
+		program, err := m.program(settings.Filter)
+		if err != nil {
+			log.WithError(err).Warn("Cannot compile notification filter. Sending notification...")
+			return true
+		}
+		matched, err := program.EvalBool(vars)
+		if err != nil {
+			log.WithError(err).Warn("Cannot evaluate notification filter. Sending notification...")
+			return true
+		}
+		return matched
+	}
+	return true
+}
+
+// filterContent applies the content filter selected in given settings. It returns false if the notification is blocked.
+// Notifications which cannot be filtered are blocked, so unwanted content is never sent.
+func (m *Manager) filterContent(log logrus.FieldLogger, settings config.NotificationSettings, msg interactive.CoreMessage) (interactive.CoreMessage, bool) {
+	if settings.ContentFilter == "" {
+		return msg, true
+	}
+
+	contentFilter, err := m.contentFilter(settings.ContentFilter)
+	if err != nil {
+		log.Errorf("while getting content filter: %s", err.Error())
+		return msg, false
+	}
+	return contentFilter.FilterMessage(msg)
+}
+
+// isQuiet returns true if a notification is sent during quiet hours of given settings, and it's not about a critical event.
+// It also returns the parsed quiet hours.
+func (m *Manager) isQuiet(log logrus.FieldLogger, settings config.NotificationSettings, varsFn func() (map[string]any, error)) (QuietHours, bool) {
+	if settings.QuietHours == "" {
+		return QuietHours{}, false
+	}
+
+	quietHours, err := m.parsedQuietHours(settings.QuietHours)
+	if err != nil {
+		log.WithError(err).Warn("Cannot parse quiet hours. Sending notification...")
+		return QuietHours{}, false
+	}
+	if !quietHours.Contains(m.now()) {
+		return quietHours, false
+	}
+
+	vars, err := varsFn()
+	if err != nil {
+		log.WithError(err).Error("Cannot prepare filter variables. Skipping notification during quiet hours...")
+		return quietHours, true
+	}
+	level, ok := eventLevel(vars)
+	return quietHours, !ok || level != config.Critical
+}
+
+func (m *Manager) parsedQuietHours(in string) (QuietHours, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// channels can be added at runtime, e.g. with BotkubeConfig resources, so their quiet hours may not be parsed yet
+	if parsed, ok := m.quietHours[in]; ok {
+		return parsed, nil
+	}
+	parsed, err := ParseQuietHours(in)
+	if err != nil {
+		return QuietHours{}, err
+	}
+	m.quietHours[in] = parsed
+	return parsed, nil
+}
+
+func (m *Manager) program(expr string) (*filter.Program, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// channels can be added at runtime, e.g. with BotkubeConfig resources, so their filters may not be compiled yet
+	if program, ok := m.programs[expr]; ok {
+		return program, nil
+	}
+	program, err := filter.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+	m.programs[expr] = program
+	return program, nil
+}
+
+func (m *Manager) contentFilter(name string) (*redaction.ContentFilter, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// channels can be added at runtime, e.g. with BotkubeConfig resources, so their content filters may not be compiled yet
+	if contentFilter, ok := m.contentFilters[name]; ok {
+		return contentFilter, nil
+	}
+	cfg, ok := m.cfg.Settings.ContentFilters[name]
+	if !ok {
+		return nil, fmt.Errorf("content filter %q is not defined", name)
+	}
+	contentFilter, err := redaction.NewContentFilter(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("while compiling content filter %q: %w", name, err)
+	}
+	m.contentFilters[name] = contentFilter
+	return contentFilter, nil
+}
+
+// aggregate adds a given notification to the batch of a given channel.
+func (m *Manager) aggregate(ctx context.Context, in Notification, msg interactive.CoreMessage, channel string, settings config.NotificationSettings, send SendFunc) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := fmt.Sprintf("%s/%s/%s/%s", in.Recipient, channel, settings.AggregationWindow, settings.Locale)
+	b, ok := m.batches[key]
+	if !ok {
+		b = &batch{
+			ctx:      ctx,
+			send:     send,
+			channel:  channel,
+			settings: settings,
+			started:  m.now(),
+			first:    msg,
+		}
+		b.timer = m.afterFunc(settings.AggregationWindow, func() {
+			m.mu.Lock()
+			_, ok := m.batches[key]
+			delete(m.batches, key)
+			m.mu.Unlock()
+			if ok {
+				m.sendBatch(b)
+			}
+		})
+		m.batches[key] = b
+	}
+	b.add(title(in, msg))
+}
+
+// holdQuiet adds a given notification to the quiet hours digest of a given channel, which is sent once quiet hours end.
+func (m *Manager) holdQuiet(ctx context.Context, in Notification, msg interactive.CoreMessage, channel string, settings config.NotificationSettings, quietHours QuietHours, send SendFunc) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := fmt.Sprintf("%s/%s/%s/%s", in.Recipient, channel, settings.QuietHours, settings.Locale)
+	b, ok := m.quietDigests[key]
+	if !ok {
+		now := m.now()
+		b = &batch{
+			ctx:      ctx,
+			send:     send,
+			channel:  channel,
+			settings: settings,
+			started:  now,
+		}
+		b.timer = m.afterFunc(quietHours.End(now).Sub(now), func() {
+			m.mu.Lock()
+			_, ok := m.quietDigests[key]
+			delete(m.quietDigests, key)
+			m.mu.Unlock()
+			if ok {
+				m.sendQuietDigest(b)
+			}
+		})
+		m.quietDigests[key] = b
+	}
+	b.add(title(in, msg))
+}
+
+// summarizeStorm counts notifications sent to a given channel, and returns true if the notification was added to the event storm digest.
+func (m *Manager) summarizeStorm(ctx context.Context, in Notification, msg interactive.CoreMessage, channel string, settings config.NotificationSettings, send SendFunc) bool {
+	cfg := m.cfg.Settings.FloodProtection
+	if !cfg.Enabled || cfg.MaxNotifications <= 0 || cfg.Window <= 0 {
+		return false
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	key := fmt.Sprintf("%s/%s", in.Recipient, channel)
+	s, ok := m.storms[key]
+	if !ok {
+		s = &storm{windowStart: now}
+		m.storms[key] = s
+	}
+
+	if s.digest == nil && now.Sub(s.windowStart) >= cfg.Window {
+		s.windowStart, s.count = now, 0
+	}
+	s.count++
+
+	if s.digest == nil {
+		if s.count <= cfg.MaxNotifications {
+			return false
+		}
+
+		m.log.WithFields(logrus.Fields{
+			"channel": channel,
+			"limit":   cfg.MaxNotifications,
+			"window":  cfg.Window,
+		}).Warn("Event storm detected. Switching channel to the digest mode...")
+		s.count = 0
+		s.digest = &batch{
+			ctx:      ctx,
+			send:     send,
+			channel:  channel,
+			settings: settings,
+			started:  now,
+		}
+		s.digest.timer = m.afterFunc(cfg.Window, func() {
+			m.checkStorm(key)
+		})
+	}
+	s.digest.add(title(in, msg))
+	return true
+}
+
+// checkStorm sends the event storm digest once the notification rate of a given channel subsides. Otherwise, the rate is checked again after the window.
+func (m *Manager) checkStorm(key string) {
+	cfg := m.cfg.Settings.FloodProtection
+
+	m.mu.Lock()
+	s, ok := m.storms[key]
+	if !ok || s.digest == nil {
+		m.mu.Unlock()
+		return
+	}
+	if s.count > cfg.MaxNotifications {
+		s.count = 0
+		s.digest.timer = m.afterFunc(cfg.Window, func() {
+			m.checkStorm(key)
+		})
+		m.mu.Unlock()
+		return
+	}
+	digest := s.digest
+	delete(m.storms, key)
+	m.mu.Unlock()
+
+	m.sendStormDigest(digest)
+}
+
+func (m *Manager) sendStormDigest(b *batch) {
+	if b.ctx.Err() != nil {
+		// the agent is shutting down
+		return
+	}
+
+	m.log.WithFields(logrus.Fields{
+		"channel":       b.channel,
+		"notifications": b.total,
+	}).Info("Event storm subsided. Sending summary...")
+	msg := stormMessage(b, m.now())
+	if err := b.send(b.ctx, localize(msg, b.settings.Locale, m.now()), []string{b.channel}); err != nil {
+		m.log.WithError(err).WithField("channel", b.channel).Error("Cannot send event storm summary")
+	}
+}
+
+func (m *Manager) sendQuietDigest(b *batch) {
+	if b.ctx.Err() != nil {
+		// the agent is shutting down
+		return
+	}
+
+	m.log.WithFields(logrus.Fields{
+		"channel":       b.channel,
+		"notifications": b.total,
+	}).Debug("Quiet hours are over. Sending digest...")
+	msg := quietDigestMessage(b, m.now())
+	if err := b.send(b.ctx, localize(msg, b.settings.Locale, m.now()), []string{b.channel}); err != nil {
+		m.log.WithError(err).WithField("channel", b.channel).Error("Cannot send quiet hours digest")
+	}
+}
+
+func (m *Manager) sendBatch(b *batch) {
+	if b.ctx.Err() != nil {
+		// the agent is shutting down
+		return
+	}
+
+	msg := b.first
+	if b.total > 1 {
+		msg = digestMessage(b, m.now())
+	}
+
+	m.log.WithFields(logrus.Fields{
+		"channel":       b.channel,
+		"notifications": b.total,
+	}).Debug("Sending aggregated notifications")
+	if err := b.send(b.ctx, localize(msg, b.settings.Locale, m.now()), []string{b.channel}); err != nil {
+		m.log.WithError(err).WithField("channel", b.channel).Error("Cannot send aggregated notifications")
+	}
+}
+
+func (b *batch) add(title string) {
+	b.total++
+	for i := range b.items {
+		if b.items[i].title == title {
+			b.items[i].count++
+			return
+		}
+	}
+	b.items = append(b.items, digestItem{title: title, count: 1})
+}
+
+func digestMessage(b *batch, sentAt time.Time) interactive.CoreMessage {
+	header := fmt.Sprintf("%d notifications", b.total)
+	return interactive.CoreMessage{
+		Header: header,
+		Message: api.Message{
+			Sections: []api.Section{
+				{
+					Base: api.Base{
+						Header:      fmt.Sprintf(":bell: %s", header),
+						Description: fmt.Sprintf("Notifications aggregated between %s and %s:", formatTime(b.settings.Locale, b.started), formatTime(b.settings.Locale, sentAt)),
+						Body: api.Body{
+							Plaintext: digestBody(b.items),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func stormMessage(b *batch, sentAt time.Time) interactive.CoreMessage {
+	header := fmt.Sprintf("Event storm detected, %d events summarized", b.total)
+	return interactive.CoreMessage{
+		Header: header,
+		Message: api.Message{
+			Sections: []api.Section{
+				{
+					Base: api.Base{
+						Header:      fmt.Sprintf(":warning: %s", header),
+						Description: fmt.Sprintf("Notifications received between %s and %s, while the notification rate was too high:", formatTime(b.settings.Locale, b.started), formatTime(b.settings.Locale, sentAt)),
+						Body: api.Body{
+							Plaintext: digestBody(b.items),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func quietDigestMessage(b *batch, sentAt time.Time) interactive.CoreMessage {
+	header := fmt.Sprintf("%d notifications held during quiet hours", b.total)
+	return interactive.CoreMessage{
+		Header: header,
+		Message: api.Message{
+			Sections: []api.Section{
+				{
+					Base: api.Base{
+						Header:      fmt.Sprintf(":crescent_moon: %s", header),
+						Description: fmt.Sprintf("Notifications received between %s and %s, during quiet hours:", formatTime(b.settings.Locale, b.started), formatTime(b.settings.Locale, sentAt)),
+						Body: api.Body{
+							Plaintext: digestBody(b.items),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func digestBody(items []digestItem) string {
+	var body strings.Builder
+	for idx, item := range items {
+		if idx == maxDigestItems {
+			fmt.Fprintf(&body, "…and %d more\n", len(items)-maxDigestItems)
+			break
+		}
+		fmt.Fprintf(&body, "• %s", item.title)
+		if item.count > 1 {
+			fmt.Fprintf(&body, " (%d times)", item.count)
+		}
+		body.WriteString("\n")
+	}
+	return body.String()
+}
+
+// localize adds the time when a given message is sent, formatted according to a given locale. The message is not modified if the locale is empty.
+func localize(msg interactive.CoreMessage, locale string, sentAt time.Time) interactive.CoreMessage {
+	if locale == "" {
+		return msg
+	}
+
+	item := api.ContextItem{Text: formatTime(locale, sentAt)}
+	sections := append([]api.Section(nil), msg.Sections...)
+	if len(sections) == 0 {
+		sections = append(sections, api.Section{})
+	}
+	last := &sections[len(sections)-1]
+	last.Context = append(append(api.ContextItems(nil), last.Context...), item)
+
+	msg.Sections = sections
+	return msg
+}
+
+func eventLevel(vars map[string]any) (config.Level, bool) {
+	event, ok := vars["event"].(map[string]any)
+	if !ok {
+		return "", false
+	}
+	level, ok := event["Level"].(string)
+	if !ok {
+		return "", false
+	}
+	_, known := levelSeverity[config.Level(level)]
+	return config.Level(level), known
+}
+
+func title(in Notification, msg interactive.CoreMessage) string {
+	for _, section := range msg.Sections {
+		if section.Header != "" {
+			return section.Header
+		}
+	}
+	if msg.Header != "" {
+		return msg.Header
+	}
+	return fmt.Sprintf("Notification from %q source", in.SourceName)
+}
@@ -0,0 +1,641 @@
+package notification
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/internal/featureflag"
+	"github.com/kubeshop/botkube/internal/filter"
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+type fakeTimer struct {
+	fn      func()
+	stopped bool
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.stopped = true
+	return true
+}
+
+type sentMessage struct {
+	msg      interactive.CoreMessage
+	channels []string
+}
+
+func TestResolve(t *testing.T) {
+	// given
+	cfg := config.Config{
+		Settings: config.Settings{
+			Notification: config.NotificationSettings{
+				MinLevel: config.Info,
+				Locale:   "en_GB",
+			},
+		},
+		Sources: map[string]config.Sources{
+			"k8s-events": {
+				Notification: config.NotificationSettings{
+					MinLevel:          config.Warn,
+					AggregationWindow: 5 * time.Minute,
+				},
+			},
+		},
+	}
+
+	// when
+	out := Resolve(cfg, "k8s-events", config.NotificationSettings{
+		MinLevel: config.Error,
+		Filter:   `event.Namespace == "prod"`,
+	})
+
+	// then
+	assert.Equal(t, Effective{
+		NotificationSettings: config.NotificationSettings{
+			MinLevel:          config.Error,
+			Filter:            `event.Namespace == "prod"`,
+			AggregationWindow: 5 * time.Minute,
+			Locale:            "en_GB",
+		},
+		Origins: Origins{
+			Profile:           DefaultOrigin,
+			MinLevel:          ChannelOrigin,
+			Filter:            ChannelOrigin,
+			AggregationWindow: SourceOrigin,
+			Locale:            GlobalOrigin,
+			QuietHours:        DefaultOrigin,
+			QuietHoursMode:    DefaultOrigin,
+			ContentFilter:     DefaultOrigin,
+		},
+	}, out)
+
+	// when source binding doesn't define settings
+	out = Resolve(cfg, "other", config.NotificationSettings{})
+
+	// then
+	assert.Equal(t, Effective{
+		NotificationSettings: config.NotificationSettings{
+			MinLevel: config.Info,
+			Locale:   "en_GB",
+		},
+		Origins: Origins{
+			Profile:           DefaultOrigin,
+			MinLevel:          GlobalOrigin,
+			Filter:            DefaultOrigin,
+			AggregationWindow: DefaultOrigin,
+			Locale:            GlobalOrigin,
+			QuietHours:        DefaultOrigin,
+			QuietHoursMode:    DefaultOrigin,
+			ContentFilter:     DefaultOrigin,
+		},
+	}, out)
+}
+
+func TestResolveProfiles(t *testing.T) {
+	// given
+	cfg := config.Config{
+		Settings: config.Settings{
+			Notification: config.NotificationSettings{
+				Profile: config.VerboseDevProfile,
+				Locale:  "en_GB",
+			},
+			NotificationProfiles: map[string]config.NotificationSettings{
+				"payments": {
+					MinLevel: config.Warn,
+					Filter:   `event.Namespace == "payments"`,
+				},
+			},
+		},
+		Sources: map[string]config.Sources{
+			"k8s-events": {
+				Notification: config.NotificationSettings{
+					AggregationWindow: time.Minute,
+				},
+			},
+		},
+	}
+
+	// when
+	out := Resolve(cfg, "k8s-events", config.NotificationSettings{
+		Profile:  config.QuietProdProfile,
+		MinLevel: config.Warn,
+	})
+
+	// then
+	assert.Equal(t, Effective{
+		NotificationSettings: config.NotificationSettings{
+			Profile:           config.QuietProdProfile,
+			MinLevel:          config.Warn,
+			AggregationWindow: 15 * time.Minute,
+			Locale:            "en_GB",
+		},
+		Origins: Origins{
+			Profile:           ChannelOrigin,
+			MinLevel:          ChannelOrigin,
+			Filter:            DefaultOrigin,
+			AggregationWindow: ProfileOrigin,
+			Locale:            GlobalOrigin,
+			QuietHours:        DefaultOrigin,
+			QuietHoursMode:    DefaultOrigin,
+			ContentFilter:     DefaultOrigin,
+		},
+	}, out)
+
+	// when a custom profile is selected
+	out = Resolve(cfg, "other", config.NotificationSettings{Profile: "payments"})
+
+	// then
+	assert.Equal(t, Effective{
+		NotificationSettings: config.NotificationSettings{
+			Profile:  "payments",
+			MinLevel: config.Warn,
+			Filter:   `event.Namespace == "payments"`,
+			Locale:   "en_GB",
+		},
+		Origins: Origins{
+			Profile:           ChannelOrigin,
+			MinLevel:          ProfileOrigin,
+			Filter:            ProfileOrigin,
+			AggregationWindow: DefaultOrigin,
+			Locale:            GlobalOrigin,
+			QuietHours:        DefaultOrigin,
+			QuietHoursMode:    DefaultOrigin,
+			ContentFilter:     DefaultOrigin,
+		},
+	}, out)
+}
+
+func TestNewManagerErrors(t *testing.T) {
+	// given
+	cfg := config.Config{
+		Settings: config.Settings{
+			Notification: config.NotificationSettings{Locale: "xx"},
+		},
+		Sources: map[string]config.Sources{
+			"k8s-events": {
+				Notification: config.NotificationSettings{Filter: "event.Level =="},
+			},
+			"prometheus": {
+				Notification: config.NotificationSettings{ContentFilter: "public"},
+			},
+		},
+	}
+
+	// when
+	_, err := NewManager(loggerx.NewNoop(), cfg, nil)
+
+	// then
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `locale "xx" used in "settings.notification" is not supported`)
+	assert.Contains(t, err.Error(), `while compiling filter for "sources.k8s-events.notification"`)
+	assert.Contains(t, err.Error(), `content filter "public" used in "sources.prometheus.notification" is not defined`)
+}
+
+func TestManagerSend(t *testing.T) {
+	// given
+	now := time.Date(2023, 3, 15, 14, 5, 0, 0, time.UTC)
+	cfg := config.Config{
+		Settings: config.Settings{
+			Notification: config.NotificationSettings{MinLevel: config.Warn},
+		},
+		Sources: map[string]config.Sources{
+			"k8s-events": {
+				Notification: config.NotificationSettings{Filter: `event.Namespace == "prod"`},
+			},
+		},
+	}
+	manager, err := NewManager(loggerx.NewNoop(), cfg, nil)
+	require.NoError(t, err)
+	manager.now = func() time.Time { return now }
+
+	channels := []Channel{
+		{Name: "all"},
+		{Name: "errors", Settings: config.NotificationSettings{MinLevel: config.Error}},
+		{Name: "german", Settings: config.NotificationSettings{Locale: "de"}},
+		{Name: "everything", Settings: config.NotificationSettings{MinLevel: config.Debug, Filter: "true"}},
+	}
+
+	var sent []sentMessage
+	send := func(_ context.Context, msg interactive.CoreMessage, channels []string) error {
+		sent = append(sent, sentMessage{msg: msg, channels: channels})
+		return nil
+	}
+	notification := func(namespace string, level config.Level) Notification {
+		return Notification{
+			Input: filter.Input{
+				SourceName: "k8s-events",
+				Event:      map[string]any{"Level": level, "Namespace": namespace},
+			},
+			Recipient: "default-group-socketSlack",
+		}
+	}
+	msg := interactive.CoreMessage{Header: "Pod failed"}
+
+	// when
+	err = manager.Send(context.Background(), notification("prod", config.Warn), msg, channels, send)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []sentMessage{
+		{msg: msg, channels: []string{"all", "everything"}},
+		{
+			msg: interactive.CoreMessage{
+				Header: "Pod failed",
+				Message: api.Message{
+					Sections: []api.Section{{Context: api.ContextItems{{Text: "15.03.2023 14:05 UTC"}}}},
+				},
+			},
+			channels: []string{"german"},
+		},
+	}, sent)
+
+	// when notification doesn't match the inherited filter
+	sent = nil
+	err = manager.Send(context.Background(), notification("dev", config.Error), msg, channels, send)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []sentMessage{{msg: msg, channels: []string{"everything"}}}, sent)
+
+	// when the level is below the global minimal level
+	sent = nil
+	err = manager.Send(context.Background(), notification("prod", config.Info), msg, channels, send)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []sentMessage{{msg: msg, channels: []string{"everything"}}}, sent)
+}
+
+func TestManagerAggregation(t *testing.T) {
+	// given
+	now := time.Date(2023, 3, 15, 14, 5, 0, 0, time.UTC)
+	manager, err := NewManager(loggerx.NewNoop(), config.Config{}, nil)
+	require.NoError(t, err)
+	manager.now = func() time.Time { return now }
+
+	var timers []*fakeTimer
+	manager.afterFunc = func(d time.Duration, f func()) timer {
+		assert.Equal(t, 10*time.Minute, d)
+		t := &fakeTimer{fn: f}
+		timers = append(timers, t)
+		return t
+	}
+
+	var sent []sentMessage
+	send := func(_ context.Context, msg interactive.CoreMessage, channels []string) error {
+		sent = append(sent, sentMessage{msg: msg, channels: channels})
+		return nil
+	}
+	channels := []Channel{
+		{Name: "digest", Settings: config.NotificationSettings{AggregationWindow: 10 * time.Minute, Locale: "en_GB"}},
+	}
+	in := Notification{
+		Input:     filter.Input{SourceName: "k8s-events"},
+		Recipient: "default-group-socketSlack",
+	}
+
+	// when
+	for _, header := range []string{"Pod failed", "Pod failed", "Node not ready"} {
+		err := manager.Send(context.Background(), in, interactive.CoreMessage{Header: header}, channels, send)
+		require.NoError(t, err)
+	}
+
+	// then
+	assert.Empty(t, sent)
+	require.Len(t, timers, 1)
+
+	// when the window elapses
+	now = now.Add(10 * time.Minute)
+	timers[0].fn()
+
+	// then
+	require.Len(t, sent, 1)
+	assert.Equal(t, []string{"digest"}, sent[0].channels)
+	assert.Equal(t, "3 notifications", sent[0].msg.Header)
+	section := sent[0].msg.Sections[0]
+	assert.Equal(t, "Notifications aggregated between 15 Mar 2023 14:05 UTC and 15 Mar 2023 14:15 UTC:", section.Description)
+	assert.Equal(t, "• Pod failed (2 times)\n• Node not ready\n", section.Body.Plaintext)
+	assert.Equal(t, api.ContextItems{{Text: "15 Mar 2023 14:15 UTC"}}, section.Context)
+
+	// when a single notification is flushed
+	sent = nil
+	err = manager.Send(context.Background(), in, interactive.CoreMessage{Header: "Pod failed"}, channels, send)
+	require.NoError(t, err)
+	manager.Flush()
+
+	// then
+	require.Len(t, timers, 2)
+	assert.True(t, timers[1].stopped)
+	require.Len(t, sent, 1)
+	assert.Equal(t, "Pod failed", sent[0].msg.Header)
+}
+
+func TestManagerAggregationFeatureFlag(t *testing.T) {
+	// given
+	flags := featureflag.NewManager(config.Config{})
+	flags.Set(featureflag.Aggregation, "alerts", false)
+	manager, err := NewManager(loggerx.NewNoop(), config.Config{}, flags)
+	require.NoError(t, err)
+	manager.afterFunc = func(time.Duration, func()) timer {
+		return &fakeTimer{}
+	}
+
+	var sent []sentMessage
+	send := func(_ context.Context, msg interactive.CoreMessage, channels []string) error {
+		sent = append(sent, sentMessage{msg: msg, channels: channels})
+		return nil
+	}
+	settings := config.NotificationSettings{AggregationWindow: 10 * time.Minute}
+	channels := []Channel{
+		{Name: "C123", Alias: "alerts", Settings: settings},
+		{Name: "C456", Alias: "digest", Settings: settings},
+	}
+	msg := interactive.CoreMessage{Header: "Pod failed"}
+
+	// when
+	err = manager.Send(context.Background(), Notification{Input: filter.Input{SourceName: "k8s-events"}}, msg, channels, send)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []sentMessage{{msg: msg, channels: []string{"C123"}}}, sent)
+}
+
+func TestManagerFloodProtection(t *testing.T) {
+	// given
+	now := time.Date(2023, 3, 15, 14, 5, 0, 0, time.UTC)
+	manager, err := NewManager(loggerx.NewNoop(), config.Config{
+		Settings: config.Settings{
+			FloodProtection: config.FloodProtection{
+				Enabled:          true,
+				MaxNotifications: 2,
+				Window:           time.Minute,
+			},
+		},
+	}, nil)
+	require.NoError(t, err)
+	require.True(t, manager.IsDefined())
+	manager.now = func() time.Time { return now }
+
+	var timers []*fakeTimer
+	manager.afterFunc = func(d time.Duration, f func()) timer {
+		assert.Equal(t, time.Minute, d)
+		t := &fakeTimer{fn: f}
+		timers = append(timers, t)
+		return t
+	}
+
+	var sent []sentMessage
+	send := func(_ context.Context, msg interactive.CoreMessage, channels []string) error {
+		sent = append(sent, sentMessage{msg: msg, channels: channels})
+		return nil
+	}
+	channels := []Channel{{Name: "alerts"}}
+	in := Notification{
+		Input:     filter.Input{SourceName: "k8s-events"},
+		Recipient: "default-group-socketSlack",
+	}
+	sendAll := func(headers ...string) {
+		for _, header := range headers {
+			err := manager.Send(context.Background(), in, interactive.CoreMessage{Header: header}, channels, send)
+			require.NoError(t, err)
+		}
+	}
+
+	// when
+	sendAll("Pod failed", "Pod failed", "Pod failed", "Node not ready")
+
+	// then
+	require.Len(t, sent, 2)
+	require.Len(t, timers, 1)
+
+	// when the rate is still too high after the window
+	sent = nil
+	now = now.Add(time.Minute)
+	sendAll("Pod failed", "Pod failed", "Pod failed")
+	timers[0].fn()
+
+	// then
+	assert.Empty(t, sent)
+	require.Len(t, timers, 2)
+
+	// when the rate subsides
+	now = now.Add(time.Minute)
+	sendAll("Pod failed")
+	timers[1].fn()
+
+	// then
+	require.Len(t, sent, 1)
+	assert.Equal(t, []string{"alerts"}, sent[0].channels)
+	assert.Equal(t, "Event storm detected, 6 events summarized", sent[0].msg.Header)
+	section := sent[0].msg.Sections[0]
+	assert.Equal(t, "Notifications received between 2023-03-15T14:05:00Z and 2023-03-15T14:07:00Z, while the notification rate was too high:", section.Description)
+	assert.Equal(t, "• Pod failed (5 times)\n• Node not ready\n", section.Body.Plaintext)
+
+	// when the storm is over
+	sent = nil
+	sendAll("Pod failed")
+
+	// then
+	require.Len(t, sent, 1)
+	assert.Equal(t, "Pod failed", sent[0].msg.Header)
+}
+
+func TestManagerQuietHours(t *testing.T) {
+	// given
+	now := time.Date(2023, 3, 15, 23, 30, 0, 0, time.UTC)
+	manager, err := NewManager(loggerx.NewNoop(), config.Config{}, nil)
+	require.NoError(t, err)
+	manager.now = func() time.Time { return now }
+
+	channels := []Channel{
+		{Name: "all"},
+		{Name: "quiet", Settings: config.NotificationSettings{QuietHours: "22:00-07:00"}},
+	}
+	var sent []sentMessage
+	send := func(_ context.Context, msg interactive.CoreMessage, channels []string) error {
+		sent = append(sent, sentMessage{msg: msg, channels: channels})
+		return nil
+	}
+	notification := func(level config.Level) Notification {
+		return Notification{
+			Input: filter.Input{
+				SourceName: "k8s-events",
+				Event:      map[string]any{"Level": level},
+			},
+		}
+	}
+	msg := interactive.CoreMessage{Header: "Pod failed"}
+
+	// when
+	err = manager.Send(context.Background(), notification(config.Error), msg, channels, send)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []sentMessage{{msg: msg, channels: []string{"all"}}}, sent)
+
+	// when the event is critical
+	sent = nil
+	err = manager.Send(context.Background(), notification(config.Critical), msg, channels, send)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []sentMessage{{msg: msg, channels: []string{"all", "quiet"}}}, sent)
+
+	// when quiet hours are over
+	sent = nil
+	now = now.Add(8 * time.Hour)
+	err = manager.Send(context.Background(), notification(config.Error), msg, channels, send)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []sentMessage{{msg: msg, channels: []string{"all", "quiet"}}}, sent)
+}
+
+func TestManagerQuietHoursDigest(t *testing.T) {
+	// given
+	now := time.Date(2023, 3, 15, 23, 30, 0, 0, time.UTC)
+	manager, err := NewManager(loggerx.NewNoop(), config.Config{}, nil)
+	require.NoError(t, err)
+	manager.now = func() time.Time { return now }
+
+	var (
+		timers    []*fakeTimer
+		durations []time.Duration
+	)
+	manager.afterFunc = func(d time.Duration, f func()) timer {
+		t := &fakeTimer{fn: f}
+		timers = append(timers, t)
+		durations = append(durations, d)
+		return t
+	}
+
+	channels := []Channel{
+		{Name: "quiet", Settings: config.NotificationSettings{QuietHours: "22:00-07:00", QuietHoursMode: config.QuietHoursModeDigest, Locale: "en_GB"}},
+	}
+	var sent []sentMessage
+	send := func(_ context.Context, msg interactive.CoreMessage, channels []string) error {
+		sent = append(sent, sentMessage{msg: msg, channels: channels})
+		return nil
+	}
+	notification := func(level config.Level) Notification {
+		return Notification{
+			Input: filter.Input{
+				SourceName: "k8s-events",
+				Event:      map[string]any{"Level": level},
+			},
+			Recipient: "default-group-socketSlack",
+		}
+	}
+
+	// when
+	for _, header := range []string{"Pod failed", "Node not ready", "Pod failed"} {
+		err := manager.Send(context.Background(), notification(config.Error), interactive.CoreMessage{Header: header}, channels, send)
+		require.NoError(t, err)
+	}
+
+	// then
+	assert.Empty(t, sent)
+	require.Len(t, timers, 1)
+	assert.Equal(t, 7*time.Hour+30*time.Minute, durations[0])
+
+	// when the event is critical
+	err = manager.Send(context.Background(), notification(config.Critical), interactive.CoreMessage{Header: "Node down"}, channels, send)
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, sent, 1)
+	assert.Equal(t, "Node down", sent[0].msg.Header)
+
+	// when quiet hours end
+	sent = nil
+	now = now.Add(7*time.Hour + 30*time.Minute)
+	timers[0].fn()
+
+	// then
+	require.Len(t, sent, 1)
+	assert.Equal(t, []string{"quiet"}, sent[0].channels)
+	assert.Equal(t, "3 notifications held during quiet hours", sent[0].msg.Header)
+	section := sent[0].msg.Sections[0]
+	assert.Equal(t, "Notifications received between 15 Mar 2023 23:30 UTC and 16 Mar 2023 07:00 UTC, during quiet hours:", section.Description)
+	assert.Equal(t, "• Pod failed (2 times)\n• Node not ready\n", section.Body.Plaintext)
+
+	// when the digest is flushed
+	sent = nil
+	now = now.Add(15 * time.Hour)
+	err = manager.Send(context.Background(), notification(config.Warn), interactive.CoreMessage{Header: "Pod evicted"}, channels, send)
+	require.NoError(t, err)
+	manager.Flush()
+
+	// then
+	require.Len(t, timers, 2)
+	assert.Equal(t, 9*time.Hour, durations[1])
+	assert.True(t, timers[1].stopped)
+	require.Len(t, sent, 1)
+	assert.Equal(t, "1 notifications held during quiet hours", sent[0].msg.Header)
+}
+
+func TestManagerContentFilters(t *testing.T) {
+	// given
+	cfg := config.Config{
+		Settings: config.Settings{
+			ContentFilters: map[string]config.ContentFilter{
+				"mask-pii": {PII: []config.PIIType{config.EmailPII}},
+				"block-words": {
+					Action: config.ContentFilterActionBlock,
+					Words:  []string{"darn"},
+				},
+			},
+		},
+	}
+	manager, err := NewManager(loggerx.NewNoop(), cfg, nil)
+	require.NoError(t, err)
+
+	channels := []Channel{
+		{Name: "private"},
+		{Name: "public", Settings: config.NotificationSettings{ContentFilter: "mask-pii"}},
+		{Name: "kids", Settings: config.NotificationSettings{ContentFilter: "block-words"}},
+	}
+	var sent []sentMessage
+	send := func(_ context.Context, msg interactive.CoreMessage, channels []string) error {
+		sent = append(sent, sentMessage{msg: msg, channels: channels})
+		return nil
+	}
+	in := Notification{Input: filter.Input{SourceName: "k8s-events"}}
+	msg := func(text string) interactive.CoreMessage {
+		return interactive.CoreMessage{
+			Header: "Pod failed",
+			Message: api.Message{
+				Sections: []api.Section{{Base: api.Base{Description: text}}},
+			},
+		}
+	}
+
+	// when
+	err = manager.Send(context.Background(), in, msg("Contact john@example.com"), channels, send)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []sentMessage{
+		{msg: msg("Contact john@example.com"), channels: []string{"private"}},
+		{msg: msg("Contact john@example.com"), channels: []string{"kids"}},
+		{msg: msg("Contact ***"), channels: []string{"public"}},
+	}, sent)
+
+	// when the message contains blocked words
+	sent = nil
+	err = manager.Send(context.Background(), in, msg("Darn, the pod failed again"), channels, send)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []sentMessage{
+		{msg: msg("Darn, the pod failed again"), channels: []string{"private"}},
+		{msg: msg("Darn, the pod failed again"), channels: []string{"public"}},
+	}, sent)
+}
@@ -0,0 +1,80 @@
+package notification
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const quietHoursLayout = "15:04"
+
+// QuietHours is a daily period of time during which only critical events are sent.
+type QuietHours struct {
+	start    time.Duration
+	end      time.Duration
+	location *time.Location
+}
+
+// ParseQuietHours parses quiet hours in the `HH:MM-HH:MM [time zone]` format, e.g. `22:00-07:00 Europe/Berlin`.
+// The period can span midnight. Times are in UTC unless a time zone is given.
+func ParseQuietHours(in string) (QuietHours, error) {
+	fields := strings.Fields(in)
+	if len(fields) == 0 || len(fields) > 2 {
+		return QuietHours{}, fmt.Errorf("quiet hours %q must be in the HH:MM-HH:MM [time zone] format", in)
+	}
+
+	from, to, found := strings.Cut(fields[0], "-")
+	if !found {
+		return QuietHours{}, fmt.Errorf("quiet hours %q must be in the HH:MM-HH:MM [time zone] format", in)
+	}
+	start, err := parseClock(from)
+	if err != nil {
+		return QuietHours{}, err
+	}
+	end, err := parseClock(to)
+	if err != nil {
+		return QuietHours{}, err
+	}
+	if start == end {
+		return QuietHours{}, fmt.Errorf("quiet hours %q must not start and end at the same time", in)
+	}
+
+	out := QuietHours{start: start, end: end, location: time.UTC}
+	if len(fields) == 2 {
+		out.location, err = time.LoadLocation(fields[1])
+		if err != nil {
+			return QuietHours{}, fmt.Errorf("while loading time zone of quiet hours: %w", err)
+		}
+	}
+	return out, nil
+}
+
+// Contains returns true if a given time is within quiet hours.
+func (q QuietHours) Contains(t time.Time) bool {
+	t = t.In(q.location)
+	sinceMidnight := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second
+	if q.start < q.end {
+		return sinceMidnight >= q.start && sinceMidnight < q.end
+	}
+	// spans midnight
+	return sinceMidnight >= q.start || sinceMidnight < q.end
+}
+
+// End returns the end of quiet hours which follows a given time.
+func (q QuietHours) End(t time.Time) time.Time {
+	local := t.In(q.location)
+	hour, minute := int(q.end/time.Hour), int(q.end%time.Hour/time.Minute)
+	end := time.Date(local.Year(), local.Month(), local.Day(), hour, minute, 0, 0, q.location)
+	if !end.After(t) {
+		end = time.Date(local.Year(), local.Month(), local.Day()+1, hour, minute, 0, 0, q.location)
+	}
+	return end
+}
+
+func parseClock(in string) (time.Duration, error) {
+	t, err := time.Parse(quietHoursLayout, in)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q of quiet hours, use the HH:MM format", in)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
@@ -0,0 +1,124 @@
+package notification
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuietHoursContains(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       string
+		time     time.Time
+		expected bool
+	}{
+		{
+			name:     "within period spanning midnight",
+			in:       "22:00-07:00",
+			time:     time.Date(2023, 3, 15, 2, 0, 0, 0, time.UTC),
+			expected: true,
+		},
+		{
+			name:     "after period spanning midnight",
+			in:       "22:00-07:00",
+			time:     time.Date(2023, 3, 15, 7, 0, 0, 0, time.UTC),
+			expected: false,
+		},
+		{
+			name:     "within daytime period",
+			in:       "12:00-13:30",
+			time:     time.Date(2023, 3, 15, 13, 29, 0, 0, time.UTC),
+			expected: true,
+		},
+		{
+			name:     "before daytime period",
+			in:       "12:00-13:30",
+			time:     time.Date(2023, 3, 15, 11, 59, 0, 0, time.UTC),
+			expected: false,
+		},
+		{
+			name:     "within period in a given time zone",
+			in:       "22:00-07:00 Europe/Warsaw",
+			time:     time.Date(2023, 3, 15, 21, 30, 0, 0, time.UTC),
+			expected: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			quietHours, err := ParseQuietHours(tc.in)
+			require.NoError(t, err)
+
+			// when
+			out := quietHours.Contains(tc.time)
+
+			// then
+			assert.Equal(t, tc.expected, out)
+		})
+	}
+}
+
+func TestQuietHoursEnd(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       string
+		time     time.Time
+		expected time.Time
+	}{
+		{
+			name:     "before midnight",
+			in:       "22:00-07:00",
+			time:     time.Date(2023, 3, 15, 23, 0, 0, 0, time.UTC),
+			expected: time.Date(2023, 3, 16, 7, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "after midnight",
+			in:       "22:00-07:00",
+			time:     time.Date(2023, 3, 16, 2, 0, 0, 0, time.UTC),
+			expected: time.Date(2023, 3, 16, 7, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "in a given time zone",
+			in:       "22:00-07:30 Europe/Warsaw",
+			time:     time.Date(2023, 3, 15, 21, 30, 0, 0, time.UTC),
+			expected: time.Date(2023, 3, 16, 6, 30, 0, 0, time.UTC),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			quietHours, err := ParseQuietHours(tc.in)
+			require.NoError(t, err)
+
+			// when
+			out := quietHours.End(tc.time)
+
+			// then
+			assert.True(t, tc.expected.Equal(out), "expected %s, got %s", tc.expected, out)
+		})
+	}
+}
+
+func TestParseQuietHoursErrors(t *testing.T) {
+	tests := []struct {
+		in          string
+		expectedErr string
+	}{
+		{in: "22:00", expectedErr: `quiet hours "22:00" must be in the HH:MM-HH:MM [time zone] format`},
+		{in: "22:00-25:00", expectedErr: `invalid time "25:00" of quiet hours, use the HH:MM format`},
+		{in: "07:00-07:00", expectedErr: `quiet hours "07:00-07:00" must not start and end at the same time`},
+		{in: "22:00-07:00 Mars/Olympus", expectedErr: "while loading time zone of quiet hours: unknown time zone Mars/Olympus"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.in, func(t *testing.T) {
+			// when
+			_, err := ParseQuietHours(tc.in)
+
+			// then
+			assert.EqualError(t, err, tc.expectedErr)
+		})
+	}
+}
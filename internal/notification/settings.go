@@ -0,0 +1,161 @@
+package notification
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+// Origin describes where a given effective setting is defined.
+type Origin string
+
+const (
+	// DefaultOrigin is used for settings which are not defined anywhere.
+	DefaultOrigin Origin = "default"
+	// GlobalOrigin is used for settings defined under `settings.notification`.
+	GlobalOrigin Origin = "global"
+	// SourceOrigin is used for settings defined for a source binding.
+	SourceOrigin Origin = "source"
+	// ChannelOrigin is used for settings defined for a channel.
+	ChannelOrigin Origin = "channel"
+	// ProfileOrigin is used for settings defined by a selected notification profile.
+	ProfileOrigin Origin = "profile"
+)
+
+// Origins holds origins of all effective settings.
+type Origins struct {
+	Profile           Origin
+	MinLevel          Origin
+	Filter            Origin
+	AggregationWindow Origin
+	Locale            Origin
+	QuietHours        Origin
+	QuietHoursMode    Origin
+	ContentFilter     Origin
+}
+
+// Effective holds notification settings merged for a given source binding and channel.
+type Effective struct {
+	config.NotificationSettings
+	Origins Origins
+}
+
+// Resolve merges notification settings for a given source binding and channel. Channel settings override
+// source binding settings, which override global settings. Empty settings are inherited. Settings of a notification profile
+// selected in a given place are applied just before the other settings defined there, so they can be tweaked.
+func Resolve(cfg config.Config, sourceName string, channel config.NotificationSettings) Effective {
+	out := Effective{
+		Origins: Origins{
+			Profile:           DefaultOrigin,
+			MinLevel:          DefaultOrigin,
+			Filter:            DefaultOrigin,
+			AggregationWindow: DefaultOrigin,
+			Locale:            DefaultOrigin,
+			QuietHours:        DefaultOrigin,
+			QuietHoursMode:    DefaultOrigin,
+			ContentFilter:     DefaultOrigin,
+		},
+	}
+
+	levels := []struct {
+		origin   Origin
+		settings config.NotificationSettings
+	}{
+		{origin: GlobalOrigin, settings: cfg.Settings.Notification},
+		{origin: SourceOrigin, settings: cfg.Sources[sourceName].Notification},
+		{origin: ChannelOrigin, settings: channel},
+	}
+	for _, level := range levels {
+		if profile, ok := cfg.NotificationProfile(level.settings.Profile); ok {
+			out.Profile, out.Origins.Profile = level.settings.Profile, level.origin
+			out.apply(profile, ProfileOrigin)
+		}
+		out.apply(level.settings, level.origin)
+	}
+	return out
+}
+
+// apply overrides effective settings with the ones defined in given settings.
+func (e *Effective) apply(s config.NotificationSettings, origin Origin) {
+	if s.MinLevel != "" {
+		e.MinLevel, e.Origins.MinLevel = s.MinLevel, origin
+	}
+	if s.Filter != "" {
+		e.Filter, e.Origins.Filter = s.Filter, origin
+	}
+	if s.AggregationWindow > 0 {
+		e.AggregationWindow, e.Origins.AggregationWindow = s.AggregationWindow, origin
+	}
+	if s.Locale != "" {
+		e.Locale, e.Origins.Locale = s.Locale, origin
+	}
+	if s.QuietHours != "" {
+		e.QuietHours, e.Origins.QuietHours = s.QuietHours, origin
+	}
+	if s.QuietHoursMode != "" {
+		e.QuietHoursMode, e.Origins.QuietHoursMode = s.QuietHoursMode, origin
+	}
+	if s.ContentFilter != "" {
+		e.ContentFilter, e.Origins.ContentFilter = s.ContentFilter, origin
+	}
+}
+
+// IsDefined returns true if notification settings are defined globally, for any source binding, or for any channel.
+// Otherwise, notifications can be sent without resolving settings for each channel.
+func IsDefined(cfg config.Config) bool {
+	for _, item := range AllSettings(cfg) {
+		if !item.Settings.IsEmpty() {
+			return true
+		}
+	}
+	return false
+}
+
+// DefinedSettings holds notification settings defined in a given place of the configuration.
+type DefinedSettings struct {
+	// Path is the YAML path of the settings, e.g. `sources.k8s-events.notification`.
+	Path string
+	// Enabled is false for settings of channels of disabled communication platforms.
+	Enabled  bool
+	Settings config.NotificationSettings
+}
+
+// AllSettings returns notification settings defined globally, in custom notification profiles, for all source bindings, and for all channels, ordered by their paths.
+func AllSettings(cfg config.Config) []DefinedSettings {
+	out := []DefinedSettings{{Path: "settings.notification", Enabled: true, Settings: cfg.Settings.Notification}}
+	add := func(enabled bool, path string, settings config.NotificationSettings) {
+		out = append(out, DefinedSettings{Path: path + ".notification", Enabled: enabled, Settings: settings})
+	}
+
+	for name, profile := range cfg.Settings.NotificationProfiles {
+		out = append(out, DefinedSettings{Path: fmt.Sprintf("settings.notificationProfiles.%s", name), Enabled: true, Settings: profile})
+	}
+	for name, src := range cfg.Sources {
+		add(true, fmt.Sprintf("sources.%s", name), src.Notification)
+	}
+	for group, comm := range cfg.Communications {
+		for alias, ch := range comm.SocketSlack.Channels {
+			add(comm.SocketSlack.Enabled, fmt.Sprintf("communications.%s.socketSlack.channels.%s", group, alias), ch.Notification.NotificationSettings)
+		}
+		for alias, ch := range comm.CloudSlack.Channels {
+			add(comm.CloudSlack.Enabled, fmt.Sprintf("communications.%s.cloudSlack.channels.%s", group, alias), ch.Notification.NotificationSettings)
+		}
+		for alias, ch := range comm.Mattermost.Channels {
+			add(comm.Mattermost.Enabled, fmt.Sprintf("communications.%s.mattermost.channels.%s", group, alias), ch.Notification.NotificationSettings)
+		}
+		for alias, ch := range comm.Discord.Channels {
+			add(comm.Discord.Enabled, fmt.Sprintf("communications.%s.discord.channels.%s", group, alias), ch.Notification.NotificationSettings)
+		}
+		for idx, team := range comm.CloudTeams.Teams {
+			for alias, ch := range team.Channels {
+				add(comm.CloudTeams.Enabled, fmt.Sprintf("communications.%s.cloudTeams.teams[%d].channels.%s", group, idx, alias), ch.Notification.NotificationSettings)
+			}
+		}
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Path < out[j].Path
+	})
+	return out
+}
@@ -0,0 +1,321 @@
+package overview
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+const (
+	defaultMaxItems     = 5
+	defaultEventsWindow = time.Hour
+)
+
+// failingWaitingReasons are reasons of waiting containers which won't start without an intervention.
+var failingWaitingReasons = map[string]struct{}{
+	"CrashLoopBackOff":           {},
+	"ImagePullBackOff":           {},
+	"ErrImagePull":               {},
+	"CreateContainerConfigError": {},
+	"InvalidImageName":           {},
+}
+
+// pressureConditions are node conditions which indicate resource pressure when their status is true.
+var pressureConditions = []corev1.NodeConditionType{
+	corev1.NodeMemoryPressure,
+	corev1.NodeDiskPressure,
+	corev1.NodePIDPressure,
+	corev1.NodeNetworkUnavailable,
+}
+
+// Snapshot holds the cluster state shown on the dashboard. Lists are limited to the configured number of items,
+// while counts include all matching items.
+type Snapshot struct {
+	CollectedAt time.Time
+
+	NodesTotal    int
+	NodesNotReady []string
+	Pressure      []NodePressure
+
+	PendingPodsCount int
+	PendingPods      []Pod
+	FailingPodsCount int
+	FailingPods      []Pod
+	TopRestarters    []Pod
+
+	WarningEventsCount int
+	WarningEvents      []Event
+}
+
+// NodePressure describes a node condition which indicates resource pressure.
+type NodePressure struct {
+	Node      string
+	Condition string
+}
+
+// Pod describes a Pod listed on the dashboard.
+type Pod struct {
+	Namespace string
+	Name      string
+	Reason    string
+	Restarts  int32
+}
+
+// Event describes a warning event listed on the dashboard.
+type Event struct {
+	Namespace string
+	Object    string
+	Reason    string
+	Message   string
+	Count     int32
+	LastSeen  time.Time
+}
+
+// Collector collects the cluster state for the overview dashboard.
+type Collector struct {
+	cfg config.Overview
+	now func() time.Time
+
+	// newK8sCli returns a client which impersonates configured groups.
+	newK8sCli func() (kubernetes.Interface, error)
+}
+
+// New returns a new Collector instance.
+func New(cfg config.Overview, restCfg *rest.Config) *Collector {
+	if cfg.MaxItems <= 0 {
+		cfg.MaxItems = defaultMaxItems
+	}
+	if cfg.EventsWindow <= 0 {
+		cfg.EventsWindow = defaultEventsWindow
+	}
+	if len(cfg.Groups) == 0 {
+		cfg.Groups = []string{config.RBACDefaultGroup}
+	}
+
+	return &Collector{
+		cfg: cfg,
+		now: time.Now,
+		newK8sCli: func() (kubernetes.Interface, error) {
+			if restCfg == nil {
+				return nil, errors.New("missing Kubernetes client configuration")
+			}
+			impersonated := rest.CopyConfig(restCfg)
+			impersonated.Impersonate = rest.ImpersonationConfig{UserName: config.RBACDefaultUser, Groups: cfg.Groups}
+			return kubernetes.NewForConfig(impersonated)
+		},
+	}
+}
+
+// Collect returns the current cluster state.
+func (c *Collector) Collect(ctx context.Context) (Snapshot, error) {
+	cli, err := c.newK8sCli()
+	if err != nil {
+		return Snapshot{}, fmt.Errorf("while creating Kubernetes client: %w", err)
+	}
+
+	out := Snapshot{CollectedAt: c.now()}
+	if err := c.collectNodes(ctx, cli, &out); err != nil {
+		return Snapshot{}, err
+	}
+	if err := c.collectPods(ctx, cli, &out); err != nil {
+		return Snapshot{}, err
+	}
+	if err := c.collectEvents(ctx, cli, &out); err != nil {
+		return Snapshot{}, err
+	}
+	return out, nil
+}
+
+func (c *Collector) collectNodes(ctx context.Context, cli kubernetes.Interface, out *Snapshot) error {
+	nodes, err := cli.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("while listing nodes: %w", err)
+	}
+
+	sort.Slice(nodes.Items, func(i, j int) bool { return nodes.Items[i].Name < nodes.Items[j].Name })
+	out.NodesTotal = len(nodes.Items)
+	for _, node := range nodes.Items {
+		if !isNodeReady(node) {
+			out.NodesNotReady = append(out.NodesNotReady, node.Name)
+		}
+		for _, cond := range node.Status.Conditions {
+			if cond.Status == corev1.ConditionTrue && isPressureCondition(cond.Type) {
+				out.Pressure = append(out.Pressure, NodePressure{Node: node.Name, Condition: string(cond.Type)})
+			}
+		}
+	}
+	return nil
+}
+
+func (c *Collector) collectPods(ctx context.Context, cli kubernetes.Interface, out *Snapshot) error {
+	pods, err := cli.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("while listing Pods: %w", err)
+	}
+
+	sort.Slice(pods.Items, func(i, j int) bool {
+		if pods.Items[i].Namespace != pods.Items[j].Namespace {
+			return pods.Items[i].Namespace < pods.Items[j].Namespace
+		}
+		return pods.Items[i].Name < pods.Items[j].Name
+	})
+
+	var restarters []Pod
+	for _, pod := range pods.Items {
+		restarts := podRestarts(pod)
+		item := Pod{Namespace: pod.Namespace, Name: pod.Name, Restarts: restarts}
+
+		if reason, failing := podFailureReason(pod); failing {
+			out.FailingPodsCount++
+			item.Reason = reason
+			out.FailingPods = appendLimited(out.FailingPods, item, c.cfg.MaxItems)
+		} else if pod.Status.Phase == corev1.PodPending {
+			out.PendingPodsCount++
+			item.Reason = podPendingReason(pod)
+			out.PendingPods = appendLimited(out.PendingPods, item, c.cfg.MaxItems)
+		}
+
+		if restarts > 0 {
+			restarters = append(restarters, Pod{Namespace: pod.Namespace, Name: pod.Name, Restarts: restarts})
+		}
+	}
+
+	sort.SliceStable(restarters, func(i, j int) bool { return restarters[i].Restarts > restarters[j].Restarts })
+	if len(restarters) > c.cfg.MaxItems {
+		restarters = restarters[:c.cfg.MaxItems]
+	}
+	out.TopRestarters = restarters
+	return nil
+}
+
+func (c *Collector) collectEvents(ctx context.Context, cli kubernetes.Interface, out *Snapshot) error {
+	events, err := cli.CoreV1().Events(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("type", corev1.EventTypeWarning).String(),
+	})
+	if err != nil {
+		return fmt.Errorf("while listing events: %w", err)
+	}
+
+	since := out.CollectedAt.Add(-c.cfg.EventsWindow)
+	var recent []Event
+	for _, event := range events.Items {
+		// the field selector may be ignored by fake clients and older API servers
+		if event.Type != corev1.EventTypeWarning {
+			continue
+		}
+		lastSeen := eventLastSeen(event)
+		if lastSeen.Before(since) {
+			continue
+		}
+		recent = append(recent, Event{
+			Namespace: event.Namespace,
+			Object:    fmt.Sprintf("%s/%s", event.InvolvedObject.Kind, event.InvolvedObject.Name),
+			Reason:    event.Reason,
+			Message:   event.Message,
+			Count:     event.Count,
+			LastSeen:  lastSeen,
+		})
+	}
+
+	sort.SliceStable(recent, func(i, j int) bool { return recent[i].LastSeen.After(recent[j].LastSeen) })
+	out.WarningEventsCount = len(recent)
+	if len(recent) > c.cfg.MaxItems {
+		recent = recent[:c.cfg.MaxItems]
+	}
+	out.WarningEvents = recent
+	return nil
+}
+
+func isNodeReady(node corev1.Node) bool {
+	for _, cond := range node.Status.Conditions {
+		if cond.Type == corev1.NodeReady {
+			return cond.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func isPressureCondition(condType corev1.NodeConditionType) bool {
+	for _, pressure := range pressureConditions {
+		if condType == pressure {
+			return true
+		}
+	}
+	return false
+}
+
+func podRestarts(pod corev1.Pod) int32 {
+	var restarts int32
+	for _, status := range pod.Status.ContainerStatuses {
+		restarts += status.RestartCount
+	}
+	return restarts
+}
+
+// podFailureReason returns the reason of a failed Pod, or a Pod with containers which won't start without an intervention.
+func podFailureReason(pod corev1.Pod) (string, bool) {
+	if pod.Status.Phase == corev1.PodFailed {
+		return valueOrDefault(pod.Status.Reason, string(corev1.PodFailed)), true
+	}
+	statuses := append(append([]corev1.ContainerStatus(nil), pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	for _, status := range statuses {
+		if status.State.Waiting == nil {
+			continue
+		}
+		if _, found := failingWaitingReasons[status.State.Waiting.Reason]; found {
+			return status.State.Waiting.Reason, true
+		}
+	}
+	return "", false
+}
+
+func podPendingReason(pod corev1.Pod) string {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodScheduled && cond.Status == corev1.ConditionFalse {
+			return valueOrDefault(cond.Reason, "Unschedulable")
+		}
+	}
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.State.Waiting != nil && status.State.Waiting.Reason != "" {
+			return status.State.Waiting.Reason
+		}
+	}
+	return string(corev1.PodPending)
+}
+
+func eventLastSeen(event corev1.Event) time.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case event.Series != nil && !event.Series.LastObservedTime.IsZero():
+		return event.Series.LastObservedTime.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	default:
+		return event.CreationTimestamp.Time
+	}
+}
+
+func appendLimited(in []Pod, item Pod, limit int) []Pod {
+	if len(in) >= limit {
+		return in
+	}
+	return append(in, item)
+}
+
+func valueOrDefault(value, def string) string {
+	if value == "" {
+		return def
+	}
+	return value
+}
@@ -0,0 +1,103 @@
+package overview
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+func TestCollectorCollect(t *testing.T) {
+	// given
+	now := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	objects := []runtime.Object{
+		node("node-a", corev1.ConditionTrue),
+		node("node-b", corev1.ConditionFalse, corev1.NodeCondition{Type: corev1.NodeMemoryPressure, Status: corev1.ConditionTrue}),
+		pod("prod", "api-1", corev1.PodRunning, 7, nil),
+		pod("prod", "api-2", corev1.PodRunning, 12, &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}),
+		pod("prod", "worker-1", corev1.PodPending, 0, &corev1.ContainerStateWaiting{Reason: "ContainerCreating"}),
+		pod("dev", "job-1", corev1.PodFailed, 0, nil),
+		pod("dev", "web-1", corev1.PodRunning, 1, nil),
+		warningEvent("prod", "old", now.Add(-2*time.Hour)),
+		warningEvent("prod", "recent", now.Add(-time.Minute)),
+		warningEvent("dev", "latest", now.Add(-time.Second)),
+	}
+
+	collector := New(config.Overview{MaxItems: 2}, nil)
+	collector.now = func() time.Time { return now }
+	collector.newK8sCli = func() (kubernetes.Interface, error) {
+		return fake.NewSimpleClientset(objects...), nil
+	}
+
+	// when
+	snapshot, err := collector.Collect(context.Background())
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, Snapshot{
+		CollectedAt:   now,
+		NodesTotal:    2,
+		NodesNotReady: []string{"node-b"},
+		Pressure:      []NodePressure{{Node: "node-b", Condition: "MemoryPressure"}},
+
+		PendingPodsCount: 1,
+		PendingPods:      []Pod{{Namespace: "prod", Name: "worker-1", Reason: "ContainerCreating"}},
+		FailingPodsCount: 2,
+		FailingPods: []Pod{
+			{Namespace: "dev", Name: "job-1", Reason: "Failed"},
+			{Namespace: "prod", Name: "api-2", Reason: "CrashLoopBackOff", Restarts: 12},
+		},
+		TopRestarters: []Pod{
+			{Namespace: "prod", Name: "api-2", Restarts: 12},
+			{Namespace: "prod", Name: "api-1", Restarts: 7},
+		},
+
+		WarningEventsCount: 2,
+		WarningEvents: []Event{
+			{Namespace: "dev", Object: "Pod/latest", Reason: "BackOff", Message: "Back-off restarting failed container", Count: 3, LastSeen: now.Add(-time.Second)},
+			{Namespace: "prod", Object: "Pod/recent", Reason: "BackOff", Message: "Back-off restarting failed container", Count: 3, LastSeen: now.Add(-time.Minute)},
+		},
+	}, snapshot)
+}
+
+func node(name string, ready corev1.ConditionStatus, conditions ...corev1.NodeCondition) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: corev1.NodeStatus{
+			Conditions: append([]corev1.NodeCondition{{Type: corev1.NodeReady, Status: ready}}, conditions...),
+		},
+	}
+}
+
+func pod(ns, name string, phase corev1.PodPhase, restarts int32, waiting *corev1.ContainerStateWaiting) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: name},
+		Status: corev1.PodStatus{
+			Phase: phase,
+			ContainerStatuses: []corev1.ContainerStatus{
+				{Name: "app", RestartCount: restarts, State: corev1.ContainerState{Waiting: waiting}},
+			},
+		},
+	}
+}
+
+func warningEvent(ns, name string, lastSeen time.Time) *corev1.Event {
+	return &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Namespace: ns, Name: name + ".1"},
+		InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: name},
+		Type:           corev1.EventTypeWarning,
+		Reason:         "BackOff",
+		Message:        "Back-off restarting failed container",
+		Count:          3,
+		LastTimestamp:  metav1.NewTime(lastSeen),
+	}
+}
@@ -0,0 +1,372 @@
+package ownership
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/api/source"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/httpx"
+)
+
+const (
+	defaultTeamAnnotation   = "botkube.io/owner"
+	defaultOnCallAnnotation = "botkube.io/on-call"
+	defaultCacheTTL         = 5 * time.Minute
+
+	// backstageComponentLabel matches Kubernetes resources with Backstage components.
+	backstageComponentLabel = "backstage.io/kubernetes-id"
+	backstageTimeout        = 5 * time.Second
+	// maxResponseSize limits the size of the response body read from Backstage.
+	maxResponseSize = 1 << 20
+)
+
+// Sources of resolved owners.
+const (
+	AnnotationSource          = "annotation"
+	NamespaceAnnotationSource = "namespace"
+	BackstageSource           = "backstage"
+	DirectorySource           = "directory"
+)
+
+// Resolver maps resources to teams which own them, and mentions their on-call handles in notifications.
+type Resolver struct {
+	log     logrus.FieldLogger
+	cfg     config.Ownership
+	k8sCli  kubernetes.Interface
+	httpCli *http.Client
+	now     func() time.Time
+
+	mu         sync.Mutex
+	namespaces map[string]cached[map[string]string]
+	dir        cached[map[string]string]
+	components map[string]cached[string]
+}
+
+type cached[T any] struct {
+	value     T
+	expiresAt time.Time
+}
+
+// NewResolver returns a new Resolver instance.
+func NewResolver(log logrus.FieldLogger, cfg config.Ownership, restCfg *rest.Config) (*Resolver, error) {
+	if !cfg.Enabled {
+		return newResolver(log, cfg, nil, nil), nil
+	}
+	if restCfg == nil {
+		return nil, errors.New("missing Kubernetes client configuration")
+	}
+	if len(cfg.Groups) == 0 {
+		cfg.Groups = []string{config.RBACDefaultGroup}
+	}
+	impersonated := rest.CopyConfig(restCfg)
+	impersonated.Impersonate = rest.ImpersonationConfig{UserName: config.RBACDefaultUser, Groups: cfg.Groups}
+	cli, err := kubernetes.NewForConfig(impersonated)
+	if err != nil {
+		return nil, fmt.Errorf("while creating Kubernetes client: %w", err)
+	}
+	return newResolver(log, cfg, cli, httpx.NewHTTPClient()), nil
+}
+
+func newResolver(log logrus.FieldLogger, cfg config.Ownership, k8sCli kubernetes.Interface, httpCli *http.Client) *Resolver {
+	if cfg.Annotations.Team == "" {
+		cfg.Annotations.Team = defaultTeamAnnotation
+	}
+	if cfg.Annotations.OnCall == "" {
+		cfg.Annotations.OnCall = defaultOnCallAnnotation
+	}
+	if cfg.CacheTTL <= 0 {
+		cfg.CacheTTL = defaultCacheTTL
+	}
+
+	return &Resolver{
+		log:        log,
+		cfg:        cfg,
+		k8sCli:     k8sCli,
+		httpCli:    httpCli,
+		now:        time.Now,
+		namespaces: map[string]cached[map[string]string]{},
+		components: map[string]cached[string]{},
+	}
+}
+
+// Assign resolves the owner of the resource a given event is about. The owner is stored in the event action context,
+// and the owning team with its on-call handles is added to the message. Events about resources without owners are returned unchanged.
+func (r *Resolver) Assign(ctx context.Context, event source.Event) source.Event {
+	if !r.cfg.Enabled {
+		return event
+	}
+
+	owner, found := r.Resolve(ctx, resourceFor(event))
+	if !found {
+		return event
+	}
+
+	event.ActionContext.Owner = &owner
+	event.Message = addContext(event.Message, note(owner))
+	return event
+}
+
+// Resource describes a resource the owner is resolved for.
+type Resource struct {
+	Namespace   string
+	Labels      map[string]string
+	Annotations map[string]string
+}
+
+// Resolve returns the owner of a given resource. Owners are looked up in annotations of the resource, annotations of its namespace,
+// the Backstage catalog and the ConfigMap directory, in that order. Failed lookups are logged and skipped.
+func (r *Resolver) Resolve(ctx context.Context, res Resource) (source.Owner, bool) {
+	if owner, found := r.fromAnnotations(res.Annotations, AnnotationSource); found {
+		return owner, true
+	}
+
+	var nsAnnotations map[string]string
+	if res.Namespace != "" {
+		var err error
+		nsAnnotations, err = r.namespaceAnnotations(ctx, res.Namespace)
+		if err != nil {
+			r.log.WithError(err).WithField("namespace", res.Namespace).Error("Cannot get namespace annotations")
+		}
+	}
+	if owner, found := r.fromAnnotations(nsAnnotations, NamespaceAnnotationSource); found {
+		return owner, true
+	}
+
+	if component := res.Labels[backstageComponentLabel]; r.cfg.Backstage.Enabled && component != "" {
+		team, err := r.componentOwner(ctx, component)
+		if err != nil {
+			r.log.WithError(err).WithField("component", component).Error("Cannot get component owner from Backstage")
+		}
+		if team != "" {
+			return r.ownerFor(team, BackstageSource), true
+		}
+	}
+
+	if r.cfg.ConfigMap.Name != "" && res.Namespace != "" {
+		directory, err := r.directory(ctx)
+		if err != nil {
+			r.log.WithError(err).Error("Cannot get ownership directory")
+		}
+		if team := directory[res.Namespace]; team != "" {
+			return r.ownerFor(team, DirectorySource), true
+		}
+	}
+
+	return source.Owner{}, false
+}
+
+// note returns the context note which mentions a given owner, e.g. `Owner: payments (@alice, @bob)`.
+func note(owner source.Owner) string {
+	if len(owner.OnCall) == 0 {
+		return fmt.Sprintf("Owner: %s", owner.Team)
+	}
+	return fmt.Sprintf("Owner: %s (%s)", owner.Team, strings.Join(owner.OnCall, ", "))
+}
+
+func (r *Resolver) fromAnnotations(annotations map[string]string, src string) (source.Owner, bool) {
+	team := strings.TrimSpace(annotations[r.cfg.Annotations.Team])
+	if team == "" {
+		return source.Owner{}, false
+	}
+
+	owner := r.ownerFor(team, src)
+	if handles := splitHandles(annotations[r.cfg.Annotations.OnCall]); len(handles) > 0 {
+		owner.OnCall = handles
+	}
+	return owner, true
+}
+
+func (r *Resolver) ownerFor(team, src string) source.Owner {
+	return source.Owner{
+		Team:   team,
+		OnCall: r.cfg.Teams[team].OnCall,
+		Source: src,
+	}
+}
+
+func (r *Resolver) namespaceAnnotations(ctx context.Context, name string) (map[string]string, error) {
+	r.mu.Lock()
+	entry, ok := r.namespaces[name]
+	r.mu.Unlock()
+	if ok && r.now().Before(entry.expiresAt) {
+		return entry.value, nil
+	}
+
+	ns, err := r.k8sCli.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("while getting namespace: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.namespaces[name] = cached[map[string]string]{value: ns.Annotations, expiresAt: r.now().Add(r.cfg.CacheTTL)}
+	return ns.Annotations, nil
+}
+
+func (r *Resolver) directory(ctx context.Context) (map[string]string, error) {
+	r.mu.Lock()
+	entry := r.dir
+	r.mu.Unlock()
+	if r.now().Before(entry.expiresAt) {
+		return entry.value, nil
+	}
+
+	cm, err := r.k8sCli.CoreV1().ConfigMaps(r.cfg.ConfigMap.Namespace).Get(ctx, r.cfg.ConfigMap.Name, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		// cache the missing directory too, so it isn't fetched for every notification
+	case err != nil:
+		return nil, fmt.Errorf("while getting ConfigMap %s/%s: %w", r.cfg.ConfigMap.Namespace, r.cfg.ConfigMap.Name, err)
+	}
+
+	var data map[string]string
+	if cm != nil {
+		data = cm.Data
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.dir = cached[map[string]string]{value: data, expiresAt: r.now().Add(r.cfg.CacheTTL)}
+	return data, nil
+}
+
+// componentOwner returns the owning team of a given Backstage component. Owners are entity references,
+// such as `group:default/payments`, so only the entity name is returned.
+func (r *Resolver) componentOwner(ctx context.Context, component string) (string, error) {
+	r.mu.Lock()
+	entry, ok := r.components[component]
+	r.mu.Unlock()
+	if ok && r.now().Before(entry.expiresAt) {
+		return entry.value, nil
+	}
+
+	team, err := r.fetchComponentOwner(ctx, component)
+	if err != nil {
+		return "", err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.components[component] = cached[string]{value: team, expiresAt: r.now().Add(r.cfg.CacheTTL)}
+	return team, nil
+}
+
+func (r *Resolver) fetchComponentOwner(ctx context.Context, component string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, backstageTimeout)
+	defer cancel()
+
+	endpoint, err := url.JoinPath(r.cfg.Backstage.URL, "api/catalog/entities/by-name/component/default", component)
+	if err != nil {
+		return "", fmt.Errorf("while building URL: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return "", fmt.Errorf("while creating request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if r.cfg.Backstage.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+r.cfg.Backstage.Token)
+	}
+
+	res, err := r.httpCli.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("while sending request: %w", err)
+	}
+	defer res.Body.Close()
+
+	raw, err := io.ReadAll(io.LimitReader(res.Body, maxResponseSize))
+	if err != nil {
+		return "", fmt.Errorf("while reading response body: %w", err)
+	}
+	switch {
+	case res.StatusCode == http.StatusNotFound:
+		return "", nil
+	case res.StatusCode < 200 || res.StatusCode >= 300:
+		return "", fmt.Errorf("got unexpected status code %d: %s", res.StatusCode, raw)
+	}
+
+	var entity struct {
+		Spec struct {
+			Owner string `json:"owner"`
+		} `json:"spec"`
+	}
+	if err := json.Unmarshal(raw, &entity); err != nil {
+		return "", fmt.Errorf("while unmarshaling response body: %w", err)
+	}
+
+	owner := entity.Spec.Owner
+	if idx := strings.LastIndex(owner, "/"); idx >= 0 {
+		owner = owner[idx+1:]
+	}
+	if idx := strings.Index(owner, ":"); idx >= 0 {
+		owner = owner[idx+1:]
+	}
+	return owner, nil
+}
+
+// resourceFor returns the namespace, labels and annotations of the object the event is about. Only the namespace is available
+// if the source doesn't provide the full object.
+func resourceFor(event source.Event) Resource {
+	var out Resource
+
+	var obj struct {
+		Metadata struct {
+			Namespace   string            `json:"namespace"`
+			Labels      map[string]string `json:"labels"`
+			Annotations map[string]string `json:"annotations"`
+		} `json:"metadata"`
+	}
+	if raw, err := json.Marshal(event.ActionContext.Object); err == nil && json.Unmarshal(raw, &obj) == nil {
+		out.Namespace = obj.Metadata.Namespace
+		out.Labels = obj.Metadata.Labels
+		out.Annotations = obj.Metadata.Annotations
+	}
+
+	if out.Namespace == "" {
+		var raw struct {
+			Namespace string
+		}
+		if in, err := json.Marshal(event.RawObject); err == nil && json.Unmarshal(in, &raw) == nil {
+			out.Namespace = raw.Namespace
+		}
+	}
+	return out
+}
+
+func splitHandles(in string) []string {
+	var out []string
+	for _, handle := range strings.Split(in, ",") {
+		if handle = strings.TrimSpace(handle); handle != "" {
+			out = append(out, handle)
+		}
+	}
+	return out
+}
+
+func addContext(msg api.Message, note string) api.Message {
+	// don't mutate sections shared with the original message
+	msg.Sections = append([]api.Section(nil), msg.Sections...)
+	if len(msg.Sections) == 0 {
+		msg.Sections = append(msg.Sections, api.Section{})
+	}
+	last := &msg.Sections[len(msg.Sections)-1]
+	last.Context = append(api.ContextItems(nil), last.Context...)
+	last.Context = append(last.Context, api.ContextItem{Text: note})
+	return msg
+}
@@ -0,0 +1,176 @@
+package ownership
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/api/source"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+func TestResolverResolve(t *testing.T) {
+	// given
+	var backstageCalls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		backstageCalls++
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		switch r.URL.Path {
+		case "/api/catalog/entities/by-name/component/default/checkout":
+			_, _ = w.Write([]byte(`{"kind":"Component","spec":{"owner":"group:default/checkout-team"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	cli := fake.NewSimpleClientset(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "payments", Annotations: map[string]string{"botkube.io/owner": "payments"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "shop"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "search"}},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "botkube", Name: "owners"},
+			Data:       map[string]string{"search": "discovery", "shop": "storefront"},
+		},
+	)
+	resolver := newResolver(loggerx.NewNoop(), config.Ownership{
+		Enabled:   true,
+		ConfigMap: config.OwnershipConfigMap{Namespace: "botkube", Name: "owners"},
+		Backstage: config.OwnershipBackstage{Enabled: true, URL: srv.URL, Token: "token"},
+		Teams: map[string]config.OwnershipTeam{
+			"payments":      {OnCall: []string{"@payments-oncall"}},
+			"checkout-team": {OnCall: []string{"@alice"}},
+		},
+	}, cli, srv.Client())
+
+	tests := []struct {
+		name     string
+		resource Resource
+		expected source.Owner
+		found    bool
+	}{
+		{
+			name: "Resource annotations",
+			resource: Resource{
+				Namespace:   "payments",
+				Annotations: map[string]string{"botkube.io/owner": "billing", "botkube.io/on-call": "@bob, @carol"},
+			},
+			expected: source.Owner{Team: "billing", OnCall: []string{"@bob", "@carol"}, Source: AnnotationSource},
+			found:    true,
+		},
+		{
+			name:     "Namespace annotations",
+			resource: Resource{Namespace: "payments"},
+			expected: source.Owner{Team: "payments", OnCall: []string{"@payments-oncall"}, Source: NamespaceAnnotationSource},
+			found:    true,
+		},
+		{
+			name:     "Backstage component",
+			resource: Resource{Namespace: "shop", Labels: map[string]string{"backstage.io/kubernetes-id": "checkout"}},
+			expected: source.Owner{Team: "checkout-team", OnCall: []string{"@alice"}, Source: BackstageSource},
+			found:    true,
+		},
+		{
+			name:     "Directory after unknown Backstage component",
+			resource: Resource{Namespace: "shop", Labels: map[string]string{"backstage.io/kubernetes-id": "unknown"}},
+			expected: source.Owner{Team: "storefront", Source: DirectorySource},
+			found:    true,
+		},
+		{
+			name:     "Directory",
+			resource: Resource{Namespace: "search"},
+			expected: source.Owner{Team: "discovery", Source: DirectorySource},
+			found:    true,
+		},
+		{
+			name:     "No owner",
+			resource: Resource{Namespace: "missing"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			owner, found := resolver.Resolve(context.Background(), tc.resource)
+
+			// then
+			assert.Equal(t, tc.found, found)
+			assert.Equal(t, tc.expected, owner)
+		})
+	}
+
+	// when
+	_, _ = resolver.Resolve(context.Background(), Resource{Namespace: "shop", Labels: map[string]string{"backstage.io/kubernetes-id": "checkout"}})
+
+	// then
+	assert.Equal(t, 2, backstageCalls, "Backstage components should be cached")
+}
+
+func TestResolverAssign(t *testing.T) {
+	// given
+	cli := fake.NewSimpleClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "payments"}})
+	resolver := newResolver(loggerx.NewNoop(), config.Ownership{
+		Enabled: true,
+		Teams: map[string]config.OwnershipTeam{
+			"payments": {OnCall: []string{"<!subteam^S012345>"}},
+		},
+	}, cli, nil)
+
+	event := source.Event{
+		RawObject: map[string]any{"Namespace": "payments"},
+		Message: api.Message{Sections: []api.Section{{
+			Base:    api.Base{Header: "Pod api failed"},
+			Context: api.ContextItems{{Text: "Cluster: prod"}},
+		}}},
+		ActionContext: source.ActionContext{
+			Object: map[string]any{
+				"metadata": map[string]any{
+					"namespace":   "payments",
+					"annotations": map[string]any{"team.example.com/owner": "payments"},
+				},
+			},
+		},
+	}
+
+	// when
+	out := resolver.Assign(context.Background(), event)
+
+	// then
+	assert.Nil(t, out.ActionContext.Owner, "custom annotation keys are not configured")
+
+	// given
+	resolver.cfg.Annotations.Team = "team.example.com/owner"
+
+	// when
+	out = resolver.Assign(context.Background(), event)
+
+	// then
+	require.NotNil(t, out.ActionContext.Owner)
+	assert.Equal(t, source.Owner{Team: "payments", OnCall: []string{"<!subteam^S012345>"}, Source: AnnotationSource}, *out.ActionContext.Owner)
+	assert.Equal(t, api.ContextItems{
+		{Text: "Cluster: prod"},
+		{Text: "Owner: payments (<!subteam^S012345>)"},
+	}, out.Message.Sections[0].Context)
+	assert.Len(t, event.Message.Sections[0].Context, 1, "original message should not be modified")
+}
+
+func TestResolverAssignDisabled(t *testing.T) {
+	// given
+	resolver, err := NewResolver(loggerx.NewNoop(), config.Ownership{}, nil)
+	require.NoError(t, err)
+	event := source.Event{RawObject: map[string]any{"Namespace": "payments"}}
+
+	// when
+	out := resolver.Assign(context.Background(), event)
+
+	// then
+	assert.Equal(t, event, out)
+}
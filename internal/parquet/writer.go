@@ -0,0 +1,150 @@
+// Package parquet writes flat Parquet files with a single row group. See https://parquet.apache.org/docs/file-format/.
+package parquet
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	pq "github.com/apache/arrow/go/v14/parquet"
+	"github.com/apache/arrow/go/v14/parquet/compress"
+	"github.com/apache/arrow/go/v14/parquet/file"
+	"github.com/apache/arrow/go/v14/parquet/schema"
+)
+
+const createdBy = "botkube"
+
+// Kind defines the type of column values.
+type Kind int
+
+const (
+	// String columns hold UTF-8 strings.
+	String Kind = iota
+	// JSON columns hold JSON documents, passed as strings or byte slices.
+	JSON
+	// TimestampMillis columns hold time.Time values, stored as milliseconds since the Unix epoch.
+	TimestampMillis
+)
+
+// Codec defines the compression of pages.
+type Codec = compress.Compression
+
+// Supported compression codecs.
+var (
+	Uncompressed = compress.Codecs.Uncompressed
+	Gzip         = compress.Codecs.Gzip
+	Zstd         = compress.Codecs.Zstd
+)
+
+// Column describes a column of the file schema.
+type Column struct {
+	Name string
+	Kind Kind
+	// Optional columns accept nil values.
+	Optional bool
+}
+
+func (c Column) node() (schema.Node, error) {
+	repetition := pq.Repetitions.Required
+	if c.Optional {
+		repetition = pq.Repetitions.Optional
+	}
+
+	switch c.Kind {
+	case JSON:
+		return schema.NewPrimitiveNodeLogical(c.Name, repetition, schema.JSONLogicalType{}, pq.Types.ByteArray, -1, -1)
+	case TimestampMillis:
+		return schema.NewPrimitiveNodeLogical(c.Name, repetition, schema.NewTimestampLogicalType(true, schema.TimeUnitMillis), pq.Types.Int64, -1, -1)
+	default:
+		return schema.NewPrimitiveNodeLogical(c.Name, repetition, schema.StringLogicalType{}, pq.Types.ByteArray, -1, -1)
+	}
+}
+
+// Write writes rows as a Parquet file. Each row holds values of all columns, in the order of the schema.
+func Write(out io.Writer, columns []Column, rows [][]any, codec Codec) error {
+	fields := make(schema.FieldList, 0, len(columns))
+	for _, col := range columns {
+		node, err := col.node()
+		if err != nil {
+			return fmt.Errorf("while creating column %q: %w", col.Name, err)
+		}
+		fields = append(fields, node)
+	}
+	root, err := schema.NewGroupNode("schema", pq.Repetitions.Required, fields, -1)
+	if err != nil {
+		return fmt.Errorf("while creating schema: %w", err)
+	}
+
+	props := pq.NewWriterProperties(pq.WithCompression(codec), pq.WithCreatedBy(createdBy))
+	w := file.NewParquetWriter(out, root, file.WithWriterProps(props))
+	rgw := w.AppendRowGroup()
+	for idx, col := range columns {
+		if err := writeColumn(rgw, col, idx, rows); err != nil {
+			return fmt.Errorf("while writing column %q: %w", col.Name, err)
+		}
+	}
+	if err := rgw.Close(); err != nil {
+		return fmt.Errorf("while closing row group: %w", err)
+	}
+	return w.Close()
+}
+
+// writeColumn writes all column values. Definition levels are written only for optional columns, as the schema is flat.
+func writeColumn(rgw file.SerialRowGroupWriter, col Column, idx int, rows [][]any) error {
+	var (
+		defLevels  []int16
+		byteArrays []pq.ByteArray
+		int64s     []int64
+	)
+	for _, row := range rows {
+		if idx >= len(row) {
+			return fmt.Errorf("row has %d values", len(row))
+		}
+		val := row[idx]
+		if val == nil {
+			if !col.Optional {
+				return fmt.Errorf("value is required")
+			}
+			defLevels = append(defLevels, 0)
+			continue
+		}
+		if col.Optional {
+			defLevels = append(defLevels, 1)
+		}
+
+		switch col.Kind {
+		case TimestampMillis:
+			ts, ok := val.(time.Time)
+			if !ok {
+				return fmt.Errorf("unexpected value type %T, expected time.Time", val)
+			}
+			int64s = append(int64s, ts.UnixMilli())
+		default:
+			switch v := val.(type) {
+			case string:
+				byteArrays = append(byteArrays, pq.ByteArray(v))
+			case []byte:
+				byteArrays = append(byteArrays, v)
+			default:
+				return fmt.Errorf("unexpected value type %T, expected string", val)
+			}
+		}
+	}
+
+	cw, err := rgw.NextColumn()
+	if err != nil {
+		return err
+	}
+	switch w := cw.(type) {
+	case *file.Int64ColumnChunkWriter:
+		_, err = w.WriteBatch(int64s, defLevels, nil)
+	case *file.ByteArrayColumnChunkWriter:
+		_, err = w.WriteBatch(byteArrays, defLevels, nil)
+	default:
+		err = fmt.Errorf("unexpected column writer %T", cw)
+	}
+	if err != nil {
+		return err
+	}
+	return cw.Close()
+}
@@ -0,0 +1,106 @@
+package parquet
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	pq "github.com/apache/arrow/go/v14/parquet"
+	"github.com/apache/arrow/go/v14/parquet/file"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrite(t *testing.T) {
+	// given
+	columns := []Column{
+		{Name: "source", Kind: String},
+		{Name: "timestamp", Kind: TimestampMillis},
+		{Name: "namespace", Kind: String, Optional: true},
+		{Name: "data", Kind: JSON},
+	}
+	ts := time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)
+	rows := [][]any{
+		{"k8s-err-events", ts, "dev", []byte(`{"kind":"Pod"}`)},
+		{"prometheus", ts.Add(time.Second), nil, `{"alert":"HighLoad"}`},
+	}
+
+	for _, codec := range []Codec{Uncompressed, Gzip, Zstd} {
+		buf := bytes.NewBuffer(nil)
+
+		// when
+		err := Write(buf, columns, rows, codec)
+
+		// then
+		require.NoError(t, err)
+
+		reader, err := file.NewParquetReader(bytes.NewReader(buf.Bytes()))
+		require.NoError(t, err)
+		assert.EqualValues(t, 2, reader.NumRows())
+		assert.Equal(t, "botkube", reader.MetaData().GetCreatedBy())
+
+		sc := reader.MetaData().Schema
+		require.Equal(t, len(columns), sc.NumColumns())
+		for idx, col := range columns {
+			assert.Equal(t, col.Name, sc.Column(idx).Name())
+		}
+		assert.Equal(t, "String", sc.Column(0).LogicalType().String())
+		assert.Equal(t, "JSON", sc.Column(3).LogicalType().String())
+
+		rg := reader.RowGroup(0)
+		for idx := range columns {
+			chunk, err := rg.MetaData().ColumnChunk(idx)
+			require.NoError(t, err)
+			assert.Equal(t, codec, chunk.Compression())
+		}
+
+		assert.Equal(t, []string{"k8s-err-events", "prometheus"}, readByteArrays(t, rg, 0, nil))
+		assert.Equal(t, []int64{ts.UnixMilli(), ts.Add(time.Second).UnixMilli()}, readInt64s(t, rg, 1))
+		var defLevels []int16
+		assert.Equal(t, []string{"dev"}, readByteArrays(t, rg, 2, &defLevels))
+		assert.Equal(t, []int16{1, 0}, defLevels)
+		assert.Equal(t, []string{`{"kind":"Pod"}`, `{"alert":"HighLoad"}`}, readByteArrays(t, rg, 3, nil))
+	}
+}
+
+func TestWriteMissingValue(t *testing.T) {
+	err := Write(io.Discard, []Column{{Name: "source", Kind: String}}, [][]any{{nil}}, Uncompressed)
+	assert.EqualError(t, err, `while writing column "source": value is required`)
+}
+
+func readByteArrays(t *testing.T, rg *file.RowGroupReader, idx int, defLevels *[]int16) []string {
+	t.Helper()
+	col, err := rg.Column(idx)
+	require.NoError(t, err)
+	reader, ok := col.(*file.ByteArrayColumnChunkReader)
+	require.True(t, ok)
+
+	values := make([]pq.ByteArray, rg.NumRows())
+	levels := make([]int16, rg.NumRows())
+	total, read, err := reader.ReadBatch(rg.NumRows(), values, levels, nil)
+	require.NoError(t, err)
+	require.EqualValues(t, rg.NumRows(), total)
+	if defLevels != nil {
+		*defLevels = levels
+	}
+
+	var out []string
+	for _, v := range values[:read] {
+		out = append(out, string(v))
+	}
+	return out
+}
+
+func readInt64s(t *testing.T, rg *file.RowGroupReader, idx int) []int64 {
+	t.Helper()
+	col, err := rg.Column(idx)
+	require.NoError(t, err)
+	reader, ok := col.(*file.Int64ColumnChunkReader)
+	require.True(t, ok)
+
+	values := make([]int64, rg.NumRows())
+	_, read, err := reader.ReadBatch(rg.NumRows(), values, nil, nil)
+	require.NoError(t, err)
+	return values[:read]
+}
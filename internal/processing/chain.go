@@ -0,0 +1,147 @@
+package processing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/exp/maps"
+	"gopkg.in/yaml.v3"
+
+	"github.com/kubeshop/botkube/pkg/api/processor"
+	"github.com/kubeshop/botkube/pkg/api/source"
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+// defaultTimeout limits processing a single event by a processor plugin, so a hung plugin doesn't stop dispatching events.
+const defaultTimeout = 5 * time.Second
+
+// ProcessorGetter returns clients for enabled processor plugins.
+type ProcessorGetter interface {
+	GetProcessor(name string) (processor.Processor, error)
+}
+
+type step struct {
+	name      string
+	pluginKey string
+	configs   []*processor.Config
+	timeout   time.Duration
+}
+
+// Chain passes events emitted by sources through all enabled processor plugins.
+type Chain struct {
+	log    logrus.FieldLogger
+	getter ProcessorGetter
+	steps  []step
+}
+
+// NewChain returns a new Chain instance. Processors are called in alphabetical order of their names,
+// and plugins within a given processor in alphabetical order of their keys.
+func NewChain(log logrus.FieldLogger, cfg map[string]config.Processors, getter ProcessorGetter) (*Chain, error) {
+	names := maps.Keys(cfg)
+	sort.Strings(names)
+
+	var steps []step
+	for _, name := range names {
+		plugins := cfg[name].Plugins
+		timeout := cfg[name].Timeout
+		if timeout <= 0 {
+			timeout = defaultTimeout
+		}
+		keys := maps.Keys(plugins)
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			pluginCfg := plugins[key]
+			if !pluginCfg.Enabled {
+				continue
+			}
+
+			// Unfortunately we need marshal it to get the raw data:
+			// https://github.com/go-yaml/yaml/issues/13
+			rawYAML, err := yaml.Marshal(pluginCfg.Config)
+			if err != nil {
+				return nil, fmt.Errorf("while marshaling config for %s from processor %s: %w", key, name, err)
+			}
+
+			steps = append(steps, step{
+				name:      name,
+				pluginKey: key,
+				configs:   []*processor.Config{{RawYAML: rawYAML}},
+				timeout:   timeout,
+			})
+		}
+	}
+
+	return &Chain{
+		log:    log,
+		getter: getter,
+		steps:  steps,
+	}, nil
+}
+
+// Process passes a given event through all enabled processors. It returns false if the event was vetoed.
+// Processors which fail or time out are skipped, so a broken processor doesn't stop notifications.
+func (c *Chain) Process(ctx context.Context, event source.Event, processCtx processor.ProcessInputContext) (source.Event, bool) {
+	for _, s := range c.steps {
+		log := c.log.WithFields(logrus.Fields{
+			"processor": s.name,
+			"pluginKey": s.pluginKey,
+		})
+
+		out, err := c.call(ctx, s, event, processCtx)
+		if err != nil {
+			log.WithError(err).Error("Cannot process event. Skipping processor...")
+			continue
+		}
+
+		if out.Veto {
+			log.WithField("sourceName", processCtx.SourceName).Debug("Event vetoed by processor")
+			return event, false
+		}
+
+		if out.Event != nil {
+			var raw any
+			if err := json.Unmarshal(out.Event, &raw); err != nil {
+				log.WithError(err).Error("Cannot unmarshal event returned by processor. Ignoring event modifications...")
+			} else {
+				event.RawObject = raw
+			}
+		}
+		if out.Message != nil {
+			event.Message = *out.Message
+		}
+	}
+
+	return event, true
+}
+
+func (c *Chain) call(ctx context.Context, s step, event source.Event, processCtx processor.ProcessInputContext) (processor.ProcessOutput, error) {
+	cli, err := c.getter.GetProcessor(s.pluginKey)
+	if err != nil {
+		return processor.ProcessOutput{}, err
+	}
+
+	rawEvent, err := json.Marshal(event.RawObject)
+	if err != nil {
+		return processor.ProcessOutput{}, fmt.Errorf("while marshaling event: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	out, err := cli.Process(ctx, processor.ProcessInput{
+		Event:   rawEvent,
+		Message: event.Message,
+		Configs: s.configs,
+		Context: processCtx,
+	})
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return processor.ProcessOutput{}, fmt.Errorf("processor didn't respond within %s: %w", s.timeout, err)
+	}
+	return out, err
+}
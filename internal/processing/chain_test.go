@@ -0,0 +1,145 @@
+package processing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/api/processor"
+	"github.com/kubeshop/botkube/pkg/api/source"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+type fakeProcessor struct {
+	fn    func(in processor.ProcessInput) (processor.ProcessOutput, error)
+	calls int
+}
+
+func (f *fakeProcessor) Process(_ context.Context, in processor.ProcessInput) (processor.ProcessOutput, error) {
+	f.calls++
+	return f.fn(in)
+}
+
+func (f *fakeProcessor) Metadata(context.Context) (api.MetadataOutput, error) {
+	return api.MetadataOutput{}, nil
+}
+
+type fakeGetter map[string]processor.Processor
+
+func (f fakeGetter) GetProcessor(name string) (processor.Processor, error) {
+	p, ok := f[name]
+	if !ok {
+		return nil, fmt.Errorf("client for processor plugin %q not found", name)
+	}
+	return p, nil
+}
+
+func TestChainProcess(t *testing.T) {
+	// given
+	var order []string
+	enricher := &fakeProcessor{fn: func(in processor.ProcessInput) (processor.ProcessOutput, error) {
+		order = append(order, "enricher")
+		assert.JSONEq(t, `{"name":"api"}`, string(in.Event))
+		assert.Equal(t, "k8s-err-events", in.Context.SourceName)
+		assert.Equal(t, "team: payments\n", string(in.Configs[0].RawYAML))
+
+		msg := in.Message
+		msg.Sections[0].Context = api.ContextItems{{Text: "Owner: payments"}}
+		return processor.ProcessOutput{
+			Event:   []byte(`{"name":"api","owner":"payments"}`),
+			Message: &msg,
+		}, nil
+	}}
+	failing := &fakeProcessor{fn: func(processor.ProcessInput) (processor.ProcessOutput, error) {
+		order = append(order, "failing")
+		return processor.ProcessOutput{}, errors.New("boom")
+	}}
+
+	chain, err := NewChain(loggerx.NewNoop(), map[string]config.Processors{
+		"b-enrich": {
+			Plugins: config.Plugins{
+				"acme/enricher": {Enabled: true, Config: map[string]any{"team": "payments"}},
+				"acme/disabled": {Enabled: false},
+			},
+		},
+		"a-broken": {
+			Plugins: config.Plugins{
+				"acme/failing": {Enabled: true},
+			},
+		},
+	}, fakeGetter{"acme/enricher": enricher, "acme/failing": failing})
+	require.NoError(t, err)
+
+	event := source.Event{
+		RawObject: map[string]any{"name": "api"},
+		Message:   api.Message{Sections: []api.Section{{Base: api.Base{Header: "Pod api failed"}}}},
+	}
+
+	// when
+	out, ok := chain.Process(context.Background(), event, processor.ProcessInputContext{SourceName: "k8s-err-events"})
+
+	// then
+	require.True(t, ok)
+	assert.Equal(t, []string{"failing", "enricher"}, order)
+	assert.Equal(t, map[string]any{"name": "api", "owner": "payments"}, out.RawObject)
+	assert.Equal(t, api.ContextItems{{Text: "Owner: payments"}}, out.Message.Sections[0].Context)
+}
+
+func TestChainProcessVeto(t *testing.T) {
+	// given
+	veto := &fakeProcessor{fn: func(processor.ProcessInput) (processor.ProcessOutput, error) {
+		return processor.ProcessOutput{Veto: true}, nil
+	}}
+	next := &fakeProcessor{fn: func(processor.ProcessInput) (processor.ProcessOutput, error) {
+		return processor.ProcessOutput{}, nil
+	}}
+
+	chain, err := NewChain(loggerx.NewNoop(), map[string]config.Processors{
+		"noise": {Plugins: config.Plugins{"acme/a-veto": {Enabled: true}, "acme/b-next": {Enabled: true}}},
+	}, fakeGetter{"acme/a-veto": veto, "acme/b-next": next})
+	require.NoError(t, err)
+
+	// when
+	_, ok := chain.Process(context.Background(), source.Event{}, processor.ProcessInputContext{})
+
+	// then
+	assert.False(t, ok)
+	assert.Equal(t, 1, veto.calls)
+	assert.Zero(t, next.calls)
+}
+
+type hungProcessor struct {
+	fakeProcessor
+}
+
+func (h *hungProcessor) Process(ctx context.Context, _ processor.ProcessInput) (processor.ProcessOutput, error) {
+	<-ctx.Done()
+	return processor.ProcessOutput{}, ctx.Err()
+}
+
+func TestChainProcessTimeout(t *testing.T) {
+	// given
+	next := &fakeProcessor{fn: func(processor.ProcessInput) (processor.ProcessOutput, error) {
+		return processor.ProcessOutput{}, nil
+	}}
+
+	chain, err := NewChain(loggerx.NewNoop(), map[string]config.Processors{
+		"a-hung": {Timeout: 10 * time.Millisecond, Plugins: config.Plugins{"acme/hung": {Enabled: true}}},
+		"b-next": {Plugins: config.Plugins{"acme/next": {Enabled: true}}},
+	}, fakeGetter{"acme/hung": &hungProcessor{}, "acme/next": next})
+	require.NoError(t, err)
+
+	// when
+	_, ok := chain.Process(context.Background(), source.Event{}, processor.ProcessInputContext{})
+
+	// then
+	assert.True(t, ok, "hung processor should be skipped")
+	assert.Equal(t, 1, next.calls)
+}
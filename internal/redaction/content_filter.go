@@ -0,0 +1,165 @@
+package redaction
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/multierror"
+)
+
+const defaultContentFilterReplacement = "***"
+
+// ContentFilter masks, or blocks, unwanted content, such as profanity or personal data, in notifications sent to selected channels.
+// It's safe for concurrent use.
+type ContentFilter struct {
+	action      config.ContentFilterAction
+	replacement string
+	patterns    []*regexp.Regexp
+}
+
+// NewContentFilter compiles configured words, patterns and patterns of personal data, and returns a new ContentFilter instance.
+func NewContentFilter(cfg config.ContentFilter) (*ContentFilter, error) {
+	out := &ContentFilter{
+		action:      cfg.Action,
+		replacement: cfg.Replacement,
+	}
+	if out.action == "" {
+		out.action = config.ContentFilterActionMask
+	}
+	if out.replacement == "" {
+		out.replacement = defaultContentFilterReplacement
+	}
+
+	if len(cfg.Words) > 0 {
+		words := make([]string, 0, len(cfg.Words))
+		for _, word := range cfg.Words {
+			words = append(words, regexp.QuoteMeta(word))
+		}
+		out.patterns = append(out.patterns, regexp.MustCompile(fmt.Sprintf(`(?i)\b(?:%s)\b`, strings.Join(words, "|"))))
+	}
+
+	errs := multierror.New()
+	for idx, expr := range cfg.Patterns {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("while compiling patterns[%d]: %w", idx, err))
+			continue
+		}
+		out.patterns = append(out.patterns, re)
+	}
+	for _, piiType := range cfg.PII {
+		expr, ok := piiPatterns[piiType]
+		if !ok {
+			errs = multierror.Append(errs, fmt.Errorf("unknown PII type %q", piiType))
+			continue
+		}
+		out.patterns = append(out.patterns, regexp.MustCompile(expr))
+	}
+	if err := errs.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// FilterMessage masks matched content in texts of a given message. It returns false if the message contains matched content,
+// and the filter blocks such messages.
+//
+// Only the message content is filtered, so headers and titles, as well as commands, URLs and other values of interactive elements,
+// are not modified.
+func (f *ContentFilter) FilterMessage(msg interactive.CoreMessage) (interactive.CoreMessage, bool) {
+	var matched bool
+	filter := func(in string) string {
+		out := f.filterString(in)
+		if out != in {
+			matched = true
+		}
+		return out
+	}
+
+	msg.Description = filter(msg.Description)
+	msg.Message = filterMessageContent(msg.Message, filter)
+	if len(msg.Messages) > 0 {
+		// the slice is shared with messages sent to other channels, so it's not modified in place
+		messages := make([]api.Message, 0, len(msg.Messages))
+		for _, item := range msg.Messages {
+			messages = append(messages, filterMessageContent(item, filter))
+		}
+		msg.Messages = messages
+	}
+
+	if matched && f.action == config.ContentFilterActionBlock {
+		return msg, false
+	}
+	return msg, true
+}
+
+// filterMessageContent filters bodies, descriptions, text field values, bullet list items and context items of a given message.
+// Slices are copied, as they are shared with messages sent to other channels.
+func filterMessageContent(msg api.Message, filter func(string) string) api.Message {
+	msg.BaseBody = filterBody(msg.BaseBody, filter)
+	if msg.Sections == nil {
+		return msg
+	}
+
+	sections := make([]api.Section, 0, len(msg.Sections))
+	for _, section := range msg.Sections {
+		section.Description = filter(section.Description)
+		section.Body = filterBody(section.Body, filter)
+
+		if section.TextFields != nil {
+			fields := make(api.TextFields, 0, len(section.TextFields))
+			for _, field := range section.TextFields {
+				field.Value = filter(field.Value)
+				fields = append(fields, field)
+			}
+			section.TextFields = fields
+		}
+
+		if section.BulletLists != nil {
+			lists := make(api.BulletLists, 0, len(section.BulletLists))
+			for _, list := range section.BulletLists {
+				items := make([]string, 0, len(list.Items))
+				for _, item := range list.Items {
+					items = append(items, filter(item))
+				}
+				list.Items = items
+				lists = append(lists, list)
+			}
+			section.BulletLists = lists
+		}
+
+		if section.Context != nil {
+			items := make(api.ContextItems, 0, len(section.Context))
+			for _, item := range section.Context {
+				item.Text = filter(item.Text)
+				items = append(items, item)
+			}
+			section.Context = items
+		}
+
+		sections = append(sections, section)
+	}
+	msg.Sections = sections
+	return msg
+}
+
+func filterBody(body api.Body, filter func(string) string) api.Body {
+	body.CodeBlock = filter(body.CodeBlock)
+	body.Plaintext = filter(body.Plaintext)
+	return body
+}
+
+func (f *ContentFilter) filterString(in string) string {
+	if in == "" {
+		return in
+	}
+	for _, p := range f.patterns {
+		in = replaceMatches(p, in, f.replacement)
+	}
+	return in
+}
@@ -0,0 +1,186 @@
+package redaction
+
+import (
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+func TestContentFilterFilterString(t *testing.T) {
+	// given
+	contentFilter, err := NewContentFilter(config.ContentFilter{
+		Words:    []string{"darn", "heck"},
+		Patterns: []string{`customer-id=(\d+)`},
+		PII:      []config.PIIType{config.EmailPII, config.PhoneNumberPII, config.CreditCardPII, config.IPAddressPII},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		in       string
+		expected string
+	}{
+		{
+			name:     "words matched case-insensitively",
+			in:       "Darn, what the heck happened?",
+			expected: "***, what the *** happened?",
+		},
+		{
+			name:     "words matched only as whole words",
+			in:       "darning socks",
+			expected: "darning socks",
+		},
+		{
+			name:     "pattern with a capturing group",
+			in:       "request for customer-id=4211 failed",
+			expected: "request for customer-id=*** failed",
+		},
+		{
+			name:     "email",
+			in:       "owner: jane.doe@example.com",
+			expected: "owner: ***",
+		},
+		{
+			name:     "phone number",
+			in:       "on-call: +48 123 456 789",
+			expected: "on-call: ***",
+		},
+		{
+			name:     "credit card number",
+			in:       "card 4111-1111-1111-1111 declined",
+			expected: "card *** declined",
+		},
+		{
+			name:     "IP address",
+			in:       "connection from 10.0.12.7 refused",
+			expected: "connection from *** refused",
+		},
+		{
+			name:     "no matches",
+			in:       "Pod api-7d4b9 restarted 3 times",
+			expected: "Pod api-7d4b9 restarted 3 times",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			out := contentFilter.filterString(tc.in)
+
+			// then
+			assert.Equal(t, tc.expected, out)
+		})
+	}
+}
+
+func TestContentFilterFilterMessage(t *testing.T) {
+	// given
+	msg := interactive.CoreMessage{
+		Header:      "Pod failed on 10.0.12.7",
+		Description: "Reported by jane.doe@example.com",
+		Message: api.Message{
+			Sections: []api.Section{
+				{
+					Base:        api.Base{Header: "Node 10.0.12.7", Description: "Contact jane.doe@example.com"},
+					TextFields:  api.TextFields{{Key: "Node", Value: "10.0.12.7"}},
+					BulletLists: api.BulletLists{{Title: "Hosts", Items: []string{"10.0.12.7", "api"}}},
+					Context:     api.ContextItems{{Text: "Owner: jane.doe@example.com"}},
+					Buttons:     api.Buttons{{Name: "Ping 10.0.12.7", Command: "@Botkube kubectl debug node/10.0.12.7"}},
+				},
+			},
+		},
+		Messages: []api.Message{
+			{BaseBody: api.Body{CodeBlock: "10.0.12.7"}},
+		},
+	}
+	original := interactive.CoreMessage{
+		Header:      msg.Header,
+		Description: msg.Description,
+		Message: api.Message{
+			Sections: []api.Section{
+				{
+					Base:        msg.Message.Sections[0].Base,
+					TextFields:  append(api.TextFields{}, msg.Message.Sections[0].TextFields...),
+					BulletLists: api.BulletLists{{Title: "Hosts", Items: []string{"10.0.12.7", "api"}}},
+					Context:     append(api.ContextItems{}, msg.Message.Sections[0].Context...),
+					Buttons:     msg.Message.Sections[0].Buttons,
+				},
+			},
+		},
+		Messages: append([]api.Message{}, msg.Messages...),
+	}
+
+	t.Run("mask", func(t *testing.T) {
+		// given
+		contentFilter, err := NewContentFilter(config.ContentFilter{
+			Replacement: "[hidden]",
+			PII:         []config.PIIType{config.EmailPII, config.IPAddressPII},
+		})
+		require.NoError(t, err)
+
+		// when
+		out, allowed := contentFilter.FilterMessage(msg)
+
+		// then
+		assert.True(t, allowed)
+		assert.Equal(t, interactive.CoreMessage{
+			Header:      "Pod failed on 10.0.12.7",
+			Description: "Reported by [hidden]",
+			Message: api.Message{
+				Sections: []api.Section{
+					{
+						Base:        api.Base{Header: "Node 10.0.12.7", Description: "Contact [hidden]"},
+						TextFields:  api.TextFields{{Key: "Node", Value: "[hidden]"}},
+						BulletLists: api.BulletLists{{Title: "Hosts", Items: []string{"[hidden]", "api"}}},
+						Context:     api.ContextItems{{Text: "Owner: [hidden]"}},
+						Buttons:     api.Buttons{{Name: "Ping 10.0.12.7", Command: "@Botkube kubectl debug node/10.0.12.7"}},
+					},
+				},
+			},
+			Messages: []api.Message{
+				{BaseBody: api.Body{CodeBlock: "[hidden]"}},
+			},
+		}, out)
+		assert.Equal(t, original, msg)
+	})
+
+	t.Run("block", func(t *testing.T) {
+		// given
+		contentFilter, err := NewContentFilter(config.ContentFilter{
+			Action: config.ContentFilterActionBlock,
+			PII:    []config.PIIType{config.EmailPII},
+		})
+		require.NoError(t, err)
+
+		// when
+		_, allowed := contentFilter.FilterMessage(msg)
+
+		// then
+		assert.False(t, allowed)
+
+		// when only a header matches
+		_, allowed = contentFilter.FilterMessage(interactive.CoreMessage{Header: "Pod failed for jane.doe@example.com"})
+
+		// then
+		assert.True(t, allowed)
+	})
+}
+
+func TestNewContentFilterErrors(t *testing.T) {
+	// when
+	_, err := NewContentFilter(config.ContentFilter{
+		Patterns: []string{`ok`, `(unclosed`},
+		PII:      []config.PIIType{"ssn"},
+	})
+
+	// then
+	assert.EqualError(t, err, heredoc.Doc(`
+		2 errors occurred:
+			* while compiling patterns[1]: error parsing regexp: missing closing ): `+"`(unclosed`"+`
+			* unknown PII type "ssn"`))
+}
@@ -0,0 +1,45 @@
+package redaction
+
+import "github.com/kubeshop/botkube/pkg/config"
+
+// builtInPatterns returns patterns of common credentials. They can be overridden, or disabled, in the configuration.
+func builtInPatterns() map[string]config.RedactionPattern {
+	return map[string]config.RedactionPattern{
+		"awsAccessKeyID": {
+			Enabled: true,
+			Regex:   `\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`,
+		},
+		"awsSecretAccessKey": {
+			Enabled: true,
+			Regex:   `(?i)aws_?secret_?access_?key["']?\s*[:=]\s*["']?([A-Za-z0-9/+=]{40})`,
+		},
+		"bearerToken": {
+			Enabled: true,
+			Regex:   `(?i)\bbearer\s+([A-Za-z0-9\-._~+/]+=*)`,
+		},
+		"password": {
+			Enabled: true,
+			Regex:   `(?i)\b(?:password|passwd|pwd)["']?\s*[:=]\s*["']?([^\s"',;}]+)`,
+		},
+		"privateKey": {
+			Enabled: true,
+			Regex:   `-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`,
+		},
+		"slackToken": {
+			Enabled: true,
+			Regex:   `\bxox[abposre]-[A-Za-z0-9-]{10,}`,
+		},
+		"githubToken": {
+			Enabled: true,
+			Regex:   `\b(?:gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{22,})`,
+		},
+	}
+}
+
+// piiPatterns contains patterns of personal data detected by content filters.
+var piiPatterns = map[config.PIIType]string{
+	config.EmailPII:       `\b[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}\b`,
+	config.PhoneNumberPII: `\+[1-9][0-9]{0,2}(?:[ .-]?[0-9]{2,4}){2,4}\b`,
+	config.CreditCardPII:  `\b(?:4[0-9]{3}|5[1-5][0-9]{2}|6011|3[47][0-9]{2})(?:[ -]?[0-9]{4}){3}\b`,
+	config.IPAddressPII:   `\b(?:(?:25[0-5]|2[0-4][0-9]|1?[0-9]?[0-9])\.){3}(?:25[0-5]|2[0-4][0-9]|1?[0-9]?[0-9])\b`,
+}
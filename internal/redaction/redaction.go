@@ -0,0 +1,350 @@
+// Package redaction masks sensitive values, such as values of Kubernetes Secrets, tokens matched by regular expressions,
+// and values of environment variables, in messages before they leave Botkube.
+package redaction
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/exp/maps"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/multierror"
+)
+
+const (
+	defaultReplacement     = "*** REDACTED ***"
+	defaultRefreshInterval = 5 * time.Minute
+)
+
+// unredactableMessage replaces messages which cannot be redacted, so sensitive values are never sent.
+var unredactableMessage = api.Message{
+	BaseBody: api.Body{Plaintext: "The message was hidden, as sensitive values couldn't be redacted from it."},
+}
+
+// Redactor masks sensitive values in strings, messages and arbitrary values. It's safe for concurrent use.
+type Redactor struct {
+	log         logrus.FieldLogger
+	cfg         config.Redaction
+	replacement string
+	patterns    []*regexp.Regexp
+	envValues   []string
+	secrets     *secretValues
+
+	mu       sync.RWMutex
+	replacer *strings.Replacer
+}
+
+// New compiles configured patterns, collects values of matching environment variables, and returns a new Redactor instance.
+// Secret values are fetched once the Redactor is started.
+func New(log logrus.FieldLogger, cfg config.Redaction, k8sCli kubernetes.Interface) (*Redactor, error) {
+	return newRedactor(log, cfg, k8sCli, os.Environ())
+}
+
+func newRedactor(log logrus.FieldLogger, cfg config.Redaction, k8sCli kubernetes.Interface, environ []string) (*Redactor, error) {
+	r := &Redactor{
+		log:         log,
+		cfg:         cfg,
+		replacement: cfg.Replacement,
+	}
+	if !cfg.Enabled {
+		return r, nil
+	}
+	if r.replacement == "" {
+		r.replacement = defaultReplacement
+	}
+
+	patterns, err := compilePatterns(cfg.Patterns)
+	if err != nil {
+		return nil, err
+	}
+	r.patterns = patterns
+
+	if cfg.EnvVars.Enabled {
+		r.envValues = envValues(environ, cfg.EnvVars.Names, cfg.MinValueLength)
+	}
+	if cfg.Secrets.Enabled {
+		r.secrets = newSecretValues(k8sCli, cfg.Secrets, cfg.MinValueLength)
+	}
+	r.setValues(nil)
+	return r, nil
+}
+
+// Enabled returns true if the redaction is enabled.
+func (r *Redactor) Enabled() bool {
+	return r != nil && r.cfg.Enabled
+}
+
+// Start fetches Secret values, and refreshes them periodically until the context is canceled.
+func (r *Redactor) Start(ctx context.Context) error {
+	if !r.Enabled() || r.secrets == nil {
+		return nil
+	}
+
+	interval := r.cfg.Secrets.RefreshInterval
+	if interval <= 0 {
+		interval = defaultRefreshInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	r.refresh(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			r.refresh(ctx)
+		}
+	}
+}
+
+// RedactString masks all sensitive values in a given string.
+func (r *Redactor) RedactString(in string) string {
+	if !r.Enabled() || in == "" {
+		return in
+	}
+
+	r.mu.RLock()
+	replacer := r.replacer
+	r.mu.RUnlock()
+	if replacer != nil {
+		in = replacer.Replace(in)
+	}
+
+	for _, p := range r.patterns {
+		in = replaceMatches(p, in, r.replacement)
+	}
+	return in
+}
+
+// RedactMessage masks sensitive values in all texts of a given message, including sections, buttons and inputs.
+func (r *Redactor) RedactMessage(msg api.Message) api.Message {
+	if !r.Enabled() {
+		return msg
+	}
+
+	out, err := redactJSON(msg, r.RedactString)
+	if err != nil {
+		r.log.Errorf("while redacting message: %s", err.Error())
+		return unredactableMessage
+	}
+	return out
+}
+
+// RedactValue masks sensitive values in all strings of a given value, e.g. an event sent to sinks.
+// Values which contain sensitive data are returned as generic JSON values, so their Go types are not preserved.
+func (r *Redactor) RedactValue(in any) any {
+	if !r.Enabled() || in == nil {
+		return in
+	}
+
+	if str, ok := in.(string); ok {
+		return r.RedactString(str)
+	}
+
+	// the value is copied, as it may be shared, e.g. with the event action context
+	raw, err := json.Marshal(in)
+	if err != nil {
+		r.log.Errorf("while marshaling value to redact: %s", err.Error())
+		return r.RedactString(fmt.Sprintf("%v", in))
+	}
+	var generic any
+	if err := json.Unmarshal(raw, &generic); err != nil {
+		r.log.Errorf("while unmarshaling value to redact: %s", err.Error())
+		return r.RedactString(string(raw))
+	}
+	out, changed := redactGeneric(generic, r.RedactString)
+	if !changed {
+		return in
+	}
+	return out
+}
+
+// redactGeneric redacts strings in a generic JSON value in place, and reports whether any of them changed.
+func redactGeneric(in any, redact func(string) string) (any, bool) {
+	switch val := in.(type) {
+	case string:
+		out := redact(val)
+		return out, out != val
+	case map[string]any:
+		var changed bool
+		for k, v := range val {
+			out, ok := redactGeneric(v, redact)
+			if ok {
+				val[k] = out
+				changed = true
+			}
+		}
+		return val, changed
+	case []any:
+		var changed bool
+		for i, v := range val {
+			out, ok := redactGeneric(v, redact)
+			if ok {
+				val[i] = out
+				changed = true
+			}
+		}
+		return val, changed
+	default:
+		return in, false
+	}
+}
+
+// replaceMatches replaces matches of a given pattern. If the pattern has a capturing group, only the first group is replaced.
+func replaceMatches(p *regexp.Regexp, in, replacement string) string {
+	if p.NumSubexp() == 0 {
+		return p.ReplaceAllLiteralString(in, replacement)
+	}
+
+	matches := p.FindAllStringSubmatchIndex(in, -1)
+	if len(matches) == 0 {
+		return in
+	}
+	var out strings.Builder
+	last := 0
+	for _, m := range matches {
+		start, end := m[2], m[3]
+		if start < 0 || start < last || strings.HasPrefix(in[start:], replacement) {
+			// group didn't participate in the match, or it's already redacted
+			continue
+		}
+		out.WriteString(in[last:start])
+		out.WriteString(replacement)
+		last = end
+	}
+	out.WriteString(in[last:])
+	return out.String()
+}
+
+func (r *Redactor) refresh(ctx context.Context) {
+	values, err := r.secrets.Fetch(ctx)
+	if err != nil {
+		// keep the previous values, so a temporary API server failure doesn't reveal them
+		r.log.Errorf("while fetching Secret values to redact: %s", err.Error())
+		return
+	}
+	r.setValues(values)
+	r.log.Debugf("Refreshed %d Secret values to redact", len(values))
+}
+
+// setValues rebuilds the replacer from given Secret values and environment variable values.
+func (r *Redactor) setValues(secretValues []string) {
+	unique := map[string]struct{}{}
+	for _, v := range append(secretValues, r.envValues...) {
+		unique[v] = struct{}{}
+	}
+	values := maps.Keys(unique)
+	// longer values go first, so a value which contains another one is redacted as a whole
+	sort.Slice(values, func(i, j int) bool {
+		if len(values[i]) != len(values[j]) {
+			return len(values[i]) > len(values[j])
+		}
+		return values[i] < values[j]
+	})
+
+	var replacer *strings.Replacer
+	if len(values) > 0 {
+		pairs := make([]string, 0, 2*len(values))
+		for _, v := range values {
+			pairs = append(pairs, v, r.replacement)
+		}
+		replacer = strings.NewReplacer(pairs...)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.replacer = replacer
+}
+
+func redactJSON[T any](in T, redact func(string) string) (T, error) {
+	var out T
+	raw, err := json.Marshal(in)
+	if err != nil {
+		return out, fmt.Errorf("while marshaling: %w", err)
+	}
+	var generic any
+	if err := json.Unmarshal(raw, &generic); err != nil {
+		return out, fmt.Errorf("while unmarshaling: %w", err)
+	}
+	redacted, changed := redactGeneric(generic, redact)
+	if !changed {
+		return in, nil
+	}
+
+	raw, err = json.Marshal(redacted)
+	if err != nil {
+		return out, fmt.Errorf("while marshaling redacted value: %w", err)
+	}
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return out, fmt.Errorf("while unmarshaling redacted value: %w", err)
+	}
+	return out, nil
+}
+
+func compilePatterns(custom map[string]config.RedactionPattern) ([]*regexp.Regexp, error) {
+	all := builtInPatterns()
+	for name, p := range custom {
+		if p.Regex == "" {
+			// e.g. only `enabled` is set to toggle a built-in pattern
+			p.Regex = all[name].Regex
+		}
+		all[name] = p
+	}
+
+	names := maps.Keys(all)
+	sort.Strings(names)
+
+	errs := multierror.New()
+	var out []*regexp.Regexp
+	for _, name := range names {
+		p := all[name]
+		if !p.Enabled {
+			continue
+		}
+		if p.Regex == "" {
+			errs = multierror.Append(errs, fmt.Errorf("regex of pattern %q cannot be empty", name))
+			continue
+		}
+		re, err := regexp.Compile(p.Regex)
+		if err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("while compiling pattern %q: %w", name, err))
+			continue
+		}
+		out = append(out, re)
+	}
+	if err := errs.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// envValues returns values of environment variables with names matching given glob patterns.
+func envValues(environ, namePatterns []string, minLength int) []string {
+	var out []string
+	for _, env := range environ {
+		name, val, ok := strings.Cut(env, "=")
+		if !ok || val == "" || len(val) < minLength {
+			continue
+		}
+		for _, pattern := range namePatterns {
+			if matched, _ := path.Match(pattern, name); matched {
+				out = append(out, val)
+				break
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,60 @@
+package redaction
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+// helmReleaseSecretType is a type of Secrets in which Helm stores releases. They are large, and contain rendered manifests
+// instead of credentials, so they are skipped.
+const helmReleaseSecretType corev1.SecretType = "helm.sh/release.v1"
+
+// secretValues fetches values of Kubernetes Secrets.
+type secretValues struct {
+	k8sCli    kubernetes.Interface
+	cfg       config.RedactionSecrets
+	minLength int
+}
+
+func newSecretValues(k8sCli kubernetes.Interface, cfg config.RedactionSecrets, minLength int) *secretValues {
+	return &secretValues{
+		k8sCli:    k8sCli,
+		cfg:       cfg,
+		minLength: minLength,
+	}
+}
+
+// Fetch returns values of all matching Secrets, both as they are, and base64-encoded, as they are printed e.g. by `kubectl get secret -o yaml`.
+func (s *secretValues) Fetch(ctx context.Context) ([]string, error) {
+	namespaces := s.cfg.Namespaces
+	if len(namespaces) == 0 {
+		namespaces = []string{metav1.NamespaceAll}
+	}
+
+	var out []string
+	for _, ns := range namespaces {
+		list, err := s.k8sCli.CoreV1().Secrets(ns).List(ctx, metav1.ListOptions{LabelSelector: s.cfg.LabelSelector})
+		if err != nil {
+			return nil, fmt.Errorf("while listing Secrets in namespace %q: %w", ns, err)
+		}
+		for _, secret := range list.Items {
+			if secret.Type == helmReleaseSecretType {
+				continue
+			}
+			for _, val := range secret.Data {
+				if len(val) == 0 || len(val) < s.minLength {
+					continue
+				}
+				out = append(out, string(val), base64.StdEncoding.EncodeToString(val))
+			}
+		}
+	}
+	return out, nil
+}
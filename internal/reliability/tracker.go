@@ -0,0 +1,260 @@
+package reliability
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/internal/metrics"
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+const (
+	defaultLatencyTarget = 30 * time.Second
+	defaultObjective     = 99
+	defaultReportPeriod  = 7 * 24 * time.Hour
+
+	reportHeader     = "Notification delivery report"
+	reportTimeFormat = "2006-01-02 15:04 MST"
+)
+
+// SendFunc sends the reliability report.
+type SendFunc func(ctx context.Context, msg interactive.CoreMessage) error
+
+// Tracker tracks delivery latency and failures of notifications against the delivery objective, and exports them as metrics.
+// If the report is enabled, it sends the reliability report of each period.
+type Tracker struct {
+	log         logrus.FieldLogger
+	cfg         config.DeliverySLO
+	clusterName string
+	send        SendFunc
+	now         func() time.Time
+
+	mu    sync.Mutex
+	since time.Time
+	stats map[config.CommPlatformIntegration]*stats
+}
+
+// stats holds deliveries of a single platform in the current period.
+type stats struct {
+	delivered    int
+	failed       int
+	withinTarget int
+	totalLatency time.Duration
+	maxLatency   time.Duration
+}
+
+// NewTracker returns a new Tracker instance.
+func NewTracker(log logrus.FieldLogger, clusterName string, cfg config.DeliverySLO, send SendFunc) *Tracker {
+	if cfg.LatencyTarget <= 0 {
+		cfg.LatencyTarget = defaultLatencyTarget
+	}
+	if cfg.Objective <= 0 {
+		cfg.Objective = defaultObjective
+	}
+	if cfg.Report.Period <= 0 {
+		cfg.Report.Period = defaultReportPeriod
+	}
+	metrics.SetDeliveryObjective(cfg.LatencyTarget, cfg.Objective)
+
+	return &Tracker{
+		log:         log,
+		cfg:         cfg,
+		clusterName: clusterName,
+		send:        send,
+		now:         time.Now,
+		since:       time.Now(),
+		stats:       map[config.CommPlatformIntegration]*stats{},
+	}
+}
+
+// Observe records delivering a notification of an event received at a given time. The time is zero for notifications
+// which are delayed on purpose, such as escalations or digests, so they are not tracked.
+func (t *Tracker) Observe(platform config.CommPlatformIntegration, receivedAt time.Time, err error) {
+	if receivedAt.IsZero() {
+		return
+	}
+	latency := t.now().Sub(receivedAt)
+	withinTarget := err == nil && latency <= t.cfg.LatencyTarget
+	metrics.ObserveDelivery(platform, latency, withinTarget, err)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	s, ok := t.stats[platform]
+	if !ok {
+		s = &stats{}
+		t.stats[platform] = s
+	}
+	if err != nil {
+		s.failed++
+		return
+	}
+	s.delivered++
+	s.totalLatency += latency
+	if latency > s.maxLatency {
+		s.maxLatency = latency
+	}
+	if withinTarget {
+		s.withinTarget++
+	}
+}
+
+// Start sends the reliability report at the end of each period. It blocks until the context is done.
+func (t *Tracker) Start(ctx context.Context) error {
+	if !t.cfg.Report.Enabled {
+		return nil
+	}
+
+	ticker := time.NewTicker(t.cfg.Report.Period)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			report := t.Report(true)
+			if len(report.Platforms) == 0 {
+				t.log.Debug("There were no deliveries in the report period. Skipping the reliability report...")
+				continue
+			}
+			if err := t.send(ctx, reportMessage(report, t.clusterName)); err != nil {
+				t.log.Errorf("while sending reliability report: %s", err.Error())
+			}
+		}
+	}
+}
+
+// Report returns the report of the current period. If reset is true, a new period is started.
+func (t *Tracker) Report(reset bool) Report {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	report := Report{
+		Since:         t.since,
+		Until:         now,
+		LatencyTarget: t.cfg.LatencyTarget,
+		Objective:     t.cfg.Objective,
+	}
+	for platform, s := range t.stats {
+		report.Platforms = append(report.Platforms, platformReport(platform, s, t.cfg.Objective))
+	}
+	sort.Slice(report.Platforms, func(i, j int) bool {
+		return report.Platforms[i].Platform < report.Platforms[j].Platform
+	})
+
+	if reset {
+		t.since = now
+		t.stats = map[config.CommPlatformIntegration]*stats{}
+	}
+	return report
+}
+
+// Report describes delivery reliability of notifications in a given period.
+type Report struct {
+	Since         time.Time
+	Until         time.Time
+	LatencyTarget time.Duration
+	Objective     float64
+	Platforms     []PlatformReport
+}
+
+// PlatformReport describes delivery reliability of a single communication platform or sink.
+type PlatformReport struct {
+	Platform  config.CommPlatformIntegration
+	Delivered int
+	Failed    int
+	// Late is the number of notifications delivered after the latency target.
+	Late       int
+	AvgLatency time.Duration
+	MaxLatency time.Duration
+	// Attainment is the percentage of notifications delivered successfully within the latency target.
+	Attainment float64
+	// BudgetLeft is the percentage of the error budget which is not used. It's negative if the objective is not met.
+	BudgetLeft float64
+}
+
+// Met returns true if the delivery objective is met.
+func (r PlatformReport) Met(objective float64) bool {
+	return r.Attainment >= objective
+}
+
+func platformReport(platform config.CommPlatformIntegration, s *stats, objective float64) PlatformReport {
+	out := PlatformReport{
+		Platform:   platform,
+		Delivered:  s.delivered,
+		Failed:     s.failed,
+		Late:       s.delivered - s.withinTarget,
+		MaxLatency: s.maxLatency,
+	}
+	if s.delivered > 0 {
+		out.AvgLatency = s.totalLatency / time.Duration(s.delivered)
+	}
+
+	total := s.delivered + s.failed
+	bad := out.Failed + out.Late
+	out.Attainment = 100 * float64(total-bad) / float64(total)
+
+	allowed := (100 - objective) / 100 * float64(total)
+	switch {
+	case allowed > 0:
+		out.BudgetLeft = 100 * (1 - float64(bad)/allowed)
+	case bad == 0:
+		out.BudgetLeft = 100
+	default:
+		// the objective is 100%, so there is no error budget
+		out.BudgetLeft = -100
+	}
+	return out
+}
+
+func reportMessage(report Report, clusterName string) interactive.CoreMessage {
+	sections := []api.Section{
+		{
+			Base: api.Base{
+				Header:      fmt.Sprintf("Objective: %s%% of notifications delivered within %s", formatPercent(report.Objective), report.LatencyTarget),
+				Description: fmt.Sprintf("Cluster %s, from %s to %s.", clusterName, report.Since.UTC().Format(reportTimeFormat), report.Until.UTC().Format(reportTimeFormat)),
+			},
+		},
+	}
+	for _, p := range report.Platforms {
+		status := "Met"
+		if !p.Met(report.Objective) {
+			status = "Not met"
+		}
+		sections = append(sections, api.Section{
+			Base: api.Base{
+				Header: string(p.Platform),
+			},
+			TextFields: api.TextFields{
+				{Key: "Objective", Value: status},
+				{Key: "Within target", Value: fmt.Sprintf("%s%%", formatPercent(p.Attainment))},
+				{Key: "Error budget left", Value: fmt.Sprintf("%s%%", formatPercent(p.BudgetLeft))},
+				{Key: "Delivered", Value: fmt.Sprintf("%d", p.Delivered)},
+				{Key: "Failed", Value: fmt.Sprintf("%d", p.Failed)},
+				{Key: "Late", Value: fmt.Sprintf("%d", p.Late)},
+				{Key: "Average latency", Value: p.AvgLatency.Round(time.Millisecond).String()},
+				{Key: "Max latency", Value: p.MaxLatency.Round(time.Millisecond).String()},
+			},
+		})
+	}
+
+	return interactive.CoreMessage{
+		Header:  reportHeader,
+		Message: api.Message{Sections: sections},
+	}
+}
+
+// formatPercent formats a percentage with up to two decimal places, e.g. `99.5` or `100`.
+func formatPercent(in float64) string {
+	return strconv.FormatFloat(math.Round(in*100)/100, 'f', -1, 64)
+}
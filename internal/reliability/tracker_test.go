@@ -0,0 +1,115 @@
+package reliability
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+func TestTrackerReport(t *testing.T) {
+	// given
+	start := time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC)
+	now := start
+	tracker := NewTracker(loggerx.NewNoop(), "prod", config.DeliverySLO{Objective: 90}, nil)
+	tracker.now = func() time.Time { return now }
+	tracker.since = start
+
+	now = start.Add(time.Hour)
+	for i := 0; i < 18; i++ {
+		tracker.Observe(config.SocketSlackCommPlatformIntegration, now.Add(-2*time.Second), nil)
+	}
+	tracker.Observe(config.SocketSlackCommPlatformIntegration, now.Add(-time.Minute), nil)
+	tracker.Observe(config.SocketSlackCommPlatformIntegration, now.Add(-time.Second), errors.New("channel not found"))
+	tracker.Observe(config.WebhookCommPlatformIntegration, now.Add(-time.Minute), nil)
+	tracker.Observe(config.WebhookCommPlatformIntegration, time.Time{}, nil)
+
+	// when
+	report := tracker.Report(true)
+
+	// then
+	assert.Equal(t, Report{
+		Since:         start,
+		Until:         start.Add(time.Hour),
+		LatencyTarget: 30 * time.Second,
+		Objective:     90,
+		Platforms: []PlatformReport{
+			{
+				Platform:   config.SocketSlackCommPlatformIntegration,
+				Delivered:  19,
+				Failed:     1,
+				Late:       1,
+				AvgLatency: 96 * time.Second / 19,
+				MaxLatency: time.Minute,
+				Attainment: 90,
+				BudgetLeft: 0,
+			},
+			{
+				Platform:   config.WebhookCommPlatformIntegration,
+				Delivered:  1,
+				Late:       1,
+				AvgLatency: time.Minute,
+				MaxLatency: time.Minute,
+				Attainment: 0,
+				BudgetLeft: -900,
+			},
+		},
+	}, report)
+	assert.True(t, report.Platforms[0].Met(report.Objective))
+	assert.False(t, report.Platforms[1].Met(report.Objective))
+
+	// when
+	next := tracker.Report(false)
+
+	// then
+	assert.Equal(t, start.Add(time.Hour), next.Since)
+	assert.Empty(t, next.Platforms)
+}
+
+func TestReportMessage(t *testing.T) {
+	// given
+	report := Report{
+		Since:         time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC),
+		Until:         time.Date(2024, 5, 13, 0, 0, 0, 0, time.UTC),
+		LatencyTarget: 30 * time.Second,
+		Objective:     99.5,
+		Platforms: []PlatformReport{
+			{
+				Platform:   config.SocketSlackCommPlatformIntegration,
+				Delivered:  599,
+				Failed:     1,
+				Late:       2,
+				AvgLatency: 1234567 * time.Microsecond,
+				MaxLatency: 45 * time.Second,
+				Attainment: 99.5,
+				BudgetLeft: 0,
+			},
+		},
+	}
+
+	// when
+	msg := reportMessage(report, "prod")
+
+	// then
+	assert.Equal(t, "Notification delivery report", msg.Header)
+	require.Len(t, msg.Sections, 2)
+	assert.Equal(t, "Objective: 99.5% of notifications delivered within 30s", msg.Sections[0].Header)
+	assert.Equal(t, "Cluster prod, from 2024-05-06 00:00 UTC to 2024-05-13 00:00 UTC.", msg.Sections[0].Description)
+	assert.Equal(t, "socketSlack", msg.Sections[1].Header)
+	assert.Equal(t, api.TextFields{
+		{Key: "Objective", Value: "Met"},
+		{Key: "Within target", Value: "99.5%"},
+		{Key: "Error budget left", Value: "0%"},
+		{Key: "Delivered", Value: "599"},
+		{Key: "Failed", Value: "1"},
+		{Key: "Late", Value: "2"},
+		{Key: "Average latency", Value: "1.235s"},
+		{Key: "Max latency", Value: "45s"},
+	}, msg.Sections[1].TextFields)
+}
@@ -0,0 +1,305 @@
+package replay
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/sliceutil"
+)
+
+var (
+	// ErrUnknownSource is returned if the source binding of a replayed event doesn't exist, or it's not started.
+	ErrUnknownSource = errors.New("source binding is not started")
+	// ErrNotReady is returned if events are replayed before the notification pipeline is started.
+	ErrNotReady = errors.New("notification pipeline is not started yet")
+)
+
+// maxRecordSize limits the size of a single recorded event.
+const maxRecordSize = 1024 * 1024
+
+// Request holds an event replayed for a given source binding.
+type Request struct {
+	// Source is the name of the source binding the event is replayed for.
+	Source string `json:"source"`
+	// Event is the raw event, e.g. the `data` property of an event recorded by the archive sinks.
+	Event any `json:"event"`
+	// Object is the full object the event is about. It's optional, and used to evaluate routing rules and owners.
+	Object any `json:"object,omitempty"`
+}
+
+// Synthetic describes a synthetic Kubernetes event.
+type Synthetic struct {
+	Kind      string
+	Name      string
+	Namespace string
+	Type      string
+	Reason    string
+	Message   string
+	Labels    map[string]string
+}
+
+// Request returns a request which replays the synthetic event for a given source binding.
+// The event has the same shape as events emitted by the Kubernetes source.
+func (s Synthetic) Request(source string) Request {
+	event := map[string]any{
+		"Kind":      s.Kind,
+		"Name":      s.Name,
+		"Namespace": s.Namespace,
+		"Type":      s.Type,
+		"Reason":    s.Reason,
+	}
+	if s.Message != "" {
+		event["Messages"] = []string{s.Message}
+	}
+
+	meta := map[string]any{"name": s.Name}
+	if s.Namespace != "" {
+		meta["namespace"] = s.Namespace
+	}
+	if len(s.Labels) > 0 {
+		meta["labels"] = s.Labels
+	}
+	return Request{
+		Source: source,
+		Event:  event,
+		Object: map[string]any{"kind": s.Kind, "metadata": meta},
+	}
+}
+
+// record is an event recorded by the archive sinks. It's decoded partially, so this package doesn't depend on sinks.
+type record struct {
+	Source string `json:"source"`
+	Data   any    `json:"data"`
+}
+
+// ParseRecords decodes events recorded by the archive sinks in the JSON lines format. If a source binding is given,
+// events are replayed for it. Otherwise, they are replayed for the first source binding they were recorded for.
+func ParseRecords(r io.Reader, source string) ([]Request, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxRecordSize)
+
+	var out []Request
+	for line := 1; scanner.Scan(); line++ {
+		raw := strings.TrimSpace(scanner.Text())
+		if raw == "" {
+			continue
+		}
+		var rec record
+		if err := json.Unmarshal([]byte(raw), &rec); err != nil {
+			return nil, fmt.Errorf("while decoding event in line %d: %w", line, err)
+		}
+
+		name := source
+		if name == "" {
+			name, _, _ = strings.Cut(rec.Source, ",")
+		}
+		if name == "" {
+			return nil, fmt.Errorf("event in line %d doesn't have a source binding", line)
+		}
+		out = append(out, Request{Source: name, Event: rec.Data})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("while reading events: %w", err)
+	}
+	return out, nil
+}
+
+// Message returns the message for a replayed event. Replayed events are not processed by source plugins,
+// so the message is rendered from common properties of Kubernetes events.
+func Message(req Request) api.Message {
+	var ev struct {
+		Kind      string
+		Name      string
+		Namespace string
+		Reason    string
+		Messages  []string
+	}
+	if raw, err := json.Marshal(req.Event); err == nil {
+		_ = json.Unmarshal(raw, &ev)
+	}
+
+	ref := strings.Join(sliceutil.FilterEmptyStrings([]string{ev.Namespace, ev.Name}), "/")
+	header := strings.Join(sliceutil.FilterEmptyStrings([]string{ev.Kind, ref, ev.Reason}), " ")
+	if header == "" {
+		header = fmt.Sprintf("Event from %s", req.Source)
+	}
+	return api.Message{
+		Sections: []api.Section{
+			{
+				Base: api.Base{
+					Header: header,
+					Body:   api.Body{Plaintext: strings.Join(ev.Messages, "\n")},
+				},
+			},
+		},
+	}
+}
+
+// Report describes how a replayed event is handled by the notification pipeline.
+type Report struct {
+	Source string `json:"source"`
+	Header string `json:"header,omitempty"`
+	// Owner is the team which owns the resource. It's empty if the owner is unknown.
+	Owner string `json:"owner,omitempty"`
+	// Filters are names of filters bound to channels which matched the event.
+	Filters []string `json:"filters,omitempty"`
+	// Dropped are channels which don't receive the notification, as it's dropped by their filters.
+	Dropped []Target `json:"dropped,omitempty"`
+	// Sources are source bindings the notification is sent for.
+	Sources []string `json:"sources,omitempty"`
+	// Routed is set if channels are selected by routing rules or the source, instead of source bindings.
+	Routed  bool     `json:"routed,omitempty"`
+	Targets []Target `json:"targets,omitempty"`
+}
+
+// Target is a channel or sink which receives the notification.
+type Target struct {
+	Integration string `json:"integration"`
+	// Channel is the channel alias, or the alias of a topic, subject or index for sinks. It's empty if the integration doesn't have them.
+	Channel string `json:"channel,omitempty"`
+}
+
+func (t Target) String() string {
+	if t.Channel == "" {
+		return t.Integration
+	}
+	return fmt.Sprintf("%s/%s", t.Integration, t.Channel)
+}
+
+// SinkTargets returns sinks which receive events of given source bindings.
+func SinkTargets(comms map[string]config.Communications, sources []string) []Target {
+	var out []Target
+	add := func(integration config.CommPlatformIntegration, channel string, bindings config.SinkBindings) {
+		if sliceutil.Intersect(bindings.Sources, sources) {
+			out = append(out, Target{Integration: string(integration), Channel: channel})
+		}
+	}
+
+	for _, name := range sortedKeys(comms) {
+		c := comms[name]
+		if c.Webhook.Enabled {
+			add(config.WebhookCommPlatformIntegration, "", c.Webhook.Bindings)
+		}
+		if c.Elasticsearch.Enabled {
+			for _, alias := range sortedKeys(c.Elasticsearch.Indices) {
+				add(config.ElasticsearchCommPlatformIntegration, alias, c.Elasticsearch.Indices[alias].Bindings)
+			}
+		}
+		if c.PagerDuty.Enabled {
+			add(config.PagerDutyCommPlatformIntegration, "", c.PagerDuty.Bindings)
+		}
+		if c.Kafka.Enabled {
+			for _, alias := range sortedKeys(c.Kafka.Topics) {
+				add(config.KafkaCommPlatformIntegration, alias, c.Kafka.Topics[alias].Bindings)
+			}
+		}
+		if c.NATS.Enabled {
+			for _, alias := range sortedKeys(c.NATS.Subjects) {
+				add(config.NATSCommPlatformIntegration, alias, c.NATS.Subjects[alias].Bindings)
+			}
+		}
+		if c.S3Archive.Enabled {
+			add(config.S3ArchiveCommPlatformIntegration, "", c.S3Archive.Bindings)
+		}
+		if c.Warehouse.Enabled {
+			add(config.WarehouseCommPlatformIntegration, "", c.Warehouse.Bindings)
+		}
+	}
+	return out
+}
+
+// Pipeline evaluates replayed events without sending notifications.
+type Pipeline interface {
+	Replay(ctx context.Context, req Request) (Report, error)
+}
+
+// Service replays events in the notification pipeline. The pipeline is set once source plugins are started,
+// so the service can be passed to components created before, e.g. executors.
+type Service struct {
+	mu       sync.RWMutex
+	pipeline Pipeline
+}
+
+// NewService returns a new Service instance.
+func NewService() *Service {
+	return &Service{}
+}
+
+// SetPipeline sets the pipeline events are replayed in.
+func (s *Service) SetPipeline(pipeline Pipeline) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pipeline = pipeline
+}
+
+// Replay evaluates a given event, and reports which channels and sinks would receive it.
+func (s *Service) Replay(ctx context.Context, req Request) (Report, error) {
+	s.mu.RLock()
+	pipeline := s.pipeline
+	s.mu.RUnlock()
+
+	if pipeline == nil {
+		return Report{}, ErrNotReady
+	}
+	return pipeline.Replay(ctx, req)
+}
+
+// ServeHTTP replays events sent in the request body as a JSON array of requests, and responds with their reports.
+func (s *Service) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		http.Error(resp, "only POST requests are supported", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var in []Request
+	if err := json.NewDecoder(req.Body).Decode(&in); err != nil {
+		http.Error(resp, fmt.Sprintf("while decoding events: %s", err.Error()), http.StatusBadRequest)
+		return
+	}
+
+	out := make([]Report, 0, len(in))
+	for _, item := range in {
+		report, err := s.Replay(req.Context(), item)
+		switch {
+		case err == nil:
+		case errors.Is(err, ErrUnknownSource):
+			http.Error(resp, err.Error(), http.StatusBadRequest)
+			return
+		case errors.Is(err, ErrNotReady):
+			http.Error(resp, err.Error(), http.StatusServiceUnavailable)
+			return
+		default:
+			http.Error(resp, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		out = append(out, report)
+	}
+
+	respJSON, err := json.Marshal(out)
+	if err != nil {
+		http.Error(resp, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	resp.Header().Set("Content-Type", "application/json")
+	_, _ = resp.Write(respJSON)
+}
+
+func sortedKeys[T any](in map[string]T) []string {
+	var out []string
+	for key := range in {
+		out = append(out, key)
+	}
+	sort.Strings(out)
+	return out
+}
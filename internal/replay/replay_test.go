@@ -0,0 +1,110 @@
+package replay
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+func TestParseRecords(t *testing.T) {
+	// given
+	raw := `{"source":"k8s-err-events,critical","clusterName":"prod","data":{"Kind":"Pod","Name":"api"}}
+
+{"source":"k8s-all-events","clusterName":"prod","data":{"Kind":"Node","Name":"node-1"}}
+`
+
+	// when
+	recorded, err := ParseRecords(strings.NewReader(raw), "")
+	require.NoError(t, err)
+	overridden, err := ParseRecords(strings.NewReader(raw), "test")
+	require.NoError(t, err)
+
+	// then
+	assert.Equal(t, []Request{
+		{Source: "k8s-err-events", Event: map[string]any{"Kind": "Pod", "Name": "api"}},
+		{Source: "k8s-all-events", Event: map[string]any{"Kind": "Node", "Name": "node-1"}},
+	}, recorded)
+	assert.Equal(t, "test", overridden[0].Source)
+	assert.Equal(t, "test", overridden[1].Source)
+}
+
+func TestParseRecordsInvalid(t *testing.T) {
+	// when
+	_, err := ParseRecords(strings.NewReader(`{"source":"a","data":{}}`+"\n{"), "")
+
+	// then
+	assert.EqualError(t, err, "while decoding event in line 2: unexpected end of JSON input")
+}
+
+func TestSinkTargets(t *testing.T) {
+	// given
+	comms := map[string]config.Communications{
+		"default": {
+			Webhook: config.Webhook{Enabled: true, Bindings: config.SinkBindings{Sources: []string{"k8s-err-events"}}},
+			Elasticsearch: config.Elasticsearch{Enabled: true, Indices: map[string]config.ELSIndex{
+				"b-errors": {Bindings: config.SinkBindings{Sources: []string{"k8s-err-events"}}},
+				"a-all":    {Bindings: config.SinkBindings{Sources: []string{"k8s-all-events", "k8s-err-events"}}},
+				"c-other":  {Bindings: config.SinkBindings{Sources: []string{"other"}}},
+			}},
+			S3Archive: config.S3Archive{Enabled: false, Bindings: config.SinkBindings{Sources: []string{"k8s-err-events"}}},
+		},
+	}
+
+	// when
+	targets := SinkTargets(comms, []string{"k8s-err-events"})
+
+	// then
+	assert.Equal(t, []Target{
+		{Integration: "webhook"},
+		{Integration: "elasticsearch", Channel: "a-all"},
+		{Integration: "elasticsearch", Channel: "b-errors"},
+	}, targets)
+}
+
+type fakePipeline struct{}
+
+func (fakePipeline) Replay(_ context.Context, req Request) (Report, error) {
+	if req.Source != "k8s-err-events" {
+		return Report{}, ErrUnknownSource
+	}
+	return Report{Source: req.Source, Header: Message(req).Sections[0].Header}, nil
+}
+
+func TestServiceServeHTTP(t *testing.T) {
+	// given
+	svc := NewService()
+	body := `[{"source":"k8s-err-events","event":{"Kind":"Pod","Name":"api","Namespace":"prod","Reason":"BackOff"}}]`
+
+	// when
+	resp := httptest.NewRecorder()
+	svc.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/replay", bytes.NewBufferString(body)))
+
+	// then
+	assert.Equal(t, http.StatusServiceUnavailable, resp.Code)
+
+	// given
+	svc.SetPipeline(fakePipeline{})
+
+	// when
+	resp = httptest.NewRecorder()
+	svc.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/replay", bytes.NewBufferString(body)))
+
+	// then
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, `[{"source":"k8s-err-events","header":"Pod prod/api BackOff"}]`, resp.Body.String())
+
+	// when
+	resp = httptest.NewRecorder()
+	svc.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/replay", bytes.NewBufferString(`[{"source":"unknown"}]`)))
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+}
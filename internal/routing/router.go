@@ -0,0 +1,133 @@
+package routing
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/utils/strings/slices"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+// Notification holds details of a notification used to select channels.
+type Notification struct {
+	SourceName string
+	// Object is the full object the event is about, if provided by the source.
+	Object any
+	// Owner is the team which owns the object. It's empty if the owner is unknown.
+	Owner string
+}
+
+// Router routes notifications to channels based on labels, annotations and owners of the resources they are about.
+type Router struct {
+	log logrus.FieldLogger
+
+	mu  sync.RWMutex
+	cfg config.Routing
+}
+
+// NewRouter returns a new Router instance.
+func NewRouter(log logrus.FieldLogger, cfg config.Routing) *Router {
+	return &Router{
+		log: log,
+		cfg: cfg,
+	}
+}
+
+// Update replaces the routing configuration. It's used to reload the rules without restarting the agent.
+func (r *Router) Update(cfg config.Routing) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cfg = cfg
+	r.log.Infof("Routing configuration updated with %d rule(s)", len(cfg.Rules))
+}
+
+// Route returns channels for a given notification. It returns false if the notification is not routed,
+// and should be sent to channels bound to its source bindings.
+func (r *Router) Route(in Notification) ([]string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if !r.cfg.Enabled {
+		return nil, false
+	}
+	if len(r.cfg.Sources) > 0 && !slices.Contains(r.cfg.Sources, in.SourceName) {
+		return nil, false
+	}
+
+	meta, err := objectMetaFor(in.Object)
+	if err != nil {
+		r.log.WithError(err).Error("Cannot get labels and annotations for routing")
+		return r.fallback()
+	}
+
+	var channels []string
+	for _, rule := range r.cfg.Rules {
+		if !matches(rule.Labels, meta.Labels) || !matches(rule.Annotations, meta.Annotations) {
+			continue
+		}
+		if len(rule.Owners) > 0 && !slices.Contains(rule.Owners, in.Owner) {
+			continue
+		}
+		for _, ch := range rule.Channels {
+			if !slices.Contains(channels, ch) {
+				channels = append(channels, ch)
+			}
+		}
+	}
+	if len(channels) == 0 {
+		return r.fallback()
+	}
+
+	r.log.WithField("channels", channels).Debug("Routing notification")
+	return channels, true
+}
+
+// fallback must be called with the lock held.
+func (r *Router) fallback() ([]string, bool) {
+	if len(r.cfg.Fallback) == 0 {
+		return nil, false
+	}
+	return r.cfg.Fallback, true
+}
+
+// matches returns true if all expected keys are present. An empty expected value matches any value.
+func matches(expected, actual map[string]string) bool {
+	for key, val := range expected {
+		got, ok := actual[key]
+		if !ok {
+			return false
+		}
+		if val != "" && val != got {
+			return false
+		}
+	}
+	return true
+}
+
+type objectMeta struct {
+	Labels      map[string]string `json:"labels"`
+	Annotations map[string]string `json:"annotations"`
+}
+
+func objectMetaFor(obj any) (objectMeta, error) {
+	if obj == nil {
+		return objectMeta{}, nil
+	}
+
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		return objectMeta{}, fmt.Errorf("while marshaling object: %w", err)
+	}
+
+	var out struct {
+		Metadata objectMeta `json:"metadata"`
+	}
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return objectMeta{}, fmt.Errorf("while unmarshaling object metadata: %w", err)
+	}
+	return out.Metadata, nil
+}
@@ -0,0 +1,149 @@
+package routing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+func TestRouterRoute(t *testing.T) {
+	// given
+	router := NewRouter(loggerx.NewNoop(), config.Routing{
+		Enabled: true,
+		Sources: []string{"k8s-err-events"},
+		Rules: []config.RoutingRule{
+			{
+				Labels:   map[string]string{"team": "payments"},
+				Channels: []string{"payments-alerts"},
+			},
+			{
+				Annotations: map[string]string{"botkube.io/oncall": ""},
+				Channels:    []string{"oncall", "payments-alerts"},
+			},
+			{
+				Owners:   []string{"storefront", "checkout"},
+				Channels: []string{"shop-alerts"},
+			},
+		},
+		Fallback: []string{"platform-alerts"},
+	})
+
+	object := func(labels, annotations map[string]any) map[string]any {
+		return map[string]any{
+			"metadata": map[string]any{
+				"name":        "api",
+				"labels":      labels,
+				"annotations": annotations,
+			},
+		}
+	}
+
+	tests := []struct {
+		name             string
+		in               Notification
+		expectedChannels []string
+		expectedRouted   bool
+	}{
+		{
+			name: "matching labels",
+			in: Notification{
+				SourceName: "k8s-err-events",
+				Object:     object(map[string]any{"team": "payments", "tier": "backend"}, nil),
+			},
+			expectedChannels: []string{"payments-alerts"},
+			expectedRouted:   true,
+		},
+		{
+			name: "multiple matching rules",
+			in: Notification{
+				SourceName: "k8s-err-events",
+				Object:     object(map[string]any{"team": "payments"}, map[string]any{"botkube.io/oncall": "true"}),
+			},
+			expectedChannels: []string{"payments-alerts", "oncall"},
+			expectedRouted:   true,
+		},
+		{
+			name: "matching owner",
+			in: Notification{
+				SourceName: "k8s-err-events",
+				Object:     object(map[string]any{"team": "payments"}, nil),
+				Owner:      "checkout",
+			},
+			expectedChannels: []string{"payments-alerts", "shop-alerts"},
+			expectedRouted:   true,
+		},
+		{
+			name: "matching owner without object",
+			in: Notification{
+				SourceName: "k8s-err-events",
+				Owner:      "storefront",
+			},
+			expectedChannels: []string{"shop-alerts"},
+			expectedRouted:   true,
+		},
+		{
+			name: "fallback channels",
+			in: Notification{
+				SourceName: "k8s-err-events",
+				Object:     object(map[string]any{"team": "search"}, nil),
+			},
+			expectedChannels: []string{"platform-alerts"},
+			expectedRouted:   true,
+		},
+		{
+			name: "fallback channels without object",
+			in: Notification{
+				SourceName: "k8s-err-events",
+			},
+			expectedChannels: []string{"platform-alerts"},
+			expectedRouted:   true,
+		},
+		{
+			name: "not routed source",
+			in: Notification{
+				SourceName: "k8s-all-events",
+				Object:     object(map[string]any{"team": "payments"}, nil),
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			channels, routed := router.Route(tc.in)
+
+			// then
+			assert.Equal(t, tc.expectedRouted, routed)
+			assert.Equal(t, tc.expectedChannels, channels)
+		})
+	}
+}
+
+func TestRouterUpdate(t *testing.T) {
+	// given
+	router := NewRouter(loggerx.NewNoop(), config.Routing{})
+	in := Notification{
+		SourceName: "k8s-err-events",
+		Object: map[string]any{
+			"metadata": map[string]any{"labels": map[string]any{"team": "payments"}},
+		},
+	}
+
+	_, routed := router.Route(in)
+	assert.False(t, routed)
+
+	// when
+	router.Update(config.Routing{
+		Enabled: true,
+		Rules: []config.RoutingRule{
+			{Labels: map[string]string{"team": "payments"}, Channels: []string{"payments-alerts"}},
+		},
+	})
+
+	// then
+	channels, routed := router.Route(in)
+	assert.True(t, routed)
+	assert.Equal(t, []string{"payments-alerts"}, channels)
+}
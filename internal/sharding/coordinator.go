@@ -0,0 +1,144 @@
+package sharding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+// ErrShardLost is returned by Coordinator.Start once the held shard couldn't be renewed, e.g. during a network partition.
+var ErrShardLost = errors.New("shard lost")
+
+// Coordinator acquires a single shard. Each shard is held with a separate Lease.
+type Coordinator struct {
+	log      logrus.FieldLogger
+	cfg      config.Sharding
+	identity string
+	electors []*leaderelection.LeaderElector
+
+	mu       sync.Mutex
+	held     int
+	cancels  []context.CancelFunc
+	acquired chan struct{}
+}
+
+// NewCoordinator returns a new Coordinator. The identity must be unique across replicas, e.g. the Pod name.
+func NewCoordinator(log logrus.FieldLogger, cfg config.Sharding, cli kubernetes.Interface, identity string) (*Coordinator, error) {
+	c := &Coordinator{
+		log:      log,
+		cfg:      cfg,
+		identity: identity,
+		held:     -1,
+		acquired: make(chan struct{}),
+	}
+
+	for idx := 0; idx < cfg.Shards; idx++ {
+		idx := idx
+		leaseName := fmt.Sprintf("%s-%d", cfg.Lease.Name, idx)
+		elector, err := leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+			Lock: &resourcelock.LeaseLock{
+				LeaseMeta: metav1.ObjectMeta{
+					Name:      leaseName,
+					Namespace: cfg.Lease.Namespace,
+				},
+				Client: cli.CoordinationV1(),
+				LockConfig: resourcelock.ResourceLockConfig{
+					Identity: identity,
+				},
+			},
+			LeaseDuration: cfg.LeaseDuration,
+			RenewDeadline: cfg.RenewDeadline,
+			RetryPeriod:   cfg.RetryPeriod,
+			// shards which are not held, or are released once Botkube is stopped, can be taken over by standby replicas right away
+			ReleaseOnCancel: true,
+			Callbacks: leaderelection.LeaderCallbacks{
+				OnStartedLeading: func(context.Context) {
+					c.onAcquired(idx)
+				},
+				OnStoppedLeading: func() {},
+			},
+			Name: leaseName,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("while creating elector for shard %d: %w", idx, err)
+		}
+		c.electors = append(c.electors, elector)
+	}
+	return c, nil
+}
+
+// Start competes for all shards until one of them is acquired. Once acquired, the shard is renewed until the context is done.
+// It returns ErrShardLost if the shard was lost before the context was done.
+func (c *Coordinator) Start(ctx context.Context) error {
+	c.log.Infof("Competing for %d shard(s) as %q...", len(c.electors), c.identity)
+
+	c.mu.Lock()
+	c.cancels = make([]context.CancelFunc, len(c.electors))
+	electorCtxs := make([]context.Context, len(c.electors))
+	for idx := range c.electors {
+		electorCtxs[idx], c.cancels[idx] = context.WithCancel(ctx)
+	}
+	c.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for idx, elector := range c.electors {
+		wg.Add(1)
+		go func(ctx context.Context, elector *leaderelection.LeaderElector) {
+			defer wg.Done()
+			elector.Run(ctx)
+		}(electorCtxs[idx], elector)
+	}
+	// all electors except the one of the held shard are cancelled once the shard is acquired
+	wg.Wait()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, cancel := range c.cancels {
+		cancel()
+	}
+	if ctx.Err() != nil || c.held < 0 {
+		return nil
+	}
+	c.log.Warnf("Shard %d lost. All components are stopped to compete for shards again.", c.held)
+	return ErrShardLost
+}
+
+// Acquired returns a channel which is closed once a shard is acquired.
+func (c *Coordinator) Acquired() <-chan struct{} {
+	return c.acquired
+}
+
+// Shard returns the acquired shard. It must be called once the channel returned by Acquired is closed.
+func (c *Coordinator) Shard() Shard {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return ShardFor(c.cfg, c.held)
+}
+
+func (c *Coordinator) onAcquired(idx int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.held >= 0 {
+		// another shard was acquired at the same time, so this one is released right away
+		c.cancels[idx]()
+		return
+	}
+
+	c.held = idx
+	for other, cancel := range c.cancels {
+		if other != idx {
+			cancel()
+		}
+	}
+	close(c.acquired)
+}
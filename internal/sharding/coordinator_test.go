@@ -0,0 +1,91 @@
+package sharding
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+func TestCoordinatorsAcquireDistinctShards(t *testing.T) {
+	// given
+	cli := fake.NewSimpleClientset()
+	cfg := config.Sharding{
+		Enabled:       true,
+		Shards:        2,
+		Lease:         config.K8sResourceRef{Name: "botkube-shard", Namespace: "botkube"},
+		LeaseDuration: 2 * time.Second,
+		RenewDeadline: time.Second,
+		RetryPeriod:   100 * time.Millisecond,
+	}
+
+	var (
+		coordinators []*Coordinator
+		cancels      []context.CancelFunc
+	)
+	for i := 0; i < 3; i++ {
+		c, err := NewCoordinator(loggerx.NewNoop(), cfg, cli, fmt.Sprintf("botkube-%d", i))
+		require.NoError(t, err)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go func() { _ = c.Start(ctx) }()
+
+		coordinators = append(coordinators, c)
+		cancels = append(cancels, cancel)
+	}
+
+	// when
+	held := map[int]int{}
+	standby := -1
+	deadline := time.After(5 * time.Second)
+	for len(held) < 2 {
+		select {
+		case <-deadline:
+			t.Fatalf("shards were not acquired, got %v", held)
+		case <-time.After(50 * time.Millisecond):
+		}
+		for idx, c := range coordinators {
+			select {
+			case <-c.Acquired():
+				held[idx] = c.Shard().Index
+			default:
+			}
+		}
+	}
+	for idx := range coordinators {
+		if _, ok := held[idx]; !ok {
+			standby = idx
+		}
+	}
+
+	// then
+	require.NotEqual(t, -1, standby)
+	var shards []int
+	for _, shard := range held {
+		shards = append(shards, shard)
+	}
+	assert.ElementsMatch(t, []int{0, 1}, shards)
+
+	// when the shard holder is stopped
+	var stopped int
+	for idx := range held {
+		stopped = idx
+		break
+	}
+	cancels[stopped]()
+
+	// then the standby replica takes over its shard
+	select {
+	case <-coordinators[standby].Acquired():
+		assert.Equal(t, held[stopped], coordinators[standby].Shard().Index)
+	case <-time.After(5 * time.Second):
+		t.Fatal("standby replica didn't take over the shard")
+	}
+}
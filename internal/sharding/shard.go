@@ -0,0 +1,83 @@
+// Package sharding splits the watch space across multiple Botkube replicas by namespaces.
+// Each replica holds a single shard with a Kubernetes Lease, and sharding-aware source plugins handle only namespaces of that shard.
+package sharding
+
+import (
+	"fmt"
+	"hash/fnv"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/kubeshop/botkube/pkg/api/source"
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+// Shard describes the part of the watch space handled by a single replica.
+type Shard struct {
+	// Index is the shard index, starting from 0.
+	Index int `yaml:"index"`
+	// Count is the number of shards.
+	Count int `yaml:"count"`
+	// Assignments maps explicitly assigned namespaces to shard indexes. Other namespaces are assigned by hash of their names.
+	Assignments map[string]int `yaml:"assignments,omitempty"`
+}
+
+// ShardFor returns a shard with a given index.
+func ShardFor(cfg config.Sharding, index int) Shard {
+	shard := Shard{
+		Index: index,
+		Count: cfg.Shards,
+	}
+	for _, assignment := range cfg.Assignments {
+		for _, ns := range assignment.Namespaces {
+			if shard.Assignments == nil {
+				shard.Assignments = map[string]int{}
+			}
+			shard.Assignments[ns] = assignment.Shard
+		}
+	}
+	return shard
+}
+
+// Owns returns true if a given namespace belongs to the shard.
+// Cluster-scoped objects, which don't have a namespace, belong to the first shard.
+func (s Shard) Owns(namespace string) bool {
+	if s.Count <= 1 {
+		return true
+	}
+	if idx, ok := s.Assignments[namespace]; ok {
+		return idx == s.Index
+	}
+	if namespace == "" {
+		return s.Index == 0
+	}
+
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(namespace))
+	return int(h.Sum32()%uint32(s.Count)) == s.Index
+}
+
+// IsFirst returns true for the first shard, which also handles source plugins that are not sharding-aware.
+func (s Shard) IsFirst() bool {
+	return s.Index == 0
+}
+
+// RunsSingletons returns true if a replica holding a given shard runs components which must run once per cluster, such as command handling,
+// or startup, help and report messages. Only the first shard runs them, and every replica if sharding is disabled, i.e. the shard is nil.
+func RunsSingletons(shard *Shard) bool {
+	return shard == nil || shard.IsFirst()
+}
+
+// PluginConfig returns the configuration passed to sharding-aware source plugins, in addition to the user-provided one.
+func (s Shard) PluginConfig() (*source.Config, error) {
+	raw, err := yaml.Marshal(pluginConfig{Sharding: s})
+	if err != nil {
+		return nil, fmt.Errorf("while marshaling shard configuration: %w", err)
+	}
+	return &source.Config{RawYAML: raw}, nil
+}
+
+// pluginConfig holds the shard configuration of sharding-aware source plugins.
+type pluginConfig struct {
+	Sharding Shard `yaml:"sharding"`
+}
@@ -0,0 +1,89 @@
+package sharding
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+func TestShardOwnsEachNamespaceOnce(t *testing.T) {
+	// given
+	cfg := config.Sharding{
+		Shards: 3,
+		Assignments: []config.ShardAssignment{
+			{Shard: 2, Namespaces: []string{"kube-system", ""}},
+		},
+	}
+	shards := []Shard{ShardFor(cfg, 0), ShardFor(cfg, 1), ShardFor(cfg, 2)}
+
+	namespaces := []string{"", "kube-system"}
+	for i := 0; i < 100; i++ {
+		namespaces = append(namespaces, fmt.Sprintf("team-%d", i))
+	}
+
+	for _, ns := range namespaces {
+		// when
+		var owners []int
+		for _, shard := range shards {
+			if shard.Owns(ns) {
+				owners = append(owners, shard.Index)
+			}
+		}
+
+		// then
+		require.Len(t, owners, 1, "namespace %q", ns)
+	}
+
+	assert.True(t, shards[2].Owns("kube-system"))
+	assert.True(t, shards[2].Owns(""))
+}
+
+func TestShardOwnsClusterScopedObjects(t *testing.T) {
+	// given
+	cfg := config.Sharding{Shards: 2}
+
+	// when
+	first, second := ShardFor(cfg, 0), ShardFor(cfg, 1)
+
+	// then
+	assert.True(t, first.Owns(""))
+	assert.False(t, second.Owns(""))
+}
+
+func TestShardPluginConfig(t *testing.T) {
+	// given
+	shard := ShardFor(config.Sharding{
+		Shards: 2,
+		Assignments: []config.ShardAssignment{
+			{Shard: 1, Namespaces: []string{"kube-system"}},
+		},
+	}, 1)
+
+	// when
+	cfg, err := shard.PluginConfig()
+
+	// then
+	require.NoError(t, err)
+	assert.YAMLEq(t, `
+sharding:
+  index: 1
+  count: 2
+  assignments:
+    kube-system: 1
+`, string(cfg.RawYAML))
+}
+
+func TestRunsSingletons(t *testing.T) {
+	// given
+	cfg := config.Sharding{Shards: 2}
+	first, second := ShardFor(cfg, 0), ShardFor(cfg, 1)
+
+	// then
+	assert.True(t, RunsSingletons(nil))
+	assert.True(t, RunsSingletons(&first))
+	assert.False(t, RunsSingletons(&second))
+}
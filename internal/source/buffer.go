@@ -0,0 +1,175 @@
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/kubeshop/botkube/internal/filter"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/notifier"
+)
+
+// channelTargetSeparator separates the notifier key and the channel name in buffer targets of channels.
+const channelTargetSeparator = "#"
+
+// retryableError is implemented by errors of platform clients which know whether a request can be retried, e.g. Slack rate limits.
+type retryableError interface {
+	Retryable() bool
+}
+
+// bufferedDelivery is a notification which is sent by a single notifier. It's persisted in the notification buffer once sending fails.
+type bufferedDelivery struct {
+	// Input is used to select channels according to notification settings.
+	Input   filter.Input            `json:"input,omitempty"`
+	Message interactive.CoreMessage `json:"message,omitempty"`
+	// Event is the raw event sent to sinks.
+	Event    any      `json:"event,omitempty"`
+	Sources  []string `json:"sources,omitempty"`
+	Channels []string `json:"channels,omitempty"`
+	// ReceivedAt is the time the event was received at. It's zero for notifications which are not tracked against the delivery objective.
+	ReceivedAt time.Time `json:"receivedAt"`
+}
+
+// botTarget is a buffer target of a bot notifier.
+type botTarget struct {
+	key      string
+	delivery bufferedDelivery
+}
+
+// botTargets returns buffer targets for a given bot notification. If the notification buffer is enabled, bots which list their channels
+// have a target per channel, so a channel which can't be reached doesn't hold notifications for other ones, and replayed notifications
+// are not sent again to channels which already received them.
+func (d *Dispatcher) botTargets(n notifier.Bot, delivery bufferedDelivery) []botTarget {
+	lister, ok := n.(notifier.ChannelLister)
+	if d.buffer == nil || !ok {
+		return []botTarget{{key: d.botKey(n), delivery: delivery}}
+	}
+
+	var out []botTarget
+	for _, ch := range lister.NotificationChannels() {
+		if !isChannelSelected(ch, delivery.Sources, delivery.Channels) {
+			continue
+		}
+
+		channelDelivery := delivery
+		channelDelivery.Channels = []string{ch.Name}
+		out = append(out, botTarget{
+			key:      fmt.Sprintf("%s%s%s", d.botKey(n), channelTargetSeparator, ch.Name),
+			delivery: channelDelivery,
+		})
+	}
+	return out
+}
+
+// deliver sends a notification with a given function. If the notification buffer is enabled, the notification is buffered once sending fails
+// with a transient error, or if older notifications for the same target are still buffered, so they are sent in order.
+// Notifications which fail with other errors, e.g. a missing channel or an invalid message, are dropped, as sending them again fails too.
+// It returns false if the notification was buffered without being sent.
+func (d *Dispatcher) deliver(target string, delivery bufferedDelivery, send func() error) (bool, error) {
+	if d.buffer == nil {
+		return true, send()
+	}
+
+	if d.buffer.Len(target) > 0 {
+		d.bufferDelivery(target, delivery)
+		return false, nil
+	}
+
+	err := send()
+	if err != nil && isTransientError(err) {
+		d.bufferDelivery(target, delivery)
+	}
+	return true, err
+}
+
+func (d *Dispatcher) bufferDelivery(target string, delivery bufferedDelivery) {
+	log := d.log.WithField("target", target)
+
+	payload, err := json.Marshal(delivery)
+	if err != nil {
+		log.Errorf("while marshaling notification to buffer: %s", err.Error())
+		return
+	}
+	appended, err := d.buffer.Append(target, payload)
+	if err != nil {
+		log.Errorf("while buffering notification: %s", err.Error())
+		return
+	}
+	if !appended {
+		log.Debug("The same notification is already buffered. Skipping...")
+		return
+	}
+	log.Info("Notification buffered. It will be sent once the integration is reachable again.")
+}
+
+// replay sends notifications buffered for a given notifier. It's run by the send worker pool, so notifications are sent in order.
+func (d *Dispatcher) replay(ctx context.Context, target string) {
+	log := d.log.WithField("target", target)
+
+	notifierKey, _, _ := strings.Cut(target, channelTargetSeparator)
+	send, ok := d.bufferTargets[notifierKey]
+	if !ok {
+		// e.g. the integration was removed from the configuration
+		log.Warn("Dropping buffered notifications, as the integration is not configured anymore...")
+		send = func(context.Context, bufferedDelivery) error { return nil }
+	}
+
+	sent, err := d.buffer.Replay(target, func(payload []byte) error {
+		var delivery bufferedDelivery
+		if err := json.Unmarshal(payload, &delivery); err != nil {
+			log.Errorf("while unmarshaling buffered notification, dropping it: %s", err.Error())
+			return nil
+		}
+		err := send(ctx, delivery)
+		if err != nil && !isTransientError(err) {
+			// the notification would block the following ones until it expires
+			log.Errorf("while sending buffered notification, dropping it: %s", err.Error())
+			return nil
+		}
+		return err
+	})
+	if sent > 0 && ok {
+		log.Infof("Sent %d buffered notification(s)", sent)
+	}
+	if err != nil {
+		log.Debugf("while sending buffered notifications, retrying later: %s", err.Error())
+	}
+}
+
+// isTransientError returns true if a given error is caused by a temporary unavailability of the target, e.g. a network failure,
+// so sending the notification again may succeed.
+func isTransientError(err error) bool {
+	var retryable retryableError
+	if errors.As(err, &retryable) {
+		return retryable.Retryable()
+	}
+
+	var netErr net.Error
+	switch {
+	case errors.Is(err, context.DeadlineExceeded),
+		errors.Is(err, io.EOF),
+		errors.Is(err, io.ErrUnexpectedEOF),
+		errors.Is(err, syscall.ECONNREFUSED),
+		errors.Is(err, syscall.ECONNRESET),
+		errors.As(err, &netErr):
+		return true
+	}
+
+	if st, ok := status.FromError(err); ok {
+		switch st.Code() {
+		case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted:
+			return true
+		}
+	}
+	return false
+}
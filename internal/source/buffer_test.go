@@ -0,0 +1,151 @@
+package source
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+	"github.com/kubeshop/botkube/pkg/notifier"
+)
+
+func TestIsTransientError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{name: "network error", err: fmt.Errorf("while sending: %w", &net.OpError{Op: "dial", Err: errors.New("connection refused")}), expected: true},
+		{name: "deadline", err: context.DeadlineExceeded, expected: true},
+		{name: "rate limit", err: &slack.RateLimitedError{}, expected: true},
+		{name: "server error", err: slack.StatusCodeError{Code: 503}, expected: true},
+		{name: "client error", err: slack.StatusCodeError{Code: 404}, expected: false},
+		{name: "unavailable gRPC server", err: status.Error(codes.Unavailable, "connection closed"), expected: true},
+		{name: "invalid gRPC request", err: status.Error(codes.InvalidArgument, "invalid message"), expected: false},
+		{name: "platform error", err: errors.New("channel_not_found"), expected: false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, isTransientError(tc.err))
+		})
+	}
+}
+
+func TestDispatcherDeliver(t *testing.T) {
+	// given
+	buffer := &fakeBuffer{entries: map[string][][]byte{}}
+	d := &Dispatcher{log: loggerx.NewNoop(), buffer: buffer}
+	delivery := bufferedDelivery{Sources: []string{"k8s-err-events"}}
+
+	// when sending fails permanently
+	sent, err := d.deliver("bot", delivery, func() error { return errors.New("channel_not_found") })
+
+	// then
+	assert.True(t, sent)
+	assert.Error(t, err)
+	assert.Zero(t, buffer.Len("bot"), "permanent errors should not be buffered")
+
+	// when sending fails temporarily
+	sent, err = d.deliver("bot", delivery, func() error { return context.DeadlineExceeded })
+
+	// then
+	assert.True(t, sent)
+	assert.Error(t, err)
+	assert.Equal(t, 1, buffer.Len("bot"))
+
+	// when
+	sent, err = d.deliver("bot", bufferedDelivery{Sources: []string{"other"}}, func() error { return nil })
+
+	// then
+	assert.False(t, sent, "notifications should be buffered while older ones are not sent")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, buffer.Len("bot"))
+}
+
+func TestDispatcherBotTargets(t *testing.T) {
+	// given
+	bot := &fakeListerBot{integration: config.SocketSlackCommPlatformIntegration, channels: []notifier.NotificationChannel{
+		{Name: "C1", Alias: "alerts", Sources: []string{"k8s-err-events"}},
+		{Name: "C2", Alias: "team", Sources: []string{"k8s-err-events", "k8s-create-events"}},
+		{Name: "C3", Alias: "other", Sources: []string{"k8s-create-events"}},
+	}}
+	d := &Dispatcher{
+		log:      loggerx.NewNoop(),
+		buffer:   &fakeBuffer{entries: map[string][][]byte{}},
+		botNames: map[notifier.Bot]string{bot: "default"},
+	}
+	d.bufferTargets = map[string]func(ctx context.Context, delivery bufferedDelivery) error{
+		d.botKey(bot): func(ctx context.Context, delivery bufferedDelivery) error {
+			return bot.SendMessageToChannels(ctx, delivery.Message, delivery.Channels)
+		},
+	}
+
+	// when
+	targets := d.botTargets(bot, bufferedDelivery{Sources: []string{"k8s-err-events"}})
+
+	// then
+	require.Len(t, targets, 2)
+	assert.Equal(t, "bot:default:socketSlack#C1", targets[0].key)
+	assert.Equal(t, []string{"C1"}, targets[0].delivery.Channels)
+	assert.Equal(t, "bot:default:socketSlack#C2", targets[1].key)
+
+	// when routed to given channels
+	targets = d.botTargets(bot, bufferedDelivery{Channels: []string{"other"}})
+
+	// then
+	require.Len(t, targets, 1)
+	assert.Equal(t, []string{"C3"}, targets[0].delivery.Channels)
+
+	// when a notification buffered for a single channel is replayed
+	_, err := d.deliver("bot:default:socketSlack#C2", bufferedDelivery{Channels: []string{"C2"}}, func() error { return context.DeadlineExceeded })
+	require.Error(t, err)
+	d.replay(context.Background(), "bot:default:socketSlack#C2")
+
+	// then
+	assert.Equal(t, [][]string{{"C2"}}, bot.sent, "the notification should be sent only to the channel it was buffered for")
+	assert.Zero(t, d.buffer.Len("bot:default:socketSlack#C2"))
+}
+
+type fakeBuffer struct {
+	mu      sync.Mutex
+	entries map[string][][]byte
+}
+
+func (f *fakeBuffer) Start(context.Context, func(target string)) {}
+
+func (f *fakeBuffer) Append(target string, payload []byte) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.entries[target] = append(f.entries[target], payload)
+	return true, nil
+}
+
+func (f *fakeBuffer) Len(target string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.entries[target])
+}
+
+func (f *fakeBuffer) Replay(target string, send func(payload []byte) error) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	sent := 0
+	for len(f.entries[target]) > 0 {
+		if err := send(f.entries[target][0]); err != nil {
+			return sent, err
+		}
+		f.entries[target] = f.entries[target][1:]
+		sent++
+	}
+	return sent, nil
+}
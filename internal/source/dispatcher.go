@@ -4,16 +4,34 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"k8s.io/client-go/rest"
+	"k8s.io/utils/strings/slices"
 
 	"github.com/kubeshop/botkube/internal/analytics"
 	"github.com/kubeshop/botkube/internal/audit"
+	"github.com/kubeshop/botkube/internal/auditlog"
+	"github.com/kubeshop/botkube/internal/escalation"
+	"github.com/kubeshop/botkube/internal/filter"
+	"github.com/kubeshop/botkube/internal/maintenance"
+	"github.com/kubeshop/botkube/internal/metrics"
+	"github.com/kubeshop/botkube/internal/notification"
+	"github.com/kubeshop/botkube/internal/routing"
+	"github.com/kubeshop/botkube/internal/sharding"
+	"github.com/kubeshop/botkube/internal/ticketing"
+	"github.com/kubeshop/botkube/internal/tracing"
+	"github.com/kubeshop/botkube/internal/workerpool"
 	"github.com/kubeshop/botkube/pkg/action"
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/api/processor"
 	"github.com/kubeshop/botkube/pkg/api/source"
 	"github.com/kubeshop/botkube/pkg/bot"
 	"github.com/kubeshop/botkube/pkg/bot/interactive"
@@ -23,26 +41,148 @@ import (
 	"github.com/kubeshop/botkube/pkg/plugin"
 )
 
+const (
+	drainPollInterval = 100 * time.Millisecond
+
+	ingestStage = "ingest"
+	sendStage   = "send"
+)
+
 // Dispatcher provides functionality to starts a given plugin, watches for incoming events and calling all notifiers to dispatch received event.
 type Dispatcher struct {
 	log                  logrus.FieldLogger
 	manager              *plugin.Manager
 	actionProvider       ActionProvider
+	processors           EventProcessor
+	owners               OwnerResolver
+	enricher             EventEnricher
+	redactor             EventRedactor
+	filter               NotificationFilter
+	incidents            IncidentTracker
+	maintenance          MaintenanceChecker
+	tickets              TicketFiler
+	router               ChannelRouter
+	notifications        ChannelNotifier
 	reporter             AnalyticsReporter
 	auditReporter        audit.AuditReporter
+	auditLogger          AuditLogger
+	buffer               NotificationBuffer
+	deliveries           DeliveryTracker
 	markdownNotifiers    []notifier.Bot
 	interactiveNotifiers []notifier.Bot
 	sinkNotifiers        []notifier.Sink
+	botNames             map[notifier.Bot]string
 	restCfg              *rest.Config
 	clusterName          string
+
+	// shard is set if the watch space is split across replicas. Sharding-aware plugins receive shardConfig in addition to the user-provided one.
+	shard          *sharding.Shard
+	shardConfig    *source.Config
+	shardedPlugins map[string]struct{}
+
+	// ingestPool processes, filters and renders events received from sources, and sendPool delivers rendered messages to notifiers.
+	ingestPool *workerpool.Pool[ingestJob]
+	sendPool   *workerpool.Pool[func()]
+	// dispatches holds dispatches by their IDs, so events spilled to disk can be resolved back.
+	dispatches sync.Map
+	// bufferTargets holds functions which send buffered notifications by notifier keys.
+	bufferTargets map[string]func(ctx context.Context, delivery bufferedDelivery) error
+
+	// inFlight is the number of dispatched messages and notifications which are being processed.
+	inFlight atomic.Int64
+}
+
+// ingestJob is an event received from a source plugin, which waits for processing.
+type ingestJob struct {
+	event      source.Event
+	dispatch   PluginDispatch
+	receivedAt time.Time
+}
+
+// spilledIngestJob is an ingestJob written to disk.
+type spilledIngestJob struct {
+	Event      source.Event `json:"event"`
+	DispatchID string       `json:"dispatchID"`
+	ReceivedAt time.Time    `json:"receivedAt"`
 }
 
 // ActionProvider defines a provider that is responsible for automated actions.
 type ActionProvider interface {
-	RenderedActions(data any, sourceBindings []string) ([]action.Action, error)
+	RenderedActions(event source.Event, sourceBindings []string) ([]action.Action, error)
 	ExecuteAction(ctx context.Context, action action.Action) interactive.CoreMessage
 }
 
+// EventProcessor passes events through processor plugins before they are dispatched.
+type EventProcessor interface {
+	Process(ctx context.Context, event source.Event, processCtx processor.ProcessInputContext) (source.Event, bool)
+}
+
+// OwnerResolver assigns owning teams to events about resources.
+type OwnerResolver interface {
+	Assign(ctx context.Context, event source.Event) source.Event
+}
+
+// EventEnricher fetches additional data for events from external HTTP endpoints.
+type EventEnricher interface {
+	Enrich(ctx context.Context, event source.Event, sourceName string) source.Event
+}
+
+// EventRedactor masks sensitive values in events before they are sent to bots and sinks.
+type EventRedactor interface {
+	RedactMessage(msg api.Message) api.Message
+	RedactValue(in any) any
+}
+
+// NotificationFilter evaluates filters bound to channels for source notifications before they are sent.
+type NotificationFilter interface {
+	Apply(in filter.Input, names []string) filter.Result
+}
+
+// IncidentTracker tracks notifications which can be acknowledged and escalated.
+type IncidentTracker interface {
+	Track(ctx context.Context, in escalation.Notification, send escalation.SendFunc) (api.Message, bool)
+}
+
+// MaintenanceChecker holds back notifications during maintenance windows.
+type MaintenanceChecker interface {
+	Apply(ctx context.Context, in maintenance.Notification, send maintenance.SendFunc) []string
+}
+
+// TicketFiler files tickets for recurring failures.
+type TicketFiler interface {
+	Handle(ctx context.Context, in ticketing.Notification)
+}
+
+// ChannelRouter selects channels for notifications based on the resources they are about.
+type ChannelRouter interface {
+	Route(in routing.Notification) ([]string, bool)
+}
+
+// ChannelNotifier sends notifications to channels according to their notification settings.
+type ChannelNotifier interface {
+	IsDefined() bool
+	Send(ctx context.Context, in notification.Notification, msg interactive.CoreMessage, channels []notification.Channel, send notification.SendFunc) error
+	Flush()
+}
+
+// AuditLogger records notification decisions in the audit log.
+type AuditLogger interface {
+	Record(e auditlog.Entry)
+}
+
+// NotificationBuffer persists notifications which couldn't be sent, so they are sent in order once the integration is reachable again.
+type NotificationBuffer interface {
+	Start(ctx context.Context, schedule func(target string))
+	Append(target string, payload []byte) (bool, error)
+	Len(target string) int
+	Replay(target string, send func(payload []byte) error) (int, error)
+}
+
+// DeliveryTracker tracks delivery latency and failures of notifications against the delivery objective.
+type DeliveryTracker interface {
+	Observe(platform config.CommPlatformIntegration, receivedAt time.Time, err error)
+}
+
 // AnalyticsReporter defines a reporter that collects analytics data.
 type AnalyticsReporter interface {
 	// ReportHandledEventSuccess reports a successfully handled event using a given integration type, communication platform, and plugin.
@@ -58,13 +198,45 @@ type AnalyticsReporter interface {
 	Close() error
 }
 
-// NewDispatcher create a new Dispatcher instance.
-func NewDispatcher(log logrus.FieldLogger, clusterName string, notifiers map[string]bot.Bot, sinkNotifiers []notifier.Sink, manager *plugin.Manager, actionProvider ActionProvider, reporter AnalyticsReporter, auditReporter audit.AuditReporter, restCfg *rest.Config) *Dispatcher {
+// DispatcherOptions contains input parameters for Dispatcher.
+type DispatcherOptions struct {
+	Log         logrus.FieldLogger
+	ClusterName string
+	Pipeline    config.EventPipeline
+	// Notifiers are communication platform bots, by their configuration keys.
+	Notifiers          map[string]bot.Bot
+	SinkNotifiers      []notifier.Sink
+	PluginManager      *plugin.Manager
+	ActionProvider     ActionProvider
+	Processors         EventProcessor
+	Owners             OwnerResolver
+	Enricher           EventEnricher
+	Redactor           EventRedactor
+	NotificationFilter NotificationFilter
+	Incidents          IncidentTracker
+	Maintenance        MaintenanceChecker
+	Tickets            TicketFiler
+	Router             ChannelRouter
+	ChannelNotifier    ChannelNotifier
+	Reporter           AnalyticsReporter
+	AuditReporter      audit.AuditReporter
+	// AuditLogger is optional. If not set, notification decisions are not recorded in the audit log.
+	AuditLogger AuditLogger
+	// Buffer is optional. If not set, notifications which couldn't be sent are dropped.
+	Buffer     NotificationBuffer
+	Deliveries DeliveryTracker
+	RestCfg    *rest.Config
+}
+
+// NewDispatcher create a new Dispatcher instance. Its worker pools are started with Start.
+func NewDispatcher(opts DispatcherOptions) (*Dispatcher, error) {
 	var (
 		interactiveNotifiers []notifier.Bot
 		markdownNotifiers    []notifier.Bot
+		botNames             = map[notifier.Bot]string{}
 	)
-	for _, n := range notifiers {
+	for name, n := range opts.Notifiers {
+		botNames[n] = name
 		if n.IntegrationName().IsInteractive() {
 			interactiveNotifiers = append(interactiveNotifiers, n)
 			continue
@@ -73,20 +245,114 @@ func NewDispatcher(log logrus.FieldLogger, clusterName string, notifiers map[str
 		markdownNotifiers = append(markdownNotifiers, n)
 	}
 
-	return &Dispatcher{
-		log:                  log,
-		manager:              manager,
-		actionProvider:       actionProvider,
-		reporter:             reporter,
-		auditReporter:        auditReporter,
+	d := &Dispatcher{
+		log:                  opts.Log,
+		manager:              opts.PluginManager,
+		actionProvider:       opts.ActionProvider,
+		processors:           opts.Processors,
+		owners:               opts.Owners,
+		enricher:             opts.Enricher,
+		redactor:             opts.Redactor,
+		filter:               opts.NotificationFilter,
+		incidents:            opts.Incidents,
+		maintenance:          opts.Maintenance,
+		tickets:              opts.Tickets,
+		router:               opts.Router,
+		notifications:        opts.ChannelNotifier,
+		reporter:             opts.Reporter,
+		auditReporter:        opts.AuditReporter,
+		auditLogger:          opts.AuditLogger,
+		buffer:               opts.Buffer,
+		deliveries:           opts.Deliveries,
 		interactiveNotifiers: interactiveNotifiers,
 		markdownNotifiers:    markdownNotifiers,
-		sinkNotifiers:        sinkNotifiers,
-		restCfg:              restCfg,
-		clusterName:          clusterName,
+		sinkNotifiers:        opts.SinkNotifiers,
+		botNames:             botNames,
+		restCfg:              opts.RestCfg,
+		clusterName:          opts.ClusterName,
+		bufferTargets:        map[string]func(ctx context.Context, delivery bufferedDelivery) error{},
+	}
+	for _, n := range opts.Notifiers {
+		n := n
+		d.bufferTargets[d.botKey(n)] = func(ctx context.Context, delivery bufferedDelivery) error {
+			return d.sendToBot(ctx, n, delivery)
+		}
+	}
+	for i, n := range opts.SinkNotifiers {
+		n := n
+		d.bufferTargets[sinkKey(i, n)] = func(ctx context.Context, delivery bufferedDelivery) error {
+			return d.sendToSink(ctx, n, delivery)
+		}
+	}
+	metrics.RegisterQueue("dispatcher_in_flight", func() int { return int(d.inFlight.Load()) })
+
+	var err error
+	d.ingestPool, err = workerpool.New(opts.Log, workerpool.Options[ingestJob]{
+		Stage:  ingestStage,
+		Config: opts.Pipeline.Ingest,
+		Handle: d.handleIngestJob,
+		OnDrop: func(job ingestJob) {
+			defer d.untrack()
+			metrics.EventDropped(job.dispatch.sourceName, metrics.OverflowDropReason)
+			d.recordNotification(job.dispatch, auditlog.DroppedOutcome, "event pipeline queue overflow", nil)
+		},
+		Codec: &workerpool.Codec[ingestJob]{
+			Encode: d.encodeIngestJob,
+			Decode: d.decodeIngestJob,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("while creating %s worker pool: %w", ingestStage, err)
+	}
+
+	d.sendPool, err = workerpool.New(opts.Log, workerpool.Options[func()]{
+		Stage:  sendStage,
+		Config: opts.Pipeline.Send,
+		Handle: func(_ context.Context, job func()) {
+			defer d.untrack()
+			job()
+		},
+		OnDrop: func(func()) {
+			d.untrack()
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("while creating %s worker pool: %w", sendStage, err)
+	}
+
+	return d, nil
+}
+
+// Start starts worker pools, and replaying buffered notifications if the buffer is enabled. They run until the context is done.
+func (d *Dispatcher) Start(ctx context.Context) {
+	d.ingestPool.Start(ctx)
+	d.sendPool.Start(ctx)
+	if d.buffer != nil {
+		go d.buffer.Start(ctx, func(target string) {
+			d.submitSend(target, func() {
+				d.replay(ctx, target)
+			})
+		})
 	}
 }
 
+// SetShard sets the shard handled by the replica. It must be called before any plugin is dispatched.
+// Given sharding-aware source plugins handle only namespaces of the shard, and other source plugins are started only by the first shard.
+func (d *Dispatcher) SetShard(shard sharding.Shard, shardedPlugins []string) error {
+	cfg, err := shard.PluginConfig()
+	if err != nil {
+		return err
+	}
+
+	d.shard = &shard
+	d.shardConfig = cfg
+	d.shardedPlugins = map[string]struct{}{}
+	for _, name := range shardedPlugins {
+		d.shardedPlugins[name] = struct{}{}
+	}
+	return nil
+}
+
 // Dispatch starts a given plugin, watches for incoming events and calling all notifiers to dispatch received event.
 func (d *Dispatcher) Dispatch(dispatch PluginDispatch) error {
 	log := d.log.WithFields(logrus.Fields{
@@ -94,6 +360,12 @@ func (d *Dispatcher) Dispatch(dispatch PluginDispatch) error {
 		"sourceName": dispatch.sourceName,
 	})
 
+	configs, shouldStart := d.pluginConfigs(dispatch)
+	if !shouldStart {
+		log.Info("Source plugin is not sharding-aware, so it is streamed by the first shard only. Skipping...")
+		return nil
+	}
+
 	log.Info("Start source streaming...")
 
 	sourceClient, err := d.manager.GetSource(dispatch.pluginName)
@@ -107,8 +379,9 @@ func (d *Dispatcher) Dispatch(dispatch PluginDispatch) error {
 	}
 
 	ctx := dispatch.ctx
+	d.dispatches.Store(dispatchID(dispatch), dispatch)
 	out, err := sourceClient.Stream(ctx, source.StreamInput{
-		Configs: []*source.Config{dispatch.pluginConfig},
+		Configs: configs,
 		Context: source.StreamInputContext{
 			CommonSourceContext: d.commonSourceCtxForDispatch(dispatch),
 			KubeConfig:          kubeconfig,
@@ -132,8 +405,17 @@ func (d *Dispatcher) Dispatch(dispatch PluginDispatch) error {
 					log.WithError(fmt.Errorf("stream for %s.%s source was closed", dispatch.sourceName, dispatch.pluginName)).Error("Stream closed")
 					return
 				}
-				log.WithField("message", msg).Debug("Dispatching received message...")
-				d.dispatchMsg(ctx, msg, dispatch)
+				log.WithField("message", msg).Debug("Queueing received message...")
+				// events from a given source are processed in order, as they share the key
+				done := d.track()
+				if !d.ingestPool.Submit(dispatchID(dispatch), ingestJob{event: msg, dispatch: dispatch, receivedAt: time.Now()}) {
+					done()
+					return
+				}
+				if out.Ack != nil {
+					// the source sends more events only once the queued ones are acknowledged, so the block overflow policy applies back pressure
+					out.Ack()
+				}
 			case <-ctx.Done():
 				return
 			}
@@ -151,23 +433,142 @@ func (d *Dispatcher) DispatchExternalRequest(dispatch ExternalRequestDispatch) e
 
 	d.log.Infof("Dispatching external request for %s", dispatch.pluginName)
 
-	ctx := dispatch.ctx
+	ctx, span := tracing.Start(dispatch.ctx, "source.HandleExternalRequest",
+		tracing.SourceNameKey.String(dispatch.sourceName),
+		tracing.PluginNameKey.String(dispatch.pluginName),
+	)
 	out, err := sourceClient.HandleExternalRequest(ctx, source.ExternalRequestInput{
 		Config:  dispatch.pluginConfig,
 		Payload: dispatch.payload,
 		Context: source.ExternalRequestInputContext{
 			CommonSourceContext: d.commonSourceCtxForDispatch(dispatch.PluginDispatch),
+			HTTP:                dispatch.http,
 		},
 	})
 	if err != nil {
-		return fmt.Errorf(`while handling external request for "%s.%s" source: %w`, dispatch.sourceName, dispatch.pluginName, err)
+		err = fmt.Errorf(`while handling external request for "%s.%s" source: %w`, dispatch.sourceName, dispatch.pluginName, err)
+		tracing.End(span, err)
+		return err
 	}
 
-	d.dispatchMsg(ctx, out.Event, dispatch.PluginDispatch)
+	d.dispatchMsg(ctx, out.Event, dispatch.PluginDispatch, time.Now())
+	span.End()
 
 	return nil
 }
 
+// ExternalRequestRoutes returns incoming webhook routes registered by a given source plugin.
+func (d *Dispatcher) ExternalRequestRoutes(ctx context.Context, pluginName string) ([]api.ExternalRequestRoute, error) {
+	sourceClient, err := d.manager.GetSource(pluginName)
+	if err != nil {
+		return nil, fmt.Errorf("while getting source client for %s: %w", pluginName, err)
+	}
+
+	meta, err := sourceClient.Metadata(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("while getting metadata for %s: %w", pluginName, err)
+	}
+	return meta.ExternalRequest.Routes, nil
+}
+
+// Drain sends aggregated notifications, waits until all in-flight and queued messages and notifications are processed, or the context is done,
+// and writes events batched by sinks.
+// It's used to reload the configuration or shut down without dropping events which are already being dispatched.
+func (d *Dispatcher) Drain(ctx context.Context) error {
+	d.notifications.Flush()
+
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
+
+	for d.inFlight.Load() > 0 {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("while waiting for %d in-flight dispatch(es): %w", d.inFlight.Load(), ctx.Err())
+		case <-ticker.C:
+		}
+	}
+
+	errs := multierror.New()
+	for _, n := range d.sinkNotifiers {
+		flusher, ok := n.(notifier.Flusher)
+		if !ok {
+			continue
+		}
+		if err := flusher.Flush(ctx); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("while flushing %s sink: %w", n.IntegrationName(), err))
+		}
+	}
+	return errs.ErrorOrNil()
+}
+
+// pluginConfigs returns configuration of a given dispatch, and whether it should be started by the replica.
+func (d *Dispatcher) pluginConfigs(dispatch PluginDispatch) ([]*source.Config, bool) {
+	configs := []*source.Config{dispatch.pluginConfig}
+	if d.shard == nil {
+		return configs, true
+	}
+	// the plugin version is not taken into account
+	name, _, _ := strings.Cut(dispatch.pluginName, "@")
+	if _, ok := d.shardedPlugins[name]; ok {
+		return append(configs, d.shardConfig), true
+	}
+	return configs, d.shard.IsFirst()
+}
+
+// track marks work as in-flight until the returned function is called.
+func (d *Dispatcher) track() func() {
+	d.inFlight.Add(1)
+	return d.untrack
+}
+
+func (d *Dispatcher) untrack() {
+	d.inFlight.Add(-1)
+}
+
+// submitSend queues sending a message by a given notifier. Messages sent by the same notifier are sent in order.
+func (d *Dispatcher) submitSend(key string, job func()) {
+	done := d.track()
+	if !d.sendPool.Submit(key, job) {
+		done()
+	}
+}
+
+// handleIngestJob dispatches a queued event. The worker pool context is used instead of the source one,
+// so events received before the source is stopped are still dispatched, e.g. during the graceful shutdown.
+func (d *Dispatcher) handleIngestJob(ctx context.Context, job ingestJob) {
+	defer d.untrack()
+	d.dispatchMsg(ctx, job.event, job.dispatch, job.receivedAt)
+}
+
+func (d *Dispatcher) encodeIngestJob(job ingestJob) ([]byte, error) {
+	return json.Marshal(spilledIngestJob{Event: job.event, DispatchID: dispatchID(job.dispatch), ReceivedAt: job.receivedAt})
+}
+
+func (d *Dispatcher) decodeIngestJob(data []byte) (ingestJob, error) {
+	var spilled spilledIngestJob
+	if err := json.Unmarshal(data, &spilled); err != nil {
+		return ingestJob{}, fmt.Errorf("while unmarshaling job: %w", err)
+	}
+	dispatch, ok := d.dispatches.Load(spilled.DispatchID)
+	if !ok {
+		return ingestJob{}, fmt.Errorf("dispatch %q not found", spilled.DispatchID)
+	}
+	return ingestJob{event: spilled.Event, dispatch: dispatch.(PluginDispatch), receivedAt: spilled.ReceivedAt}, nil
+}
+
+// dispatchID identifies a given dispatch. The same source may be dispatched both with and without interactivity support.
+func dispatchID(dispatch PluginDispatch) string {
+	return fmt.Sprintf("%s/%s/%t", dispatch.sourceName, dispatch.pluginName, dispatch.isInteractivitySupported)
+}
+
+func (d *Dispatcher) botKey(n notifier.Bot) string {
+	return fmt.Sprintf("bot:%s:%s", d.botNames[n], n.IntegrationName())
+}
+
+func sinkKey(idx int, n notifier.Sink) string {
+	return fmt.Sprintf("sink:%d:%s", idx, n.IntegrationName())
+}
+
 func (d *Dispatcher) getBotNotifiers(dispatch PluginDispatch) []notifier.Bot {
 	if dispatch.isInteractivitySupported {
 		return d.interactiveNotifiers
@@ -182,40 +583,240 @@ func (d *Dispatcher) getSinkNotifiers(dispatch PluginDispatch) []notifier.Sink {
 	return d.sinkNotifiers
 }
 
-func (d *Dispatcher) dispatchMsg(ctx context.Context, event source.Event, dispatch PluginDispatch) {
+func (d *Dispatcher) dispatchMsg(ctx context.Context, event source.Event, dispatch PluginDispatch, receivedAt time.Time) {
+	defer d.track()()
+
 	var (
 		pluginName = dispatch.pluginName
 		sources    = []string{dispatch.sourceName}
 	)
 
+	// notifications and action results are sent asynchronously, so their spans may end after the root one
+	ctx, span := tracing.Start(ctx, "source.Dispatch",
+		tracing.SourceNameKey.String(dispatch.sourceName),
+		tracing.PluginNameKey.String(pluginName),
+		tracing.ClusterNameKey.String(d.clusterName),
+	)
+	defer span.End()
+	metrics.EventReceived(dispatch.sourceName)
+
+	processCtx, processSpan := tracing.Start(ctx, "source.Process")
+	event, ok := d.processors.Process(processCtx, event, processor.ProcessInputContext{
+		SourceName:        dispatch.sourceName,
+		SourceDisplayName: dispatch.sourceDisplayName,
+		PluginName:        pluginName,
+		ClusterName:       d.clusterName,
+	})
+	processSpan.SetAttributes(tracing.DroppedKey.Bool(!ok))
+	processSpan.End()
+	if !ok {
+		d.log.WithField("sourceName", dispatch.sourceName).Debug("Event vetoed by processor plugin")
+		metrics.EventDropped(dispatch.sourceName, metrics.ProcessorDropReason)
+		d.recordNotification(dispatch, auditlog.DroppedOutcome, "vetoed by processor plugin", nil)
+		return
+	}
+
+	// owners are resolved first, so they are available in enrichment templates
+	enrichCtx, enrichSpan := tracing.Start(ctx, "source.Enrich")
+	event = d.owners.Assign(enrichCtx, event)
+	event = d.enricher.Enrich(enrichCtx, event, dispatch.sourceName)
+	enrichSpan.End()
+
+	// redact sensitive values before notifications are sent, so none of notifications, tickets or audit events reveal them
+	event.Message = d.redactor.RedactMessage(event.Message)
+	event.RawObject = d.redactor.RedactValue(event.RawObject)
+
+	in := filter.Input{
+		SourceName:        dispatch.sourceName,
+		SourceDisplayName: dispatch.sourceDisplayName,
+		PluginName:        pluginName,
+		ClusterName:       d.clusterName,
+		Event:             event.RawObject,
+		Message:           event.Message,
+	}
+	d.notify(ctx, event, in, dispatch, receivedAt)
+
+	// ticket policies have their own conditions, so they are evaluated also for notifications held back or suppressed, e.g. to detect recovery
+	d.tickets.Handle(ctx, ticketing.Notification{
+		Input:       in,
+		Interactive: dispatch.isInteractivitySupported,
+	})
+
+	if err := d.reportAuditEvent(ctx, pluginName, event.RawObject, dispatch.sourceName, dispatch.sourceDisplayName); err != nil {
+		d.log.Errorf("while reporting audit event for source %q: %s", dispatch.sourceName, err.Error())
+	}
+
+	// execute actions
+	_, renderSpan := tracing.Start(ctx, "action.Render")
+	actions, err := d.actionProvider.RenderedActions(event, sources)
+	tracing.End(renderSpan, err)
+	if err != nil {
+		d.log.Errorf("while rendering automated actions: %s", err.Error())
+		return
+	}
+	for _, act := range actions {
+		d.executeAction(ctx, act, sources, dispatch)
+	}
+}
+
+// executeAction executes a given automated action, and sends its result to bot and sink notifiers.
+func (d *Dispatcher) executeAction(ctx context.Context, act action.Action, sources []string, dispatch PluginDispatch) {
+	ctx, span := tracing.Start(ctx, "action.Execute", tracing.ActionNameKey.String(act.DisplayName))
+	defer span.End()
+
+	log := d.log.WithFields(logrus.Fields{
+		"name":    act.DisplayName,
+		"command": act.Command,
+	})
+	log.Infof("Executing automated action...")
+	genericMsg := d.actionProvider.ExecuteAction(ctx, act)
+	log.WithField("message", fmt.Sprintf("%+v", genericMsg)).Debug("Automated action executed. Printing output message...")
+
 	for _, n := range d.getBotNotifiers(dispatch) {
-		go func(n notifier.Bot) {
+		n := n
+		d.submitSend(d.botKey(n), func() {
 			defer analytics.ReportPanicIfOccurs(d.log, d.reporter)
-			msg := interactive.CoreMessage{
-				Message: event.Message,
+			ctx, span := startSendSpan(ctx, n)
+			err := n.SendMessage(ctx, genericMsg, sources)
+			tracing.End(span, err)
+			if err != nil {
+				d.log.Errorf("while sending action result to %q bot: %s", n.IntegrationName(), err.Error())
 			}
-			err := n.SendMessage(ctx, msg, sources)
+		})
+	}
+
+	for i, n := range d.getSinkNotifiers(dispatch) {
+		n := n
+		d.submitSend(sinkKey(i, n), func() {
+			ctx, span := startSendSpan(ctx, n)
+			start := time.Now()
+			err := n.SendEvent(ctx, genericMsg, sources)
+			metrics.ObserveMessageSend(n.IntegrationName(), "", start, &err)
+			tracing.End(span, err)
 			if err != nil {
-				reportErr := d.reportError(err, n, pluginName, event)
-				if reportErr != nil {
-					err = multierror.Append(err, fmt.Errorf("while reporting error: %w", reportErr))
+				d.log.Errorf("while sending action result to %q sink: %s", n.IntegrationName(), err.Error())
+			}
+		})
+	}
+}
+
+// startSendSpan starts a span of rendering and sending a message by a given notifier.
+func startSendSpan(ctx context.Context, n genericNotifier) (context.Context, trace.Span) {
+	return tracing.Start(ctx, "notifier.Send",
+		tracing.PlatformKey.String(string(n.IntegrationName())),
+		tracing.IntegrationTypeKey.String(string(n.Type())),
+	)
+}
+
+// notify sends a given event to bot and sink notifiers. Delivery of notifications sent right away is tracked from a given time the event was received at.
+func (d *Dispatcher) notify(ctx context.Context, event source.Event, in filter.Input, dispatch PluginDispatch, receivedAt time.Time) {
+	channels, routed := d.router.Route(routing.Notification{
+		SourceName: dispatch.sourceName,
+		Object:     event.ActionContext.Object,
+		Owner:      ownerTeam(event),
+	})
+	if len(event.Channels) > 0 {
+		// channels set by the source, e.g. with a resource annotation, take precedence over routing rules
+		channels, routed = event.Channels, true
+	}
+	sendFn := func(ctx context.Context, msg interactive.CoreMessage, sources []string) {
+		var target []string
+		// messages sent to different source bindings, e.g. escalations, are not routed
+		if routed && slices.Contains(sources, dispatch.sourceName) {
+			target = channels
+		}
+		d.send(ctx, event, in, msg, sources, target, dispatch)
+	}
+
+	sources := d.maintenance.Apply(ctx, maintenance.Notification{
+		Input:       in,
+		Object:      event.ActionContext.Object,
+		Sources:     []string{dispatch.sourceName},
+		Interactive: dispatch.isInteractivitySupported,
+	}, sendFn)
+	if len(sources) == 0 {
+		d.log.WithField("sourceName", dispatch.sourceName).Debug("Notification held back during maintenance window")
+		metrics.EventDropped(dispatch.sourceName, metrics.MaintenanceDropReason)
+		d.recordNotification(dispatch, auditlog.HeldBackOutcome, "maintenance window", nil)
+		return
+	}
+
+	msg, ok := d.incidents.Track(ctx, escalation.Notification{
+		Input:       in,
+		Sources:     sources,
+		Interactive: dispatch.isInteractivitySupported,
+	}, sendFn)
+	if !ok {
+		d.log.WithField("sourceName", dispatch.sourceName).Debug("Notification suppressed, as the incident is acknowledged")
+		metrics.EventDeduplicated(dispatch.sourceName)
+		d.recordNotification(dispatch, auditlog.SuppressedOutcome, "incident acknowledged", nil)
+		return
+	}
+
+	// escalations and digests are sent later on purpose, so only the immediate notification is tracked
+	sendFn(withReceivedAt(ctx, receivedAt), interactive.CoreMessage{Message: msg}, sources)
+}
+
+// send sends a given message to bot notifiers, and the raw event to sink notifiers, bound to given source bindings.
+// If channels are set, bots which support it send the message to them instead of channels bound to source bindings.
+// Bots which list channels apply filters bound to them, see filterChannels.
+// If notification settings are defined, bots which support it send the message to each channel according to its settings.
+func (d *Dispatcher) send(ctx context.Context, event source.Event, in filter.Input, msg interactive.CoreMessage, sources, channels []string, dispatch PluginDispatch) {
+	pluginName := dispatch.pluginName
+	details := map[string]string{"sourceBindings": strings.Join(sources, ",")}
+	if channels != nil {
+		details["channels"] = strings.Join(channels, ",")
+	}
+	d.recordNotification(dispatch, auditlog.SentOutcome, "", details)
+
+	receivedAt := receivedAtFrom(ctx)
+	botDelivery := bufferedDelivery{Input: in, Message: msg, Sources: sources, Channels: channels, ReceivedAt: receivedAt}
+	for _, n := range d.getBotNotifiers(dispatch) {
+		n := n
+		var targets []botTarget
+		for _, delivery := range d.filterChannels(n, botDelivery).deliveries {
+			targets = append(targets, d.botTargets(n, delivery)...)
+		}
+		for _, target := range targets {
+			target := target
+			d.submitSend(target.key, func() {
+				defer analytics.ReportPanicIfOccurs(d.log, d.reporter)
+				sent, err := d.deliver(target.key, target.delivery, func() error {
+					return d.sendToBot(ctx, n, target.delivery)
+				})
+				if !sent {
+					return
 				}
+				if err != nil {
+					reportErr := d.reportError(err, n, pluginName, event)
+					if reportErr != nil {
+						err = multierror.Append(err, fmt.Errorf("while reporting error: %w", reportErr))
+					}
 
-				d.log.Errorf("while sending bot message: %s", err.Error())
-				return
-			}
+					d.log.Errorf("while sending bot message: %s", err.Error())
+					return
+				}
 
-			reportErr := d.reportSuccess(n, pluginName, event)
-			if reportErr != nil {
-				d.log.Error(err)
-			}
-		}(n)
+				reportErr := d.reportSuccess(n, pluginName, event)
+				if reportErr != nil {
+					d.log.Error(err)
+				}
+			})
+		}
 	}
 
-	for _, n := range d.getSinkNotifiers(dispatch) {
-		go func(n notifier.Sink) {
+	sinkDelivery := bufferedDelivery{Event: event.RawObject, Sources: sources, ReceivedAt: receivedAt}
+	for i, n := range d.getSinkNotifiers(dispatch) {
+		n := n
+		key := sinkKey(i, n)
+		d.submitSend(key, func() {
 			defer analytics.ReportPanicIfOccurs(d.log, d.reporter)
-			err := n.SendEvent(ctx, event.RawObject, sources)
+			sent, err := d.deliver(key, sinkDelivery, func() error {
+				return d.sendToSink(ctx, n, sinkDelivery)
+			})
+			if !sent {
+				return
+			}
 			if err != nil {
 				reportErr := d.reportError(err, n, pluginName, event)
 				if reportErr != nil {
@@ -230,47 +831,47 @@ func (d *Dispatcher) dispatchMsg(ctx context.Context, event source.Event, dispat
 			if reportErr != nil {
 				d.log.Error(err)
 			}
-		}(n)
-	}
-
-	if err := d.reportAuditEvent(ctx, pluginName, event.RawObject, dispatch.sourceName, dispatch.sourceDisplayName); err != nil {
-		d.log.Errorf("while reporting audit event for source %q: %s", dispatch.sourceName, err.Error())
+		})
 	}
+}
 
-	// execute actions
-	actions, err := d.actionProvider.RenderedActions(event.RawObject, sources)
-	if err != nil {
-		d.log.Errorf("while rendering automated actions: %s", err.Error())
-		return
+// sendToBot sends a given message by a bot notifier.
+func (d *Dispatcher) sendToBot(ctx context.Context, n notifier.Bot, delivery bufferedDelivery) error {
+	ctx, span := startSendSpan(ctx, n)
+	var err error
+	if lister, ok := n.(notifier.ChannelLister); ok && d.notifications.IsDefined() {
+		err = d.sendWithSettings(ctx, lister, notification.Notification{Input: delivery.Input, Recipient: d.botNames[n]}, delivery.Message, delivery.Sources, delivery.Channels)
+	} else if sender, ok := n.(notifier.ChannelSender); ok && delivery.Channels != nil {
+		err = sender.SendMessageToChannels(ctx, delivery.Message, delivery.Channels)
+	} else {
+		err = n.SendMessage(ctx, delivery.Message, delivery.Sources)
 	}
-	for _, act := range actions {
-		log := d.log.WithFields(logrus.Fields{
-			"name":    act.DisplayName,
-			"command": act.Command,
-		})
-		log.Infof("Executing automated action...")
-		genericMsg := d.actionProvider.ExecuteAction(ctx, act)
-		log.WithField("message", fmt.Sprintf("%+v", genericMsg)).Debug("Automated action executed. Printing output message...")
+	tracing.End(span, err)
+	d.deliveries.Observe(n.IntegrationName(), delivery.ReceivedAt, err)
+	return err
+}
 
-		for _, n := range d.getBotNotifiers(dispatch) {
-			go func(n notifier.Bot) {
-				defer analytics.ReportPanicIfOccurs(d.log, d.reporter)
-				err := n.SendMessage(ctx, genericMsg, sources)
-				if err != nil {
-					d.log.Errorf("while sending action result to %q bot: %s", n.IntegrationName(), err.Error())
-				}
-			}(n)
-		}
+// sendToSink sends a given raw event by a sink notifier.
+func (d *Dispatcher) sendToSink(ctx context.Context, n notifier.Sink, delivery bufferedDelivery) error {
+	ctx, span := startSendSpan(ctx, n)
+	start := time.Now()
+	err := n.SendEvent(ctx, delivery.Event, delivery.Sources)
+	metrics.ObserveMessageSend(n.IntegrationName(), "", start, &err)
+	tracing.End(span, err)
+	d.deliveries.Observe(n.IntegrationName(), delivery.ReceivedAt, err)
+	return err
+}
 
-		for _, n := range d.getSinkNotifiers(dispatch) {
-			go func(n notifier.Sink) {
-				err := n.SendEvent(ctx, genericMsg, sources)
-				if err != nil {
-					d.log.Errorf("while sending action result to %q sink: %s", n.IntegrationName(), err.Error())
-				}
-			}(n)
+// sendWithSettings sends a given message to channels selected by source bindings, or given channels if set, according to their notification settings.
+func (d *Dispatcher) sendWithSettings(ctx context.Context, lister notifier.ChannelLister, in notification.Notification, msg interactive.CoreMessage, sources, channels []string) error {
+	var selected []notification.Channel
+	for _, ch := range lister.NotificationChannels() {
+		if !isChannelSelected(ch, sources, channels) {
+			continue
 		}
+		selected = append(selected, notification.Channel{Name: ch.Name, Alias: ch.Alias, Settings: ch.Settings})
 	}
+	return d.notifications.Send(ctx, in, msg, selected, lister.SendMessageToChannels)
 }
 
 func (d *Dispatcher) reportAuditEvent(ctx context.Context, pluginName string, event any, sourceName, sourceDisplayName string) error {
@@ -291,6 +892,21 @@ func (d *Dispatcher) reportAuditEvent(ctx context.Context, pluginName string, ev
 	return d.auditReporter.ReportSourceAuditEvent(ctx, e)
 }
 
+// recordNotification records a decision whether a notification from a given dispatch is sent.
+func (d *Dispatcher) recordNotification(dispatch PluginDispatch, outcome auditlog.Outcome, reason string, details map[string]string) {
+	if d.auditLogger == nil {
+		return
+	}
+	d.auditLogger.Record(auditlog.Entry{
+		Type:    auditlog.NotificationEntryType,
+		Outcome: outcome,
+		Reason:  reason,
+		Plugin:  dispatch.pluginName,
+		Source:  dispatch.sourceName,
+		Details: details,
+	})
+}
+
 type genericNotifier interface {
 	IntegrationName() config.CommPlatformIntegration
 	Type() config.IntegrationType
@@ -336,3 +952,24 @@ func (d *Dispatcher) commonSourceCtxForDispatch(in PluginDispatch) source.Common
 		},
 	}
 }
+
+// ownerTeam returns the team which owns the resource a given event is about, or an empty string if it's unknown.
+func ownerTeam(event source.Event) string {
+	if event.ActionContext.Owner == nil {
+		return ""
+	}
+	return event.ActionContext.Owner.Team
+}
+
+type receivedAtKey struct{}
+
+// withReceivedAt returns a context of sending a notification about an event received at a given time.
+func withReceivedAt(ctx context.Context, receivedAt time.Time) context.Context {
+	return context.WithValue(ctx, receivedAtKey{}, receivedAt)
+}
+
+// receivedAtFrom returns the time the event was received at. It's zero for notifications which are not tracked against the delivery objective.
+func receivedAtFrom(ctx context.Context) time.Time {
+	receivedAt, _ := ctx.Value(receivedAtKey{}).(time.Time)
+	return receivedAt
+}
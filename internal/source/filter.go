@@ -0,0 +1,117 @@
+package source
+
+import (
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/utils/strings/slices"
+
+	"github.com/kubeshop/botkube/pkg/notifier"
+	"github.com/kubeshop/botkube/pkg/sliceutil"
+)
+
+// channelFilters holds the outcome of filters bound to channels of a single bot.
+type channelFilters struct {
+	// deliveries are notifications to send.
+	deliveries []bufferedDelivery
+	// matched are names of filters which matched the notification.
+	matched []string
+	// dropped are names of channels for which the notification was dropped.
+	dropped []string
+}
+
+// filterChannels evaluates filters bound to channels of a given bot which receive a given notification.
+// Channels with the same filters share the outcome, so it returns a delivery with explicit channels for each group of them:
+// channels for which the notification is dropped are skipped, rerouted notifications are sent to channels of the same bot bound to
+// the target source bindings, and modified notifications have the changed message. Every channel receives the notification at most once.
+// Bots which don't list channels, and notifications which are not sent to channels with filters, are returned unchanged.
+func (d *Dispatcher) filterChannels(n notifier.Bot, delivery bufferedDelivery) channelFilters {
+	unchanged := channelFilters{deliveries: []bufferedDelivery{delivery}}
+	lister, ok := n.(notifier.ChannelLister)
+	if !ok || d.filter == nil {
+		return unchanged
+	}
+
+	all := lister.NotificationChannels()
+	type group struct {
+		filters  []string
+		channels []string
+	}
+	var (
+		groups    []*group
+		byFilters = map[string]*group{}
+		filtered  bool
+	)
+	for _, ch := range all {
+		if !isChannelSelected(ch, delivery.Sources, delivery.Channels) {
+			continue
+		}
+		key := strings.Join(ch.Filters, ",")
+		g, found := byFilters[key]
+		if !found {
+			g = &group{filters: ch.Filters}
+			byFilters[key] = g
+			groups = append(groups, g)
+		}
+		g.channels = append(g.channels, ch.Name)
+		filtered = filtered || len(ch.Filters) > 0
+	}
+	if !filtered {
+		return unchanged
+	}
+
+	in := delivery.Input
+	in.Message = delivery.Message.Message
+
+	var out channelFilters
+	sent := map[string]struct{}{}
+	for _, g := range groups {
+		next, channels := delivery, g.channels
+		if len(g.filters) > 0 {
+			res := d.filter.Apply(in, g.filters)
+			out.matched = append(out.matched, res.Matched...)
+			if res.Drop {
+				d.log.WithFields(logrus.Fields{
+					"sourceName": in.SourceName,
+					"channels":   g.channels,
+				}).Debug("Notification dropped by filter")
+				out.dropped = append(out.dropped, g.channels...)
+				continue
+			}
+			next.Input.Message, next.Message.Message = res.Message, res.Message
+			if res.Header != "" {
+				next.Message.Header = res.Header
+			}
+			if len(res.RouteTo) > 0 {
+				channels = nil
+				for _, ch := range all {
+					if sliceutil.Intersect(res.RouteTo, ch.Sources) {
+						channels = append(channels, ch.Name)
+					}
+				}
+			}
+		}
+
+		next.Channels = nil
+		for _, name := range channels {
+			if _, found := sent[name]; found {
+				continue
+			}
+			sent[name] = struct{}{}
+			next.Channels = append(next.Channels, name)
+		}
+		if len(next.Channels) == 0 {
+			continue
+		}
+		out.deliveries = append(out.deliveries, next)
+	}
+	return out
+}
+
+// isChannelSelected returns true if a given channel receives a notification sent to given source bindings, or given channels if set.
+func isChannelSelected(ch notifier.NotificationChannel, sources, channels []string) bool {
+	if channels != nil {
+		return slices.Contains(channels, ch.Alias) || slices.Contains(channels, ch.Name)
+	}
+	return sliceutil.Intersect(sources, ch.Sources)
+}
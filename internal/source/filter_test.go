@@ -0,0 +1,115 @@
+package source
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/internal/filter"
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+	"github.com/kubeshop/botkube/pkg/notifier"
+)
+
+func TestDispatcherFilterChannels(t *testing.T) {
+	// given
+	log := loggerx.NewNoop()
+	filterEngine, err := filter.NewEngine(log, config.Filters{
+		"drop-kube-system": {
+			Enabled:    true,
+			Expression: `event.Namespace == "kube-system"`,
+			Action:     config.DropFilterAction,
+		},
+		"label-prod": {
+			Enabled:    true,
+			Expression: `event.Namespace.startsWith("prod")`,
+			Action:     config.ModifyFilterAction,
+			Modify:     config.FilterModification{Header: "Production issue"},
+		},
+		"reroute-errors": {
+			Enabled:    true,
+			Expression: `event.Type == "error"`,
+			Action:     config.RerouteFilterAction,
+			RouteTo:    []string{"critical"},
+		},
+	})
+	require.NoError(t, err)
+
+	bot := &fakeListerBot{integration: config.SocketSlackCommPlatformIntegration, channels: []notifier.NotificationChannel{
+		{Name: "C1", Alias: "general", Sources: []string{"k8s-events"}, Filters: []string{"drop-kube-system", "label-prod"}},
+		{Name: "C2", Alias: "team", Sources: []string{"k8s-events"}, Filters: []string{"drop-kube-system", "label-prod"}},
+		{Name: "C3", Alias: "errors", Sources: []string{"k8s-events"}, Filters: []string{"reroute-errors"}},
+		{Name: "C4", Alias: "oncall", Sources: []string{"critical"}},
+		{Name: "C5", Alias: "audit", Sources: []string{"k8s-events"}},
+	}}
+	d := &Dispatcher{log: log, filter: filterEngine}
+
+	msg := api.Message{Sections: []api.Section{{Base: api.Base{Header: "Pod error"}}}}
+	delivery := func(ns, eventType string) bufferedDelivery {
+		return bufferedDelivery{
+			Input: filter.Input{
+				SourceName: "k8s-events",
+				Event:      map[string]any{"Namespace": ns, "Type": eventType},
+				Message:    msg,
+			},
+			Message: interactive.CoreMessage{Message: msg},
+			Sources: []string{"k8s-events"},
+		}
+	}
+
+	t.Run("modified and rerouted", func(t *testing.T) {
+		// when
+		out := d.filterChannels(bot, delivery("prod", "error"))
+
+		// then
+		require.Len(t, out.deliveries, 3)
+		assert.Equal(t, []string{"C1", "C2"}, out.deliveries[0].Channels)
+		assert.Equal(t, "Production issue", out.deliveries[0].Message.Sections[0].Header)
+		assert.Equal(t, "Production issue", out.deliveries[0].Input.Message.Sections[0].Header)
+		assert.Equal(t, []string{"C4"}, out.deliveries[1].Channels)
+		assert.Equal(t, "Pod error", out.deliveries[1].Message.Sections[0].Header)
+		assert.Equal(t, []string{"C5"}, out.deliveries[2].Channels)
+		assert.Equal(t, []string{"label-prod", "reroute-errors"}, out.matched)
+		assert.Empty(t, out.dropped)
+	})
+
+	t.Run("dropped", func(t *testing.T) {
+		// when
+		out := d.filterChannels(bot, delivery("kube-system", "normal"))
+
+		// then
+		require.Len(t, out.deliveries, 2)
+		assert.Equal(t, []string{"C3"}, out.deliveries[0].Channels)
+		assert.Equal(t, []string{"C5"}, out.deliveries[1].Channels)
+		assert.Equal(t, []string{"drop-kube-system"}, out.matched)
+		assert.Equal(t, []string{"C1", "C2"}, out.dropped)
+	})
+
+	t.Run("rerouted to a channel which already receives it", func(t *testing.T) {
+		// given
+		in := delivery("dev", "error")
+		in.Channels = []string{"errors", "oncall"}
+
+		// when
+		out := d.filterChannels(bot, in)
+
+		// then
+		require.Len(t, out.deliveries, 1)
+		assert.Equal(t, []string{"C4"}, out.deliveries[0].Channels)
+	})
+
+	t.Run("channels without filters", func(t *testing.T) {
+		// given
+		in := delivery("kube-system", "error")
+		in.Channels = []string{"audit"}
+
+		// when
+		out := d.filterChannels(bot, in)
+
+		// then
+		assert.Equal(t, []bufferedDelivery{in}, out.deliveries)
+	})
+}
@@ -3,13 +3,22 @@ package source
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/time/rate"
 
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/api/source"
 	"github.com/kubeshop/botkube/pkg/config"
 	"github.com/kubeshop/botkube/pkg/httpx"
 	"github.com/kubeshop/botkube/pkg/multierror"
@@ -29,87 +38,264 @@ func (w IncomingWebhookData) FullURLForSource(sourceName string) string {
 	return fmt.Sprintf("%s/%s/%s", w.inClusterBaseURL, incomingWebhookPathPrefix, sourceName)
 }
 
+// maxIncomingWebhookBodySize is the maximum size of the incoming webhook request body.
+const maxIncomingWebhookBodySize = 25 << 20 // 25MB, the GitHub payload limit
+
+// externalRequestDispatcher dispatches incoming webhook requests to source plugins.
+type externalRequestDispatcher interface {
+	DispatchExternalRequest(dispatch ExternalRequestDispatch) error
+	ExternalRequestRoutes(ctx context.Context, pluginName string) ([]api.ExternalRequestRoute, error)
+}
+
 // NewIncomingWebhookServer creates a new HTTP server for incoming webhooks.
 func NewIncomingWebhookServer(log logrus.FieldLogger, cfg *config.Config, dispatcher *Dispatcher, startedSources map[string]StartedSources) *httpx.Server {
 	addr := fmt.Sprintf(":%d", cfg.Plugins.IncomingWebhook.Port)
 	router := incomingWebhookRouter(log, cfg, dispatcher, startedSources)
 
 	log.Infof("Starting server on %q...", addr)
-	return httpx.NewServer(log, addr, router)
+	return httpx.NewServer(log, addr, otelhttp.NewHandler(router, "source.IncomingWebhook"))
 }
 
-func incomingWebhookRouter(log logrus.FieldLogger, cfg *config.Config, dispatcher *Dispatcher, startedSources map[string]StartedSources) *mux.Router {
+func incomingWebhookRouter(log logrus.FieldLogger, cfg *config.Config, dispatcher externalRequestDispatcher, startedSources map[string]StartedSources) *mux.Router {
 	router := mux.NewRouter()
 	pathPrefix := fmt.Sprintf("/%s/", incomingWebhookPathPrefix)
-	router.PathPrefix(pathPrefix).Methods(http.MethodPost).Handler(
+	router.PathPrefix(pathPrefix).Handler(
 		http.StripPrefix(
 			pathPrefix,
-			http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
-				if request == nil || request.URL == nil || request.URL.Path == "" {
-					writeJSONError(log, writer, "Source name in path is required", http.StatusBadRequest)
-					return
-				}
-
-				sourceName := request.URL.Path
-				logger := log.WithFields(logrus.Fields{
-					"sourceName": sourceName,
-				})
-				logger.Debugf("Handling incoming webhook request...")
-
-				sourcePlugins, ok := startedSources[sourceName]
-				if !ok || len(sourcePlugins) == 0 {
-					writeJSONError(log, writer, fmt.Sprintf("source %q not found", sourceName), http.StatusNotFound)
-					return
-				}
-
-				payload, err := io.ReadAll(request.Body)
-				if err != nil {
-					writeJSONError(log, writer, fmt.Sprintf("while reading request body: %s", err.Error()), http.StatusInternalServerError)
-					return
-				}
-				defer request.Body.Close()
-
-				multiErr := multierror.New()
-				for _, src := range sourcePlugins {
-					logger.WithFields(logrus.Fields{
-						"pluginName":               src.PluginName,
-						"isInteractivitySupported": src.IsInteractivitySupported,
-					}).Debug("Dispatching message...")
-
-					err := dispatcher.DispatchExternalRequest(ExternalRequestDispatch{
-						PluginDispatch: PluginDispatch{
-							ctx:                      context.Background(),
-							sourceName:               sourceName,
-							sourceDisplayName:        src.SourceDisplayName,
-							pluginName:               src.PluginName,
-							pluginConfig:             src.PluginConfig,
-							isInteractivitySupported: src.IsInteractivitySupported,
-							cfg:                      cfg,
-							pluginContext:            config.PluginContext{},
-							incomingWebhook: IncomingWebhookData{
-								inClusterBaseURL: cfg.Plugins.IncomingWebhook.InClusterBaseURL,
-							},
-						},
-						payload: payload,
-					})
-					if err != nil {
-						multiErr = multierror.Append(multiErr, err)
-					}
-				}
-
-				if multiErr.ErrorOrNil() != nil {
-					wrappedErr := fmt.Errorf("while dispatching external request: %w", multiErr)
-					writeJSONError(log, writer, wrappedErr.Error(), http.StatusInternalServerError)
-					return
-				}
-
-				writeJSONSuccess(log, writer)
-			}),
+			&incomingWebhookHandler{
+				log:            log,
+				cfg:            cfg,
+				dispatcher:     dispatcher,
+				startedSources: startedSources,
+				limiters:       map[string]*rate.Limiter{},
+			},
 		),
 	)
 	return router
 }
 
+// incomingWebhookHandler handles requests sent to the source URL, e.g. `/sources/v1/{sourceName}`,
+// and to routes registered by source plugins, e.g. `/sources/v1/{sourceName}/{path}`.
+type incomingWebhookHandler struct {
+	log            logrus.FieldLogger
+	cfg            *config.Config
+	dispatcher     externalRequestDispatcher
+	startedSources map[string]StartedSources
+
+	limitersMu sync.Mutex
+	limiters   map[string]*rate.Limiter
+}
+
+func (h *incomingWebhookHandler) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	if request == nil || request.URL == nil || strings.Trim(request.URL.Path, "/") == "" {
+		writeJSONError(h.log, writer, "Source name in path is required", http.StatusBadRequest)
+		return
+	}
+
+	sourceName, routePath := h.splitPath(request.URL.Path)
+	logger := h.log.WithFields(logrus.Fields{
+		"sourceName": sourceName,
+		"route":      routePath,
+	})
+	logger.Debugf("Handling incoming webhook request...")
+
+	sourcePlugins, ok := h.startedSources[sourceName]
+	if !ok || len(sourcePlugins) == 0 {
+		writeJSONError(h.log, writer, fmt.Sprintf("source %q not found", sourceName), http.StatusNotFound)
+		return
+	}
+
+	targets, route, found := h.matchRoute(request.Context(), logger, sourcePlugins, routePath)
+	if !found {
+		writeJSONError(h.log, writer, fmt.Sprintf("route %q not found for source %q", routePath, sourceName), http.StatusNotFound)
+		return
+	}
+	if !isMethodAllowed(route, request.Method) {
+		writeJSONError(h.log, writer, fmt.Sprintf("method %s not allowed", request.Method), http.StatusMethodNotAllowed)
+		return
+	}
+
+	srcCfg := h.cfg.Sources[sourceName].IncomingWebhook
+	if !h.allow(sourceName+"/"+routePath, srcCfg.RateLimit) {
+		logger.Warn("Rejecting incoming webhook request as the rate limit was exceeded")
+		writeJSONError(h.log, writer, "rate limit exceeded", http.StatusTooManyRequests)
+		return
+	}
+
+	payload, err := io.ReadAll(http.MaxBytesReader(writer, request.Body, maxIncomingWebhookBodySize))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			writeJSONError(h.log, writer, "request body too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		writeJSONError(h.log, writer, fmt.Sprintf("while reading request body: %s", err.Error()), http.StatusInternalServerError)
+		return
+	}
+	defer request.Body.Close()
+
+	auth := incomingWebhookAuth(h.cfg.Plugins.IncomingWebhook.Auth, srcCfg.Auth, route)
+	if err := verifyIncomingWebhookAuth(auth, request, payload); err != nil {
+		if errors.Is(err, errIncomingWebhookUnauthorized) {
+			logger.Warn("Rejecting unauthorized incoming webhook request")
+		} else {
+			logger.Errorf("Rejecting incoming webhook request as the %q authentication is misconfigured: %s", auth.Type, err.Error())
+		}
+		writeJSONError(h.log, writer, errIncomingWebhookUnauthorized.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	httpCtx := source.HTTPRequestContext{
+		Route:  routePath,
+		Method: request.Method,
+		Header: withoutCredentials(request.Header, auth),
+		Query:  request.URL.Query(),
+	}
+
+	// notifications are sent after the request is handled, so only the trace context is inherited
+	dispatchCtx := trace.ContextWithSpanContext(context.Background(), trace.SpanContextFromContext(request.Context()))
+
+	multiErr := multierror.New()
+	for _, src := range targets {
+		logger.WithFields(logrus.Fields{
+			"pluginName":               src.PluginName,
+			"isInteractivitySupported": src.IsInteractivitySupported,
+		}).Debug("Dispatching message...")
+
+		err := h.dispatcher.DispatchExternalRequest(ExternalRequestDispatch{
+			PluginDispatch: PluginDispatch{
+				ctx:                      dispatchCtx,
+				sourceName:               sourceName,
+				sourceDisplayName:        src.SourceDisplayName,
+				pluginName:               src.PluginName,
+				pluginConfig:             src.PluginConfig,
+				isInteractivitySupported: src.IsInteractivitySupported,
+				cfg:                      h.cfg,
+				pluginContext:            config.PluginContext{},
+				incomingWebhook: IncomingWebhookData{
+					inClusterBaseURL: h.cfg.Plugins.IncomingWebhook.InClusterBaseURL,
+				},
+			},
+			payload: payload,
+			http:    httpCtx,
+		})
+		if err != nil {
+			multiErr = multierror.Append(multiErr, err)
+		}
+	}
+
+	if multiErr.ErrorOrNil() != nil {
+		wrappedErr := fmt.Errorf("while dispatching external request: %w", multiErr)
+		writeJSONError(h.log, writer, wrappedErr.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSONSuccess(h.log, writer)
+}
+
+// splitPath returns the source name and the route path. Requests to the source URL have an empty route path.
+func (h *incomingWebhookHandler) splitPath(path string) (string, string) {
+	path = strings.Trim(path, "/")
+	if _, found := h.startedSources[path]; found {
+		return path, ""
+	}
+	sourceName, routePath, _ := strings.Cut(path, "/")
+	return sourceName, routePath
+}
+
+// matchRoute returns source plugins which handle a given route path. Requests to the source URL are handled by all plugins,
+// while requests to other paths are handled only by plugins which registered a given route.
+func (h *incomingWebhookHandler) matchRoute(ctx context.Context, log logrus.FieldLogger, sourcePlugins StartedSources, routePath string) ([]StartedSource, api.ExternalRequestRoute, bool) {
+	var (
+		targets []StartedSource
+		matched api.ExternalRequestRoute
+		found   bool
+	)
+	for _, isInteractivitySupported := range []bool{false, true} {
+		src, ok := sourcePlugins[isInteractivitySupported]
+		if !ok {
+			continue
+		}
+
+		routes, err := h.dispatcher.ExternalRequestRoutes(ctx, src.PluginName)
+		if err != nil {
+			log.WithField("pluginName", src.PluginName).Warnf("Cannot get incoming webhook routes: %s", err.Error())
+		}
+
+		route, ok := findRoute(routes, routePath)
+		if !ok {
+			if routePath == "" {
+				targets = append(targets, src)
+			}
+			continue
+		}
+		if !found {
+			matched, found = route, true
+		}
+		targets = append(targets, src)
+	}
+
+	if routePath == "" {
+		return targets, matched, len(targets) > 0
+	}
+	return targets, matched, found
+}
+
+// allow returns false if the rate limit for a given route is exceeded. Limits of the source binding take precedence over the global ones.
+func (h *incomingWebhookHandler) allow(key string, srcLimit config.IncomingWebhookRateLimit) bool {
+	limit := h.cfg.Plugins.IncomingWebhook.RateLimit
+	if srcLimit.MaxRequests > 0 {
+		limit = srcLimit
+	}
+	if limit.MaxRequests <= 0 || limit.Window <= 0 {
+		return true
+	}
+
+	h.limitersMu.Lock()
+	defer h.limitersMu.Unlock()
+
+	limiter, ok := h.limiters[key]
+	if !ok {
+		limiter = rate.NewLimiter(rate.Every(limit.Window/time.Duration(limit.MaxRequests)), limit.MaxRequests)
+		h.limiters[key] = limiter
+	}
+	return limiter.Allow()
+}
+
+func findRoute(routes []api.ExternalRequestRoute, routePath string) (api.ExternalRequestRoute, bool) {
+	for _, route := range routes {
+		if strings.Trim(route.Path, "/") == routePath {
+			return route, true
+		}
+	}
+	return api.ExternalRequestRoute{}, false
+}
+
+func isMethodAllowed(route api.ExternalRequestRoute, method string) bool {
+	if len(route.Methods) == 0 {
+		return method == http.MethodPost
+	}
+	for _, m := range route.Methods {
+		if strings.EqualFold(m, method) {
+			return true
+		}
+	}
+	return false
+}
+
+// withoutCredentials returns a copy of request headers without secrets used to authenticate the request,
+// so they are not passed to source plugins.
+func withoutCredentials(header http.Header, auth config.IncomingWebhookAuth) http.Header {
+	out := header.Clone()
+	switch auth.Type {
+	case config.BasicIncomingWebhookAuth, config.BearerIncomingWebhookAuth:
+		out.Del("Authorization")
+	case config.HeaderIncomingWebhookAuth:
+		out.Del(auth.Header)
+	}
+	return out
+}
+
 func writeJSONError(log logrus.FieldLogger, w http.ResponseWriter, errMsg string, code int) {
 	response := struct {
 		Error string `json:"error"`
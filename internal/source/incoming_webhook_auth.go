@@ -0,0 +1,116 @@
+package source
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+const (
+	defaultHMACSignatureHeader = "X-Hub-Signature-256"
+	hmacSignaturePrefix        = "sha256="
+	bearerTokenPrefix          = "Bearer "
+)
+
+var (
+	errIncomingWebhookUnauthorized = errors.New("unauthorized")
+	errMissingWebhookCredentials   = errors.New("credentials are not configured")
+)
+
+// incomingWebhookAuth returns the authentication of a given source route. Settings of the source binding take precedence
+// over the global settings, which take precedence over the scheme declared by the plugin for the route.
+// The `none` scheme declared by a plugin is ignored, so only the operator can disable authentication.
+func incomingWebhookAuth(global, src config.IncomingWebhookAuth, route api.ExternalRequestRoute) config.IncomingWebhookAuth {
+	pluginScheme := config.IncomingWebhookAuthType(route.AuthScheme)
+	if pluginScheme == config.NoneIncomingWebhookAuth {
+		pluginScheme = ""
+	}
+
+	out := config.IncomingWebhookAuth{
+		Type:     firstNonEmpty(src.Type, global.Type, pluginScheme),
+		Username: firstNonEmpty(src.Username, global.Username),
+		Password: firstNonEmpty(src.Password, global.Password),
+		Token:    firstNonEmpty(src.Token, global.Token),
+		Header:   firstNonEmpty(src.Header, global.Header, route.AuthHeader),
+	}
+	if out.Type == config.HMACSHA256IncomingWebhookAuth && out.Header == "" {
+		out.Header = defaultHMACSignatureHeader
+	}
+	return out
+}
+
+// verifyIncomingWebhookAuth returns an error if a given request doesn't satisfy the authentication.
+// It returns errMissingWebhookCredentials if no scheme is set or the scheme requires credentials which are not configured,
+// so requests are never accepted by accident.
+func verifyIncomingWebhookAuth(auth config.IncomingWebhookAuth, req *http.Request, body []byte) error {
+	switch auth.Type {
+	case "":
+		return errMissingWebhookCredentials
+	case config.NoneIncomingWebhookAuth:
+		return nil
+	case config.BasicIncomingWebhookAuth:
+		if auth.Username == "" || auth.Password == "" {
+			return errMissingWebhookCredentials
+		}
+		username, password, ok := req.BasicAuth()
+		if !ok || !secureEqual(username, auth.Username) || !secureEqual(password, auth.Password) {
+			return errIncomingWebhookUnauthorized
+		}
+		return nil
+	case config.BearerIncomingWebhookAuth:
+		if auth.Token == "" {
+			return errMissingWebhookCredentials
+		}
+		token, found := strings.CutPrefix(req.Header.Get("Authorization"), bearerTokenPrefix)
+		if !found || !secureEqual(token, auth.Token) {
+			return errIncomingWebhookUnauthorized
+		}
+		return nil
+	case config.HeaderIncomingWebhookAuth:
+		if auth.Token == "" || auth.Header == "" {
+			return errMissingWebhookCredentials
+		}
+		if !secureEqual(req.Header.Get(auth.Header), auth.Token) {
+			return errIncomingWebhookUnauthorized
+		}
+		return nil
+	case config.HMACSHA256IncomingWebhookAuth:
+		if auth.Token == "" {
+			return errMissingWebhookCredentials
+		}
+		signature := strings.TrimPrefix(req.Header.Get(auth.Header), hmacSignaturePrefix)
+		got, err := hex.DecodeString(signature)
+		if err != nil || len(got) == 0 {
+			return errIncomingWebhookUnauthorized
+		}
+		mac := hmac.New(sha256.New, []byte(auth.Token))
+		mac.Write(body)
+		if !hmac.Equal(got, mac.Sum(nil)) {
+			return errIncomingWebhookUnauthorized
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown authentication scheme %q", auth.Type)
+	}
+}
+
+func secureEqual(got, expected string) bool {
+	return subtle.ConstantTimeCompare([]byte(got), []byte(expected)) == 1
+}
+
+func firstNonEmpty[T ~string](values ...T) T {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,277 @@
+package source
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+type fakeExternalRequestDispatcher struct {
+	mu         sync.Mutex
+	routes     map[string][]api.ExternalRequestRoute
+	dispatched []ExternalRequestDispatch
+}
+
+func (f *fakeExternalRequestDispatcher) DispatchExternalRequest(dispatch ExternalRequestDispatch) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.dispatched = append(f.dispatched, dispatch)
+	return nil
+}
+
+func (f *fakeExternalRequestDispatcher) ExternalRequestRoutes(_ context.Context, pluginName string) ([]api.ExternalRequestRoute, error) {
+	return f.routes[pluginName], nil
+}
+
+func TestIncomingWebhookRouter(t *testing.T) {
+	// given
+	const secret = "s3cr3t"
+	body := `{"action":"opened"}`
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	signature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	cfg := &config.Config{
+		Plugins: config.PluginManagement{
+			IncomingWebhook: config.IncomingWebhook{
+				Auth: config.IncomingWebhookAuth{Token: secret},
+			},
+		},
+		Sources: map[string]config.Sources{
+			"alerts": {
+				IncomingWebhook: config.SourceIncomingWebhook{
+					Auth: config.IncomingWebhookAuth{Type: config.BearerIncomingWebhookAuth, Token: "alerts-token"},
+				},
+			},
+			"misconfigured": {
+				IncomingWebhook: config.SourceIncomingWebhook{
+					Auth: config.IncomingWebhookAuth{Type: config.BasicIncomingWebhookAuth},
+				},
+			},
+			"cm-watcher": {
+				IncomingWebhook: config.SourceIncomingWebhook{
+					Auth: config.IncomingWebhookAuth{Type: config.NoneIncomingWebhookAuth},
+				},
+			},
+		},
+	}
+	startedSources := map[string]StartedSources{
+		"github":          {false: {PluginName: "botkube/github"}},
+		"alerts":          {false: {PluginName: "botkube/alertmanager"}},
+		"cm-watcher":      {false: {PluginName: "botkube/cm-watcher"}},
+		"misconfigured":   {false: {PluginName: "botkube/cm-watcher"}},
+		"unauthenticated": {false: {PluginName: "botkube/cm-watcher"}},
+		"alerts-default":  {false: {PluginName: "botkube/alertmanager"}},
+	}
+	routes := map[string][]api.ExternalRequestRoute{
+		"botkube/github": {
+			{Path: "events", AuthScheme: string(config.HMACSHA256IncomingWebhookAuth)},
+		},
+		"botkube/alertmanager": {
+			{Path: "/v2/alerts/", Methods: []string{http.MethodPost, http.MethodPut}, AuthScheme: string(config.NoneIncomingWebhookAuth)},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		header         http.Header
+		expectedStatus int
+		expectedRoute  string
+	}{
+		{
+			name:           "legacy source URL",
+			method:         http.MethodPost,
+			path:           "/sources/v1/cm-watcher",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "valid HMAC signature",
+			method:         http.MethodPost,
+			path:           "/sources/v1/github/events",
+			header:         http.Header{"X-Hub-Signature-256": []string{signature}},
+			expectedStatus: http.StatusOK,
+			expectedRoute:  "events",
+		},
+		{
+			name:           "invalid HMAC signature",
+			method:         http.MethodPost,
+			path:           "/sources/v1/github/events",
+			header:         http.Header{"X-Hub-Signature-256": []string{"sha256=00ff"}},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "source auth overrides plugin scheme",
+			method:         http.MethodPut,
+			path:           "/sources/v1/alerts/v2/alerts",
+			header:         http.Header{"Authorization": []string{"Bearer alerts-token"}},
+			expectedStatus: http.StatusOK,
+			expectedRoute:  "v2/alerts",
+		},
+		{
+			name:           "invalid bearer token",
+			method:         http.MethodPost,
+			path:           "/sources/v1/alerts/v2/alerts",
+			header:         http.Header{"Authorization": []string{"Bearer other"}},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "missing credentials",
+			method:         http.MethodPost,
+			path:           "/sources/v1/misconfigured",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "missing scheme",
+			method:         http.MethodPost,
+			path:           "/sources/v1/unauthenticated",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "plugin none scheme is ignored",
+			method:         http.MethodPost,
+			path:           "/sources/v1/alerts-default/v2/alerts",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "method not allowed",
+			method:         http.MethodGet,
+			path:           "/sources/v1/github/events",
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:           "unknown route",
+			method:         http.MethodPost,
+			path:           "/sources/v1/github/unknown",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "unknown source",
+			method:         http.MethodPost,
+			path:           "/sources/v1/unknown",
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			dispatcher := &fakeExternalRequestDispatcher{routes: routes}
+			router := incomingWebhookRouter(loggerx.NewNoop(), cfg, dispatcher, startedSources)
+
+			req := httptest.NewRequest(tc.method, tc.path+"?page=2", strings.NewReader(body))
+			for name, values := range tc.header {
+				req.Header[name] = values
+			}
+			rec := httptest.NewRecorder()
+
+			// when
+			router.ServeHTTP(rec, req)
+
+			// then
+			assert.Equal(t, tc.expectedStatus, rec.Code, rec.Body.String())
+			if tc.expectedStatus != http.StatusOK {
+				assert.Empty(t, dispatcher.dispatched)
+				return
+			}
+			require.Len(t, dispatcher.dispatched, 1)
+			dispatch := dispatcher.dispatched[0]
+			assert.Equal(t, body, string(dispatch.payload))
+			assert.Equal(t, tc.expectedRoute, dispatch.http.Route)
+			assert.Equal(t, tc.method, dispatch.http.Method)
+			assert.Equal(t, "2", dispatch.http.Query.Get("page"))
+			assert.Empty(t, dispatch.http.Header.Get("Authorization"))
+		})
+	}
+}
+
+func TestIncomingWebhookAuth(t *testing.T) {
+	tests := []struct {
+		name     string
+		global   config.IncomingWebhookAuth
+		src      config.IncomingWebhookAuth
+		route    api.ExternalRequestRoute
+		expected config.IncomingWebhookAuth
+	}{
+		{
+			name:     "global scheme overrides plugin scheme",
+			global:   config.IncomingWebhookAuth{Type: config.BearerIncomingWebhookAuth, Token: "token"},
+			route:    api.ExternalRequestRoute{AuthScheme: string(config.HMACSHA256IncomingWebhookAuth), AuthHeader: "X-Hub-Signature-256"},
+			expected: config.IncomingWebhookAuth{Type: config.BearerIncomingWebhookAuth, Token: "token", Header: "X-Hub-Signature-256"},
+		},
+		{
+			name:     "source scheme overrides global scheme",
+			global:   config.IncomingWebhookAuth{Type: config.BearerIncomingWebhookAuth, Token: "token"},
+			src:      config.IncomingWebhookAuth{Type: config.HMACSHA256IncomingWebhookAuth, Token: "secret"},
+			expected: config.IncomingWebhookAuth{Type: config.HMACSHA256IncomingWebhookAuth, Token: "secret", Header: defaultHMACSignatureHeader},
+		},
+		{
+			name:     "plugin scheme is used if not configured",
+			global:   config.IncomingWebhookAuth{Token: "secret"},
+			route:    api.ExternalRequestRoute{AuthScheme: string(config.HMACSHA256IncomingWebhookAuth)},
+			expected: config.IncomingWebhookAuth{Type: config.HMACSHA256IncomingWebhookAuth, Token: "secret", Header: defaultHMACSignatureHeader},
+		},
+		{
+			name:     "plugin none scheme is ignored",
+			global:   config.IncomingWebhookAuth{Token: "token"},
+			route:    api.ExternalRequestRoute{AuthScheme: string(config.NoneIncomingWebhookAuth)},
+			expected: config.IncomingWebhookAuth{Token: "token"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			auth := incomingWebhookAuth(tc.global, tc.src, tc.route)
+
+			// then
+			assert.Equal(t, tc.expected, auth)
+		})
+	}
+}
+
+func TestIncomingWebhookRouterRateLimit(t *testing.T) {
+	// given
+	cfg := &config.Config{
+		Plugins: config.PluginManagement{
+			IncomingWebhook: config.IncomingWebhook{
+				Auth:      config.IncomingWebhookAuth{Type: config.NoneIncomingWebhookAuth},
+				RateLimit: config.IncomingWebhookRateLimit{MaxRequests: 2, Window: time.Hour},
+			},
+		},
+	}
+	startedSources := map[string]StartedSources{
+		"cm-watcher": {false: {PluginName: "botkube/cm-watcher"}},
+		"other":      {false: {PluginName: "botkube/cm-watcher"}},
+	}
+	router := incomingWebhookRouter(loggerx.NewNoop(), cfg, &fakeExternalRequestDispatcher{}, startedSources)
+
+	send := func(path string) int {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, path, strings.NewReader("{}")))
+		return rec.Code
+	}
+
+	// when
+	var codes []int
+	for i := 0; i < 3; i++ {
+		codes = append(codes, send("/sources/v1/cm-watcher"))
+	}
+	otherCode := send("/sources/v1/other")
+
+	// then
+	assert.Equal(t, []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests}, codes)
+	assert.Equal(t, http.StatusOK, otherCode)
+}
@@ -8,6 +8,7 @@ import (
 	"k8s.io/client-go/discovery/cached/memory"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/metadata"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/restmapper"
 	"k8s.io/client-go/tools/clientcmd"
@@ -17,6 +18,7 @@ import (
 type Client struct {
 	dynamicCli   dynamic.Interface
 	discoveryCli discovery.DiscoveryInterface
+	metadataCli  metadata.Interface
 	mapper       meta.RESTMapper
 	k8sCli       *kubernetes.Clientset
 }
@@ -35,9 +37,14 @@ func NewClient(kubeConfigBytes []byte) (*Client, error) {
 	if err != nil {
 		return nil, fmt.Errorf("while creating K8s clientset. %v", err)
 	}
+	metadataCli, err := metadata.NewForConfig(kubeConfig)
+	if err != nil {
+		return nil, fmt.Errorf("while creating K8s metadata client. %v", err)
+	}
 	return &Client{
 		dynamicCli:   dynamicCli,
 		discoveryCli: discoveryCli,
+		metadataCli:  metadataCli,
 		k8sCli:       k8sCli,
 		mapper:       mapper,
 	}, nil
@@ -7,6 +7,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/kubeshop/botkube/internal/sharding"
+	"github.com/kubeshop/botkube/pkg/api"
 	"github.com/kubeshop/botkube/pkg/api/source"
 	"github.com/kubeshop/botkube/pkg/config"
 	"github.com/kubeshop/botkube/pkg/plugin"
@@ -17,6 +19,7 @@ import (
 type Config struct {
 	ExtraButtons         []ExtraButtons     `yaml:"extraButtons"`
 	InformerResyncPeriod time.Duration      `yaml:"informerResyncPeriod"`
+	Informers            *Informers         `yaml:"informers"`
 	Log                  config.Logger      `yaml:"log"`
 	Recommendations      *Recommendations   `yaml:"recommendations"`
 	Event                *KubernetesEvent   `yaml:"event"`
@@ -26,32 +29,58 @@ type Config struct {
 	Annotations          *map[string]string `yaml:"annotations"`
 	Labels               *map[string]string `yaml:"labels"`
 	Filters              *Filters           `yaml:"filters"`
+	Severity             *Severity          `yaml:"severity"`
+	// Templates override the layout of notifications about matching events.
+	Templates []MessageTemplate `yaml:"templates"`
+	// Sharding is set by Botkube once the watch space is split across replicas. Events from namespaces of other shards are skipped.
+	Sharding *sharding.Shard `yaml:"sharding"`
 }
 
 type (
+	// ExtraButtons defines a quick action rule. Buttons are attached to notifications about events matching the trigger.
 	ExtraButtons struct {
 		Enabled bool    `yaml:"enabled"`
 		Trigger Trigger `yaml:"trigger"`
-		Button  Button  `yaml:"button"`
+		// Button is a single button rendered for matched events.
+		Button Button `yaml:"button"`
+		// Buttons holds additional buttons rendered for matched events, e.g. "Logs", "Describe" and "Restart".
+		Buttons []Button `yaml:"buttons"`
 	}
 	Button struct {
 		CommandTpl  string `yaml:"commandTpl"`
 		DisplayName string `yaml:"displayName"`
+		// Style is the button style. If not set, the primary one is used.
+		Style api.ButtonStyle `yaml:"style"`
 	}
 	Trigger struct {
 		Type []EventType `yaml:"type"`
+		// Kinds limits the trigger to events for given object kinds, e.g. Pod or Node. Matching is case-insensitive.
+		Kinds []string `yaml:"kinds"`
+		// Reason limits the trigger to events which reason matches the constraints, e.g. "BackOff" or "Node.*Pressure".
+		Reason RegexConstraints `yaml:"reason"`
 	}
 )
 
+// AllButtons returns all buttons defined for a given rule.
+func (b *ExtraButtons) AllButtons() []Button {
+	var out []Button
+	if b.Button != (Button{}) {
+		out = append(out, b.Button)
+	}
+	return append(out, b.Buttons...)
+}
+
 func (b *ExtraButtons) NormalizeAndValidate() error {
 	// the multierr pkg is not used as it breaks the final error indent making it hard to read
 	var issues []string
-	if b.Button.DisplayName == "" {
-		issues = append(issues, "displayName cannot be empty")
+	if b.Button == (Button{}) && len(b.Buttons) == 0 {
+		issues = append(issues, "button or buttons must be defined")
 	}
-
-	if b.Button.CommandTpl == "" {
-		issues = append(issues, "commandTpl cannot be empty")
+	if b.Button != (Button{}) {
+		issues = append(issues, b.Button.validate("")...)
+	}
+	for idx, btn := range b.Buttons {
+		issues = append(issues, btn.validate(fmt.Sprintf("buttons[%d].", idx))...)
 	}
 
 	if b.Trigger.Type == nil {
@@ -73,6 +102,57 @@ func (b *ExtraButtons) NormalizeAndValidate() error {
 	return nil
 }
 
+func (b Button) validate(prefix string) []string {
+	var issues []string
+	if b.DisplayName == "" {
+		issues = append(issues, prefix+"displayName cannot be empty")
+	}
+
+	if b.CommandTpl == "" {
+		issues = append(issues, prefix+"commandTpl cannot be empty")
+	}
+
+	switch b.Style {
+	case api.ButtonStyleDefault, api.ButtonStylePrimary, api.ButtonStyleDanger:
+	default:
+		issues = append(issues, fmt.Sprintf("unknown %sstyle %q", prefix, b.Style))
+	}
+	return issues
+}
+
+// Severity contains rules which map events to severity levels. The level is used for notification colors, PagerDuty severities,
+// and it's available in CEL conditions, e.g. for escalation policies.
+type Severity struct {
+	// Rules are evaluated in order, and the first matching rule sets the event level.
+	// If no rule matches, the level is based on the event type.
+	Rules []SeverityRule `yaml:"rules"`
+}
+
+// SeverityRule maps matching events to a given level. All specified criteria must match.
+type SeverityRule struct {
+	// Types limits the rule to given event types, e.g. `error` or `delete`. Kubernetes events of the Warning type have the `error` type.
+	Types []EventType `yaml:"types"`
+	// Kinds limits the rule to events for given object kinds, e.g. Pod or Node. Matching is case-insensitive.
+	Kinds []string `yaml:"kinds"`
+	// Reason limits the rule to events which reason matches the constraints, e.g. "OOMKilling" or "Node.*Pressure".
+	Reason RegexConstraints `yaml:"reason"`
+	// Expression is an optional CEL expression returning bool, e.g. `event.Namespace.startsWith("prod-")`.
+	// Available variables: `event` and `cluster`.
+	Expression string `yaml:"expression"`
+	// Level is the severity level set for matching events.
+	Level Level `yaml:"level"`
+}
+
+// MessageTemplate overrides the layout of notifications about events matching the trigger.
+type MessageTemplate struct {
+	Trigger Trigger `yaml:"trigger"`
+	// Template is a Go template rendering a YAML list of message sections. It's rendered with the event object,
+	// and `.Object` and `.OldObject` hold the raw Kubernetes objects. Apart from the sprig functions,
+	// the `diff`, `table` and `severityEmoji` helpers are available.
+	// If the template renders nothing, the default layout is used.
+	Template string `yaml:"template"`
+}
+
 // Commands contains allowed verbs and resources
 type Commands struct {
 	Verbs     []string `yaml:"verbs"`
@@ -247,9 +327,27 @@ type UpdateSetting struct {
 	IncludeDiff bool     `yaml:"includeDiff"`
 }
 
+// Informers contains settings which reduce memory used by informers on large clusters.
+// Similarly to the informer resync period, they are plugin-wide, so they are taken from the first source configuration.
+type Informers struct {
+	// StripManagedFields removes `metadata.managedFields` from objects before they are cached.
+	StripManagedFields bool `yaml:"stripManagedFields"`
+	// Resources holds informer settings by resource type, e.g. `v1/pods`.
+	Resources map[string]InformerSettings `yaml:"resources"`
+}
+
+// InformerSettings holds settings of an informer for a given resource type.
+type InformerSettings struct {
+	// MetadataOnly watches only metadata of objects, so spec and status are not available in notifications,
+	// update diffs, recommendations and action templates.
+	MetadataOnly bool `yaml:"metadataOnly"`
+	// FieldSelector limits watched objects, e.g. `status.phase!=Succeeded`. Supported fields depend on the resource type.
+	FieldSelector string `yaml:"fieldSelector"`
+}
+
 // Filters contains configuration for built-in filters.
 type Filters struct {
-	// ObjectAnnotationChecker enables support for `botkube.io/disable` resource annotation.
+	// ObjectAnnotationChecker enables support for `botkube.io/disable`, `botkube.io/disable-notifications` and `botkube.io/channel` resource annotations.
 	ObjectAnnotationChecker bool `yaml:"objectAnnotationChecker"`
 
 	// NodeEventsChecker filters out Node-related events that are not important.
@@ -281,6 +379,9 @@ func MergeConfigs(configs []*source.Config) (Config, error) {
 			ObjectAnnotationChecker: true,
 			NodeEventsChecker:       true,
 		},
+		Informers: &Informers{
+			StripManagedFields: true,
+		},
 	}
 	var out Config
 	if err := plugin.MergeSourceConfigsWithDefaults(defaults, configs, &out); err != nil {
@@ -297,10 +398,23 @@ const (
 	// Info level
 	Info    Level = "info"
 	Success Level = "success"
+	// Warning level
+	Warning Level = "warning"
 	// Error level
 	Error Level = "error"
+	// Critical level
+	Critical Level = "critical"
 )
 
+// IsValid checks if the level is one of the known levels.
+func (l Level) IsValid() bool {
+	switch l {
+	case Info, Success, Warning, Error, Critical:
+		return true
+	}
+	return false
+}
+
 const (
 	// AllNamespaceIndicator represents a keyword for allowing all Kubernetes Namespaces.
 	AllNamespaceIndicator = ".*"
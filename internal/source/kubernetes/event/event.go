@@ -5,40 +5,45 @@ import (
 	"strings"
 	"time"
 
-	coreV1 "k8s.io/api/core/v1"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/duration"
 
 	"github.com/kubeshop/botkube/internal/source/kubernetes/config"
 	"github.com/kubeshop/botkube/internal/source/kubernetes/k8sutil"
-	"github.com/kubeshop/botkube/pkg/k8sx"
 )
 
 // Event stores data about a given event for Kubernetes object.
 type Event struct {
-	APIVersion      string
-	Kind            string
-	Title           string
-	Name            string
-	Namespace       string
-	Messages        []string
-	Type            config.EventType
-	Reason          string
-	Level           config.Level
-	Cluster         string
-	TimeStamp       time.Time
-	Count           int32
+	APIVersion string
+	Kind       string
+	Title      string
+	Name       string
+	Namespace  string
+	Messages   []string
+	Type       config.EventType
+	Reason     string
+	Level      config.Level
+	Cluster    string
+	TimeStamp  time.Time
+	Count      int32
+	// FirstTimestamp is the time of the first occurrence of a repeated Kubernetes event.
+	FirstTimestamp  time.Time `json:",omitempty"`
 	Action          string
 	Skip            bool `json:",omitempty"`
 	Resource        string
 	Recommendations []string
 	Warnings        []string
+	// Channels holds channels set with the `botkube.io/channel` annotation. It's not part of the raw event, as it's passed to Botkube separately.
+	Channels []string `json:"-"`
 
 	// The following fields are ignored when marshalling the event by purpose.
 	// We send the whole Event struct via sink.Elasticsearch integration.
 	// When using ELS dynamic mapping, we should avoid complex, dynamic objects, which could result into type conflicts.
 	ObjectMeta metaV1.ObjectMeta `json:"-"`
 	Object     interface{}       `json:"-"`
+	// OldObject holds the previous version of the object for update events.
+	OldObject interface{} `json:"-"`
 }
 
 // Action describes an automated action for a given event.
@@ -49,6 +54,17 @@ type Action struct {
 	DisplayName      string
 }
 
+// Occurrences describes how many times a repeated Kubernetes event occurred, e.g. `x400 over 2h`. It's empty for events which occurred once.
+func (e *Event) Occurrences() string {
+	if e.Count <= 1 {
+		return ""
+	}
+	if e.FirstTimestamp.IsZero() || !e.TimeStamp.After(e.FirstTimestamp) {
+		return fmt.Sprintf("x%d", e.Count)
+	}
+	return fmt.Sprintf("x%d over %s", e.Count, duration.ShortHumanDuration(e.TimeStamp.Sub(e.FirstTimestamp)))
+}
+
 // HasRecommendationsOrWarnings returns true if event has recommendations or warnings.
 func (e *Event) HasRecommendationsOrWarnings() bool {
 	return len(e.Recommendations) > 0 || len(e.Warnings) > 0
@@ -106,14 +122,12 @@ func New(objectMeta metaV1.ObjectMeta, object interface{}, eventType config.Even
 	}
 
 	if typeMeta.Kind == "Event" {
-		var eventObj coreV1.Event
-
 		unstrObj, ok := object.(*unstructured.Unstructured)
 		if !ok {
 			return Event{}, fmt.Errorf("cannot convert type %T into *unstructured.Unstructured", object)
 		}
 
-		err := k8sx.TransformIntoTypedObject(unstrObj, &eventObj)
+		eventObj, err := k8sutil.ToCoreEvent(unstrObj)
 		if err != nil {
 			return Event{}, fmt.Errorf("while transforming object type %T into type: %T: %w", object, eventObj, err)
 		}
@@ -137,6 +151,11 @@ func New(objectMeta metaV1.ObjectMeta, object interface{}, eventType config.Even
 			// still zero? try event time
 			event.TimeStamp = eventObj.EventTime.Time
 		}
+		event.FirstTimestamp = eventObj.FirstTimestamp.Time
+		if event.FirstTimestamp.IsZero() {
+			// events.k8s.io/v1 events keep the time of the first occurrence of the series
+			event.FirstTimestamp = eventObj.EventTime.Time
+		}
 	}
 
 	return event, nil
@@ -0,0 +1,26 @@
+package event
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventOccurrences(t *testing.T) {
+	first := time.Date(2024, 5, 6, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		event    Event
+		expected string
+	}{
+		{name: "Single occurrence", event: Event{Count: 1, FirstTimestamp: first, TimeStamp: first}, expected: ""},
+		{name: "Series", event: Event{Count: 400, FirstTimestamp: first, TimeStamp: first.Add(2 * time.Hour)}, expected: "x400 over 2h"},
+		{name: "Unknown first occurrence", event: Event{Count: 3, TimeStamp: first}, expected: "x3"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.event.Occurrences())
+		})
+	}
+}
@@ -3,6 +3,7 @@ package filters
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -16,6 +17,11 @@ import (
 const (
 	// DisableAnnotation is the object disable annotation.
 	DisableAnnotation string = "botkube.io/disable"
+	// DisableNotificationsAnnotation is the alias of the object disable annotation.
+	DisableNotificationsAnnotation string = "botkube.io/disable-notifications"
+	// ChannelAnnotation is the object annotation which overrides channels for event notifications.
+	// It contains comma-separated channel names or aliases, e.g. `#team-x` or `team-x,team-y`.
+	ChannelAnnotation string = "botkube.io/channel"
 )
 
 // ObjectAnnotationChecker forwards events to specific channels based on a special annotation if it is set on a given K8s resource.
@@ -42,8 +48,11 @@ func (f *ObjectAnnotationChecker) Run(ctx context.Context, event *event.Event) e
 	if f.isObjectNotifDisabled(obj) {
 		event.Skip = true
 		f.log.Debug("Object Notification Disable through annotations")
+		return nil
 	}
 
+	event.Channels = channelsFromAnnotation(obj)
+
 	f.log.Debug("Object annotations filter successful!")
 	return nil
 }
@@ -58,12 +67,26 @@ func (f *ObjectAnnotationChecker) Describe() string {
 	return "Filters or reroutes events based on botkube.io/* Kubernetes resource annotations."
 }
 
-// isObjectNotifDisabled checks annotation botkube.io/disable.
-// Annotation botkube.io/disable disables the event notifications from objects.
+// isObjectNotifDisabled checks annotations botkube.io/disable and botkube.io/disable-notifications.
+// Both annotations disable the event notifications from objects.
 func (f *ObjectAnnotationChecker) isObjectNotifDisabled(obj metaV1.ObjectMeta) bool {
-	if obj.Annotations[DisableAnnotation] == "true" {
+	if obj.Annotations[DisableAnnotation] == "true" || obj.Annotations[DisableNotificationsAnnotation] == "true" {
 		f.log.Debug("Skipping Disabled Event Notifications!")
 		return true
 	}
 	return false
 }
+
+// channelsFromAnnotation returns channels from annotation botkube.io/channel.
+// The leading `#` is trimmed, as channels are configured without it.
+func channelsFromAnnotation(obj metaV1.ObjectMeta) []string {
+	var out []string
+	for _, ch := range strings.Split(obj.Annotations[ChannelAnnotation], ",") {
+		ch = strings.TrimPrefix(strings.TrimSpace(ch), "#")
+		if ch == "" {
+			continue
+		}
+		out = append(out, ch)
+	}
+	return out
+}
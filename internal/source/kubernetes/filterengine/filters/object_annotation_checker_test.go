@@ -3,6 +3,7 @@ package filters
 import (
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/kubeshop/botkube/pkg/loggerx"
@@ -13,10 +14,11 @@ func TestIsObjectNotifDisabled(t *testing.T) {
 		annotation metaV1.ObjectMeta
 		expected   bool
 	}{
-		`Empty ObjectMeta`:                 {metaV1.ObjectMeta{}, false},
-		`ObjectMeta with some annotations`: {metaV1.ObjectMeta{Annotations: map[string]string{"foo": "bar"}}, false},
-		`ObjectMeta with disable false`:    {metaV1.ObjectMeta{Annotations: map[string]string{"botkube.io/disable": "false"}}, false},
-		`ObjectMeta with disable true`:     {metaV1.ObjectMeta{Annotations: map[string]string{"botkube.io/disable": "true"}}, true},
+		`Empty ObjectMeta`:                           {metaV1.ObjectMeta{}, false},
+		`ObjectMeta with some annotations`:           {metaV1.ObjectMeta{Annotations: map[string]string{"foo": "bar"}}, false},
+		`ObjectMeta with disable false`:              {metaV1.ObjectMeta{Annotations: map[string]string{"botkube.io/disable": "false"}}, false},
+		`ObjectMeta with disable true`:               {metaV1.ObjectMeta{Annotations: map[string]string{"botkube.io/disable": "true"}}, true},
+		`ObjectMeta with disable-notifications true`: {metaV1.ObjectMeta{Annotations: map[string]string{"botkube.io/disable-notifications": "true"}}, true},
 	}
 	for name, test := range tests {
 		name, test := name, test
@@ -29,3 +31,21 @@ func TestIsObjectNotifDisabled(t *testing.T) {
 		})
 	}
 }
+
+func TestChannelsFromAnnotation(t *testing.T) {
+	tests := map[string]struct {
+		annotation metaV1.ObjectMeta
+		expected   []string
+	}{
+		`Empty ObjectMeta`:   {metaV1.ObjectMeta{}, nil},
+		`Single channel`:     {metaV1.ObjectMeta{Annotations: map[string]string{"botkube.io/channel": "#team-x"}}, []string{"team-x"}},
+		`Multiple channels`:  {metaV1.ObjectMeta{Annotations: map[string]string{"botkube.io/channel": "team-x, #team-y,"}}, []string{"team-x", "team-y"}},
+		`Empty channel list`: {metaV1.ObjectMeta{Annotations: map[string]string{"botkube.io/channel": " "}}, nil},
+	}
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, channelsFromAnnotation(test.annotation))
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package kubernetes
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/metadata"
+	"k8s.io/client-go/metadata/metadatainformer"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/kubeshop/botkube/internal/source/kubernetes/config"
+)
+
+// informerFactory creates informers for resources according to the informer settings.
+// Similarly to the shared informer factories, there is a single informer per resource.
+type informerFactory struct {
+	log          logrus.FieldLogger
+	dynamicCli   dynamic.Interface
+	metadataCli  metadata.Interface
+	mapper       meta.RESTMapper
+	resyncPeriod time.Duration
+	cfg          config.Informers
+
+	mu        sync.Mutex
+	informers map[schema.GroupVersionResource]cache.SharedIndexInformer
+	wg        sync.WaitGroup
+}
+
+func newInformerFactory(log logrus.FieldLogger, client *Client, resyncPeriod time.Duration, cfg *config.Informers) *informerFactory {
+	f := &informerFactory{
+		log:          log,
+		dynamicCli:   client.dynamicCli,
+		metadataCli:  client.metadataCli,
+		mapper:       client.mapper,
+		resyncPeriod: resyncPeriod,
+		informers:    map[schema.GroupVersionResource]cache.SharedIndexInformer{},
+	}
+	if cfg != nil {
+		f.cfg = *cfg
+	}
+	return f
+}
+
+// ForResource returns an informer for a given resource.
+func (f *informerFactory) ForResource(gvr schema.GroupVersionResource) (cache.SharedIndexInformer, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if informer, ok := f.informers[gvr]; ok {
+		return informer, nil
+	}
+
+	resource := gvrToString(gvr)
+	settings := f.cfg.Resources[resource]
+	if settings.FieldSelector != "" {
+		if _, err := fields.ParseSelector(settings.FieldSelector); err != nil {
+			return nil, fmt.Errorf("while parsing field selector for %s: %w", resource, err)
+		}
+	}
+	tweakListOptions := func(opts *metaV1.ListOptions) {
+		opts.FieldSelector = settings.FieldSelector
+	}
+	indexers := cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}
+
+	var (
+		informer cache.SharedIndexInformer
+		gvk      schema.GroupVersionKind
+	)
+	if settings.MetadataOnly && resource == eventsResource {
+		// Kubernetes events are reported based on their reason and message
+		f.log.Warnf("Metadata-only informer is not supported for %s. Watching full objects...", resource)
+		settings.MetadataOnly = false
+	}
+	if settings.MetadataOnly {
+		var err error
+		gvk, err = f.mapper.KindFor(gvr)
+		if err != nil {
+			return nil, fmt.Errorf("while getting kind for %s: %w", resource, err)
+		}
+		informer = metadatainformer.NewFilteredMetadataInformer(f.metadataCli, gvr, metaV1.NamespaceAll, f.resyncPeriod, indexers, tweakListOptions).Informer()
+	} else {
+		informer = dynamicinformer.NewFilteredDynamicInformer(f.dynamicCli, gvr, metaV1.NamespaceAll, f.resyncPeriod, indexers, tweakListOptions).Informer()
+	}
+
+	if err := informer.SetTransform(transformFn(gvk, f.cfg.StripManagedFields)); err != nil {
+		return nil, fmt.Errorf("while setting transform function for %s: %w", resource, err)
+	}
+
+	f.informers[gvr] = informer
+	return informer, nil
+}
+
+// Start starts all created informers. They run until the stop channel is closed.
+func (f *informerFactory) Start(stopCh <-chan struct{}) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, informer := range f.informers {
+		f.wg.Add(1)
+		go func(informer cache.SharedIndexInformer) {
+			defer f.wg.Done()
+			informer.Run(stopCh)
+		}(informer)
+	}
+}
+
+// Shutdown waits until all started informers are stopped.
+func (f *informerFactory) Shutdown() {
+	f.wg.Wait()
+}
+
+// transformFn returns a function which prepares objects before they are cached.
+// Metadata-only objects are converted to unstructured ones with a given kind, so they are handled in the same way as full objects.
+func transformFn(gvk schema.GroupVersionKind, stripManagedFields bool) cache.TransformFunc {
+	return func(obj interface{}) (interface{}, error) {
+		if partial, ok := obj.(*metaV1.PartialObjectMetadata); ok {
+			u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(partial)
+			if err != nil {
+				return nil, fmt.Errorf("while converting %T to unstructured: %w", obj, err)
+			}
+			unstructuredObj := &unstructured.Unstructured{Object: u}
+			unstructuredObj.SetGroupVersionKind(gvk)
+			obj = unstructuredObj
+		}
+
+		if !stripManagedFields {
+			return obj, nil
+		}
+		accessor, err := meta.Accessor(obj)
+		if err != nil {
+			// e.g. cache.DeletedFinalStateUnknown, which holds an already transformed object
+			return obj, nil
+		}
+		accessor.SetManagedFields(nil)
+		return obj, nil
+	}
+}
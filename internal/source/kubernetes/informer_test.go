@@ -0,0 +1,88 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestTransformFn(t *testing.T) {
+	podGVK := schema.GroupVersionKind{Version: "v1", Kind: "Pod"}
+	managedFields := []metaV1.ManagedFieldsEntry{{Manager: "kubectl", Operation: metaV1.ManagedFieldsOperationApply}}
+
+	newPod := func() *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{Object: map[string]interface{}{
+			"spec": map[string]interface{}{"nodeName": "node-1"},
+		}}
+		obj.SetGroupVersionKind(podGVK)
+		obj.SetName("nginx")
+		obj.SetNamespace("default")
+		obj.SetManagedFields(managedFields)
+		return obj
+	}
+
+	t.Run("strips managed fields", func(t *testing.T) {
+		// when
+		out, err := transformFn(schema.GroupVersionKind{}, true)(newPod())
+
+		// then
+		require.NoError(t, err)
+		obj, ok := out.(*unstructured.Unstructured)
+		require.True(t, ok)
+		assert.Empty(t, obj.GetManagedFields())
+		assert.Equal(t, "node-1", obj.Object["spec"].(map[string]interface{})["nodeName"])
+	})
+
+	t.Run("keeps managed fields if disabled", func(t *testing.T) {
+		// when
+		out, err := transformFn(schema.GroupVersionKind{}, false)(newPod())
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, managedFields, out.(*unstructured.Unstructured).GetManagedFields())
+	})
+
+	t.Run("converts metadata-only objects", func(t *testing.T) {
+		// given
+		in := &metaV1.PartialObjectMetadata{
+			TypeMeta: metaV1.TypeMeta{APIVersion: "meta.k8s.io/v1", Kind: "PartialObjectMetadata"},
+			ObjectMeta: metaV1.ObjectMeta{
+				Name:          "nginx",
+				Namespace:     "default",
+				Labels:        map[string]string{"app": "nginx"},
+				ManagedFields: managedFields,
+			},
+		}
+
+		// when
+		out, err := transformFn(podGVK, true)(in)
+
+		// then
+		require.NoError(t, err)
+		obj, ok := out.(*unstructured.Unstructured)
+		require.True(t, ok)
+		assert.Equal(t, "v1", obj.GetAPIVersion())
+		assert.Equal(t, "Pod", obj.GetKind())
+		assert.Equal(t, "nginx", obj.GetName())
+		assert.Equal(t, "default", obj.GetNamespace())
+		assert.Equal(t, map[string]string{"app": "nginx"}, obj.GetLabels())
+		assert.Empty(t, obj.GetManagedFields())
+	})
+
+	t.Run("passes through objects without metadata", func(t *testing.T) {
+		// given
+		in := cache.DeletedFinalStateUnknown{Key: "default/nginx", Obj: newPod()}
+
+		// when
+		out, err := transformFn(podGVK, true)(in)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, in, out)
+	})
+}
@@ -0,0 +1,60 @@
+package k8sutil
+
+import (
+	coreV1 "k8s.io/api/core/v1"
+	eventsV1 "k8s.io/api/events/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/kubeshop/botkube/pkg/k8sx"
+)
+
+// ToCoreEvent converts a given Kubernetes event to the core/v1 Event. Both core/v1 and events.k8s.io/v1 events are supported,
+// so events watched with any of these APIs are handled in the same way.
+func ToCoreEvent(obj *unstructured.Unstructured) (coreV1.Event, error) {
+	if obj.GroupVersionKind().Group != eventsV1.GroupName {
+		var out coreV1.Event
+		err := k8sx.TransformIntoTypedObject(obj, &out)
+		return out, err
+	}
+
+	var in eventsV1.Event
+	if err := k8sx.TransformIntoTypedObject(obj, &in); err != nil {
+		return coreV1.Event{}, err
+	}
+	out := coreV1.Event{
+		TypeMeta:            in.TypeMeta,
+		ObjectMeta:          in.ObjectMeta,
+		InvolvedObject:      in.Regarding,
+		Related:             in.Related,
+		Reason:              in.Reason,
+		Message:             in.Note,
+		Type:                in.Type,
+		Action:              in.Action,
+		Source:              in.DeprecatedSource,
+		FirstTimestamp:      in.DeprecatedFirstTimestamp,
+		LastTimestamp:       in.DeprecatedLastTimestamp,
+		Count:               in.DeprecatedCount,
+		EventTime:           in.EventTime,
+		ReportingController: in.ReportingController,
+		ReportingInstance:   in.ReportingInstance,
+	}
+	if in.Series != nil {
+		out.Series = &coreV1.EventSeries{
+			Count:            in.Series.Count,
+			LastObservedTime: in.Series.LastObservedTime,
+		}
+	}
+	return out, nil
+}
+
+// SeriesCount returns the number of occurrences of a given event. Repeated events are reported either with the count,
+// or with the event series.
+func SeriesCount(e coreV1.Event) int32 {
+	if e.Series != nil && e.Series.Count > e.Count {
+		return e.Series.Count
+	}
+	if e.Count == 0 {
+		return 1
+	}
+	return e.Count
+}
@@ -0,0 +1,69 @@
+package k8sutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestToCoreEvent(t *testing.T) {
+	// given
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "events.k8s.io/v1",
+		"kind":       "Event",
+		"metadata":   map[string]any{"name": "api.17a1", "namespace": "prod"},
+		"regarding": map[string]any{
+			"apiVersion": "v1",
+			"kind":       "Pod",
+			"name":       "api",
+			"namespace":  "prod",
+		},
+		"reason":    "BackOff",
+		"note":      "Back-off restarting failed container",
+		"type":      "Warning",
+		"eventTime": "2024-05-06T10:00:00.000000Z",
+		"series": map[string]any{
+			"count":            int64(400),
+			"lastObservedTime": "2024-05-06T12:00:00.000000Z",
+		},
+		"reportingController": "kubelet",
+	}}
+
+	// when
+	out, err := ToCoreEvent(obj)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "api.17a1", out.Name)
+	assert.Equal(t, coreV1.ObjectReference{APIVersion: "v1", Kind: "Pod", Name: "api", Namespace: "prod"}, out.InvolvedObject)
+	assert.Equal(t, "BackOff", out.Reason)
+	assert.Equal(t, "Back-off restarting failed container", out.Message)
+	assert.Equal(t, "Warning", out.Type)
+	assert.Equal(t, "kubelet", out.ReportingController)
+	assert.True(t, out.EventTime.Time.Equal(time.Date(2024, 5, 6, 10, 0, 0, 0, time.UTC)))
+	require.NotNil(t, out.Series)
+	assert.EqualValues(t, 400, out.Series.Count)
+	assert.True(t, out.Series.LastObservedTime.Time.Equal(time.Date(2024, 5, 6, 12, 0, 0, 0, time.UTC)))
+	assert.EqualValues(t, 400, SeriesCount(out))
+}
+
+func TestSeriesCount(t *testing.T) {
+	tests := []struct {
+		name     string
+		event    coreV1.Event
+		expected int32
+	}{
+		{name: "Single event", event: coreV1.Event{}, expected: 1},
+		{name: "Count", event: coreV1.Event{Count: 12}, expected: 12},
+		{name: "Series", event: coreV1.Event{Count: 1, Series: &coreV1.EventSeries{Count: 30}}, expected: 30},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, SeriesCount(tc.event))
+		})
+	}
+}
@@ -3,6 +3,7 @@ package k8sutil
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	coreV1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -11,8 +12,6 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/dynamic"
-
-	"github.com/kubeshop/botkube/pkg/k8sx"
 )
 
 // GetObjectMetaData returns metadata of the given object
@@ -38,8 +37,7 @@ func GetObjectMetaData(ctx context.Context, dynamicCli dynamic.Interface, mapper
 		ManagedFields:              unstructuredObject.GetManagedFields(),
 	}
 	if GetObjectTypeMetaData(obj).Kind == "Event" {
-		var eventObj coreV1.Event
-		err := k8sx.TransformIntoTypedObject(obj.(*unstructured.Unstructured), &eventObj)
+		eventObj, err := ToCoreEvent(obj.(*unstructured.Unstructured))
 		if err != nil {
 			return metaV1.ObjectMeta{}, fmt.Errorf("while transforming object type: %T into type %T: %w", obj, eventObj, err)
 		}
@@ -97,3 +95,45 @@ func extractAnnotationsFromEvent(ctx context.Context, dynamicCli dynamic.Interfa
 	}
 	return annotations.GetAnnotations(), nil
 }
+
+// maxOwnerChainDepth limits the number of owners resolved by GetTopOwnerRef.
+const maxOwnerChainDepth = 10
+
+// GetTopOwnerRef returns the top-most controller of a given object in the `kind/name` format, e.g. `deployment/nginx`.
+// Each owner in the chain is fetched from the cluster. If the object doesn't have any controller, or it doesn't exist anymore,
+// the object's own reference is returned.
+func GetTopOwnerRef(ctx context.Context, dynamicCli dynamic.Interface, mapper meta.RESTMapper, gvk schema.GroupVersionKind, namespace, name string) (string, error) {
+	ref := ownerRef(gvk.Kind, name)
+	for i := 0; i < maxOwnerChainDepth; i++ {
+		gvr, err := GetResourceFromKind(mapper, gvk)
+		if err != nil {
+			return "", err
+		}
+
+		obj, err := dynamicCli.Resource(gvr).Namespace(namespace).Get(ctx, name, metaV1.GetOptions{})
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return ref, nil
+			}
+			return "", fmt.Errorf("while getting %s: %w", ref, err)
+		}
+
+		controller := metaV1.GetControllerOfNoCopy(obj)
+		if controller == nil {
+			return ref, nil
+		}
+
+		gv, err := schema.ParseGroupVersion(controller.APIVersion)
+		if err != nil {
+			return "", fmt.Errorf("while parsing API version of %s owner: %w", ref, err)
+		}
+		gvk = gv.WithKind(controller.Kind)
+		name = controller.Name
+		ref = ownerRef(controller.Kind, controller.Name)
+	}
+	return ref, nil
+}
+
+func ownerRef(kind, name string) string {
+	return fmt.Sprintf("%s/%s", strings.ToLower(kind), name)
+}
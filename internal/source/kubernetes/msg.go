@@ -3,6 +3,7 @@ package kubernetes
 import (
 	"bytes"
 	"fmt"
+	"strings"
 	"text/template"
 
 	sprig "github.com/go-task/slim-sprig"
@@ -17,9 +18,11 @@ import (
 )
 
 var emojiForLevel = map[config.Level]string{
-	config.Success: "🟢",
-	config.Info:    "💡",
-	config.Error:   "❗",
+	config.Success:  "🟢",
+	config.Info:     "💡",
+	config.Warning:  "⚠️",
+	config.Error:    "❗",
+	config.Critical: "🚨",
 }
 
 type EventCommandsGetter interface {
@@ -27,13 +30,15 @@ type EventCommandsGetter interface {
 }
 type MessageBuilder struct {
 	commandsGetter           EventCommandsGetter
+	templates                *MessageTemplates
 	log                      logrus.FieldLogger
 	isInteractivitySupported bool
 }
 
-func NewMessageBuilder(isInteractivitySupported bool, log logrus.FieldLogger, commandsGetter EventCommandsGetter) *MessageBuilder {
+func NewMessageBuilder(isInteractivitySupported bool, log logrus.FieldLogger, commandsGetter EventCommandsGetter, templates *MessageTemplates) *MessageBuilder {
 	return &MessageBuilder{
 		commandsGetter:           commandsGetter,
+		templates:                templates,
 		log:                      log,
 		isInteractivitySupported: isInteractivitySupported,
 	}
@@ -42,9 +47,7 @@ func NewMessageBuilder(isInteractivitySupported bool, log logrus.FieldLogger, co
 func (m *MessageBuilder) FromEvent(event event.Event, actions []config.ExtraButtons) (api.Message, error) {
 	msg := api.Message{
 		Timestamp: event.TimeStamp,
-		Sections: []api.Section{
-			m.baseNotificationSection(event),
-		},
+		Sections:  m.notificationSections(event),
 	}
 
 	if !m.isInteractivitySupported {
@@ -85,21 +88,53 @@ func (m *MessageBuilder) getExtraButtonsAssignedToEvent(actions []config.ExtraBu
 			continue
 		}
 
-		if !slices.Contains(act.Trigger.Type, e.Type) {
+		triggered, err := isTriggered(act.Trigger, e)
+		if err != nil {
+			issues = multierrx.Append(issues, fmt.Errorf("invalid extraButtons[%d].trigger: %s", idx, err))
 			continue
 		}
-
-		btn, err := m.renderActionButton(act, e)
-		if err != nil {
-			issues = multierrx.Append(issues, fmt.Errorf("invalid extraButtons[%d].commandTpl: %s", idx, err))
+		if !triggered {
 			continue
 		}
-		actBtns = append(actBtns, btn)
+
+		for _, btnCfg := range act.AllButtons() {
+			btn, err := m.renderActionButton(btnCfg, e)
+			if err != nil {
+				issues = multierrx.Append(issues, fmt.Errorf("invalid extraButtons[%d].commandTpl: %s", idx, err))
+				continue
+			}
+			actBtns = append(actBtns, btn)
+		}
 	}
 
 	return actBtns, issues.ErrorOrNil()
 }
 
+// isTriggered returns true if a given event matches all criteria defined in the trigger.
+func isTriggered(trigger config.Trigger, e event.Event) (bool, error) {
+	if !slices.Contains(trigger.Type, e.Type) {
+		return false, nil
+	}
+
+	if len(trigger.Kinds) > 0 && !slices.ContainsFunc(trigger.Kinds, func(kind string) bool {
+		return strings.EqualFold(kind, e.Kind)
+	}) {
+		return false, nil
+	}
+
+	if trigger.Reason.AreConstraintsDefined() {
+		match, err := trigger.Reason.IsAllowed(e.Reason)
+		if err != nil {
+			return false, fmt.Errorf("while matching reason: %w", err)
+		}
+		if !match {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
 func ptrSection(s *api.Selects) api.Selects {
 	if s == nil {
 		return api.Selects{}
@@ -142,6 +177,20 @@ func (m *MessageBuilder) getCommandSelectIfShould(event event.Event) (*api.Selec
 	}, nil
 }
 
+// notificationSections returns sections rendered with the message template triggered by a given event.
+// If there is no such template, or it fails, the default layout is used.
+func (m *MessageBuilder) notificationSections(event event.Event) []api.Section {
+	sections, ok, err := m.templates.Render(event)
+	if err != nil {
+		m.log.Errorf("Failed to render message template for %q event. The default layout is used. Issues:\n%s", event.Type.String(), err)
+		return []api.Section{m.baseNotificationSection(event)}
+	}
+	if !ok {
+		return []api.Section{m.baseNotificationSection(event)}
+	}
+	return sections
+}
+
 func (m *MessageBuilder) baseNotificationSection(event event.Event) api.Section {
 	section := api.Section{
 		Base: api.Base{
@@ -153,6 +202,7 @@ func (m *MessageBuilder) baseNotificationSection(event event.Event) api.Section
 	section.TextFields = m.appendTextFieldIfNotEmpty(section.TextFields, "Name", event.Name)
 	section.TextFields = m.appendTextFieldIfNotEmpty(section.TextFields, "Namespace", event.Namespace)
 	section.TextFields = m.appendTextFieldIfNotEmpty(section.TextFields, "Reason", event.Reason)
+	section.TextFields = m.appendTextFieldIfNotEmpty(section.TextFields, "Occurrences", event.Occurrences())
 	section.TextFields = m.appendTextFieldIfNotEmpty(section.TextFields, "Action", event.Action)
 	section.TextFields = m.appendTextFieldIfNotEmpty(section.TextFields, "Cluster", event.Cluster)
 
@@ -184,8 +234,8 @@ func (m *MessageBuilder) appendBulletListIfNotEmpty(bulletLists api.BulletLists,
 	})
 }
 
-func (m *MessageBuilder) renderActionButton(act config.ExtraButtons, e event.Event) (api.Button, error) {
-	tmpl, err := template.New(act.Button.DisplayName).Funcs(sprig.FuncMap()).Parse(act.Button.CommandTpl)
+func (m *MessageBuilder) renderActionButton(btn config.Button, e event.Event) (api.Button, error) {
+	tmpl, err := template.New(btn.DisplayName).Funcs(sprig.FuncMap()).Parse(btn.CommandTpl)
 	if err != nil {
 		return api.Button{}, err
 	}
@@ -196,6 +246,11 @@ func (m *MessageBuilder) renderActionButton(act config.ExtraButtons, e event.Eve
 		return api.Button{}, err
 	}
 
+	style := btn.Style
+	if style == api.ButtonStyleDefault {
+		style = api.ButtonStylePrimary
+	}
+
 	btns := api.NewMessageButtonBuilder()
-	return btns.ForCommandWithoutDesc(act.Button.DisplayName, buf.String(), api.ButtonStylePrimary), nil
+	return btns.ForCommandWithoutDesc(btn.DisplayName, buf.String(), style), nil
 }
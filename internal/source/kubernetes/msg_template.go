@@ -0,0 +1,177 @@
+package kubernetes
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+
+	sprig "github.com/go-task/slim-sprig"
+	"github.com/pmezard/go-difflib/difflib"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kubeshop/botkube/internal/source/kubernetes/config"
+	"github.com/kubeshop/botkube/internal/source/kubernetes/event"
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/multierror"
+)
+
+type messageTemplate struct {
+	trigger config.Trigger
+	tpl     *template.Template
+}
+
+// MessageTemplates renders notification sections with user-defined templates.
+type MessageTemplates struct {
+	templates []messageTemplate
+}
+
+// templateData is passed to message templates. Objects are exposed as maps, so their fields can be accessed directly, e.g. `.Object.spec.replicas`.
+type templateData struct {
+	event.Event
+	Object    map[string]any
+	OldObject map[string]any
+}
+
+// NewMessageTemplates validates the message templates, parses them and returns a new MessageTemplates instance.
+func NewMessageTemplates(cfg []config.MessageTemplate) (*MessageTemplates, error) {
+	out := &MessageTemplates{}
+
+	errs := multierror.New()
+	for idx, tplCfg := range cfg {
+		if len(tplCfg.Trigger.Type) == 0 {
+			errs = multierror.Append(errs, fmt.Errorf("templates[%d]: trigger.type cannot be empty", idx))
+			continue
+		}
+		for i, t := range tplCfg.Trigger.Type {
+			t = config.EventType(strings.ToLower(string(t)))
+			if !t.IsValid() {
+				errs = multierror.Append(errs, fmt.Errorf("templates[%d]: unknown trigger.type[%q]", idx, t))
+			}
+			tplCfg.Trigger.Type[i] = t
+		}
+
+		tpl, err := template.New(fmt.Sprintf("templates[%d]", idx)).Funcs(messageTemplateFuncs()).Parse(tplCfg.Template)
+		if err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("templates[%d]: while parsing template: %w", idx, err))
+			continue
+		}
+		out.templates = append(out.templates, messageTemplate{trigger: tplCfg.Trigger, tpl: tpl})
+	}
+	if err := errs.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// Render renders sections with the first template triggered by a given event.
+// It returns false if no template is triggered, or if the template rendered nothing.
+func (m *MessageTemplates) Render(e event.Event) ([]api.Section, bool, error) {
+	if m == nil {
+		return nil, false, nil
+	}
+
+	for _, t := range m.templates {
+		triggered, err := isTriggered(t.trigger, e)
+		if err != nil {
+			return nil, false, fmt.Errorf("while matching %s trigger: %w", t.tpl.Name(), err)
+		}
+		if !triggered {
+			continue
+		}
+
+		var buf bytes.Buffer
+		if err := t.tpl.Execute(&buf, newTemplateData(e)); err != nil {
+			return nil, false, fmt.Errorf("while rendering %s: %w", t.tpl.Name(), err)
+		}
+		if strings.TrimSpace(buf.String()) == "" {
+			return nil, false, nil
+		}
+
+		var sections []api.Section
+		if err := yaml.Unmarshal(buf.Bytes(), &sections); err != nil {
+			return nil, false, fmt.Errorf("while decoding sections rendered by %s: %w", t.tpl.Name(), err)
+		}
+		return sections, true, nil
+	}
+
+	return nil, false, nil
+}
+
+func newTemplateData(e event.Event) templateData {
+	return templateData{
+		Event:     e,
+		Object:    objectMap(e.Object),
+		OldObject: objectMap(e.OldObject),
+	}
+}
+
+func objectMap(obj any) map[string]any {
+	unstr, ok := obj.(*unstructured.Unstructured)
+	if !ok || unstr == nil {
+		return nil
+	}
+	return unstr.Object
+}
+
+func messageTemplateFuncs() template.FuncMap {
+	funcs := sprig.TxtFuncMap()
+	funcs["diff"] = diffFunc
+	funcs["table"] = tableFunc
+	funcs["severityEmoji"] = severityEmojiFunc
+	return funcs
+}
+
+// diffFunc returns the unified diff of YAML representations of two values, e.g. `{{ diff .OldObject.spec .Object.spec }}`.
+// It returns an empty string if values are equal.
+func diffFunc(before, after any) (string, error) {
+	oldRaw, err := yaml.Marshal(before)
+	if err != nil {
+		return "", fmt.Errorf("while marshaling old value: %w", err)
+	}
+	newRaw, err := yaml.Marshal(after)
+	if err != nil {
+		return "", fmt.Errorf("while marshaling new value: %w", err)
+	}
+
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:       difflib.SplitLines(strings.TrimSuffix(string(oldRaw), "\n")),
+		B:       difflib.SplitLines(strings.TrimSuffix(string(newRaw), "\n")),
+		Context: 1,
+	})
+}
+
+// tableFunc renders rows as a table with aligned columns, e.g. `{{ table (list "NAME" "READY") (list (list "api" true)) }}`.
+func tableFunc(headers []any, rows []any) (string, error) {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 8, 2, ' ', 0)
+
+	writeRow := func(cells []any) {
+		strCells := make([]string, 0, len(cells))
+		for _, cell := range cells {
+			strCells = append(strCells, fmt.Sprint(cell))
+		}
+		fmt.Fprintln(w, strings.Join(strCells, "\t"))
+	}
+
+	writeRow(headers)
+	for idx, row := range rows {
+		cells, ok := row.([]any)
+		if !ok {
+			return "", fmt.Errorf("row %d is %T, not a list", idx, row)
+		}
+		writeRow(cells)
+	}
+	if err := w.Flush(); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// severityEmojiFunc returns the emoji used for a given level, e.g. `{{ severityEmoji .Level }}`.
+func severityEmojiFunc(level any) string {
+	return emojiForLevel[config.Level(fmt.Sprint(level))]
+}
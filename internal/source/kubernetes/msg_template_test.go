@@ -0,0 +1,139 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/kubeshop/botkube/internal/source/kubernetes/config"
+	"github.com/kubeshop/botkube/internal/source/kubernetes/event"
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+func TestMessageTemplatesRender(t *testing.T) {
+	// given
+	templates, err := NewMessageTemplates([]config.MessageTemplate{
+		{
+			Trigger: config.Trigger{Type: []config.EventType{"UPDATE"}, Kinds: []string{"Deployment"}},
+			Template: heredoc.Doc(`
+				- header: {{ severityEmoji .Level }} {{ .Kind }} {{ .Namespace }}/{{ .Name }} scaled
+				  textFields:
+				    - key: Replicas
+				      value: "{{ .Object.spec.replicas }}"
+				  body:
+				    codeBlock: {{ diff .OldObject.spec .Object.spec | toJson }}
+				- header: Containers
+				  body:
+				    codeBlock: {{ table (list "NAME" "IMAGE") (list (list "api" "api:v2") (list "sidecar" "envoy:1.29")) | toJson }}`),
+		},
+		{
+			Trigger:  config.Trigger{Type: []config.EventType{"update"}},
+			Template: `{{ if eq .Namespace "dev" }}- header: ignored{{ end }}`,
+		},
+	})
+	require.NoError(t, err)
+
+	deployment := func(replicas int64) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]any{
+			"spec": map[string]any{"replicas": replicas, "paused": false},
+		}}
+	}
+
+	tests := []struct {
+		name             string
+		event            event.Event
+		expectedSections []api.Section
+		expectedOK       bool
+	}{
+		{
+			name: "Rendered",
+			event: event.Event{
+				Kind: "Deployment", Name: "api", Namespace: "prod", Type: config.UpdateEvent, Level: config.Warning,
+				Object: deployment(3), OldObject: deployment(2),
+			},
+			expectedSections: []api.Section{
+				{
+					Base: api.Base{
+						Header: "⚠️ Deployment prod/api scaled",
+						Body: api.Body{CodeBlock: heredoc.Doc(`
+							@@ -1,2 +1,2 @@
+							 paused: false
+							-replicas: 2
+							+replicas: 3
+						`)},
+					},
+					TextFields: api.TextFields{{Key: "Replicas", Value: "3"}},
+				},
+				{
+					Base: api.Base{
+						Header: "Containers",
+						Body: api.Body{CodeBlock: heredoc.Doc(`
+							NAME     IMAGE
+							api      api:v2
+							sidecar  envoy:1.29`)},
+					},
+				},
+			},
+			expectedOK: true,
+		},
+		{
+			name:       "Not triggered",
+			event:      event.Event{Kind: "Pod", Name: "api", Namespace: "prod", Type: config.CreateEvent},
+			expectedOK: false,
+		},
+		{
+			name:       "Rendered nothing",
+			event:      event.Event{Kind: "Pod", Name: "api", Namespace: "prod", Type: config.UpdateEvent},
+			expectedOK: false,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			sections, ok, err := templates.Render(tc.event)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedOK, ok)
+			assert.Equal(t, tc.expectedSections, sections)
+		})
+	}
+}
+
+func TestNewMessageTemplatesErrors(t *testing.T) {
+	// when
+	_, err := NewMessageTemplates([]config.MessageTemplate{
+		{Template: "- header: test"},
+		{Trigger: config.Trigger{Type: []config.EventType{"scaled"}}, Template: "- header: test"},
+		{Trigger: config.Trigger{Type: []config.EventType{"error"}}, Template: "{{ .Name "},
+	})
+
+	// then
+	assert.EqualError(t, err, heredoc.Doc(`
+		3 errors occurred:
+			* templates[0]: trigger.type cannot be empty
+			* templates[1]: unknown trigger.type["scaled"]
+			* templates[2]: while parsing template: template: templates[2]:1: unclosed action`))
+}
+
+func TestMessageBuilderFallsBackToDefaultLayout(t *testing.T) {
+	// given
+	templates, err := NewMessageTemplates([]config.MessageTemplate{
+		{Trigger: config.Trigger{Type: []config.EventType{"error"}}, Template: "header: not a list"},
+	})
+	require.NoError(t, err)
+	builder := NewMessageBuilder(false, loggerx.NewNoop(), nil, templates)
+	e := event.Event{Kind: "Pod", Name: "api", Title: "v1/pods error", Type: config.ErrorEvent, Level: config.Error}
+
+	// when
+	msg, err := builder.FromEvent(e, nil)
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, msg.Sections, 1)
+	assert.Equal(t, "❗ v1/pods error", msg.Sections[0].Header)
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/kubeshop/botkube/internal/source/kubernetes/config"
 	"github.com/kubeshop/botkube/internal/source/kubernetes/event"
+	"github.com/kubeshop/botkube/pkg/api"
 )
 
 func TestGetExtraButtonsAssignedToEvent(t *testing.T) {
@@ -79,3 +80,79 @@ func TestGetExtraButtonsAssignedToEvent(t *testing.T) {
 		assert.Equal(t, givenButtons[idx].Button.DisplayName, btn.Name)
 	}
 }
+
+func TestGetExtraButtonsAssignedToEventQuickActions(t *testing.T) {
+	// given
+	builder := MessageBuilder{}
+	givenButtons := []config.ExtraButtons{
+		{
+			Enabled: true,
+			Trigger: config.Trigger{
+				Type:   []config.EventType{"error"},
+				Kinds:  []string{"pod"},
+				Reason: config.RegexConstraints{Include: []string{"BackOff|Failed"}},
+			},
+			Buttons: []config.Button{
+				{DisplayName: "Logs", CommandTpl: "kubectl logs pod/{{ .Name }} -n {{ .Namespace }}"},
+				{DisplayName: "Restart", CommandTpl: "kubectl delete pod {{ .Name }} -n {{ .Namespace }}", Style: api.ButtonStyleDanger},
+			},
+		},
+		{
+			Enabled: true,
+			Trigger: config.Trigger{
+				Type:   []config.EventType{"error"},
+				Kinds:  []string{"Node"},
+				Reason: config.RegexConstraints{Include: []string{"Node.*Pressure"}},
+			},
+			Button: config.Button{DisplayName: "Cordon", CommandTpl: "kubectl cordon {{ .Name }}"},
+		},
+		{
+			Enabled: true,
+			Trigger: config.Trigger{
+				Type: []config.EventType{"error"},
+			},
+			Buttons: []config.Button{
+				{DisplayName: "Invalid", CommandTpl: "kubectl get po", Style: "blue"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		event    event.Event
+		expBtns  api.Buttons
+		expIssue string
+	}{
+		{
+			name:  "pod failure",
+			event: event.Event{Type: "error", Kind: "Pod", Name: "api", Namespace: "prod", Reason: "BackOff"},
+			expBtns: api.Buttons{
+				{Name: "Logs", Command: api.MessageBotNamePlaceholder + " kubectl logs pod/api -n prod", Style: api.ButtonStylePrimary},
+				{Name: "Restart", Command: api.MessageBotNamePlaceholder + " kubectl delete pod api -n prod", Style: api.ButtonStyleDanger},
+			},
+		},
+		{
+			name:  "node pressure",
+			event: event.Event{Type: "error", Kind: "Node", Name: "node-1", Reason: "NodeHasDiskPressure"},
+			expBtns: api.Buttons{
+				{Name: "Cordon", Command: api.MessageBotNamePlaceholder + " kubectl cordon node-1", Style: api.ButtonStylePrimary},
+			},
+		},
+		{
+			name:  "pod with not matching reason",
+			event: event.Event{Type: "error", Kind: "Pod", Name: "api", Reason: "Scheduled"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			gotBtns, err := builder.getExtraButtonsAssignedToEvent(givenButtons, tc.event)
+
+			// then
+			assert.EqualError(t, err, heredoc.Doc(`
+				1 error occurred:
+					* invalid extraButtons[2]: unknown buttons[0].style "blue"`))
+			assert.Equal(t, tc.expBtns, gotBtns)
+		})
+	}
+}
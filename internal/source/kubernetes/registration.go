@@ -7,7 +7,6 @@ import (
 	"strings"
 
 	"github.com/sirupsen/logrus"
-	coreV1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -17,7 +16,6 @@ import (
 	"github.com/kubeshop/botkube/internal/source/kubernetes/config"
 	"github.com/kubeshop/botkube/internal/source/kubernetes/event"
 	"github.com/kubeshop/botkube/internal/source/kubernetes/k8sutil"
-	"github.com/kubeshop/botkube/pkg/k8sx"
 	"github.com/kubeshop/botkube/pkg/multierror"
 )
 
@@ -44,6 +42,7 @@ func (r registration) handleEvent(ctx context.Context, resource string, eventTyp
 			logger.Errorf("while creating new event: %s", err.Error())
 			return
 		}
+		event.OldObject = oldObj
 
 		sources, diffs, err := r.qualifyEvent(event, newObj, oldObj, routes)
 		if err != nil {
@@ -70,56 +69,99 @@ func (r registration) handleEvent(ctx context.Context, resource string, eventTyp
 }
 
 func (r registration) handleMapped(ctx context.Context, eventType config.EventType, routeTable map[string][]entry, fn eventHandler) {
-	_, _ = r.informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
-		AddFunc: func(obj interface{}) {
-			var eventObj coreV1.Event
-			err := k8sx.TransformIntoTypedObject(obj.(*unstructured.Unstructured), &eventObj)
-			if err != nil {
-				r.log.Errorf("Unable to transform object type: %v, into type: %v", reflect.TypeOf(obj), reflect.TypeOf(eventObj))
-				return
-			}
-			_, err = cache.MetaNamespaceKeyFunc(obj)
-			if err != nil {
-				r.log.Errorf("Failed to get MetaNamespaceKey from event resource")
-				return
-			}
+	handleFunc := func(obj interface{}) {
+		eventObj, err := k8sutil.ToCoreEvent(obj.(*unstructured.Unstructured))
+		if err != nil {
+			r.log.Errorf("Unable to transform object type: %v, into type: %v", reflect.TypeOf(obj), reflect.TypeOf(eventObj))
+			return
+		}
+		_, err = cache.MetaNamespaceKeyFunc(obj)
+		if err != nil {
+			r.log.Errorf("Failed to get MetaNamespaceKey from event resource")
+			return
+		}
 
-			// Find involved object type
-			gvr, err := k8sutil.GetResourceFromKind(r.mapper, eventObj.InvolvedObject.GroupVersionKind())
-			if err != nil {
-				r.log.Errorf("Failed to get involved object: %v", err)
-				return
-			}
+		// Find involved object type
+		gvr, err := k8sutil.GetResourceFromKind(r.mapper, eventObj.InvolvedObject.GroupVersionKind())
+		if err != nil {
+			r.log.Errorf("Failed to get involved object: %v", err)
+			return
+		}
 
-			if !r.canHandleEvent(eventObj.Type) {
-				return
-			}
+		if !r.canHandleEvent(eventObj.Type) {
+			return
+		}
 
-			gvrString := gvrToString(gvr)
-			if !r.includesSrcResource(gvrString) {
-				return
-			}
+		gvrString := gvrToString(gvr)
+		if !r.includesSrcResource(gvrString) {
+			return
+		}
 
-			event, err := r.eventForObj(ctx, obj, eventType, gvrString)
-			if err != nil {
-				r.log.Errorf("while creating new event: %s", err.Error())
-				return
-			}
+		event, err := r.eventForObj(ctx, obj, eventType, gvrString)
+		if err != nil {
+			r.log.Errorf("while creating new event: %s", err.Error())
+			return
+		}
 
-			routes := eventRoutes(routeTable, gvrString, eventType)
-			sources, err := r.matchEvent(routes, event)
-			if err != nil {
-				r.log.Errorf("cannot calculate event for observed mapped resource event: %q in Add event handler: %s", eventType, err.Error())
-				// continue anyway, there could be still some sources to handle
-			}
-			if len(sources) == 0 {
+		routes := eventRoutes(routeTable, gvrString, eventType)
+		sources, err := r.matchEvent(routes, event)
+		if err != nil {
+			r.log.Errorf("cannot calculate event for observed mapped resource event: %q in Add event handler: %s", eventType, err.Error())
+			// continue anyway, there could be still some sources to handle
+		}
+		if len(sources) == 0 {
+			return
+		}
+		fn(ctx, event, sources, nil)
+	}
+
+	_, _ = r.informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: handleFunc,
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			if !isSeriesMilestone(oldObj, newObj) {
 				return
 			}
-			fn(ctx, event, sources, nil)
+			handleFunc(newObj)
 		},
 	})
 }
 
+// isSeriesMilestone returns true if the number of occurrences of a repeated event reached the next power of two, e.g. 2, 4, 8 and so on.
+// This way, an event repeated with exponential back-off, such as "BackOff (x400 over 2h)", is reported a few times instead of hundreds.
+//
+// Updates of objects with the same resource version are skipped. They are emitted on informer resyncs, and once the watch is resumed
+// with the full list of events, e.g. after the API server restart, so already reported events are not reported again.
+func isSeriesMilestone(oldObj, newObj interface{}) bool {
+	oldUnstruct, ok := oldObj.(*unstructured.Unstructured)
+	if !ok {
+		return false
+	}
+	newUnstruct, ok := newObj.(*unstructured.Unstructured)
+	if !ok {
+		return false
+	}
+	if oldUnstruct.GetResourceVersion() == newUnstruct.GetResourceVersion() {
+		return false
+	}
+
+	oldEvent, err := k8sutil.ToCoreEvent(oldUnstruct)
+	if err != nil {
+		return false
+	}
+	newEvent, err := k8sutil.ToCoreEvent(newUnstruct)
+	if err != nil {
+		return false
+	}
+
+	oldCount, newCount := k8sutil.SeriesCount(oldEvent), k8sutil.SeriesCount(newEvent)
+	for milestone := int32(2); milestone > 0 && milestone <= newCount; milestone *= 2 {
+		if milestone > oldCount {
+			return true
+		}
+	}
+	return false
+}
+
 func (r registration) canHandleEvent(target string) bool {
 	for _, e := range r.events {
 		if strings.EqualFold(target, e.String()) {
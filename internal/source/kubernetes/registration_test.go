@@ -0,0 +1,38 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestIsSeriesMilestone(t *testing.T) {
+	event := func(resourceVersion string, count int64) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "events.k8s.io/v1",
+			"kind":       "Event",
+			"metadata":   map[string]any{"name": "api.17a1", "namespace": "prod", "resourceVersion": resourceVersion},
+			"reason":     "BackOff",
+			"series":     map[string]any{"count": count},
+		}}
+	}
+
+	tests := []struct {
+		name     string
+		old      *unstructured.Unstructured
+		new      *unstructured.Unstructured
+		expected bool
+	}{
+		{name: "Second occurrence", old: event("1", 1), new: event("2", 2), expected: true},
+		{name: "Power of two reached", old: event("1", 250), new: event("2", 256), expected: true},
+		{name: "Between powers of two", old: event("1", 257), new: event("2", 400), expected: false},
+		{name: "Resync", old: event("1", 1), new: event("1", 1), expected: false},
+		{name: "Updated without new occurrences", old: event("1", 4), new: event("2", 4), expected: false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, isSeriesMilestone(tc.old, tc.new))
+		})
+	}
+}
@@ -14,7 +14,8 @@ import (
 	"github.com/kubeshop/botkube/pkg/formatx"
 )
 
-const eventsResource = "v1/events"
+// eventsResource is watched to report Kubernetes events. The events.k8s.io/v1 API exposes event series, so repeated events are reported as updates.
+const eventsResource = "events.k8s.io/v1/events"
 
 type mergedEvents map[string]map[config.EventType]struct{}
 type registrationHandler func(resource string) (cache.SharedIndexInformer, error)
@@ -95,12 +96,12 @@ func (r *Router) RegisterInformers(targetEvents []config.EventType, handler regi
 }
 
 // MapWithEventsInformer allows resources to report on an event (srcEvent)
-// that can only be observed by watching the general k8s v1/events resource
+// that can only be observed by watching the general k8s events resource
 // using a different event (dstEvent).
 //
 // For example, you can report the "error" EventType for a resource
 // by having the router watch/interrogate the "warning" EventType
-// reported by the v1/events resource.
+// reported by the events resource.
 func (r *Router) MapWithEventsInformer(srcEvent config.EventType, dstEvent config.EventType, handler registrationHandler) error {
 	srcResources := r.resourcesForEvents([]config.EventType{srcEvent})
 	if len(srcResources) == 0 {
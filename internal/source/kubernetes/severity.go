@@ -0,0 +1,130 @@
+package kubernetes
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/internal/filter"
+	"github.com/kubeshop/botkube/internal/source/kubernetes/config"
+	"github.com/kubeshop/botkube/internal/source/kubernetes/event"
+	"github.com/kubeshop/botkube/pkg/multierror"
+)
+
+type severityRule struct {
+	cfg     config.SeverityRule
+	program *filter.Program
+}
+
+// SeverityMapper sets event levels based on the configured severity rules.
+type SeverityMapper struct {
+	log   logrus.FieldLogger
+	rules []severityRule
+}
+
+// NewSeverityMapper validates the severity rules, compiles their expressions and returns a new SeverityMapper instance.
+func NewSeverityMapper(log logrus.FieldLogger, cfg *config.Severity) (*SeverityMapper, error) {
+	mapper := &SeverityMapper{log: log}
+	if cfg == nil {
+		return mapper, nil
+	}
+
+	errs := multierror.New()
+	for idx, ruleCfg := range cfg.Rules {
+		if !ruleCfg.Level.IsValid() {
+			errs = multierror.Append(errs, fmt.Errorf("rules[%d]: unknown level %q", idx, ruleCfg.Level))
+			continue
+		}
+		for i, t := range ruleCfg.Types {
+			t = config.EventType(strings.ToLower(string(t)))
+			if !t.IsValid() {
+				errs = multierror.Append(errs, fmt.Errorf("rules[%d]: unknown type %q", idx, t))
+			}
+			ruleCfg.Types[i] = t
+		}
+
+		rule := severityRule{cfg: ruleCfg}
+		if ruleCfg.Expression != "" {
+			program, err := filter.Compile(ruleCfg.Expression)
+			if err != nil {
+				errs = multierror.Append(errs, fmt.Errorf("rules[%d]: while compiling expression: %w", idx, err))
+				continue
+			}
+			rule.program = program
+		}
+		mapper.rules = append(mapper.rules, rule)
+	}
+	if err := errs.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+
+	return mapper, nil
+}
+
+// Apply sets the level of a given event according to the first matching rule.
+func (m *SeverityMapper) Apply(e *event.Event) {
+	var vars map[string]any
+	for idx, rule := range m.rules {
+		matched, err := rule.matches(*e, func() (map[string]any, error) {
+			if vars != nil {
+				return vars, nil
+			}
+			var err error
+			vars, err = filter.Variables(filter.Input{Event: e, ClusterName: e.Cluster})
+			return vars, err
+		})
+		if err != nil {
+			m.log.WithError(err).Debugf("Cannot evaluate severity rule %d. Skipping...", idx)
+			continue
+		}
+		if matched {
+			e.Level = rule.cfg.Level
+			return
+		}
+	}
+}
+
+func (r severityRule) matches(e event.Event, vars func() (map[string]any, error)) (bool, error) {
+	if len(r.cfg.Types) > 0 && !containsType(r.cfg.Types, e.Type) {
+		return false, nil
+	}
+
+	if len(r.cfg.Kinds) > 0 && !containsFold(r.cfg.Kinds, e.Kind) {
+		return false, nil
+	}
+
+	if r.cfg.Reason.AreConstraintsDefined() {
+		allowed, err := r.cfg.Reason.IsAllowed(e.Reason)
+		if err != nil || !allowed {
+			return false, err
+		}
+	}
+
+	if r.program == nil {
+		return true, nil
+	}
+	in, err := vars()
+	if err != nil {
+		return false, fmt.Errorf("while preparing variables: %w", err)
+	}
+	return r.program.EvalBool(in)
+}
+
+func containsType(types []config.EventType, t config.EventType) bool {
+	for _, item := range types {
+		if item == t {
+			return true
+		}
+	}
+	return false
+}
+
+func containsFold(items []string, value string) bool {
+	for _, item := range items {
+		if strings.EqualFold(item, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,95 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/internal/source/kubernetes/config"
+	"github.com/kubeshop/botkube/internal/source/kubernetes/event"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+func TestSeverityMapperApply(t *testing.T) {
+	// given
+	mapper, err := NewSeverityMapper(loggerx.NewNoop(), &config.Severity{
+		Rules: []config.SeverityRule{
+			{
+				Types: []config.EventType{"Error"},
+				Reason: config.RegexConstraints{
+					Include: []string{"OOMKilling", "Node.*Pressure"},
+				},
+				Level: config.Critical,
+			},
+			{
+				Kinds:      []string{"pod"},
+				Expression: `event.Namespace.startsWith("dev-")`,
+				Level:      config.Warning,
+			},
+			{
+				Types: []config.EventType{config.DeleteEvent},
+				Level: config.Warning,
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		in            event.Event
+		expectedLevel config.Level
+	}{
+		{
+			name:          "matching type and reason",
+			in:            event.Event{Kind: "Node", Type: config.ErrorEvent, Reason: "NodeMemoryPressure", Level: config.Error},
+			expectedLevel: config.Critical,
+		},
+		{
+			name:          "matching kind and expression",
+			in:            event.Event{Kind: "Pod", Namespace: "dev-payments", Type: config.ErrorEvent, Reason: "BackOff", Level: config.Error},
+			expectedLevel: config.Warning,
+		},
+		{
+			name:          "matching type",
+			in:            event.Event{Kind: "Deployment", Type: config.DeleteEvent, Level: config.Info},
+			expectedLevel: config.Warning,
+		},
+		{
+			name:          "not matching expression",
+			in:            event.Event{Kind: "Pod", Namespace: "prod-payments", Type: config.ErrorEvent, Reason: "BackOff", Level: config.Error},
+			expectedLevel: config.Error,
+		},
+		{
+			name:          "no matching rules",
+			in:            event.Event{Kind: "Pod", Namespace: "prod-payments", Type: config.CreateEvent, Level: config.Info},
+			expectedLevel: config.Info,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			mapper.Apply(&tc.in)
+
+			// then
+			assert.Equal(t, tc.expectedLevel, tc.in.Level)
+		})
+	}
+}
+
+func TestNewSeverityMapperErrors(t *testing.T) {
+	// when
+	_, err := NewSeverityMapper(loggerx.NewNoop(), &config.Severity{
+		Rules: []config.SeverityRule{
+			{Level: "urgent"},
+			{Types: []config.EventType{"restart"}, Level: config.Error},
+			{Expression: "event.Name ==", Level: config.Error},
+		},
+	})
+
+	// then
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `rules[0]: unknown level "urgent"`)
+	assert.Contains(t, err.Error(), `rules[1]: unknown type "restart"`)
+	assert.Contains(t, err.Error(), "rules[2]: while compiling expression")
+}
@@ -12,15 +12,17 @@ import (
 	"github.com/sirupsen/logrus"
 	"golang.org/x/exp/maps"
 	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	"k8s.io/client-go/dynamic/dynamicinformer"
 	"k8s.io/client-go/tools/cache"
 
 	"github.com/kubeshop/botkube/internal/command"
+	"github.com/kubeshop/botkube/internal/sharding"
 	"github.com/kubeshop/botkube/internal/source/kubernetes/commander"
 	"github.com/kubeshop/botkube/internal/source/kubernetes/config"
 	"github.com/kubeshop/botkube/internal/source/kubernetes/event"
 	"github.com/kubeshop/botkube/internal/source/kubernetes/filterengine"
+	"github.com/kubeshop/botkube/internal/source/kubernetes/k8sutil"
 	"github.com/kubeshop/botkube/internal/source/kubernetes/recommendation"
 	"github.com/kubeshop/botkube/pkg/api"
 	"github.com/kubeshop/botkube/pkg/api/source"
@@ -79,6 +81,7 @@ type ActiveSourceConfig struct {
 	messageBuilder *MessageBuilder
 	filterEngine   *filterengine.DefaultFilterEngine
 	recommFactory  *recommendation.Factory
+	severityMapper *SeverityMapper
 }
 
 // NewSource returns a new instance of Source.
@@ -148,7 +151,7 @@ func (s *Source) Stream(ctx context.Context, input source.StreamInput) (source.S
 
 	var fns []func(context.Context)
 	for kubeConfig, srcCfgs := range cfgsByKubeConfig {
-		fn := s.genFnForKubeconfig(id, []byte(kubeConfig), globalLogger, systemSrcCfg.cfg.InformerResyncPeriod, srcCfgs)
+		fn := s.genFnForKubeconfig(id, []byte(kubeConfig), globalLogger, systemSrcCfg.cfg.InformerResyncPeriod, systemSrcCfg.cfg.Informers, systemSrcCfg.cfg.Sharding, srcCfgs)
 		fns = append(fns, fn)
 	}
 
@@ -159,7 +162,7 @@ func (s *Source) Stream(ctx context.Context, input source.StreamInput) (source.S
 	}, nil
 }
 
-func (s *Source) configureProcessForSources(ctx context.Context, id int, kubeConfig []byte, globalLogger logrus.FieldLogger, informerResyncPeriod time.Duration, srcCfgs map[string]SourceConfig) error {
+func (s *Source) configureProcessForSources(ctx context.Context, id int, kubeConfig []byte, globalLogger logrus.FieldLogger, informerResyncPeriod time.Duration, informers *config.Informers, shard *sharding.Shard, srcCfgs map[string]SourceConfig) error {
 	client, err := NewClient(kubeConfig)
 	if err != nil {
 		return fmt.Errorf("while creating Kubernetes client: %w", err)
@@ -176,13 +179,22 @@ func (s *Source) configureProcessForSources(ctx context.Context, id int, kubeCon
 
 		recommFactory := recommendation.NewFactory(logger.WithField("component", "Recommendations"), client.dynamicCli)
 		filterEngine := filterengine.WithAllFilters(logger, client.dynamicCli, client.mapper, cfg.Filters)
-		messageBuilder := NewMessageBuilder(srcCfg.isInteractivitySupported, logger.WithField(componentLogFieldKey, "Message Builder"), cmdr)
+		msgTemplates, err := NewMessageTemplates(cfg.Templates)
+		if err != nil {
+			return fmt.Errorf("while parsing message templates for source %q: %w", srcCfg.name, err)
+		}
+		messageBuilder := NewMessageBuilder(srcCfg.isInteractivitySupported, logger.WithField(componentLogFieldKey, "Message Builder"), cmdr, msgTemplates)
+		severityMapper, err := NewSeverityMapper(logger.WithField(componentLogFieldKey, "Severity Mapper"), cfg.Severity)
+		if err != nil {
+			return fmt.Errorf("while creating severity mapper for source %q: %w", srcCfg.name, err)
+		}
 
 		srcCfg.ActiveSourceConfig = &ActiveSourceConfig{
 			logger:         logger,
 			recommFactory:  recommFactory,
 			filterEngine:   filterEngine,
 			messageBuilder: messageBuilder,
+			severityMapper: severityMapper,
 		}
 
 		s.configStore.Store(srcCfg.name, srcCfg)
@@ -191,8 +203,11 @@ func (s *Source) configureProcessForSources(ctx context.Context, id int, kubeCon
 	router := NewRouter(client.mapper, client.dynamicCli, globalLogger)
 	router.BuildTable(srcCfgs)
 
+	if shard != nil {
+		globalLogger.Infof("Handling namespaces of shard %d out of %d...", shard.Index, shard.Count)
+	}
 	globalLogger.Info("Registering informers...")
-	dynamicKubeInformerFactory := dynamicinformer.NewDynamicSharedInformerFactory(client.dynamicCli, informerResyncPeriod)
+	kubeInformerFactory := newInformerFactory(globalLogger, client, informerResyncPeriod, informers)
 
 	err = router.RegisterInformers([]config.EventType{
 		config.CreateEvent,
@@ -204,7 +219,7 @@ func (s *Source) configureProcessForSources(ctx context.Context, id int, kubeCon
 			globalLogger.WithError(err).Errorf("Unable to parse resource: %s to register with informer\n", resource)
 			return nil, err
 		}
-		return dynamicKubeInformerFactory.ForResource(gvr).Informer(), nil
+		return kubeInformerFactory.ForResource(gvr)
 	})
 	if err != nil {
 		exitOnError(err, globalLogger.WithFields(logrus.Fields{
@@ -226,7 +241,7 @@ func (s *Source) configureProcessForSources(ctx context.Context, id int, kubeCon
 				globalLogger.WithError(err).Errorf("Unable to parse resource: %s to register with informer\n", resource)
 				return nil, err
 			}
-			return dynamicKubeInformerFactory.ForResource(gvr).Informer(), nil
+			return kubeInformerFactory.ForResource(gvr)
 		})
 	if err != nil {
 		return fmt.Errorf("while mapping with events informer: %w", err)
@@ -241,29 +256,34 @@ func (s *Source) configureProcessForSources(ctx context.Context, id int, kubeCon
 		router.RegisterEventHandler(
 			ctx,
 			eventType,
-			s.handleEventFn(globalLogger),
+			s.handleEventFn(globalLogger, client, shard),
 		)
 	}
 
 	router.HandleMappedEvent(
 		ctx,
 		config.ErrorEvent,
-		s.handleEventFn(globalLogger),
+		s.handleEventFn(globalLogger, client, shard),
 	)
 
 	globalLogger.Info("Starting background process...")
 	stopCh := ctx.Done()
-	dynamicKubeInformerFactory.Start(stopCh)
+	kubeInformerFactory.Start(stopCh)
 	<-stopCh
-	dynamicKubeInformerFactory.Shutdown()
+	kubeInformerFactory.Shutdown()
 	globalLogger.Info("Stopped background process...")
 	return nil
 }
 
-func (s *Source) handleEventFn(log logrus.FieldLogger) func(ctx context.Context, e event.Event, sources, updateDiffs []string) {
+func (s *Source) handleEventFn(log logrus.FieldLogger, client *Client, shard *sharding.Shard) func(ctx context.Context, e event.Event, sources, updateDiffs []string) {
 	globalLogger := log
 
 	return func(ctx context.Context, e event.Event, sources, updateDiffs []string) {
+		// other replicas handle namespaces of their shards
+		if shard != nil && !shard.Owns(e.Namespace) {
+			return
+		}
+
 		globalLogger.Debugf("Processing %s to %s/%v in %s namespace", e.Type, e.Resource, e.Name, e.Namespace)
 
 		// Skip older events
@@ -282,6 +302,8 @@ func (s *Source) handleEventFn(log logrus.FieldLogger) func(ctx context.Context,
 			e.Messages = append(e.Messages, updateDiffs...)
 		}
 
+		actionCtx := actionContextFor(ctx, globalLogger, client, e)
+
 		errs := multierror.New()
 		for _, sourceKey := range sources {
 			eventCopy := e
@@ -300,9 +322,9 @@ func (s *Source) handleEventFn(log logrus.FieldLogger) func(ctx context.Context,
 			eventCopy.Cluster = srcCfg.clusterName
 
 			// Filter events
-			e = srcCfg.filterEngine.Run(ctx, eventCopy)
-			if e.Skip {
-				srcCfg.logger.WithField("event", e).Debugf("Skipping event as skip flag is set to true")
+			eventCopy = srcCfg.filterEngine.Run(ctx, eventCopy)
+			if eventCopy.Skip {
+				srcCfg.logger.WithField("event", eventCopy).Debugf("Skipping event as skip flag is set to true")
 				continue
 			}
 
@@ -318,6 +340,8 @@ func (s *Source) handleEventFn(log logrus.FieldLogger) func(ctx context.Context,
 				continue
 			}
 
+			srcCfg.severityMapper.Apply(&eventCopy)
+
 			msg, err := srcCfg.messageBuilder.FromEvent(eventCopy, srcCfg.cfg.ExtraButtons)
 			if err != nil {
 				errs = multierror.Append(errs, fmt.Errorf("while building message from event: %w", err))
@@ -328,6 +352,8 @@ func (s *Source) handleEventFn(log logrus.FieldLogger) func(ctx context.Context,
 				Message:         msg,
 				RawObject:       eventCopy,
 				AnalyticsLabels: event.AnonymizedEventDetailsFrom(eventCopy),
+				ActionContext:   actionCtx,
+				Channels:        eventCopy.Channels,
 			}
 
 			srcCfg.eventCh <- message
@@ -339,9 +365,26 @@ func (s *Source) handleEventFn(log logrus.FieldLogger) func(ctx context.Context,
 	}
 }
 
-func (s *Source) genFnForKubeconfig(id int, kubeConfig []byte, globalLogger logrus.FieldLogger, informerResyncPeriod time.Duration, srcCfgs map[string]SourceConfig) func(ctx context.Context) {
+// actionContextFor returns the full object and its top-most owner, so they can be used in action command templates.
+func actionContextFor(ctx context.Context, log logrus.FieldLogger, client *Client, e event.Event) source.ActionContext {
+	var actionCtx source.ActionContext
+	if obj, ok := e.Object.(*unstructured.Unstructured); ok {
+		actionCtx.Object = obj.Object
+	}
+
+	gvk := schema.FromAPIVersionAndKind(e.APIVersion, e.Kind)
+	topOwnerRef, err := k8sutil.GetTopOwnerRef(ctx, client.dynamicCli, client.mapper, gvk, e.Namespace, e.Name)
+	if err != nil {
+		log.WithError(err).Debugf("Cannot resolve top owner of %s/%s", e.Kind, e.Name)
+		return actionCtx
+	}
+	actionCtx.TopOwnerRef = topOwnerRef
+	return actionCtx
+}
+
+func (s *Source) genFnForKubeconfig(id int, kubeConfig []byte, globalLogger logrus.FieldLogger, informerResyncPeriod time.Duration, informers *config.Informers, shard *sharding.Shard, srcCfgs map[string]SourceConfig) func(ctx context.Context) {
 	return func(ctx context.Context) {
-		err := s.configureProcessForSources(ctx, id, kubeConfig, globalLogger, informerResyncPeriod, srcCfgs)
+		err := s.configureProcessForSources(ctx, id, kubeConfig, globalLogger, informerResyncPeriod, informers, shard, srcCfgs)
 		if err != nil {
 			exitOnError(fmt.Errorf("while configuring process for sources"), globalLogger.WithError(err).WithField("srcCfgs", maps.Keys(srcCfgs)))
 		}
@@ -0,0 +1,130 @@
+package source
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/kubeshop/botkube/internal/filter"
+	"github.com/kubeshop/botkube/internal/replay"
+	"github.com/kubeshop/botkube/internal/routing"
+	"github.com/kubeshop/botkube/pkg/api/source"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/notifier"
+)
+
+var _ replay.Pipeline = &Dispatcher{}
+
+// Replay evaluates a given event in the notification pipeline without sending it, and reports which channels and sinks would receive it.
+// Processor plugins, maintenance windows and incident tracking are skipped, as they keep state of seen events.
+func (d *Dispatcher) Replay(ctx context.Context, req replay.Request) (replay.Report, error) {
+	dispatches := d.dispatchesFor(req.Source)
+	if len(dispatches) == 0 {
+		return replay.Report{}, fmt.Errorf("%w: %q", replay.ErrUnknownSource, req.Source)
+	}
+
+	event := source.Event{
+		Message:       replay.Message(req),
+		RawObject:     req.Event,
+		ActionContext: source.ActionContext{Object: req.Object},
+	}
+	event = d.owners.Assign(ctx, event)
+	event = d.enricher.Enrich(ctx, event, req.Source)
+	event.Message = d.redactor.RedactMessage(event.Message)
+	event.RawObject = d.redactor.RedactValue(event.RawObject)
+
+	in := filter.Input{
+		SourceName:        req.Source,
+		SourceDisplayName: dispatches[0].sourceDisplayName,
+		PluginName:        dispatches[0].pluginName,
+		ClusterName:       d.clusterName,
+		Event:             event.RawObject,
+		Message:           event.Message,
+	}
+	report := replay.Report{
+		Source:  req.Source,
+		Owner:   ownerTeam(event),
+		Sources: []string{req.Source},
+	}
+	if len(event.Message.Sections) > 0 {
+		report.Header = event.Message.Sections[0].Header
+	}
+
+	channels, routed := d.router.Route(routing.Notification{
+		SourceName: req.Source,
+		Object:     event.ActionContext.Object,
+		Owner:      report.Owner,
+	})
+	report.Routed = routed
+	if !routed {
+		channels = nil
+	}
+
+	delivery := bufferedDelivery{Input: in, Message: interactive.CoreMessage{Message: event.Message}, Sources: report.Sources, Channels: channels}
+	for _, dispatch := range dispatches {
+		for _, n := range d.getBotNotifiers(dispatch) {
+			filtered := d.filterChannels(n, delivery)
+			for _, next := range filtered.deliveries {
+				report.Targets = append(report.Targets, botTargets(n, next.Sources, next.Channels)...)
+			}
+			report.Filters = append(report.Filters, filtered.matched...)
+			if len(filtered.dropped) > 0 {
+				report.Dropped = append(report.Dropped, botTargets(n, nil, filtered.dropped)...)
+			}
+		}
+		if dispatch.isInteractivitySupported || dispatch.cfg == nil {
+			continue
+		}
+		report.Targets = append(report.Targets, replay.SinkTargets(dispatch.cfg.Communications, report.Sources)...)
+	}
+	slices.Sort(report.Filters)
+	report.Filters = slices.Compact(report.Filters)
+	return report, nil
+}
+
+// dispatchesFor returns started dispatches of a given source binding.
+func (d *Dispatcher) dispatchesFor(sourceName string) []PluginDispatch {
+	var out []PluginDispatch
+	d.dispatches.Range(func(_, value any) bool {
+		dispatch := value.(PluginDispatch)
+		if dispatch.sourceName == sourceName {
+			out = append(out, dispatch)
+		}
+		return true
+	})
+	// interactive and non-interactive dispatches are reported in the same order
+	slices.SortFunc(out, func(a, b PluginDispatch) int {
+		return boolOrder(a.isInteractivitySupported) - boolOrder(b.isInteractivitySupported)
+	})
+	return out
+}
+
+// botTargets returns channels of a given bot which receive a notification sent to given source bindings, or given channels if set.
+// Bots which don't list channels are reported without them.
+func botTargets(n notifier.Bot, sources, channels []string) []replay.Target {
+	lister, ok := n.(notifier.ChannelLister)
+	if !ok {
+		return []replay.Target{{Integration: string(n.IntegrationName())}}
+	}
+
+	var out []replay.Target
+	for _, ch := range lister.NotificationChannels() {
+		if !isChannelSelected(ch, sources, channels) {
+			continue
+		}
+
+		name := ch.Alias
+		if name == "" {
+			name = ch.Name
+		}
+		out = append(out, replay.Target{Integration: string(n.IntegrationName()), Channel: name})
+	}
+	return out
+}
+
+func boolOrder(in bool) int {
+	if in {
+		return 1
+	}
+	return 0
+}
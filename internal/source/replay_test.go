@@ -0,0 +1,203 @@
+package source
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/internal/enrichment"
+	"github.com/kubeshop/botkube/internal/filter"
+	"github.com/kubeshop/botkube/internal/redaction"
+	"github.com/kubeshop/botkube/internal/replay"
+	"github.com/kubeshop/botkube/internal/routing"
+	"github.com/kubeshop/botkube/pkg/api/source"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+	"github.com/kubeshop/botkube/pkg/notifier"
+)
+
+type fakeListerBot struct {
+	integration config.CommPlatformIntegration
+	channels    []notifier.NotificationChannel
+	sent        [][]string
+}
+
+func (f *fakeListerBot) SendMessageToAll(context.Context, interactive.CoreMessage) error { return nil }
+func (f *fakeListerBot) SendMessage(context.Context, interactive.CoreMessage, []string) error {
+	return nil
+}
+func (f *fakeListerBot) SendMessageToChannels(_ context.Context, _ interactive.CoreMessage, channels []string) error {
+	f.sent = append(f.sent, channels)
+	return nil
+}
+func (f *fakeListerBot) IntegrationName() config.CommPlatformIntegration { return f.integration }
+func (f *fakeListerBot) Type() config.IntegrationType                    { return config.BotIntegrationType }
+func (f *fakeListerBot) NotificationChannels() []notifier.NotificationChannel {
+	return f.channels
+}
+
+type fakeOwnerResolver struct {
+	team string
+}
+
+func (f fakeOwnerResolver) Assign(_ context.Context, event source.Event) source.Event {
+	event.ActionContext.Owner = &source.Owner{Team: f.team}
+	return event
+}
+
+func TestDispatcherReplay(t *testing.T) {
+	// given
+	log := loggerx.NewNoop()
+	enricher, err := enrichment.NewEnricher(log, "prod", nil)
+	require.NoError(t, err)
+	redactor, err := redaction.New(log, config.Redaction{}, nil)
+	require.NoError(t, err)
+	filterEngine, err := filter.NewEngine(log, config.Filters{
+		"drop-kube-system": {
+			Enabled:    true,
+			Expression: `event.Namespace == "kube-system"`,
+			Action:     config.DropFilterAction,
+		},
+		"reroute-errors": {
+			Enabled:    true,
+			Expression: `event.Type == "error"`,
+			Action:     config.RerouteFilterAction,
+			RouteTo:    []string{"critical"},
+		},
+	})
+	require.NoError(t, err)
+
+	slack := &fakeListerBot{
+		integration: config.SocketSlackCommPlatformIntegration,
+		channels: []notifier.NotificationChannel{
+			{Name: "C1", Alias: "general", Sources: []string{"k8s-events"}, Filters: []string{"reroute-errors", "drop-kube-system"}},
+			{Name: "C2", Alias: "oncall", Sources: []string{"critical"}},
+			{Name: "C3", Alias: "payments", Sources: []string{"other"}},
+			{Name: "C4", Alias: "audit", Sources: []string{"k8s-events"}},
+		},
+	}
+	cfg := &config.Config{
+		Communications: map[string]config.Communications{
+			"default": {
+				Webhook: config.Webhook{Enabled: true, Bindings: config.SinkBindings{Sources: []string{"k8s-events"}}},
+				Kafka: config.Kafka{Enabled: true, Topics: map[string]config.KafkaTopic{
+					"alerts": {Bindings: config.SinkBindings{Sources: []string{"critical"}}},
+				}},
+			},
+		},
+	}
+
+	d := &Dispatcher{
+		log:               log,
+		owners:            fakeOwnerResolver{team: "payments"},
+		enricher:          enricher,
+		redactor:          redactor,
+		filter:            filterEngine,
+		markdownNotifiers: []notifier.Bot{slack},
+		clusterName:       "prod",
+	}
+	d.dispatches.Store("k8s-events", PluginDispatch{pluginName: "botkube/kubernetes", sourceName: "k8s-events", cfg: cfg})
+
+	tests := []struct {
+		name      string
+		synthetic replay.Synthetic
+		routing   config.Routing
+		expected  replay.Report
+	}{
+		{
+			name:      "Source bindings",
+			synthetic: replay.Synthetic{Kind: "Pod", Name: "api", Namespace: "prod", Type: "create"},
+			expected: replay.Report{
+				Source:  "k8s-events",
+				Header:  "Pod prod/api",
+				Owner:   "payments",
+				Sources: []string{"k8s-events"},
+				Targets: []replay.Target{
+					{Integration: "socketSlack", Channel: "general"},
+					{Integration: "socketSlack", Channel: "audit"},
+					{Integration: "webhook"},
+				},
+			},
+		},
+		{
+			name:      "Rerouted",
+			synthetic: replay.Synthetic{Kind: "Pod", Name: "api", Namespace: "prod", Type: "error", Reason: "BackOff"},
+			expected: replay.Report{
+				Source:  "k8s-events",
+				Header:  "Pod prod/api BackOff",
+				Owner:   "payments",
+				Filters: []string{"reroute-errors"},
+				Sources: []string{"k8s-events"},
+				Targets: []replay.Target{
+					{Integration: "socketSlack", Channel: "oncall"},
+					{Integration: "socketSlack", Channel: "audit"},
+					{Integration: "webhook"},
+				},
+			},
+		},
+		{
+			name:      "Dropped",
+			synthetic: replay.Synthetic{Kind: "Pod", Name: "dns", Namespace: "kube-system", Type: "error"},
+			expected: replay.Report{
+				Source:  "k8s-events",
+				Header:  "Pod kube-system/dns",
+				Owner:   "payments",
+				Filters: []string{"drop-kube-system"},
+				Sources: []string{"k8s-events"},
+				Targets: []replay.Target{
+					{Integration: "socketSlack", Channel: "audit"},
+					{Integration: "webhook"},
+				},
+				Dropped: []replay.Target{
+					{Integration: "socketSlack", Channel: "general"},
+				},
+			},
+		},
+		{
+			name:      "Routed by owner",
+			synthetic: replay.Synthetic{Kind: "Pod", Name: "api", Namespace: "prod", Type: "create"},
+			routing: config.Routing{
+				Enabled: true,
+				Rules:   []config.RoutingRule{{Owners: []string{"payments"}, Channels: []string{"payments"}}},
+			},
+			expected: replay.Report{
+				Source:  "k8s-events",
+				Header:  "Pod prod/api",
+				Owner:   "payments",
+				Sources: []string{"k8s-events"},
+				Routed:  true,
+				Targets: []replay.Target{
+					{Integration: "socketSlack", Channel: "payments"},
+					{Integration: "webhook"},
+				},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			d.router = routing.NewRouter(log, tc.routing)
+
+			// when
+			report, err := d.Replay(context.Background(), tc.synthetic.Request("k8s-events"))
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, report)
+		})
+	}
+}
+
+func TestDispatcherReplayUnknownSource(t *testing.T) {
+	// given
+	d := &Dispatcher{log: loggerx.NewNoop()}
+
+	// when
+	_, err := d.Replay(context.Background(), replay.Request{Source: "unknown"})
+
+	// then
+	assert.ErrorIs(t, err, replay.ErrUnknownSource)
+	assert.EqualError(t, err, `source binding is not started: "unknown"`)
+}
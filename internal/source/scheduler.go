@@ -38,6 +38,7 @@ type PluginDispatch struct {
 type ExternalRequestDispatch struct {
 	PluginDispatch
 	payload []byte
+	http    source.HTTPRequestContext
 }
 
 // StartedSources holds information about started source plugins grouped by interactivity supported.
@@ -230,6 +231,34 @@ func (d *Scheduler) generateConfigs(ctx context.Context) error {
 				return err
 			}
 		}
+
+		if commGroupCfg.Kafka.Enabled {
+			for _, topic := range commGroupCfg.Kafka.Topics {
+				if err := d.generateSourceConfigs(ctx, false, topic.Bindings.Sources); err != nil {
+					return err
+				}
+			}
+		}
+
+		if commGroupCfg.NATS.Enabled {
+			for _, subject := range commGroupCfg.NATS.Subjects {
+				if err := d.generateSourceConfigs(ctx, false, subject.Bindings.Sources); err != nil {
+					return err
+				}
+			}
+		}
+
+		if commGroupCfg.S3Archive.Enabled {
+			if err := d.generateSourceConfigs(ctx, false, commGroupCfg.S3Archive.Bindings.Sources); err != nil {
+				return err
+			}
+		}
+
+		if commGroupCfg.Warehouse.Enabled {
+			if err := d.generateSourceConfigs(ctx, false, commGroupCfg.Warehouse.Bindings.Sources); err != nil {
+				return err
+			}
+		}
 	}
 
 	// Schedule all sources used by actions
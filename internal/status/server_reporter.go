@@ -0,0 +1,93 @@
+package status
+
+import (
+	"context"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/internal/config/remote"
+	"github.com/kubeshop/botkube/pkg/version"
+)
+
+var _ StatusReporter = (*ServerStatusReporter)(nil)
+
+// ServerClient defines client for a self-hosted configuration server.
+type ServerClient interface {
+	ReportStatus(ctx context.Context, status remote.AgentStatus) error
+}
+
+// ServerStatusReporter reports status to a self-hosted configuration server.
+type ServerStatusReporter struct {
+	log    logrus.FieldLogger
+	client ServerClient
+
+	mu              sync.RWMutex
+	resourceVersion int
+	k8sVer          string
+}
+
+// NewServerStatusReporter returns a new ServerStatusReporter instance.
+func NewServerStatusReporter(log logrus.FieldLogger, client ServerClient) *ServerStatusReporter {
+	return &ServerStatusReporter{
+		log:    withDefaultLogger(log).WithField("component", "ServerStatusReporter"),
+		client: client,
+	}
+}
+
+// ReportDeploymentConnectionInit reports connection initialization.
+func (r *ServerStatusReporter) ReportDeploymentConnectionInit(ctx context.Context, k8sVer string) error {
+	r.mu.Lock()
+	r.k8sVer = k8sVer
+	r.mu.Unlock()
+	return r.report(ctx, remote.AgentPhaseConnecting, "")
+}
+
+// ReportDeploymentStartup reports deployment startup.
+func (r *ServerStatusReporter) ReportDeploymentStartup(ctx context.Context) error {
+	return r.report(ctx, remote.AgentPhaseConnected, "")
+}
+
+// ReportDeploymentShutdown reports deployment shutdown.
+func (r *ServerStatusReporter) ReportDeploymentShutdown(ctx context.Context) error {
+	return r.report(ctx, remote.AgentPhaseDisconnected, "")
+}
+
+// ReportDeploymentFailure reports deployment failure.
+func (r *ServerStatusReporter) ReportDeploymentFailure(ctx context.Context, errMsg string) error {
+	return r.report(ctx, remote.AgentPhaseFailure, errMsg)
+}
+
+// SetResourceVersion sets resource version.
+func (r *ServerStatusReporter) SetResourceVersion(resourceVersion int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.resourceVersion = resourceVersion
+}
+
+func (r *ServerStatusReporter) SetLogger(logger logrus.FieldLogger) {
+	r.log = logger.WithField("component", "ServerStatusReporter")
+}
+
+func (r *ServerStatusReporter) report(ctx context.Context, phase remote.AgentPhase, msg string) error {
+	r.mu.RLock()
+	status := remote.AgentStatus{
+		Phase:           phase,
+		Message:         msg,
+		ResourceVersion: r.resourceVersion,
+		BotkubeVersion:  version.Info().Version,
+		K8sVersion:      r.k8sVer,
+	}
+	r.mu.RUnlock()
+
+	logger := r.log.WithFields(logrus.Fields{
+		"phase":           phase,
+		"resourceVersion": status.ResourceVersion,
+	})
+	logger.Debug("Reporting...")
+	if err := r.client.ReportStatus(ctx, status); err != nil {
+		return err
+	}
+	logger.Debug("Reporting successful.")
+	return nil
+}
@@ -0,0 +1,51 @@
+package status_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/internal/config/remote"
+	"github.com/kubeshop/botkube/internal/status"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+type fakeServerClient struct {
+	statuses []remote.AgentStatus
+}
+
+func (f *fakeServerClient) ReportStatus(_ context.Context, status remote.AgentStatus) error {
+	f.statuses = append(f.statuses, status)
+	return nil
+}
+
+func TestServerStatusReporter(t *testing.T) {
+	// given
+	client := &fakeServerClient{}
+	reporter := status.NewServerStatusReporter(loggerx.NewNoop(), client)
+	ctx := context.Background()
+
+	// when
+	require.NoError(t, reporter.ReportDeploymentConnectionInit(ctx, "v1.28.0"))
+	reporter.SetResourceVersion(3)
+	require.NoError(t, reporter.ReportDeploymentStartup(ctx))
+	require.NoError(t, reporter.ReportDeploymentFailure(ctx, "while starting controller"))
+	require.NoError(t, reporter.ReportDeploymentShutdown(ctx))
+
+	// then
+	require.Len(t, client.statuses, 4)
+	assert.Equal(t, remote.AgentPhaseConnecting, client.statuses[0].Phase)
+	assert.Equal(t, 0, client.statuses[0].ResourceVersion)
+	assert.Equal(t, remote.AgentPhaseConnected, client.statuses[1].Phase)
+	assert.Equal(t, 3, client.statuses[1].ResourceVersion)
+	assert.Equal(t, remote.AgentStatus{
+		Phase:           remote.AgentPhaseFailure,
+		Message:         "while starting controller",
+		ResourceVersion: 3,
+		BotkubeVersion:  client.statuses[2].BotkubeVersion,
+		K8sVersion:      "v1.28.0",
+	}, client.statuses[2])
+	assert.Equal(t, remote.AgentPhaseDisconnected, client.statuses[3].Phase)
+}
@@ -0,0 +1,109 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// systemConfigMap stores sets of entries under separate keys of the system ConfigMap.
+type systemConfigMap struct {
+	name      string
+	namespace string
+
+	k8sCli kubernetes.Interface
+}
+
+// getEntries returns entries stored under a given key.
+func (s *systemConfigMap) getEntries(ctx context.Context, key string) (map[string]bool, error) {
+	obj, err := s.k8sCli.CoreV1().ConfigMaps(s.namespace).Get(ctx, s.name, metav1.GetOptions{})
+	switch {
+	case err == nil:
+	case apierrors.IsNotFound(err):
+		return map[string]bool{}, nil
+	default:
+		return map[string]bool{}, fmt.Errorf("while getting the Config Map: %w", err)
+	}
+
+	return extractEntries(obj, key)
+}
+
+// markEntries adds given entries to the ones stored under a given key.
+func (s *systemConfigMap) markEntries(ctx context.Context, key string, entries []string) error {
+	marked := map[string]bool{}
+	for _, item := range entries {
+		marked[item] = true
+	}
+	rawMarked, err := json.Marshal(marked)
+	if err != nil {
+		return fmt.Errorf("while marshaling input keys: %w", err)
+	}
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      s.name,
+			Namespace: s.namespace,
+		},
+		Data: map[string]string{
+			key: string(rawMarked),
+		},
+	}
+
+	_, err = s.k8sCli.CoreV1().ConfigMaps(s.namespace).Create(ctx, cm, metav1.CreateOptions{})
+	switch {
+	case err == nil:
+	case apierrors.IsAlreadyExists(err):
+		old, err := s.k8sCli.CoreV1().ConfigMaps(cm.Namespace).Get(ctx, cm.Name, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("while getting already existing ConfigMap: %w", err)
+		}
+
+		previousEntries, err := extractEntries(old, key)
+		if err != nil {
+			return fmt.Errorf("while extracting %s details: %w", key, err)
+		}
+
+		for _, item := range entries {
+			previousEntries[item] = true
+		}
+
+		newRawMarked, err := json.Marshal(previousEntries)
+		if err != nil {
+			return fmt.Errorf("while marshaling final output: %w", err)
+		}
+
+		newCM := old.DeepCopy()
+		if newCM.Data == nil {
+			newCM.Data = map[string]string{}
+		}
+		newCM.Data[key] = string(newRawMarked)
+
+		_, err = s.k8sCli.CoreV1().ConfigMaps(cm.Namespace).Update(ctx, newCM, metav1.UpdateOptions{})
+		if err != nil {
+			return fmt.Errorf("while updating the ConfigMap with %s details: %w", key, err)
+		}
+
+	default:
+		return fmt.Errorf("while creating the ConfigMap with %s details: %w", key, err)
+	}
+
+	return nil
+}
+
+func extractEntries(cm *corev1.ConfigMap, key string) (map[string]bool, error) {
+	data, found := cm.Data[key]
+	if !found {
+		return map[string]bool{}, nil
+	}
+
+	out := map[string]bool{}
+	if err := json.Unmarshal([]byte(data), &out); err != nil {
+		return map[string]bool{}, fmt.Errorf("while unmarhshaling the %s data: %w", key, err)
+	}
+	return out, nil
+}
@@ -0,0 +1,38 @@
+package storage
+
+import (
+	"context"
+
+	"k8s.io/client-go/kubernetes"
+)
+
+// OnboardingEntries holds keys of channels to which the setup wizard was already sent.
+type OnboardingEntries map[string]bool
+
+const onboardingKey = "onboarding"
+
+// Onboarding provides functionality to persist the information about channels which got the setup wizard.
+type Onboarding struct {
+	cm systemConfigMap
+}
+
+// NewForOnboarding returns a new Onboarding instance.
+func NewForOnboarding(ns, name string, k8sCli kubernetes.Interface) *Onboarding {
+	return &Onboarding{
+		cm: systemConfigMap{
+			namespace: ns,
+			name:      name,
+			k8sCli:    k8sCli,
+		},
+	}
+}
+
+// GetOnboardedChannels returns keys of channels to which the setup wizard was already sent.
+func (o *Onboarding) GetOnboardedChannels(ctx context.Context) (OnboardingEntries, error) {
+	return o.cm.getEntries(ctx, onboardingKey)
+}
+
+// MarkChannelsAsOnboarded marks given channel keys as onboarded.
+func (o *Onboarding) MarkChannelsAsOnboarded(ctx context.Context, channels []string) error {
+	return o.cm.markEntries(ctx, onboardingKey, channels)
+}
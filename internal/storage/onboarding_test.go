@@ -0,0 +1,42 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestOnboardingPreservesOtherEntries(t *testing.T) {
+	// given
+	cli := fake.NewSimpleClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "botkube-system", Namespace: "botkube"},
+		Data: map[string]string{
+			helpKey: `{"default-group/socketSlack":true}`,
+		},
+	})
+	onboarding := NewForOnboarding("botkube", "botkube-system", cli)
+	help := NewForHelp("botkube", "botkube-system", cli)
+
+	// when
+	err := onboarding.MarkChannelsAsOnboarded(context.Background(), []string{"default-group/socketSlack/alerts"})
+	require.NoError(t, err)
+	err = onboarding.MarkChannelsAsOnboarded(context.Background(), []string{"default-group/socketSlack/dev"})
+	require.NoError(t, err)
+
+	// then
+	channels, err := onboarding.GetOnboardedChannels(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, OnboardingEntries{
+		"default-group/socketSlack/alerts": true,
+		"default-group/socketSlack/dev":    true,
+	}, channels)
+
+	sentHelp, err := help.GetSentHelpDetails(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, HelpEntries{"default-group/socketSlack": true}, sentHelp)
+}
@@ -0,0 +1,85 @@
+package ticketing
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/google/go-github/v53/github"
+	"golang.org/x/oauth2"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/httpx"
+)
+
+var _ Tracker = &gitHubTracker{}
+
+type gitHubTracker struct {
+	cli *github.Client
+	cfg config.GitHubTickets
+}
+
+func newGitHubTracker(cfg config.GitHubTickets) (*gitHubTracker, error) {
+	httpCli := oauth2.NewClient(context.Background(), oauth2.StaticTokenSource(&oauth2.Token{AccessToken: cfg.Token}))
+	httpCli.Timeout = httpx.DefaultTimeout
+
+	cli := github.NewClient(httpCli)
+	if cfg.BaseURL != "" {
+		var err error
+		cli, err = github.NewEnterpriseClient(cfg.BaseURL, cfg.BaseURL, httpCli)
+		if err != nil {
+			return nil, fmt.Errorf("while creating GitHub Enterprise client: %w", err)
+		}
+	}
+
+	return &gitHubTracker{cli: cli, cfg: cfg}, nil
+}
+
+func (g *gitHubTracker) Create(ctx context.Context, ticket Ticket) (Reference, error) {
+	req := &github.IssueRequest{
+		Title: github.String(ticket.Title),
+		Body:  github.String(ticket.Body),
+	}
+	if len(g.cfg.Labels) > 0 {
+		req.Labels = &g.cfg.Labels
+	}
+
+	issue, _, err := g.cli.Issues.Create(ctx, g.cfg.Owner, g.cfg.Repo, req)
+	if err != nil {
+		return Reference{}, fmt.Errorf("while creating GitHub issue: %w", err)
+	}
+
+	return Reference{
+		ID:  strconv.Itoa(issue.GetNumber()),
+		URL: issue.GetHTMLURL(),
+	}, nil
+}
+
+func (g *gitHubTracker) Comment(ctx context.Context, ref Reference, body string) error {
+	number, err := strconv.Atoi(ref.ID)
+	if err != nil {
+		return fmt.Errorf("while parsing GitHub issue number: %w", err)
+	}
+
+	_, _, err = g.cli.Issues.CreateComment(ctx, g.cfg.Owner, g.cfg.Repo, number, &github.IssueComment{Body: github.String(body)})
+	if err != nil {
+		return fmt.Errorf("while commenting GitHub issue %d: %w", number, err)
+	}
+	return nil
+}
+
+func (g *gitHubTracker) Close(ctx context.Context, ref Reference, comment string) error {
+	if err := g.Comment(ctx, ref, comment); err != nil {
+		return err
+	}
+
+	number, _ := strconv.Atoi(ref.ID) // already validated by Comment
+	_, _, err := g.cli.Issues.Edit(ctx, g.cfg.Owner, g.cfg.Repo, number, &github.IssueRequest{
+		State:       github.String("closed"),
+		StateReason: github.String("completed"),
+	})
+	if err != nil {
+		return fmt.Errorf("while closing GitHub issue %d: %w", number, err)
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+package ticketing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/httpx"
+)
+
+const (
+	defaultJiraIssueType       = "Bug"
+	defaultJiraCloseTransition = "Done"
+)
+
+var _ Tracker = &jiraTracker{}
+
+type jiraTracker struct {
+	httpCli *http.Client
+	cfg     config.JiraTickets
+}
+
+func newJiraTracker(cfg config.JiraTickets) *jiraTracker {
+	if cfg.IssueType == "" {
+		cfg.IssueType = defaultJiraIssueType
+	}
+	if cfg.CloseTransition == "" {
+		cfg.CloseTransition = defaultJiraCloseTransition
+	}
+	cfg.URL = strings.TrimSuffix(cfg.URL, "/")
+
+	return &jiraTracker{
+		httpCli: httpx.NewHTTPClient(),
+		cfg:     cfg,
+	}
+}
+
+type jiraIssueFields struct {
+	Project     jiraKey  `json:"project"`
+	Summary     string   `json:"summary"`
+	Description string   `json:"description"`
+	IssueType   jiraName `json:"issuetype"`
+	Labels      []string `json:"labels,omitempty"`
+}
+
+type jiraKey struct {
+	Key string `json:"key"`
+}
+
+type jiraName struct {
+	Name string `json:"name"`
+}
+
+type jiraTransition struct {
+	ID   string `json:"id"`
+	Name string `json:"name,omitempty"`
+}
+
+func (j *jiraTracker) Create(ctx context.Context, ticket Ticket) (Reference, error) {
+	in := struct {
+		Fields jiraIssueFields `json:"fields"`
+	}{
+		Fields: jiraIssueFields{
+			Project:     jiraKey{Key: j.cfg.Project},
+			Summary:     ticket.Title,
+			Description: ticket.Body,
+			IssueType:   jiraName{Name: j.cfg.IssueType},
+			Labels:      j.cfg.Labels,
+		},
+	}
+
+	var out jiraKey
+	if err := j.do(ctx, http.MethodPost, "/rest/api/2/issue", in, &out); err != nil {
+		return Reference{}, fmt.Errorf("while creating Jira issue: %w", err)
+	}
+
+	return Reference{
+		ID:  out.Key,
+		URL: fmt.Sprintf("%s/browse/%s", j.cfg.URL, out.Key),
+	}, nil
+}
+
+func (j *jiraTracker) Comment(ctx context.Context, ref Reference, body string) error {
+	in := map[string]string{"body": body}
+	if err := j.do(ctx, http.MethodPost, issuePath(ref, "comment"), in, nil); err != nil {
+		return fmt.Errorf("while commenting Jira issue %s: %w", ref.ID, err)
+	}
+	return nil
+}
+
+func (j *jiraTracker) Close(ctx context.Context, ref Reference, comment string) error {
+	if err := j.Comment(ctx, ref, comment); err != nil {
+		return err
+	}
+
+	var out struct {
+		Transitions []jiraTransition `json:"transitions"`
+	}
+	if err := j.do(ctx, http.MethodGet, issuePath(ref, "transitions"), nil, &out); err != nil {
+		return fmt.Errorf("while listing transitions of Jira issue %s: %w", ref.ID, err)
+	}
+
+	var transition *jiraTransition
+	for idx := range out.Transitions {
+		if strings.EqualFold(out.Transitions[idx].Name, j.cfg.CloseTransition) {
+			transition = &out.Transitions[idx]
+			break
+		}
+	}
+	if transition == nil {
+		return fmt.Errorf("transition %q not found for Jira issue %s", j.cfg.CloseTransition, ref.ID)
+	}
+
+	in := map[string]jiraTransition{"transition": {ID: transition.ID}}
+	if err := j.do(ctx, http.MethodPost, issuePath(ref, "transitions"), in, nil); err != nil {
+		return fmt.Errorf("while closing Jira issue %s: %w", ref.ID, err)
+	}
+	return nil
+}
+
+func (j *jiraTracker) do(ctx context.Context, method, path string, in, out any) error {
+	var body io.Reader
+	if in != nil {
+		raw, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("while marshaling request body: %w", err)
+		}
+		body = bytes.NewReader(raw)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, j.cfg.URL+path, body)
+	if err != nil {
+		return fmt.Errorf("while creating request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if j.cfg.Username != "" {
+		req.SetBasicAuth(j.cfg.Username, j.cfg.Token)
+	} else {
+		req.Header.Set("Authorization", "Bearer "+j.cfg.Token)
+	}
+
+	res, err := j.httpCli.Do(req)
+	if err != nil {
+		return fmt.Errorf("while sending request: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		raw, _ := io.ReadAll(io.LimitReader(res.Body, 4096))
+		return fmt.Errorf("got unexpected status code %d: %s", res.StatusCode, raw)
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(res.Body).Decode(out); err != nil {
+		return fmt.Errorf("while decoding response body: %w", err)
+	}
+	return nil
+}
+
+func issuePath(ref Reference, subresource string) string {
+	return fmt.Sprintf("/rest/api/2/issue/%s/%s", url.PathEscape(ref.ID), subresource)
+}
@@ -0,0 +1,356 @@
+package ticketing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	sprig "github.com/go-task/slim-sprig"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+
+	"github.com/kubeshop/botkube/internal/filter"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/multierror"
+)
+
+// Notification holds a source notification evaluated by ticket policies.
+type Notification struct {
+	filter.Input
+	// Interactive is set if the notification is dispatched to platforms which support interactive buttons.
+	Interactive bool
+}
+
+type policy struct {
+	name      string
+	cfg       config.TicketPolicy
+	condition *filter.Program
+	resolve   *filter.Program
+	title     *template.Template
+	tracker   Tracker
+}
+
+// resource identifies the object a given event is about.
+type resource struct {
+	Kind      string
+	Namespace string
+	Name      string
+	Reason    string
+}
+
+func (r resource) String() string {
+	if r.Namespace == "" {
+		return fmt.Sprintf("%s %s", r.Kind, r.Name)
+	}
+	return fmt.Sprintf("%s %s/%s", r.Kind, r.Namespace, r.Name)
+}
+
+// entry is a dedup registry entry for a given policy, resource and reason.
+type entry struct {
+	policy   string
+	resource resource
+	// interactive is the dispatch which created the entry. The same event may be dispatched separately
+	// for interactive and non-interactive platforms, so the other dispatch is ignored to not count failures twice.
+	interactive bool
+	// failures holds times of failures which didn't result in a ticket yet.
+	failures []time.Time
+	// occurrences is the number of failures since the ticket was filed.
+	occurrences   int
+	ticket        *Reference
+	lastCommentAt time.Time
+}
+
+type titleData struct {
+	Event   any
+	Source  string
+	Cluster string
+	Count   int
+}
+
+// Manager files tickets for recurring failures. It maintains a dedup registry of open tickets, so repeated failures
+// are added as comments to the already open ticket, and closes tickets once resources recover.
+type Manager struct {
+	log      logrus.FieldLogger
+	policies []policy
+	now      func() time.Time
+
+	// mu is held during calls to issue trackers, so concurrent events don't file duplicated tickets.
+	mu       sync.Mutex
+	registry map[string]*entry
+}
+
+// NewManager compiles all enabled ticket policies and returns a new Manager instance.
+func NewManager(log logrus.FieldLogger, cfg config.Tickets) (*Manager, error) {
+	names := maps.Keys(cfg)
+	sort.Strings(names)
+
+	errs := multierror.New()
+	var policies []policy
+	for _, name := range names {
+		policyCfg := cfg[name]
+		if !policyCfg.Enabled {
+			continue
+		}
+
+		p, err := newPolicy(name, policyCfg)
+		if err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("while creating ticket policy %q: %w", name, err))
+			continue
+		}
+		policies = append(policies, p)
+	}
+	if err := errs.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+
+	return &Manager{
+		log:      log,
+		policies: policies,
+		now:      time.Now,
+		registry: map[string]*entry{},
+	}, nil
+}
+
+func newPolicy(name string, cfg config.TicketPolicy) (policy, error) {
+	p := policy{name: name, cfg: cfg}
+
+	var err error
+	if cfg.Condition != "" {
+		p.condition, err = filter.Compile(cfg.Condition)
+		if err != nil {
+			return policy{}, fmt.Errorf("while compiling condition: %w", err)
+		}
+	}
+	if cfg.ResolveCondition != "" {
+		p.resolve, err = filter.Compile(cfg.ResolveCondition)
+		if err != nil {
+			return policy{}, fmt.Errorf("while compiling resolve condition: %w", err)
+		}
+	}
+	if cfg.Title != "" {
+		p.title, err = template.New(name).Funcs(sprig.TxtFuncMap()).Parse(cfg.Title)
+		if err != nil {
+			return policy{}, fmt.Errorf("while parsing title template: %w", err)
+		}
+	}
+
+	p.tracker, err = newTracker(cfg)
+	if err != nil {
+		return policy{}, err
+	}
+	return p, nil
+}
+
+// Handle evaluates ticket policies for a given notification. Matching failures are counted per resource and event reason,
+// and a ticket is filed once the policy threshold is reached. Further failures are added as comments to the open ticket.
+// Matching recovery events close all open tickets for a given resource.
+func (m *Manager) Handle(ctx context.Context, in Notification) {
+	var (
+		vars map[string]any
+		res  resource
+	)
+	for _, p := range m.policies {
+		if !slices.Contains(p.cfg.Sources, in.SourceName) {
+			continue
+		}
+		log := m.log.WithField("ticketPolicy", p.name)
+
+		if vars == nil {
+			var err error
+			res, err = resourceOf(in.Event)
+			if err != nil {
+				log.WithError(err).Debug("Cannot identify resource. Skipping...")
+				return
+			}
+			vars, err = filter.Variables(in.Input)
+			if err != nil {
+				log.WithError(err).Error("Cannot prepare ticket condition variables")
+				return
+			}
+		}
+
+		if p.resolve != nil {
+			resolved, err := p.resolve.EvalBool(vars)
+			if err != nil {
+				log.WithError(err).Debug("Cannot evaluate resolve condition. Skipping...")
+				continue
+			}
+			if resolved {
+				m.resolve(ctx, log, p, res)
+				continue
+			}
+		}
+
+		if p.condition != nil {
+			failed, err := p.condition.EvalBool(vars)
+			if err != nil {
+				log.WithError(err).Debug("Cannot evaluate condition. Skipping...")
+				continue
+			}
+			if !failed {
+				continue
+			}
+		}
+		m.fail(ctx, log, p, res, in)
+	}
+}
+
+func (m *Manager) fail(ctx context.Context, log logrus.FieldLogger, p policy, res resource, in Notification) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	m.prune(now)
+
+	key := registryKey(p.name, res)
+	e, ok := m.registry[key]
+	if !ok {
+		e = &entry{policy: p.name, resource: res, interactive: in.Interactive}
+		m.registry[key] = e
+	}
+	if e.interactive != in.Interactive {
+		return
+	}
+
+	if e.ticket != nil {
+		e.occurrences++
+		if p.cfg.CommentInterval > 0 && now.Sub(e.lastCommentAt) < p.cfg.CommentInterval {
+			return
+		}
+		body := fmt.Sprintf("Failure occurred again at %s (occurrence %d).\n\n%s", now.UTC().Format(time.RFC3339), e.occurrences, plaintext(in))
+		if err := p.tracker.Comment(ctx, *e.ticket, body); err != nil {
+			log.WithError(err).Error("Cannot comment ticket")
+			return
+		}
+		e.lastCommentAt = now
+		return
+	}
+
+	e.failures = append(e.failures, now)
+	threshold := p.cfg.Threshold
+	if threshold < 1 {
+		threshold = 1
+	}
+	if len(e.failures) < threshold {
+		return
+	}
+
+	ticket := Ticket{
+		Title: m.title(log, p, res, in, len(e.failures)),
+		Body:  fmt.Sprintf("%s\n\nResource: %s\nReason: %s\nSource: %s\nCluster: %s", plaintext(in), res, res.Reason, in.SourceName, in.ClusterName),
+	}
+	ref, err := p.tracker.Create(ctx, ticket)
+	if err != nil {
+		log.WithError(err).Error("Cannot file ticket")
+		return
+	}
+
+	log.WithField("ticket", ref.ID).Infof("Filed ticket for %s", res)
+	e.ticket = &ref
+	e.failures = nil
+	e.occurrences = 1
+	e.lastCommentAt = now
+}
+
+func (m *Manager) resolve(ctx context.Context, log logrus.FieldLogger, p policy, res resource) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	comment := fmt.Sprintf("%s recovered at %s. Closing the ticket.", res, now.UTC().Format(time.RFC3339))
+	for key, e := range m.registry {
+		// a recovery event has a different reason, so all entries for a given resource are resolved
+		if e.policy != p.name || e.resource.Kind != res.Kind || e.resource.Namespace != res.Namespace || e.resource.Name != res.Name {
+			continue
+		}
+
+		if e.ticket != nil {
+			if err := p.tracker.Close(ctx, *e.ticket, comment); err != nil {
+				log.WithError(err).WithField("ticket", e.ticket.ID).Error("Cannot close ticket")
+				continue
+			}
+			log.WithField("ticket", e.ticket.ID).Infof("Closed ticket for %s", res)
+		}
+		delete(m.registry, key)
+	}
+}
+
+// prune removes failures which are out of the policy window, and entries without failures and tickets.
+// It must be called with the lock held.
+func (m *Manager) prune(now time.Time) {
+	windows := make(map[string]time.Duration, len(m.policies))
+	for _, p := range m.policies {
+		windows[p.name] = p.cfg.Window
+	}
+
+	for key, e := range m.registry {
+		if e.ticket != nil {
+			continue
+		}
+		window := windows[e.policy]
+		if window > 0 {
+			e.failures = slices.DeleteFunc(e.failures, func(t time.Time) bool {
+				return now.Sub(t) >= window
+			})
+		}
+		if len(e.failures) == 0 {
+			delete(m.registry, key)
+		}
+	}
+}
+
+func (m *Manager) title(log logrus.FieldLogger, p policy, res resource, in Notification, count int) string {
+	if p.title != nil {
+		var buff strings.Builder
+		err := p.title.Execute(&buff, titleData{
+			Event:   in.Event,
+			Source:  in.SourceName,
+			Cluster: in.ClusterName,
+			Count:   count,
+		})
+		if err == nil && strings.TrimSpace(buff.String()) != "" {
+			return strings.TrimSpace(buff.String())
+		}
+		log.WithError(err).Error("Cannot render ticket title. Using the default one...")
+	}
+
+	title := fmt.Sprintf("%s is failing", res)
+	if res.Reason != "" {
+		title = fmt.Sprintf("%s: %s", title, res.Reason)
+	}
+	if in.ClusterName != "" {
+		title = fmt.Sprintf("[%s] %s", in.ClusterName, title)
+	}
+	return title
+}
+
+func plaintext(in Notification) string {
+	return strings.TrimSpace(interactive.MessageToPlaintext(interactive.CoreMessage{Message: in.Message}, interactive.NewlineFormatter))
+}
+
+func resourceOf(event any) (resource, error) {
+	raw, err := json.Marshal(event)
+	if err != nil {
+		return resource{}, fmt.Errorf("while marshaling event: %w", err)
+	}
+
+	var out resource
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return resource{}, fmt.Errorf("while unmarshaling event: %w", err)
+	}
+	if out.Kind == "" || out.Name == "" {
+		return resource{}, fmt.Errorf("event doesn't describe any resource")
+	}
+	return out, nil
+}
+
+func registryKey(policy string, res resource) string {
+	return strings.Join([]string{policy, res.Kind, res.Namespace, res.Name, res.Reason}, "/")
+}
@@ -0,0 +1,170 @@
+package ticketing
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/internal/filter"
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+type fakeTracker struct {
+	created  []Ticket
+	comments map[string][]string
+	closed   []string
+}
+
+func (f *fakeTracker) Create(_ context.Context, ticket Ticket) (Reference, error) {
+	f.created = append(f.created, ticket)
+	id := fmt.Sprintf("OPS-%d", len(f.created))
+	return Reference{ID: id, URL: "https://jira.example.com/browse/" + id}, nil
+}
+
+func (f *fakeTracker) Comment(_ context.Context, ref Reference, body string) error {
+	if f.comments == nil {
+		f.comments = map[string][]string{}
+	}
+	f.comments[ref.ID] = append(f.comments[ref.ID], body)
+	return nil
+}
+
+func (f *fakeTracker) Close(_ context.Context, ref Reference, _ string) error {
+	f.closed = append(f.closed, ref.ID)
+	return nil
+}
+
+func TestManagerHandle(t *testing.T) {
+	// given
+	now := time.Date(2023, 1, 1, 10, 0, 0, 0, time.UTC)
+	tracker := &fakeTracker{}
+	manager := newTestManager(t, config.TicketPolicy{
+		Enabled:          true,
+		Sources:          []string{"k8s-events"},
+		Condition:        `event.Level == "error"`,
+		ResolveCondition: `event.Level == "info"`,
+		Threshold:        2,
+		Window:           time.Hour,
+		CommentInterval:  10 * time.Minute,
+	}, tracker, func() time.Time { return now })
+
+	failure := fixInput("error", "BackOff")
+
+	// when first failure is below threshold
+	manager.Handle(context.Background(), failure)
+
+	// then
+	assert.Empty(t, tracker.created)
+
+	// when the same event is dispatched to interactive platforms
+	interactive := failure
+	interactive.Interactive = true
+	manager.Handle(context.Background(), interactive)
+
+	// then
+	assert.Empty(t, tracker.created)
+
+	// when the failure recurs
+	now = now.Add(time.Minute)
+	manager.Handle(context.Background(), failure)
+
+	// then
+	require.Len(t, tracker.created, 1)
+	assert.Equal(t, "[prod] Pod default/api is failing: BackOff", tracker.created[0].Title)
+	assert.Contains(t, tracker.created[0].Body, "Back-off restarting failed container")
+
+	// when repeated failures occur
+	now = now.Add(time.Minute)
+	manager.Handle(context.Background(), failure) // within comment interval
+	now = now.Add(10 * time.Minute)
+	manager.Handle(context.Background(), failure)
+
+	// then
+	assert.Len(t, tracker.created, 1)
+	require.Len(t, tracker.comments["OPS-1"], 1)
+	assert.Contains(t, tracker.comments["OPS-1"][0], "occurrence 3")
+
+	// when an event doesn't match any condition, or is about other source
+	manager.Handle(context.Background(), fixInput("warning", "BackOff"))
+	other := failure
+	other.SourceName = "other"
+	manager.Handle(context.Background(), other)
+
+	// then
+	assert.Len(t, tracker.created, 1)
+	assert.Len(t, tracker.comments["OPS-1"], 1)
+
+	// when the resource recovers
+	manager.Handle(context.Background(), fixInput("info", "Started"))
+
+	// then
+	assert.Equal(t, []string{"OPS-1"}, tracker.closed)
+	assert.Empty(t, manager.registry)
+}
+
+func TestManagerHandleFailuresOutOfWindow(t *testing.T) {
+	// given
+	now := time.Date(2023, 1, 1, 10, 0, 0, 0, time.UTC)
+	tracker := &fakeTracker{}
+	manager := newTestManager(t, config.TicketPolicy{
+		Enabled:   true,
+		Sources:   []string{"k8s-events"},
+		Threshold: 2,
+		Window:    time.Hour,
+		Title:     "{{ .Event.Kind }} {{ .Event.Name }} failed {{ .Count }} times",
+	}, tracker, func() time.Time { return now })
+
+	// when
+	manager.Handle(context.Background(), fixInput("error", "BackOff"))
+	now = now.Add(2 * time.Hour)
+	manager.Handle(context.Background(), fixInput("error", "BackOff"))
+
+	// then
+	assert.Empty(t, tracker.created)
+
+	// when
+	now = now.Add(time.Minute)
+	manager.Handle(context.Background(), fixInput("error", "BackOff"))
+
+	// then
+	require.Len(t, tracker.created, 1)
+	assert.Equal(t, "Pod api failed 2 times", tracker.created[0].Title)
+}
+
+func newTestManager(t *testing.T, cfg config.TicketPolicy, tracker Tracker, now func() time.Time) *Manager {
+	t.Helper()
+
+	cfg.Jira = config.JiraTickets{Enabled: true, URL: "https://jira.example.com", Token: "token", Project: "OPS"}
+	manager, err := NewManager(loggerx.NewNoop(), config.Tickets{"crashes": cfg})
+	require.NoError(t, err)
+	require.Len(t, manager.policies, 1)
+
+	manager.policies[0].tracker = tracker
+	manager.now = now
+	return manager
+}
+
+func fixInput(level, reason string) Notification {
+	return Notification{
+		Input: filter.Input{
+			SourceName:  "k8s-events",
+			ClusterName: "prod",
+			Event: map[string]any{
+				"Kind":      "Pod",
+				"Name":      "api",
+				"Namespace": "default",
+				"Level":     level,
+				"Reason":    reason,
+			},
+			Message: api.Message{
+				Sections: []api.Section{{Base: api.Base{Header: "Pod api", Description: "Back-off restarting failed container"}}},
+			},
+		},
+	}
+}
@@ -0,0 +1,39 @@
+package ticketing
+
+import (
+	"context"
+	"errors"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+// Ticket holds a ticket filed for a recurring failure.
+type Ticket struct {
+	Title string
+	Body  string
+}
+
+// Reference identifies a filed ticket.
+type Reference struct {
+	// ID is the issue number for GitHub, and the issue key for Jira.
+	ID  string
+	URL string
+}
+
+// Tracker files, comments and closes tickets in an issue tracker.
+type Tracker interface {
+	Create(ctx context.Context, ticket Ticket) (Reference, error)
+	Comment(ctx context.Context, ref Reference, body string) error
+	Close(ctx context.Context, ref Reference, comment string) error
+}
+
+func newTracker(cfg config.TicketPolicy) (Tracker, error) {
+	switch {
+	case cfg.GitHub.Enabled:
+		return newGitHubTracker(cfg.GitHub)
+	case cfg.Jira.Enabled:
+		return newJiraTracker(cfg.Jira), nil
+	default:
+		return nil, errors.New("either GitHub or Jira needs to be enabled")
+	}
+}
@@ -0,0 +1,114 @@
+// Package tracing exports OpenTelemetry traces of events and commands handled by Botkube.
+// Spans are created with the global tracer provider, so they are no-op unless tracing is enabled.
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+const instrumentationName = "github.com/kubeshop/botkube"
+
+// Attributes of Botkube spans.
+const (
+	ClusterNameKey     = attribute.Key("k8s.cluster.name")
+	SourceNameKey      = attribute.Key("botkube.source.name")
+	PluginNameKey      = attribute.Key("botkube.plugin.name")
+	PlatformKey        = attribute.Key("botkube.platform")
+	IntegrationTypeKey = attribute.Key("botkube.integration.type")
+	ActionNameKey      = attribute.Key("botkube.action.name")
+	CommandKey         = attribute.Key("botkube.command")
+	ExecutorKey        = attribute.Key("botkube.executor")
+	DroppedKey         = attribute.Key("botkube.dropped")
+)
+
+// ShutdownFunc flushes pending spans, and stops exporting them.
+type ShutdownFunc func(ctx context.Context) error
+
+// Setup configures the global tracer provider, which exports spans to a given OTLP receiver, and the W3C Trace Context propagator.
+// If tracing is disabled, the no-op tracer provider is used.
+func Setup(ctx context.Context, cfg config.Tracing, clusterName, version string) (ShutdownFunc, error) {
+	if !cfg.Enabled {
+		otel.SetTracerProvider(noop.NewTracerProvider())
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := newExporter(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("while creating OTLP exporter: %w", err)
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithTelemetrySDK(),
+		resource.WithFromEnv(),
+		resource.WithAttributes(
+			semconv.ServiceName(cfg.ServiceName),
+			semconv.ServiceVersion(version),
+			ClusterNameKey.String(clusterName),
+		),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("while creating resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	return provider.Shutdown, nil
+}
+
+func newExporter(ctx context.Context, cfg config.Tracing) (*otlptrace.Exporter, error) {
+	if cfg.Protocol == config.HTTPTracingProtocol {
+		opts := []otlptracehttp.Option{
+			otlptracehttp.WithEndpoint(cfg.Endpoint),
+			otlptracehttp.WithHeaders(cfg.Headers),
+		}
+		if cfg.Insecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		return otlptracehttp.New(ctx, opts...)
+	}
+
+	opts := []otlptracegrpc.Option{
+		otlptracegrpc.WithEndpoint(cfg.Endpoint),
+		otlptracegrpc.WithHeaders(cfg.Headers),
+	}
+	if cfg.Insecure {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	}
+	return otlptracegrpc.New(ctx, opts...)
+}
+
+// Start starts a span with given attributes. The span is a child of the span from a given context, if any.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End records a given error, if any, and ends the span.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,59 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+func TestStartEnd(t *testing.T) {
+	// given
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(prev)
+
+	// when
+	ctx, parent := Start(context.Background(), "source.Dispatch", SourceNameKey.String("k8s-events"))
+	_, child := Start(ctx, "notifier.Send", PlatformKey.String("slack"))
+	End(child, errors.New("channel not found"))
+	End(parent, nil)
+
+	// then
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+
+	assert.Equal(t, "notifier.Send", spans[0].Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, "channel not found", spans[0].Status().Description)
+	require.Len(t, spans[0].Events(), 1)
+	assert.Equal(t, "exception", spans[0].Events()[0].Name)
+
+	assert.Equal(t, "source.Dispatch", spans[1].Name())
+	assert.Equal(t, codes.Unset, spans[1].Status().Code)
+	assert.Contains(t, spans[1].Attributes(), SourceNameKey.String("k8s-events"))
+}
+
+func TestSetupDisabled(t *testing.T) {
+	// when
+	shutdown, err := Setup(context.Background(), config.Tracing{Enabled: false}, "dev", "v1.0.0")
+
+	// then
+	require.NoError(t, err)
+	assert.NoError(t, shutdown(context.Background()))
+
+	_, span := Start(context.Background(), "source.Dispatch")
+	assert.False(t, span.SpanContext().IsValid())
+	assert.False(t, span.IsRecording())
+}
@@ -0,0 +1,159 @@
+package upgradecheck
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+// lastAppliedAnnotation holds the manifest last applied with kubectl or Helm, which includes the API version the resource is managed with.
+const lastAppliedAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// removedAPI describes an API version which is no longer served since a given Kubernetes minor version.
+type removedAPI struct {
+	deprecated  schema.GroupVersionResource
+	kind        string
+	removedIn   uint64
+	replacement *schema.GroupVersionResource
+}
+
+// removedAPIs lists API versions removed in Kubernetes 1.22 and later, based on the official deprecation guide.
+var removedAPIs = []removedAPI{
+	removed("admissionregistration.k8s.io", "v1beta1", "mutatingwebhookconfigurations", "MutatingWebhookConfiguration", 22, "v1"),
+	removed("admissionregistration.k8s.io", "v1beta1", "validatingwebhookconfigurations", "ValidatingWebhookConfiguration", 22, "v1"),
+	removed("apiextensions.k8s.io", "v1beta1", "customresourcedefinitions", "CustomResourceDefinition", 22, "v1"),
+	removed("apiregistration.k8s.io", "v1beta1", "apiservices", "APIService", 22, "v1"),
+	removed("certificates.k8s.io", "v1beta1", "certificatesigningrequests", "CertificateSigningRequest", 22, "v1"),
+	removed("coordination.k8s.io", "v1beta1", "leases", "Lease", 22, "v1"),
+	removed("networking.k8s.io", "v1beta1", "ingresses", "Ingress", 22, "v1"),
+	removedTo("extensions", "v1beta1", "ingresses", "Ingress", 22, schema.GroupVersionResource{Group: "networking.k8s.io", Version: "v1", Resource: "ingresses"}),
+	removed("networking.k8s.io", "v1beta1", "ingressclasses", "IngressClass", 22, "v1"),
+	removed("rbac.authorization.k8s.io", "v1beta1", "clusterroles", "ClusterRole", 22, "v1"),
+	removed("rbac.authorization.k8s.io", "v1beta1", "clusterrolebindings", "ClusterRoleBinding", 22, "v1"),
+	removed("rbac.authorization.k8s.io", "v1beta1", "roles", "Role", 22, "v1"),
+	removed("rbac.authorization.k8s.io", "v1beta1", "rolebindings", "RoleBinding", 22, "v1"),
+	removed("scheduling.k8s.io", "v1beta1", "priorityclasses", "PriorityClass", 22, "v1"),
+	removed("storage.k8s.io", "v1beta1", "csidrivers", "CSIDriver", 22, "v1"),
+	removed("storage.k8s.io", "v1beta1", "csinodes", "CSINode", 22, "v1"),
+	removed("storage.k8s.io", "v1beta1", "storageclasses", "StorageClass", 22, "v1"),
+	removed("storage.k8s.io", "v1beta1", "volumeattachments", "VolumeAttachment", 22, "v1"),
+	removed("batch", "v1beta1", "cronjobs", "CronJob", 25, "v1"),
+	removed("discovery.k8s.io", "v1beta1", "endpointslices", "EndpointSlice", 25, "v1"),
+	removed("events.k8s.io", "v1beta1", "events", "Event", 25, "v1"),
+	removed("autoscaling", "v2beta1", "horizontalpodautoscalers", "HorizontalPodAutoscaler", 25, "v2"),
+	removed("policy", "v1beta1", "poddisruptionbudgets", "PodDisruptionBudget", 25, "v1"),
+	{
+		deprecated: schema.GroupVersionResource{Group: "policy", Version: "v1beta1", Resource: "podsecuritypolicies"},
+		kind:       "PodSecurityPolicy",
+		removedIn:  25,
+	},
+	removed("node.k8s.io", "v1beta1", "runtimeclasses", "RuntimeClass", 25, "v1"),
+	removed("flowcontrol.apiserver.k8s.io", "v1beta1", "flowschemas", "FlowSchema", 26, "v1beta3"),
+	removed("flowcontrol.apiserver.k8s.io", "v1beta1", "prioritylevelconfigurations", "PriorityLevelConfiguration", 26, "v1beta3"),
+	removed("autoscaling", "v2beta2", "horizontalpodautoscalers", "HorizontalPodAutoscaler", 26, "v2"),
+	removed("storage.k8s.io", "v1beta1", "csistoragecapacities", "CSIStorageCapacity", 27, "v1"),
+	removed("flowcontrol.apiserver.k8s.io", "v1beta2", "flowschemas", "FlowSchema", 29, "v1"),
+	removed("flowcontrol.apiserver.k8s.io", "v1beta2", "prioritylevelconfigurations", "PriorityLevelConfiguration", 29, "v1"),
+	removed("flowcontrol.apiserver.k8s.io", "v1beta3", "flowschemas", "FlowSchema", 32, "v1"),
+	removed("flowcontrol.apiserver.k8s.io", "v1beta3", "prioritylevelconfigurations", "PriorityLevelConfiguration", 32, "v1"),
+}
+
+// removed returns a removed API which is replaced with another version of the same group.
+func removed(group, version, resource, kind string, removedIn uint64, replacementVersion string) removedAPI {
+	return removedTo(group, version, resource, kind, removedIn, schema.GroupVersionResource{Group: group, Version: replacementVersion, Resource: resource})
+}
+
+func removedTo(group, version, resource, kind string, removedIn uint64, replacement schema.GroupVersionResource) removedAPI {
+	return removedAPI{
+		deprecated:  schema.GroupVersionResource{Group: group, Version: version, Resource: resource},
+		kind:        kind,
+		removedIn:   removedIn,
+		replacement: &replacement,
+	}
+}
+
+// checkRemovedAPIs reports resources managed with API versions removed between the current and target versions.
+// Resources are listed with any served version, and the API version they are managed with is read from the last applied manifest,
+// as the API server converts stored resources to the requested version.
+func checkRemovedAPIs(ctx context.Context, cli dynamic.Interface, current, target uint64) ([]Finding, error) {
+	var out []Finding
+	for _, api := range removedAPIs {
+		if api.removedIn <= current || api.removedIn > target {
+			continue
+		}
+
+		items, err := listServed(ctx, cli, api)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			if api.replacement != nil && !managedWith(item, api.deprecated.GroupVersion()) {
+				continue
+			}
+			out = append(out, removedAPIFinding(api, item))
+		}
+	}
+	return out, nil
+}
+
+// listServed lists resources with the replacement version, or the deprecated one if the replacement isn't served yet.
+// It returns no resources if neither version is served.
+func listServed(ctx context.Context, cli dynamic.Interface, api removedAPI) ([]unstructured.Unstructured, error) {
+	var gvrs []schema.GroupVersionResource
+	if api.replacement != nil {
+		gvrs = append(gvrs, *api.replacement)
+	}
+	gvrs = append(gvrs, api.deprecated)
+
+	for _, gvr := range gvrs {
+		list, err := cli.Resource(gvr).Namespace(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+		switch {
+		case err == nil:
+			return list.Items, nil
+		case apierrors.IsNotFound(err):
+			continue
+		default:
+			return nil, fmt.Errorf("while listing %s: %w", gvr.String(), err)
+		}
+	}
+	return nil, nil
+}
+
+// managedWith returns true if the last applied manifest of a given resource uses a given API version.
+func managedWith(item unstructured.Unstructured, gv schema.GroupVersion) bool {
+	raw, found := item.GetAnnotations()[lastAppliedAnnotation]
+	if !found {
+		return false
+	}
+	var manifest struct {
+		APIVersion string `json:"apiVersion"`
+	}
+	if err := json.Unmarshal([]byte(raw), &manifest); err != nil {
+		return false
+	}
+	return manifest.APIVersion == gv.String()
+}
+
+func removedAPIFinding(api removedAPI, item unstructured.Unstructured) Finding {
+	deprecated := api.deprecated.GroupVersion().String()
+	finding := Finding{
+		Namespace: item.GetNamespace(),
+		Check:     CheckRemovedAPIs,
+		Severity:  SeverityBlocker,
+		Object:    fmt.Sprintf("%s/%s", strings.ToLower(api.kind), item.GetName()),
+		Message:   fmt.Sprintf("%s %s is removed in 1.%d", deprecated, api.kind, api.removedIn),
+	}
+	if api.replacement == nil {
+		finding.Action = fmt.Sprintf("Migrate off %s and delete the resource, as the API has no replacement.", api.kind)
+		return finding
+	}
+	finding.Action = fmt.Sprintf("Update the manifest to %s.", api.replacement.GroupVersion().String())
+	return finding
+}
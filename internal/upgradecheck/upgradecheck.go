@@ -0,0 +1,271 @@
+package upgradecheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+// ErrInvalidTarget is returned when the target version cannot be upgraded to.
+var ErrInvalidTarget = errors.New("invalid target version")
+
+// Check is the name of a readiness check.
+type Check string
+
+const (
+	// CheckRemovedAPIs reports resources managed with API versions removed in the target version.
+	CheckRemovedAPIs Check = "Removed APIs"
+	// CheckDisruptionBudgets reports PodDisruptionBudgets which block draining nodes.
+	CheckDisruptionBudgets Check = "PodDisruptionBudgets"
+	// CheckVersionSkew reports control plane and node versions outside the supported skew.
+	CheckVersionSkew Check = "Version skew"
+)
+
+// Severity describes how a finding affects the upgrade.
+type Severity string
+
+const (
+	// SeverityBlocker means the upgrade will break workloads or fail unless the finding is resolved.
+	SeverityBlocker Severity = "blocker"
+	// SeverityWarning means the upgrade is possible, but the finding should be reviewed.
+	SeverityWarning Severity = "warning"
+)
+
+// Finding is a single issue found by a readiness check.
+type Finding struct {
+	// Namespace is empty for cluster-scoped resources.
+	Namespace string
+	Check     Check
+	Severity  Severity
+	// Object is given in the `kind/name` form.
+	Object  string
+	Message string
+	// Action describes how to resolve the finding.
+	Action string
+}
+
+// Report describes the cluster readiness for an upgrade to a given version.
+type Report struct {
+	CurrentVersion string
+	TargetVersion  string
+	// Findings are sorted by namespace, with cluster-scoped ones first.
+	Findings []Finding
+}
+
+// Ready returns true if no blockers were found.
+func (r Report) Ready() bool {
+	return r.Count(SeverityBlocker) == 0
+}
+
+// Count returns the number of findings with a given severity.
+func (r Report) Count(severity Severity) int {
+	count := 0
+	for _, f := range r.Findings {
+		if f.Severity == severity {
+			count++
+		}
+	}
+	return count
+}
+
+// Checker checks whether the cluster is ready for an upgrade.
+type Checker struct {
+	// newClients return clients which impersonate configured groups.
+	newClients func() (kubernetes.Interface, dynamic.Interface, error)
+}
+
+// New returns a new Checker instance.
+func New(cfg config.UpgradeCheck, restCfg *rest.Config) *Checker {
+	if len(cfg.Groups) == 0 {
+		cfg.Groups = []string{config.RBACDefaultGroup}
+	}
+
+	return &Checker{
+		newClients: func() (kubernetes.Interface, dynamic.Interface, error) {
+			if restCfg == nil {
+				return nil, nil, errors.New("missing Kubernetes client configuration")
+			}
+			impersonated := rest.CopyConfig(restCfg)
+			impersonated.Impersonate = rest.ImpersonationConfig{UserName: config.RBACDefaultUser, Groups: cfg.Groups}
+			cli, err := kubernetes.NewForConfig(impersonated)
+			if err != nil {
+				return nil, nil, err
+			}
+			dynamicCli, err := dynamic.NewForConfig(impersonated)
+			if err != nil {
+				return nil, nil, err
+			}
+			return cli, dynamicCli, nil
+		},
+	}
+}
+
+// Check returns the readiness report for an upgrade to a given minor version, e.g. `1.31`.
+// It returns ErrInvalidTarget if the target version is malformed or isn't newer than the current one.
+func (c *Checker) Check(ctx context.Context, target string) (Report, error) {
+	targetVer, err := parseMinor(target)
+	if err != nil {
+		return Report{}, fmt.Errorf("%w: %q must be given as a minor version, e.g. 1.31", ErrInvalidTarget, target)
+	}
+
+	cli, dynamicCli, err := c.newClients()
+	if err != nil {
+		return Report{}, fmt.Errorf("while creating Kubernetes clients: %w", err)
+	}
+	info, err := cli.Discovery().ServerVersion()
+	if err != nil {
+		return Report{}, fmt.Errorf("while getting server version: %w", err)
+	}
+	currentVer, err := parseMinor(info.GitVersion)
+	if err != nil {
+		return Report{}, fmt.Errorf("while parsing server version %q: %w", info.GitVersion, err)
+	}
+
+	if targetVer.Major() != currentVer.Major() || targetVer.Minor() <= currentVer.Minor() {
+		return Report{}, fmt.Errorf("%w: %s is not newer than the current version %s", ErrInvalidTarget, formatMinor(targetVer), formatMinor(currentVer))
+	}
+
+	out := Report{
+		CurrentVersion: formatMinor(currentVer),
+		TargetVersion:  formatMinor(targetVer),
+	}
+	out.Findings = append(out.Findings, checkControlPlaneSkew(currentVer, targetVer)...)
+
+	apis, err := checkRemovedAPIs(ctx, dynamicCli, currentVer.Minor(), targetVer.Minor())
+	if err != nil {
+		return Report{}, err
+	}
+	out.Findings = append(out.Findings, apis...)
+
+	nodes, err := checkNodeSkew(ctx, cli, currentVer, targetVer)
+	if err != nil {
+		return Report{}, err
+	}
+	out.Findings = append(out.Findings, nodes...)
+
+	budgets, err := checkDisruptionBudgets(ctx, cli)
+	if err != nil {
+		return Report{}, err
+	}
+	out.Findings = append(out.Findings, budgets...)
+
+	sort.SliceStable(out.Findings, func(i, j int) bool {
+		return out.Findings[i].Namespace < out.Findings[j].Namespace
+	})
+	return out, nil
+}
+
+// checkControlPlaneSkew reports upgrades which skip minor versions, as the control plane can be upgraded only one minor version at a time.
+func checkControlPlaneSkew(current, target *semver.Version) []Finding {
+	if target.Minor() == current.Minor()+1 {
+		return nil
+	}
+	next := current.IncMinor()
+	return []Finding{
+		{
+			Check:    CheckVersionSkew,
+			Severity: SeverityBlocker,
+			Object:   "cluster/control-plane",
+			Message:  fmt.Sprintf("upgrade from %s to %s skips minor versions", formatMinor(current), formatMinor(target)),
+			Action:   fmt.Sprintf("Upgrade one minor version at a time, starting with %s.", formatMinor(&next)),
+		},
+	}
+}
+
+// checkNodeSkew reports nodes with kubelets older than supported by the target control plane version,
+// and nodes which weren't upgraded to the current version yet.
+func checkNodeSkew(ctx context.Context, cli kubernetes.Interface, current, target *semver.Version) ([]Finding, error) {
+	nodes, err := cli.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("while listing nodes: %w", err)
+	}
+
+	// kubelets can be up to three minor versions older than the API server since Kubernetes 1.28, and two before
+	maxSkew := uint64(3)
+	if target.Minor() < 28 {
+		maxSkew = 2
+	}
+
+	sort.Slice(nodes.Items, func(i, j int) bool { return nodes.Items[i].Name < nodes.Items[j].Name })
+	var out []Finding
+	for _, node := range nodes.Items {
+		kubelet, err := parseMinor(node.Status.NodeInfo.KubeletVersion)
+		if err != nil {
+			continue
+		}
+		object := fmt.Sprintf("node/%s", node.Name)
+		switch {
+		case kubelet.Minor()+maxSkew < target.Minor():
+			out = append(out, Finding{
+				Check:    CheckVersionSkew,
+				Severity: SeverityBlocker,
+				Object:   object,
+				Message:  fmt.Sprintf("kubelet %s is more than %d minor versions older than %s", formatMinor(kubelet), maxSkew, formatMinor(target)),
+				Action:   fmt.Sprintf("Upgrade the node to at least 1.%d first.", target.Minor()-maxSkew),
+			})
+		case kubelet.Minor() < current.Minor():
+			out = append(out, Finding{
+				Check:    CheckVersionSkew,
+				Severity: SeverityWarning,
+				Object:   object,
+				Message:  fmt.Sprintf("kubelet %s is older than the control plane %s", formatMinor(kubelet), formatMinor(current)),
+				Action:   "Upgrade the node after the control plane, so it doesn't fall out of the supported skew in later upgrades.",
+			})
+		}
+	}
+	return out, nil
+}
+
+// checkDisruptionBudgets reports PodDisruptionBudgets which don't allow any disruption, so nodes cannot be drained during the upgrade.
+func checkDisruptionBudgets(ctx context.Context, cli kubernetes.Interface) ([]Finding, error) {
+	budgets, err := cli.PolicyV1().PodDisruptionBudgets(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("while listing PodDisruptionBudgets: %w", err)
+	}
+
+	sort.Slice(budgets.Items, func(i, j int) bool { return budgets.Items[i].Name < budgets.Items[j].Name })
+	var out []Finding
+	for _, pdb := range budgets.Items {
+		status := pdb.Status
+		if status.ExpectedPods == 0 || status.DisruptionsAllowed > 0 {
+			continue
+		}
+
+		finding := Finding{
+			Namespace: pdb.Namespace,
+			Check:     CheckDisruptionBudgets,
+			Severity:  SeverityBlocker,
+			Object:    fmt.Sprintf("poddisruptionbudget/%s", pdb.Name),
+			Message:   fmt.Sprintf("no disruptions allowed with %d/%d healthy Pods", status.CurrentHealthy, status.ExpectedPods),
+			Action:    "Relax minAvailable or maxUnavailable, or scale up the workload, so nodes can be drained.",
+		}
+		if status.CurrentHealthy < status.DesiredHealthy {
+			finding.Action = fmt.Sprintf("Fix unhealthy Pods, as %d healthy Pods are required.", status.DesiredHealthy)
+		}
+		out = append(out, finding)
+	}
+	return out, nil
+}
+
+// parseMinor parses a version, such as `1.31` or `v1.30.2-eks-1234`, and drops everything but the major and minor version.
+func parseMinor(in string) (*semver.Version, error) {
+	ver, err := semver.NewVersion(strings.TrimSpace(in))
+	if err != nil {
+		return nil, err
+	}
+	return semver.New(ver.Major(), ver.Minor(), 0, "", ""), nil
+}
+
+func formatMinor(ver *semver.Version) string {
+	return fmt.Sprintf("%d.%d", ver.Major(), ver.Minor())
+}
@@ -0,0 +1,224 @@
+package upgradecheck
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/dynamic"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+func TestCheckerCheck(t *testing.T) {
+	// given
+	checker := fakeChecker(t, "v1.24.7-eks-1234",
+		[]runtime.Object{
+			node("worker-1", "v1.24.3"),
+			node("worker-2", "v1.21.9"),
+			node("worker-3", "v1.23.1"),
+			pdb("prod", "api", 3, 3, 3, 0),
+			pdb("prod", "web", 1, 2, 2, 0),
+			pdb("dev", "api", 2, 1, 1, 1),
+			pdb("dev", "empty", 0, 0, 0, 0),
+		},
+		[]runtime.Object{
+			managed("batch/v1", "CronJob", "prod", "backup", "batch/v1beta1"),
+			managed("batch/v1", "CronJob", "prod", "report", "batch/v1"),
+			managed("batch/v1", "CronJob", "dev", "cleanup", ""),
+			managed("autoscaling/v2", "HorizontalPodAutoscaler", "dev", "api", "autoscaling/v2beta1"),
+			managed("autoscaling/v2", "HorizontalPodAutoscaler", "dev", "web", "autoscaling/v2beta2"),
+			managed("node.k8s.io/v1", "RuntimeClass", "", "gvisor", "node.k8s.io/v1beta1"),
+		},
+	)
+
+	// when
+	report, err := checker.Check(context.Background(), "1.25")
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "1.24", report.CurrentVersion)
+	assert.Equal(t, "1.25", report.TargetVersion)
+	assert.False(t, report.Ready())
+	assert.Equal(t, 6, report.Count(SeverityBlocker))
+	assert.Equal(t, 1, report.Count(SeverityWarning))
+	assert.Equal(t, []Finding{
+		{
+			Check:    CheckRemovedAPIs,
+			Severity: SeverityBlocker,
+			Object:   "runtimeclass/gvisor",
+			Message:  "node.k8s.io/v1beta1 RuntimeClass is removed in 1.25",
+			Action:   "Update the manifest to node.k8s.io/v1.",
+		},
+		{
+			Check:    CheckVersionSkew,
+			Severity: SeverityBlocker,
+			Object:   "node/worker-2",
+			Message:  "kubelet 1.21 is more than 2 minor versions older than 1.25",
+			Action:   "Upgrade the node to at least 1.23 first.",
+		},
+		{
+			Check:    CheckVersionSkew,
+			Severity: SeverityWarning,
+			Object:   "node/worker-3",
+			Message:  "kubelet 1.23 is older than the control plane 1.24",
+			Action:   "Upgrade the node after the control plane, so it doesn't fall out of the supported skew in later upgrades.",
+		},
+		{
+			Namespace: "dev",
+			Check:     CheckRemovedAPIs,
+			Severity:  SeverityBlocker,
+			Object:    "horizontalpodautoscaler/api",
+			Message:   "autoscaling/v2beta1 HorizontalPodAutoscaler is removed in 1.25",
+			Action:    "Update the manifest to autoscaling/v2.",
+		},
+		{
+			Namespace: "prod",
+			Check:     CheckRemovedAPIs,
+			Severity:  SeverityBlocker,
+			Object:    "cronjob/backup",
+			Message:   "batch/v1beta1 CronJob is removed in 1.25",
+			Action:    "Update the manifest to batch/v1.",
+		},
+		{
+			Namespace: "prod",
+			Check:     CheckDisruptionBudgets,
+			Severity:  SeverityBlocker,
+			Object:    "poddisruptionbudget/api",
+			Message:   "no disruptions allowed with 3/3 healthy Pods",
+			Action:    "Relax minAvailable or maxUnavailable, or scale up the workload, so nodes can be drained.",
+		},
+		{
+			Namespace: "prod",
+			Check:     CheckDisruptionBudgets,
+			Severity:  SeverityBlocker,
+			Object:    "poddisruptionbudget/web",
+			Message:   "no disruptions allowed with 1/2 healthy Pods",
+			Action:    "Fix unhealthy Pods, as 2 healthy Pods are required.",
+		},
+	}, report.Findings)
+}
+
+func TestCheckerCheckSkippedMinorVersions(t *testing.T) {
+	// given
+	checker := fakeChecker(t, "v1.29.1", []runtime.Object{node("worker-1", "v1.29.0")}, nil)
+
+	// when
+	report, err := checker.Check(context.Background(), "v1.31")
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []Finding{
+		{
+			Check:    CheckVersionSkew,
+			Severity: SeverityBlocker,
+			Object:   "cluster/control-plane",
+			Message:  "upgrade from 1.29 to 1.31 skips minor versions",
+			Action:   "Upgrade one minor version at a time, starting with 1.30.",
+		},
+	}, report.Findings)
+}
+
+func TestCheckerCheckInvalidTarget(t *testing.T) {
+	tests := []struct {
+		name        string
+		target      string
+		expectedErr string
+	}{
+		{
+			name:        "Malformed version",
+			target:      "latest",
+			expectedErr: `invalid target version: "latest" must be given as a minor version, e.g. 1.31`,
+		},
+		{
+			name:        "Current version",
+			target:      "1.30",
+			expectedErr: "invalid target version: 1.30 is not newer than the current version 1.30",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			checker := fakeChecker(t, "v1.30.2", nil, nil)
+
+			// when
+			_, err := checker.Check(context.Background(), tc.target)
+
+			// then
+			assert.ErrorIs(t, err, ErrInvalidTarget)
+			assert.EqualError(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func fakeChecker(t *testing.T, serverVersion string, objects []runtime.Object, dynamicObjects []runtime.Object) *Checker {
+	t.Helper()
+
+	cli := fake.NewSimpleClientset(objects...)
+	discovery, ok := cli.Discovery().(*fakediscovery.FakeDiscovery)
+	require.True(t, ok)
+	discovery.FakedServerVersion = &version.Info{GitVersion: serverVersion}
+
+	listKinds := map[schema.GroupVersionResource]string{}
+	for _, api := range removedAPIs {
+		listKinds[api.deprecated] = api.kind + "List"
+		if api.replacement != nil {
+			listKinds[*api.replacement] = api.kind + "List"
+		}
+	}
+	dynamicCli := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds, dynamicObjects...)
+
+	checker := New(config.UpgradeCheck{}, nil)
+	checker.newClients = func() (kubernetes.Interface, dynamic.Interface, error) {
+		return cli, dynamicCli, nil
+	}
+	return checker
+}
+
+func node(name, kubelet string) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: corev1.NodeStatus{
+			NodeInfo: corev1.NodeSystemInfo{KubeletVersion: kubelet},
+		},
+	}
+}
+
+func pdb(namespace, name string, healthy, desired, expected, allowed int32) *policyv1.PodDisruptionBudget {
+	return &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Status: policyv1.PodDisruptionBudgetStatus{
+			CurrentHealthy:     healthy,
+			DesiredHealthy:     desired,
+			ExpectedPods:       expected,
+			DisruptionsAllowed: allowed,
+		},
+	}
+}
+
+// managed returns a resource whose last applied manifest uses a given API version.
+func managed(apiVersion, kind, namespace, name, appliedVersion string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(apiVersion)
+	obj.SetKind(kind)
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	if appliedVersion != "" {
+		obj.SetAnnotations(map[string]string{
+			lastAppliedAnnotation: `{"apiVersion":"` + appliedVersion + `","kind":"` + kind + `"}`,
+		})
+	}
+	return obj
+}
@@ -0,0 +1,287 @@
+// Package workerpool runs jobs with a bounded number of workers, which take them from a bounded queue.
+// Jobs with the same key are handled one at a time, in the order they were submitted.
+package workerpool
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/internal/metrics"
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+const (
+	defaultWorkers   = 1
+	defaultQueueSize = 100
+)
+
+// Codec encodes jobs written to disk with the spill-to-disk overflow policy.
+type Codec[T any] struct {
+	Encode func(job T) ([]byte, error)
+	Decode func(data []byte) (T, error)
+}
+
+// Options holds settings of a Pool.
+type Options[T any] struct {
+	// Stage names the pool in metrics and logs.
+	Stage  string
+	Config config.PipelineStage
+	// Handle handles a single job. The context is the one passed to Start.
+	Handle func(ctx context.Context, job T)
+	// OnDrop is called for jobs which are dropped, either with the drop-oldest overflow policy, or once the pool is stopped.
+	OnDrop func(job T)
+	// Codec is required by the spill-to-disk overflow policy.
+	Codec *Codec[T]
+}
+
+type item[T any] struct {
+	key      string
+	job      T
+	queuedAt time.Time
+}
+
+// Pool runs jobs with a bounded number of workers. Once its queue is full, the configured overflow policy is applied.
+type Pool[T any] struct {
+	log     logrus.FieldLogger
+	stage   string
+	workers int
+	size    int
+	policy  config.PipelineOverflowPolicy
+	handle  func(context.Context, T)
+	onDrop  func(T)
+	spill   *spillStore[T]
+
+	mu       sync.Mutex
+	notEmpty *sync.Cond
+	notFull  *sync.Cond
+	queue    []item[T]
+	// active holds keys of jobs which are being handled.
+	active  map[string]struct{}
+	started bool
+	stopped bool
+}
+
+// New returns a new Pool instance. Workers are started with Start.
+func New[T any](log logrus.FieldLogger, opts Options[T]) (*Pool[T], error) {
+	p := &Pool[T]{
+		log:     log.WithField("stage", opts.Stage),
+		stage:   opts.Stage,
+		workers: opts.Config.Workers,
+		size:    opts.Config.QueueSize,
+		policy:  opts.Config.Overflow,
+		handle:  opts.Handle,
+		onDrop:  opts.OnDrop,
+		active:  map[string]struct{}{},
+	}
+	if p.workers <= 0 {
+		p.workers = defaultWorkers
+	}
+	if p.size <= 0 {
+		p.size = defaultQueueSize
+	}
+	if p.policy == "" {
+		p.policy = config.BlockPipelineOverflowPolicy
+	}
+	if p.onDrop == nil {
+		p.onDrop = func(T) {}
+	}
+	p.notEmpty = sync.NewCond(&p.mu)
+	p.notFull = sync.NewCond(&p.mu)
+
+	if p.policy == config.SpillToDiskPipelineOverflowPolicy {
+		if opts.Codec == nil {
+			return nil, fmt.Errorf("spill-to-disk overflow policy is not supported by the %s stage", opts.Stage)
+		}
+		spill, err := newSpillStore(opts.Config.SpillDir, *opts.Codec)
+		if err != nil {
+			return nil, fmt.Errorf("while preparing spill directory: %w", err)
+		}
+		p.spill = spill
+	}
+
+	metrics.RegisterQueue("pipeline_"+opts.Stage, p.Len)
+	return p, nil
+}
+
+// Start starts workers, which run until the context is done. Jobs which are left in the queue are dropped.
+func (p *Pool[T]) Start(ctx context.Context) {
+	p.mu.Lock()
+	p.started = true
+	p.mu.Unlock()
+
+	metrics.SetPipelineWorkers(p.stage, p.workers)
+	var wg sync.WaitGroup
+	for i := 0; i < p.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p.work(ctx)
+		}()
+	}
+
+	go func() {
+		<-ctx.Done()
+		p.mu.Lock()
+		p.stopped = true
+		p.notEmpty.Broadcast()
+		p.notFull.Broadcast()
+		p.mu.Unlock()
+
+		wg.Wait()
+		p.dropAll()
+	}()
+}
+
+// Submit queues a given job. If the queue is full, the overflow policy is applied, so Submit may block with the `block` policy.
+// It returns false if the job wasn't queued, as the pool is stopped.
+func (p *Pool[T]) Submit(key string, job T) bool {
+	it := item[T]{key: key, job: job, queuedAt: time.Now()}
+
+	p.mu.Lock()
+	if p.stopped {
+		p.mu.Unlock()
+		return false
+	}
+
+	// once items are spilled, new ones are spilled too, so they are handled in order
+	if p.spill != nil && (p.spill.Len() > 0 || len(p.queue) >= p.size) {
+		if len(p.queue) >= p.size {
+			metrics.PipelineOverflow(p.stage, p.policy)
+		}
+		err := p.spill.Write(it)
+		if err == nil {
+			p.mu.Unlock()
+			return true
+		}
+		p.log.Errorf("while spilling job to disk, falling back to blocking: %s", err.Error())
+	}
+
+	var dropped []T
+	if len(p.queue) >= p.size {
+		if p.spill == nil {
+			metrics.PipelineOverflow(p.stage, p.policy)
+		}
+		switch p.policy {
+		case config.DropOldestPipelineOverflowPolicy:
+			dropped = p.dropOldestLocked()
+		default:
+			for len(p.queue) >= p.size && !p.stopped {
+				p.notFull.Wait()
+			}
+			if p.stopped {
+				p.mu.Unlock()
+				return false
+			}
+		}
+	}
+
+	p.queue = append(p.queue, it)
+	p.notEmpty.Signal()
+	p.mu.Unlock()
+
+	for _, job := range dropped {
+		p.onDrop(job)
+	}
+	return true
+}
+
+// Len returns the number of queued jobs, including spilled ones.
+func (p *Pool[T]) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	out := len(p.queue)
+	if p.spill != nil {
+		out += p.spill.Len()
+	}
+	return out
+}
+
+func (p *Pool[T]) work(ctx context.Context) {
+	for {
+		p.mu.Lock()
+		idx := p.nextLocked()
+		for idx < 0 && !p.stopped {
+			p.notEmpty.Wait()
+			idx = p.nextLocked()
+		}
+		if p.stopped {
+			p.mu.Unlock()
+			return
+		}
+
+		it := p.queue[idx]
+		p.queue = append(p.queue[:idx], p.queue[idx+1:]...)
+		p.active[it.key] = struct{}{}
+		p.refillLocked()
+		p.notFull.Signal()
+		p.mu.Unlock()
+
+		idle := metrics.PipelineWorkerBusy(p.stage, it.queuedAt)
+		p.handle(ctx, it.job)
+		idle()
+
+		p.mu.Lock()
+		delete(p.active, it.key)
+		// a queued job with the same key can be handled now
+		p.notEmpty.Broadcast()
+		p.mu.Unlock()
+	}
+}
+
+// nextLocked returns the index of the oldest queued job which key is not being handled, or -1 if there is no such job.
+func (p *Pool[T]) nextLocked() int {
+	for i, it := range p.queue {
+		if _, busy := p.active[it.key]; !busy {
+			return i
+		}
+	}
+	return -1
+}
+
+// refillLocked moves spilled jobs back to the queue, once there is room for them.
+func (p *Pool[T]) refillLocked() {
+	if p.spill == nil {
+		return
+	}
+	for len(p.queue) < p.size && p.spill.Len() > 0 {
+		it, err := p.spill.Read()
+		if err != nil {
+			p.log.Errorf("while reading spilled job: %s", err.Error())
+			continue
+		}
+		p.queue = append(p.queue, it)
+	}
+}
+
+func (p *Pool[T]) dropOldestLocked() []T {
+	oldest := p.queue[0]
+	p.queue = p.queue[1:]
+	p.log.WithField("key", oldest.key).Debug("Queue is full. Dropping the oldest job...")
+	return []T{oldest.job}
+}
+
+// dropAll drops jobs left once the pool is stopped, so their resources are released.
+func (p *Pool[T]) dropAll() {
+	p.mu.Lock()
+	left := p.queue
+	p.queue = nil
+	if p.spill != nil {
+		for p.spill.Len() > 0 {
+			it, err := p.spill.Read()
+			if err != nil {
+				continue
+			}
+			left = append(left, it)
+		}
+	}
+	p.mu.Unlock()
+
+	for _, it := range left {
+		p.onDrop(it.job)
+	}
+}
@@ -0,0 +1,236 @@
+package workerpool
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+const waitTimeout = 5 * time.Second
+
+type recorder struct {
+	mu      sync.Mutex
+	handled []int
+	dropped []int
+}
+
+func (r *recorder) handle(job int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.handled = append(r.handled, job)
+}
+
+func (r *recorder) drop(job int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.dropped = append(r.dropped, job)
+}
+
+func (r *recorder) Handled() []int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]int(nil), r.handled...)
+}
+
+func (r *recorder) Dropped() []int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]int(nil), r.dropped...)
+}
+
+var intCodec = &Codec[int]{
+	Encode: func(job int) ([]byte, error) {
+		return []byte(strconv.Itoa(job)), nil
+	},
+	Decode: func(data []byte) (int, error) {
+		return strconv.Atoi(string(data))
+	},
+}
+
+func TestPoolKeepsOrderOfJobsWithTheSameKey(t *testing.T) {
+	// given
+	rec := &recorder{}
+	pool, err := New(loggerx.NewNoop(), Options[int]{
+		Stage:  "test-order",
+		Config: config.PipelineStage{Workers: 4, QueueSize: 100},
+		Handle: func(_ context.Context, job int) {
+			// jobs with the lower numbers take longer, so they would finish last if handled concurrently
+			time.Sleep(time.Duration(10-job) * time.Millisecond)
+			rec.handle(job)
+		},
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	pool.Start(ctx)
+
+	// when
+	for i := 0; i < 10; i++ {
+		assert.True(t, pool.Submit("same", i))
+	}
+
+	// then
+	require.Eventually(t, func() bool { return len(rec.Handled()) == 10 }, waitTimeout, 10*time.Millisecond)
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, rec.Handled())
+}
+
+func TestPoolOverflow(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  config.PipelineStage
+
+		expHandled []int
+		expDropped []int
+	}{
+		{
+			name:       "drop oldest",
+			cfg:        config.PipelineStage{Workers: 1, QueueSize: 2, Overflow: config.DropOldestPipelineOverflowPolicy},
+			expHandled: []int{0, 3, 4},
+			expDropped: []int{1, 2},
+		},
+		{
+			name:       "spill to disk",
+			cfg:        config.PipelineStage{Workers: 1, QueueSize: 2, Overflow: config.SpillToDiskPipelineOverflowPolicy},
+			expHandled: []int{0, 1, 2, 3, 4},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			rec := &recorder{}
+			release := make(chan struct{})
+			started := make(chan struct{}, 1)
+
+			cfg := tc.cfg
+			cfg.SpillDir = t.TempDir()
+			pool, err := New(loggerx.NewNoop(), Options[int]{
+				Stage:  "test-overflow",
+				Config: cfg,
+				Handle: func(_ context.Context, job int) {
+					if job == 0 {
+						started <- struct{}{}
+						<-release
+					}
+					rec.handle(job)
+				},
+				OnDrop: rec.drop,
+				Codec:  intCodec,
+			})
+			require.NoError(t, err)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			pool.Start(ctx)
+
+			// when
+			require.True(t, pool.Submit("key", 0))
+			<-started
+			for i := 1; i < 5; i++ {
+				require.True(t, pool.Submit("key", i))
+			}
+			close(release)
+
+			// then
+			require.Eventually(t, func() bool { return len(rec.Handled()) == len(tc.expHandled) }, waitTimeout, 10*time.Millisecond)
+			assert.Equal(t, tc.expHandled, rec.Handled())
+			assert.Equal(t, tc.expDropped, rec.Dropped())
+			assert.Zero(t, pool.Len())
+		})
+	}
+}
+
+func TestPoolBlocksOnFullQueue(t *testing.T) {
+	// given
+	rec := &recorder{}
+	release := make(chan struct{})
+	pool, err := New(loggerx.NewNoop(), Options[int]{
+		Stage:  "test-block",
+		Config: config.PipelineStage{Workers: 1, QueueSize: 1},
+		Handle: func(_ context.Context, job int) {
+			<-release
+			rec.handle(job)
+		},
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	pool.Start(ctx)
+
+	require.True(t, pool.Submit("key", 0))
+	require.Eventually(t, func() bool { return pool.Len() == 0 }, waitTimeout, 10*time.Millisecond)
+	require.True(t, pool.Submit("key", 1))
+
+	// when
+	submitted := make(chan struct{})
+	go func() {
+		pool.Submit("key", 2)
+		close(submitted)
+	}()
+
+	// then
+	select {
+	case <-submitted:
+		t.Fatal("Submit returned although the queue is full")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	select {
+	case <-submitted:
+	case <-time.After(waitTimeout):
+		t.Fatal("Submit didn't return once there was room in the queue")
+	}
+	require.Eventually(t, func() bool { return len(rec.Handled()) == 3 }, waitTimeout, 10*time.Millisecond)
+}
+
+func TestPoolDropsQueuedJobsOnStop(t *testing.T) {
+	// given
+	rec := &recorder{}
+	release := make(chan struct{})
+	pool, err := New(loggerx.NewNoop(), Options[int]{
+		Stage:  "test-stop",
+		Config: config.PipelineStage{Workers: 1, QueueSize: 10},
+		Handle: func(_ context.Context, job int) {
+			<-release
+			rec.handle(job)
+		},
+		OnDrop: rec.drop,
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	pool.Start(ctx)
+	require.True(t, pool.Submit("key", 0))
+	require.True(t, pool.Submit("key", 1))
+	require.True(t, pool.Submit("key", 2))
+
+	// when
+	cancel()
+	close(release)
+
+	// then
+	require.Eventually(t, func() bool { return len(rec.Handled())+len(rec.Dropped()) == 3 }, waitTimeout, 10*time.Millisecond)
+	assert.False(t, pool.Submit("key", 3))
+}
+
+func TestNewSpillToDiskRequiresCodec(t *testing.T) {
+	// when
+	_, err := New(loggerx.NewNoop(), Options[int]{
+		Stage:  "test-codec",
+		Config: config.PipelineStage{Overflow: config.SpillToDiskPipelineOverflowPolicy, SpillDir: t.TempDir()},
+		Handle: func(context.Context, int) {},
+	})
+
+	// then
+	assert.EqualError(t, err, "spill-to-disk overflow policy is not supported by the test-codec stage")
+}
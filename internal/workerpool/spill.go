@@ -0,0 +1,97 @@
+package workerpool
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const spillFileExt = ".job"
+
+// spilledItem is a queued item written to disk.
+type spilledItem struct {
+	Key      string    `json:"key"`
+	QueuedAt time.Time `json:"queuedAt"`
+	Job      []byte    `json:"job"`
+}
+
+// spillStore writes queued items to disk, one file per item, and reads them back in the same order. It's not safe for concurrent use.
+type spillStore[T any] struct {
+	dir   string
+	codec Codec[T]
+	// head is the sequence number of the next item to read, and tail is the sequence number of the next item to write.
+	head uint64
+	tail uint64
+}
+
+// newSpillStore prepares a given directory. Items spilled before, e.g. by the previous Botkube run, are removed,
+// as the sources they come from are not available anymore.
+func newSpillStore[T any](dir string, codec Codec[T]) (*spillStore[T], error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("while creating directory: %w", err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("while listing directory: %w", err)
+	}
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), spillFileExt) {
+			continue
+		}
+		if err := os.Remove(filepath.Join(dir, e.Name())); err != nil {
+			return nil, fmt.Errorf("while removing stale spilled item: %w", err)
+		}
+	}
+
+	return &spillStore[T]{dir: dir, codec: codec}, nil
+}
+
+// Len returns the number of spilled items.
+func (s *spillStore[T]) Len() int {
+	return int(s.tail - s.head)
+}
+
+// Write writes a given item to disk.
+func (s *spillStore[T]) Write(it item[T]) error {
+	job, err := s.codec.Encode(it.job)
+	if err != nil {
+		return fmt.Errorf("while encoding job: %w", err)
+	}
+	data, err := json.Marshal(spilledItem{Key: it.key, QueuedAt: it.queuedAt, Job: job})
+	if err != nil {
+		return fmt.Errorf("while marshaling item: %w", err)
+	}
+	if err := os.WriteFile(s.path(s.tail), data, 0o600); err != nil {
+		return fmt.Errorf("while writing item: %w", err)
+	}
+	s.tail++
+	return nil
+}
+
+// Read reads and removes the oldest spilled item. The item is skipped if it cannot be read.
+func (s *spillStore[T]) Read() (item[T], error) {
+	path := s.path(s.head)
+	s.head++
+	defer os.Remove(path) //nolint:errcheck // the file is also removed on the next start
+
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return item[T]{}, fmt.Errorf("while reading item: %w", err)
+	}
+	var spilled spilledItem
+	if err := json.Unmarshal(data, &spilled); err != nil {
+		return item[T]{}, fmt.Errorf("while unmarshaling item: %w", err)
+	}
+	job, err := s.codec.Decode(spilled.Job)
+	if err != nil {
+		return item[T]{}, fmt.Errorf("while decoding job: %w", err)
+	}
+	return item[T]{key: spilled.Key, job: job, queuedAt: spilled.QueuedAt}, nil
+}
+
+func (s *spillStore[T]) path(seq uint64) string {
+	return filepath.Join(s.dir, fmt.Sprintf("%020d%s", seq, spillFileExt))
+}
@@ -0,0 +1,79 @@
+package action
+
+import (
+	"sync"
+	"time"
+)
+
+// budget tracks the number of executions per key within a sliding time window.
+type budget struct {
+	mu    sync.Mutex
+	now   func() time.Time
+	execs map[string]*executions
+}
+
+type executions struct {
+	window   time.Duration
+	times    []time.Time
+	notified bool
+}
+
+func newBudget() *budget {
+	return &budget{
+		now:   time.Now,
+		execs: map[string]*executions{},
+	}
+}
+
+// Take records an execution for a given key if there were less than max executions within the window.
+// It returns whether the execution is allowed, and the number of executions within the window, including the allowed one.
+func (b *budget) Take(key string, max int, window time.Duration) (bool, int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	b.prune(now)
+
+	execs, ok := b.execs[key]
+	if !ok {
+		execs = &executions{}
+		b.execs[key] = execs
+	}
+	execs.window = window
+
+	if len(execs.times) >= max {
+		return false, len(execs.times)
+	}
+
+	execs.times = append(execs.times, now)
+	execs.notified = false
+	return true, len(execs.times)
+}
+
+// MarkNotified returns true if it's called for the first time since the budget for a given key was exhausted.
+func (b *budget) MarkNotified(key string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	execs, ok := b.execs[key]
+	if !ok || execs.notified {
+		return false
+	}
+	execs.notified = true
+	return true
+}
+
+// prune removes executions which are out of their windows, so the budget doesn't grow with the number of observed resources.
+func (b *budget) prune(now time.Time) {
+	for key, execs := range b.execs {
+		idx := 0
+		for idx < len(execs.times) && now.Sub(execs.times[idx]) >= execs.window {
+			idx++
+		}
+		if idx == len(execs.times) {
+			delete(b.execs, key)
+			continue
+		}
+		execs.times = execs.times[idx:]
+	}
+}
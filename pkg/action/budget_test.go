@@ -0,0 +1,48 @@
+package action
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBudgetTake(t *testing.T) {
+	// given
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	b := newBudget()
+	b.now = func() time.Time { return now }
+
+	take := func(key string) bool {
+		allowed, _ := b.Take(key, 2, time.Hour)
+		return allowed
+	}
+
+	// when
+	assert.True(t, take("describe/prod/deployment/api"))
+	now = now.Add(30 * time.Minute)
+	assert.True(t, take("describe/prod/deployment/api"))
+	assert.True(t, take("describe/prod/deployment/db"), "other resources should have separate budget")
+
+	// then
+	assert.False(t, take("describe/prod/deployment/api"))
+	assert.True(t, b.MarkNotified("describe/prod/deployment/api"))
+	assert.False(t, b.MarkNotified("describe/prod/deployment/api"), "notice should be reported only once")
+
+	// when the first execution leaves the window
+	now = now.Add(30 * time.Minute)
+
+	// then
+	allowed, used := b.Take("describe/prod/deployment/api", 2, time.Hour)
+	assert.True(t, allowed)
+	assert.Equal(t, 2, used)
+	assert.False(t, take("describe/prod/deployment/api"))
+	assert.True(t, b.MarkNotified("describe/prod/deployment/api"), "notice should be reported again after the budget was reset")
+
+	// when all executions leave the window
+	now = now.Add(2 * time.Hour)
+	b.Take("describe/prod/deployment/other", 2, time.Hour)
+
+	// then
+	assert.Len(t, b.execs, 1, "stale keys should be removed")
+}
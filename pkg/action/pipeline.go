@@ -0,0 +1,200 @@
+package action
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+
+	sprig "github.com/go-task/slim-sprig"
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+const (
+	maxStepOutputInMessage = 1000
+
+	stepSucceededIcon = ":white_check_mark:"
+	stepFailedIcon    = ":x:"
+	stepSkippedIcon   = ":fast_forward:"
+)
+
+// StepResult holds the result of an executed pipeline step. It's available for the next steps under `.Steps.<name>`.
+type StepResult struct {
+	// Command is the rendered step command.
+	Command string
+	// Output is the plaintext output of the command.
+	Output string
+	// JSON holds the parsed output if it's a valid JSON, e.g. for `kubectl get pod -o json`.
+	JSON any
+	// Error describes why the step failed. Empty if the step succeeded.
+	Error string
+}
+
+type pipelineData struct {
+	renderingData
+	Steps map[string]StepResult
+}
+
+// executePipeline executes all action steps one by one and returns the pipeline execution log.
+func (p *Provider) executePipeline(ctx context.Context, action Action) interactive.CoreMessage {
+	log := p.log.WithField("action", action.DisplayName)
+
+	data := pipelineData{
+		renderingData: renderingData{
+			Event:       action.Event,
+			Object:      action.Context.Object,
+			TopOwnerRef: action.Context.TopOwnerRef,
+			Enrichments: action.Context.Enrichments,
+			Owner:       ownerOf(action.Context),
+		},
+		Steps: map[string]StepResult{},
+	}
+
+	var executed []StepResult
+	for idx, step := range action.Steps {
+		log := log.WithFields(logrus.Fields{
+			"step":  step.Name,
+			"index": idx + 1,
+		})
+
+		res := p.executeStep(ctx, action, step, data)
+		data.Steps[step.Name] = res
+		executed = append(executed, res)
+
+		if res.Error == "" {
+			log.Info("Action pipeline step succeeded")
+			continue
+		}
+
+		log.WithField("error", res.Error).Warn("Action pipeline step failed")
+		if step.OnError != config.ContinueOnActionStepError {
+			break
+		}
+	}
+
+	return pipelineMessage(action, executed)
+}
+
+func (p *Provider) executeStep(ctx context.Context, action Action, step config.ActionStep, data pipelineData) StepResult {
+	cmd, err := renderStepCommand(step, data)
+	if err != nil {
+		return StepResult{Error: err.Error()}
+	}
+
+	out, err := p.executeCommand(ctx, action, cmd)
+	res := StepResult{
+		Command: cmd,
+		Output:  messageText(out),
+	}
+	if err != nil {
+		res.Error = err.Error()
+		return res
+	}
+
+	var parsed any
+	if json.Unmarshal([]byte(res.Output), &parsed) == nil {
+		res.JSON = parsed
+	}
+	return res
+}
+
+func renderStepCommand(step config.ActionStep, data pipelineData) (string, error) {
+	tpl, err := template.New(step.Name).Funcs(sprig.TxtFuncMap()).Option("missingkey=error").Parse(step.Command)
+	if err != nil {
+		return "", fmt.Errorf("while parsing command template for %q step: %w", step.Name, err)
+	}
+
+	var out bytes.Buffer
+	if err := tpl.Execute(&out, data); err != nil {
+		return "", fmt.Errorf("while rendering command for %q step: %w", step.Name, err)
+	}
+	return strings.TrimSpace(out.String()), nil
+}
+
+// messageText returns the plaintext content of a given message.
+func messageText(msg interactive.CoreMessage) string {
+	var parts []string
+	appendBody := func(body api.Body) {
+		for _, item := range []string{body.CodeBlock, body.Plaintext} {
+			if item = strings.TrimSpace(item); item != "" {
+				parts = append(parts, item)
+			}
+		}
+	}
+
+	appendBody(msg.Message.BaseBody)
+	for _, section := range msg.Message.Sections {
+		appendBody(section.Body)
+	}
+	return strings.Join(parts, "\n")
+}
+
+func pipelineMessage(action Action, executed []StepResult) interactive.CoreMessage {
+	failed := 0
+	for _, res := range executed {
+		if res.Error != "" {
+			failed++
+		}
+	}
+
+	header := fmt.Sprintf("Action pipeline %q", action.DisplayName)
+	sections := []api.Section{
+		{
+			Base: api.Base{
+				Header:      header,
+				Description: fmt.Sprintf("Executed %d/%d steps, %d failed.", len(executed), len(action.Steps), failed),
+			},
+		},
+	}
+
+	for idx, step := range action.Steps {
+		if idx >= len(executed) {
+			sections = append(sections, api.Section{
+				Base: api.Base{
+					Description: fmt.Sprintf("%s %d. *%s* skipped", stepSkippedIcon, idx+1, step.Name),
+				},
+			})
+			continue
+		}
+
+		res := executed[idx]
+		section := api.Section{
+			Base: api.Base{
+				Description: fmt.Sprintf("%s %d. *%s*", stepSucceededIcon, idx+1, step.Name),
+				Body: api.Body{
+					CodeBlock: truncate(res.Output, maxStepOutputInMessage),
+				},
+			},
+		}
+		if res.Command != "" {
+			section.Context = api.ContextItems{{Text: fmt.Sprintf("`%s`", res.Command)}}
+		}
+		if res.Error != "" {
+			section.Description = fmt.Sprintf("%s %d. *%s* failed", stepFailedIcon, idx+1, step.Name)
+			if res.Output == "" {
+				section.Body.CodeBlock = res.Error
+			}
+		}
+		sections = append(sections, section)
+	}
+
+	return interactive.CoreMessage{
+		Description: header,
+		Message: api.Message{
+			Sections: sections,
+		},
+	}
+}
+
+func truncate(in string, max int) string {
+	if len(in) <= max {
+		return in
+	}
+	return in[:max] + "..."
+}
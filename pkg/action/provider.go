@@ -3,15 +3,18 @@ package action
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
-	"html/template"
 	"strings"
+	"text/template"
 
 	sprig "github.com/go-task/slim-sprig"
 	"github.com/sirupsen/logrus"
 
+	"github.com/kubeshop/botkube/internal/filter"
 	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/api/source"
 	"github.com/kubeshop/botkube/pkg/bot/interactive"
 	"github.com/kubeshop/botkube/pkg/config"
 	"github.com/kubeshop/botkube/pkg/execute"
@@ -30,6 +33,23 @@ type Action struct {
 	Command          string
 	ExecutorBindings []string
 	DisplayName      string
+
+	// Steps are set for action pipelines. In such case, Command is empty.
+	Steps []config.ActionStep
+	// Event holds the event data used to render the pipeline step commands.
+	Event any
+	// Context holds additional event details used to render the pipeline step commands.
+	Context source.ActionContext
+
+	// Remediation is set for actions which mutate cluster resources. Such actions are executed only within the configured budget.
+	Remediation *Remediation
+	// RateLimitNotice is set if the action exceeded its rate limit. In such case, the action is not executed and only the notice is posted.
+	RateLimitNotice string
+}
+
+// ResultExecutor is an executor which reports command failures.
+type ResultExecutor interface {
+	ExecuteWithResult(ctx context.Context) (interactive.CoreMessage, error)
 }
 
 // ExecutorFactory facilitates creation of execute.Executor instances.
@@ -42,18 +62,46 @@ type Provider struct {
 	log             logrus.FieldLogger
 	cfg             config.Actions
 	executorFactory ExecutorFactory
+	conditions      map[string]*filter.Program
+	remediations    *budget
+	rateLimits      *budget
 }
 
-// NewProvider returns new instance of Provider.
-func NewProvider(log logrus.FieldLogger, cfg config.Actions, executorFactory ExecutorFactory) *Provider {
-	return &Provider{log: log, cfg: cfg, executorFactory: executorFactory}
+// NewProvider returns new instance of Provider. It returns an error if any enabled action has an invalid condition.
+func NewProvider(log logrus.FieldLogger, cfg config.Actions, executorFactory ExecutorFactory) (*Provider, error) {
+	conditions := map[string]*filter.Program{}
+	errs := multierror.New()
+	for name, action := range cfg {
+		if !action.Enabled || action.Condition == "" {
+			continue
+		}
+		program, err := filter.CompileWithVariables(action.Condition, filter.ActionVariables...)
+		if err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("while compiling condition for Action %q: %w", action.DisplayName, err))
+			continue
+		}
+		conditions[name] = program
+	}
+	if err := errs.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+
+	return &Provider{
+		log:             log,
+		cfg:             cfg,
+		executorFactory: executorFactory,
+		conditions:      conditions,
+		remediations:    newBudget(),
+		rateLimits:      newBudget(),
+	}, nil
 }
 
-// RenderedActions finds and processes actions for given data.
-func (p *Provider) RenderedActions(e any, sourceBindings []string) ([]Action, error) {
+// RenderedActions finds and processes actions for a given event.
+func (p *Provider) RenderedActions(e source.Event, sourceBindings []string) ([]Action, error) {
 	var actions []Action
 	errs := multierror.New()
-	for _, action := range p.cfg {
+	var conditionVars map[string]any
+	for name, action := range p.cfg {
 		if !action.Enabled {
 			continue
 		}
@@ -62,11 +110,74 @@ func (p *Provider) RenderedActions(e any, sourceBindings []string) ([]Action, er
 			continue
 		}
 
-		p.log.Debugf("Rendering Action %q (command: %q)...", action.DisplayName, action.Command)
+		if program, ok := p.conditions[name]; ok {
+			if conditionVars == nil {
+				vars, err := conditionVariables(e)
+				if err != nil {
+					errs = multierror.Append(errs, fmt.Errorf("while preparing condition variables for Action %q: %w", action.DisplayName, err))
+					continue
+				}
+				conditionVars = vars
+			}
+
+			matched, err := program.EvalBool(conditionVars)
+			if err != nil {
+				// e.g. a label used in the condition is not set on the object
+				p.log.WithError(err).Debugf("Cannot evaluate condition for Action %q. Skipping...", action.DisplayName)
+				continue
+			}
+			if !matched {
+				continue
+			}
+		}
+
+		if action.RateLimit.MaxExecutions > 0 {
+			limited, notice := p.rateLimited(name, action, resourceRef(e))
+			if limited {
+				if notice != "" {
+					actions = append(actions, Action{
+						DisplayName:      action.DisplayName,
+						ExecutorBindings: action.Bindings.Executors,
+						RateLimitNotice:  notice,
+					})
+				}
+				continue
+			}
+		}
+
 		renderingData := renderingData{
-			Event: e,
+			Event:       e.RawObject,
+			Object:      e.ActionContext.Object,
+			TopOwnerRef: e.ActionContext.TopOwnerRef,
+			Enrichments: e.ActionContext.Enrichments,
+			Owner:       ownerOf(e.ActionContext),
+		}
+
+		var remediation *Remediation
+		if action.Remediation.Enabled {
+			rem, err := p.remediationFor(name, action, e, renderingData)
+			if err != nil {
+				errs = multierror.Append(errs, err)
+				continue
+			}
+			remediation = &rem
+		}
+
+		if len(action.Steps) > 0 {
+			// step commands are rendered just before execution, as they can use outputs of previous steps
+			actions = append(actions, Action{
+				DisplayName:      action.DisplayName,
+				ExecutorBindings: action.Bindings.Executors,
+				Steps:            action.Steps,
+				Event:            e.RawObject,
+				Context:          e.ActionContext,
+				Remediation:      remediation,
+			})
+			continue
 		}
-		renderedCmd, err := p.renderActionCommand(action, renderingData)
+
+		p.log.Debugf("Rendering Action %q (command: %q)...", action.DisplayName, action.Command)
+		renderedCmd, err := p.renderActionCommand(action, action.Command, renderingData)
 		if err != nil {
 			errs = multierror.Append(errs, err)
 			continue
@@ -78,14 +189,55 @@ func (p *Provider) RenderedActions(e any, sourceBindings []string) ([]Action, er
 			DisplayName:      action.DisplayName,
 			Command:          fmt.Sprintf("%s %s", api.MessageBotNamePlaceholder, renderedCmd),
 			ExecutorBindings: action.Bindings.Executors,
+			Remediation:      remediation,
 		})
 	}
 
 	return actions, errs.ErrorOrNil()
 }
 
+// conditionVariables returns the event data available in action conditions. The data is converted through JSON,
+// so it's accessible the same way as in the JSON representation.
+func conditionVariables(e source.Event) (map[string]any, error) {
+	vars := map[string]any{
+		"topOwnerRef": e.ActionContext.TopOwnerRef,
+	}
+	for name, in := range map[string]any{"event": e.RawObject, "object": e.ActionContext.Object, "enrichments": e.ActionContext.Enrichments, "owner": e.ActionContext.Owner} {
+		raw, err := json.Marshal(in)
+		if err != nil {
+			return nil, fmt.Errorf("while marshaling %s: %w", name, err)
+		}
+		var out any
+		if err := json.Unmarshal(raw, &out); err != nil {
+			return nil, fmt.Errorf("while unmarshaling %s: %w", name, err)
+		}
+		vars[name] = out
+	}
+	return vars, nil
+}
+
 // ExecuteAction executes action for given event.
 func (p *Provider) ExecuteAction(ctx context.Context, action Action) interactive.CoreMessage {
+	if action.RateLimitNotice != "" {
+		return rateLimitedMessage(action)
+	}
+	if action.Remediation != nil {
+		return p.executeRemediation(ctx, action)
+	}
+	return p.execute(ctx, action)
+}
+
+func (p *Provider) execute(ctx context.Context, action Action) interactive.CoreMessage {
+	if len(action.Steps) > 0 {
+		return p.executePipeline(ctx, action)
+	}
+
+	response, _ := p.executeCommand(ctx, action, action.Command)
+	return response
+}
+
+// executeCommand executes a given command on behalf of the action. The error is returned only if the executor is able to report failures.
+func (p *Provider) executeCommand(ctx context.Context, action Action, cmd string) (interactive.CoreMessage, error) {
 	userName := fmt.Sprintf("Automation %q", action.DisplayName)
 	e := p.executorFactory.NewDefault(execute.NewDefaultInput{
 		Conversation: execute.Conversation{
@@ -98,32 +250,52 @@ func (p *Provider) ExecuteAction(ctx context.Context, action Action) interactive
 		CommGroupName:   unknownValue,
 		Platform:        unknownValue,
 		NotifierHandler: &universalNotifierHandler{},
-		Message:         strings.TrimSpace(strings.TrimPrefix(action.Command, api.MessageBotNamePlaceholder)),
+		Message:         strings.TrimSpace(strings.TrimPrefix(cmd, api.MessageBotNamePlaceholder)),
 		User: execute.UserInput{
 			Mention:     userName,
 			DisplayName: userName,
 		},
 	})
-	response := e.Execute(ctx)
 
-	return response
+	if withResult, ok := e.(ResultExecutor); ok {
+		return withResult.ExecuteWithResult(ctx)
+	}
+	return e.Execute(ctx), nil
 }
 
+// renderingData holds data available in action command templates.
 type renderingData struct {
+	// Event holds the whole event, e.g. `{{ .Event.Namespace }}`.
 	Event any
+	// Object holds the full object that the event relates to, if provided by a given source.
+	Object any
+	// TopOwnerRef holds the top-most owner of the object in the `kind/name` format, e.g. `deployment/nginx`.
+	TopOwnerRef string
+	// Enrichments holds responses of enrichment hooks indexed by their names, e.g. `{{ .Enrichments.catalog.team }}`.
+	Enrichments map[string]any
+	// Owner holds the team which owns the object, e.g. `{{ .Owner.Team }}`. It's empty if the owner is unknown.
+	Owner source.Owner
+}
+
+// ownerOf returns the owner from a given action context, so templates don't fail on a missing owner.
+func ownerOf(in source.ActionContext) source.Owner {
+	if in.Owner == nil {
+		return source.Owner{}
+	}
+	return *in.Owner
 }
 
-func (p *Provider) renderActionCommand(action config.Action, data renderingData) (string, error) {
-	tpl := template.New("action-cmd").Funcs(sprig.FuncMap())
-	tpl, err := tpl.Parse(action.Command)
+func (p *Provider) renderActionCommand(action config.Action, command string, data renderingData) (string, error) {
+	tpl := template.New("action-cmd").Funcs(sprig.TxtFuncMap())
+	tpl, err := tpl.Parse(command)
 	if err != nil {
-		return "", fmt.Errorf("while parsing command template %q for Action %q: %w", action.Command, action.DisplayName, err)
+		return "", fmt.Errorf("while parsing command template %q for Action %q: %w", command, action.DisplayName, err)
 	}
 
 	var result bytes.Buffer
 	err = tpl.Execute(&result, data)
 	if err != nil {
-		return "", fmt.Errorf("while rendering command %q for Action %q: %w", action.Command, action.DisplayName, err)
+		return "", fmt.Errorf("while rendering command %q for Action %q: %w", command, action.DisplayName, err)
 	}
 
 	return result.String(), nil
@@ -2,6 +2,7 @@ package action_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 
@@ -11,6 +12,7 @@ import (
 
 	"github.com/kubeshop/botkube/pkg/action"
 	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/api/source"
 	"github.com/kubeshop/botkube/pkg/bot/interactive"
 	"github.com/kubeshop/botkube/pkg/config"
 	"github.com/kubeshop/botkube/pkg/execute"
@@ -23,7 +25,7 @@ func TestProvider_RenderedActionsForEvent(t *testing.T) {
 	testCases := []struct {
 		Name               string
 		Config             config.Actions
-		Event              event
+		Event              source.Event
 		SourceBindings     []string
 		ExpectedResult     []action.Action
 		ExpectedErrMessage string
@@ -32,7 +34,7 @@ func TestProvider_RenderedActionsForEvent(t *testing.T) {
 			Name:           "Success - filter disabled actions and the ones with different bindings",
 			Config:         fixActionsConfig(),
 			SourceBindings: []string{"success", "disabled"},
-			Event:          fixEvent("name"),
+			Event:          source.Event{RawObject: fixEvent("name")},
 			ExpectedResult: []action.Action{
 				{
 					Command:          "{{BotName}} kubectl get po name",
@@ -41,18 +43,145 @@ func TestProvider_RenderedActionsForEvent(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name: "Full event context with Sprig functions",
+			Config: config.Actions{
+				"restart": {
+					Enabled:     true,
+					DisplayName: "Restart",
+					Command:     `kubectl -n {{ .Event.Name }} rollout restart {{ .TopOwnerRef }} -l 'app={{ .Object.metadata.labels.app | lower }}'`,
+					Bindings: config.ActionBindings{
+						Sources:   []string{"success"},
+						Executors: []string{"executor-binding1"},
+					},
+				},
+			},
+			SourceBindings: []string{"success"},
+			Event: source.Event{
+				RawObject: fixEvent("prod"),
+				ActionContext: source.ActionContext{
+					Object: map[string]any{
+						"metadata": map[string]any{
+							"labels": map[string]any{"app": "API"},
+						},
+					},
+					TopOwnerRef: "deployment/api",
+				},
+			},
+			ExpectedResult: []action.Action{
+				{
+					Command:          "{{BotName}} kubectl -n prod rollout restart deployment/api -l 'app=api'",
+					ExecutorBindings: []string{"executor-binding1"},
+					DisplayName:      "Restart",
+				},
+			},
+		},
+		{
+			Name: "Only actions with matching conditions",
+			Config: config.Actions{
+				"prod-errors": {
+					Enabled:     true,
+					DisplayName: "Prod errors",
+					Command:     "kubectl describe {{ .TopOwnerRef }}",
+					Condition:   `event.Name.startsWith("prod-") && object.metadata.labels.team == "payments"`,
+					Bindings: config.ActionBindings{
+						Sources:   []string{"success"},
+						Executors: []string{"executor-binding1"},
+					},
+				},
+				"dev-errors": {
+					Enabled:     true,
+					DisplayName: "Dev errors",
+					Command:     "kubectl describe {{ .TopOwnerRef }}",
+					Condition:   `event.Name.startsWith("dev-")`,
+					Bindings: config.ActionBindings{
+						Sources:   []string{"success"},
+						Executors: []string{"executor-binding1"},
+					},
+				},
+				"missing-label": {
+					Enabled:     true,
+					DisplayName: "Missing label",
+					Command:     "kubectl describe {{ .TopOwnerRef }}",
+					Condition:   `object.metadata.labels.env == "prod"`,
+					Bindings: config.ActionBindings{
+						Sources:   []string{"success"},
+						Executors: []string{"executor-binding1"},
+					},
+				},
+			},
+			SourceBindings: []string{"success"},
+			Event: source.Event{
+				RawObject: fixEvent("prod-api"),
+				ActionContext: source.ActionContext{
+					Object: map[string]any{
+						"metadata": map[string]any{
+							"labels": map[string]any{"team": "payments"},
+						},
+					},
+					TopOwnerRef: "deployment/api",
+				},
+			},
+			ExpectedResult: []action.Action{
+				{
+					Command:          "{{BotName}} kubectl describe deployment/api",
+					ExecutorBindings: []string{"executor-binding1"},
+					DisplayName:      "Prod errors",
+				},
+			},
+		},
+		{
+			Name: "Owner in template and condition",
+			Config: config.Actions{
+				"page-owner": {
+					Enabled:     true,
+					DisplayName: "Page owner",
+					Command:     `pagerduty trigger --team {{ .Owner.Team }} {{ .TopOwnerRef }}`,
+					Condition:   `owner.team == "payments"`,
+					Bindings: config.ActionBindings{
+						Sources:   []string{"success"},
+						Executors: []string{"executor-binding1"},
+					},
+				},
+				"unowned": {
+					Enabled:     true,
+					DisplayName: "Unowned",
+					Command:     `kubectl describe {{ .TopOwnerRef }}{{ if not .Owner.Team }} # unowned{{ end }}`,
+					Condition:   `owner == null`,
+					Bindings: config.ActionBindings{
+						Sources:   []string{"success"},
+						Executors: []string{"executor-binding1"},
+					},
+				},
+			},
+			SourceBindings: []string{"success"},
+			Event: source.Event{
+				RawObject: fixEvent("prod-api"),
+				ActionContext: source.ActionContext{
+					TopOwnerRef: "deployment/api",
+					Owner:       &source.Owner{Team: "payments", Source: "annotation"},
+				},
+			},
+			ExpectedResult: []action.Action{
+				{
+					Command:          "{{BotName}} pagerduty trigger --team payments deployment/api",
+					ExecutorBindings: []string{"executor-binding1"},
+					DisplayName:      "Page owner",
+				},
+			},
+		},
 		{
 			Name:           "No matching actions",
 			Config:         fixActionsConfig(),
 			SourceBindings: []string{"totally-different"},
-			Event:          fixEvent("name"),
+			Event:          source.Event{RawObject: fixEvent("name")},
 			ExpectedResult: nil,
 		},
 		{
 			Name:           "Both valid and invalid actions",
 			Config:         fixActionsConfig(),
 			SourceBindings: []string{"success", "invalid-command"},
-			Event:          fixEvent("name"),
+			Event:          source.Event{RawObject: fixEvent("name")},
 			ExpectedResult: []action.Action{
 				{
 					Command:          "{{BotName}} kubectl get po name",
@@ -68,7 +197,8 @@ func TestProvider_RenderedActionsForEvent(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.Name, func(t *testing.T) {
-			provider := action.NewProvider(loggerx.NewNoop(), tc.Config, nil)
+			provider, err := action.NewProvider(loggerx.NewNoop(), tc.Config, nil)
+			require.NoError(t, err)
 
 			// when
 			result, err := provider.RenderedActions(tc.Event, tc.SourceBindings)
@@ -114,7 +244,8 @@ func TestProvider_ExecuteEventAction(t *testing.T) {
 	}
 
 	execFactory := &fakeFactory{t: t, expectedInput: expectedExecutorInput}
-	provider := action.NewProvider(loggerx.NewNoop(), config.Actions{}, execFactory)
+	provider, err := action.NewProvider(loggerx.NewNoop(), config.Actions{}, execFactory)
+	require.NoError(t, err)
 
 	// when
 	msg := provider.ExecuteAction(context.Background(), eventAction)
@@ -124,6 +255,59 @@ func TestProvider_ExecuteEventAction(t *testing.T) {
 	assert.Equal(t, fixInteractiveMessage(botName), msg)
 }
 
+func TestProvider_ExecutePipelineAction(t *testing.T) {
+	// given
+	pipeline := action.Action{
+		DisplayName: "Ticket",
+		Event:       fixEvent("api-0"),
+		Steps: []config.ActionStep{
+			{Name: "pod", Command: "kubectl get po {{ .Event.Name }} -o json"},
+			{Name: "logs", Command: "kubectl logs {{ .Steps.pod.JSON.metadata.name }} -n {{ .Steps.pod.JSON.metadata.namespace }}", OnError: config.ContinueOnActionStepError},
+			{Name: "ticket", Command: `jira create --summary "{{ .Event.Name }} failed" --description {{ .Steps.logs.Output | quote }}`},
+			{Name: "notify", Command: "kubectl get po"},
+		},
+	}
+	execFactory := &fakePipelineFactory{
+		outputs: map[string]string{
+			"kubectl get po api-0 -o json": `{"metadata": {"name": "api-0", "namespace": "prod"}}`,
+			"kubectl logs api-0 -n prod":   "panic: boom",
+		},
+		failing: map[string]bool{
+			"kubectl logs api-0 -n prod":                                       true,
+			`jira create --summary "api-0 failed" --description "panic: boom"`: true,
+		},
+	}
+	provider, err := action.NewProvider(loggerx.NewNoop(), config.Actions{}, execFactory)
+	require.NoError(t, err)
+
+	// when
+	msg := provider.ExecuteAction(context.Background(), pipeline)
+
+	// then
+	assert.Equal(t, []string{
+		"kubectl get po api-0 -o json",
+		"kubectl logs api-0 -n prod",
+		`jira create --summary "api-0 failed" --description "panic: boom"`,
+	}, execFactory.executed)
+
+	require.Len(t, msg.Message.Sections, 5)
+	assert.Equal(t, "Executed 3/4 steps, 2 failed.", msg.Message.Sections[0].Description)
+	assert.Equal(t, ":white_check_mark: 1. *pod*", msg.Message.Sections[1].Description)
+	assert.Equal(t, ":x: 2. *logs* failed", msg.Message.Sections[2].Description)
+	assert.Equal(t, "panic: boom", msg.Message.Sections[2].Body.CodeBlock)
+	assert.Equal(t, ":x: 3. *ticket* failed", msg.Message.Sections[3].Description)
+	assert.Equal(t, ":fast_forward: 4. *notify* skipped", msg.Message.Sections[4].Description)
+}
+
+func TestNewProviderInvalidCondition(t *testing.T) {
+	_, err := action.NewProvider(loggerx.NewNoop(), config.Actions{
+		"broken":   {Enabled: true, DisplayName: "Broken", Command: "kubectl get po", Condition: `event.Level ==`},
+		"disabled": {Enabled: false, DisplayName: "Disabled", Command: "kubectl get po", Condition: `event.Level ==`},
+	}, nil)
+
+	assert.ErrorContains(t, err, `while compiling condition for Action "Broken": Syntax error: mismatched input '<EOF>'`)
+}
+
 func fixActionsConfig() config.Actions {
 	executorBindings := []string{"executor-binding1", "executor-binding2"}
 	sampleCommand := "kubectl get po {{ .Event.Name }}"
@@ -191,6 +375,35 @@ func (f *fakeFactory) NewDefault(input execute.NewDefaultInput) execute.Executor
 
 type fakeExecutor struct{}
 
+type fakePipelineFactory struct {
+	outputs  map[string]string
+	failing  map[string]bool
+	executed []string
+}
+
+func (f *fakePipelineFactory) NewDefault(input execute.NewDefaultInput) execute.Executor {
+	f.executed = append(f.executed, input.Message)
+	return &fakeResultExecutor{out: f.outputs[input.Message], failed: f.failing[input.Message]}
+}
+
+type fakeResultExecutor struct {
+	out    string
+	failed bool
+}
+
+func (e *fakeResultExecutor) Execute(ctx context.Context) interactive.CoreMessage {
+	msg, _ := e.ExecuteWithResult(ctx)
+	return msg
+}
+
+func (e *fakeResultExecutor) ExecuteWithResult(_ context.Context) (interactive.CoreMessage, error) {
+	msg := interactive.CoreMessage{Message: api.NewCodeBlockMessage(e.out, false)}
+	if e.failed {
+		return msg, errors.New("command failed")
+	}
+	return msg, nil
+}
+
 func (fakeExecutor) Execute(_ context.Context) interactive.CoreMessage {
 	return fixInteractiveMessage("{{BotName}}")
 }
@@ -0,0 +1,45 @@
+package action
+
+import (
+	"fmt"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+// rateLimited returns true if a given action exceeded its rate limit for a given resource.
+// The notice is returned only for the first rate limited execution, so the channel is not flooded with notices.
+func (p *Provider) rateLimited(name string, action config.Action, resource string) (bool, string) {
+	key := name + "/" + resource
+	allowed, _ := p.rateLimits.Take(key, action.RateLimit.MaxExecutions, action.RateLimit.Window)
+	if allowed {
+		return false, ""
+	}
+
+	log := p.log.WithField("action", action.DisplayName).WithField("resource", resource)
+	if !p.rateLimits.MarkNotified(key) {
+		log.Debug("Action rate limited. Skipping...")
+		return true, ""
+	}
+
+	log.Info("Action rate limited. Further executions are skipped until the rate limit window passes.")
+	return true, fmt.Sprintf("Action executed %d times for `%s` within %s. Further executions are skipped until the rate limit window passes.", action.RateLimit.MaxExecutions, resource, action.RateLimit.Window)
+}
+
+func rateLimitedMessage(action Action) interactive.CoreMessage {
+	header := fmt.Sprintf("Action %q rate limited", action.DisplayName)
+	return interactive.CoreMessage{
+		Description: header,
+		Message: api.Message{
+			Sections: []api.Section{
+				{
+					Base: api.Base{
+						Header:      header,
+						Description: action.RateLimitNotice,
+					},
+				},
+			},
+		},
+	}
+}
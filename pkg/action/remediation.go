@@ -0,0 +1,109 @@
+package action
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/api/source"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+// Remediation holds details of an action which mutates cluster resources.
+type Remediation struct {
+	// Name is the action configuration name.
+	Name string
+	// Resource identifies the remediated resource, e.g. `prod/deployment/api`.
+	Resource      string
+	MaxExecutions int
+	Window        time.Duration
+	// RollbackCommand is the rendered command which reverts the remediation. It's empty if not configured.
+	RollbackCommand string
+}
+
+func (p *Provider) remediationFor(name string, action config.Action, e source.Event, data renderingData) (Remediation, error) {
+	rem := Remediation{
+		Name:          name,
+		Resource:      resourceRef(e),
+		MaxExecutions: action.Remediation.MaxExecutions,
+		Window:        action.Remediation.Window,
+	}
+	if action.Remediation.RollbackCommand == "" {
+		return rem, nil
+	}
+
+	rollbackCmd, err := p.renderActionCommand(action, action.Remediation.RollbackCommand, data)
+	if err != nil {
+		return Remediation{}, err
+	}
+	rem.RollbackCommand = strings.TrimSpace(rollbackCmd)
+	return rem, nil
+}
+
+// executeRemediation executes a given action only if the remediation budget for a given resource is not exhausted.
+func (p *Provider) executeRemediation(ctx context.Context, action Action) interactive.CoreMessage {
+	rem := action.Remediation
+	log := p.log.WithField("action", action.DisplayName).WithField("resource", rem.Resource)
+
+	allowed, used := p.remediations.Take(rem.Name+"/"+rem.Resource, rem.MaxExecutions, rem.Window)
+	if !allowed {
+		log.Warn("Remediation budget exhausted. Skipping...")
+		header := fmt.Sprintf("Remediation %q skipped", action.DisplayName)
+		return interactive.CoreMessage{
+			Description: header,
+			Message: api.Message{
+				Sections: []api.Section{
+					{
+						Base: api.Base{
+							Header:      header,
+							Description: fmt.Sprintf("Remediation budget for `%s` is exhausted: %d executions per %s.", rem.Resource, rem.MaxExecutions, rem.Window),
+						},
+					},
+				},
+			},
+		}
+	}
+
+	log.WithField("execution", used).Info("Executing remediation...")
+	msg := p.execute(ctx, action)
+
+	section := api.Section{
+		Context: api.ContextItems{
+			{Text: fmt.Sprintf("Remediation %d/%d for `%s` within %s.", used, rem.MaxExecutions, rem.Resource, rem.Window)},
+		},
+	}
+	if rem.RollbackCommand != "" {
+		btnBuilder := api.NewMessageButtonBuilder()
+		section.Buttons = api.Buttons{
+			btnBuilder.ForCommandWithoutDesc("Rollback", rem.RollbackCommand, api.ButtonStyleDanger),
+		}
+	}
+	msg.Message.Sections = append(msg.Message.Sections, section)
+	return msg
+}
+
+// resourceRef returns the reference of the resource related to a given event. If available, the top-most owner is used,
+// so that e.g. all Pods of a given Deployment share the same reference.
+func resourceRef(e source.Event) string {
+	var event struct {
+		Kind      string
+		Name      string
+		Namespace string
+	}
+	if raw, err := json.Marshal(e.RawObject); err == nil {
+		_ = json.Unmarshal(raw, &event)
+	}
+
+	ref := e.ActionContext.TopOwnerRef
+	if ref == "" {
+		ref = fmt.Sprintf("%s/%s", strings.ToLower(event.Kind), event.Name)
+	}
+	if event.Namespace != "" {
+		ref = fmt.Sprintf("%s/%s", event.Namespace, ref)
+	}
+	return ref
+}
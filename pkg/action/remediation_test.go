@@ -0,0 +1,77 @@
+package action_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/action"
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/api/source"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+func TestProvider_ExecuteRemediation(t *testing.T) {
+	// given
+	cfg := config.Actions{
+		"restart": {
+			Enabled:     true,
+			DisplayName: "Restart",
+			Command:     "kubectl rollout restart {{ .TopOwnerRef }} -n {{ .Event.Namespace }}",
+			Remediation: config.ActionRemediation{
+				Enabled:         true,
+				MaxExecutions:   2,
+				Window:          time.Hour,
+				RollbackCommand: "kubectl rollout undo {{ .TopOwnerRef }} -n {{ .Event.Namespace }}",
+			},
+			Bindings: config.ActionBindings{
+				Sources:   []string{"k8s-err-events"},
+				Executors: []string{"k8s-tools"},
+			},
+		},
+	}
+	execFactory := &fakePipelineFactory{
+		outputs: map[string]string{
+			"kubectl rollout restart deployment/api -n prod": "deployment.apps/api restarted",
+		},
+	}
+	provider, err := action.NewProvider(loggerx.NewNoop(), cfg, execFactory)
+	require.NoError(t, err)
+
+	event := source.Event{
+		RawObject: map[string]any{"Kind": "Pod", "Name": "api-123", "Namespace": "prod"},
+		ActionContext: source.ActionContext{
+			TopOwnerRef: "deployment/api",
+		},
+	}
+
+	// when
+	var msgs []string
+	for i := 0; i < 3; i++ {
+		actions, err := provider.RenderedActions(event, []string{"k8s-err-events"})
+		require.NoError(t, err)
+		require.Len(t, actions, 1)
+
+		msg := provider.ExecuteAction(context.Background(), actions[0])
+		lastSection := msg.Message.Sections[len(msg.Message.Sections)-1]
+		if len(lastSection.Context) > 0 {
+			msgs = append(msgs, lastSection.Context[0].Text)
+			require.Len(t, lastSection.Buttons, 1)
+			assert.Equal(t, api.MessageBotNamePlaceholder+" kubectl rollout undo deployment/api -n prod", lastSection.Buttons[0].Command)
+			continue
+		}
+		msgs = append(msgs, lastSection.Description)
+	}
+
+	// then
+	assert.Equal(t, []string{
+		"Remediation 1/2 for `prod/deployment/api` within 1h0m0s.",
+		"Remediation 2/2 for `prod/deployment/api` within 1h0m0s.",
+		"Remediation budget for `prod/deployment/api` is exhausted: 2 executions per 1h0m0s.",
+	}, msgs)
+	assert.Len(t, execFactory.executed, 2)
+}
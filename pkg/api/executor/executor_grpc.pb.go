@@ -20,9 +20,11 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	Executor_Execute_FullMethodName  = "/executor.Executor/Execute"
-	Executor_Metadata_FullMethodName = "/executor.Executor/Metadata"
-	Executor_Help_FullMethodName     = "/executor.Executor/Help"
+	Executor_Execute_FullMethodName           = "/executor.Executor/Execute"
+	Executor_Metadata_FullMethodName          = "/executor.Executor/Metadata"
+	Executor_Help_FullMethodName              = "/executor.Executor/Help"
+	Executor_HandleInteraction_FullMethodName = "/executor.Executor/HandleInteraction"
+	Executor_Metrics_FullMethodName           = "/executor.Executor/Metrics"
 )
 
 // ExecutorClient is the client API for Executor service.
@@ -32,6 +34,10 @@ type ExecutorClient interface {
 	Execute(ctx context.Context, in *ExecuteRequest, opts ...grpc.CallOption) (*ExecuteResponse, error)
 	Metadata(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*MetadataResponse, error)
 	Help(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*HelpResponse, error)
+	// HandleInteraction handles interactions with messages returned by the plugin, such as button clicks, select changes, and form submissions.
+	HandleInteraction(ctx context.Context, in *InteractionRequest, opts ...grpc.CallOption) (*InteractionResponse, error)
+	// Metrics returns Prometheus metrics exported by the plugin, so they can be re-exposed by Botkube.
+	Metrics(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*MetricsResponse, error)
 }
 
 type executorClient struct {
@@ -69,6 +75,24 @@ func (c *executorClient) Help(ctx context.Context, in *emptypb.Empty, opts ...gr
 	return out, nil
 }
 
+func (c *executorClient) HandleInteraction(ctx context.Context, in *InteractionRequest, opts ...grpc.CallOption) (*InteractionResponse, error) {
+	out := new(InteractionResponse)
+	err := c.cc.Invoke(ctx, Executor_HandleInteraction_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *executorClient) Metrics(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*MetricsResponse, error) {
+	out := new(MetricsResponse)
+	err := c.cc.Invoke(ctx, Executor_Metrics_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ExecutorServer is the server API for Executor service.
 // All implementations must embed UnimplementedExecutorServer
 // for forward compatibility
@@ -76,6 +100,10 @@ type ExecutorServer interface {
 	Execute(context.Context, *ExecuteRequest) (*ExecuteResponse, error)
 	Metadata(context.Context, *emptypb.Empty) (*MetadataResponse, error)
 	Help(context.Context, *emptypb.Empty) (*HelpResponse, error)
+	// HandleInteraction handles interactions with messages returned by the plugin, such as button clicks, select changes, and form submissions.
+	HandleInteraction(context.Context, *InteractionRequest) (*InteractionResponse, error)
+	// Metrics returns Prometheus metrics exported by the plugin, so they can be re-exposed by Botkube.
+	Metrics(context.Context, *emptypb.Empty) (*MetricsResponse, error)
 	mustEmbedUnimplementedExecutorServer()
 }
 
@@ -92,6 +120,12 @@ func (UnimplementedExecutorServer) Metadata(context.Context, *emptypb.Empty) (*M
 func (UnimplementedExecutorServer) Help(context.Context, *emptypb.Empty) (*HelpResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Help not implemented")
 }
+func (UnimplementedExecutorServer) HandleInteraction(context.Context, *InteractionRequest) (*InteractionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HandleInteraction not implemented")
+}
+func (UnimplementedExecutorServer) Metrics(context.Context, *emptypb.Empty) (*MetricsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Metrics not implemented")
+}
 func (UnimplementedExecutorServer) mustEmbedUnimplementedExecutorServer() {}
 
 // UnsafeExecutorServer may be embedded to opt out of forward compatibility for this service.
@@ -159,6 +193,42 @@ func _Executor_Help_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Executor_HandleInteraction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InteractionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutorServer).HandleInteraction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Executor_HandleInteraction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutorServer).HandleInteraction(ctx, req.(*InteractionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Executor_Metrics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutorServer).Metrics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Executor_Metrics_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutorServer).Metrics(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Executor_ServiceDesc is the grpc.ServiceDesc for Executor service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -178,6 +248,14 @@ var Executor_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Help",
 			Handler:    _Executor_Help_Handler,
 		},
+		{
+			MethodName: "HandleInteraction",
+			Handler:    _Executor_HandleInteraction_Handler,
+		},
+		{
+			MethodName: "Metrics",
+			Handler:    _Executor_Metrics_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "executor.proto",
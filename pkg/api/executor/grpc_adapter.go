@@ -4,17 +4,26 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/go-plugin"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/slack-go/slack"
 	"google.golang.org/grpc"
 	"google.golang.org/protobuf/types/known/emptypb"
 
 	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/api/metrics"
 	"github.com/kubeshop/botkube/pkg/mathx"
 )
 
-const maxMessageNumberForSingleCommandExecution = 15
+const (
+	maxMessageNumberForSingleCommandExecution = 15
+
+	// executeDeadlineGracePeriod is subtracted from the deadline propagated from Botkube, so the plugin
+	// has time to return a partial output before Botkube stops waiting for the response.
+	executeDeadlineGracePeriod = 2 * time.Second
+)
 
 // Executor defines the Botkube executor plugin functionality.
 type Executor interface {
@@ -75,10 +84,13 @@ type (
 
 	// User represents the user that sent a message.
 	User struct {
-		// ID represents users identifier.
+		// Mention represents a user platform specific mention of the user.
 		Mention string
 		// DisplayName represents user display name. It can be empty.
 		DisplayName string
+		// ID represents the user identifier on a given communication platform. It can be empty.
+		// Unlike display names, users can't change it, so use it to authorize users.
+		ID string
 	}
 
 	// ExecuteOutput holds the output of the Execute function.
@@ -137,60 +149,28 @@ type grpcClient struct {
 }
 
 func (p *grpcClient) Execute(ctx context.Context, in ExecuteInput) (ExecuteOutput, error) {
-	grpcInput := &ExecuteRequest{
-		Command: in.Command,
-		Configs: in.Configs,
-		Context: &ExecuteContext{
-			IsInteractivitySupported: in.Context.IsInteractivitySupported,
-			KubeConfig:               in.Context.KubeConfig,
-			Message: &MessageContext{
-				Text:             in.Context.Message.Text,
-				Url:              in.Context.Message.URL,
-				ParentActivityId: in.Context.Message.ParentActivityID,
-				User: &UserContext{
-					Mention:     in.Context.Message.User.Mention,
-					DisplayName: in.Context.Message.User.DisplayName,
-				},
-			},
-			IncomingWebhook: &IncomingWebhookContext{
-				BaseSourceURL: in.Context.IncomingWebhook.BaseSourceURL,
-			},
-		},
-	}
-
-	if in.Context.IsInteractivitySupported && in.Context.SlackState != nil {
-		rawState, err := json.Marshal(in.Context.SlackState)
-		if err != nil {
-			return ExecuteOutput{}, fmt.Errorf("while marshaling slack state: %w", err)
-		}
-		grpcInput.Context.SlackState = rawState
+	execCtx, err := toExecuteContext(in.Context)
+	if err != nil {
+		return ExecuteOutput{}, err
 	}
 
-	res, err := p.client.Execute(ctx, grpcInput)
+	res, err := p.client.Execute(ctx, &ExecuteRequest{
+		Command: in.Command,
+		Configs: in.Configs,
+		Context: execCtx,
+	})
 	if err != nil {
 		return ExecuteOutput{}, err
 	}
 
-	extract := func(in []byte) (api.Message, error) {
-		if len(in) == 0 || string(in) == "" {
-			return api.Message{}, nil
-		}
-
-		var msg api.Message
-		if err := json.Unmarshal(in, &msg); err != nil {
-			return api.Message{}, fmt.Errorf("while unmarshalling message from JSON: %w", err)
-		}
-
-		return msg, nil
-	}
-	msg, err := extract(res.Message)
+	msg, err := extractMessage(res.Message)
 	if err != nil {
 		return ExecuteOutput{}, err
 	}
 
 	var msgs []api.Message
 	for _, item := range res.Messages[:mathx.Min(maxMessageNumberForSingleCommandExecution, len(res.Messages))] {
-		casted, err := extract(item)
+		casted, err := extractMessage(item)
 		if err != nil {
 			return ExecuteOutput{}, err
 		}
@@ -235,31 +215,105 @@ func (p *grpcClient) Help(ctx context.Context) (api.Message, error) {
 	return msg, nil
 }
 
+func (p *grpcClient) HandleInteraction(ctx context.Context, in InteractionInput) (InteractionOutput, error) {
+	execCtx, err := toExecuteContext(in.Context)
+	if err != nil {
+		return InteractionOutput{}, err
+	}
+
+	res, err := p.client.HandleInteraction(ctx, &InteractionRequest{
+		CallbackId:    in.Callback.ID,
+		CallbackValue: in.Callback.Value,
+		Type:          string(in.Type),
+		Values:        in.Values,
+		Configs:       in.Configs,
+		Context:       execCtx,
+	})
+	if err != nil {
+		return InteractionOutput{}, err
+	}
+
+	msg, err := extractMessage(res.Message)
+	if err != nil {
+		return InteractionOutput{}, err
+	}
+	return InteractionOutput{
+		Message: msg,
+	}, nil
+}
+
+// Metrics returns Prometheus metrics exported by the plugin.
+func (p *grpcClient) Metrics(ctx context.Context) ([]*dto.MetricFamily, error) {
+	resp, err := p.client.Metrics(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, err
+	}
+	return metrics.Decode(resp.Families)
+}
+
+func toExecuteContext(in ExecuteInputContext) (*ExecuteContext, error) {
+	out := &ExecuteContext{
+		IsInteractivitySupported: in.IsInteractivitySupported,
+		KubeConfig:               in.KubeConfig,
+		Message: &MessageContext{
+			Text:             in.Message.Text,
+			Url:              in.Message.URL,
+			ParentActivityId: in.Message.ParentActivityID,
+			User: &UserContext{
+				Mention:     in.Message.User.Mention,
+				DisplayName: in.Message.User.DisplayName,
+				Id:          in.Message.User.ID,
+			},
+		},
+		IncomingWebhook: &IncomingWebhookContext{
+			BaseSourceURL: in.IncomingWebhook.BaseSourceURL,
+		},
+	}
+
+	if in.IsInteractivitySupported && in.SlackState != nil {
+		rawState, err := json.Marshal(in.SlackState)
+		if err != nil {
+			return nil, fmt.Errorf("while marshaling slack state: %w", err)
+		}
+		out.SlackState = rawState
+	}
+	return out, nil
+}
+
+func extractMessage(in []byte) (api.Message, error) {
+	if len(in) == 0 {
+		return api.Message{}, nil
+	}
+
+	var msg api.Message
+	if err := json.Unmarshal(in, &msg); err != nil {
+		return api.Message{}, fmt.Errorf("while unmarshalling message from JSON: %w", err)
+	}
+
+	return msg, nil
+}
+
 type grpcServer struct {
 	UnimplementedExecutorServer
 	Impl Executor
 }
 
 func (p *grpcServer) Execute(ctx context.Context, request *ExecuteRequest) (*ExecuteResponse, error) {
-	var slackState slack.BlockActionStates
-	if request.Context != nil && request.Context.SlackState != nil {
-		if err := json.Unmarshal(request.Context.SlackState, &slackState); err != nil {
-			return nil, fmt.Errorf("while unmarshalling slack state from JSON: %w", err)
-		}
+	execCtx, err := p.fromExecuteContext(request.Context)
+	if err != nil {
+		return nil, err
+	}
+
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) > 2*executeDeadlineGracePeriod {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, deadline.Add(-executeDeadlineGracePeriod))
+		defer cancel()
 	}
 
 	out, err := p.Impl.Execute(ctx, ExecuteInput{
 		Command: request.Command,
 		Configs: request.Configs,
-		Context: ExecuteInputContext{
-			SlackState:               &slackState,
-			IsInteractivitySupported: request.Context.IsInteractivitySupported,
-			KubeConfig:               request.Context.KubeConfig,
-			Message:                  p.toMessageIfPresent(request.Context.Message),
-			IncomingWebhook: IncomingWebhookDetailsContext{
-				BaseSourceURL: request.Context.IncomingWebhook.BaseSourceURL,
-			},
-		},
+		Context: execCtx,
 	})
 	if err != nil {
 		return nil, err
@@ -286,6 +340,59 @@ func (p *grpcServer) Execute(ctx context.Context, request *ExecuteRequest) (*Exe
 	}, nil
 }
 
+func (p *grpcServer) HandleInteraction(ctx context.Context, request *InteractionRequest) (*InteractionResponse, error) {
+	handler, ok := p.Impl.(InteractionHandler)
+	if !ok {
+		return p.UnimplementedExecutorServer.HandleInteraction(ctx, request)
+	}
+
+	execCtx, err := p.fromExecuteContext(request.Context)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := handler.HandleInteraction(ctx, InteractionInput{
+		Callback: api.Callback{
+			ID:    request.CallbackId,
+			Value: request.CallbackValue,
+		},
+		Type:    InteractionType(request.Type),
+		Values:  request.Values,
+		Configs: request.Configs,
+		Context: execCtx,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	marshalled, err := json.Marshal(out.Message)
+	if err != nil {
+		return nil, fmt.Errorf("while marshalling message to JSON: %w", err)
+	}
+	return &InteractionResponse{
+		Message: marshalled,
+	}, nil
+}
+
+func (p *grpcServer) fromExecuteContext(in *ExecuteContext) (ExecuteInputContext, error) {
+	var slackState slack.BlockActionStates
+	if in.GetSlackState() != nil {
+		if err := json.Unmarshal(in.SlackState, &slackState); err != nil {
+			return ExecuteInputContext{}, fmt.Errorf("while unmarshalling slack state from JSON: %w", err)
+		}
+	}
+
+	return ExecuteInputContext{
+		SlackState:               &slackState,
+		IsInteractivitySupported: in.GetIsInteractivitySupported(),
+		KubeConfig:               in.GetKubeConfig(),
+		Message:                  p.toMessageIfPresent(in.GetMessage()),
+		IncomingWebhook: IncomingWebhookDetailsContext{
+			BaseSourceURL: in.GetIncomingWebhook().GetBaseSourceURL(),
+		},
+	}, nil
+}
+
 func (*grpcServer) toMessageIfPresent(msg *MessageContext) Message {
 	if msg == nil {
 		return Message{}
@@ -295,6 +402,7 @@ func (*grpcServer) toMessageIfPresent(msg *MessageContext) Message {
 		user = User{
 			Mention:     msg.User.Mention,
 			DisplayName: msg.User.DisplayName,
+			ID:          msg.User.Id,
 		}
 	}
 
@@ -324,6 +432,16 @@ func (p *grpcServer) Metadata(ctx context.Context, _ *emptypb.Empty) (*MetadataR
 	}, nil
 }
 
+func (p *grpcServer) Metrics(_ context.Context, _ *emptypb.Empty) (*MetricsResponse, error) {
+	families, err := metrics.Encode(p.Impl)
+	if err != nil {
+		return nil, err
+	}
+	return &MetricsResponse{
+		Families: families,
+	}, nil
+}
+
 func (p *grpcServer) Help(ctx context.Context, _ *emptypb.Empty) (*HelpResponse, error) {
 	help, err := p.Impl.Help(ctx)
 	if err != nil {
@@ -347,6 +465,7 @@ func Serve(p map[string]plugin.Plugin) {
 			MagicCookieKey:   api.HandshakeConfig.MagicCookieKey,
 			MagicCookieValue: api.HandshakeConfig.MagicCookieValue,
 		},
-		GRPCServer: plugin.DefaultGRPCServer,
+		GRPCServer:  api.GRPCServer,
+		TLSProvider: api.TLSProvider,
 	})
 }
@@ -0,0 +1,176 @@
+package executor
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/kubeshop/botkube/pkg/api"
+)
+
+func TestGRPCClientHandleInteraction(t *testing.T) {
+	// given
+	exec := &interactiveExecutor{}
+	cli := newTestGRPCClient(t, &grpcServer{Impl: exec})
+
+	in := InteractionInput{
+		Callback: api.Callback{
+			ID:    "approve",
+			Value: "my-release",
+		},
+		Type:   SelectValueChangeInteraction,
+		Values: []string{"default"},
+		Configs: []*Config{
+			{RawYAML: []byte("foo: bar")},
+		},
+		Context: ExecuteInputContext{
+			IsInteractivitySupported: true,
+			Message: Message{
+				User: User{
+					Mention:     "<@U123>",
+					DisplayName: "Jane Doe",
+					ID:          "U123",
+				},
+			},
+		},
+	}
+
+	// when
+	out, err := cli.HandleInteraction(context.Background(), in)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "approved my-release in default", out.Message.BaseBody.Plaintext)
+	require.Len(t, out.Message.Sections, 1)
+	assert.Equal(t, &api.Callback{ID: "undo", Value: "my-release"}, out.Message.Sections[0].Buttons[0].Callback)
+
+	assert.Equal(t, in.Callback, exec.got.Callback)
+	assert.Equal(t, in.Type, exec.got.Type)
+	assert.Equal(t, in.Values, exec.got.Values)
+	assert.Equal(t, "foo: bar", string(exec.got.Configs[0].RawYAML))
+	assert.Equal(t, in.Context.Message.User, exec.got.Context.Message.User)
+}
+
+func TestGRPCClientHandleInteractionNotImplemented(t *testing.T) {
+	// given
+	cli := newTestGRPCClient(t, &grpcServer{Impl: &noopExecutor{}})
+
+	// when
+	_, err := cli.HandleInteraction(context.Background(), InteractionInput{
+		Callback: api.Callback{ID: "approve"},
+		Type:     ButtonClickInteraction,
+	})
+
+	// then
+	require.Error(t, err)
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
+
+func TestGRPCClientMetrics(t *testing.T) {
+	// given
+	registry := prometheus.NewRegistry()
+	counter := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "executions_total",
+		Help: "Total number of executions.",
+	})
+	registry.MustRegister(counter)
+	counter.Add(2)
+
+	cli := newTestGRPCClient(t, &grpcServer{Impl: &metricsExecutor{registry: registry}})
+
+	// when
+	families, err := cli.Metrics(context.Background())
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, families, 1)
+	assert.Equal(t, "executions_total", families[0].GetName())
+	assert.Equal(t, 2.0, families[0].GetMetric()[0].GetCounter().GetValue())
+}
+
+func newTestGRPCClient(t *testing.T, srv ExecutorServer) *grpcClient {
+	t.Helper()
+
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	RegisterExecutorServer(server, srv)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	return &grpcClient{
+		client: NewExecutorClient(conn),
+	}
+}
+
+// noopExecutor simulates plugins which don't handle interactions.
+type noopExecutor struct{}
+
+func (*noopExecutor) Execute(context.Context, ExecuteInput) (ExecuteOutput, error) {
+	return ExecuteOutput{}, nil
+}
+
+func (*noopExecutor) Metadata(context.Context) (api.MetadataOutput, error) {
+	return api.MetadataOutput{}, nil
+}
+
+func (*noopExecutor) Help(context.Context) (api.Message, error) {
+	return api.Message{}, nil
+}
+
+// interactiveExecutor records the received interaction and responds with a message with a callback.
+type interactiveExecutor struct {
+	noopExecutor
+	got InteractionInput
+}
+
+func (e *interactiveExecutor) HandleInteraction(_ context.Context, in InteractionInput) (InteractionOutput, error) {
+	e.got = in
+
+	btns := api.NewMessageButtonBuilder()
+	return InteractionOutput{
+		Message: api.Message{
+			BaseBody: api.Body{
+				Plaintext: "approved " + in.Callback.Value + " in " + in.Values[0],
+			},
+			Sections: []api.Section{
+				{
+					Buttons: []api.Button{
+						btns.ForCallback("Undo", api.Callback{ID: "undo", Value: in.Callback.Value}),
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+// metricsExecutor exports metrics from a custom registry.
+type metricsExecutor struct {
+	noopExecutor
+	registry *prometheus.Registry
+}
+
+func (e *metricsExecutor) MetricsGatherer() prometheus.Gatherer {
+	return e.registry
+}
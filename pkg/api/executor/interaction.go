@@ -0,0 +1,50 @@
+package executor
+
+import (
+	"context"
+
+	"github.com/kubeshop/botkube/pkg/api"
+)
+
+// InteractionHandler defines the optional Botkube executor plugin functionality to handle interactions with messages returned by the plugin.
+// Interactions with elements that define api.Callback are delivered to the HandleInteraction method instead of being executed as commands.
+type InteractionHandler interface {
+	HandleInteraction(context.Context, InteractionInput) (InteractionOutput, error)
+}
+
+// InteractionType defines the type of the interaction with a message element.
+type InteractionType string
+
+const (
+	// ButtonClickInteraction is the type of button clicks.
+	ButtonClickInteraction InteractionType = "buttonClick"
+	// SelectValueChangeInteraction is the type of select value changes.
+	SelectValueChangeInteraction InteractionType = "selectValueChange"
+	// MultiSelectValueChangeInteraction is the type of multi-select value changes.
+	MultiSelectValueChangeInteraction InteractionType = "multiSelectValueChange"
+	// PlainTextInputInteraction is the type of plain text input submissions, e.g. from forms.
+	PlainTextInputInteraction InteractionType = "plainTextInput"
+)
+
+type (
+	// InteractionInput holds the input of the HandleInteraction function.
+	InteractionInput struct {
+		// Callback is the callback defined on the message element the user interacted with.
+		Callback api.Callback
+		// Type is the type of the interaction.
+		Type InteractionType
+		// Values holds values selected or typed by the user. It's empty for button clicks.
+		Values []string
+		// Configs is a list of Executor configurations specified by users.
+		Configs []*Config
+		// Context holds the interaction context. The user who interacted with the message is available under Context.Message.User.
+		Context ExecuteInputContext
+	}
+
+	// InteractionOutput holds the output of the HandleInteraction function.
+	InteractionOutput struct {
+		// Message represents the output of handling a given interaction.
+		// It may define callbacks too, so the plugin can build multi-step interactive flows.
+		Message api.Message
+	}
+)
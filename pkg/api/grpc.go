@@ -0,0 +1,145 @@
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// PluginAuthTokenEnvName is the name of the environment variable with the token which Botkube sends in all plugin gRPC calls.
+	PluginAuthTokenEnvName = "BOTKUBE_PLUGIN_AUTH_TOKEN"
+	// PluginTLSCertEnvName is the name of the environment variable with the PEM-encoded certificate of the plugin gRPC server.
+	PluginTLSCertEnvName = "BOTKUBE_PLUGIN_TLS_CERT"
+	// PluginTLSKeyEnvName is the name of the environment variable with the PEM-encoded private key of the plugin gRPC server.
+	PluginTLSKeyEnvName = "BOTKUBE_PLUGIN_TLS_KEY"
+	// PluginTLSCAEnvName is the name of the environment variable with the PEM-encoded CA which issued Botkube client certificates.
+	PluginTLSCAEnvName = "BOTKUBE_PLUGIN_TLS_CA"
+
+	authorizationMetadataKey = "authorization"
+	bearerPrefix             = "Bearer "
+)
+
+// tracePropagator is set explicitly, as plugins don't configure the global OpenTelemetry propagator.
+var tracePropagator = propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+
+// GRPCServer returns a plugin gRPC server which extracts the trace context propagated by Botkube,
+// so plugins can continue traces of events and commands.
+//
+// If Botkube passed the authentication token, all calls without it are rejected.
+// The token is removed from the environment, so it isn't inherited by processes started by the plugin.
+func GRPCServer(opts []grpc.ServerOption) *grpc.Server {
+	token := os.Getenv(PluginAuthTokenEnvName)
+	_ = os.Unsetenv(PluginAuthTokenEnvName)
+	return grpcServer(opts, token)
+}
+
+func grpcServer(opts []grpc.ServerOption, token string) *grpc.Server {
+	opts = append(opts, grpc.StatsHandler(otelgrpc.NewServerHandler(otelgrpc.WithPropagators(tracePropagator))))
+	if token != "" {
+		opts = append(opts,
+			grpc.ChainUnaryInterceptor(unaryAuthInterceptor(token)),
+			grpc.ChainStreamInterceptor(streamAuthInterceptor(token)),
+		)
+	}
+	return grpc.NewServer(opts...)
+}
+
+// GRPCDialOptions returns dial options for plugin gRPC clients, which propagate the trace context to plugins.
+func GRPCDialOptions() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler(otelgrpc.WithPropagators(tracePropagator))),
+	}
+}
+
+// TLSProvider returns the TLS configuration of the plugin gRPC server, built from certificates passed by Botkube.
+// The server requires client certificates issued by the passed CA.
+//
+// It returns nil if Botkube didn't pass certificates. In such case, ephemeral certificates are negotiated
+// if Botkube requested it, otherwise TLS is not used.
+func TLSProvider() (*tls.Config, error) {
+	certPEM, keyPEM, caPEM := os.Getenv(PluginTLSCertEnvName), os.Getenv(PluginTLSKeyEnvName), os.Getenv(PluginTLSCAEnvName)
+	for _, name := range []string{PluginTLSCertEnvName, PluginTLSKeyEnvName, PluginTLSCAEnvName} {
+		_ = os.Unsetenv(name)
+	}
+	return serverTLSConfig(certPEM, keyPEM, caPEM)
+}
+
+func serverTLSConfig(certPEM, keyPEM, caPEM string) (*tls.Config, error) {
+	if certPEM == "" && keyPEM == "" && caPEM == "" {
+		return nil, nil
+	}
+
+	cert, err := tls.X509KeyPair([]byte(certPEM), []byte(keyPEM))
+	if err != nil {
+		return nil, fmt.Errorf("while loading plugin certificate: %w", err)
+	}
+	caPool := x509.NewCertPool()
+	if !caPool.AppendCertsFromPEM([]byte(caPEM)) {
+		return nil, errors.New("while loading plugin CA: no valid PEM certificates found")
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    caPool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+func unaryAuthInterceptor(token string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := authenticate(ctx, token); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func streamAuthInterceptor(token string) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := authenticate(ss.Context(), token); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+// authenticate compares the bearer token from the call metadata with the expected one in constant time.
+func authenticate(ctx context.Context, token string) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, val := range md.Get(authorizationMetadataKey) {
+		got, found := strings.CutPrefix(val, bearerPrefix)
+		if found && subtle.ConstantTimeCompare([]byte(got), []byte(token)) == 1 {
+			return nil
+		}
+	}
+	return status.Error(codes.Unauthenticated, "missing or invalid plugin authentication token")
+}
+
+// TokenCredentials attaches the plugin authentication token to all gRPC calls.
+type TokenCredentials struct {
+	Token string
+}
+
+// GetRequestMetadata returns the authorization metadata.
+func (c TokenCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{authorizationMetadataKey: bearerPrefix + c.Token}, nil
+}
+
+// RequireTransportSecurity returns false, as plugins may be connected over a local socket without TLS.
+func (TokenCredentials) RequireTransportSecurity() bool {
+	return false
+}
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestAuthenticate(t *testing.T) {
+	const token = "s3cr3t"
+	tests := []struct {
+		name     string
+		md       metadata.MD
+		expected codes.Code
+	}{
+		{
+			name:     "valid token",
+			md:       metadata.Pairs("authorization", "Bearer s3cr3t"),
+			expected: codes.OK,
+		},
+		{
+			name:     "invalid token",
+			md:       metadata.Pairs("authorization", "Bearer other"),
+			expected: codes.Unauthenticated,
+		},
+		{
+			name:     "token without the bearer scheme",
+			md:       metadata.Pairs("authorization", "s3cr3t"),
+			expected: codes.Unauthenticated,
+		},
+		{
+			name:     "missing metadata",
+			expected: codes.Unauthenticated,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			if tc.md != nil {
+				ctx = metadata.NewIncomingContext(ctx, tc.md)
+			}
+
+			// when
+			err := authenticate(ctx, token)
+
+			// then
+			assert.Equal(t, tc.expected, status.Code(err))
+		})
+	}
+}
+
+func TestTokenCredentials(t *testing.T) {
+	// when
+	md, err := TokenCredentials{Token: "s3cr3t"}.GetRequestMetadata(context.Background())
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"authorization": "Bearer s3cr3t"}, md)
+
+	// the metadata is accepted by the server side
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.New(md))
+	assert.NoError(t, authenticate(ctx, "s3cr3t"))
+}
@@ -123,6 +123,8 @@ type Select struct {
 	OptionGroups []OptionGroup `json:"optionGroups,omitempty" yaml:"optionGroups"`
 	// InitialOption holds already pre-selected options. MUST be a sub-set of OptionGroups.
 	InitialOption *OptionItem `json:"initialOption,omitempty" yaml:"initialOption"`
+	// Callback delivers the selected option back to the plugin instead of executing the command.
+	Callback *Callback `json:"callback,omitempty" yaml:"callback"`
 }
 
 // Base holds generic message fields.
@@ -229,6 +231,13 @@ type LabelInput struct {
 	Text             string                `json:"text,omitempty" yaml:"text"`
 	Placeholder      string                `json:"placeholder,omitempty" yaml:"placeholder"`
 	DispatchedAction DispatchedInputAction `json:"dispatchedAction,omitempty" yaml:"dispatchedAction"`
+	// Multiline renders a text area, e.g. for pasting YAML manifests. Multiline inputs can't dispatch actions on enter,
+	// so their values are read from the message state when another element, like a button, is used.
+	Multiline bool `json:"multiline,omitempty" yaml:"multiline"`
+	// InitialValue prefills the input, e.g. with a manifest to edit.
+	InitialValue string `json:"initialValue,omitempty" yaml:"initialValue"`
+	// Callback delivers the typed text back to the plugin instead of executing the command.
+	Callback *Callback `json:"callback,omitempty" yaml:"callback"`
 }
 
 // AreOptionsDefined returns true if some options are available.
@@ -256,6 +265,9 @@ type MultiSelect struct {
 
 	// InitialOptions hold already pre-selected options. MUST be a sub-set of Options.
 	InitialOptions []OptionItem `json:"initialOptions,omitempty" yaml:"initialOptions"`
+
+	// Callback delivers the selected options back to the plugin instead of executing the command.
+	Callback *Callback `json:"callback,omitempty" yaml:"callback"`
 }
 
 // OptionGroup holds information about options in the same group.
@@ -336,6 +348,19 @@ type Button struct {
 	Command string      `json:"command,omitempty" yaml:"command"`
 	URL     string      `json:"url,omitempty" yaml:"url"`
 	Style   ButtonStyle `json:"style,omitempty" yaml:"style"`
+
+	// Callback delivers the button click back to the plugin instead of executing the command.
+	Callback *Callback `json:"callback,omitempty" yaml:"callback"`
+}
+
+// Callback defines the callback of an interactive element. If set, interactions with the element are delivered back
+// to the executor plugin which returned the message, instead of executing the element command.
+type Callback struct {
+	// ID identifies the element in the plugin. It follows the `{verb}-{action}` convention, e.g. `edit-apply`, as interactions
+	// are authorized as the `{verb}` command of the plugin.
+	ID string `json:"id" yaml:"id"`
+	// Value is passed to the plugin together with the ID, e.g. the name of the approved release.
+	Value string `json:"value,omitempty" yaml:"value"`
 }
 
 // ButtonBuilder provides a simplified way to construct a Button model.
@@ -412,6 +437,20 @@ func (b *ButtonBuilder) ForCommand(name, cmd, desc string, style ...ButtonStyle)
 	return b.commandWithCmdDesc(name, cmd, desc, bt)
 }
 
+// ForCallback returns button which click is delivered back to the plugin.
+func (b *ButtonBuilder) ForCallback(name string, callback Callback, style ...ButtonStyle) Button {
+	bt := ButtonStyleDefault
+	if len(style) > 0 {
+		bt = style[0]
+	}
+
+	return Button{
+		Name:     name,
+		Callback: &callback,
+		Style:    bt,
+	}
+}
+
 // ForURLWithBoldDesc returns link button with description.
 func (b *ButtonBuilder) ForURLWithBoldDesc(name, desc, url string, style ...ButtonStyle) Button {
 	urlBtn := b.ForURL(name, url, style...)
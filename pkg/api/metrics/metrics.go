@@ -0,0 +1,61 @@
+// Package metrics provides functionality to export Prometheus metrics from plugins to Botkube.
+// It is kept separate from the api package, as the Prometheus client doesn't support WebAssembly plugins.
+package metrics
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+)
+
+// Gatherer defines the optional plugin functionality to export Prometheus metrics from a custom registry.
+// Plugins which don't implement it export metrics registered in the prometheus.DefaultRegisterer.
+// Exported metrics are re-exposed by Botkube under the plugin namespace, e.g. `botkube_executor_botkube_helm_`.
+type Gatherer interface {
+	MetricsGatherer() prometheus.Gatherer
+}
+
+// Encode gathers metrics exported by a given plugin implementation and encodes them in the length-delimited protobuf format.
+func Encode(impl any) ([]byte, error) {
+	gatherer := prometheus.DefaultGatherer
+	if custom, ok := impl.(Gatherer); ok {
+		gatherer = custom.MetricsGatherer()
+	}
+
+	families, err := gatherer.Gather()
+	if err != nil {
+		return nil, fmt.Errorf("while gathering metrics: %w", err)
+	}
+
+	var buff bytes.Buffer
+	enc := expfmt.NewEncoder(&buff, expfmt.FmtProtoDelim)
+	for _, family := range families {
+		if err := enc.Encode(family); err != nil {
+			return nil, fmt.Errorf("while encoding %q metric family: %w", family.GetName(), err)
+		}
+	}
+	return buff.Bytes(), nil
+}
+
+// Decode decodes metric families encoded by Encode.
+func Decode(in []byte) ([]*dto.MetricFamily, error) {
+	dec := expfmt.NewDecoder(bytes.NewReader(in), expfmt.FmtProtoDelim)
+
+	var out []*dto.MetricFamily
+	for {
+		family := &dto.MetricFamily{}
+		err := dec.Decode(family)
+		if errors.Is(err, io.EOF) {
+			return out, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("while decoding metric family: %w", err)
+		}
+		out = append(out, family)
+	}
+}
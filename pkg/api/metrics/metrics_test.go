@@ -0,0 +1,63 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type customMetricsPlugin struct {
+	registry *prometheus.Registry
+}
+
+func (p *customMetricsPlugin) MetricsGatherer() prometheus.Gatherer {
+	return p.registry
+}
+
+func TestEncodeDecode(t *testing.T) {
+	// given
+	registry := prometheus.NewRegistry()
+	releases := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "releases_total",
+		Help: "Total number of installed releases.",
+	}, []string{"namespace"})
+	registry.MustRegister(releases)
+	releases.WithLabelValues("default").Add(3)
+	releases.WithLabelValues("team-a").Inc()
+
+	queue := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "queue_length",
+		Help: "Length of the queue.",
+	})
+	registry.MustRegister(queue)
+	queue.Set(7)
+
+	// when
+	raw, err := Encode(&customMetricsPlugin{registry: registry})
+	require.NoError(t, err)
+	families, err := Decode(raw)
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, families, 2)
+
+	assert.Equal(t, "queue_length", families[0].GetName())
+	assert.Equal(t, 7.0, families[0].GetMetric()[0].GetGauge().GetValue())
+
+	assert.Equal(t, "releases_total", families[1].GetName())
+	assert.Equal(t, "Total number of installed releases.", families[1].GetHelp())
+	require.Len(t, families[1].GetMetric(), 2)
+	assert.Equal(t, "default", families[1].GetMetric()[0].GetLabel()[0].GetValue())
+	assert.Equal(t, 3.0, families[1].GetMetric()[0].GetCounter().GetValue())
+}
+
+func TestDecodeEmpty(t *testing.T) {
+	// when
+	families, err := Decode(nil)
+
+	// then
+	require.NoError(t, err)
+	assert.Empty(t, families)
+}
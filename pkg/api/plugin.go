@@ -33,12 +33,33 @@ type MetadataOutput struct {
 	Dependencies map[string]Dependency
 	// Recommended plugin recommended
 	Recommended bool
+
+	// EventSchemaVersion is the version of the event schema used by a source plugin. It's reported by the plugin when it's started,
+	// based on the Botkube version it was built with, so it doesn't need to be set by plugins.
+	EventSchemaVersion string
 }
 
 // ExternalRequestMetadata contains the metadata for external requests.
 type ExternalRequestMetadata struct {
 	// Payload contains the external requests payload information.
 	Payload ExternalRequestPayload
+	// Routes are HTTP routes registered on the incoming webhook server, e.g. for GitHub or Alertmanager webhooks.
+	// Requests to the source URL are always handled, even if no routes are defined.
+	Routes []ExternalRequestRoute
+}
+
+// ExternalRequestRoute contains the incoming webhook route registered by a source plugin.
+// The route is served under the source URL, e.g. `/sources/v1/{sourceName}/{path}`.
+type ExternalRequestRoute struct {
+	// Path is the route path relative to the source URL, e.g. `github`.
+	Path string
+	// Methods are accepted HTTP methods. Defaults to POST.
+	Methods []string
+	// AuthScheme is the default authentication scheme of the route, e.g. `hmacSHA256`.
+	// It's used only if the source binding doesn't configure the scheme. Credentials are always configured by users.
+	AuthScheme string
+	// AuthHeader is the name of the header with the token or signature, e.g. `X-Hub-Signature-256`.
+	AuthHeader string
 }
 
 // ExternalRequestPayload contains the incoming webhook payload information.
@@ -0,0 +1,252 @@
+package processor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/go-plugin"
+	dto "github.com/prometheus/client_model/go"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/api/metrics"
+)
+
+// Processor defines the Botkube processor plugin functionality.
+// Processors receive every event emitted by sources before it is dispatched, and can modify or veto it.
+type Processor interface {
+	Process(context.Context, ProcessInput) (ProcessOutput, error)
+	Metadata(context.Context) (api.MetadataOutput, error)
+}
+
+type (
+	// ProcessInput holds the input of the Process function.
+	ProcessInput struct {
+		// Event is the JSON representation of the raw event emitted by a source.
+		Event []byte
+		// Message is the message rendered by a source for a given event.
+		Message api.Message
+		// Configs is a list of Processor configurations specified by users.
+		Configs []*Config
+		// Context holds details about the origin of a given event.
+		Context ProcessInputContext
+	}
+
+	// ProcessInputContext holds details about the origin of a given event.
+	ProcessInputContext struct {
+		// SourceName is the name of the source binding that emitted a given event.
+		SourceName string
+		// SourceDisplayName is the display name of the source binding that emitted a given event.
+		SourceDisplayName string
+		// PluginName is the name of the source plugin that emitted a given event.
+		PluginName string
+		// ClusterName is the name of the underlying Kubernetes cluster which is provided by end user.
+		ClusterName string
+	}
+
+	// ProcessOutput holds the output of the Process function.
+	ProcessOutput struct {
+		// Veto drops a given event. It's not sent to any communication platform or sink, and it doesn't trigger actions.
+		Veto bool
+		// Event is the JSON representation of the modified raw event. If nil, the event is not modified.
+		Event []byte
+		// Message is the modified message. If nil, the message is not modified.
+		Message *api.Message
+	}
+)
+
+// ProtocolVersion is the version that must match between Botkube core
+// and Botkube plugins. This should be bumped whenever a change happens in
+// one or the other that makes it so that they can't safely communicate.
+// This could be adding a new interface value, it could be how helper/schema computes diffs, etc.
+//
+// NOTE: In the future we can consider using VersionedPlugins. These can be used to negotiate
+// a compatible version between client and server. If this is set, Handshake.ProtocolVersion is not required.
+const ProtocolVersion = 3
+
+var _ plugin.GRPCPlugin = &Plugin{}
+
+// Plugin This is the implementation of plugin.GRPCPlugin, so we can serve and consume different Botkube Processors.
+type Plugin struct {
+	// The GRPC plugin must still implement the Plugin interface.
+	plugin.NetRPCUnsupportedPlugin
+
+	// Processor represents a concrete implementation that handles the business logic.
+	Processor Processor
+}
+
+// GRPCServer registers plugin for serving with the given GRPCServer.
+func (p *Plugin) GRPCServer(_ *plugin.GRPCBroker, s *grpc.Server) error {
+	RegisterProcessorServer(s, &grpcServer{
+		Impl: p.Processor,
+	})
+	return nil
+}
+
+// GRPCClient returns the interface implementation for the plugin that is serving via gRPC by GRPCServer.
+func (p *Plugin) GRPCClient(_ context.Context, _ *plugin.GRPCBroker, c *grpc.ClientConn) (interface{}, error) {
+	return &grpcClient{
+		client: NewProcessorClient(c),
+	}, nil
+}
+
+type grpcClient struct {
+	client ProcessorClient
+}
+
+func (p *grpcClient) Process(ctx context.Context, in ProcessInput) (ProcessOutput, error) {
+	marshalled, err := json.Marshal(in.Message)
+	if err != nil {
+		return ProcessOutput{}, fmt.Errorf("while marshalling message to JSON: %w", err)
+	}
+
+	res, err := p.client.Process(ctx, &ProcessRequest{
+		Event:   in.Event,
+		Message: marshalled,
+		Configs: in.Configs,
+		Context: &ProcessContext{
+			SourceName:        in.Context.SourceName,
+			SourceDisplayName: in.Context.SourceDisplayName,
+			PluginName:        in.Context.PluginName,
+			ClusterName:       in.Context.ClusterName,
+		},
+	})
+	if err != nil {
+		return ProcessOutput{}, err
+	}
+
+	out := ProcessOutput{
+		Veto: res.Veto,
+	}
+	if len(res.Event) > 0 {
+		out.Event = res.Event
+	}
+	if len(res.Message) > 0 {
+		var msg api.Message
+		if err := json.Unmarshal(res.Message, &msg); err != nil {
+			return ProcessOutput{}, fmt.Errorf("while unmarshalling message from JSON: %w", err)
+		}
+		out.Message = &msg
+	}
+
+	return out, nil
+}
+
+func (p *grpcClient) Metadata(ctx context.Context) (api.MetadataOutput, error) {
+	resp, err := p.client.Metadata(ctx, &emptypb.Empty{})
+	if err != nil {
+		return api.MetadataOutput{}, err
+	}
+
+	return api.MetadataOutput{
+		Version:          resp.Version,
+		Description:      resp.Description,
+		DocumentationURL: resp.DocumentationUrl,
+		JSONSchema: api.JSONSchema{
+			Value:  resp.GetJsonSchema().GetValue(),
+			RefURL: resp.GetJsonSchema().GetRefUrl(),
+		},
+		Dependencies: api.ConvertDependenciesToAPI(resp.Dependencies),
+		Recommended:  resp.Recommended,
+	}, nil
+}
+
+// Metrics returns Prometheus metrics exported by the plugin.
+func (p *grpcClient) Metrics(ctx context.Context) ([]*dto.MetricFamily, error) {
+	resp, err := p.client.Metrics(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, err
+	}
+	return metrics.Decode(resp.Families)
+}
+
+type grpcServer struct {
+	UnimplementedProcessorServer
+	Impl Processor
+}
+
+func (p *grpcServer) Process(ctx context.Context, request *ProcessRequest) (*ProcessResponse, error) {
+	var msg api.Message
+	if len(request.Message) > 0 {
+		if err := json.Unmarshal(request.Message, &msg); err != nil {
+			return nil, fmt.Errorf("while unmarshalling message from JSON: %w", err)
+		}
+	}
+
+	var processCtx ProcessInputContext
+	if request.Context != nil {
+		processCtx = ProcessInputContext{
+			SourceName:        request.Context.SourceName,
+			SourceDisplayName: request.Context.SourceDisplayName,
+			PluginName:        request.Context.PluginName,
+			ClusterName:       request.Context.ClusterName,
+		}
+	}
+
+	out, err := p.Impl.Process(ctx, ProcessInput{
+		Event:   request.Event,
+		Message: msg,
+		Configs: request.Configs,
+		Context: processCtx,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &ProcessResponse{
+		Veto:  out.Veto,
+		Event: out.Event,
+	}
+	if out.Message != nil {
+		resp.Message, err = json.Marshal(out.Message)
+		if err != nil {
+			return nil, fmt.Errorf("while marshalling message to JSON: %w", err)
+		}
+	}
+
+	return resp, nil
+}
+
+func (p *grpcServer) Metadata(ctx context.Context, _ *emptypb.Empty) (*MetadataResponse, error) {
+	meta, err := p.Impl.Metadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &MetadataResponse{
+		Version:          meta.Version,
+		Description:      meta.Description,
+		DocumentationUrl: meta.DocumentationURL,
+		JsonSchema: &JSONSchema{
+			Value:  meta.JSONSchema.Value,
+			RefUrl: meta.JSONSchema.RefURL,
+		},
+		Dependencies: api.ConvertDependenciesFromAPI[*Dependency, Dependency](meta.Dependencies),
+		Recommended:  meta.Recommended,
+	}, nil
+}
+
+func (p *grpcServer) Metrics(_ context.Context, _ *emptypb.Empty) (*MetricsResponse, error) {
+	families, err := metrics.Encode(p.Impl)
+	if err != nil {
+		return nil, err
+	}
+	return &MetricsResponse{
+		Families: families,
+	}, nil
+}
+
+// Serve serves given plugins.
+func Serve(p map[string]plugin.Plugin) {
+	plugin.Serve(&plugin.ServeConfig{
+		Plugins: p,
+		HandshakeConfig: plugin.HandshakeConfig{
+			ProtocolVersion:  ProtocolVersion,
+			MagicCookieKey:   api.HandshakeConfig.MagicCookieKey,
+			MagicCookieValue: api.HandshakeConfig.MagicCookieValue,
+		},
+		GRPCServer:  api.GRPCServer,
+		TLSProvider: api.TLSProvider,
+	})
+}
@@ -0,0 +1,92 @@
+package source
+
+import (
+	"fmt"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// EventSchemaVersion is the version of the Event schema used by source plugins built with this package.
+// The major version is bumped whenever a field is removed, renamed or changes its meaning, so Botkube can't consume such events.
+// The minor version is bumped whenever a new field is added. Events of an older minor version are still consumed, as added fields are optional.
+//
+// The version is sent to Botkube when the plugin is started, so plugins don't need to set it.
+const EventSchemaVersion = "1.1"
+
+// legacyEventSchemaVersion is assumed for plugins which don't report the event schema version, as they were built before it was introduced.
+const legacyEventSchemaVersion = "1.0"
+
+// eventSchemaVersionHeader is the gRPC header with the event schema version, sent by plugins in the Metadata response.
+const eventSchemaVersionHeader = "botkube-event-schema-version"
+
+// EventSchema describes a given version of the Event schema.
+type EventSchema struct {
+	// Version is the schema version in the `major.minor` format.
+	Version string
+	// Fields describes the Event fields added in this version.
+	Fields []EventSchemaField
+}
+
+// EventSchemaField describes a single Event field.
+type EventSchemaField struct {
+	// Name is the name of the field in the JSON-encoded event.
+	Name        string
+	Description string
+}
+
+// EventSchemas is the registry of all Event schema versions, in order. Each version documents fields it added to the previous one.
+// When the Event struct changes, a new entry must be added here, and EventSchemaVersion must be bumped.
+var EventSchemas = []EventSchema{
+	{
+		Version: "1.0",
+		Fields: []EventSchemaField{
+			{Name: "Message", Description: "Message sent to communication platforms and sinks."},
+			{Name: "RawObject", Description: "Object which the event relates to, used by filters and sinks."},
+			{Name: "AnalyticsLabels", Description: "Labels attached to anonymous analytics events."},
+		},
+	},
+	{
+		Version: "1.1",
+		Fields: []EventSchemaField{
+			{Name: "ActionContext", Description: "Additional event details available in action command templates."},
+			{Name: "Channels", Description: "Channels which override the ones bound to the source binding."},
+		},
+	},
+}
+
+// EventSchemaFields returns all fields of a given Event schema version, including the ones added in previous versions.
+func EventSchemaFields(version string) ([]EventSchemaField, error) {
+	var out []EventSchemaField
+	for _, schema := range EventSchemas {
+		out = append(out, schema.Fields...)
+		if schema.Version == version {
+			return out, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown event schema version %q", version)
+}
+
+// CheckEventSchemaCompatibility checks whether events of a given schema version, reported by a source plugin, can be consumed.
+// It returns an error if major versions differ. If events can be consumed, but not all their fields are supported,
+// or the plugin doesn't report its schema version, the returned warning describes it.
+func CheckEventSchemaCompatibility(version string) (string, error) {
+	var warning string
+	if version == "" {
+		version = legacyEventSchemaVersion
+		warning = fmt.Sprintf("plugin doesn't report the event schema version, so %s is assumed. Rebuild it with a newer Botkube version to verify compatibility.", version)
+	}
+
+	got, err := semver.NewVersion(version)
+	if err != nil {
+		return "", fmt.Errorf("while parsing event schema version %q: %w", version, err)
+	}
+	supported := semver.MustParse(EventSchemaVersion)
+
+	if got.Major() != supported.Major() {
+		return "", fmt.Errorf("event schema version %s is incompatible with version %s supported by Botkube, as their major versions differ", version, EventSchemaVersion)
+	}
+	if got.Minor() > supported.Minor() {
+		warning = fmt.Sprintf("event schema version %s is newer than version %s supported by Botkube, so fields added after it are ignored. Upgrade Botkube to consume them.", version, EventSchemaVersion)
+	}
+	return warning, nil
+}
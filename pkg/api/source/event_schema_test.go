@@ -0,0 +1,99 @@
+package source
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventSchemasDocumentEvent(t *testing.T) {
+	// given
+	var expFields []string
+	eventType := reflect.TypeOf(Event{})
+	for i := 0; i < eventType.NumField(); i++ {
+		expFields = append(expFields, eventType.Field(i).Name)
+	}
+
+	// when
+	fields, err := EventSchemaFields(EventSchemaVersion)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, EventSchemaVersion, EventSchemas[len(EventSchemas)-1].Version, "the latest registered schema must be the current one")
+
+	var gotFields []string
+	for _, field := range fields {
+		assert.NotEmpty(t, field.Description, "field %s is not documented", field.Name)
+		gotFields = append(gotFields, field.Name)
+	}
+	assert.ElementsMatch(t, expFields, gotFields, "Event fields changed, register a new event schema version")
+}
+
+func TestEventSchemaFields(t *testing.T) {
+	// when
+	fields, err := EventSchemaFields("1.0")
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, EventSchemas[0].Fields, fields)
+
+	// when
+	_, err = EventSchemaFields("0.9")
+
+	// then
+	assert.EqualError(t, err, `unknown event schema version "0.9"`)
+}
+
+func TestCheckEventSchemaCompatibility(t *testing.T) {
+	tests := []struct {
+		name       string
+		version    string
+		expWarning string
+		expErr     string
+	}{
+		{
+			name:    "Current version",
+			version: EventSchemaVersion,
+		},
+		{
+			name:    "Older minor version",
+			version: "1.0",
+		},
+		{
+			name:       "Not reported version",
+			version:    "",
+			expWarning: "plugin doesn't report the event schema version, so 1.0 is assumed. Rebuild it with a newer Botkube version to verify compatibility.",
+		},
+		{
+			name:       "Newer minor version",
+			version:    "1.7",
+			expWarning: "event schema version 1.7 is newer than version 1.1 supported by Botkube, so fields added after it are ignored. Upgrade Botkube to consume them.",
+		},
+		{
+			name:    "Different major version",
+			version: "2.0",
+			expErr:  "event schema version 2.0 is incompatible with version 1.1 supported by Botkube, as their major versions differ",
+		},
+		{
+			name:    "Invalid version",
+			version: "latest",
+			expErr:  `while parsing event schema version "latest": Invalid Semantic Version`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			warning, err := CheckEventSchemaCompatibility(tc.version)
+
+			// then
+			if tc.expErr != "" {
+				assert.EqualError(t, err, tc.expErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expWarning, warning)
+		})
+	}
+}
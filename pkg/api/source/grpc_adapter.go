@@ -3,16 +3,25 @@ package source
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"sync/atomic"
 
 	"github.com/hashicorp/go-plugin"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 
 	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/api/metrics"
 )
 
 // Source defines the Botkube source plugin functionality.
@@ -46,6 +55,10 @@ type (
 		//   - api.NewCodeBlockMessage("body", true)
 		//   - api.NewPlaintextMessage("body", true)
 		Event chan Event
+
+		// Ack acknowledges that the oldest received and not yet acknowledged event was dispatched, so the plugin can send another one.
+		// It must be called once per each received event. It's nil if the plugin doesn't support acknowledgements.
+		Ack func()
 	}
 
 	// ExternalRequestInput holds the input of the HandleExternalRequest function.
@@ -63,6 +76,18 @@ type (
 	// ExternalRequestInputContext holds single ex context.
 	ExternalRequestInputContext struct {
 		CommonSourceContext
+
+		// HTTP holds details of the incoming HTTP request.
+		HTTP HTTPRequestContext
+	}
+
+	// HTTPRequestContext holds details of the incoming HTTP request.
+	HTTPRequestContext struct {
+		// Route is the path of the matched route registered by the plugin. It's empty for requests to the source URL.
+		Route  string
+		Method string
+		Header http.Header
+		Query  url.Values
 	}
 
 	// CommonSourceContext holds common source context.
@@ -98,6 +123,35 @@ type (
 		Message         api.Message
 		RawObject       any
 		AnalyticsLabels map[string]interface{}
+		// ActionContext holds additional event details available in action command templates. It's not sent to sinks.
+		ActionContext ActionContext
+		// Channels overrides channels the message is sent to, e.g. based on a resource annotation.
+		// Channels are identified by their aliases or names used in the configuration. If empty, channels bound to the source binding are used.
+		Channels []string
+	}
+
+	// ActionContext holds additional event details exposed as top-level fields in action command templates.
+	ActionContext struct {
+		// Object holds the full object that the event relates to, e.g. `{{ .Object.metadata.labels.app }}`.
+		Object any `json:"object,omitempty"`
+		// TopOwnerRef holds the reference to the top-most owner of the object in the `kind/name` format, e.g. `deployment/nginx`.
+		TopOwnerRef string `json:"topOwnerRef,omitempty"`
+		// Enrichments holds responses of enrichment hooks indexed by their names, e.g. `{{ .Enrichments.catalog.team }}`.
+		// It's populated by Botkube before the event is dispatched.
+		Enrichments map[string]any `json:"enrichments,omitempty"`
+		// Owner holds the team which owns the object, e.g. `{{ .Owner.Team }}`. It's populated by Botkube before the event is dispatched,
+		// if the resource ownership directory is enabled.
+		Owner *Owner `json:"owner,omitempty"`
+	}
+
+	// Owner describes the team which owns a given resource.
+	Owner struct {
+		// Team is the name of the owning team, e.g. `payments`.
+		Team string `json:"team"`
+		// OnCall holds handles mentioned in notifications, e.g. `@payments-oncall`.
+		OnCall []string `json:"onCall,omitempty"`
+		// Source describes where the owner was found, e.g. `annotation` or `backstage`.
+		Source string `json:"source,omitempty"`
 	}
 )
 
@@ -110,6 +164,10 @@ type (
 // a compatible version between client and server. If this is set, Handshake.ProtocolVersion is not required.
 const ProtocolVersion = 3
 
+// ackStreamWindow is the maximum number of events which a plugin can send before they are acknowledged.
+// Once it's reached, the plugin stops reading events from the source, so no events are buffered and lost if dispatching is slow.
+const ackStreamWindow = 100
+
 var _ plugin.GRPCPlugin = &Plugin{}
 
 // Plugin This is the implementation of plugin.GRPCPlugin, so we can serve and consume different Botkube Sources.
@@ -134,14 +192,19 @@ func (p *Plugin) GRPCClient(_ context.Context, _ *plugin.GRPCBroker, c *grpc.Cli
 	return &grpcClient{
 		client: NewSourceClient(c),
 		logger: NewLogger(),
+		window: ackStreamWindow,
 	}, nil
 }
 
 type grpcClient struct {
 	client SourceClient
 	logger logrus.FieldLogger
+	// window is the maximum number of events which the plugin can send before they are acknowledged.
+	window uint32
 }
 
+// Stream starts streaming events with acknowledgements. If the plugin doesn't support them, e.g. it's built with an older version
+// of Botkube, events are streamed without them.
 func (p *grpcClient) Stream(ctx context.Context, in StreamInput) (StreamOutput, error) {
 	request := &StreamRequest{
 		Configs: in.Configs,
@@ -150,6 +213,94 @@ func (p *grpcClient) Stream(ctx context.Context, in StreamInput) (StreamOutput,
 			KubeConfig:    in.Context.KubeConfig,
 		},
 	}
+
+	out, err := p.ackStream(ctx, request)
+	if status.Code(err) != codes.Unimplemented {
+		return out, err
+	}
+	p.logger.Debug("Source doesn't support acknowledgements. Streaming without them...")
+	return p.stream(ctx, request)
+}
+
+func (p *grpcClient) ackStream(ctx context.Context, request *StreamRequest) (StreamOutput, error) {
+	stream, err := p.client.AckStream(ctx)
+	if err != nil {
+		return StreamOutput{}, err
+	}
+	err = stream.Send(&AckStreamRequest{
+		Stream: request,
+		Ack:    &StreamAck{Credits: p.window},
+	})
+	if err != nil {
+		return StreamOutput{}, err
+	}
+
+	// the plugin sends headers once the stream is started, otherwise the stream was terminated, e.g. with the Unimplemented status
+	if md, _ := stream.Header(); md == nil {
+		_, err := stream.Recv()
+		if err == nil {
+			err = errors.New("unexpected event before stream start")
+		}
+		return StreamOutput{}, err
+	}
+
+	var (
+		// received holds IDs of received and not yet acknowledged events, in order
+		received = make(chan uint64, p.window)
+		sendMu   sync.Mutex
+	)
+	out := StreamOutput{
+		Event: make(chan Event),
+		Ack: func() {
+			var id uint64
+			select {
+			case id = <-received:
+			default:
+				return
+			}
+
+			sendMu.Lock()
+			defer sendMu.Unlock()
+			if err := stream.Send(&AckStreamRequest{Ack: &StreamAck{Id: id, Credits: 1}}); err != nil {
+				p.logger.Debugf("while acknowledging event %d: %s", id, err)
+			}
+		},
+	}
+
+	go func() {
+		defer close(out.Event)
+		for {
+			feature, err := stream.Recv()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				p.logger.Errorf("canceling streaming: %s", status.Convert(err).Message())
+				return
+			}
+			event, err := unmarshalEvent(feature.Event)
+			if err != nil {
+				p.logger.Errorf("canceling streaming: %s", err)
+				return
+			}
+
+			select {
+			case received <- feature.Id:
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case out.Event <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+func (p *grpcClient) stream(ctx context.Context, request *StreamRequest) (StreamOutput, error) {
 	stream, err := p.client.Stream(ctx, request)
 	if err != nil {
 		return StreamOutput{}, err
@@ -160,12 +311,14 @@ func (p *grpcClient) Stream(ctx context.Context, in StreamInput) (StreamOutput,
 	}
 
 	go func() {
+		// the channel is closed also on errors, e.g. when the plugin is stopped, so that consumers don't wait forever
+		defer close(out.Event)
 		for {
 			// RecvMsg blocks until it receives a message into m or the stream is
 			// done. It returns io.EOF when the stream completes successfully.
 			feature, err := stream.Recv()
 			if err == io.EOF {
-				break
+				return
 			}
 
 			// On any other error, the stream is aborted and the error contains the RPC
@@ -175,16 +328,13 @@ func (p *grpcClient) Stream(ctx context.Context, in StreamInput) (StreamOutput,
 				// TODO: we should consider adding error feedback channel to StreamOutput.
 				return
 			}
-			var event Event
-			if len(feature.Event) != 0 && string(feature.Event) != "" {
-				if err := json.Unmarshal(feature.Event, &event); err != nil {
-					p.logger.Errorf("canceling streaming: cannot unmarshal JSON message: %s", err.Error())
-					return
-				}
+			event, err := unmarshalEvent(feature.Event)
+			if err != nil {
+				p.logger.Errorf("canceling streaming: %s", err)
+				return
 			}
 			out.Event <- event
 		}
-		close(out.Event)
 	}()
 
 	return out, nil
@@ -196,6 +346,7 @@ func (p *grpcClient) HandleExternalRequest(ctx context.Context, in ExternalReque
 		Config:  in.Config,
 		Context: &ExternalRequestContext{
 			SourceContext: sourceContextToGRPC(in.Context.CommonSourceContext),
+			Http:          httpContextToGRPC(in.Context.HTTP),
 		},
 	}
 	out, err := p.client.HandleExternalRequest(ctx, request)
@@ -220,7 +371,8 @@ func (p *grpcClient) HandleExternalRequest(ctx context.Context, in ExternalReque
 }
 
 func (p *grpcClient) Metadata(ctx context.Context) (api.MetadataOutput, error) {
-	resp, err := p.client.Metadata(ctx, &emptypb.Empty{})
+	var header metadata.MD
+	resp, err := p.client.Metadata(ctx, &emptypb.Empty{}, grpc.Header(&header))
 	if err != nil {
 		return api.MetadataOutput{}, err
 	}
@@ -235,6 +387,14 @@ func (p *grpcClient) Metadata(ctx context.Context) (api.MetadataOutput, error) {
 				},
 			},
 		}
+		for _, route := range resp.ExternalRequest.Routes {
+			externalRequest.Routes = append(externalRequest.Routes, api.ExternalRequestRoute{
+				Path:       route.GetPath(),
+				Methods:    route.GetMethods(),
+				AuthScheme: route.GetAuthScheme(),
+				AuthHeader: route.GetAuthHeader(),
+			})
+		}
 	}
 
 	return api.MetadataOutput{
@@ -245,12 +405,22 @@ func (p *grpcClient) Metadata(ctx context.Context) (api.MetadataOutput, error) {
 			Value:  resp.GetJsonSchema().GetValue(),
 			RefURL: resp.GetJsonSchema().GetRefUrl(),
 		},
-		ExternalRequest: externalRequest,
-		Dependencies:    api.ConvertDependenciesToAPI(resp.Dependencies),
-		Recommended:     resp.Recommended,
+		ExternalRequest:    externalRequest,
+		Dependencies:       api.ConvertDependenciesToAPI(resp.Dependencies),
+		Recommended:        resp.Recommended,
+		EventSchemaVersion: firstHeaderValue(header, eventSchemaVersionHeader),
 	}, nil
 }
 
+// Metrics returns Prometheus metrics exported by the plugin.
+func (p *grpcClient) Metrics(ctx context.Context) ([]*dto.MetricFamily, error) {
+	resp, err := p.client.Metrics(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, err
+	}
+	return metrics.Decode(resp.Families)
+}
+
 type grpcServer struct {
 	UnimplementedSourceServer
 	Source Source
@@ -261,6 +431,10 @@ func (p *grpcServer) Metadata(ctx context.Context, _ *emptypb.Empty) (*MetadataR
 	if err != nil {
 		return nil, err
 	}
+	// the version is sent in the header, as older Botkube versions don't expect it
+	if err := grpc.SetHeader(ctx, metadata.Pairs(eventSchemaVersionHeader, EventSchemaVersion)); err != nil {
+		return nil, err
+	}
 	return &MetadataResponse{
 		Version:          meta.Version,
 		Description:      meta.Description,
@@ -276,24 +450,29 @@ func (p *grpcServer) Metadata(ctx context.Context, _ *emptypb.Empty) (*MetadataR
 					RefUrl: meta.ExternalRequest.Payload.JSONSchema.RefURL,
 				},
 			},
+			Routes: routesToGRPC(meta.ExternalRequest.Routes),
 		},
 		Dependencies: api.ConvertDependenciesFromAPI[*Dependency, Dependency](meta.Dependencies),
 		Recommended:  meta.Recommended,
 	}, nil
 }
 
+func (p *grpcServer) Metrics(_ context.Context, _ *emptypb.Empty) (*MetricsResponse, error) {
+	families, err := metrics.Encode(p.Source)
+	if err != nil {
+		return nil, err
+	}
+	return &MetricsResponse{
+		Families: families,
+	}, nil
+}
+
 func (p *grpcServer) Stream(req *StreamRequest, gstream Source_StreamServer) error {
 	ctx := gstream.Context()
 
 	// It's up to the 'Stream' method to close the returned channels as it sends the data to it.
 	// We can only use 'ctx' to cancel streaming and release associated resources.
-	stream, err := p.Source.Stream(ctx, StreamInput{
-		Configs: req.Configs,
-		Context: StreamInputContext{
-			KubeConfig:          req.Context.KubeConfig,
-			CommonSourceContext: sourceContextFromGRPC(req.Context.SourceContext),
-		},
-	})
+	stream, err := p.Source.Stream(ctx, streamInputFromGRPC(req))
 	if err != nil {
 		return err
 	}
@@ -322,12 +501,93 @@ func (p *grpcServer) Stream(req *StreamRequest, gstream Source_StreamServer) err
 	}
 }
 
+// AckStream sends events only if Botkube granted credits for them. Otherwise, events are not read from the source,
+// so the source is blocked until Botkube dispatches already sent events.
+func (p *grpcServer) AckStream(gstream Source_AckStreamServer) error {
+	ctx := gstream.Context()
+
+	req, err := gstream.Recv()
+	if err != nil {
+		return err
+	}
+	if req.Stream == nil {
+		return status.Error(codes.InvalidArgument, "the first message must start the stream")
+	}
+
+	stream, err := p.Source.Stream(ctx, streamInputFromGRPC(req.Stream))
+	if err != nil {
+		return err
+	}
+	if err := gstream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+
+	var credits atomic.Int64
+	credits.Store(int64(req.GetAck().GetCredits()))
+	granted := make(chan struct{}, 1)
+	recvErr := make(chan error, 1)
+	go func() {
+		for {
+			req, err := gstream.Recv()
+			if err != nil {
+				recvErr <- err
+				return
+			}
+			credits.Add(int64(req.GetAck().GetCredits()))
+			select {
+			case granted <- struct{}{}:
+			default:
+			}
+		}
+	}()
+
+	var id uint64
+	for {
+		for credits.Load() <= 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case err := <-recvErr:
+				return ignoreEOF(err)
+			case <-granted:
+			}
+		}
+
+		select {
+		case <-ctx.Done(): // client canceled stream, we can release this connection.
+			return ctx.Err()
+		case err := <-recvErr:
+			return ignoreEOF(err)
+		case msg, ok := <-stream.Event:
+			if !ok {
+				return nil // output closed, no more events
+			}
+
+			marshalled, err := json.Marshal(msg)
+			if err != nil {
+				return fmt.Errorf("while marshalling msg to byte: %w", err)
+			}
+
+			id++
+			credits.Add(-1)
+			err = gstream.Send(&StreamResponse{
+				Event: marshalled,
+				Id:    id,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
 func (p *grpcServer) HandleExternalRequest(ctx context.Context, req *ExternalRequest) (*ExternalRequestResponse, error) {
 	out, err := p.Source.HandleExternalRequest(ctx, ExternalRequestInput{
 		Payload: req.Payload,
 		Config:  req.Config,
 		Context: ExternalRequestInputContext{
 			CommonSourceContext: sourceContextFromGRPC(req.Context.SourceContext),
+			HTTP:                httpContextFromGRPC(req.Context.GetHttp()),
 		},
 	})
 	if err != nil {
@@ -353,10 +613,49 @@ func Serve(p map[string]plugin.Plugin) {
 			MagicCookieKey:   api.HandshakeConfig.MagicCookieKey,
 			MagicCookieValue: api.HandshakeConfig.MagicCookieValue,
 		},
-		GRPCServer: plugin.DefaultGRPCServer,
+		GRPCServer:  api.GRPCServer,
+		TLSProvider: api.TLSProvider,
 	})
 }
 
+func streamInputFromGRPC(req *StreamRequest) StreamInput {
+	return StreamInput{
+		Configs: req.Configs,
+		Context: StreamInputContext{
+			KubeConfig:          req.GetContext().GetKubeConfig(),
+			CommonSourceContext: sourceContextFromGRPC(req.GetContext().GetSourceContext()),
+		},
+	}
+}
+
+func unmarshalEvent(in []byte) (Event, error) {
+	var event Event
+	if len(in) == 0 {
+		return event, nil
+	}
+	if err := json.Unmarshal(in, &event); err != nil {
+		return Event{}, fmt.Errorf("cannot unmarshal JSON message: %w", err)
+	}
+	return event, nil
+}
+
+// ignoreEOF returns nil if a given error is io.EOF, which means that the stream was closed by the client.
+func ignoreEOF(err error) error {
+	if err == io.EOF {
+		return nil
+	}
+	return err
+}
+
+// firstHeaderValue returns the first value of a given gRPC header, or an empty string if it's not set.
+func firstHeaderValue(md metadata.MD, key string) string {
+	values := md.Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
 func sourceContextToGRPC(in CommonSourceContext) *SourceContext {
 	return &SourceContext{
 		IsInteractivitySupported: in.IsInteractivitySupported,
@@ -389,3 +688,49 @@ func sourceContextFromGRPC(in *SourceContext) CommonSourceContext {
 		IncomingWebhook:          incomingWebhook,
 	}
 }
+
+func httpContextToGRPC(in HTTPRequestContext) *ExternalRequestHTTPContext {
+	out := &ExternalRequestHTTPContext{
+		Route:  in.Route,
+		Method: in.Method,
+		Query:  in.Query.Encode(),
+	}
+	for name, values := range in.Header {
+		out.Headers = append(out.Headers, &HTTPHeader{Name: name, Values: values})
+	}
+	return out
+}
+
+func httpContextFromGRPC(in *ExternalRequestHTTPContext) HTTPRequestContext {
+	if in == nil {
+		return HTTPRequestContext{}
+	}
+
+	// query is encoded by Botkube, so it's always valid
+	query, _ := url.ParseQuery(in.Query)
+	out := HTTPRequestContext{
+		Route:  in.Route,
+		Method: in.Method,
+		Query:  query,
+	}
+	if len(in.Headers) > 0 {
+		out.Header = http.Header{}
+		for _, header := range in.Headers {
+			out.Header[header.Name] = header.Values
+		}
+	}
+	return out
+}
+
+func routesToGRPC(in []api.ExternalRequestRoute) []*ExternalRequestRoute {
+	var out []*ExternalRequestRoute
+	for _, route := range in {
+		out = append(out, &ExternalRequestRoute{
+			Path:       route.Path,
+			Methods:    route.Methods,
+			AuthScheme: route.AuthScheme,
+			AuthHeader: route.AuthHeader,
+		})
+	}
+	return out
+}
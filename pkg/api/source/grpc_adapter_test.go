@@ -0,0 +1,184 @@
+package source
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/kubeshop/botkube/pkg/api"
+)
+
+func TestGRPCClientAckStreamBackpressure(t *testing.T) {
+	// given
+	src := &countingSource{}
+	cli := newTestGRPCClient(t, &grpcServer{Source: src}, 2)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// when
+	out, err := cli.Stream(ctx, StreamInput{})
+
+	// then
+	require.NoError(t, err)
+	require.NotNil(t, out.Ack)
+
+	// only events with granted credits are read from the source
+	require.Eventually(t, func() bool { return src.sent.Load() == 2 }, 5*time.Second, 10*time.Millisecond)
+	time.Sleep(100 * time.Millisecond)
+	assert.EqualValues(t, 2, src.sent.Load())
+
+	// when
+	event := <-out.Event
+	out.Ack()
+
+	// then
+	assert.Equal(t, "event 1", event.Message.BaseBody.Plaintext)
+	require.Eventually(t, func() bool { return src.sent.Load() == 3 }, 5*time.Second, 10*time.Millisecond)
+
+	for i := 2; i <= 3; i++ {
+		event := <-out.Event
+		out.Ack()
+		assert.Equal(t, fmt.Sprintf("event %d", i), event.Message.BaseBody.Plaintext)
+	}
+}
+
+func TestGRPCClientStreamWithoutAcknowledgements(t *testing.T) {
+	// given
+	cli := newTestGRPCClient(t, &legacyGRPCServer{grpcServer{Source: &countingSource{}}}, 2)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// when
+	out, err := cli.Stream(ctx, StreamInput{})
+
+	// then
+	require.NoError(t, err)
+	assert.Nil(t, out.Ack)
+	for i := 1; i <= 3; i++ {
+		event := <-out.Event
+		assert.Equal(t, fmt.Sprintf("event %d", i), event.Message.BaseBody.Plaintext)
+	}
+}
+
+func TestGRPCClientExternalRequestRoutes(t *testing.T) {
+	// given
+	routes := []api.ExternalRequestRoute{
+		{Path: "events", Methods: []string{http.MethodPost}, AuthScheme: "hmacSHA256", AuthHeader: "X-Hub-Signature-256"},
+	}
+	src := &webhookSource{routes: routes}
+	cli := newTestGRPCClient(t, &grpcServer{Source: src}, 1)
+	httpCtx := HTTPRequestContext{
+		Route:  "events",
+		Method: http.MethodPost,
+		Header: http.Header{"X-Github-Event": []string{"push"}},
+		Query:  url.Values{"page": []string{"2"}},
+	}
+
+	// when
+	meta, err := cli.Metadata(context.Background())
+	require.NoError(t, err)
+	_, err = cli.HandleExternalRequest(context.Background(), ExternalRequestInput{
+		Context: ExternalRequestInputContext{HTTP: httpCtx},
+	})
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, routes, meta.ExternalRequest.Routes)
+	assert.Equal(t, EventSchemaVersion, meta.EventSchemaVersion)
+	assert.Equal(t, httpCtx, src.received)
+}
+
+func newTestGRPCClient(t *testing.T, srv SourceServer, window uint32) *grpcClient {
+	t.Helper()
+
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	RegisterSourceServer(server, srv)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	return &grpcClient{
+		client: NewSourceClient(conn),
+		logger: NewLogger(),
+		window: window,
+	}
+}
+
+// countingSource streams numbered events and counts events read from it.
+type countingSource struct {
+	HandleExternalRequestUnimplemented
+	sent atomic.Int32
+}
+
+func (s *countingSource) Stream(ctx context.Context, _ StreamInput) (StreamOutput, error) {
+	out := StreamOutput{Event: make(chan Event)}
+	go func() {
+		for i := 1; ; i++ {
+			select {
+			case out.Event <- Event{Message: api.NewPlaintextMessage(fmt.Sprintf("event %d", i), false)}:
+				s.sent.Add(1)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out, nil
+}
+
+func (s *countingSource) Metadata(context.Context) (api.MetadataOutput, error) {
+	return api.MetadataOutput{}, nil
+}
+
+// legacyGRPCServer simulates plugins built with Botkube versions which don't support acknowledgements.
+type legacyGRPCServer struct {
+	grpcServer
+}
+
+func (*legacyGRPCServer) AckStream(Source_AckStreamServer) error {
+	return UnimplementedSourceServer{}.AckStream(nil)
+}
+
+// webhookSource registers incoming webhook routes and records received HTTP request details.
+type webhookSource struct {
+	routes   []api.ExternalRequestRoute
+	received HTTPRequestContext
+}
+
+func (s *webhookSource) Stream(context.Context, StreamInput) (StreamOutput, error) {
+	return StreamOutput{}, nil
+}
+
+func (s *webhookSource) HandleExternalRequest(_ context.Context, in ExternalRequestInput) (ExternalRequestOutput, error) {
+	s.received = in.Context.HTTP
+	return ExternalRequestOutput{}, nil
+}
+
+func (s *webhookSource) Metadata(context.Context) (api.MetadataOutput, error) {
+	return api.MetadataOutput{
+		ExternalRequest: api.ExternalRequestMetadata{Routes: s.routes},
+	}, nil
+}
@@ -21,8 +21,10 @@ const _ = grpc.SupportPackageIsVersion7
 
 const (
 	Source_Stream_FullMethodName                = "/source.Source/Stream"
+	Source_AckStream_FullMethodName             = "/source.Source/AckStream"
 	Source_HandleExternalRequest_FullMethodName = "/source.Source/HandleExternalRequest"
 	Source_Metadata_FullMethodName              = "/source.Source/Metadata"
+	Source_Metrics_FullMethodName               = "/source.Source/Metrics"
 )
 
 // SourceClient is the client API for Source service.
@@ -30,8 +32,13 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type SourceClient interface {
 	Stream(ctx context.Context, in *StreamRequest, opts ...grpc.CallOption) (Source_StreamClient, error)
+	// AckStream streams events with explicit acknowledgements. Botkube grants credits for sending events,
+	// so the plugin stops sending them once Botkube cannot keep up with dispatching.
+	AckStream(ctx context.Context, opts ...grpc.CallOption) (Source_AckStreamClient, error)
 	HandleExternalRequest(ctx context.Context, in *ExternalRequest, opts ...grpc.CallOption) (*ExternalRequestResponse, error)
 	Metadata(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*MetadataResponse, error)
+	// Metrics returns Prometheus metrics exported by the plugin, so they can be re-exposed by Botkube.
+	Metrics(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*MetricsResponse, error)
 }
 
 type sourceClient struct {
@@ -74,6 +81,37 @@ func (x *sourceStreamClient) Recv() (*StreamResponse, error) {
 	return m, nil
 }
 
+func (c *sourceClient) AckStream(ctx context.Context, opts ...grpc.CallOption) (Source_AckStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Source_ServiceDesc.Streams[1], Source_AckStream_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &sourceAckStreamClient{stream}
+	return x, nil
+}
+
+type Source_AckStreamClient interface {
+	Send(*AckStreamRequest) error
+	Recv() (*StreamResponse, error)
+	grpc.ClientStream
+}
+
+type sourceAckStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *sourceAckStreamClient) Send(m *AckStreamRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *sourceAckStreamClient) Recv() (*StreamResponse, error) {
+	m := new(StreamResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *sourceClient) HandleExternalRequest(ctx context.Context, in *ExternalRequest, opts ...grpc.CallOption) (*ExternalRequestResponse, error) {
 	out := new(ExternalRequestResponse)
 	err := c.cc.Invoke(ctx, Source_HandleExternalRequest_FullMethodName, in, out, opts...)
@@ -92,13 +130,27 @@ func (c *sourceClient) Metadata(ctx context.Context, in *emptypb.Empty, opts ...
 	return out, nil
 }
 
+func (c *sourceClient) Metrics(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*MetricsResponse, error) {
+	out := new(MetricsResponse)
+	err := c.cc.Invoke(ctx, Source_Metrics_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SourceServer is the server API for Source service.
 // All implementations must embed UnimplementedSourceServer
 // for forward compatibility
 type SourceServer interface {
 	Stream(*StreamRequest, Source_StreamServer) error
+	// AckStream streams events with explicit acknowledgements. Botkube grants credits for sending events,
+	// so the plugin stops sending them once Botkube cannot keep up with dispatching.
+	AckStream(Source_AckStreamServer) error
 	HandleExternalRequest(context.Context, *ExternalRequest) (*ExternalRequestResponse, error)
 	Metadata(context.Context, *emptypb.Empty) (*MetadataResponse, error)
+	// Metrics returns Prometheus metrics exported by the plugin, so they can be re-exposed by Botkube.
+	Metrics(context.Context, *emptypb.Empty) (*MetricsResponse, error)
 	mustEmbedUnimplementedSourceServer()
 }
 
@@ -109,12 +161,18 @@ type UnimplementedSourceServer struct {
 func (UnimplementedSourceServer) Stream(*StreamRequest, Source_StreamServer) error {
 	return status.Errorf(codes.Unimplemented, "method Stream not implemented")
 }
+func (UnimplementedSourceServer) AckStream(Source_AckStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method AckStream not implemented")
+}
 func (UnimplementedSourceServer) HandleExternalRequest(context.Context, *ExternalRequest) (*ExternalRequestResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method HandleExternalRequest not implemented")
 }
 func (UnimplementedSourceServer) Metadata(context.Context, *emptypb.Empty) (*MetadataResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Metadata not implemented")
 }
+func (UnimplementedSourceServer) Metrics(context.Context, *emptypb.Empty) (*MetricsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Metrics not implemented")
+}
 func (UnimplementedSourceServer) mustEmbedUnimplementedSourceServer() {}
 
 // UnsafeSourceServer may be embedded to opt out of forward compatibility for this service.
@@ -149,6 +207,32 @@ func (x *sourceStreamServer) Send(m *StreamResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _Source_AckStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(SourceServer).AckStream(&sourceAckStreamServer{stream})
+}
+
+type Source_AckStreamServer interface {
+	Send(*StreamResponse) error
+	Recv() (*AckStreamRequest, error)
+	grpc.ServerStream
+}
+
+type sourceAckStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *sourceAckStreamServer) Send(m *StreamResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *sourceAckStreamServer) Recv() (*AckStreamRequest, error) {
+	m := new(AckStreamRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func _Source_HandleExternalRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ExternalRequest)
 	if err := dec(in); err != nil {
@@ -185,6 +269,24 @@ func _Source_Metadata_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Source_Metrics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SourceServer).Metrics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Source_Metrics_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SourceServer).Metrics(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Source_ServiceDesc is the grpc.ServiceDesc for Source service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -200,6 +302,10 @@ var Source_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Metadata",
 			Handler:    _Source_Metadata_Handler,
 		},
+		{
+			MethodName: "Metrics",
+			Handler:    _Source_Metrics_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -207,6 +313,12 @@ var Source_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _Source_Stream_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "AckStream",
+			Handler:       _Source_AckStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "source.proto",
 }
@@ -0,0 +1,94 @@
+package wasm
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/kubeshop/botkube/pkg/api"
+)
+
+// Executor defines the Botkube executor plugin functionality for WebAssembly modules.
+type Executor interface {
+	Metadata() (api.MetadataOutput, error)
+	Execute(ExecuteInput) (ExecuteOutput, error)
+	Help() (api.Message, error)
+}
+
+// Source defines the Botkube source plugin functionality for WebAssembly modules.
+type Source interface {
+	Metadata() (api.MetadataOutput, error)
+	// Stream emits events until it returns. Returning stops the source.
+	Stream(in StreamInput, emit func(Event) error) error
+	HandleExternalRequest(ExternalRequestInput) (ExternalRequestOutput, error)
+}
+
+// ServeExecutor serves a given executor. It must be called from the main function of the module.
+func ServeExecutor(impl Executor) {
+	serve(func(method Method, in io.Reader, out io.Writer) error {
+		switch method {
+		case MethodMetadata:
+			meta, err := impl.Metadata()
+			return writeOutput(out, meta, err)
+		case MethodHelp:
+			help, err := impl.Help()
+			return writeOutput(out, help, err)
+		case MethodExecute:
+			var input ExecuteInput
+			if err := json.NewDecoder(in).Decode(&input); err != nil {
+				return fmt.Errorf("while decoding input: %w", err)
+			}
+			output, err := impl.Execute(input)
+			return writeOutput(out, output, err)
+		}
+		return fmt.Errorf("unsupported method %q", method)
+	})
+}
+
+// ServeSource serves a given source. It must be called from the main function of the module.
+func ServeSource(impl Source) {
+	serve(func(method Method, in io.Reader, out io.Writer) error {
+		switch method {
+		case MethodMetadata:
+			meta, err := impl.Metadata()
+			return writeOutput(out, meta, err)
+		case MethodStream:
+			var input StreamInput
+			if err := json.NewDecoder(in).Decode(&input); err != nil {
+				return fmt.Errorf("while decoding input: %w", err)
+			}
+			enc := json.NewEncoder(out)
+			return impl.Stream(input, func(event Event) error {
+				return enc.Encode(event)
+			})
+		case MethodHandleExternalRequest:
+			var input ExternalRequestInput
+			if err := json.NewDecoder(in).Decode(&input); err != nil {
+				return fmt.Errorf("while decoding input: %w", err)
+			}
+			output, err := impl.HandleExternalRequest(input)
+			return writeOutput(out, output, err)
+		}
+		return fmt.Errorf("unsupported method %q", method)
+	})
+}
+
+func serve(handle func(method Method, in io.Reader, out io.Writer) error) {
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, "method name is required as the first argument")
+		os.Exit(1)
+	}
+
+	if err := handle(Method(os.Args[1]), os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+}
+
+func writeOutput(out io.Writer, output any, err error) error {
+	if err != nil {
+		return err
+	}
+	return json.NewEncoder(out).Encode(output)
+}
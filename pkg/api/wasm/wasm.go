@@ -0,0 +1,132 @@
+// Package wasm defines the protocol between Botkube and plugins distributed as WebAssembly modules.
+//
+// A WebAssembly plugin is a WASI command, e.g. built with `GOOS=wasip1 GOARCH=wasm go build`. For each call, Botkube runs
+// the module with the method name as the first argument, writes the JSON-encoded input to the standard input
+// and reads the JSON-encoded output from the standard output. Events of the stream method are written as JSON lines.
+// A non-zero exit code fails the call, and the standard error is used as the error message.
+//
+// Modules are sandboxed: they have no access to the filesystem, network, environment variables or host processes.
+// Only the standard streams, clocks and a random source are available.
+package wasm
+
+import (
+	"github.com/kubeshop/botkube/pkg/api"
+)
+
+// Method is a name of the plugin function called by Botkube.
+type Method string
+
+const (
+	// MethodMetadata returns api.MetadataOutput.
+	MethodMetadata Method = "metadata"
+	// MethodExecute takes ExecuteInput and returns ExecuteOutput.
+	MethodExecute Method = "execute"
+	// MethodHelp returns api.Message.
+	MethodHelp Method = "help"
+	// MethodStream takes StreamInput and writes Event JSON lines until the module exits.
+	MethodStream Method = "stream"
+	// MethodHandleExternalRequest takes ExternalRequestInput and returns ExternalRequestOutput.
+	MethodHandleExternalRequest Method = "handleExternalRequest"
+)
+
+type (
+	// Config holds the plugin configuration specified by users.
+	Config struct {
+		// RawYAML contains the plugin configuration in YAML definitions.
+		RawYAML []byte `json:"rawYAML,omitempty"`
+	}
+
+	// ExecuteInput holds the input of the execute method.
+	ExecuteInput struct {
+		// Command holds the command to be executed.
+		Command string `json:"command"`
+		// Configs is a list of Executor configurations specified by users.
+		Configs []Config `json:"configs,omitempty"`
+		// Context holds execution context.
+		Context ExecuteInputContext `json:"context"`
+	}
+
+	// ExecuteInputContext holds execution context.
+	ExecuteInputContext struct {
+		// IsInteractivitySupported is set to true only if communication platform supports interactive Messages.
+		IsInteractivitySupported bool `json:"isInteractivitySupported"`
+		// Message holds details of the message that triggered a given Executor.
+		Message Message `json:"message"`
+	}
+
+	// Message holds information about the message that triggered a given Executor.
+	Message struct {
+		Text             string `json:"text,omitempty"`
+		URL              string `json:"url,omitempty"`
+		User             User   `json:"user"`
+		ParentActivityID string `json:"parentActivityId,omitempty"`
+	}
+
+	// User represents the user that sent a message.
+	User struct {
+		Mention     string `json:"mention,omitempty"`
+		DisplayName string `json:"displayName,omitempty"`
+	}
+
+	// ExecuteOutput holds the output of the execute method.
+	ExecuteOutput struct {
+		// Message represents the output of processing a given input command.
+		Message api.Message `json:"message"`
+		// Messages holds a collection of messages that should be dispatched to the user in the context of a given command execution.
+		Messages []api.Message `json:"messages,omitempty"`
+	}
+
+	// StreamInput holds the input of the stream method.
+	StreamInput struct {
+		// Configs is a list of Source configurations specified by users.
+		Configs []Config `json:"configs,omitempty"`
+		// Context holds streaming context.
+		Context SourceContext `json:"context"`
+	}
+
+	// ExternalRequestInput holds the input of the handleExternalRequest method.
+	ExternalRequestInput struct {
+		// Payload is the payload of the incoming webhook.
+		Payload []byte `json:"payload,omitempty"`
+		// Config is Source configuration specified by users.
+		Config Config `json:"config"`
+		// Context holds single dispatch context.
+		Context SourceContext `json:"context"`
+		// HTTP holds details of the incoming HTTP request.
+		HTTP HTTPRequest `json:"http"`
+	}
+
+	// HTTPRequest holds details of the incoming HTTP request.
+	HTTPRequest struct {
+		// Route is the path of the matched route registered by the plugin. It's empty for requests to the source URL.
+		Route  string              `json:"route,omitempty"`
+		Method string              `json:"method,omitempty"`
+		Header map[string][]string `json:"header,omitempty"`
+		// Query is the URL-encoded query of the request.
+		Query string `json:"query,omitempty"`
+	}
+
+	// ExternalRequestOutput holds the output of the handleExternalRequest method.
+	ExternalRequestOutput struct {
+		Event Event `json:"event"`
+	}
+
+	// SourceContext holds common source context.
+	SourceContext struct {
+		// IsInteractivitySupported is set to true only if a communication platform supports interactive Messages.
+		IsInteractivitySupported bool `json:"isInteractivitySupported"`
+		// ClusterName is the name of the underlying Kubernetes cluster which is provided by end user.
+		ClusterName string `json:"clusterName,omitempty"`
+		// SourceName is the name of the source plugin configuration.
+		SourceName string `json:"sourceName,omitempty"`
+	}
+
+	// Event is a single event emitted by a source.
+	Event struct {
+		Message         api.Message    `json:"message"`
+		RawObject       any            `json:"rawObject,omitempty"`
+		AnalyticsLabels map[string]any `json:"analyticsLabels,omitempty"`
+		// Channels overrides channels the message is sent to. If empty, channels bound to the source binding are used.
+		Channels []string `json:"channels,omitempty"`
+	}
+)
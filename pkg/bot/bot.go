@@ -2,9 +2,12 @@ package bot
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/kubeshop/botkube/internal/analytics"
+	"github.com/kubeshop/botkube/internal/autocomplete"
 	"github.com/kubeshop/botkube/internal/health"
+	"github.com/kubeshop/botkube/internal/metrics"
 	"github.com/kubeshop/botkube/pkg/config"
 	"github.com/kubeshop/botkube/pkg/execute"
 	"github.com/kubeshop/botkube/pkg/notifier"
@@ -22,6 +25,26 @@ type Bot interface {
 	notifier.Bot
 }
 
+// NotificationStarter is implemented by bots which can be started only to send notifications, without handling commands.
+type NotificationStarter interface {
+	StartNotifications(ctx context.Context) error
+}
+
+// StartFunc returns a function which starts a given bot. If handleCommands is false, the bot is started only to send notifications,
+// so replicas which share the communication platform configuration don't respond to the same command many times.
+// Bots which can't send notifications, such as NATS, are not started in that case.
+func StartFunc(b Bot, handleCommands bool) func(ctx context.Context) error {
+	if handleCommands {
+		return b.Start
+	}
+	if starter, ok := b.(NotificationStarter); ok {
+		return starter.StartNotifications
+	}
+	return func(context.Context) error {
+		return nil
+	}
+}
+
 type Status struct {
 	Status   health.PlatformStatusMsg
 	Restarts string
@@ -33,6 +56,11 @@ type ExecutorFactory interface {
 	NewDefault(cfg execute.NewDefaultInput) execute.Executor
 }
 
+// Autocompleter suggests options for interactive pickers, such as Slack external selects, based on the command they complete.
+type Autocompleter interface {
+	Suggest(cmd, query string) []autocomplete.Option
+}
+
 // AnalyticsReporter defines a reporter that collects analytics data.
 type AnalyticsReporter interface {
 	// ReportBotEnabled reports an enabled bot.
@@ -56,6 +84,16 @@ type AnalyticsCommandReporter interface {
 	ReportCommand(in analytics.ReportCommandInput) error
 }
 
+// isChannelSelected returns true if a channel with a given alias or identifier is one of given channels.
+func isChannelSelected(alias, identifier string, channels []string) bool {
+	for _, ch := range channels {
+		if ch == alias || ch == identifier {
+			return true
+		}
+	}
+	return false
+}
+
 type channelConfigByID struct {
 	config.ChannelBindingsByID
 
@@ -76,6 +114,11 @@ type CommGroupMetadata struct {
 	Index int
 }
 
+// registerMessageQueue reports the number of messages of a given bot which wait to be handled, e.g. `socketSlack/default`.
+func registerMessageQueue(platform config.CommPlatformIntegration, commGroup CommGroupMetadata, depth func() int) {
+	metrics.RegisterQueue(fmt.Sprintf("%s/%s", platform, commGroup.Name), depth)
+}
+
 func AsNotifiers(bots map[string]Bot) []notifier.Bot {
 	notifiers := make([]notifier.Bot, 0, len(bots))
 	for _, bot := range bots {
@@ -0,0 +1,76 @@
+package bot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/internal/health"
+	"github.com/kubeshop/botkube/internal/sharding"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/notifier"
+)
+
+type fakeStartedBot struct {
+	notifier.Bot
+	started string
+}
+
+func (f *fakeStartedBot) Start(context.Context) error {
+	f.started = "commands"
+	return nil
+}
+
+func (f *fakeStartedBot) GetStatus() health.PlatformStatus {
+	return health.PlatformStatus{}
+}
+
+type fakeNotificationBot struct {
+	fakeStartedBot
+}
+
+func (f *fakeNotificationBot) StartNotifications(context.Context) error {
+	f.started = "notifications"
+	return nil
+}
+
+func TestStartFuncWithTwoShards(t *testing.T) {
+	cfg := config.Sharding{Enabled: true, Shards: 2}
+	tests := []struct {
+		name            string
+		shard           sharding.Shard
+		expSlackStarted string
+		expNATSStarted  string
+	}{
+		{
+			name:            "first shard handles commands",
+			shard:           sharding.ShardFor(cfg, 0),
+			expSlackStarted: "commands",
+			expNATSStarted:  "commands",
+		},
+		{
+			name:            "second shard only sends notifications",
+			shard:           sharding.ShardFor(cfg, 1),
+			expSlackStarted: "notifications",
+			expNATSStarted:  "",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			slackBot := &fakeNotificationBot{}
+			natsBot := &fakeStartedBot{}
+			handleCommands := sharding.RunsSingletons(&tc.shard)
+
+			// when
+			require.NoError(t, StartFunc(slackBot, handleCommands)(context.Background()))
+			require.NoError(t, StartFunc(natsBot, handleCommands)(context.Background()))
+
+			// then
+			assert.Equal(t, tc.expSlackStarted, slackBot.started)
+			assert.Equal(t, tc.expNATSStarted, natsBot.started)
+		})
+	}
+}
@@ -8,19 +8,21 @@ import (
 	"regexp"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/sirupsen/logrus"
 	"github.com/sourcegraph/conc/pool"
 
 	"github.com/kubeshop/botkube/internal/health"
-	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/internal/metrics"
 	"github.com/kubeshop/botkube/pkg/bot/interactive"
 	"github.com/kubeshop/botkube/pkg/config"
 	conversationx "github.com/kubeshop/botkube/pkg/conversation"
 	"github.com/kubeshop/botkube/pkg/execute"
 	"github.com/kubeshop/botkube/pkg/execute/command"
 	"github.com/kubeshop/botkube/pkg/multierror"
+	"github.com/kubeshop/botkube/pkg/notifier"
 	"github.com/kubeshop/botkube/pkg/sliceutil"
 )
 
@@ -29,6 +31,7 @@ import (
 //    - review all the methods and see if they can be simplified.
 
 var _ Bot = &Discord{}
+var _ notifier.ChannelLister = &Discord{}
 
 const (
 	// discordBotMentionRegexFmt supports also nicknames (the exclamation mark).
@@ -62,7 +65,16 @@ type Discord struct {
 
 // discordMessage contains message details to execute command and send back the result.
 type discordMessage struct {
-	Event *discordgo.MessageCreate
+	// Event is nil for reactions.
+	Event    *discordgo.MessageCreate
+	Reaction *discordgo.MessageReactionAdd
+}
+
+func (m discordMessage) channelID() string {
+	if m.Reaction != nil {
+		return m.Reaction.ChannelID
+	}
+	return m.Event.ChannelID
 }
 
 // NewDiscord creates a new Discord instance.
@@ -102,6 +114,7 @@ func NewDiscord(log logrus.FieldLogger, commGroupMetadata CommGroupMetadata, cfg
 func (b *Discord) startMessageProcessor(ctx context.Context) {
 	b.log.Info("Starting discord message processor...")
 	defer b.log.Info("Stopped discord message processor...")
+	registerMessageQueue(b.IntegrationName(), b.commGroupMetadata, func() int { return len(b.messages) })
 
 	for msg := range b.messages {
 		b.discordMessageWorkers.Go(func() {
@@ -121,6 +134,11 @@ func (b *Discord) Start(ctx context.Context) error {
 			Event: m,
 		}
 	})
+	b.api.AddHandler(func(s *discordgo.Session, r *discordgo.MessageReactionAdd) {
+		b.messages <- discordMessage{
+			Reaction: r,
+		}
+	})
 
 	// Open a websocket connection to Discord and begin listening.
 	err := b.api.Open()
@@ -146,8 +164,24 @@ func (b *Discord) Start(ctx context.Context) error {
 // SendMessage sends interactive message to selected Discord channels.
 // Context is not supported by client: See https://github.com/bwmarrin/discordgo/issues/752.
 func (b *Discord) SendMessage(_ context.Context, msg interactive.CoreMessage, sourceBindings []string) error {
+	return b.sendToChannels(msg, b.getChannelsToNotify(sourceBindings))
+}
+
+// SendMessageToChannels sends interactive message to given Discord channels.
+func (b *Discord) SendMessageToChannels(_ context.Context, msg interactive.CoreMessage, channels []string) error {
+	var channelIDs []string
+	for _, cfg := range b.getChannels() {
+		if !cfg.notify || !isChannelSelected(cfg.alias, cfg.Identifier(), channels) {
+			continue
+		}
+		channelIDs = append(channelIDs, cfg.Identifier())
+	}
+	return b.sendToChannels(msg, channelIDs)
+}
+
+func (b *Discord) sendToChannels(msg interactive.CoreMessage, channelIDs []string) error {
 	errs := multierror.New()
-	for _, channelID := range b.getChannelsToNotify(sourceBindings) {
+	for _, channelID := range channelIDs {
 		err := b.send(channelID, msg)
 		if err != nil {
 			errs = multierror.Append(errs, fmt.Errorf("while sending Discord message to channel %q: %w", channelID, err))
@@ -185,6 +219,24 @@ func (b *Discord) Type() config.IntegrationType {
 	return config.BotIntegrationType
 }
 
+// NotificationChannels returns Discord channels which have notifications enabled.
+func (b *Discord) NotificationChannels() []notifier.NotificationChannel {
+	var out []notifier.NotificationChannel
+	for _, cfg := range b.getChannels() {
+		if !cfg.notify {
+			continue
+		}
+		out = append(out, notifier.NotificationChannel{
+			Name:     cfg.Identifier(),
+			Alias:    cfg.alias,
+			Sources:  cfg.Bindings.Sources,
+			Filters:  cfg.Bindings.Filters,
+			Settings: cfg.Notification.NotificationSettings,
+		})
+	}
+	return out
+}
+
 // TODO: Support custom routing via annotations for Discord as well
 func (b *Discord) getChannelsToNotify(sourceBindings []string) []string {
 	var out []string
@@ -232,22 +284,42 @@ func (b *Discord) SetNotificationsEnabled(channelID string, enabled bool) error
 
 // HandleMessage handles the incoming messages.
 func (b *Discord) handleMessage(ctx context.Context, dm discordMessage) error {
-	// Handle message only if starts with mention
-	req, found := b.findAndTrimBotMention(dm.Event.Content)
+	channelID := dm.channelID()
+	channel, exists := b.getChannels()[channelID]
+	if !exists {
+		channel = channelConfigByID{
+			ChannelBindingsByID: config.ChannelBindingsByID{
+				ID: channelID,
+			},
+		}
+	}
+
+	// Handle message only if starts with mention, or matches channel triggers
+	var (
+		req    string
+		found  bool
+		author *discordgo.User
+	)
+	if dm.Reaction != nil {
+		req, author, found = b.reactionCommand(channel, dm.Reaction)
+	} else {
+		author = dm.Event.Author
+		req, found = b.findAndTrimBotMention(dm.Event.Content)
+		if !found && author.ID != b.botID {
+			req, found = b.triggeredCommand(channel, dm.Event)
+		}
+	}
 	if !found {
-		b.log.Debugf("Ignoring message as it doesn't contain %q mention", b.botID)
+		b.log.Debugf("Ignoring message as it doesn't contain %q mention nor matches channel triggers", b.botID)
 		return nil
 	}
 
 	b.log.Debugf("Discord incoming Request: %s", req)
 
-	channel, exists := b.getChannels()[dm.Event.ChannelID]
-	if !exists {
-		channel = channelConfigByID{
-			ChannelBindingsByID: config.ChannelBindingsByID{
-				ID: dm.Event.ChannelID,
-			},
-		}
+	// only verified emails identify users
+	var email string
+	if author.Verified {
+		email = author.Email
 	}
 
 	e := b.executorFactory.NewDefault(execute.NewDefaultInput{
@@ -260,18 +332,21 @@ func (b *Discord) handleMessage(ctx context.Context, dm discordMessage) error {
 			ID:               channel.Identifier(),
 			ExecutorBindings: channel.Bindings.Executors,
 			SourceBindings:   channel.Bindings.Sources,
+			ReadOnly:         channel.ReadOnly,
 			IsKnown:          exists,
 			CommandOrigin:    command.TypedOrigin,
 		},
 		Message: req,
 		User: execute.UserInput{
-			Mention:     fmt.Sprintf("<@%s>", dm.Event.Author.ID),
-			DisplayName: dm.Event.Author.String(),
+			Mention:     fmt.Sprintf("<@%s>", author.ID),
+			DisplayName: author.String(),
+			ID:          author.ID,
+			Email:       email,
 		},
 	})
 
 	response := e.Execute(ctx)
-	err := b.send(dm.Event.ChannelID, response)
+	err := b.send(channelID, response)
 	if err != nil {
 		return fmt.Errorf("while sending message: %w", err)
 	}
@@ -279,12 +354,74 @@ func (b *Discord) handleMessage(ctx context.Context, dm discordMessage) error {
 	return nil
 }
 
-func (b *Discord) send(channelID string, resp interactive.CoreMessage) error {
+// StartNotifications starts the bot without the Gateway connection, as notifications are sent with the REST API.
+func (b *Discord) StartNotifications(ctx context.Context) error {
+	b.log.Info("Starting bot without handling commands")
+	b.setFailureReason("", "")
+	<-ctx.Done()
+	return nil
+}
+
+// triggeredCommand returns a command triggered without mentioning the bot, i.e. with the channel prefix or a reply to a bot message.
+func (b *Discord) triggeredCommand(channel channelConfigByID, msg *discordgo.MessageCreate) (string, bool) {
+	if cmd, found := trimCommandPrefix(channel.Triggers, msg.Content); found {
+		return cmd, true
+	}
+
+	ref := msg.ReferencedMessage
+	if !channel.Triggers.Replies || msg.Type != discordgo.MessageTypeReply || ref == nil || ref.Author == nil || ref.Author.ID != b.botID {
+		return "", false
+	}
+	cmd := strings.TrimSpace(msg.Content)
+	return cmd, cmd != ""
+}
+
+// reactionCommand returns the command from the reacted message if the reaction is configured as a channel trigger,
+// or the acknowledgement command if the reaction is an ack emoji added to an incident notification.
+func (b *Discord) reactionCommand(channel channelConfigByID, reaction *discordgo.MessageReactionAdd) (string, *discordgo.User, bool) {
+	isAck, isTrigger := isAckEmoji(channel.Triggers, reaction.Emoji.Name), isEmojiTrigger(channel.Triggers, reaction.Emoji.Name)
+	if reaction.UserID == b.botID || (!isAck && !isTrigger) {
+		return "", nil, false
+	}
+
+	msg, err := b.api.ChannelMessage(reaction.ChannelID, reaction.MessageID)
+	if err != nil {
+		b.log.WithError(err).Error("Cannot get reacted message")
+		return "", nil, false
+	}
+
+	author := &discordgo.User{ID: reaction.UserID}
+	if reaction.Member != nil && reaction.Member.User != nil {
+		author = reaction.Member.User
+	}
+
+	if isAck && msg.Author != nil && msg.Author.ID == b.botID {
+		if cmd, found := ackIncidentCommand(msg); found {
+			return cmd, author, true
+		}
+	}
+	if !isTrigger {
+		return "", nil, false
+	}
+
+	// the reacted message may be written for any other trigger
+	if cmd, found := b.findAndTrimBotMention(msg.Content); found {
+		return strings.TrimSpace(cmd), author, true
+	}
+	if cmd, found := trimCommandPrefix(channel.Triggers, msg.Content); found {
+		return cmd, author, true
+	}
+	cmd := strings.TrimSpace(msg.Content)
+	return cmd, author, cmd != ""
+}
+
+func (b *Discord) send(channelID string, resp interactive.CoreMessage) (err error) {
+	defer metrics.ObserveMessageSend(b.IntegrationName(), channelID, time.Now(), &err)
 	b.log.Debugf("Sending message to channel %q: %+v", channelID, resp)
 
 	resp.ReplaceBotNamePlaceholder(b.BotName())
 
-	discordMsg, err := b.formatMessage(resp)
+	discordMsg, err := b.renderer.RenderMessage(resp)
 	if err != nil {
 		return fmt.Errorf("while formatting message: %w", err)
 	}
@@ -325,45 +462,6 @@ func (b *Discord) findAndTrimBotMention(msg string) (string, bool) {
 	return b.botMentionRegex.ReplaceAllString(msg, ""), true
 }
 
-func (b *Discord) formatMessage(msg interactive.CoreMessage) (*discordgo.MessageSend, error) {
-	// 1. Check the size and upload message as a file if it's too long
-	plaintext := interactive.MessageToPlaintext(msg, interactive.NewlineFormatter)
-	if len(plaintext) == 0 {
-		return nil, errors.New("while reading Discord response: empty response")
-	}
-	if len(plaintext) >= discordMaxMessageSize {
-		return &discordgo.MessageSend{
-			Content: msg.Description,
-			Files: []*discordgo.File{
-				{
-					Name:   "Response.txt",
-					Reader: strings.NewReader(plaintext),
-				},
-			},
-		}, nil
-	}
-
-	// 2. If it's not a simplified event, render as markdown
-	if msg.Type != api.NonInteractiveSingleSection {
-		return &discordgo.MessageSend{
-			Content: b.renderer.MessageToMarkdown(msg),
-		}, nil
-	}
-
-	// TODO: For now, we just render only with a few fields that are always present in the event message.
-	// This should be removed once we will add support for rendering AdaptiveCard with all message primitives.
-	messageEmbed, err := b.renderer.NonInteractiveSectionToCard(msg)
-	if err != nil {
-		return nil, fmt.Errorf("while rendering event message embed: %w", err)
-	}
-
-	return &discordgo.MessageSend{
-		Embeds: []*discordgo.MessageEmbed{
-			&messageEmbed,
-		},
-	}, nil
-}
-
 func (b *Discord) shutdown() {
 	b.shutdownOnce.Do(func() {
 		b.log.Info("Shutting down discord message processor...")
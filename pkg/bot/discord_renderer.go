@@ -1,6 +1,9 @@
 package bot
 
 import (
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
@@ -27,6 +30,46 @@ func (d *DiscordRenderer) MessageToMarkdown(in interactive.CoreMessage) string {
 	return interactive.RenderMessage(d.mdFormatter, in)
 }
 
+// RenderMessage returns the Discord message for a given message. Messages which are too long are attached as a file.
+func (d *DiscordRenderer) RenderMessage(msg interactive.CoreMessage) (*discordgo.MessageSend, error) {
+	// 1. Check the size and upload message as a file if it's too long
+	plaintext := interactive.MessageToPlaintext(msg, interactive.NewlineFormatter)
+	if len(plaintext) == 0 {
+		return nil, errors.New("while reading Discord response: empty response")
+	}
+	if len(plaintext) >= discordMaxMessageSize {
+		return &discordgo.MessageSend{
+			Content: msg.Description,
+			Files: []*discordgo.File{
+				{
+					Name:   "Response.txt",
+					Reader: strings.NewReader(plaintext),
+				},
+			},
+		}, nil
+	}
+
+	// 2. If it's not a simplified event, render as markdown
+	if msg.Type != api.NonInteractiveSingleSection {
+		return &discordgo.MessageSend{
+			Content: d.MessageToMarkdown(msg),
+		}, nil
+	}
+
+	// TODO: For now, we just render only with a few fields that are always present in the event message.
+	// This should be removed once we will add support for rendering AdaptiveCard with all message primitives.
+	messageEmbed, err := d.NonInteractiveSectionToCard(msg)
+	if err != nil {
+		return nil, fmt.Errorf("while rendering event message embed: %w", err)
+	}
+
+	return &discordgo.MessageSend{
+		Embeds: []*discordgo.MessageEmbed{
+			&messageEmbed,
+		},
+	}, nil
+}
+
 // NonInteractiveSectionToCard returns MessageEmbed for the given event message.
 // Note: It cannot be used for other messages as we take into account only first message section with limited primitives:
 // - TextFields
@@ -20,6 +20,7 @@ type HelpMessage struct {
 	platform               config.CommPlatformIntegration
 	clusterName            string
 	enabledPluginExecutors []string
+	pluginHelp             []PluginHelp
 }
 
 // NewHelpMessage return a new instance of HelpMessage.
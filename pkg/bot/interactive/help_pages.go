@@ -0,0 +1,247 @@
+package interactive
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/maputil"
+)
+
+const (
+	// HelpPageCommand is the command which shows a given page of the help message, e.g. `help page 2`.
+	HelpPageCommand = "help page"
+
+	pluginsPerHelpPage = 3
+)
+
+// PluginHelp holds documentation of an enabled executor plugin. It's used to generate the plugin help section.
+type PluginHelp struct {
+	// Name is the plugin key, e.g. `botkube/kubectl`.
+	Name             string
+	Version          string
+	Description      string
+	DocumentationURL string
+	// ConfigSchema is the JSON schema of the plugin configuration. Its top-level properties are listed as configuration options.
+	ConfigSchema json.RawMessage
+}
+
+// Command returns the command which runs the plugin, e.g. `kubectl` for the `botkube/kubectl` plugin.
+func (p PluginHelp) Command() string {
+	_, name, _, err := config.DecomposePluginKey(p.Name)
+	if err != nil || name == "" {
+		return p.Name
+	}
+	return name
+}
+
+// WithPluginHelp sets documentation of enabled executor plugins. Plugins without the documentation are described only by their names.
+func (h *HelpMessage) WithPluginHelp(in []PluginHelp) *HelpMessage {
+	h.pluginHelp = in
+	return h
+}
+
+// PageCount returns the number of help message pages.
+func (h *HelpMessage) PageCount() int {
+	return 1 + (len(h.plugins())+pluginsPerHelpPage-1)/pluginsPerHelpPage
+}
+
+// BuildPage returns a given page of the help message. The first page describes built-in commands, and the following ones
+// document enabled executor plugins. Pages out of range are replaced with the closest valid page.
+func (h *HelpMessage) BuildPage(page int) CoreMessage {
+	count := h.PageCount()
+	if page < 1 {
+		page = 1
+	}
+	if page > count {
+		page = count
+	}
+
+	var sections []api.Section
+	if page == 1 {
+		sections = append(sections, h.botkubeCloud()...)
+		sections = append(sections, h.aiPlugin()...)
+		sections = append(sections, h.basicCommands()...)
+		sections = append(sections, h.notificationSections()...)
+		sections = append(sections, h.pluginIndex()...)
+		sections = append(sections, h.cluster()...)
+		sections = append(sections, h.advancedFeatures()...)
+	} else {
+		plugins := h.plugins()
+		start := (page - 2) * pluginsPerHelpPage
+		end := min(start+pluginsPerHelpPage, len(plugins))
+		for _, p := range plugins[start:end] {
+			sections = append(sections, h.pluginDocs(p))
+		}
+	}
+	sections = append(sections, h.pagination(page, count)...)
+	sections = append(sections, h.footer()...)
+
+	return CoreMessage{
+		Message: api.Message{
+			Sections: sections,
+		},
+	}
+}
+
+// plugins returns documentation of all enabled executor plugins, sorted by names.
+func (h *HelpMessage) plugins() []PluginHelp {
+	docs := map[string]PluginHelp{}
+	for _, p := range h.pluginHelp {
+		docs[p.Name] = p
+	}
+
+	var out []PluginHelp
+	for _, name := range h.enabledPluginExecutors {
+		p, found := docs[name]
+		if !found {
+			p = PluginHelp{Name: name}
+		}
+		out = append(out, p)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Name < out[j].Name
+	})
+	return out
+}
+
+func (h *HelpMessage) pluginIndex() []api.Section {
+	plugins := h.plugins()
+	if len(plugins) == 0 {
+		return nil
+	}
+
+	var items []string
+	for idx, p := range plugins {
+		items = append(items, fmt.Sprintf("`%s %s` - see page %d", api.MessageBotNamePlaceholder, p.Command(), 2+idx/pluginsPerHelpPage))
+	}
+
+	return []api.Section{
+		{
+			Base: api.Base{
+				Header: "🔌 Plugins",
+			},
+			BulletLists: api.BulletLists{
+				{
+					Title: "Enabled executor plugins",
+					Items: items,
+				},
+			},
+		},
+	}
+}
+
+func (h *HelpMessage) pluginDocs(p PluginHelp) api.Section {
+	header := fmt.Sprintf("🔌 %s", p.Name)
+	if p.Version != "" {
+		header = fmt.Sprintf("%s %s", header, p.Version)
+	}
+
+	desc := fmt.Sprintf("`%s %s help` - show all commands supported by the plugin", api.MessageBotNamePlaceholder, p.Command())
+	if p.Description != "" {
+		desc = p.Description + "\n" + desc
+	}
+
+	section := api.Section{
+		Base: api.Base{
+			Header:      header,
+			Description: desc,
+		},
+		Buttons: api.Buttons{
+			h.btnBuilder.ForCommandWithoutDesc("Try it", fmt.Sprintf("%s help", p.Command()), api.ButtonStylePrimary),
+		},
+	}
+	if p.DocumentationURL != "" {
+		section.Buttons = append(section.Buttons, h.btnBuilder.ForURL("Read docs", p.DocumentationURL))
+	}
+	if opts := configOptions(p.ConfigSchema); len(opts) > 0 {
+		section.BulletLists = api.BulletLists{
+			{
+				Title: "Configuration options",
+				Items: opts,
+			},
+		}
+	}
+	return section
+}
+
+func (h *HelpMessage) pagination(page, count int) []api.Section {
+	if count <= 1 {
+		return nil
+	}
+
+	var btns api.Buttons
+	if page > 1 {
+		btns = append(btns, h.btnBuilder.ForCommandWithoutDesc("Previous", fmt.Sprintf("%s %d", HelpPageCommand, page-1)))
+	}
+	if page < count {
+		btns = append(btns, h.btnBuilder.ForCommandWithoutDesc("Next", fmt.Sprintf("%s %d", HelpPageCommand, page+1), api.ButtonStylePrimary))
+	}
+
+	return []api.Section{
+		{
+			Buttons: btns,
+			Context: api.ContextItems{
+				{Text: fmt.Sprintf("Page %d of %d", page, count)},
+			},
+		},
+	}
+}
+
+type configSchemaProperty struct {
+	Type        any    `json:"type"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Default     any    `json:"default"`
+}
+
+// configOptions describes top-level properties of a given JSON schema, sorted by names. It returns nil if the schema cannot be parsed.
+func configOptions(schema json.RawMessage) []string {
+	if len(schema) == 0 {
+		return nil
+	}
+
+	var parsed struct {
+		Properties map[string]configSchemaProperty `json:"properties"`
+		Required   []string                        `json:"required"`
+	}
+	if err := json.Unmarshal(schema, &parsed); err != nil {
+		return nil
+	}
+
+	var out []string
+	for _, name := range maputil.SortKeys(parsed.Properties) {
+		prop := parsed.Properties[name]
+
+		var attrs []string
+		if prop.Type != nil {
+			attrs = append(attrs, fmt.Sprint(prop.Type))
+		}
+		if slices.Contains(parsed.Required, name) {
+			attrs = append(attrs, "required")
+		}
+
+		item := fmt.Sprintf("`%s`", name)
+		if len(attrs) > 0 {
+			item = fmt.Sprintf("%s (%s)", item, strings.Join(attrs, ", "))
+		}
+		desc := prop.Description
+		if desc == "" {
+			desc = prop.Title
+		}
+		if desc != "" {
+			item = fmt.Sprintf("%s - %s", item, strings.TrimSuffix(desc, "."))
+		}
+		if prop.Default != nil {
+			def, _ := json.Marshal(prop.Default)
+			item = fmt.Sprintf("%s. Defaults to `%s`", item, def)
+		}
+		out = append(out, item)
+	}
+	return out
+}
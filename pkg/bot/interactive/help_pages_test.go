@@ -0,0 +1,111 @@
+package interactive
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+func TestHelpMessageBuildPage(t *testing.T) {
+	// given
+	executors := []string{"botkube/kubectl", "botkube/helm", "botkube/flux", "botkube/exec"}
+	docs := []PluginHelp{
+		{
+			Name:             "botkube/helm",
+			Version:          "v1.0.0",
+			Description:      "Run Helm commands.",
+			DocumentationURL: "https://docs.botkube.io/helm",
+			ConfigSchema: json.RawMessage(`{
+				"type": "object",
+				"properties": {
+					"defaultNamespace": {"type": "string", "description": "Namespace used when not specified.", "default": "default"},
+					"helmDriver": {"type": "string", "title": "Storage driver"},
+					"helmCacheDir": {}
+				},
+				"required": ["helmDriver"]
+			}`),
+		},
+	}
+	help := NewHelpMessage(config.SocketSlackCommPlatformIntegration, "testing", executors).WithPluginHelp(docs)
+
+	// when
+	first := help.BuildPage(1)
+
+	// then
+	assert.Equal(t, 3, help.PageCount())
+
+	index := findSection(t, first.Sections, "🔌 Plugins")
+	assert.Equal(t, []string{
+		"`{{BotName}} exec` - see page 2",
+		"`{{BotName}} flux` - see page 2",
+		"`{{BotName}} helm` - see page 2",
+		"`{{BotName}} kubectl` - see page 3",
+	}, index.BulletLists[0].Items)
+
+	nav := first.Sections[len(first.Sections)-2]
+	assert.Equal(t, "Page 1 of 3", nav.Context[0].Text)
+	require.Len(t, nav.Buttons, 1)
+	assert.Equal(t, "Next", nav.Buttons[0].Name)
+	assert.Equal(t, "{{BotName}} help page 2", nav.Buttons[0].Command)
+
+	// when
+	second := help.BuildPage(2)
+
+	// then
+	helm := findSection(t, second.Sections, "🔌 botkube/helm v1.0.0")
+	assert.Equal(t, "Run Helm commands.\n`{{BotName}} helm help` - show all commands supported by the plugin", helm.Description)
+	assert.Equal(t, []string{
+		"`defaultNamespace` (string) - Namespace used when not specified. Defaults to `\"default\"`",
+		"`helmCacheDir`",
+		"`helmDriver` (string, required) - Storage driver",
+	}, helm.BulletLists[0].Items)
+	require.Len(t, helm.Buttons, 2)
+	assert.Equal(t, "{{BotName}} helm help", helm.Buttons[0].Command)
+	assert.Equal(t, "https://docs.botkube.io/helm", helm.Buttons[1].URL)
+
+	flux := findSection(t, second.Sections, "🔌 botkube/flux")
+	assert.Empty(t, flux.BulletLists)
+	require.Len(t, flux.Buttons, 1)
+	assert.Equal(t, "Try it", flux.Buttons[0].Name)
+
+	// when
+	last := help.BuildPage(42)
+
+	// then
+	findSection(t, last.Sections, "🔌 botkube/kubectl")
+	nav = last.Sections[len(last.Sections)-2]
+	assert.Equal(t, "Page 3 of 3", nav.Context[0].Text)
+	require.Len(t, nav.Buttons, 1)
+	assert.Equal(t, "{{BotName}} help page 2", nav.Buttons[0].Command)
+}
+
+func TestHelpMessageBuildPageWithoutPlugins(t *testing.T) {
+	// given
+	help := NewHelpMessage(config.SocketSlackCommPlatformIntegration, "testing", nil)
+
+	// when
+	msg := help.BuildPage(1)
+
+	// then
+	assert.Equal(t, 1, help.PageCount())
+	for _, section := range msg.Sections {
+		assert.NotEqual(t, "🔌 Plugins", section.Header)
+		assert.Empty(t, section.Context)
+	}
+}
+
+func findSection(t *testing.T, sections []api.Section, header string) api.Section {
+	t.Helper()
+	for _, section := range sections {
+		if section.Header == header {
+			return section
+		}
+	}
+	t.Fatalf("section %q not found", header)
+	return api.Section{}
+}
@@ -10,19 +10,24 @@ import (
 	"regexp"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/gorilla/websocket"
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/sirupsen/logrus"
 	"github.com/sourcegraph/conc/pool"
 
 	"github.com/kubeshop/botkube/internal/health"
+	"github.com/kubeshop/botkube/internal/metrics"
 	"github.com/kubeshop/botkube/pkg/api"
 	"github.com/kubeshop/botkube/pkg/bot/interactive"
 	"github.com/kubeshop/botkube/pkg/config"
 	"github.com/kubeshop/botkube/pkg/execute"
 	"github.com/kubeshop/botkube/pkg/execute/command"
 	"github.com/kubeshop/botkube/pkg/multierror"
+	"github.com/kubeshop/botkube/pkg/notifier"
 	"github.com/kubeshop/botkube/pkg/sliceutil"
+	"github.com/kubeshop/botkube/pkg/tlsx"
 )
 
 // TODO: Refactor this file as a part of https://github.com/kubeshop/botkube/issues/667
@@ -30,6 +35,7 @@ import (
 //    - review all the methods and see if they can be simplified.
 
 var _ Bot = &Mattermost{}
+var _ notifier.ChannelLister = &Mattermost{}
 
 const (
 	// WebSocketProtocol stores protocol initials for web socket
@@ -58,6 +64,7 @@ type Mattermost struct {
 	botUserID         string
 	teamName          string
 	webSocketURL      string
+	wsDialer          *websocket.Dialer
 	wsClient          *model.WebSocketClient
 	apiClient         *model.Client4
 	channelsMutex     sync.RWMutex
@@ -107,6 +114,27 @@ func NewMattermost(ctx context.Context, log logrus.FieldLogger, commGroupMetadat
 	client := model.NewAPIv4Client(mmURL)
 	client.SetOAuthToken(cfg.Token)
 
+	wsDialer := websocket.DefaultDialer
+	if !cfg.TLS.IsZero() {
+		tlsCfg, err := tlsx.NewClientConfig(tlsx.ClientOptions{
+			Name:               "Mattermost",
+			CAFile:             cfg.TLS.CAFile,
+			MinVersion:         cfg.TLS.MinVersion,
+			CipherSuites:       cfg.TLS.CipherSuites,
+			InsecureSkipVerify: cfg.TLS.InsecureSkipVerify,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("while creating Mattermost TLS configuration: %w", err)
+		}
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = tlsCfg
+		client.HTTPClient = &http.Client{Transport: transport}
+
+		dialer := *websocket.DefaultDialer
+		dialer.TLSClientConfig = tlsCfg
+		wsDialer = &dialer
+	}
+
 	// In Mattermost v7.0+, what we see in MM Console is `display_name` of a team.
 	// We need `name` of the team to make the rest of the business logic work.
 	team, err := getMattermostTeam(ctx, client, cfg.Team)
@@ -134,6 +162,7 @@ func NewMattermost(ctx context.Context, log logrus.FieldLogger, commGroupMetadat
 		teamName:          team.Name,
 		apiClient:         client,
 		webSocketURL:      webSocketURL,
+		wsDialer:          wsDialer,
 		commGroupMetadata: commGroupMetadata,
 		channels:          channelsByIDCfg,
 		botMentionRegex:   botMentionRegex,
@@ -149,6 +178,7 @@ func NewMattermost(ctx context.Context, log logrus.FieldLogger, commGroupMetadat
 func (b *Mattermost) startMessageProcessor(ctx context.Context) {
 	b.log.Info("Starting mattermost message processor...")
 	defer b.log.Info("Stopped mattermost message processor...")
+	registerMessageQueue(b.IntegrationName(), b.commGroupMetadata, func() int { return len(b.messages) })
 
 	for msg := range b.messages {
 		b.messageWorkers.Go(func() {
@@ -189,7 +219,7 @@ func (b *Mattermost) Start(ctx context.Context) error {
 			return nil
 		default:
 			var appErr error
-			b.wsClient, appErr = model.NewWebSocketClient4(b.webSocketURL, b.apiClient.AuthToken)
+			b.wsClient, appErr = model.NewWebSocketClient4WithDialer(b.wsDialer, b.webSocketURL, b.apiClient.AuthToken)
 			if appErr != nil {
 				b.setStatusReason(health.FailureReasonConnectionError, fmt.Sprintf("while creating WebSocket connection: %s", appErr.Error()))
 				return fmt.Errorf("while creating WebSocket connection: %w", appErr)
@@ -250,15 +280,6 @@ func (b *Mattermost) handleMessage(ctx context.Context, mm mattermostMessage) er
 		return nil
 	}
 
-	// Handle message only if starts with mention
-	trimmedMsg, found := b.findAndTrimBotMention(post.Message)
-	if !found {
-		b.log.Debugf("Ignoring message as it doesn't contain %q mention", b.botName)
-		return nil
-	}
-	req := trimmedMsg
-	b.log.Debugf("Mattermost incoming Request: %s", req)
-
 	channelID := mm.Event.GetBroadcast().ChannelId
 	channel, exists := b.getChannels()[channelID]
 	if !exists {
@@ -269,6 +290,17 @@ func (b *Mattermost) handleMessage(ctx context.Context, mm mattermostMessage) er
 		}
 	}
 
+	// Handle message only if starts with mention, or matches channel triggers
+	req, found := b.findAndTrimBotMention(post.Message)
+	if !found {
+		req, found = b.triggeredCommand(ctx, channel, post)
+	}
+	if !found {
+		b.log.Debugf("Ignoring message as it doesn't contain %q mention nor matches channel triggers", b.botName)
+		return nil
+	}
+	b.log.Debugf("Mattermost incoming Request: %s", req)
+
 	userName, err := b.getUserName(ctx, post.UserId)
 	if err != nil {
 		b.log.Errorf("while getting user name: %s", err.Error())
@@ -287,12 +319,14 @@ func (b *Mattermost) handleMessage(ctx context.Context, mm mattermostMessage) er
 			ID:               channel.Identifier(),
 			ExecutorBindings: channel.Bindings.Executors,
 			SourceBindings:   channel.Bindings.Sources,
+			ReadOnly:         channel.ReadOnly,
 			IsKnown:          exists,
 			CommandOrigin:    command.TypedOrigin,
 		},
 		User: execute.UserInput{
 			//Mention:     "", // not used currently
 			DisplayName: userName,
+			ID:          post.UserId,
 		},
 		Message: req,
 	})
@@ -305,8 +339,30 @@ func (b *Mattermost) handleMessage(ctx context.Context, mm mattermostMessage) er
 	return nil
 }
 
+// triggeredCommand returns a command triggered without mentioning the bot, i.e. with the channel prefix or a reply to a bot message.
+func (b *Mattermost) triggeredCommand(ctx context.Context, channel channelConfigByID, post *model.Post) (string, bool) {
+	if cmd, found := trimCommandPrefix(channel.Triggers, post.Message); found {
+		return cmd, true
+	}
+
+	if post.RootId == "" || !channel.Triggers.Replies {
+		return "", false
+	}
+	root, _, err := b.apiClient.GetPost(ctx, post.RootId, "")
+	if err != nil {
+		b.log.WithError(err).Error("Cannot get thread root post")
+		return "", false
+	}
+	if root.UserId != b.botUserID {
+		return "", false
+	}
+	cmd := strings.TrimSpace(post.Message)
+	return cmd, cmd != ""
+}
+
 // Send messages to Mattermost
-func (b *Mattermost) send(ctx context.Context, channelID string, resp interactive.CoreMessage) error {
+func (b *Mattermost) send(ctx context.Context, channelID string, resp interactive.CoreMessage) (err error) {
+	defer metrics.ObserveMessageSend(b.IntegrationName(), channelID, time.Now(), &err)
 	b.log.Debugf("Sending message to channel %q: %+v", channelID, resp)
 
 	resp.ReplaceBotNamePlaceholder(b.BotName())
@@ -371,6 +427,18 @@ func (b *Mattermost) formatMessage(ctx context.Context, msg interactive.CoreMess
 }
 
 // Check if Mattermost server is reachable
+// StartNotifications starts the bot without the WebSocket connection, as notifications are sent with the REST API.
+func (b *Mattermost) StartNotifications(ctx context.Context) error {
+	b.log.Info("Starting bot without handling commands")
+	if err := b.checkServerConnection(ctx); err != nil {
+		b.setStatusReason(health.FailureReasonConnectionError, fmt.Sprintf("while pinging Mattermost server %q: %s", b.serverURL, err.Error()))
+		return fmt.Errorf("while pinging Mattermost server %q: %w", b.serverURL, err)
+	}
+	b.setStatusReason("", "")
+	<-ctx.Done()
+	return nil
+}
+
 func (b *Mattermost) checkServerConnection(ctx context.Context) error {
 	// Check api connection
 	if _, _, err := b.apiClient.GetOldClientConfig(ctx, ""); err != nil {
@@ -421,6 +489,24 @@ func (b *Mattermost) listen(ctx context.Context) {
 	}
 }
 
+// NotificationChannels returns Mattermost channels which have notifications enabled.
+func (b *Mattermost) NotificationChannels() []notifier.NotificationChannel {
+	var out []notifier.NotificationChannel
+	for _, cfg := range b.getChannels() {
+		if !cfg.notify {
+			continue
+		}
+		out = append(out, notifier.NotificationChannel{
+			Name:     cfg.name,
+			Alias:    cfg.alias,
+			Sources:  cfg.Bindings.Sources,
+			Filters:  cfg.Bindings.Filters,
+			Settings: cfg.Notification.NotificationSettings,
+		})
+	}
+	return out
+}
+
 func (b *Mattermost) getChannelsToNotify(eventSources []string) []string {
 	var out []string
 	for _, cfg := range b.getChannels() {
@@ -436,10 +522,31 @@ func (b *Mattermost) getChannelsToNotify(eventSources []string) []string {
 	return out
 }
 
+// getChannelsToRoute returns channels with given aliases or names which have notifications enabled.
+func (b *Mattermost) getChannelsToRoute(channels []string) []string {
+	var out []string
+	for _, cfg := range b.getChannels() {
+		if !cfg.notify || !isChannelSelected(cfg.alias, cfg.name, channels) {
+			continue
+		}
+		out = append(out, cfg.Identifier())
+	}
+	return out
+}
+
 // SendMessage sends message to selected Mattermost channels.
 func (b *Mattermost) SendMessage(ctx context.Context, msg interactive.CoreMessage, sourceBindings []string) error {
+	return b.sendToChannels(ctx, msg, b.getChannelsToNotify(sourceBindings))
+}
+
+// SendMessageToChannels sends message to given Mattermost channels.
+func (b *Mattermost) SendMessageToChannels(ctx context.Context, msg interactive.CoreMessage, channels []string) error {
+	return b.sendToChannels(ctx, msg, b.getChannelsToRoute(channels))
+}
+
+func (b *Mattermost) sendToChannels(ctx context.Context, msg interactive.CoreMessage, channelIDs []string) error {
 	errs := multierror.New()
-	for _, channelID := range b.getChannelsToNotify(sourceBindings) {
+	for _, channelID := range channelIDs {
 		err := b.send(ctx, channelID, msg)
 		if err != nil {
 			errs = multierror.Append(errs, fmt.Errorf("while sending Mattermost message to channel %q: %w", channelID, err))
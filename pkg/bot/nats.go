@@ -0,0 +1,275 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/sirupsen/logrus"
+	"github.com/sourcegraph/conc/pool"
+
+	"github.com/kubeshop/botkube/internal/health"
+	natsx "github.com/kubeshop/botkube/internal/nats"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/execute"
+	"github.com/kubeshop/botkube/pkg/execute/command"
+)
+
+var _ Bot = &NATS{}
+
+const (
+	// natsUserHeader is the optional message header which identifies the user who triggered the command, e.g. a service name.
+	natsUserHeader    = "Botkube-User"
+	natsDefaultUser   = "NATS"
+	natsReconnectWait = 5 * time.Second
+)
+
+// natsConn is a connection used to receive commands and send back their output.
+type natsConn interface {
+	QueueSubscribe(subject, queue string, handler nats.MsgHandler) (*nats.Subscription, error)
+	PublishMsg(msg *nats.Msg) error
+	LastError() error
+	Close()
+}
+
+// NATS receives commands on a NATS subject, executes them, and sends the output to the reply subject of a given message.
+// It doesn't send notifications, as events are published by the NATS sink.
+type NATS struct {
+	log               logrus.FieldLogger
+	executorFactory   ExecutorFactory
+	reporter          AnalyticsReporter
+	commGroupMetadata CommGroupMetadata
+	cfg               config.NATSCommands
+	connect           func(opts ...nats.Option) (natsConn, error)
+	workers           *pool.Pool
+
+	statusMux     sync.RWMutex
+	status        health.PlatformStatusMsg
+	failureReason health.FailureReasonMsg
+	errorMsg      string
+}
+
+// NewNATS creates a new NATS instance.
+func NewNATS(log logrus.FieldLogger, commGroupMetadata CommGroupMetadata, cfg config.NATS, executorFactory ExecutorFactory, reporter AnalyticsReporter) (*NATS, error) {
+	connCfg, err := natsx.NewConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return newNATS(log, commGroupMetadata, cfg.Commands, executorFactory, reporter, func(opts ...nats.Option) (natsConn, error) {
+		return natsx.Connect(connCfg, opts...)
+	}), nil
+}
+
+func newNATS(log logrus.FieldLogger, commGroupMetadata CommGroupMetadata, cfg config.NATSCommands, executorFactory ExecutorFactory, reporter AnalyticsReporter, connect func(opts ...nats.Option) (natsConn, error)) *NATS {
+	return &NATS{
+		log:               log,
+		executorFactory:   executorFactory,
+		reporter:          reporter,
+		commGroupMetadata: commGroupMetadata,
+		cfg:               cfg,
+		connect:           connect,
+		workers:           pool.New().WithMaxGoroutines(platformMessageWorkersCount),
+		status:            health.StatusUnknown,
+	}
+}
+
+// Start subscribes to the command subject. Lost connections are re-established by the NATS client.
+// Once the client gives up and closes the connection, Start connects again until the context is cancelled.
+func (b *NATS) Start(ctx context.Context) error {
+	b.log.Info("Starting bot")
+	defer b.workers.Wait()
+
+	reported := false
+	for {
+		err := b.listen(ctx, func() {
+			if reported {
+				return
+			}
+			reported = true
+			if err := b.reporter.ReportBotEnabled(b.IntegrationName(), b.commGroupMetadata.Index); err != nil {
+				b.log.Errorf("report analytics error: %s", err.Error())
+			}
+		})
+		if ctx.Err() != nil {
+			b.log.Info("Shutdown requested. Finishing...")
+			return nil
+		}
+
+		b.log.WithError(err).Errorf("NATS connection closed. Reconnecting in %s...", natsReconnectWait)
+		b.setFailureReason(health.FailureReasonConnectionError, err.Error())
+		select {
+		case <-ctx.Done():
+			b.log.Info("Shutdown requested. Finishing...")
+			return nil
+		case <-time.After(natsReconnectWait):
+		}
+	}
+}
+
+// listen handles commands until the connection is closed, or the context is cancelled.
+func (b *NATS) listen(ctx context.Context, onConnected func()) error {
+	closed := make(chan struct{})
+	conn, err := b.connect(
+		nats.ClosedHandler(func(*nats.Conn) {
+			close(closed)
+		}),
+		nats.DisconnectErrHandler(func(_ *nats.Conn, err error) {
+			if err == nil {
+				return
+			}
+			b.log.WithError(err).Error("NATS connection lost. Reconnecting...")
+			b.setFailureReason(health.FailureReasonConnectionError, err.Error())
+		}),
+		nats.ReconnectHandler(func(*nats.Conn) {
+			b.log.Info("Botkube reconnected to NATS.")
+			b.setFailureReason("", "")
+		}),
+	)
+	if err != nil {
+		return fmt.Errorf("while connecting: %w", err)
+	}
+	defer conn.Close()
+
+	_, err = conn.QueueSubscribe(b.cfg.Subject, b.cfg.QueueGroup, func(msg *nats.Msg) {
+		if ctx.Err() != nil {
+			return
+		}
+		b.workers.Go(func() {
+			if err := b.handleMessage(ctx, conn, msg); err != nil {
+				b.log.WithError(err).Error("Failed to handle NATS message")
+			}
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("while subscribing to subject %q: %w", b.cfg.Subject, err)
+	}
+
+	b.log.Infof("Botkube connected to NATS! Listening for commands on subject %q.", b.cfg.Subject)
+	b.setFailureReason("", "")
+	onConnected()
+
+	select {
+	case <-ctx.Done():
+		return nil
+	case <-closed:
+		if err := conn.LastError(); err != nil {
+			return err
+		}
+		return nats.ErrConnectionClosed
+	}
+}
+
+func (b *NATS) handleMessage(ctx context.Context, conn natsConn, msg *nats.Msg) error {
+	req := strings.TrimSpace(string(msg.Data))
+	if req == "" {
+		b.log.Debug("Ignoring empty NATS message")
+		return nil
+	}
+	b.log.Debugf("NATS incoming Request: %s", req)
+
+	user := msg.Header.Get(natsUserHeader)
+	if user == "" {
+		user = natsDefaultUser
+	}
+
+	e := b.executorFactory.NewDefault(execute.NewDefaultInput{
+		CommGroupName:   b.commGroupMetadata.Name,
+		Platform:        b.IntegrationName(),
+		NotifierHandler: b,
+		Conversation: execute.Conversation{
+			Alias:            b.cfg.Subject,
+			DisplayName:      b.cfg.Subject,
+			ID:               b.cfg.Subject,
+			ExecutorBindings: b.cfg.Bindings.Executors,
+			IsKnown:          true,
+			CommandOrigin:    command.TypedOrigin,
+		},
+		Message: req,
+		User: execute.UserInput{
+			Mention:     user,
+			DisplayName: user,
+		},
+	})
+	response := e.Execute(ctx)
+
+	if msg.Reply == "" {
+		b.log.Debug("Skipping command output, as the NATS message doesn't have the reply subject")
+		return nil
+	}
+
+	response.ReplaceBotNamePlaceholder(b.BotName())
+	err := conn.PublishMsg(&nats.Msg{
+		Subject: msg.Reply,
+		Data:    []byte(interactive.MessageToPlaintext(response, interactive.NewlineFormatter)),
+	})
+	if err != nil {
+		return fmt.Errorf("while sending command output: %w", err)
+	}
+	return nil
+}
+
+// SendMessage is no-op, as events are published by the NATS sink.
+func (b *NATS) SendMessage(context.Context, interactive.CoreMessage, []string) error {
+	return nil
+}
+
+// SendMessageToAll is no-op, as there are no channels to send the message to.
+func (b *NATS) SendMessageToAll(context.Context, interactive.CoreMessage) error {
+	return nil
+}
+
+// NotificationsEnabled returns false, as notifications can't be configured for the command subject.
+func (b *NATS) NotificationsEnabled(string) bool {
+	return false
+}
+
+// SetNotificationsEnabled returns an error, as notifications can't be configured for the command subject.
+func (b *NATS) SetNotificationsEnabled(string, bool) error {
+	return execute.ErrNotificationsNotConfigured
+}
+
+// BotName returns the Bot name.
+func (b *NATS) BotName() string {
+	return "@Botkube"
+}
+
+// IntegrationName describes the integration name.
+func (b *NATS) IntegrationName() config.CommPlatformIntegration {
+	return config.NATSCommandsCommPlatformIntegration
+}
+
+// Type describes the integration type.
+func (b *NATS) Type() config.IntegrationType {
+	return config.BotIntegrationType
+}
+
+// GetStatus gets bot status.
+func (b *NATS) GetStatus() health.PlatformStatus {
+	b.statusMux.RLock()
+	defer b.statusMux.RUnlock()
+
+	return health.PlatformStatus{
+		Status:   b.status,
+		Restarts: "0/0",
+		Reason:   b.failureReason,
+		ErrorMsg: b.errorMsg,
+	}
+}
+
+func (b *NATS) setFailureReason(reason health.FailureReasonMsg, errorMsg string) {
+	b.statusMux.Lock()
+	defer b.statusMux.Unlock()
+
+	if reason == "" {
+		b.status = health.StatusHealthy
+	} else {
+		b.status = health.StatusUnHealthy
+	}
+	b.failureReason = reason
+	b.errorMsg = errorMsg
+}
@@ -0,0 +1,154 @@
+package bot
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/internal/health"
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/execute"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+type fakeNATSConn struct {
+	mu        sync.Mutex
+	subject   string
+	queue     string
+	handler   nats.MsgHandler
+	published []*nats.Msg
+}
+
+func (f *fakeNATSConn) QueueSubscribe(subject, queue string, handler nats.MsgHandler) (*nats.Subscription, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.subject, f.queue, f.handler = subject, queue, handler
+	return nil, nil
+}
+
+func (f *fakeNATSConn) PublishMsg(msg *nats.Msg) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.published = append(f.published, msg)
+	return nil
+}
+
+func (f *fakeNATSConn) LastError() error {
+	return nil
+}
+
+func (f *fakeNATSConn) Close() {}
+
+func (f *fakeNATSConn) getHandler() nats.MsgHandler {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.handler
+}
+
+func (f *fakeNATSConn) getPublished() []*nats.Msg {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*nats.Msg(nil), f.published...)
+}
+
+type fakeExecutorFactory struct {
+	mu     sync.Mutex
+	inputs []execute.NewDefaultInput
+}
+
+func (f *fakeExecutorFactory) NewDefault(cfg execute.NewDefaultInput) execute.Executor {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.inputs = append(f.inputs, cfg)
+	return &fakeExecutor{msg: cfg.Message}
+}
+
+type fakeExecutor struct {
+	msg string
+}
+
+func (f *fakeExecutor) Execute(context.Context) interactive.CoreMessage {
+	return interactive.CoreMessage{
+		Message: api.Message{
+			BaseBody: api.Body{
+				Plaintext: "executed: " + f.msg,
+			},
+		},
+	}
+}
+
+type fakeBotReporter struct{}
+
+func (fakeBotReporter) ReportBotEnabled(config.CommPlatformIntegration, int) error {
+	return nil
+}
+
+func TestNATS_Start(t *testing.T) {
+	// given
+	conn := &fakeNATSConn{}
+	executorFactory := &fakeExecutorFactory{}
+	bot := newNATS(loggerx.NewNoop(), CommGroupMetadata{Name: "default", Index: 1}, config.NATSCommands{
+		Enabled:    true,
+		Subject:    "botkube.commands.labs",
+		QueueGroup: "botkube",
+		Bindings:   config.NATSCommandBindings{Executors: []string{"k8s-tools"}},
+	}, executorFactory, fakeBotReporter{}, func(...nats.Option) (natsConn, error) {
+		return conn, nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stopped := make(chan error)
+	go func() {
+		stopped <- bot.Start(ctx)
+	}()
+	require.Eventually(t, func() bool {
+		return conn.getHandler() != nil
+	}, time.Second, 10*time.Millisecond)
+
+	// when
+	handler := conn.getHandler()
+	handler(&nats.Msg{Subject: "botkube.commands.labs", Reply: "_INBOX.1", Header: nats.Header{"Botkube-User": []string{"ci"}}, Data: []byte(" kubectl get pods\n")})
+	handler(&nats.Msg{Subject: "botkube.commands.labs", Data: []byte("kubectl get nodes")})
+	handler(&nats.Msg{Subject: "botkube.commands.labs", Reply: "_INBOX.2", Data: []byte("  ")})
+
+	require.Eventually(t, func() bool {
+		executorFactory.mu.Lock()
+		defer executorFactory.mu.Unlock()
+		return len(executorFactory.inputs) == 2
+	}, time.Second, 10*time.Millisecond)
+	cancel()
+
+	// then
+	select {
+	case err := <-stopped:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("bot not stopped")
+	}
+
+	assert.Equal(t, "botkube.commands.labs", conn.subject)
+	assert.Equal(t, "botkube", conn.queue)
+	assert.Equal(t, []*nats.Msg{{Subject: "_INBOX.1", Data: []byte("executed: kubectl get pods\n")}}, conn.getPublished())
+	assert.Equal(t, health.StatusHealthy, bot.GetStatus().Status)
+
+	var first execute.NewDefaultInput
+	for _, in := range executorFactory.inputs {
+		if in.Message == "kubectl get pods" {
+			first = in
+		}
+	}
+	assert.Equal(t, config.NATSCommandsCommPlatformIntegration, first.Platform)
+	assert.Equal(t, "default", first.CommGroupName)
+	assert.Equal(t, []string{"k8s-tools"}, first.Conversation.ExecutorBindings)
+	assert.Equal(t, "botkube.commands.labs", first.Conversation.ID)
+	assert.True(t, first.Conversation.IsKnown)
+	assert.Equal(t, execute.UserInput{Mention: "ci", DisplayName: "ci"}, first.User)
+}
@@ -0,0 +1,107 @@
+package bot
+
+import (
+	"encoding/json"
+	"fmt"
+
+	cards "github.com/DanielTitkov/go-adaptive-cards"
+	"github.com/bwmarrin/discordgo"
+	"github.com/slack-go/slack"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+)
+
+// PreviewPlatform is a communication platform a message preview is rendered for.
+type PreviewPlatform string
+
+const (
+	// SlackPreviewPlatform renders Slack Block Kit payloads.
+	SlackPreviewPlatform PreviewPlatform = "slack"
+	// TeamsPreviewPlatform renders Adaptive Card payloads.
+	TeamsPreviewPlatform PreviewPlatform = "teams"
+	// DiscordPreviewPlatform renders Discord message payloads.
+	DiscordPreviewPlatform PreviewPlatform = "discord"
+)
+
+// AllPreviewPlatforms returns all platforms a message preview can be rendered for.
+func AllPreviewPlatforms() []PreviewPlatform {
+	return []PreviewPlatform{SlackPreviewPlatform, TeamsPreviewPlatform, DiscordPreviewPlatform}
+}
+
+// SlackPreview is the Slack payload of a message, as sent with the `chat.postMessage` API.
+type SlackPreview struct {
+	Text   string          `json:"text,omitempty"`
+	Blocks json.RawMessage `json:"blocks,omitempty"`
+}
+
+// Preview holds payloads of a single message rendered for communication platforms.
+type Preview struct {
+	Slack   *SlackPreview          `json:"slack,omitempty"`
+	Teams   *cards.Card            `json:"teams,omitempty"`
+	Discord *discordgo.MessageSend `json:"discord,omitempty"`
+}
+
+// RenderPreview renders a given message for given platforms with the same renderers bots use, so plugin authors can iterate
+// on message layouts without redeploying a plugin. The bot name placeholder is replaced with a given bot name.
+// Adaptive Cards are rendered only for non-interactive single section messages. Other messages are sent to MS Teams as Markdown,
+// so their card consists of a single text block.
+func RenderPreview(msg api.Message, botName string, platforms ...PreviewPlatform) (Preview, error) {
+	msg.ReplaceBotNamePlaceholder(botName)
+	coreMsg := interactive.CoreMessage{Message: msg}
+
+	var (
+		out Preview
+		err error
+	)
+	for _, platform := range platforms {
+		switch platform {
+		case SlackPreviewPlatform:
+			out.Slack, err = renderSlackPreview(coreMsg)
+		case TeamsPreviewPlatform:
+			out.Teams, err = renderTeamsPreview(coreMsg)
+		case DiscordPreviewPlatform:
+			out.Discord, err = NewDiscordRenderer().RenderMessage(coreMsg)
+		default:
+			err = fmt.Errorf("unknown platform %q", platform)
+		}
+		if err != nil {
+			return Preview{}, fmt.Errorf("while rendering %s preview: %w", platform, err)
+		}
+	}
+	return out, nil
+}
+
+func renderSlackPreview(msg interactive.CoreMessage) (*SlackPreview, error) {
+	// options are applied the same way the Slack client does, so the payload is identical to the sent one
+	_, values, err := slack.UnsafeApplyMsgOptions("", "", "", NewSlackRenderer().RenderInteractiveMessage(msg))
+	if err != nil {
+		return nil, err
+	}
+
+	out := &SlackPreview{Text: values.Get("text")}
+	if blocks := values.Get("blocks"); blocks != "" {
+		out.Blocks = json.RawMessage(blocks)
+	}
+	return out, nil
+}
+
+func renderTeamsPreview(msg interactive.CoreMessage) (*cards.Card, error) {
+	renderer := NewTeamsRenderer()
+	if msg.Type == api.NonInteractiveSingleSection {
+		return renderer.NonInteractiveSectionToCard(msg)
+	}
+
+	card := cards.New([]cards.Node{
+		&cards.TextBlock{
+			Text: renderer.MessageToMarkdown(msg),
+			Wrap: cards.TruePtr(),
+		},
+	}, []cards.Node{}).
+		WithSchema(cards.DefaultSchema).
+		WithVersion(cards.Version12)
+	if err := card.Prepare(); err != nil {
+		return nil, fmt.Errorf("while preparing card: %w", err)
+	}
+	return card, nil
+}
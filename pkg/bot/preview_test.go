@@ -0,0 +1,66 @@
+package bot
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/api"
+)
+
+func TestRenderPreview(t *testing.T) {
+	// given
+	msg := api.Message{
+		Sections: []api.Section{
+			{
+				Base: api.Base{
+					Header: "Pod restarted",
+				},
+				Buttons: api.Buttons{
+					api.NewMessageButtonBuilder().ForCommandWithoutDesc("Logs", "kubectl logs api-1"),
+				},
+			},
+		},
+	}
+
+	// when
+	preview, err := RenderPreview(msg, "@Botkube", AllPreviewPlatforms()...)
+
+	// then
+	require.NoError(t, err)
+
+	require.NotNil(t, preview.Slack)
+	var blocks []map[string]any
+	require.NoError(t, json.Unmarshal(preview.Slack.Blocks, &blocks))
+	require.Len(t, blocks, 2)
+	assert.Equal(t, "actions", blocks[1]["type"])
+	assert.Contains(t, string(preview.Slack.Blocks), `"value":"@Botkube kubectl logs api-1"`)
+
+	require.NotNil(t, preview.Teams)
+	require.Len(t, preview.Teams.Body, 1)
+
+	require.NotNil(t, preview.Discord)
+	assert.Contains(t, preview.Discord.Content, "@Botkube kubectl logs api-1")
+}
+
+func TestRenderPreviewSelectedPlatforms(t *testing.T) {
+	// given
+	msg := api.NewPlaintextMessage("Hello", false)
+
+	// when
+	preview, err := RenderPreview(msg, "@Botkube", SlackPreviewPlatform)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, &SlackPreview{Text: "Hello"}, preview.Slack)
+	assert.Nil(t, preview.Teams)
+	assert.Nil(t, preview.Discord)
+
+	// when
+	_, err = RenderPreview(msg, "@Botkube", "mattermost")
+
+	// then
+	assert.EqualError(t, err, `while rendering mattermost preview: unknown platform "mattermost"`)
+}
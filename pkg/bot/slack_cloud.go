@@ -24,6 +24,7 @@ import (
 	"github.com/kubeshop/botkube/internal/analytics"
 	"github.com/kubeshop/botkube/internal/config/remote"
 	"github.com/kubeshop/botkube/internal/health"
+	"github.com/kubeshop/botkube/internal/metrics"
 	"github.com/kubeshop/botkube/pkg/api"
 	"github.com/kubeshop/botkube/pkg/api/cloudplatform"
 	pb "github.com/kubeshop/botkube/pkg/api/cloudslack"
@@ -35,6 +36,7 @@ import (
 	"github.com/kubeshop/botkube/pkg/formatx"
 	"github.com/kubeshop/botkube/pkg/grpcx"
 	"github.com/kubeshop/botkube/pkg/multierror"
+	"github.com/kubeshop/botkube/pkg/notifier"
 	"github.com/kubeshop/botkube/pkg/sliceutil"
 )
 
@@ -46,6 +48,7 @@ const (
 )
 
 var _ Bot = &CloudSlack{}
+var _ notifier.ChannelLister = &CloudSlack{}
 
 // CloudSlack listens for user's message, execute commands and sends back the response.
 type CloudSlack struct {
@@ -56,19 +59,21 @@ type CloudSlack struct {
 	reporter          AnalyticsCommandReporter
 	commGroupMetadata CommGroupMetadata
 	realNamesForID    map[string]string
-	botMentionRegex   *regexp.Regexp
-	botID             string
-	channelsMutex     sync.RWMutex
-	renderer          *SlackRenderer
-	channels          map[string]channelConfigByName
-	notifyMutex       sync.Mutex
-	clusterName       string
-	msgStatusTracker  *SlackMessageStatusTracker
-	status            health.PlatformStatusMsg
-	failuresNo        int
-	failureReason     health.FailureReasonMsg
-	errorMsg          string
-	reportOnce        sync.Once
+	// emailsForID is filled together with real names. Emails are available only with the `users:read.email` scope.
+	emailsForID      map[string]string
+	botMentionRegex  *regexp.Regexp
+	botID            string
+	channelsMutex    sync.RWMutex
+	renderer         *SlackRenderer
+	channels         map[string]channelConfigByName
+	notifyMutex      sync.Mutex
+	clusterName      string
+	msgStatusTracker *SlackMessageStatusTracker
+	status           health.PlatformStatusMsg
+	failuresNo       int
+	failureReason    health.FailureReasonMsg
+	errorMsg         string
+	reportOnce       sync.Once
 }
 
 func NewCloudSlack(log logrus.FieldLogger,
@@ -107,6 +112,7 @@ func NewCloudSlack(log logrus.FieldLogger,
 		botID:             cfg.BotID,
 		clusterName:       clusterName,
 		realNamesForID:    map[string]string{},
+		emailsForID:       map[string]string{},
 		msgStatusTracker:  NewSlackMessageStatusTracker(log, client),
 		status:            health.StatusUnknown,
 		failuresNo:        0,
@@ -161,6 +167,14 @@ func (b *CloudSlack) withRetries(ctx context.Context, log logrus.FieldLogger, ma
 	)
 }
 
+// StartNotifications starts the bot without the Cloud Slack stream, as notifications are sent with the Slack Web API.
+func (b *CloudSlack) StartNotifications(ctx context.Context) error {
+	b.log.Info("Starting bot without handling commands")
+	b.setFailureReason("", "")
+	<-ctx.Done()
+	return nil
+}
+
 func (b *CloudSlack) start(ctx context.Context) error {
 	messageWorkers := pool.New().WithMaxGoroutines(platformMessageWorkersCount)
 	messages := make(chan *pb.ConnectResponse, platformMessageChannelSize)
@@ -250,6 +264,7 @@ func (b *CloudSlack) start(ctx context.Context) error {
 func (b *CloudSlack) startMessageProcessor(ctx context.Context, messageWorkers *pool.Pool, messages chan *pb.ConnectResponse) {
 	b.log.Info("Starting cloud slack message processor...")
 	defer b.log.Info("Stopped cloud slack message processor...")
+	registerMessageQueue(b.IntegrationName(), b.commGroupMetadata, func() int { return len(messages) })
 
 	for msg := range messages {
 		messageWorkers.Go(func() {
@@ -416,8 +431,17 @@ func (b *CloudSlack) handleStreamMessage(ctx context.Context, data *pb.ConnectRe
 }
 
 func (b *CloudSlack) SendMessage(ctx context.Context, msg interactive.CoreMessage, sourceBindings []string) error {
+	return b.sendToChannels(ctx, msg, b.getChannelsToNotify(sourceBindings))
+}
+
+// SendMessageToChannels sends message with interactive sections to given Slack channels.
+func (b *CloudSlack) SendMessageToChannels(ctx context.Context, msg interactive.CoreMessage, channels []string) error {
+	return b.sendToChannels(ctx, msg, b.getChannelsToRoute(channels))
+}
+
+func (b *CloudSlack) sendToChannels(ctx context.Context, msg interactive.CoreMessage, channelNames []string) error {
 	errs := multierror.New()
-	for _, channelName := range b.getChannelsToNotify(sourceBindings) {
+	for _, channelName := range channelNames {
 		msgMetadata := slackMessage{
 			Channel: channelName,
 			BlockID: uuid.New().String(),
@@ -470,6 +494,9 @@ func (b *CloudSlack) getRealNameWithFallbackToUserID(ctx context.Context, userID
 		return userID
 	}
 
+	if user != nil && user.Profile.Email != "" {
+		b.emailsForID[userID] = user.Profile.Email
+	}
 	if user == nil || user.RealName == "" {
 		return userID
 	}
@@ -512,6 +539,7 @@ func (b *CloudSlack) handleMessage(ctx context.Context, event slackMessage) erro
 			DisplayName:      info.Name,
 			ExecutorBindings: channel.Bindings.Executors,
 			SourceBindings:   channel.Bindings.Sources,
+			ReadOnly:         channel.ReadOnly,
 			IsKnown:          exists,
 			CommandOrigin:    event.CommandOrigin,
 			SlackState:       event.State,
@@ -521,6 +549,8 @@ func (b *CloudSlack) handleMessage(ctx context.Context, event slackMessage) erro
 		User: execute.UserInput{
 			Mention:     fmt.Sprintf("<@%s>", event.UserID),
 			DisplayName: event.UserName,
+			ID:          event.UserID,
+			Email:       b.emailsForID[event.UserID],
 		},
 	})
 
@@ -538,7 +568,8 @@ func (b *CloudSlack) handleMessage(ctx context.Context, event slackMessage) erro
 	return nil
 }
 
-func (b *CloudSlack) send(ctx context.Context, event slackMessage, resp interactive.CoreMessage) error {
+func (b *CloudSlack) send(ctx context.Context, event slackMessage, resp interactive.CoreMessage) (err error) {
+	defer metrics.ObserveMessageSend(b.IntegrationName(), event.Channel, time.Now(), &err)
 	b.log.Debugf("Sending message to channel %q: %+v", event.Channel, resp)
 
 	if resp.IsEmpty() { // don't send empty messages
@@ -554,7 +585,6 @@ func (b *CloudSlack) send(ctx context.Context, event slackMessage, resp interact
 
 	// Upload message as a file if too long
 	var file *slack.File
-	var err error
 	if len(markdown) >= slackMaxMessageSize {
 		file, err = b.uploadFileToSlack(ctx, event, resp)
 		if err != nil {
@@ -708,6 +738,24 @@ func (b *CloudSlack) setChannels(channels map[string]channelConfigByName) {
 	b.channels = channels
 }
 
+// NotificationChannels returns Slack channels which have notifications enabled.
+func (b *CloudSlack) NotificationChannels() []notifier.NotificationChannel {
+	var out []notifier.NotificationChannel
+	for _, cfg := range b.getChannels() {
+		if !cfg.notify {
+			continue
+		}
+		out = append(out, notifier.NotificationChannel{
+			Name:     cfg.Identifier(),
+			Alias:    cfg.alias,
+			Sources:  cfg.Bindings.Sources,
+			Filters:  cfg.Bindings.Filters,
+			Settings: cfg.Notification.NotificationSettings,
+		})
+	}
+	return out
+}
+
 func (b *CloudSlack) getChannelsToNotify(sourceBindings []string) []string {
 	var out []string
 	for _, cfg := range b.getChannels() {
@@ -725,6 +773,18 @@ func (b *CloudSlack) getChannelsToNotify(sourceBindings []string) []string {
 	return out
 }
 
+// getChannelsToRoute returns channels with given aliases or names which have notifications enabled.
+func (b *CloudSlack) getChannelsToRoute(channels []string) []string {
+	var out []string
+	for _, cfg := range b.getChannels() {
+		if !cfg.notify || !isChannelSelected(cfg.alias, cfg.Identifier(), channels) {
+			continue
+		}
+		out = append(out, cfg.Identifier())
+	}
+	return out
+}
+
 func (b *CloudSlack) checkStreamingError(data []byte) error {
 	if len(data) == 0 {
 		return nil
@@ -340,6 +340,8 @@ func (b *SlackRenderer) renderInput(s api.LabelInput) slack.Block {
 	}
 
 	input := slack.NewPlainTextInputBlockElement(placeholder, s.Command)
+	input.Multiline = s.Multiline
+	input.InitialValue = s.InitialValue
 	block := slack.NewInputBlock(s.Command, label, nil, input)
 
 	if s.DispatchedAction != "" {
@@ -68,6 +68,8 @@ type slackMessage struct {
 	BlockID              string
 	EventTimeStamp       string
 	RootMessageTimeStamp string
+	// Reaction is the name of the emoji added to the message with the EventTimeStamp. It's empty for other events.
+	Reaction string
 }
 
 // GetTimestamp returns the timestamp for the response message.
@@ -7,6 +7,7 @@ import (
 	"regexp"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
@@ -17,6 +18,7 @@ import (
 
 	"github.com/kubeshop/botkube/internal/analytics"
 	"github.com/kubeshop/botkube/internal/health"
+	"github.com/kubeshop/botkube/internal/metrics"
 	"github.com/kubeshop/botkube/pkg/api"
 	"github.com/kubeshop/botkube/pkg/bot/interactive"
 	"github.com/kubeshop/botkube/pkg/config"
@@ -24,6 +26,7 @@ import (
 	"github.com/kubeshop/botkube/pkg/execute/command"
 	"github.com/kubeshop/botkube/pkg/formatx"
 	"github.com/kubeshop/botkube/pkg/multierror"
+	"github.com/kubeshop/botkube/pkg/notifier"
 	"github.com/kubeshop/botkube/pkg/sliceutil"
 )
 
@@ -40,14 +43,21 @@ import (
 // source: https://api.slack.com/reference/block-kit/blocks#section
 const (
 	slackMaxMessageSize = 3001
+
+	// slackMaxOptionLength is the maximum length of text and value of select options.
+	slackMaxOptionLength = 75
 )
 
 var _ Bot = &SocketSlack{}
+var _ notifier.ChannelLister = &SocketSlack{}
+var _ execute.InProgressMessageSender = &SocketSlack{}
+var _ execute.MessageUpdater = &SocketSlack{}
 
 // SocketSlack listens for user's message, execute commands and sends back the response.
 type SocketSlack struct {
 	log               logrus.FieldLogger
 	executorFactory   ExecutorFactory
+	autocompleter     Autocompleter
 	reporter          socketSlackAnalyticsReporter
 	botID             string
 	client            *slack.Client
@@ -58,13 +68,15 @@ type SocketSlack struct {
 	commGroupMetadata CommGroupMetadata
 	renderer          *SlackRenderer
 	realNamesForID    map[string]string
-	msgStatusTracker  *SlackMessageStatusTracker
-	messages          chan slackMessage
-	messageWorkers    *pool.Pool
-	shutdownOnce      sync.Once
-	status            health.PlatformStatusMsg
-	failureReason     health.FailureReasonMsg
-	errorMsg          string
+	// emailsForID is filled together with real names. Emails are available only with the `users:read.email` scope.
+	emailsForID      map[string]string
+	msgStatusTracker *SlackMessageStatusTracker
+	messages         chan slackMessage
+	messageWorkers   *pool.Pool
+	shutdownOnce     sync.Once
+	status           health.PlatformStatusMsg
+	failureReason    health.FailureReasonMsg
+	errorMsg         string
 }
 
 // socketSlackAnalyticsReporter defines a reporter that collects analytics data.
@@ -73,8 +85,8 @@ type socketSlackAnalyticsReporter interface {
 	ReportCommand(in analytics.ReportCommandInput) error
 }
 
-// NewSocketSlack creates a new SocketSlack instance.
-func NewSocketSlack(log logrus.FieldLogger, commGroupMetadata CommGroupMetadata, cfg config.SocketSlack, executorFactory ExecutorFactory, reporter socketSlackAnalyticsReporter) (*SocketSlack, error) {
+// NewSocketSlack creates a new SocketSlack instance. The autocompleter is optional. If not set, external selects don't suggest any options.
+func NewSocketSlack(log logrus.FieldLogger, commGroupMetadata CommGroupMetadata, cfg config.SocketSlack, executorFactory ExecutorFactory, autocompleter Autocompleter, reporter socketSlackAnalyticsReporter) (*SocketSlack, error) {
 	client := slack.New(cfg.BotToken, slack.OptionAppLevelToken(cfg.AppToken))
 
 	authResp, err := client.AuthTest()
@@ -96,6 +108,7 @@ func NewSocketSlack(log logrus.FieldLogger, commGroupMetadata CommGroupMetadata,
 	return &SocketSlack{
 		log:               log,
 		executorFactory:   executorFactory,
+		autocompleter:     autocompleter,
 		reporter:          reporter,
 		botID:             botID,
 		client:            client,
@@ -104,6 +117,7 @@ func NewSocketSlack(log logrus.FieldLogger, commGroupMetadata CommGroupMetadata,
 		renderer:          NewSlackRenderer(),
 		botMentionRegex:   botMentionRegex,
 		realNamesForID:    map[string]string{},
+		emailsForID:       map[string]string{},
 		msgStatusTracker:  NewSlackMessageStatusTracker(log, client),
 		messages:          make(chan slackMessage, platformMessageChannelSize),
 		messageWorkers:    pool.New().WithMaxGoroutines(platformMessageWorkersCount),
@@ -174,9 +188,14 @@ func (b *SocketSlack) Start(ctx context.Context) error {
 							continue
 						}
 
-						// For now, we don't follow thread messages.
-						if ev.ThreadTimeStamp != "" {
-							b.log.Debug("Ignoring thread message...")
+						if ev.User == b.botID {
+							b.log.Debug("Ignoring own message...")
+							continue
+						}
+
+						// Thread messages are handled only if they trigger commands as replies to bot messages or with the channel prefix.
+						if ev.ThreadTimeStamp != "" && ev.BotID != "" {
+							b.log.Debug("Ignoring thread message from other bot...")
 							continue
 						}
 
@@ -214,6 +233,22 @@ func (b *SocketSlack) Start(ctx context.Context) error {
 							CommandOrigin:        command.TypedOrigin,
 						}
 
+						b.messages <- msg
+					case *slackevents.ReactionAddedEvent:
+						// reactions added by the bot mark the message status
+						if ev.User == b.botID || ev.Item.Type != slack.TYPE_MESSAGE {
+							continue
+						}
+						b.log.Debugf("Got reaction %s", formatx.StructDumper().Sdump(ev))
+						msg := slackMessage{
+							Channel:        ev.Item.Channel,
+							EventTimeStamp: ev.Item.Timestamp,
+							UserID:         ev.User,
+							UserName:       b.getRealNameWithFallbackToUserID(ctx, ev.User),
+							CommandOrigin:  command.TypedOrigin,
+							Reaction:       ev.Reaction,
+						}
+
 						b.messages <- msg
 					default:
 						b.log.Debugf("Got callback event that we don't watch %s", formatx.StructDumper().Sdump(eventsAPIEvent))
@@ -226,6 +261,12 @@ func (b *SocketSlack) Start(ctx context.Context) error {
 					continue
 				}
 
+				if callback.Type == slack.InteractionTypeBlockSuggestion {
+					// options of external selects are returned in the acknowledgement
+					websocketClient.Ack(*event.Request, b.suggestOptions(callback))
+					continue
+				}
+
 				websocketClient.Ack(*event.Request)
 
 				switch callback.Type {
@@ -316,6 +357,24 @@ func (b *SocketSlack) Start(ctx context.Context) error {
 	}
 }
 
+// suggestOptions returns options of an external select. The select's action ID holds the command it completes, while the value holds the typed query.
+func (b *SocketSlack) suggestOptions(callback slack.InteractionCallback) slack.OptionsResponse {
+	out := slack.OptionsResponse{Options: []*slack.OptionBlockObject{}}
+	if b.autocompleter == nil {
+		return out
+	}
+
+	cmd := strings.TrimSpace(strings.TrimPrefix(callback.ActionID, b.BotName()))
+	for _, opt := range b.autocompleter.Suggest(cmd, callback.Value) {
+		if len(opt.Name) > slackMaxOptionLength || len(opt.Value) > slackMaxOptionLength {
+			// Slack rejects the whole response if any option is too long
+			continue
+		}
+		out.Options = append(out.Options, slack.NewOptionBlockObject(opt.Value, b.renderer.plainTextBlock(opt.Name), nil))
+	}
+	return out
+}
+
 func removeBotNameFromIDs(botName string, state *slack.BlockActionStates) *slack.BlockActionStates {
 	if state == nil {
 		return nil
@@ -378,6 +437,7 @@ func (b *SocketSlack) SetNotificationsEnabled(channelName string, enabled bool)
 func (b *SocketSlack) startMessageProcessor(ctx context.Context) {
 	b.log.Info("Starting socket slack message processor...")
 	defer b.log.Info("Stopped socket slack message processor...")
+	registerMessageQueue(b.IntegrationName(), b.commGroupMetadata, func() int { return len(b.messages) })
 
 	for msg := range b.messages {
 		b.messageWorkers.Go(func() {
@@ -418,16 +478,26 @@ func (b *SocketSlack) handleMessage(ctx context.Context, event slackMessage) err
 	channel, exists := b.getChannels()[info.Name]
 	bindings := channel.Bindings
 	processedEmoji := msgProcessedEmoji
-	if !hasBotMention { // there wasn't botkube mentions, trying to match against messages
-		messageTrigger, matched := b.hasMatchingTextMessageTrigger(channel, request, event.UserID)
-		if !matched {
-			b.log.WithField("matchers", formatx.StructDumper().Sdump(channel.MessageTriggers)).Debugf("Ignoring message as it doesn't contain %q mention nor text matchers", b.botID)
+	if !hasBotMention { // there wasn't botkube mentions, trying to match against channel triggers and messages
+		cmd, triggered := b.triggeredCommand(channel, event, request)
+		switch {
+		case triggered:
+			request = cmd
+		case event.ThreadTimeStamp != "" || event.Reaction != "":
+			// text message triggers match only root messages
+			b.log.WithField("triggers", formatx.StructDumper().Sdump(channel.Triggers)).Debug("Ignoring message as it doesn't match channel triggers")
 			return nil
-		}
-		bindings = config.BotBindings{Executors: messageTrigger.Executors}
-		request = messageTrigger.Command
-		if messageTrigger.ProcessedEmojiIndicator != nil {
-			processedEmoji = *messageTrigger.ProcessedEmojiIndicator
+		default:
+			messageTrigger, matched := b.hasMatchingTextMessageTrigger(channel, request, event.UserID)
+			if !matched {
+				b.log.WithField("matchers", formatx.StructDumper().Sdump(channel.MessageTriggers)).Debugf("Ignoring message as it doesn't contain %q mention nor text matchers", b.botID)
+				return nil
+			}
+			bindings = config.BotBindings{Executors: messageTrigger.Executors}
+			request = messageTrigger.Command
+			if messageTrigger.ProcessedEmojiIndicator != nil {
+				processedEmoji = *messageTrigger.ProcessedEmojiIndicator
+			}
 		}
 	}
 
@@ -449,6 +519,7 @@ func (b *SocketSlack) handleMessage(ctx context.Context, event slackMessage) err
 			DisplayName:      info.Name,
 			ExecutorBindings: bindings.Executors,
 			SourceBindings:   bindings.Sources,
+			ReadOnly:         channel.ReadOnly,
 			IsKnown:          exists,
 			CommandOrigin:    event.CommandOrigin,
 			SlackState:       event.State,
@@ -460,6 +531,8 @@ func (b *SocketSlack) handleMessage(ctx context.Context, event slackMessage) err
 		User: execute.UserInput{
 			Mention:     fmt.Sprintf("<@%s>", event.UserID),
 			DisplayName: event.UserName,
+			ID:          event.UserID,
+			Email:       b.emailsForID[event.UserID],
 		},
 	})
 
@@ -480,6 +553,116 @@ func (b *SocketSlack) handleMessage(ctx context.Context, event slackMessage) err
 	return nil
 }
 
+// StartNotifications starts the bot without the Socket Mode connection, as notifications are sent with the Web API.
+func (b *SocketSlack) StartNotifications(ctx context.Context) error {
+	b.log.Info("Starting bot without handling commands")
+	b.setFailureReason("", "")
+	<-ctx.Done()
+	return nil
+}
+
+// SendInProgressMessage sends a message in the thread of a command that is still being executed.
+func (b *SocketSlack) SendInProgressMessage(ctx context.Context, conversation execute.Conversation, msg interactive.CoreMessage) error {
+	msg.Message.ParentActivityID = conversation.ParentActivityID
+	return b.send(ctx, slackMessage{Channel: conversation.ID}, msg)
+}
+
+// PostUpdatableMessage posts a message in the thread of a command and returns its timestamp, which identifies the message.
+func (b *SocketSlack) PostUpdatableMessage(ctx context.Context, conversation execute.Conversation, msg interactive.CoreMessage) (string, error) {
+	msg.ReplaceBotNamePlaceholder(b.BotName())
+	options := []slack.MsgOption{
+		b.renderer.RenderInteractiveMessage(msg),
+	}
+	if conversation.ParentActivityID != "" {
+		options = append(options, slack.MsgOptionTS(conversation.ParentActivityID))
+	}
+
+	_, ts, err := b.client.PostMessageContext(ctx, conversation.ID, options...)
+	if err != nil {
+		return "", fmt.Errorf("while posting Slack message: %w", slackError(err, conversation.ID))
+	}
+	return ts, nil
+}
+
+// UpdateMessage replaces content of a message posted with PostUpdatableMessage.
+func (b *SocketSlack) UpdateMessage(ctx context.Context, conversation execute.Conversation, messageID string, msg interactive.CoreMessage) error {
+	msg.ReplaceBotNamePlaceholder(b.BotName())
+	_, _, _, err := b.client.UpdateMessageContext(ctx, conversation.ID, messageID, b.renderer.RenderInteractiveMessage(msg))
+	if err != nil {
+		return fmt.Errorf("while updating Slack message: %w", slackError(err, conversation.ID))
+	}
+	return nil
+}
+
+// triggeredCommand returns a command triggered without mentioning the bot, i.e. with the channel prefix, a reply to a bot message,
+// an emoji reaction to a message with the command, or an ack emoji reaction to an incident notification.
+func (b *SocketSlack) triggeredCommand(channel channelConfigByName, event slackMessage, text string) (string, bool) {
+	triggers := channel.Triggers
+	if event.Reaction != "" {
+		isAck, isTrigger := isAckEmoji(triggers, event.Reaction), isEmojiTrigger(triggers, event.Reaction)
+		if !isAck && !isTrigger {
+			return "", false
+		}
+		msg, err := b.getMessage(event.Channel, event.EventTimeStamp)
+		if err != nil {
+			b.log.WithError(err).Error("Cannot get reacted message")
+			return "", false
+		}
+		if isAck && msg.User == b.botID {
+			if cmd, found := ackIncidentCommand(msg); found {
+				return cmd, true
+			}
+		}
+		if !isTrigger {
+			return "", false
+		}
+		// the reacted message may be written for any other trigger
+		if cmd, found := b.findAndTrimBotMention(msg.Text); found {
+			return strings.TrimSpace(cmd), true
+		}
+		if cmd, found := trimCommandPrefix(triggers, msg.Text); found {
+			return cmd, true
+		}
+		cmd := strings.TrimSpace(msg.Text)
+		return cmd, cmd != ""
+	}
+
+	if cmd, found := trimCommandPrefix(triggers, text); found {
+		return cmd, true
+	}
+
+	if event.ThreadTimeStamp == "" || !triggers.Replies {
+		return "", false
+	}
+	parent, err := b.getMessage(event.Channel, event.ThreadTimeStamp)
+	if err != nil {
+		b.log.WithError(err).Error("Cannot get thread parent message")
+		return "", false
+	}
+	if parent.User != b.botID {
+		return "", false
+	}
+	cmd := strings.TrimSpace(text)
+	return cmd, cmd != ""
+}
+
+// getMessage returns a root message with a given timestamp.
+func (b *SocketSlack) getMessage(channelID, ts string) (slack.Message, error) {
+	res, err := b.client.GetConversationHistory(&slack.GetConversationHistoryParameters{
+		ChannelID: channelID,
+		Latest:    ts,
+		Inclusive: true,
+		Limit:     1,
+	})
+	if err != nil {
+		return slack.Message{}, slackError(err, channelID)
+	}
+	if len(res.Messages) == 0 || res.Messages[0].Timestamp != ts {
+		return slack.Message{}, fmt.Errorf("message %s not found", ts)
+	}
+	return res.Messages[0], nil
+}
+
 func (b *SocketSlack) hasMatchingTextMessageTrigger(channel channelConfigByName, request string, id string) (config.TextMessageTriggers, bool) {
 	for _, binding := range channel.MessageTriggers {
 		allowed, err := binding.Text.IsAllowed(request)
@@ -501,7 +684,8 @@ func (b *SocketSlack) hasMatchingTextMessageTrigger(channel channelConfigByName,
 	return config.TextMessageTriggers{}, false
 }
 
-func (b *SocketSlack) send(ctx context.Context, event slackMessage, in interactive.CoreMessage) error {
+func (b *SocketSlack) send(ctx context.Context, event slackMessage, in interactive.CoreMessage) (err error) {
+	defer metrics.ObserveMessageSend(b.IntegrationName(), event.Channel, time.Now(), &err)
 	b.log.Debugf("Sending message to channel %q: %+v", event.Channel, in)
 
 	var msgs []api.Message
@@ -598,6 +782,24 @@ func (b *SocketSlack) send(ctx context.Context, event slackMessage, in interacti
 	return nil
 }
 
+// NotificationChannels returns Slack channels which have notifications enabled.
+func (b *SocketSlack) NotificationChannels() []notifier.NotificationChannel {
+	var out []notifier.NotificationChannel
+	for _, cfg := range b.getChannels() {
+		if !cfg.notify {
+			continue
+		}
+		out = append(out, notifier.NotificationChannel{
+			Name:     cfg.Identifier(),
+			Alias:    cfg.alias,
+			Sources:  cfg.Bindings.Sources,
+			Filters:  cfg.Bindings.Filters,
+			Settings: cfg.Notification.NotificationSettings,
+		})
+	}
+	return out
+}
+
 func (b *SocketSlack) getChannelsToNotify(sourceBindings []string) []string {
 	var out []string
 	for _, cfg := range b.getChannels() {
@@ -615,10 +817,31 @@ func (b *SocketSlack) getChannelsToNotify(sourceBindings []string) []string {
 	return out
 }
 
+// getChannelsToRoute returns channels with given aliases or names which have notifications enabled.
+func (b *SocketSlack) getChannelsToRoute(channels []string) []string {
+	var out []string
+	for _, cfg := range b.getChannels() {
+		if !cfg.notify || !isChannelSelected(cfg.alias, cfg.Identifier(), channels) {
+			continue
+		}
+		out = append(out, cfg.Identifier())
+	}
+	return out
+}
+
 // SendMessage sends message with interactive sections to selected Slack channels.
 func (b *SocketSlack) SendMessage(ctx context.Context, msg interactive.CoreMessage, sourceBindings []string) error {
+	return b.sendToChannels(ctx, msg, b.getChannelsToNotify(sourceBindings))
+}
+
+// SendMessageToChannels sends message with interactive sections to given Slack channels.
+func (b *SocketSlack) SendMessageToChannels(ctx context.Context, msg interactive.CoreMessage, channels []string) error {
+	return b.sendToChannels(ctx, msg, b.getChannelsToRoute(channels))
+}
+
+func (b *SocketSlack) sendToChannels(ctx context.Context, msg interactive.CoreMessage, channelNames []string) error {
 	errs := multierror.New()
-	for _, channelName := range b.getChannelsToNotify(sourceBindings) {
+	for _, channelName := range channelNames {
 		msgMetadata := slackMessage{
 			Channel:         channelName,
 			ThreadTimeStamp: "",
@@ -691,7 +914,7 @@ func resolveBlockActionCommand(act slack.BlockAction) (string, command.Origin) {
 		}
 		cmd = fmt.Sprintf("%s %s", act.ActionID, strings.Join(items, ","))
 		cmdOrigin = command.MultiSelectValueChangeOrigin
-	case "static_select":
+	case "static_select", "external_select":
 		// Example of commands that are handled here:
 		//   @Botkube kcc --verbs get
 		//   @Botkube kcc --resource-type
@@ -739,6 +962,9 @@ func (b *SocketSlack) getRealNameWithFallbackToUserID(ctx context.Context, userI
 		return userID
 	}
 
+	if user != nil && user.Profile.Email != "" {
+		b.emailsForID[userID] = user.Profile.Email
+	}
 	if user == nil || user.RealName == "" {
 		return userID
 	}
@@ -1,10 +1,14 @@
 package bot
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/slack-go/slack"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/internal/autocomplete"
 )
 
 func TestNormalizeState(t *testing.T) {
@@ -59,3 +63,48 @@ func TestNormalizeState(t *testing.T) {
 	// then
 	assert.Equal(t, exp, out)
 }
+
+type fakeAutocompleter struct {
+	cmd   string
+	query string
+}
+
+func (f *fakeAutocompleter) Suggest(cmd, query string) []autocomplete.Option {
+	f.cmd, f.query = cmd, query
+	return []autocomplete.Option{
+		{Name: "prod", Value: "prod"},
+		{Name: "too-long", Value: strings.Repeat("a", 76)},
+		{Name: "production", Value: "production"},
+	}
+}
+
+func TestSuggestOptions(t *testing.T) {
+	// given
+	completer := &fakeAutocompleter{}
+	b := &SocketSlack{botID: "U1", autocompleter: completer, renderer: NewSlackRenderer()}
+
+	// when
+	out := b.suggestOptions(slack.InteractionCallback{
+		ActionID: "<@U1> kubectl get pods -n",
+		Value:    "pro",
+	})
+
+	// then
+	assert.Equal(t, "kubectl get pods -n", completer.cmd)
+	assert.Equal(t, "pro", completer.query)
+	require.Len(t, out.Options, 2)
+	assert.Equal(t, "prod", out.Options[0].Value)
+	assert.Equal(t, "production", out.Options[1].Text.Text)
+}
+
+func TestSuggestOptionsWithoutAutocompleter(t *testing.T) {
+	// given
+	b := &SocketSlack{botID: "U1", renderer: NewSlackRenderer()}
+
+	// when
+	out := b.suggestOptions(slack.InteractionCallback{ActionID: "<@U1> kubectl get pods -n"})
+
+	// then
+	assert.NotNil(t, out.Options)
+	assert.Empty(t, out.Options)
+}
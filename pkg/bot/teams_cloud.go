@@ -18,6 +18,7 @@ import (
 	"golang.org/x/sync/errgroup"
 
 	"github.com/kubeshop/botkube/internal/health"
+	"github.com/kubeshop/botkube/internal/metrics"
 	"github.com/kubeshop/botkube/pkg/api"
 	pb "github.com/kubeshop/botkube/pkg/api/cloudteams"
 	"github.com/kubeshop/botkube/pkg/bot/interactive"
@@ -26,6 +27,7 @@ import (
 	"github.com/kubeshop/botkube/pkg/execute/command"
 	"github.com/kubeshop/botkube/pkg/formatx"
 	"github.com/kubeshop/botkube/pkg/multierror"
+	"github.com/kubeshop/botkube/pkg/notifier"
 	"github.com/kubeshop/botkube/pkg/sliceutil"
 )
 
@@ -38,6 +40,7 @@ const (
 var mdEmojiTag = regexp.MustCompile(`:(\w+):`)
 
 var _ Bot = &CloudTeams{}
+var _ notifier.ChannelLister = &CloudTeams{}
 
 // CloudTeams listens for user's messages, execute commands and sends back the response.
 // It sends also source notifications.
@@ -120,7 +123,15 @@ func NewCloudTeams(
 // Start MS Teams server to serve messages from Teams client
 func (b *CloudTeams) Start(ctx context.Context) error {
 	return b.withRetries(ctx, b.log, maxRetries, func() error {
-		return b.start(ctx)
+		return b.start(ctx, b.handleStreamMessage)
+	})
+}
+
+// StartNotifications starts the bot without handling commands. Notifications are sent over the Cloud Teams stream,
+// so the stream is opened, but activities received from it are ignored.
+func (b *CloudTeams) StartNotifications(ctx context.Context) error {
+	return b.withRetries(ctx, b.log, maxRetries, func() error {
+		return b.start(ctx, ignoreStreamMessage)
 	})
 }
 
@@ -134,6 +145,18 @@ func (b *CloudTeams) SendMessage(ctx context.Context, msg interactive.CoreMessag
 	return b.sendAgentActivity(ctx, msg, b.getChannelsToNotify(sourceBindings))
 }
 
+// SendMessageToChannels sends message to given Teams channels.
+func (b *CloudTeams) SendMessageToChannels(ctx context.Context, msg interactive.CoreMessage, channels []string) error {
+	var out []teamsCloudChannelConfigByID
+	for _, cfg := range b.getChannels() {
+		if !cfg.notify || !isChannelSelected(cfg.alias, cfg.Identifier(), channels) {
+			continue
+		}
+		out = append(out, cfg)
+	}
+	return b.sendAgentActivity(ctx, msg, out)
+}
+
 // IntegrationName describes the integration name.
 func (b *CloudTeams) IntegrationName() config.CommPlatformIntegration {
 	return config.CloudTeamsCommPlatformIntegration
@@ -188,7 +211,7 @@ func (b *CloudTeams) GetStatus() health.PlatformStatus {
 	}
 }
 
-func (b *CloudTeams) start(ctx context.Context) error {
+func (b *CloudTeams) start(ctx context.Context, handleFn handleStreamFn) error {
 	svc, err := newGrpcCloudTeamsConnector(b.log, b.cfg.Server)
 	if err != nil {
 		return fmt.Errorf("while creating gRPC connector: %w", err)
@@ -208,10 +231,11 @@ func (b *CloudTeams) start(ctx context.Context) error {
 	b.failuresNo = 0 // Reset the failures to start exponential back-off from the beginning
 	b.setFailureReason("", "")
 	b.log.Info("Botkube connected to Cloud Teams!")
+	registerMessageQueue(b.IntegrationName(), b.commGroupMetadata, func() int { return len(b.agentActivityMessage) })
 
 	parallel, ctx := errgroup.WithContext(ctx)
 	parallel.Go(func() error {
-		return svc.ProcessCloudActivity(ctx, b.handleStreamMessage)
+		return svc.ProcessCloudActivity(ctx, handleFn)
 	})
 	parallel.Go(func() error {
 		return svc.ProcessAgentActivity(ctx, b.agentActivityMessage)
@@ -256,6 +280,11 @@ func (b *CloudTeams) withRetries(ctx context.Context, log logrus.FieldLogger, ma
 	)
 }
 
+// ignoreStreamMessage drops a given Cloud Teams activity without a response.
+func ignoreStreamMessage(context.Context, *pb.CloudActivity) (*pb.AgentActivity, error) {
+	return nil, nil
+}
+
 func (b *CloudTeams) handleStreamMessage(ctx context.Context, data *pb.CloudActivity) (*pb.AgentActivity, error) {
 	b.setFailureReason("", "")
 	var act schema.Activity
@@ -312,6 +341,7 @@ func (b *CloudTeams) processMessage(ctx context.Context, act schema.Activity, ch
 			ID:               channel.Identifier(),
 			ExecutorBindings: channel.Bindings.Executors,
 			SourceBindings:   channel.Bindings.Sources,
+			ReadOnly:         channel.ReadOnly,
 			CommandOrigin:    b.mapToCommandOrigin(act),
 			DisplayName:      channelDisplayName,
 			ParentActivityID: act.Conversation.ID,
@@ -322,6 +352,8 @@ func (b *CloudTeams) processMessage(ctx context.Context, act schema.Activity, ch
 			// https://github.com/kubeshop/botkube/issues/1331
 			Mention:     act.From.Name,
 			DisplayName: act.From.Name,
+			// Azure Active Directory object ID is stable, unlike the channel-specific user ID
+			ID: act.From.AadObjectID,
 		},
 		AuditContext: act.ChannelData,
 	})
@@ -382,11 +414,13 @@ func (b *CloudTeams) extractExplicitOrigin(act schema.Activity) (command.Origin,
 func (b *CloudTeams) sendAgentActivity(ctx context.Context, msg interactive.CoreMessage, channels []teamsCloudChannelConfigByID) error {
 	errs := multierror.New()
 	for _, channel := range channels {
+		start := time.Now()
 		b.log.Debugf("Sending message to channel %q: %+v", channel.ID, msg)
 
 		msg.ReplaceBotNamePlaceholder(b.BotName(), api.BotNameWithClusterName(b.clusterName))
 		raw, err := json.Marshal(msg)
 		if err != nil {
+			metrics.ObserveMessageSend(b.IntegrationName(), channel.ID, start, &err)
 			errs = multierror.Append(errs, fmt.Errorf("while proxing message via agent for channel id %q: %w", channel.ID, err))
 			continue
 		}
@@ -405,6 +439,8 @@ func (b *CloudTeams) sendAgentActivity(ctx context.Context, msg interactive.Core
 			return ctx.Err()
 		case b.agentActivityMessage <- act:
 		}
+		// messages are sent asynchronously by the agent stream, so only queueing them is observed
+		metrics.ObserveMessageSend(b.IntegrationName(), channel.ID, start, nil)
 	}
 	return errs.ErrorOrNil()
 }
@@ -436,6 +472,24 @@ func (b *CloudTeams) getChannelForActivity(act schema.Activity) (teamsCloudChann
 	return channel, exists, nil
 }
 
+// NotificationChannels returns Teams channels which have notifications enabled.
+func (b *CloudTeams) NotificationChannels() []notifier.NotificationChannel {
+	var out []notifier.NotificationChannel
+	for _, cfg := range b.getChannels() {
+		if !cfg.notify {
+			continue
+		}
+		out = append(out, notifier.NotificationChannel{
+			Name:     cfg.Identifier(),
+			Alias:    cfg.alias,
+			Sources:  cfg.Bindings.Sources,
+			Filters:  cfg.Bindings.Filters,
+			Settings: cfg.Notification.NotificationSettings,
+		})
+	}
+	return out
+}
+
 func (b *CloudTeams) getChannelsToNotify(sourceBindings []string) []teamsCloudChannelConfigByID {
 	var out []teamsCloudChannelConfigByID
 	for _, cfg := range b.getChannels() {
@@ -0,0 +1,70 @@
+package bot
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+// trimCommandPrefix returns the command from a message starting with the channel command prefix, e.g. `kubectl get pods` for `!bk kubectl get pods`.
+// The prefix must be followed by whitespace, so `!bk` doesn't trigger commands for `!bkp`.
+func trimCommandPrefix(triggers config.ChannelTriggers, msg string) (string, bool) {
+	prefix := strings.TrimSpace(triggers.Prefix)
+	if prefix == "" {
+		return "", false
+	}
+
+	msg = strings.TrimSpace(msg)
+	rest, found := strings.CutPrefix(msg, prefix)
+	if !found {
+		return "", false
+	}
+	if next, _ := utf8.DecodeRuneInString(rest); rest != "" && !unicode.IsSpace(next) {
+		return "", false
+	}
+
+	cmd := strings.TrimSpace(rest)
+	return cmd, cmd != ""
+}
+
+// ackIncidentCmdRegex matches the acknowledgement command added to incident notifications.
+var ackIncidentCmdRegex = regexp.MustCompile(`ack incident ([0-9a-f]+)`)
+
+// isEmojiTrigger returns true if a given reaction runs the reacted message as a command. Names can be given with or without colons, e.g. `:robot_face:`.
+func isEmojiTrigger(triggers config.ChannelTriggers, reaction string) bool {
+	return containsEmoji(triggers.Emojis, reaction)
+}
+
+// isAckEmoji returns true if a given reaction acknowledges the incident reported in the reacted message.
+func isAckEmoji(triggers config.ChannelTriggers, reaction string) bool {
+	return containsEmoji(triggers.AckEmojis, reaction)
+}
+
+func containsEmoji(emojis []string, reaction string) bool {
+	reaction = strings.Trim(reaction, ":")
+	for _, emoji := range emojis {
+		if strings.Trim(emoji, ":") == reaction {
+			return true
+		}
+	}
+	return false
+}
+
+// ackIncidentCommand returns the command acknowledging the incident reported in a given bot message.
+// The message is searched as a whole, as platforms render the acknowledgement instructions and button differently.
+func ackIncidentCommand(msg any) (string, bool) {
+	raw, err := json.Marshal(msg)
+	if err != nil {
+		return "", false
+	}
+	match := ackIncidentCmdRegex.FindSubmatch(raw)
+	if match == nil {
+		return "", false
+	}
+	return fmt.Sprintf("ack incident %s", match[1]), true
+}
@@ -0,0 +1,160 @@
+package bot
+
+import (
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+func TestTrimCommandPrefix(t *testing.T) {
+	tests := []struct {
+		name        string
+		prefix      string
+		msg         string
+		expectedCmd string
+		expectedOK  bool
+	}{
+		{
+			name:        "Command with prefix",
+			prefix:      "!bk",
+			msg:         "  !bk kubectl get pods -n prod ",
+			expectedCmd: "kubectl get pods -n prod",
+			expectedOK:  true,
+		},
+		{
+			name:        "Prefix followed by new line",
+			prefix:      "!bk",
+			msg:         "!bk\nhelm list",
+			expectedCmd: "helm list",
+			expectedOK:  true,
+		},
+		{
+			name:   "Prefix as a part of a word",
+			prefix: "!bk",
+			msg:    "!bkp get pods",
+		},
+		{
+			name:   "Prefix only",
+			prefix: "!bk",
+			msg:    "!bk",
+		},
+		{
+			name:   "Other message",
+			prefix: "!bk",
+			msg:    "kubectl get pods",
+		},
+		{
+			name: "Prefix not configured",
+			msg:  " kubectl get pods",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			cmd, ok := trimCommandPrefix(config.ChannelTriggers{Prefix: tc.prefix}, tc.msg)
+
+			// then
+			assert.Equal(t, tc.expectedOK, ok)
+			assert.Equal(t, tc.expectedCmd, cmd)
+		})
+	}
+}
+
+func TestIsEmojiTrigger(t *testing.T) {
+	// given
+	triggers := config.ChannelTriggers{Emojis: []string{":robot_face:", "🤖"}}
+
+	// then
+	assert.True(t, isEmojiTrigger(triggers, "robot_face"))
+	assert.True(t, isEmojiTrigger(triggers, "🤖"))
+	assert.False(t, isEmojiTrigger(triggers, "eyes"))
+	assert.False(t, isEmojiTrigger(config.ChannelTriggers{}, "robot_face"))
+}
+
+func TestIsAckEmoji(t *testing.T) {
+	// given
+	triggers := config.ChannelTriggers{Emojis: []string{"robot_face"}, AckEmojis: []string{":white_check_mark:", "✅"}}
+
+	// then
+	assert.True(t, isAckEmoji(triggers, "white_check_mark"))
+	assert.True(t, isAckEmoji(triggers, "✅"))
+	assert.False(t, isAckEmoji(triggers, "robot_face"))
+}
+
+func TestAckIncidentCommand(t *testing.T) {
+	// given
+	notification := &discordgo.Message{
+		Content: "Incident 5f2b8c0e9d41a7c3. To acknowledge it, run `<@bot> ack incident 5f2b8c0e9d41a7c3`.",
+	}
+
+	// when
+	cmd, found := ackIncidentCommand(notification)
+
+	// then
+	assert.True(t, found)
+	assert.Equal(t, "ack incident 5f2b8c0e9d41a7c3", cmd)
+
+	// when
+	_, found = ackIncidentCommand(&discordgo.Message{Content: "Pod created"})
+
+	// then
+	assert.False(t, found)
+}
+
+func TestDiscordTriggeredCommand(t *testing.T) {
+	// given
+	b := &Discord{botID: "bot"}
+	channel := channelConfigByID{
+		ChannelBindingsByID: config.ChannelBindingsByID{
+			Triggers: config.ChannelTriggers{Prefix: "!bk", Replies: true},
+		},
+	}
+	reply := func(content, authorID string) *discordgo.MessageCreate {
+		return &discordgo.MessageCreate{Message: &discordgo.Message{
+			Type:              discordgo.MessageTypeReply,
+			Content:           content,
+			ReferencedMessage: &discordgo.Message{Author: &discordgo.User{ID: authorID}},
+		}}
+	}
+
+	tests := []struct {
+		name        string
+		msg         *discordgo.MessageCreate
+		expectedCmd string
+		expectedOK  bool
+	}{
+		{
+			name:        "Prefix",
+			msg:         &discordgo.MessageCreate{Message: &discordgo.Message{Content: "!bk kubectl get pods"}},
+			expectedCmd: "kubectl get pods",
+			expectedOK:  true,
+		},
+		{
+			name:        "Reply to bot message",
+			msg:         reply(" kubectl describe pod api-0 ", "bot"),
+			expectedCmd: "kubectl describe pod api-0",
+			expectedOK:  true,
+		},
+		{
+			name: "Reply to user message",
+			msg:  reply("kubectl describe pod api-0", "user"),
+		},
+		{
+			name: "Regular message",
+			msg:  &discordgo.MessageCreate{Message: &discordgo.Message{Content: "kubectl get pods"}},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			cmd, ok := b.triggeredCommand(channel, tc.msg)
+
+			// then
+			assert.Equal(t, tc.expectedOK, ok)
+			assert.Equal(t, tc.expectedCmd, cmd)
+		})
+	}
+}
@@ -106,6 +106,21 @@ const (
 
 	// PagerDutyCommPlatformIntegration defines an outgoing PagerDuty integration.
 	PagerDutyCommPlatformIntegration CommPlatformIntegration = "pagerDuty"
+
+	// KafkaCommPlatformIntegration defines an outgoing Kafka integration.
+	KafkaCommPlatformIntegration CommPlatformIntegration = "kafka"
+
+	// NATSCommPlatformIntegration defines an outgoing NATS integration.
+	NATSCommPlatformIntegration CommPlatformIntegration = "nats"
+
+	// NATSCommandsCommPlatformIntegration defines NATS integration which receives commands.
+	NATSCommandsCommPlatformIntegration CommPlatformIntegration = "natsCommands"
+
+	// S3ArchiveCommPlatformIntegration defines an outgoing integration which archives events in S3-compatible object storages.
+	S3ArchiveCommPlatformIntegration CommPlatformIntegration = "s3Archive"
+
+	// WarehouseCommPlatformIntegration defines an outgoing integration which streams events into data warehouses, such as BigQuery.
+	WarehouseCommPlatformIntegration CommPlatformIntegration = "warehouse"
 )
 
 func (c CommPlatformIntegration) IsInteractive() bool {
@@ -144,15 +159,27 @@ const (
 
 // Config structure of configuration yaml file
 type Config struct {
-	Actions        Actions                   `yaml:"actions" validate:"dive"`
-	Sources        map[string]Sources        `yaml:"sources" validate:"dive"`
-	Executors      map[string]Executors      `yaml:"executors" validate:"dive"`
-	Aliases        Aliases                   `yaml:"aliases" validate:"dive"`
-	Communications map[string]Communications `yaml:"communications"  validate:"required,min=1,dive"`
+	Actions            Actions                   `yaml:"actions" validate:"dive"`
+	Sources            map[string]Sources        `yaml:"sources" validate:"dive"`
+	Executors          map[string]Executors      `yaml:"executors" validate:"dive"`
+	Processors         map[string]Processors     `yaml:"processors" validate:"dive"`
+	Aliases            Aliases                   `yaml:"aliases" validate:"dive"`
+	Runbooks           Runbooks                  `yaml:"runbooks" validate:"dive"`
+	Filters            Filters                   `yaml:"filters" validate:"dive"`
+	Enrichments        Enrichments               `yaml:"enrichments" validate:"dive"`
+	Escalations        Escalations               `yaml:"escalations" validate:"dive"`
+	Tickets            Tickets                   `yaml:"tickets" validate:"dive"`
+	MaintenanceWindows MaintenanceWindows        `yaml:"maintenanceWindows" validate:"dive"`
+	Routing            Routing                   `yaml:"routing"`
+	Ownership          Ownership                 `yaml:"ownership,omitempty"`
+	Communications     map[string]Communications `yaml:"communications"  validate:"required,min=1,dive"`
+	SecretProviders    SecretProviders           `yaml:"secretProviders" validate:"dive"`
 
 	Analytics     Analytics        `yaml:"analytics"`
 	Settings      Settings         `yaml:"settings"`
 	ConfigWatcher CfgWatcher       `yaml:"configWatcher"`
+	ConfigCRD     ConfigCRD        `yaml:"configCRD"`
+	GitSync       GitSync          `yaml:"gitSync"`
 	Plugins       PluginManagement `yaml:"plugins"`
 }
 
@@ -163,11 +190,140 @@ type PluginManagement struct {
 	IncomingWebhook     IncomingWebhook              `yaml:"incomingWebhook"`
 	RestartPolicy       PluginRestartPolicy          `yaml:"restartPolicy"`
 	HealthCheckInterval time.Duration                `yaml:"healthCheckInterval"`
+	// OCI holds settings of OCI registries used for `oci://` index and plugin URLs.
+	OCI PluginsOCI `yaml:"oci,omitempty"`
+	// Resources holds resource limits and isolation settings of plugin processes.
+	Resources PluginResources `yaml:"resources,omitempty"`
+	// Mirror holds settings of an internal mirror of plugin indexes, binaries and their dependencies.
+	Mirror PluginsMirror `yaml:"mirror,omitempty"`
+	// RequireConfigSchema prevents Botkube from starting if a configured plugin doesn't define the JSON schema of its configuration.
+	// Configurations of plugins which define the schema are always validated against it.
+	RequireConfigSchema bool `yaml:"requireConfigSchema,omitempty"`
+	// Security holds settings which secure gRPC connections between Botkube and plugin processes.
+	Security PluginSecurity `yaml:"security,omitempty"`
+}
+
+// PluginMTLSMode defines how plugin gRPC connections are secured with mutual TLS.
+type PluginMTLSMode string
+
+const (
+	// DisabledPluginMTLSMode doesn't use TLS for plugin connections.
+	DisabledPluginMTLSMode PluginMTLSMode = "disabled"
+	// AutoPluginMTLSMode uses ephemeral, self-signed certificates generated for each plugin process.
+	AutoPluginMTLSMode PluginMTLSMode = "auto"
+	// CAPluginMTLSMode uses certificates issued from a mounted CA, e.g. managed by cert-manager.
+	CAPluginMTLSMode PluginMTLSMode = "ca"
+)
+
+// PluginSecurity holds settings which secure gRPC connections between Botkube and plugin processes.
+type PluginSecurity struct {
+	MTLS PluginMTLS `yaml:"mtls"`
+	// AuthTokens enables per-plugin tokens. A random token is generated for each plugin process, and it's required in all gRPC calls.
+	AuthTokens bool `yaml:"authTokens"`
+}
+
+// PluginMTLS holds mutual TLS settings of plugin gRPC connections.
+//
+// In the `ca` mode, the CA is read from disk each time a plugin is started, and certificates of both sides are issued from it.
+// Botkube issues a new client certificate for each TLS handshake. Plugins are restarted with a new server certificate after two thirds
+// of its validity, or once the CA is rotated, e.g. by cert-manager.
+type PluginMTLS struct {
+	Mode       PluginMTLSMode `yaml:"mode" validate:"omitempty,oneof=disabled auto ca"`
+	CACertFile string         `yaml:"caCertFile,omitempty" validate:"required_if=Mode ca"`
+	CAKeyFile  string         `yaml:"caKeyFile,omitempty" validate:"required_if=Mode ca"`
+	// CertValidity is the validity of certificates issued from the CA.
+	CertValidity time.Duration `yaml:"certValidity"`
+}
+
+// PluginsMirror contains settings of an internal mirror which serves plugin indexes, binaries and their dependencies,
+// e.g. in air-gapped environments.
+type PluginsMirror struct {
+	// URL is the base URL of the mirror. HTTP(S) URLs are fetched from the mirror with the original host and path appended,
+	// e.g. `https://example.com/helm.tar.gz` is fetched from `<URL>/example.com/helm.tar.gz`.
+	URL string `yaml:"url,omitempty" validate:"required_if=AirGapped true,omitempty,url"`
+	// AirGapped disables falling back to the original URLs if the mirror fails.
+	// OCI artifacts are pulled only from mirror registries defined in the OCI settings.
+	AirGapped bool `yaml:"airGapped,omitempty"`
+}
+
+// PluginResources holds resource limits and isolation settings of plugin processes.
+type PluginResources struct {
+	// Default holds settings applied to all plugins.
+	Default PluginResourceLimits `yaml:"default,omitempty"`
+	// Plugins holds settings of given plugins, indexed by plugin names without versions, e.g. `botkube/helm`.
+	// Settings which are set override the default ones.
+	Plugins map[string]PluginResourceLimits `yaml:"plugins,omitempty" validate:"dive"`
+}
+
+// PluginResourceLimits holds resource limits and isolation settings of a plugin process.
+type PluginResourceLimits struct {
+	// CPU is the maximum CPU usage, e.g. `500m` or `2`.
+	CPU string `yaml:"cpu,omitempty"`
+	// Memory is the maximum memory usage, e.g. `256Mi`.
+	Memory string `yaml:"memory,omitempty"`
+	// RunAsUser is the UID used to run the plugin process. It requires Botkube to run as root or with the CAP_SETUID capability.
+	RunAsUser *uint32 `yaml:"runAsUser,omitempty"`
+	// RunAsGroup is the GID used to run the plugin process. If not set, the RunAsUser value is used.
+	RunAsGroup *uint32 `yaml:"runAsGroup,omitempty"`
+}
+
+// IsZero returns true if there are no limits and isolation settings.
+func (l PluginResourceLimits) IsZero() bool {
+	return l.CPU == "" && l.Memory == "" && l.RunAsUser == nil && l.RunAsGroup == nil
+}
+
+// ForPlugin returns settings for a given plugin key, e.g. `botkube/helm@v1.0.0`.
+func (r PluginResources) ForPlugin(key string) PluginResourceLimits {
+	out := r.Default
+	repo, name, _, err := DecomposePluginKey(key)
+	if err != nil {
+		return out
+	}
+
+	overrides, found := r.Plugins[fmt.Sprintf("%s/%s", repo, name)]
+	if !found {
+		return out
+	}
+	if overrides.CPU != "" {
+		out.CPU = overrides.CPU
+	}
+	if overrides.Memory != "" {
+		out.Memory = overrides.Memory
+	}
+	if overrides.RunAsUser != nil {
+		out.RunAsUser = overrides.RunAsUser
+	}
+	if overrides.RunAsGroup != nil {
+		out.RunAsGroup = overrides.RunAsGroup
+	}
+	return out
+}
+
+// PluginsOCI contains settings of OCI registries which distribute plugin indexes and binaries.
+type PluginsOCI struct {
+	// DockerConfigPath is the path of the Docker config file with registry credentials, e.g. mounted from a `kubernetes.io/dockerconfigjson` Secret.
+	// The default Docker config is used as a fallback. Credentials of a plugin repository take precedence for its registry.
+	DockerConfigPath string `yaml:"dockerConfigPath,omitempty"`
+	// Mirrors holds mirror registries, indexed by the mirrored registry host, e.g. `ghcr.io`. Mirrors are tried in order before the mirrored registry.
+	Mirrors map[string][]string `yaml:"mirrors,omitempty"`
+	// PlainHTTP holds hosts of registries which are accessed over HTTP instead of HTTPS, e.g. `localhost:5000`.
+	PlainHTTP []string `yaml:"plainHTTP,omitempty"`
 }
 
 type PluginRestartPolicy struct {
 	Type      PluginRestartPolicyType `yaml:"type"`
 	Threshold int                     `yaml:"threshold"`
+	// Backoff holds delays between restarts of a crashed plugin.
+	Backoff PluginRestartBackoff `yaml:"backoff,omitempty"`
+	// CooldownPeriod is the time after which a plugin deactivated by the DeactivatePlugin policy is started again.
+	// If it crashes again, it's deactivated for the next period. If not set, the plugin stays deactivated.
+	CooldownPeriod time.Duration `yaml:"cooldownPeriod,omitempty"`
+}
+
+// PluginRestartBackoff holds an exponential backoff of plugin restarts. The delay is doubled with each restart.
+type PluginRestartBackoff struct {
+	InitialDelay time.Duration `yaml:"initialDelay,omitempty"`
+	MaxDelay     time.Duration `yaml:"maxDelay,omitempty"`
 }
 
 type PluginRestartPolicyType string
@@ -185,6 +341,52 @@ func (p PluginRestartPolicyType) ToLower() string {
 type PluginsRepository struct {
 	URL     string `yaml:"url"`
 	Headers map[string]string
+	// Auth holds credentials for private repositories. They are sent with requests for the index and for plugin binaries hosted on the same host as the index.
+	Auth PluginsRepositoryAuth `yaml:"auth,omitempty"`
+	// TLS holds TLS settings used for requests for the index and plugin binaries.
+	TLS PluginsRepositoryTLS `yaml:"tls,omitempty"`
+}
+
+// PluginsRepositoryAuthType defines the authentication method of a plugin repository.
+type PluginsRepositoryAuthType string
+
+const (
+	// BasicPluginsRepositoryAuth uses the HTTP basic authentication with Username and Password.
+	BasicPluginsRepositoryAuth PluginsRepositoryAuthType = "basic"
+	// BearerPluginsRepositoryAuth sends Token in the `Authorization: Bearer` header.
+	BearerPluginsRepositoryAuth PluginsRepositoryAuthType = "bearer"
+	// SignedURLPluginsRepositoryAuth appends SignedQuery to URLs, e.g. an Azure SAS token or a Google Cloud Storage signature.
+	SignedURLPluginsRepositoryAuth PluginsRepositoryAuthType = "signedURL"
+)
+
+// PluginsRepositoryAuth contains credentials for a private plugin repository. Set secret values with environment variables
+// referencing Kubernetes Secrets, e.g. `BOTKUBE_PLUGINS_REPOSITORIES_{NAME}_AUTH_TOKEN`, so they are not stored in a ConfigMap.
+type PluginsRepositoryAuth struct {
+	Type     PluginsRepositoryAuthType `yaml:"type,omitempty"`
+	Username string                    `yaml:"username,omitempty"`
+	Password string                    `yaml:"password,omitempty"`
+	Token    string                    `yaml:"token,omitempty"`
+	// SignedQuery is the query string with the URL signature, e.g. `sv=2022-11-02&sig=...`.
+	SignedQuery string `yaml:"signedQuery,omitempty"`
+}
+
+// PluginsRepositoryTLS contains TLS settings of a plugin repository. Files can be mounted from Kubernetes Secrets.
+type PluginsRepositoryTLS struct {
+	// CAFile is the path of PEM-encoded CA certificates used to verify the repository server, in addition to the system ones.
+	CAFile string `yaml:"caFile,omitempty"`
+	// CertFile and KeyFile are paths of the PEM-encoded client certificate and its private key, used for mutual TLS.
+	CertFile string `yaml:"certFile,omitempty"`
+	KeyFile  string `yaml:"keyFile,omitempty"`
+	// MinVersion and CipherSuites override the global TLS policy.
+	MinVersion   string   `yaml:"minVersion,omitempty" validate:"omitempty,oneof=1.0 1.1 1.2 1.3"`
+	CipherSuites []string `yaml:"cipherSuites,omitempty"`
+	// InsecureSkipVerify disables the server certificate verification. Use it only for testing.
+	InsecureSkipVerify bool `yaml:"insecureSkipVerify,omitempty"`
+}
+
+// IsZero returns true if no TLS settings are set.
+func (t PluginsRepositoryTLS) IsZero() bool {
+	return t.CAFile == "" && t.CertFile == "" && t.KeyFile == "" && t.MinVersion == "" && len(t.CipherSuites) == 0 && !t.InsecureSkipVerify
 }
 
 // IncomingWebhook contains configuration for incoming source webhook.
@@ -194,6 +396,59 @@ type IncomingWebhook struct {
 
 	// InClusterBaseURL is the in-cluster URL of the incoming webhook. Passed for plugins in context.
 	InClusterBaseURL string `yaml:"inClusterBaseURL"`
+
+	// Auth holds the default authentication of incoming webhook requests. It can be overridden per source binding.
+	// The scheme is required if the incoming webhook is enabled.
+	Auth IncomingWebhookAuth `yaml:"auth,omitempty"`
+	// RateLimit holds the default rate limit of incoming webhook requests. It applies to each source route separately.
+	RateLimit IncomingWebhookRateLimit `yaml:"rateLimit,omitempty"`
+}
+
+// IncomingWebhookAuthType defines the authentication scheme of incoming webhook requests.
+type IncomingWebhookAuthType string
+
+const (
+	// NoneIncomingWebhookAuth accepts all requests.
+	NoneIncomingWebhookAuth IncomingWebhookAuthType = "none"
+	// BasicIncomingWebhookAuth requires the HTTP basic authentication with Username and Password.
+	BasicIncomingWebhookAuth IncomingWebhookAuthType = "basic"
+	// BearerIncomingWebhookAuth requires Token in the `Authorization: Bearer` header.
+	BearerIncomingWebhookAuth IncomingWebhookAuthType = "bearer"
+	// HeaderIncomingWebhookAuth requires Token in the Header header, e.g. `X-Gitlab-Token`.
+	HeaderIncomingWebhookAuth IncomingWebhookAuthType = "header"
+	// HMACSHA256IncomingWebhookAuth requires the hex-encoded HMAC-SHA256 signature of the request body, signed with Token, in the Header header.
+	// The signature can be prefixed with `sha256=`, as sent by GitHub in the `X-Hub-Signature-256` header.
+	HMACSHA256IncomingWebhookAuth IncomingWebhookAuthType = "hmacSHA256"
+)
+
+// IncomingWebhookAuth contains the authentication of incoming webhook requests. Set secret values with environment variables
+// referencing Kubernetes Secrets, so they are not stored in a ConfigMap.
+//
+// The scheme set for a source binding takes precedence over the global one, which takes precedence over the scheme declared
+// by the source plugin for a given route. The `none` scheme declared by a plugin is ignored, so a plugin cannot disable authentication.
+// Requests are rejected if no scheme is set, or if the scheme requires credentials which are not configured.
+type IncomingWebhookAuth struct {
+	Type     IncomingWebhookAuthType `yaml:"type,omitempty" validate:"omitempty,oneof=none basic bearer header hmacSHA256"`
+	Username string                  `yaml:"username,omitempty"`
+	Password string                  `yaml:"password,omitempty"`
+	// Token is the bearer or header token, or the HMAC secret.
+	Token string `yaml:"token,omitempty"`
+	// Header is the name of the header with the token or signature, used by the `header` and `hmacSHA256` schemes.
+	Header string `yaml:"header,omitempty"`
+}
+
+// IncomingWebhookRateLimit contains configuration for incoming webhook rate limiting. Rate limiting is disabled if MaxRequests is zero.
+type IncomingWebhookRateLimit struct {
+	// MaxRequests is the maximum number of requests for a single source route within Window.
+	MaxRequests int `yaml:"maxRequests" validate:"min=0"`
+	// Window is the period of time in which MaxRequests applies.
+	Window time.Duration `yaml:"window" validate:"required_with=MaxRequests"`
+}
+
+// SourceIncomingWebhook contains incoming webhook settings of a given source binding. Empty values fall back to the global ones.
+type SourceIncomingWebhook struct {
+	Auth      IncomingWebhookAuth      `yaml:"auth,omitempty"`
+	RateLimit IncomingWebhookRateLimit `yaml:"rateLimit,omitempty"`
 }
 
 // CloudSlackChannel contains configuration bindings per channel.
@@ -214,6 +469,12 @@ type ChannelBindingsByName struct {
 	Notification    ChannelNotification   `yaml:"notification"` // TODO: rename to `notifications` later
 	Bindings        BotBindings           `yaml:"bindings"`
 	MessageTriggers []TextMessageTriggers `yaml:"messageTriggers"`
+	// FeatureFlags override global states of experimental features for this channel, indexed by flag names.
+	FeatureFlags map[string]bool `yaml:"featureFlags,omitempty"`
+	// ReadOnly allows only read-only commands, such as `kubectl get` or `helm status`, in this channel regardless of RBAC.
+	ReadOnly bool `yaml:"readOnly,omitempty"`
+	// Triggers configure how commands are triggered in this channel in addition to mentioning the bot.
+	Triggers ChannelTriggers `yaml:"triggers,omitempty"`
 }
 
 // Identifier returns ChannelBindingsByName identifier.
@@ -226,6 +487,22 @@ func (c ChannelBindingsByName) GetBotBindings() BotBindings {
 	return c.Bindings
 }
 
+// ChannelTriggers configure how commands are triggered in addition to mentioning the bot. They are supported by Socket Slack,
+// Mattermost and Discord.
+type ChannelTriggers struct {
+	// Prefix triggers commands for messages starting with it, e.g. `!bk` for `!bk kubectl get pods`.
+	Prefix string `yaml:"prefix,omitempty"`
+	// Replies triggers commands for replies to messages posted by the bot, e.g. `kubectl describe pod api-0` replied to a notification.
+	// The whole reply is the command.
+	Replies bool `yaml:"replies,omitempty"`
+	// Emojis contains names of reactions which run the reacted message as a command, e.g. `robot_face` on Slack or `🤖` on Discord.
+	// They are not supported by Mattermost. Slack requires the `reactions:read` scope and the `reaction_added` event subscription.
+	Emojis []string `yaml:"emojis,omitempty"`
+	// AckEmojis contains names of reactions which acknowledge incidents when added to notifications posted by the bot,
+	// e.g. `white_check_mark` on Slack or `✅` on Discord. They have the same requirements as Emojis.
+	AckEmojis []string `yaml:"ackEmojis,omitempty"`
+}
+
 type TextMessageTriggerEvent string
 
 const (
@@ -261,6 +538,12 @@ type ChannelBindingsByID struct {
 	ID           string              `yaml:"id"`
 	Notification ChannelNotification `yaml:"notification"` // TODO: rename to `notifications` later
 	Bindings     BotBindings         `yaml:"bindings"`
+	// FeatureFlags override global states of experimental features for this channel, indexed by flag names.
+	FeatureFlags map[string]bool `yaml:"featureFlags,omitempty"`
+	// ReadOnly allows only read-only commands, such as `kubectl get` or `helm status`, in this channel regardless of RBAC.
+	ReadOnly bool `yaml:"readOnly,omitempty"`
+	// Triggers configure how commands are triggered in this channel in addition to mentioning the bot.
+	Triggers ChannelTriggers `yaml:"triggers,omitempty"`
 }
 
 // Identifier returns ChannelBindingsByID identifier.
@@ -277,6 +560,8 @@ func (c ChannelBindingsByID) GetBotBindings() BotBindings {
 type BotBindings struct {
 	Sources   []string `yaml:"sources"`
 	Executors []string `yaml:"executors"`
+	// Filters are names of filters evaluated for notifications sent to the channel. Bindings of bots which don't list channels, such as Teams, ignore them.
+	Filters []string `yaml:"filters,omitempty"`
 }
 
 // SinkBindings contains configuration for possible Sink bindings.
@@ -289,10 +574,59 @@ type Actions map[string]Action
 
 // Action contains configuration for Botkube app event automations.
 type Action struct {
-	Enabled     bool           `yaml:"enabled"`
-	DisplayName string         `yaml:"displayName"`
-	Command     string         `yaml:"command" validate:"required_if=Enabled true"`
-	Bindings    ActionBindings `yaml:"bindings"`
+	Enabled     bool   `yaml:"enabled"`
+	DisplayName string `yaml:"displayName"`
+	// Command is required for enabled actions which don't define Steps.
+	Command string `yaml:"command" validate:"excluded_with=Steps"`
+	// Steps define a pipeline of commands executed one by one. Each step command can use outputs of the previous steps.
+	Steps []ActionStep `yaml:"steps,omitempty" validate:"dive"`
+	// Condition is an optional CEL expression. If set, the action is executed only for events for which it evaluates to true,
+	// e.g. `event.Level == "error" && event.Namespace.startsWith("prod-")`.
+	Condition string `yaml:"condition,omitempty"`
+	// Remediation marks the action as a remediation which mutates cluster resources.
+	Remediation ActionRemediation `yaml:"remediation,omitempty"`
+	// RateLimit limits the number of executions per resource, e.g. for a crash looping Pod.
+	RateLimit ActionRateLimit `yaml:"rateLimit,omitempty"`
+	Bindings  ActionBindings  `yaml:"bindings"`
+}
+
+// ActionRateLimit contains configuration for action rate limiting. Rate limiting is disabled if MaxExecutions is zero.
+type ActionRateLimit struct {
+	// MaxExecutions is the maximum number of executions for a single resource within Window.
+	MaxExecutions int `yaml:"maxExecutions" validate:"min=0"`
+	// Window is the period of time in which MaxExecutions applies.
+	Window time.Duration `yaml:"window" validate:"required_with=MaxExecutions"`
+}
+
+// ActionRemediation contains configuration for actions which mutate cluster resources, e.g. restart a Deployment.
+// Such actions are executed only within the declared budget, and each execution is posted with a rollback button.
+type ActionRemediation struct {
+	Enabled bool `yaml:"enabled"`
+	// MaxExecutions is the maximum number of remediations for a single resource within Window.
+	MaxExecutions int `yaml:"maxExecutions" validate:"required_if=Enabled true,omitempty,min=1"`
+	// Window is the period of time in which MaxExecutions applies.
+	Window time.Duration `yaml:"window" validate:"required_if=Enabled true"`
+	// RollbackCommand is an optional Go template of a command which reverts the remediation, e.g. `kubectl rollout undo {{ .TopOwnerRef }} -n {{ .Event.Namespace }}`.
+	RollbackCommand string `yaml:"rollbackCommand,omitempty"`
+}
+
+// ActionStepErrorPolicy defines what happens when an action pipeline step fails.
+type ActionStepErrorPolicy string
+
+const (
+	// StopOnActionStepError stops the pipeline execution. It's the default policy.
+	StopOnActionStepError ActionStepErrorPolicy = "stop"
+	// ContinueOnActionStepError executes the next steps.
+	ContinueOnActionStepError ActionStepErrorPolicy = "continue"
+)
+
+// ActionStep defines a single step of an action pipeline.
+type ActionStep struct {
+	// Name identifies the step output in the next steps, e.g. `{{ .Steps.getPod.Output }}`.
+	Name string `yaml:"name" validate:"required"`
+	// Command is a Go template rendered just before the step execution. Available data: `.Event`, `.Object`, `.TopOwnerRef`, `.Owner` and `.Steps`.
+	Command string                `yaml:"command" validate:"required"`
+	OnError ActionStepErrorPolicy `yaml:"onError" validate:"omitempty,oneof=stop continue"`
 }
 
 // ActionBindings contains configuration for action bindings.
@@ -303,8 +637,12 @@ type ActionBindings struct {
 
 // Sources contains configuration for Botkube app sources.
 type Sources struct {
-	DisplayName string  `yaml:"displayName"`
-	Plugins     Plugins `yaml:",inline" koanf:",remain"`
+	DisplayName string `yaml:"displayName"`
+	// Notification contains default settings for notifications from this source binding.
+	Notification NotificationSettings `yaml:"notification,omitempty"`
+	// IncomingWebhook contains incoming webhook settings for source plugins from this binding.
+	IncomingWebhook SourceIncomingWebhook `yaml:"incomingWebhook,omitempty"`
+	Plugins         Plugins               `yaml:",inline" koanf:",remain"`
 }
 
 // GetPlugins returns Sources.Plugins.
@@ -334,6 +672,20 @@ type PolicyRule struct {
 	User UserPolicySubject `yaml:"user"`
 	// Group is the policy subject for group.
 	Group GroupPolicySubject `yaml:"group"`
+	// ServiceAccount is the policy subject for the ServiceAccount whose short-lived token is minted for each executed command.
+	// If set, executor plugins get the kubeconfig with the minted token instead of the one impersonating the user and group.
+	// It's not used by source plugins.
+	ServiceAccount ServiceAccountPolicySubject `yaml:"serviceAccount,omitempty"`
+}
+
+// HasChatUserSubject returns true if the user, group or ServiceAccount subject is resolved from the chat user.
+func (r *PolicyRule) HasChatUserSubject() bool {
+	return r.User.Type == ChatUserPolicySubjectType || r.Group.Type == ChatUserPolicySubjectType || r.ServiceAccount.Type == ChatUserPolicySubjectType
+}
+
+// HasServiceAccountSubject returns true if tokens of a ServiceAccount are minted for executed commands.
+func (r *PolicyRule) HasServiceAccountSubject() bool {
+	return r.ServiceAccount.Type != EmptyPolicySubjectType
 }
 
 // GroupPolicySubject is the RBAC subject.
@@ -368,6 +720,23 @@ type UserStaticSubject struct {
 	Value string `yaml:"value"`
 }
 
+// ServiceAccountPolicySubject is the RBAC subject for the ServiceAccount whose tokens are minted for executed commands.
+// The ServiceAccount name is sanitized, so names of channels and chat users can be used.
+type ServiceAccountPolicySubject struct {
+	// Type is the type of policy subject.
+	Type PolicySubjectType `yaml:"type"`
+	// Static is static reference of subject for given static policy rule.
+	Static UserStaticSubject `yaml:"static"`
+	// Prefix is optional string prefixed to subjects.
+	Prefix string `yaml:"prefix"`
+	// Namespace is the namespace of the ServiceAccount.
+	Namespace string `yaml:"namespace"`
+	// Expiration is the lifetime of minted tokens. Kubernetes requires at least 10 minutes, which is also the default.
+	Expiration time.Duration `yaml:"expiration"`
+	// Audiences are the intended audiences of minted tokens. If empty, the API server audiences are used.
+	Audiences []string `yaml:"audiences"`
+}
+
 // PolicySubjectType defines the types for policy subjects.
 type PolicySubjectType string
 
@@ -378,6 +747,9 @@ const (
 	StaticPolicySubjectType PolicySubjectType = "Static"
 	// ChannelNamePolicySubjectType is the channel name policy type.
 	ChannelNamePolicySubjectType PolicySubjectType = "ChannelName"
+	// ChatUserPolicySubjectType is the policy type which resolves the subject from the chat user who executed the command,
+	// according to the identity mapping.
+	ChatUserPolicySubjectType PolicySubjectType = "ChatUser"
 )
 
 // Executors contains executors configuration parameters.
@@ -400,6 +772,21 @@ func (e Executors) GetPlugins() Plugins {
 	return e.Plugins
 }
 
+// Processors contains processor plugins configuration parameters.
+// Processors receive every event emitted by sources before it is dispatched, and can modify or veto it.
+// They are called in alphabetical order of their names.
+type Processors struct {
+	DisplayName string `yaml:"displayName"`
+	// Timeout limits processing a single event by each plugin. Once it elapses, the plugin is skipped. Defaults to 5 seconds.
+	Timeout time.Duration `yaml:"timeout,omitempty" validate:"gte=0"`
+	Plugins Plugins       `yaml:",inline" koanf:",remain"`
+}
+
+// GetPlugins returns Processors.Plugins
+func (p Processors) GetPlugins() Plugins {
+	return p.Plugins
+}
+
 // Aliases contains aliases configuration.
 type Aliases map[string]Alias
 
@@ -409,9 +796,325 @@ type Alias struct {
 	DisplayName string `yaml:"displayName"`
 }
 
+// Runbooks contains configuration for executable runbooks.
+type Runbooks map[string]Runbook
+
+// Runbook defines an ordered list of steps executed interactively in a thread.
+type Runbook struct {
+	DisplayName string        `yaml:"displayName"`
+	Description string        `yaml:"description"`
+	Steps       []RunbookStep `yaml:"steps" validate:"required,min=1,dive"`
+}
+
+// RunbookStepType defines the type of runbook step.
+type RunbookStepType string
+
+const (
+	// CommandRunbookStep executes a Botkube command, e.g. `kubectl get pods`.
+	CommandRunbookStep RunbookStepType = "command"
+	// PromptRunbookStep asks user for an input value available for next steps under the step name.
+	PromptRunbookStep RunbookStepType = "prompt"
+	// ConfirmationRunbookStep waits for an explicit confirmation before going further.
+	ConfirmationRunbookStep RunbookStepType = "confirmation"
+)
+
+// RunbookStep defines a single runbook step.
+type RunbookStep struct {
+	Name string          `yaml:"name" validate:"required"`
+	Type RunbookStepType `yaml:"type" validate:"oneof=command prompt confirmation"`
+	// Command is executed for command steps. It supports Go template with the `{{ .Inputs }}` map populated by prompt steps.
+	Command string `yaml:"command" validate:"required_if=Type command"`
+	// Message is displayed to the user before executing a given step.
+	Message string `yaml:"message"`
+}
+
+// Filters contains configuration for filters evaluated for source notifications before they are sent to channels which bind them.
+type Filters map[string]Filter
+
+// FilterAction defines what happens with a notification matched by a filter.
+type FilterAction string
+
+const (
+	// DropFilterAction stops the notification from being sent.
+	DropFilterAction FilterAction = "drop"
+	// ModifyFilterAction changes the notification message.
+	ModifyFilterAction FilterAction = "modify"
+	// RerouteFilterAction sends the notification to other channels of the same communication platform.
+	RerouteFilterAction FilterAction = "reroute"
+)
+
+// Filter defines a CEL expression evaluated for source notifications sent to channels which bind it in `bindings.filters`.
+// Filters bound to a given channel are evaluated in alphabetical order of their names.
+type Filter struct {
+	Enabled bool `yaml:"enabled"`
+	// Expression is a CEL expression returning bool. Available variables: `event`, `message`, `source` and `cluster`.
+	Expression string       `yaml:"expression" validate:"required"`
+	Action     FilterAction `yaml:"action" validate:"oneof=drop modify reroute"`
+	// Modify is used for the modify action.
+	Modify FilterModification `yaml:"modify"`
+	// RouteTo contains source bindings used for the reroute action. Notification is sent to channels of the same communication platform
+	// which are bound to them, instead of the channel which binds the filter.
+	RouteTo []string `yaml:"routeTo" validate:"required_if=Action reroute"`
+}
+
+// FilterModification defines changes applied to a notification message.
+type FilterModification struct {
+	// Header replaces the message header.
+	Header string `yaml:"header"`
+	// Context adds a context note at the end of the message.
+	Context string `yaml:"context"`
+}
+
+// Enrichments contains HTTP hooks which fetch additional data for source notifications before they are sent.
+type Enrichments map[string]Enrichment
+
+// Enrichment defines an HTTP endpoint called with the event payload. The JSON response is available in templates as `.Enrichments.{name}`.
+// Enrichments are called in alphabetical order of their names, so responses of previous enrichments are sent to the next ones.
+type Enrichment struct {
+	Enabled bool `yaml:"enabled"`
+	// URL is the endpoint called with a POST request. The JSON body contains the `source`, `cluster`, `event`, `object` and `enrichments` fields.
+	URL string `yaml:"url" validate:"required_if=Enabled true"`
+	// Headers are added to the request, e.g. `Authorization`.
+	Headers map[string]string `yaml:"headers"`
+	// Timeout limits the request duration. If not set, 5s is used.
+	Timeout time.Duration `yaml:"timeout"`
+	// Sources limits the enrichment to notifications from given source bindings. If empty, all notifications are enriched.
+	Sources []string `yaml:"sources"`
+	// Context is an optional Go template added as a context note to the message, e.g. `Owner: {{ .Enrichments.catalog.team }}`.
+	// Available data: `.Event`, `.Object`, `.TopOwnerRef`, `.Enrichments` and `.Owner`.
+	Context string `yaml:"context"`
+}
+
+// Escalations contains escalation policies for notifications which are not acknowledged in time.
+type Escalations map[string]EscalationPolicy
+
+// EscalationPolicy defines how a notification is escalated until it's acknowledged.
+// If a notification matches multiple policies, the first one in alphabetical order of names is used.
+type EscalationPolicy struct {
+	Enabled bool `yaml:"enabled"`
+	// Sources are source bindings for which notifications are escalated.
+	Sources []string `yaml:"sources" validate:"required_if=Enabled true"`
+	// Condition is an optional CEL expression which limits the escalated notifications, e.g. `event.Level == "error"`.
+	// Available variables: `event`, `message`, `source` and `cluster`.
+	Condition string `yaml:"condition"`
+	// Steps are executed one by one until the notification is acknowledged.
+	Steps []EscalationStep `yaml:"steps" validate:"required_if=Enabled true,dive"`
+}
+
+// EscalationStep defines a single escalation step.
+type EscalationStep struct {
+	// After is the time since the original notification after which the step is executed.
+	After time.Duration `yaml:"after" validate:"required"`
+	// Mentions are added to the re-sent notification, e.g. `<!here>` or `<@U0123456>` for Slack.
+	Mentions []string `yaml:"mentions"`
+	// RouteTo contains source bindings used to select channels and sinks for the re-sent notification, e.g. a secondary channel or a PagerDuty sink.
+	// If empty, the notification is re-sent to the original channels.
+	RouteTo []string `yaml:"routeTo"`
+}
+
+// Tickets contains policies which automatically file tickets for recurring failures.
+type Tickets map[string]TicketPolicy
+
+// TicketPolicy defines when tickets are filed, commented and closed. Filed tickets are tracked per resource and event reason,
+// so repeated failures are added as comments to the already open ticket instead of creating duplicates.
+type TicketPolicy struct {
+	Enabled bool `yaml:"enabled"`
+	// Sources are source bindings for which notifications are evaluated. To close tickets on recovery, include source bindings which emit recovery events.
+	Sources []string `yaml:"sources" validate:"required_if=Enabled true"`
+	// Condition is an optional CEL expression which matches failures, e.g. `event.Type == "error"`. If empty, all events which don't match ResolveCondition are failures.
+	// Available variables: `event`, `message`, `source` and `cluster`.
+	Condition string `yaml:"condition"`
+	// ResolveCondition is an optional CEL expression which matches recovery events, e.g. `event.Level == "info"`.
+	// Open tickets for the resource of a matching event are closed. If empty, tickets are never closed automatically.
+	ResolveCondition string `yaml:"resolveCondition"`
+	// Threshold is the number of failures within Window after which a ticket is filed. Defaults to 1.
+	Threshold int `yaml:"threshold" validate:"min=0"`
+	// Window is the period of time in which Threshold applies. Required if Threshold is greater than 1.
+	Window time.Duration `yaml:"window"`
+	// CommentInterval is the minimum period of time between comments added for repeated failures. If empty, each failure is commented.
+	CommentInterval time.Duration `yaml:"commentInterval"`
+	// Title is an optional Go template of the ticket title, e.g. `{{ .Event.Kind }} {{ .Event.Name }} is failing`.
+	// Available data: `.Event`, `.Source`, `.Cluster` and `.Count`.
+	Title string `yaml:"title"`
+	// GitHub files tickets as GitHub issues. Exactly one of GitHub and Jira must be enabled.
+	GitHub GitHubTickets `yaml:"github"`
+	// Jira files tickets as Jira issues. Exactly one of GitHub and Jira must be enabled.
+	Jira JiraTickets `yaml:"jira"`
+}
+
+// GitHubTickets contains configuration for filing GitHub issues.
+type GitHubTickets struct {
+	Enabled bool `yaml:"enabled"`
+	// BaseURL is the GitHub Enterprise API address, e.g. `https://github.example.com/api/v3/`. If empty, github.com is used.
+	BaseURL string `yaml:"baseURL"`
+	// Token is a personal access token with permissions to create and edit issues.
+	Token string `yaml:"token" validate:"required_if=Enabled true"`
+	Owner string `yaml:"owner" validate:"required_if=Enabled true"`
+	Repo  string `yaml:"repo" validate:"required_if=Enabled true"`
+	// Labels are added to filed issues.
+	Labels []string `yaml:"labels"`
+}
+
+// JiraTickets contains configuration for filing Jira issues.
+type JiraTickets struct {
+	Enabled bool `yaml:"enabled"`
+	// URL is the Jira address, e.g. `https://example.atlassian.net`.
+	URL string `yaml:"url" validate:"required_if=Enabled true"`
+	// Username is used together with Token for basic authentication. If empty, Token is sent as a bearer token.
+	Username string `yaml:"username"`
+	// Token is an API token or a personal access token.
+	Token   string `yaml:"token" validate:"required_if=Enabled true"`
+	Project string `yaml:"project" validate:"required_if=Enabled true"`
+	// IssueType is the type of filed issues. Defaults to `Bug`.
+	IssueType string `yaml:"issueType"`
+	// Labels are added to filed issues.
+	Labels []string `yaml:"labels"`
+	// CloseTransition is the name of the workflow transition used to close issues. Defaults to `Done`.
+	CloseTransition string `yaml:"closeTransition"`
+}
+
+// MaintenanceWindows contains recurring periods of time during which notifications are not sent.
+type MaintenanceWindows map[string]MaintenanceWindow
+
+// MaintenanceMode defines what happens with notifications during a maintenance window.
+type MaintenanceMode string
+
+const (
+	// MaintenanceModeSuppress drops notifications during a maintenance window.
+	MaintenanceModeSuppress MaintenanceMode = "suppress"
+	// MaintenanceModeDigest collects notifications during a maintenance window and sends a summary once it ends.
+	MaintenanceModeDigest MaintenanceMode = "digest"
+)
+
+// MaintenanceWindow defines a recurring maintenance window.
+type MaintenanceWindow struct {
+	Enabled bool `yaml:"enabled"`
+	// Schedule is a cron expression with five fields, or one of `@hourly`, `@daily`, `@weekly` and `@monthly` descriptors, which defines when the window starts.
+	Schedule string `yaml:"schedule" validate:"required_if=Enabled true"`
+	// Timezone is an IANA time zone name used to evaluate Schedule. Defaults to UTC.
+	Timezone string `yaml:"timezone"`
+	// Duration defines how long the window lasts.
+	Duration time.Duration `yaml:"duration" validate:"required_if=Enabled true"`
+	// Mode is either `suppress` or `digest`. Defaults to `suppress`.
+	Mode MaintenanceMode `yaml:"mode" validate:"omitempty,oneof=suppress digest"`
+	// Namespaces limits the window to events from given Kubernetes namespaces. If not defined, all notifications are matched.
+	Namespaces RegexConstraints `yaml:"namespaces"`
+	// Selector is a Kubernetes label selector which limits the window to events about matching objects, e.g. `team=payments,tier!=frontend`.
+	Selector string `yaml:"selector"`
+	// Sources are source bindings for which notifications are not sent to channels and sinks. If empty, all source bindings are matched.
+	// To limit the window to a given channel, use the source bindings of that channel.
+	Sources []string `yaml:"sources"`
+}
+
+// Routing contains rules which route notifications to channels based on labels and annotations of the resources they are about,
+// so a single source binding can serve many teams.
+type Routing struct {
+	Enabled bool `yaml:"enabled"`
+	// Sources are source bindings for which notifications are routed. If empty, notifications from all source bindings are routed.
+	Sources []string `yaml:"sources"`
+	// Rules are evaluated in order. A notification is sent to channels of all matching rules.
+	Rules []RoutingRule `yaml:"rules" validate:"dive"`
+	// Fallback contains channels used if no rule matches. If empty, such notifications are sent to channels bound to their source bindings.
+	Fallback []string `yaml:"fallback"`
+}
+
+// RoutingRule maps resources with given labels and annotations to channels.
+type RoutingRule struct {
+	// Labels which the resource must have. An empty value matches any value of a given label.
+	Labels map[string]string `yaml:"labels"`
+	// Annotations which the resource must have. An empty value matches any value of a given annotation.
+	Annotations map[string]string `yaml:"annotations"`
+	// Owners contains teams, one of which must own the resource. If empty, resources owned by any team or not owned at all are matched.
+	// Owners are resolved according to the `ownership` configuration.
+	Owners []string `yaml:"owners,omitempty"`
+	// Channels contains aliases or names of the configured channels the notification is sent to.
+	Channels []string `yaml:"channels" validate:"required,min=1"`
+}
+
+// Ownership maps resources to teams which own them. The owner is mentioned in notifications, available in templates as `.Owner`,
+// and can be matched by routing rules.
+//
+// Owners are resolved from annotations of the resource, then from annotations of its namespace, then from the Backstage catalog,
+// and finally from the ConfigMap directory.
+type Ownership struct {
+	Enabled bool `yaml:"enabled"`
+	// Annotations contains keys of annotations which hold the owner.
+	Annotations OwnershipAnnotations `yaml:"annotations,omitempty"`
+	// ConfigMap is a directory which maps namespaces to owning teams.
+	ConfigMap OwnershipConfigMap `yaml:"configMap,omitempty"`
+	// Backstage resolves owners of components from the Backstage software catalog.
+	Backstage OwnershipBackstage `yaml:"backstage,omitempty"`
+	// Teams contains on-call handles of teams, which are mentioned in notifications, e.g. `<!subteam^S012345>` for Slack user groups.
+	Teams map[string]OwnershipTeam `yaml:"teams,omitempty"`
+	// CacheTTL is the time for which namespaces, the ConfigMap directory and Backstage entities are cached. Defaults to 5m.
+	CacheTTL time.Duration `yaml:"cacheTTL,omitempty"`
+	// Groups are Kubernetes groups impersonated to read namespaces and the ConfigMap directory. Defaults to the default Botkube RBAC group.
+	Groups []string `yaml:"groups,omitempty"`
+}
+
+// OwnershipAnnotations contains keys of annotations which hold the owner. They are read from the resource and its namespace.
+type OwnershipAnnotations struct {
+	// Team is the annotation which holds the owning team. Defaults to `botkube.io/owner`.
+	Team string `yaml:"team,omitempty"`
+	// OnCall is the annotation which holds comma-separated on-call handles. It overrides handles configured for the team.
+	// Defaults to `botkube.io/on-call`.
+	OnCall string `yaml:"onCall,omitempty"`
+}
+
+// OwnershipConfigMap points to a ConfigMap whose data maps namespace names to owning teams, e.g. `payments-prod: payments`.
+// The directory is not used if the name is empty.
+type OwnershipConfigMap struct {
+	Namespace string `yaml:"namespace,omitempty" validate:"required_with=Name"`
+	Name      string `yaml:"name,omitempty"`
+}
+
+// OwnershipBackstage resolves owners from the Backstage software catalog. Resources are matched with components
+// by the `backstage.io/kubernetes-id` label, and the `spec.owner` of the component is used as the owning team.
+type OwnershipBackstage struct {
+	Enabled bool `yaml:"enabled"`
+	// URL is the base URL of the Backstage backend, e.g. `https://backstage.example.com`.
+	URL string `yaml:"url,omitempty" validate:"required_if=Enabled true,omitempty,url"`
+	// Token is sent as a bearer token in the Authorization header.
+	Token string `yaml:"token,omitempty"`
+}
+
+// OwnershipTeam holds details of a team.
+type OwnershipTeam struct {
+	// OnCall contains handles mentioned in notifications about resources owned by the team.
+	OnCall []string `yaml:"onCall,omitempty"`
+}
+
+// AnalyticsBackend defines the backend anonymous analytics are sent to.
+type AnalyticsBackend string
+
+const (
+	// SegmentAnalyticsBackend sends analytics to Twilio Segment, if the API key was set during the build.
+	SegmentAnalyticsBackend AnalyticsBackend = "segment"
+	// CollectorAnalyticsBackend sends analytics to a self-hosted collector endpoint.
+	CollectorAnalyticsBackend AnalyticsBackend = "collector"
+)
+
 // Analytics contains configuration parameters for analytics collection.
 type Analytics struct {
 	Disable bool `yaml:"disable"`
+	// Backend is the backend analytics are sent to. Defaults to `segment`.
+	Backend   AnalyticsBackend   `yaml:"backend,omitempty" validate:"omitempty,oneof=segment collector"`
+	Collector AnalyticsCollector `yaml:"collector,omitempty"`
+}
+
+// AnalyticsCollector contains settings of the self-hosted collector. Events are sent in batches as JSON documents,
+// described by the `analytics.CollectorBatch` type.
+type AnalyticsCollector struct {
+	// URL is the collector endpoint batches are POSTed to.
+	URL string `yaml:"url,omitempty"`
+	// Headers are added to each request, e.g. to authenticate Botkube.
+	Headers map[string]string `yaml:"headers,omitempty"`
+	// Timeout limits each request. Defaults to 30 seconds.
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+	// FlushInterval is the maximum time events are batched before they are sent. Defaults to 5 seconds.
+	FlushInterval time.Duration `yaml:"flushInterval,omitempty"`
+	// MaxBatchSize is the number of events which triggers sending the batch before the flush interval elapses. Defaults to 100.
+	MaxBatchSize int `yaml:"maxBatchSize,omitempty" validate:"gte=0"`
 }
 
 // RegexConstraints contains a list of allowed and excluded values.
@@ -487,6 +1190,103 @@ func (r *RegexConstraints) IsAllowed(value string) (bool, error) {
 // ChannelNotification contains notification configuration for a given platform.
 type ChannelNotification struct {
 	Disabled bool `yaml:"disabled"`
+	// NotificationSettings override the ones defined for source bindings and globally.
+	NotificationSettings `yaml:",inline" mapstructure:",squash"`
+}
+
+// NotificationSettings contains settings applied to notifications sent to channels. They can be defined globally,
+// for a given source binding, and for a given channel. Channel settings override source binding settings,
+// which override global settings. Empty settings are inherited.
+type NotificationSettings struct {
+	// Profile is the name of a notification profile, e.g. `quiet-prod`. Settings of the profile are applied first,
+	// so they can be tweaked with the other settings defined in the same place.
+	Profile string `yaml:"profile,omitempty"`
+	// MinLevel is the minimal level of events which are sent. Events without a level are always sent.
+	MinLevel Level `yaml:"minLevel,omitempty" validate:"omitempty,oneof=debug info warn error critical"`
+	// Filter is a CEL expression which must evaluate to true for notifications to be sent. It has access to the same variables as filters.
+	Filter string `yaml:"filter,omitempty"`
+	// AggregationWindow is the period of time during which notifications are collected and sent as a single message.
+	AggregationWindow time.Duration `yaml:"aggregationWindow,omitempty"`
+	// Locale is used to format dates in notifications, e.g. `de` or `en_GB`.
+	Locale string `yaml:"locale,omitempty"`
+	// QuietHours is a daily period of time during which only critical events are sent, e.g. `22:00-07:00` or `22:00-07:00 Europe/Berlin`.
+	// Times are in UTC unless an IANA time zone name is given.
+	QuietHours string `yaml:"quietHours,omitempty"`
+	// QuietHoursMode is either `suppress` or `digest`. Defaults to `suppress`.
+	QuietHoursMode QuietHoursMode `yaml:"quietHoursMode,omitempty" validate:"omitempty,oneof=suppress digest"`
+	// ContentFilter is the name of a content filter defined under `settings.contentFilters`, which masks or blocks unwanted content in notifications.
+	ContentFilter string `yaml:"contentFilter,omitempty"`
+}
+
+// QuietHoursMode defines what happens with non-critical notifications during quiet hours.
+type QuietHoursMode string
+
+const (
+	// QuietHoursModeSuppress drops non-critical notifications during quiet hours.
+	QuietHoursModeSuppress QuietHoursMode = "suppress"
+	// QuietHoursModeDigest collects non-critical notifications during quiet hours and sends them as a single digest once quiet hours end.
+	QuietHoursModeDigest QuietHoursMode = "digest"
+)
+
+// ContentFilterAction defines what happens with notifications which contain content matched by a content filter.
+type ContentFilterAction string
+
+const (
+	// ContentFilterActionMask replaces matched content.
+	ContentFilterActionMask ContentFilterAction = "mask"
+	// ContentFilterActionBlock drops notifications with matched content.
+	ContentFilterActionBlock ContentFilterAction = "block"
+)
+
+// PIIType is a type of personal data detected by content filters.
+type PIIType string
+
+const (
+	// EmailPII matches email addresses.
+	EmailPII PIIType = "email"
+	// PhoneNumberPII matches phone numbers in the international format, e.g. `+48 123 456 789`.
+	PhoneNumberPII PIIType = "phoneNumber"
+	// CreditCardPII matches credit card numbers.
+	CreditCardPII PIIType = "creditCard"
+	// IPAddressPII matches IPv4 addresses.
+	IPAddressPII PIIType = "ipAddress"
+)
+
+// ContentFilter contains configuration of masking, or blocking, unwanted content, such as profanity or personal data,
+// in notifications sent to channels which select it. Unlike the redaction of sensitive values, it applies only to selected channels,
+// e.g. public ones.
+type ContentFilter struct {
+	// Action is either `mask` or `block`. Defaults to `mask`.
+	Action ContentFilterAction `yaml:"action,omitempty" validate:"omitempty,oneof=mask block"`
+	// Replacement is the text which replaces masked content. Defaults to `***`.
+	Replacement string `yaml:"replacement,omitempty"`
+	// Words are matched as whole words, case-insensitively.
+	Words []string `yaml:"words,omitempty"`
+	// Patterns are regular expressions of unwanted content. If a pattern has a capturing group, only the first group is masked.
+	Patterns []string `yaml:"patterns,omitempty"`
+	// PII contains types of detected personal data: `email`, `phoneNumber`, `creditCard` and `ipAddress`.
+	PII []PIIType `yaml:"pii,omitempty" validate:"dive,oneof=email phoneNumber creditCard ipAddress"`
+}
+
+// IsEmpty returns true if no setting is defined.
+func (s NotificationSettings) IsEmpty() bool {
+	return s == NotificationSettings{}
+}
+
+// Onboarding contains configuration of the setup wizard, which lets users pick sources, the minimal level and quiet hours of a channel.
+type Onboarding struct {
+	// Enabled posts the setup wizard to each channel with enabled notifications once Botkube connects to it for the first time.
+	Enabled bool `yaml:"enabled"`
+}
+
+// FloodProtection contains configuration of the circuit breaker for notifications. Once the notification rate of a channel exceeds
+// the threshold, the channel is switched to the digest mode, and a single summary is sent once the rate subsides.
+type FloodProtection struct {
+	Enabled bool `yaml:"enabled"`
+	// MaxNotifications is the maximum number of notifications sent to a single channel within Window, before it's switched to the digest mode.
+	MaxNotifications int `yaml:"maxNotifications" validate:"required_if=Enabled true,omitempty,min=1"`
+	// Window is the period of time in which MaxNotifications applies. The rate is checked every Window until it subsides.
+	Window time.Duration `yaml:"window" validate:"required_if=Enabled true"`
 }
 
 // Communications contains communication platforms that are supported.
@@ -499,6 +1299,10 @@ type Communications struct {
 	Webhook       Webhook       `yaml:"webhook,omitempty"`
 	Elasticsearch Elasticsearch `yaml:"elasticsearch,omitempty"`
 	PagerDuty     PagerDuty     `yaml:"pagerDuty,omitempty"`
+	Kafka         Kafka         `yaml:"kafka,omitempty"`
+	NATS          NATS          `yaml:"nats,omitempty"`
+	S3Archive     S3Archive     `yaml:"s3Archive,omitempty"`
+	Warehouse     Warehouse     `yaml:"warehouse,omitempty"`
 }
 
 // SocketSlack configuration to authentication and send notifications
@@ -533,16 +1337,30 @@ type GRPCServerTLSConfig struct {
 	InsecureSkipVerify bool   `yaml:"insecureSkipVerify"`
 }
 
+// ElasticsearchDistribution defines the search engine the Elasticsearch sink is compatible with.
+type ElasticsearchDistribution string
+
+const (
+	// ElasticElasticsearchDistribution is Elasticsearch. Lifecycle policies are managed with ILM.
+	ElasticElasticsearchDistribution ElasticsearchDistribution = "elasticsearch"
+	// OpenSearchElasticsearchDistribution is OpenSearch. Lifecycle policies are managed with ISM, and mapping types are never sent.
+	OpenSearchElasticsearchDistribution ElasticsearchDistribution = "opensearch"
+)
+
 // Elasticsearch config auth settings
 type Elasticsearch struct {
-	Enabled       bool                `yaml:"enabled"`
-	Username      string              `yaml:"username"`
-	Password      string              `yaml:"password"`
-	Server        string              `yaml:"server"`
-	SkipTLSVerify bool                `yaml:"skipTLSVerify"`
-	AWSSigning    AWSSigning          `yaml:"awsSigning"`
-	Indices       map[string]ELSIndex `yaml:"indices"  validate:"required_if=Enabled true,dive,omitempty,min=1"`
-	LogLevel      string              `yaml:"logLevel"`
+	Enabled  bool   `yaml:"enabled"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+	// APIKey is the Base64-encoded API key, used instead of the basic authentication.
+	APIKey        string `yaml:"apiKey,omitempty"`
+	Server        string `yaml:"server"`
+	SkipTLSVerify bool   `yaml:"skipTLSVerify"`
+	// Distribution enables the compatibility mode with a given search engine. Defaults to `elasticsearch`.
+	Distribution ElasticsearchDistribution `yaml:"distribution,omitempty" validate:"omitempty,oneof=elasticsearch opensearch"`
+	AWSSigning   AWSSigning                `yaml:"awsSigning"`
+	Indices      map[string]ELSIndex       `yaml:"indices"  validate:"required_if=Enabled true,dive,omitempty,min=1"`
+	LogLevel     string                    `yaml:"logLevel"`
 }
 
 // AWSSigning contains AWS configurations
@@ -558,10 +1376,35 @@ type ELSIndex struct {
 	Type     string `yaml:"type"`
 	Shards   int    `yaml:"shards"`
 	Replicas int    `yaml:"replicas"`
+	// DataStream writes events to the data stream with a given name, instead of daily indices.
+	DataStream bool             `yaml:"dataStream,omitempty"`
+	Template   ELSIndexTemplate `yaml:"template,omitempty"`
+	Lifecycle  ELSLifecycle     `yaml:"lifecycle,omitempty"`
 
 	Bindings SinkBindings `yaml:"bindings"`
 }
 
+// ELSIndexTemplate describes the composable index template which is created, or updated, before the first event is sent.
+// It matches daily indices, or the data stream, and applies the shards, replicas and lifecycle settings.
+type ELSIndexTemplate struct {
+	Enabled bool `yaml:"enabled"`
+	// Priority of the template. Defaults to 200, so it takes precedence over built-in templates.
+	Priority int `yaml:"priority,omitempty" validate:"gte=0"`
+}
+
+// ELSLifecycle describes the lifecycle policy which is created, or updated, before the first event is sent.
+// Elasticsearch uses ILM policies, and OpenSearch uses ISM policies.
+type ELSLifecycle struct {
+	Enabled bool `yaml:"enabled"`
+	// Policy is the name of the policy. Defaults to `<index name>-policy`.
+	Policy string `yaml:"policy,omitempty"`
+	// RolloverMaxAge and RolloverMaxSize, e.g. `50gb`, trigger the rollover of data stream backing indices. They are ignored for daily indices.
+	RolloverMaxAge  time.Duration `yaml:"rolloverMaxAge,omitempty"`
+	RolloverMaxSize string        `yaml:"rolloverMaxSize,omitempty"`
+	// DeleteAfter is the retention of indices. If empty, indices are not deleted.
+	DeleteAfter time.Duration `yaml:"deleteAfter,omitempty"`
+}
+
 // Mattermost configuration to authentication and send notifications
 type Mattermost struct {
 	Enabled  bool                                   `yaml:"enabled"`
@@ -570,6 +1413,24 @@ type Mattermost struct {
 	Token    string                                 `yaml:"token"`
 	Team     string                                 `yaml:"team"`
 	Channels IdentifiableMap[ChannelBindingsByName] `yaml:"channels"  validate:"required_if=Enabled true,dive,omitempty,min=1"`
+	// TLS holds TLS settings of connections to a self-hosted Mattermost server.
+	TLS MattermostTLS `yaml:"tls,omitempty"`
+}
+
+// MattermostTLS contains TLS settings of connections to the Mattermost server. Files can be mounted from Kubernetes Secrets.
+type MattermostTLS struct {
+	// CAFile is the path of PEM-encoded CA certificates used to verify the server, in addition to the system ones.
+	CAFile string `yaml:"caFile,omitempty"`
+	// MinVersion and CipherSuites override the global TLS policy.
+	MinVersion   string   `yaml:"minVersion,omitempty" validate:"omitempty,oneof=1.0 1.1 1.2 1.3"`
+	CipherSuites []string `yaml:"cipherSuites,omitempty"`
+	// InsecureSkipVerify disables the server certificate verification. Use it only for testing.
+	InsecureSkipVerify bool `yaml:"insecureSkipVerify,omitempty"`
+}
+
+// IsZero returns true if no TLS settings are set.
+func (t MattermostTLS) IsZero() bool {
+	return t.CAFile == "" && t.MinVersion == "" && len(t.CipherSuites) == 0 && !t.InsecureSkipVerify
 }
 
 // Teams creds for authentication with MS Teams
@@ -609,6 +1470,83 @@ type Webhook struct {
 	Enabled  bool         `yaml:"enabled"`
 	URL      string       `yaml:"url"`
 	Bindings SinkBindings `yaml:"bindings" validate:"required_if=Enabled true"`
+	// Format is the format of sent events. Defaults to `default`.
+	Format      WebhookFormat      `yaml:"format,omitempty" validate:"omitempty,oneof=default cloudEvents"`
+	CloudEvents WebhookCloudEvents `yaml:"cloudEvents,omitempty"`
+	// Template is the Go template of the request body. If set, it's used instead of the format.
+	// The template is rendered with the `Source`, `ClusterName`, `Timestamp`, `Namespace`, `Resource` and `Data` fields of an event.
+	// Sprig functions, such as `toJson`, are available.
+	Template string `yaml:"template,omitempty"`
+	// ContentType is the content type of the templated request body. Defaults to `application/json`.
+	ContentType string `yaml:"contentType,omitempty"`
+	// Headers are added to each request, e.g. to authenticate Botkube.
+	Headers map[string]string `yaml:"headers,omitempty"`
+	// Timeout limits each request. Defaults to 30 seconds.
+	Timeout time.Duration  `yaml:"timeout,omitempty"`
+	Signing WebhookSigning `yaml:"signing,omitempty"`
+	Retry   WebhookRetry   `yaml:"retry,omitempty"`
+	TLS     WebhookTLS     `yaml:"tls,omitempty"`
+}
+
+// WebhookSigning contains settings of the HMAC-SHA256 request signing.
+type WebhookSigning struct {
+	Enabled bool `yaml:"enabled"`
+	// Secret is the key used to sign request bodies.
+	Secret string `yaml:"secret" validate:"required_if=Enabled true"`
+	// Header is the header with the `sha256=<hex-encoded signature>` value. Defaults to `X-Botkube-Signature-256`.
+	Header string `yaml:"header,omitempty"`
+}
+
+// WebhookRetry contains settings of retries. Requests are retried on connection errors, and on 429 and 5xx responses.
+type WebhookRetry struct {
+	// MaxRetries is the number of retries. Events which couldn't be delivered are logged as dead letters.
+	MaxRetries int `yaml:"maxRetries" validate:"gte=0"`
+	// Backoff is the delay before the first retry. It's doubled after each retry. Defaults to 1 second.
+	Backoff time.Duration `yaml:"backoff,omitempty"`
+	// MaxBackoff limits the delay between retries. Defaults to 30 seconds.
+	MaxBackoff time.Duration `yaml:"maxBackoff,omitempty"`
+}
+
+// WebhookTLS contains TLS settings of requests. Files can be mounted from Kubernetes Secrets.
+type WebhookTLS struct {
+	// CAFile is the path of PEM-encoded CA certificates used to verify the server, in addition to the system ones.
+	CAFile string `yaml:"caFile,omitempty"`
+	// CertFile and KeyFile are paths of the PEM-encoded client certificate and its private key, used for mutual TLS.
+	CertFile string `yaml:"certFile,omitempty"`
+	KeyFile  string `yaml:"keyFile,omitempty"`
+	// MinVersion and CipherSuites override the global TLS policy.
+	MinVersion   string   `yaml:"minVersion,omitempty" validate:"omitempty,oneof=1.0 1.1 1.2 1.3"`
+	CipherSuites []string `yaml:"cipherSuites,omitempty"`
+	// InsecureSkipVerify disables the server certificate verification. Use it only for testing.
+	InsecureSkipVerify bool `yaml:"insecureSkipVerify,omitempty"`
+}
+
+// WebhookFormat defines the format of events sent by the webhook sink.
+type WebhookFormat string
+
+const (
+	// DefaultWebhookFormat sends events as Botkube JSON payloads.
+	DefaultWebhookFormat WebhookFormat = "default"
+	// CloudEventsWebhookFormat sends events as CloudEvents 1.0.
+	CloudEventsWebhookFormat WebhookFormat = "cloudEvents"
+)
+
+// CloudEventsMode defines the content mode of CloudEvents sent over HTTP.
+type CloudEventsMode string
+
+const (
+	// BinaryCloudEventsMode sends event attributes as `ce-` headers, and event data as the request body.
+	BinaryCloudEventsMode CloudEventsMode = "binary"
+	// StructuredCloudEventsMode sends the whole event as the `application/cloudevents+json` request body.
+	StructuredCloudEventsMode CloudEventsMode = "structured"
+)
+
+// WebhookCloudEvents contains settings of the CloudEvents format.
+type WebhookCloudEvents struct {
+	// Mode is the content mode. Defaults to `binary`.
+	Mode CloudEventsMode `yaml:"mode,omitempty" validate:"omitempty,oneof=binary structured"`
+	// Source is the `source` attribute of events. Defaults to `/botkube/<cluster name>`.
+	Source string `yaml:"source,omitempty"`
 }
 
 // PagerDuty describes the PagerDuty sink.
@@ -623,15 +1561,419 @@ type PagerDuty struct {
 	V2EventsAPIBasePath string
 }
 
+// KafkaEncoding defines how events are encoded in Kafka records.
+type KafkaEncoding string
+
+const (
+	// JSONKafkaEncoding encodes events as JSON objects.
+	JSONKafkaEncoding KafkaEncoding = "json"
+	// AvroKafkaEncoding encodes events as Avro records.
+	AvroKafkaEncoding KafkaEncoding = "avro"
+)
+
+// KafkaPartitionKey defines the key of Kafka records, which selects the topic partition.
+type KafkaPartitionKey string
+
+const (
+	// NoneKafkaPartitionKey distributes records across partitions.
+	NoneKafkaPartitionKey KafkaPartitionKey = "none"
+	// NamespaceKafkaPartitionKey publishes events from the same namespace to the same partition.
+	NamespaceKafkaPartitionKey KafkaPartitionKey = "namespace"
+	// ResourceKafkaPartitionKey publishes events about the same resource to the same partition, so their order is preserved.
+	ResourceKafkaPartitionKey KafkaPartitionKey = "resource"
+)
+
+// KafkaRequiredAcks defines delivery guarantees of Kafka records.
+type KafkaRequiredAcks string
+
+const (
+	// AllKafkaRequiredAcks waits until all in-sync replicas acknowledge the record.
+	AllKafkaRequiredAcks KafkaRequiredAcks = "all"
+	// LeaderKafkaRequiredAcks waits until the partition leader writes the record.
+	LeaderKafkaRequiredAcks KafkaRequiredAcks = "leader"
+	// NoneKafkaRequiredAcks doesn't wait for acknowledgements, so records may be lost.
+	NoneKafkaRequiredAcks KafkaRequiredAcks = "none"
+)
+
+// Kafka describes the Kafka sink.
+type Kafka struct {
+	// Enabled indicates if the Kafka sink is enabled.
+	Enabled bool `yaml:"enabled"`
+	// Brokers are addresses of brokers used to discover the Kafka cluster, e.g. `kafka:9092`.
+	Brokers []string `yaml:"brokers" validate:"required_if=Enabled true"`
+	// ClientID identifies Botkube in broker logs and quotas. Defaults to `botkube`.
+	ClientID string `yaml:"clientID,omitempty"`
+	// Topics are topics events are published to. The property name is an alias for a given topic configuration.
+	Topics map[string]KafkaTopic `yaml:"topics" validate:"required_if=Enabled true,dive"`
+	// Encoding is the format of records. Defaults to `json`.
+	Encoding KafkaEncoding `yaml:"encoding,omitempty" validate:"omitempty,oneof=json avro"`
+	// Avro contains settings used if the encoding is `avro`.
+	Avro KafkaAvro `yaml:"avro,omitempty"`
+	// PartitionKey is the event property used as the record key. Defaults to `none`.
+	PartitionKey KafkaPartitionKey `yaml:"partitionKey,omitempty" validate:"omitempty,oneof=none namespace resource"`
+	// RequiredAcks defines delivery guarantees. Defaults to `all`.
+	RequiredAcks KafkaRequiredAcks `yaml:"requiredAcks,omitempty" validate:"omitempty,oneof=all leader none"`
+	// MaxRetries is the number of retries of records which failed with a retriable error, e.g. during a leader election.
+	MaxRetries int `yaml:"maxRetries,omitempty" validate:"gte=0"`
+	// RetryBackoff is the time to wait before retrying a record.
+	RetryBackoff time.Duration `yaml:"retryBackoff,omitempty"`
+	// Timeout limits connecting to brokers, and waiting for acknowledgements.
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+	TLS     KafkaTLS      `yaml:"tls,omitempty"`
+	SASL    KafkaSASL     `yaml:"sasl,omitempty"`
+}
+
+// KafkaTopic describes a Kafka topic events are published to.
+type KafkaTopic struct {
+	Name     string       `yaml:"name" validate:"required"`
+	Bindings SinkBindings `yaml:"bindings"`
+}
+
+// KafkaAvro contains settings of the Avro encoding.
+type KafkaAvro struct {
+	// SchemaRegistry is used to register the record schema. If not set, records use the Avro single-object encoding.
+	SchemaRegistry KafkaSchemaRegistry `yaml:"schemaRegistry,omitempty"`
+}
+
+// KafkaSchemaRegistry describes a Confluent-compatible schema registry.
+type KafkaSchemaRegistry struct {
+	// URL is the schema registry URL, e.g. `http://schema-registry:8081`.
+	URL      string `yaml:"url,omitempty"`
+	Username string `yaml:"username,omitempty"`
+	Password string `yaml:"password,omitempty"`
+}
+
+// KafkaTLS contains TLS settings of connections to brokers. Files can be mounted from Kubernetes Secrets.
+type KafkaTLS struct {
+	Enabled bool `yaml:"enabled"`
+	// CAFile is the path of PEM-encoded CA certificates used to verify brokers, in addition to the system ones.
+	CAFile string `yaml:"caFile,omitempty"`
+	// CertFile and KeyFile are paths of the PEM-encoded client certificate and its private key, used for mutual TLS.
+	CertFile string `yaml:"certFile,omitempty"`
+	KeyFile  string `yaml:"keyFile,omitempty"`
+	// MinVersion and CipherSuites override the global TLS policy.
+	MinVersion   string   `yaml:"minVersion,omitempty" validate:"omitempty,oneof=1.0 1.1 1.2 1.3"`
+	CipherSuites []string `yaml:"cipherSuites,omitempty"`
+	// InsecureSkipVerify disables the broker certificate verification. Use it only for testing.
+	InsecureSkipVerify bool `yaml:"insecureSkipVerify,omitempty"`
+}
+
+// KafkaSASL contains SASL authentication settings.
+type KafkaSASL struct {
+	Enabled bool `yaml:"enabled"`
+	// Mechanism is one of: PLAIN, SCRAM-SHA-256, SCRAM-SHA-512. Defaults to PLAIN.
+	Mechanism string `yaml:"mechanism,omitempty" validate:"omitempty,oneof=PLAIN SCRAM-SHA-256 SCRAM-SHA-512"`
+	Username  string `yaml:"username,omitempty"`
+	Password  string `yaml:"password,omitempty"`
+}
+
+// NATSStorage defines the storage backend of a JetStream stream.
+type NATSStorage string
+
+const (
+	// FileNATSStorage stores messages on disk.
+	FileNATSStorage NATSStorage = "file"
+	// MemoryNATSStorage stores messages in memory.
+	MemoryNATSStorage NATSStorage = "memory"
+)
+
+// NATS describes the NATS integration. Events are published to subjects, and commands can be received on a dedicated subject.
+type NATS struct {
+	// Enabled indicates if the NATS integration is enabled.
+	Enabled bool `yaml:"enabled"`
+	// Servers are URLs of NATS servers, e.g. `nats://nats:4222`. Use the `tls://` scheme to require TLS.
+	Servers []string `yaml:"servers" validate:"required_if=Enabled true"`
+	// Name identifies the connection in the server monitoring. Defaults to `botkube`.
+	Name string `yaml:"name,omitempty"`
+	// Username and Password are used for the user/password authentication.
+	Username string `yaml:"username,omitempty"`
+	Password string `yaml:"password,omitempty"`
+	// Token is used for the token authentication.
+	Token string `yaml:"token,omitempty"`
+	// Timeout limits connecting to servers, and waiting for acknowledgements.
+	Timeout   time.Duration `yaml:"timeout,omitempty"`
+	TLS       NATSTLS       `yaml:"tls,omitempty"`
+	JetStream NATSJetStream `yaml:"jetStream,omitempty"`
+	// Subjects are subjects events are published to. The property name is an alias for a given subject configuration.
+	Subjects map[string]NATSSubject `yaml:"subjects" validate:"dive"`
+	Commands NATSCommands           `yaml:"commands,omitempty"`
+}
+
+// NATSSubject describes a NATS subject events are published to.
+type NATSSubject struct {
+	Name     string       `yaml:"name" validate:"required"`
+	Bindings SinkBindings `yaml:"bindings"`
+}
+
+// NATSTLS contains TLS settings of connections to NATS servers. Files can be mounted from Kubernetes Secrets.
+type NATSTLS struct {
+	Enabled bool `yaml:"enabled"`
+	// CAFile is the path of PEM-encoded CA certificates used to verify servers, in addition to the system ones.
+	CAFile string `yaml:"caFile,omitempty"`
+	// CertFile and KeyFile are paths of the PEM-encoded client certificate and its private key, used for mutual TLS.
+	CertFile string `yaml:"certFile,omitempty"`
+	KeyFile  string `yaml:"keyFile,omitempty"`
+	// MinVersion and CipherSuites override the global TLS policy.
+	MinVersion   string   `yaml:"minVersion,omitempty" validate:"omitempty,oneof=1.0 1.1 1.2 1.3"`
+	CipherSuites []string `yaml:"cipherSuites,omitempty"`
+	// InsecureSkipVerify disables the server certificate verification. Use it only for testing.
+	InsecureSkipVerify bool `yaml:"insecureSkipVerify,omitempty"`
+}
+
+// NATSJetStream contains JetStream settings. If enabled, events are published only once the stream persists them.
+type NATSJetStream struct {
+	Enabled bool `yaml:"enabled"`
+	// Stream is the name of the stream which captures subjects. If set, the stream is created, or updated with missing subjects.
+	// If empty, the stream has to be managed outside Botkube.
+	Stream string `yaml:"stream,omitempty"`
+	// Storage is the storage backend of the created stream. Defaults to `file`.
+	Storage NATSStorage `yaml:"storage,omitempty" validate:"omitempty,oneof=file memory"`
+	// Replicas is the number of replicas of the created stream. Defaults to 1.
+	Replicas int `yaml:"replicas,omitempty" validate:"gte=0"`
+	// MaxAge is the maximum age of messages in the created stream. If empty, messages don't expire.
+	MaxAge time.Duration `yaml:"maxAge,omitempty"`
+}
+
+// NATSCommands describes the subject Botkube receives commands on. Command output is sent to the reply subject of a given message.
+type NATSCommands struct {
+	Enabled bool `yaml:"enabled"`
+	// Subject is the subject Botkube subscribes to, e.g. `botkube.commands.my-cluster`.
+	Subject string `yaml:"subject" validate:"required_if=Enabled true"`
+	// QueueGroup is the queue group name. If set, each command is executed by only one of Botkube instances subscribed to the subject.
+	QueueGroup string              `yaml:"queueGroup,omitempty"`
+	Bindings   NATSCommandBindings `yaml:"bindings"`
+}
+
+// NATSCommandBindings contains executor bindings of NATS commands.
+type NATSCommandBindings struct {
+	Executors []string `yaml:"executors"`
+}
+
+// S3ArchiveFormat defines the format of archived objects.
+type S3ArchiveFormat string
+
+const (
+	// NDJSONS3ArchiveFormat stores events as newline-delimited JSON objects.
+	NDJSONS3ArchiveFormat S3ArchiveFormat = "ndjson"
+	// ParquetS3ArchiveFormat stores events as rows of a Parquet file.
+	ParquetS3ArchiveFormat S3ArchiveFormat = "parquet"
+)
+
+// S3ArchiveCompression defines the compression of archived objects.
+type S3ArchiveCompression string
+
+const (
+	// NoneS3ArchiveCompression disables the compression.
+	NoneS3ArchiveCompression S3ArchiveCompression = "none"
+	// GzipS3ArchiveCompression compresses objects with gzip.
+	GzipS3ArchiveCompression S3ArchiveCompression = "gzip"
+	// ZstdS3ArchiveCompression compresses objects with Zstandard.
+	ZstdS3ArchiveCompression S3ArchiveCompression = "zstd"
+)
+
+// S3Archive describes the sink which archives batches of events in S3-compatible object storages, such as AWS S3, Google Cloud Storage or MinIO.
+type S3Archive struct {
+	// Enabled indicates if the S3 archive sink is enabled.
+	Enabled bool   `yaml:"enabled"`
+	Bucket  string `yaml:"bucket" validate:"required_if=Enabled true"`
+	Region  string `yaml:"region,omitempty"`
+	// Endpoint is the URL of an S3-compatible API, e.g. `https://storage.googleapis.com` or `http://minio:9000`. If empty, AWS S3 is used.
+	Endpoint string `yaml:"endpoint,omitempty"`
+	// ForcePathStyle uses path-style URLs of objects, which are required by MinIO.
+	ForcePathStyle bool `yaml:"forcePathStyle,omitempty"`
+	// AccessKeyID and SecretAccessKey are static credentials, such as GCS HMAC keys.
+	// If empty, the default AWS credentials chain is used, which includes IAM roles for service accounts.
+	AccessKeyID     string `yaml:"accessKeyID,omitempty"`
+	SecretAccessKey string `yaml:"secretAccessKey,omitempty"`
+	// Prefix is the Go template of the object key prefix. Available fields: `ClusterName`, `Date`, `Year`, `Month`, `Day` and `Hour`.
+	// Defaults to `{{ .ClusterName }}/{{ .Year }}/{{ .Month }}/{{ .Day }}`.
+	Prefix string `yaml:"prefix,omitempty"`
+	// Format is the format of archived objects. Defaults to `ndjson`.
+	Format S3ArchiveFormat `yaml:"format,omitempty" validate:"omitempty,oneof=ndjson parquet"`
+	// Compression is the compression of archived objects. Defaults to `gzip`.
+	Compression S3ArchiveCompression `yaml:"compression,omitempty" validate:"omitempty,oneof=none gzip zstd"`
+	// FlushInterval is the maximum time events are batched before they are written. Defaults to 5 minutes.
+	FlushInterval time.Duration `yaml:"flushInterval,omitempty"`
+	// MaxBatchBytes is the size of JSON-encoded events which triggers writing the batch before the flush interval elapses. Defaults to 8 MiB.
+	MaxBatchBytes int          `yaml:"maxBatchBytes,omitempty" validate:"gte=0"`
+	Bindings      SinkBindings `yaml:"bindings"`
+}
+
+// WarehouseBackend defines the data warehouse events are streamed into.
+type WarehouseBackend string
+
+const (
+	// BigQueryWarehouseBackend streams events into Google BigQuery.
+	BigQueryWarehouseBackend WarehouseBackend = "bigQuery"
+	// ClickHouseWarehouseBackend inserts events into ClickHouse using its HTTP interface.
+	ClickHouseWarehouseBackend WarehouseBackend = "clickHouse"
+)
+
+// Warehouse describes the sink which streams events into a data warehouse table, enabling SQL analytics over historical events.
+// The dataset, or database, and the table are created if they don't exist, and missing columns are added.
+type Warehouse struct {
+	// Enabled indicates if the warehouse sink is enabled.
+	Enabled bool `yaml:"enabled"`
+	// Backend is the data warehouse events are streamed into. Defaults to `bigQuery`.
+	Backend WarehouseBackend `yaml:"backend,omitempty" validate:"omitempty,oneof=bigQuery clickHouse"`
+	// Table is the name of the table with events. Defaults to `botkube_events`.
+	Table      string              `yaml:"table,omitempty"`
+	BigQuery   WarehouseBigQuery   `yaml:"bigQuery,omitempty"`
+	ClickHouse WarehouseClickHouse `yaml:"clickHouse,omitempty"`
+	// FlushInterval is the maximum time events are batched before they are inserted. Defaults to 10 seconds.
+	FlushInterval time.Duration `yaml:"flushInterval,omitempty"`
+	// MaxBatchSize is the number of events which triggers inserting the batch before the flush interval elapses. Defaults to 500.
+	MaxBatchSize int          `yaml:"maxBatchSize,omitempty" validate:"gte=0"`
+	Bindings     SinkBindings `yaml:"bindings"`
+}
+
+// WarehouseBigQuery contains BigQuery settings.
+type WarehouseBigQuery struct {
+	Project string `yaml:"project,omitempty"`
+	Dataset string `yaml:"dataset,omitempty"`
+	// Location is the location of the created dataset, e.g. `US` or `europe-west1`. If empty, the BigQuery default is used.
+	Location string `yaml:"location,omitempty"`
+	// CredentialsFile is the path of the service account key file. If empty, the Application Default Credentials are used,
+	// which include Workload Identity.
+	CredentialsFile string `yaml:"credentialsFile,omitempty"`
+}
+
+// WarehouseClickHouse contains ClickHouse settings.
+type WarehouseClickHouse struct {
+	// URL is the address of the ClickHouse HTTP interface, e.g. `http://clickhouse:8123`.
+	URL      string `yaml:"url,omitempty"`
+	Database string `yaml:"database,omitempty"`
+	Username string `yaml:"username,omitempty"`
+	Password string `yaml:"password,omitempty"`
+}
+
 // CfgWatcher describes configuration for watching the configuration.
 type CfgWatcher struct {
 	Enabled   bool                `yaml:"enabled"`
 	Remote    RemoteCfgWatcher    `yaml:"remote"`
 	InCluster InClusterCfgWatcher `yaml:"inCluster"`
+	HotReload HotReloadCfgWatcher `yaml:"hotReload"`
 
 	Deployment K8sResourceRef `yaml:"deployment"`
 }
 
+// HotReloadCfgWatcher describes configuration for reloading the configuration without restarting the Botkube Pod.
+type HotReloadCfgWatcher struct {
+	// Enabled reloads all components, such as communication platforms, sources, executors and actions, in place instead of restarting the Deployment.
+	Enabled bool `yaml:"enabled"`
+	// SyncTimeout is the maximum time to wait until the changed configuration is propagated to mounted configuration files.
+	SyncTimeout time.Duration `yaml:"syncTimeout"`
+	// DrainTimeout is the maximum time to wait for in-flight notifications and actions before components are stopped.
+	DrainTimeout time.Duration `yaml:"drainTimeout"`
+}
+
+// ConfigCRD contains configuration for the BotkubeConfig custom resources, which are merged into the runtime configuration.
+type ConfigCRD struct {
+	Enabled bool `yaml:"enabled"`
+	// RBACGroupPrefix is prefixed to the namespace of a given BotkubeConfig resource to get the static RBAC group
+	// used by all plugins defined in this resource.
+	RBACGroupPrefix string           `yaml:"rbacGroupPrefix"`
+	Webhook         ConfigCRDWebhook `yaml:"webhook"`
+}
+
+// ConfigCRDWebhook contains configuration for the BotkubeConfig validating admission webhook.
+type ConfigCRDWebhook struct {
+	Enabled bool `yaml:"enabled"`
+	Port    int  `yaml:"port"`
+	// CertDir is the directory with the `tls.crt` and `tls.key` files used to serve the webhook.
+	CertDir string `yaml:"certDir"`
+}
+
+// GitSync contains configuration for syncing the configuration from a Git repository.
+// Configuration files from the repository are merged after the configuration files, and changes pushed to the branch are applied by the Config Watcher.
+type GitSync struct {
+	Enabled bool `yaml:"enabled"`
+	// Repository is the HTTPS or SSH URL of the Git repository.
+	Repository string `yaml:"repository" validate:"required_if=Enabled true"`
+	Branch     string `yaml:"branch" validate:"required_if=Enabled true"`
+	// Path is the path to a configuration file or to a directory with configuration files in the repository.
+	// YAML files in a directory, including nested ones, are merged in the alphabetical order.
+	Path string      `yaml:"path"`
+	Auth GitSyncAuth `yaml:"auth"`
+	// PollInterval is the interval of checking the branch for new commits. Polling is disabled if it's zero.
+	PollInterval time.Duration  `yaml:"pollInterval"`
+	Webhook      GitSyncWebhook `yaml:"webhook"`
+	// CacheDir is the directory where the repository is fetched.
+	CacheDir string `yaml:"cacheDir"`
+}
+
+// GitSyncAuth contains credentials for the Git repository. Use Token for HTTPS repositories, and SSHKeyPath for SSH ones.
+type GitSyncAuth struct {
+	Username   string `yaml:"username"`
+	Token      string `yaml:"token"`
+	SSHKeyPath string `yaml:"sshKeyPath"`
+}
+
+// GitSyncWebhook contains configuration for the push webhook, which triggers checking the branch without waiting for the next poll.
+type GitSyncWebhook struct {
+	Enabled bool `yaml:"enabled"`
+	Port    int  `yaml:"port"`
+	// Secret is used to verify the GitHub signature or the GitLab token of webhook requests. Requests are not verified if it's empty.
+	Secret string `yaml:"secret"`
+}
+
+// SecretProviders contains external secret providers keyed by names used in secret references.
+// A string value in the form of `secret://{provider}/{name}#{key}` is replaced with the secret fetched from a given provider.
+// The `#{key}` suffix is optional and selects a given key of a JSON secret.
+type SecretProviders map[string]SecretProvider
+
+// SecretProviderType defines the type of external secret provider.
+type SecretProviderType string
+
+const (
+	// VaultSecretProviderType is the HashiCorp Vault secret provider.
+	VaultSecretProviderType SecretProviderType = "vault"
+	// AWSSecretsManagerProviderType is the AWS Secrets Manager secret provider.
+	AWSSecretsManagerProviderType SecretProviderType = "awsSecretsManager"
+	// GCPSecretManagerProviderType is the GCP Secret Manager secret provider.
+	GCPSecretManagerProviderType SecretProviderType = "gcpSecretManager"
+)
+
+// SecretProvider contains configuration for a given external secret provider.
+type SecretProvider struct {
+	Type SecretProviderType `yaml:"type" validate:"oneof=vault awsSecretsManager gcpSecretManager"`
+	// RefreshInterval is the interval of fetching secrets again to detect rotation. If not set, secrets are fetched only on startup.
+	RefreshInterval   time.Duration             `yaml:"refreshInterval"`
+	Vault             VaultSecretProvider       `yaml:"vault"`
+	AWSSecretsManager AWSSecretsManagerProvider `yaml:"awsSecretsManager"`
+	GCPSecretManager  GCPSecretManagerProvider  `yaml:"gcpSecretManager"`
+}
+
+// VaultSecretProvider contains configuration for the HashiCorp Vault secret provider. Both KV v1 and v2 secrets engines are supported.
+type VaultSecretProvider struct {
+	Address   string `yaml:"address"`
+	Namespace string `yaml:"namespace"`
+	// Token is the static Vault token. It is not used if the Kubernetes auth method is configured.
+	Token          string              `yaml:"token"`
+	KubernetesAuth VaultKubernetesAuth `yaml:"kubernetesAuth"`
+}
+
+// VaultKubernetesAuth contains configuration for the Vault Kubernetes auth method.
+type VaultKubernetesAuth struct {
+	Role      string `yaml:"role"`
+	MountPath string `yaml:"mountPath"`
+	// TokenPath is the path of the Kubernetes service account token file. Defaults to the token mounted in the Pod.
+	TokenPath string `yaml:"tokenPath"`
+}
+
+// AWSSecretsManagerProvider contains configuration for the AWS Secrets Manager secret provider.
+// The default AWS credentials chain is used, which includes IAM roles for service accounts.
+type AWSSecretsManagerProvider struct {
+	Region  string `yaml:"region"`
+	RoleArn string `yaml:"roleArn"`
+}
+
+// GCPSecretManagerProvider contains configuration for the GCP Secret Manager secret provider.
+// Application Default Credentials are used.
+type GCPSecretManagerProvider struct {
+	Project string `yaml:"project"`
+}
+
 // RemoteCfgWatcher describes configuration for watching the configuration using remote config provider.
 type RemoteCfgWatcher struct {
 	PollInterval time.Duration `yaml:"pollInterval"`
@@ -654,6 +1996,494 @@ type Settings struct {
 	InformersResyncPeriod   time.Duration    `yaml:"informersResyncPeriod"`
 	Kubeconfig              string           `yaml:"kubeconfig"`
 	SACredentialsPathPrefix string           `yaml:"saCredentialsPathPrefix"`
+	Execution               Execution        `yaml:"execution"`
+	Authorization           Authorization    `yaml:"authorization"`
+	Incidents               Incidents        `yaml:"incidents"`
+	// Notification contains global default settings for notifications.
+	Notification NotificationSettings `yaml:"notification"`
+	// NotificationProfiles contains custom notification profiles. They override built-in profiles with the same name.
+	NotificationProfiles map[string]NotificationSettings `yaml:"notificationProfiles,omitempty"`
+	// ContentFilters contains filters of unwanted content in notifications, indexed by names. Channels select them with the `contentFilter` notification setting.
+	ContentFilters map[string]ContentFilter `yaml:"contentFilters,omitempty" validate:"dive"`
+	// FloodProtection contains configuration of the circuit breaker which summarizes notifications for channels flooded with events.
+	FloodProtection FloodProtection `yaml:"floodProtection"`
+	// Onboarding contains configuration of the setup wizard posted to new channels.
+	Onboarding Onboarding `yaml:"onboarding"`
+	// FeatureFlags contains global states of experimental features, indexed by flag names. Flags which are not set use their defaults.
+	FeatureFlags map[string]bool `yaml:"featureFlags,omitempty"`
+	// ConfigHistory contains configuration for keeping the history of applied configurations.
+	ConfigHistory ConfigHistory `yaml:"configHistory"`
+	// Tracing contains configuration for exporting OpenTelemetry traces of handled events and commands.
+	Tracing Tracing `yaml:"tracing"`
+	// Metrics contains configuration of Prometheus metrics exposed on the metrics port.
+	Metrics Metrics `yaml:"metrics"`
+	// AuditLog contains configuration of the structured audit log.
+	AuditLog AuditLog `yaml:"auditLog"`
+	// Redaction contains configuration of masking sensitive values in messages.
+	Redaction Redaction `yaml:"redaction"`
+	// IdentityMapping contains configuration of resolving chat users to Kubernetes users and groups for the `ChatUser` RBAC policy.
+	IdentityMapping IdentityMapping `yaml:"identityMapping"`
+	// TLS contains the TLS policy of all outbound connections. Integration-specific TLS settings take precedence.
+	TLS TLSPolicy `yaml:"tls,omitempty"`
+	// EventPipeline contains configuration of worker pools and queues between stages of event processing.
+	EventPipeline EventPipeline `yaml:"eventPipeline,omitempty"`
+	// EventBuffer contains configuration of the persistent buffer of notifications which couldn't be sent.
+	EventBuffer EventBuffer `yaml:"eventBuffer,omitempty"`
+	// Shutdown contains configuration of the graceful shutdown.
+	Shutdown Shutdown `yaml:"shutdown,omitempty"`
+	// LeaderElection contains configuration of electing the active replica.
+	LeaderElection LeaderElection `yaml:"leaderElection,omitempty"`
+	// Sharding contains configuration of splitting the watch space across replicas.
+	Sharding Sharding `yaml:"sharding,omitempty"`
+	// Overview contains configuration of the cluster overview dashboard returned by the `@Botkube overview` command.
+	Overview Overview `yaml:"overview,omitempty"`
+	// Watch contains configuration of the `@Botkube watch` command, which renders live status of a given resource in a chat message.
+	Watch Watch `yaml:"watch,omitempty"`
+	// UpgradeCheck contains configuration of the `@Botkube upgrade-check` command, which reports cluster readiness for a Kubernetes upgrade.
+	UpgradeCheck UpgradeCheck `yaml:"upgradeCheck,omitempty"`
+	// Autocomplete contains configuration of options suggested in interactive pickers, such as namespaces or Pod names.
+	Autocomplete Autocomplete `yaml:"autocomplete,omitempty"`
+	// DeliverySLO contains configuration of the notification delivery objective, and the periodic reliability report.
+	DeliverySLO DeliverySLO `yaml:"deliverySLO,omitempty"`
+}
+
+// Overview contains configuration of the cluster overview dashboard.
+type Overview struct {
+	// Groups are impersonated to read the cluster state, so the dashboard shows only resources they can read.
+	// Defaults to `botkube-plugins-default`.
+	Groups []string `yaml:"groups,omitempty"`
+	// MaxItems limits the number of items in each list, e.g. pending Pods or warning events. Defaults to 5.
+	MaxItems int `yaml:"maxItems,omitempty" validate:"gte=0"`
+	// EventsWindow is the time range recent warning events are listed from. Defaults to 1 hour.
+	EventsWindow time.Duration `yaml:"eventsWindow,omitempty"`
+}
+
+// Watch contains configuration of watching resources from chat.
+type Watch struct {
+	// Groups are impersonated to get and watch resources, so only resources they can read are watched.
+	// Defaults to `botkube-plugins-default`.
+	Groups []string `yaml:"groups,omitempty"`
+	// Duration is the time a resource is watched for, unless a different one is given with the `--for` flag. Defaults to 10 minutes.
+	Duration time.Duration `yaml:"duration,omitempty"`
+	// MaxDuration is the maximum time which can be given with the `--for` flag. Defaults to 1 hour.
+	MaxDuration time.Duration `yaml:"maxDuration,omitempty"`
+	// UpdateInterval is the minimum time between updates of the status message, to stay within rate limits of chat platforms.
+	// Defaults to 5 seconds.
+	UpdateInterval time.Duration `yaml:"updateInterval,omitempty"`
+}
+
+// UpgradeCheck contains configuration of the pre-upgrade cluster readiness report.
+type UpgradeCheck struct {
+	// Groups are impersonated to read the cluster state, so the report covers only resources they can read.
+	// Defaults to `botkube-plugins-default`.
+	Groups []string `yaml:"groups,omitempty"`
+}
+
+// Autocomplete contains configuration of the autocomplete service, which suggests options for external selects.
+// Suggestions are served from an in-memory cache of resource metadata, so they stay fast on large clusters.
+type Autocomplete struct {
+	Enabled bool `yaml:"enabled"`
+	// Groups are impersonated to list and watch cached resources, so only resources they can read are suggested.
+	// Defaults to `botkube-plugins-default`.
+	Groups []string `yaml:"groups,omitempty"`
+	// MaxOptions limits the number of suggested options. Defaults to 100, which is the maximum supported by Slack.
+	MaxOptions int `yaml:"maxOptions,omitempty" validate:"gte=0,lte=100"`
+	// ResyncPeriod is the period of cache resyncs and resource kinds refreshes. Defaults to 30 minutes.
+	ResyncPeriod time.Duration `yaml:"resyncPeriod,omitempty"`
+}
+
+// DeliverySLO contains configuration of the notification delivery objective. Delivery latency is measured from receiving
+// the event from a source to sending the notification by a communication platform or sink.
+type DeliverySLO struct {
+	// LatencyTarget is the time in which notifications should be delivered. Defaults to 30 seconds.
+	LatencyTarget time.Duration `yaml:"latencyTarget,omitempty"`
+	// Objective is the percentage of notifications which should be delivered successfully within LatencyTarget. Defaults to 99.
+	Objective float64 `yaml:"objective,omitempty" validate:"gte=0,lte=100"`
+	// Report contains configuration of the reliability report sent to all bots at the end of each period.
+	Report DeliveryReport `yaml:"report,omitempty"`
+}
+
+// DeliveryReport contains configuration of the periodic reliability report of notification delivery.
+type DeliveryReport struct {
+	Enabled bool `yaml:"enabled"`
+	// Period is the time covered by a single report. Defaults to one week.
+	Period time.Duration `yaml:"period,omitempty"`
+}
+
+// Sharding contains configuration of splitting the watch space across replicas by namespaces.
+// Each replica handles a single shard, which it holds with a Lease. Replicas which don't hold any shard stand by to take over.
+// Only the first shard handles commands and sends messages which are sent once per cluster, such as the help message.
+type Sharding struct {
+	Enabled bool `yaml:"enabled"`
+	// Shards is the number of shards.
+	Shards int `yaml:"shards"`
+	// Assignments explicitly assign namespaces to shards. Other namespaces are assigned by hash of their names.
+	Assignments []ShardAssignment `yaml:"assignments"`
+	// Plugins are source plugins which handle only namespaces of the shard. Other source plugins are started only by the first shard.
+	Plugins []string `yaml:"plugins"`
+	// Lease is the prefix of Leases used as locks. The shard index is appended to the name, e.g. `botkube-shard-0`.
+	Lease K8sResourceRef `yaml:"lease"`
+	// LeaseDuration is the period of time after which standby replicas can take over the shard, if its holder didn't renew it.
+	LeaseDuration time.Duration `yaml:"leaseDuration"`
+	// RenewDeadline is the period of time during which the holder retries renewing the shard before it gives it up.
+	// It must be lower than LeaseDuration.
+	RenewDeadline time.Duration `yaml:"renewDeadline"`
+	// RetryPeriod is the period of time between attempts to acquire or renew a shard.
+	RetryPeriod time.Duration `yaml:"retryPeriod"`
+}
+
+// ShardAssignment assigns namespaces to a given shard.
+type ShardAssignment struct {
+	// Shard is the shard index, starting from 0.
+	Shard      int      `yaml:"shard"`
+	Namespaces []string `yaml:"namespaces"`
+}
+
+// LeaderElection contains configuration of the Lease-based leader election.
+// Once enabled, only the elected replica handles events and chat traffic, and others stand by to take over.
+type LeaderElection struct {
+	Enabled bool `yaml:"enabled"`
+	// Lease is the Lease used as a lock.
+	Lease K8sResourceRef `yaml:"lease"`
+	// LeaseDuration is the period of time after which standby replicas can take over the leadership, if the leader didn't renew it.
+	LeaseDuration time.Duration `yaml:"leaseDuration"`
+	// RenewDeadline is the period of time during which the leader retries renewing the leadership before it gives it up.
+	// It must be lower than LeaseDuration.
+	RenewDeadline time.Duration `yaml:"renewDeadline"`
+	// RetryPeriod is the period of time between attempts to acquire or renew the leadership.
+	RetryPeriod time.Duration `yaml:"retryPeriod"`
+}
+
+// Shutdown contains configuration of the graceful shutdown, e.g. during a rolling upgrade.
+// Once Botkube receives the termination signal, sources are stopped, and already received events are dispatched before other components are stopped.
+type Shutdown struct {
+	// DrainTimeout is the maximum time to wait for in-flight events and queued notifications.
+	// It should be lower than the termination grace period of the Pod.
+	DrainTimeout time.Duration `yaml:"drainTimeout"`
+	// Notice is sent to all communication platforms before Botkube is stopped.
+	Notice ShutdownNotice `yaml:"notice"`
+}
+
+// ShutdownNotice contains configuration of the message sent before Botkube is stopped.
+type ShutdownNotice struct {
+	Enabled bool   `yaml:"enabled"`
+	Message string `yaml:"message"`
+}
+
+// EventBuffer contains configuration of the disk-backed buffer of notifications which couldn't be sent, e.g. during a chat platform outage.
+// Buffered notifications survive restarts, and they are replayed in order, once the communication platform or sink is reachable again.
+// Only notifications which failed with transient errors are buffered, separately for each channel.
+type EventBuffer struct {
+	Enabled bool `yaml:"enabled"`
+	// Path is the path of the buffer database file. It should be on a persistent volume, so notifications survive restarts.
+	Path string `yaml:"path" validate:"required_if=Enabled true"`
+	// MaxEntries is the maximum number of buffered notifications per channel or sink. Once reached, the oldest ones are dropped. Zero means no limit.
+	MaxEntries int `yaml:"maxEntries" validate:"min=0"`
+	// MaxAge is the maximum age of buffered notifications. Older ones are dropped instead of being replayed. Zero means no limit.
+	MaxAge time.Duration `yaml:"maxAge"`
+	// RetryInterval is the period of time after which sending buffered notifications is retried.
+	RetryInterval time.Duration `yaml:"retryInterval"`
+}
+
+// PipelineOverflowPolicy defines what happens with a new item once a pipeline queue is full.
+type PipelineOverflowPolicy string
+
+const (
+	// BlockPipelineOverflowPolicy blocks the previous stage until there is room in the queue, which slows down sources.
+	BlockPipelineOverflowPolicy PipelineOverflowPolicy = "block"
+	// DropOldestPipelineOverflowPolicy drops the oldest queued item to make room for the new one.
+	DropOldestPipelineOverflowPolicy PipelineOverflowPolicy = "dropOldest"
+	// SpillToDiskPipelineOverflowPolicy writes new items to disk, and reads them back once there is room in the queue.
+	// It's supported only by the ingest stage.
+	SpillToDiskPipelineOverflowPolicy PipelineOverflowPolicy = "spillToDisk"
+)
+
+// EventPipeline contains configuration of bounded worker pools and queues between stages of event processing,
+// which keep the memory usage stable under event storms. Events of the same source binding, as well as messages
+// sent by the same notifier, are handled in order.
+type EventPipeline struct {
+	// Ingest holds settings of the stage which processes, enriches, filters and routes events received from sources.
+	Ingest PipelineStage `yaml:"ingest"`
+	// Send holds settings of the stage which renders and sends messages to communication platforms and sinks.
+	Send PipelineStage `yaml:"send"`
+}
+
+// PipelineStage holds settings of a worker pool and its queue.
+type PipelineStage struct {
+	// Workers is the number of items handled concurrently. Defaults to 1.
+	Workers int `yaml:"workers" validate:"min=0"`
+	// QueueSize is the number of items which wait for workers. Defaults to 100.
+	QueueSize int `yaml:"queueSize" validate:"min=0"`
+	// Overflow is the policy applied once the queue is full. Defaults to `block`.
+	Overflow PipelineOverflowPolicy `yaml:"overflow" validate:"omitempty,oneof=block dropOldest spillToDisk"`
+	// SpillDir is the directory where items are written with the `spillToDisk` policy. Spilled items are removed on startup.
+	SpillDir string `yaml:"spillDir,omitempty" validate:"required_if=Overflow spillToDisk"`
+}
+
+// TLSPolicy holds the TLS policy of outbound connections, e.g. to chat platforms, webhooks, plugin repositories and sinks.
+// Use it to trust a CA of a TLS-intercepting proxy. Files can be mounted from Kubernetes Secrets.
+type TLSPolicy struct {
+	// CAFile is the path of PEM-encoded CA certificates trusted in addition to the system ones.
+	CAFile string `yaml:"caFile,omitempty"`
+	// MinVersion is the minimum TLS version, one of `1.0`, `1.1`, `1.2` and `1.3`. Defaults to `1.2`.
+	MinVersion string `yaml:"minVersion,omitempty" validate:"omitempty,oneof=1.0 1.1 1.2 1.3"`
+	// CipherSuites are names of allowed TLS 1.0-1.2 cipher suites, e.g. `TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256`.
+	// Insecure cipher suites are rejected. Defaults to the Go secure ones.
+	CipherSuites []string `yaml:"cipherSuites,omitempty"`
+	// InsecureSkipVerify disables the server certificate verification of all connections. Use it only for testing.
+	InsecureSkipVerify bool `yaml:"insecureSkipVerify,omitempty"`
+}
+
+// IdentityMapping contains configuration of resolving chat users to Kubernetes users and groups, which are impersonated
+// by executors with the `ChatUser` RBAC policy.
+type IdentityMapping struct {
+	// Users maps chat users to Kubernetes identities. Keys are chat user IDs or emails verified by the communication platform.
+	// They take precedence over the OIDC mapping.
+	Users map[string]KubernetesIdentity `yaml:"users,omitempty"`
+	OIDC  OIDCIdentityMapping           `yaml:"oidc"`
+}
+
+// KubernetesIdentity is a Kubernetes user together with its groups.
+type KubernetesIdentity struct {
+	User   string   `yaml:"user"`
+	Groups []string `yaml:"groups"`
+}
+
+// OIDCIdentityMapping resolves chat users by their emails in the same way as the Kubernetes API server OIDC authenticator,
+// configured with the `email` username claim, does. Groups claims are taken from the static group membership.
+type OIDCIdentityMapping struct {
+	Enabled bool `yaml:"enabled"`
+	// UsernamePrefix must be the same as the `--oidc-username-prefix` flag of the API server, e.g. `oidc:`.
+	UsernamePrefix string `yaml:"usernamePrefix"`
+	// GroupsPrefix must be the same as the `--oidc-groups-prefix` flag of the API server, e.g. `oidc:`.
+	GroupsPrefix string `yaml:"groupsPrefix"`
+	// Groups maps names of OIDC groups to emails of their members.
+	Groups map[string][]string `yaml:"groups,omitempty"`
+	// AllowedDomains limits resolved emails to given domains. If empty, all domains are allowed.
+	AllowedDomains []string `yaml:"allowedDomains"`
+}
+
+// Redaction contains configuration of masking sensitive values in executor output and events before they are sent
+// to communication platforms and sinks.
+type Redaction struct {
+	Enabled bool `yaml:"enabled"`
+	// Replacement is the text which replaces redacted values.
+	Replacement string `yaml:"replacement"`
+	// MinValueLength is the minimum length of Secret and environment variable values which are redacted.
+	// Shorter values, such as `true`, are skipped, as they would mask unrelated text.
+	MinValueLength int `yaml:"minValueLength"`
+	// Patterns contains regular expressions of sensitive values, indexed by names. They override built-in patterns with the same name.
+	// If a pattern has a capturing group, only the first group is redacted.
+	Patterns map[string]RedactionPattern `yaml:"patterns,omitempty"`
+	Secrets  RedactionSecrets            `yaml:"secrets"`
+	EnvVars  RedactionEnvVars            `yaml:"envVars"`
+}
+
+// RedactionPattern is a regular expression of sensitive values.
+type RedactionPattern struct {
+	Enabled bool   `yaml:"enabled"`
+	Regex   string `yaml:"regex"`
+}
+
+// RedactionSecrets contains configuration of redacting values of Kubernetes Secrets, both as they are, and base64-encoded.
+type RedactionSecrets struct {
+	Enabled bool `yaml:"enabled"`
+	// Namespaces limits Secrets to given namespaces. If empty, Secrets from all namespaces are used.
+	Namespaces    []string `yaml:"namespaces"`
+	LabelSelector string   `yaml:"labelSelector"`
+	// RefreshInterval is the period of time after which Secret values are fetched again.
+	RefreshInterval time.Duration `yaml:"refreshInterval"`
+}
+
+// RedactionEnvVars contains configuration of redacting values of Botkube environment variables.
+type RedactionEnvVars struct {
+	Enabled bool `yaml:"enabled"`
+	// Names are glob patterns of environment variable names, e.g. `*_TOKEN`.
+	Names []string `yaml:"names"`
+}
+
+// AuditLog contains configuration of the structured audit log of executed commands, interactions, configuration changes, and notification decisions.
+// Entries are written to all enabled backends.
+type AuditLog struct {
+	Enabled bool `yaml:"enabled"`
+	// Retention is the period of time for which entries are kept. Older entries are removed from backends which support it. Zero keeps entries forever.
+	Retention time.Duration `yaml:"retention"`
+	// FlushInterval is the period of time after which recorded entries are written to backends.
+	FlushInterval time.Duration         `yaml:"flushInterval"`
+	File          AuditLogFile          `yaml:"file"`
+	Elasticsearch AuditLogElasticsearch `yaml:"elasticsearch"`
+	Webhook       AuditLogWebhook       `yaml:"webhook"`
+	S3            AuditLogS3            `yaml:"s3"`
+}
+
+// AuditLogFile contains configuration of the audit log backend which writes entries to JSON Lines files, one per day.
+type AuditLogFile struct {
+	Enabled bool `yaml:"enabled"`
+	// Directory is the directory where files are written. It should be a persistent volume, so entries survive restarts.
+	Directory string `yaml:"directory" validate:"required_if=Enabled true"`
+}
+
+// AuditLogElasticsearch contains configuration of the audit log backend which indexes entries in Elasticsearch, one index per day.
+type AuditLogElasticsearch struct {
+	Enabled       bool   `yaml:"enabled"`
+	Server        string `yaml:"server" validate:"required_if=Enabled true"`
+	Username      string `yaml:"username"`
+	Password      string `yaml:"password"`
+	APIKey        string `yaml:"apiKey"`
+	SkipTLSVerify bool   `yaml:"skipTLSVerify"`
+	// IndexPrefix is the prefix of index names, e.g. `botkube-audit` for `botkube-audit-2024.01.31`.
+	IndexPrefix string `yaml:"indexPrefix"`
+}
+
+// AuditLogWebhook contains configuration of the audit log backend which posts batches of entries to a given URL.
+type AuditLogWebhook struct {
+	Enabled bool   `yaml:"enabled"`
+	URL     string `yaml:"url" validate:"required_if=Enabled true"`
+	// Headers are sent with each request, e.g. to authenticate Botkube.
+	Headers map[string]string `yaml:"headers,omitempty"`
+	Timeout time.Duration     `yaml:"timeout"`
+}
+
+// AuditLogS3 contains configuration of the audit log backend which writes batches of entries as JSON Lines objects to an S3-compatible object storage.
+type AuditLogS3 struct {
+	Enabled         bool   `yaml:"enabled"`
+	Bucket          string `yaml:"bucket" validate:"required_if=Enabled true"`
+	Region          string `yaml:"region"`
+	Endpoint        string `yaml:"endpoint"`
+	ForcePathStyle  bool   `yaml:"forcePathStyle"`
+	AccessKeyID     string `yaml:"accessKeyID"`
+	SecretAccessKey string `yaml:"secretAccessKey"`
+	// Prefix is the prefix of object keys. Objects are written under `{prefix}/{clusterName}/{year}/{month}/{day}/`.
+	Prefix string `yaml:"prefix"`
+}
+
+// MetricsLabel defines a label of Botkube metrics which can be disabled.
+type MetricsLabel string
+
+const (
+	// SourceMetricsLabel is a source binding name.
+	SourceMetricsLabel MetricsLabel = "source"
+	// PlatformMetricsLabel is a communication platform or sink name, e.g. `socketSlack`.
+	PlatformMetricsLabel MetricsLabel = "platform"
+	// ChannelMetricsLabel is a channel name or ID.
+	ChannelMetricsLabel MetricsLabel = "channel"
+	// ExecutorMetricsLabel is an executor plugin name.
+	ExecutorMetricsLabel MetricsLabel = "executor"
+)
+
+// Metrics contains configuration of Prometheus metrics.
+type Metrics struct {
+	// MaxLabelValues limits the number of distinct values of each label, so the number of time series stays bounded.
+	// Further values are reported as `other`. Zero means no limit.
+	MaxLabelValues int `yaml:"maxLabelValues" validate:"gte=0"`
+	// DisabledLabels are labels which are always reported with an empty value, e.g. `channel` for installations with many channels.
+	DisabledLabels []MetricsLabel `yaml:"disabledLabels,omitempty" validate:"dive,oneof=source platform channel executor"`
+}
+
+// TracingProtocol defines the OTLP transport protocol.
+type TracingProtocol string
+
+const (
+	// GRPCTracingProtocol exports spans using OTLP over gRPC.
+	GRPCTracingProtocol TracingProtocol = "grpc"
+	// HTTPTracingProtocol exports spans using OTLP over HTTP with protobuf payloads.
+	HTTPTracingProtocol TracingProtocol = "http"
+)
+
+// Tracing contains configuration for exporting OpenTelemetry traces using OTLP.
+type Tracing struct {
+	Enabled bool `yaml:"enabled"`
+	// Endpoint is the address of the OTLP receiver, e.g. `otel-collector.monitoring:4317` for gRPC, or `otel-collector.monitoring:4318` for HTTP.
+	Endpoint string          `yaml:"endpoint" validate:"required_if=Enabled true"`
+	Protocol TracingProtocol `yaml:"protocol" validate:"omitempty,oneof=grpc http"`
+	// Insecure disables TLS of the connection to the OTLP receiver.
+	Insecure bool `yaml:"insecure"`
+	// Headers are sent with each export request, e.g. to authenticate Botkube.
+	Headers map[string]string `yaml:"headers,omitempty"`
+	// SampleRatio is the fraction of traces which are sampled. Traces started by other services, e.g. incoming webhooks, follow their sampling decision.
+	SampleRatio float64 `yaml:"sampleRatio" validate:"gte=0,lte=1"`
+	// ServiceName is the `service.name` resource attribute of spans.
+	ServiceName string `yaml:"serviceName"`
+}
+
+// ConfigHistory contains configuration for keeping a bounded history of applied configurations, which can be rolled back.
+type ConfigHistory struct {
+	Enabled bool `yaml:"enabled"`
+	// Limit is the maximum number of kept configuration revisions.
+	Limit int `yaml:"limit"`
+	// Secret is the Secret where the history is stored. Configuration snapshots may contain credentials, so they are never stored in a ConfigMap.
+	Secret K8sResourceRef `yaml:"secret"`
+}
+
+// Incidents contains configuration for acknowledging notifications.
+// Notifications matching escalation policies can be always acknowledged.
+type Incidents struct {
+	// Enabled adds the Acknowledge button to notifications from Sources.
+	Enabled bool `yaml:"enabled"`
+	// Sources are source bindings for which notifications can be acknowledged. If empty, all notifications can be acknowledged.
+	Sources []string `yaml:"sources"`
+	// SuppressFor is the period of time for which further notifications about an acknowledged resource are not sent.
+	SuppressFor time.Duration `yaml:"suppressFor"`
+	// Retention is the period of time for which not acknowledged incidents are tracked. It should be longer than the escalation steps.
+	Retention time.Duration `yaml:"retention"`
+}
+
+// Authorization contains configuration for authorizing executed commands.
+type Authorization struct {
+	OPA OPAAuthorization `yaml:"opa"`
+}
+
+// OPAAuthorization contains configuration for evaluating commands against Rego policies served by Open Policy Agent.
+type OPAAuthorization struct {
+	Enabled bool `yaml:"enabled"`
+	// URL is the OPA server address, e.g. http://opa.botkube:8181.
+	URL string `yaml:"url" validate:"required_if=Enabled true"`
+	// Path is the policy decision path, e.g. `botkube/authz/allow`. The decision must be a boolean, or an object with the `allow` and optional `reason` fields.
+	Path string `yaml:"path" validate:"required_if=Enabled true"`
+	// Timeout for a single policy evaluation.
+	Timeout time.Duration `yaml:"timeout"`
+	// FailOpen allows commands when the policy cannot be evaluated. By default, such commands are denied.
+	FailOpen bool `yaml:"failOpen"`
+}
+
+// Execution contains configuration for executed commands.
+type Execution struct {
+	// Timeout is the default timeout for executor plugin commands. Zero means no timeout.
+	Timeout time.Duration `yaml:"timeout"`
+	// Timeouts overrides the default timeout for commands matching a given pattern.
+	Timeouts []CommandTimeout `yaml:"timeouts" validate:"dive"`
+	// InProgressMessageDelay specifies after how long a message with the Cancel button is posted for a still running command.
+	// Zero disables the message.
+	InProgressMessageDelay time.Duration `yaml:"inProgressMessageDelay"`
+	// RateLimit limits how often commands can be executed, to protect the cluster and Botkube against abuse.
+	RateLimit CommandRateLimits `yaml:"rateLimit"`
+}
+
+// CommandRateLimits contains rate limits for executed commands. Commands exceeding any of the limits are rejected.
+type CommandRateLimits struct {
+	// PerUser limits commands executed by a single user across all channels.
+	PerUser CommandRateLimit `yaml:"perUser"`
+	// PerChannel limits commands executed in a single channel by all users.
+	PerChannel CommandRateLimit `yaml:"perChannel"`
+	// ExemptUsers are not rate limited. Users are matched by the platform ID or the verified email, e.g. `U01234567` or `admin@example.com`.
+	ExemptUsers []string `yaml:"exemptUsers"`
+}
+
+// CommandRateLimit defines a rate limit for executed commands. Zero MaxCommands disables the limit.
+type CommandRateLimit struct {
+	// MaxCommands is the maximum number of commands within Window.
+	MaxCommands int `yaml:"maxCommands" validate:"min=0"`
+	// Window is the period of time in which MaxCommands applies.
+	Window time.Duration `yaml:"window" validate:"required_with=MaxCommands"`
+	// Burst is the maximum number of commands executed in a quick succession. Zero means MaxCommands.
+	Burst int `yaml:"burst" validate:"min=0"`
+}
+
+// CommandTimeout defines a timeout for commands matching a given pattern.
+type CommandTimeout struct {
+	// Command is a regex matched against the whole command, e.g. `^helm (install|upgrade)`.
+	Command string        `yaml:"command" validate:"required"`
+	Timeout time.Duration `yaml:"timeout"`
 }
 
 // Formatter log formatter
@@ -703,17 +2533,38 @@ type LoadWithDefaultsDetails struct {
 
 // LoadWithDefaults loads new configuration from files and environment variables.
 func LoadWithDefaults(configs [][]byte) (*Config, LoadWithDefaultsDetails, error) {
+	cfg, err := MergeWithDefaults(configs)
+	if err != nil {
+		return nil, LoadWithDefaultsDetails{}, err
+	}
+
+	result, err := ValidateStruct(*cfg)
+	if err != nil {
+		return nil, LoadWithDefaultsDetails{}, fmt.Errorf("while validating loaded configuration: %w", err)
+	}
+	if err := result.Criticals.ErrorOrNil(); err != nil {
+		return nil, LoadWithDefaultsDetails{}, fmt.Errorf("found critical validation errors: %w", err)
+	}
+
+	return cfg, LoadWithDefaultsDetails{
+		ValidateWarnings: result.Warnings.ErrorOrNil(),
+	}, nil
+}
+
+// MergeWithDefaults merges configuration from files and environment variables with the default configuration.
+// Contrary to LoadWithDefaults, the merged configuration is not validated.
+func MergeWithDefaults(configs [][]byte) (*Config, error) {
 	k := koanf.New(configDelimiter)
 
 	// load default settings
 	if err := k.Load(rawbytes.Provider(defaultConfiguration), koanfyaml.Parser()); err != nil {
-		return nil, LoadWithDefaultsDetails{}, fmt.Errorf("while loading default configuration: %w", err)
+		return nil, fmt.Errorf("while loading default configuration: %w", err)
 	}
 
 	// merge with user configs
 	for _, rawCfg := range configs {
 		if err := k.Load(rawbytes.Provider(rawCfg), koanfyaml.Parser()); err != nil {
-			return nil, LoadWithDefaultsDetails{}, err
+			return nil, err
 		}
 	}
 
@@ -724,7 +2575,7 @@ func LoadWithDefaults(configs [][]byte) (*Config, LoadWithDefaultsDetails, error
 		normalizeConfigEnvName,
 	), nil)
 	if err != nil {
-		return nil, LoadWithDefaultsDetails{}, err
+		return nil, err
 	}
 
 	var cfg Config
@@ -743,20 +2594,10 @@ func LoadWithDefaults(configs [][]byte) (*Config, LoadWithDefaultsDetails, error
 		},
 	})
 	if err != nil {
-		return nil, LoadWithDefaultsDetails{}, err
+		return nil, err
 	}
 
-	result, err := ValidateStruct(cfg)
-	if err != nil {
-		return nil, LoadWithDefaultsDetails{}, fmt.Errorf("while validating loaded configuration: %w", err)
-	}
-	if err := result.Criticals.ErrorOrNil(); err != nil {
-		return nil, LoadWithDefaultsDetails{}, fmt.Errorf("found critical validation errors: %w", err)
-	}
-
-	return &cfg, LoadWithDefaultsDetails{
-		ValidateWarnings: result.Warnings.ErrorOrNil(),
-	}, nil
+	return &cfg, nil
 }
 
 func normalizeConfigEnvName(name string) string {
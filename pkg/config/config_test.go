@@ -182,6 +182,25 @@ func TestLoadedConfigValidationErrors(t *testing.T) {
 				readTestdataFile(t, "missing-action-bindings.yaml"),
 			},
 		},
+		{
+			name: "invalid action steps",
+			expErrMsg: heredoc.Doc(`
+				found critical validation errors: 2 errors occurred:
+					* Key: 'Config.Actions[collect-details].Steps[1].Name' Name is a required field
+					* Key: 'Config.Actions[collect-details].Steps[2].Name' Name 'getPod' is used by multiple steps`),
+			configs: [][]byte{
+				readTestdataFile(t, "invalid-action-steps.yaml"),
+			},
+		},
+		{
+			name: "invalid command timeout pattern",
+			expErrMsg: heredoc.Doc(`
+				found critical validation errors: 1 error occurred:
+					* Key: 'Config.Settings.Execution.Timeouts[1].Command' Command must be a valid regular expression: error parsing regexp: missing closing ]: ` + "`[invalid`"),
+			configs: [][]byte{
+				readTestdataFile(t, "invalid-command-timeout.yaml"),
+			},
+		},
 		{
 			name: "missing alias command",
 			expErrMsg: heredoc.Doc(`
@@ -218,6 +237,35 @@ func TestLoadedConfigValidationErrors(t *testing.T) {
 				readTestdataFile(t, "sources-rbac.yaml"),
 			},
 		},
+		{
+			name: "invalid sharding",
+			expErrMsg: heredoc.Doc(`
+				found critical validation errors: 3 errors occurred:
+					* Key: 'Config.Settings.Sharding.Enabled' Enabled cannot be enabled together with LeaderElection
+					* Key: 'Config.Settings.Sharding.Assignments[1].Shard' Shard must be lower than the number of shards (2)
+					* Key: 'Config.Settings.Sharding.Assignments[1].Namespaces' Namespaces namespace 'monitoring' is assigned to multiple shards`),
+			configs: [][]byte{
+				readTestdataFile(t, "invalid-sharding.yaml"),
+			},
+		},
+		{
+			name: "missing incoming webhook auth",
+			expErrMsg: heredoc.Doc(`
+				found critical validation errors: 1 error occurred:
+					* Key: 'Config.Plugins.IncomingWebhook.Auth.Type' Type is a required field`),
+			configs: [][]byte{
+				readTestdataFile(t, "missing-incoming-webhook-auth.yaml"),
+			},
+		},
+		{
+			name: "invalid analytics collector",
+			expErrMsg: heredoc.Doc(`
+				found critical validation errors: 1 error occurred:
+					* Key: 'Config.Analytics.Collector.URL' URL is a required field`),
+			configs: [][]byte{
+				readTestdataFile(t, "invalid-analytics.yaml"),
+			},
+		},
 		{
 			name: "Invalid channel names",
 			expErrMsg: heredoc.Doc(`
@@ -253,6 +301,57 @@ func TestLoadedConfigValidationErrors(t *testing.T) {
 	}
 }
 
+func TestValidateStructFieldPaths(t *testing.T) {
+	// given
+	cfg := config.Config{
+		Communications: map[string]config.Communications{
+			"default-group": {
+				CloudSlack: config.CloudSlack{
+					Enabled: true,
+					Token:   "token",
+					Channels: config.IdentifiableMap[config.CloudSlackChannel]{
+						"default": {
+							ChannelBindingsByName: config.ChannelBindingsByName{
+								Name:     "general",
+								Bindings: config.BotBindings{Sources: []string{"k8s-evnts"}},
+							},
+						},
+					},
+				},
+			},
+		},
+		Filters: config.Filters{"drop-noise": {Action: config.DropFilterAction}},
+		Routing: config.Routing{Rules: []config.RoutingRule{{}}},
+	}
+
+	// when
+	result, err := config.ValidateStruct(cfg)
+
+	// then
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []config.FieldIssue{
+		{
+			Path:    "filters.drop-noise.expression",
+			Tag:     "required",
+			Field:   "Expression",
+			Message: "Expression is a required field",
+		},
+		{
+			Path:    "routing.rules[0].channels",
+			Tag:     "required",
+			Field:   "Channels",
+			Message: "Channels is a required field",
+		},
+		{
+			Path:    "communications.default-group.cloudSlack.channels.default.bindings",
+			Tag:     "invalid_binding",
+			Field:   "k8s-evnts",
+			Param:   "Config.Sources",
+			Message: "'k8s-evnts' binding not defined in Config.Sources",
+		},
+	}, result.Fields)
+}
+
 func TestLoadedConfigEnabledPluginErrors(t *testing.T) {
 	// given
 	tests := []struct {
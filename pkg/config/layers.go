@@ -0,0 +1,148 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/knadh/koanf"
+	koanfyaml "github.com/knadh/koanf/parsers/yaml"
+	"github.com/knadh/koanf/providers/env"
+	"github.com/knadh/koanf/providers/rawbytes"
+)
+
+const (
+	// DefaultsLayerName is the name of the layer with the built-in default configuration.
+	DefaultsLayerName = "defaults"
+	// EnvLayerName is the name of the layer with configuration defined in `BOTKUBE_*` environment variables.
+	EnvLayerName = "environment variables"
+)
+
+// Layer holds a single configuration source, such as a file, URL or ConfigMap.
+type Layer struct {
+	// Name identifies the configuration source, e.g. a file path. It must not contain any credentials.
+	Name string
+	Data []byte
+}
+
+// Layers holds configuration sources ordered by their merge priority.
+//
+// The configuration is deep-merged in a deterministic order, where each layer overrides the previous ones:
+//  1. built-in defaults,
+//  2. configuration locations in the order they are specified, but files with the `_` name prefix are merged last,
+//  3. BotkubeConfig resources, ordered by their namespaces and names,
+//  4. `BOTKUBE_*` environment variables.
+//
+// Maps are merged key by key, while scalars and lists are replaced as a whole.
+type Layers []Layer
+
+// Files returns configuration of all layers.
+func (l Layers) Files() YAMLFiles {
+	out := make(YAMLFiles, 0, len(l))
+	for _, layer := range l {
+		out = append(out, layer.Data)
+	}
+	return out
+}
+
+// LayeredProvider is a Provider which knows names of its configuration sources.
+type LayeredProvider interface {
+	Provider
+	Layers(ctx context.Context) (Layers, int, error)
+}
+
+// LayersOf returns configuration layers of a given provider. If the provider doesn't name its configuration sources,
+// layers are named after their positions.
+func LayersOf(ctx context.Context, p Provider) (Layers, int, error) {
+	if lp, ok := p.(LayeredProvider); ok {
+		return lp.Layers(ctx)
+	}
+
+	files, ver, err := p.Configs(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	out := make(Layers, 0, len(files))
+	for idx, file := range files {
+		out = append(out, Layer{Name: fmt.Sprintf("configuration #%d", idx+1), Data: file})
+	}
+	return out, ver, nil
+}
+
+// ValueOrigin describes which layer a given effective configuration value comes from.
+type ValueOrigin struct {
+	// Path is the dot-separated path of the value, e.g. `settings.clusterName`.
+	Path  string
+	Layer string
+}
+
+// Origins returns origins of all effective configuration values, ordered by their paths. The defaults and environment
+// variables layers are included in the same way as in MergeWithDefaults.
+func Origins(layers Layers) ([]ValueOrigin, error) {
+	all := append(Layers{{Name: DefaultsLayerName, Data: defaultConfiguration}}, layers...)
+
+	origins := map[string]string{}
+	for _, layer := range all {
+		values, err := flattenLayer(layer.Data)
+		if err != nil {
+			return nil, fmt.Errorf("while loading %q: %w", layer.Name, err)
+		}
+		setOrigins(origins, values, layer.Name)
+	}
+
+	k := koanf.New(configDelimiter)
+	err := k.Load(env.Provider(
+		configEnvVariablePrefix,
+		configDelimiter,
+		normalizeConfigEnvName,
+	), nil)
+	if err != nil {
+		return nil, err
+	}
+	setOrigins(origins, k.All(), EnvLayerName)
+
+	out := make([]ValueOrigin, 0, len(origins))
+	for path, layer := range origins {
+		out = append(out, ValueOrigin{Path: path, Layer: layer})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Path < out[j].Path
+	})
+	return out, nil
+}
+
+func flattenLayer(data []byte) (map[string]any, error) {
+	k := koanf.New(configDelimiter)
+	if err := k.Load(rawbytes.Provider(data), koanfyaml.Parser()); err != nil {
+		return nil, err
+	}
+	return k.All(), nil
+}
+
+// setOrigins records a given layer as the origin of given flattened values, following the koanf merge rules.
+func setOrigins(origins map[string]string, values map[string]any, layer string) {
+	for path, value := range values {
+		prefix := path + configDelimiter
+		nested := false
+		for existing := range origins {
+			if strings.HasPrefix(existing, prefix) {
+				nested = true
+				break
+			}
+		}
+
+		// an empty map doesn't override values nested in the previous layers
+		if m, ok := value.(map[string]any); ok && len(m) == 0 && nested {
+			continue
+		}
+
+		for existing := range origins {
+			// a scalar replaces a map, and a map replaces a scalar
+			if strings.HasPrefix(existing, prefix) || strings.HasPrefix(path, existing+configDelimiter) {
+				delete(origins, existing)
+			}
+		}
+		origins[path] = layer
+	}
+}
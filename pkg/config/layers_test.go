@@ -0,0 +1,96 @@
+package config_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+type fakeProvider struct {
+	files config.YAMLFiles
+}
+
+func (f fakeProvider) Configs(context.Context) (config.YAMLFiles, int, error) {
+	return f.files, 2, nil
+}
+
+func TestLayersOf(t *testing.T) {
+	// given
+	provider := fakeProvider{files: config.YAMLFiles{[]byte("a: 1"), []byte("b: 2")}}
+
+	// when
+	layers, ver, err := config.LayersOf(context.Background(), provider)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, 2, ver)
+	assert.Equal(t, config.Layers{
+		{Name: "configuration #1", Data: []byte("a: 1")},
+		{Name: "configuration #2", Data: []byte("b: 2")},
+	}, layers)
+	assert.Equal(t, provider.files, layers.Files())
+}
+
+func TestOrigins(t *testing.T) {
+	// given
+	t.Setenv("BOTKUBE_SETTINGS_CLUSTER__NAME", "cluster-name-from-env")
+	layers := config.Layers{
+		{
+			Name: "global.yaml",
+			Data: []byte(heredoc.Doc(`
+				settings:
+				  clusterName: global
+				  log:
+				    level: debug
+				actions:
+				  show-created-resource:
+				    enabled: true
+				    bindings:
+				      sources: [k8s-events]
+			`)),
+		},
+		{
+			Name: "override.yaml",
+			Data: []byte(heredoc.Doc(`
+				settings:
+				  log:
+				    level: error
+				actions:
+				  show-created-resource: "disabled"
+				  other: {}
+			`)),
+		},
+	}
+
+	// when
+	origins, err := config.Origins(layers)
+
+	// then
+	require.NoError(t, err)
+	byPath := map[string]string{}
+	for _, origin := range origins {
+		byPath[origin.Path] = origin.Layer
+	}
+
+	assert.Equal(t, config.EnvLayerName, byPath["settings.clusterName"])
+	assert.Equal(t, "override.yaml", byPath["settings.log.level"])
+	assert.Equal(t, config.DefaultsLayerName, byPath["settings.log.disableColors"])
+	assert.Equal(t, "override.yaml", byPath["actions.show-created-resource"])
+	assert.Equal(t, "override.yaml", byPath["actions.other"])
+	assert.NotContains(t, byPath, "actions.show-created-resource.enabled")
+	assert.NotContains(t, byPath, "actions.show-created-resource.bindings.sources")
+	assert.IsIncreasing(t, pathsOf(origins))
+}
+
+func pathsOf(in []config.ValueOrigin) []string {
+	out := make([]string, 0, len(in))
+	for _, item := range in {
+		out = append(out, item.Path)
+	}
+	return out
+}
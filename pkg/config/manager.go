@@ -37,11 +37,18 @@ type PersistenceManager interface {
 	PersistSourceBindings(ctx context.Context, commGroupName string, platform CommPlatformIntegration, channelAlias string, sourceBindings []string) error
 	PersistNotificationsEnabled(ctx context.Context, commGroupName string, platform CommPlatformIntegration, channelAlias string, enabled bool) error
 	PersistActionEnabled(ctx context.Context, name string, enabled bool) error
+	PersistNotificationSettings(ctx context.Context, commGroupName string, platform CommPlatformIntegration, channelAlias string, settings NotificationSettings) error
+	PersistPluginConfig(ctx context.Context, pluginType, group, pluginKey string, cfg any) error
+	PersistPluginEnabled(ctx context.Context, pluginType, group, pluginKey, commGroupName string, platform CommPlatformIntegration, channelAlias string, bindings ChannelRuntimeBindings) error
 	SetResourceVersion(resourceVersion int)
 }
 
-// ErrUnsupportedPlatform is an error returned when a platform is not supported.
-var ErrUnsupportedPlatform = errors.New("unsupported platform to persist data")
+var (
+	// ErrUnsupportedPlatform is an error returned when a platform is not supported.
+	ErrUnsupportedPlatform = errors.New("unsupported platform to persist data")
+	// ErrUnsupportedRemotePersistence is an error returned when a given change cannot be persisted in the remote configuration.
+	ErrUnsupportedRemotePersistence = errors.New("this change cannot be persisted in the remote configuration, use Botkube Cloud to change it")
+)
 
 // NewManager creates a new PersistenceManager instance.
 func NewManager(remoteCfgEnabled bool, log logrus.FieldLogger, cfg PersistentConfig, cfgVersion int, k8sCli kubernetes.Interface, client GraphQLClient, resVerClient ResVerClient) PersistenceManager {
@@ -50,7 +50,7 @@ func (m *K8sConfigPersistenceManager) PersistSourceBindings(ctx context.Context,
 			platformCfg.MSTeamsOnlyRuntimeState = &ChannelRuntimeState{}
 		}
 
-		platformCfg.MSTeamsOnlyRuntimeState.Bindings.Sources = sourceBindings
+		platformCfg.MSTeamsOnlyRuntimeState.Bindings = withSourceBindings(platformCfg.MSTeamsOnlyRuntimeState.Bindings, sourceBindings)
 		state.Communications[commGroupName][platform] = platformCfg
 
 		err = configMapStorage.Update(ctx, cm, state)
@@ -71,7 +71,7 @@ func (m *K8sConfigPersistenceManager) PersistSourceBindings(ctx context.Context,
 		channel = ChannelRuntimeState{}
 	}
 
-	channel.Bindings.Sources = sourceBindings
+	channel.Bindings = withSourceBindings(channel.Bindings, sourceBindings)
 	state.Communications[commGroupName][platform].Channels[channelAlias] = channel
 
 	err = configMapStorage.Update(ctx, cm, state)
@@ -148,4 +148,82 @@ func (m *K8sConfigPersistenceManager) PersistActionEnabled(ctx context.Context,
 	return cmStorage.Update(ctx, cm, state)
 }
 
+// PersistNotificationSettings persists notification settings for a given channel in the runtime ConfigMap.
+func (m *K8sConfigPersistenceManager) PersistNotificationSettings(ctx context.Context, commGroupName string, platform CommPlatformIntegration, channelAlias string, settings NotificationSettings) error {
+	if _, ok := supportedPlatformsNotifications[platform]; !ok {
+		return ErrUnsupportedPlatform
+	}
+
+	cmStorage := configMapStorage[RuntimeState]{k8sCli: m.k8sCli, cfg: m.cfg.Runtime}
+	state, cm, err := cmStorage.Get(ctx)
+	if err != nil {
+		return err
+	}
+
+	if state.Communications == nil {
+		state.Communications = make(map[string]CommunicationsRuntimeState)
+	}
+	commGroup, exists := state.Communications[commGroupName]
+	if !exists {
+		commGroup = make(CommunicationsRuntimeState)
+		state.Communications[commGroupName] = commGroup
+	}
+
+	platformCfg := commGroup[platform]
+	if platformCfg.Channels == nil {
+		platformCfg.Channels = make(map[string]ChannelRuntimeState)
+	}
+
+	channel := platformCfg.Channels[channelAlias]
+	channel.Notification = NewChannelNotificationRuntimeState(settings)
+	platformCfg.Channels[channelAlias] = channel
+	commGroup[platform] = platformCfg
+
+	return cmStorage.Update(ctx, cm, state)
+}
+
+// PersistPluginConfig persists the configuration of a given plugin in the runtime ConfigMap.
+// The pluginType is one of: executor, source, processor.
+func (m *K8sConfigPersistenceManager) PersistPluginConfig(ctx context.Context, pluginType, group, pluginKey string, cfg any) error {
+	cmStorage := configMapStorage[RuntimeState]{k8sCli: m.k8sCli, cfg: m.cfg.Runtime}
+	state, cm, err := cmStorage.Get(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := state.SetPluginConfig(pluginType, group, pluginKey, cfg); err != nil {
+		return err
+	}
+	return cmStorage.Update(ctx, cm, state)
+}
+
+// PersistPluginEnabled enables a given plugin in a given group, and sets bindings of a given channel in the runtime ConfigMap.
+// Both changes are persisted with a single update, so Botkube reloads the configuration only once.
+func (m *K8sConfigPersistenceManager) PersistPluginEnabled(ctx context.Context, pluginType, group, pluginKey, commGroupName string, platform CommPlatformIntegration, channelAlias string, bindings ChannelRuntimeBindings) error {
+	if _, ok := supportedPlatformsSourceBindings[platform]; !ok {
+		return ErrUnsupportedPlatform
+	}
+
+	cmStorage := configMapStorage[RuntimeState]{k8sCli: m.k8sCli, cfg: m.cfg.Runtime}
+	state, cm, err := cmStorage.Get(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := state.EnablePlugin(pluginType, group, pluginKey); err != nil {
+		return err
+	}
+	state.SetChannelBindings(commGroupName, platform, channelAlias, bindings)
+	return cmStorage.Update(ctx, cm, state)
+}
+
 func (m *K8sConfigPersistenceManager) SetResourceVersion(resourceVersion int) {}
+
+// withSourceBindings returns channel bindings with given source bindings. Executor bindings set at runtime are preserved.
+func withSourceBindings(in *ChannelRuntimeBindings, sourceBindings []string) *ChannelRuntimeBindings {
+	out := &ChannelRuntimeBindings{Sources: sourceBindings}
+	if in != nil {
+		out.Executors = in.Executors
+	}
+	return out
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/MakeNowJust/heredoc"
 	"github.com/stretchr/testify/assert"
@@ -381,3 +382,79 @@ func TestPersistenceManager_PersistActionEnabled(t *testing.T) {
 		})
 	}
 }
+
+func TestPersistenceManager_PersistNotificationSettings(t *testing.T) {
+	// given
+	commGroupName := "default-group"
+	cfg := config.PartialPersistentConfig{
+		ConfigMap: config.K8sResourceRef{
+			Name:      "foo",
+			Namespace: "ns",
+		},
+		FileName: "_runtime_state.yaml",
+	}
+	cfgMap := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cfg.ConfigMap.Name,
+			Namespace: cfg.ConfigMap.Namespace,
+		},
+		Data: map[string]string{
+			cfg.FileName: heredoc.Doc(`
+				communications:
+				  default-group:
+				    socketSlack:
+				      channels:
+				        general:
+				          bindings:
+				            sources:
+				              - k8s-events
+			`),
+		},
+	}
+	k8sCli := fake.NewSimpleClientset(cfgMap)
+	manager := config.NewManager(false, loggerx.NewNoop(), config.PersistentConfig{Runtime: cfg}, 0, k8sCli, nil, nil)
+	settings := config.NotificationSettings{
+		Profile:           config.QuietProdProfile,
+		MinLevel:          config.Warn,
+		AggregationWindow: 5 * time.Minute,
+	}
+
+	// when
+	err := manager.PersistNotificationSettings(context.Background(), commGroupName, config.SocketSlackCommPlatformIntegration, "general", settings)
+	require.NoError(t, err)
+	err = manager.PersistNotificationSettings(context.Background(), commGroupName, config.SocketSlackCommPlatformIntegration, "alerts", config.NotificationSettings{})
+	require.NoError(t, err)
+
+	// then
+	got, err := k8sCli.CoreV1().ConfigMaps(cfg.ConfigMap.Namespace).Get(context.Background(), cfg.ConfigMap.Name, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, heredoc.Doc(`
+		communications:
+		  default-group:
+		    socketSlack:
+		      channels:
+		        alerts:
+		          notification:
+		            profile: ""
+		            minLevel: ""
+		            filter: ""
+		            aggregationWindow: 0s
+		            locale: ""
+		        general:
+		          bindings:
+		            sources:
+		              - k8s-events
+		          notification:
+		            profile: quiet-prod
+		            minLevel: warn
+		            filter: ""
+		            aggregationWindow: 5m0s
+		            locale: ""
+	`), got.Data[cfg.FileName])
+
+	// when
+	err = manager.PersistNotificationSettings(context.Background(), commGroupName, config.WebhookCommPlatformIntegration, "general", settings)
+
+	// then
+	assert.EqualError(t, err, "unsupported platform to persist data")
+}
@@ -172,6 +172,19 @@ func (m *RemotePersistenceManager) PersistActionEnabled(ctx context.Context, nam
 	return nil
 }
 
+// PersistNotificationSettings returns ErrUnsupportedRemotePersistence, as notification settings cannot be patched in the remote configuration.
+func (m *RemotePersistenceManager) PersistNotificationSettings(context.Context, string, CommPlatformIntegration, string, NotificationSettings) error {
+	return ErrUnsupportedRemotePersistence
+}
+
+func (m *RemotePersistenceManager) PersistPluginConfig(context.Context, string, string, string, any) error {
+	return ErrUnsupportedRemotePersistence
+}
+
+func (m *RemotePersistenceManager) PersistPluginEnabled(context.Context, string, string, string, string, CommPlatformIntegration, string, ChannelRuntimeBindings) error {
+	return ErrUnsupportedRemotePersistence
+}
+
 func (m *RemotePersistenceManager) SetResourceVersion(resourceVersion int) {
 	m.resVerMutex.Lock()
 	defer m.resVerMutex.Unlock()
@@ -104,3 +104,60 @@ func TestDecomposePluginKey(t *testing.T) {
 		}
 	})
 }
+
+func TestPluginResourcesForPlugin(t *testing.T) {
+	// given
+	uid, helmUID := uint32(1000), uint32(2000)
+	resources := PluginResources{
+		Default: PluginResourceLimits{CPU: "500m", Memory: "256Mi", RunAsUser: &uid},
+		Plugins: map[string]PluginResourceLimits{
+			"botkube/helm": {Memory: "1Gi", RunAsUser: &helmUID},
+		},
+	}
+
+	// when
+	helm := resources.ForPlugin("botkube/helm@v1.0.0")
+	kubectl := resources.ForPlugin("botkube/kubectl")
+
+	// then
+	assert.Equal(t, PluginResourceLimits{CPU: "500m", Memory: "1Gi", RunAsUser: &helmUID}, helm)
+	assert.Equal(t, resources.Default, kubectl)
+}
+
+func TestPluginResourceLimitsValidation(t *testing.T) {
+	// given
+	cfg := PluginManagement{
+		Resources: PluginResources{
+			Default: PluginResourceLimits{CPU: "half", Memory: "256Mi"},
+			Plugins: map[string]PluginResourceLimits{
+				"botkube/helm": {Memory: "1 GB"},
+			},
+		},
+	}
+
+	// when
+	result, err := ValidateStruct(cfg)
+
+	// then
+	require.NoError(t, err)
+	assert.EqualError(t, result.Criticals.ErrorOrNil(), heredoc.Doc(`
+		2 errors occurred:
+			* Key: 'PluginManagement.Resources.Default.CPU' CPU must be a valid quantity, e.g. '500m' or '256Mi'
+			* Key: 'PluginManagement.Resources.Plugins[botkube/helm].Memory' Memory must be a valid quantity, e.g. '500m' or '256Mi'`))
+}
+
+func TestPluginsMirrorValidation(t *testing.T) {
+	// given
+	cfg := PluginManagement{
+		Mirror: PluginsMirror{AirGapped: true},
+	}
+
+	// when
+	result, err := ValidateStruct(cfg)
+
+	// then
+	require.NoError(t, err)
+	assert.EqualError(t, result.Criticals.ErrorOrNil(), heredoc.Doc(`
+		1 error occurred:
+			* Key: 'PluginManagement.Mirror.URL' URL is a required field`))
+}
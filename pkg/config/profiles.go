@@ -0,0 +1,58 @@
+package config
+
+import (
+	"sort"
+	"time"
+)
+
+// Built-in notification profiles.
+const (
+	// QuietProdProfile sends only errors, collected into a single message every 15 minutes.
+	QuietProdProfile = "quiet-prod"
+	// VerboseDevProfile sends all events immediately.
+	VerboseDevProfile = "verbose-dev"
+	// SecurityFocusedProfile sends changes of RBAC resources, secrets and network policies, together with errors.
+	SecurityFocusedProfile = "security-focused"
+)
+
+// BuiltinNotificationProfiles returns curated notification settings for common setups, indexed by profile names.
+func BuiltinNotificationProfiles() map[string]NotificationSettings {
+	return map[string]NotificationSettings{
+		QuietProdProfile: {
+			MinLevel:          Error,
+			AggregationWindow: 15 * time.Minute,
+		},
+		VerboseDevProfile: {
+			MinLevel: Debug,
+		},
+		SecurityFocusedProfile: {
+			Filter: `has(event.Kind) && event.Kind in ["Role", "ClusterRole", "RoleBinding", "ClusterRoleBinding", "ServiceAccount", "Secret", "NetworkPolicy"] || has(event.Level) && event.Level in ["error", "critical"]`,
+		},
+	}
+}
+
+// NotificationProfile returns settings of a notification profile with a given name. Custom profiles defined under
+// `settings.notificationProfiles` take precedence over built-in ones.
+func (c Config) NotificationProfile(name string) (NotificationSettings, bool) {
+	if profile, ok := c.Settings.NotificationProfiles[name]; ok {
+		return profile, true
+	}
+	profile, ok := BuiltinNotificationProfiles()[name]
+	return profile, ok
+}
+
+// NotificationProfileNames returns sorted names of all built-in and custom notification profiles.
+func (c Config) NotificationProfileNames() []string {
+	builtin := BuiltinNotificationProfiles()
+	var out []string
+	for name := range builtin {
+		out = append(out, name)
+	}
+	for name := range c.Settings.NotificationProfiles {
+		if _, found := builtin[name]; !found {
+			out = append(out, name)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
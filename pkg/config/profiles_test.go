@@ -0,0 +1,47 @@
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kubeshop/botkube/internal/filter"
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+func TestBuiltinNotificationProfilesFilters(t *testing.T) {
+	for name, profile := range config.BuiltinNotificationProfiles() {
+		if profile.Filter == "" {
+			continue
+		}
+		_, err := filter.Compile(profile.Filter)
+		assert.NoError(t, err, "profile %q", name)
+	}
+}
+
+func TestConfigNotificationProfile(t *testing.T) {
+	// given
+	cfg := config.Config{
+		Settings: config.Settings{
+			NotificationProfiles: map[string]config.NotificationSettings{
+				config.QuietProdProfile: {MinLevel: config.Critical},
+				"payments":              {Filter: `event.Namespace == "payments"`},
+			},
+		},
+	}
+
+	// when
+	custom, customFound := cfg.NotificationProfile(config.QuietProdProfile)
+	builtin, builtinFound := cfg.NotificationProfile(config.VerboseDevProfile)
+	_, unknownFound := cfg.NotificationProfile("loud-prod")
+
+	// then
+	assert.True(t, customFound)
+	assert.Equal(t, config.NotificationSettings{MinLevel: config.Critical}, custom)
+	assert.True(t, builtinFound)
+	assert.Equal(t, config.Debug, builtin.MinLevel)
+	assert.False(t, unknownFound)
+	assert.Equal(t, []string{"payments", "quiet-prod", "security-focused", "verbose-dev"}, cfg.NotificationProfileNames())
+	assert.Equal(t, 15*time.Minute, config.BuiltinNotificationProfiles()[config.QuietProdProfile].AggregationWindow)
+}
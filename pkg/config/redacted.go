@@ -14,6 +14,38 @@ func HideSensitiveInfo(in Config) Config {
 		val.SocketSlack.AppToken = redactedSecretStr
 		val.SocketSlack.BotToken = redactedSecretStr
 		val.Elasticsearch.Password = redactedSecretStr
+		if val.Elasticsearch.APIKey != "" {
+			val.Elasticsearch.APIKey = redactedSecretStr
+		}
+		if val.Kafka.SASL.Password != "" {
+			val.Kafka.SASL.Password = redactedSecretStr
+		}
+		if val.Kafka.Avro.SchemaRegistry.Password != "" {
+			val.Kafka.Avro.SchemaRegistry.Password = redactedSecretStr
+		}
+		if val.NATS.Password != "" {
+			val.NATS.Password = redactedSecretStr
+		}
+		if val.NATS.Token != "" {
+			val.NATS.Token = redactedSecretStr
+		}
+		if val.S3Archive.SecretAccessKey != "" {
+			val.S3Archive.SecretAccessKey = redactedSecretStr
+		}
+		if val.Warehouse.ClickHouse.Password != "" {
+			val.Warehouse.ClickHouse.Password = redactedSecretStr
+		}
+		if val.Webhook.Signing.Secret != "" {
+			val.Webhook.Signing.Secret = redactedSecretStr
+		}
+		if len(val.Webhook.Headers) > 0 {
+			// header values, such as Authorization, may contain credentials
+			headers := make(map[string]string, len(val.Webhook.Headers))
+			for name := range val.Webhook.Headers {
+				headers[name] = redactedSecretStr
+			}
+			val.Webhook.Headers = headers
+		}
 		val.Discord.Token = redactedSecretStr
 		val.Mattermost.Token = redactedSecretStr
 		val.CloudSlack.Token = redactedSecretStr
@@ -40,5 +72,67 @@ func HideSensitiveInfo(in Config) Config {
 		out.Communications[key] = val
 	}
 
+	if len(in.Enrichments) > 0 {
+		out.Enrichments = make(Enrichments, len(in.Enrichments))
+		for name, enrichment := range in.Enrichments {
+			headers := make(map[string]string, len(enrichment.Headers))
+			for key := range enrichment.Headers {
+				headers[key] = redactedSecretStr
+			}
+			enrichment.Headers = headers
+			out.Enrichments[name] = enrichment
+		}
+	}
+
+	if len(in.Tickets) > 0 {
+		out.Tickets = make(Tickets, len(in.Tickets))
+		for name, policy := range in.Tickets {
+			policy.GitHub.Token = redactedSecretStr
+			policy.Jira.Token = redactedSecretStr
+			out.Tickets[name] = policy
+		}
+	}
+
+	if len(in.SecretProviders) > 0 {
+		out.SecretProviders = make(SecretProviders, len(in.SecretProviders))
+		for name, provider := range in.SecretProviders {
+			provider.Vault.Token = redactedSecretStr
+			out.SecretProviders[name] = provider
+		}
+	}
+
+	if in.Ownership.Backstage.Token != "" {
+		out.Ownership.Backstage.Token = redactedSecretStr
+	}
+	if in.GitSync.Auth.Token != "" {
+		out.GitSync.Auth.Token = redactedSecretStr
+	}
+	if in.GitSync.Webhook.Secret != "" {
+		out.GitSync.Webhook.Secret = redactedSecretStr
+	}
+	if len(in.Settings.Tracing.Headers) > 0 {
+		headers := make(map[string]string, len(in.Settings.Tracing.Headers))
+		for key := range in.Settings.Tracing.Headers {
+			headers[key] = redactedSecretStr
+		}
+		out.Settings.Tracing.Headers = headers
+	}
+	if in.Settings.AuditLog.Elasticsearch.Password != "" {
+		out.Settings.AuditLog.Elasticsearch.Password = redactedSecretStr
+	}
+	if in.Settings.AuditLog.Elasticsearch.APIKey != "" {
+		out.Settings.AuditLog.Elasticsearch.APIKey = redactedSecretStr
+	}
+	if in.Settings.AuditLog.S3.SecretAccessKey != "" {
+		out.Settings.AuditLog.S3.SecretAccessKey = redactedSecretStr
+	}
+	if len(in.Settings.AuditLog.Webhook.Headers) > 0 {
+		headers := make(map[string]string, len(in.Settings.AuditLog.Webhook.Headers))
+		for key := range in.Settings.AuditLog.Webhook.Headers {
+			headers[key] = redactedSecretStr
+		}
+		out.Settings.AuditLog.Webhook.Headers = headers
+	}
+
 	return out
 }
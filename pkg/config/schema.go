@@ -0,0 +1,275 @@
+package config
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	jsonSchemaDraft      = "http://json-schema.org/draft-07/schema#"
+	jsonSchemaDefsPrefix = "#/definitions/"
+)
+
+var (
+	durationType        = reflect.TypeOf(time.Duration(0))
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	schemaDefNameRegex  = regexp.MustCompile(`[^A-Za-z0-9_.-]+`)
+)
+
+// PluginConfigSchemas holds JSON schemas of plugin configurations, indexed by plugin names in the `{repository}/{plugin}` format.
+type PluginConfigSchemas struct {
+	Sources    map[string]json.RawMessage
+	Executors  map[string]json.RawMessage
+	Processors map[string]json.RawMessage
+}
+
+// JSONSchema returns the JSON schema of the Botkube configuration. Configuration of plugins with given schemas is described by them,
+// while configuration of other plugins can be any object.
+func JSONSchema(plugins PluginConfigSchemas) ([]byte, error) {
+	g := schemaGenerator{
+		defs:     map[string]any{},
+		defNames: map[reflect.Type]string{},
+		plugins: map[reflect.Type]pluginSchemas{
+			reflect.TypeOf(Sources{}):    {kind: "source", schemas: plugins.Sources},
+			reflect.TypeOf(Executors{}):  {kind: "executor", schemas: plugins.Executors},
+			reflect.TypeOf(Processors{}): {kind: "processor", schemas: plugins.Processors},
+		},
+	}
+
+	out := g.structSchema(reflect.TypeOf(Config{}))
+	if g.err != nil {
+		return nil, g.err
+	}
+	out["$schema"] = jsonSchemaDraft
+	out["title"] = "Botkube configuration"
+	out["definitions"] = g.defs
+
+	return json.MarshalIndent(out, "", "  ")
+}
+
+type pluginSchemas struct {
+	kind    string
+	schemas map[string]json.RawMessage
+}
+
+type schemaGenerator struct {
+	defs     map[string]any
+	defNames map[reflect.Type]string
+	plugins  map[reflect.Type]pluginSchemas
+	err      error
+}
+
+func (g *schemaGenerator) schemaOf(t reflect.Type) map[string]any {
+	switch {
+	case t == durationType:
+		return map[string]any{
+			"type":        []string{"string", "integer"},
+			"description": "Duration, such as `30s` or `5m`.",
+		}
+	case t.Kind() != reflect.Pointer && reflect.PointerTo(t).Implements(textUnmarshalerType):
+		return map[string]any{"type": "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return g.schemaOf(t.Elem())
+	// strings are accepted for booleans and numbers, as the configuration is loaded with weakly typed input, e.g. `enabled: "true"`
+	case reflect.Bool:
+		return weaklyTyped("boolean", `^(1|t|T|TRUE|true|True|0|f|F|FALSE|false|False)?$`)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return weaklyTyped("integer", `^[-+]?[0-9]*$`)
+	case reflect.Float32, reflect.Float64:
+		return weaklyTyped("number", `^[-+]?[0-9]*(\.[0-9]*)?([eE][-+]?[0-9]+)?$`)
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]any{"type": "string"}
+		}
+		return map[string]any{"type": "array", "items": g.schemaOf(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": g.schemaOf(t.Elem())}
+	case reflect.Struct:
+		return g.structRef(t)
+	default:
+		// interfaces, such as plugin configuration, accept any value
+		return map[string]any{}
+	}
+}
+
+func weaklyTyped(typ, strPattern string) map[string]any {
+	return map[string]any{
+		"anyOf": []any{
+			map[string]any{"type": typ},
+			map[string]any{"type": "string", "pattern": strPattern},
+		},
+	}
+}
+
+// structRef stores the schema of a given struct in definitions, so recursive and repeated types are described only once.
+func (g *schemaGenerator) structRef(t reflect.Type) map[string]any {
+	if t.Name() == "" {
+		return g.structSchema(t)
+	}
+
+	name, found := g.defNames[t]
+	if !found {
+		name = g.defName(t)
+		g.defNames[t] = name
+		// reserve the name, as nested types are described first
+		g.defs[name] = map[string]any{}
+		g.defs[name] = g.structSchema(t)
+	}
+	return map[string]any{"$ref": jsonSchemaDefsPrefix + name}
+}
+
+func (g *schemaGenerator) defName(t reflect.Type) string {
+	name := schemaDefNameRegex.ReplaceAllString(t.Name(), "_")
+	if _, taken := g.defs[name]; taken {
+		name = schemaDefNameRegex.ReplaceAllString(t.PkgPath()+"."+t.Name(), "_")
+	}
+	return name
+}
+
+func (g *schemaGenerator) structSchema(t reflect.Type) map[string]any {
+	fields, remain := configFields(t)
+	props := map[string]any{}
+	for _, field := range fields {
+		props[field.name] = g.schemaOf(field.typ)
+	}
+
+	out := map[string]any{
+		"type":                 "object",
+		"properties":           props,
+		"additionalProperties": false,
+	}
+	if remain == nil {
+		return out
+	}
+
+	// remaining properties hold plugins, e.g. `botkube/kubernetes` in a source group
+	pluginRef := g.schemaOf(remain.Elem())
+	out["additionalProperties"] = pluginRef
+	plugins := g.plugins[t]
+	for name, raw := range plugins.schemas {
+		cfgSchema, err := g.embedSchema(fmt.Sprintf("%s:%s", plugins.kind, name), raw)
+		if err != nil {
+			g.err = fmt.Errorf("while embedding JSON schema of %s plugin %q: %w", plugins.kind, name, err)
+			continue
+		}
+		props[name] = map[string]any{
+			"allOf": []any{
+				pluginRef,
+				map[string]any{"properties": map[string]any{"config": cfgSchema}},
+			},
+		}
+	}
+	return out
+}
+
+// embedSchema moves a given external schema together with its definitions to the definitions of the generated schema,
+// and rewrites its local references accordingly.
+func (g *schemaGenerator) embedSchema(name string, raw json.RawMessage) (map[string]any, error) {
+	var schema map[string]any
+	if err := json.Unmarshal(raw, &schema); err != nil {
+		return nil, err
+	}
+
+	prefix := schemaDefNameRegex.ReplaceAllString(name, "_")
+	for _, key := range []string{"definitions", "$defs"} {
+		defs, ok := schema[key].(map[string]any)
+		if !ok {
+			continue
+		}
+		for defName, def := range defs {
+			g.defs[prefix+"."+defName] = rewriteSchemaRefs(def, prefix)
+		}
+		delete(schema, key)
+	}
+	delete(schema, "$schema")
+	delete(schema, "$id")
+
+	g.defs[prefix] = rewriteSchemaRefs(schema, prefix)
+	return map[string]any{"$ref": jsonSchemaDefsPrefix + prefix}, nil
+}
+
+func rewriteSchemaRefs(in any, prefix string) any {
+	switch v := in.(type) {
+	case map[string]any:
+		for key, item := range v {
+			ref, isRef := item.(string)
+			if key != "$ref" || !isRef {
+				v[key] = rewriteSchemaRefs(item, prefix)
+				continue
+			}
+			switch {
+			case ref == "#":
+				v[key] = jsonSchemaDefsPrefix + prefix
+			case strings.HasPrefix(ref, jsonSchemaDefsPrefix):
+				v[key] = jsonSchemaDefsPrefix + prefix + "." + strings.TrimPrefix(ref, jsonSchemaDefsPrefix)
+			case strings.HasPrefix(ref, "#/$defs/"):
+				v[key] = jsonSchemaDefsPrefix + prefix + "." + strings.TrimPrefix(ref, "#/$defs/")
+			}
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = rewriteSchemaRefs(item, prefix)
+		}
+	}
+	return in
+}
+
+// configField is a single property of a configuration struct.
+type configField struct {
+	name string
+	typ  reflect.Type
+}
+
+// configFields returns properties of a given struct type, with inlined structs flattened, sorted by name.
+// It also returns the type of the map which holds all remaining properties, or nil if there is none.
+func configFields(t reflect.Type) ([]configField, reflect.Type) {
+	var (
+		out    []configField
+		remain reflect.Type
+	)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if name == "-" {
+			continue
+		}
+		inline := strings.Contains(opts, "inline") || strings.Contains(field.Tag.Get("mapstructure"), ",squash")
+		if !inline {
+			if name == "" {
+				name = strings.ToLower(field.Name)
+			}
+			out = append(out, configField{name: name, typ: field.Type})
+			continue
+		}
+
+		switch field.Type.Kind() {
+		case reflect.Struct:
+			nested, nestedRemain := configFields(field.Type)
+			out = append(out, nested...)
+			if nestedRemain != nil {
+				remain = nestedRemain
+			}
+		case reflect.Map:
+			remain = field.Type
+		}
+	}
+
+	sort.Slice(out, func(i, j int) bool { return out[i].name < out[j].name })
+	return out, remain
+}
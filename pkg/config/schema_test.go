@@ -0,0 +1,127 @@
+package config_test
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xeipuuv/gojsonschema"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+func TestJSONSchema(t *testing.T) {
+	// given
+	plugins := config.PluginConfigSchemas{
+		Executors: map[string]json.RawMessage{
+			"botkube/helm": json.RawMessage(heredoc.Doc(`
+				{
+				  "$schema": "http://json-schema.org/draft-07/schema#",
+				  "type": "object",
+				  "properties": {
+				    "helmDriver": {"$ref": "#/definitions/driver"}
+				  },
+				  "definitions": {
+				    "driver": {"type": "string", "enum": ["secret", "configmap", "memory"]}
+				  }
+				}`)),
+		},
+	}
+	defaults, err := os.ReadFile("default.yaml")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		config       string
+		expectedErrs []string
+	}{
+		{
+			name:   "default configuration",
+			config: string(defaults),
+		},
+		{
+			name: "valid configuration",
+			config: heredoc.Doc(`
+				executors:
+				  k8s-tools:
+				    displayName: Tools
+				    botkube/helm:
+				      enabled: true
+				      config:
+				        helmDriver: secret
+				    botkube/kubectl:
+				      enabled: true
+				      config:
+				        anything: goes
+				communications:
+				  default-group:
+				    socketSlack:
+				      enabled: true
+				      channels:
+				        default:
+				          name: general
+				          bindings:
+				            executors: [k8s-tools]
+				settings:
+				  clusterName: prod
+				plugins:
+				  healthCheckInterval: 10s
+				  incomingWebhook:
+				    port: 2115
+			`),
+		},
+		{
+			name: "invalid configuration",
+			config: heredoc.Doc(`
+				executors:
+				  k8s-tools:
+				    botkube/helm:
+				      enabled: true
+				      config:
+				        helmDriver: sql
+				communications:
+				  default-group:
+				    socketSlack:
+				      channels:
+				        default:
+				          name: general
+				          bindigs:
+				            executors: [k8s-tools]
+				plugins:
+				  incomingWebhook:
+				    enabled: "true"
+				    port: 2115a
+			`),
+			expectedErrs: []string{
+				"communications.default-group.socketSlack.channels.default: Additional property bindigs is not allowed",
+				`executors.k8s-tools.botkube/helm.config.helmDriver: executors.k8s-tools.botkube/helm.config.helmDriver must be one of the following: "secret", "configmap", "memory"`,
+				"executors.k8s-tools.botkube/helm: Must validate all the schemas (allOf)",
+				"plugins.incomingWebhook.port: Must validate at least one schema (anyOf)",
+				"plugins.incomingWebhook.port: Does not match pattern '^[-+]?[0-9]*$'",
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			schema, err := config.JSONSchema(plugins)
+			require.NoError(t, err)
+
+			// then
+			doc, err := yaml.YAMLToJSON([]byte(tc.config))
+			require.NoError(t, err)
+			result, err := gojsonschema.Validate(gojsonschema.NewBytesLoader(schema), gojsonschema.NewBytesLoader(doc))
+			require.NoError(t, err)
+
+			var errs []string
+			for _, desc := range result.Errors() {
+				errs = append(errs, desc.String())
+			}
+			assert.ElementsMatch(t, tc.expectedErrs, errs)
+		})
+	}
+}
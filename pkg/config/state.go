@@ -15,6 +15,19 @@ import (
 type RuntimeState struct {
 	Communications map[string]CommunicationsRuntimeState `yaml:"communications,omitempty"`
 	Actions        ActionsRuntimeState                   `yaml:"actions,omitempty"`
+	Executors      map[string]PluginsRuntimeState        `yaml:"executors,omitempty"`
+	Sources        map[string]PluginsRuntimeState        `yaml:"sources,omitempty"`
+	Processors     map[string]PluginsRuntimeState        `yaml:"processors,omitempty"`
+}
+
+// PluginsRuntimeState holds the runtime state of plugins defined in a given group, indexed by plugin keys.
+type PluginsRuntimeState map[string]PluginRuntimeState
+
+// PluginRuntimeState holds the plugin configuration edited at runtime.
+type PluginRuntimeState struct {
+	// Enabled is set only for plugins enabled at runtime, so it doesn't override the value from other configuration files.
+	Enabled *bool `yaml:"enabled,omitempty"`
+	Config  any   `yaml:"config,omitempty"`
 }
 
 // ActionsRuntimeState are the actions persisted in runtime state
@@ -36,6 +49,87 @@ func (a *ActionsRuntimeState) SetEnabled(name string, enabled bool) error {
 	return fmt.Errorf("action with name %q not found", name)
 }
 
+// SetPluginConfig sets the configuration of a given plugin. The pluginType is one of: executor, source, processor.
+func (s *RuntimeState) SetPluginConfig(pluginType, group, pluginKey string, cfg any) error {
+	plugins, err := s.pluginsGroup(pluginType, group)
+	if err != nil {
+		return err
+	}
+
+	plugin := plugins[pluginKey]
+	plugin.Config = cfg
+	plugins[pluginKey] = plugin
+	return nil
+}
+
+// EnablePlugin enables a given plugin. The configuration set at runtime is preserved. The pluginType is one of: executor, source, processor.
+func (s *RuntimeState) EnablePlugin(pluginType, group, pluginKey string) error {
+	plugins, err := s.pluginsGroup(pluginType, group)
+	if err != nil {
+		return err
+	}
+
+	enabled := true
+	plugin := plugins[pluginKey]
+	plugin.Enabled = &enabled
+	plugins[pluginKey] = plugin
+	return nil
+}
+
+func (s *RuntimeState) pluginsGroup(pluginType, group string) (PluginsRuntimeState, error) {
+	var groups *map[string]PluginsRuntimeState
+	switch pluginType {
+	case "executor":
+		groups = &s.Executors
+	case "source":
+		groups = &s.Sources
+	case "processor":
+		groups = &s.Processors
+	default:
+		return nil, fmt.Errorf("unknown plugin type %q", pluginType)
+	}
+
+	if *groups == nil {
+		*groups = map[string]PluginsRuntimeState{}
+	}
+	plugins, exists := (*groups)[group]
+	if !exists {
+		plugins = PluginsRuntimeState{}
+		(*groups)[group] = plugins
+	}
+	return plugins, nil
+}
+
+// SetChannelBindings sets bindings of a given channel. For the Teams integration, bindings are set for all channels.
+func (s *RuntimeState) SetChannelBindings(commGroupName string, platform CommPlatformIntegration, channelAlias string, bindings ChannelRuntimeBindings) {
+	if s.Communications == nil {
+		s.Communications = make(map[string]CommunicationsRuntimeState)
+	}
+	commGroup, exists := s.Communications[commGroupName]
+	if !exists {
+		commGroup = make(CommunicationsRuntimeState)
+		s.Communications[commGroupName] = commGroup
+	}
+
+	platformCfg := commGroup[platform]
+	if platform == TeamsCommPlatformIntegration {
+		if platformCfg.MSTeamsOnlyRuntimeState == nil {
+			platformCfg.MSTeamsOnlyRuntimeState = &ChannelRuntimeState{}
+		}
+		platformCfg.MSTeamsOnlyRuntimeState.Bindings = &bindings
+		commGroup[platform] = platformCfg
+		return
+	}
+
+	if platformCfg.Channels == nil {
+		platformCfg.Channels = make(map[string]ChannelRuntimeState)
+	}
+	channel := platformCfg.Channels[channelAlias]
+	channel.Bindings = &bindings
+	platformCfg.Channels[channelAlias] = channel
+	commGroup[platform] = platformCfg
+}
+
 // MarshalToMap marshals the runtime state to a string map.
 func (s RuntimeState) MarshalToMap(cfg PartialPersistentConfig) (map[string]string, error) {
 	return marshalToMap(&s, cfg.FileName)
@@ -54,12 +148,36 @@ type BotRuntimeState struct {
 
 // ChannelRuntimeState represents the runtime state for a channel.
 type ChannelRuntimeState struct {
-	Bindings ChannelRuntimeBindings `yaml:"bindings"`
+	Bindings     *ChannelRuntimeBindings          `yaml:"bindings,omitempty"`
+	Notification *ChannelNotificationRuntimeState `yaml:"notification,omitempty"`
+}
+
+// ChannelNotificationRuntimeState represents the notification settings for a channel.
+// Empty settings are persisted as well, so they override settings defined in other configuration files.
+type ChannelNotificationRuntimeState struct {
+	Profile           string `yaml:"profile"`
+	MinLevel          Level  `yaml:"minLevel"`
+	Filter            string `yaml:"filter"`
+	AggregationWindow string `yaml:"aggregationWindow"`
+	Locale            string `yaml:"locale"`
+}
+
+// NewChannelNotificationRuntimeState returns the runtime state for given notification settings.
+func NewChannelNotificationRuntimeState(in NotificationSettings) *ChannelNotificationRuntimeState {
+	return &ChannelNotificationRuntimeState{
+		Profile:           in.Profile,
+		MinLevel:          in.MinLevel,
+		Filter:            in.Filter,
+		AggregationWindow: in.AggregationWindow.String(),
+		Locale:            in.Locale,
+	}
 }
 
 // ChannelRuntimeBindings represents the bindings for a channel.
 type ChannelRuntimeBindings struct {
 	Sources []string `yaml:"sources"`
+	// Executors are set only if executor bindings are changed at runtime, so they don't override bindings from other configuration files.
+	Executors []string `yaml:"executors,omitempty"`
 }
 
 // StartupState represents the startup state.
@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/MakeNowJust/heredoc"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestActionsSetEnabled(t *testing.T) {
@@ -41,3 +43,64 @@ func TestActionsSetEnabled(t *testing.T) {
 		})
 	}
 }
+
+func TestRuntimeStateSetPluginConfig(t *testing.T) {
+	// given
+	state := &RuntimeState{
+		Executors: map[string]PluginsRuntimeState{
+			"helm": {"botkube/helm": PluginRuntimeState{Config: map[string]any{"replicas": 1}}},
+		},
+	}
+
+	// when
+	err := state.SetPluginConfig("executor", "helm", "botkube/helm", map[string]any{"replicas": 2})
+	assert.NoError(t, err)
+	err = state.SetPluginConfig("source", "k8s", "botkube/kubernetes", map[string]any{"namespace": "default"})
+	assert.NoError(t, err)
+	err = state.SetPluginConfig("bogus", "k8s", "botkube/kubernetes", nil)
+
+	// then
+	assert.EqualError(t, err, `unknown plugin type "bogus"`)
+	assert.Equal(t, map[string]any{"replicas": 2}, state.Executors["helm"]["botkube/helm"].Config)
+	assert.Equal(t, map[string]any{"namespace": "default"}, state.Sources["k8s"]["botkube/kubernetes"].Config)
+	assert.Nil(t, state.Processors)
+}
+
+func TestRuntimeStateEnablePlugin(t *testing.T) {
+	// given
+	state := &RuntimeState{
+		Executors: map[string]PluginsRuntimeState{
+			"helm": {"botkube/helm": PluginRuntimeState{Config: map[string]any{"replicas": 1}}},
+		},
+	}
+
+	// when
+	err := state.EnablePlugin("executor", "helm", "botkube/helm")
+	require.NoError(t, err)
+	state.SetChannelBindings("default", SocketSlackCommPlatformIntegration, "alias", ChannelRuntimeBindings{
+		Sources:   []string{"k8s-events"},
+		Executors: []string{"helm"},
+	})
+
+	// then
+	out, err := state.MarshalToMap(PartialPersistentConfig{FileName: "_runtime_state.yaml"})
+	require.NoError(t, err)
+	assert.Equal(t, heredoc.Doc(`
+		communications:
+		  default:
+		    socketSlack:
+		      channels:
+		        alias:
+		          bindings:
+		            sources:
+		              - k8s-events
+		            executors:
+		              - helm
+		executors:
+		  helm:
+		    botkube/helm:
+		      enabled: true
+		      config:
+		        replicas: 1
+	`), out["_runtime_state.yaml"])
+}
@@ -0,0 +1,168 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/kubeshop/botkube/pkg/multierror"
+)
+
+const (
+	yamlMergeKey = "<<"
+	// pluginNameSep separates the repository and plugin name in plugin keys, e.g. `botkube/kubernetes`.
+	pluginNameSep = "/"
+)
+
+// UnknownKey describes a property which is not a part of the Botkube configuration, so it's ignored, e.g. a misspelled `bindigs` instead of `bindings`.
+type UnknownKey struct {
+	// Name is the name of the property, e.g. `bindigs`.
+	Name string
+	// Path is the YAML path of the property, e.g. `communications.default-group.socketSlack.channels.default.bindigs`.
+	Path string
+	// Line is the line of the property in the configuration file.
+	Line int
+	// Known holds names of properties allowed in the same place.
+	Known []string
+}
+
+// String returns the property path together with its line.
+func (k UnknownKey) String() string {
+	return fmt.Sprintf("%s (line %d)", k.Path, k.Line)
+}
+
+// FindUnknownKeys returns all properties of a given configuration file which are not a part of the Botkube configuration.
+// Plugin configuration is not checked, as it's validated by plugins.
+func FindUnknownKeys(file []byte) ([]UnknownKey, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(file, &doc); err != nil {
+		return nil, err
+	}
+
+	var out []UnknownKey
+	findUnknownKeys(&doc, reflect.TypeOf(Config{}), "", &out)
+	return out, nil
+}
+
+// ValidateKnownKeys returns an error listing properties of given layers which are not a part of the Botkube configuration, together with their exact paths.
+func ValidateKnownKeys(layers Layers) error {
+	errs := multierror.New()
+	for _, layer := range layers {
+		keys, err := FindUnknownKeys(layer.Data)
+		if err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("while parsing %q: %w", layer.Name, err))
+			continue
+		}
+		for _, key := range keys {
+			errs = multierror.Append(errs, fmt.Errorf("unknown property %s in %q", key.String(), layer.Name))
+		}
+	}
+	if err := errs.ErrorOrNil(); err != nil {
+		return fmt.Errorf("strict mode: %w", err)
+	}
+	return nil
+}
+
+func findUnknownKeys(node *yaml.Node, t reflect.Type, path string, out *[]UnknownKey) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, child := range node.Content {
+			findUnknownKeys(child, t, path, out)
+		}
+		return
+	case yaml.AliasNode:
+		findUnknownKeys(node.Alias, t, path, out)
+		return
+	}
+
+	switch {
+	case t == durationType, t.Kind() == reflect.Interface:
+		return
+	case t.Kind() == reflect.Slice && node.Kind == yaml.SequenceNode:
+		for idx, item := range node.Content {
+			findUnknownKeys(item, t.Elem(), fmt.Sprintf("%s[%d]", path, idx), out)
+		}
+	case t.Kind() == reflect.Map && node.Kind == yaml.MappingNode:
+		forEachProperty(node, func(key, value *yaml.Node) {
+			findUnknownKeys(value, t.Elem(), joinPath(path, key.Value), out)
+		})
+	case t.Kind() == reflect.Struct && node.Kind == yaml.MappingNode:
+		fields, remain := configFields(t)
+		forEachProperty(node, func(key, value *yaml.Node) {
+			if field, found := fieldByName(fields, key.Value); found {
+				findUnknownKeys(value, field.typ, joinPath(path, key.Value), out)
+				return
+			}
+			// remaining properties must be plugins, as they are always prefixed with their repository name
+			if remain != nil && strings.Contains(key.Value, pluginNameSep) {
+				findUnknownKeys(value, remain.Elem(), joinPath(path, key.Value), out)
+				return
+			}
+
+			known := make([]string, 0, len(fields))
+			for _, field := range fields {
+				known = append(known, field.name)
+			}
+			*out = append(*out, UnknownKey{
+				Name:  key.Value,
+				Path:  joinPath(path, key.Value),
+				Line:  key.Line,
+				Known: known,
+			})
+		})
+	}
+}
+
+// forEachProperty calls a given function for all properties of a mapping node, including ones merged with the `<<` key.
+func forEachProperty(node *yaml.Node, fn func(key, value *yaml.Node)) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if key.Value != yamlMergeKey {
+			fn(key, value)
+			continue
+		}
+
+		for _, merged := range mergedMappings(value) {
+			forEachProperty(merged, fn)
+		}
+	}
+}
+
+func mergedMappings(node *yaml.Node) []*yaml.Node {
+	switch node.Kind {
+	case yaml.AliasNode:
+		return mergedMappings(node.Alias)
+	case yaml.MappingNode:
+		return []*yaml.Node{node}
+	case yaml.SequenceNode:
+		var out []*yaml.Node
+		for _, item := range node.Content {
+			out = append(out, mergedMappings(item)...)
+		}
+		return out
+	default:
+		return nil
+	}
+}
+
+// fieldByName finds a field with a given name. The name is case-insensitive, in the same way as when the configuration is loaded.
+func fieldByName(fields []configField, name string) (configField, bool) {
+	for _, field := range fields {
+		if strings.EqualFold(field.name, name) {
+			return field, true
+		}
+	}
+	return configField{}, false
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,83 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+func TestFindUnknownKeys(t *testing.T) {
+	// given
+	file := heredoc.Doc(`
+		x-channel: &channel
+		  name: general
+		  bindigs:
+		    executors: [k8s-tools]
+		communications:
+		  default-group:
+		    socketSlack:
+		      Enabled: true
+		      channels:
+		        default:
+		          <<: *channel
+		          notification:
+		            disabled: false
+		sources:
+		  k8s-events:
+		    displayName: Kubernetes
+		    botkube/kubernetes:
+		      enabled: true
+		      config:
+		        anything: goes
+		    dispalyName: typo
+		routing:
+		  rules:
+		    - channels: [general]
+		      labels:
+		        team: payments
+		      sorces: [k8s-events]
+		settings:
+		  log:
+		    level: info
+		    leve: debug
+	`)
+
+	// when
+	keys, err := config.FindUnknownKeys([]byte(file))
+
+	// then
+	require.NoError(t, err)
+	var got []string
+	for _, key := range keys {
+		got = append(got, key.String())
+	}
+	assert.Equal(t, []string{
+		"x-channel (line 1)",
+		"communications.default-group.socketSlack.channels.default.bindigs (line 3)",
+		"sources.k8s-events.dispalyName (line 21)",
+		"routing.rules[0].sorces (line 27)",
+		"settings.log.leve (line 31)",
+	}, got)
+	assert.Equal(t, "bindigs", keys[1].Name)
+	assert.Contains(t, keys[1].Known, "bindings")
+}
+
+func TestValidateKnownKeys(t *testing.T) {
+	// given
+	layers := config.Layers{
+		{Name: "global_config.yaml", Data: []byte("settings:\n  clusterName: prod\n")},
+		{Name: "comm_config.yaml", Data: []byte("communications:\n  default-group:\n    socketSlak:\n      enabled: true\n")},
+	}
+
+	// when
+	err := config.ValidateKnownKeys(layers)
+
+	// then
+	assert.EqualError(t, err, heredoc.Doc(`
+		strict mode: 1 error occurred:
+			* unknown property communications.default-group.socketSlak (line 3) in "comm_config.yaml"`))
+}
@@ -0,0 +1,354 @@
+package validation
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+
+	"github.com/kubeshop/botkube/internal/featureflag"
+	"github.com/kubeshop/botkube/internal/filter"
+	"github.com/kubeshop/botkube/internal/notification"
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+const celExampleFix = "Fix the expression syntax, e.g. `event.Level == \"error\" && event.Namespace.startsWith(\"prod-\")`."
+
+// checker runs validations which span multiple configuration parts, and collects found issues.
+type checker struct {
+	cfg    config.Config
+	issues []Issue
+}
+
+func (c *checker) report(enabled bool, path, msg, fix string) {
+	severity := ErrorSeverity
+	if !enabled {
+		// disabled components are not started, so they cannot break Botkube
+		severity = WarningSeverity
+	}
+	c.issues = append(c.issues, Issue{Severity: severity, Path: path, Message: msg, Fix: fix})
+}
+
+// checkSourceBindingReferences checks source bindings used outside communication platform bindings,
+// which are already covered by the struct validation.
+func (c *checker) checkSourceBindingReferences() {
+	check := func(enabled bool, path string, bindings []string) {
+		for idx, name := range bindings {
+			if _, found := c.cfg.Sources[name]; found {
+				continue
+			}
+			c.report(enabled, fmt.Sprintf("%s[%d]", path, idx), fmt.Sprintf("source binding %q is not defined", name), undefinedNameFix(name, "sources", keysOf(c.cfg.Sources)))
+		}
+	}
+
+	for _, name := range keysOf(c.cfg.Filters) {
+		f := c.cfg.Filters[name]
+		check(f.Enabled, fmt.Sprintf("filters.%s.routeTo", name), f.RouteTo)
+	}
+	for _, name := range keysOf(c.cfg.Enrichments) {
+		e := c.cfg.Enrichments[name]
+		check(e.Enabled, fmt.Sprintf("enrichments.%s.sources", name), e.Sources)
+	}
+	for _, name := range keysOf(c.cfg.Escalations) {
+		e := c.cfg.Escalations[name]
+		check(e.Enabled, fmt.Sprintf("escalations.%s.sources", name), e.Sources)
+		for idx, step := range e.Steps {
+			check(e.Enabled, fmt.Sprintf("escalations.%s.steps[%d].routeTo", name, idx), step.RouteTo)
+		}
+	}
+	for _, name := range keysOf(c.cfg.Tickets) {
+		t := c.cfg.Tickets[name]
+		check(t.Enabled, fmt.Sprintf("tickets.%s.sources", name), t.Sources)
+	}
+	for _, name := range keysOf(c.cfg.MaintenanceWindows) {
+		w := c.cfg.MaintenanceWindows[name]
+		check(w.Enabled, fmt.Sprintf("maintenanceWindows.%s.sources", name), w.Sources)
+	}
+	check(c.cfg.Routing.Enabled, "routing.sources", c.cfg.Routing.Sources)
+	check(c.cfg.Settings.Incidents.Enabled, "settings.incidents.sources", c.cfg.Settings.Incidents.Sources)
+}
+
+// checkChannelReferences checks channels used by routing rules. They must be either aliases or names of channels of enabled communication platforms.
+func (c *checker) checkChannelReferences() {
+	channels := c.enabledChannels()
+	check := func(path string, names []string) {
+		for idx, name := range names {
+			if _, found := channels[name]; found {
+				continue
+			}
+			fix := undefinedNameFix(name, "communications", maps.Keys(channels))
+			c.report(c.cfg.Routing.Enabled, fmt.Sprintf("%s[%d]", path, idx), fmt.Sprintf("channel %q is not defined for any enabled communication platform", name), fix)
+		}
+	}
+
+	for idx, rule := range c.cfg.Routing.Rules {
+		check(fmt.Sprintf("routing.rules[%d].channels", idx), rule.Channels)
+	}
+	check("routing.fallback", c.cfg.Routing.Fallback)
+}
+
+func (c *checker) enabledChannels() map[string]struct{} {
+	out := map[string]struct{}{}
+	add := func(alias, identifier string) {
+		out[alias] = struct{}{}
+		out[identifier] = struct{}{}
+	}
+
+	for _, comm := range c.cfg.Communications {
+		if comm.SocketSlack.Enabled {
+			for alias, ch := range comm.SocketSlack.Channels {
+				add(alias, ch.Identifier())
+			}
+		}
+		if comm.CloudSlack.Enabled {
+			for alias, ch := range comm.CloudSlack.Channels {
+				add(alias, ch.Identifier())
+				if ch.Alias != nil {
+					out[*ch.Alias] = struct{}{}
+				}
+			}
+		}
+		if comm.Mattermost.Enabled {
+			for alias, ch := range comm.Mattermost.Channels {
+				add(alias, ch.Identifier())
+			}
+		}
+		if comm.Discord.Enabled {
+			for alias, ch := range comm.Discord.Channels {
+				add(alias, ch.Identifier())
+			}
+		}
+		if comm.CloudTeams.Enabled {
+			for _, team := range comm.CloudTeams.Teams {
+				for alias, ch := range team.Channels {
+					add(alias, ch.Identifier())
+				}
+			}
+		}
+	}
+	return out
+}
+
+// checkExpressions compiles all CEL expressions.
+func (c *checker) checkExpressions() {
+	check := func(enabled bool, path, expr string, vars []string) {
+		if expr == "" {
+			return
+		}
+		if _, err := filter.CompileWithVariables(expr, vars...); err != nil {
+			c.report(enabled, path, fmt.Sprintf("invalid CEL expression: %s", err.Error()), celExampleFix)
+		}
+	}
+
+	for _, name := range keysOf(c.cfg.Filters) {
+		f := c.cfg.Filters[name]
+		check(f.Enabled, fmt.Sprintf("filters.%s.expression", name), f.Expression, filter.InputVariables)
+	}
+	for _, name := range keysOf(c.cfg.Actions) {
+		a := c.cfg.Actions[name]
+		check(a.Enabled, fmt.Sprintf("actions.%s.condition", name), a.Condition, filter.ActionVariables)
+	}
+	for _, name := range keysOf(c.cfg.Escalations) {
+		e := c.cfg.Escalations[name]
+		check(e.Enabled, fmt.Sprintf("escalations.%s.condition", name), e.Condition, filter.InputVariables)
+	}
+	for _, name := range keysOf(c.cfg.Tickets) {
+		t := c.cfg.Tickets[name]
+		check(t.Enabled, fmt.Sprintf("tickets.%s.condition", name), t.Condition, filter.InputVariables)
+		check(t.Enabled, fmt.Sprintf("tickets.%s.resolveCondition", name), t.ResolveCondition, filter.InputVariables)
+	}
+}
+
+// checkNotificationSettings compiles notification filters, and checks if used locales, notification profiles and content filters are defined.
+func (c *checker) checkNotificationSettings() {
+	profiles := c.cfg.NotificationProfileNames()
+	for _, item := range notification.AllSettings(c.cfg) {
+		if name := item.Settings.Profile; name != "" {
+			switch {
+			case strings.HasPrefix(item.Path, "settings.notificationProfiles."):
+				c.report(item.Enabled, item.Path+".profile", "notification profiles cannot select other profiles", "Copy the settings of the selected profile instead.")
+			case !slices.Contains(profiles, name):
+				fix := fmt.Sprintf("Use one of the notification profiles: %s.", strings.Join(profiles, ", "))
+				if suggestion := closest(name, profiles); suggestion != "" {
+					fix = fmt.Sprintf("Did you mean %q? %s", suggestion, fix)
+				}
+				c.report(item.Enabled, item.Path+".profile", fmt.Sprintf("notification profile %q is not defined", name), fix)
+			}
+		}
+		if expr := item.Settings.Filter; expr != "" {
+			if _, err := filter.Compile(expr); err != nil {
+				c.report(item.Enabled, item.Path+".filter", fmt.Sprintf("invalid CEL expression: %s", err.Error()), celExampleFix)
+			}
+		}
+		if locale := item.Settings.Locale; locale != "" && !notification.IsLocaleSupported(locale) {
+			fix := fmt.Sprintf("Use one of the supported locales: %s.", strings.Join(notification.SupportedLocales(), ", "))
+			if suggestion := closest(locale, notification.SupportedLocales()); suggestion != "" {
+				fix = fmt.Sprintf("Did you mean %q? %s", suggestion, fix)
+			}
+			c.report(item.Enabled, item.Path+".locale", fmt.Sprintf("locale %q is not supported", locale), fix)
+		}
+		if name := item.Settings.ContentFilter; name != "" {
+			if _, found := c.cfg.Settings.ContentFilters[name]; !found {
+				c.report(item.Enabled, item.Path+".contentFilter", fmt.Sprintf("content filter %q is not defined", name), undefinedNameFix(name, "settings.contentFilters", keysOf(c.cfg.Settings.ContentFilters)))
+			}
+		}
+	}
+}
+
+// checkFeatureFlags checks if feature flags set globally and for channels are known. Unknown flags are ignored, so they are reported as warnings.
+func (c *checker) checkFeatureFlags() {
+	names := featureflag.Names()
+	check := func(path string, flags map[string]bool) {
+		for _, name := range keysOf(flags) {
+			if _, found := featureflag.Lookup(name); found {
+				continue
+			}
+			fix := fmt.Sprintf("Use one of the feature flags: %s.", strings.Join(names, ", "))
+			if suggestion := closest(name, names); suggestion != "" {
+				fix = fmt.Sprintf("Did you mean %q? %s", suggestion, fix)
+			}
+			c.issues = append(c.issues, Issue{Severity: WarningSeverity, Path: fmt.Sprintf("%s.%s", path, name), Message: fmt.Sprintf("feature flag %q is not defined", name), Fix: fix})
+		}
+	}
+
+	check("settings.featureFlags", c.cfg.Settings.FeatureFlags)
+	for _, item := range featureflag.ChannelFlags(c.cfg) {
+		check(item.Path, item.Flags)
+	}
+}
+
+// checkRegexConstraints compiles all regular expressions.
+func (c *checker) checkRegexConstraints() {
+	check := func(enabled bool, path, expr string) {
+		if _, err := regexp.Compile(expr); err != nil {
+			c.report(enabled, path, fmt.Sprintf("invalid regular expression: %s", err.Error()), "Fix the syntax, or escape special characters such as `.`, `*` and `(` with `\\`.")
+		}
+	}
+	checkConstraints := func(enabled bool, path string, rc config.RegexConstraints) {
+		for idx, expr := range rc.Include {
+			check(enabled, fmt.Sprintf("%s.include[%d]", path, idx), expr)
+		}
+		for idx, expr := range rc.Exclude {
+			check(enabled, fmt.Sprintf("%s.exclude[%d]", path, idx), expr)
+		}
+	}
+	checkTriggers := func(enabled bool, path string, channels config.IdentifiableMap[config.ChannelBindingsByName]) {
+		for _, alias := range keysOf(channels) {
+			for idx, trigger := range channels[alias].MessageTriggers {
+				checkConstraints(enabled, fmt.Sprintf("%s.channels.%s.messageTriggers[%d].text", path, alias, idx), trigger.Text)
+			}
+		}
+	}
+
+	for _, group := range keysOf(c.cfg.Communications) {
+		comm := c.cfg.Communications[group]
+		checkTriggers(comm.SocketSlack.Enabled, fmt.Sprintf("communications.%s.socketSlack", group), comm.SocketSlack.Channels)
+		checkTriggers(comm.Mattermost.Enabled, fmt.Sprintf("communications.%s.mattermost", group), comm.Mattermost.Channels)
+
+		cloudSlackChannels := config.IdentifiableMap[config.ChannelBindingsByName]{}
+		for alias, ch := range comm.CloudSlack.Channels {
+			cloudSlackChannels[alias] = ch.ChannelBindingsByName
+		}
+		checkTriggers(comm.CloudSlack.Enabled, fmt.Sprintf("communications.%s.cloudSlack", group), cloudSlackChannels)
+	}
+	for _, name := range keysOf(c.cfg.MaintenanceWindows) {
+		w := c.cfg.MaintenanceWindows[name]
+		checkConstraints(w.Enabled, fmt.Sprintf("maintenanceWindows.%s.namespaces", name), w.Namespaces)
+	}
+	for idx, timeout := range c.cfg.Settings.Execution.Timeouts {
+		check(true, fmt.Sprintf("settings.execution.timeouts[%d].command", idx), timeout.Command)
+	}
+	for _, name := range keysOf(c.cfg.Settings.ContentFilters) {
+		for idx, expr := range c.cfg.Settings.ContentFilters[name].Patterns {
+			check(true, fmt.Sprintf("settings.contentFilters.%s.patterns[%d]", name, idx), expr)
+		}
+	}
+}
+
+// checkPluginRepositories checks if repositories of all enabled plugins are defined, so the plugins can be downloaded.
+func (c *checker) checkPluginRepositories() {
+	repos := keysOf(c.cfg.Plugins.Repositories)
+	check := func(path string, plugins config.Plugins) {
+		for _, key := range keysOf(plugins) {
+			if !plugins[key].Enabled {
+				continue
+			}
+			repo, _, _, err := config.DecomposePluginKey(key)
+			if err != nil {
+				// invalid keys are reported by the struct validation
+				continue
+			}
+			if _, found := c.cfg.Plugins.Repositories[repo]; found {
+				continue
+			}
+			c.report(true, fmt.Sprintf("%s.%s", path, key), fmt.Sprintf("plugin repository %q is not defined", repo), undefinedNameFix(repo, "plugins.repositories", repos))
+		}
+	}
+
+	for _, name := range keysOf(c.cfg.Sources) {
+		check(fmt.Sprintf("sources.%s", name), c.cfg.Sources[name].Plugins)
+	}
+	for _, name := range keysOf(c.cfg.Executors) {
+		check(fmt.Sprintf("executors.%s", name), c.cfg.Executors[name].Plugins)
+	}
+	for _, name := range keysOf(c.cfg.Processors) {
+		check(fmt.Sprintf("processors.%s", name), c.cfg.Processors[name].Plugins)
+	}
+}
+
+// undefinedNameFix suggests the most similar defined name, if there is any.
+func undefinedNameFix(name, section string, defined []string) string {
+	if suggestion := closest(name, defined); suggestion != "" {
+		return fmt.Sprintf("Did you mean %q? Otherwise, define %q under %q.", suggestion, name, section)
+	}
+	return fmt.Sprintf("Define %q under %q, or remove the reference.", name, section)
+}
+
+// closest returns the candidate with the lowest edit distance to a given name, as long as the distance is small enough to be a typo.
+func closest(name string, candidates []string) string {
+	sort.Strings(candidates)
+
+	maxDist := len(name)/4 + 1
+	var (
+		out     string
+		outDist = maxDist + 1
+	)
+	for _, candidate := range candidates {
+		if dist := editDistance(name, candidate); dist < outDist {
+			out, outDist = candidate, dist
+		}
+	}
+	return out
+}
+
+// editDistance returns the Levenshtein distance between two strings.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr := make([]int, len(rb)+1)
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev = curr
+	}
+	return prev[len(rb)]
+}
+
+func keysOf[V any](in map[string]V) []string {
+	keys := maps.Keys(in)
+	sort.Strings(keys)
+	return keys
+}
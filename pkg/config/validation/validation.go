@@ -0,0 +1,220 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/multierror"
+)
+
+// Severity defines how serious a given issue is.
+type Severity string
+
+const (
+	// ErrorSeverity marks issues which prevent Botkube from starting.
+	ErrorSeverity Severity = "error"
+	// WarningSeverity marks issues which don't prevent Botkube from starting, but likely lead to unexpected behavior.
+	WarningSeverity Severity = "warning"
+)
+
+// Issue describes a single configuration issue.
+type Issue struct {
+	Severity Severity
+	// Path is the YAML path of the invalid property, e.g. `routing.rules[0].channels[1]`. It's empty for issues which concern the whole configuration.
+	Path    string
+	Message string
+	// Fix describes how to resolve the issue. It's empty if there is no better advice than the message itself.
+	Fix string
+}
+
+// Result holds all issues found in the configuration.
+type Result struct {
+	Issues []Issue
+}
+
+// Errors returns issues which prevent Botkube from starting.
+func (r Result) Errors() []Issue {
+	return r.filter(ErrorSeverity)
+}
+
+// Warnings returns issues which don't prevent Botkube from starting.
+func (r Result) Warnings() []Issue {
+	return r.filter(WarningSeverity)
+}
+
+// Err returns all issues with the error severity as a single error, or nil if there are none.
+func (r Result) Err() error {
+	issues := multierror.New()
+	for _, issue := range r.Errors() {
+		issues = multierror.Append(issues, errors.New(issue.String()))
+	}
+	return issues.ErrorOrNil()
+}
+
+// Print writes a human-readable report of all issues.
+func (r Result) Print(w io.Writer) {
+	if len(r.Issues) == 0 {
+		fmt.Fprintln(w, "Configuration is valid.")
+		return
+	}
+
+	for _, issue := range r.Issues {
+		fmt.Fprintf(w, "%-8s %s\n", strings.ToUpper(string(issue.Severity)), issue.String())
+		if issue.Fix != "" {
+			fmt.Fprintf(w, "%-8s fix: %s\n", "", issue.Fix)
+		}
+	}
+	fmt.Fprintf(w, "\nFound %d error(s) and %d warning(s).\n", len(r.Errors()), len(r.Warnings()))
+}
+
+func (r Result) filter(severity Severity) []Issue {
+	var out []Issue
+	for _, issue := range r.Issues {
+		if issue.Severity == severity {
+			out = append(out, issue)
+		}
+	}
+	return out
+}
+
+// String returns the issue message prefixed with its path.
+func (i Issue) String() string {
+	if i.Path == "" {
+		return i.Message
+	}
+	return fmt.Sprintf("%s: %s", i.Path, i.Message)
+}
+
+// Configs merges given configuration files with the default configuration in the same way as Botkube does on startup,
+// and validates the merged configuration. Properties which are not a part of the Botkube configuration are reported as warnings,
+// or as errors if strict is true, as Botkube refuses to start with them in the strict mode.
+func Configs(files config.YAMLFiles, strict bool) Result {
+	var issues []Issue
+	for idx, file := range files {
+		var out any
+		if err := yaml.Unmarshal(file, &out); err != nil {
+			issues = append(issues, Issue{
+				Severity: ErrorSeverity,
+				Message:  fmt.Sprintf("configuration file %d is not a valid YAML: %s", idx+1, err.Error()),
+				Fix:      "Fix the syntax in the reported line. Make sure that the indentation uses spaces only.",
+			})
+		}
+	}
+	if len(issues) > 0 {
+		return Result{Issues: issues}
+	}
+	unknownKeys := unknownKeysIssues(files, strict)
+
+	cfg, err := config.MergeWithDefaults(files)
+	if err != nil {
+		return Result{Issues: []Issue{{
+			Severity: ErrorSeverity,
+			Message:  fmt.Sprintf("cannot decode configuration: %s", err.Error()),
+			Fix:      "Check the types of the reported properties. For example, durations must be specified as strings such as `30s` or `5m`.",
+		}}}
+	}
+
+	result := Config(*cfg)
+	result.Issues = append(unknownKeys, result.Issues...)
+	sortIssues(result.Issues)
+	return result
+}
+
+func unknownKeysIssues(files config.YAMLFiles, strict bool) []Issue {
+	severity, msgFmt := WarningSeverity, "unknown property in configuration file %d, line %d, is ignored"
+	if strict {
+		severity, msgFmt = ErrorSeverity, "unknown property in configuration file %d, line %d"
+	}
+
+	var issues []Issue
+	for idx, file := range files {
+		// files are already parsed successfully
+		keys, _ := config.FindUnknownKeys(file)
+		for _, key := range keys {
+			fix := "Remove the property, or check its indentation."
+			if suggestion := closest(key.Name, key.Known); suggestion != "" {
+				fix = fmt.Sprintf("Did you mean %q? Otherwise, remove the property.", suggestion)
+			}
+			issues = append(issues, Issue{
+				Severity: severity,
+				Path:     key.Path,
+				Message:  fmt.Sprintf(msgFmt, idx+1, key.Line),
+				Fix:      fix,
+			})
+		}
+	}
+	return issues
+}
+
+// Config validates a given configuration. Apart from the validation done on Botkube startup, it checks also references between
+// configuration parts, such as source bindings used by filters or channels used by routing rules, and syntax of CEL and regular expressions.
+func Config(cfg config.Config) Result {
+	var issues []Issue
+
+	result, err := config.ValidateStruct(cfg)
+	if err != nil {
+		issues = append(issues, Issue{
+			Severity: ErrorSeverity,
+			Message:  fmt.Sprintf("cannot validate configuration: %s", err.Error()),
+		})
+	}
+	for _, field := range result.Fields {
+		issues = append(issues, issueForField(cfg, field))
+	}
+
+	c := checker{cfg: cfg}
+	c.checkSourceBindingReferences()
+	c.checkChannelReferences()
+	c.checkExpressions()
+	c.checkNotificationSettings()
+	c.checkFeatureFlags()
+	c.checkRegexConstraints()
+	c.checkPluginRepositories()
+	issues = append(issues, c.issues...)
+	sortIssues(issues)
+
+	return Result{Issues: issues}
+}
+
+// sortIssues puts errors first, and orders issues by paths to get the same report for the same configuration.
+func sortIssues(issues []Issue) {
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].Severity != issues[j].Severity {
+			return issues[i].Severity == ErrorSeverity
+		}
+		return issues[i].Path < issues[j].Path
+	})
+}
+
+func issueForField(cfg config.Config, field config.FieldIssue) Issue {
+	issue := Issue{
+		Severity: ErrorSeverity,
+		Path:     field.Path,
+		Message:  field.Message,
+	}
+	if field.Warning {
+		issue.Severity = WarningSeverity
+	}
+
+	switch field.Tag {
+	case "required", "required_if", "required_with":
+		issue.Fix = "Set a value for this property."
+	case "oneof":
+		issue.Fix = fmt.Sprintf("Use one of the allowed values: %s.", strings.Join(strings.Fields(field.Param), ", "))
+	case "invalid_binding":
+		section, names := "sources", keysOf(cfg.Sources)
+		if field.Param == "Config.Executors" {
+			section, names = "executors", keysOf(cfg.Executors)
+		}
+		issue.Fix = undefinedNameFix(field.Field, section, names)
+	case "invalid_plugin_rbac":
+		issue.Fix = "Use the same `context.rbac` settings for the same plugins bound together, or bind them to different channels."
+	}
+	return issue
+}
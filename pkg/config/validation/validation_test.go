@@ -0,0 +1,287 @@
+package validation_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/config/validation"
+)
+
+func TestConfigs(t *testing.T) {
+	// given
+	files := config.YAMLFiles{
+		[]byte(heredoc.Doc(`
+			plugins:
+			  repositories:
+			    botkube:
+			      url: https://example.com/botkube.yaml
+			sources:
+			  k8s-events:
+			    botkube/kubernetes:
+			      enabled: true
+			executors:
+			  echo:
+			    botkube-labs/echo:
+			      enabled: true
+			communications:
+			  default-group:
+			    socketSlack:
+			      enabled: true
+			      botToken: xoxb-123
+			      appToken: xapp-456
+			      channels:
+			        default:
+			          name: general
+			          bindings:
+			            sources: [k8s-evnts]
+			          featureFlags:
+			            aggregation: false
+			            aiExecuter: false
+		`)),
+		[]byte(heredoc.Doc(`
+			filters:
+			  noisy:
+			    enabled: true
+			    expression: 'event.Kind =='
+			    action: drop
+			escalations:
+			  critical:
+			    enabled: false
+			    sources: [k8s-eventz]
+			    steps:
+			      - after: 10m
+			maintenanceWindows:
+			  nightly:
+			    enabled: true
+			    schedule: "0 1 * * *"
+			    duration: 1h
+			    namespaces:
+			      include: ["prod-("]
+			routing:
+			  enabled: true
+			  rules:
+			    - labels:
+			        team: payments
+			      channels: [genral]
+			settings:
+			  notification:
+			    locale: en-GB
+			    profile: quiet-prd
+			  notificationProfiles:
+			    payments:
+			      profile: verbose-dev
+			      contentFilter: public-chanel
+			  contentFilters:
+			    public-channel:
+			      patterns: ["(internal"]
+		`)),
+	}
+
+	// when
+	result := validation.Configs(files, false)
+
+	// then
+	assert.Equal(t, []validation.Issue{
+		{
+			Severity: validation.ErrorSeverity,
+			Path:     "communications.default-group.socketSlack.channels.default.bindings",
+			Message:  "'k8s-evnts' binding not defined in Config.Sources",
+			Fix:      `Did you mean "k8s-events"? Otherwise, define "k8s-evnts" under "sources".`,
+		},
+		{
+			Severity: validation.ErrorSeverity,
+			Path:     "executors.echo.botkube-labs/echo",
+			Message:  `plugin repository "botkube-labs" is not defined`,
+			Fix:      `Define "botkube-labs" under "plugins.repositories", or remove the reference.`,
+		},
+		{
+			Severity: validation.ErrorSeverity,
+			Path:     "filters.noisy.expression",
+			Message:  "invalid CEL expression: Syntax error: mismatched input '<EOF>' expecting {'[', '{', '(', '.', '-', '!', 'true', 'false', 'null', NUM_FLOAT, NUM_INT, NUM_UINT, STRING, BYTES, IDENTIFIER} at position 13",
+			Fix:      "Fix the expression syntax, e.g. `event.Level == \"error\" && event.Namespace.startsWith(\"prod-\")`.",
+		},
+		{
+			Severity: validation.ErrorSeverity,
+			Path:     "maintenanceWindows.nightly.namespaces.include[0]",
+			Message:  "invalid regular expression: error parsing regexp: missing closing ): `prod-(`",
+			Fix:      "Fix the syntax, or escape special characters such as `.`, `*` and `(` with `\\`.",
+		},
+		{
+			Severity: validation.ErrorSeverity,
+			Path:     "routing.rules[0].channels[0]",
+			Message:  `channel "genral" is not defined for any enabled communication platform`,
+			Fix:      `Did you mean "general"? Otherwise, define "genral" under "communications".`,
+		},
+		{
+			Severity: validation.ErrorSeverity,
+			Path:     "settings.contentFilters.public-channel.patterns[0]",
+			Message:  "invalid regular expression: error parsing regexp: missing closing ): `(internal`",
+			Fix:      "Fix the syntax, or escape special characters such as `.`, `*` and `(` with `\\`.",
+		},
+		{
+			Severity: validation.ErrorSeverity,
+			Path:     "settings.notification.locale",
+			Message:  `locale "en-GB" is not supported`,
+			Fix:      `Did you mean "en_GB"? Use one of the supported locales: cs, da, de, en, en_GB, en_US, es, fi, fr, it, ja, ko, nb, nl, pl, pt, pt_BR, sv, tr, uk, zh.`,
+		},
+		{
+			Severity: validation.ErrorSeverity,
+			Path:     "settings.notification.profile",
+			Message:  `notification profile "quiet-prd" is not defined`,
+			Fix:      `Did you mean "quiet-prod"? Use one of the notification profiles: payments, quiet-prod, security-focused, verbose-dev.`,
+		},
+		{
+			Severity: validation.ErrorSeverity,
+			Path:     "settings.notificationProfiles.payments.contentFilter",
+			Message:  `content filter "public-chanel" is not defined`,
+			Fix:      `Did you mean "public-channel"? Otherwise, define "public-chanel" under "settings.contentFilters".`,
+		},
+		{
+			Severity: validation.ErrorSeverity,
+			Path:     "settings.notificationProfiles.payments.profile",
+			Message:  "notification profiles cannot select other profiles",
+			Fix:      "Copy the settings of the selected profile instead.",
+		},
+		{
+			Severity: validation.WarningSeverity,
+			Path:     "communications.default-group.socketSlack.channels.default.featureFlags.aiExecuter",
+			Message:  `feature flag "aiExecuter" is not defined`,
+			Fix:      `Did you mean "aiExecutor"? Use one of the feature flags: aggregation, aiExecutor.`,
+		},
+		{
+			Severity: validation.WarningSeverity,
+			Path:     "escalations.critical.sources[0]",
+			Message:  `source binding "k8s-eventz" is not defined`,
+			Fix:      `Did you mean "k8s-events"? Otherwise, define "k8s-eventz" under "sources".`,
+		},
+	}, result.Issues)
+	assert.Len(t, result.Errors(), 10)
+	assert.Len(t, result.Warnings(), 2)
+	assert.Error(t, result.Err())
+}
+
+func TestConfigsInvalidYAML(t *testing.T) {
+	// given
+	files := config.YAMLFiles{
+		[]byte("settings:\n  clusterName: prod\n"),
+		[]byte("communications:\n  default-group:\n\tsocketSlack: {}\n"),
+	}
+
+	// when
+	result := validation.Configs(files, false)
+
+	// then
+	require.Len(t, result.Issues, 1)
+	assert.EqualError(t, result.Err(), heredoc.Doc(`
+		1 error occurred:
+			* configuration file 2 is not a valid YAML: yaml: line 3: found character that cannot start any token`))
+}
+
+func TestConfigsUnknownKeys(t *testing.T) {
+	// given
+	files := config.YAMLFiles{
+		[]byte(heredoc.Doc(`
+			executors:
+			  k8s-tools:
+			    botkube/kubectl:
+			      enabled: true
+			communications:
+			  default-group:
+			    socketSlack:
+			      enabled: true
+			      botToken: xoxb-123
+			      appToken: xapp-456
+			      channels:
+			        default:
+			          name: general
+			          bindigs:
+			            executors: [k8s-tools]
+			      fooBar: baz
+			plugins:
+			  repositories:
+			    botkube:
+			      url: https://example.com/botkube.yaml
+		`)),
+	}
+	expected := []validation.Issue{
+		{
+			Path:    "communications.default-group.socketSlack.channels.default.bindigs",
+			Message: "unknown property in configuration file 1, line 14",
+			Fix:     `Did you mean "bindings"? Otherwise, remove the property.`,
+		},
+		{
+			Path:    "communications.default-group.socketSlack.fooBar",
+			Message: "unknown property in configuration file 1, line 16",
+			Fix:     "Remove the property, or check its indentation.",
+		},
+	}
+
+	t.Run("default mode", func(t *testing.T) {
+		// when
+		result := validation.Configs(files, false)
+
+		// then
+		for i := range expected {
+			expected[i].Severity = validation.WarningSeverity
+			expected[i].Message += ", is ignored"
+		}
+		assert.Equal(t, expected, result.Issues)
+		assert.NoError(t, result.Err())
+	})
+
+	t.Run("strict mode", func(t *testing.T) {
+		// when
+		result := validation.Configs(files, true)
+
+		// then
+		for i := range expected {
+			expected[i].Severity = validation.ErrorSeverity
+			expected[i].Message = strings.TrimSuffix(expected[i].Message, ", is ignored")
+		}
+		assert.Equal(t, expected, result.Issues)
+		assert.Error(t, result.Err())
+	})
+}
+
+func TestResultPrint(t *testing.T) {
+	// given
+	result := validation.Result{Issues: []validation.Issue{
+		{
+			Severity: validation.ErrorSeverity,
+			Path:     "routing.fallback[0]",
+			Message:  `channel "genral" is not defined for any enabled communication platform`,
+			Fix:      `Did you mean "general"? Otherwise, define "genral" under "communications".`,
+		},
+		{
+			Severity: validation.WarningSeverity,
+			Path:     "communications.default-group.socketSlack.UPPER",
+			Message:  "The channel name 'UPPER' seems to be invalid.",
+		},
+	}}
+	var out bytes.Buffer
+
+	// when
+	result.Print(&out)
+
+	// then
+	assert.Equal(t, heredoc.Doc(`
+		ERROR    routing.fallback[0]: channel "genral" is not defined for any enabled communication platform
+		         fix: Did you mean "general"? Otherwise, define "genral" under "communications".
+		WARNING  communications.default-group.socketSlack.UPPER: The channel name 'UPPER' seems to be invalid.
+
+		Found 1 error(s) and 1 warning(s).
+	`), out.String())
+
+	// when there are no issues
+	out.Reset()
+	validation.Result{}.Print(&out)
+
+	// then
+	assert.Equal(t, "Configuration is valid.\n", out.String())
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"reflect"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/go-playground/locales/en"
@@ -11,6 +12,7 @@ import (
 	"github.com/go-playground/validator/v10"
 	en_translations "github.com/go-playground/validator/v10/translations/en"
 	"github.com/hashicorp/go-multierror"
+	"k8s.io/apimachinery/pkg/api/resource"
 
 	"github.com/kubeshop/botkube/pkg/conversation"
 	"github.com/kubeshop/botkube/pkg/execute/command"
@@ -28,6 +30,16 @@ const (
 	invalidAliasCommandTag      = "invalid_alias_command"
 	invalidPluginRBACTag        = "invalid_plugin_rbac"
 	invalidActionRBACTag        = "invalid_action_tag"
+	invalidActionChatUserTag    = "invalid_action_chat_user"
+	invalidSourceChatUserTag    = "invalid_source_chat_user"
+	conflictingTicketTrackerTag = "conflicting_ticket_tracker"
+	invalidQuantityTag          = "invalid_quantity"
+	unsupportedOverflowTag      = "unsupported_overflow"
+	conflictingSettingTag       = "conflicting_setting"
+	invalidShardTag             = "invalid_shard"
+	duplicatedShardNamespaceTag = "duplicated_shard_namespace"
+	duplicatedActionStepTag     = "duplicated_action_step"
+	invalidRegexTag             = "invalid_regex"
 	appTokenPrefix              = "xapp-"
 	botTokenPrefix              = "xoxb-"
 )
@@ -53,6 +65,21 @@ var (
 type ValidateResult struct {
 	Criticals *multierror.Error
 	Warnings  *multierror.Error
+	// Fields holds details of all reported issues, both critical ones and warnings.
+	Fields []FieldIssue
+}
+
+// FieldIssue holds details of a single issue reported for a given field.
+type FieldIssue struct {
+	// Path is the YAML path of the field, e.g. `communications.default-group.socketSlack.channels.default.bindings`.
+	Path string
+	// Tag is the name of the failed validation, e.g. `required` or `invalid_binding`.
+	Tag   string
+	Field string
+	Param string
+	// Message is the translated description of the issue.
+	Message string
+	Warning bool
 }
 
 // pluginProvider defines behavior for providing Plugins
@@ -87,9 +114,19 @@ func ValidateStruct(in any) (ValidateResult, error) {
 	validate.RegisterStructValidation(discordValidator, Discord{})
 	validate.RegisterStructValidation(cloudSlackValidator, CloudSlack{})
 	validate.RegisterStructValidation(mattermostValidator, Mattermost{})
+	validate.RegisterStructValidation(warehouseStructValidator, Warehouse{})
+	validate.RegisterStructValidation(analyticsStructValidator, Analytics{})
 
 	validate.RegisterStructValidation(sourceStructValidator, Sources{})
 	validate.RegisterStructValidation(executorStructValidator, Executors{})
+	validate.RegisterStructValidation(processorStructValidator, Processors{})
+	validate.RegisterStructValidation(actionStructValidator, Action{})
+	validate.RegisterStructValidation(ticketPolicyStructValidator, TicketPolicy{})
+	validate.RegisterStructValidation(pluginResourceLimitsStructValidator, PluginResourceLimits{})
+	validate.RegisterStructValidation(eventPipelineStructValidator, EventPipeline{})
+	validate.RegisterStructValidation(shardingStructValidator, Settings{})
+	validate.RegisterStructValidation(commandTimeoutStructValidator, CommandTimeout{})
+	validate.RegisterStructValidation(incomingWebhookStructValidator, IncomingWebhook{})
 
 	err := validate.Struct(in)
 	if err == nil {
@@ -108,7 +145,17 @@ func ValidateStruct(in any) (ValidateResult, error) {
 	for _, e := range errs {
 		msg := fmt.Errorf("Key: '%s' %s", e.StructNamespace(), e.Translate(trans))
 
-		if _, found := warnsOnlyTags[e.Tag()]; found {
+		_, isWarning := warnsOnlyTags[e.Tag()]
+		result.Fields = append(result.Fields, FieldIssue{
+			Path:    yamlPathFor(reflect.TypeOf(in), e.StructNamespace()),
+			Tag:     e.Tag(),
+			Field:   e.Field(),
+			Param:   e.Param(),
+			Message: e.Translate(trans),
+			Warning: isWarning,
+		})
+
+		if isWarning {
 			result.Warnings = multierrx.Append(result.Warnings, msg)
 			continue
 		}
@@ -118,10 +165,85 @@ func ValidateStruct(in any) (ValidateResult, error) {
 	return result, nil
 }
 
+// yamlPathFor converts the struct namespace reported by validator, e.g. `Config.Communications[default].SocketSlack.Enabled`,
+// to the YAML path of a given field, e.g. `communications.default.socketSlack.enabled`.
+// Segments which don't refer to fields, such as names of undefined bindings, are skipped together with all following segments.
+func yamlPathFor(typ reflect.Type, structNamespace string) string {
+	// the first segment is the name of the validated type
+	_, rest, _ := strings.Cut(structNamespace, ".")
+
+	var out strings.Builder
+	for rest != "" {
+		for typ.Kind() == reflect.Pointer {
+			typ = typ.Elem()
+		}
+
+		if strings.HasPrefix(rest, "[") {
+			key, next, found := strings.Cut(rest[1:], "]")
+			if !found {
+				break
+			}
+			rest = strings.TrimPrefix(next, ".")
+
+			switch typ.Kind() {
+			case reflect.Map:
+				if out.Len() > 0 {
+					out.WriteString(".")
+				}
+				out.WriteString(key)
+			case reflect.Slice, reflect.Array:
+				fmt.Fprintf(&out, "[%s]", key)
+			default:
+				return out.String()
+			}
+			typ = typ.Elem()
+			continue
+		}
+
+		end := strings.IndexAny(rest, ".[")
+		if end == -1 {
+			end = len(rest)
+		}
+		name := rest[:end]
+		rest = strings.TrimPrefix(rest[end:], ".")
+
+		if typ.Kind() != reflect.Struct {
+			break
+		}
+		field, found := typ.FieldByName(name)
+		if !found {
+			break
+		}
+		typ = field.Type
+
+		yamlName, opts, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if yamlName == "" && strings.Contains(opts, "inline") {
+			continue
+		}
+		if yamlName == "" {
+			yamlName = strings.ToLower(name[:1]) + name[1:]
+		}
+		if out.Len() > 0 {
+			out.WriteString(".")
+		}
+		out.WriteString(yamlName)
+	}
+
+	return out.String()
+}
+
 func registerCustomTranslations(validate *validator.Validate, trans ut.Translator) error {
 	return registerTranslation(validate, trans, map[string]string{
-		"invalid_slack_token": "{0} {1}",
-		invalidChannelNameTag: "The channel name '{0}' seems to be invalid. See the documentation to learn more: {1}.",
+		"invalid_slack_token":       "{0} {1}",
+		invalidChannelNameTag:       "The channel name '{0}' seems to be invalid. See the documentation to learn more: {1}.",
+		conflictingTicketTrackerTag: "{0} cannot be enabled together with {1}",
+		invalidQuantityTag:          "{0} must be a valid quantity, e.g. '500m' or '256Mi'",
+		unsupportedOverflowTag:      "{0} overflow policy is not supported by the {1} stage",
+		conflictingSettingTag:       "{0} cannot be enabled together with {1}",
+		invalidShardTag:             "{0} must be lower than the number of shards ({1})",
+		duplicatedShardNamespaceTag: "{0} namespace '{1}' is assigned to multiple shards",
+		duplicatedActionStepTag:     "{0} '{1}' is used by multiple steps",
+		invalidRegexTag:             "{0} must be a valid regular expression: {1}",
 	})
 }
 
@@ -147,6 +269,8 @@ func registerBindingsValidator(validate *validator.Validate, trans ut.Translator
 		invalidPluginDefinitionTag:  "{0}{1}",
 		invalidPluginRBACTag:        "Binding is referencing plugins of same kind with different RBAC. '{0}' and '{1}' bindings must be identical when used together.",
 		invalidActionRBACTag:        "Plugin {0} has 'ChannelName' RBAC policy. This is not supported for actions. See https://docs.botkube.io/configuration/action#rbac",
+		invalidActionChatUserTag:    "Plugin {0} has 'ChatUser' RBAC policy. This is not supported for actions, as they are not executed by chat users.",
+		invalidSourceChatUserTag:    "Plugin {0} has 'ChatUser' RBAC policy. This is not supported for sources, as they are not triggered by chat users.",
 	})
 }
 
@@ -281,6 +405,15 @@ func executorStructValidator(sl validator.StructLevel) {
 	validatePlugins(sl, executor.Plugins)
 }
 
+func processorStructValidator(sl validator.StructLevel) {
+	processor, ok := sl.Current().Interface().(Processors)
+	if !ok {
+		return
+	}
+
+	validatePlugins(sl, processor.Plugins)
+}
+
 func botBindingsStructValidator(sl validator.StructLevel) {
 	bindings, ok := sl.Current().Interface().(BotBindings)
 	if !ok {
@@ -292,6 +425,7 @@ func botBindingsStructValidator(sl validator.StructLevel) {
 	}
 	validateSourceBindings(sl, conf.Sources, bindings.Sources)
 	validateExecutorBindings(sl, conf.Executors, bindings.Executors)
+	validateFilterBindings(sl, conf.Filters, bindings.Filters)
 }
 
 func actionBindingsStructValidator(sl validator.StructLevel) {
@@ -308,6 +442,167 @@ func actionBindingsStructValidator(sl validator.StructLevel) {
 	validateActionExecutors(sl, conf.Executors, bindings.Executors)
 }
 
+func actionStructValidator(sl validator.StructLevel) {
+	action, ok := sl.Current().Interface().(Action)
+	if !ok || !action.Enabled {
+		return
+	}
+
+	if action.Command == "" && len(action.Steps) == 0 {
+		sl.ReportError(action.Command, "Command", "Command", "required", "")
+	}
+
+	// step outputs are indexed by names, so steps with the same name would overwrite outputs of each other
+	names := map[string]struct{}{}
+	for idx, step := range action.Steps {
+		if step.Name == "" {
+			continue // reported by the step validation
+		}
+		if _, found := names[step.Name]; found {
+			sl.ReportError(step.Name, "Name", fmt.Sprintf("Steps[%d].Name", idx), duplicatedActionStepTag, step.Name)
+			continue
+		}
+		names[step.Name] = struct{}{}
+	}
+}
+
+func ticketPolicyStructValidator(sl validator.StructLevel) {
+	policy, ok := sl.Current().Interface().(TicketPolicy)
+	if !ok || !policy.Enabled {
+		return
+	}
+
+	switch {
+	case !policy.GitHub.Enabled && !policy.Jira.Enabled:
+		sl.ReportError(policy.GitHub, "GitHub", "GitHub", "required", "")
+	case policy.GitHub.Enabled && policy.Jira.Enabled:
+		sl.ReportError(policy.Jira, "Jira", "Jira", conflictingTicketTrackerTag, "GitHub")
+	}
+
+	if policy.Threshold > 1 && policy.Window <= 0 {
+		sl.ReportError(policy.Window, "Window", "Window", "required", "")
+	}
+}
+
+func warehouseStructValidator(sl validator.StructLevel) {
+	warehouse, ok := sl.Current().Interface().(Warehouse)
+	if !ok || !warehouse.Enabled {
+		return
+	}
+
+	if warehouse.Backend == ClickHouseWarehouseBackend {
+		if warehouse.ClickHouse.URL == "" {
+			sl.ReportError(warehouse.ClickHouse.URL, "ClickHouse.URL", "URL", "required", "")
+		}
+		return
+	}
+
+	if warehouse.BigQuery.Project == "" {
+		sl.ReportError(warehouse.BigQuery.Project, "BigQuery.Project", "Project", "required", "")
+	}
+	if warehouse.BigQuery.Dataset == "" {
+		sl.ReportError(warehouse.BigQuery.Dataset, "BigQuery.Dataset", "Dataset", "required", "")
+	}
+}
+
+func analyticsStructValidator(sl validator.StructLevel) {
+	analytics, ok := sl.Current().Interface().(Analytics)
+	if !ok || analytics.Disable || analytics.Backend != CollectorAnalyticsBackend {
+		return
+	}
+
+	if analytics.Collector.URL == "" {
+		sl.ReportError(analytics.Collector.URL, "URL", "Collector.URL", "required", "")
+	}
+}
+
+func pluginResourceLimitsStructValidator(sl validator.StructLevel) {
+	limits, ok := sl.Current().Interface().(PluginResourceLimits)
+	if !ok {
+		return
+	}
+
+	for _, field := range []struct {
+		name  string
+		value string
+	}{
+		{name: "CPU", value: limits.CPU},
+		{name: "Memory", value: limits.Memory},
+	} {
+		if field.value == "" {
+			continue
+		}
+		if _, err := resource.ParseQuantity(field.value); err != nil {
+			sl.ReportError(field.value, field.name, field.name, invalidQuantityTag, "")
+		}
+	}
+}
+
+func eventPipelineStructValidator(sl validator.StructLevel) {
+	pipeline, ok := sl.Current().Interface().(EventPipeline)
+	if !ok {
+		return
+	}
+
+	// messages hold references to notifiers, so they cannot be written to disk
+	if pipeline.Send.Overflow == SpillToDiskPipelineOverflowPolicy {
+		sl.ReportError(pipeline.Send.Overflow, "Send.Overflow", "Overflow", unsupportedOverflowTag, "send")
+	}
+}
+
+func commandTimeoutStructValidator(sl validator.StructLevel) {
+	timeout, ok := sl.Current().Interface().(CommandTimeout)
+	if !ok || timeout.Command == "" {
+		return
+	}
+
+	if _, err := regexp.Compile(timeout.Command); err != nil {
+		sl.ReportError(timeout.Command, "Command", "Command", invalidRegexTag, err.Error())
+	}
+}
+
+func incomingWebhookStructValidator(sl validator.StructLevel) {
+	webhook, ok := sl.Current().Interface().(IncomingWebhook)
+	if !ok || !webhook.Enabled {
+		return
+	}
+
+	// the server must never be exposed without authentication by accident, so unauthenticated requests have to be accepted explicitly with `none`
+	if webhook.Auth.Type == "" {
+		sl.ReportError(webhook.Auth.Type, "Type", "Auth.Type", "required", "")
+	}
+}
+
+func shardingStructValidator(sl validator.StructLevel) {
+	settings, ok := sl.Current().Interface().(Settings)
+	if !ok || !settings.Sharding.Enabled {
+		return
+	}
+	sharding := settings.Sharding
+
+	// the leader would handle only its shard, so namespaces of other shards wouldn't be watched at all
+	if settings.LeaderElection.Enabled {
+		sl.ReportError(sharding.Enabled, "Enabled", "Sharding.Enabled", conflictingSettingTag, "LeaderElection")
+	}
+	if sharding.Shards < 1 {
+		sl.ReportError(sharding.Shards, "Shards", "Sharding.Shards", "min", "1")
+	}
+
+	assigned := map[string]struct{}{}
+	for idx, assignment := range sharding.Assignments {
+		if assignment.Shard < 0 || assignment.Shard >= sharding.Shards {
+			sl.ReportError(assignment.Shard, "Shard", fmt.Sprintf("Sharding.Assignments[%d].Shard", idx), invalidShardTag, strconv.Itoa(sharding.Shards))
+		}
+		for _, ns := range assignment.Namespaces {
+			if _, found := assigned[ns]; found {
+				sl.ReportError(assignment.Namespaces, "Namespaces", fmt.Sprintf("Sharding.Assignments[%d].Namespaces", idx), duplicatedShardNamespaceTag, ns)
+				continue
+			}
+			assigned[ns] = struct{}{}
+		}
+	}
+}
+
 func aliasesStructValidator(sl validator.StructLevel) {
 	alias, ok := sl.Current().Interface().(Alias)
 	if !ok {
@@ -370,6 +665,9 @@ func validateSourceBindings(sl validator.StructLevel, sources map[string]Sources
 			if !plugin.Enabled {
 				continue
 			}
+			if plugin.Context.RBAC != nil && plugin.Context.RBAC.HasChatUserSubject() {
+				sl.ReportError(bindings, pluginKey, source, invalidSourceChatUserTag, "")
+			}
 
 			enabledPluginsViaBindings = append(enabledPluginsViaBindings, pluginKey)
 		}
@@ -379,6 +677,14 @@ func validateSourceBindings(sl validator.StructLevel, sources map[string]Sources
 	validatePluginRBAC(sl, sources, bindings)
 }
 
+func validateFilterBindings(sl validator.StructLevel, filters Filters, bindings []string) {
+	for _, name := range bindings {
+		if _, ok := filters[name]; !ok {
+			sl.ReportError(bindings, name, name, invalidBindingTag, "Config.Filters")
+		}
+	}
+}
+
 func validatePluginRBAC[P pluginProvider](sl validator.StructLevel, pluginConfigs map[string]P, bindings []string) {
 	// 1. identify duplicates
 	groups := make(map[string][]string)
@@ -431,9 +737,12 @@ func validateActionExecutors(sl validator.StructLevel, executors map[string]Exec
 			if plugin.Context.RBAC == nil {
 				continue
 			}
-			if plugin.Context.RBAC.Group.Type == ChannelNamePolicySubjectType {
+			if plugin.Context.RBAC.Group.Type == ChannelNamePolicySubjectType || plugin.Context.RBAC.ServiceAccount.Type == ChannelNamePolicySubjectType {
 				sl.ReportError(bindings, pluginKey, executor, invalidActionRBACTag, "")
 			}
+			if plugin.Context.RBAC.HasChatUserSubject() {
+				sl.ReportError(bindings, pluginKey, executor, invalidActionChatUserTag, "")
+			}
 		}
 	}
 }
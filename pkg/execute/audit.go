@@ -0,0 +1,181 @@
+package execute
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/pflag"
+
+	"github.com/kubeshop/botkube/internal/auditlog"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/execute/command"
+)
+
+const (
+	auditNotEnabled           = "Audit log is not enabled."
+	auditQueryNotSupported    = "None of the enabled audit log backends supports querying. Enable the `file` or `elasticsearch` backend to query entries."
+	auditNoEntries            = "There are no audit entries matching the query."
+	auditInvalidType          = "Invalid entry type %q. Use one of: %s."
+	auditInvalidLimit         = "Invalid limit %d. Use a positive number."
+	auditDefaultSince         = 24 * time.Hour
+	auditDefaultLimit         = 20
+	auditMaxCommandLength     = 60
+	auditListFeature          = "list"
+	auditEntryTimeFormat      = "2006-01-02 15:04:05"
+	auditEmptyColumnValue     = "-"
+	auditTruncatedValueSuffix = "..."
+)
+
+var auditFeatureName = FeatureName{
+	Name:    auditListFeature,
+	Aliases: []string{noFeature},
+}
+
+// AuditLogger records audit entries, and queries them.
+type AuditLogger interface {
+	Record(e auditlog.Entry)
+	Query(ctx context.Context, q auditlog.Query) ([]auditlog.Entry, error)
+	Enabled() bool
+}
+
+// AuditExecutor executes all commands that are related to the audit log.
+type AuditExecutor struct {
+	log    logrus.FieldLogger
+	logger AuditLogger
+}
+
+// NewAuditExecutor returns a new AuditExecutor instance.
+func NewAuditExecutor(log logrus.FieldLogger, logger AuditLogger) *AuditExecutor {
+	return &AuditExecutor{
+		log:    log,
+		logger: logger,
+	}
+}
+
+// FeatureName returns the name and aliases of the feature provided by this executor
+func (e *AuditExecutor) FeatureName() FeatureName {
+	return auditFeatureName
+}
+
+// Commands returns slice of commands the executor supports
+func (e *AuditExecutor) Commands() map[command.Verb]CommandFn {
+	return map[command.Verb]CommandFn{
+		command.AuditVerb: e.Audit,
+	}
+}
+
+// Audit prints audit entries matching the query given in flags, starting from the newest one.
+func (e *AuditExecutor) Audit(ctx context.Context, cmdCtx CommandContext) (interactive.CoreMessage, error) {
+	if e.logger == nil || !e.logger.Enabled() {
+		return respond(auditNotEnabled, cmdCtx), nil
+	}
+
+	// flags are allowed only after the `list` feature, e.g. `audit list --type command`
+	var args []string
+	if len(cmdCtx.Args) > 2 {
+		args = cmdCtx.Args[2:]
+	}
+
+	var (
+		entryType, user, source string
+		since                   time.Duration
+		limit                   int
+	)
+	flags := pflag.NewFlagSet("audit", pflag.ContinueOnError)
+	flags.StringVar(&entryType, "type", "", "Entry type")
+	flags.StringVar(&user, "user", "", "User display name")
+	flags.StringVar(&source, "source", "", "Source name")
+	flags.DurationVar(&since, "since", auditDefaultSince, "Period of time to show entries for")
+	flags.IntVar(&limit, "limit", auditDefaultLimit, "Maximum number of entries")
+	if err := flags.Parse(args); err != nil {
+		return respond(fmt.Sprintf("Cannot parse command: %s", err.Error()), cmdCtx), nil
+	}
+
+	if entryType != "" && !isValidAuditEntryType(entryType) {
+		return respond(fmt.Sprintf(auditInvalidType, entryType, auditEntryTypesList()), cmdCtx), nil
+	}
+	if limit <= 0 {
+		return respond(fmt.Sprintf(auditInvalidLimit, limit), cmdCtx), nil
+	}
+
+	entries, err := e.logger.Query(ctx, auditlog.Query{
+		Type:   auditlog.EntryType(entryType),
+		User:   user,
+		Source: source,
+		Since:  time.Now().Add(-since),
+		Limit:  limit,
+	})
+	switch {
+	case err == nil:
+	case errors.Is(err, auditlog.ErrQueryNotSupported):
+		return respond(auditQueryNotSupported, cmdCtx), nil
+	default:
+		return interactive.CoreMessage{}, fmt.Errorf("while querying audit log: %w", err)
+	}
+
+	if len(entries) == 0 {
+		return respond(auditNoEntries, cmdCtx), nil
+	}
+	return respond(auditTabularOutput(entries), cmdCtx), nil
+}
+
+func auditTabularOutput(entries []auditlog.Entry) string {
+	buf := new(bytes.Buffer)
+	w := tabwriter.NewWriter(buf, 5, 0, 1, ' ', 0)
+	fmt.Fprintf(w, "TIME\tTYPE\tOUTCOME\tUSER\tSOURCE\tDETAILS")
+	for _, e := range entries {
+		details := e.Command
+		if details == "" {
+			details = e.Reason
+		}
+		fmt.Fprintf(w, "\n%s\t%s\t%s\t%s\t%s\t%s",
+			e.Time.UTC().Format(auditEntryTimeFormat),
+			e.Type,
+			e.Outcome,
+			auditColumnValue(e.User),
+			auditColumnValue(e.Source),
+			auditColumnValue(truncateAuditValue(details)),
+		)
+	}
+
+	w.Flush()
+	return buf.String()
+}
+
+func auditColumnValue(in string) string {
+	if in == "" {
+		return auditEmptyColumnValue
+	}
+	return in
+}
+
+func truncateAuditValue(in string) string {
+	in = newLinePattern.ReplaceAllString(in, " ")
+	if len(in) <= auditMaxCommandLength {
+		return in
+	}
+	return in[:auditMaxCommandLength-len(auditTruncatedValueSuffix)] + auditTruncatedValueSuffix
+}
+
+func isValidAuditEntryType(in string) bool {
+	for _, t := range auditlog.AllEntryTypes() {
+		if string(t) == in {
+			return true
+		}
+	}
+	return false
+}
+
+func auditEntryTypesList() string {
+	var out []string
+	for _, t := range auditlog.AllEntryTypes() {
+		out = append(out, fmt.Sprintf("`%s`", t))
+	}
+	return strings.Join(out, ", ")
+}
@@ -0,0 +1,131 @@
+package execute
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/internal/auditlog"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+func TestAuditExecutor(t *testing.T) {
+	// given
+	logger := &fakeAuditLogger{
+		enabled: true,
+		entries: []auditlog.Entry{
+			{
+				Time:    time.Date(2024, 1, 31, 10, 0, 0, 0, time.UTC),
+				Type:    auditlog.CommandEntryType,
+				Outcome: auditlog.DeniedOutcome,
+				User:    "Joe",
+				Command: "kubectl delete pod nginx",
+			},
+			{
+				Time:    time.Date(2024, 1, 31, 9, 0, 0, 0, time.UTC),
+				Type:    auditlog.NotificationEntryType,
+				Outcome: auditlog.SuppressedOutcome,
+				Source:  "k8s-err-events",
+				Reason:  "incident acknowledged",
+			},
+		},
+	}
+	executor := NewAuditExecutor(loggerx.NewNoop(), logger)
+	cmdCtx := CommandContext{
+		Args:           []string{"audit", "list", "--type", "command", "--user", "Joe", "--since", "1h", "--limit", "5"},
+		ExecutorFilter: newExecutorTextFilter(""),
+	}
+
+	// when
+	msg, err := executor.Audit(context.Background(), cmdCtx)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, heredoc.Doc(`
+		TIME                TYPE         OUTCOME    USER SOURCE         DETAILS
+		2024-01-31 10:00:00 command      denied     Joe  -              kubectl delete pod nginx
+		2024-01-31 09:00:00 notification suppressed -    k8s-err-events incident acknowledged`), msg.BaseBody.CodeBlock)
+	assert.Equal(t, auditlog.CommandEntryType, logger.query.Type)
+	assert.Equal(t, "Joe", logger.query.User)
+	assert.Equal(t, 5, logger.query.Limit)
+	assert.WithinDuration(t, time.Now().Add(-time.Hour), logger.query.Since, time.Minute)
+}
+
+func TestAuditExecutorInvalidInput(t *testing.T) {
+	tests := []struct {
+		name        string
+		logger      AuditLogger
+		args        []string
+		expectedMsg string
+	}{
+		{
+			name:        "disabled",
+			logger:      &fakeAuditLogger{},
+			args:        []string{"audit"},
+			expectedMsg: "Audit log is not enabled.",
+		},
+		{
+			name:        "query not supported",
+			logger:      &fakeAuditLogger{enabled: true, err: auditlog.ErrQueryNotSupported},
+			args:        []string{"audit"},
+			expectedMsg: "None of the enabled audit log backends supports querying. Enable the `file` or `elasticsearch` backend to query entries.",
+		},
+		{
+			name:        "unknown type",
+			logger:      &fakeAuditLogger{enabled: true},
+			args:        []string{"audit", "list", "--type", "event"},
+			expectedMsg: "Invalid entry type \"event\". Use one of: `command`, `interaction`, `configChange`, `notification`.",
+		},
+		{
+			name:        "invalid limit",
+			logger:      &fakeAuditLogger{enabled: true},
+			args:        []string{"audit", "list", "--limit", "0"},
+			expectedMsg: "Invalid limit 0. Use a positive number.",
+		},
+		{
+			name:        "no entries",
+			logger:      &fakeAuditLogger{enabled: true},
+			args:        []string{"audit", "list"},
+			expectedMsg: "There are no audit entries matching the query.",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			executor := NewAuditExecutor(loggerx.NewNoop(), tc.logger)
+			cmdCtx := CommandContext{
+				Args:           tc.args,
+				ExecutorFilter: newExecutorTextFilter(""),
+			}
+
+			// when
+			msg, err := executor.Audit(context.Background(), cmdCtx)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedMsg, msg.BaseBody.CodeBlock)
+		})
+	}
+}
+
+type fakeAuditLogger struct {
+	enabled bool
+	entries []auditlog.Entry
+	err     error
+	query   auditlog.Query
+}
+
+func (f *fakeAuditLogger) Record(auditlog.Entry) {}
+
+func (f *fakeAuditLogger) Query(_ context.Context, q auditlog.Query) ([]auditlog.Entry, error) {
+	f.query = q
+	return f.entries, f.err
+}
+
+func (f *fakeAuditLogger) Enabled() bool {
+	return f.enabled
+}
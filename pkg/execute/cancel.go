@@ -0,0 +1,78 @@
+package execute
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/execute/command"
+)
+
+const (
+	cancelExecIDMissing = "You need to specify the execution ID, e.g. `%s cancel command 5f2b8c0e9d41a7c3`. Use the Cancel button of a running command to get it."
+	cancelExecNotFound  = "Command execution %q is not running. It may have already finished."
+	cancelExecRequested = "Canceling `%s`..."
+)
+
+var cmdFeatureName = FeatureName{
+	Name:    "command",
+	Aliases: []string{"commands", "cmd", "cmds"},
+}
+
+// CancelExecutor executes all commands that are related to canceling running commands.
+type CancelExecutor struct {
+	log     logrus.FieldLogger
+	tracker *ExecutionTracker
+}
+
+// NewCancelExecutor returns a new CancelExecutor instance.
+func NewCancelExecutor(log logrus.FieldLogger, tracker *ExecutionTracker) *CancelExecutor {
+	return &CancelExecutor{
+		log:     log,
+		tracker: tracker,
+	}
+}
+
+// FeatureName returns the name and aliases of the feature provided by this executor
+func (e *CancelExecutor) FeatureName() FeatureName {
+	return cmdFeatureName
+}
+
+// Commands returns slice of commands the executor supports
+func (e *CancelExecutor) Commands() map[command.Verb]CommandFn {
+	return map[command.Verb]CommandFn{
+		command.CancelVerb: e.Cancel,
+	}
+}
+
+// Cancel cancels a running command execution.
+func (e *CancelExecutor) Cancel(_ context.Context, cmdCtx CommandContext) (interactive.CoreMessage, error) {
+	if len(cmdCtx.Args) < 3 {
+		return respond(fmt.Sprintf(cancelExecIDMissing, api.MessageBotNamePlaceholder), cmdCtx), nil
+	}
+
+	id := cmdCtx.Args[2]
+	// the execution can be canceled only in the conversation it was started in
+	cmd, err := e.tracker.Cancel(id, cmdCtx.Conversation.ID, cmdCtx.User.Mention)
+	switch {
+	case err == nil:
+	case errors.Is(err, ErrExecutionNotFound):
+		return respond(fmt.Sprintf(cancelExecNotFound, id), cmdCtx), nil
+	default:
+		return interactive.CoreMessage{}, fmt.Errorf("while canceling execution %q: %w", id, err)
+	}
+
+	e.log.WithField("id", id).Infof("Execution of %q canceled by %s", cmd, cmdCtx.User.DisplayName)
+	return interactive.CoreMessage{
+		Description: header(cmdCtx),
+		Message: api.Message{
+			BaseBody: api.Body{
+				Plaintext: fmt.Sprintf(cancelExecRequested, cmd),
+			},
+		},
+	}, nil
+}
@@ -14,6 +14,33 @@ const (
 	EditVerb     Verb = "edit"
 	StatusVerb   Verb = "status"
 	ShowVerb     Verb = "show"
+	RunVerb      Verb = "run"
+	ResumeVerb   Verb = "resume"
+	PauseVerb    Verb = "pause"
+	AbortVerb    Verb = "abort"
+	CancelVerb   Verb = "cancel"
+	AckVerb      Verb = "ack"
+	UpgradeVerb  Verb = "upgrade"
+	// MaintenanceVerb is followed by a subcommand, e.g. `maintenance start 2h`, so its features are handled by a single function.
+	MaintenanceVerb Verb = "maintenance"
+	// ConfigVerb is followed by a subcommand, e.g. `config effective`, so its features are handled by a single function.
+	ConfigVerb Verb = "config"
+	// PluginsVerb is followed by a subcommand, e.g. `plugins search helm`, so its features are handled by a single function.
+	PluginsVerb Verb = "plugins"
+	// AuditVerb is followed by the `list` feature and query flags, e.g. `audit list --type command`.
+	AuditVerb Verb = "audit"
+	// DoctorVerb runs connectivity and permission checks, and reports them with remediation hints.
+	DoctorVerb Verb = "doctor"
+	// OverviewVerb returns the cluster overview dashboard, which can be refreshed in place.
+	OverviewVerb Verb = "overview"
+	// WatchVerb watches a given resource and renders its live status in a chat message.
+	WatchVerb Verb = "watch"
+	// UpgradeCheckVerb reports whether the cluster is ready for an upgrade to a given Kubernetes version.
+	UpgradeCheckVerb Verb = "upgrade-check"
+	// ReplayVerb evaluates a synthetic event for a given source binding, and reports which channels and sinks would receive it.
+	ReplayVerb Verb = "replay"
+	// IncidentsVerb lists tracked incidents. It's a shorthand for `list incidents`.
+	IncidentsVerb Verb = "incidents"
 )
 
 func AllVerbs() []Verb {
@@ -28,5 +55,22 @@ func AllVerbs() []Verb {
 		EditVerb,
 		StatusVerb,
 		ShowVerb,
+		RunVerb,
+		ResumeVerb,
+		PauseVerb,
+		AbortVerb,
+		CancelVerb,
+		AckVerb,
+		UpgradeVerb,
+		MaintenanceVerb,
+		ConfigVerb,
+		PluginsVerb,
+		AuditVerb,
+		DoctorVerb,
+		OverviewVerb,
+		WatchVerb,
+		UpgradeCheckVerb,
+		ReplayVerb,
+		IncidentsVerb,
 	}
 }
@@ -0,0 +1,399 @@
+package execute
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/exp/slices"
+
+	"github.com/kubeshop/botkube/internal/filter"
+	"github.com/kubeshop/botkube/internal/notification"
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/execute/command"
+)
+
+const (
+	configEditFeature             = "edit"
+	configEditSelectsID           = "config-edit"
+	configEditUnknownSettingFmt   = "Unknown setting %q. Use one of: %s."
+	configEditMissingValueFmt     = "Missing value for the %q setting. Use `%s` to inherit it."
+	configEditUnknownChannel      = "This channel is not defined in the Botkube configuration, so its settings cannot be edited."
+	configEditUnsupportedPlatform = "Editing channel settings is not supported for this communication platform."
+	configEditedMsgFmt            = ":white_check_mark: %s changed %s to %s for this channel. Expect Botkube reload in a few seconds..."
+	configEditedWithoutReloadFmt  = ":white_check_mark: %s changed %s to %s for this channel.\nAs the Config Watcher is disabled, you need to restart Botkube manually to apply the changes."
+)
+
+// Editable channel settings.
+const (
+	profileSetting           = "profile"
+	minLevelSetting          = "minLevel"
+	filterSetting            = "filter"
+	aggregationWindowSetting = "aggregationWindow"
+	localeSetting            = "locale"
+	quietHoursSetting        = "quietHours"
+	sourcesSetting           = "sources"
+)
+
+var (
+	configEditFeatureName = FeatureName{
+		Name: configEditFeature,
+	}
+	editableSettings        = []string{profileSetting, minLevelSetting, filterSetting, aggregationWindowSetting, localeSetting, quietHoursSetting, sourcesSetting}
+	editableLevels          = []config.Level{config.Debug, config.Info, config.Warn, config.Error, config.Critical}
+	editableAggregationWins = []time.Duration{time.Minute, 5 * time.Minute, 15 * time.Minute, time.Hour}
+	editableQuietHours      = []string{"22:00-07:00", "20:00-08:00", "18:00-09:00"}
+)
+
+// ChannelSettingsStorage provides functionality to persist settings of a given channel.
+type ChannelSettingsStorage interface {
+	BindingsStorage
+	PersistNotificationSettings(ctx context.Context, commGroupName string, platform config.CommPlatformIntegration, channelAlias string, settings config.NotificationSettings) error
+}
+
+// ConfigEditExecutor edits notification settings and source bindings of the current channel.
+// Changes are validated before they are persisted, and are applied once Botkube reloads the configuration.
+type ConfigEditExecutor struct {
+	log        logrus.FieldLogger
+	cfg        config.Config
+	cfgManager ChannelSettingsStorage
+	cfgHistory ConfigHistoryStore
+}
+
+// NewConfigEditExecutor returns a new ConfigEditExecutor instance. The cfgHistory is nil if the configuration history is disabled.
+func NewConfigEditExecutor(log logrus.FieldLogger, cfgManager ChannelSettingsStorage, cfgHistory ConfigHistoryStore, cfg config.Config) *ConfigEditExecutor {
+	return &ConfigEditExecutor{
+		log:        log,
+		cfg:        cfg,
+		cfgManager: cfgManager,
+		cfgHistory: cfgHistory,
+	}
+}
+
+// FeatureName returns the name and aliases of the feature provided by this executor
+func (e *ConfigEditExecutor) FeatureName() FeatureName {
+	return configEditFeatureName
+}
+
+// Commands returns slice of commands the executor supports
+func (e *ConfigEditExecutor) Commands() map[command.Verb]CommandFn {
+	return map[command.Verb]CommandFn{
+		command.ConfigVerb: e.Config,
+	}
+}
+
+// Config returns the interactive form with channel settings, or changes a given setting, e.g. `config edit minLevel warn`.
+// Use `-` as the value to inherit a given notification setting from the source binding or global settings.
+func (e *ConfigEditExecutor) Config(ctx context.Context, cmdCtx CommandContext) (interactive.CoreMessage, error) {
+	if cmdCtx.Conversation.Alias == "" {
+		return plaintextMessage(configEditUnknownChannel), nil
+	}
+
+	current := channelNotificationSettings(e.cfg, cmdCtx.CommGroupName, cmdCtx.Platform, cmdCtx.Conversation.Alias)
+	if len(cmdCtx.Args) < 3 {
+		return e.form(current), nil
+	}
+
+	setting, ok := canonicalSetting(cmdCtx.Args[2])
+	if !ok {
+		return plaintextMessage(fmt.Sprintf(configEditUnknownSettingFmt, cmdCtx.Args[2], strings.Join(editableSettings, ", "))), nil
+	}
+	value := strings.TrimSpace(strings.Join(cmdCtx.Args[3:], " "))
+	if value == "" {
+		return plaintextMessage(fmt.Sprintf(configEditMissingValueFmt, setting, effectiveConfigNotSet)), nil
+	}
+
+	if setting == sourcesSetting {
+		return e.editSources(ctx, cmdCtx, value)
+	}
+
+	updated, err := applySetting(current, setting, value, e.cfg.NotificationProfileNames())
+	if err != nil {
+		return plaintextMessage(fmt.Sprintf(":exclamation: Invalid %s: %s", setting, err.Error())), nil
+	}
+
+	err = e.cfgManager.PersistNotificationSettings(ctx, cmdCtx.CommGroupName, cmdCtx.Platform, cmdCtx.Conversation.Alias, updated)
+	if msg, ok := persistErrMessage(err); ok {
+		return plaintextMessage(msg), nil
+	}
+	if err != nil {
+		return interactive.CoreMessage{}, fmt.Errorf("while persisting notification settings: %w", err)
+	}
+
+	return e.editedMessage(ctx, cmdCtx, fmt.Sprintf("`%s`", setting), fmt.Sprintf("`%s`", value)), nil
+}
+
+func (e *ConfigEditExecutor) editSources(ctx context.Context, cmdCtx CommandContext, value string) (interactive.CoreMessage, error) {
+	var sources []string
+	for _, name := range strings.Split(value, ",") {
+		name = strings.Trim(strings.TrimSpace(name), "`\"'")
+		if name == "" {
+			continue
+		}
+		if _, ok := e.cfg.Sources[name]; !ok {
+			return plaintextMessage(fmt.Sprintf(":exclamation: Invalid %s: source %q is not defined in the configuration.", sourcesSetting, name)), nil
+		}
+		sources = append(sources, name)
+	}
+	if len(sources) == 0 {
+		return plaintextMessage(fmt.Sprintf(":exclamation: Invalid %s: at least one source is required.", sourcesSetting)), nil
+	}
+
+	err := e.cfgManager.PersistSourceBindings(ctx, cmdCtx.CommGroupName, cmdCtx.Platform, cmdCtx.Conversation.Alias, sources)
+	if msg, ok := persistErrMessage(err); ok {
+		return plaintextMessage(msg), nil
+	}
+	if err != nil {
+		return interactive.CoreMessage{}, fmt.Errorf("while persisting source bindings configuration: %w", err)
+	}
+
+	return e.editedMessage(ctx, cmdCtx, "source bindings", fmt.Sprintf("`%s`", strings.Join(sources, ", "))), nil
+}
+
+func (e *ConfigEditExecutor) editedMessage(ctx context.Context, cmdCtx CommandContext, setting, value string) interactive.CoreMessage {
+	user := userMention(cmdCtx)
+	if e.cfgHistory != nil {
+		// the change is recorded in the configuration history once it's applied
+		if err := e.cfgHistory.SetTrigger(ctx, fmt.Sprintf("`%s` by %s", cmdCtx.CleanCmd, user)); err != nil {
+			e.log.WithError(err).Warn("Cannot describe the configuration change in the configuration history")
+		}
+	}
+
+	msgFmt := configEditedMsgFmt
+	if !e.cfg.ConfigWatcher.Enabled {
+		msgFmt = configEditedWithoutReloadFmt
+	}
+	return plaintextMessage(fmt.Sprintf(msgFmt, user, setting, value))
+}
+
+// form returns the interactive form with current notification settings of the channel.
+func (e *ConfigEditExecutor) form(current config.NotificationSettings) interactive.CoreMessage {
+	cmdPrefix := configEditCmdPrefix()
+	inherit := inheritOption()
+
+	windows := []api.OptionItem{inherit}
+	for _, window := range editableAggregationWins {
+		windows = append(windows, api.OptionItem{Name: window.String(), Value: window.String()})
+	}
+	profiles := []api.OptionItem{inherit}
+	for _, name := range e.cfg.NotificationProfileNames() {
+		profiles = append(profiles, api.OptionItem{Name: name, Value: name})
+	}
+	locales := []api.OptionItem{inherit}
+	for _, locale := range notification.SupportedLocales() {
+		locales = append(locales, api.OptionItem{Name: locale, Value: locale})
+	}
+
+	var window string
+	if current.AggregationWindow > 0 {
+		window = current.AggregationWindow.String()
+	}
+	filterDesc := "The notification filter is not set."
+	if current.Filter != "" {
+		filterDesc = fmt.Sprintf("Current notification filter: `%s`", current.Filter)
+	}
+
+	btnBuilder := api.NewMessageButtonBuilder()
+	return interactive.CoreMessage{
+		Header: "Edit channel settings",
+		Message: api.Message{
+			OnlyVisibleForYou: true,
+			Sections: []api.Section{
+				{
+					Base: api.Base{
+						Description: "Settings which are not defined for this channel are inherited from the selected profile, the source binding and global settings.",
+					},
+					Selects: api.Selects{
+						ID: configEditSelectsID,
+						Items: []api.Select{
+							settingSelect(profileSetting, profiles, current.Profile),
+							settingSelect(minLevelSetting, levelOptions(), string(current.MinLevel)),
+							settingSelect(aggregationWindowSetting, windows, window),
+							settingSelect(localeSetting, locales, current.Locale),
+							settingSelect(quietHoursSetting, quietHoursOptions(current.QuietHours), current.QuietHours),
+						},
+					},
+				},
+				{
+					Base: api.Base{
+						Description: filterDesc,
+					},
+					PlaintextInputs: api.LabelInputs{
+						{
+							Command:          fmt.Sprintf("%s %s ", cmdPrefix, filterSetting),
+							DispatchedAction: api.DispatchInputActionOnEnter,
+							Placeholder:      fmt.Sprintf("Type a CEL expression and press Enter, or type %s to inherit it", effectiveConfigNotSet),
+							Text:             filterSetting,
+						},
+					},
+					Buttons: api.Buttons{
+						btnBuilder.ForCommandWithoutDesc("Edit source bindings", "edit sourcebindings"),
+						btnBuilder.ForCommandWithoutDesc("Show effective settings", fmt.Sprintf("%s %s", command.ConfigVerb, effectiveConfigFeature)),
+					},
+				},
+			},
+		},
+	}
+}
+
+func configEditCmdPrefix() string {
+	return fmt.Sprintf("%s %s %s", api.MessageBotNamePlaceholder, command.ConfigVerb, configEditFeature)
+}
+
+// inheritOption is selected if a given setting is not defined for the channel.
+func inheritOption() api.OptionItem {
+	return api.OptionItem{Name: "Inherit", Value: effectiveConfigNotSet}
+}
+
+// settingSelect returns a select which changes a given channel setting with the `config edit` command.
+func settingSelect(setting string, options []api.OptionItem, current string) api.Select {
+	out := api.Select{
+		Type:         api.StaticSelect,
+		Name:         setting,
+		Command:      fmt.Sprintf("%s %s", configEditCmdPrefix(), setting),
+		OptionGroups: []api.OptionGroup{{Name: setting, Options: options}},
+	}
+	for idx := range options {
+		if options[idx].Value == current || (current == "" && options[idx] == inheritOption()) {
+			out.InitialOption = &options[idx]
+			break
+		}
+	}
+	return out
+}
+
+func levelOptions() []api.OptionItem {
+	out := []api.OptionItem{inheritOption()}
+	for _, level := range editableLevels {
+		out = append(out, api.OptionItem{Name: string(level), Value: string(level)})
+	}
+	return out
+}
+
+// quietHoursOptions returns predefined quiet hours, and the current ones if they are not predefined.
+func quietHoursOptions(current string) []api.OptionItem {
+	out := []api.OptionItem{inheritOption()}
+	for _, period := range editableQuietHours {
+		out = append(out, api.OptionItem{Name: period + " UTC", Value: period})
+	}
+	if current != "" && !slices.Contains(editableQuietHours, current) {
+		out = append(out, api.OptionItem{Name: current, Value: current})
+	}
+	return out
+}
+
+// canonicalSetting returns the name of a given editable setting, which is matched case-insensitively.
+func canonicalSetting(in string) (string, bool) {
+	for _, setting := range editableSettings {
+		if strings.EqualFold(setting, in) {
+			return setting, true
+		}
+	}
+	return "", false
+}
+
+// applySetting validates a given value and returns settings with the value applied. Profiles holds names of available notification profiles.
+func applySetting(in config.NotificationSettings, setting, value string, profiles []string) (config.NotificationSettings, error) {
+	inherit := value == effectiveConfigNotSet
+	switch setting {
+	case profileSetting:
+		if inherit {
+			in.Profile = ""
+			return in, nil
+		}
+		for _, name := range profiles {
+			if strings.EqualFold(name, value) {
+				in.Profile = name
+				return in, nil
+			}
+		}
+		return in, fmt.Errorf("use one of: %s", strings.Join(profiles, ", "))
+	case minLevelSetting:
+		if inherit {
+			in.MinLevel = ""
+			return in, nil
+		}
+		for _, level := range editableLevels {
+			if strings.EqualFold(string(level), value) {
+				in.MinLevel = level
+				return in, nil
+			}
+		}
+		return in, fmt.Errorf("use one of: %s", joinLevels(editableLevels))
+	case filterSetting:
+		if inherit {
+			in.Filter = ""
+			return in, nil
+		}
+		value = strings.Trim(value, "`")
+		if _, err := filter.Compile(value); err != nil {
+			return in, err
+		}
+		in.Filter = value
+	case aggregationWindowSetting:
+		if inherit {
+			in.AggregationWindow = 0
+			return in, nil
+		}
+		window, err := time.ParseDuration(value)
+		if err != nil {
+			return in, err
+		}
+		if window <= 0 {
+			return in, errors.New("the duration must be positive")
+		}
+		in.AggregationWindow = window
+	case localeSetting:
+		if inherit {
+			in.Locale = ""
+			return in, nil
+		}
+		if !notification.IsLocaleSupported(value) {
+			return in, fmt.Errorf("use one of: %s", strings.Join(notification.SupportedLocales(), ", "))
+		}
+		in.Locale = value
+	case quietHoursSetting:
+		if inherit {
+			in.QuietHours = ""
+			return in, nil
+		}
+		if _, err := notification.ParseQuietHours(value); err != nil {
+			return in, err
+		}
+		in.QuietHours = value
+	}
+	return in, nil
+}
+
+func persistErrMessage(err error) (string, bool) {
+	switch {
+	case errors.Is(err, config.ErrUnsupportedPlatform):
+		return configEditUnsupportedPlatform, true
+	case errors.Is(err, config.ErrUnsupportedRemotePersistence):
+		return fmt.Sprintf(":exclamation: %s.", err.Error()), true
+	}
+	return "", false
+}
+
+func plaintextMessage(msg string) interactive.CoreMessage {
+	return interactive.CoreMessage{
+		Message: api.Message{
+			BaseBody: api.Body{
+				Plaintext: msg,
+			},
+		},
+	}
+}
+
+func joinLevels(levels []config.Level) string {
+	out := make([]string, 0, len(levels))
+	for _, level := range levels {
+		out = append(out, string(level))
+	}
+	return strings.Join(out, ", ")
+}
@@ -0,0 +1,263 @@
+package execute
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+type fakeChannelSettingsStorage struct {
+	err      error
+	settings *config.NotificationSettings
+	sources  []string
+}
+
+func (f *fakeChannelSettingsStorage) PersistSourceBindings(_ context.Context, _ string, _ config.CommPlatformIntegration, _ string, sourceBindings []string) error {
+	f.sources = sourceBindings
+	return f.err
+}
+
+func (f *fakeChannelSettingsStorage) PersistNotificationSettings(_ context.Context, _ string, _ config.CommPlatformIntegration, _ string, settings config.NotificationSettings) error {
+	f.settings = &settings
+	return f.err
+}
+
+func TestConfigEditExecutor(t *testing.T) {
+	// given
+	cfg := config.Config{
+		ConfigWatcher: config.CfgWatcher{Enabled: true},
+		Sources: map[string]config.Sources{
+			"k8s-events": {},
+			"prometheus": {},
+		},
+		Communications: map[string]config.Communications{
+			"default-group": {
+				SocketSlack: config.SocketSlack{
+					Channels: config.IdentifiableMap[config.ChannelBindingsByName]{
+						"alerts": {
+							Name: "alerts",
+							Notification: config.ChannelNotification{
+								NotificationSettings: config.NotificationSettings{
+									Filter:            `event.Namespace == "prod"`,
+									AggregationWindow: 5 * time.Minute,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name             string
+		args             string
+		alias            string
+		storageErr       error
+		expectedMsg      string
+		expectedSettings *config.NotificationSettings
+		expectedSources  []string
+	}{
+		{
+			name:        "change min level",
+			args:        "config edit minlevel warn",
+			alias:       "alerts",
+			expectedMsg: ":white_check_mark: @Joe changed `minLevel` to `warn` for this channel. Expect Botkube reload in a few seconds...",
+			expectedSettings: &config.NotificationSettings{
+				MinLevel:          config.Warn,
+				Filter:            `event.Namespace == "prod"`,
+				AggregationWindow: 5 * time.Minute,
+			},
+		},
+		{
+			name:        "select profile",
+			args:        "config edit profile Quiet-Prod",
+			alias:       "alerts",
+			expectedMsg: ":white_check_mark: @Joe changed `profile` to `Quiet-Prod` for this channel. Expect Botkube reload in a few seconds...",
+			expectedSettings: &config.NotificationSettings{
+				Profile:           config.QuietProdProfile,
+				Filter:            `event.Namespace == "prod"`,
+				AggregationWindow: 5 * time.Minute,
+			},
+		},
+		{
+			name:        "inherit aggregation window",
+			args:        "config edit aggregationWindow -",
+			alias:       "alerts",
+			expectedMsg: ":white_check_mark: @Joe changed `aggregationWindow` to `-` for this channel. Expect Botkube reload in a few seconds...",
+			expectedSettings: &config.NotificationSettings{
+				Filter: `event.Namespace == "prod"`,
+			},
+		},
+		{
+			name:        "change filter",
+			args:        `config edit filter event.Level == "error"`,
+			alias:       "alerts",
+			expectedMsg: ":white_check_mark: @Joe changed `filter` to `event.Level == \"error\"` for this channel. Expect Botkube reload in a few seconds...",
+			expectedSettings: &config.NotificationSettings{
+				Filter:            `event.Level == "error"`,
+				AggregationWindow: 5 * time.Minute,
+			},
+		},
+		{
+			name:        "change quiet hours",
+			args:        "config edit quietHours 22:00-07:00 Europe/Berlin",
+			alias:       "alerts",
+			expectedMsg: ":white_check_mark: @Joe changed `quietHours` to `22:00-07:00 Europe/Berlin` for this channel. Expect Botkube reload in a few seconds...",
+			expectedSettings: &config.NotificationSettings{
+				Filter:            `event.Namespace == "prod"`,
+				AggregationWindow: 5 * time.Minute,
+				QuietHours:        "22:00-07:00 Europe/Berlin",
+			},
+		},
+		{
+			name:        "invalid quiet hours",
+			args:        "config edit quietHours 22:00",
+			alias:       "alerts",
+			expectedMsg: `:exclamation: Invalid quietHours: quiet hours "22:00" must be in the HH:MM-HH:MM [time zone] format`,
+		},
+		{
+			name:            "change source bindings",
+			args:            "config edit sources k8s-events, prometheus",
+			alias:           "alerts",
+			expectedMsg:     ":white_check_mark: @Joe changed source bindings to `k8s-events, prometheus` for this channel. Expect Botkube reload in a few seconds...",
+			expectedSources: []string{"k8s-events", "prometheus"},
+		},
+		{
+			name:        "invalid filter",
+			args:        "config edit filter event.Level ==",
+			alias:       "alerts",
+			expectedMsg: ":exclamation: Invalid filter:",
+		},
+		{
+			name:        "invalid aggregation window",
+			args:        "config edit aggregationWindow -5m",
+			alias:       "alerts",
+			expectedMsg: ":exclamation: Invalid aggregationWindow: the duration must be positive",
+		},
+		{
+			name:        "unknown profile",
+			args:        "config edit profile loud-prod",
+			alias:       "alerts",
+			expectedMsg: ":exclamation: Invalid profile: use one of: quiet-prod, security-focused, verbose-dev",
+		},
+		{
+			name:        "invalid level",
+			args:        "config edit minLevel fatal",
+			alias:       "alerts",
+			expectedMsg: ":exclamation: Invalid minLevel: use one of: debug, info, warn, error, critical",
+		},
+		{
+			name:        "unknown source",
+			args:        "config edit sources k8s-events,other",
+			alias:       "alerts",
+			expectedMsg: `:exclamation: Invalid sources: source "other" is not defined in the configuration.`,
+		},
+		{
+			name:        "unknown setting",
+			args:        "config edit color red",
+			alias:       "alerts",
+			expectedMsg: `Unknown setting "color". Use one of: profile, minLevel, filter, aggregationWindow, locale, quietHours, sources.`,
+		},
+		{
+			name:        "missing value",
+			args:        "config edit locale",
+			alias:       "alerts",
+			expectedMsg: "Missing value for the \"locale\" setting. Use `-` to inherit it.",
+		},
+		{
+			name:        "unknown channel",
+			args:        "config edit minLevel warn",
+			expectedMsg: configEditUnknownChannel,
+		},
+		{
+			name:        "remote configuration",
+			args:        "config edit minLevel warn",
+			alias:       "alerts",
+			storageErr:  config.ErrUnsupportedRemotePersistence,
+			expectedMsg: ":exclamation: this change cannot be persisted in the remote configuration, use Botkube Cloud to change it.",
+			expectedSettings: &config.NotificationSettings{
+				MinLevel:          config.Warn,
+				Filter:            `event.Namespace == "prod"`,
+				AggregationWindow: 5 * time.Minute,
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			storage := &fakeChannelSettingsStorage{err: tc.storageErr}
+			e := NewConfigEditExecutor(loggerx.NewNoop(), storage, nil, cfg)
+			cmdCtx := CommandContext{
+				Args:          strings.Fields(tc.args),
+				CommGroupName: "default-group",
+				Platform:      config.SocketSlackCommPlatformIntegration,
+				User:          UserInput{Mention: "@Joe"},
+				Conversation:  Conversation{Alias: tc.alias},
+			}
+
+			// when
+			msg, err := e.Config(context.Background(), cmdCtx)
+
+			// then
+			require.NoError(t, err)
+			assert.True(t, strings.HasPrefix(msg.BaseBody.Plaintext, tc.expectedMsg), msg.BaseBody.Plaintext)
+			assert.Equal(t, tc.expectedSettings, storage.settings)
+			assert.Equal(t, tc.expectedSources, storage.sources)
+		})
+	}
+}
+
+func TestConfigEditExecutorForm(t *testing.T) {
+	// given
+	cfg := config.Config{
+		Communications: map[string]config.Communications{
+			"default-group": {
+				SocketSlack: config.SocketSlack{
+					Channels: config.IdentifiableMap[config.ChannelBindingsByName]{
+						"alerts": {
+							Name: "alerts",
+							Notification: config.ChannelNotification{
+								NotificationSettings: config.NotificationSettings{
+									MinLevel: config.Error,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	e := NewConfigEditExecutor(loggerx.NewNoop(), &fakeChannelSettingsStorage{}, nil, cfg)
+	cmdCtx := CommandContext{
+		Args:          []string{"config", "edit"},
+		CommGroupName: "default-group",
+		Platform:      config.SocketSlackCommPlatformIntegration,
+		Conversation:  Conversation{Alias: "alerts"},
+	}
+
+	// when
+	msg, err := e.Config(context.Background(), cmdCtx)
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, msg.Sections, 2)
+	selects := msg.Sections[0].Selects.Items
+	require.Len(t, selects, 5)
+	assert.Equal(t, "{{BotName}} config edit profile", selects[0].Command)
+	assert.Equal(t, effectiveConfigNotSet, selects[0].InitialOption.Value)
+	assert.Len(t, selects[0].OptionGroups[0].Options, 4)
+	assert.Equal(t, "{{BotName}} config edit minLevel", selects[1].Command)
+	assert.Equal(t, "error", selects[1].InitialOption.Value)
+	assert.Equal(t, "{{BotName}} config edit aggregationWindow", selects[2].Command)
+	assert.Equal(t, effectiveConfigNotSet, selects[2].InitialOption.Value)
+	assert.Equal(t, "{{BotName}} config edit quietHours", selects[4].Command)
+	assert.Equal(t, effectiveConfigNotSet, selects[4].InitialOption.Value)
+	assert.Equal(t, "{{BotName}} config edit filter ", msg.Sections[1].PlaintextInputs[0].Command)
+}
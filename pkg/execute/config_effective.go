@@ -0,0 +1,136 @@
+package execute
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/internal/notification"
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/execute/command"
+)
+
+const (
+	effectiveConfigFeature         = "effective"
+	effectiveConfigNoSourceBinding = "There are no source bindings configured for this channel, so there are no notification settings to show."
+	effectiveConfigUnknownCmd      = "Unknown config command. Use `%s config effective` to show notification settings applied to this channel."
+	effectiveConfigNotSet          = "-"
+)
+
+var effectiveConfigFeatureName = FeatureName{
+	Name:    effectiveConfigFeature,
+	Aliases: []string{noFeature},
+}
+
+// EffectiveConfigExecutor shows notification settings merged from the global, source binding and channel levels.
+type EffectiveConfigExecutor struct {
+	log logrus.FieldLogger
+	cfg config.Config
+}
+
+// NewEffectiveConfigExecutor returns a new EffectiveConfigExecutor instance.
+func NewEffectiveConfigExecutor(log logrus.FieldLogger, cfg config.Config) *EffectiveConfigExecutor {
+	return &EffectiveConfigExecutor{
+		log: log,
+		cfg: cfg,
+	}
+}
+
+// FeatureName returns the name and aliases of the feature provided by this executor
+func (e *EffectiveConfigExecutor) FeatureName() FeatureName {
+	return effectiveConfigFeatureName
+}
+
+// Commands returns slice of commands the executor supports
+func (e *EffectiveConfigExecutor) Commands() map[command.Verb]CommandFn {
+	return map[command.Verb]CommandFn{
+		command.ConfigVerb: e.Config,
+	}
+}
+
+// Config shows effective notification settings for the current channel and each of its source bindings.
+func (e *EffectiveConfigExecutor) Config(_ context.Context, cmdCtx CommandContext) (interactive.CoreMessage, error) {
+	var feature string
+	if len(cmdCtx.Args) > 1 {
+		feature = strings.ToLower(cmdCtx.Args[1])
+	}
+	if feature != effectiveConfigFeature && feature != noFeature {
+		return respond(fmt.Sprintf(effectiveConfigUnknownCmd, api.MessageBotNamePlaceholder), cmdCtx), nil
+	}
+
+	sources := append([]string(nil), cmdCtx.Conversation.SourceBindings...)
+	if len(sources) == 0 {
+		return respond(effectiveConfigNoSourceBinding, cmdCtx), nil
+	}
+	sort.Strings(sources)
+
+	channel := channelNotificationSettings(e.cfg, cmdCtx.CommGroupName, cmdCtx.Platform, cmdCtx.Conversation.Alias)
+
+	buf := new(bytes.Buffer)
+	w := tabwriter.NewWriter(buf, 5, 0, 1, ' ', 0)
+	fmt.Fprintf(w, "SOURCE\tSETTING\tVALUE\tORIGIN")
+	for _, name := range sources {
+		eff := notification.Resolve(e.cfg, name, channel)
+
+		var window string
+		if eff.AggregationWindow > 0 {
+			window = eff.AggregationWindow.String()
+		}
+		rows := []struct {
+			setting string
+			value   string
+			origin  notification.Origin
+		}{
+			{setting: "profile", value: eff.Profile, origin: eff.Origins.Profile},
+			{setting: "minLevel", value: string(eff.MinLevel), origin: eff.Origins.MinLevel},
+			{setting: "filter", value: eff.Filter, origin: eff.Origins.Filter},
+			{setting: "aggregationWindow", value: window, origin: eff.Origins.AggregationWindow},
+			{setting: "locale", value: eff.Locale, origin: eff.Origins.Locale},
+			{setting: "quietHours", value: eff.QuietHours, origin: eff.Origins.QuietHours},
+			{setting: "quietHoursMode", value: string(eff.QuietHoursMode), origin: eff.Origins.QuietHoursMode},
+			{setting: "contentFilter", value: eff.ContentFilter, origin: eff.Origins.ContentFilter},
+		}
+		for _, row := range rows {
+			if row.value == "" {
+				row.value = effectiveConfigNotSet
+			}
+			fmt.Fprintf(w, "\n%s\t%s\t%s\t%s", name, row.setting, row.value, row.origin)
+		}
+	}
+	w.Flush()
+
+	return respond(buf.String(), cmdCtx), nil
+}
+
+// channelNotificationSettings returns notification settings defined for a channel with a given alias.
+func channelNotificationSettings(cfg config.Config, commGroupName string, platform config.CommPlatformIntegration, alias string) config.NotificationSettings {
+	comm, ok := cfg.Communications[commGroupName]
+	if !ok {
+		return config.NotificationSettings{}
+	}
+
+	switch platform {
+	case config.SocketSlackCommPlatformIntegration:
+		return comm.SocketSlack.Channels[alias].Notification.NotificationSettings
+	case config.CloudSlackCommPlatformIntegration:
+		return comm.CloudSlack.Channels[alias].Notification.NotificationSettings
+	case config.MattermostCommPlatformIntegration:
+		return comm.Mattermost.Channels[alias].Notification.NotificationSettings
+	case config.DiscordCommPlatformIntegration:
+		return comm.Discord.Channels[alias].Notification.NotificationSettings
+	case config.CloudTeamsCommPlatformIntegration:
+		for _, team := range comm.CloudTeams.Teams {
+			if ch, ok := team.Channels[alias]; ok {
+				return ch.Notification.NotificationSettings
+			}
+		}
+	}
+	return config.NotificationSettings{}
+}
@@ -0,0 +1,87 @@
+package execute
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+func TestEffectiveConfigExecutor(t *testing.T) {
+	// given
+	cfg := config.Config{
+		Settings: config.Settings{
+			Notification: config.NotificationSettings{
+				MinLevel: config.Info,
+				Locale:   "en_GB",
+			},
+		},
+		Sources: map[string]config.Sources{
+			"k8s-events": {
+				Notification: config.NotificationSettings{
+					MinLevel:          config.Warn,
+					AggregationWindow: 5 * time.Minute,
+				},
+			},
+			"prometheus": {},
+		},
+		Communications: map[string]config.Communications{
+			"default-group": {
+				SocketSlack: config.SocketSlack{
+					Channels: config.IdentifiableMap[config.ChannelBindingsByName]{
+						"alerts": {
+							Name: "alerts",
+							Notification: config.ChannelNotification{
+								NotificationSettings: config.NotificationSettings{
+									Profile: config.VerboseDevProfile,
+									Filter:  `event.Namespace == "prod"`,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	cmdCtx := CommandContext{
+		Args:           []string{"config", "effective"},
+		CommGroupName:  "default-group",
+		Platform:       config.SocketSlackCommPlatformIntegration,
+		ExecutorFilter: newExecutorTextFilter(""),
+		Conversation: Conversation{
+			Alias:          "alerts",
+			SourceBindings: []string{"prometheus", "k8s-events"},
+		},
+	}
+	e := NewEffectiveConfigExecutor(loggerx.NewNoop(), cfg)
+
+	// when
+	msg, err := e.Config(context.Background(), cmdCtx)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, heredoc.Doc(`
+		SOURCE     SETTING           VALUE                     ORIGIN
+		k8s-events profile           verbose-dev               channel
+		k8s-events minLevel          debug                     profile
+		k8s-events filter            event.Namespace == "prod" channel
+		k8s-events aggregationWindow 5m0s                      source
+		k8s-events locale            en_GB                     global
+		k8s-events quietHours        -                         default
+		k8s-events quietHoursMode    -                         default
+		k8s-events contentFilter     -                         default
+		prometheus profile           verbose-dev               channel
+		prometheus minLevel          debug                     profile
+		prometheus filter            event.Namespace == "prod" channel
+		prometheus aggregationWindow -                         default
+		prometheus locale            en_GB                     global
+		prometheus quietHours        -                         default
+		prometheus quietHoursMode    -                         default
+		prometheus contentFilter     -                         default`), msg.BaseBody.CodeBlock)
+}
@@ -0,0 +1,223 @@
+package execute
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/internal/config/history"
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/execute/command"
+)
+
+const (
+	configHistoryFeature          = "history"
+	configRollbackFeature         = "rollback"
+	configRollbackCancel          = "cancel"
+	configHistoryDisabled         = "Configuration history is disabled. Set `settings.configHistory.enabled` to `true` to keep applied configurations."
+	configHistoryEmpty            = "There are no configuration revisions recorded yet."
+	configHistoryPinnedFmt        = "The configuration is rolled back to revision %d, so changes of configuration sources are not applied. Use `%s config rollback cancel` to apply them again."
+	configRollbackInvalidFmt      = "Invalid revision %q. Use `%s config history` to list available revisions."
+	configRolledBackMsgFmt        = ":leftwards_arrow_with_hook: %s rolled back the configuration to revision %d. Expect Botkube reload in a few seconds..."
+	configRolledBackWithoutReload = ":leftwards_arrow_with_hook: %s rolled back the configuration to revision %d.\nAs the Config Watcher is disabled, you need to restart Botkube manually to apply the changes."
+	configRollbackCancelledFmt    = ":white_check_mark: %s cancelled the configuration rollback. Expect Botkube reload in a few seconds..."
+	configRollbackCancelledNoRel  = ":white_check_mark: %s cancelled the configuration rollback.\nAs the Config Watcher is disabled, you need to restart Botkube manually to apply the changes."
+	configRollbackNotPinned       = "The configuration is not rolled back."
+	// configHistoryMaxChanges limits the number of changes listed for a single revision in chat.
+	configHistoryMaxChanges = 10
+)
+
+var (
+	configHistoryFeatureName = FeatureName{
+		Name:    configHistoryFeature,
+		Aliases: []string{"revisions"},
+	}
+	configRollbackFeatureName = FeatureName{
+		Name: configRollbackFeature,
+	}
+)
+
+// ConfigHistoryStore provides functionality to list applied configurations and roll them back.
+type ConfigHistoryStore interface {
+	List(ctx context.Context) ([]history.Entry, error)
+	Pinned(ctx context.Context) (history.Entry, bool, error)
+	Rollback(ctx context.Context, revision int, by string) (history.Entry, error)
+	CancelRollback(ctx context.Context, by string) (bool, error)
+	SetTrigger(ctx context.Context, trigger string) error
+}
+
+// ConfigHistoryExecutor lists applied configuration revisions together with their changes and triggers.
+type ConfigHistoryExecutor struct {
+	log   logrus.FieldLogger
+	store ConfigHistoryStore
+}
+
+// NewConfigHistoryExecutor returns a new ConfigHistoryExecutor instance. The store is nil if the configuration history is disabled.
+func NewConfigHistoryExecutor(log logrus.FieldLogger, store ConfigHistoryStore) *ConfigHistoryExecutor {
+	return &ConfigHistoryExecutor{
+		log:   log,
+		store: store,
+	}
+}
+
+// FeatureName returns the name and aliases of the feature provided by this executor
+func (e *ConfigHistoryExecutor) FeatureName() FeatureName {
+	return configHistoryFeatureName
+}
+
+// Commands returns slice of commands the executor supports
+func (e *ConfigHistoryExecutor) Commands() map[command.Verb]CommandFn {
+	return map[command.Verb]CommandFn{
+		command.ConfigVerb: e.Config,
+	}
+}
+
+// Config lists applied configuration revisions, starting from the current one.
+func (e *ConfigHistoryExecutor) Config(ctx context.Context, cmdCtx CommandContext) (interactive.CoreMessage, error) {
+	if e.store == nil {
+		return plaintextMessage(configHistoryDisabled), nil
+	}
+
+	entries, err := e.store.List(ctx)
+	if err != nil {
+		return interactive.CoreMessage{}, fmt.Errorf("while listing configuration history: %w", err)
+	}
+	if len(entries) == 0 {
+		return plaintextMessage(configHistoryEmpty), nil
+	}
+	pinned, isPinned, err := e.store.Pinned(ctx)
+	if err != nil {
+		return interactive.CoreMessage{}, fmt.Errorf("while checking configuration rollback: %w", err)
+	}
+
+	var out strings.Builder
+	for idx, entry := range entries {
+		if idx > 0 {
+			out.WriteString("\n")
+		}
+		fmt.Fprintf(&out, "Revision %d", entry.Revision)
+		if idx == 0 {
+			out.WriteString(" (current)")
+		}
+		fmt.Fprintf(&out, ", applied at %s\n", entry.AppliedAt.UTC().Format(time.RFC3339))
+		fmt.Fprintf(&out, "Trigger: %s\n", entry.Trigger)
+		for i, change := range entry.Changes {
+			if i == configHistoryMaxChanges {
+				fmt.Fprintf(&out, "  …and %d more\n", len(entry.Changes)-configHistoryMaxChanges)
+				break
+			}
+			fmt.Fprintf(&out, "  %s\n", change)
+		}
+	}
+
+	msg := respond(out.String(), cmdCtx)
+	var section api.Section
+	if isPinned {
+		section.Base.Description = fmt.Sprintf(configHistoryPinnedFmt, pinned.Revision, api.MessageBotNamePlaceholder)
+	}
+	if len(entries) > 1 {
+		btnBuilder := api.NewMessageButtonBuilder()
+		section.Buttons = api.Buttons{
+			btnBuilder.ForCommandWithoutDesc(fmt.Sprintf("Roll back to revision %d", entries[1].Revision), fmt.Sprintf("%s %s %d", command.ConfigVerb, configRollbackFeature, entries[1].Revision)),
+		}
+	}
+	if section.Base.Description != "" || len(section.Buttons) > 0 {
+		msg.Sections = append(msg.Sections, section)
+	}
+	return msg, nil
+}
+
+// ConfigRollbackExecutor rolls back the configuration to a given revision, or cancels the rollback.
+type ConfigRollbackExecutor struct {
+	log   logrus.FieldLogger
+	cfg   config.Config
+	store ConfigHistoryStore
+}
+
+// NewConfigRollbackExecutor returns a new ConfigRollbackExecutor instance. The store is nil if the configuration history is disabled.
+func NewConfigRollbackExecutor(log logrus.FieldLogger, store ConfigHistoryStore, cfg config.Config) *ConfigRollbackExecutor {
+	return &ConfigRollbackExecutor{
+		log:   log,
+		cfg:   cfg,
+		store: store,
+	}
+}
+
+// FeatureName returns the name and aliases of the feature provided by this executor
+func (e *ConfigRollbackExecutor) FeatureName() FeatureName {
+	return configRollbackFeatureName
+}
+
+// Commands returns slice of commands the executor supports
+func (e *ConfigRollbackExecutor) Commands() map[command.Verb]CommandFn {
+	return map[command.Verb]CommandFn{
+		command.ConfigVerb: e.Config,
+	}
+}
+
+// Config rolls back the configuration, e.g. `config rollback` to the previous revision, or `config rollback 3` to a given one.
+// The revision is applied instead of the configuration sources until `config rollback cancel` is executed.
+func (e *ConfigRollbackExecutor) Config(ctx context.Context, cmdCtx CommandContext) (interactive.CoreMessage, error) {
+	if e.store == nil {
+		return plaintextMessage(configHistoryDisabled), nil
+	}
+	user := userMention(cmdCtx)
+
+	var revision int
+	if len(cmdCtx.Args) > 2 {
+		arg := strings.ToLower(cmdCtx.Args[2])
+		if arg == configRollbackCancel {
+			return e.cancel(ctx, user)
+		}
+
+		var err error
+		revision, err = strconv.Atoi(strings.TrimPrefix(arg, "#"))
+		if err != nil || revision <= 0 {
+			return plaintextMessage(fmt.Sprintf(configRollbackInvalidFmt, cmdCtx.Args[2], api.MessageBotNamePlaceholder)), nil
+		}
+	}
+
+	entry, err := e.store.Rollback(ctx, revision, user)
+	switch {
+	case errors.Is(err, history.ErrNoPreviousRevision), errors.Is(err, history.ErrRevisionNotFound), errors.Is(err, history.ErrRevisionApplied):
+		return plaintextMessage(fmt.Sprintf(":exclamation: Cannot roll back the configuration: %s.", err.Error())), nil
+	case err != nil:
+		return interactive.CoreMessage{}, fmt.Errorf("while rolling back configuration: %w", err)
+	}
+
+	msgFmt := configRolledBackMsgFmt
+	if !e.cfg.ConfigWatcher.Enabled {
+		msgFmt = configRolledBackWithoutReload
+	}
+	return plaintextMessage(fmt.Sprintf(msgFmt, user, entry.Revision)), nil
+}
+
+func (e *ConfigRollbackExecutor) cancel(ctx context.Context, user string) (interactive.CoreMessage, error) {
+	cancelled, err := e.store.CancelRollback(ctx, user)
+	if err != nil {
+		return interactive.CoreMessage{}, fmt.Errorf("while cancelling configuration rollback: %w", err)
+	}
+	if !cancelled {
+		return plaintextMessage(configRollbackNotPinned), nil
+	}
+
+	msgFmt := configRollbackCancelledFmt
+	if !e.cfg.ConfigWatcher.Enabled {
+		msgFmt = configRollbackCancelledNoRel
+	}
+	return plaintextMessage(fmt.Sprintf(msgFmt, user)), nil
+}
+
+func userMention(cmdCtx CommandContext) string {
+	if cmdCtx.User.Mention == "" {
+		return "Anonymous"
+	}
+	return cmdCtx.User.Mention
+}
@@ -0,0 +1,154 @@
+package execute
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubeshop/botkube/internal/config/history"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+func TestConfigRollbackExecutor(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        string
+		expectedMsg string
+	}{
+		{
+			name:        "previous revision",
+			args:        "config rollback",
+			expectedMsg: ":leftwards_arrow_with_hook: @Joe rolled back the configuration to revision 2. Expect Botkube reload in a few seconds...",
+		},
+		{
+			name:        "given revision",
+			args:        "config rollback 1",
+			expectedMsg: ":leftwards_arrow_with_hook: @Joe rolled back the configuration to revision 1. Expect Botkube reload in a few seconds...",
+		},
+		{
+			name:        "current revision",
+			args:        "config rollback 3",
+			expectedMsg: ":exclamation: Cannot roll back the configuration: revision is already applied: 3.",
+		},
+		{
+			name:        "unknown revision",
+			args:        "config rollback 42",
+			expectedMsg: ":exclamation: Cannot roll back the configuration: revision not found in the configuration history: 42.",
+		},
+		{
+			name:        "invalid revision",
+			args:        "config rollback latest",
+			expectedMsg: `Invalid revision "latest". Use ` + "`{{BotName}} config history`" + ` to list available revisions.`,
+		},
+		{
+			name:        "cancel without rollback",
+			args:        "config rollback cancel",
+			expectedMsg: configRollbackNotPinned,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			store := historyWithRevisions(t, "info", "warn", "debug")
+			e := NewConfigRollbackExecutor(loggerx.NewNoop(), store, config.Config{ConfigWatcher: config.CfgWatcher{Enabled: true}})
+			cmdCtx := CommandContext{
+				Args: strings.Fields(tc.args),
+				User: UserInput{Mention: "@Joe"},
+			}
+
+			// when
+			msg, err := e.Config(context.Background(), cmdCtx)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedMsg, msg.BaseBody.Plaintext)
+		})
+	}
+}
+
+func TestConfigRollbackExecutorCancel(t *testing.T) {
+	// given
+	ctx := context.Background()
+	store := historyWithRevisions(t, "info", "warn")
+	_, err := store.Rollback(ctx, 1, "@Alice")
+	require.NoError(t, err)
+
+	e := NewConfigRollbackExecutor(loggerx.NewNoop(), store, config.Config{})
+	cmdCtx := CommandContext{
+		Args: []string{"config", "rollback", "cancel"},
+		User: UserInput{Mention: "@Joe"},
+	}
+
+	// when
+	msg, err := e.Config(ctx, cmdCtx)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, ":white_check_mark: @Joe cancelled the configuration rollback.\nAs the Config Watcher is disabled, you need to restart Botkube manually to apply the changes.", msg.BaseBody.Plaintext)
+	_, pinned, err := store.Pinned(ctx)
+	require.NoError(t, err)
+	assert.False(t, pinned)
+}
+
+func TestConfigHistoryExecutor(t *testing.T) {
+	// given
+	ctx := context.Background()
+	store := historyWithRevisions(t, "info", "warn")
+	_, err := store.Rollback(ctx, 1, "@Alice")
+	require.NoError(t, err)
+
+	e := NewConfigHistoryExecutor(loggerx.NewNoop(), store)
+	cmdCtx := CommandContext{
+		Args:           []string{"config", "history"},
+		ExecutorFilter: newExecutorTextFilter(""),
+	}
+
+	// when
+	msg, err := e.Config(ctx, cmdCtx)
+
+	// then
+	require.NoError(t, err)
+	body := msg.BaseBody.CodeBlock
+	assert.Contains(t, body, "Revision 2 (current)")
+	assert.Contains(t, body, `  ~ settings.log.level: "info" → "warn"`)
+	assert.Contains(t, body, "Revision 1, applied at")
+	assert.Contains(t, body, "Trigger: Initial configuration")
+
+	require.Len(t, msg.Sections, 1)
+	assert.Contains(t, msg.Sections[0].Base.Description, "The configuration is rolled back to revision 1")
+	require.Len(t, msg.Sections[0].Buttons, 1)
+	assert.Equal(t, "{{BotName}} config rollback 1", msg.Sections[0].Buttons[0].Command)
+}
+
+func TestConfigHistoryExecutorDisabled(t *testing.T) {
+	// given
+	e := NewConfigHistoryExecutor(loggerx.NewNoop(), nil)
+
+	// when
+	msg, err := e.Config(context.Background(), CommandContext{Args: []string{"config", "history"}})
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, configHistoryDisabled, msg.BaseBody.Plaintext)
+}
+
+func historyWithRevisions(t *testing.T, logLevels ...string) *history.Store {
+	t.Helper()
+
+	store := history.NewStore(loggerx.NewNoop(), fake.NewSimpleClientset(), config.ConfigHistory{
+		Enabled: true,
+		Secret:  config.K8sResourceRef{Name: "botkube-config-history", Namespace: "botkube"},
+	})
+	for _, level := range logLevels {
+		_, _, err := store.Record(context.Background(), config.Layers{
+			{Name: "global_config.yaml", Data: []byte("communications: {default-group: {}}\nsettings:\n  log:\n    level: " + level)},
+		})
+		require.NoError(t, err)
+	}
+	return store
+}
@@ -0,0 +1,80 @@
+package execute
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/execute/command"
+)
+
+const configOriginNoMatch = "There are no configuration values under %q."
+
+var configOriginFeatureName = FeatureName{
+	Name:    "origin",
+	Aliases: []string{"origins"},
+}
+
+// ConfigOriginExecutor shows which configuration layer each effective configuration value comes from.
+type ConfigOriginExecutor struct {
+	log    logrus.FieldLogger
+	layers config.Layers
+}
+
+// NewConfigOriginExecutor returns a new ConfigOriginExecutor instance.
+func NewConfigOriginExecutor(log logrus.FieldLogger, layers config.Layers) *ConfigOriginExecutor {
+	return &ConfigOriginExecutor{
+		log:    log,
+		layers: layers,
+	}
+}
+
+// FeatureName returns the name and aliases of the feature provided by this executor
+func (e *ConfigOriginExecutor) FeatureName() FeatureName {
+	return configOriginFeatureName
+}
+
+// Commands returns slice of commands the executor supports
+func (e *ConfigOriginExecutor) Commands() map[command.Verb]CommandFn {
+	return map[command.Verb]CommandFn{
+		command.ConfigVerb: e.Config,
+	}
+}
+
+// Config lists paths of effective configuration values together with layers they come from, optionally limited to a given path prefix.
+// Values are not printed, as they may contain credentials.
+func (e *ConfigOriginExecutor) Config(_ context.Context, cmdCtx CommandContext) (interactive.CoreMessage, error) {
+	var prefix string
+	if len(cmdCtx.Args) > 2 {
+		prefix = cmdCtx.Args[2]
+	}
+
+	origins, err := config.Origins(e.layers)
+	if err != nil {
+		return interactive.CoreMessage{}, fmt.Errorf("while resolving configuration origins: %w", err)
+	}
+
+	buf := new(bytes.Buffer)
+	w := tabwriter.NewWriter(buf, 5, 0, 1, ' ', 0)
+	fmt.Fprintf(w, "PATH\tLAYER")
+	found := false
+	for _, origin := range origins {
+		if !strings.HasPrefix(origin.Path, prefix) {
+			continue
+		}
+		found = true
+		fmt.Fprintf(w, "\n%s\t%s", origin.Path, origin.Layer)
+	}
+	w.Flush()
+
+	if !found {
+		return respond(fmt.Sprintf(configOriginNoMatch, prefix), cmdCtx), nil
+	}
+	return respond(buf.String(), cmdCtx), nil
+}
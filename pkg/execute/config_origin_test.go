@@ -0,0 +1,69 @@
+package execute
+
+import (
+	"context"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+func TestConfigOriginExecutor(t *testing.T) {
+	// given
+	t.Setenv("BOTKUBE_SETTINGS_LOG_LEVEL", "debug")
+	layers := config.Layers{
+		{
+			Name: "/config/global_config.yaml",
+			Data: []byte(heredoc.Doc(`
+				settings:
+				  clusterName: dev
+				  log:
+				    level: info
+			`)),
+		},
+		{
+			Name: "configmap://botkube/overrides/settings.yaml",
+			Data: []byte(heredoc.Doc(`
+				settings:
+				  clusterName: prod
+			`)),
+		},
+	}
+	e := NewConfigOriginExecutor(loggerx.NewNoop(), layers)
+	cmdCtx := CommandContext{
+		Args:           []string{"config", "origin", "settings.clusterName"},
+		ExecutorFilter: newExecutorTextFilter(""),
+	}
+
+	// when
+	msg, err := e.Config(context.Background(), cmdCtx)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, heredoc.Doc(`
+		PATH                 LAYER
+		settings.clusterName configmap://botkube/overrides/settings.yaml`), msg.BaseBody.CodeBlock)
+
+	// when
+	cmdCtx.Args = []string{"config", "origin", "settings.log."}
+	msg, err = e.Config(context.Background(), cmdCtx)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, heredoc.Doc(`
+		PATH                       LAYER
+		settings.log.disableColors defaults
+		settings.log.level         environment variables`), msg.BaseBody.CodeBlock)
+
+	// when
+	cmdCtx.Args = []string{"config", "origin", "unknown"}
+	msg, err = e.Config(context.Background(), cmdCtx)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, `There are no configuration values under "unknown".`, msg.BaseBody.CodeBlock)
+}
@@ -0,0 +1,128 @@
+package execute
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/execute/command"
+)
+
+const (
+	configSetupFeature     = "setup"
+	configSetupSelectsID   = "config-setup"
+	configSetupDescription = "Botkube is connected to this channel. Pick the sources you want to be notified about, the minimal severity of sent events, and quiet hours during which only critical events are sent.\n" +
+		"Each change is saved in the Botkube configuration right away. Run `%s config setup` to open this wizard again."
+)
+
+var configSetupFeatureName = FeatureName{
+	Name:    configSetupFeature,
+	Aliases: []string{"wizard"},
+}
+
+// ConfigSetupExecutor returns the setup wizard, which is also posted to each channel once Botkube connects to it for the first time.
+type ConfigSetupExecutor struct {
+	log logrus.FieldLogger
+	cfg config.Config
+}
+
+// NewConfigSetupExecutor returns a new ConfigSetupExecutor instance.
+func NewConfigSetupExecutor(log logrus.FieldLogger, cfg config.Config) *ConfigSetupExecutor {
+	return &ConfigSetupExecutor{
+		log: log,
+		cfg: cfg,
+	}
+}
+
+// FeatureName returns the name and aliases of the feature provided by this executor
+func (e *ConfigSetupExecutor) FeatureName() FeatureName {
+	return configSetupFeatureName
+}
+
+// Commands returns slice of commands the executor supports
+func (e *ConfigSetupExecutor) Commands() map[command.Verb]CommandFn {
+	return map[command.Verb]CommandFn{
+		command.ConfigVerb: e.Config,
+	}
+}
+
+// Config returns the setup wizard for the current channel.
+func (e *ConfigSetupExecutor) Config(_ context.Context, cmdCtx CommandContext) (interactive.CoreMessage, error) {
+	if cmdCtx.Conversation.Alias == "" {
+		return plaintextMessage(configEditUnknownChannel), nil
+	}
+
+	current := channelNotificationSettings(e.cfg, cmdCtx.CommGroupName, cmdCtx.Platform, cmdCtx.Conversation.Alias)
+	return SetupWizardMessage(e.cfg, cmdCtx.Conversation.SourceBindings, current), nil
+}
+
+// SetupWizardMessage returns the interactive setup card for a channel with given source bindings and notification settings.
+// Selected values are persisted with the `config edit` command.
+func SetupWizardMessage(cfg config.Config, sources []string, current config.NotificationSettings) interactive.CoreMessage {
+	var options, selected []api.OptionItem
+	for name, src := range cfg.Sources {
+		opt := api.OptionItem{Name: name, Value: name}
+		if src.DisplayName != "" {
+			opt.Name = src.DisplayName
+		}
+		options = append(options, opt)
+	}
+	sort.Slice(options, func(i, j int) bool {
+		return options[i].Value < options[j].Value
+	})
+	for _, opt := range options {
+		for _, name := range sources {
+			if opt.Value == name {
+				selected = append(selected, opt)
+			}
+		}
+	}
+
+	sections := []api.Section{
+		{
+			Base: api.Base{
+				Description: fmt.Sprintf(configSetupDescription, api.MessageBotNamePlaceholder),
+			},
+		},
+	}
+	if len(options) > 0 {
+		sections = append(sections, api.Section{
+			MultiSelect: api.MultiSelect{
+				Name: "Sources",
+				Description: api.Body{
+					Plaintext: "Select notification sources.",
+				},
+				Command:        fmt.Sprintf("%s %s", configEditCmdPrefix(), sourcesSetting),
+				Options:        options,
+				InitialOptions: selected,
+			},
+		})
+	}
+
+	btnBuilder := api.NewMessageButtonBuilder()
+	sections = append(sections, api.Section{
+		Selects: api.Selects{
+			ID: configSetupSelectsID,
+			Items: []api.Select{
+				settingSelect(minLevelSetting, levelOptions(), string(current.MinLevel)),
+				settingSelect(quietHoursSetting, quietHoursOptions(current.QuietHours), current.QuietHours),
+			},
+		},
+		Buttons: api.Buttons{
+			btnBuilder.ForCommandWithoutDesc("More settings", fmt.Sprintf("%s %s", command.ConfigVerb, configEditFeature)),
+			btnBuilder.ForCommandWithoutDesc("Show effective settings", fmt.Sprintf("%s %s", command.ConfigVerb, effectiveConfigFeature)),
+		},
+	})
+
+	return interactive.CoreMessage{
+		Header: "Set up Botkube for this channel",
+		Message: api.Message{
+			Sections: sections,
+		},
+	}
+}
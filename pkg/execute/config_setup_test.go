@@ -0,0 +1,80 @@
+package execute
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+func TestConfigSetupExecutor(t *testing.T) {
+	// given
+	cfg := config.Config{
+		Sources: map[string]config.Sources{
+			"k8s-events": {DisplayName: "Kubernetes events"},
+			"prometheus": {},
+		},
+		Communications: map[string]config.Communications{
+			"default-group": {
+				SocketSlack: config.SocketSlack{
+					Channels: config.IdentifiableMap[config.ChannelBindingsByName]{
+						"alerts": {
+							Name: "alerts",
+							Notification: config.ChannelNotification{
+								NotificationSettings: config.NotificationSettings{
+									MinLevel:   config.Warn,
+									QuietHours: "23:00-06:00 Europe/Berlin",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	e := NewConfigSetupExecutor(loggerx.NewNoop(), cfg)
+	cmdCtx := CommandContext{
+		Args:          []string{"config", "setup"},
+		CommGroupName: "default-group",
+		Platform:      config.SocketSlackCommPlatformIntegration,
+		Conversation: Conversation{
+			Alias:          "alerts",
+			SourceBindings: []string{"prometheus"},
+		},
+	}
+
+	// when
+	msg, err := e.Config(context.Background(), cmdCtx)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "Set up Botkube for this channel", msg.Header)
+	require.Len(t, msg.Sections, 3)
+
+	sources := msg.Sections[1].MultiSelect
+	assert.Equal(t, "{{BotName}} config edit sources", sources.Command)
+	assert.Equal(t, []api.OptionItem{
+		{Name: "Kubernetes events", Value: "k8s-events"},
+		{Name: "prometheus", Value: "prometheus"},
+	}, sources.Options)
+	assert.Equal(t, []api.OptionItem{{Name: "prometheus", Value: "prometheus"}}, sources.InitialOptions)
+
+	selects := msg.Sections[2].Selects.Items
+	require.Len(t, selects, 2)
+	assert.Equal(t, "{{BotName}} config edit minLevel", selects[0].Command)
+	assert.Equal(t, "warn", selects[0].InitialOption.Value)
+	assert.Equal(t, "{{BotName}} config edit quietHours", selects[1].Command)
+	assert.Equal(t, "23:00-06:00 Europe/Berlin", selects[1].InitialOption.Value)
+
+	// when the channel is not defined in the configuration
+	msg, err = e.Config(context.Background(), CommandContext{Args: []string{"config", "setup"}})
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, configEditUnknownChannel, msg.BaseBody.Plaintext)
+}
@@ -41,8 +41,21 @@ func TestConfigExecutorShowConfig(t *testing.T) {
 						actions: {}
 						sources: {}
 						executors: {}
+						processors: {}
 						aliases: {}
+						runbooks: {}
+						filters: {}
+						enrichments: {}
+						escalations: {}
+						tickets: {}
+						maintenanceWindows: {}
+						routing:
+						    enabled: false
+						    sources: []
+						    rules: []
+						    fallback: []
 						communications: {}
+						secretProviders: {}
 						analytics:
 						    disable: false
 						settings:
@@ -65,13 +78,131 @@ func TestConfigExecutorShowConfig(t *testing.T) {
 						    informersResyncPeriod: 0s
 						    kubeconfig: ""
 						    saCredentialsPathPrefix: ""
+						    execution:
+						        timeout: 0s
+						        timeouts: []
+						        inProgressMessageDelay: 0s
+						        rateLimit:
+						            perUser:
+						                maxCommands: 0
+						                window: 0s
+						                burst: 0
+						            perChannel:
+						                maxCommands: 0
+						                window: 0s
+						                burst: 0
+						            exemptUsers: []
+						    authorization:
+						        opa:
+						            enabled: false
+						            url: ""
+						            path: ""
+						            timeout: 0s
+						            failOpen: false
+						    incidents:
+						        enabled: false
+						        sources: []
+						        suppressFor: 0s
+						        retention: 0s
+						    notification: {}
+						    floodProtection:
+						        enabled: false
+						        maxNotifications: 0
+						        window: 0s
+						    onboarding:
+						        enabled: false
+						    configHistory:
+						        enabled: false
+						        limit: 0
+						        secret: {}
+						    tracing:
+						        enabled: false
+						        endpoint: ""
+						        protocol: ""
+						        insecure: false
+						        sampleRatio: 0
+						        serviceName: ""
+						    metrics:
+						        maxLabelValues: 0
+						    auditLog:
+						        enabled: false
+						        retention: 0s
+						        flushInterval: 0s
+						        file:
+						            enabled: false
+						            directory: ""
+						        elasticsearch:
+						            enabled: false
+						            server: ""
+						            username: ""
+						            password: ""
+						            apiKey: ""
+						            skipTLSVerify: false
+						            indexPrefix: ""
+						        webhook:
+						            enabled: false
+						            url: ""
+						            timeout: 0s
+						        s3:
+						            enabled: false
+						            bucket: ""
+						            region: ""
+						            endpoint: ""
+						            forcePathStyle: false
+						            accessKeyID: ""
+						            secretAccessKey: ""
+						            prefix: ""
+						    redaction:
+						        enabled: false
+						        replacement: ""
+						        minValueLength: 0
+						        secrets:
+						            enabled: false
+						            namespaces: []
+						            labelSelector: ""
+						            refreshInterval: 0s
+						        envVars:
+						            enabled: false
+						            names: []
+						    identityMapping:
+						        oidc:
+						            enabled: false
+						            usernamePrefix: ""
+						            groupsPrefix: ""
+						            allowedDomains: []
 						configWatcher:
 						    enabled: false
 						    remote:
 						        pollInterval: 0s
 						    inCluster:
 						        informerResyncPeriod: 0s
+						    hotReload:
+						        enabled: false
+						        syncTimeout: 0s
+						        drainTimeout: 0s
 						    deployment: {}
+						configCRD:
+						    enabled: false
+						    rbacGroupPrefix: ""
+						    webhook:
+						        enabled: false
+						        port: 0
+						        certDir: ""
+						gitSync:
+						    enabled: false
+						    repository: ""
+						    branch: ""
+						    path: ""
+						    auth:
+						        username: ""
+						        token: ""
+						        sshKeyPath: ""
+						    pollInterval: 0s
+						    webhook:
+						        enabled: false
+						        port: 0
+						        secret: ""
+						    cacheDir: ""
 						plugins:
 						    cacheDir: ""
 						    repositories: {}
@@ -0,0 +1,91 @@
+package execute
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/internal/doctor"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/execute/command"
+)
+
+const (
+	doctorNotAvailable = "Diagnostics are not available."
+	doctorNoChecks     = "There is nothing to check."
+	doctorSummaryFmt   = "%d of %d checks failed."
+)
+
+var doctorFeatureName = FeatureName{Name: noFeature}
+
+// Doctor runs connectivity and permission checks.
+type Doctor interface {
+	Run(ctx context.Context) doctor.Report
+}
+
+// DoctorExecutor executes the diagnostics command.
+type DoctorExecutor struct {
+	log    logrus.FieldLogger
+	doctor Doctor
+}
+
+// NewDoctorExecutor returns a new DoctorExecutor instance. The doctor is optional.
+func NewDoctorExecutor(log logrus.FieldLogger, doctor Doctor) *DoctorExecutor {
+	return &DoctorExecutor{
+		log:    log,
+		doctor: doctor,
+	}
+}
+
+// FeatureName returns the name and aliases of the feature provided by this executor
+func (e *DoctorExecutor) FeatureName() FeatureName {
+	return doctorFeatureName
+}
+
+// Commands returns slice of commands the executor supports
+func (e *DoctorExecutor) Commands() map[command.Verb]CommandFn {
+	return map[command.Verb]CommandFn{
+		command.DoctorVerb: e.Doctor,
+	}
+}
+
+// Doctor runs all checks, and responds with the pass/fail report, followed by remediation hints of failed checks.
+func (e *DoctorExecutor) Doctor(ctx context.Context, cmdCtx CommandContext) (interactive.CoreMessage, error) {
+	if e.doctor == nil {
+		return respond(doctorNotAvailable, cmdCtx), nil
+	}
+
+	report := e.doctor.Run(ctx)
+	if len(report.Results) == 0 {
+		return respond(doctorNoChecks, cmdCtx), nil
+	}
+	return respond(doctorTabularOutput(report), cmdCtx), nil
+}
+
+func doctorTabularOutput(report doctor.Report) string {
+	buf := new(bytes.Buffer)
+	w := tabwriter.NewWriter(buf, 5, 0, 1, ' ', 0)
+	fmt.Fprintf(w, "CHECK\tTARGET\tSTATUS\tDETAILS")
+	for _, res := range report.Results {
+		fmt.Fprintf(w, "\n%s\t%s\t%s\t%s", res.Check, res.Target, strings.ToUpper(string(res.Status)), auditColumnValue(res.Details))
+	}
+	w.Flush()
+
+	var hints []string
+	for _, res := range report.Results {
+		if res.Hint == "" {
+			continue
+		}
+		hints = append(hints, fmt.Sprintf("- %s %s: %s", res.Check, res.Target, res.Hint))
+	}
+
+	fmt.Fprintf(buf, "\n\n"+doctorSummaryFmt, report.Failed(), len(report.Results))
+	if len(hints) > 0 {
+		fmt.Fprintf(buf, "\n\nHints:\n%s", strings.Join(hints, "\n"))
+	}
+	return buf.String()
+}
@@ -0,0 +1,52 @@
+package execute
+
+import (
+	"context"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/internal/doctor"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+func TestDoctorExecutor(t *testing.T) {
+	// given
+	e := NewDoctorExecutor(loggerx.NewNoop(), fakeDoctor{
+		Results: []doctor.Result{
+			{Check: "platform", Target: "default-group/socketSlack", Status: doctor.PassStatus},
+			{Check: "rbac", Target: "k8s-events/v1/pods", Status: doctor.FailStatus, Details: "Denied: watch in all namespaces.", Hint: "Grant the watch verb."},
+		},
+	})
+	cmdCtx := CommandContext{Args: []string{"doctor"}, ExecutorFilter: newExecutorTextFilter("")}
+
+	// when
+	msg, err := e.Doctor(context.Background(), cmdCtx)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, heredoc.Doc(`
+		CHECK    TARGET                    STATUS DETAILS
+		platform default-group/socketSlack PASS   -
+		rbac     k8s-events/v1/pods        FAIL   Denied: watch in all namespaces.
+
+		1 of 2 checks failed.
+
+		Hints:
+		- rbac k8s-events/v1/pods: Grant the watch verb.`), msg.BaseBody.CodeBlock)
+
+	// when the doctor is not set
+	msg, err = NewDoctorExecutor(loggerx.NewNoop(), nil).Doctor(context.Background(), cmdCtx)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, doctorNotAvailable, msg.BaseBody.CodeBlock)
+}
+
+type fakeDoctor doctor.Report
+
+func (f fakeDoctor) Run(context.Context) doctor.Report {
+	return doctor.Report(f)
+}
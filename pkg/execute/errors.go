@@ -8,6 +8,11 @@ import (
 var (
 	errInvalidCommand     = errors.New("invalid command")
 	errUnsupportedCommand = errors.New("unsupported command")
+
+	errCommandDenied        = errors.New("command denied by authorization policy")
+	errExecutionInterrupted = errors.New("command execution interrupted")
+	errFeatureDisabled      = errors.New("command gated by a disabled feature flag")
+	errRateLimited          = errors.New("command rate limit exceeded")
 )
 
 // ExecutionCommandError defines error occurred during command execution.
@@ -0,0 +1,151 @@
+package execute
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+// ErrExecutionNotFound is returned when a given command execution is not running.
+var ErrExecutionNotFound = errors.New("execution not found")
+
+// executionIDBytes is the number of random bytes of execution IDs, so they can't be guessed.
+const executionIDBytes = 8
+
+type commandTimeout struct {
+	pattern *regexp.Regexp
+	timeout time.Duration
+}
+
+type runningExecution struct {
+	cmd          string
+	conversation string
+	canceledBy   string
+	cancel       context.CancelFunc
+}
+
+// ExecutionTracker tracks running executor plugin commands, so they can be timed out or canceled on user request.
+type ExecutionTracker struct {
+	log            logrus.FieldLogger
+	defaultTimeout time.Duration
+	timeouts       []commandTimeout
+	inProgressIn   time.Duration
+
+	mu      sync.Mutex
+	running map[string]*runningExecution
+}
+
+// NewExecutionTracker returns a new ExecutionTracker instance.
+func NewExecutionTracker(log logrus.FieldLogger, cfg config.Execution) *ExecutionTracker {
+	var timeouts []commandTimeout
+	for _, t := range cfg.Timeouts {
+		pattern, err := regexp.Compile(t.Command)
+		if err != nil {
+			// patterns are validated on config load
+			log.WithError(err).Errorf("Ignoring timeout for invalid command pattern %q", t.Command)
+			continue
+		}
+		timeouts = append(timeouts, commandTimeout{pattern: pattern, timeout: t.Timeout})
+	}
+
+	return &ExecutionTracker{
+		log:            log,
+		defaultTimeout: cfg.Timeout,
+		timeouts:       timeouts,
+		inProgressIn:   cfg.InProgressMessageDelay,
+		running:        map[string]*runningExecution{},
+	}
+}
+
+// TimeoutFor returns timeout for a given command. The first matching pattern wins.
+// Zero means that the command doesn't time out.
+func (t *ExecutionTracker) TimeoutFor(cmd string) time.Duration {
+	for _, item := range t.timeouts {
+		if item.pattern.MatchString(cmd) {
+			return item.timeout
+		}
+	}
+	return t.defaultTimeout
+}
+
+// InProgressMessageDelay returns after how long the in-progress message should be sent.
+func (t *ExecutionTracker) InProgressMessageDelay() time.Duration {
+	return t.inProgressIn
+}
+
+// Start registers a new execution started in a given conversation and returns its context together with ID
+// and a function that must be called once the execution is finished.
+func (t *ExecutionTracker) Start(ctx context.Context, conversationID, cmd string) (context.Context, string, func(), error) {
+	return t.StartWithTimeout(ctx, conversationID, cmd, t.TimeoutFor(cmd))
+}
+
+// StartWithTimeout works the same as Start, but it uses a given timeout instead of the configured ones.
+// Zero means that the execution doesn't time out.
+func (t *ExecutionTracker) StartWithTimeout(ctx context.Context, conversationID, cmd string, timeout time.Duration) (context.Context, string, func(), error) {
+	raw := make([]byte, executionIDBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, "", nil, fmt.Errorf("while generating execution ID: %w", err)
+	}
+	id := hex.EncodeToString(raw)
+
+	var cancel context.CancelFunc
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.running[id] = &runningExecution{
+		cmd:          cmd,
+		conversation: conversationID,
+		cancel:       cancel,
+	}
+
+	done := func() {
+		cancel()
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		delete(t.running, id)
+	}
+	return ctx, id, done, nil
+}
+
+// Cancel cancels a given execution and returns the canceled command.
+// Only executions started in a given conversation can be canceled, other ones are reported as not found.
+func (t *ExecutionTracker) Cancel(id, conversationID, user string) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	exec, found := t.running[id]
+	if !found || exec.conversation != conversationID {
+		return "", ErrExecutionNotFound
+	}
+	if exec.canceledBy == "" {
+		exec.canceledBy = user
+	}
+	exec.cancel()
+	return exec.cmd, nil
+}
+
+// CanceledBy returns the user who canceled a given execution. Empty string means that it wasn't canceled.
+func (t *ExecutionTracker) CanceledBy(id string) string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	exec, found := t.running[id]
+	if !found {
+		return ""
+	}
+	return exec.canceledBy
+}
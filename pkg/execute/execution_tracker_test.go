@@ -0,0 +1,95 @@
+package execute
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+func TestExecutionTrackerTimeoutFor(t *testing.T) {
+	// given
+	tracker := NewExecutionTracker(loggerx.NewNoop(), config.Execution{
+		Timeout: time.Minute,
+		Timeouts: []config.CommandTimeout{
+			{Command: "^helm (install|upgrade)", Timeout: 10 * time.Minute},
+			{Command: "[invalid", Timeout: time.Second},
+			{Command: "^helm", Timeout: 2 * time.Minute},
+		},
+	})
+
+	// when-then
+	assert.Equal(t, 10*time.Minute, tracker.TimeoutFor("helm upgrade foo"))
+	assert.Equal(t, 2*time.Minute, tracker.TimeoutFor("helm list"))
+	assert.Equal(t, time.Minute, tracker.TimeoutFor("kubectl get pods"))
+}
+
+func TestExecutionTrackerCancel(t *testing.T) {
+	// given
+	tracker := NewExecutionTracker(loggerx.NewNoop(), config.Execution{})
+	ctx, id, done, err := tracker.Start(context.Background(), "C1", "kubectl logs -f api")
+	require.NoError(t, err)
+	_, _, otherDone, err := tracker.Start(context.Background(), "C1", "kubectl logs -f db")
+	require.NoError(t, err)
+	defer otherDone()
+
+	// then
+	assert.Len(t, id, 2*executionIDBytes)
+
+	// when canceled in other conversation
+	_, err = tracker.Cancel(id, "C2", "@mallory")
+
+	// then
+	assert.ErrorIs(t, err, ErrExecutionNotFound)
+	assert.NoError(t, ctx.Err())
+
+	// when
+	cmd, err := tracker.Cancel(id, "C1", "@alice")
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "kubectl logs -f api", cmd)
+	assert.ErrorIs(t, ctx.Err(), context.Canceled)
+	assert.Equal(t, "@alice", tracker.CanceledBy(id))
+
+	// when finished
+	done()
+
+	// then
+	_, err = tracker.Cancel(id, "C1", "@bob")
+	assert.ErrorIs(t, err, ErrExecutionNotFound)
+}
+
+func TestInterruptedExecutionMessage(t *testing.T) {
+	// given
+	tracker := NewExecutionTracker(loggerx.NewNoop(), config.Execution{Timeout: time.Millisecond})
+	e := &DefaultExecutor{log: loggerx.NewNoop(), executionTracker: tracker}
+	cmdCtx := CommandContext{
+		ClusterName:    "dev",
+		ExpandedRawCmd: "kubectl logs -f api",
+		CleanCmd:       "kubectl logs -f api",
+		ExecutorFilter: newExecutorTextFilter(""),
+	}
+
+	ctx, id, done, err := tracker.Start(context.Background(), "C1", cmdCtx.CleanCmd)
+	require.NoError(t, err)
+	defer done()
+	<-ctx.Done()
+
+	// when
+	msg, err := e.interruptedExecutionMessage(ctx, id, interactive.CoreMessage{}, NewExecutionCommandError("line 1\nline 2\ncommand interrupted: context deadline exceeded"), cmdCtx)
+
+	// then
+	assert.ErrorIs(t, err, errExecutionInterrupted)
+	assert.Equal(t, api.Body{
+		Plaintext: "Command timed out after 1ms.",
+		CodeBlock: "line 1\nline 2\ncommand interrupted: context deadline exceeded",
+	}, msg.Message.BaseBody)
+}
@@ -9,10 +9,16 @@ import (
 	"time"
 
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/kubeshop/botkube/internal/analytics"
 	"github.com/kubeshop/botkube/internal/audit"
+	"github.com/kubeshop/botkube/internal/auditlog"
+	"github.com/kubeshop/botkube/internal/authz"
+	"github.com/kubeshop/botkube/internal/featureflag"
+	"github.com/kubeshop/botkube/internal/metrics"
 	remoteapi "github.com/kubeshop/botkube/internal/remote"
+	"github.com/kubeshop/botkube/internal/tracing"
 	"github.com/kubeshop/botkube/pkg/api"
 	"github.com/kubeshop/botkube/pkg/bot/interactive"
 	"github.com/kubeshop/botkube/pkg/config"
@@ -20,6 +26,7 @@ import (
 	"github.com/kubeshop/botkube/pkg/execute/command"
 	"github.com/kubeshop/botkube/pkg/formatx"
 	"github.com/kubeshop/botkube/pkg/plugin"
+	"github.com/kubeshop/botkube/pkg/shellx"
 )
 
 const (
@@ -29,9 +36,19 @@ const (
 
 	anonymizedInvalidVerb = "{invalid verb}"
 
+	// builtinExecutorName is the executor metrics label of built-in commands, such as `list` or `edit`.
+	builtinExecutorName = "builtin"
+
 	lineLimitToShowFilter = 16
 
 	invalidCmdWithUsage = "error: unknown option `%s`\nusage: %s"
+
+	cmdInProgressMsg  = "Command is still running..."
+	cmdCanceledMsgFmt = "Command canceled by %s."
+	cmdTimedOutMsgFmt = "Command timed out after %s."
+
+	cmdDeniedMsg              = "You are not allowed to run this command."
+	cmdDeniedWithReasonMsgFmt = "You are not allowed to run this command: %s"
 )
 
 var newLinePattern = regexp.MustCompile(`\r?\n`)
@@ -62,10 +79,59 @@ type DefaultExecutor struct {
 	auditReporter         audit.AuditReporter
 	pluginHealthStats     *plugin.HealthStats
 	auditContext          map[string]interface{}
+	executionTracker      *ExecutionTracker
+	cmdRateLimiter        *CommandRateLimiter
+	cmdAuthorizer         CommandAuthorizer
+	featureFlags          FeatureFlagManager
+	auditLogger           AuditLogger
+	redactor              Redactor
+}
+
+// Redactor masks sensitive values in messages.
+type Redactor interface {
+	RedactString(in string) string
+	RedactMessage(msg api.Message) api.Message
 }
 
 // Execute executes commands and returns output
 func (e *DefaultExecutor) Execute(ctx context.Context) interactive.CoreMessage {
+	msg, _ := e.ExecuteWithResult(ctx)
+	return msg
+}
+
+// allowCommand checks command rate limits. Commands of all actions and pipelines share the same user and channel,
+// so automations are limited by action rate limits instead.
+func (e *DefaultExecutor) allowCommand() (time.Duration, bool) {
+	if e.conversation.CommandOrigin == command.AutomationOrigin {
+		return 0, true
+	}
+	return e.cmdRateLimiter.Allow(e.platform, e.user, e.conversation.ID)
+}
+
+// ExecuteWithResult executes commands and returns output. Additionally, it returns an error if the command failed,
+// so callers can react on failures. The returned message already describes the error for end users.
+func (e *DefaultExecutor) ExecuteWithResult(ctx context.Context) (interactive.CoreMessage, error) {
+	ctx, span := tracing.Start(ctx, "executor.Execute", tracing.PlatformKey.String(string(e.platform)))
+	msg, err := e.executeWithResult(ctx)
+	tracing.End(span, err)
+	return e.redact(msg), err
+}
+
+// redact masks sensitive values in the command output before it's sent to the communication platform.
+func (e *DefaultExecutor) redact(msg interactive.CoreMessage) interactive.CoreMessage {
+	if e.redactor == nil {
+		return msg
+	}
+	msg.Header = e.redactor.RedactString(msg.Header)
+	msg.Description = e.redactor.RedactString(msg.Description)
+	msg.Message = e.redactor.RedactMessage(msg.Message)
+	for i := range msg.Messages {
+		msg.Messages[i] = e.redactor.RedactMessage(msg.Messages[i])
+	}
+	return msg
+}
+
+func (e *DefaultExecutor) executeWithResult(ctx context.Context) (_ interactive.CoreMessage, err error) {
 	empty := interactive.CoreMessage{}
 	rawCmd := sanitizeCommand(e.message)
 
@@ -89,14 +155,21 @@ func (e *DefaultExecutor) Execute(ctx context.Context) interactive.CoreMessage {
 	flags, err := ParseFlags(expandedRawCmd)
 	if err != nil {
 		e.log.WithError(err).WithField("msg", expandedRawCmd).Error("Failed to parse user message")
+		body := api.Body{
+			Plaintext: cantParseCmd,
+		}
+		var parseErr *shellx.ParseError
+		if errors.As(err, &parseErr) {
+			body = api.Body{
+				CodeBlock: fmt.Sprintf(cantParseCmdWithReason, parseErr.Error()),
+			}
+		}
 		return interactive.CoreMessage{
 			Description: header(cmdCtx),
 			Message: api.Message{
-				BaseBody: api.Body{
-					Plaintext: cantParseCmd,
-				},
+				BaseBody: body,
 			},
-		}
+		}, err
 	}
 
 	cmdCtx.CleanCmd = flags.CleanCmd
@@ -105,16 +178,20 @@ func (e *DefaultExecutor) Execute(ctx context.Context) interactive.CoreMessage {
 	cmdCtx.Args = flags.TokenizedCmd
 	cmdCtx.ExecutorFilter = newExecutorTextFilter(flags.Filter)
 
+	// only the command name and verb are recorded, as arguments may contain sensitive data
+	spanCmd, spanVerb := parseCmdVerb(cmdCtx.Args)
+	trace.SpanFromContext(ctx).SetAttributes(tracing.CommandKey.String(strings.TrimSpace(spanCmd + " " + spanVerb)))
+
 	if len(cmdCtx.Args) == 0 {
 		if e.conversation.IsKnown {
 			msg, err := e.helpExecutor.Help(ctx, cmdCtx)
 			if err != nil {
 				e.log.Errorf("while getting help message: %s", err.Error())
-				return respond(err.Error(), cmdCtx)
+				return respond(err.Error(), cmdCtx), err
 			}
-			return msg
+			return msg, nil
 		}
-		return empty // this prevents all bots on all clusters to answer something
+		return empty, nil // this prevents all bots on all clusters to answer something
 	}
 
 	if !cmdCtx.ProvidedClusterNameEqualOrEmpty() {
@@ -122,53 +199,86 @@ func (e *DefaultExecutor) Execute(ctx context.Context) interactive.CoreMessage {
 			"config-cluster-name":  cmdCtx.ClusterName,
 			"command-cluster-name": cmdCtx.ProvidedClusterName,
 		}).Debugf("Specified cluster name doesn't match ours. Ignoring further execution...")
-		return empty // user specified different target cluster
+		return empty, nil // user specified different target cluster
 	}
 
 	// commands below are executed only if the channel is configured
 	if !e.conversation.IsKnown {
 		e.log.Info("Unknown conversation. Returning empty message...")
-		return empty
+		return empty, nil
+	}
+
+	var auditPluginName string
+	defer func() {
+		e.recordAuditEntry(cmdCtx, auditPluginName, err)
+	}()
+
+	if retryAfter, ok := e.allowCommand(); !ok {
+		e.log.WithFields(logrus.Fields{
+			"user":       e.user.DisplayName,
+			"channel":    e.conversation.ID,
+			"retryAfter": retryAfter,
+		}).Info("Command rate limit exceeded")
+		return rateLimitedMessage(retryAfter, cmdCtx), errRateLimited
+	}
+
+	if e.pluginExecutor.CanHandleInteraction(e.conversation.ExecutorBindings, cmdCtx.Args) {
+		_, auditPluginName = e.pluginExecutor.getEnabledPlugins(e.conversation.ExecutorBindings, cmdCtx.Args[1])
+		return e.handlePluginInteraction(ctx, cmdCtx)
 	}
 
 	isPluginCmd := e.pluginExecutor.CanHandle(e.conversation.ExecutorBindings, cmdCtx.Args)
 	if isPluginCmd {
 		_, fullPluginName := e.pluginExecutor.getEnabledPlugins(e.conversation.ExecutorBindings, cmdCtx.Args[0])
+		auditPluginName = fullPluginName
 		e.reportCommand(ctx, fullPluginName, e.pluginExecutor.GetCommandPrefix(cmdCtx.Args), cmdCtx.ExecutorFilter.IsActive(), cmdCtx)
 
 		if isHelpCmd(cmdCtx.Args) {
-			return e.ExecuteHelp(ctx, cmdCtx)
+			return e.ExecuteHelp(ctx, cmdCtx), nil
+		}
+
+		if denied, ok := e.authorize(ctx, cmdCtx, fullPluginName); !ok {
+			return denied, errCommandDenied
+		}
+
+		if flag, gated := featureflag.ForExecutorPlugin(fullPluginName); gated && e.featureFlags != nil && !e.featureFlags.Enabled(flag, e.conversation.Alias) {
+			return respond(featureDisabledMessage(flag), cmdCtx), errFeatureDisabled
 		}
 
-		out, err := e.pluginExecutor.Execute(ctx, e.conversation.ExecutorBindings, e.conversation.SlackState, cmdCtx)
+		out, err := e.executePluginCommand(ctx, cmdCtx)
 		switch {
 		case err == nil:
+		case errors.Is(err, errExecutionInterrupted):
+			return out, err
 		case IsExecutionCommandError(err):
-			return respond(err.Error(), cmdCtx)
+			return respond(err.Error(), cmdCtx), err
 		default:
 			// TODO: Return error when the DefaultExecutor is refactored as a part of https://github.com/kubeshop/botkube/issues/589
 			e.log.Errorf("while executing command %q: %s", cmdCtx.CleanCmd, err.Error())
-			return empty
+			return empty, err
 		}
-		return out
+		return out, nil
 	}
 
 	help, found := GetInstallHelpForKnownPlugin(cmdCtx.Args)
 	if found {
-		return respond(help, cmdCtx)
+		return respond(help, cmdCtx), errUnsupportedCommand
 	}
 
 	cmdVerb := command.Verb(strings.ToLower(cmdCtx.Args[0]))
 	var cmdRes string
-	if len(cmdCtx.Args) > 1 {
+	if len(cmdCtx.Args) > 1 && !strings.HasPrefix(cmdCtx.Args[1], "-") {
+		// flags given directly after the verb, e.g. `status --detailed`, belong to the default feature
 		cmdRes = strings.ToLower(cmdCtx.Args[1])
+		// resources given in the `kind/name` form, e.g. `watch deployment/foo`, belong to the feature named after the kind
+		cmdRes, _, _ = strings.Cut(cmdRes, "/")
 	}
 
 	fn, foundRes, foundFn := e.cmdsMapping.FindFn(cmdVerb, cmdRes)
 	if !foundRes {
 		e.reportCommand(ctx, "", anonymizedInvalidVerb, false, cmdCtx)
 		e.log.Infof("received unsupported command: %q", cmdCtx.CleanCmd)
-		return respond(unsupportedCmdMsg, cmdCtx)
+		return respond(unsupportedCmdMsg, cmdCtx), errUnsupportedCommand
 	}
 
 	if !foundFn {
@@ -180,7 +290,7 @@ func (e *DefaultExecutor) Execute(ctx context.Context) interactive.CoreMessage {
 		e.reportCommand(ctx, "", reportedCmd, false, cmdCtx)
 		helpMsg := e.cmdsMapping.HelpMessageForVerb(cmdVerb)
 		responseMsg := fmt.Sprintf(invalidCmdWithUsage, cmdRes, helpMsg)
-		return respond(responseMsg, cmdCtx)
+		return respond(responseMsg, cmdCtx), errInvalidCommand
 	} else {
 		cmdToReport := string(cmdVerb)
 		if cmdRes != "" {
@@ -189,22 +299,219 @@ func (e *DefaultExecutor) Execute(ctx context.Context) interactive.CoreMessage {
 		e.reportCommand(ctx, "", cmdToReport, false, cmdCtx)
 	}
 
+	if denied, ok := e.authorize(ctx, cmdCtx, ""); !ok {
+		return denied, errCommandDenied
+	}
+
+	start := time.Now()
 	msg, err := fn(ctx, cmdCtx)
+	metrics.ObserveCommand(builtinExecutorName, start, err)
 	switch {
 	case err == nil:
 	case errors.Is(err, errInvalidCommand):
-		return respond(incompleteCmdMsg, cmdCtx)
+		return respond(incompleteCmdMsg, cmdCtx), err
 	case errors.Is(err, errUnsupportedCommand):
-		return respond(unsupportedCmdMsg, cmdCtx)
+		return respond(unsupportedCmdMsg, cmdCtx), err
 	case IsExecutionCommandError(err):
-		return respond(err.Error(), cmdCtx)
+		return respond(err.Error(), cmdCtx), err
 	default:
 		e.log.Errorf("while executing command %q: %s", cmdCtx.CleanCmd, err.Error())
 		msg := fmt.Sprintf(internalErrorMsgFmt, cmdCtx.ClusterName)
-		return respond(msg, cmdCtx)
+		return respond(msg, cmdCtx), err
 	}
 
-	return msg
+	return msg, nil
+}
+
+// authorize evaluates a given command against the configured authorization policies. If the command is denied, a message with the reason is returned.
+// Commands which aren't known to be read-only are denied in read-only channels regardless of the policies.
+func (e *DefaultExecutor) authorize(ctx context.Context, cmdCtx CommandContext, pluginName string) (interactive.CoreMessage, bool) {
+	args := authz.ParseArgs(cmdCtx.Args, pluginName != "")
+	if e.conversation.ReadOnly && !isReadOnlyCommand(pluginName, args) {
+		e.log.WithFields(logrus.Fields{
+			"channel": e.conversation.ID,
+			"command": cmdCtx.CleanCmd,
+		}).Info("Command denied in read-only channel")
+		return deniedMessage(readOnlyChannelMsg(pluginName, args), cmdCtx), false
+	}
+
+	if e.cmdAuthorizer == nil {
+		return interactive.CoreMessage{}, true
+	}
+
+	decision := e.cmdAuthorizer.Authorize(ctx, authz.Input{
+		Command:       cmdCtx.CleanCmd,
+		Args:          cmdCtx.Args,
+		Plugin:        pluginName,
+		Verb:          args.Verb,
+		Resource:      args.Resource,
+		Namespace:     args.Namespace,
+		AllNamespaces: args.AllNamespaces,
+		Ambiguous:     args.Ambiguous(),
+		User: authz.User{
+			Mention:     e.user.Mention,
+			DisplayName: e.user.DisplayName,
+			ID:          e.user.ID,
+			Email:       e.user.Email,
+		},
+		Channel: authz.Channel{
+			ID:    e.conversation.ID,
+			Name:  e.conversation.DisplayName,
+			Alias: e.conversation.Alias,
+		},
+		Platform:    string(e.platform),
+		ClusterName: cmdCtx.ClusterName,
+	})
+	if decision.Allowed {
+		return interactive.CoreMessage{}, true
+	}
+
+	msg := cmdDeniedMsg
+	if decision.Reason != "" {
+		msg = fmt.Sprintf(cmdDeniedWithReasonMsgFmt, decision.Reason)
+	}
+	return deniedMessage(msg, cmdCtx), false
+}
+
+func deniedMessage(msg string, cmdCtx CommandContext) interactive.CoreMessage {
+	return interactive.CoreMessage{
+		Description: header(cmdCtx),
+		Message: api.Message{
+			BaseBody: api.Body{
+				Plaintext: msg,
+			},
+		},
+	}
+}
+
+// handlePluginInteraction delivers an interaction with a message element back to the plugin which returned the message.
+// The interaction is authorized as the plugin command it continues, e.g. `kubectl edit {callback value}` for the `edit-apply` callback.
+func (e *DefaultExecutor) handlePluginInteraction(ctx context.Context, cmdCtx CommandContext) (interactive.CoreMessage, error) {
+	_, fullPluginName := e.pluginExecutor.getEnabledPlugins(e.conversation.ExecutorBindings, cmdCtx.Args[1])
+	e.reportCommand(ctx, fullPluginName, interactionCmdName, false, cmdCtx)
+	if cmdCtx.CmdHeader == "" {
+		cmdCtx.CmdHeader = fmt.Sprintf("`%s` interaction", cmdCtx.Args[1])
+	}
+
+	authzCtx := cmdCtx
+	authzCtx.Args = interactionAuthzArgs(cmdCtx.Args)
+	if denied, ok := e.authorize(ctx, authzCtx, fullPluginName); !ok {
+		return denied, errCommandDenied
+	}
+
+	if flag, gated := featureflag.ForExecutorPlugin(fullPluginName); gated && e.featureFlags != nil && !e.featureFlags.Enabled(flag, e.conversation.Alias) {
+		return respond(featureDisabledMessage(flag), cmdCtx), errFeatureDisabled
+	}
+
+	out, err := e.pluginExecutor.HandleInteraction(ctx, e.conversation.ExecutorBindings, e.conversation.SlackState, cmdCtx)
+	switch {
+	case err == nil:
+	case IsExecutionCommandError(err):
+		return respond(err.Error(), cmdCtx), err
+	default:
+		e.log.Errorf("while handling interaction %q: %s", cmdCtx.CleanCmd, err.Error())
+		return interactive.CoreMessage{}, err
+	}
+	return out, nil
+}
+
+// executePluginCommand executes a given plugin command respecting the configured timeout.
+// If the command is still running after the configured delay, a message with the Cancel button is sent.
+func (e *DefaultExecutor) executePluginCommand(ctx context.Context, cmdCtx CommandContext) (interactive.CoreMessage, error) {
+	if e.executionTracker == nil {
+		return e.pluginExecutor.Execute(ctx, e.conversation.ExecutorBindings, e.conversation.SlackState, cmdCtx)
+	}
+
+	execCtx, id, done, err := e.executionTracker.Start(ctx, cmdCtx.Conversation.ID, cmdCtx.CleanCmd)
+	if err != nil {
+		return interactive.CoreMessage{}, err
+	}
+	defer done()
+
+	type result struct {
+		msg interactive.CoreMessage
+		err error
+	}
+	resultCh := make(chan result, 1)
+	go func() {
+		msg, err := e.pluginExecutor.Execute(execCtx, e.conversation.ExecutorBindings, e.conversation.SlackState, cmdCtx)
+		resultCh <- result{msg: msg, err: err}
+	}()
+
+	var inProgress <-chan time.Time
+	if delay := e.executionTracker.InProgressMessageDelay(); delay > 0 {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		inProgress = timer.C
+	}
+
+	for {
+		select {
+		case res := <-resultCh:
+			return e.interruptedExecutionMessage(execCtx, id, res.msg, res.err, cmdCtx)
+		case <-inProgress:
+			e.sendInProgressMessage(ctx, id, cmdCtx)
+		}
+	}
+}
+
+// interruptedExecutionMessage returns a message describing why a given execution was interrupted together with a partial output, if available,
+// and the errExecutionInterrupted error. If the execution wasn't interrupted, the original output is returned.
+func (e *DefaultExecutor) interruptedExecutionMessage(execCtx context.Context, id string, msg interactive.CoreMessage, err error, cmdCtx CommandContext) (interactive.CoreMessage, error) {
+	var reason string
+	switch canceledBy := e.executionTracker.CanceledBy(id); {
+	case canceledBy != "":
+		reason = fmt.Sprintf(cmdCanceledMsgFmt, canceledBy)
+	case errors.Is(execCtx.Err(), context.DeadlineExceeded):
+		reason = fmt.Sprintf(cmdTimedOutMsgFmt, e.executionTracker.TimeoutFor(cmdCtx.CleanCmd))
+	default:
+		return msg, err
+	}
+
+	e.log.WithField("id", id).Infof("Execution of %q interrupted: %s", cmdCtx.CleanCmd, reason)
+
+	var partialOutput string
+	if err != nil && IsExecutionCommandError(err) {
+		partialOutput = cmdCtx.ExecutorFilter.Apply(strings.TrimSpace(err.Error()))
+	}
+	return interactive.CoreMessage{
+		Description: header(cmdCtx),
+		Message: api.Message{
+			BaseBody: api.Body{
+				Plaintext: reason,
+				CodeBlock: partialOutput,
+			},
+		},
+	}, errExecutionInterrupted
+}
+
+func (e *DefaultExecutor) sendInProgressMessage(ctx context.Context, id string, cmdCtx CommandContext) {
+	sender, ok := e.notifierHandler.(InProgressMessageSender)
+	if !ok {
+		return
+	}
+
+	btnBuilder := api.NewMessageButtonBuilder()
+	msg := interactive.CoreMessage{
+		Description: header(cmdCtx),
+		Message: api.Message{
+			Sections: []api.Section{
+				{
+					Base: api.Base{
+						Body: api.Body{
+							Plaintext: cmdInProgressMsg,
+						},
+					},
+					Buttons: []api.Button{
+						btnBuilder.ForCommandWithoutDesc("Cancel", fmt.Sprintf("%s %s %s", command.CancelVerb, cmdFeatureName.Name, id), api.ButtonStyleDanger),
+					},
+				},
+			},
+		},
+	}
+	if err := sender.SendInProgressMessage(ctx, e.conversation, msg); err != nil {
+		e.log.WithError(err).Error("Failed to send in-progress message")
+	}
 }
 
 func (e *DefaultExecutor) ExecuteHelp(ctx context.Context, cmdCtx CommandContext) interactive.CoreMessage {
@@ -296,6 +603,41 @@ func (e *DefaultExecutor) reportAuditEvent(ctx context.Context, pluginName strin
 	return e.auditReporter.ReportExecutorAuditEvent(ctx, event)
 }
 
+// recordAuditEntry records the outcome of a given command in the audit log.
+func (e *DefaultExecutor) recordAuditEntry(cmdCtx CommandContext, pluginName string, err error) {
+	if e.auditLogger == nil {
+		return
+	}
+
+	entry := auditlog.Entry{
+		Type:     auditlog.CommandEntryType,
+		Outcome:  auditlog.SuccessOutcome,
+		Platform: cmdCtx.Platform.String(),
+		Channel:  cmdCtx.Conversation.ID,
+		User:     cmdCtx.User.DisplayName,
+		Command:  cmdCtx.ExpandedRawCmd,
+		Plugin:   pluginName,
+	}
+	if cmdCtx.Conversation.DisplayName != "" {
+		entry.Channel = cmdCtx.Conversation.DisplayName
+	}
+	switch cmdCtx.Conversation.CommandOrigin {
+	case command.ButtonClickOrigin, command.SelectValueChangeOrigin, command.MultiSelectValueChangeOrigin, command.PlainTextInputOrigin:
+		entry.Type = auditlog.InteractionEntryType
+	}
+	switch {
+	case err == nil:
+	case errors.Is(err, errCommandDenied), errors.Is(err, errFeatureDisabled), errors.Is(err, errRateLimited):
+		entry.Outcome = auditlog.DeniedOutcome
+		entry.Reason = err.Error()
+	default:
+		entry.Outcome = auditlog.FailureOutcome
+		entry.Reason = err.Error()
+	}
+
+	e.auditLogger.Record(entry)
+}
+
 // appendByUserOnlyIfNeeded returns the "by Foo" only if the command was executed via button.
 func appendByUserOnlyIfNeeded(cmd, user string, origin command.Origin) string {
 	if user == "" || origin == command.TypedOrigin {
@@ -9,6 +9,7 @@ import (
 
 	"github.com/kubeshop/botkube/internal/analytics"
 	"github.com/kubeshop/botkube/internal/audit"
+	"github.com/kubeshop/botkube/internal/authz"
 	guard "github.com/kubeshop/botkube/internal/command"
 	"github.com/kubeshop/botkube/pkg/bot/interactive"
 	"github.com/kubeshop/botkube/pkg/config"
@@ -35,6 +36,12 @@ type DefaultExecutorFactory struct {
 	cmdsMapping           *CommandMapping
 	auditReporter         audit.AuditReporter
 	pluginHealthStats     *plugin.HealthStats
+	executionTracker      *ExecutionTracker
+	cmdRateLimiter        *CommandRateLimiter
+	cmdAuthorizer         CommandAuthorizer
+	featureFlags          FeatureFlagManager
+	auditLogger           AuditLogger
+	redactor              Redactor
 }
 
 // DefaultExecutorFactoryParams contains input parameters for DefaultExecutorFactory.
@@ -49,6 +56,35 @@ type DefaultExecutorFactoryParams struct {
 	BotKubeVersion    string
 	AuditReporter     audit.AuditReporter
 	PluginHealthStats *plugin.HealthStats
+	CommandAuthorizer CommandAuthorizer
+	// IncidentManager is optional. If not set, incidents cannot be acknowledged.
+	IncidentManager IncidentManager
+	// MaintenanceManager is optional. If not set, maintenance windows cannot be managed.
+	MaintenanceManager MaintenanceManager
+	// FeatureFlags is optional. If not set, feature flags cannot be changed at runtime, and commands gated by them are always allowed.
+	FeatureFlags FeatureFlagManager
+	// CfgLayers are configuration sources the configuration is merged from. They are used to show where configuration values come from.
+	CfgLayers config.Layers
+	// CfgCommit is the SHA of the Git commit the configuration is synced from. It's empty if the Git sync is disabled.
+	CfgCommit string
+	// CfgHistory is optional. If not set, the configuration history is disabled and the configuration cannot be rolled back.
+	CfgHistory ConfigHistoryStore
+	// AuditLogger is optional. If not set, executed commands are not recorded in the audit log, and the audit log cannot be queried.
+	AuditLogger AuditLogger
+	// HealthChecker is optional. If not set, the detailed status of Botkube components is not available.
+	HealthChecker ComponentHealthChecker
+	// Redactor is optional. If not set, sensitive values are not masked in the command output.
+	Redactor Redactor
+	// Doctor is optional. If not set, diagnostics are not available.
+	Doctor Doctor
+	// OverviewCollector is optional. If not set, the cluster overview dashboard is not available.
+	OverviewCollector OverviewCollector
+	// ResourceWatcher is optional. If not set, resources cannot be watched from chat.
+	ResourceWatcher ResourceWatcher
+	// UpgradeChecker is optional. If not set, the pre-upgrade readiness report is not available.
+	UpgradeChecker UpgradeChecker
+	// EventReplayer is optional. If not set, events cannot be replayed.
+	EventReplayer EventReplayer
 }
 
 // Executor is an interface for processes to execute commands
@@ -69,6 +105,11 @@ type CommandGuard interface {
 	FilterSupportedVerbs(allVerbs []string) []string
 }
 
+// CommandAuthorizer is an interface that allows to authorize a given command before it's executed.
+type CommandAuthorizer interface {
+	Authorize(ctx context.Context, in authz.Input) authz.Decision
+}
+
 // NewExecutorFactory creates new DefaultExecutorFactory.
 func NewExecutorFactory(params DefaultExecutorFactoryParams) (*DefaultExecutorFactory, error) {
 	actionExecutor := NewActionExecutor(
@@ -95,10 +136,8 @@ func NewExecutorFactory(params DefaultExecutorFactoryParams) (*DefaultExecutorFa
 	notifierExecutor := NewNotifierExecutor(
 		params.Log.WithField("component", "Notifier Executor"),
 		params.CfgManager,
-	)
-	helpExecutor := NewHelpExecutor(
-		params.Log.WithField("component", "Help Executor"),
-		params.Cfg,
+		params.CfgCommit,
+		params.HealthChecker,
 	)
 	configExecutor := NewConfigExecutor(
 		params.Log.WithField("component", "Config Executor"),
@@ -116,6 +155,117 @@ func NewExecutorFactory(params DefaultExecutorFactoryParams) (*DefaultExecutorFa
 		params.Log.WithField("component", "Alias Executor"),
 		params.Cfg,
 	)
+	runbookExecutor := NewRunbookExecutor(
+		params.Log.WithField("component", "Runbook Executor"),
+		params.Cfg,
+	)
+	executionTracker := NewExecutionTracker(
+		params.Log.WithField("component", "Execution Tracker"),
+		params.Cfg.Settings.Execution,
+	)
+	cancelExecutor := NewCancelExecutor(
+		params.Log.WithField("component", "Cancel Executor"),
+		executionTracker,
+	)
+	incidentExecutor := NewIncidentExecutor(
+		params.Log.WithField("component", "Incident Executor"),
+		params.IncidentManager,
+	)
+	maintenanceExecutor := NewMaintenanceExecutor(
+		params.Log.WithField("component", "Maintenance Executor"),
+		params.MaintenanceManager,
+	)
+	featureFlagExecutor := NewFeatureFlagExecutor(
+		params.Log.WithField("component", "Feature Flag Executor"),
+		params.FeatureFlags,
+	)
+	effectiveConfigExecutor := NewEffectiveConfigExecutor(
+		params.Log.WithField("component", "Effective Config Executor"),
+		params.Cfg,
+	)
+	configOriginExecutor := NewConfigOriginExecutor(
+		params.Log.WithField("component", "Config Origin Executor"),
+		params.CfgLayers,
+	)
+	configEditExecutor := NewConfigEditExecutor(
+		params.Log.WithField("component", "Config Edit Executor"),
+		params.CfgManager,
+		params.CfgHistory,
+		params.Cfg,
+	)
+	configSetupExecutor := NewConfigSetupExecutor(
+		params.Log.WithField("component", "Config Setup Executor"),
+		params.Cfg,
+	)
+	configHistoryExecutor := NewConfigHistoryExecutor(
+		params.Log.WithField("component", "Config History Executor"),
+		params.CfgHistory,
+	)
+	var (
+		pluginUpgrader      PluginUpgrader
+		pluginConfigSchemas PluginConfigSchemaGetter
+		pluginMarketplace   PluginMarketplace
+		pluginDocs          PluginDocsGetter
+	)
+	if params.PluginManager != nil {
+		pluginUpgrader = params.PluginManager
+		pluginConfigSchemas = params.PluginManager
+		pluginMarketplace = params.PluginManager
+		pluginDocs = params.PluginManager
+	}
+	helpExecutor := NewHelpExecutor(
+		params.Log.WithField("component", "Help Executor"),
+		pluginDocs,
+		params.Cfg,
+	)
+	pluginUpgradeExecutor := NewPluginUpgradeExecutor(
+		params.Log.WithField("component", "Plugin Upgrade Executor"),
+		pluginUpgrader,
+	)
+	pluginConfigExecutor := NewPluginConfigExecutor(
+		params.Log.WithField("component", "Plugin Config Executor"),
+		pluginConfigSchemas,
+		params.CfgManager,
+		params.CfgHistory,
+		params.Cfg,
+	)
+	pluginMarketplaceExecutor := NewPluginMarketplaceExecutor(
+		params.Log.WithField("component", "Plugin Marketplace Executor"),
+		pluginMarketplace,
+		params.CfgManager,
+		params.CfgHistory,
+		params.Cfg,
+	)
+	configRollbackExecutor := NewConfigRollbackExecutor(
+		params.Log.WithField("component", "Config Rollback Executor"),
+		params.CfgHistory,
+		params.Cfg,
+	)
+	auditExecutor := NewAuditExecutor(
+		params.Log.WithField("component", "Audit Executor"),
+		params.AuditLogger,
+	)
+	doctorExecutor := NewDoctorExecutor(
+		params.Log.WithField("component", "Doctor Executor"),
+		params.Doctor,
+	)
+	overviewExecutor := NewOverviewExecutor(
+		params.Log.WithField("component", "Overview Executor"),
+		params.OverviewCollector,
+	)
+	watchExecutor := NewWatchExecutor(
+		params.Log.WithField("component", "Watch Executor"),
+		params.ResourceWatcher,
+		executionTracker,
+	)
+	upgradeCheckExecutor := NewUpgradeCheckExecutor(
+		params.Log.WithField("component", "Upgrade Check Executor"),
+		params.UpgradeChecker,
+	)
+	replayExecutor := NewReplayExecutor(
+		params.Log.WithField("component", "Replay Executor"),
+		params.EventReplayer,
+	)
 
 	executors := []CommandExecutor{
 		actionExecutor,
@@ -123,18 +273,40 @@ func NewExecutorFactory(params DefaultExecutorFactoryParams) (*DefaultExecutorFa
 		pingExecutor,
 		versionExecutor,
 		helpExecutor,
+		NewHelpPageExecutor(helpExecutor),
 		feedbackExecutor,
 		notifierExecutor,
 		configExecutor,
 		execExecutor,
 		sourceExecutor,
 		aliasExecutor,
+		runbookExecutor,
+		cancelExecutor,
+		incidentExecutor,
+		NewIncidentListExecutor(incidentExecutor),
+		maintenanceExecutor,
+		featureFlagExecutor,
+		effectiveConfigExecutor,
+		configOriginExecutor,
+		configEditExecutor,
+		configSetupExecutor,
+		configHistoryExecutor,
+		configRollbackExecutor,
+		pluginUpgradeExecutor,
+		pluginConfigExecutor,
+		pluginMarketplaceExecutor,
+		auditExecutor,
+		doctorExecutor,
+		overviewExecutor,
+		watchExecutor,
+		upgradeCheckExecutor,
+		replayExecutor,
 	}
 	mappings, err := NewCmdsMapping(executors)
 	if err != nil {
 		return nil, err
 	}
-	return &DefaultExecutorFactory{
+	factory := &DefaultExecutorFactory{
 		log:               params.Log,
 		cfg:               params.Cfg,
 		analyticsReporter: params.AnalyticsReporter,
@@ -157,7 +329,17 @@ func NewExecutorFactory(params DefaultExecutorFactoryParams) (*DefaultExecutorFa
 		cmdsMapping:           mappings,
 		auditReporter:         params.AuditReporter,
 		pluginHealthStats:     params.PluginHealthStats,
-	}, nil
+		executionTracker:      executionTracker,
+		cmdRateLimiter:        NewCommandRateLimiter(params.Cfg.Settings.Execution.RateLimit),
+		cmdAuthorizer:         params.CommandAuthorizer,
+		featureFlags:          params.FeatureFlags,
+		auditLogger:           params.AuditLogger,
+		redactor:              params.Redactor,
+	}
+	// runbook command steps are executed as regular Botkube commands
+	runbookExecutor.executorFactory = factory
+
+	return factory, nil
 }
 
 // Conversation contains details about the conversation.
@@ -167,6 +349,8 @@ type Conversation struct {
 	ID               string
 	ExecutorBindings []string
 	SourceBindings   []string
+	// ReadOnly is set for channels in which only read-only commands are allowed.
+	ReadOnly         bool
 	IsKnown          bool
 	CommandOrigin    command.Origin
 	SlackState       *slack.BlockActionStates
@@ -190,6 +374,10 @@ type NewDefaultInput struct {
 type UserInput struct {
 	Mention     string
 	DisplayName string
+	// ID is the user identifier on a given communication platform. It's empty if the platform doesn't provide it.
+	ID string
+	// Email is the user email. It's empty if the platform doesn't provide it.
+	Email string
 }
 
 // NewDefault creates new Default Executor.
@@ -212,6 +400,12 @@ func (f *DefaultExecutorFactory) NewDefault(cfg NewDefaultInput) Executor {
 		cmdsMapping:           f.cmdsMapping,
 		auditReporter:         f.auditReporter,
 		pluginHealthStats:     f.pluginHealthStats,
+		executionTracker:      f.executionTracker,
+		cmdRateLimiter:        f.cmdRateLimiter,
+		cmdAuthorizer:         f.cmdAuthorizer,
+		featureFlags:          f.featureFlags,
+		auditLogger:           f.auditLogger,
+		redactor:              f.redactor,
 		user:                  cfg.User,
 		notifierHandler:       cfg.NotifierHandler,
 		conversation:          cfg.Conversation,
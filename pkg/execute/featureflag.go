@@ -0,0 +1,161 @@
+package execute
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/pflag"
+
+	"github.com/kubeshop/botkube/internal/featureflag"
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/execute/command"
+)
+
+const (
+	featureFlagsNotEnabled   = "Feature flags are not enabled."
+	featureFlagMissing       = "You need to specify the feature flag, e.g. `%s %s feature %s`."
+	featureFlagUnknown       = "Unknown feature flag %q. Use one of: %s."
+	featureFlagChanged       = "%s %s the %q feature %s. The change is kept until Botkube restarts; to make it permanent, update the configuration."
+	featureFlagDisabledMsg   = "The %q feature is disabled for this channel. Use `%s enable feature %s` to enable it."
+	featureFlagScopeGlobal   = "globally"
+	featureFlagScopeChannel  = "for this channel"
+	featureFlagGlobalFlagArg = "global"
+)
+
+var featureFlagFeatureName = FeatureName{
+	Name:    "feature",
+	Aliases: []string{"features", "flag", "flags"},
+}
+
+// FeatureFlagManager manages states of feature flags.
+type FeatureFlagManager interface {
+	Enabled(flag featureflag.Flag, channelAlias string) bool
+	Set(flag featureflag.Flag, channelAlias string, enabled bool)
+	Statuses(channelAlias string) []featureflag.Status
+}
+
+// FeatureFlagExecutor executes all commands that are related to feature flags.
+type FeatureFlagExecutor struct {
+	log     logrus.FieldLogger
+	manager FeatureFlagManager
+}
+
+// NewFeatureFlagExecutor returns a new FeatureFlagExecutor instance.
+func NewFeatureFlagExecutor(log logrus.FieldLogger, manager FeatureFlagManager) *FeatureFlagExecutor {
+	return &FeatureFlagExecutor{
+		log:     log,
+		manager: manager,
+	}
+}
+
+// FeatureName returns the name and aliases of the feature provided by this executor
+func (e *FeatureFlagExecutor) FeatureName() FeatureName {
+	return featureFlagFeatureName
+}
+
+// Commands returns slice of commands the executor supports
+func (e *FeatureFlagExecutor) Commands() map[command.Verb]CommandFn {
+	return map[command.Verb]CommandFn{
+		command.EnableVerb:  e.Enable,
+		command.DisableVerb: e.Disable,
+		command.ListVerb:    e.List,
+		command.StatusVerb:  e.List,
+	}
+}
+
+// Enable enables a given feature flag for the channel, or globally if the `--global` flag is set.
+func (e *FeatureFlagExecutor) Enable(_ context.Context, cmdCtx CommandContext) (interactive.CoreMessage, error) {
+	return e.set(cmdCtx, true), nil
+}
+
+// Disable disables a given feature flag for the channel, or globally if the `--global` flag is set.
+func (e *FeatureFlagExecutor) Disable(_ context.Context, cmdCtx CommandContext) (interactive.CoreMessage, error) {
+	return e.set(cmdCtx, false), nil
+}
+
+// List returns states of all feature flags for the channel, or global states if the `--global` flag is set.
+func (e *FeatureFlagExecutor) List(_ context.Context, cmdCtx CommandContext) (interactive.CoreMessage, error) {
+	if e.manager == nil {
+		return respond(featureFlagsNotEnabled, cmdCtx), nil
+	}
+
+	_, global, err := parseFeatureFlagArgs(cmdCtx.Args)
+	if err != nil {
+		return respond(fmt.Sprintf("Cannot parse command: %s", err.Error()), cmdCtx), nil
+	}
+
+	alias := cmdCtx.Conversation.Alias
+	if global {
+		alias = ""
+	}
+	return respond(featureFlagTabularOutput(e.manager.Statuses(alias)), cmdCtx), nil
+}
+
+func (e *FeatureFlagExecutor) set(cmdCtx CommandContext, enabled bool) interactive.CoreMessage {
+	if e.manager == nil {
+		return respond(featureFlagsNotEnabled, cmdCtx)
+	}
+
+	verb, _ := parseCmdVerb(cmdCtx.Args)
+	args, global, err := parseFeatureFlagArgs(cmdCtx.Args)
+	if err != nil {
+		return respond(fmt.Sprintf("Cannot parse command: %s", err.Error()), cmdCtx)
+	}
+	if len(args) == 0 {
+		return respond(fmt.Sprintf(featureFlagMissing, api.MessageBotNamePlaceholder, verb, featureflag.AIExecutor), cmdCtx)
+	}
+	def, found := featureflag.Lookup(args[0])
+	if !found {
+		return respond(fmt.Sprintf(featureFlagUnknown, args[0], quoteJoin(featureflag.Names())), cmdCtx)
+	}
+
+	alias, scope := cmdCtx.Conversation.Alias, featureFlagScopeChannel
+	if global {
+		alias, scope = "", featureFlagScopeGlobal
+	}
+	e.manager.Set(def.Name, alias, enabled)
+	e.log.WithFields(logrus.Fields{
+		"flag":    def.Name,
+		"channel": alias,
+		"enabled": enabled,
+	}).Infof("Feature flag changed by %s", cmdCtx.User.DisplayName)
+
+	return respond(fmt.Sprintf(featureFlagChanged, cmdCtx.User.Mention, verb+"d", def.Name, scope), cmdCtx)
+}
+
+// parseFeatureFlagArgs returns arguments following the `{verb} feature` command and the `--global` flag.
+func parseFeatureFlagArgs(args []string) ([]string, bool, error) {
+	var global bool
+	flags := pflag.NewFlagSet("feature", pflag.ContinueOnError)
+	flags.BoolVar(&global, featureFlagGlobalFlagArg, false, "Apply globally")
+
+	var rest []string
+	if len(args) > 2 {
+		rest = args[2:]
+	}
+	if err := flags.Parse(rest); err != nil {
+		return nil, false, err
+	}
+	return flags.Args(), global, nil
+}
+
+func featureFlagTabularOutput(statuses []featureflag.Status) string {
+	buf := new(bytes.Buffer)
+	w := tabwriter.NewWriter(buf, 5, 0, 1, ' ', 0)
+	fmt.Fprintf(w, "FLAG\tENABLED\tORIGIN\tDESCRIPTION")
+	for _, status := range statuses {
+		fmt.Fprintf(w, "\n%s\t%t\t%s\t%s", status.Name, status.Enabled, status.Origin, status.Description)
+	}
+
+	w.Flush()
+	return buf.String()
+}
+
+// featureDisabledMessage returns the message which is sent when a command gated by a disabled feature flag is executed.
+func featureDisabledMessage(flag featureflag.Flag) string {
+	return fmt.Sprintf(featureFlagDisabledMsg, flag, api.MessageBotNamePlaceholder, flag)
+}
@@ -0,0 +1,100 @@
+package execute
+
+import (
+	"context"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/internal/featureflag"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+func TestFeatureFlagExecutor(t *testing.T) {
+	// given
+	manager := featureflag.NewManager(config.Config{
+		Settings: config.Settings{
+			FeatureFlags: map[string]bool{"aggregation": false},
+		},
+	})
+	executor := NewFeatureFlagExecutor(loggerx.NewNoop(), manager)
+	cmdCtx := CommandContext{
+		User:           UserInput{Mention: "@Joe"},
+		Conversation:   Conversation{Alias: "dev"},
+		ExecutorFilter: newExecutorTextFilter(""),
+	}
+
+	// when: disable for the channel
+	cmdCtx.Args = []string{"disable", "feature", "AIExecutor"}
+	msg, err := executor.Disable(context.Background(), cmdCtx)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, `@Joe disabled the "aiExecutor" feature for this channel. The change is kept until Botkube restarts; to make it permanent, update the configuration.`, msg.BaseBody.CodeBlock)
+	assert.False(t, manager.Enabled(featureflag.AIExecutor, "dev"))
+	assert.True(t, manager.Enabled(featureflag.AIExecutor, "prod"))
+
+	// when: enable globally
+	cmdCtx.Args = []string{"enable", "feature", "aggregation", "--global"}
+	msg, err = executor.Enable(context.Background(), cmdCtx)
+
+	// then
+	require.NoError(t, err)
+	assert.Contains(t, msg.BaseBody.CodeBlock, `@Joe enabled the "aggregation" feature globally.`)
+	assert.True(t, manager.Enabled(featureflag.Aggregation, "prod"))
+
+	// when: list
+	cmdCtx.Args = []string{"list", "features"}
+	msg, err = executor.List(context.Background(), cmdCtx)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, heredoc.Doc(`
+		FLAG        ENABLED ORIGIN  DESCRIPTION
+		aggregation true    global  Collect notifications during the aggregation window and send them as a single message
+		aiExecutor  false   channel Run commands of the AI executor plugin`), msg.BaseBody.CodeBlock)
+}
+
+func TestFeatureFlagExecutorInvalidInput(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		expectedMsg string
+	}{
+		{
+			name:        "missing flag",
+			args:        []string{"enable", "feature"},
+			expectedMsg: "You need to specify the feature flag, e.g. `{{BotName}} enable feature aiExecutor`.",
+		},
+		{
+			name:        "unknown flag",
+			args:        []string{"enable", "feature", "renderer"},
+			expectedMsg: `Unknown feature flag "renderer". Use one of: "aggregation", "aiExecutor".`,
+		},
+		{
+			name:        "unknown option",
+			args:        []string{"disable", "feature", "aggregation", "--all"},
+			expectedMsg: "Cannot parse command: unknown flag: --all",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			executor := NewFeatureFlagExecutor(loggerx.NewNoop(), featureflag.NewManager(config.Config{}))
+			cmdCtx := CommandContext{
+				Args:           tc.args,
+				ExecutorFilter: newExecutorTextFilter(""),
+			}
+
+			// when
+			msg, err := executor.Enable(context.Background(), cmdCtx)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedMsg, msg.BaseBody.CodeBlock)
+		})
+	}
+}
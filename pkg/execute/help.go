@@ -2,6 +2,7 @@ package execute
 
 import (
 	"context"
+	"strconv"
 
 	"github.com/sirupsen/logrus"
 
@@ -12,22 +13,31 @@ import (
 )
 
 var (
-	helpFeatureName = FeatureName{Name: noFeature}
+	helpFeatureName     = FeatureName{Name: noFeature}
+	helpPageFeatureName = FeatureName{Name: "page"}
 )
 
+// PluginDocsGetter returns documentation of running plugins.
+type PluginDocsGetter interface {
+	Docs(ctx context.Context, pluginType plugin.Type, pluginKey string) (plugin.Docs, error)
+}
+
 // HelpExecutor executes all commands that are related to help
 type HelpExecutor struct {
 	log                    logrus.FieldLogger
+	docs                   PluginDocsGetter
 	enabledPluginExecutors []string
 }
 
-// NewHelpExecutor returns a new HelpExecutor instance
-func NewHelpExecutor(log logrus.FieldLogger, cfg config.Config) *HelpExecutor {
+// NewHelpExecutor returns a new HelpExecutor instance. The docs is nil if plugins are not enabled.
+// In such case, plugins are described only by their names.
+func NewHelpExecutor(log logrus.FieldLogger, docs PluginDocsGetter, cfg config.Config) *HelpExecutor {
 	collector := plugin.NewCollector(log)
 	enabledPluginExecutors, _ := collector.GetAllEnabledAndUsedPlugins(&cfg)
 
 	return &HelpExecutor{
 		log:                    log,
+		docs:                   docs,
 		enabledPluginExecutors: enabledPluginExecutors,
 	}
 }
@@ -44,7 +54,75 @@ func (e *HelpExecutor) Commands() map[command.Verb]CommandFn {
 	}
 }
 
-// Help returns new help message
-func (e *HelpExecutor) Help(_ context.Context, cmdCtx CommandContext) (interactive.CoreMessage, error) {
-	return interactive.NewHelpMessage(cmdCtx.Platform, cmdCtx.ClusterName, e.enabledPluginExecutors).Build(false), nil
+// Help returns the first page of the help message.
+func (e *HelpExecutor) Help(ctx context.Context, cmdCtx CommandContext) (interactive.CoreMessage, error) {
+	return e.page(ctx, cmdCtx, 1), nil
+}
+
+// HelpPage returns a given page of the help message, e.g. `help page 2`.
+func (e *HelpExecutor) HelpPage(ctx context.Context, cmdCtx CommandContext) (interactive.CoreMessage, error) {
+	if len(cmdCtx.Args) != 3 {
+		return interactive.CoreMessage{}, errInvalidCommand
+	}
+
+	page, err := strconv.Atoi(cmdCtx.Args[2])
+	if err != nil || page < 1 {
+		return interactive.CoreMessage{}, NewExecutionCommandError("Page %q is invalid. It must be a positive number.", cmdCtx.Args[2])
+	}
+	return e.page(ctx, cmdCtx, page), nil
+}
+
+func (e *HelpExecutor) page(ctx context.Context, cmdCtx CommandContext, page int) interactive.CoreMessage {
+	msg := interactive.NewHelpMessage(cmdCtx.Platform, cmdCtx.ClusterName, e.enabledPluginExecutors).
+		WithPluginHelp(e.pluginHelp(ctx)).
+		BuildPage(page)
+	// navigating between pages replaces the message, so the channel isn't flooded with help messages
+	msg.ReplaceOriginal = cmdCtx.Conversation.CommandOrigin == command.ButtonClickOrigin && cmdCtx.Platform.IsInteractive()
+	return msg
+}
+
+// pluginHelp returns documentation of enabled executor plugins. Plugins which documentation cannot be fetched are skipped.
+func (e *HelpExecutor) pluginHelp(ctx context.Context) []interactive.PluginHelp {
+	if e.docs == nil {
+		return nil
+	}
+
+	var out []interactive.PluginHelp
+	for _, name := range e.enabledPluginExecutors {
+		docs, err := e.docs.Docs(ctx, plugin.TypeExecutor, name)
+		if err != nil {
+			e.log.WithError(err).WithField("plugin", name).Debug("Cannot get plugin documentation")
+			continue
+		}
+		out = append(out, interactive.PluginHelp{
+			Name:             name,
+			Version:          docs.Version,
+			Description:      docs.Description,
+			DocumentationURL: docs.DocumentationURL,
+			ConfigSchema:     docs.JSONSchema,
+		})
+	}
+	return out
+}
+
+// HelpPageExecutor executes the help command for a given page.
+type HelpPageExecutor struct {
+	*HelpExecutor
+}
+
+// NewHelpPageExecutor returns a new HelpPageExecutor instance.
+func NewHelpPageExecutor(help *HelpExecutor) *HelpPageExecutor {
+	return &HelpPageExecutor{HelpExecutor: help}
+}
+
+// FeatureName returns the name and aliases of the feature provided by this executor
+func (e *HelpPageExecutor) FeatureName() FeatureName {
+	return helpPageFeatureName
+}
+
+// Commands returns slice of commands the executor supports
+func (e *HelpPageExecutor) Commands() map[command.Verb]CommandFn {
+	return map[command.Verb]CommandFn{
+		command.HelpVerb: e.HelpPage,
+	}
 }
@@ -0,0 +1,94 @@
+package execute
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/execute/command"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+	"github.com/kubeshop/botkube/pkg/plugin"
+)
+
+type fakePluginDocsGetter map[string]plugin.Docs
+
+func (f fakePluginDocsGetter) Docs(_ context.Context, _ plugin.Type, pluginKey string) (plugin.Docs, error) {
+	docs, found := f[pluginKey]
+	if !found {
+		return plugin.Docs{}, errors.New("not found")
+	}
+	return docs, nil
+}
+
+func TestHelpExecutor(t *testing.T) {
+	// given
+	cfg := config.Config{
+		Executors: map[string]config.Executors{
+			"tools": {
+				Plugins: config.Plugins{
+					"botkube/helm":    {Enabled: true},
+					"botkube/kubectl": {Enabled: true},
+				},
+			},
+		},
+		Communications: map[string]config.Communications{
+			"default": {
+				SocketSlack: config.SocketSlack{
+					Enabled: true,
+					Channels: config.IdentifiableMap[config.ChannelBindingsByName]{
+						"alias": {Name: "botkube", Bindings: config.BotBindings{Executors: []string{"tools"}}},
+					},
+				},
+			},
+		},
+	}
+	docs := fakePluginDocsGetter{
+		"botkube/helm": {
+			Version:     "v1.0.0",
+			Description: "Run Helm commands.",
+			JSONSchema:  []byte(`{"properties": {"helmDriver": {"type": "string"}}}`),
+		},
+	}
+	e := NewHelpExecutor(loggerx.NewNoop(), docs, cfg)
+	cmdCtx := CommandContext{
+		Args:         strings.Fields("help page 2"),
+		Platform:     config.SocketSlackCommPlatformIntegration,
+		Conversation: Conversation{CommandOrigin: command.ButtonClickOrigin},
+	}
+
+	// when
+	msg, err := e.HelpPage(context.Background(), cmdCtx)
+
+	// then
+	require.NoError(t, err)
+	assert.True(t, msg.ReplaceOriginal)
+	assert.Equal(t, "🔌 botkube/helm v1.0.0", msg.Sections[0].Header)
+	assert.Equal(t, api.BulletLists{{Title: "Configuration options", Items: []string{"`helmDriver` (string)"}}}, msg.Sections[0].BulletLists)
+	assert.Equal(t, "🔌 botkube/kubectl", msg.Sections[1].Header)
+
+	// when
+	msg, err = e.Help(context.Background(), CommandContext{Args: []string{"help"}, Platform: config.SocketSlackCommPlatformIntegration})
+
+	// then
+	require.NoError(t, err)
+	assert.False(t, msg.ReplaceOriginal)
+	assert.Equal(t, "🛠️ Basic commands", msg.Sections[0].Header)
+
+	// when
+	_, err = e.HelpPage(context.Background(), CommandContext{Args: strings.Fields("help page first")})
+
+	// then
+	assert.EqualError(t, err, `Page "first" is invalid. It must be a positive number.`)
+
+	// when
+	_, err = e.HelpPage(context.Background(), CommandContext{Args: strings.Fields("help page")})
+
+	// then
+	assert.ErrorIs(t, err, errInvalidCommand)
+}
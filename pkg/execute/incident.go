@@ -0,0 +1,169 @@
+package execute
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/internal/escalation"
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/execute/command"
+)
+
+const (
+	ackIncidentIDMissing    = "You need to specify the incident ID, e.g. `%s ack incident 5f2b8c0e9d41a7c3`. Run `%s incidents` to list them."
+	ackIncidentNotFound     = "Incident %q is not tracked. It may have already expired."
+	ackIncidentAcknowledged = "Incident %s (%s) acknowledged by %s."
+	ackIncidentAlready      = "Incident %s (%s) was already acknowledged by %s at %s."
+	incidentsNotEnabled     = "Incidents are not enabled."
+	noIncidents             = "There are no tracked incidents in this channel."
+
+	incidentTimeFormat = "2006-01-02 15:04:05 MST"
+)
+
+var (
+	incidentFeatureName = FeatureName{
+		Name:    "incident",
+		Aliases: []string{"incidents", "inc"},
+	}
+	incidentListFeatureName = FeatureName{Name: noFeature}
+)
+
+// IncidentManager manages acknowledgeable notifications. Incidents are scoped to source bindings they were sent to.
+type IncidentManager interface {
+	Acknowledge(id string, sources []string, user string) (escalation.Incident, error)
+	List(sources []string) []escalation.Incident
+}
+
+// IncidentExecutor executes all commands that are related to incidents.
+type IncidentExecutor struct {
+	log     logrus.FieldLogger
+	manager IncidentManager
+}
+
+// NewIncidentExecutor returns a new IncidentExecutor instance.
+func NewIncidentExecutor(log logrus.FieldLogger, manager IncidentManager) *IncidentExecutor {
+	return &IncidentExecutor{
+		log:     log,
+		manager: manager,
+	}
+}
+
+// FeatureName returns the name and aliases of the feature provided by this executor
+func (e *IncidentExecutor) FeatureName() FeatureName {
+	return incidentFeatureName
+}
+
+// Commands returns slice of commands the executor supports
+func (e *IncidentExecutor) Commands() map[command.Verb]CommandFn {
+	return map[command.Verb]CommandFn{
+		command.AckVerb:  e.Ack,
+		command.ListVerb: e.List,
+	}
+}
+
+// Ack acknowledges a given incident, so it's not escalated anymore and further notifications about the same resource are suppressed.
+// If the command was triggered by the Acknowledge button, the original notification is updated.
+func (e *IncidentExecutor) Ack(_ context.Context, cmdCtx CommandContext) (interactive.CoreMessage, error) {
+	if e.manager == nil {
+		return respond(incidentsNotEnabled, cmdCtx), nil
+	}
+	if len(cmdCtx.Args) < 3 {
+		return respond(fmt.Sprintf(ackIncidentIDMissing, api.MessageBotNamePlaceholder, api.MessageBotNamePlaceholder), cmdCtx), nil
+	}
+
+	id := cmdCtx.Args[2]
+	// only incidents sent to this channel can be acknowledged here
+	inc, err := e.manager.Acknowledge(id, cmdCtx.Conversation.SourceBindings, cmdCtx.User.Mention)
+	switch {
+	case err == nil:
+	case errors.Is(err, escalation.ErrIncidentNotFound):
+		return respond(fmt.Sprintf(ackIncidentNotFound, id), cmdCtx), nil
+	case errors.Is(err, escalation.ErrIncidentAlreadyAcknowledged):
+		return respond(fmt.Sprintf(ackIncidentAlready, id, inc.Title, inc.AcknowledgedBy, inc.AcknowledgedAt.UTC().Format(incidentTimeFormat)), cmdCtx), nil
+	default:
+		return interactive.CoreMessage{}, fmt.Errorf("while acknowledging incident %q: %w", id, err)
+	}
+
+	e.log.WithField("id", id).Infof("Incident acknowledged by %s", cmdCtx.User.DisplayName)
+	if cmdCtx.Conversation.CommandOrigin != command.ButtonClickOrigin || !cmdCtx.Platform.IsInteractive() {
+		return respond(fmt.Sprintf(ackIncidentAcknowledged, id, inc.Title, cmdCtx.User.Mention), cmdCtx), nil
+	}
+
+	// the stored message doesn't contain the acknowledgement instructions, so they are removed from the updated notification
+	msg := inc.Message
+	msg.Sections = append(append([]api.Section(nil), msg.Sections...), api.Section{
+		Context: api.ContextItems{
+			{Text: fmt.Sprintf(":white_check_mark: Incident %s acknowledged by %s at %s.", id, inc.AcknowledgedBy, inc.AcknowledgedAt.UTC().Format(incidentTimeFormat))},
+		},
+	})
+	msg.ReplaceOriginal = true
+	return interactive.CoreMessage{
+		Header:  inc.Header,
+		Message: msg,
+	}, nil
+}
+
+// List returns a tabular representation of incidents tracked in a given channel.
+func (e *IncidentExecutor) List(_ context.Context, cmdCtx CommandContext) (interactive.CoreMessage, error) {
+	if e.manager == nil {
+		return respond(incidentsNotEnabled, cmdCtx), nil
+	}
+
+	incidents := e.manager.List(cmdCtx.Conversation.SourceBindings)
+	if len(incidents) == 0 {
+		return respond(noIncidents, cmdCtx), nil
+	}
+	return respond(incidentsTabularOutput(incidents, time.Now()), cmdCtx), nil
+}
+
+// IncidentListExecutor executes the `incidents` command, which is a shorthand for `list incidents`.
+type IncidentListExecutor struct {
+	*IncidentExecutor
+}
+
+// NewIncidentListExecutor returns a new IncidentListExecutor instance.
+func NewIncidentListExecutor(incidents *IncidentExecutor) *IncidentListExecutor {
+	return &IncidentListExecutor{IncidentExecutor: incidents}
+}
+
+// FeatureName returns the name and aliases of the feature provided by this executor
+func (e *IncidentListExecutor) FeatureName() FeatureName {
+	return incidentListFeatureName
+}
+
+// Commands returns slice of commands the executor supports
+func (e *IncidentListExecutor) Commands() map[command.Verb]CommandFn {
+	return map[command.Verb]CommandFn{
+		command.IncidentsVerb: e.List,
+	}
+}
+
+func incidentsTabularOutput(incidents []escalation.Incident, now time.Time) string {
+	buf := new(bytes.Buffer)
+	w := tabwriter.NewWriter(buf, 5, 0, 1, ' ', 0)
+	fmt.Fprintf(w, "ID\tTITLE\tSTATUS\tAGE")
+	for _, inc := range incidents {
+		fmt.Fprintf(w, "\n%s\t%s\t%s\t%s", inc.ID, inc.Title, incidentStatus(inc), now.Sub(inc.CreatedAt).Round(time.Second))
+	}
+
+	w.Flush()
+	return buf.String()
+}
+
+func incidentStatus(inc escalation.Incident) string {
+	switch {
+	case inc.IsAcknowledged():
+		return fmt.Sprintf("acknowledged by %s at %s", inc.AcknowledgedBy, inc.AcknowledgedAt.UTC().Format(incidentTimeFormat))
+	case inc.Escalations > 0:
+		return fmt.Sprintf("escalated %d/%d", inc.Escalations, inc.EscalationSteps)
+	default:
+		return "open"
+	}
+}
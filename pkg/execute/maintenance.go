@@ -0,0 +1,181 @@
+package execute
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/pflag"
+
+	"github.com/kubeshop/botkube/internal/maintenance"
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/execute/command"
+)
+
+const (
+	maintenanceNotEnabled       = "Maintenance windows are not enabled."
+	maintenanceDurationMissing  = "You need to specify the duration of the maintenance window, e.g. `%s maintenance start 2h`."
+	maintenanceInvalidDuration  = "Invalid duration %q. Use a positive duration, e.g. `30m` or `2h`."
+	maintenanceInvalidMode      = "Invalid mode %q. Use either `suppress` or `digest`."
+	maintenanceNoSourceBindings = "There are no source bindings configured for this channel, so there are no notifications to hold back."
+	maintenanceStarted          = "Maintenance window %q started by %s. Notifications from %s source bindings are %s until %s."
+	maintenanceNotStarted       = "There is no maintenance window started for this channel."
+	maintenanceStopped          = "Stopped maintenance window(s): %s."
+	noMaintenanceWindows        = "There are no active maintenance windows."
+	maintenanceUnknownCmd       = "Unknown maintenance command. Use `%s maintenance start {duration}`, `%s maintenance stop` or `%s maintenance status`."
+
+	maintenanceStartFeature  = "start"
+	maintenanceStopFeature   = "stop"
+	maintenanceStatusFeature = "status"
+)
+
+var maintenanceFeatureName = FeatureName{
+	Name:    maintenanceStartFeature,
+	Aliases: []string{maintenanceStopFeature, maintenanceStatusFeature, noFeature},
+}
+
+// MaintenanceManager manages maintenance windows during which notifications are held back.
+type MaintenanceManager interface {
+	Start(duration time.Duration, sources []string, mode config.MaintenanceMode, user string) maintenance.Window
+	Stop(sources []string) []maintenance.Window
+	Active() []maintenance.Window
+}
+
+// MaintenanceExecutor executes all commands that are related to maintenance windows.
+type MaintenanceExecutor struct {
+	log     logrus.FieldLogger
+	manager MaintenanceManager
+}
+
+// NewMaintenanceExecutor returns a new MaintenanceExecutor instance.
+func NewMaintenanceExecutor(log logrus.FieldLogger, manager MaintenanceManager) *MaintenanceExecutor {
+	return &MaintenanceExecutor{
+		log:     log,
+		manager: manager,
+	}
+}
+
+// FeatureName returns the name and aliases of the feature provided by this executor
+func (e *MaintenanceExecutor) FeatureName() FeatureName {
+	return maintenanceFeatureName
+}
+
+// Commands returns slice of commands the executor supports
+func (e *MaintenanceExecutor) Commands() map[command.Verb]CommandFn {
+	return map[command.Verb]CommandFn{
+		command.MaintenanceVerb: e.Maintenance,
+	}
+}
+
+// Maintenance starts, stops or prints the status of maintenance windows.
+func (e *MaintenanceExecutor) Maintenance(_ context.Context, cmdCtx CommandContext) (interactive.CoreMessage, error) {
+	if e.manager == nil {
+		return respond(maintenanceNotEnabled, cmdCtx), nil
+	}
+
+	var feature string
+	if len(cmdCtx.Args) > 1 {
+		feature = strings.ToLower(cmdCtx.Args[1])
+	}
+
+	switch feature {
+	case maintenanceStartFeature:
+		return e.start(cmdCtx), nil
+	case maintenanceStopFeature:
+		return e.stop(cmdCtx), nil
+	case maintenanceStatusFeature, noFeature:
+		return e.status(cmdCtx), nil
+	default:
+		botName := api.MessageBotNamePlaceholder
+		return respond(fmt.Sprintf(maintenanceUnknownCmd, botName, botName, botName), cmdCtx), nil
+	}
+}
+
+func (e *MaintenanceExecutor) start(cmdCtx CommandContext) interactive.CoreMessage {
+	var mode string
+	flags := pflag.NewFlagSet("maintenance", pflag.ContinueOnError)
+	flags.StringVar(&mode, "mode", string(config.MaintenanceModeSuppress), "Maintenance mode")
+	if err := flags.Parse(cmdCtx.Args[2:]); err != nil {
+		return respond(fmt.Sprintf("Cannot parse command: %s", err.Error()), cmdCtx)
+	}
+
+	args := flags.Args()
+	if len(args) == 0 {
+		return respond(fmt.Sprintf(maintenanceDurationMissing, api.MessageBotNamePlaceholder), cmdCtx)
+	}
+	duration, err := time.ParseDuration(args[0])
+	if err != nil || duration <= 0 {
+		return respond(fmt.Sprintf(maintenanceInvalidDuration, args[0]), cmdCtx)
+	}
+
+	maintenanceMode := config.MaintenanceMode(strings.ToLower(mode))
+	if maintenanceMode != config.MaintenanceModeSuppress && maintenanceMode != config.MaintenanceModeDigest {
+		return respond(fmt.Sprintf(maintenanceInvalidMode, mode), cmdCtx)
+	}
+
+	sources := cmdCtx.Conversation.SourceBindings
+	if len(sources) == 0 {
+		return respond(maintenanceNoSourceBindings, cmdCtx)
+	}
+
+	w := e.manager.Start(duration, sources, maintenanceMode, cmdCtx.User.Mention)
+	e.log.WithField("window", w.Name).Infof("Maintenance window started by %s", cmdCtx.User.DisplayName)
+
+	action := "suppressed"
+	if maintenanceMode == config.MaintenanceModeDigest {
+		action = "collected into a digest"
+	}
+	return respond(fmt.Sprintf(maintenanceStarted, w.Name, cmdCtx.User.Mention, quoteJoin(sources), action, w.EndsAt.UTC().Format(incidentTimeFormat)), cmdCtx)
+}
+
+func (e *MaintenanceExecutor) stop(cmdCtx CommandContext) interactive.CoreMessage {
+	stopped := e.manager.Stop(cmdCtx.Conversation.SourceBindings)
+	if len(stopped) == 0 {
+		return respond(maintenanceNotStarted, cmdCtx)
+	}
+
+	names := make([]string, 0, len(stopped))
+	for _, w := range stopped {
+		names = append(names, w.Name)
+	}
+	e.log.Infof("Maintenance windows %v stopped by %s", names, cmdCtx.User.DisplayName)
+	return respond(fmt.Sprintf(maintenanceStopped, quoteJoin(names)), cmdCtx)
+}
+
+func (e *MaintenanceExecutor) status(cmdCtx CommandContext) interactive.CoreMessage {
+	windows := e.manager.Active()
+	if len(windows) == 0 {
+		return respond(noMaintenanceWindows, cmdCtx)
+	}
+	return respond(maintenanceTabularOutput(windows), cmdCtx)
+}
+
+func maintenanceTabularOutput(windows []maintenance.Window) string {
+	buf := new(bytes.Buffer)
+	w := tabwriter.NewWriter(buf, 5, 0, 1, ' ', 0)
+	fmt.Fprintf(w, "NAME\tMODE\tSOURCES\tENDS")
+	for _, window := range windows {
+		sources := "all"
+		if len(window.Sources) > 0 {
+			sources = strings.Join(window.Sources, ",")
+		}
+		fmt.Fprintf(w, "\n%s\t%s\t%s\t%s", window.Name, window.Mode, sources, window.EndsAt.UTC().Format(incidentTimeFormat))
+	}
+
+	w.Flush()
+	return buf.String()
+}
+
+func quoteJoin(items []string) string {
+	quoted := make([]string, 0, len(items))
+	for _, item := range items {
+		quoted = append(quoted, fmt.Sprintf("%q", item))
+	}
+	return strings.Join(quoted, ", ")
+}
@@ -69,12 +69,15 @@ func (cmdCtx CommandContext) ProvidedClusterNameEqualOrEmpty() bool {
 type FeatureName struct {
 	Name    string
 	Aliases []string
+	// Positional is true if the command without features takes positional arguments directly after the verb, e.g. `upgrade-check 1.31`.
+	Positional bool
 }
 
 // CommandMapping allows to register and lookup commands and dynamically build help messages
 type CommandMapping struct {
-	commands map[command.Verb]map[string]CommandFn
-	help     map[command.Verb][]FeatureName
+	commands   map[command.Verb]map[string]CommandFn
+	help       map[command.Verb][]FeatureName
+	positional map[command.Verb]bool
 }
 
 // NewCmdsMapping registers command and help mappings
@@ -82,6 +85,7 @@ func NewCmdsMapping(executors []CommandExecutor) (*CommandMapping, error) {
 	mappingsErrs := multierror.New()
 	cmdsMapping := make(map[command.Verb]map[string]CommandFn)
 	helpMapping := make(map[command.Verb][]FeatureName)
+	positional := make(map[command.Verb]bool)
 	for _, executor := range executors {
 		cmds := executor.Commands()
 		subCmd := executor.FeatureName()
@@ -94,6 +98,9 @@ func NewCmdsMapping(executors []CommandExecutor) (*CommandMapping, error) {
 			}
 			cmdsMapping[verb][subCmd.Name] = cmdFn
 			helpMapping[verb] = append(helpMapping[verb], subCmd)
+			if subCmd.Name == noFeature && subCmd.Positional {
+				positional[verb] = true
+			}
 			for _, featureName := range subCmd.Aliases {
 				if _, ok := cmdsMapping[verb][featureName]; ok {
 					mappingsErrs = multierror.Append(mappingsErrs, fmt.Errorf("command collision: tried to register '%s %s', but it already exists", verb, featureName))
@@ -106,18 +113,23 @@ func NewCmdsMapping(executors []CommandExecutor) (*CommandMapping, error) {
 		return nil, err
 	}
 	return &CommandMapping{
-		commands: cmdsMapping,
-		help:     helpMapping,
+		commands:   cmdsMapping,
+		help:       helpMapping,
+		positional: positional,
 	}, nil
 }
 
-// FindFn looks up CommandFn by verb and feature
+// FindFn looks up CommandFn by verb and feature. Unknown features of commands which take positional arguments
+// are treated as arguments of the command without features.
 func (m *CommandMapping) FindFn(verb command.Verb, feature string) (CommandFn, bool, bool) {
 	features, ok := m.commands[verb]
 	if !ok {
 		return nil, false, false
 	}
 	fn, ok := features[feature]
+	if !ok && m.positional[verb] {
+		fn, ok = features[noFeature]
+	}
 	if !ok {
 		return nil, true, false
 	}
@@ -1,12 +1,17 @@
 package execute
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"strings"
+	"text/tabwriter"
 
 	"github.com/sirupsen/logrus"
+	"github.com/spf13/pflag"
 
+	"github.com/kubeshop/botkube/internal/health"
 	"github.com/kubeshop/botkube/pkg/bot/interactive"
 	"github.com/kubeshop/botkube/pkg/config"
 	"github.com/kubeshop/botkube/pkg/execute/command"
@@ -16,7 +21,10 @@ const (
 	notifierStartMsgFmt                = "Brace yourselves, incoming notifications from cluster '%s'."
 	notifierStopMsgFmt                 = "Sure! I won't send you notifications from cluster '%s' here."
 	notifierStatusMsgFmt               = "Notifications from cluster '%s' are %s here."
+	notifierCfgCommitMsgFmt            = "Configuration is synced from Git commit `%s`."
 	notifierNotConfiguredMsgFmt        = "I'm not configured to send notifications here ('%s') from cluster '%s', so you cannot turn them on or off."
+	notifierDetailedStatusNotAvailable = "Detailed status of Botkube components is not available."
+	notifierComponentIndent            = "  "
 	notifierPersistenceNotSupportedFmt = "Platform %q doesn't support persistence for notifications. When Botkube Pod restarts, default notification settings will be applied for this platform."
 )
 
@@ -36,6 +44,20 @@ type NotifierHandler interface {
 	SetNotificationsEnabled(conversationID string, enabled bool) error
 }
 
+// InProgressMessageSender is implemented by bots that can send a message while the command is still being executed.
+type InProgressMessageSender interface {
+	// SendInProgressMessage sends a given message to a conversation in which the command was executed.
+	SendInProgressMessage(ctx context.Context, conversation Conversation, msg interactive.CoreMessage) error
+}
+
+// MessageUpdater is implemented by bots that can post a message and update it later.
+type MessageUpdater interface {
+	// PostUpdatableMessage posts a given message in the thread of the executed command and returns the message ID.
+	PostUpdatableMessage(ctx context.Context, conversation Conversation, msg interactive.CoreMessage) (string, error)
+	// UpdateMessage replaces content of a message posted with PostUpdatableMessage.
+	UpdateMessage(ctx context.Context, conversation Conversation, messageID string, msg interactive.CoreMessage) error
+}
+
 var (
 	// ErrNotificationsNotConfigured describes an error when user wants to toggle on/off the notifications for not configured channel.
 	ErrNotificationsNotConfigured = errors.New("notifications not configured for this channel")
@@ -50,17 +72,28 @@ type NotificationsStorage interface {
 	PersistNotificationsEnabled(ctx context.Context, commGroupName string, platform config.CommPlatformIntegration, channelAlias string, enabled bool) error
 }
 
+// ComponentHealthChecker provides the health tree of Botkube components.
+type ComponentHealthChecker interface {
+	Components() health.Component
+}
+
 // NotifierExecutor executes all commands that are related to notifications.
 type NotifierExecutor struct {
-	log        logrus.FieldLogger
-	cfgManager NotificationsStorage
+	log           logrus.FieldLogger
+	cfgManager    NotificationsStorage
+	cfgCommit     string
+	healthChecker ComponentHealthChecker
 }
 
-// NewNotifierExecutor creates a new instance of NotifierExecutor
-func NewNotifierExecutor(log logrus.FieldLogger, cfgManager NotificationsStorage) *NotifierExecutor {
+// NewNotifierExecutor creates a new instance of NotifierExecutor. The cfgCommit is the SHA of the Git commit
+// the configuration is synced from, and it's reported in the status. It's empty if the Git sync is disabled.
+// The healthChecker is optional, and it's used to print the detailed status of Botkube components.
+func NewNotifierExecutor(log logrus.FieldLogger, cfgManager NotificationsStorage, cfgCommit string, healthChecker ComponentHealthChecker) *NotifierExecutor {
 	return &NotifierExecutor{
-		log:        log,
-		cfgManager: cfgManager,
+		log:           log,
+		cfgManager:    cfgManager,
+		cfgCommit:     cfgCommit,
+		healthChecker: healthChecker,
 	}
 }
 
@@ -127,12 +160,74 @@ func (e *NotifierExecutor) Disable(ctx context.Context, cmdCtx CommandContext) (
 // Status returns the status of a notifier (per channel)
 func (e *NotifierExecutor) Status(ctx context.Context, cmdCtx CommandContext) (interactive.CoreMessage, error) {
 	cmdVerb, cmdRes := parseCmdVerb(cmdCtx.Args)
+
+	// flags are allowed both directly after the verb and after the feature, e.g. `status --detailed`
+	var flagArgs []string
+	switch {
+	case strings.HasPrefix(cmdRes, "-"):
+		flagArgs = cmdCtx.Args[1:]
+		cmdRes = ""
+	case len(cmdCtx.Args) > 2:
+		flagArgs = cmdCtx.Args[2:]
+	}
+	var detailed bool
+	flags := pflag.NewFlagSet("status", pflag.ContinueOnError)
+	flags.BoolVar(&detailed, "detailed", false, "Print the health of all Botkube components")
+	if err := flags.Parse(flagArgs); err != nil {
+		return respond(fmt.Sprintf("Cannot parse command: %s", err.Error()), cmdCtx), nil
+	}
+
 	enabled := cmdCtx.NotifierHandler.NotificationsEnabled(cmdCtx.Conversation.ID)
 	enabledStr := notifierStatusStrings[enabled]
 	msg := fmt.Sprintf(notifierStatusMsgFmt, cmdCtx.ClusterName, enabledStr)
+	if e.cfgCommit != "" {
+		msg = fmt.Sprintf("%s\n"+notifierCfgCommitMsgFmt, msg, e.cfgCommit)
+	}
+	if detailed {
+		if e.healthChecker == nil {
+			return respond(fmt.Sprintf("%s\n\n%s", msg, notifierDetailedStatusNotAvailable), cmdCtx), nil
+		}
+		return respond(fmt.Sprintf("%s\n\nComponents:\n%s", msg, componentsTabularOutput(e.healthChecker.Components())), cmdCtx), nil
+	}
+	if plugins := cmdCtx.PluginHealthStats.List(); cmdRes == "" && len(plugins) > 0 {
+		msg = fmt.Sprintf("%s\n\nPlugins:\n%s", msg, pluginHealthTabularOutput(plugins))
+	}
 	if cmdRes == "" {
 		helpMsg := cmdCtx.Mapping.HelpMessageForVerb(command.Verb(cmdVerb))
 		msg = fmt.Sprintf("%s\n\n%s\n", msg, helpMsg)
 	}
 	return respond(msg, cmdCtx), nil
 }
+
+// componentsTabularOutput prints the health tree of components. Nested components are indented.
+func componentsTabularOutput(root health.Component) string {
+	buf := new(bytes.Buffer)
+	w := tabwriter.NewWriter(buf, 5, 0, 1, ' ', 0)
+	fmt.Fprintf(w, "COMPONENT\tKIND\tSTATUS\tRESTARTS\tDETAILS")
+	writeComponentRows(w, root, 0)
+
+	w.Flush()
+	return buf.String()
+}
+
+func writeComponentRows(w *tabwriter.Writer, c health.Component, depth int) {
+	details := c.Reason
+	switch {
+	case c.LastError == "":
+	case details == "":
+		details = fmt.Sprintf("last error: %s", c.LastError)
+	default:
+		details = fmt.Sprintf("%s, last error: %s", details, c.LastError)
+	}
+	fmt.Fprintf(w, "\n%s%s\t%s\t%s\t%s\t%s",
+		strings.Repeat(notifierComponentIndent, depth),
+		c.Name,
+		c.Kind,
+		c.Status,
+		valueOrDefault(c.Restarts, pluginNoValue),
+		valueOrDefault(newLinePattern.ReplaceAllString(details, " "), pluginNoValue),
+	)
+	for _, child := range c.Components {
+		writeComponentRows(w, child, depth+1)
+	}
+}
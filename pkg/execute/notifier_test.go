@@ -4,9 +4,11 @@ import (
 	"context"
 	"testing"
 
+	"github.com/MakeNowJust/heredoc"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/kubeshop/botkube/internal/health"
 	"github.com/kubeshop/botkube/pkg/config"
 	"github.com/kubeshop/botkube/pkg/loggerx"
 )
@@ -77,7 +79,7 @@ func TestNotifierExecutorStart(t *testing.T) {
 	}
 	for _, tc := range testCases {
 		t.Run(tc.Name, func(t *testing.T) {
-			e := NewNotifierExecutor(loggerx.NewNoop(), &fakeCfgPersistenceManager{expectedAlias: channelAlias})
+			e := NewNotifierExecutor(loggerx.NewNoop(), &fakeCfgPersistenceManager{expectedAlias: channelAlias}, "", nil)
 			msg, err := e.Enable(context.Background(), tc.CmdCtx)
 			if err != nil {
 				assert.EqualError(t, err, tc.ExpectedError)
@@ -151,7 +153,7 @@ func TestNotifierExecutorStop(t *testing.T) {
 	}
 	for _, tc := range testCases {
 		t.Run(tc.Name, func(t *testing.T) {
-			e := NewNotifierExecutor(loggerx.NewNoop(), &fakeCfgPersistenceManager{expectedAlias: channelAlias})
+			e := NewNotifierExecutor(loggerx.NewNoop(), &fakeCfgPersistenceManager{expectedAlias: channelAlias}, "", nil)
 			msg, err := e.Disable(context.Background(), tc.CmdCtx)
 			if err != nil {
 				assert.EqualError(t, err, tc.ExpectedError)
@@ -204,7 +206,7 @@ func TestNotifierExecutorStatus(t *testing.T) {
 	}
 	for _, tc := range testCases {
 		t.Run(tc.Name, func(t *testing.T) {
-			e := NewNotifierExecutor(loggerx.NewNoop(), &fakeCfgPersistenceManager{expectedAlias: channelAlias})
+			e := NewNotifierExecutor(loggerx.NewNoop(), &fakeCfgPersistenceManager{expectedAlias: channelAlias}, "", nil)
 			mapping, err := NewCmdsMapping([]CommandExecutor{e})
 			require.NoError(t, err)
 			tc.CmdCtx.Mapping = mapping
@@ -215,6 +217,75 @@ func TestNotifierExecutorStatus(t *testing.T) {
 	}
 }
 
+func TestNotifierExecutorStatusWithConfigCommit(t *testing.T) {
+	// given
+	e := NewNotifierExecutor(loggerx.NewNoop(), &fakeCfgPersistenceManager{}, "3f9a1c2d8e7b6a5f4e3d2c1b0a9f8e7d6c5b4a39", nil)
+	cmdCtx := CommandContext{
+		Args:         []string{"status", "notifications"},
+		Conversation: Conversation{ID: "conv-id"},
+		ClusterName:  "cluster",
+		NotifierHandler: &fakeNotifierHandler{
+			conf: map[string]bool{"conv-id": true},
+		},
+		ExecutorFilter: newExecutorTextFilter(""),
+	}
+
+	// when
+	msg, err := e.Status(context.Background(), cmdCtx)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "Notifications from cluster 'cluster' are enabled here.\nConfiguration is synced from Git commit `3f9a1c2d8e7b6a5f4e3d2c1b0a9f8e7d6c5b4a39`.", msg.BaseBody.CodeBlock)
+}
+
+func TestNotifierExecutorDetailedStatus(t *testing.T) {
+	// given
+	checker := &fakeComponentHealthChecker{root: health.Component{
+		Name:   "botkube",
+		Kind:   health.AgentComponentKind,
+		Status: health.StatusDegraded,
+		Components: []health.Component{
+			{Name: "default-group-socketSlack", Kind: health.BotComponentKind, Status: health.StatusHealthy},
+			{Name: "k8s-err-events", Kind: health.SourceComponentKind, Status: health.StatusUnHealthy, Components: []health.Component{
+				{Name: "botkube/kubernetes", Kind: health.PluginComponentKind, Status: health.StatusUnHealthy, Reason: "Deactivated", LastError: "plugin is not responding", Restarts: "3/3"},
+			}},
+		},
+	}}
+	e := NewNotifierExecutor(loggerx.NewNoop(), &fakeCfgPersistenceManager{}, "", checker)
+	cmdCtx := CommandContext{
+		Args:         []string{"status", "--detailed"},
+		Conversation: Conversation{ID: "conv-id"},
+		ClusterName:  "cluster",
+		NotifierHandler: &fakeNotifierHandler{
+			conf: map[string]bool{"conv-id": true},
+		},
+		ExecutorFilter: newExecutorTextFilter(""),
+	}
+
+	// when
+	msg, err := e.Status(context.Background(), cmdCtx)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, heredoc.Doc(`
+		Notifications from cluster 'cluster' are enabled here.
+
+		Components:
+		COMPONENT                   KIND   STATUS    RESTARTS DETAILS
+		botkube                     agent  Degraded  -        -
+		  default-group-socketSlack bot    Healthy   -        -
+		  k8s-err-events            source Unhealthy -        -
+		    botkube/kubernetes      plugin Unhealthy 3/3      Deactivated, last error: plugin is not responding`), msg.BaseBody.CodeBlock)
+}
+
+type fakeComponentHealthChecker struct {
+	root health.Component
+}
+
+func (f *fakeComponentHealthChecker) Components() health.Component {
+	return f.root
+}
+
 type fakeNotifierHandler struct {
 	conf map[string]bool
 }
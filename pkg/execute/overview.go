@@ -0,0 +1,160 @@
+package execute
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/internal/overview"
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/execute/command"
+)
+
+const (
+	overviewNotAvailable = "Cluster overview is not available."
+	overviewHeader       = "Cluster overview"
+	overviewNone         = "None"
+	overviewTimeFormat   = "2006-01-02 15:04:05 MST"
+)
+
+var overviewFeatureName = FeatureName{Name: noFeature}
+
+// OverviewCollector collects the cluster state for the overview dashboard.
+type OverviewCollector interface {
+	Collect(ctx context.Context) (overview.Snapshot, error)
+}
+
+// OverviewExecutor executes the cluster overview command.
+type OverviewExecutor struct {
+	log       logrus.FieldLogger
+	collector OverviewCollector
+}
+
+// NewOverviewExecutor returns a new OverviewExecutor instance. The collector is optional.
+func NewOverviewExecutor(log logrus.FieldLogger, collector OverviewCollector) *OverviewExecutor {
+	return &OverviewExecutor{
+		log:       log,
+		collector: collector,
+	}
+}
+
+// FeatureName returns the name and aliases of the feature provided by this executor
+func (e *OverviewExecutor) FeatureName() FeatureName {
+	return overviewFeatureName
+}
+
+// Commands returns slice of commands the executor supports
+func (e *OverviewExecutor) Commands() map[command.Verb]CommandFn {
+	return map[command.Verb]CommandFn{
+		command.OverviewVerb: e.Overview,
+	}
+}
+
+// Overview responds with the cluster overview dashboard. If the command was triggered by the Refresh button,
+// the dashboard message is updated in place.
+func (e *OverviewExecutor) Overview(ctx context.Context, cmdCtx CommandContext) (interactive.CoreMessage, error) {
+	if e.collector == nil {
+		return respond(overviewNotAvailable, cmdCtx), nil
+	}
+
+	snapshot, err := e.collector.Collect(ctx)
+	if err != nil {
+		return interactive.CoreMessage{}, fmt.Errorf("while collecting cluster overview: %w", err)
+	}
+
+	msg := overviewMessage(snapshot)
+	msg.ReplaceOriginal = cmdCtx.Conversation.CommandOrigin == command.ButtonClickOrigin && cmdCtx.Platform.IsInteractive()
+	return interactive.CoreMessage{
+		Header:  overviewHeader,
+		Message: msg,
+	}, nil
+}
+
+func overviewMessage(s overview.Snapshot) api.Message {
+	var pressure []string
+	for _, item := range s.Pressure {
+		pressure = append(pressure, fmt.Sprintf("%s: %s", item.Node, item.Condition))
+	}
+	var restarters []string
+	for _, pod := range s.TopRestarters {
+		restarters = append(restarters, fmt.Sprintf("%s/%s: %d restarts", pod.Namespace, pod.Name, pod.Restarts))
+	}
+	var events []string
+	for _, event := range s.WarningEvents {
+		events = append(events, fmt.Sprintf("%s %s/%s (x%d, %s ago): %s", event.Reason, event.Namespace, event.Object, event.Count, s.CollectedAt.Sub(event.LastSeen).Round(time.Second), event.Message))
+	}
+
+	btnBuilder := api.NewMessageButtonBuilder()
+	return api.Message{
+		Sections: []api.Section{
+			{
+				Base: api.Base{
+					Header: "Nodes",
+				},
+				TextFields: api.TextFields{
+					{Key: "Ready", Value: fmt.Sprintf("%d/%d", s.NodesTotal-len(s.NodesNotReady), s.NodesTotal)},
+					{Key: "Not ready", Value: joinOrNone(s.NodesNotReady)},
+				},
+				BulletLists: api.BulletLists{
+					overviewList("Pressure conditions", pressure),
+				},
+			},
+			{
+				Base: api.Base{
+					Header: "Pods",
+				},
+				TextFields: api.TextFields{
+					{Key: "Pending", Value: fmt.Sprintf("%d", s.PendingPodsCount)},
+					{Key: "Failing", Value: fmt.Sprintf("%d", s.FailingPodsCount)},
+				},
+				BulletLists: api.BulletLists{
+					overviewList("Pending", overviewPods(s.PendingPods)),
+					overviewList("Failing", overviewPods(s.FailingPods)),
+					overviewList("Top restarters", restarters),
+				},
+			},
+			{
+				Base: api.Base{
+					Header: fmt.Sprintf("Recent warning events (%d)", s.WarningEventsCount),
+				},
+				BulletLists: api.BulletLists{
+					overviewList("Latest", events),
+				},
+			},
+			{
+				Buttons: api.Buttons{
+					btnBuilder.ForCommandWithoutDesc("Refresh", string(command.OverviewVerb), api.ButtonStylePrimary),
+				},
+				Context: api.ContextItems{
+					{Text: fmt.Sprintf("Updated at %s", s.CollectedAt.UTC().Format(overviewTimeFormat))},
+				},
+			},
+		},
+	}
+}
+
+func overviewPods(pods []overview.Pod) []string {
+	var out []string
+	for _, pod := range pods {
+		out = append(out, fmt.Sprintf("%s/%s: %s", pod.Namespace, pod.Name, pod.Reason))
+	}
+	return out
+}
+
+func overviewList(title string, items []string) api.BulletList {
+	if len(items) == 0 {
+		items = []string{overviewNone}
+	}
+	return api.BulletList{Title: title, Items: items}
+}
+
+func joinOrNone(items []string) string {
+	if len(items) == 0 {
+		return overviewNone
+	}
+	return strings.Join(items, ", ")
+}
@@ -0,0 +1,87 @@
+package execute
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/internal/overview"
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/execute/command"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+func TestOverviewExecutor(t *testing.T) {
+	// given
+	now := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	e := NewOverviewExecutor(loggerx.NewNoop(), fakeOverviewCollector{
+		CollectedAt:        now,
+		NodesTotal:         3,
+		NodesNotReady:      []string{"node-b"},
+		PendingPodsCount:   1,
+		PendingPods:        []overview.Pod{{Namespace: "prod", Name: "worker-1", Reason: "Unschedulable"}},
+		TopRestarters:      []overview.Pod{{Namespace: "prod", Name: "api-1", Restarts: 7}},
+		WarningEventsCount: 1,
+		WarningEvents: []overview.Event{
+			{Namespace: "prod", Object: "Pod/api-1", Reason: "BackOff", Message: "Back-off restarting failed container", Count: 3, LastSeen: now.Add(-time.Minute)},
+		},
+	})
+	cmdCtx := CommandContext{
+		Args:           []string{"overview"},
+		Platform:       config.SocketSlackCommPlatformIntegration,
+		ExecutorFilter: newExecutorTextFilter(""),
+		Conversation:   Conversation{CommandOrigin: command.TypedOrigin},
+	}
+
+	// when
+	msg, err := e.Overview(context.Background(), cmdCtx)
+
+	// then
+	require.NoError(t, err)
+	assert.False(t, msg.ReplaceOriginal)
+	require.Len(t, msg.Sections, 4)
+
+	nodes := msg.Sections[0]
+	assert.Equal(t, api.TextFields{{Key: "Ready", Value: "2/3"}, {Key: "Not ready", Value: "node-b"}}, nodes.TextFields)
+	assert.Equal(t, api.BulletLists{{Title: "Pressure conditions", Items: []string{"None"}}}, nodes.BulletLists)
+
+	pods := msg.Sections[1]
+	assert.Equal(t, api.BulletLists{
+		{Title: "Pending", Items: []string{"prod/worker-1: Unschedulable"}},
+		{Title: "Failing", Items: []string{"None"}},
+		{Title: "Top restarters", Items: []string{"prod/api-1: 7 restarts"}},
+	}, pods.BulletLists)
+
+	events := msg.Sections[2]
+	assert.Equal(t, "Recent warning events (1)", events.Header)
+	assert.Equal(t, []string{"BackOff prod/Pod/api-1 (x3, 1m0s ago): Back-off restarting failed container"}, events.BulletLists[0].Items)
+
+	footer := msg.Sections[3]
+	require.Len(t, footer.Buttons, 1)
+	assert.Equal(t, api.MessageBotNamePlaceholder+" overview", footer.Buttons[0].Command)
+
+	// when the Refresh button is clicked
+	cmdCtx.Conversation.CommandOrigin = command.ButtonClickOrigin
+	msg, err = e.Overview(context.Background(), cmdCtx)
+
+	// then the dashboard is updated in place
+	require.NoError(t, err)
+	assert.True(t, msg.ReplaceOriginal)
+
+	// when the collector is not set
+	msg, err = NewOverviewExecutor(loggerx.NewNoop(), nil).Overview(context.Background(), cmdCtx)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, overviewNotAvailable, msg.BaseBody.CodeBlock)
+}
+
+type fakeOverviewCollector overview.Snapshot
+
+func (f fakeOverviewCollector) Collect(context.Context) (overview.Snapshot, error) {
+	return overview.Snapshot(f), nil
+}
@@ -5,12 +5,14 @@ import (
 	"regexp"
 	"strings"
 
-	"github.com/mattn/go-shellwords"
 	"github.com/spf13/pflag"
+
+	"github.com/kubeshop/botkube/pkg/shellx"
 )
 
 const (
 	cantParseCmd           = "cannot parse command. Please use 'help' to see supported commands"
+	cantParseCmdWithReason = "cannot parse command: %s"
 	incorrectParamFlag     = "incorrect use of %s flag: %s"
 	missingCmdParamValue   = `incorrect use of %s flag: an argument is missing. use %s="value" or %s value`
 	multipleParams         = "incorrect use of %s flag: found more than one %s flag"
@@ -53,7 +55,7 @@ func ParseFlags(cmd string) (Flags, error) {
 		return Flags{}, err
 	}
 
-	tokenized, err := shellwords.Parse(cmd)
+	tokenized, err := shellx.Split(cmd)
 	if err != nil {
 		return Flags{}, err
 	}
@@ -70,7 +72,7 @@ func extractParam(cmd, flagName string) (string, string, error) {
 	flag := fmt.Sprintf("--%s", flagName)
 	var withParam string
 	var params []string
-	args, _ := shellwords.Parse(cmd)
+	args, _ := shellx.Split(cmd)
 	f := pflag.NewFlagSet("extract-params", pflag.ContinueOnError)
 	f.BoolP("help", "h", false, "to make sure that parsing is ignoring the --help,-h flags")
 
@@ -129,7 +131,7 @@ func ensureEvenSingleQuotes(cmd string) string {
 func extractBoolParam(cmd, flagName string) (string, bool, error) {
 	flag := fmt.Sprintf("--%s", flagName)
 	var isSet bool
-	args, _ := shellwords.Parse(cmd)
+	args, _ := shellx.Split(cmd)
 	f := pflag.NewFlagSet("extract-params", pflag.ContinueOnError)
 	f.BoolP("help", "h", false, "to make sure that parsing is ignoring the --help,-h flags")
 
@@ -106,6 +106,12 @@ func TestRemoveBotkubeRelatedFlags(t *testing.T) {
 			ClusterName: "api",
 			Filter:      "=./Users/botkube/somefile.txt",
 		},
+		{
+			Name:        "Jsonpath with quotes and cluster name",
+			Input:       `kubectl get po -o jsonpath='{range .items[*]}{.metadata.name}{"\n"}{end}' --cluster-name=foo`,
+			Cmd:         `kubectl get po -o jsonpath='{range .items[*]}{.metadata.name}{"\n"}{end}'`,
+			ClusterName: "foo",
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.Name, func(t *testing.T) {
@@ -144,6 +150,11 @@ func TestExtractExecutorFilter_WithErrors(t *testing.T) {
 			Cmd:    "kubectl get po --filter --filter -n kube-system",
 			ErrMsg: `an argument is missing`,
 		},
+		{
+			Name:   "raise error when double quote is not terminated",
+			Cmd:    `kubectl get po -o jsonpath="{.items[*]}`,
+			ErrMsg: "unterminated double-quoted string (line 1, column 28)",
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.Name, func(t *testing.T) {
@@ -0,0 +1,443 @@
+package execute
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/execute/command"
+	"github.com/kubeshop/botkube/pkg/maputil"
+	"github.com/kubeshop/botkube/pkg/plugin"
+)
+
+const (
+	pluginConfigUsageFmt               = "Use `%s config plugin {executor|source|processor} {group} {plugin}` to edit the plugin configuration, e.g. `%[1]s config plugin executor helm botkube/helm`."
+	pluginConfigNoPlugins              = "There are no plugins enabled."
+	pluginConfigNotFoundFmt            = "The %s plugin %q is not enabled in the %q group."
+	pluginConfigNoSchemaFmt            = "The %s plugin %q doesn't define the JSON schema of its configuration, so it cannot be edited in chat."
+	pluginConfigUnknownPropFmt         = "Unknown property %q. Use one of: %s."
+	pluginConfigMissingValueFmt        = "Missing value for the %q property."
+	pluginConfigReadOnlyPropFmt        = "The %q property can be changed only in the configuration file."
+	pluginConfigEditedMsgFmt           = ":white_check_mark: %s changed `%s` of the %s plugin %q to `%s`. Expect Botkube reload in a few seconds..."
+	pluginConfigEditedWithoutReloadFmt = ":white_check_mark: %s changed `%s` of the %s plugin %q to `%s`.\nAs the Config Watcher is disabled, you need to restart Botkube manually to apply the changes."
+)
+
+var pluginConfigTypes = []plugin.Type{plugin.TypeExecutor, plugin.TypeSource, plugin.TypeProcessor}
+
+// PluginConfigSchemaGetter returns JSON schemas of plugin configurations.
+type PluginConfigSchemaGetter interface {
+	ConfigSchema(ctx context.Context, pluginType plugin.Type, pluginKey string) (json.RawMessage, error)
+}
+
+// PluginConfigStorage provides functionality to persist plugin configurations.
+type PluginConfigStorage interface {
+	PersistPluginConfig(ctx context.Context, pluginType, group, pluginKey string, cfg any) error
+}
+
+// PluginConfigExecutor edits plugin configurations with interactive forms generated from JSON schemas defined by plugins.
+// Changes are validated against the schema before they are persisted, and are applied once Botkube reloads the configuration.
+type PluginConfigExecutor struct {
+	log        logrus.FieldLogger
+	cfg        config.Config
+	schemas    PluginConfigSchemaGetter
+	cfgManager PluginConfigStorage
+	cfgHistory ConfigHistoryStore
+}
+
+// NewPluginConfigExecutor returns a new PluginConfigExecutor instance. The schemas is nil if plugins are not enabled,
+// and the cfgHistory is nil if the configuration history is disabled.
+func NewPluginConfigExecutor(log logrus.FieldLogger, schemas PluginConfigSchemaGetter, cfgManager PluginConfigStorage, cfgHistory ConfigHistoryStore, cfg config.Config) *PluginConfigExecutor {
+	return &PluginConfigExecutor{
+		log:        log,
+		cfg:        cfg,
+		schemas:    schemas,
+		cfgManager: cfgManager,
+		cfgHistory: cfgHistory,
+	}
+}
+
+// FeatureName returns the name and aliases of the feature provided by this executor
+func (e *PluginConfigExecutor) FeatureName() FeatureName {
+	return pluginFeatureName
+}
+
+// Commands returns slice of commands the executor supports
+func (e *PluginConfigExecutor) Commands() map[command.Verb]CommandFn {
+	return map[command.Verb]CommandFn{
+		command.ConfigVerb: e.Config,
+	}
+}
+
+// Config lists enabled plugins, returns the interactive configuration form of a given plugin,
+// or changes a given property, e.g. `config plugin executor helm botkube/helm defaultNamespace team-a`.
+func (e *PluginConfigExecutor) Config(ctx context.Context, cmdCtx CommandContext) (interactive.CoreMessage, error) {
+	if len(cmdCtx.Args) < 3 {
+		return e.list(), nil
+	}
+	if len(cmdCtx.Args) < 5 {
+		return plaintextMessage(fmt.Sprintf(pluginConfigUsageFmt, api.MessageBotNamePlaceholder)), nil
+	}
+
+	pluginType, group, pluginKey := plugin.Type(cmdCtx.Args[2]), cmdCtx.Args[3], cmdCtx.Args[4]
+	current, found := e.pluginConfig(pluginType, group, pluginKey)
+	if !found {
+		return plaintextMessage(fmt.Sprintf(pluginConfigNotFoundFmt, pluginType, pluginKey, group)), nil
+	}
+
+	var schema json.RawMessage
+	if e.schemas != nil {
+		var err error
+		schema, err = e.schemas.ConfigSchema(ctx, pluginType, pluginKey)
+		if err != nil {
+			return interactive.CoreMessage{}, fmt.Errorf("while getting JSON schema of the %s plugin %q: %w", pluginType, pluginKey, err)
+		}
+	}
+	if schema == nil {
+		return plaintextMessage(fmt.Sprintf(pluginConfigNoSchemaFmt, pluginType, pluginKey)), nil
+	}
+
+	props, err := parseConfigSchemaProperties(schema)
+	if err != nil {
+		return interactive.CoreMessage{}, fmt.Errorf("while parsing JSON schema of the %s plugin %q: %w", pluginType, pluginKey, err)
+	}
+	values, err := pluginConfigValues(current.Config)
+	if err != nil {
+		return interactive.CoreMessage{}, fmt.Errorf("while reading configuration of the %s plugin %q: %w", pluginType, pluginKey, err)
+	}
+
+	if len(cmdCtx.Args) < 6 {
+		return pluginConfigForm(pluginType, group, pluginKey, props, values), nil
+	}
+
+	name := cmdCtx.Args[5]
+	prop, ok := props[name]
+	if !ok {
+		return plaintextMessage(fmt.Sprintf(pluginConfigUnknownPropFmt, name, strings.Join(maputil.SortKeys(props), ", "))), nil
+	}
+	if !prop.IsEditable() {
+		return plaintextMessage(fmt.Sprintf(pluginConfigReadOnlyPropFmt, name)), nil
+	}
+	raw := strings.TrimSpace(strings.Join(cmdCtx.Args[6:], " "))
+	if raw == "" {
+		return plaintextMessage(fmt.Sprintf(pluginConfigMissingValueFmt, name)), nil
+	}
+
+	value, err := prop.Parse(raw)
+	if err != nil {
+		return plaintextMessage(fmt.Sprintf(":exclamation: Invalid %s: %s", name, err.Error())), nil
+	}
+	values[name] = value
+	if err := plugin.ValidateConfig(schema, values); err != nil {
+		return plaintextMessage(fmt.Sprintf(":exclamation: Invalid %s: %s", name, err.Error())), nil
+	}
+
+	err = e.cfgManager.PersistPluginConfig(ctx, string(pluginType), group, pluginKey, values)
+	if msg, ok := persistErrMessage(err); ok {
+		return plaintextMessage(msg), nil
+	}
+	if err != nil {
+		return interactive.CoreMessage{}, fmt.Errorf("while persisting plugin configuration: %w", err)
+	}
+
+	return e.editedMessage(ctx, cmdCtx, name, pluginType, pluginKey, raw), nil
+}
+
+func (e *PluginConfigExecutor) editedMessage(ctx context.Context, cmdCtx CommandContext, name string, pluginType plugin.Type, pluginKey, value string) interactive.CoreMessage {
+	user := userMention(cmdCtx)
+	if e.cfgHistory != nil {
+		// the change is recorded in the configuration history once it's applied
+		if err := e.cfgHistory.SetTrigger(ctx, fmt.Sprintf("`%s` by %s", cmdCtx.CleanCmd, user)); err != nil {
+			e.log.WithError(err).Warn("Cannot describe the configuration change in the configuration history")
+		}
+	}
+
+	msgFmt := pluginConfigEditedMsgFmt
+	if !e.cfg.ConfigWatcher.Enabled {
+		msgFmt = pluginConfigEditedWithoutReloadFmt
+	}
+	return plaintextMessage(fmt.Sprintf(msgFmt, user, name, pluginType, pluginKey, value))
+}
+
+func (e *PluginConfigExecutor) pluginConfig(pluginType plugin.Type, group, pluginKey string) (config.Plugin, bool) {
+	var plugins config.Plugins
+	switch pluginType {
+	case plugin.TypeExecutor:
+		plugins = e.cfg.Executors[group].Plugins
+	case plugin.TypeSource:
+		plugins = e.cfg.Sources[group].Plugins
+	case plugin.TypeProcessor:
+		plugins = e.cfg.Processors[group].Plugins
+	}
+
+	p, found := plugins[pluginKey]
+	if !found || !p.Enabled {
+		return config.Plugin{}, false
+	}
+	return p, true
+}
+
+// list returns enabled plugins with buttons which open their configuration forms.
+func (e *PluginConfigExecutor) list() interactive.CoreMessage {
+	groups := map[plugin.Type]map[string]config.Plugins{
+		plugin.TypeExecutor:  {},
+		plugin.TypeSource:    {},
+		plugin.TypeProcessor: {},
+	}
+	for name, group := range e.cfg.Executors {
+		groups[plugin.TypeExecutor][name] = group.Plugins
+	}
+	for name, group := range e.cfg.Sources {
+		groups[plugin.TypeSource][name] = group.Plugins
+	}
+	for name, group := range e.cfg.Processors {
+		groups[plugin.TypeProcessor][name] = group.Plugins
+	}
+
+	btnBuilder := api.NewMessageButtonBuilder()
+	var sections []api.Section
+	for _, pluginType := range pluginConfigTypes {
+		for _, groupName := range maputil.SortKeys(groups[pluginType]) {
+			plugins := groups[pluginType][groupName]
+			for _, pluginKey := range maputil.SortKeys(plugins) {
+				if !plugins[pluginKey].Enabled {
+					continue
+				}
+				sections = append(sections, api.Section{
+					Base: api.Base{
+						Description: fmt.Sprintf("%s `%s` in the `%s` group", pluginType, pluginKey, groupName),
+					},
+					Buttons: api.Buttons{
+						btnBuilder.ForCommandWithoutDesc("Edit configuration", fmt.Sprintf("%s %s %s %s %s", command.ConfigVerb, pluginFeatureName.Name, pluginType, groupName, pluginKey)),
+					},
+				})
+			}
+		}
+	}
+	if len(sections) == 0 {
+		return plaintextMessage(pluginConfigNoPlugins)
+	}
+
+	return interactive.CoreMessage{
+		Header: "Plugin configurations",
+		Message: api.Message{
+			OnlyVisibleForYou: true,
+			Sections:          sections,
+		},
+	}
+}
+
+// pluginConfigForm returns the interactive form generated from top-level properties of the plugin configuration JSON schema.
+func pluginConfigForm(pluginType plugin.Type, group, pluginKey string, props map[string]configSchemaProperty, values map[string]any) interactive.CoreMessage {
+	cmdPrefix := fmt.Sprintf("%s %s %s %s %s %s", api.MessageBotNamePlaceholder, command.ConfigVerb, pluginFeatureName.Name, pluginType, group, pluginKey)
+
+	var (
+		selects  []api.Select
+		inputs   api.LabelInputs
+		readOnly []string
+	)
+	for _, name := range maputil.SortKeys(props) {
+		prop := props[name]
+		current, isSet := values[name]
+		if !isSet {
+			current = prop.Default
+		}
+
+		if !prop.IsEditable() {
+			readOnly = append(readOnly, fmt.Sprintf("• `%s` (%s)", name, prop.TypeName()))
+			continue
+		}
+
+		options := prop.Options()
+		if len(options) == 0 {
+			placeholder := fmt.Sprintf("Type %s and press Enter", prop.TypeName())
+			if current != nil {
+				placeholder = fmt.Sprintf("Current value: %v", current)
+			}
+			inputs = append(inputs, api.LabelInput{
+				Command:          fmt.Sprintf("%s %s ", cmdPrefix, name),
+				DispatchedAction: api.DispatchInputActionOnEnter,
+				Placeholder:      placeholder,
+				Text:             prop.Label(name),
+			})
+			continue
+		}
+
+		item := api.Select{
+			Type:         api.StaticSelect,
+			Name:         prop.Label(name),
+			Command:      fmt.Sprintf("%s %s", cmdPrefix, name),
+			OptionGroups: []api.OptionGroup{{Name: prop.Label(name), Options: options}},
+		}
+		for idx := range options {
+			if current != nil && options[idx].Value == fmt.Sprint(current) {
+				item.InitialOption = &options[idx]
+				break
+			}
+		}
+		selects = append(selects, item)
+	}
+
+	sections := []api.Section{
+		{
+			Base: api.Base{
+				Description: fmt.Sprintf("Configuration of the %s plugin `%s` in the `%s` group. Changes are validated against the plugin JSON schema.", pluginType, pluginKey, group),
+			},
+			Selects: api.Selects{
+				ID:    fmt.Sprintf("plugin-config-%s-%s", group, pluginKey),
+				Items: selects,
+			},
+			PlaintextInputs: inputs,
+		},
+	}
+	if len(readOnly) > 0 {
+		sections = append(sections, api.Section{
+			Base: api.Base{
+				Description: "The following properties can be changed only in the configuration file:\n" + strings.Join(readOnly, "\n"),
+			},
+		})
+	}
+
+	return interactive.CoreMessage{
+		Header: fmt.Sprintf("Edit %s configuration", pluginKey),
+		Message: api.Message{
+			OnlyVisibleForYou: true,
+			Sections:          sections,
+		},
+	}
+}
+
+// configSchemaProperty holds the subset of the JSON schema property definition used to generate configuration forms.
+type configSchemaProperty struct {
+	Type        any    `json:"type"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Enum        []any  `json:"enum"`
+	Default     any    `json:"default"`
+}
+
+func parseConfigSchemaProperties(schema json.RawMessage) (map[string]configSchemaProperty, error) {
+	var out struct {
+		Properties map[string]configSchemaProperty `json:"properties"`
+	}
+	if err := json.Unmarshal(schema, &out); err != nil {
+		return nil, err
+	}
+	return out.Properties, nil
+}
+
+// TypeName returns the JSON type of the property. Nullable types are reported as their non-null type.
+func (p configSchemaProperty) TypeName() string {
+	switch typ := p.Type.(type) {
+	case string:
+		return typ
+	case []any:
+		for _, item := range typ {
+			if name, ok := item.(string); ok && name != "null" {
+				return name
+			}
+		}
+	}
+	if len(p.Enum) > 0 {
+		return "string"
+	}
+	return "object"
+}
+
+// IsEditable returns true if the property can be edited in chat. Only scalar properties are supported.
+func (p configSchemaProperty) IsEditable() bool {
+	switch p.TypeName() {
+	case "boolean", "string", "integer", "number":
+		return true
+	}
+	return false
+}
+
+// Label returns the property title if defined, or the property name otherwise.
+func (p configSchemaProperty) Label(name string) string {
+	if p.Title != "" {
+		return p.Title
+	}
+	return name
+}
+
+// Options returns options for properties with a closed set of values, such as enums and booleans.
+func (p configSchemaProperty) Options() []api.OptionItem {
+	values := p.Enum
+	if len(values) == 0 && p.TypeName() == "boolean" {
+		values = []any{true, false}
+	}
+
+	out := make([]api.OptionItem, 0, len(values))
+	for _, value := range values {
+		out = append(out, api.OptionItem{Name: fmt.Sprint(value), Value: fmt.Sprint(value)})
+	}
+	return out
+}
+
+// Parse converts a value typed by the user to the property type.
+func (p configSchemaProperty) Parse(in string) (any, error) {
+	in = strings.Trim(in, "`\"'")
+	for _, value := range p.Enum {
+		if fmt.Sprint(value) == in {
+			return value, nil
+		}
+	}
+	if len(p.Enum) > 0 {
+		options := make([]string, 0, len(p.Enum))
+		for _, value := range p.Enum {
+			options = append(options, fmt.Sprint(value))
+		}
+		sort.Strings(options)
+		return nil, fmt.Errorf("use one of: %s", strings.Join(options, ", "))
+	}
+
+	switch p.TypeName() {
+	case "boolean":
+		out, err := strconv.ParseBool(in)
+		if err != nil {
+			return nil, errors.New("use true or false")
+		}
+		return out, nil
+	case "integer":
+		out, err := strconv.ParseInt(in, 10, 64)
+		if err != nil {
+			return nil, errors.New("the value must be an integer")
+		}
+		return out, nil
+	case "number":
+		out, err := strconv.ParseFloat(in, 64)
+		if err != nil {
+			return nil, errors.New("the value must be a number")
+		}
+		return out, nil
+	}
+	return in, nil
+}
+
+// pluginConfigValues returns the plugin configuration as a map. Configurations decoded from YAML may contain maps with non-string keys,
+// so they are normalized first.
+func pluginConfigValues(cfg any) (map[string]any, error) {
+	out := map[string]any{}
+	if cfg == nil {
+		return out, nil
+	}
+
+	raw, err := yaml.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(raw, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,237 @@
+package execute
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+	"github.com/kubeshop/botkube/pkg/plugin"
+)
+
+const testPluginConfigSchema = `{
+  "type": "object",
+  "properties": {
+    "defaultNamespace": {"type": "string", "title": "Default namespace"},
+    "replicas": {"type": "integer", "minimum": 1},
+    "dryRun": {"type": "boolean", "default": false},
+    "output": {"type": "string", "enum": ["json", "yaml"]},
+    "labels": {"type": "object"}
+  }
+}`
+
+type fakePluginConfigStorage struct {
+	err       error
+	persisted any
+}
+
+func (f *fakePluginConfigStorage) PersistPluginConfig(_ context.Context, _, _, _ string, cfg any) error {
+	f.persisted = cfg
+	return f.err
+}
+
+type fakePluginConfigSchemaGetter map[string]string
+
+func (f fakePluginConfigSchemaGetter) ConfigSchema(_ context.Context, _ plugin.Type, pluginKey string) (json.RawMessage, error) {
+	schema, found := f[pluginKey]
+	if !found {
+		return nil, nil
+	}
+	return json.RawMessage(schema), nil
+}
+
+func TestPluginConfigExecutor(t *testing.T) {
+	// given
+	cfg := config.Config{
+		ConfigWatcher: config.CfgWatcher{Enabled: true},
+		Executors: map[string]config.Executors{
+			"helm": {
+				Plugins: config.Plugins{
+					"botkube/helm": {Enabled: true, Config: map[string]any{"defaultNamespace": "default", "replicas": 1}},
+				},
+			},
+			"echo": {
+				Plugins: config.Plugins{
+					"botkube/echo": {Enabled: true},
+				},
+			},
+		},
+	}
+	schemas := fakePluginConfigSchemaGetter{"botkube/helm": testPluginConfigSchema}
+
+	tests := []struct {
+		name              string
+		args              string
+		storageErr        error
+		expectedMsg       string
+		expectedPersisted any
+	}{
+		{
+			name:              "change integer",
+			args:              "config plugin executor helm botkube/helm replicas 3",
+			expectedMsg:       ":white_check_mark: @Joe changed `replicas` of the executor plugin \"botkube/helm\" to `3`. Expect Botkube reload in a few seconds...",
+			expectedPersisted: map[string]any{"defaultNamespace": "default", "replicas": int64(3)},
+		},
+		{
+			name:              "change boolean",
+			args:              "config plugin executor helm botkube/helm dryRun true",
+			expectedMsg:       ":white_check_mark: @Joe changed `dryRun` of the executor plugin \"botkube/helm\" to `true`.",
+			expectedPersisted: map[string]any{"defaultNamespace": "default", "replicas": 1, "dryRun": true},
+		},
+		{
+			name:              "change string with spaces",
+			args:              "config plugin executor helm botkube/helm defaultNamespace team a",
+			expectedMsg:       ":white_check_mark: @Joe changed `defaultNamespace` of the executor plugin \"botkube/helm\" to `team a`.",
+			expectedPersisted: map[string]any{"defaultNamespace": "team a", "replicas": 1},
+		},
+		{
+			name:        "value violates schema",
+			args:        "config plugin executor helm botkube/helm replicas 0",
+			expectedMsg: ":exclamation: Invalid replicas: 1 error occurred:\n\t* replicas: Must be greater than or equal to 1",
+		},
+		{
+			name:        "invalid type",
+			args:        "config plugin executor helm botkube/helm replicas many",
+			expectedMsg: ":exclamation: Invalid replicas: the value must be an integer",
+		},
+		{
+			name:        "unknown enum value",
+			args:        "config plugin executor helm botkube/helm output xml",
+			expectedMsg: ":exclamation: Invalid output: use one of: json, yaml",
+		},
+		{
+			name:        "read-only property",
+			args:        "config plugin executor helm botkube/helm labels foo",
+			expectedMsg: `The "labels" property can be changed only in the configuration file.`,
+		},
+		{
+			name:        "unknown property",
+			args:        "config plugin executor helm botkube/helm color red",
+			expectedMsg: `Unknown property "color". Use one of: defaultNamespace, dryRun, labels, output, replicas.`,
+		},
+		{
+			name:        "missing value",
+			args:        "config plugin executor helm botkube/helm replicas",
+			expectedMsg: `Missing value for the "replicas" property.`,
+		},
+		{
+			name:        "plugin without schema",
+			args:        "config plugin executor echo botkube/echo foo bar",
+			expectedMsg: `The executor plugin "botkube/echo" doesn't define the JSON schema of its configuration, so it cannot be edited in chat.`,
+		},
+		{
+			name:        "plugin not enabled",
+			args:        "config plugin source k8s botkube/kubernetes",
+			expectedMsg: `The source plugin "botkube/kubernetes" is not enabled in the "k8s" group.`,
+		},
+		{
+			name:              "remote configuration",
+			args:              "config plugin executor helm botkube/helm replicas 2",
+			storageErr:        config.ErrUnsupportedRemotePersistence,
+			expectedMsg:       ":exclamation: this change cannot be persisted in the remote configuration, use Botkube Cloud to change it.",
+			expectedPersisted: map[string]any{"defaultNamespace": "default", "replicas": int64(2)},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			storage := &fakePluginConfigStorage{err: tc.storageErr}
+			e := NewPluginConfigExecutor(loggerx.NewNoop(), schemas, storage, nil, cfg)
+			cmdCtx := CommandContext{
+				Args: strings.Fields(tc.args),
+				User: UserInput{Mention: "@Joe"},
+			}
+
+			// when
+			msg, err := e.Config(context.Background(), cmdCtx)
+
+			// then
+			require.NoError(t, err)
+			assert.True(t, strings.HasPrefix(msg.BaseBody.Plaintext, tc.expectedMsg), msg.BaseBody.Plaintext)
+			assert.Equal(t, tc.expectedPersisted, storage.persisted)
+		})
+	}
+}
+
+func TestPluginConfigExecutorForm(t *testing.T) {
+	// given
+	cfg := config.Config{
+		Executors: map[string]config.Executors{
+			"helm": {
+				Plugins: config.Plugins{
+					"botkube/helm": {Enabled: true, Config: map[string]any{"replicas": 2, "output": "yaml"}},
+				},
+			},
+		},
+	}
+	e := NewPluginConfigExecutor(loggerx.NewNoop(), fakePluginConfigSchemaGetter{"botkube/helm": testPluginConfigSchema}, &fakePluginConfigStorage{}, nil, cfg)
+
+	// when
+	msg, err := e.Config(context.Background(), CommandContext{
+		Args: strings.Fields("config plugin executor helm botkube/helm"),
+	})
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, msg.Sections, 2)
+
+	selects := msg.Sections[0].Selects.Items
+	require.Len(t, selects, 2)
+	assert.Equal(t, "dryRun", selects[0].Name)
+	assert.Equal(t, "{{BotName}} config plugin executor helm botkube/helm dryRun", selects[0].Command)
+	assert.Equal(t, &api.OptionItem{Name: "false", Value: "false"}, selects[0].InitialOption)
+	assert.Equal(t, "output", selects[1].Name)
+	assert.Equal(t, &api.OptionItem{Name: "yaml", Value: "yaml"}, selects[1].InitialOption)
+
+	inputs := msg.Sections[0].PlaintextInputs
+	require.Len(t, inputs, 2)
+	assert.Equal(t, "Default namespace", inputs[0].Text)
+	assert.Equal(t, "{{BotName}} config plugin executor helm botkube/helm defaultNamespace ", inputs[0].Command)
+	assert.Equal(t, "Type string and press Enter", inputs[0].Placeholder)
+	assert.Equal(t, "replicas", inputs[1].Text)
+	assert.Equal(t, "Current value: 2", inputs[1].Placeholder)
+
+	assert.Contains(t, msg.Sections[1].Description, "`labels` (object)")
+}
+
+func TestPluginConfigExecutorList(t *testing.T) {
+	// given
+	cfg := config.Config{
+		Executors: map[string]config.Executors{
+			"helm": {
+				Plugins: config.Plugins{
+					"botkube/helm": {Enabled: true},
+				},
+			},
+		},
+		Sources: map[string]config.Sources{
+			"k8s": {
+				Plugins: config.Plugins{
+					"botkube/kubernetes": {Enabled: true},
+				},
+			},
+			"disabled": {
+				Plugins: config.Plugins{
+					"botkube/prometheus": {Enabled: false},
+				},
+			},
+		},
+	}
+	e := NewPluginConfigExecutor(loggerx.NewNoop(), nil, &fakePluginConfigStorage{}, nil, cfg)
+
+	// when
+	msg, err := e.Config(context.Background(), CommandContext{
+		Args: strings.Fields("config plugin"),
+	})
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, msg.Sections, 2)
+	assert.Equal(t, "{{BotName}} config plugin executor helm botkube/helm", msg.Sections[0].Buttons[0].Command)
+	assert.Equal(t, "{{BotName}} config plugin source k8s botkube/kubernetes", msg.Sections[1].Buttons[0].Command)
+}
@@ -4,13 +4,18 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/slack-go/slack"
 	"google.golang.org/grpc/status"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/yaml"
 
+	"github.com/kubeshop/botkube/internal/identity"
+	"github.com/kubeshop/botkube/internal/metrics"
+	"github.com/kubeshop/botkube/internal/tracing"
 	"github.com/kubeshop/botkube/pkg/api"
 	"github.com/kubeshop/botkube/pkg/api/executor"
 	"github.com/kubeshop/botkube/pkg/bot/interactive"
@@ -18,21 +23,28 @@ import (
 	"github.com/kubeshop/botkube/pkg/plugin"
 )
 
+// chatUserNotResolvedMsgFmt is returned if a command uses the `ChatUser` RBAC policy, but the user is not in the identity mapping.
+const chatUserNotResolvedMsgFmt = "I can't map your chat user %q to a Kubernetes identity, so I can't execute this command on your behalf. Ask your Botkube administrator to add you to the identity mapping."
+
 // PluginExecutor provides functionality to run registered Botkube plugins.
 type PluginExecutor struct {
-	log           logrus.FieldLogger
-	cfg           config.Config
-	pluginManager *plugin.Manager
-	restCfg       *rest.Config
+	log              logrus.FieldLogger
+	cfg              config.Config
+	pluginManager    *plugin.Manager
+	restCfg          *rest.Config
+	identityResolver *identity.Resolver
+	// k8sCli is used to mint ServiceAccount tokens. If not set, it's created from restCfg when needed.
+	k8sCli kubernetes.Interface
 }
 
 // NewPluginExecutor creates a new instance of PluginExecutor.
 func NewPluginExecutor(log logrus.FieldLogger, cfg config.Config, manager *plugin.Manager, restCfg *rest.Config) *PluginExecutor {
 	return &PluginExecutor{
-		log:           log,
-		cfg:           cfg,
-		pluginManager: manager,
-		restCfg:       restCfg,
+		log:              log,
+		cfg:              cfg,
+		pluginManager:    manager,
+		restCfg:          restCfg,
+		identityResolver: identity.NewResolver(cfg.Settings.IdentityMapping),
 	}
 }
 
@@ -81,19 +93,9 @@ func (e *PluginExecutor) Execute(ctx context.Context, bindings []string, slackSt
 		return interactive.CoreMessage{}, fmt.Errorf("while collecting configs: %w", err)
 	}
 
-	channel := cmdCtx.Conversation.DisplayName
-	if channel == "" {
-		channel = cmdCtx.Conversation.ID
-	}
-
-	input := plugin.KubeConfigInput{
-		Channel: channel,
-	}
-	e.log.WithField("input", input).Debug("Generating Kubeconfig...")
-
-	kubeconfig, err := plugin.GenerateKubeConfig(e.restCfg, e.cfg.Settings.ClusterName, plugins[0].Context, input)
+	execCtx, err := e.executeInputContext(ctx, plugins, slackState, cmdCtx)
 	if err != nil {
-		return interactive.CoreMessage{}, fmt.Errorf("while generating kube config: %w", err)
+		return interactive.CoreMessage{}, err
 	}
 
 	cli, err := e.pluginManager.GetExecutor(fullPluginName)
@@ -101,31 +103,15 @@ func (e *PluginExecutor) Execute(ctx context.Context, bindings []string, slackSt
 		return interactive.CoreMessage{}, fmt.Errorf("while getting concrete plugin client: %w", err)
 	}
 
-	if slackState != nil {
-		e.sanitizeSlackStateIDs(slackState)
-	}
-
-	resp, err := cli.Execute(ctx, executor.ExecuteInput{
+	spanCtx, span := tracing.Start(ctx, "executor.ExecutePlugin", tracing.ExecutorKey.String(fullPluginName))
+	start := time.Now()
+	resp, err := cli.Execute(spanCtx, executor.ExecuteInput{
 		Command: cmdCtx.CleanCmd,
 		Configs: configs,
-		Context: executor.ExecuteInputContext{
-			IsInteractivitySupported: e.isInteractivitySupported(cmdCtx),
-			SlackState:               slackState,
-			KubeConfig:               kubeconfig,
-			Message: executor.Message{
-				Text: cmdCtx.Conversation.Text,
-				URL:  cmdCtx.Conversation.URL,
-				User: executor.User{
-					Mention:     cmdCtx.User.Mention,
-					DisplayName: cmdCtx.User.DisplayName,
-				},
-				ParentActivityID: cmdCtx.Conversation.ParentActivityID,
-			},
-			IncomingWebhook: executor.IncomingWebhookDetailsContext{
-				BaseSourceURL: e.cfg.Plugins.IncomingWebhook.InClusterBaseURL + "/sources/v1",
-			},
-		},
+		Context: execCtx,
 	})
+	metrics.ObserveCommand(fullPluginName, start, err)
+	tracing.End(span, err)
 	if err != nil {
 		s, ok := status.FromError(err)
 		if !ok {
@@ -142,6 +128,11 @@ func (e *PluginExecutor) Execute(ctx context.Context, bindings []string, slackSt
 		return emptyMsg(cmdCtx), nil
 	}
 
+	bindCallbacks(&resp.Message, cmdName)
+	for idx := range resp.Messages {
+		bindCallbacks(&resp.Messages[idx], cmdName)
+	}
+
 	if resp.Message.Type == api.BaseBodyWithFilterMessage {
 		return e.filterMessage(resp.Message, cmdCtx), nil
 	}
@@ -157,6 +148,75 @@ func (e *PluginExecutor) Execute(ctx context.Context, bindings []string, slackSt
 	return out, nil
 }
 
+// executeInputContext returns the context of a plugin call triggered by a given command.
+func (e *PluginExecutor) executeInputContext(ctx context.Context, plugins []config.Plugin, slackState *slack.BlockActionStates, cmdCtx CommandContext) (executor.ExecuteInputContext, error) {
+	channel := cmdCtx.Conversation.DisplayName
+	if channel == "" {
+		channel = cmdCtx.Conversation.ID
+	}
+
+	input := plugin.KubeConfigInput{
+		Channel: channel,
+	}
+	if rbac := plugins[0].Context.RBAC; rbac != nil && rbac.HasChatUserSubject() {
+		id, found := e.identityResolver.Resolve(identity.ChatUser{
+			ID:    cmdCtx.User.ID,
+			Email: cmdCtx.User.Email,
+		})
+		if !found {
+			return executor.ExecuteInputContext{}, NewExecutionCommandError(chatUserNotResolvedMsgFmt, cmdCtx.User.DisplayName)
+		}
+		input.ChatUser = &plugin.ChatUserIdentity{User: id.User, Groups: id.Groups}
+	}
+	e.log.WithField("input", input).Debug("Generating Kubeconfig...")
+
+	kubeconfig, err := e.kubeConfig(ctx, plugins[0].Context, input)
+	if err != nil {
+		return executor.ExecuteInputContext{}, fmt.Errorf("while generating kube config: %w", err)
+	}
+
+	if slackState != nil {
+		e.sanitizeSlackStateIDs(slackState)
+	}
+
+	return executor.ExecuteInputContext{
+		IsInteractivitySupported: e.isInteractivitySupported(cmdCtx),
+		SlackState:               slackState,
+		KubeConfig:               kubeconfig,
+		Message: executor.Message{
+			Text: cmdCtx.Conversation.Text,
+			URL:  cmdCtx.Conversation.URL,
+			User: executor.User{
+				Mention:     cmdCtx.User.Mention,
+				DisplayName: cmdCtx.User.DisplayName,
+				ID:          cmdCtx.User.ID,
+			},
+			ParentActivityID: cmdCtx.Conversation.ParentActivityID,
+		},
+		IncomingWebhook: executor.IncomingWebhookDetailsContext{
+			BaseSourceURL: e.cfg.Plugins.IncomingWebhook.InClusterBaseURL + "/sources/v1",
+		},
+	}, nil
+}
+
+// kubeConfig returns the kubeconfig passed to a plugin. If the RBAC policy defines the ServiceAccount subject, the kubeconfig contains
+// a short-lived token minted for the command, instead of Botkube credentials with impersonation.
+func (e *PluginExecutor) kubeConfig(ctx context.Context, pluginCtx config.PluginContext, input plugin.KubeConfigInput) ([]byte, error) {
+	if pluginCtx.RBAC == nil || !pluginCtx.RBAC.HasServiceAccountSubject() {
+		return plugin.GenerateKubeConfig(e.restCfg, e.cfg.Settings.ClusterName, pluginCtx, input)
+	}
+
+	k8sCli := e.k8sCli
+	if k8sCli == nil {
+		cli, err := kubernetes.NewForConfig(e.restCfg)
+		if err != nil {
+			return nil, fmt.Errorf("while creating k8s client: %w", err)
+		}
+		k8sCli = cli
+	}
+	return plugin.GenerateServiceAccountKubeConfig(ctx, k8sCli, e.restCfg, e.cfg.Settings.ClusterName, pluginCtx, input)
+}
+
 func (e *PluginExecutor) isInteractivitySupported(cmdCtx CommandContext) bool {
 	// TODO(https://github.com/kubeshop/botkube-cloud/issues/645): add support for kubectl builder
 	if strings.EqualFold(cmdCtx.CleanCmd, "kubectl") && cmdCtx.Platform == config.CloudTeamsCommPlatformIntegration {
@@ -231,6 +291,7 @@ func (e *PluginExecutor) Help(ctx context.Context, bindings []string, cmdCtx Com
 	if err != nil {
 		return interactive.CoreMessage{}, err
 	}
+	bindCallbacks(&msg, cmdName)
 
 	if msg.IsEmpty() {
 		return emptyMsg(cmdCtx), nil
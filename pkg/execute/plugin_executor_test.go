@@ -1,9 +1,19 @@
 package execute
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
 )
 
 func TestPluginExecutor_GetCommandPrefix(t *testing.T) {
@@ -46,3 +56,96 @@ func TestPluginExecutor_GetCommandPrefix(t *testing.T) {
 		})
 	}
 }
+
+func TestPluginExecutor_ExecuteInputContextChatUser(t *testing.T) {
+	// given
+	cfg := config.Config{
+		Settings: config.Settings{
+			IdentityMapping: config.IdentityMapping{
+				Users: map[string]config.KubernetesIdentity{
+					"alice@example.com": {User: "alice", Groups: []string{"sre"}},
+				},
+			},
+		},
+	}
+	e := NewPluginExecutor(loggerx.NewNoop(), cfg, nil, &rest.Config{Host: "https://kubernetes.default"})
+	plugins := []config.Plugin{
+		{
+			Enabled: true,
+			Context: config.PluginContext{
+				RBAC: &config.PolicyRule{
+					User:  config.UserPolicySubject{Type: config.ChatUserPolicySubjectType},
+					Group: config.GroupPolicySubject{Type: config.ChatUserPolicySubjectType},
+				},
+			},
+		},
+	}
+
+	// when
+	execCtx, err := e.executeInputContext(context.Background(), plugins, nil, CommandContext{
+		User: UserInput{DisplayName: "Alice", Email: "alice@example.com"},
+	})
+
+	// then
+	require.NoError(t, err)
+	assert.Contains(t, string(execCtx.KubeConfig), "as: alice")
+	assert.Contains(t, string(execCtx.KubeConfig), "- sre")
+
+	// when
+	_, err = e.executeInputContext(context.Background(), plugins, nil, CommandContext{
+		User: UserInput{DisplayName: "Mallory", Email: "mallory@example.com"},
+	})
+
+	// then
+	require.Error(t, err)
+	assert.True(t, IsExecutionCommandError(err))
+	assert.Contains(t, err.Error(), `I can't map your chat user "Mallory" to a Kubernetes identity`)
+}
+
+func TestPluginExecutor_ExecuteInputContextServiceAccount(t *testing.T) {
+	// given
+	cfg := config.Config{
+		Settings: config.Settings{
+			IdentityMapping: config.IdentityMapping{
+				Users: map[string]config.KubernetesIdentity{
+					"alice@example.com": {User: "alice"},
+				},
+			},
+		},
+	}
+	e := NewPluginExecutor(loggerx.NewNoop(), cfg, nil, &rest.Config{Host: "https://kubernetes.default", BearerToken: "botkube-token"})
+	k8sCli := fake.NewSimpleClientset()
+	var gotName string
+	k8sCli.PrependReactor("create", "serviceaccounts", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		gotName = action.(k8stesting.CreateActionImpl).Name
+		req := action.(k8stesting.CreateAction).GetObject().(*authenticationv1.TokenRequest)
+		req.Status.Token = "minted-token"
+		return true, req, nil
+	})
+	e.k8sCli = k8sCli
+	plugins := []config.Plugin{
+		{
+			Enabled: true,
+			Context: config.PluginContext{
+				RBAC: &config.PolicyRule{
+					ServiceAccount: config.ServiceAccountPolicySubject{
+						Type:      config.ChatUserPolicySubjectType,
+						Prefix:    "chat-",
+						Namespace: "botkube",
+					},
+				},
+			},
+		},
+	}
+
+	// when
+	execCtx, err := e.executeInputContext(context.Background(), plugins, nil, CommandContext{
+		User: UserInput{DisplayName: "Alice", Email: "alice@example.com"},
+	})
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "chat-alice", gotName)
+	assert.Contains(t, string(execCtx.KubeConfig), "token: minted-token")
+	assert.NotContains(t, string(execCtx.KubeConfig), "botkube-token")
+}
@@ -0,0 +1,171 @@
+package execute
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/slack-go/slack"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/api/executor"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/execute/command"
+)
+
+// interactionCmdName is the name of the internal command which delivers interactions with plugin messages back to plugins.
+// Commands of elements with callbacks are rewritten to `interaction {plugin} {callback ID} {callback value}`, and communication
+// platforms append values selected or typed by the user, the same as for other commands.
+const interactionCmdName = "interaction"
+
+// interactionArgsNo is the number of interaction command arguments before the values provided by the user.
+const interactionArgsNo = 4
+
+func isInteractionCmd(args []string) bool {
+	return len(args) >= interactionArgsNo && args[0] == interactionCmdName
+}
+
+// interactionAuthzArgs returns arguments of the plugin command which a given interaction continues, so it's authorized with
+// the underlying verb. Callback IDs follow the `{verb}-{action}` convention, e.g. `interaction kubectl edit-apply {id}` is
+// authorized as `kubectl edit {id}`.
+func interactionAuthzArgs(args []string) []string {
+	verb, _, _ := strings.Cut(args[2], "-")
+	out := []string{args[1], verb}
+	return append(out, args[3:]...)
+}
+
+// CanHandleInteraction returns true if it's an interaction with a message returned by a known plugin executor.
+func (e *PluginExecutor) CanHandleInteraction(bindings []string, args []string) bool {
+	if !isInteractionCmd(args) {
+		return false
+	}
+
+	plugins, _ := e.getEnabledPlugins(bindings, args[1])
+	return len(plugins) > 0
+}
+
+// HandleInteraction delivers an interaction with a message element back to the plugin which returned the message.
+func (e *PluginExecutor) HandleInteraction(ctx context.Context, bindings []string, slackState *slack.BlockActionStates, cmdCtx CommandContext) (interactive.CoreMessage, error) {
+	e.log.WithFields(logrus.Fields{
+		"bindings": bindings,
+		"command":  cmdCtx.CleanCmd,
+	}).Debug("Handling plugin interaction...")
+
+	pluginName := cmdCtx.Args[1]
+	plugins, fullPluginName := e.getEnabledPlugins(bindings, pluginName)
+
+	configs, err := e.collectConfigs(plugins)
+	if err != nil {
+		return interactive.CoreMessage{}, fmt.Errorf("while collecting configs: %w", err)
+	}
+
+	execCtx, err := e.executeInputContext(ctx, plugins, slackState, cmdCtx)
+	if err != nil {
+		return interactive.CoreMessage{}, err
+	}
+
+	cli, err := e.pluginManager.GetExecutor(fullPluginName)
+	if err != nil {
+		return interactive.CoreMessage{}, fmt.Errorf("while getting concrete plugin client: %w", err)
+	}
+
+	handler, ok := cli.(executor.InteractionHandler)
+	if !ok {
+		return interactive.CoreMessage{}, NewExecutionCommandError(fmt.Sprintf("Plugin %q doesn't support interactive messages.", pluginName))
+	}
+
+	resp, err := handler.HandleInteraction(ctx, executor.InteractionInput{
+		Callback: api.Callback{
+			ID:    cmdCtx.Args[2],
+			Value: cmdCtx.Args[3],
+		},
+		Type:    executor.InteractionType(cmdCtx.Conversation.CommandOrigin),
+		Values:  interactionValues(cmdCtx.Conversation.CommandOrigin, cmdCtx.Args[interactionArgsNo:]),
+		Configs: configs,
+		Context: execCtx,
+	})
+	if err != nil {
+		s, ok := status.FromError(err)
+		if !ok {
+			return interactive.CoreMessage{}, NewExecutionCommandError(err.Error())
+		}
+		if s.Code() == codes.Unimplemented {
+			return interactive.CoreMessage{}, NewExecutionCommandError(fmt.Sprintf("Plugin %q doesn't support interactive messages.", pluginName))
+		}
+		return interactive.CoreMessage{}, NewExecutionCommandError(s.Message())
+	}
+
+	if resp.Message.Type == api.SkipMessage {
+		return interactive.CoreMessage{}, nil
+	}
+
+	if resp.Message.IsEmpty() {
+		return emptyMsg(cmdCtx), nil
+	}
+
+	bindCallbacks(&resp.Message, pluginName)
+	out := interactive.CoreMessage{
+		Message: resp.Message,
+	}
+	if !resp.Message.OnlyVisibleForYou {
+		out.Description = header(cmdCtx)
+	}
+	return out, nil
+}
+
+// interactionValues returns values selected or typed by the user, which were appended to the interaction command.
+func interactionValues(origin command.Origin, args []string) []string {
+	if len(args) == 0 {
+		return nil
+	}
+
+	switch origin {
+	case command.ButtonClickOrigin:
+		return nil
+	case command.SelectValueChangeOrigin:
+		// option values are appended without quotes
+		return []string{strings.Join(args, " ")}
+	case command.MultiSelectValueChangeOrigin:
+		return strings.Split(strings.Join(args, " "), ",")
+	default:
+		return args
+	}
+}
+
+// bindCallbacks rewrites commands of message elements with callbacks, so interactions with them are delivered back to a given plugin.
+func bindCallbacks(msg *api.Message, pluginName string) {
+	for idx := range msg.Sections {
+		section := &msg.Sections[idx]
+		for i, btn := range section.Buttons {
+			if btn.Callback != nil {
+				section.Buttons[i].Command = interactionCommand(pluginName, *btn.Callback)
+			}
+		}
+		for i, item := range section.Selects.Items {
+			if item.Callback != nil {
+				section.Selects.Items[i].Command = interactionCommand(pluginName, *item.Callback)
+			}
+		}
+		if section.MultiSelect.Callback != nil {
+			section.MultiSelect.Command = interactionCommand(pluginName, *section.MultiSelect.Callback)
+		}
+		bindInputCallbacks(section.PlaintextInputs, pluginName)
+	}
+	bindInputCallbacks(msg.PlaintextInputs, pluginName)
+}
+
+func bindInputCallbacks(inputs api.LabelInputs, pluginName string) {
+	for i, item := range inputs {
+		if item.Callback != nil {
+			inputs[i].Command = interactionCommand(pluginName, *item.Callback)
+		}
+	}
+}
+
+func interactionCommand(pluginName string, callback api.Callback) string {
+	// the whitespace at the end is required, as typed values are appended directly to the command of plain text inputs
+	return fmt.Sprintf("%s %s %s %q %q ", api.MessageBotNamePlaceholder, interactionCmdName, pluginName, callback.ID, callback.Value)
+}
@@ -0,0 +1,175 @@
+package execute
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/execute/command"
+)
+
+func TestBindCallbacks(t *testing.T) {
+	// given
+	msg := api.Message{
+		Sections: []api.Section{
+			{
+				Buttons: []api.Button{
+					{Name: "Approve", Callback: &api.Callback{ID: "approve", Value: "my release"}},
+					{Name: "Logs", Command: "{{BotName}} kubectl logs"},
+				},
+				Selects: api.Selects{
+					Items: []api.Select{
+						{Name: "Namespace", Callback: &api.Callback{ID: "namespace"}},
+					},
+				},
+				MultiSelect: api.MultiSelect{Name: "Resources", Callback: &api.Callback{ID: "resources"}},
+			},
+		},
+		PlaintextInputs: api.LabelInputs{
+			{Text: "Reason", Callback: &api.Callback{ID: "reason", Value: "my release"}},
+		},
+	}
+
+	// when
+	bindCallbacks(&msg, "helm")
+
+	// then
+	section := msg.Sections[0]
+	assert.Equal(t, `{{BotName}} interaction helm "approve" "my release" `, section.Buttons[0].Command)
+	assert.Equal(t, "{{BotName}} kubectl logs", section.Buttons[1].Command)
+	assert.Equal(t, `{{BotName}} interaction helm "namespace" "" `, section.Selects.Items[0].Command)
+	assert.Equal(t, `{{BotName}} interaction helm "resources" "" `, section.MultiSelect.Command)
+	assert.Equal(t, `{{BotName}} interaction helm "reason" "my release" `, msg.PlaintextInputs[0].Command)
+}
+
+func TestInteractionCommandRoundTrip(t *testing.T) {
+	testCases := []struct {
+		Name           string
+		Origin         command.Origin
+		AppendedByUser string
+		ExpectedValues []string
+	}{
+		{
+			Name:   "Button click",
+			Origin: command.ButtonClickOrigin,
+		},
+		{
+			Name:           "Select value change",
+			Origin:         command.SelectValueChangeOrigin,
+			AppendedByUser: " kube-system",
+			ExpectedValues: []string{"kube-system"},
+		},
+		{
+			Name:           "Multi-select value change",
+			Origin:         command.MultiSelectValueChangeOrigin,
+			AppendedByUser: " pods,deployments",
+			ExpectedValues: []string{"pods", "deployments"},
+		},
+		{
+			Name:           "Plain text input",
+			Origin:         command.PlainTextInputOrigin,
+			AppendedByUser: fmt.Sprintf("%q", "rollback after \"failed\" upgrade"),
+			ExpectedValues: []string{`rollback after "failed" upgrade`},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			// given
+			cmd := interactionCommand("helm", api.Callback{ID: "approve", Value: "my release"})
+			cmd = strings.TrimPrefix(cmd, api.MessageBotNamePlaceholder) + tc.AppendedByUser
+
+			// when
+			flags, err := ParseFlags(cmd)
+
+			// then
+			require.NoError(t, err)
+			require.True(t, isInteractionCmd(flags.TokenizedCmd))
+			assert.Equal(t, "helm", flags.TokenizedCmd[1])
+			assert.Equal(t, "approve", flags.TokenizedCmd[2])
+			assert.Equal(t, "my release", flags.TokenizedCmd[3])
+			assert.Equal(t, tc.ExpectedValues, interactionValues(tc.Origin, flags.TokenizedCmd[interactionArgsNo:]))
+		})
+	}
+}
+
+func TestPluginExecutor_CanHandleInteraction(t *testing.T) {
+	// given
+	p := &PluginExecutor{
+		cfg: config.Config{
+			Executors: map[string]config.Executors{
+				"helm": {
+					Plugins: config.Plugins{
+						"botkube/helm": config.Plugin{Enabled: true},
+					},
+				},
+				"disabled": {
+					Plugins: config.Plugins{
+						"botkube/echo": config.Plugin{Enabled: false},
+					},
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		Name     string
+		Bindings []string
+		Args     []string
+		Expected bool
+	}{
+		{
+			Name:     "Enabled plugin",
+			Bindings: []string{"helm"},
+			Args:     []string{"interaction", "helm", "approve", ""},
+			Expected: true,
+		},
+		{
+			Name:     "Plugin not bound to the channel",
+			Bindings: []string{"disabled"},
+			Args:     []string{"interaction", "helm", "approve", ""},
+			Expected: false,
+		},
+		{
+			Name:     "Disabled plugin",
+			Bindings: []string{"helm", "disabled"},
+			Args:     []string{"interaction", "echo", "approve", ""},
+			Expected: false,
+		},
+		{
+			Name:     "Missing callback",
+			Bindings: []string{"helm"},
+			Args:     []string{"interaction", "helm"},
+			Expected: false,
+		},
+		{
+			Name:     "Plugin command",
+			Bindings: []string{"helm"},
+			Args:     []string{"helm", "list"},
+			Expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			// when
+			out := p.CanHandleInteraction(tc.Bindings, tc.Args)
+
+			// then
+			assert.Equal(t, tc.Expected, out)
+		})
+	}
+}
+
+func TestInteractionAuthzArgs(t *testing.T) {
+	// when
+	args := interactionAuthzArgs([]string{interactionCmdName, "kubectl", "edit-apply", "4f2a9c1b"})
+
+	// then
+	assert.Equal(t, []string{"kubectl", "edit", "4f2a9c1b"}, args)
+}
@@ -0,0 +1,414 @@
+package execute
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/pflag"
+	"golang.org/x/exp/slices"
+	stringutil "k8s.io/utils/strings"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/execute/command"
+	"github.com/kubeshop/botkube/pkg/maputil"
+	"github.com/kubeshop/botkube/pkg/plugin"
+)
+
+const (
+	pluginsMarketplaceNotEnabled   = "Plugins are not enabled, so there are no plugin repositories to browse."
+	pluginsNoAvailable             = "There are no plugins available in the configured plugin repositories."
+	pluginsNoneMatching            = "There are no plugins matching %q."
+	pluginsQueryMissing            = "You need to specify the search phrase, e.g. `%s plugins search helm`."
+	pluginsKeyMissing              = "You need to specify the plugin, e.g. `%s plugins %s botkube/helm`."
+	pluginsUnknownCmd              = "Unknown plugins command. Use `%s plugins list`, `%[1]s plugins search {phrase}`, `%[1]s plugins info {plugin}` or `%[1]s plugins enable {plugin}`."
+	pluginsNotFound                = "The plugin %q is not available in the configured plugin repositories."
+	pluginsAmbiguousType           = "The plugin %q is available as %s. Specify its type with the `--type` flag."
+	pluginsVersionNotFound         = "The plugin %q is not available in the %s version. Available versions: %s."
+	pluginsUnsupportedType         = "Enabling %s plugins in chat is not supported. Update the configuration to enable the %q plugin."
+	pluginsEnableUnknownChannel    = "This channel is not defined in the Botkube configuration, so plugins cannot be enabled for it."
+	pluginsAlreadyEnabled          = "The %s plugin %q is already enabled for this channel in the %q group."
+	pluginsGroupTaken              = "Cannot enable the %s plugin %q, as the %q group is already used by other plugins. Update the configuration to enable it."
+	pluginsEnabledMsgFmt           = ":white_check_mark: %s enabled the %s plugin %q for this channel in the %q group. Expect Botkube reload in a few seconds...\nTo adjust its configuration, use `%s config plugin %[2]s %[4]s %[3]s`."
+	pluginsEnabledWithoutReloadFmt = ":white_check_mark: %s enabled the %s plugin %q for this channel in the %q group.\nAs the Config Watcher is disabled, you need to restart Botkube manually to apply the changes. To adjust its configuration, use `%s config plugin %[2]s %[4]s %[3]s`."
+	pluginsTypeFlagArg             = "type"
+	pluginsDescriptionCharCount    = 60
+
+	pluginsListFeature   = "list"
+	pluginsSearchFeature = "search"
+	pluginsInfoFeature   = "info"
+	pluginsEnableFeature = "enable"
+)
+
+var pluginsFeatureName = FeatureName{
+	Name:    pluginsSearchFeature,
+	Aliases: []string{pluginsListFeature, pluginsInfoFeature, pluginsEnableFeature, noFeature},
+}
+
+// PluginMarketplace lists plugins available in configured plugin repositories.
+type PluginMarketplace interface {
+	AvailablePlugins(ctx context.Context) ([]plugin.AvailablePlugin, error)
+	AvailablePluginConfigSchema(ctx context.Context, p plugin.AvailablePlugin) (json.RawMessage, error)
+}
+
+// PluginEnableStorage provides functionality to persist plugins enabled at runtime.
+type PluginEnableStorage interface {
+	PersistPluginEnabled(ctx context.Context, pluginType, group, pluginKey, commGroupName string, platform config.CommPlatformIntegration, channelAlias string, bindings config.ChannelRuntimeBindings) error
+}
+
+// PluginMarketplaceExecutor browses plugins available in configured plugin repositories and enables them for the current channel.
+// Enabled plugins are persisted in the runtime configuration, and are started once Botkube reloads the configuration.
+type PluginMarketplaceExecutor struct {
+	log         logrus.FieldLogger
+	cfg         config.Config
+	marketplace PluginMarketplace
+	cfgManager  PluginEnableStorage
+	cfgHistory  ConfigHistoryStore
+}
+
+// NewPluginMarketplaceExecutor returns a new PluginMarketplaceExecutor instance. The marketplace is nil if plugins are not enabled,
+// and the cfgHistory is nil if the configuration history is disabled.
+func NewPluginMarketplaceExecutor(log logrus.FieldLogger, marketplace PluginMarketplace, cfgManager PluginEnableStorage, cfgHistory ConfigHistoryStore, cfg config.Config) *PluginMarketplaceExecutor {
+	return &PluginMarketplaceExecutor{
+		log:         log,
+		cfg:         cfg,
+		marketplace: marketplace,
+		cfgManager:  cfgManager,
+		cfgHistory:  cfgHistory,
+	}
+}
+
+// FeatureName returns the name and aliases of the feature provided by this executor
+func (e *PluginMarketplaceExecutor) FeatureName() FeatureName {
+	return pluginsFeatureName
+}
+
+// Commands returns slice of commands the executor supports
+func (e *PluginMarketplaceExecutor) Commands() map[command.Verb]CommandFn {
+	return map[command.Verb]CommandFn{
+		command.PluginsVerb: e.Plugins,
+	}
+}
+
+// Plugins lists, searches, describes or enables plugins available in configured plugin repositories, e.g. `plugins search helm`.
+func (e *PluginMarketplaceExecutor) Plugins(ctx context.Context, cmdCtx CommandContext) (interactive.CoreMessage, error) {
+	if e.marketplace == nil {
+		return respond(pluginsMarketplaceNotEnabled, cmdCtx), nil
+	}
+
+	var feature string
+	if len(cmdCtx.Args) > 1 {
+		feature = strings.ToLower(cmdCtx.Args[1])
+	}
+
+	switch feature {
+	case pluginsListFeature, noFeature:
+		return e.list(ctx, cmdCtx, "")
+	case pluginsSearchFeature:
+		query := strings.TrimSpace(strings.Join(cmdCtx.Args[2:], " "))
+		if query == "" {
+			return respond(fmt.Sprintf(pluginsQueryMissing, api.MessageBotNamePlaceholder), cmdCtx), nil
+		}
+		return e.list(ctx, cmdCtx, query)
+	case pluginsInfoFeature:
+		return e.info(ctx, cmdCtx)
+	case pluginsEnableFeature:
+		return e.enable(ctx, cmdCtx)
+	default:
+		return respond(fmt.Sprintf(pluginsUnknownCmd, api.MessageBotNamePlaceholder), cmdCtx), nil
+	}
+}
+
+func (e *PluginMarketplaceExecutor) list(ctx context.Context, cmdCtx CommandContext, query string) (interactive.CoreMessage, error) {
+	available, err := e.marketplace.AvailablePlugins(ctx)
+	if err != nil {
+		return interactive.CoreMessage{}, fmt.Errorf("while listing available plugins: %w", err)
+	}
+	if len(available) == 0 {
+		return respond(pluginsNoAvailable, cmdCtx), nil
+	}
+
+	var matching []plugin.AvailablePlugin
+	for _, p := range available {
+		if query != "" && !matchesPluginQuery(p, query) {
+			continue
+		}
+		matching = append(matching, p)
+	}
+	if len(matching) == 0 {
+		return respond(fmt.Sprintf(pluginsNoneMatching, query), cmdCtx), nil
+	}
+	return respond(availablePluginTabularOutput(matching), cmdCtx), nil
+}
+
+func (e *PluginMarketplaceExecutor) info(ctx context.Context, cmdCtx CommandContext) (interactive.CoreMessage, error) {
+	p, _, msg, err := e.findPlugin(ctx, cmdCtx, pluginsInfoFeature)
+	if err != nil {
+		return interactive.CoreMessage{}, err
+	}
+	if msg != "" {
+		return respond(msg, cmdCtx), nil
+	}
+
+	schema, err := e.marketplace.AvailablePluginConfigSchema(ctx, p)
+	if err != nil {
+		return interactive.CoreMessage{}, fmt.Errorf("while getting JSON schema of the %s plugin %q: %w", p.Type, p.Key, err)
+	}
+
+	details := []string{
+		fmt.Sprintf("*Type:* %s", p.Type),
+		fmt.Sprintf("*Description:* %s", valueOrDefault(p.Description, pluginNoValue)),
+		fmt.Sprintf("*Versions:* %s", strings.Join(p.Versions, ", ")),
+	}
+	if p.DocumentationURL != "" {
+		details = append(details, fmt.Sprintf("*Documentation:* %s", p.DocumentationURL))
+	}
+
+	sections := []api.Section{
+		{
+			Base: api.Base{
+				Description: strings.Join(details, "\n"),
+			},
+		},
+		{
+			Base: api.Base{
+				Description: configSchemaSummary(schema),
+			},
+		},
+	}
+	if p.Type != plugin.TypeProcessor {
+		btnBuilder := api.NewMessageButtonBuilder()
+		sections[len(sections)-1].Buttons = api.Buttons{
+			btnBuilder.ForCommandWithoutDesc("Enable for this channel", fmt.Sprintf("%s %s %s --%s %s", command.PluginsVerb, pluginsEnableFeature, p.Key, pluginsTypeFlagArg, p.Type), api.ButtonStylePrimary),
+		}
+	}
+
+	return interactive.CoreMessage{
+		Header: fmt.Sprintf("Plugin %s", p.Key),
+		Message: api.Message{
+			OnlyVisibleForYou: true,
+			Sections:          sections,
+		},
+	}, nil
+}
+
+func (e *PluginMarketplaceExecutor) enable(ctx context.Context, cmdCtx CommandContext) (interactive.CoreMessage, error) {
+	if cmdCtx.Conversation.Alias == "" {
+		return plaintextMessage(pluginsEnableUnknownChannel), nil
+	}
+
+	p, version, msg, err := e.findPlugin(ctx, cmdCtx, pluginsEnableFeature)
+	if err != nil {
+		return interactive.CoreMessage{}, err
+	}
+	if msg != "" {
+		return plaintextMessage(msg), nil
+	}
+	if p.Type == plugin.TypeProcessor {
+		return plaintextMessage(fmt.Sprintf(pluginsUnsupportedType, p.Type, p.Key)), nil
+	}
+
+	groups := e.pluginGroups(p.Type)
+	group, found := groupWithPlugin(groups, p.Key)
+	if found && groups[group][existingPluginKey(groups[group], p.Key)].Enabled && slices.Contains(channelBindings(cmdCtx, p.Type), group) {
+		return plaintextMessage(fmt.Sprintf(pluginsAlreadyEnabled, p.Type, p.Key, group)), nil
+	}
+
+	pluginKey := p.Key
+	if found {
+		pluginKey = existingPluginKey(groups[group], p.Key)
+	} else {
+		group = strings.ReplaceAll(p.Key, "/", "-")
+		if len(groups[group]) > 0 {
+			return plaintextMessage(fmt.Sprintf(pluginsGroupTaken, p.Type, p.Key, group)), nil
+		}
+		if version != "" {
+			pluginKey = fmt.Sprintf("%s@%s", p.Key, version)
+		}
+	}
+
+	bindings := config.ChannelRuntimeBindings{
+		Sources:   cmdCtx.Conversation.SourceBindings,
+		Executors: cmdCtx.Conversation.ExecutorBindings,
+	}
+	switch p.Type {
+	case plugin.TypeSource:
+		bindings.Sources = appendIfMissing(bindings.Sources, group)
+	case plugin.TypeExecutor:
+		bindings.Executors = appendIfMissing(bindings.Executors, group)
+	}
+
+	err = e.cfgManager.PersistPluginEnabled(ctx, string(p.Type), group, pluginKey, cmdCtx.CommGroupName, cmdCtx.Platform, cmdCtx.Conversation.Alias, bindings)
+	if msg, ok := persistErrMessage(err); ok {
+		return plaintextMessage(msg), nil
+	}
+	if err != nil {
+		return interactive.CoreMessage{}, fmt.Errorf("while persisting enabled plugin: %w", err)
+	}
+
+	user := userMention(cmdCtx)
+	e.log.WithFields(logrus.Fields{
+		"plugin": pluginKey,
+		"type":   p.Type,
+		"group":  group,
+	}).Infof("Plugin enabled by %s", user)
+
+	if e.cfgHistory != nil {
+		// the change is recorded in the configuration history once it's applied
+		if err := e.cfgHistory.SetTrigger(ctx, fmt.Sprintf("`%s` by %s", cmdCtx.CleanCmd, user)); err != nil {
+			e.log.WithError(err).Warn("Cannot describe the configuration change in the configuration history")
+		}
+	}
+
+	msgFmt := pluginsEnabledMsgFmt
+	if !e.cfg.ConfigWatcher.Enabled {
+		msgFmt = pluginsEnabledWithoutReloadFmt
+	}
+	return plaintextMessage(fmt.Sprintf(msgFmt, user, p.Type, pluginKey, group, api.MessageBotNamePlaceholder)), nil
+}
+
+// findPlugin returns the available plugin and the optional version specified in command arguments, e.g. `plugins info botkube/helm@v1.0.0 --type executor`.
+// If the plugin cannot be found, a message for the user is returned.
+func (e *PluginMarketplaceExecutor) findPlugin(ctx context.Context, cmdCtx CommandContext, feature string) (plugin.AvailablePlugin, string, string, error) {
+	var pluginType string
+	flags := pflag.NewFlagSet("plugins", pflag.ContinueOnError)
+	flags.StringVar(&pluginType, pluginsTypeFlagArg, "", "Plugin type")
+	if err := flags.Parse(cmdCtx.Args[2:]); err != nil {
+		return plugin.AvailablePlugin{}, "", fmt.Sprintf("Cannot parse command: %s", err.Error()), nil
+	}
+	if flags.NArg() == 0 {
+		return plugin.AvailablePlugin{}, "", fmt.Sprintf(pluginsKeyMissing, api.MessageBotNamePlaceholder, feature), nil
+	}
+	key, version, _ := strings.Cut(flags.Arg(0), "@")
+
+	available, err := e.marketplace.AvailablePlugins(ctx)
+	if err != nil {
+		return plugin.AvailablePlugin{}, "", "", fmt.Errorf("while listing available plugins: %w", err)
+	}
+
+	var (
+		candidates []plugin.AvailablePlugin
+		types      []string
+	)
+	for _, p := range available {
+		if p.Key != key || (pluginType != "" && string(p.Type) != pluginType) {
+			continue
+		}
+		candidates = append(candidates, p)
+		types = append(types, string(p.Type))
+	}
+	switch len(candidates) {
+	case 0:
+		return plugin.AvailablePlugin{}, "", fmt.Sprintf(pluginsNotFound, key), nil
+	case 1:
+	default:
+		return plugin.AvailablePlugin{}, "", fmt.Sprintf(pluginsAmbiguousType, key, strings.Join(types, " and ")), nil
+	}
+
+	p := candidates[0]
+	if version != "" && !slices.Contains(p.Versions, version) {
+		return plugin.AvailablePlugin{}, "", fmt.Sprintf(pluginsVersionNotFound, key, version, strings.Join(p.Versions, ", ")), nil
+	}
+	return p, version, "", nil
+}
+
+func (e *PluginMarketplaceExecutor) pluginGroups(pluginType plugin.Type) map[string]config.Plugins {
+	out := map[string]config.Plugins{}
+	switch pluginType {
+	case plugin.TypeExecutor:
+		for name, group := range e.cfg.Executors {
+			out[name] = group.Plugins
+		}
+	case plugin.TypeSource:
+		for name, group := range e.cfg.Sources {
+			out[name] = group.Plugins
+		}
+	}
+	return out
+}
+
+// groupWithPlugin returns the first group, sorted by name, which defines a given plugin in any version.
+func groupWithPlugin(groups map[string]config.Plugins, key string) (string, bool) {
+	for _, name := range maputil.SortKeys(groups) {
+		if existingPluginKey(groups[name], key) != "" {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// existingPluginKey returns the key, including the optional version, under which a given plugin is defined.
+func existingPluginKey(plugins config.Plugins, key string) string {
+	for _, existing := range maputil.SortKeys(plugins) {
+		repo, name, _, err := config.DecomposePluginKey(existing)
+		if err != nil {
+			continue
+		}
+		if fmt.Sprintf("%s/%s", repo, name) == key {
+			return existing
+		}
+	}
+	return ""
+}
+
+func channelBindings(cmdCtx CommandContext, pluginType plugin.Type) []string {
+	if pluginType == plugin.TypeSource {
+		return cmdCtx.Conversation.SourceBindings
+	}
+	return cmdCtx.Conversation.ExecutorBindings
+}
+
+func matchesPluginQuery(p plugin.AvailablePlugin, query string) bool {
+	query = strings.ToLower(query)
+	return strings.Contains(strings.ToLower(p.Key), query) || strings.Contains(strings.ToLower(p.Description), query)
+}
+
+// configSchemaSummary returns top-level properties of the plugin configuration JSON schema.
+func configSchemaSummary(schema json.RawMessage) string {
+	if schema == nil {
+		return "The plugin doesn't define the JSON schema of its configuration."
+	}
+	props, err := parseConfigSchemaProperties(schema)
+	if err != nil || len(props) == 0 {
+		return "The plugin configuration doesn't define any properties."
+	}
+
+	lines := []string{"*Configuration properties:*"}
+	for _, name := range maputil.SortKeys(props) {
+		prop := props[name]
+		line := fmt.Sprintf("• `%s` (%s)", name, prop.TypeName())
+		if label := prop.Label(name); label != name {
+			line += " " + label
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func availablePluginTabularOutput(plugins []plugin.AvailablePlugin) string {
+	buf := new(bytes.Buffer)
+	w := tabwriter.NewWriter(buf, 5, 0, 1, ' ', 0)
+	fmt.Fprintf(w, "PLUGIN\tTYPE\tLATEST\tDESCRIPTION")
+	for _, p := range plugins {
+		desc := stringutil.ShortenString(strings.TrimSpace(p.Description), pluginsDescriptionCharCount)
+		fmt.Fprintf(w, "\n%s\t%s\t%s\t%s", p.Key, p.Type, valueOrDefault(p.LatestVersion(), pluginNoLatestVersion), valueOrDefault(desc, pluginNoValue))
+	}
+
+	w.Flush()
+	return buf.String()
+}
+
+func appendIfMissing(in []string, item string) []string {
+	if slices.Contains(in, item) {
+		return in
+	}
+	out := make([]string, 0, len(in)+1)
+	out = append(out, in...)
+	return append(out, item)
+}
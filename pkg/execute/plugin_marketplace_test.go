@@ -0,0 +1,278 @@
+package execute
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+	"github.com/kubeshop/botkube/pkg/plugin"
+)
+
+var testAvailablePlugins = []plugin.AvailablePlugin{
+	{Key: "botkube/helm", Type: plugin.TypeExecutor, Description: "Runs Helm CLI commands.", DocumentationURL: "https://docs.botkube.io/helm", Versions: []string{"v1.1.0", "v1.0.0"}},
+	{Key: "botkube/kubernetes", Type: plugin.TypeSource, Description: "Consumes Kubernetes events.", Versions: []string{"v1.0.0"}},
+	{Key: "botkube/owner-enricher", Type: plugin.TypeProcessor, Description: "Enriches events with owner references.", Versions: []string{"v1.0.0"}},
+	{Key: "mszostok/echo", Type: plugin.TypeExecutor, Description: "Sends back the command.", Versions: []string{"v1.0.0"}},
+	{Key: "mszostok/echo", Type: plugin.TypeSource, Description: "Emits echo events.", Versions: []string{"v1.0.0"}},
+}
+
+type fakePluginMarketplace struct {
+	plugins []plugin.AvailablePlugin
+	schemas map[string]string
+}
+
+func (f *fakePluginMarketplace) AvailablePlugins(context.Context) ([]plugin.AvailablePlugin, error) {
+	return f.plugins, nil
+}
+
+func (f *fakePluginMarketplace) AvailablePluginConfigSchema(_ context.Context, p plugin.AvailablePlugin) (json.RawMessage, error) {
+	schema, found := f.schemas[p.Key]
+	if !found {
+		return nil, nil
+	}
+	return json.RawMessage(schema), nil
+}
+
+type fakePluginEnableStorage struct {
+	err       error
+	persisted []string
+	bindings  *config.ChannelRuntimeBindings
+}
+
+func (f *fakePluginEnableStorage) PersistPluginEnabled(_ context.Context, pluginType, group, pluginKey, _ string, _ config.CommPlatformIntegration, _ string, bindings config.ChannelRuntimeBindings) error {
+	f.persisted = []string{pluginType, group, pluginKey}
+	f.bindings = &bindings
+	return f.err
+}
+
+func TestPluginMarketplaceExecutorList(t *testing.T) {
+	tests := []struct {
+		name         string
+		args         string
+		expectedBody string
+	}{
+		{
+			name: "list",
+			args: "plugins",
+			expectedBody: heredoc.Doc(`
+				PLUGIN                 TYPE      LATEST DESCRIPTION
+				botkube/helm           executor  v1.1.0 Runs Helm CLI commands.
+				botkube/kubernetes     source    v1.0.0 Consumes Kubernetes events.
+				botkube/owner-enricher processor v1.0.0 Enriches events with owner references.
+				mszostok/echo          executor  v1.0.0 Sends back the command.
+				mszostok/echo          source    v1.0.0 Emits echo events.`),
+		},
+		{
+			name: "search by key and description",
+			args: "plugins search KUBERNETES",
+			expectedBody: heredoc.Doc(`
+				PLUGIN             TYPE   LATEST DESCRIPTION
+				botkube/kubernetes source v1.0.0 Consumes Kubernetes events.`),
+		},
+		{
+			name:         "search without matches",
+			args:         "plugins search prometheus",
+			expectedBody: `There are no plugins matching "prometheus".`,
+		},
+		{
+			name:         "search without phrase",
+			args:         "plugins search",
+			expectedBody: "You need to specify the search phrase, e.g. `{{BotName}} plugins search helm`.",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			e := NewPluginMarketplaceExecutor(loggerx.NewNoop(), &fakePluginMarketplace{plugins: testAvailablePlugins}, &fakePluginEnableStorage{}, nil, config.Config{})
+			cmdCtx := CommandContext{
+				Args:           strings.Fields(tc.args),
+				ExecutorFilter: newExecutorTextFilter(""),
+			}
+
+			// when
+			msg, err := e.Plugins(context.Background(), cmdCtx)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedBody, msg.BaseBody.CodeBlock)
+		})
+	}
+}
+
+func TestPluginMarketplaceExecutorInfo(t *testing.T) {
+	// given
+	marketplace := &fakePluginMarketplace{
+		plugins: testAvailablePlugins,
+		schemas: map[string]string{"botkube/helm": testPluginConfigSchema},
+	}
+	e := NewPluginMarketplaceExecutor(loggerx.NewNoop(), marketplace, &fakePluginEnableStorage{}, nil, config.Config{})
+
+	// when
+	msg, err := e.Plugins(context.Background(), CommandContext{
+		Args:           strings.Fields("plugins info botkube/helm"),
+		ExecutorFilter: newExecutorTextFilter(""),
+	})
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, msg.Sections, 2)
+	assert.Equal(t, "Plugin botkube/helm", msg.Header)
+	assert.Equal(t, heredoc.Doc(`
+		*Type:* executor
+		*Description:* Runs Helm CLI commands.
+		*Versions:* v1.1.0, v1.0.0
+		*Documentation:* https://docs.botkube.io/helm`), msg.Sections[0].Description)
+	assert.Contains(t, msg.Sections[1].Description, "• `defaultNamespace` (string) Default namespace")
+	assert.Contains(t, msg.Sections[1].Description, "• `labels` (object)")
+	require.Len(t, msg.Sections[1].Buttons, 1)
+	assert.Equal(t, "{{BotName}} plugins enable botkube/helm --type executor", msg.Sections[1].Buttons[0].Command)
+}
+
+func TestPluginMarketplaceExecutorEnable(t *testing.T) {
+	// given
+	cfg := config.Config{
+		ConfigWatcher: config.CfgWatcher{Enabled: true},
+		Executors: map[string]config.Executors{
+			"helm": {
+				Plugins: config.Plugins{
+					"botkube/helm@v1.0.0": {Enabled: true},
+				},
+			},
+			"mszostok-echo": {
+				Plugins: config.Plugins{
+					"mszostok/other": {Enabled: true},
+				},
+			},
+		},
+		Sources: map[string]config.Sources{
+			"k8s": {
+				Plugins: config.Plugins{
+					"botkube/kubernetes": {Enabled: false},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name              string
+		args              string
+		executorBindings  []string
+		storageErr        error
+		expectedMsg       string
+		expectedPersisted []string
+		expectedBindings  *config.ChannelRuntimeBindings
+	}{
+		{
+			name:              "bind existing group",
+			args:              "plugins enable botkube/helm",
+			expectedMsg:       ":white_check_mark: @Joe enabled the executor plugin \"botkube/helm@v1.0.0\" for this channel in the \"helm\" group. Expect Botkube reload in a few seconds...\nTo adjust its configuration, use `{{BotName}} config plugin executor helm botkube/helm@v1.0.0`.",
+			expectedPersisted: []string{"executor", "helm", "botkube/helm@v1.0.0"},
+			expectedBindings:  &config.ChannelRuntimeBindings{Sources: []string{"k8s-events"}, Executors: []string{"kubectl", "helm"}},
+		},
+		{
+			name:              "enable disabled source",
+			args:              "plugins enable botkube/kubernetes",
+			expectedMsg:       ":white_check_mark: @Joe enabled the source plugin \"botkube/kubernetes\" for this channel in the \"k8s\" group.",
+			expectedPersisted: []string{"source", "k8s", "botkube/kubernetes"},
+			expectedBindings:  &config.ChannelRuntimeBindings{Sources: []string{"k8s-events", "k8s"}, Executors: []string{"kubectl"}},
+		},
+		{
+			name:             "already enabled",
+			args:             "plugins enable botkube/helm",
+			executorBindings: []string{"kubectl", "helm"},
+			expectedMsg:      `The executor plugin "botkube/helm" is already enabled for this channel in the "helm" group.`,
+		},
+		{
+			name:              "create new group with version",
+			args:              "plugins enable mszostok/echo@v1.0.0 --type source",
+			expectedMsg:       ":white_check_mark: @Joe enabled the source plugin \"mszostok/echo@v1.0.0\" for this channel in the \"mszostok-echo\" group.",
+			expectedPersisted: []string{"source", "mszostok-echo", "mszostok/echo@v1.0.0"},
+			expectedBindings:  &config.ChannelRuntimeBindings{Sources: []string{"k8s-events", "mszostok-echo"}, Executors: []string{"kubectl"}},
+		},
+		{
+			name:        "group used by other plugins",
+			args:        "plugins enable mszostok/echo --type executor",
+			expectedMsg: `Cannot enable the executor plugin "mszostok/echo", as the "mszostok-echo" group is already used by other plugins.`,
+		},
+		{
+			name:        "ambiguous type",
+			args:        "plugins enable mszostok/echo",
+			expectedMsg: "The plugin \"mszostok/echo\" is available as executor and source. Specify its type with the `--type` flag.",
+		},
+		{
+			name:        "unknown version",
+			args:        "plugins enable botkube/helm@v2.0.0",
+			expectedMsg: `The plugin "botkube/helm" is not available in the v2.0.0 version. Available versions: v1.1.0, v1.0.0.`,
+		},
+		{
+			name:        "unknown plugin",
+			args:        "plugins enable botkube/prometheus",
+			expectedMsg: `The plugin "botkube/prometheus" is not available in the configured plugin repositories.`,
+		},
+		{
+			name:        "processor",
+			args:        "plugins enable botkube/owner-enricher",
+			expectedMsg: `Enabling processor plugins in chat is not supported.`,
+		},
+		{
+			name:              "remote configuration",
+			args:              "plugins enable botkube/kubernetes",
+			storageErr:        config.ErrUnsupportedRemotePersistence,
+			expectedMsg:       ":exclamation: this change cannot be persisted in the remote configuration, use Botkube Cloud to change it.",
+			expectedPersisted: []string{"source", "k8s", "botkube/kubernetes"},
+			expectedBindings:  &config.ChannelRuntimeBindings{Sources: []string{"k8s-events", "k8s"}, Executors: []string{"kubectl"}},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			storage := &fakePluginEnableStorage{err: tc.storageErr}
+			e := NewPluginMarketplaceExecutor(loggerx.NewNoop(), &fakePluginMarketplace{plugins: testAvailablePlugins}, storage, nil, cfg)
+
+			executorBindings := tc.executorBindings
+			if executorBindings == nil {
+				executorBindings = []string{"kubectl"}
+			}
+			cmdCtx := CommandContext{
+				Args: strings.Fields(tc.args),
+				User: UserInput{Mention: "@Joe"},
+				Conversation: Conversation{
+					Alias:            "alerts",
+					SourceBindings:   []string{"k8s-events"},
+					ExecutorBindings: executorBindings,
+				},
+			}
+
+			// when
+			msg, err := e.Plugins(context.Background(), cmdCtx)
+
+			// then
+			require.NoError(t, err)
+			assert.True(t, strings.HasPrefix(msg.BaseBody.Plaintext, tc.expectedMsg), msg.BaseBody.Plaintext)
+			assert.Equal(t, tc.expectedPersisted, storage.persisted)
+			assert.Equal(t, tc.expectedBindings, storage.bindings)
+		})
+	}
+}
+
+func TestPluginMarketplaceExecutorUnknownChannel(t *testing.T) {
+	// given
+	storage := &fakePluginEnableStorage{}
+	e := NewPluginMarketplaceExecutor(loggerx.NewNoop(), &fakePluginMarketplace{plugins: testAvailablePlugins}, storage, nil, config.Config{})
+
+	// when
+	msg, err := e.Plugins(context.Background(), CommandContext{
+		Args: strings.Fields("plugins enable botkube/helm"),
+	})
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "This channel is not defined in the Botkube configuration, so plugins cannot be enabled for it.", msg.BaseBody.Plaintext)
+	assert.Nil(t, storage.persisted)
+}
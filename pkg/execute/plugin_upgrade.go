@@ -0,0 +1,176 @@
+package execute
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/pflag"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/execute/command"
+	"github.com/kubeshop/botkube/pkg/plugin"
+)
+
+const (
+	pluginsNotEnabled     = "There are no plugins enabled."
+	pluginKeyMissing      = "You need to specify the plugin, e.g. `%s upgrade plugin botkube/kubernetes --version v1.0.0`. Skip the version to upgrade to the latest one."
+	pluginUpToDate        = "Plugin %q is already running the requested version."
+	pluginNotFound        = "Cannot upgrade plugin: %s."
+	pluginUpgraded        = "%s upgraded %s plugin %q from %s to %s. The old version is stopped once it processes in-flight requests. The upgrade is kept until Botkube restarts; to make it permanent, update the plugin version in the configuration."
+	pluginVersionFlagArg  = "version"
+	pluginNoValue         = "-"
+	pluginNoLatestVersion = "unknown"
+)
+
+var pluginFeatureName = FeatureName{
+	Name:    "plugin",
+	Aliases: []string{"plugins"},
+}
+
+// PluginUpgrader upgrades running plugins.
+type PluginUpgrader interface {
+	RunningPlugins() []plugin.RunningPlugin
+	Upgrade(ctx context.Context, pluginKey, version string) (plugin.UpgradeOutput, error)
+}
+
+// PluginUpgradeExecutor executes all commands that are related to upgrading running plugins.
+type PluginUpgradeExecutor struct {
+	log      logrus.FieldLogger
+	upgrader PluginUpgrader
+}
+
+// NewPluginUpgradeExecutor returns a new PluginUpgradeExecutor instance.
+func NewPluginUpgradeExecutor(log logrus.FieldLogger, upgrader PluginUpgrader) *PluginUpgradeExecutor {
+	return &PluginUpgradeExecutor{
+		log:      log,
+		upgrader: upgrader,
+	}
+}
+
+// FeatureName returns the name and aliases of the feature provided by this executor
+func (e *PluginUpgradeExecutor) FeatureName() FeatureName {
+	return pluginFeatureName
+}
+
+// Commands returns slice of commands the executor supports
+func (e *PluginUpgradeExecutor) Commands() map[command.Verb]CommandFn {
+	return map[command.Verb]CommandFn{
+		command.ListVerb:    e.List,
+		command.StatusVerb:  e.Status,
+		command.UpgradeVerb: e.Upgrade,
+	}
+}
+
+// List returns a tabular representation of running plugins with their versions.
+func (e *PluginUpgradeExecutor) List(_ context.Context, cmdCtx CommandContext) (interactive.CoreMessage, error) {
+	if e.upgrader == nil {
+		return respond(pluginsNotEnabled, cmdCtx), nil
+	}
+
+	plugins := e.upgrader.RunningPlugins()
+	if len(plugins) == 0 {
+		return respond(pluginsNotEnabled, cmdCtx), nil
+	}
+	return respond(pluginTabularOutput(plugins), cmdCtx), nil
+}
+
+// Status returns a tabular representation of health of running plugins.
+func (e *PluginUpgradeExecutor) Status(_ context.Context, cmdCtx CommandContext) (interactive.CoreMessage, error) {
+	plugins := cmdCtx.PluginHealthStats.List()
+	if len(plugins) == 0 {
+		return respond(pluginsNotEnabled, cmdCtx), nil
+	}
+	return respond(pluginHealthTabularOutput(plugins), cmdCtx), nil
+}
+
+// Upgrade upgrades a given plugin to the version specified with the `--version` flag, or to the latest one.
+func (e *PluginUpgradeExecutor) Upgrade(ctx context.Context, cmdCtx CommandContext) (interactive.CoreMessage, error) {
+	if e.upgrader == nil {
+		return respond(pluginsNotEnabled, cmdCtx), nil
+	}
+
+	args, version, err := parsePluginUpgradeArgs(cmdCtx.Args)
+	if err != nil {
+		return respond(fmt.Sprintf("Cannot parse command: %s", err.Error()), cmdCtx), nil
+	}
+	if len(args) == 0 {
+		return respond(fmt.Sprintf(pluginKeyMissing, api.MessageBotNamePlaceholder), cmdCtx), nil
+	}
+
+	key := args[0]
+	out, err := e.upgrader.Upgrade(ctx, key, version)
+	switch {
+	case err == nil:
+	case errors.Is(err, plugin.ErrPluginUpToDate):
+		return respond(fmt.Sprintf(pluginUpToDate, key), cmdCtx), nil
+	case plugin.IsNotFoundError(err):
+		return respond(fmt.Sprintf(pluginNotFound, err.Error()), cmdCtx), nil
+	default:
+		return interactive.CoreMessage{}, fmt.Errorf("while upgrading plugin %q: %w", key, err)
+	}
+
+	e.log.WithFields(logrus.Fields{
+		"plugin":      out.Key,
+		"fromVersion": out.FromVersion,
+		"toVersion":   out.ToVersion,
+	}).Infof("Plugin upgraded by %s", cmdCtx.User.DisplayName)
+
+	return respond(fmt.Sprintf(pluginUpgraded, cmdCtx.User.Mention, out.Type, out.Key, valueOrDefault(out.FromVersion, pluginNoValue), out.ToVersion), cmdCtx), nil
+}
+
+// parsePluginUpgradeArgs returns arguments following the `upgrade plugin` command and the `--version` flag.
+func parsePluginUpgradeArgs(args []string) ([]string, string, error) {
+	var version string
+	flags := pflag.NewFlagSet("plugin", pflag.ContinueOnError)
+	flags.StringVar(&version, pluginVersionFlagArg, "", "Plugin version")
+
+	var rest []string
+	if len(args) > 2 {
+		rest = args[2:]
+	}
+	if err := flags.Parse(rest); err != nil {
+		return nil, "", err
+	}
+	return flags.Args(), version, nil
+}
+
+func pluginTabularOutput(plugins []plugin.RunningPlugin) string {
+	buf := new(bytes.Buffer)
+	w := tabwriter.NewWriter(buf, 5, 0, 1, ' ', 0)
+	fmt.Fprintf(w, "PLUGIN\tTYPE\tVERSION\tLATEST")
+	for _, p := range plugins {
+		fmt.Fprintf(w, "\n%s\t%s\t%s\t%s", p.Key, p.Type, valueOrDefault(p.Version, pluginNoValue), valueOrDefault(p.LatestVersion, pluginNoLatestVersion))
+	}
+
+	w.Flush()
+	return buf.String()
+}
+
+func pluginHealthTabularOutput(plugins []plugin.PluginHealth) string {
+	buf := new(bytes.Buffer)
+	w := tabwriter.NewWriter(buf, 5, 0, 1, ' ', 0)
+	fmt.Fprintf(w, "PLUGIN\tSTATUS\tRESTARTS\tLAST_RESTART\tNEXT_RESTART")
+	for _, p := range plugins {
+		nextRestart := pluginNoValue
+		if !p.NextRestartTime.IsZero() {
+			nextRestart = p.NextRestartTime.UTC().Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "\n%s\t%s\t%d/%d\t%s\t%s", p.Name, p.Status, p.Restarts, p.Threshold, valueOrDefault(p.LastTransitionTime, pluginNoValue), nextRestart)
+	}
+
+	w.Flush()
+	return buf.String()
+}
+
+func valueOrDefault(version, def string) string {
+	if version == "" {
+		return def
+	}
+	return version
+}
@@ -0,0 +1,180 @@
+package execute
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/loggerx"
+	"github.com/kubeshop/botkube/pkg/plugin"
+)
+
+func TestPluginUpgradeExecutor(t *testing.T) {
+	// given
+	upgrader := &fakePluginUpgrader{
+		plugins: []plugin.RunningPlugin{
+			{Key: "botkube/helm", Type: plugin.TypeExecutor, Version: "v1.0.0", LatestVersion: "v1.1.0"},
+			{Key: "botkube/kubernetes", Type: plugin.TypeSource, Version: "v1.0.0"},
+		},
+	}
+	executor := NewPluginUpgradeExecutor(loggerx.NewNoop(), upgrader)
+	cmdCtx := CommandContext{
+		User:           UserInput{Mention: "@Joe"},
+		ExecutorFilter: newExecutorTextFilter(""),
+	}
+
+	// when: list
+	cmdCtx.Args = []string{"list", "plugins"}
+	msg, err := executor.List(context.Background(), cmdCtx)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, heredoc.Doc(`
+		PLUGIN             TYPE     VERSION LATEST
+		botkube/helm       executor v1.0.0  v1.1.0
+		botkube/kubernetes source   v1.0.0  unknown`), msg.BaseBody.CodeBlock)
+
+	// when: upgrade
+	cmdCtx.Args = []string{"upgrade", "plugin", "botkube/helm", "--version", "v1.1.0"}
+	msg, err = executor.Upgrade(context.Background(), cmdCtx)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, `@Joe upgraded executor plugin "botkube/helm" from v1.0.0 to v1.1.0. The old version is stopped once it processes in-flight requests. The upgrade is kept until Botkube restarts; to make it permanent, update the plugin version in the configuration.`, msg.BaseBody.CodeBlock)
+	assert.Equal(t, "botkube/helm", upgrader.upgradedKey)
+	assert.Equal(t, "v1.1.0", upgrader.upgradedVersion)
+}
+
+func TestPluginUpgradeExecutorStatus(t *testing.T) {
+	// given
+	stats := plugin.NewHealthStats(3)
+	stats.Register("botkube/kubernetes")
+	stats.Register("botkube/helm")
+	stats.Increment("botkube/helm")
+	stats.MarkDeactivated("botkube/helm", time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+
+	executor := NewPluginUpgradeExecutor(loggerx.NewNoop(), nil)
+	cmdCtx := CommandContext{
+		Args:              []string{"status", "plugins"},
+		PluginHealthStats: stats,
+		ExecutorFilter:    newExecutorTextFilter(""),
+	}
+
+	// when
+	msg, err := executor.Status(context.Background(), cmdCtx)
+
+	// then
+	require.NoError(t, err)
+	lines := strings.Split(msg.BaseBody.CodeBlock, "\n")
+	require.Len(t, lines, 3)
+	assert.Equal(t, "PLUGIN             STATUS      RESTARTS LAST_RESTART         NEXT_RESTART", lines[0])
+	assert.Regexp(t, `^botkube/helm       Deactivated 1/3      \S+ 2026-01-02T03:04:05Z$`, lines[1])
+	assert.Equal(t, "botkube/kubernetes Running     0/3      -                    -", lines[2])
+}
+
+func TestPluginUpgradeExecutorInvalidInput(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		upgradeErr  error
+		expectedMsg string
+		expectedErr string
+	}{
+		{
+			name:        "missing plugin",
+			args:        []string{"upgrade", "plugin"},
+			expectedMsg: "You need to specify the plugin, e.g. `{{BotName}} upgrade plugin botkube/kubernetes --version v1.0.0`. Skip the version to upgrade to the latest one.",
+		},
+		{
+			name:        "unknown option",
+			args:        []string{"upgrade", "plugin", "botkube/helm", "--force"},
+			expectedMsg: "Cannot parse command: unknown flag: --force",
+		},
+		{
+			name:        "up to date",
+			args:        []string{"upgrade", "plugin", "botkube/helm"},
+			upgradeErr:  fmt.Errorf("%w: v1.0.0", plugin.ErrPluginUpToDate),
+			expectedMsg: `Plugin "botkube/helm" is already running the requested version.`,
+		},
+		{
+			name:        "not found",
+			args:        []string{"upgrade", "plugin", "botkube/argocd"},
+			upgradeErr:  plugin.NewNotFoundPluginError("plugin %q is not enabled", "botkube/argocd"),
+			expectedMsg: `Cannot upgrade plugin: plugin "botkube/argocd" is not enabled.`,
+		},
+		{
+			name:        "upgrade failure",
+			args:        []string{"upgrade", "plugin", "botkube/helm"},
+			upgradeErr:  errors.New("checksum mismatch"),
+			expectedErr: `while upgrading plugin "botkube/helm": checksum mismatch`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			executor := NewPluginUpgradeExecutor(loggerx.NewNoop(), &fakePluginUpgrader{err: tc.upgradeErr})
+			cmdCtx := CommandContext{
+				Args:           tc.args,
+				ExecutorFilter: newExecutorTextFilter(""),
+			}
+
+			// when
+			msg, err := executor.Upgrade(context.Background(), cmdCtx)
+
+			// then
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedMsg, msg.BaseBody.CodeBlock)
+		})
+	}
+}
+
+func TestPluginUpgradeExecutorNotEnabled(t *testing.T) {
+	// given
+	executor := NewPluginUpgradeExecutor(loggerx.NewNoop(), nil)
+	cmdCtx := CommandContext{
+		Args:           []string{"upgrade", "plugin", "botkube/helm"},
+		ExecutorFilter: newExecutorTextFilter(""),
+	}
+
+	// when
+	msg, err := executor.Upgrade(context.Background(), cmdCtx)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "There are no plugins enabled.", msg.BaseBody.CodeBlock)
+}
+
+type fakePluginUpgrader struct {
+	plugins         []plugin.RunningPlugin
+	err             error
+	upgradedKey     string
+	upgradedVersion string
+}
+
+func (f *fakePluginUpgrader) RunningPlugins() []plugin.RunningPlugin {
+	return f.plugins
+}
+
+func (f *fakePluginUpgrader) Upgrade(_ context.Context, pluginKey, version string) (plugin.UpgradeOutput, error) {
+	if f.err != nil {
+		return plugin.UpgradeOutput{}, f.err
+	}
+	f.upgradedKey, f.upgradedVersion = pluginKey, version
+	return plugin.UpgradeOutput{
+		Key:         pluginKey,
+		Type:        plugin.TypeExecutor,
+		FromVersion: "v1.0.0",
+		ToVersion:   version,
+	}, nil
+}
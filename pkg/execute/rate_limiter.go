@@ -0,0 +1,162 @@
+package execute
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+const rateLimitedMsg = "You're sending commands too quickly. Please slow down and try again in %s."
+
+// CommandRateLimiter limits how often commands are executed by a single user and in a single channel.
+type CommandRateLimiter struct {
+	perUser    config.CommandRateLimit
+	perChannel config.CommandRateLimit
+	exempt     map[string]struct{}
+	now        func() time.Time
+
+	mu        sync.Mutex
+	limiters  map[string]*rate.Limiter
+	lastPrune time.Time
+}
+
+// NewCommandRateLimiter returns a new CommandRateLimiter instance.
+func NewCommandRateLimiter(cfg config.CommandRateLimits) *CommandRateLimiter {
+	exempt := map[string]struct{}{}
+	for _, user := range cfg.ExemptUsers {
+		exempt[strings.ToLower(user)] = struct{}{}
+	}
+
+	return &CommandRateLimiter{
+		perUser:    cfg.PerUser,
+		perChannel: cfg.PerChannel,
+		exempt:     exempt,
+		now:        time.Now,
+		limiters:   map[string]*rate.Limiter{},
+	}
+}
+
+// Allow returns true if a given user can execute a command in a given channel.
+// Otherwise, it returns the time after which the command can be retried.
+// The command is counted towards both limits only if it's allowed by both of them.
+func (l *CommandRateLimiter) Allow(platform config.CommPlatformIntegration, user UserInput, channelID string) (time.Duration, bool) {
+	if l.isExempt(user) {
+		return 0, true
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.pruneIdle(now)
+
+	var reservations []*rate.Reservation
+	if userKey := userRateLimitKey(user); userKey != "" {
+		if r := l.reserve(fmt.Sprintf("user/%s/%s", platform, userKey), l.perUser, now); r != nil {
+			reservations = append(reservations, r)
+		}
+	}
+	if channelID != "" {
+		if r := l.reserve(fmt.Sprintf("channel/%s/%s", platform, channelID), l.perChannel, now); r != nil {
+			reservations = append(reservations, r)
+		}
+	}
+
+	var retryAfter time.Duration
+	for _, r := range reservations {
+		if delay := r.DelayFrom(now); delay > retryAfter {
+			retryAfter = delay
+		}
+	}
+	if retryAfter == 0 {
+		return 0, true
+	}
+
+	// the rejected command doesn't consume tokens, so users are not locked out for retrying too early
+	for _, r := range reservations {
+		r.CancelAt(now)
+	}
+	return retryAfter, false
+}
+
+// isExempt matches users only by the platform ID and the verified email, as mentions and display names can be spoofed on some platforms.
+func (l *CommandRateLimiter) isExempt(user UserInput) bool {
+	for _, id := range []string{user.ID, user.Email} {
+		if id == "" {
+			continue
+		}
+		if _, ok := l.exempt[strings.ToLower(id)]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// reserve returns nil if the limit is disabled.
+func (l *CommandRateLimiter) reserve(key string, limit config.CommandRateLimit, now time.Time) *rate.Reservation {
+	if limit.MaxCommands <= 0 || limit.Window <= 0 {
+		return nil
+	}
+
+	limiter, ok := l.limiters[key]
+	if !ok {
+		burst := limit.Burst
+		if burst <= 0 {
+			burst = limit.MaxCommands
+		}
+		limiter = rate.NewLimiter(rate.Every(limit.Window/time.Duration(limit.MaxCommands)), burst)
+		l.limiters[key] = limiter
+	}
+	return limiter.ReserveN(now, 1)
+}
+
+// pruneIdle removes fully replenished limiters, as they are equivalent to new ones. It runs at most once per window.
+func (l *CommandRateLimiter) pruneIdle(now time.Time) {
+	window := l.perUser.Window
+	if l.perChannel.Window > window {
+		window = l.perChannel.Window
+	}
+	if window <= 0 || now.Sub(l.lastPrune) < window {
+		return
+	}
+
+	for key, limiter := range l.limiters {
+		if limiter.TokensAt(now) >= float64(limiter.Burst()) {
+			delete(l.limiters, key)
+		}
+	}
+	l.lastPrune = now
+}
+
+func userRateLimitKey(user UserInput) string {
+	switch {
+	case user.ID != "":
+		return user.ID
+	case user.Mention != "":
+		return user.Mention
+	default:
+		return user.DisplayName
+	}
+}
+
+// rateLimitedMessage returns a message visible only for the command author, so the channel is not flooded with rejections.
+func rateLimitedMessage(retryAfter time.Duration, cmdCtx CommandContext) interactive.CoreMessage {
+	// rounded up, so the retried command is not rejected again
+	retryAfter = (retryAfter + time.Second - 1).Truncate(time.Second)
+	return interactive.CoreMessage{
+		Description: header(cmdCtx),
+		Message: api.Message{
+			BaseBody: api.Body{
+				Plaintext: fmt.Sprintf(rateLimitedMsg, retryAfter),
+			},
+			OnlyVisibleForYou: true,
+		},
+	}
+}
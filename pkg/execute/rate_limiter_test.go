@@ -0,0 +1,107 @@
+package execute
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+func TestCommandRateLimiterPerUser(t *testing.T) {
+	// given
+	limiter := NewCommandRateLimiter(config.CommandRateLimits{
+		PerUser: config.CommandRateLimit{MaxCommands: 2, Window: time.Minute},
+	})
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter.now = func() time.Time { return now }
+	alice := UserInput{ID: "U1", Mention: "<@U1>"}
+	bob := UserInput{ID: "U2", Mention: "<@U2>"}
+
+	// when-then
+	assertAllowed(t, limiter, alice, "C1")
+	assertAllowed(t, limiter, alice, "C2")
+	retryAfter, ok := limiter.Allow(config.SocketSlackCommPlatformIntegration, alice, "C1")
+	assert.False(t, ok)
+	assert.Equal(t, 30*time.Second, retryAfter)
+
+	assertAllowed(t, limiter, bob, "C1")
+
+	// when a token is replenished
+	now = now.Add(30 * time.Second)
+
+	// then
+	assertAllowed(t, limiter, alice, "C1")
+}
+
+func TestCommandRateLimiterPerChannel(t *testing.T) {
+	// given
+	limiter := NewCommandRateLimiter(config.CommandRateLimits{
+		PerUser:    config.CommandRateLimit{MaxCommands: 1, Window: time.Minute},
+		PerChannel: config.CommandRateLimit{MaxCommands: 10, Window: time.Minute, Burst: 2},
+	})
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter.now = func() time.Time { return now }
+
+	// when-then
+	assertAllowed(t, limiter, UserInput{ID: "U1"}, "C1")
+	assertAllowed(t, limiter, UserInput{ID: "U2"}, "C1")
+	_, ok := limiter.Allow(config.SocketSlackCommPlatformIntegration, UserInput{ID: "U3"}, "C1")
+	assert.False(t, ok)
+
+	// when the channel limit is replenished, the rejected user is not limited, as the rejected command wasn't counted
+	now = now.Add(6 * time.Second)
+
+	// then
+	assertAllowed(t, limiter, UserInput{ID: "U3"}, "C1")
+}
+
+func TestCommandRateLimiterExemptUsers(t *testing.T) {
+	// given
+	limiter := NewCommandRateLimiter(config.CommandRateLimits{
+		PerUser:     config.CommandRateLimit{MaxCommands: 1, Window: time.Hour},
+		PerChannel:  config.CommandRateLimit{MaxCommands: 1, Window: time.Hour},
+		ExemptUsers: []string{"Admin@example.com"},
+	})
+	admin := UserInput{ID: "U1", Email: "admin@example.com"}
+
+	// when-then
+	for i := 0; i < 5; i++ {
+		assertAllowed(t, limiter, admin, "C1")
+	}
+}
+
+func TestCommandRateLimiterSpoofedMentionIsNotExempt(t *testing.T) {
+	// given
+	limiter := NewCommandRateLimiter(config.CommandRateLimits{
+		PerUser:     config.CommandRateLimit{MaxCommands: 1, Window: time.Hour},
+		ExemptUsers: []string{"Admin"},
+	})
+	// e.g. Teams uses display names as mentions, and NATS takes them from a request header
+	spoofed := UserInput{ID: "U2", Mention: "Admin", DisplayName: "Admin"}
+
+	// when
+	assertAllowed(t, limiter, spoofed, "C1")
+	_, ok := limiter.Allow(config.SocketSlackCommPlatformIntegration, spoofed, "C1")
+
+	// then
+	assert.False(t, ok)
+}
+
+func TestCommandRateLimiterDisabled(t *testing.T) {
+	// given
+	limiter := NewCommandRateLimiter(config.CommandRateLimits{})
+
+	// when-then
+	for i := 0; i < 100; i++ {
+		assertAllowed(t, limiter, UserInput{ID: "U1"}, "C1")
+	}
+}
+
+func assertAllowed(t *testing.T, limiter *CommandRateLimiter, user UserInput, channel string) {
+	t.Helper()
+
+	_, ok := limiter.Allow(config.SocketSlackCommPlatformIntegration, user, channel)
+	assert.True(t, ok, "command of %q in %q should be allowed", user.ID, channel)
+}
@@ -0,0 +1,120 @@
+package execute
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kubeshop/botkube/internal/authz"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/execute/command"
+)
+
+const (
+	readOnlyChannelMsgFmt          = "Policy violation: this channel is read-only, and `%s` is not a read-only command. Run it in a channel with write access."
+	readOnlyChannelAmbiguousMsgFmt = "Policy violation: this channel is read-only, and `%s` can't be verified as a read-only command, as it's preceded by an unknown flag. Pass flag values with `=`, e.g. `--flag=value`, or put flags after the command."
+)
+
+// anySubcommand allows a read-only verb with any arguments, e.g. `kubectl get`.
+var anySubcommand map[string]struct{}
+
+// readOnlyVerbs are command verbs allowed in read-only channels, by executor plugin name. Built-in commands are listed under an empty name.
+// Verbs with subcommands are allowed only with the listed ones, e.g. `kubectl rollout status` unlike `kubectl rollout restart`.
+// Commands which aren't listed, including the ones of unknown plugins, are denied.
+var readOnlyVerbs = map[string]map[string]map[string]struct{}{
+	"": {
+		string(command.PingVerb):         anySubcommand,
+		string(command.HelpVerb):         anySubcommand,
+		string(command.VersionVerb):      anySubcommand,
+		string(command.FeedbackVerb):     anySubcommand,
+		string(command.ListVerb):         anySubcommand,
+		string(command.StatusVerb):       anySubcommand,
+		string(command.ShowVerb):         anySubcommand,
+		string(command.CancelVerb):       anySubcommand,
+		string(command.AckVerb):          anySubcommand,
+		string(command.AuditVerb):        anySubcommand,
+		string(command.DoctorVerb):       anySubcommand,
+		string(command.OverviewVerb):     anySubcommand,
+		string(command.WatchVerb):        anySubcommand,
+		string(command.UpgradeCheckVerb): anySubcommand,
+		string(command.ReplayVerb):       anySubcommand,
+		string(command.IncidentsVerb):    anySubcommand,
+		string(command.ConfigVerb):       {effectiveConfigFeature: {}, configHistoryFeature: {}, configOriginFeatureName.Name: {}, configOriginFeatureName.Aliases[0]: {}},
+		string(command.PluginsVerb):      {pluginsListFeature: {}, pluginsSearchFeature: {}, pluginsInfoFeature: {}},
+	},
+	"kubectl": {
+		"get": anySubcommand, "describe": anySubcommand, "logs": anySubcommand, "top": anySubcommand, "explain": anySubcommand,
+		"events": anySubcommand, "diff": anySubcommand, "api-resources": anySubcommand, "api-versions": anySubcommand,
+		"cluster-info": anySubcommand, "version": anySubcommand,
+		"auth":    {"can-i": {}, "whoami": {}},
+		"rollout": {"status": {}, "history": {}},
+	},
+	"helm": {
+		"list": anySubcommand, "ls": anySubcommand, "status": anySubcommand, "history": anySubcommand, "get": anySubcommand,
+		"show": anySubcommand, "search": anySubcommand, "template": anySubcommand, "version": anySubcommand, "env": anySubcommand,
+	},
+	"alertmanager": {
+		"silence": {"list": {}},
+	},
+	"node": {
+		"check": anySubcommand,
+	},
+	"terraform": {
+		"workspaces": anySubcommand,
+		"plan":       anySubcommand,
+	},
+}
+
+// readOnlyPlugins are executor plugins which don't mutate resources with any command, e.g. `ai` which only answers questions.
+var readOnlyPlugins = map[string]struct{}{
+	"ai": {},
+}
+
+// isReadOnlyCommand returns true if a command with given parsed arguments is allowed in read-only channels.
+// Commands with a verb, or a subcommand of a restricted verb, preceded by an unknown flag are denied, as the flag may take it as a value.
+// The plugin key is empty for built-in commands.
+func isReadOnlyCommand(pluginKey string, args authz.Args) bool {
+	name := shortPluginName(pluginKey)
+	if _, found := readOnlyPlugins[name]; found {
+		return true
+	}
+	if args.AmbiguousVerb {
+		return false
+	}
+
+	subcommands, found := readOnlyVerbs[name][strings.ToLower(args.Verb)]
+	if !found {
+		return false
+	}
+	if subcommands == nil {
+		return true
+	}
+	if args.AmbiguousResource {
+		return false
+	}
+	_, found = subcommands[strings.ToLower(args.Resource)]
+	return found
+}
+
+func readOnlyChannelMsg(pluginKey string, args authz.Args) string {
+	cmd := args.Verb
+	subcommands, found := readOnlyVerbs[shortPluginName(pluginKey)][strings.ToLower(args.Verb)]
+	if subcommands != nil && args.Resource != "" {
+		cmd = fmt.Sprintf("%s %s", args.Verb, args.Resource)
+	}
+	if args.AmbiguousVerb || (found && subcommands != nil && args.AmbiguousResource) {
+		return fmt.Sprintf(readOnlyChannelAmbiguousMsgFmt, cmd)
+	}
+	return fmt.Sprintf(readOnlyChannelMsgFmt, cmd)
+}
+
+// shortPluginName returns the name of a plugin with a given key, e.g. `kubectl` for `botkube/kubectl@v1.0.0`.
+func shortPluginName(pluginKey string) string {
+	if pluginKey == "" {
+		return ""
+	}
+	_, name, _, err := config.DecomposePluginKey(pluginKey)
+	if err != nil {
+		return pluginKey
+	}
+	return name
+}
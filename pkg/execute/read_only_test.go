@@ -0,0 +1,212 @@
+package execute
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kubeshop/botkube/internal/authz"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+func TestAuthorizeReadOnlyChannel(t *testing.T) {
+	tests := []struct {
+		name        string
+		cmd         string
+		pluginName  string
+		readOnly    bool
+		expectedMsg string
+	}{
+		{
+			name:        "Mutating kubectl command",
+			cmd:         "kubectl -n prod delete pod api-0",
+			pluginName:  "botkube/kubectl",
+			readOnly:    true,
+			expectedMsg: "Policy violation: this channel is read-only, and `delete` is not a read-only command. Run it in a channel with write access.",
+		},
+		{
+			name:        "Exec into a container",
+			cmd:         "kubectl exec -n prod api-0 -- sh",
+			pluginName:  "botkube/kubectl@v1.0.0",
+			readOnly:    true,
+			expectedMsg: "Policy violation: this channel is read-only, and `exec` is not a read-only command. Run it in a channel with write access.",
+		},
+		{
+			name:        "Port forwarding",
+			cmd:         "kubectl port-forward svc/api 8080:80",
+			pluginName:  "botkube/kubectl",
+			readOnly:    true,
+			expectedMsg: "Policy violation: this channel is read-only, and `port-forward` is not a read-only command. Run it in a channel with write access.",
+		},
+		{
+			name:        "Certificate approval",
+			cmd:         "kubectl certificate approve csr-1",
+			pluginName:  "botkube/kubectl",
+			readOnly:    true,
+			expectedMsg: "Policy violation: this channel is read-only, and `certificate` is not a read-only command. Run it in a channel with write access.",
+		},
+		{
+			name:        "Mutating subcommand",
+			cmd:         "kubectl rollout restart deployment/api",
+			pluginName:  "botkube/kubectl",
+			readOnly:    true,
+			expectedMsg: "Policy violation: this channel is read-only, and `rollout restart` is not a read-only command. Run it in a channel with write access.",
+		},
+		{
+			name:        "Mutating plugin subcommand",
+			cmd:         "alertmanager silence create --matcher alertname=Watchdog",
+			pluginName:  "botkube/alertmanager",
+			readOnly:    true,
+			expectedMsg: "Policy violation: this channel is read-only, and `silence create` is not a read-only command. Run it in a channel with write access.",
+		},
+		{
+			name:        "Unknown plugin command",
+			cmd:         "custom get pods",
+			pluginName:  "acme/custom",
+			readOnly:    true,
+			expectedMsg: "Policy violation: this channel is read-only, and `get` is not a read-only command. Run it in a channel with write access.",
+		},
+		{
+			name:        "Interaction continuing a mutating command",
+			cmd:         "kubectl edit 4f2a9c1b",
+			pluginName:  "botkube/kubectl",
+			readOnly:    true,
+			expectedMsg: "Policy violation: this channel is read-only, and `edit` is not a read-only command. Run it in a channel with write access.",
+		},
+		{
+			name:        "Mutating command hidden behind a flag value",
+			cmd:         "kubectl --field-manager get label pod foo a=b",
+			pluginName:  "botkube/kubectl",
+			readOnly:    true,
+			expectedMsg: "Policy violation: this channel is read-only, and `get` can't be verified as a read-only command, as it's preceded by an unknown flag. Pass flag values with `=`, e.g. `--flag=value`, or put flags after the command.",
+		},
+		{
+			name:        "Mutating commands hidden behind flag values",
+			cmd:         "kubectl --cascade get delete deploy api",
+			pluginName:  "botkube/kubectl",
+			readOnly:    true,
+			expectedMsg: "Policy violation: this channel is read-only, and `get` can't be verified as a read-only command, as it's preceded by an unknown flag. Pass flag values with `=`, e.g. `--flag=value`, or put flags after the command.",
+		},
+		{
+			name:        "Mutating subcommand hidden behind a flag value",
+			cmd:         "kubectl rollout --context status restart deployment/api",
+			pluginName:  "botkube/kubectl",
+			readOnly:    true,
+			expectedMsg: "Policy violation: this channel is read-only, and `rollout status` can't be verified as a read-only command, as it's preceded by an unknown flag. Pass flag values with `=`, e.g. `--flag=value`, or put flags after the command.",
+		},
+		{
+			name:        "Mutating helm command hidden behind a flag value",
+			cmd:         "helm --kube-context list uninstall api",
+			pluginName:  "botkube/helm",
+			readOnly:    true,
+			expectedMsg: "Policy violation: this channel is read-only, and `list` can't be verified as a read-only command, as it's preceded by an unknown flag. Pass flag values with `=`, e.g. `--flag=value`, or put flags after the command.",
+		},
+		{
+			name:       "Read-only command with flags",
+			cmd:        "kubectl --context=prod get --sort-by .metadata.name pods -owide",
+			pluginName: "botkube/kubectl",
+			readOnly:   true,
+		},
+		{
+			name:        "Mutating built-in command",
+			cmd:         "edit SourceBindings k8s-err-events",
+			readOnly:    true,
+			expectedMsg: "Policy violation: this channel is read-only, and `edit` is not a read-only command. Run it in a channel with write access.",
+		},
+		{
+			name:        "Mutating built-in subcommand",
+			cmd:         "config rollback 3",
+			readOnly:    true,
+			expectedMsg: "Policy violation: this channel is read-only, and `config rollback` is not a read-only command. Run it in a channel with write access.",
+		},
+		{
+			name:     "Read-only built-in command",
+			cmd:      "config effective",
+			readOnly: true,
+		},
+		{
+			name:       "Read-only plugin subcommand",
+			cmd:        "alertmanager silence list",
+			pluginName: "botkube/alertmanager",
+			readOnly:   true,
+		},
+		{
+			name:       "Read-only plugin",
+			cmd:        "ai why is api-0 crashing",
+			pluginName: "botkube/ai",
+			readOnly:   true,
+		},
+		{
+			name:       "Read-only subcommand",
+			cmd:        "kubectl rollout status deployment/api",
+			pluginName: "botkube/kubectl",
+			readOnly:   true,
+		},
+		{
+			name:       "Read-only command",
+			cmd:        "kubectl get pods -n prod",
+			pluginName: "botkube/kubectl",
+			readOnly:   true,
+		},
+		{
+			name:       "Mutating command in regular channel",
+			cmd:        "helm upgrade api ./chart",
+			pluginName: "botkube/helm",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			e := &DefaultExecutor{
+				log:          loggerx.NewNoop(),
+				conversation: Conversation{ID: "C123", ReadOnly: tc.readOnly},
+			}
+			cmdCtx := CommandContext{
+				Args:           strings.Fields(tc.cmd),
+				CleanCmd:       tc.cmd,
+				ExecutorFilter: newExecutorTextFilter(""),
+			}
+
+			// when
+			msg, allowed := e.authorize(context.Background(), cmdCtx, tc.pluginName)
+
+			// then
+			assert.Equal(t, tc.expectedMsg == "", allowed)
+			assert.Equal(t, tc.expectedMsg, msg.Message.BaseBody.Plaintext)
+		})
+	}
+}
+
+func TestAuthorizeAmbiguousCommand(t *testing.T) {
+	// given
+	authorizer := &fakeCommandAuthorizer{}
+	e := &DefaultExecutor{
+		log:           loggerx.NewNoop(),
+		cmdAuthorizer: authorizer,
+	}
+	cmd := "kubectl --cascade orphan delete deploy api"
+	cmdCtx := CommandContext{
+		Args:           strings.Fields(cmd),
+		CleanCmd:       cmd,
+		ExecutorFilter: newExecutorTextFilter(""),
+	}
+
+	// when
+	_, allowed := e.authorize(context.Background(), cmdCtx, "botkube/kubectl")
+
+	// then
+	assert.False(t, allowed)
+	assert.Equal(t, "orphan", authorizer.got.Verb)
+	assert.True(t, authorizer.got.Ambiguous)
+}
+
+type fakeCommandAuthorizer struct {
+	got authz.Input
+}
+
+func (f *fakeCommandAuthorizer) Authorize(_ context.Context, in authz.Input) authz.Decision {
+	f.got = in
+	return authz.Decision{Allowed: !in.Ambiguous}
+}
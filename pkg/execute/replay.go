@@ -0,0 +1,121 @@
+package execute
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/pflag"
+
+	"github.com/kubeshop/botkube/internal/replay"
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/execute/command"
+)
+
+const (
+	replayNotAvailable = "Event replay is not available."
+	replayUsage        = "You need to specify the source binding, e.g. `%s replay k8s-err-events --kind Pod --name api --namespace prod --type error --reason BackOff`."
+	replayNoTargets    = "None of channels or sinks would receive it."
+)
+
+var replayFeatureName = FeatureName{Name: noFeature, Positional: true}
+
+// EventReplayer evaluates events in the notification pipeline without sending them.
+type EventReplayer interface {
+	Replay(ctx context.Context, req replay.Request) (replay.Report, error)
+}
+
+// ReplayExecutor executes the event replay command.
+type ReplayExecutor struct {
+	log      logrus.FieldLogger
+	replayer EventReplayer
+}
+
+// NewReplayExecutor returns a new ReplayExecutor instance. The replayer is optional.
+func NewReplayExecutor(log logrus.FieldLogger, replayer EventReplayer) *ReplayExecutor {
+	return &ReplayExecutor{
+		log:      log,
+		replayer: replayer,
+	}
+}
+
+// FeatureName returns the name and aliases of the feature provided by this executor
+func (e *ReplayExecutor) FeatureName() FeatureName {
+	return replayFeatureName
+}
+
+// Commands returns slice of commands the executor supports
+func (e *ReplayExecutor) Commands() map[command.Verb]CommandFn {
+	return map[command.Verb]CommandFn{
+		command.ReplayVerb: e.Replay,
+	}
+}
+
+// Replay evaluates a synthetic Kubernetes event for a given source binding, e.g. `replay k8s-err-events --kind Pod --name api`,
+// and responds with channels and sinks which would receive it. Nothing is sent to them.
+func (e *ReplayExecutor) Replay(ctx context.Context, cmdCtx CommandContext) (interactive.CoreMessage, error) {
+	if e.replayer == nil {
+		return respond(replayNotAvailable, cmdCtx), nil
+	}
+	if len(cmdCtx.Args) < 2 || strings.HasPrefix(cmdCtx.Args[1], "-") {
+		return respond(fmt.Sprintf(replayUsage, api.MessageBotNamePlaceholder), cmdCtx), nil
+	}
+
+	var synthetic replay.Synthetic
+	flags := pflag.NewFlagSet("replay", pflag.ContinueOnError)
+	flags.StringVar(&synthetic.Kind, "kind", "Pod", "Resource kind")
+	flags.StringVar(&synthetic.Name, "name", "replay", "Resource name")
+	flags.StringVar(&synthetic.Namespace, "namespace", "default", "Resource namespace")
+	flags.StringVar(&synthetic.Type, "type", "error", "Event type")
+	flags.StringVar(&synthetic.Reason, "reason", "", "Event reason")
+	flags.StringVar(&synthetic.Message, "message", "", "Event message")
+	flags.StringToStringVar(&synthetic.Labels, "labels", nil, "Resource labels")
+	if err := flags.Parse(cmdCtx.Args[2:]); err != nil {
+		return respond(fmt.Sprintf("Cannot parse command: %s", err.Error()), cmdCtx), nil
+	}
+
+	report, err := e.replayer.Replay(ctx, synthetic.Request(cmdCtx.Args[1]))
+	switch {
+	case err == nil:
+	case errors.Is(err, replay.ErrUnknownSource), errors.Is(err, replay.ErrNotReady):
+		return respond(fmt.Sprintf("Cannot replay the event: %s.", err.Error()), cmdCtx), nil
+	default:
+		return interactive.CoreMessage{}, fmt.Errorf("while replaying event: %w", err)
+	}
+	return respond(replayOutput(report), cmdCtx), nil
+}
+
+func replayOutput(report replay.Report) string {
+	var out strings.Builder
+	fmt.Fprintf(&out, "Event: %s\n", report.Header)
+	if report.Owner != "" {
+		fmt.Fprintf(&out, "Owner: %s\n", report.Owner)
+	}
+	if len(report.Filters) > 0 {
+		fmt.Fprintf(&out, "Matched filters: %s\n", strings.Join(report.Filters, ", "))
+	}
+
+	fmt.Fprintf(&out, "Source bindings: %s\n", strings.Join(report.Sources, ", "))
+	if report.Routed {
+		out.WriteString("Channels are selected by routing rules.\n")
+	}
+	if len(report.Targets) == 0 {
+		fmt.Fprintf(&out, "\n%s", replayNoTargets)
+	} else {
+		out.WriteString("\nThe notification would be sent to:")
+		for _, target := range report.Targets {
+			fmt.Fprintf(&out, "\n- %s", target)
+		}
+	}
+
+	if len(report.Dropped) > 0 {
+		out.WriteString("\n\nThe notification would be dropped by filters of:")
+		for _, target := range report.Dropped {
+			fmt.Fprintf(&out, "\n- %s", target)
+		}
+	}
+	return out.String()
+}
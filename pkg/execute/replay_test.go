@@ -0,0 +1,143 @@
+package execute
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/internal/replay"
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+func TestReplayExecutor(t *testing.T) {
+	tests := []struct {
+		name        string
+		cmd         string
+		report      replay.Report
+		expectedReq replay.Request
+		expectedMsg string
+	}{
+		{
+			name: "Sent",
+			cmd:  "replay k8s-err-events --kind Deployment --name api --namespace prod --reason BackOff --labels team=payments",
+			report: replay.Report{
+				Source:  "k8s-err-events",
+				Header:  "Deployment prod/api BackOff",
+				Owner:   "payments",
+				Filters: []string{"reroute-errors"},
+				Sources: []string{"k8s-err-events"},
+				Routed:  true,
+				Targets: []replay.Target{{Integration: "socketSlack", Channel: "oncall"}, {Integration: "webhook"}},
+			},
+			expectedReq: replay.Synthetic{
+				Kind: "Deployment", Name: "api", Namespace: "prod", Type: "error", Reason: "BackOff",
+				Labels: map[string]string{"team": "payments"},
+			}.Request("k8s-err-events"),
+			expectedMsg: heredoc.Doc(`
+				Event: Deployment prod/api BackOff
+				Owner: payments
+				Matched filters: reroute-errors
+				Source bindings: k8s-err-events
+				Channels are selected by routing rules.
+
+				The notification would be sent to:
+				- socketSlack/oncall
+				- webhook`),
+		},
+		{
+			name: "Dropped",
+			cmd:  "replay k8s-err-events --namespace kube-system",
+			report: replay.Report{
+				Source:  "k8s-err-events",
+				Header:  "Pod kube-system/replay",
+				Filters: []string{"drop-kube-system"},
+				Sources: []string{"k8s-err-events"},
+				Targets: []replay.Target{{Integration: "webhook"}},
+				Dropped: []replay.Target{{Integration: "socketSlack", Channel: "general"}},
+			},
+			expectedReq: replay.Synthetic{Kind: "Pod", Name: "replay", Namespace: "kube-system", Type: "error"}.Request("k8s-err-events"),
+			expectedMsg: heredoc.Doc(`
+				Event: Pod kube-system/replay
+				Matched filters: drop-kube-system
+				Source bindings: k8s-err-events
+
+				The notification would be sent to:
+				- webhook
+
+				The notification would be dropped by filters of:
+				- socketSlack/general`),
+		},
+		{
+			name:        "No targets",
+			cmd:         "replay k8s-err-events",
+			report:      replay.Report{Source: "k8s-err-events", Header: "Pod default/replay", Sources: []string{"k8s-err-events"}},
+			expectedReq: replay.Synthetic{Kind: "Pod", Name: "replay", Namespace: "default", Type: "error"}.Request("k8s-err-events"),
+			expectedMsg: heredoc.Doc(`
+				Event: Pod default/replay
+				Source bindings: k8s-err-events
+
+				None of channels or sinks would receive it.`),
+		},
+		{
+			name:        "Unknown source binding",
+			cmd:         "replay unknown",
+			expectedReq: replay.Synthetic{Kind: "Pod", Name: "replay", Namespace: "default", Type: "error"}.Request("unknown"),
+			expectedMsg: `Cannot replay the event: source binding is not started: "unknown".`,
+		},
+		{
+			name:        "Missing source binding",
+			cmd:         "replay --kind Pod",
+			expectedMsg: fmt.Sprintf(replayUsage, api.MessageBotNamePlaceholder),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			replayer := &fakeReplayer{report: tc.report}
+			e := NewReplayExecutor(loggerx.NewNoop(), replayer)
+			cmdCtx := CommandContext{
+				Args:           strings.Fields(tc.cmd),
+				ExecutorFilter: newExecutorTextFilter(""),
+			}
+
+			// when
+			msg, err := e.Replay(context.Background(), cmdCtx)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedMsg, msg.BaseBody.CodeBlock)
+			assert.Equal(t, tc.expectedReq, replayer.req)
+		})
+	}
+}
+
+func TestReplayExecutorNotAvailable(t *testing.T) {
+	// given
+	cmdCtx := CommandContext{Args: []string{"replay", "k8s-err-events"}, ExecutorFilter: newExecutorTextFilter("")}
+
+	// when
+	msg, err := NewReplayExecutor(loggerx.NewNoop(), nil).Replay(context.Background(), cmdCtx)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, replayNotAvailable, msg.BaseBody.CodeBlock)
+}
+
+type fakeReplayer struct {
+	req    replay.Request
+	report replay.Report
+}
+
+func (f *fakeReplayer) Replay(_ context.Context, req replay.Request) (replay.Report, error) {
+	f.req = req
+	if req.Source == "unknown" {
+		return replay.Report{}, fmt.Errorf("%w: %q", replay.ErrUnknownSource, req.Source)
+	}
+	return f.report, nil
+}
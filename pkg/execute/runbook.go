@@ -0,0 +1,582 @@
+package execute
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"text/template"
+	"time"
+
+	sprig "github.com/go-task/slim-sprig"
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/execute/command"
+	"github.com/kubeshop/botkube/pkg/maputil"
+)
+
+const (
+	runbookNameMissing      = "You forgot to pass runbook name. Please pass one of the following runbooks:\n\n%s"
+	runbookExecIDMissing    = "You forgot to pass runbook execution ID. Run `%s list runbooks` to see recent executions."
+	runbookNotFound         = "Runbook %q not found. Please pass one of the following runbooks:\n\n%s"
+	runbookExecNotFound     = "Runbook execution %q not found. Executions are kept in memory, so it could be lost after Botkube restart."
+	runbookExecNotActive    = "Runbook execution %q is already %s."
+	runbookStepNotCurrent   = "Step %d of runbook execution %q is not the current one. Use buttons of the latest runbook message."
+	runbookStepInProgress   = "Step %d of runbook execution %q is already running."
+	runbookMaxExecutions    = 50
+	runbookMaxOutputLength  = 300
+	runbookExecutionIDBytes = 4
+)
+
+var runbookFeatureName = FeatureName{
+	Name:    "runbook",
+	Aliases: []string{"runbooks", "rb"},
+}
+
+// RunbookExecutionState defines the state of runbook execution.
+type RunbookExecutionState string
+
+const (
+	RunbookExecutionRunning   RunbookExecutionState = "running"
+	RunbookExecutionPaused    RunbookExecutionState = "paused"
+	RunbookExecutionCompleted RunbookExecutionState = "completed"
+	RunbookExecutionAborted   RunbookExecutionState = "aborted"
+)
+
+// RunbookStepRecord holds the execution record of a single step.
+type RunbookStepRecord struct {
+	Name       string
+	Type       config.RunbookStepType
+	FinishedBy string
+	FinishedAt time.Time
+	Output     string
+}
+
+// RunbookExecution holds the state and the execution record of a given runbook run.
+type RunbookExecution struct {
+	ID          string
+	RunbookName string
+	StartedBy   string
+	StartedAt   time.Time
+	State       RunbookExecutionState
+	CurrentStep int
+	Inputs      map[string]string
+	Records     []RunbookStepRecord
+
+	// stepInProgress is set when the current step is executed, so it isn't executed twice.
+	stepInProgress bool
+}
+
+// RunbookCmdExecutorFactory facilitates creation of executors for runbook command steps.
+type RunbookCmdExecutorFactory interface {
+	NewDefault(cfg NewDefaultInput) Executor
+}
+
+// RunbookExecutor executes all commands that are related to runbooks.
+type RunbookExecutor struct {
+	log             logrus.FieldLogger
+	runbooks        config.Runbooks
+	executorFactory RunbookCmdExecutorFactory
+
+	mu         sync.Mutex
+	executions map[string]*RunbookExecution
+	order      []string
+}
+
+// NewRunbookExecutor returns a new RunbookExecutor instance.
+func NewRunbookExecutor(log logrus.FieldLogger, cfg config.Config) *RunbookExecutor {
+	return &RunbookExecutor{
+		log:        log,
+		runbooks:   cfg.Runbooks,
+		executions: map[string]*RunbookExecution{},
+	}
+}
+
+// Commands returns slice of commands the executor supports
+func (e *RunbookExecutor) Commands() map[command.Verb]CommandFn {
+	return map[command.Verb]CommandFn{
+		command.ListVerb:   e.List,
+		command.RunVerb:    e.Run,
+		command.ResumeVerb: e.Resume,
+		command.PauseVerb:  e.Pause,
+		command.AbortVerb:  e.Abort,
+		command.StatusVerb: e.Status,
+	}
+}
+
+// FeatureName returns the name and aliases of the feature provided by this executor
+func (e *RunbookExecutor) FeatureName() FeatureName {
+	return runbookFeatureName
+}
+
+// List returns a tabular representation of runbooks and recent executions.
+func (e *RunbookExecutor) List(_ context.Context, cmdCtx CommandContext) (interactive.CoreMessage, error) {
+	e.log.Debug("Listing runbooks...")
+	return respond(e.tabularOutput(), cmdCtx), nil
+}
+
+// Run starts a new execution of a given runbook.
+func (e *RunbookExecutor) Run(_ context.Context, cmdCtx CommandContext) (interactive.CoreMessage, error) {
+	if len(cmdCtx.Args) < 3 {
+		return respond(fmt.Sprintf(runbookNameMissing, e.runbooksTabularOutput()), cmdCtx), nil
+	}
+
+	name := cmdCtx.Args[2]
+	rb, found := e.runbooks[name]
+	if !found {
+		return respond(fmt.Sprintf(runbookNotFound, name, e.runbooksTabularOutput()), cmdCtx), nil
+	}
+
+	exec := e.newExecution(name, cmdCtx.User.Mention)
+	e.log.WithFields(logrus.Fields{
+		"runbook":     name,
+		"executionID": exec.ID,
+	}).Info("Starting runbook execution...")
+
+	return e.stepMessage(cmdCtx, rb, *exec, nil), nil
+}
+
+// Resume executes the current step of a given runbook execution. Paused executions are resumed.
+// Steps are executed only if they are referred by their numbers, so repeated clicks and clicks of stale buttons are ignored.
+// Otherwise, the current step is displayed.
+func (e *RunbookExecutor) Resume(ctx context.Context, cmdCtx CommandContext) (interactive.CoreMessage, error) {
+	exec, rb, msg, ok := e.getActiveExecution(cmdCtx)
+	if !ok {
+		return msg, nil
+	}
+
+	if len(cmdCtx.Args) < 4 {
+		return e.stepMessage(cmdCtx, rb, exec, nil), nil
+	}
+	stepNo, err := strconv.Atoi(cmdCtx.Args[3])
+	if err != nil {
+		return e.stepMessage(cmdCtx, rb, exec, nil), nil
+	}
+	stepIdx := stepNo - 1
+
+	exec, err = e.claimStep(exec.ID, stepIdx)
+	if err != nil {
+		return respond(err.Error(), cmdCtx), nil
+	}
+
+	step := rb.Steps[stepIdx]
+	var output []api.Section
+	switch step.Type {
+	case config.PromptRunbookStep:
+		input := strings.TrimSpace(strings.Join(cmdCtx.Args[4:], " "))
+		if input == "" {
+			exec = e.updateExecution(exec.ID, func(in *RunbookExecution) { in.stepInProgress = false })
+			return e.stepMessage(cmdCtx, rb, exec, nil), nil
+		}
+		exec, err = e.finishStep(exec.ID, stepIdx, cmdCtx.User.Mention, input, func(in *RunbookExecution) {
+			in.Inputs[step.Name] = input
+		})
+	case config.ConfirmationRunbookStep:
+		exec, err = e.finishStep(exec.ID, stepIdx, cmdCtx.User.Mention, "confirmed", nil)
+	default:
+		out, runErr := e.runCommandStep(ctx, cmdCtx, step, exec.Inputs)
+		if runErr != nil {
+			e.updateExecution(exec.ID, func(in *RunbookExecution) { in.stepInProgress = false })
+			return interactive.CoreMessage{}, runErr
+		}
+		output = out
+		exec, err = e.finishStep(exec.ID, stepIdx, cmdCtx.User.Mention, sectionsToRecord(out), nil)
+	}
+	if err != nil {
+		return interactive.CoreMessage{}, err
+	}
+
+	return e.stepMessage(cmdCtx, rb, exec, output), nil
+}
+
+// Pause pauses a given runbook execution.
+func (e *RunbookExecutor) Pause(_ context.Context, cmdCtx CommandContext) (interactive.CoreMessage, error) {
+	exec, rb, msg, ok := e.getActiveExecution(cmdCtx)
+	if !ok {
+		return msg, nil
+	}
+
+	exec = e.updateExecution(exec.ID, func(in *RunbookExecution) { in.State = RunbookExecutionPaused })
+	return e.stepMessage(cmdCtx, rb, exec, nil), nil
+}
+
+// Abort aborts a given runbook execution.
+func (e *RunbookExecutor) Abort(_ context.Context, cmdCtx CommandContext) (interactive.CoreMessage, error) {
+	exec, rb, msg, ok := e.getActiveExecution(cmdCtx)
+	if !ok {
+		return msg, nil
+	}
+
+	exec = e.updateExecution(exec.ID, func(in *RunbookExecution) { in.State = RunbookExecutionAborted })
+	return e.stepMessage(cmdCtx, rb, exec, nil), nil
+}
+
+// Status returns the execution record of a given runbook execution.
+func (e *RunbookExecutor) Status(_ context.Context, cmdCtx CommandContext) (interactive.CoreMessage, error) {
+	if len(cmdCtx.Args) < 3 {
+		return respond(fmt.Sprintf(runbookExecIDMissing, api.MessageBotNamePlaceholder), cmdCtx), nil
+	}
+	exec, found := e.getExecution(cmdCtx.Args[2])
+	if !found {
+		return respond(fmt.Sprintf(runbookExecNotFound, cmdCtx.Args[2]), cmdCtx), nil
+	}
+
+	buf := new(bytes.Buffer)
+	w := tabwriter.NewWriter(buf, 5, 0, 1, ' ', 0)
+	fmt.Fprintf(w, "Runbook: %s\nStarted by: %s at %s\nState: %s\n\n", exec.RunbookName, exec.StartedBy, exec.StartedAt.Format(time.RFC3339), exec.State)
+	fmt.Fprintf(w, "STEP\tTYPE\tFINISHED BY\tFINISHED AT\tOUTPUT")
+	for _, rec := range exec.Records {
+		fmt.Fprintf(w, "\n%s\t%s\t%s\t%s\t%s", rec.Name, rec.Type, rec.FinishedBy, rec.FinishedAt.Format(time.RFC3339), oneLine(rec.Output))
+	}
+	w.Flush()
+
+	return respond(buf.String(), cmdCtx), nil
+}
+
+func (e *RunbookExecutor) runCommandStep(ctx context.Context, cmdCtx CommandContext, step config.RunbookStep, inputs map[string]string) ([]api.Section, error) {
+	if e.executorFactory == nil {
+		return nil, fmt.Errorf("executor factory for runbook command steps is not set")
+	}
+
+	cmd, err := renderRunbookCommand(step, inputs)
+	if err != nil {
+		return nil, NewExecutionCommandError(err.Error())
+	}
+
+	e.log.WithField("command", cmd).Debug("Running runbook command step...")
+	out := e.executorFactory.NewDefault(NewDefaultInput{
+		CommGroupName:   cmdCtx.CommGroupName,
+		Platform:        cmdCtx.Platform,
+		NotifierHandler: cmdCtx.NotifierHandler,
+		Conversation:    cmdCtx.Conversation,
+		Message:         cmd,
+		User:            cmdCtx.User,
+		AuditContext:    cmdCtx.AuditContext,
+	}).Execute(ctx)
+
+	sections := []api.Section{
+		{
+			Base: api.Base{
+				Description: out.Description,
+				Body:        out.BaseBody,
+			},
+		},
+	}
+	return append(sections, out.Sections...), nil
+}
+
+func renderRunbookCommand(step config.RunbookStep, inputs map[string]string) (string, error) {
+	tpl, err := template.New("runbook-cmd").Funcs(sprig.TxtFuncMap()).Option("missingkey=error").Parse(step.Command)
+	if err != nil {
+		return "", fmt.Errorf("while parsing command template for %q step: %w", step.Name, err)
+	}
+
+	var out bytes.Buffer
+	if err := tpl.Execute(&out, map[string]any{"Inputs": inputs}); err != nil {
+		return "", fmt.Errorf("while rendering command for %q step: %w", step.Name, err)
+	}
+	return out.String(), nil
+}
+
+func (e *RunbookExecutor) stepMessage(cmdCtx CommandContext, rb config.Runbook, exec RunbookExecution, output []api.Section) interactive.CoreMessage {
+	btnBuilder := api.NewMessageButtonBuilder()
+	header := fmt.Sprintf("Runbook %q", runbookDisplayName(exec.RunbookName, rb))
+	execCtx := api.ContextItems{
+		{Text: fmt.Sprintf("Execution `%s` started by %s", exec.ID, exec.StartedBy)},
+	}
+
+	status := api.Section{
+		Base: api.Base{
+			Header: header,
+		},
+		Context: execCtx,
+	}
+
+	// the step number is passed, so only the step the buttons were rendered for is executed
+	resumeCmd := fmt.Sprintf("resume runbook %s %d", exec.ID, exec.CurrentStep+1)
+	pauseCmd := fmt.Sprintf("pause runbook %s", exec.ID)
+	abortCmd := fmt.Sprintf("abort runbook %s", exec.ID)
+	statusBtn := btnBuilder.ForCommandWithoutDesc("Execution record", fmt.Sprintf("status runbook %s", exec.ID))
+
+	switch {
+	case exec.State == RunbookExecutionAborted:
+		status.Description = fmt.Sprintf("Execution aborted at step %d/%d.", exec.CurrentStep+1, len(rb.Steps))
+		status.Buttons = api.Buttons{statusBtn}
+	case exec.State == RunbookExecutionCompleted:
+		status.Description = fmt.Sprintf("All %d steps completed.", len(rb.Steps))
+		status.Buttons = api.Buttons{statusBtn}
+	case exec.State == RunbookExecutionPaused:
+		status.Description = fmt.Sprintf("Execution paused at step %d/%d.", exec.CurrentStep+1, len(rb.Steps))
+		status.Buttons = api.Buttons{
+			btnBuilder.ForCommandWithoutDesc("Resume", resumeCmd, api.ButtonStylePrimary),
+			btnBuilder.ForCommandWithoutDesc("Abort", abortCmd, api.ButtonStyleDanger),
+		}
+	default:
+		step := rb.Steps[exec.CurrentStep]
+		status.Description = fmt.Sprintf("Step %d/%d: *%s*", exec.CurrentStep+1, len(rb.Steps), step.Name)
+		if step.Message != "" {
+			status.Description = fmt.Sprintf("%s\n%s", status.Description, step.Message)
+		}
+		switch step.Type {
+		case config.PromptRunbookStep:
+			status.PlaintextInputs = api.LabelInputs{
+				{
+					Command:          fmt.Sprintf("%s %s ", api.MessageBotNamePlaceholder, resumeCmd),
+					DispatchedAction: api.DispatchInputActionOnEnter,
+					Placeholder:      "Type the value and press Enter",
+					Text:             step.Name,
+				},
+			}
+			status.Buttons = api.Buttons{
+				btnBuilder.ForCommandWithoutDesc("Pause", pauseCmd),
+				btnBuilder.ForCommandWithoutDesc("Abort", abortCmd, api.ButtonStyleDanger),
+			}
+		case config.ConfirmationRunbookStep:
+			status.Buttons = api.Buttons{
+				btnBuilder.ForCommandWithoutDesc("Confirm", resumeCmd, api.ButtonStylePrimary),
+				btnBuilder.ForCommandWithoutDesc("Pause", pauseCmd),
+				btnBuilder.ForCommandWithoutDesc("Abort", abortCmd, api.ButtonStyleDanger),
+			}
+		default:
+			cmd, err := renderRunbookCommand(step, exec.Inputs)
+			if err == nil {
+				status.Body.CodeBlock = cmd
+			}
+			status.Buttons = api.Buttons{
+				btnBuilder.ForCommandWithoutDesc("Run step", resumeCmd, api.ButtonStylePrimary),
+				btnBuilder.ForCommandWithoutDesc("Pause", pauseCmd),
+				btnBuilder.ForCommandWithoutDesc("Abort", abortCmd, api.ButtonStyleDanger),
+			}
+		}
+	}
+
+	return interactive.CoreMessage{
+		Description: header,
+		Message: api.Message{
+			Type:     api.ThreadMessage,
+			Sections: append(output, status),
+		},
+	}
+}
+
+func (e *RunbookExecutor) getActiveExecution(cmdCtx CommandContext) (RunbookExecution, config.Runbook, interactive.CoreMessage, bool) {
+	if len(cmdCtx.Args) < 3 {
+		return RunbookExecution{}, config.Runbook{}, respond(fmt.Sprintf(runbookExecIDMissing, api.MessageBotNamePlaceholder), cmdCtx), false
+	}
+
+	id := cmdCtx.Args[2]
+	exec, found := e.getExecution(id)
+	if !found {
+		return RunbookExecution{}, config.Runbook{}, respond(fmt.Sprintf(runbookExecNotFound, id), cmdCtx), false
+	}
+
+	if exec.State == RunbookExecutionCompleted || exec.State == RunbookExecutionAborted {
+		return RunbookExecution{}, config.Runbook{}, respond(fmt.Sprintf(runbookExecNotActive, id, exec.State), cmdCtx), false
+	}
+
+	rb, found := e.runbooks[exec.RunbookName]
+	if !found || exec.CurrentStep >= len(rb.Steps) {
+		return RunbookExecution{}, config.Runbook{}, respond(fmt.Sprintf(runbookNotFound, exec.RunbookName, e.runbooksTabularOutput()), cmdCtx), false
+	}
+
+	return exec, rb, interactive.CoreMessage{}, true
+}
+
+func (e *RunbookExecutor) newExecution(name, user string) *RunbookExecution {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	exec := &RunbookExecution{
+		ID:          newRunbookExecutionID(),
+		RunbookName: name,
+		StartedBy:   user,
+		StartedAt:   time.Now(),
+		State:       RunbookExecutionRunning,
+		Inputs:      map[string]string{},
+	}
+	e.executions[exec.ID] = exec
+	e.order = append(e.order, exec.ID)
+
+	// keep memory bounded
+	if len(e.order) > runbookMaxExecutions {
+		delete(e.executions, e.order[0])
+		e.order = e.order[1:]
+	}
+
+	return exec
+}
+
+func (e *RunbookExecutor) getExecution(id string) (RunbookExecution, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	exec, found := e.executions[id]
+	if !found {
+		return RunbookExecution{}, false
+	}
+	return exec.copy(), true
+}
+
+func (e *RunbookExecutor) updateExecution(id string, mutate func(in *RunbookExecution)) RunbookExecution {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	exec, found := e.executions[id]
+	if !found {
+		return RunbookExecution{}
+	}
+	mutate(exec)
+	return exec.copy()
+}
+
+// claimStep marks a given step of an active runbook execution as running. It returns an error if the step is not the current one,
+// or it's already running.
+func (e *RunbookExecutor) claimStep(id string, stepIdx int) (RunbookExecution, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	exec, found := e.executions[id]
+	if !found {
+		return RunbookExecution{}, fmt.Errorf(runbookExecNotFound, id)
+	}
+	if exec.State == RunbookExecutionCompleted || exec.State == RunbookExecutionAborted {
+		return RunbookExecution{}, fmt.Errorf(runbookExecNotActive, id, exec.State)
+	}
+	if stepIdx != exec.CurrentStep || stepIdx >= len(e.runbooks[exec.RunbookName].Steps) {
+		return RunbookExecution{}, fmt.Errorf(runbookStepNotCurrent, stepIdx+1, id)
+	}
+	if exec.stepInProgress {
+		return RunbookExecution{}, fmt.Errorf(runbookStepInProgress, stepIdx+1, id)
+	}
+
+	exec.stepInProgress = true
+	exec.State = RunbookExecutionRunning
+	return exec.copy(), nil
+}
+
+// finishStep records the output of a given step, previously claimed with claimStep, and advances the execution to the next step.
+func (e *RunbookExecutor) finishStep(id string, stepIdx int, user, output string, mutate func(in *RunbookExecution)) (RunbookExecution, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	exec, found := e.executions[id]
+	if !found {
+		return RunbookExecution{}, fmt.Errorf(runbookExecNotFound, id)
+	}
+	steps := e.runbooks[exec.RunbookName].Steps
+	if stepIdx != exec.CurrentStep || stepIdx >= len(steps) {
+		return RunbookExecution{}, fmt.Errorf(runbookStepNotCurrent, stepIdx+1, id)
+	}
+
+	if mutate != nil {
+		mutate(exec)
+	}
+	step := steps[stepIdx]
+	exec.Records = append(exec.Records, RunbookStepRecord{
+		Name:       step.Name,
+		Type:       step.Type,
+		FinishedBy: user,
+		FinishedAt: time.Now(),
+		Output:     truncate(output, runbookMaxOutputLength),
+	})
+
+	exec.stepInProgress = false
+	exec.CurrentStep++
+	switch {
+	case exec.State == RunbookExecutionAborted:
+		// the execution was aborted while the step was running
+	case exec.CurrentStep >= len(steps):
+		exec.State = RunbookExecutionCompleted
+	default:
+		exec.State = RunbookExecutionRunning
+	}
+	return exec.copy(), nil
+}
+
+func (e *RunbookExecutor) tabularOutput() string {
+	buf := new(bytes.Buffer)
+	buf.WriteString(e.runbooksTabularOutput())
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if len(e.order) == 0 {
+		return buf.String()
+	}
+
+	w := tabwriter.NewWriter(buf, 5, 0, 1, ' ', 0)
+	fmt.Fprintf(w, "\n\nEXECUTION\tRUNBOOK\tSTATE\tSTEP\tSTARTED BY")
+	for i := len(e.order) - 1; i >= 0; i-- {
+		exec := e.executions[e.order[i]]
+		fmt.Fprintf(w, "\n%s\t%s\t%s\t%d/%d\t%s", exec.ID, exec.RunbookName, exec.State, exec.CurrentStep, len(e.runbooks[exec.RunbookName].Steps), exec.StartedBy)
+	}
+	w.Flush()
+	return buf.String()
+}
+
+func (e *RunbookExecutor) runbooksTabularOutput() string {
+	buf := new(bytes.Buffer)
+	w := tabwriter.NewWriter(buf, 5, 0, 1, ' ', 0)
+	fmt.Fprintf(w, "RUNBOOK\tSTEPS\tDISPLAY NAME")
+	for _, name := range maputil.SortKeys(e.runbooks) {
+		rb := e.runbooks[name]
+		fmt.Fprintf(w, "\n%s\t%d\t%s", name, len(rb.Steps), rb.DisplayName)
+	}
+	w.Flush()
+	return buf.String()
+}
+
+func (r RunbookExecution) copy() RunbookExecution {
+	out := r
+	out.Inputs = make(map[string]string, len(r.Inputs))
+	for k, v := range r.Inputs {
+		out.Inputs[k] = v
+	}
+	out.Records = append([]RunbookStepRecord(nil), r.Records...)
+	return out
+}
+
+func runbookDisplayName(name string, rb config.Runbook) string {
+	if rb.DisplayName != "" {
+		return rb.DisplayName
+	}
+	return name
+}
+
+func sectionsToRecord(sections []api.Section) string {
+	var out []string
+	for _, s := range sections {
+		for _, text := range []string{s.Body.CodeBlock, s.Body.Plaintext} {
+			if text != "" {
+				out = append(out, text)
+			}
+		}
+	}
+	return strings.Join(out, "\n")
+}
+
+func newRunbookExecutionID() string {
+	raw := make([]byte, runbookExecutionIDBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return fmt.Sprintf("%x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(raw)
+}
+
+func oneLine(in string) string {
+	return truncate(removeMultipleSpaces(newLinePattern.ReplaceAllString(in, " ")), 60)
+}
+
+func truncate(in string, max int) string {
+	if len(in) <= max {
+		return in
+	}
+	return in[:max] + "..."
+}
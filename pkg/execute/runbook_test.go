@@ -0,0 +1,243 @@
+package execute
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+func TestRunbookExecutor_StepByStep(t *testing.T) {
+	// given
+	factory := &fakeRunbookCmdFactory{}
+	executor := NewRunbookExecutor(loggerx.NewNoop(), config.Config{Runbooks: fixRunbooksCfg()})
+	executor.executorFactory = factory
+
+	cmdCtx := CommandContext{
+		User:           UserInput{Mention: "@Joe"},
+		ExecutorFilter: newExecutorTextFilter(""),
+	}
+
+	// when: run
+	cmdCtx.Args = []string{"run", "runbook", "restart"}
+	msg, err := executor.Run(context.Background(), cmdCtx)
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, msg.Sections, 1)
+	assert.Equal(t, api.ThreadMessage, msg.Type)
+	assert.Contains(t, msg.Sections[0].Description, "Step 1/3: *namespace*")
+	require.Len(t, msg.Sections[0].PlaintextInputs, 1)
+
+	id := firstExecutionID(t, executor)
+
+	// when: provide input
+	cmdCtx.Args = []string{"resume", "runbook", id, "1", "botkube"}
+	msg, err = executor.Resume(context.Background(), cmdCtx)
+
+	// then
+	require.NoError(t, err)
+	assert.Contains(t, msg.Sections[0].Description, "Step 2/3: *confirm*")
+
+	// when: confirm
+	cmdCtx.Args = []string{"resume", "runbook", id, "2"}
+	msg, err = executor.Resume(context.Background(), cmdCtx)
+
+	// then
+	require.NoError(t, err)
+	assert.Contains(t, msg.Sections[0].Description, "Step 3/3: *restart*")
+	assert.Equal(t, "kubectl rollout restart deploy -n botkube", msg.Sections[0].Body.CodeBlock)
+
+	// when: run command step
+	cmdCtx.Args = []string{"resume", "runbook", id, "3"}
+	msg, err = executor.Resume(context.Background(), cmdCtx)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []string{"kubectl rollout restart deploy -n botkube"}, factory.executed)
+	require.Len(t, msg.Sections, 2)
+	assert.Equal(t, "deployment.apps/botkube restarted", msg.Sections[0].Body.CodeBlock)
+	assert.Equal(t, "All 3 steps completed.", msg.Sections[1].Description)
+
+	exec, found := executor.getExecution(id)
+	require.True(t, found)
+	assert.Equal(t, RunbookExecutionCompleted, exec.State)
+	require.Len(t, exec.Records, 3)
+	assert.Equal(t, "botkube", exec.Records[0].Output)
+	assert.Equal(t, "@Joe", exec.Records[2].FinishedBy)
+
+	// when: resume completed
+	msg, err = executor.Resume(context.Background(), cmdCtx)
+
+	// then
+	require.NoError(t, err)
+	assert.Contains(t, msg.BaseBody.CodeBlock, "is already completed")
+	assert.Len(t, factory.executed, 1)
+}
+
+func TestRunbookExecutor_ResumeStaleStep(t *testing.T) {
+	// given
+	factory := &fakeRunbookCmdFactory{}
+	executor := NewRunbookExecutor(loggerx.NewNoop(), config.Config{Runbooks: fixRunbooksCfg()})
+	executor.executorFactory = factory
+
+	cmdCtx := CommandContext{
+		User:           UserInput{Mention: "@Joe"},
+		ExecutorFilter: newExecutorTextFilter(""),
+		Args:           []string{"run", "runbook", "restart"},
+	}
+	_, err := executor.Run(context.Background(), cmdCtx)
+	require.NoError(t, err)
+	id := firstExecutionID(t, executor)
+
+	cmdCtx.Args = []string{"resume", "runbook", id, "1", "botkube"}
+	_, err = executor.Resume(context.Background(), cmdCtx)
+	require.NoError(t, err)
+
+	// when: confirm clicked twice
+	cmdCtx.Args = []string{"resume", "runbook", id, "2"}
+	_, err = executor.Resume(context.Background(), cmdCtx)
+	require.NoError(t, err)
+	msg, err := executor.Resume(context.Background(), cmdCtx)
+
+	// then
+	require.NoError(t, err)
+	assert.Contains(t, msg.BaseBody.CodeBlock, "Step 2 of runbook execution")
+	assert.Contains(t, msg.BaseBody.CodeBlock, "is not the current one")
+	assert.Empty(t, factory.executed)
+
+	// when: stale button clicked while the execution is paused
+	_, err = executor.Pause(context.Background(), CommandContext{Args: []string{"pause", "runbook", id}})
+	require.NoError(t, err)
+	msg, err = executor.Resume(context.Background(), cmdCtx)
+
+	// then
+	require.NoError(t, err)
+	assert.Contains(t, msg.BaseBody.CodeBlock, "is not the current one")
+	assert.Empty(t, factory.executed)
+
+	// when: out of range step
+	cmdCtx.Args = []string{"resume", "runbook", id, "4"}
+	msg, err = executor.Resume(context.Background(), cmdCtx)
+
+	// then
+	require.NoError(t, err)
+	assert.Contains(t, msg.BaseBody.CodeBlock, "Step 4 of runbook execution")
+	assert.Empty(t, factory.executed)
+
+	// when: command step run, and its button clicked again
+	cmdCtx.Args = []string{"resume", "runbook", id, "3"}
+	_, err = executor.Resume(context.Background(), cmdCtx)
+	require.NoError(t, err)
+	msg, err = executor.Resume(context.Background(), cmdCtx)
+
+	// then
+	require.NoError(t, err)
+	assert.Contains(t, msg.BaseBody.CodeBlock, "is already completed")
+	assert.Equal(t, []string{"kubectl rollout restart deploy -n botkube"}, factory.executed)
+}
+
+func TestRunbookExecutor_PauseAndAbort(t *testing.T) {
+	// given
+	executor := NewRunbookExecutor(loggerx.NewNoop(), config.Config{Runbooks: fixRunbooksCfg()})
+	cmdCtx := CommandContext{
+		User:           UserInput{Mention: "@Joe"},
+		ExecutorFilter: newExecutorTextFilter(""),
+		Args:           []string{"run", "runbook", "restart"},
+	}
+	_, err := executor.Run(context.Background(), cmdCtx)
+	require.NoError(t, err)
+	id := firstExecutionID(t, executor)
+
+	// when
+	cmdCtx.Args = []string{"pause", "runbook", id}
+	msg, err := executor.Pause(context.Background(), cmdCtx)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "Execution paused at step 1/3.", msg.Sections[0].Description)
+
+	// when
+	cmdCtx.Args = []string{"abort", "runbook", id}
+	msg, err = executor.Abort(context.Background(), cmdCtx)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "Execution aborted at step 1/3.", msg.Sections[0].Description)
+
+	exec, found := executor.getExecution(id)
+	require.True(t, found)
+	assert.Equal(t, RunbookExecutionAborted, exec.State)
+}
+
+func TestRunbookExecutor_RunUnknown(t *testing.T) {
+	// given
+	executor := NewRunbookExecutor(loggerx.NewNoop(), config.Config{Runbooks: fixRunbooksCfg()})
+	cmdCtx := CommandContext{
+		Args:           []string{"run", "runbook", "other"},
+		ExecutorFilter: newExecutorTextFilter(""),
+	}
+
+	// when
+	msg, err := executor.Run(context.Background(), cmdCtx)
+
+	// then
+	require.NoError(t, err)
+	assert.Contains(t, msg.BaseBody.CodeBlock, `Runbook "other" not found`)
+	assert.Contains(t, msg.BaseBody.CodeBlock, "restart 3     Restart deployments")
+}
+
+func firstExecutionID(t *testing.T, executor *RunbookExecutor) string {
+	t.Helper()
+	require.Len(t, executor.order, 1)
+	return executor.order[0]
+}
+
+type fakeRunbookCmdFactory struct {
+	executed []string
+}
+
+func (f *fakeRunbookCmdFactory) NewDefault(cfg NewDefaultInput) Executor {
+	f.executed = append(f.executed, cfg.Message)
+	return fakeRunbookCmdExecutor{}
+}
+
+type fakeRunbookCmdExecutor struct{}
+
+func (fakeRunbookCmdExecutor) Execute(context.Context) interactive.CoreMessage {
+	return interactive.CoreMessage{
+		Message: api.Message{
+			BaseBody: api.Body{CodeBlock: "deployment.apps/botkube restarted"},
+		},
+	}
+}
+
+func fixRunbooksCfg() config.Runbooks {
+	return config.Runbooks{
+		"restart": {
+			DisplayName: "Restart deployments",
+			Steps: []config.RunbookStep{
+				{
+					Name:    "namespace",
+					Type:    config.PromptRunbookStep,
+					Message: "Which namespace?",
+				},
+				{
+					Name: "confirm",
+					Type: config.ConfirmationRunbookStep,
+				},
+				{
+					Name:    "restart",
+					Type:    config.CommandRunbookStep,
+					Command: "kubectl rollout restart deploy -n {{ .Inputs.namespace }}",
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,130 @@
+package execute
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/internal/upgradecheck"
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/execute/command"
+)
+
+const (
+	upgradeCheckNotAvailable = "Upgrade readiness check is not available."
+	upgradeCheckUsage        = "You need to specify the target Kubernetes version, e.g. `%s upgrade-check 1.31`."
+	upgradeCheckHeader       = "Upgrade readiness"
+	upgradeCheckClusterScope = "Cluster-scoped"
+	upgradeCheckNoFindings   = "No blockers or warnings found. The cluster is ready for the upgrade."
+)
+
+var upgradeCheckFeatureName = FeatureName{Name: noFeature, Positional: true}
+
+// UpgradeChecker checks whether the cluster is ready for a Kubernetes upgrade.
+type UpgradeChecker interface {
+	Check(ctx context.Context, target string) (upgradecheck.Report, error)
+}
+
+// UpgradeCheckExecutor executes the pre-upgrade readiness check.
+type UpgradeCheckExecutor struct {
+	log     logrus.FieldLogger
+	checker UpgradeChecker
+}
+
+// NewUpgradeCheckExecutor returns a new UpgradeCheckExecutor instance. The checker is optional.
+func NewUpgradeCheckExecutor(log logrus.FieldLogger, checker UpgradeChecker) *UpgradeCheckExecutor {
+	return &UpgradeCheckExecutor{
+		log:     log,
+		checker: checker,
+	}
+}
+
+// FeatureName returns the name and aliases of the feature provided by this executor
+func (e *UpgradeCheckExecutor) FeatureName() FeatureName {
+	return upgradeCheckFeatureName
+}
+
+// Commands returns slice of commands the executor supports
+func (e *UpgradeCheckExecutor) Commands() map[command.Verb]CommandFn {
+	return map[command.Verb]CommandFn{
+		command.UpgradeCheckVerb: e.UpgradeCheck,
+	}
+}
+
+// UpgradeCheck responds with the readiness report for an upgrade to a given version, e.g. `upgrade-check 1.31`.
+// Findings of deprecated API, PodDisruptionBudget and version skew checks are grouped into action items per namespace.
+func (e *UpgradeCheckExecutor) UpgradeCheck(ctx context.Context, cmdCtx CommandContext) (interactive.CoreMessage, error) {
+	if e.checker == nil {
+		return respond(upgradeCheckNotAvailable, cmdCtx), nil
+	}
+	if len(cmdCtx.Args) != 2 {
+		return respond(fmt.Sprintf(upgradeCheckUsage, api.MessageBotNamePlaceholder), cmdCtx), nil
+	}
+
+	report, err := e.checker.Check(ctx, cmdCtx.Args[1])
+	switch {
+	case err == nil:
+	case errors.Is(err, upgradecheck.ErrInvalidTarget):
+		return respond(err.Error(), cmdCtx), nil
+	default:
+		return interactive.CoreMessage{}, fmt.Errorf("while checking upgrade readiness: %w", err)
+	}
+
+	return interactive.CoreMessage{
+		Header:  upgradeCheckHeader,
+		Message: upgradeCheckMessage(report),
+	}, nil
+}
+
+func upgradeCheckMessage(report upgradecheck.Report) api.Message {
+	status := "Ready"
+	if !report.Ready() {
+		status = "Not ready"
+	}
+	sections := []api.Section{
+		{
+			Base: api.Base{
+				Header: fmt.Sprintf("Upgrade from %s to %s", report.CurrentVersion, report.TargetVersion),
+			},
+			TextFields: api.TextFields{
+				{Key: "Status", Value: status},
+				{Key: "Blockers", Value: fmt.Sprintf("%d", report.Count(upgradecheck.SeverityBlocker))},
+				{Key: "Warnings", Value: fmt.Sprintf("%d", report.Count(upgradecheck.SeverityWarning))},
+			},
+		},
+	}
+	if len(report.Findings) == 0 {
+		sections[0].Description = upgradeCheckNoFindings
+		return api.Message{Sections: sections}
+	}
+
+	// findings are already sorted by namespace, with cluster-scoped ones first
+	var (
+		namespaces []string
+		items      = map[string][]string{}
+	)
+	for _, f := range report.Findings {
+		if _, found := items[f.Namespace]; !found {
+			namespaces = append(namespaces, f.Namespace)
+		}
+		items[f.Namespace] = append(items[f.Namespace], fmt.Sprintf("[%s] %s `%s`: %s. %s", f.Severity, f.Check, f.Object, f.Message, f.Action))
+	}
+	for _, ns := range namespaces {
+		header := upgradeCheckClusterScope
+		if ns != "" {
+			header = fmt.Sprintf("Namespace %s", ns)
+		}
+		sections = append(sections, api.Section{
+			Base: api.Base{
+				Header: header,
+			},
+			BulletLists: api.BulletLists{
+				{Title: "Action items", Items: items[ns]},
+			},
+		})
+	}
+	return api.Message{Sections: sections}
+}
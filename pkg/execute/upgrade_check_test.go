@@ -0,0 +1,164 @@
+package execute
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/internal/upgradecheck"
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/execute/command"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+type fakeUpgradeChecker struct {
+	report upgradecheck.Report
+	target string
+}
+
+func (f *fakeUpgradeChecker) Check(_ context.Context, target string) (upgradecheck.Report, error) {
+	if target != "1.31" {
+		return upgradecheck.Report{}, fmt.Errorf("%w: %s is not newer than the current version 1.30", upgradecheck.ErrInvalidTarget, target)
+	}
+	f.target = target
+	return f.report, nil
+}
+
+func TestUpgradeCheckExecutor(t *testing.T) {
+	// given
+	checker := &fakeUpgradeChecker{
+		report: upgradecheck.Report{
+			CurrentVersion: "1.30",
+			TargetVersion:  "1.31",
+			Findings: []upgradecheck.Finding{
+				{Check: upgradecheck.CheckVersionSkew, Severity: upgradecheck.SeverityWarning, Object: "node/worker-1", Message: "kubelet 1.29 is older than the control plane 1.30", Action: "Upgrade the node."},
+				{Namespace: "prod", Check: upgradecheck.CheckDisruptionBudgets, Severity: upgradecheck.SeverityBlocker, Object: "poddisruptionbudget/api", Message: "no disruptions allowed with 3/3 healthy Pods", Action: "Relax minAvailable."},
+				{Namespace: "prod", Check: upgradecheck.CheckRemovedAPIs, Severity: upgradecheck.SeverityBlocker, Object: "flowschema/api", Message: "flowcontrol.apiserver.k8s.io/v1beta3 FlowSchema is removed in 1.32", Action: "Update the manifest."},
+			},
+		},
+	}
+	e := NewUpgradeCheckExecutor(loggerx.NewNoop(), checker)
+
+	// when
+	msg, err := e.UpgradeCheck(context.Background(), CommandContext{
+		Args:           strings.Fields("upgrade-check 1.31"),
+		ExecutorFilter: newExecutorTextFilter(""),
+	})
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "1.31", checker.target)
+	assert.Equal(t, "Upgrade readiness", msg.Header)
+	require.Len(t, msg.Sections, 3)
+
+	assert.Equal(t, "Upgrade from 1.30 to 1.31", msg.Sections[0].Header)
+	assert.Equal(t, api.TextFields{
+		{Key: "Status", Value: "Not ready"},
+		{Key: "Blockers", Value: "2"},
+		{Key: "Warnings", Value: "1"},
+	}, msg.Sections[0].TextFields)
+
+	assert.Equal(t, "Cluster-scoped", msg.Sections[1].Header)
+	assert.Equal(t, api.BulletLists{
+		{Title: "Action items", Items: []string{
+			"[warning] Version skew `node/worker-1`: kubelet 1.29 is older than the control plane 1.30. Upgrade the node.",
+		}},
+	}, msg.Sections[1].BulletLists)
+
+	assert.Equal(t, "Namespace prod", msg.Sections[2].Header)
+	assert.Equal(t, api.BulletLists{
+		{Title: "Action items", Items: []string{
+			"[blocker] PodDisruptionBudgets `poddisruptionbudget/api`: no disruptions allowed with 3/3 healthy Pods. Relax minAvailable.",
+			"[blocker] Removed APIs `flowschema/api`: flowcontrol.apiserver.k8s.io/v1beta3 FlowSchema is removed in 1.32. Update the manifest.",
+		}},
+	}, msg.Sections[2].BulletLists)
+}
+
+func TestUpgradeCheckExecutorReady(t *testing.T) {
+	// given
+	e := NewUpgradeCheckExecutor(loggerx.NewNoop(), &fakeUpgradeChecker{
+		report: upgradecheck.Report{CurrentVersion: "1.30", TargetVersion: "1.31"},
+	})
+
+	// when
+	msg, err := e.UpgradeCheck(context.Background(), CommandContext{
+		Args:           strings.Fields("upgrade-check 1.31"),
+		ExecutorFilter: newExecutorTextFilter(""),
+	})
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, msg.Sections, 1)
+	assert.Equal(t, "Ready", msg.Sections[0].TextFields[0].Value)
+	assert.Equal(t, "No blockers or warnings found. The cluster is ready for the upgrade.", msg.Sections[0].Description)
+}
+
+func TestUpgradeCheckExecutorInvalidInput(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        string
+		checker     UpgradeChecker
+		expectedMsg string
+	}{
+		{
+			name:        "Checker not configured",
+			args:        "upgrade-check 1.31",
+			expectedMsg: "Upgrade readiness check is not available.",
+		},
+		{
+			name:        "Missing target version",
+			args:        "upgrade-check",
+			checker:     &fakeUpgradeChecker{},
+			expectedMsg: "You need to specify the target Kubernetes version, e.g. `{{BotName}} upgrade-check 1.31`.",
+		},
+		{
+			name:        "Invalid target version",
+			args:        "upgrade-check 1.30",
+			checker:     &fakeUpgradeChecker{},
+			expectedMsg: "invalid target version: 1.30 is not newer than the current version 1.30",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			e := NewUpgradeCheckExecutor(loggerx.NewNoop(), tc.checker)
+
+			// when
+			msg, err := e.UpgradeCheck(context.Background(), CommandContext{
+				Args:           strings.Fields(tc.args),
+				ExecutorFilter: newExecutorTextFilter(""),
+			})
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedMsg, msg.BaseBody.CodeBlock)
+		})
+	}
+}
+
+func TestCommandMappingFindFnPositional(t *testing.T) {
+	// given
+	mapping, err := NewCmdsMapping([]CommandExecutor{
+		NewUpgradeCheckExecutor(loggerx.NewNoop(), nil),
+		NewOverviewExecutor(loggerx.NewNoop(), nil),
+	})
+	require.NoError(t, err)
+
+	// when
+	_, foundVerb, foundFn := mapping.FindFn(command.UpgradeCheckVerb, "1.31")
+
+	// then
+	assert.True(t, foundVerb)
+	assert.True(t, foundFn)
+
+	// when
+	_, foundVerb, foundFn = mapping.FindFn(command.OverviewVerb, "unknown")
+
+	// then
+	assert.True(t, foundVerb)
+	assert.False(t, foundFn)
+}
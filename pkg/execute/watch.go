@@ -0,0 +1,289 @@
+package execute
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/pflag"
+
+	"github.com/kubeshop/botkube/internal/livewatch"
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/execute/command"
+)
+
+const (
+	watchNotAvailable   = "Watching resources is not available."
+	watchNotSupported   = "Watching resources is not supported on this platform."
+	watchUsage          = "You need to specify a single resource, e.g. `%s watch deployment/foo -n default --for 10m`."
+	watchNotFound       = "Resource `%s` not found in the `%s` namespace."
+	watchStarted        = "Watching `%s` in the `%s` namespace until %s. The live status is posted in the thread."
+	watchHeader         = "Watch"
+	watchTimedOut       = "Watch finished at %s."
+	watchStoppedBy      = "Watch stopped by %s at %s."
+	watchResourceGone   = "Resource was deleted at %s. Watch finished."
+	watchFailed         = "Watch failed at %s: %s"
+	watchUpdatedAt      = "Updated at %s. Watching until %s."
+	watchUpdateTimeout  = 30 * time.Second
+	watchDefaultFeature = "deployment"
+)
+
+// ResourceWatcher watches Kubernetes resources for the watch command.
+type ResourceWatcher interface {
+	Duration(requested time.Duration) (time.Duration, error)
+	UpdateInterval() time.Duration
+	Status(ctx context.Context, ref livewatch.Ref) (livewatch.Status, error)
+	Watch(ctx context.Context, ref livewatch.Ref, onChange func(livewatch.Status)) error
+}
+
+// WatchExecutor executes the watch command, which posts a status message of a given resource and updates it on every change.
+type WatchExecutor struct {
+	log     logrus.FieldLogger
+	watcher ResourceWatcher
+	tracker *ExecutionTracker
+	now     func() time.Time
+}
+
+// NewWatchExecutor returns a new WatchExecutor instance. The watcher is optional.
+// Watches are registered in the tracker, so they can be stopped with the cancel command.
+func NewWatchExecutor(log logrus.FieldLogger, watcher ResourceWatcher, tracker *ExecutionTracker) *WatchExecutor {
+	return &WatchExecutor{
+		log:     log,
+		watcher: watcher,
+		tracker: tracker,
+		now:     time.Now,
+	}
+}
+
+// FeatureName returns the name and aliases of the feature provided by this executor
+func (e *WatchExecutor) FeatureName() FeatureName {
+	var aliases []string
+	for _, name := range livewatch.KindAliases() {
+		if name != watchDefaultFeature {
+			aliases = append(aliases, name)
+		}
+	}
+	return FeatureName{
+		Name:    watchDefaultFeature,
+		Aliases: aliases,
+	}
+}
+
+// Commands returns slice of commands the executor supports
+func (e *WatchExecutor) Commands() map[command.Verb]CommandFn {
+	return map[command.Verb]CommandFn{
+		command.WatchVerb: e.Watch,
+	}
+}
+
+// Watch starts watching a given resource, e.g. `watch deployment/foo -n default --for 10m`.
+// The status message is posted in the thread and updated until the watch times out, is stopped, or the resource is deleted.
+func (e *WatchExecutor) Watch(ctx context.Context, cmdCtx CommandContext) (interactive.CoreMessage, error) {
+	if e.watcher == nil {
+		return respond(watchNotAvailable, cmdCtx), nil
+	}
+	updater, ok := cmdCtx.NotifierHandler.(MessageUpdater)
+	if !ok {
+		return respond(watchNotSupported, cmdCtx), nil
+	}
+
+	var (
+		namespace string
+		requested time.Duration
+	)
+	flags := pflag.NewFlagSet("watch", pflag.ContinueOnError)
+	flags.StringVarP(&namespace, "namespace", "n", "", "Namespace of the resource")
+	flags.DurationVar(&requested, "for", 0, "Watch duration")
+	if err := flags.Parse(cmdCtx.Args[1:]); err != nil {
+		return respond(fmt.Sprintf("Cannot parse command: %s", err.Error()), cmdCtx), nil
+	}
+	if flags.NArg() != 1 {
+		return respond(fmt.Sprintf(watchUsage, api.MessageBotNamePlaceholder), cmdCtx), nil
+	}
+
+	ref, err := livewatch.ParseRef(flags.Arg(0), namespace)
+	if err != nil {
+		return respond(err.Error(), cmdCtx), nil
+	}
+	duration, err := e.watcher.Duration(requested)
+	if err != nil {
+		return respond(err.Error(), cmdCtx), nil
+	}
+
+	status, err := e.watcher.Status(ctx, ref)
+	switch {
+	case err == nil:
+	case errors.Is(err, livewatch.ErrNotFound):
+		return respond(fmt.Sprintf(watchNotFound, ref, ref.Namespace), cmdCtx), nil
+	default:
+		return interactive.CoreMessage{}, err
+	}
+
+	// the watch outlives the command, so it's not bound to the command context
+	watchCtx, id, done, err := e.tracker.StartWithTimeout(context.Background(), cmdCtx.Conversation.ID, cmdCtx.CleanCmd, duration)
+	if err != nil {
+		return interactive.CoreMessage{}, err
+	}
+	until := e.now().Add(duration)
+	live := liveWatch{
+		ref:          ref,
+		id:           id,
+		until:        until,
+		updater:      updater,
+		conversation: cmdCtx.Conversation,
+	}
+
+	live.messageID, err = updater.PostUpdatableMessage(ctx, cmdCtx.Conversation, live.message(status, fmt.Sprintf(watchUpdatedAt, e.formatTime(e.now()), e.formatTime(until)), true))
+	if err != nil {
+		done()
+		return interactive.CoreMessage{}, fmt.Errorf("while posting status message: %w", err)
+	}
+
+	e.log.WithField("id", id).Infof("Watching %s in %q namespace started by %s", ref, ref.Namespace, cmdCtx.User.DisplayName)
+	go e.run(watchCtx, done, live, status)
+
+	return respond(fmt.Sprintf(watchStarted, ref, ref.Namespace, e.formatTime(until)), cmdCtx), nil
+}
+
+// run updates the status message on resource changes, at most once per the update interval, until the watch is finished.
+func (e *WatchExecutor) run(ctx context.Context, done func(), live liveWatch, last livewatch.Status) {
+	defer done()
+
+	updates := make(chan livewatch.Status, 1)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- e.watcher.Watch(ctx, live.ref, func(status livewatch.Status) {
+			// only the latest status is kept
+			select {
+			case <-updates:
+			default:
+			}
+			updates <- status
+		})
+	}()
+
+	ticker := time.NewTicker(e.watcher.UpdateInterval())
+	defer ticker.Stop()
+
+	var pending *livewatch.Status
+	for {
+		select {
+		case status := <-updates:
+			pending = &status
+		case <-ticker.C:
+			if pending == nil {
+				continue
+			}
+			next := nextStatus(last, *pending)
+			pending = nil
+			if reflect.DeepEqual(next, last) {
+				continue
+			}
+			last = next
+			e.update(live, live.message(last, fmt.Sprintf(watchUpdatedAt, e.formatTime(e.now()), e.formatTime(live.until)), true))
+		case err := <-errCh:
+			select {
+			case status := <-updates:
+				pending = &status
+			default:
+			}
+			if pending != nil {
+				last = nextStatus(last, *pending)
+			}
+			e.update(live, live.message(last, e.finishedReason(ctx, live, last, err), false))
+			return
+		}
+	}
+}
+
+// nextStatus returns the status to show. A deleted resource is shown with its last known status.
+func nextStatus(last, next livewatch.Status) livewatch.Status {
+	if next.Deleted {
+		last.Deleted = true
+		return last
+	}
+	return next
+}
+
+func (e *WatchExecutor) finishedReason(ctx context.Context, live liveWatch, last livewatch.Status, err error) string {
+	now := e.formatTime(e.now())
+	switch {
+	case err != nil:
+		e.log.WithError(err).WithField("id", live.id).Errorf("Watching %s failed", live.ref)
+		return fmt.Sprintf(watchFailed, now, err.Error())
+	case last.Deleted:
+		return fmt.Sprintf(watchResourceGone, now)
+	case errors.Is(ctx.Err(), context.Canceled):
+		if user := e.tracker.CanceledBy(live.id); user != "" {
+			return fmt.Sprintf(watchStoppedBy, user, now)
+		}
+	}
+	return fmt.Sprintf(watchTimedOut, now)
+}
+
+func (e *WatchExecutor) update(live liveWatch, msg interactive.CoreMessage) {
+	ctx, cancel := context.WithTimeout(context.Background(), watchUpdateTimeout)
+	defer cancel()
+	if err := live.updater.UpdateMessage(ctx, live.conversation, live.messageID, msg); err != nil {
+		e.log.WithError(err).WithField("id", live.id).Error("Failed to update watch status message")
+	}
+}
+
+func (e *WatchExecutor) formatTime(t time.Time) string {
+	return t.UTC().Format(incidentTimeFormat)
+}
+
+// liveWatch holds details of a running watch.
+type liveWatch struct {
+	ref          livewatch.Ref
+	id           string
+	until        time.Time
+	updater      MessageUpdater
+	conversation Conversation
+	messageID    string
+}
+
+func (w liveWatch) message(status livewatch.Status, note string, running bool) interactive.CoreMessage {
+	var fields api.TextFields
+	if status.Deleted {
+		fields = append(fields, api.TextField{Key: "Status", Value: "Deleted"})
+	}
+	for _, f := range status.Fields {
+		fields = append(fields, api.TextField{Key: f.Key, Value: f.Value})
+	}
+
+	footer := api.Section{
+		Context: api.ContextItems{
+			{Text: note},
+		},
+	}
+	if running {
+		btnBuilder := api.NewMessageButtonBuilder()
+		footer.Buttons = api.Buttons{
+			btnBuilder.ForCommandWithoutDesc("Stop", fmt.Sprintf("%s %s %s", command.CancelVerb, cmdFeatureName.Name, w.id), api.ButtonStyleDanger),
+		}
+	}
+
+	return interactive.CoreMessage{
+		Header: watchHeader,
+		Message: api.Message{
+			Sections: []api.Section{
+				{
+					Base: api.Base{
+						Header: fmt.Sprintf("%s in the %s namespace", w.ref, w.ref.Namespace),
+					},
+					TextFields: fields,
+					BulletLists: api.BulletLists{
+						overviewList("Conditions", status.Conditions),
+						overviewList("Images", status.Images),
+					},
+				},
+				footer,
+			},
+		},
+	}
+}
@@ -0,0 +1,235 @@
+package execute
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/internal/livewatch"
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/execute/command"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+type fakeResourceWatcher struct {
+	status  livewatch.Status
+	changes chan livewatch.Status
+}
+
+func (f *fakeResourceWatcher) Duration(requested time.Duration) (time.Duration, error) {
+	if requested == 0 {
+		return time.Minute, nil
+	}
+	return requested, nil
+}
+
+func (f *fakeResourceWatcher) UpdateInterval() time.Duration {
+	return 10 * time.Millisecond
+}
+
+func (f *fakeResourceWatcher) Status(_ context.Context, ref livewatch.Ref) (livewatch.Status, error) {
+	if ref.Name != "api" {
+		return livewatch.Status{}, livewatch.ErrNotFound
+	}
+	return f.status, nil
+}
+
+func (f *fakeResourceWatcher) Watch(ctx context.Context, _ livewatch.Ref, onChange func(livewatch.Status)) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case status := <-f.changes:
+			onChange(status)
+			if status.Deleted {
+				return nil
+			}
+		}
+	}
+}
+
+type fakeMessageUpdater struct {
+	fakeNotifierHandler
+
+	mu       sync.Mutex
+	posted   []interactive.CoreMessage
+	messages map[string]interactive.CoreMessage
+}
+
+func (f *fakeMessageUpdater) PostUpdatableMessage(_ context.Context, _ Conversation, msg interactive.CoreMessage) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.posted = append(f.posted, msg)
+	f.messages["1"] = msg
+	return "1", nil
+}
+
+func (f *fakeMessageUpdater) UpdateMessage(_ context.Context, _ Conversation, messageID string, msg interactive.CoreMessage) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.messages[messageID] = msg
+	return nil
+}
+
+func (f *fakeMessageUpdater) message(id string) interactive.CoreMessage {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.messages[id]
+}
+
+func TestWatchExecutor(t *testing.T) {
+	// given
+	now := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	watcher := &fakeResourceWatcher{
+		status: livewatch.Status{
+			Fields:     []livewatch.Field{{Key: "Ready", Value: "1/3"}},
+			Conditions: []string{"Available=False"},
+			Images:     []string{"app: api:v1"},
+		},
+		changes: make(chan livewatch.Status),
+	}
+	tracker := NewExecutionTracker(loggerx.NewNoop(), config.Execution{})
+	e := NewWatchExecutor(loggerx.NewNoop(), watcher, tracker)
+	e.now = func() time.Time { return now }
+	updater := &fakeMessageUpdater{messages: map[string]interactive.CoreMessage{}}
+	cmdCtx := CommandContext{
+		Args:            strings.Fields("watch deploy/api -n prod --for 5m"),
+		CleanCmd:        "watch deploy/api -n prod --for 5m",
+		ExecutorFilter:  newExecutorTextFilter(""),
+		NotifierHandler: updater,
+		User:            UserInput{Mention: "<@U1>"},
+		Conversation:    Conversation{ID: "C1"},
+	}
+
+	// when
+	msg, err := e.Watch(context.Background(), cmdCtx)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "Watching `deployment/api` in the `prod` namespace until 2023-06-01 12:05:00 UTC. The live status is posted in the thread.", msg.BaseBody.CodeBlock)
+
+	require.Len(t, updater.posted, 1)
+	posted := updater.posted[0]
+	require.Len(t, posted.Sections, 2)
+	assert.Equal(t, "deployment/api in the prod namespace", posted.Sections[0].Header)
+	assert.Equal(t, api.TextFields{{Key: "Ready", Value: "1/3"}}, posted.Sections[0].TextFields)
+	assert.Equal(t, api.BulletLists{
+		{Title: "Conditions", Items: []string{"Available=False"}},
+		{Title: "Images", Items: []string{"app: api:v1"}},
+	}, posted.Sections[0].BulletLists)
+	assert.Equal(t, "Updated at 2023-06-01 12:00:00 UTC. Watching until 2023-06-01 12:05:00 UTC.", posted.Sections[1].Context[0].Text)
+	require.Len(t, posted.Sections[1].Buttons, 1)
+	require.Len(t, tracker.running, 1)
+	var execID string
+	for id := range tracker.running {
+		execID = id
+	}
+	assert.Equal(t, api.MessageBotNamePlaceholder+" cancel command "+execID, posted.Sections[1].Buttons[0].Command)
+
+	// when the resource changes
+	watcher.changes <- livewatch.Status{Fields: []livewatch.Field{{Key: "Ready", Value: "3/3"}}, Images: []string{"app: api:v2"}}
+
+	// then
+	assert.Eventually(t, func() bool {
+		fields := updater.message("1").Sections[0].TextFields
+		return len(fields) == 1 && fields[0].Value == "3/3"
+	}, time.Second, 10*time.Millisecond)
+
+	// when the Stop button is clicked
+	_, err = tracker.Cancel(execID, "C1", "<@U2>")
+	require.NoError(t, err)
+
+	// then
+	assert.Eventually(t, func() bool {
+		footer := updater.message("1").Sections[1]
+		return len(footer.Buttons) == 0 && footer.Context[0].Text == "Watch stopped by <@U2> at 2023-06-01 12:00:00 UTC."
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, api.TextFields{{Key: "Ready", Value: "3/3"}}, updater.message("1").Sections[0].TextFields)
+}
+
+func TestWatchExecutorResourceDeleted(t *testing.T) {
+	// given
+	watcher := &fakeResourceWatcher{
+		status:  livewatch.Status{Fields: []livewatch.Field{{Key: "Ready", Value: "1/1"}}},
+		changes: make(chan livewatch.Status),
+	}
+	e := NewWatchExecutor(loggerx.NewNoop(), watcher, NewExecutionTracker(loggerx.NewNoop(), config.Execution{}))
+	updater := &fakeMessageUpdater{messages: map[string]interactive.CoreMessage{}}
+
+	_, err := e.Watch(context.Background(), CommandContext{
+		Args:            strings.Fields("watch pod/api"),
+		ExecutorFilter:  newExecutorTextFilter(""),
+		NotifierHandler: updater,
+	})
+	require.NoError(t, err)
+
+	// when
+	watcher.changes <- livewatch.Status{Deleted: true}
+
+	// then
+	assert.Eventually(t, func() bool {
+		section := updater.message("1").Sections[0]
+		return len(section.TextFields) == 2 && section.TextFields[0] == api.TextField{Key: "Status", Value: "Deleted"}
+	}, time.Second, 10*time.Millisecond)
+	assert.Contains(t, updater.message("1").Sections[1].Context[0].Text, "Resource was deleted at")
+}
+
+func TestWatchExecutorInvalidInput(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        string
+		handler     NotifierHandler
+		expectedMsg string
+	}{
+		{
+			name:        "Platform without message updates",
+			args:        "watch deployment/api",
+			handler:     &fakeNotifierHandler{},
+			expectedMsg: watchNotSupported,
+		},
+		{
+			name:        "Missing resource",
+			args:        "watch deployment",
+			expectedMsg: `resource "deployment" must be given in the kind/name form, e.g. deployment/foo`,
+		},
+		{
+			name:        "Multiple resources",
+			args:        "watch deployment/api deployment/web",
+			expectedMsg: "You need to specify a single resource, e.g. `{{BotName}} watch deployment/foo -n default --for 10m`.",
+		},
+		{
+			name:        "Resource not found",
+			args:        "watch deployment/web -n prod",
+			expectedMsg: "Resource `deployment/web` not found in the `prod` namespace.",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			handler := tc.handler
+			if handler == nil {
+				handler = &fakeMessageUpdater{messages: map[string]interactive.CoreMessage{}}
+			}
+			e := NewWatchExecutor(loggerx.NewNoop(), &fakeResourceWatcher{}, NewExecutionTracker(loggerx.NewNoop(), config.Execution{}))
+
+			// when
+			msg, err := e.Watch(context.Background(), CommandContext{
+				Args:            strings.Fields(tc.args),
+				ExecutorFilter:  newExecutorTextFilter(""),
+				NotifierHandler: handler,
+				Conversation:    Conversation{CommandOrigin: command.TypedOrigin},
+			})
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedMsg, msg.BaseBody.CodeBlock)
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"google.golang.org/grpc/credentials/insecure"
 
 	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/tlsx"
 )
 
 // ClientTransportCredentials returns gRPC client transport credentials based on the provided configuration.
@@ -24,7 +25,8 @@ func ClientTransportCredentials(log logrus.FieldLogger, cfg config.GRPCServer) (
 		err      error
 	)
 	if cfg.TLS.UseSystemCertPool {
-		certPool, err = x509.SystemCertPool()
+		// the system pool is extended with CA certificates of the global TLS policy
+		certPool, err = tlsx.RootCAs()
 		if err != nil {
 			return nil, fmt.Errorf("while getting system certificate pool: %w", err)
 		}
@@ -39,11 +41,11 @@ func ClientTransportCredentials(log logrus.FieldLogger, cfg config.GRPCServer) (
 		}
 	}
 
-	if cfg.TLS.InsecureSkipVerify {
-		log.Warn("InsecureSkipVerify is enabled. Skipping TLS certificate verification...")
+	tlsCfg, err := tlsx.NewClientConfig(tlsx.ClientOptions{Name: "gRPC server", InsecureSkipVerify: cfg.TLS.InsecureSkipVerify})
+	if err != nil {
+		return nil, fmt.Errorf("while creating TLS configuration: %w", err)
 	}
-
-	//nolint:gosec // G402: TLS InsecureSkipVerify may be true. - Yes, indeed - just for development purposes.
-	tlsCfg := &tls.Config{MinVersion: tls.VersionTLS13, InsecureSkipVerify: cfg.TLS.InsecureSkipVerify, RootCAs: certPool}
+	tlsCfg.MinVersion = tls.VersionTLS13
+	tlsCfg.RootCAs = certPool
 	return credentials.NewTLS(tlsCfg), nil
 }
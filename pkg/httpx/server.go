@@ -27,13 +27,7 @@ func NewServer(log logrus.FieldLogger, addr string, handler http.Handler) *Serve
 
 // Serve starts the HTTP server and blocks unil the channel is closed or an error occurs.
 func (s *Server) Serve(ctx context.Context) error {
-	go func() {
-		<-ctx.Done()
-		s.log.Info("Shutdown requested. Finishing...")
-		if err := s.srv.Shutdown(context.Background()); err != nil {
-			s.log.Error("while shutting down server: %w", err)
-		}
-	}()
+	s.shutdownOnDone(ctx)
 
 	s.log.Infof("Starting server on address %q", s.srv.Addr)
 	if err := s.srv.ListenAndServe(); err != http.ErrServerClosed {
@@ -42,3 +36,25 @@ func (s *Server) Serve(ctx context.Context) error {
 
 	return nil
 }
+
+// ServeTLS starts the HTTPS server and blocks until the channel is closed or an error occurs.
+func (s *Server) ServeTLS(ctx context.Context, certFile, keyFile string) error {
+	s.shutdownOnDone(ctx)
+
+	s.log.Infof("Starting TLS server on address %q", s.srv.Addr)
+	if err := s.srv.ListenAndServeTLS(certFile, keyFile); err != http.ErrServerClosed {
+		return fmt.Errorf("while starting TLS server: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Server) shutdownOnDone(ctx context.Context) {
+	go func() {
+		<-ctx.Done()
+		s.log.Info("Shutdown requested. Finishing...")
+		if err := s.srv.Shutdown(context.Background()); err != nil {
+			s.log.Error("while shutting down server: %w", err)
+		}
+	}()
+}
@@ -27,6 +27,35 @@ type Bot interface {
 	Type() config.IntegrationType
 }
 
+// ChannelSender is implemented by bots which can send messages to specific channels.
+type ChannelSender interface {
+	// SendMessageToChannels sends a generic message to given channels. Channels are identified by their aliases or names used in the configuration.
+	SendMessageToChannels(context.Context, interactive.CoreMessage, []string) error
+}
+
+// NotificationChannel holds a channel to which notifications are sent.
+type NotificationChannel struct {
+	// Name is the channel name or ID, which can be passed to ChannelSender.
+	Name string
+	// Alias is the channel alias used in the configuration.
+	Alias string
+	// Sources are source bindings of the channel.
+	Sources []string
+	// Filters are names of filters evaluated for notifications sent to the channel.
+	Filters []string
+	// Settings are notification settings defined for the channel.
+	Settings config.NotificationSettings
+}
+
+// ChannelLister is implemented by bots which can send messages to specific channels, and list channels with enabled notifications.
+// It's used to send notifications to each channel according to its notification settings.
+type ChannelLister interface {
+	ChannelSender
+
+	// NotificationChannels returns channels with enabled notifications.
+	NotificationChannels() []NotificationChannel
+}
+
 // SendPlaintextMessage sends a plaintext message to specified providers.
 func SendPlaintextMessage(ctx context.Context, notifiers []Bot, msg string) error {
 	if msg == "" {
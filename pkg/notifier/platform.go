@@ -9,4 +9,5 @@ import (
 type Platform interface {
 	GetStatus() health.PlatformStatus
 	IntegrationName() config.CommPlatformIntegration
+	Type() config.IntegrationType
 }
@@ -21,3 +21,9 @@ type Sink interface {
 	// GetStatus gets sink status
 	GetStatus() health.PlatformStatus
 }
+
+// Flusher is implemented by sinks which batch events, so the batch can be written before Botkube is stopped or reloaded.
+type Flusher interface {
+	// Flush writes batched events.
+	Flush(context.Context) error
+}
@@ -0,0 +1,135 @@
+package plugin
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+const (
+	// cacheEntrySuffix is the suffix of files which describe cached plugin binaries and dependencies.
+	cacheEntrySuffix = ".cache.json"
+	// sharedDependenciesDir is the directory in the cache directory, where dependencies shared by plugins are stored.
+	sharedDependenciesDir = "dependencies"
+)
+
+// cacheEntry describes a cached file, so it can be reused after Botkube restarts.
+type cacheEntry struct {
+	// Source is the original URL of the file.
+	Source string `json:"source"`
+	// ArtifactChecksum is the checksum of the downloaded artifact defined in the plugin index. It differs from the file checksum for archives.
+	ArtifactChecksum string `json:"artifactChecksum,omitempty"`
+	// Checksum is the SHA256 checksum of the file, used to detect files which were modified or only partially written.
+	Checksum string `json:"checksum"`
+}
+
+// isCached returns true if a given file was downloaded from a given source and it wasn't modified since then.
+// Files cached by previous Botkube versions don't have the entry, so they are reused only if there is no artifact checksum to verify.
+func isCached(path, source, artifactChecksum string) bool {
+	if !DoesBinaryExist(path) {
+		return false
+	}
+
+	data, err := os.ReadFile(filepath.Clean(path + cacheEntrySuffix))
+	if errors.Is(err, os.ErrNotExist) {
+		return artifactChecksum == ""
+	}
+	if err != nil {
+		return false
+	}
+
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return false
+	}
+	if entry.Source != source || entry.ArtifactChecksum != artifactChecksum {
+		return false
+	}
+
+	checksum, err := fileSHA256(path)
+	return err == nil && checksum == entry.Checksum
+}
+
+// markCached stores the cache entry of a given downloaded file.
+func markCached(path, source, artifactChecksum string) error {
+	checksum, err := fileSHA256(path)
+	if err != nil {
+		return fmt.Errorf("while calculating checksum of %q: %w", path, err)
+	}
+
+	data, err := json.Marshal(cacheEntry{
+		Source:           source,
+		ArtifactChecksum: artifactChecksum,
+		Checksum:         checksum,
+	})
+	if err != nil {
+		return fmt.Errorf("while marshaling cache entry: %w", err)
+	}
+	if err := os.WriteFile(filepath.Clean(path+cacheEntrySuffix), data, filePerms); err != nil {
+		return fmt.Errorf("while saving cache entry: %w", err)
+	}
+	return nil
+}
+
+// linkFile links a given file to a given destination, replacing the existing file. If hard links are not supported,
+// the file is copied.
+func linkFile(src, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), dirPerms); err != nil {
+		return fmt.Errorf("while creating directory %q: %w", filepath.Dir(dst), err)
+	}
+	if err := os.Remove(dst); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("while removing %q: %w", dst, err)
+	}
+	if err := os.Link(src, dst); err == nil {
+		return nil
+	}
+
+	in, err := os.Open(filepath.Clean(src))
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(filepath.Clean(dst), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, binPerms)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return fmt.Errorf("while copying %q: %w", src, err)
+	}
+	return out.Close()
+}
+
+// isSameFile returns true if given paths point to the same file, e.g. a hard link.
+func isSameFile(a, b string) bool {
+	statA, errA := os.Stat(a)
+	statB, errB := os.Stat(b)
+	return errA == nil && errB == nil && os.SameFile(statA, statB)
+}
+
+// sharedDependencyPath returns the path of a given dependency in the directory shared by plugins.
+// Dependencies are stored by their URLs, so the same binary is downloaded only once, e.g. `kubectl` used by multiple plugins.
+func sharedDependencyPath(cacheDir, name, source string) string {
+	digest := sha256.Sum256([]byte(source))
+	return filepath.Join(cacheDir, sharedDependenciesDir, hex.EncodeToString(digest[:8]), name)
+}
+
+func fileSHA256(path string) (string, error) {
+	file, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", fmt.Errorf("while calculating checksum: %w", err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -0,0 +1,101 @@
+package plugin
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+func TestIsCached(t *testing.T) {
+	// given
+	dir := t.TempDir()
+	path := filepath.Join(dir, "executor_v1.0.0_helm")
+	require.NoError(t, os.WriteFile(path, []byte("binary"), binPerms))
+
+	// then: legacy cache without an entry is reused only without a checksum to verify
+	assert.True(t, isCached(path, "https://example.com/helm", ""))
+	assert.False(t, isCached(path, "https://example.com/helm", "abc"))
+
+	// when
+	require.NoError(t, markCached(path, "https://example.com/helm", "abc"))
+
+	// then
+	assert.True(t, isCached(path, "https://example.com/helm", "abc"))
+	assert.False(t, isCached(path, "https://example.com/helm", "def"))
+	assert.False(t, isCached(path, "https://example.com/helm-v2", "abc"))
+
+	// when: binary is modified
+	require.NoError(t, os.WriteFile(path, []byte("partially written"), binPerms))
+
+	// then
+	assert.False(t, isCached(path, "https://example.com/helm", "abc"))
+	assert.False(t, isCached(filepath.Join(dir, "not-existing"), "https://example.com/helm", ""))
+}
+
+func TestManagerDownloadCache(t *testing.T) {
+	// given
+	var downloads atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			downloads.Add(1)
+		}
+		_, _ = w.Write([]byte(r.URL.Path))
+	}))
+	t.Cleanup(srv.Close)
+
+	cacheDir := t.TempDir()
+	manager := &Manager{
+		log: loggerx.NewNoop(),
+		cfg: config.PluginManagement{CacheDir: cacheDir},
+	}
+	kubectl := map[string]string{runtime.GOOS + "/" + runtime.GOARCH: srv.URL + "/kubectl"}
+	helmPath := filepath.Join(cacheDir, "botkube", "executor_v1.0.0_helm")
+	helm := storeEntry{
+		URLs:         map[string]URL{wasmPlatformSelector: {URL: srv.URL + "/helm"}},
+		Dependencies: map[string]map[string]string{"kubectl": kubectl},
+	}
+	k8sPath := filepath.Join(cacheDir, "botkube", "source_v1.0.0_kubernetes")
+	k8s := storeEntry{
+		URLs:         map[string]URL{wasmPlatformSelector: {URL: srv.URL + "/kubernetes"}},
+		Dependencies: map[string]map[string]string{"kubectl": kubectl},
+	}
+
+	// when
+	require.NoError(t, manager.ensurePluginDownloaded(context.Background(), helmPath, helm, nil))
+	require.NoError(t, manager.ensurePluginDownloaded(context.Background(), k8sPath, k8s, nil))
+
+	// then: the shared dependency is downloaded once
+	assert.EqualValues(t, 3, downloads.Load())
+	for _, binPath := range []string{helmPath, k8sPath} {
+		data, err := os.ReadFile(filepath.Join(dependencyDirForBin(binPath), "kubectl"))
+		require.NoError(t, err)
+		assert.Equal(t, "/kubectl", string(data))
+	}
+
+	// when: restarted
+	require.NoError(t, manager.ensurePluginDownloaded(context.Background(), helmPath, helm, nil))
+
+	// then
+	assert.EqualValues(t, 3, downloads.Load())
+
+	// when: cached binary is corrupted
+	require.NoError(t, os.WriteFile(helmPath, []byte("corrupted"), binPerms))
+	require.NoError(t, manager.ensurePluginDownloaded(context.Background(), helmPath, helm, nil))
+
+	// then
+	assert.EqualValues(t, 4, downloads.Load())
+	data, err := os.ReadFile(helmPath)
+	require.NoError(t, err)
+	assert.Equal(t, "/helm", string(data))
+}
@@ -0,0 +1,63 @@
+package plugin
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// certRenewalCheckInterval is the interval of checking whether plugin server certificates need to be renewed.
+const certRenewalCheckInterval = time.Minute
+
+// renewCertificatesPeriodically renews plugin server certificates issued from the CA, until a given context is canceled.
+func (m *Manager) renewCertificatesPeriodically(ctx context.Context) {
+	ticker := time.NewTicker(certRenewalCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.renewCertificates(time.Now())
+		}
+	}
+}
+
+// renewCertificates restarts plugins whose server certificates are due for renewal, or were issued by a CA which has been rotated since.
+// The certificate is passed to the plugin process when it starts, so a new process is started and calls are switched to it,
+// in the same way as during the plugin upgrade.
+func (m *Manager) renewCertificates(now time.Time) {
+	ca, err := loadCA(m.cfg.Security.MTLS.CACertFile, m.cfg.Security.MTLS.CAKeyFile)
+	if err != nil {
+		m.log.WithError(err).Error("Failed to load plugin CA. Retrying renewal of plugin certificates later...")
+		return
+	}
+
+	m.upgradeMu.Lock()
+	defer m.upgradeMu.Unlock()
+
+	renewPluginCertificates(m, m.executorsStore, TypeExecutor, m.executorSupervisorChan, nil, ca.fingerprint, now)
+	renewPluginCertificates(m, m.sourcesStore, TypeSource, m.sourceSupervisorChan, m.rescheduleStreams, ca.fingerprint, now)
+	renewPluginCertificates(m, m.processorsStore, TypeProcessor, m.processorSupervisorChan, nil, ca.fingerprint, now)
+}
+
+func renewPluginCertificates[T any](m *Manager, s *store[T], pluginType Type, supervisorChan chan pluginMetadata, switchOver func(ctx context.Context, key string), caFingerprint string, now time.Time) {
+	for key, p := range s.EnabledPlugins.Snapshot() {
+		if !p.serverCert.renewalDue(caFingerprint, now) {
+			continue
+		}
+
+		log := m.log.WithFields(logrus.Fields{
+			"plugin":  key,
+			"version": p.Version,
+		})
+		log.Info("Restarting plugin to renew its certificate...")
+		pm := p.metadata
+		pm.failure = nil
+		if err := replacePlugin(m, s, pluginType, pm, supervisorChan, switchOver, log); err != nil {
+			log.WithError(err).Error("Failed to renew plugin certificate. Retrying later...")
+		}
+	}
+}
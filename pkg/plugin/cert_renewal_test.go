@@ -0,0 +1,127 @@
+package plugin
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/api/executor"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+func TestManagerRenewCertificates(t *testing.T) {
+	// given
+	const key = "botkube/echo@v1.0.0"
+	binPath := buildWASMModule(t, "executor")
+	bin, err := os.ReadFile(binPath)
+	require.NoError(t, err)
+	srv := newFakeRepositoryServer(t, Index{Entries: []IndexEntry{{
+		Name:    "echo",
+		Type:    TypeExecutor,
+		Version: "v1.0.0",
+		URLs: []IndexURL{
+			{URL: "/echo.wasm", Platform: IndexURLPlatform{OS: "wasip1", Arch: "wasm"}},
+		},
+	}}}, bin)
+
+	caCertFile, caKeyFile := writeTestCA(t)
+	manager := NewManager(loggerx.NewNoop(), config.Logger{}, config.PluginManagement{
+		CacheDir: t.TempDir(),
+		Repositories: map[string]config.PluginsRepository{
+			"botkube": {URL: srv.URL + "/index.yaml"},
+		},
+		Security: config.PluginSecurity{
+			MTLS: config.PluginMTLS{Mode: config.CAPluginMTLSMode, CACertFile: caCertFile, CAKeyFile: caKeyFile},
+		},
+	}, []string{key}, nil, nil, make(chan string), NewHealthStats(1))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, manager.Start(ctx))
+	defer manager.Shutdown()
+
+	ca, err := loadCA(caCertFile, caKeyFile)
+	require.NoError(t, err)
+	now := time.Now()
+
+	// WebAssembly modules don't use certificates, so the renewal is simulated
+	issued := func() enabledPlugins[executor.Executor] {
+		p, found := manager.executorsStore.EnabledPlugins.Get(key)
+		require.True(t, found)
+		p.serverCert = &pluginServerCert{renewAt: now.Add(time.Hour), caFingerprint: ca.fingerprint}
+		manager.executorsStore.EnabledPlugins.Insert(key, p)
+		return p
+	}
+	current := func() enabledPlugins[executor.Executor] {
+		p, found := manager.executorsStore.EnabledPlugins.Get(key)
+		require.True(t, found)
+		return p
+	}
+
+	t.Run("certificate valid", func(t *testing.T) {
+		// given
+		before := issued()
+
+		// when
+		manager.renewCertificates(now)
+
+		// then
+		assert.Same(t, before.inFlight, current().inFlight)
+	})
+
+	t.Run("certificate due for renewal", func(t *testing.T) {
+		// given
+		before := issued()
+
+		// when
+		manager.renewCertificates(now.Add(2 * time.Hour))
+
+		// then
+		after := current()
+		assert.NotSame(t, before.inFlight, after.inFlight)
+		assert.Equal(t, before.metadata.binPath, after.metadata.binPath)
+	})
+
+	t.Run("CA rotated", func(t *testing.T) {
+		// given
+		before := issued()
+		rotatedCertFile, rotatedKeyFile := writeTestCA(t)
+		copyFile(t, rotatedCertFile, caCertFile)
+		copyFile(t, rotatedKeyFile, caKeyFile)
+
+		// when
+		manager.renewCertificates(now)
+
+		// then
+		assert.NotSame(t, before.inFlight, current().inFlight)
+	})
+
+	cli, err := manager.GetExecutor(key)
+	require.NoError(t, err)
+	_, err = cli.Help(ctx)
+	assert.NoError(t, err)
+}
+
+func TestPluginServerCertRenewalDue(t *testing.T) {
+	now := time.Now()
+	cert := &pluginServerCert{renewAt: now.Add(time.Hour), caFingerprint: "ca-1"}
+
+	assert.False(t, cert.renewalDue("ca-1", now))
+	assert.True(t, cert.renewalDue("ca-1", now.Add(time.Hour)))
+	assert.True(t, cert.renewalDue("ca-2", now))
+
+	var noCert *pluginServerCert
+	assert.False(t, noCert.renewalDue("ca-1", now))
+}
+
+func copyFile(t *testing.T, src, dst string) {
+	t.Helper()
+	data, err := os.ReadFile(src)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(dst, data, 0o600))
+}
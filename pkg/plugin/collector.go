@@ -83,6 +83,39 @@ func (c *Collector) GetAllEnabledAndUsedPlugins(cfg *config.Config) ([]string, [
 				boundSources[name] = struct{}{}
 			}
 		}
+
+		if commGroupCfg.Kafka.Enabled {
+			for _, topic := range commGroupCfg.Kafka.Topics {
+				for _, name := range topic.Bindings.Sources {
+					boundSources[name] = struct{}{}
+				}
+			}
+		}
+
+		if commGroupCfg.NATS.Enabled {
+			for _, subject := range commGroupCfg.NATS.Subjects {
+				for _, name := range subject.Bindings.Sources {
+					boundSources[name] = struct{}{}
+				}
+			}
+			if commGroupCfg.NATS.Commands.Enabled {
+				for _, name := range commGroupCfg.NATS.Commands.Bindings.Executors {
+					boundExecutors[name] = struct{}{}
+				}
+			}
+		}
+
+		if commGroupCfg.S3Archive.Enabled {
+			for _, name := range commGroupCfg.S3Archive.Bindings.Sources {
+				boundSources[name] = struct{}{}
+			}
+		}
+
+		if commGroupCfg.Warehouse.Enabled {
+			for _, name := range commGroupCfg.Warehouse.Bindings.Sources {
+				boundSources[name] = struct{}{}
+			}
+		}
 	}
 
 	// Collect all used executors/sources by actions
@@ -150,3 +183,24 @@ func (c *Collector) GetAllEnabledAndUsedPlugins(cfg *config.Config) ([]string, [
 
 	return maps.Keys(usedExecutorPlugins), maps.Keys(usedSourcePlugins)
 }
+
+// GetAllEnabledProcessors returns the list of all enabled processor plugins. Processors don't need to be bound to any
+// communicator, as they process events emitted by all sources.
+func (c *Collector) GetAllEnabledProcessors(cfg *config.Config) []string {
+	enabledProcessorPlugins := map[string]struct{}{}
+	for groupName, groupItems := range cfg.Processors {
+		for name, processor := range groupItems.Plugins {
+			if !processor.Enabled {
+				c.log.WithFields(logrus.Fields{
+					"groupName": groupName,
+					"pluginKey": name,
+				}).Debug("Processor plugin defined but not enabled.")
+				continue
+			}
+
+			enabledProcessorPlugins[name] = struct{}{}
+		}
+	}
+
+	return maps.Keys(enabledProcessorPlugins)
+}
@@ -10,7 +10,8 @@ import (
 
 	"github.com/alexflint/go-arg"
 	"github.com/gookit/color"
-	"github.com/mattn/go-shellwords"
+
+	"github.com/kubeshop/botkube/pkg/shellx"
 )
 
 // ParseCommand processes a given command string and stores the result in a given destination.
@@ -32,7 +33,7 @@ func ParseCommand(pluginName, command string, destination any) error {
 		return fmt.Errorf("while creating parser: %w", err)
 	}
 
-	args, err := shellwords.Parse(command)
+	args, err := shellx.Split(command)
 	if err != nil {
 		return err
 	}
@@ -88,10 +89,7 @@ func ExecuteCommand(ctx context.Context, rawCmd string, mutators ...ExecuteComma
 
 	var stdout, stderr bytes.Buffer
 
-	parser := shellwords.NewParser()
-	parser.ParseEnv = false
-	parser.ParseBacktick = false
-	args, err := parser.Parse(rawCmd)
+	args, err := shellx.Split(rawCmd)
 	if err != nil {
 		return ExecuteCommandOutput{}, err
 	}
@@ -131,6 +129,9 @@ func ExecuteCommand(ctx context.Context, rawCmd string, mutators ...ExecuteComma
 	}
 
 	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			err = fmt.Errorf("command interrupted: %w", ctxErr)
+		}
 		return out, runErr(out.Stdout, out.Stderr, err)
 	}
 	if out.ExitCode != 0 {
@@ -0,0 +1,203 @@
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/xeipuuv/gojsonschema"
+	"gopkg.in/yaml.v3"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/maputil"
+	"github.com/kubeshop/botkube/pkg/multierror"
+)
+
+// ConfigSchema returns the JSON schema of a given plugin configuration.
+// The schema is taken from the index entry of the running plugin version. It returns nil if the plugin doesn't define the schema.
+func (m *Manager) ConfigSchema(ctx context.Context, pluginType Type, pluginKey string) (json.RawMessage, error) {
+	entry, err := m.runningEntry(pluginType, pluginKey)
+	if err != nil {
+		return nil, err
+	}
+	return m.resolveJSONSchema(ctx, entry.JSONSchema)
+}
+
+// resolveJSONSchema returns a given schema, fetching it if it's defined as a remote reference. It returns nil if the schema is not defined.
+func (m *Manager) resolveJSONSchema(ctx context.Context, in JSONSchema) (json.RawMessage, error) {
+	if in.Value != "" {
+		return json.RawMessage(in.Value), nil
+	}
+	if in.RefURL == "" {
+		return nil, nil
+	}
+
+	var out json.RawMessage
+	err := fetchWithMirror(m.mirror, in.RefURL, func(candidate string) error {
+		var err error
+		schema := JSONSchema{RefURL: candidate}
+		out, err = schema.Get(ctx, m.httpClient)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ValidateConfigs validates configurations of enabled plugins against JSON schemas defined by plugins.
+// Plugins without the schema are reported as an error only if the schema is required by the plugin management configuration.
+func (m *Manager) ValidateConfigs(ctx context.Context, cfg config.Config) error {
+	issues := multierror.New()
+	issues = multierror.Append(issues, validateGroups(ctx, m, TypeExecutor, "executors", cfg.Executors)...)
+	issues = multierror.Append(issues, validateGroups(ctx, m, TypeSource, "sources", cfg.Sources)...)
+	issues = multierror.Append(issues, validateGroups(ctx, m, TypeProcessor, "processors", cfg.Processors)...)
+	return issues.ErrorOrNil()
+}
+
+type pluginsGetter interface {
+	GetPlugins() config.Plugins
+}
+
+func validateGroups[T pluginsGetter](ctx context.Context, m *Manager, pluginType Type, prefix string, groups map[string]T) []error {
+	var issues []error
+	for _, groupName := range maputil.SortKeys(groups) {
+		plugins := groups[groupName].GetPlugins()
+		for _, pluginKey := range maputil.SortKeys(plugins) {
+			plugin := plugins[pluginKey]
+			if !plugin.Enabled || !m.isEnabled(pluginType, pluginKey) {
+				continue
+			}
+
+			path := fmt.Sprintf("%s.%s.%s.config", prefix, groupName, pluginKey)
+			schema, err := m.ConfigSchema(ctx, pluginType, pluginKey)
+			if err != nil {
+				issues = append(issues, fmt.Errorf("%s: while getting JSON schema: %w", path, err))
+				continue
+			}
+
+			if schema == nil {
+				if m.cfg.RequireConfigSchema {
+					issues = append(issues, fmt.Errorf("%s: %s plugin %q doesn't define the JSON schema of its configuration", path, pluginType, pluginKey))
+					continue
+				}
+				m.log.WithField("plugin", pluginKey).Warnf("The %s plugin doesn't define the JSON schema of its configuration. Skipping validation...", pluginType)
+				continue
+			}
+
+			if plugin.Config == nil {
+				continue
+			}
+			violations, err := configViolations(schema, plugin.Config)
+			if err != nil {
+				issues = append(issues, fmt.Errorf("%s: %w", path, err))
+				continue
+			}
+			for _, violation := range violations {
+				field := path
+				if violation.Field() != gojsonschema.STRING_CONTEXT_ROOT {
+					field += "." + violation.Field()
+				}
+				issues = append(issues, fmt.Errorf("%s: %s", field, violation.Description()))
+			}
+		}
+	}
+	return issues
+}
+
+// ValidateConfig validates a given plugin configuration against the JSON schema.
+// Each violation is reported in the `{field}: {description}` format.
+func ValidateConfig(schema json.RawMessage, cfg any) error {
+	violations, err := configViolations(schema, cfg)
+	if err != nil {
+		return err
+	}
+
+	issues := multierror.New()
+	for _, violation := range violations {
+		issues = multierror.Append(issues, fmt.Errorf("%s: %s", violation.Field(), violation.Description()))
+	}
+	return issues.ErrorOrNil()
+}
+
+func configViolations(schema json.RawMessage, cfg any) ([]gojsonschema.ResultError, error) {
+	raw, err := toJSON(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("while converting configuration to JSON: %w", err)
+	}
+
+	result, err := gojsonschema.Validate(gojsonschema.NewBytesLoader(schema), gojsonschema.NewBytesLoader(raw))
+	if err != nil {
+		return nil, fmt.Errorf("while validating configuration: %w", err)
+	}
+	return result.Errors(), nil
+}
+
+// toJSON marshals a given configuration to JSON. Configurations decoded from YAML may contain maps with non-string keys,
+// so they are normalized first.
+func toJSON(cfg any) ([]byte, error) {
+	raw, err := yaml.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var normalized any
+	if err := yaml.Unmarshal(raw, &normalized); err != nil {
+		return nil, err
+	}
+	return json.Marshal(normalized)
+}
+
+func (m *Manager) isEnabled(pluginType Type, pluginKey string) bool {
+	switch pluginType {
+	case TypeExecutor:
+		_, found := m.executorsStore.EnabledPlugins.Get(pluginKey)
+		return found
+	case TypeSource:
+		_, found := m.sourcesStore.EnabledPlugins.Get(pluginKey)
+		return found
+	case TypeProcessor:
+		_, found := m.processorsStore.EnabledPlugins.Get(pluginKey)
+		return found
+	}
+	return false
+}
+
+// runningEntry returns the index entry of the running plugin version.
+func (m *Manager) runningEntry(pluginType Type, pluginKey string) (storeEntry, error) {
+	repoName, pluginName, ver, err := config.DecomposePluginKey(pluginKey)
+	if err != nil {
+		return storeEntry{}, err
+	}
+
+	var (
+		repo    storeRepository
+		running string
+	)
+	switch pluginType {
+	case TypeExecutor:
+		repo = m.executorsStore.Repository
+		p, _ := m.executorsStore.EnabledPlugins.Get(pluginKey)
+		running = p.Version
+	case TypeSource:
+		repo = m.sourcesStore.Repository
+		p, _ := m.sourcesStore.EnabledPlugins.Get(pluginKey)
+		running = p.Version
+	case TypeProcessor:
+		repo = m.processorsStore.Repository
+		p, _ := m.processorsStore.EnabledPlugins.Get(pluginKey)
+		running = p.Version
+	default:
+		return storeEntry{}, fmt.Errorf("unknown plugin type %q", pluginType)
+	}
+	if running != "" {
+		ver = running
+	}
+
+	candidates, _ := repo.Get(repoName, pluginName)
+	entry, found := findEntryVersion(candidates, ver)
+	if !found {
+		return storeEntry{}, NewNotFoundPluginError("not found %s plugin called %q in %q repository", pluginType.String(), pluginName, repoName)
+	}
+	return entry, nil
+}
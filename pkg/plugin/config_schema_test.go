@@ -0,0 +1,168 @@
+package plugin
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/api/executor"
+	"github.com/kubeshop/botkube/pkg/api/processor"
+	"github.com/kubeshop/botkube/pkg/api/source"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+const testConfigSchema = `{
+  "type": "object",
+  "properties": {
+    "defaultNamespace": {"type": "string"},
+    "replicas": {"type": "integer", "minimum": 1}
+  },
+  "required": ["defaultNamespace"],
+  "additionalProperties": false
+}`
+
+func TestValidateConfig(t *testing.T) {
+	testCases := []struct {
+		Name        string
+		Config      any
+		ExpectedErr string
+	}{
+		{
+			Name: "Valid configuration",
+			Config: map[string]any{
+				"defaultNamespace": "default",
+				"replicas":         2,
+			},
+		},
+		{
+			Name: "Map with non-string keys",
+			Config: map[any]any{
+				"defaultNamespace": "default",
+			},
+		},
+		{
+			Name: "Invalid configuration",
+			Config: map[string]any{
+				"replicas": 0,
+				"unknown":  true,
+			},
+			ExpectedErr: heredoc.Doc(`
+				3 errors occurred:
+					* (root): defaultNamespace is required
+					* (root): Additional property unknown is not allowed
+					* replicas: Must be greater than or equal to 1`),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			// when
+			err := ValidateConfig([]byte(testConfigSchema), tc.Config)
+
+			// then
+			if tc.ExpectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tc.ExpectedErr)
+		})
+	}
+}
+
+func TestManager_ValidateConfigs(t *testing.T) {
+	// given
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(testConfigSchema))
+	}))
+	defer srv.Close()
+
+	executorsStore := newStore[executor.Executor]()
+	executorsStore.Repository.Insert("botkube", "helm", storeEntry{Version: "v1.1.0"})
+	executorsStore.Repository.Insert("botkube", "helm", storeEntry{Version: "v1.0.0", JSONSchema: JSONSchema{Value: testConfigSchema}})
+	executorsStore.EnabledPlugins.Insert("botkube/helm", enabledPlugins[executor.Executor]{Version: "v1.0.0"})
+
+	sourcesStore := newStore[source.Source]()
+	sourcesStore.Repository.Insert("botkube", "kubernetes", storeEntry{Version: "v1.0.0", JSONSchema: JSONSchema{RefURL: srv.URL}})
+	sourcesStore.Repository.Insert("botkube", "prometheus", storeEntry{Version: "v1.0.0"})
+	sourcesStore.EnabledPlugins.Insert("botkube/kubernetes", enabledPlugins[source.Source]{Version: "v1.0.0"})
+	sourcesStore.EnabledPlugins.Insert("botkube/prometheus", enabledPlugins[source.Source]{Version: "v1.0.0"})
+
+	cfg := config.Config{
+		Executors: map[string]config.Executors{
+			"helm": {
+				Plugins: config.Plugins{
+					"botkube/helm": {Enabled: true, Config: map[string]any{"defaultNamespace": "default"}},
+				},
+			},
+			"helm-invalid": {
+				Plugins: config.Plugins{
+					"botkube/helm": {Enabled: true, Config: map[string]any{"replicas": 2}},
+				},
+			},
+			"disabled": {
+				Plugins: config.Plugins{
+					"botkube/helm": {Enabled: false, Config: map[string]any{"replicas": "2"}},
+				},
+			},
+		},
+		Sources: map[string]config.Sources{
+			"k8s": {
+				Plugins: config.Plugins{
+					"botkube/kubernetes": {Enabled: true, Config: map[string]any{"defaultNamespace": "default", "replicas": 0}},
+				},
+			},
+			"prometheus": {
+				Plugins: config.Plugins{
+					"botkube/prometheus": {Enabled: true, Config: map[string]any{"url": "http://localhost:9090"}},
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		Name                string
+		RequireConfigSchema bool
+		ExpectedErr         string
+	}{
+		{
+			Name: "Schema not required",
+			ExpectedErr: heredoc.Doc(`
+				2 errors occurred:
+					* executors.helm-invalid.botkube/helm.config: defaultNamespace is required
+					* sources.k8s.botkube/kubernetes.config.replicas: Must be greater than or equal to 1`),
+		},
+		{
+			Name:                "Schema required",
+			RequireConfigSchema: true,
+			ExpectedErr: heredoc.Doc(`
+				3 errors occurred:
+					* executors.helm-invalid.botkube/helm.config: defaultNamespace is required
+					* sources.k8s.botkube/kubernetes.config.replicas: Must be greater than or equal to 1
+					* sources.prometheus.botkube/prometheus.config: source plugin "botkube/prometheus" doesn't define the JSON schema of its configuration`),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			manager := &Manager{
+				log:             loggerx.NewNoop(),
+				cfg:             config.PluginManagement{RequireConfigSchema: tc.RequireConfigSchema},
+				httpClient:      srv.Client(),
+				executorsStore:  &executorsStore,
+				sourcesStore:    &sourcesStore,
+				processorsStore: &store[processor.Processor]{},
+			}
+
+			// when
+			err := manager.ValidateConfigs(context.Background(), cfg)
+
+			// then
+			require.Error(t, err)
+			assert.Equal(t, tc.ExpectedErr, err.Error())
+		})
+	}
+}
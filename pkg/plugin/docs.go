@@ -0,0 +1,35 @@
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// Docs holds documentation of a running plugin, taken from the index entry of the running plugin version.
+type Docs struct {
+	Description      string
+	DocumentationURL string
+	Version          string
+	// JSONSchema is the JSON schema of the plugin configuration. It's nil if the plugin doesn't define the schema.
+	JSONSchema json.RawMessage
+}
+
+// Docs returns documentation of a given running plugin.
+func (m *Manager) Docs(ctx context.Context, pluginType Type, pluginKey string) (Docs, error) {
+	entry, err := m.runningEntry(pluginType, pluginKey)
+	if err != nil {
+		return Docs{}, err
+	}
+
+	schema, err := m.resolveJSONSchema(ctx, entry.JSONSchema)
+	if err != nil {
+		return Docs{}, err
+	}
+
+	return Docs{
+		Description:      entry.Description,
+		DocumentationURL: entry.DocumentationURL,
+		Version:          entry.Version,
+		JSONSchema:       schema,
+	}, nil
+}
@@ -0,0 +1,53 @@
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/api/executor"
+	"github.com/kubeshop/botkube/pkg/api/processor"
+	"github.com/kubeshop/botkube/pkg/api/source"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+func TestManager_Docs(t *testing.T) {
+	// given
+	executorsStore := newStore[executor.Executor]()
+	executorsStore.Repository.Insert("botkube", "helm", storeEntry{Version: "v1.1.0", Description: "Newer Helm"})
+	executorsStore.Repository.Insert("botkube", "helm", storeEntry{
+		Version:          "v1.0.0",
+		Description:      "Run Helm commands",
+		DocumentationURL: "https://docs.botkube.io/helm",
+		JSONSchema:       JSONSchema{Value: testConfigSchema},
+	})
+	executorsStore.EnabledPlugins.Insert("botkube/helm", enabledPlugins[executor.Executor]{Version: "v1.0.0"})
+
+	manager := &Manager{
+		log:             loggerx.NewNoop(),
+		executorsStore:  &executorsStore,
+		sourcesStore:    &store[source.Source]{},
+		processorsStore: &store[processor.Processor]{},
+	}
+
+	// when
+	docs, err := manager.Docs(context.Background(), TypeExecutor, "botkube/helm")
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, Docs{
+		Description:      "Run Helm commands",
+		DocumentationURL: "https://docs.botkube.io/helm",
+		Version:          "v1.0.0",
+		JSONSchema:       json.RawMessage(testConfigSchema),
+	}, docs)
+
+	// when
+	_, err = manager.Docs(context.Background(), TypeExecutor, "botkube/unknown")
+
+	// then
+	assert.True(t, IsNotFoundError(err))
+}
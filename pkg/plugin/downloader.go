@@ -16,29 +16,76 @@ var allowedExt = map[string]struct{}{
 	".sh":  {},
 }
 
-// downloadBinary downloads binary into specific destination.
-func downloadBinary(ctx context.Context, destPath string, binaryURL URL, autoDetectFilename bool) error {
+// downloadBinary downloads binary into specific destination. The repository client is optional, it's used to download binaries from private repositories.
+func downloadBinary(ctx context.Context, destPath string, binaryURL URL, autoDetectFilename bool, repoCli *repositoryClient) error {
 	dir, filename := filepath.Split(destPath)
 	err := os.MkdirAll(dir, dirPerms)
 	if err != nil {
 		return fmt.Errorf("while creating directory %q where the binary should be stored: %w", dir, err)
 	}
 
-	pwd, err := os.Getwd()
+	tmpDestPath := destPath + ".downloading"
+	if stat, err := os.Stat(tmpDestPath); err == nil && stat.IsDir() {
+		if err = os.RemoveAll(tmpDestPath); err != nil {
+			return fmt.Errorf("while deleting temporary directory %q: %w", tmpDestPath, err)
+		}
+	}
+
+	var isArchive bool
+	if IsOCIURL(binaryURL.URL) {
+		isArchive, err = pullOCIBinary(ctx, tmpDestPath, filename, binaryURL, repoCli)
+	} else {
+		isArchive, err = getBinary(ctx, tmpDestPath, filename, binaryURL, repoCli)
+	}
 	if err != nil {
-		return fmt.Errorf("while getting working directory: %w", err)
+		return err
 	}
 
-	tmpDestPath := destPath + ".downloading"
 	if stat, err := os.Stat(tmpDestPath); err == nil && stat.IsDir() {
+		if autoDetectFilename && isArchive {
+			filename, err = getFirstFileInDirectory(tmpDestPath)
+			if err != nil {
+				return fmt.Errorf("while getting binary name")
+			}
+		}
+
+		tempFileName := filepath.Join(tmpDestPath, filename)
+
+		if err = os.Rename(tempFileName, destPath); err != nil {
+			return fmt.Errorf("while renaming binary %q: %w", tempFileName, err)
+		}
 		if err = os.RemoveAll(tmpDestPath); err != nil {
 			return fmt.Errorf("while deleting temporary directory %q: %w", tmpDestPath, err)
 		}
 	}
+	if stat, err := os.Stat(destPath); err == nil && !stat.IsDir() {
+		err = os.Chmod(destPath, binPerms)
+		if err != nil {
+			return fmt.Errorf("while setting permissions for %q: %w", destPath, err)
+		}
+	}
+
+	return nil
+}
 
-	parsedURL, err := url.Parse(binaryURL.URL)
+// getBinary downloads a given URL with go-getter into a given destination. Archives are unpacked into the destination directory.
+func getBinary(ctx context.Context, dst, filename string, binaryURL URL, repoCli *repositoryClient) (bool, error) {
+	pwd, err := os.Getwd()
 	if err != nil {
-		return fmt.Errorf("while parsing URL %q: %w", binaryURL.URL, err)
+		return false, fmt.Errorf("while getting working directory: %w", err)
+	}
+
+	rawURL := binaryURL.URL
+	if repoCli != nil {
+		rawURL, err = repoCli.SignURL(rawURL)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return false, fmt.Errorf("while parsing URL %q: %w", binaryURL.URL, err)
 	}
 	// Add go-getter magic params
 	queryParams := parsedURL.Query()
@@ -47,45 +94,76 @@ func downloadBinary(ctx context.Context, destPath string, binaryURL URL, autoDet
 		queryParams.Set("checksum", binaryURL.Checksum)
 	}
 	parsedURL.RawQuery = queryParams.Encode()
-	urlWithGoGetterMagicParams := parsedURL.String()
 
 	getterCli := &getter.Client{
 		Ctx:  ctx,
-		Src:  urlWithGoGetterMagicParams,
-		Dst:  tmpDestPath,
+		Src:  parsedURL.String(),
+		Dst:  dst,
 		Pwd:  pwd,
 		Mode: getter.ClientModeAny,
 	}
+	if repoCli != nil {
+		getters, err := repoCli.Getters(rawURL)
+		if err != nil {
+			return false, err
+		}
+		getterCli.Getters = getters
+	}
 
 	err = getterCli.Get()
 	if err != nil {
-		return fmt.Errorf("while downloading binary with go-getter via url %s: %w", urlWithGoGetterMagicParams, err)
+		return false, fmt.Errorf("while downloading binary with go-getter via url %s: %w", binaryURL.URL, err)
 	}
+	return hasArchiveExtension(parsedURL.Path), nil
+}
 
-	if stat, err := os.Stat(tmpDestPath); err == nil && stat.IsDir() {
-		if autoDetectFilename && hasArchiveExtension(parsedURL.Path) {
-			filename, err = getFirstFileInDirectory(tmpDestPath)
-			if err != nil {
-				return fmt.Errorf("while getting binary name")
-			}
-		}
+// pullOCIBinary pulls a given OCI artifact into a given destination directory. The artifact is stored under the given filename,
+// unless it's an archive, which is unpacked into the destination directory.
+func pullOCIBinary(ctx context.Context, dst, filename string, binaryURL URL, repoCli *repositoryClient) (bool, error) {
+	if repoCli == nil || repoCli.oci == nil {
+		return false, fmt.Errorf("OCI registry client is required to pull %q", binaryURL.URL)
+	}
+	if err := os.MkdirAll(dst, dirPerms); err != nil {
+		return false, fmt.Errorf("while creating temporary directory %q: %w", dst, err)
+	}
 
-		tempFileName := filepath.Join(tmpDestPath, filename)
+	artifactPath := filepath.Join(dst, filename+".artifact")
+	file, err := os.OpenFile(filepath.Clean(artifactPath), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, filePerms)
+	if err != nil {
+		return false, fmt.Errorf("while creating file: %w", err)
+	}
+	title, err := repoCli.oci.Pull(ctx, binaryURL.URL, file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return false, err
+	}
 
-		if err = os.Rename(tempFileName, destPath); err != nil {
-			return fmt.Errorf("while renaming binary %q: %w", tempFileName, err)
-		}
-		if err = os.RemoveAll(tmpDestPath); err != nil {
-			return fmt.Errorf("while deleting temporary directory %q: %w", tmpDestPath, err)
+	if binaryURL.Checksum != "" {
+		if err := verifySHA256(artifactPath, binaryURL.Checksum); err != nil {
+			return false, err
 		}
 	}
-	if stat, err := os.Stat(destPath); err == nil && !stat.IsDir() {
-		err = os.Chmod(destPath, binPerms)
-		if err != nil {
-			return fmt.Errorf("while setting permissions for %q: %w", destPath, err)
-		}
+
+	ext := archiveExtension(title)
+	if ext == "" {
+		return false, os.Rename(artifactPath, filepath.Join(dst, filename))
+	}
+	if err := getter.Decompressors[ext].Decompress(dst, artifactPath, true, 0); err != nil {
+		return false, fmt.Errorf("while unpacking %q: %w", title, err)
 	}
+	return true, os.Remove(artifactPath)
+}
 
+func verifySHA256(path, expected string) error {
+	actual, err := fileSHA256(path)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(actual, expected) {
+		return fmt.Errorf("checksums did not match: expected %s, got %s", expected, actual)
+	}
 	return nil
 }
 
@@ -0,0 +1,29 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/pkg/api/source"
+)
+
+// checkEventSchema verifies whether events sent by a given source plugin can be consumed, based on the event schema version
+// reported by the plugin. It returns an error for incompatible plugins, so they are not started instead of silently dropping
+// or misinterpreting their events.
+func checkEventSchema(ctx context.Context, logger logrus.FieldLogger, pluginKey string, cli source.Source) error {
+	meta, err := cli.Metadata(ctx)
+	if err != nil {
+		return fmt.Errorf("while getting metadata: %w", err)
+	}
+
+	warning, err := source.CheckEventSchemaCompatibility(meta.EventSchemaVersion)
+	if err != nil {
+		return err
+	}
+	if warning != "" {
+		logger.WithField("plugin", pluginKey).Warnf("Source plugin %s", warning)
+	}
+	return nil
+}
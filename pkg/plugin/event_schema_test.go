@@ -0,0 +1,62 @@
+package plugin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/api/source"
+)
+
+type fakeSchemaSource struct {
+	source.Source
+	version string
+}
+
+func (f *fakeSchemaSource) Metadata(context.Context) (api.MetadataOutput, error) {
+	return api.MetadataOutput{EventSchemaVersion: f.version}, nil
+}
+
+func TestCheckEventSchema(t *testing.T) {
+	t.Run("compatible", func(t *testing.T) {
+		// given
+		logger, hook := logtest.NewNullLogger()
+
+		// when
+		err := checkEventSchema(context.Background(), logger, "botkube/kubernetes", &fakeSchemaSource{version: source.EventSchemaVersion})
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, hook.AllEntries())
+	})
+
+	t.Run("not reported", func(t *testing.T) {
+		// given
+		logger, hook := logtest.NewNullLogger()
+
+		// when
+		err := checkEventSchema(context.Background(), logger, "community/legacy", &fakeSchemaSource{})
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, hook.AllEntries(), 1)
+		assert.Equal(t, logrus.WarnLevel, hook.LastEntry().Level)
+		assert.Equal(t, "community/legacy", hook.LastEntry().Data["plugin"])
+	})
+
+	t.Run("incompatible", func(t *testing.T) {
+		// given
+		logger, _ := logtest.NewNullLogger()
+
+		// when
+		err := checkEventSchema(context.Background(), logger, "community/next", &fakeSchemaSource{version: "2.0"})
+
+		// then
+		assert.EqualError(t, err, "event schema version 2.0 is incompatible with version 1.1 supported by Botkube, as their major versions differ")
+	})
+}
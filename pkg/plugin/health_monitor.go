@@ -2,49 +2,61 @@ package plugin
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/sirupsen/logrus"
 
 	"github.com/kubeshop/botkube/pkg/api/executor"
+	"github.com/kubeshop/botkube/pkg/api/processor"
 	"github.com/kubeshop/botkube/pkg/api/source"
 	"github.com/kubeshop/botkube/pkg/config"
 )
 
+const (
+	defaultRestartInitialDelay = time.Second
+	defaultRestartMaxDelay     = time.Minute
+)
+
 // HealthMonitor restarts a failed plugin process and inform scheduler to start dispatching loop again with a new client that was generated.
 type HealthMonitor struct {
-	log                    logrus.FieldLogger
-	logConfig              config.Logger
-	sourceSupervisorChan   chan pluginMetadata
-	executorSupervisorChan chan pluginMetadata
-	schedulerChan          chan string
-	executorsStore         *store[executor.Executor]
-	sourcesStore           *store[source.Source]
-	policy                 config.PluginRestartPolicy
-	pluginHealthStats      *HealthStats
-	healthCheckInterval    time.Duration
+	log                     logrus.FieldLogger
+	logConfig               config.Logger
+	sourceSupervisorChan    chan pluginMetadata
+	executorSupervisorChan  chan pluginMetadata
+	processorSupervisorChan chan pluginMetadata
+	schedulerChan           chan string
+	executorsStore          *store[executor.Executor]
+	sourcesStore            *store[source.Source]
+	processorsStore         *store[processor.Processor]
+	policy                  config.PluginRestartPolicy
+	pluginHealthStats       *HealthStats
+	healthCheckInterval     time.Duration
 }
 
 // NewHealthMonitor returns a new HealthMonitor instance.
-func NewHealthMonitor(logger logrus.FieldLogger, logCfg config.Logger, policy config.PluginRestartPolicy, schedulerChan chan string, sourceSupervisorChan, executorSupervisorChan chan pluginMetadata, executorsStore *store[executor.Executor], sourcesStore *store[source.Source], healthCheckInterval time.Duration, stats *HealthStats) *HealthMonitor {
+func NewHealthMonitor(logger logrus.FieldLogger, logCfg config.Logger, policy config.PluginRestartPolicy, schedulerChan chan string, sourceSupervisorChan, executorSupervisorChan, processorSupervisorChan chan pluginMetadata, executorsStore *store[executor.Executor], sourcesStore *store[source.Source], processorsStore *store[processor.Processor], healthCheckInterval time.Duration, stats *HealthStats) *HealthMonitor {
 	return &HealthMonitor{
-		log:                    logger,
-		logConfig:              logCfg,
-		policy:                 policy,
-		schedulerChan:          schedulerChan,
-		sourceSupervisorChan:   sourceSupervisorChan,
-		executorSupervisorChan: executorSupervisorChan,
-		executorsStore:         executorsStore,
-		sourcesStore:           sourcesStore,
-		pluginHealthStats:      stats,
-		healthCheckInterval:    healthCheckInterval,
+		log:                     logger,
+		logConfig:               logCfg,
+		policy:                  policy,
+		schedulerChan:           schedulerChan,
+		sourceSupervisorChan:    sourceSupervisorChan,
+		executorSupervisorChan:  executorSupervisorChan,
+		processorSupervisorChan: processorSupervisorChan,
+		executorsStore:          executorsStore,
+		sourcesStore:            sourcesStore,
+		processorsStore:         processorsStore,
+		pluginHealthStats:       stats,
+		healthCheckInterval:     healthCheckInterval,
 	}
 }
 
-// Start starts monitor processes for sources and executors.
+// Start starts monitor processes for sources, executors and processors.
 func (m *HealthMonitor) Start(ctx context.Context) {
 	go m.monitorSourcePluginHealth(ctx)
 	go m.monitorExecutorPluginHealth(ctx)
+	go m.monitorProcessorPluginHealth(ctx)
 }
 
 func (m *HealthMonitor) monitorSourcePluginHealth(ctx context.Context) {
@@ -54,27 +66,15 @@ func (m *HealthMonitor) monitorSourcePluginHealth(ctx context.Context) {
 		case <-ctx.Done():
 			return
 		case plugin := <-m.sourceSupervisorChan:
-			m.log.Infof("Restarting source plugin %q, attempt %d/%d...", plugin.pluginKey, m.pluginHealthStats.GetRestartCount(plugin.pluginKey)+1, m.policy.Threshold)
-			if source, ok := m.sourcesStore.EnabledPlugins.Get(plugin.pluginKey); ok && source.Cleanup != nil {
-				m.log.Debugf("Releasing resources of source plugin %q...", plugin.pluginKey)
-				source.Cleanup()
-			}
-
-			m.sourcesStore.EnabledPlugins.Delete(plugin.pluginKey)
-
-			if ok := m.shouldRestartPlugin(plugin.pluginKey); !ok {
-				m.log.Warnf("Plugin %q has been restarted too many times. Deactivating...", plugin.pluginKey)
+			if ok := releaseCrashedPlugin(m, m.sourcesStore, TypeSource, plugin); !ok {
 				continue
 			}
-
-			p, err := createGRPCClient[source.Source](ctx, m.log, m.logConfig, plugin, TypeSource, m.sourceSupervisorChan, m.healthCheckInterval)
-			if err != nil {
-				m.log.WithError(err).Errorf("Failed to restart plugin %q.", plugin.pluginKey)
-				continue
-			}
-
-			m.sourcesStore.EnabledPlugins.Insert(plugin.pluginKey, p)
-			m.schedulerChan <- plugin.pluginKey
+			go restartPlugin(ctx, m, m.sourcesStore, TypeSource, plugin, m.sourceSupervisorChan, func() {
+				select {
+				case m.schedulerChan <- plugin.pluginKey:
+				case <-ctx.Done():
+				}
+			})
 		}
 	}
 }
@@ -86,27 +86,120 @@ func (m *HealthMonitor) monitorExecutorPluginHealth(ctx context.Context) {
 		case <-ctx.Done():
 			return
 		case plugin := <-m.executorSupervisorChan:
-			m.log.Infof("Restarting executor plugin %q, attempt %d/%d...", plugin.pluginKey, m.pluginHealthStats.GetRestartCount(plugin.pluginKey)+1, m.policy.Threshold)
-
-			if executor, ok := m.executorsStore.EnabledPlugins.Get(plugin.pluginKey); ok && executor.Cleanup != nil {
-				m.log.Infof("Releasing executors of executor plugin %q...", plugin.pluginKey)
-				executor.Cleanup()
+			if ok := releaseCrashedPlugin(m, m.executorsStore, TypeExecutor, plugin); !ok {
+				continue
 			}
+			go restartPlugin(ctx, m, m.executorsStore, TypeExecutor, plugin, m.executorSupervisorChan, nil)
+		}
+	}
+}
 
-			m.executorsStore.EnabledPlugins.Delete(plugin.pluginKey)
-			if ok := m.shouldRestartPlugin(plugin.pluginKey); !ok {
-				m.log.Warnf("Plugin %q has been restarted too many times. Deactivating...", plugin.pluginKey)
+func (m *HealthMonitor) monitorProcessorPluginHealth(ctx context.Context) {
+	m.log.Info("Starting processor plugin supervisor...")
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case plugin := <-m.processorSupervisorChan:
+			if ok := releaseCrashedPlugin(m, m.processorsStore, TypeProcessor, plugin); !ok {
 				continue
 			}
+			go restartPlugin(ctx, m, m.processorsStore, TypeProcessor, plugin, m.processorSupervisorChan, nil)
+		}
+	}
+}
 
-			p, err := createGRPCClient[executor.Executor](ctx, m.log, m.logConfig, plugin, TypeExecutor, m.executorSupervisorChan, m.healthCheckInterval)
-			if err != nil {
-				m.log.WithError(err).Errorf("Failed to restart plugin %q.", plugin.pluginKey)
-				continue
+// releaseCrashedPlugin releases resources of a crashed plugin and removes it from the store. It returns false if the crashed plugin
+// was already replaced with another version, e.g. during the upgrade, so it shouldn't be restarted.
+func releaseCrashedPlugin[T any](m *HealthMonitor, s *store[T], pluginType Type, plugin pluginMetadata) bool {
+	p, ok := s.EnabledPlugins.Get(plugin.pluginKey)
+	if ok && p.Version != plugin.version {
+		m.log.Infof("Crashed version %s of %s plugin %q was already replaced with version %s. Skipping restart...", plugin.version, pluginType, plugin.pluginKey, p.Version)
+		return false
+	}
+
+	if ok && p.Cleanup != nil {
+		m.log.Debugf("Releasing resources of %s plugin %q...", pluginType, plugin.pluginKey)
+		p.Cleanup()
+	}
+	s.EnabledPlugins.Delete(plugin.pluginKey)
+	return true
+}
+
+// restartPlugin restarts a crashed plugin with an exponential backoff, until it starts successfully or the restart threshold is reached.
+// Once the threshold is reached, the plugin is deactivated. If the cooldown period is set, the plugin is started again after it.
+func restartPlugin[T any](ctx context.Context, m *HealthMonitor, s *store[T], pluginType Type, plugin pluginMetadata, supervisorChan chan pluginMetadata, onRestarted func()) {
+	log := m.log.WithField("plugin", plugin.pluginKey)
+	m.pluginHealthStats.SetLastError(plugin.pluginKey, plugin.failure)
+	for {
+		restarts := m.pluginHealthStats.GetRestartCount(plugin.pluginKey)
+		if ok := m.shouldRestartPlugin(plugin.pluginKey); !ok {
+			if m.policy.CooldownPeriod <= 0 {
+				log.Warnf("Plugin %q has been restarted too many times. Deactivating...", plugin.pluginKey)
+				m.pluginHealthStats.MarkDeactivated(plugin.pluginKey, time.Time{})
+				return
+			}
+
+			log.Warnf("Plugin %q has been restarted too many times. Deactivating for %s...", plugin.pluginKey, m.policy.CooldownPeriod)
+			m.pluginHealthStats.MarkDeactivated(plugin.pluginKey, time.Now().Add(m.policy.CooldownPeriod))
+			if !sleep(ctx, m.policy.CooldownPeriod) {
+				return
 			}
+			// give the plugin one more chance, if it crashes again, it's deactivated immediately
+			m.pluginHealthStats.ResetRestartCount(plugin.pluginKey, m.policy.Threshold-1)
+			continue
+		}
+
+		delay := m.restartDelay(restarts)
+		log.Infof("Restarting %s plugin %q in %s, attempt %d/%d...", pluginType, plugin.pluginKey, delay, restarts+1, m.policy.Threshold)
+		m.pluginHealthStats.MarkRestarting(plugin.pluginKey, time.Now().Add(delay))
+		if !sleep(ctx, delay) {
+			return
+		}
+
+		p, err := createGRPCClient[T](ctx, m.log, m.logConfig, plugin, pluginType, supervisorChan, m.healthCheckInterval)
+		if err != nil {
+			log.WithError(err).Errorf("Failed to restart plugin %q.", plugin.pluginKey)
+			m.pluginHealthStats.SetLastError(plugin.pluginKey, fmt.Errorf("while restarting plugin: %w", err))
+			continue
+		}
 
-			m.executorsStore.EnabledPlugins.Insert(plugin.pluginKey, p)
+		s.EnabledPlugins.Insert(plugin.pluginKey, p)
+		m.pluginHealthStats.MarkRunning(plugin.pluginKey)
+		if onRestarted != nil {
+			onRestarted()
 		}
+		return
+	}
+}
+
+// restartDelay returns the delay before the next restart. It's doubled with each restart, up to the max delay.
+func (m *HealthMonitor) restartDelay(restarts int) time.Duration {
+	initial, maxDelay := m.policy.Backoff.InitialDelay, m.policy.Backoff.MaxDelay
+	if initial <= 0 {
+		initial = defaultRestartInitialDelay
+	}
+	if maxDelay <= 0 {
+		maxDelay = defaultRestartMaxDelay
+	}
+
+	delay := initial
+	for i := 0; i < restarts && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxDelay)
+}
+
+// sleep waits for a given duration. It returns false if the context is done in the meantime.
+func sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
 	}
 }
 
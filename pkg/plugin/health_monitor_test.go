@@ -0,0 +1,132 @@
+package plugin
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/api/executor"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+func TestHealthMonitorRestartDelay(t *testing.T) {
+	tests := []struct {
+		name          string
+		backoff       config.PluginRestartBackoff
+		restarts      int
+		expectedDelay time.Duration
+	}{
+		{name: "first restart", backoff: config.PluginRestartBackoff{InitialDelay: time.Second, MaxDelay: time.Minute}, restarts: 0, expectedDelay: time.Second},
+		{name: "doubled delay", backoff: config.PluginRestartBackoff{InitialDelay: time.Second, MaxDelay: time.Minute}, restarts: 3, expectedDelay: 8 * time.Second},
+		{name: "max delay", backoff: config.PluginRestartBackoff{InitialDelay: time.Second, MaxDelay: time.Minute}, restarts: 10, expectedDelay: time.Minute},
+		{name: "defaults", restarts: 1, expectedDelay: 2 * defaultRestartInitialDelay},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			monitor := &HealthMonitor{policy: config.PluginRestartPolicy{Backoff: tc.backoff}}
+
+			// when
+			delay := monitor.restartDelay(tc.restarts)
+
+			// then
+			assert.Equal(t, tc.expectedDelay, delay)
+		})
+	}
+}
+
+func TestHealthMonitorCircuitBreaker(t *testing.T) {
+	// given
+	const key = "botkube/echo"
+	stats := NewHealthStats(2)
+	stats.Register(key)
+	executorsStore := newStore[executor.Executor]()
+	monitor := &HealthMonitor{
+		log:               loggerx.NewNoop(),
+		pluginHealthStats: stats,
+		policy: config.PluginRestartPolicy{
+			Type:      config.KeepAgentRunningWhenThresholdReached,
+			Threshold: 2,
+			Backoff: config.PluginRestartBackoff{
+				InitialDelay: time.Millisecond,
+				MaxDelay:     time.Millisecond,
+			},
+			CooldownPeriod: time.Hour,
+		},
+	}
+	// the binary doesn't exist, so each restart fails
+	plugin := pluginMetadata{
+		binPath:   filepath.Join(t.TempDir(), "executor_v1.0.0_echo"),
+		pluginKey: key,
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// when
+	done := make(chan struct{})
+	go func() {
+		restartPlugin(ctx, monitor, &executorsStore, TypeExecutor, plugin, nil, nil)
+		close(done)
+	}()
+
+	// then
+	require.Eventually(t, func() bool {
+		status, _, _, _ := stats.GetStats(key)
+		return status == pluginDeactivated
+	}, 5*time.Second, 10*time.Millisecond)
+
+	health := stats.List()
+	require.Len(t, health, 1)
+	assert.Equal(t, key, health[0].Name)
+	assert.Equal(t, 3, health[0].Restarts)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), health[0].NextRestartTime, time.Minute)
+
+	_, found := executorsStore.EnabledPlugins.Get(key)
+	assert.False(t, found)
+
+	// when
+	cancel()
+
+	// then
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("plugin restart didn't stop after the context was canceled")
+	}
+}
+
+func TestHealthStats(t *testing.T) {
+	// given
+	stats := NewHealthStats(3)
+	stats.Register("botkube/kubernetes")
+	stats.Register("botkube/helm")
+
+	// when
+	stats.Increment("botkube/helm")
+	restartAt := time.Now().Add(time.Minute)
+	stats.MarkRestarting("botkube/helm", restartAt)
+
+	// then
+	health := stats.List()
+	require.Len(t, health, 2)
+	assert.Equal(t, "botkube/helm", health[0].Name)
+	assert.Equal(t, pluginRestarting, health[0].Status)
+	assert.Equal(t, 1, health[0].Restarts)
+	assert.Equal(t, 3, health[0].Threshold)
+	assert.Equal(t, restartAt, health[0].NextRestartTime)
+	assert.Equal(t, "botkube/kubernetes", health[1].Name)
+	assert.Equal(t, pluginRunning, health[1].Status)
+
+	// when
+	stats.MarkRunning("botkube/helm")
+
+	// then
+	status, restarts, threshold, _ := stats.GetStats("botkube/helm")
+	assert.Equal(t, pluginRunning, status)
+	assert.Equal(t, 1, restarts)
+	assert.Equal(t, 3, threshold)
+}
@@ -1,12 +1,14 @@
 package plugin
 
 import (
+	"sort"
 	"sync"
 	"time"
 )
 
 const (
 	pluginRunning     = "Running"
+	pluginRestarting  = "Restarting"
 	pluginDeactivated = "Deactivated"
 )
 
@@ -21,6 +23,28 @@ type pluginStats struct {
 	restartCount       int
 	restartThreshold   int
 	lastTransitionTime string
+	// status is empty for plugins registered before the status was tracked explicitly, so it's derived from the restart count.
+	status string
+	// nextRestartTime is the time of the next restart of a plugin which is restarting, or deactivated for the cooldown period.
+	nextRestartTime time.Time
+	// lastError describes the last failure of a plugin, e.g. why it stopped responding.
+	lastError string
+}
+
+// PluginHealth holds the health details of a given plugin.
+type PluginHealth struct {
+	Name               string
+	Status             string
+	Restarts           int
+	Threshold          int
+	LastTransitionTime string
+	NextRestartTime    time.Time
+	LastError          string
+}
+
+// IsRunning returns true if the plugin is running.
+func (p PluginHealth) IsRunning() bool {
+	return p.Status == pluginRunning
 }
 
 // NewHealthStats returns a new HealthStats instance.
@@ -31,18 +55,80 @@ func NewHealthStats(threshold int) *HealthStats {
 	}
 }
 
+// Register starts tracking the health of a given running plugin.
+func (h *HealthStats) Register(plugin string) {
+	h.Lock()
+	defer h.Unlock()
+	if _, ok := h.pluginStats[plugin]; ok {
+		return
+	}
+	h.pluginStats[plugin] = pluginStats{
+		restartThreshold: h.globalRestartThreshold,
+		status:           pluginRunning,
+	}
+	reportPluginStatus(plugin, pluginRunning)
+}
+
 // Increment increments restart count for a plugin.
 func (h *HealthStats) Increment(plugin string) {
 	h.Lock()
 	defer h.Unlock()
-	if _, ok := h.pluginStats[plugin]; !ok {
-		h.pluginStats[plugin] = pluginStats{}
+	stats := h.pluginStats[plugin]
+	stats.restartCount++
+	stats.restartThreshold = h.globalRestartThreshold
+	stats.lastTransitionTime = time.Now().Format(time.RFC3339)
+	h.pluginStats[plugin] = stats
+	pluginRestartsTotal.WithLabelValues(plugin).Inc()
+}
+
+// ResetRestartCount sets the restart count for a plugin, e.g. to give a deactivated plugin one more chance after the cooldown period.
+func (h *HealthStats) ResetRestartCount(plugin string, count int) {
+	h.Lock()
+	defer h.Unlock()
+	stats := h.pluginStats[plugin]
+	stats.restartCount = count
+	h.pluginStats[plugin] = stats
+}
+
+// MarkRunning marks a given plugin as running.
+func (h *HealthStats) MarkRunning(plugin string) {
+	h.setStatus(plugin, pluginRunning, time.Time{})
+}
+
+// MarkRestarting marks a given plugin as restarting at a given time.
+func (h *HealthStats) MarkRestarting(plugin string, restartAt time.Time) {
+	h.setStatus(plugin, pluginRestarting, restartAt)
+}
+
+// MarkDeactivated marks a given plugin as deactivated. If the restart time isn't zero, the plugin is restarted then.
+func (h *HealthStats) MarkDeactivated(plugin string, restartAt time.Time) {
+	h.setStatus(plugin, pluginDeactivated, restartAt)
+}
+
+// SetLastError records the last failure of a given plugin. It's kept once the plugin is running again, so it can be investigated later.
+func (h *HealthStats) SetLastError(plugin string, err error) {
+	if err == nil {
+		return
 	}
-	h.pluginStats[plugin] = pluginStats{
-		restartCount:       h.pluginStats[plugin].restartCount + 1,
-		lastTransitionTime: time.Now().Format(time.RFC3339),
-		restartThreshold:   h.globalRestartThreshold,
+	h.Lock()
+	defer h.Unlock()
+	stats := h.pluginStats[plugin]
+	stats.lastError = err.Error()
+	h.pluginStats[plugin] = stats
+}
+
+func (h *HealthStats) setStatus(plugin, status string, nextRestartTime time.Time) {
+	h.Lock()
+	defer h.Unlock()
+	stats := h.pluginStats[plugin]
+	if stats.status != status {
+		stats.lastTransitionTime = time.Now().Format(time.RFC3339)
 	}
+	stats.restartThreshold = h.globalRestartThreshold
+	stats.status = status
+	stats.nextRestartTime = nextRestartTime
+	h.pluginStats[plugin] = stats
+	reportPluginStatus(plugin, status)
 }
 
 // GetRestartCount returns restart count for a plugin.
@@ -60,16 +146,47 @@ func (h *HealthStats) GetStats(plugin string) (status string, restarts int, thre
 	h.RLock()
 	defer h.RUnlock()
 	status = pluginRunning
-	if _, ok := h.pluginStats[plugin]; !ok {
+	stats, ok := h.pluginStats[plugin]
+	if !ok {
 		threshold = h.globalRestartThreshold
 		return
 	}
 
-	if h.pluginStats[plugin].restartCount > h.pluginStats[plugin].restartThreshold {
-		status = pluginDeactivated
+	return stats.getStatus(), stats.restartCount, stats.restartThreshold, stats.lastTransitionTime
+}
+
+// List returns health details of all tracked plugins, sorted by names.
+func (h *HealthStats) List() []PluginHealth {
+	if h == nil {
+		return nil
+	}
+	h.RLock()
+	defer h.RUnlock()
+
+	out := make([]PluginHealth, 0, len(h.pluginStats))
+	for name, stats := range h.pluginStats {
+		out = append(out, PluginHealth{
+			Name:               name,
+			Status:             stats.getStatus(),
+			Restarts:           stats.restartCount,
+			Threshold:          stats.restartThreshold,
+			LastTransitionTime: stats.lastTransitionTime,
+			NextRestartTime:    stats.nextRestartTime,
+			LastError:          stats.lastError,
+		})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Name < out[j].Name
+	})
+	return out
+}
+
+func (s pluginStats) getStatus() string {
+	if s.status != "" {
+		return s.status
+	}
+	if s.restartCount > s.restartThreshold {
+		return pluginDeactivated
 	}
-	restarts = h.pluginStats[plugin].restartCount
-	threshold = h.pluginStats[plugin].restartThreshold
-	timestamp = h.pluginStats[plugin].lastTransitionTime
-	return
+	return pluginRunning
 }
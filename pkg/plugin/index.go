@@ -1,6 +1,7 @@
 package plugin
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -9,7 +10,9 @@ import (
 	"strings"
 
 	"github.com/dustin/go-humanize"
+	"gopkg.in/yaml.v3"
 
+	"github.com/kubeshop/botkube/pkg/config"
 	"github.com/kubeshop/botkube/pkg/multierror"
 	"github.com/kubeshop/botkube/pkg/stringx"
 )
@@ -22,9 +25,11 @@ const (
 	TypeSource Type = "source"
 	// TypeExecutor represents the executor plugin.
 	TypeExecutor Type = "executor"
+	// TypeProcessor represents the processor plugin, which filters and enriches events emitted by sources.
+	TypeProcessor Type = "processor"
 )
 
-var allKnownTypes = []Type{TypeSource, TypeExecutor}
+var allKnownTypes = []Type{TypeSource, TypeExecutor, TypeProcessor}
 
 // IsValid checks if type is a known type.
 func (t Type) IsValid() bool {
@@ -186,3 +191,67 @@ func (s *JSONSchema) Get(ctx context.Context, httpCli *http.Client) (json.RawMes
 	}
 	return out, nil
 }
+
+// FetchConfigSchemas downloads indexes of given repositories and returns JSON schemas of plugin configurations defined for the latest plugin versions.
+// Plugins are named in the `{repository}/{plugin}` format, in the same way as in the Botkube configuration.
+func FetchConfigSchemas(ctx context.Context, httpCli *http.Client, repositories map[string]config.PluginsRepository) (config.PluginConfigSchemas, error) {
+	out := config.PluginConfigSchemas{
+		Sources:    map[string]json.RawMessage{},
+		Executors:  map[string]json.RawMessage{},
+		Processors: map[string]json.RawMessage{},
+	}
+	for repo, repoCfg := range repositories {
+		index, err := fetchIndex(ctx, httpCli, repoCfg)
+		if err != nil {
+			return config.PluginConfigSchemas{}, fmt.Errorf("while fetching %q repository index: %w", repo, err)
+		}
+
+		latest := map[string]IndexEntry{}
+		for _, entry := range index.Entries {
+			key := string(entry.Type) + "/" + entry.Name
+			if current, found := latest[key]; found && !semvVerAGreaterThanB(entry.Version, current.Version) {
+				continue
+			}
+			latest[key] = entry
+		}
+
+		for _, entry := range latest {
+			if entry.JSONSchema.Value == "" && entry.JSONSchema.RefURL == "" {
+				continue
+			}
+			schema, err := entry.JSONSchema.Get(ctx, httpCli)
+			if err != nil {
+				return config.PluginConfigSchemas{}, fmt.Errorf("while getting JSON schema of %s plugin %q: %w", entry.Type, entry.Name, err)
+			}
+
+			name := repo + "/" + entry.Name
+			switch entry.Type {
+			case TypeSource:
+				out.Sources[name] = schema
+			case TypeExecutor:
+				out.Executors[name] = schema
+			case TypeProcessor:
+				out.Processors[name] = schema
+			}
+		}
+	}
+	return out, nil
+}
+
+func fetchIndex(ctx context.Context, httpCli *http.Client, repo config.PluginsRepository) (Index, error) {
+	repoCli, err := newRepositoryClient(httpCli, repo, config.PluginsOCI{})
+	if err != nil {
+		return Index{}, err
+	}
+
+	var buf bytes.Buffer
+	if err := repoCli.FetchIndex(ctx, repo.URL, repo.Headers, &buf); err != nil {
+		return Index{}, err
+	}
+
+	var index Index
+	if err := yaml.NewDecoder(&buf).Decode(&index); err != nil {
+		return Index{}, fmt.Errorf("while unmarshaling index: %w", err)
+	}
+	return index, nil
+}
@@ -77,7 +77,7 @@ func (i *IndexBuilder) Build(dir, urlBasePath, pluginNameFilter string, skipChec
 			return Index{}, fmt.Errorf("while getting %s plugin's metadata: %w", key, err)
 		}
 
-		urls, err := i.mapToIndexURLs(dir, bins, urlBasePath, meta.Dependencies, skipChecksum, useArchive)
+		urls, err := i.mapToIndexURLs(dir, bins, urlBasePath, meta.Version, meta.Dependencies, skipChecksum, useArchive)
 		if err != nil {
 			return Index{}, err
 		}
@@ -115,7 +115,7 @@ func (i *IndexBuilder) Build(dir, urlBasePath, pluginNameFilter string, skipChec
 	return out, nil
 }
 
-func (i *IndexBuilder) mapToIndexURLs(parentDir string, bins []pluginBinariesIndex, urlBasePath string, deps map[string]api.Dependency, skipChecksum bool, useArchive bool) ([]IndexURL, error) {
+func (i *IndexBuilder) mapToIndexURLs(parentDir string, bins []pluginBinariesIndex, urlBasePath, version string, deps map[string]api.Dependency, skipChecksum bool, useArchive bool) ([]IndexURL, error) {
 	var urls []IndexURL
 	var checksum string
 	var err error
@@ -131,7 +131,7 @@ func (i *IndexBuilder) mapToIndexURLs(parentDir string, bins []pluginBinariesInd
 			continue
 		}
 		urls = append(urls, IndexURL{
-			URL:      fmt.Sprintf("%s/%s", urlBasePath, bin.BinaryPath),
+			URL:      indexBinaryURL(urlBasePath, bin.BinaryPath, version),
 			Checksum: checksum,
 			Platform: IndexURLPlatform{
 				OS:   bin.OS,
@@ -144,6 +144,15 @@ func (i *IndexBuilder) mapToIndexURLs(parentDir string, bins []pluginBinariesInd
 	return urls, nil
 }
 
+// indexBinaryURL returns the download URL of a given binary. For OCI registries, each binary is stored in a separate repository
+// tagged with the plugin version.
+func indexBinaryURL(urlBasePath, binaryPath, version string) string {
+	if IsOCIURL(urlBasePath) {
+		return fmt.Sprintf("%s/%s:%s", strings.TrimSuffix(urlBasePath, "/"), binaryPath, version)
+	}
+	return fmt.Sprintf("%s/%s", urlBasePath, binaryPath)
+}
+
 func (i *IndexBuilder) calculateChecksum(bin string) (string, error) {
 	if info, err := os.Stat(bin); err != nil || info.IsDir() {
 		return "", fmt.Errorf("while getting file info: %w", err)
@@ -180,7 +189,8 @@ func (i *IndexBuilder) getPluginMetadata(dir string, index []pluginBinariesIndex
 	os, arch := runtime.GOOS, runtime.GOARCH
 
 	for _, item := range index {
-		if item.Arch != arch || item.OS != os {
+		isWASM := fmt.Sprintf("%s/%s", item.OS, item.Arch) == wasmPlatformSelector
+		if !isWASM && (item.Arch != arch || item.OS != os) {
 			continue
 		}
 
@@ -212,7 +222,7 @@ func (i *IndexBuilder) getPluginMetadata(dir string, index []pluginBinariesIndex
 }
 
 func (i *IndexBuilder) appendIndexEntry(entries map[string][]pluginBinariesIndex, entryName string, pNameRegex *regexp.Regexp) error {
-	if !strings.HasPrefix(entryName, TypeExecutor.String()) && !strings.HasPrefix(entryName, TypeSource.String()) {
+	if !strings.HasPrefix(entryName, TypeExecutor.String()) && !strings.HasPrefix(entryName, TypeSource.String()) && !strings.HasPrefix(entryName, TypeProcessor.String()) {
 		i.log.WithField("file", entryName).Debug("Ignoring file as not recognized as plugin")
 		return nil
 	}
@@ -307,6 +317,17 @@ func hasArchiveExtension(path string) bool {
 	return false
 }
 
+// archiveExtension returns the longest archive extension of a given path, e.g. `tar.gz`, or an empty string if the path is not an archive.
+func archiveExtension(path string) string {
+	var out string
+	for _, ext := range getAvailableDecompressors() {
+		if strings.HasSuffix(path, "."+ext) && len(ext) > len(out) {
+			out = ext
+		}
+	}
+	return out
+}
+
 func trimArchiveExtension(in string) string {
 	for _, ext := range getAvailableDecompressors() {
 		in = strings.TrimSuffix(in, "."+ext)
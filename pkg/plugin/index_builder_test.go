@@ -139,3 +139,31 @@ func TestRemoveArchiveExtension(t *testing.T) {
 		})
 	}
 }
+
+func TestIndexBinaryURL(t *testing.T) {
+	tests := []struct {
+		name        string
+		urlBasePath string
+		expURL      string
+	}{
+		{
+			name:        "HTTP",
+			urlBasePath: "https://github.com/kubeshop/botkube/releases/download/v1.0.0",
+			expURL:      "https://github.com/kubeshop/botkube/releases/download/v1.0.0/executor_echo_linux_amd64.tar.gz",
+		},
+		{
+			name:        "OCI",
+			urlBasePath: "oci://ghcr.io/kubeshop/botkube-plugins/",
+			expURL:      "oci://ghcr.io/kubeshop/botkube-plugins/executor_echo_linux_amd64.tar.gz:v1.0.0",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			gotURL := indexBinaryURL(tc.urlBasePath, "executor_echo_linux_amd64.tar.gz", "v1.0.0")
+
+			// then
+			assert.Equal(t, tc.expURL, gotURL)
+		})
+	}
+}
@@ -1,12 +1,19 @@
 package plugin
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/MakeNowJust/heredoc"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v3"
+
+	"github.com/kubeshop/botkube/pkg/config"
 )
 
 func TestValidateIndexErrors(t *testing.T) {
@@ -31,7 +38,7 @@ func TestValidateIndexErrors(t *testing.T) {
 			* entries[7]: 1 error occurred:
 				* field name cannot be empty
 			* entries[8]: 1 error occurred:
-				* field type is not valid, allowed values are [source executor]
+				* field type is not valid, allowed values are [source executor processor]
 			* entries[9]: 1 error occurred:
 				* dependency URL for key "kubectl" and platform "linux/arm64" cannot be empty`)
 
@@ -56,3 +63,54 @@ func TestValidateIndexSuccess(t *testing.T) {
 	// then
 	assert.NoError(t, err)
 }
+
+func TestFetchConfigSchemas(t *testing.T) {
+	// given
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	mux.HandleFunc("/index.yaml", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = fmt.Fprintf(w, heredoc.Doc(`
+			entries:
+			  - name: helm
+			    type: executor
+			    version: v1.1.0
+			    jsonSchema:
+			      refURL: %s/helm.json
+			  - name: helm
+			    type: executor
+			    version: v1.0.0
+			    jsonSchema:
+			      value: '{"title": "old"}'
+			  - name: kubernetes
+			    type: source
+			    version: v1.0.0
+			    jsonSchema:
+			      value: '{"title": "kubernetes"}'
+			  - name: echo
+			    type: executor
+			    version: v1.0.0
+		`), srv.URL)
+	})
+	mux.HandleFunc("/helm.json", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"title": "helm"}`))
+	})
+
+	// when
+	schemas, err := FetchConfigSchemas(context.Background(), srv.Client(), map[string]config.PluginsRepository{
+		"botkube": {URL: srv.URL + "/index.yaml"},
+	})
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, config.PluginConfigSchemas{
+		Sources: map[string]json.RawMessage{
+			"botkube/kubernetes": json.RawMessage(`{"title": "kubernetes"}`),
+		},
+		Executors: map[string]json.RawMessage{
+			"botkube/helm": json.RawMessage(`{"title": "helm"}`),
+		},
+		Processors: map[string]json.RawMessage{},
+	}, schemas)
+}
@@ -0,0 +1,182 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+const (
+	// watchdogInterval is the interval of resource usage checks of plugins which cannot be limited with cgroups.
+	watchdogInterval = 5 * time.Second
+	// watchdogCPUViolations is the number of consecutive checks with CPU usage over the limit, after which the plugin is stopped.
+	// Short spikes are tolerated, as the CPU usage cannot be throttled without cgroups.
+	watchdogCPUViolations = 3
+)
+
+// resourceLimits holds parsed resource limits and isolation settings of a plugin process.
+type resourceLimits struct {
+	// milliCPU is the CPU limit in thousandths of a core.
+	milliCPU    int64
+	memoryBytes int64
+	uid         *uint32
+	gid         *uint32
+}
+
+func parseResourceLimits(in config.PluginResourceLimits) (resourceLimits, error) {
+	out := resourceLimits{
+		uid: in.RunAsUser,
+		gid: in.RunAsGroup,
+	}
+	if out.gid == nil {
+		out.gid = in.RunAsUser
+	}
+
+	if in.CPU != "" {
+		cpu, err := resource.ParseQuantity(in.CPU)
+		if err != nil {
+			return resourceLimits{}, fmt.Errorf("while parsing CPU limit: %w", err)
+		}
+		out.milliCPU = cpu.MilliValue()
+	}
+	if in.Memory != "" {
+		memory, err := resource.ParseQuantity(in.Memory)
+		if err != nil {
+			return resourceLimits{}, fmt.Errorf("while parsing memory limit: %w", err)
+		}
+		out.memoryBytes = memory.Value()
+	}
+	return out, nil
+}
+
+func (l resourceLimits) hasUsageLimits() bool {
+	return l.milliCPU > 0 || l.memoryBytes > 0
+}
+
+// processUsage holds the resource usage of a process.
+type processUsage struct {
+	cpuTime     time.Duration
+	memoryBytes int64
+}
+
+// processIsolation enforces resource limits of a plugin process and runs it as a different user.
+// The limits are enforced by cgroups if they are available. Otherwise, a watchdog stops the plugin once it exceeds the limits,
+// and the plugin supervisor restarts it.
+type processIsolation struct {
+	log              logrus.FieldLogger
+	limits           resourceLimits
+	cgroup           *cgroup
+	watchdogInterval time.Duration
+}
+
+// isolateProcess configures a given plugin command to run with given limits. It must be called before the command is started.
+func isolateProcess(log logrus.FieldLogger, cmd *exec.Cmd, pluginKey string, in config.PluginResourceLimits) (*processIsolation, error) {
+	limits, err := parseResourceLimits(in)
+	if err != nil {
+		return nil, err
+	}
+
+	if limits.uid != nil {
+		if err := setProcessCredential(cmd, *limits.uid, *limits.gid); err != nil {
+			return nil, err
+		}
+	}
+
+	out := &processIsolation{
+		log:              log.WithField("plugin", pluginKey),
+		limits:           limits,
+		watchdogInterval: watchdogInterval,
+	}
+	if !limits.hasUsageLimits() {
+		return out, nil
+	}
+
+	cg, err := newCgroup(cgroupNameForPlugin(pluginKey), limits)
+	if err != nil {
+		out.log.WithError(err).Info("Cgroups are not available. Resource limits are enforced by the watchdog.")
+		return out, nil
+	}
+	cg.attach(cmd)
+	out.cgroup = cg
+	return out, nil
+}
+
+// Started starts enforcing limits of a started plugin process.
+func (p *processIsolation) Started(ctx context.Context, process *os.Process) {
+	if p.cgroup != nil {
+		p.cgroup.releaseFD()
+		return
+	}
+	if p.limits.hasUsageLimits() && process != nil {
+		go p.watch(ctx, process)
+	}
+}
+
+// Close releases resources used to enforce limits. It must be called once the plugin process exits.
+func (p *processIsolation) Close() {
+	if p.cgroup != nil {
+		p.cgroup.remove()
+	}
+}
+
+// watch stops the plugin process once it exceeds the memory limit, or once it exceeds the CPU limit for several consecutive checks.
+func (p *processIsolation) watch(ctx context.Context, process *os.Process) {
+	ticker := time.NewTicker(p.watchdogInterval)
+	defer ticker.Stop()
+
+	var (
+		prev       processUsage
+		prevTime   time.Time
+		violations int
+	)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		usage, err := readProcessUsage(process.Pid)
+		if err != nil {
+			// most likely, the process has already exited
+			p.log.WithError(err).Debug("Stopping resource usage watchdog...")
+			return
+		}
+		now := time.Now()
+
+		if p.limits.memoryBytes > 0 && usage.memoryBytes > p.limits.memoryBytes {
+			p.log.Warnf("Plugin uses %d bytes of memory, which exceeds the limit of %d bytes. Stopping...", usage.memoryBytes, p.limits.memoryBytes)
+			_ = process.Kill()
+			return
+		}
+
+		if p.limits.milliCPU > 0 && !prevTime.IsZero() {
+			milliCPU := int64((usage.cpuTime - prev.cpuTime).Seconds() / now.Sub(prevTime).Seconds() * 1000)
+			if milliCPU > p.limits.milliCPU {
+				violations++
+			} else {
+				violations = 0
+			}
+			if violations >= watchdogCPUViolations {
+				p.log.Warnf("Plugin uses %dm CPU, which exceeds the limit of %dm CPU. Stopping...", milliCPU, p.limits.milliCPU)
+				_ = process.Kill()
+				return
+			}
+		}
+		prev, prevTime = usage, now
+	}
+}
+
+// cgroupNameForPlugin returns a cgroup name for a given plugin key, e.g. `botkube_helm` for `botkube/helm@v1.0.0`.
+func cgroupNameForPlugin(pluginKey string) string {
+	name, _, _ := strings.Cut(pluginKey, "@")
+	return strings.ReplaceAll(name, "/", "_")
+}
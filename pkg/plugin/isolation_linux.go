@@ -0,0 +1,170 @@
+package plugin
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	cgroupRoot = "/sys/fs/cgroup"
+	// cgroupParentName is the name of the cgroup which holds cgroups of all plugins. It's created in the cgroup of Botkube.
+	cgroupParentName = "botkube-plugins"
+	cgroupCPUPeriod  = 100000
+	// clockTicksPerSecond is the USER_HZ value used by the Linux kernel to report CPU time, which is 100 on all supported architectures.
+	clockTicksPerSecond = 100
+)
+
+// cgroup is a cgroup v2 which limits resources of a plugin process.
+type cgroup struct {
+	dir string
+	fd  *os.File
+}
+
+func newCgroup(name string, limits resourceLimits) (*cgroup, error) {
+	if _, err := os.Stat(filepath.Join(cgroupRoot, "cgroup.controllers")); err != nil {
+		return nil, errors.New("cgroup v2 is not mounted")
+	}
+
+	own, err := ownCgroupPath()
+	if err != nil {
+		return nil, err
+	}
+
+	base := filepath.Join(cgroupRoot, own)
+	parent := filepath.Join(base, cgroupParentName)
+	if err := os.Mkdir(parent, 0o755); err != nil && !os.IsExist(err) {
+		return nil, fmt.Errorf("while creating parent cgroup: %w", err)
+	}
+	for _, dir := range []string{base, parent} {
+		if err := writeCgroupFile(dir, "cgroup.subtree_control", "+cpu +memory"); err != nil {
+			return nil, fmt.Errorf("while enabling cgroup controllers: %w", err)
+		}
+	}
+
+	dir := filepath.Join(parent, name)
+	if err := os.Mkdir(dir, 0o755); err != nil && !os.IsExist(err) {
+		return nil, fmt.Errorf("while creating cgroup: %w", err)
+	}
+	if limits.memoryBytes > 0 {
+		if err := writeCgroupFile(dir, "memory.max", strconv.FormatInt(limits.memoryBytes, 10)); err != nil {
+			return nil, fmt.Errorf("while setting memory limit: %w", err)
+		}
+	}
+	if limits.milliCPU > 0 {
+		quota := limits.milliCPU * cgroupCPUPeriod / 1000
+		if err := writeCgroupFile(dir, "cpu.max", fmt.Sprintf("%d %d", quota, cgroupCPUPeriod)); err != nil {
+			return nil, fmt.Errorf("while setting CPU limit: %w", err)
+		}
+	}
+
+	fd, err := os.Open(filepath.Clean(dir))
+	if err != nil {
+		return nil, fmt.Errorf("while opening cgroup: %w", err)
+	}
+	return &cgroup{dir: dir, fd: fd}, nil
+}
+
+// attach starts a given command directly in the cgroup, so the limits apply also to processes the plugin starts.
+func (c *cgroup) attach(cmd *exec.Cmd) {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.UseCgroupFD = true
+	cmd.SysProcAttr.CgroupFD = int(c.fd.Fd())
+}
+
+func (c *cgroup) releaseFD() {
+	_ = c.fd.Close()
+}
+
+func (c *cgroup) remove() {
+	_ = c.fd.Close()
+	// the cgroup cannot be removed if it's still used, e.g. by a new version of the plugin
+	_ = os.Remove(c.dir)
+}
+
+func writeCgroupFile(dir, name, value string) error {
+	// #nosec G306
+	return os.WriteFile(filepath.Join(dir, name), []byte(value), 0o644)
+}
+
+// ownCgroupPath returns the cgroup v2 path of the current process.
+func ownCgroupPath() (string, error) {
+	data, err := os.ReadFile("/proc/self/cgroup")
+	if err != nil {
+		return "", fmt.Errorf("while reading own cgroup: %w", err)
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		if path, found := strings.CutPrefix(scanner.Text(), "0::"); found {
+			return path, nil
+		}
+	}
+	return "", errors.New("cgroup v2 path not found")
+}
+
+func setProcessCredential(cmd *exec.Cmd, uid, gid uint32) error {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.Credential = &syscall.Credential{
+		Uid:         uid,
+		Gid:         gid,
+		NoSetGroups: true,
+	}
+	return nil
+}
+
+// readProcessUsage reads the CPU time and resident memory of a given process from procfs.
+func readProcessUsage(pid int) (processUsage, error) {
+	stat, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return processUsage{}, err
+	}
+	// the process name may contain spaces, so fields are read after it
+	_, rest, found := bytes.Cut(stat, []byte(") "))
+	if !found {
+		return processUsage{}, errors.New("unexpected stat format")
+	}
+	// utime and stime are the 14th and 15th fields, and the rest starts with the 3rd one
+	fields := strings.Fields(string(rest))
+	if len(fields) < 13 {
+		return processUsage{}, errors.New("unexpected stat format")
+	}
+	utime, err := strconv.ParseInt(fields[11], 10, 64)
+	if err != nil {
+		return processUsage{}, fmt.Errorf("while parsing utime: %w", err)
+	}
+	stime, err := strconv.ParseInt(fields[12], 10, 64)
+	if err != nil {
+		return processUsage{}, fmt.Errorf("while parsing stime: %w", err)
+	}
+
+	statm, err := os.ReadFile(fmt.Sprintf("/proc/%d/statm", pid))
+	if err != nil {
+		return processUsage{}, err
+	}
+	memFields := strings.Fields(string(statm))
+	if len(memFields) < 2 {
+		return processUsage{}, errors.New("unexpected statm format")
+	}
+	residentPages, err := strconv.ParseInt(memFields[1], 10, 64)
+	if err != nil {
+		return processUsage{}, fmt.Errorf("while parsing resident memory: %w", err)
+	}
+
+	return processUsage{
+		cpuTime:     time.Duration(utime+stime) * time.Second / clockTicksPerSecond,
+		memoryBytes: residentPages * int64(os.Getpagesize()),
+	}, nil
+}
@@ -0,0 +1,31 @@
+//go:build !linux
+
+package plugin
+
+import (
+	"errors"
+	"os/exec"
+)
+
+var errIsolationUnsupported = errors.New("supported only on Linux")
+
+// cgroup is not supported on this platform.
+type cgroup struct{}
+
+func newCgroup(string, resourceLimits) (*cgroup, error) {
+	return nil, errIsolationUnsupported
+}
+
+func (c *cgroup) attach(*exec.Cmd) {}
+
+func (c *cgroup) releaseFD() {}
+
+func (c *cgroup) remove() {}
+
+func setProcessCredential(*exec.Cmd, uint32, uint32) error {
+	return errors.New("running plugins as a different user is supported only on Linux")
+}
+
+func readProcessUsage(int) (processUsage, error) {
+	return processUsage{}, errIsolationUnsupported
+}
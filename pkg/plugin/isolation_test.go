@@ -0,0 +1,83 @@
+package plugin
+
+import (
+	"context"
+	"os/exec"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+func TestParseResourceLimits(t *testing.T) {
+	// given
+	uid := uint32(1000)
+
+	// when
+	limits, err := parseResourceLimits(config.PluginResourceLimits{
+		CPU:       "1500m",
+		Memory:    "256Mi",
+		RunAsUser: &uid,
+	})
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, int64(1500), limits.milliCPU)
+	assert.Equal(t, int64(256*1024*1024), limits.memoryBytes)
+	assert.Equal(t, &uid, limits.uid)
+	// the group defaults to the user
+	assert.Equal(t, &uid, limits.gid)
+	assert.True(t, limits.hasUsageLimits())
+}
+
+func TestParseResourceLimitsInvalid(t *testing.T) {
+	// when
+	_, err := parseResourceLimits(config.PluginResourceLimits{Memory: "a lot"})
+
+	// then
+	assert.EqualError(t, err, "while parsing memory limit: quantities must match the regular expression '^([+-]?[0-9.]+)([eEinumkKMGTP]*[-+]?[0-9]*)$'")
+}
+
+func TestCgroupNameForPlugin(t *testing.T) {
+	assert.Equal(t, "botkube_helm", cgroupNameForPlugin("botkube/helm@v1.0.0"))
+	assert.Equal(t, "botkube_kubectl", cgroupNameForPlugin("botkube/kubectl"))
+}
+
+func TestProcessIsolationWatchdog(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("The watchdog reads resource usage from procfs available only on Linux")
+	}
+
+	// given
+	cmd := exec.Command("sleep", "30")
+	require.NoError(t, cmd.Start())
+
+	isolation := &processIsolation{
+		log:              loggerx.NewNoop(),
+		limits:           resourceLimits{memoryBytes: 1},
+		watchdogInterval: 10 * time.Millisecond,
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// when
+	isolation.Started(ctx, cmd.Process)
+
+	// then
+	done := make(chan error)
+	go func() {
+		done <- cmd.Wait()
+	}()
+	select {
+	case err := <-done:
+		assert.EqualError(t, err, "signal: killed")
+	case <-time.After(5 * time.Second):
+		_ = cmd.Process.Kill()
+		t.Fatal("process exceeding the memory limit wasn't stopped")
+	}
+}
@@ -19,17 +19,24 @@ const (
 	kubeconfigDefaultNamespace = "default"
 )
 
+// ErrChatUserNotResolved is returned if the RBAC policy uses the chat user subject, but the chat user identity is not given.
+var ErrChatUserNotResolved = errors.New("chat user is not resolved to Kubernetes identity")
+
 // KubeConfigInput defines the input for GenerateKubeConfig.
 type KubeConfigInput struct {
 	Channel string
+	// ChatUser is the Kubernetes identity of the chat user who executed the command. It's nil if the user is not resolved.
+	ChatUser *ChatUserIdentity
+}
+
+// ChatUserIdentity is the Kubernetes user, together with its groups, a chat user is resolved to.
+type ChatUserIdentity struct {
+	User   string
+	Groups []string
 }
 
 // GenerateKubeConfig generates kubeconfig based on RBAC policy.
 func GenerateKubeConfig(restCfg *rest.Config, clusterName string, pluginCtx config.PluginContext, input KubeConfigInput) ([]byte, error) {
-	if clusterName == "" {
-		clusterName = kubeconfigDefaultValue
-	}
-
 	rbac := pluginCtx.RBAC
 	if rbac == nil {
 		return nil, nil
@@ -40,6 +47,25 @@ func GenerateKubeConfig(restCfg *rest.Config, clusterName string, pluginCtx conf
 		return nil, nil
 	}
 
+	if rbac.HasChatUserSubject() && input.ChatUser == nil {
+		return nil, ErrChatUserNotResolved
+	}
+
+	return marshalKubeConfig(restCfg, clusterName, clientcmdapi.AuthInfo{
+		Token:                 restCfg.BearerToken,
+		TokenFile:             restCfg.BearerTokenFile,
+		ClientCertificateData: restCfg.CertData,
+		ClientKeyData:         restCfg.KeyData,
+		Impersonate:           generateUserSubject(rbac.User, rbac.Group, input),
+		ImpersonateGroups:     generateGroupSubject(rbac.Group, input),
+	})
+}
+
+func marshalKubeConfig(restCfg *rest.Config, clusterName string, authInfo clientcmdapi.AuthInfo) ([]byte, error) {
+	if clusterName == "" {
+		clusterName = kubeconfigDefaultValue
+	}
+
 	apiCfg := clientcmdapi.Config{
 		Kind:       "Config",
 		APIVersion: "v1",
@@ -66,15 +92,8 @@ func GenerateKubeConfig(restCfg *rest.Config, clusterName string, pluginCtx conf
 		CurrentContext: clusterName,
 		AuthInfos: []clientcmdapi.NamedAuthInfo{
 			{
-				Name: clusterName,
-				AuthInfo: clientcmdapi.AuthInfo{
-					Token:                 restCfg.BearerToken,
-					TokenFile:             restCfg.BearerTokenFile,
-					ClientCertificateData: restCfg.CertData,
-					ClientKeyData:         restCfg.KeyData,
-					Impersonate:           generateUserSubject(rbac.User, rbac.Group, input),
-					ImpersonateGroups:     generateGroupSubject(rbac.Group, input),
-				},
+				Name:     clusterName,
+				AuthInfo: authInfo,
 			},
 		},
 	}
@@ -87,12 +106,27 @@ func GenerateKubeConfig(restCfg *rest.Config, clusterName string, pluginCtx conf
 	return yamlKubeConfig, nil
 }
 
+// ImpersonatedSubjects returns the user and groups impersonated with the kubeconfig generated for a given plugin context.
+// It returns false if the kubeconfig is not generated, or the subjects cannot be resolved from a given input.
+func ImpersonatedSubjects(pluginCtx config.PluginContext, input KubeConfigInput) (string, []string, bool) {
+	rbac := pluginCtx.RBAC
+	if rbac == nil || (rbac.User.Type == config.EmptyPolicySubjectType && rbac.Group.Type == config.EmptyPolicySubjectType) {
+		return "", nil, false
+	}
+	if rbac.HasChatUserSubject() && input.ChatUser == nil {
+		return "", nil, false
+	}
+	return generateUserSubject(rbac.User, rbac.Group, input), generateGroupSubject(rbac.Group, input), true
+}
+
 func generateUserSubject(rbac config.UserPolicySubject, group config.GroupPolicySubject, input KubeConfigInput) (user string) {
 	switch rbac.Type {
 	case config.StaticPolicySubjectType:
 		user = rbac.Prefix + rbac.Static.Value
 	case config.ChannelNamePolicySubjectType:
 		user = rbac.Prefix + input.Channel
+	case config.ChatUserPolicySubjectType:
+		user = rbac.Prefix + input.ChatUser.User
 	default:
 		if group.Type != config.EmptyPolicySubjectType {
 			user = config.RBACDefaultUser
@@ -109,6 +143,10 @@ func generateGroupSubject(rbac config.GroupPolicySubject, input KubeConfigInput)
 		}
 	case config.ChannelNamePolicySubjectType:
 		group = append(group, rbac.Prefix+input.Channel)
+	case config.ChatUserPolicySubjectType:
+		for _, value := range input.ChatUser.Groups {
+			group = append(group, rbac.Prefix+value)
+		}
 	}
 	return
 }
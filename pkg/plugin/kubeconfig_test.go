@@ -0,0 +1,54 @@
+package plugin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/rest"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+func TestGenerateKubeConfigChatUser(t *testing.T) {
+	// given
+	restCfg := &rest.Config{Host: "https://kubernetes.default", BearerToken: "token"}
+	pluginCtx := config.PluginContext{
+		RBAC: &config.PolicyRule{
+			User:  config.UserPolicySubject{Type: config.ChatUserPolicySubjectType},
+			Group: config.GroupPolicySubject{Type: config.ChatUserPolicySubjectType, Prefix: "chat:"},
+		},
+	}
+	input := KubeConfigInput{
+		Channel:  "general",
+		ChatUser: &ChatUserIdentity{User: "oidc:alice@example.com", Groups: []string{"oidc:sre"}},
+	}
+
+	// when
+	raw, err := GenerateKubeConfig(restCfg, "dev", pluginCtx, input)
+
+	// then
+	require.NoError(t, err)
+	var kubeconfig clientcmdapi.Config
+	require.NoError(t, yaml.Unmarshal(raw, &kubeconfig))
+	require.Len(t, kubeconfig.AuthInfos, 1)
+	assert.Equal(t, "oidc:alice@example.com", kubeconfig.AuthInfos[0].AuthInfo.Impersonate)
+	assert.Equal(t, []string{"chat:oidc:sre"}, kubeconfig.AuthInfos[0].AuthInfo.ImpersonateGroups)
+}
+
+func TestGenerateKubeConfigChatUserNotResolved(t *testing.T) {
+	// given
+	pluginCtx := config.PluginContext{
+		RBAC: &config.PolicyRule{
+			User: config.UserPolicySubject{Type: config.ChatUserPolicySubjectType},
+		},
+	}
+
+	// when
+	_, err := GenerateKubeConfig(&rest.Config{}, "dev", pluginCtx, KubeConfigInput{Channel: "general"})
+
+	// then
+	assert.ErrorIs(t, err, ErrChatUserNotResolved)
+}
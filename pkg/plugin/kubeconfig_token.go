@@ -0,0 +1,88 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api/v1"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+// minTokenExpiration is the minimal lifetime of tokens accepted by the TokenRequest API.
+const minTokenExpiration = 10 * time.Minute
+
+var invalidServiceAccountNameChars = regexp.MustCompile(`[^a-z0-9.-]+`)
+
+// GenerateServiceAccountKubeConfig generates kubeconfig with a short-lived token minted for the ServiceAccount resolved from the RBAC policy.
+// Unlike the kubeconfig generated by GenerateKubeConfig, it doesn't contain Botkube credentials, so a plugin can't act beyond
+// the ServiceAccount permissions, and only until the token expires. It returns nil if the policy doesn't define the ServiceAccount subject.
+func GenerateServiceAccountKubeConfig(ctx context.Context, k8sCli kubernetes.Interface, restCfg *rest.Config, clusterName string, pluginCtx config.PluginContext, input KubeConfigInput) ([]byte, error) {
+	rbac := pluginCtx.RBAC
+	if rbac == nil || !rbac.HasServiceAccountSubject() {
+		return nil, nil
+	}
+
+	if rbac.HasChatUserSubject() && input.ChatUser == nil {
+		return nil, ErrChatUserNotResolved
+	}
+
+	sa := rbac.ServiceAccount
+	if sa.Namespace == "" {
+		return nil, errors.New("namespace of the ServiceAccount subject cannot be empty")
+	}
+	name := ServiceAccountSubject(sa, input)
+	if name == "" {
+		return nil, errors.New("name of the ServiceAccount subject cannot be empty")
+	}
+
+	expiration := sa.Expiration
+	if expiration < minTokenExpiration {
+		expiration = minTokenExpiration
+	}
+	expirationSeconds := int64(expiration.Seconds())
+	req := &authenticationv1.TokenRequest{
+		Spec: authenticationv1.TokenRequestSpec{
+			Audiences:         sa.Audiences,
+			ExpirationSeconds: &expirationSeconds,
+		},
+	}
+	resp, err := k8sCli.CoreV1().ServiceAccounts(sa.Namespace).CreateToken(ctx, name, req, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("while minting token for ServiceAccount %s/%s: %w", sa.Namespace, name, err)
+	}
+
+	return marshalKubeConfig(restCfg, clusterName, clientcmdapi.AuthInfo{
+		Token: resp.Status.Token,
+	})
+}
+
+// ServiceAccountSubject returns the name of the ServiceAccount resolved from a given policy subject.
+// Characters which are not allowed in ServiceAccount names are replaced with `-`.
+func ServiceAccountSubject(sa config.ServiceAccountPolicySubject, input KubeConfigInput) string {
+	var name string
+	switch sa.Type {
+	case config.StaticPolicySubjectType:
+		name = sa.Static.Value
+	case config.ChannelNamePolicySubjectType:
+		name = input.Channel
+	case config.ChatUserPolicySubjectType:
+		if input.ChatUser != nil {
+			name = input.ChatUser.User
+		}
+	}
+	if name == "" {
+		return ""
+	}
+
+	name = invalidServiceAccountNameChars.ReplaceAllString(strings.ToLower(sa.Prefix+name), "-")
+	return strings.Trim(name, "-.")
+}
@@ -0,0 +1,110 @@
+package plugin
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	k8stesting "k8s.io/client-go/testing"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+func TestGenerateServiceAccountKubeConfig(t *testing.T) {
+	// given
+	restCfg := &rest.Config{Host: "https://kubernetes.default", BearerToken: "botkube-token", TLSClientConfig: rest.TLSClientConfig{CAData: []byte("ca")}}
+	pluginCtx := config.PluginContext{
+		RBAC: &config.PolicyRule{
+			User: config.UserPolicySubject{Type: config.StaticPolicySubjectType, Static: config.UserStaticSubject{Value: "botkube"}},
+			ServiceAccount: config.ServiceAccountPolicySubject{
+				Type:       config.ChannelNamePolicySubjectType,
+				Prefix:     "chan-",
+				Namespace:  "botkube-sandbox",
+				Expiration: time.Minute,
+				Audiences:  []string{"botkube-plugins"},
+			},
+		},
+	}
+
+	k8sCli := fake.NewSimpleClientset()
+	var (
+		gotNamespace, gotName string
+		gotSpec               authenticationv1.TokenRequestSpec
+	)
+	k8sCli.PrependReactor("create", "serviceaccounts", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		createAction := action.(k8stesting.CreateAction)
+		if createAction.GetSubresource() != "token" {
+			return false, nil, nil
+		}
+		req := createAction.GetObject().(*authenticationv1.TokenRequest)
+		gotNamespace, gotName, gotSpec = createAction.GetNamespace(), createAction.(k8stesting.CreateActionImpl).Name, req.Spec
+		req.Status.Token = "minted-token"
+		return true, req, nil
+	})
+
+	// when
+	raw, err := GenerateServiceAccountKubeConfig(context.Background(), k8sCli, restCfg, "dev", pluginCtx, KubeConfigInput{Channel: "Prod_Alerts"})
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "botkube-sandbox", gotNamespace)
+	assert.Equal(t, "chan-prod-alerts", gotName)
+	require.NotNil(t, gotSpec.ExpirationSeconds)
+	assert.Equal(t, int64(600), *gotSpec.ExpirationSeconds)
+	assert.Equal(t, []string{"botkube-plugins"}, gotSpec.Audiences)
+
+	var kubeconfig clientcmdapi.Config
+	require.NoError(t, yaml.Unmarshal(raw, &kubeconfig))
+	require.Len(t, kubeconfig.AuthInfos, 1)
+	assert.Equal(t, clientcmdapi.AuthInfo{Token: "minted-token"}, kubeconfig.AuthInfos[0].AuthInfo)
+	require.Len(t, kubeconfig.Clusters, 1)
+	assert.Equal(t, "https://kubernetes.default", kubeconfig.Clusters[0].Cluster.Server)
+	assert.Equal(t, []byte("ca"), kubeconfig.Clusters[0].Cluster.CertificateAuthorityData)
+}
+
+func TestGenerateServiceAccountKubeConfigErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		givenSA     config.ServiceAccountPolicySubject
+		givenInput  KubeConfigInput
+		expErrorMsg string
+	}{
+		{
+			name:        "Chat user not resolved",
+			givenSA:     config.ServiceAccountPolicySubject{Type: config.ChatUserPolicySubjectType, Namespace: "botkube"},
+			expErrorMsg: ErrChatUserNotResolved.Error(),
+		},
+		{
+			name:        "Missing namespace",
+			givenSA:     config.ServiceAccountPolicySubject{Type: config.StaticPolicySubjectType, Static: config.UserStaticSubject{Value: "readonly"}},
+			expErrorMsg: "namespace of the ServiceAccount subject cannot be empty",
+		},
+		{
+			name:        "Missing name",
+			givenSA:     config.ServiceAccountPolicySubject{Type: config.ChannelNamePolicySubjectType, Namespace: "botkube"},
+			expErrorMsg: "name of the ServiceAccount subject cannot be empty",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			pluginCtx := config.PluginContext{
+				RBAC: &config.PolicyRule{ServiceAccount: tc.givenSA},
+			}
+
+			// when
+			_, err := GenerateServiceAccountKubeConfig(context.Background(), fake.NewSimpleClientset(), &rest.Config{}, "dev", pluginCtx, tc.givenInput)
+
+			// then
+			assert.EqualError(t, err, tc.expErrorMsg)
+		})
+	}
+}
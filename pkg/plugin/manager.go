@@ -1,6 +1,7 @@
 package plugin
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
@@ -21,6 +22,7 @@ import (
 	"github.com/kubeshop/botkube/internal/config/remote"
 	"github.com/kubeshop/botkube/pkg/api"
 	"github.com/kubeshop/botkube/pkg/api/executor"
+	"github.com/kubeshop/botkube/pkg/api/processor"
 	"github.com/kubeshop/botkube/pkg/api/source"
 	"github.com/kubeshop/botkube/pkg/config"
 	"github.com/kubeshop/botkube/pkg/formatx"
@@ -45,8 +47,9 @@ const (
 // This map is used in order to identify a plugin called Dispense.
 // This map is globally available and must stay consistent in order for all the plugins to work.
 var pluginMap = map[string]plugin.Plugin{
-	TypeSource.String():   &source.Plugin{},
-	TypeExecutor.String(): &executor.Plugin{},
+	TypeSource.String():    &source.Plugin{},
+	TypeExecutor.String():  &executor.Plugin{},
+	TypeProcessor.String(): &processor.Plugin{},
 }
 
 // IndexRenderData returns plugin index render data.
@@ -54,7 +57,7 @@ type IndexRenderData struct {
 	Remote remote.Config `yaml:"remote"`
 }
 
-// Manager provides functionality for managing executor and source plugins.
+// Manager provides functionality for managing executor, source and processor plugins.
 type Manager struct {
 	isStarted       atomic.Bool
 	log             logrus.FieldLogger
@@ -62,10 +65,14 @@ type Manager struct {
 	cfg             config.PluginManagement
 	httpClient      *http.Client
 	indexRenderData IndexRenderData
+	// repoClients holds clients of enabled repositories, indexed by repository names.
+	repoClients map[string]*repositoryClient
+	mirror      *urlMirror
 
-	sourceSupervisorChan   chan pluginMetadata
-	executorSupervisorChan chan pluginMetadata
-	schedulerChan          chan string
+	sourceSupervisorChan    chan pluginMetadata
+	executorSupervisorChan  chan pluginMetadata
+	processorSupervisorChan chan pluginMetadata
+	schedulerChan           chan string
 
 	executorsToEnable []string
 	executorsStore    *store[executor.Executor]
@@ -73,21 +80,37 @@ type Manager struct {
 	sourcesStore    *store[source.Source]
 	sourcesToEnable []string
 
+	processorsStore    *store[processor.Processor]
+	processorsToEnable []string
+
 	healthCheckInterval time.Duration
+	healthStats         *HealthStats
 	monitor             *HealthMonitor
+
+	// ctx is the context passed to Start, used by plugins started after the Plugin Manager start.
+	ctx context.Context
+	// upgradeMu serializes plugin upgrades.
+	upgradeMu sync.Mutex
 }
 
 type pluginMetadata struct {
 	binPath   string
 	pluginKey string
+	version   string
+	resources config.PluginResourceLimits
+	security  config.PluginSecurity
+	// failure is set once the plugin stops responding, and it describes why.
+	failure error
 }
 
 // NewManager returns a new Manager instance.
-func NewManager(logger logrus.FieldLogger, logCfg config.Logger, cfg config.PluginManagement, executors, sources []string, schedulerChan chan string, stats *HealthStats) *Manager {
+func NewManager(logger logrus.FieldLogger, logCfg config.Logger, cfg config.PluginManagement, executors, sources, processors []string, schedulerChan chan string, stats *HealthStats) *Manager {
 	sourceSupervisorChan := make(chan pluginMetadata)
 	executorSupervisorChan := make(chan pluginMetadata)
+	processorSupervisorChan := make(chan pluginMetadata)
 	executorsStore := newStore[executor.Executor]()
 	sourcesStore := newStore[source.Source]()
+	processorsStore := newStore[processor.Processor]()
 
 	remoteCfg, _ := remote.GetConfig()
 	indexRenderData := IndexRenderData{
@@ -95,19 +118,24 @@ func NewManager(logger logrus.FieldLogger, logCfg config.Logger, cfg config.Plug
 	}
 
 	return &Manager{
-		cfg:                    cfg,
-		httpClient:             httpx.NewHTTPClient(),
-		indexRenderData:        indexRenderData,
-		sourceSupervisorChan:   sourceSupervisorChan,
-		executorSupervisorChan: executorSupervisorChan,
-		schedulerChan:          schedulerChan,
-		executorsToEnable:      executors,
-		executorsStore:         &executorsStore,
-		sourcesToEnable:        sources,
-		sourcesStore:           &sourcesStore,
-		log:                    logger.WithField("component", "Plugin Manager"),
-		logConfig:              logCfg, // used when we create on-demand loggers for plugins
-		healthCheckInterval:    cfg.HealthCheckInterval,
+		cfg:                     cfg,
+		httpClient:              httpx.NewHTTPClient(),
+		repoClients:             map[string]*repositoryClient{},
+		indexRenderData:         indexRenderData,
+		sourceSupervisorChan:    sourceSupervisorChan,
+		executorSupervisorChan:  executorSupervisorChan,
+		processorSupervisorChan: processorSupervisorChan,
+		schedulerChan:           schedulerChan,
+		executorsToEnable:       executors,
+		executorsStore:          &executorsStore,
+		sourcesToEnable:         sources,
+		sourcesStore:            &sourcesStore,
+		processorsToEnable:      processors,
+		processorsStore:         &processorsStore,
+		log:                     logger.WithField("component", "Plugin Manager"),
+		logConfig:               logCfg, // used when we create on-demand loggers for plugins
+		healthCheckInterval:     cfg.HealthCheckInterval,
+		healthStats:             stats,
 		monitor: NewHealthMonitor(
 			logger.WithField("component", "Plugin Health Monitor"),
 			logCfg,
@@ -115,8 +143,10 @@ func NewManager(logger logrus.FieldLogger, logCfg config.Logger, cfg config.Plug
 			schedulerChan,
 			sourceSupervisorChan,
 			executorSupervisorChan,
+			processorSupervisorChan,
 			&executorsStore,
 			&sourcesStore,
+			&processorsStore,
 			cfg.HealthCheckInterval,
 			stats,
 		),
@@ -125,14 +155,15 @@ func NewManager(logger logrus.FieldLogger, logCfg config.Logger, cfg config.Plug
 
 // Start downloads and starts all enabled plugins.
 func (m *Manager) Start(ctx context.Context) error {
-	if len(m.executorsToEnable) == 0 && len(m.sourcesToEnable) == 0 {
+	if len(m.executorsToEnable) == 0 && len(m.sourcesToEnable) == 0 && len(m.processorsToEnable) == 0 {
 		m.log.Info("No external plugins are enabled.")
 		return nil
 	}
 
 	m.log.WithFields(logrus.Fields{
-		"enabledExecutors": strings.Join(m.executorsToEnable, ","),
-		"enabledSources":   strings.Join(m.sourcesToEnable, ","),
+		"enabledExecutors":  strings.Join(m.executorsToEnable, ","),
+		"enabledSources":    strings.Join(m.sourcesToEnable, ","),
+		"enabledProcessors": strings.Join(m.processorsToEnable, ","),
 	}).Info("Starting Plugin Manager for all enabled plugins")
 
 	err := m.start(ctx, false)
@@ -147,8 +178,13 @@ func (m *Manager) Start(ctx context.Context) error {
 
 	m.monitor.Start(ctx)
 
+	m.ctx = ctx
 	m.isStarted.Store(true)
 
+	if m.cfg.Security.MTLS.Mode == config.CAPluginMTLSMode {
+		go m.renewCertificatesPeriodically(ctx)
+	}
+
 	return nil
 }
 
@@ -178,6 +214,22 @@ func (m *Manager) start(ctx context.Context, forceUpdate bool) error {
 	}
 	m.sourcesStore.EnabledPlugins = sourcesClients
 
+	processorPlugins, err := m.loadPlugins(ctx, TypeProcessor, m.processorsToEnable, m.processorsStore.Repository)
+	if err != nil {
+		return err
+	}
+	processorClients, err := createGRPCClients[processor.Processor](ctx, m.log, m.logConfig, processorPlugins, TypeProcessor, m.processorSupervisorChan, m.healthCheckInterval)
+	if err != nil {
+		return fmt.Errorf("while creating processor plugins: %w", err)
+	}
+	m.processorsStore.EnabledPlugins = processorClients
+
+	for _, plugins := range []map[string]pluginMetadata{executorPlugins, sourcesPlugins, processorPlugins} {
+		for key := range plugins {
+			m.healthStats.Register(key)
+		}
+	}
+
 	return nil
 }
 
@@ -192,7 +244,7 @@ func (m *Manager) GetExecutor(name string) (executor.Executor, error) {
 		return nil, fmt.Errorf("client for executor plugin %q not found", name)
 	}
 
-	return client.Client, nil
+	return &trackedExecutor{Executor: client.Client, inFlight: client.inFlight}, nil
 }
 
 // GetSource returns the source client for a given plugin.
@@ -206,7 +258,21 @@ func (m *Manager) GetSource(name string) (source.Source, error) {
 		return nil, fmt.Errorf("client for source plugin %q not found", name)
 	}
 
-	return client.Client, nil
+	return &trackedSource{Source: client.Client, inFlight: client.inFlight}, nil
+}
+
+// GetProcessor returns the processor client for a given plugin.
+func (m *Manager) GetProcessor(name string) (processor.Processor, error) {
+	if !m.isStarted.Load() {
+		return nil, ErrNotStartedPluginManager
+	}
+
+	client, found := m.processorsStore.EnabledPlugins.Get(name)
+	if !found || client.Client == nil {
+		return nil, fmt.Errorf("client for processor plugin %q not found", name)
+	}
+
+	return &trackedProcessor{Processor: client.Client, inFlight: client.inFlight}, nil
 }
 
 // Shutdown performs any necessary cleanup.
@@ -215,6 +281,7 @@ func (m *Manager) Shutdown() {
 	var wg sync.WaitGroup
 	releasePlugins(&wg, m.sourcesStore.EnabledPlugins)
 	releasePlugins(&wg, m.executorsStore.EnabledPlugins)
+	releasePlugins(&wg, m.processorsStore.EnabledPlugins)
 	wg.Wait()
 }
 
@@ -244,12 +311,13 @@ func (m *Manager) loadPlugins(ctx context.Context, pluginType Type, pluginsToEna
 			return nil, NewNotFoundPluginError("not found %s plugin called %q in %q repository", pluginType.String(), pluginName, repoName)
 		}
 
-		// entries are sorted by version, first is the latest one.
-		latestPluginInfo := candidates[0]
-
 		// if plugin version not defined by user, use the latest one
+		pluginInfo, found := findEntryVersion(candidates, ver)
+		if !found {
+			return nil, NewNotFoundPluginError("not found version %q of %s plugin called %q in %q repository", ver, pluginType.String(), pluginName, repoName)
+		}
 		if ver == "" {
-			ver = latestPluginInfo.Version
+			ver = pluginInfo.Version
 		}
 
 		binPath := filepath.Join(m.cfg.CacheDir, repoName, fmt.Sprintf("%s_%s_%s", pluginType, ver, pluginName))
@@ -259,7 +327,7 @@ func (m *Manager) loadPlugins(ctx context.Context, pluginType Type, pluginsToEna
 			"binPath": binPath,
 		})
 
-		err = m.ensurePluginDownloaded(ctx, binPath, latestPluginInfo)
+		err = m.ensurePluginDownloaded(ctx, binPath, pluginInfo, m.repoClients[repoName])
 		if err != nil {
 			return nil, fmt.Errorf("while fetching plugin %q binary: %w", pluginKey, err)
 		}
@@ -267,6 +335,9 @@ func (m *Manager) loadPlugins(ctx context.Context, pluginType Type, pluginsToEna
 		loadedPlugins[pluginKey] = pluginMetadata{
 			pluginKey: pluginKey,
 			binPath:   binPath,
+			version:   pluginInfo.Version,
+			resources: m.cfg.Resources.ForPlugin(pluginKey),
+			security:  m.cfg.Security,
 		}
 
 		log.Infof("%s plugin registered successfully.", formatx.ToTitle(pluginType))
@@ -300,6 +371,7 @@ func (m *Manager) collectEnabledRepositories() ([]string, error) {
 
 	requestedRepositories := collect(m.executorsToEnable, TypeExecutor)
 	requestedRepositories = append(requestedRepositories, collect(m.sourcesToEnable, TypeSource)...)
+	requestedRepositories = append(requestedRepositories, collect(m.processorsToEnable, TypeProcessor)...)
 
 	if err := issues.ErrorOrNil(); err != nil {
 		return nil, err
@@ -314,48 +386,66 @@ func (m *Manager) loadRepositoriesMetadata(ctx context.Context, forceUpdate bool
 		return err
 	}
 
+	m.mirror, err = newURLMirror(m.cfg.Mirror)
+	if err != nil {
+		return err
+	}
+
 	rawIndexes := map[string][]byte{}
 	for _, repo := range repos {
-		entry := m.cfg.Repositories[repo]
-		path := filepath.Join(m.cfg.CacheDir, filepath.Clean(fmt.Sprintf("%s.yaml", repo)))
-
-		if _, err := os.Stat(path); forceUpdate || os.IsNotExist(err) {
-			m.log.WithFields(logrus.Fields{
-				"repo":        repo,
-				"url":         entry.URL,
-				"forceUpdate": forceUpdate,
-			}).Info("Downloading repository index")
-
-			err := m.fetchIndex(ctx, path, entry)
-			if err != nil {
-				return fmt.Errorf("while fetching index for %q repository with URL %q: %w", repo, entry.URL, err)
-			}
-		}
-
-		data, err := os.ReadFile(filepath.Clean(path))
+		data, err := m.readIndex(ctx, repo, forceUpdate)
 		if err != nil {
-			return fmt.Errorf("while reading index file: %w", err)
+			return err
 		}
-
 		rawIndexes[repo] = data
 	}
 
-	executorsRepos, sourcesRepos, err := newStoreRepositories(rawIndexes)
+	executorsRepos, sourcesRepos, processorsRepos, err := newStoreRepositories(rawIndexes)
 	if err != nil {
 		return fmt.Errorf("while building repositories store: %w", err)
 	}
 	m.executorsStore.Repository = executorsRepos
 	m.sourcesStore.Repository = sourcesRepos
+	m.processorsStore.Repository = processorsRepos
 
 	return nil
 }
 
-func (m *Manager) fetchIndex(ctx context.Context, path string, repo config.PluginsRepository) error {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, repo.URL, http.NoBody)
+// readIndex returns the index of a given repository. The index is downloaded only if it's not cached yet, or if forceUpdate is true.
+func (m *Manager) readIndex(ctx context.Context, repo string, forceUpdate bool) ([]byte, error) {
+	entry := m.cfg.Repositories[repo]
+	path := filepath.Join(m.cfg.CacheDir, filepath.Clean(fmt.Sprintf("%s.yaml", repo)))
+
+	repoCli, err := newRepositoryClient(m.httpClient, entry, m.cfg.OCI)
 	if err != nil {
-		return fmt.Errorf("while creating request: %w", err)
+		return nil, fmt.Errorf("while creating client for %q repository: %w", repo, err)
+	}
+	if m.cfg.Mirror.AirGapped {
+		repoCli.oci = repoCli.oci.withMirrorsOnly()
 	}
+	m.repoClients[repo] = repoCli
 
+	if _, err := os.Stat(path); forceUpdate || os.IsNotExist(err) {
+		m.log.WithFields(logrus.Fields{
+			"repo":        repo,
+			"url":         entry.URL,
+			"forceUpdate": forceUpdate,
+		}).Info("Downloading repository index")
+
+		err := m.fetchIndex(ctx, path, entry, repoCli)
+		if err != nil {
+			return nil, fmt.Errorf("while fetching index for %q repository with URL %q: %w", repo, entry.URL, err)
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("while reading index file: %w", err)
+	}
+	return data, nil
+}
+
+func (m *Manager) fetchIndex(ctx context.Context, path string, repo config.PluginsRepository, repoCli *repositoryClient) error {
 	headers, err := m.renderPluginIndexHeaders(repo.Headers)
 	if err != nil {
 		return fmt.Errorf("while rendering plugin index header: %w", err)
@@ -364,39 +454,67 @@ func (m *Manager) fetchIndex(ctx context.Context, path string, repo config.Plugi
 	var strBuilder strings.Builder
 	for key, value := range headers {
 		strBuilder.WriteString(fmt.Sprintf("%s=%s\n", key, stringutil.ShortenString(value, printHeaderValueCharCount)))
-		req.Header.Set(key, value)
 	}
 
 	m.log.WithFields(logrus.Fields{
 		"headers": strBuilder.String(),
 		"url":     repo.URL,
-	}).Debug("Fetching index via GET request...")
+	}).Debug("Fetching index...")
 
-	res, err := m.httpClient.Do(req)
+	var buf bytes.Buffer
+	err = fetchWithMirror(m.mirror, repo.URL, func(indexURL string) error {
+		buf.Reset()
+		return repoCli.FetchIndex(ctx, indexURL, headers, &buf)
+	})
 	if err != nil {
-		return fmt.Errorf("while executing request: %w", err)
-	}
-	defer res.Body.Close()
-
-	if res.StatusCode != http.StatusOK {
-		return fmt.Errorf("incorrect status code: %d", res.StatusCode)
+		return err
 	}
 
 	err = os.MkdirAll(filepath.Dir(path), dirPerms)
 	if err != nil {
 		return fmt.Errorf("while creating directory where repository index should be stored: %w", err)
 	}
-	file, err := os.OpenFile(filepath.Clean(path), os.O_CREATE|os.O_WRONLY, filePerms)
+	err = os.WriteFile(filepath.Clean(path), buf.Bytes(), filePerms)
 	if err != nil {
-		return fmt.Errorf("while creating file: %w", err)
+		return fmt.Errorf("while saving index body: %w", err)
 	}
-	defer file.Close()
+	return nil
+}
 
-	_, err = io.Copy(file, res.Body)
+// CheckRepositories downloads indexes of all configured repositories, without caching them, and returns errors indexed by repository names.
+// A repository is reachable if there is no error for it.
+func (m *Manager) CheckRepositories(ctx context.Context) map[string]error {
+	out := map[string]error{}
+	mirror, err := newURLMirror(m.cfg.Mirror)
 	if err != nil {
-		return fmt.Errorf("while saving index body: %w", err)
+		for repo := range m.cfg.Repositories {
+			out[repo] = err
+		}
+		return out
 	}
-	return nil
+
+	for repo, entry := range m.cfg.Repositories {
+		out[repo] = m.checkRepository(ctx, entry, mirror)
+	}
+	return out
+}
+
+func (m *Manager) checkRepository(ctx context.Context, repo config.PluginsRepository, mirror *urlMirror) error {
+	repoCli, err := newRepositoryClient(m.httpClient, repo, m.cfg.OCI)
+	if err != nil {
+		return fmt.Errorf("while creating repository client: %w", err)
+	}
+	if m.cfg.Mirror.AirGapped {
+		repoCli.oci = repoCli.oci.withMirrorsOnly()
+	}
+	headers, err := m.renderPluginIndexHeaders(repo.Headers)
+	if err != nil {
+		return fmt.Errorf("while rendering plugin index header: %w", err)
+	}
+
+	return fetchWithMirror(mirror, repo.URL, func(indexURL string) error {
+		return repoCli.FetchIndex(ctx, indexURL, headers, io.Discard)
+	})
 }
 
 func (m *Manager) renderPluginIndexHeaders(headers map[string]string) (map[string]string, error) {
@@ -417,57 +535,108 @@ func (m *Manager) renderPluginIndexHeaders(headers map[string]string) (map[strin
 }
 
 func createGRPCClients[C any](ctx context.Context, logger logrus.FieldLogger, logConfig config.Logger, pluginMeta map[string]pluginMetadata, pluginType Type, supervisorChan chan pluginMetadata, healthCheckInterval time.Duration) (*storePlugins[C], error) {
-	out := map[string]enabledPlugins[C]{}
+	out := &storePlugins[C]{data: map[string]enabledPlugins[C]{}}
 	for key, pm := range pluginMeta {
-		p, err := createGRPCClient[C](ctx, logger, logConfig, pm, pluginType, supervisorChan, healthCheckInterval)
+		p, err := createPluginClient[C](ctx, logger, logConfig, pm, pluginType, supervisorChan, healthCheckInterval)
 		if err != nil {
-			return nil, fmt.Errorf("while creating GRPC client for %s plugin %q: %w", pluginType.String(), key, err)
+			return nil, err
 		}
-		out[key] = p
+		out.Insert(key, p)
 	}
 
-	return &storePlugins[C]{data: out}, nil
+	return out, nil
+}
+
+// createPluginClient returns a client for a given plugin, which runs either as a process or a WebAssembly module.
+func createPluginClient[C any](ctx context.Context, logger logrus.FieldLogger, logConfig config.Logger, pm pluginMetadata, pluginType Type, supervisorChan chan pluginMetadata, healthCheckInterval time.Duration) (enabledPlugins[C], error) {
+	createClient, kind := createGRPCClient[C], "GRPC"
+	if IsWASMModule(pm.binPath) {
+		createClient, kind = createWASMClient[C], "WebAssembly"
+	}
+	p, err := createClient(ctx, logger, logConfig, pm, pluginType, supervisorChan, healthCheckInterval)
+	if err != nil {
+		return enabledPlugins[C]{}, fmt.Errorf("while creating %s client for %s plugin %q: %w", kind, pluginType.String(), pm.pluginKey, err)
+	}
+	return p, nil
 }
 
 func createGRPCClient[C any](ctx context.Context, logger logrus.FieldLogger, logConfig config.Logger, pm pluginMetadata, pluginType Type, supervisorChan chan pluginMetadata, healthCheckInterval time.Duration) (enabledPlugins[C], error) {
 	pluginLogger, stdoutLogger, stderrLogger := NewPluginLoggers(logger, logConfig, pm.pluginKey, pluginType)
 
+	//nolint:gosec // warns us about 'Subprocess launching with variable', but we are the one that created that variable.
+	cmd := newPluginOSRunCommand(pm.binPath)
+	creds, err := newPluginCredentials(pm.security)
+	if err != nil {
+		return enabledPlugins[C]{}, fmt.Errorf("while preparing plugin credentials: %w", err)
+	}
+	cmd.Env = append(cmd.Env, creds.env...)
+
+	isolation, err := isolateProcess(logger, cmd, pm.pluginKey, pm.resources)
+	if err != nil {
+		return enabledPlugins[C]{}, fmt.Errorf("while configuring resource limits: %w", err)
+	}
+
 	cli := plugin.NewClient(&plugin.ClientConfig{
-		Plugins: pluginMap,
-		//nolint:gosec // warns us about 'Subprocess launching with variable', but we are the one that created that variable.
-		Cmd:              newPluginOSRunCommand(pm.binPath),
+		Plugins:          pluginMap,
+		Cmd:              cmd,
 		AllowedProtocols: []plugin.Protocol{plugin.ProtocolGRPC},
 		HandshakeConfig: plugin.HandshakeConfig{
 			ProtocolVersion:  executor.ProtocolVersion,
 			MagicCookieKey:   api.HandshakeConfig.MagicCookieKey,
 			MagicCookieValue: api.HandshakeConfig.MagicCookieValue,
 		},
-		Logger:     pluginLogger,
-		SyncStdout: stdoutLogger,
-		SyncStderr: stderrLogger,
+		Logger:          pluginLogger,
+		SyncStdout:      stdoutLogger,
+		SyncStderr:      stderrLogger,
+		AutoMTLS:        creds.autoMTLS,
+		TLSConfig:       creds.tlsConfig,
+		GRPCDialOptions: append(api.GRPCDialOptions(), creds.dialOptions...),
 	})
 
+	kill := func() {
+		cli.Kill()
+		isolation.Close()
+	}
+
 	rpcClient, err := cli.Client()
 	if err != nil {
+		kill()
 		return enabledPlugins[C]{}, err
 	}
 
 	raw, err := rpcClient.Dispense(pluginType.String())
 	if err != nil {
+		kill()
 		return enabledPlugins[C]{}, err
 	}
 
 	concreteCli, ok := raw.(C)
 	if !ok {
-		cli.Kill()
+		kill()
 		return enabledPlugins[C]{}, fmt.Errorf("registered client doesn't implement required %s interface", pluginType.String())
 	}
 
-	startPluginHealthWatcher(ctx, logger, rpcClient, pm, supervisorChan, healthCheckInterval)
+	if src, ok := raw.(source.Source); ok && pluginType == TypeSource {
+		if err := checkEventSchema(ctx, logger, pm.pluginKey, src); err != nil {
+			kill()
+			return enabledPlugins[C]{}, fmt.Errorf("while checking event schema: %w", err)
+		}
+	}
+
+	// the watcher is stopped before the plugin is killed, so an intentionally stopped plugin, e.g. an upgraded one, isn't restarted
+	watchCtx, stopWatcher := context.WithCancel(ctx)
+	isolation.Started(watchCtx, cmd.Process)
+	startPluginHealthWatcher(watchCtx, logger, rpcClient, pm, supervisorChan, healthCheckInterval)
 
 	return enabledPlugins[C]{
-		Client:  concreteCli,
-		Cleanup: cli.Kill,
+		Client: concreteCli,
+		Cleanup: func() {
+			stopWatcher()
+			kill()
+		},
+		Version:    pm.version,
+		metadata:   pm,
+		serverCert: creds.serverCert,
 	}, nil
 }
 
@@ -479,12 +648,19 @@ func startPluginHealthWatcher(ctx context.Context, logger logrus.FieldLogger, rp
 	}
 	ticker := time.NewTicker(interval)
 	go func() {
+		defer ticker.Stop()
 		for {
 			select {
 			case <-ticker.C:
 				if err := rpcClient.Ping(); err != nil {
+					if ctx.Err() != nil {
+						logger.Infof("Exiting plugin %q supervisor...", pm.pluginKey)
+						return
+					}
+					pluginHealthCheckFailuresTotal.WithLabelValues(pm.pluginKey).Inc()
 					logger.WithError(err).Errorf("Plugin %q is not responding.", pm.pluginKey)
 					logger.WithField("name", pm.pluginKey).Debugf("Informing supervisor to restart plugin...")
+					pm.failure = fmt.Errorf("plugin is not responding: %w", err)
 					supervisorChan <- pm
 					return
 				}
@@ -520,58 +696,76 @@ func newPluginOSRunCommand(path string) *exec.Cmd {
 	return cmd
 }
 
-func (m *Manager) ensurePluginDownloaded(ctx context.Context, binPath string, info storeEntry) error {
+// ensurePluginDownloaded downloads a given plugin binary and its dependencies, unless they are already cached.
+// Dependencies are stored in a directory shared by all plugins and linked to the plugin dependency directory.
+func (m *Manager) ensurePluginDownloaded(ctx context.Context, binPath string, info storeEntry, repoCli *repositoryClient) error {
 	selector := fmt.Sprintf("%s/%s", runtime.GOOS, runtime.GOARCH)
 
 	log := m.log.WithFields(logrus.Fields{
 		"binPath": binPath,
 	})
 
-	// Ensure plugin downloaded
-	if !DoesBinaryExist(binPath) {
-		err := os.MkdirAll(filepath.Dir(binPath), dirPerms)
-		if err != nil {
-			return fmt.Errorf("while creating directory where plugin should be stored: %w", err)
-		}
-
-		url, found := info.URLs[selector]
-		if !found {
-			return NewNotFoundPluginError("cannot find download url for %s", selector)
-		}
+	url, found := info.URLs[selector]
+	if !found {
+		// WebAssembly modules run on all platforms
+		url, found = info.URLs[wasmPlatformSelector]
+	}
+	if !found {
+		return NewNotFoundPluginError("cannot find download url for %s", selector)
+	}
 
+	// Ensure plugin downloaded
+	if !isCached(binPath, url.URL, url.Checksum) {
 		log.WithFields(logrus.Fields{
 			"url": url,
 		}).Info("Downloading plugin...")
 
-		err = downloadBinary(ctx, binPath, url, true)
+		err := fetchWithMirror(m.mirror, url.URL, func(candidate string) error {
+			return downloadBinary(ctx, binPath, URL{URL: candidate, Checksum: url.Checksum}, true, repoCli)
+		})
 		if err != nil {
 			return fmt.Errorf("while downloading dependency from URL %q (checksum: %q): %w", url.URL, url.Checksum, err)
 		}
+		if err := markCached(binPath, url.URL, url.Checksum); err != nil {
+			return err
+		}
 	}
 
 	// Ensure all dependencies are downloaded
 	log.Info("Ensuring plugin dependencies are downloaded...")
 	depDir := dependencyDirForBin(binPath)
 	for depName, dep := range info.Dependencies {
+		depURL, found := dep[selector]
+		if !found {
+			return NewNotFoundPluginError("cannot find download url for current platform for a dependency %q of the plugin %q", depName, binPath)
+		}
+
 		depPath := filepath.Join(depDir, depName)
-		if DoesBinaryExist(depPath) {
+		sharedPath := sharedDependencyPath(m.cfg.CacheDir, depName, depURL)
+		if isSameFile(depPath, sharedPath) && isCached(sharedPath, depURL, "") {
 			m.log.Debugf("Binary %q found locally. Skipping...", depName)
 			continue
 		}
 
-		depURL, found := dep[selector]
-		if !found {
-			return NewNotFoundPluginError("cannot find download url for current platform for a dependency %q of the plugin %q", depName, binPath)
-		}
+		if !isCached(sharedPath, depURL, "") {
+			log.WithFields(logrus.Fields{
+				"dependencyName": depName,
+				"dependencyUrl":  depURL,
+			}).Info("Downloading dependency...")
 
-		log.WithFields(logrus.Fields{
-			"dependencyName": depName,
-			"dependencyUrl":  depURL,
-		}).Info("Downloading dependency...")
+			err := fetchWithMirror(m.mirror, depURL, func(candidate string) error {
+				return downloadBinary(ctx, sharedPath, URL{URL: candidate}, false, repoCli)
+			})
+			if err != nil {
+				return fmt.Errorf("while downloading dependency %q for %q: %w", depName, binPath, err)
+			}
+			if err := markCached(sharedPath, depURL, ""); err != nil {
+				return err
+			}
+		}
 
-		err := downloadBinary(ctx, depPath, URL{URL: depURL}, false)
-		if err != nil {
-			return fmt.Errorf("while downloading dependency %q for %q: %w", depName, binPath, err)
+		if err := linkFile(sharedPath, depPath); err != nil {
+			return fmt.Errorf("while linking dependency %q for %q: %w", depName, binPath, err)
 		}
 	}
 
@@ -73,7 +73,7 @@ func TestCollectEnabledRepositories(t *testing.T) {
 			// given
 			manager := NewManager(loggerx.NewNoop(), config.Logger{}, config.PluginManagement{
 				Repositories: tc.definedRepositories,
-			}, tc.enabledExecutors, tc.enabledSources, make(chan string), NewHealthStats(1))
+			}, tc.enabledExecutors, tc.enabledSources, nil, make(chan string), NewHealthStats(1))
 
 			// when
 			out, err := manager.collectEnabledRepositories()
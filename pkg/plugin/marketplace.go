@@ -0,0 +1,97 @@
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/kubeshop/botkube/pkg/maputil"
+)
+
+// AvailablePlugin holds details of a plugin available in a configured plugin repository.
+type AvailablePlugin struct {
+	// Key is the plugin key without the version, e.g. `botkube/helm`.
+	Key              string
+	Type             Type
+	Description      string
+	DocumentationURL string
+	// Versions are available versions, sorted from the latest one.
+	Versions    []string
+	JSONSchema  JSONSchema
+	Recommended bool
+}
+
+// LatestVersion returns the latest available version.
+func (p AvailablePlugin) LatestVersion() string {
+	if len(p.Versions) == 0 {
+		return ""
+	}
+	return p.Versions[0]
+}
+
+// AvailablePlugins returns plugins available in all configured plugin repositories, sorted by keys and types.
+// In contrast to enabled plugins, indexes of repositories which are not referred by any plugin are read as well.
+// Indexes are downloaded only if they are not cached yet.
+func (m *Manager) AvailablePlugins(ctx context.Context) ([]AvailablePlugin, error) {
+	if !m.isStarted.Load() {
+		return nil, ErrNotStartedPluginManager
+	}
+
+	m.upgradeMu.Lock()
+	defer m.upgradeMu.Unlock()
+
+	rawIndexes := map[string][]byte{}
+	for _, repo := range maputil.SortKeys(m.cfg.Repositories) {
+		data, err := m.readIndex(ctx, repo, false)
+		if err != nil {
+			return nil, err
+		}
+		rawIndexes[repo] = data
+	}
+
+	executorsRepos, sourcesRepos, processorsRepos, err := newStoreRepositories(rawIndexes)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []AvailablePlugin
+	out = appendAvailablePlugins(out, TypeExecutor, executorsRepos)
+	out = appendAvailablePlugins(out, TypeSource, sourcesRepos)
+	out = appendAvailablePlugins(out, TypeProcessor, processorsRepos)
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Key == out[j].Key {
+			return out[i].Type < out[j].Type
+		}
+		return out[i].Key < out[j].Key
+	})
+	return out, nil
+}
+
+// AvailablePluginConfigSchema returns the JSON schema of a given plugin configuration. It returns nil if the plugin doesn't define the schema.
+func (m *Manager) AvailablePluginConfigSchema(ctx context.Context, p AvailablePlugin) (json.RawMessage, error) {
+	return m.resolveJSONSchema(ctx, p.JSONSchema)
+}
+
+func appendAvailablePlugins(out []AvailablePlugin, pluginType Type, repo storeRepository) []AvailablePlugin {
+	for key, entries := range repo {
+		if len(entries) == 0 {
+			continue
+		}
+
+		latest := entries[0]
+		item := AvailablePlugin{
+			Key:              key,
+			Type:             pluginType,
+			Description:      latest.Description,
+			DocumentationURL: latest.DocumentationURL,
+			JSONSchema:       latest.JSONSchema,
+			Recommended:      latest.Recommended,
+		}
+		for _, entry := range entries {
+			item.Versions = append(item.Versions, entry.Version)
+		}
+		out = append(out, item)
+	}
+	return out
+}
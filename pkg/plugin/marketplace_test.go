@@ -0,0 +1,69 @@
+package plugin
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+func TestManager_AvailablePlugins(t *testing.T) {
+	// given
+	srv := httptest.NewServer(http.FileServer(http.Dir("testdata/TestNewStoreRepository")))
+	defer srv.Close()
+
+	manager := &Manager{
+		log:        loggerx.NewNoop(),
+		httpClient: srv.Client(),
+		cfg: config.PluginManagement{
+			CacheDir: t.TempDir(),
+			Repositories: map[string]config.PluginsRepository{
+				"botkube":  {URL: srv.URL + "/botkube.yaml"},
+				"mszostok": {URL: srv.URL + "/mszostok.yaml"},
+			},
+		},
+		repoClients: map[string]*repositoryClient{},
+	}
+	manager.isStarted.Store(true)
+
+	// when
+	plugins, err := manager.AvailablePlugins(context.Background())
+
+	// then
+	require.NoError(t, err)
+
+	var keys []string
+	for _, p := range plugins {
+		keys = append(keys, string(p.Type)+" "+p.Key)
+	}
+	assert.Equal(t, []string{
+		"executor botkube/helm",
+		"executor botkube/kubectl",
+		"source botkube/kubernetes",
+		"source mszostok/cm-watcher",
+		"executor mszostok/echo",
+		"processor mszostok/owner-enricher",
+	}, keys)
+
+	kubectl := plugins[1]
+	assert.Equal(t, []string{"v1.5.0", "v1.0.0"}, kubectl.Versions)
+	assert.Equal(t, "v1.5.0", kubectl.LatestVersion())
+	assert.Equal(t, "Kubectl executor plugin.", kubectl.Description)
+}
+
+func TestManager_AvailablePluginsNotStarted(t *testing.T) {
+	// given
+	manager := &Manager{}
+
+	// when
+	_, err := manager.AvailablePlugins(context.Background())
+
+	// then
+	assert.ErrorIs(t, err, ErrNotStartedPluginManager)
+}
@@ -0,0 +1,46 @@
+package plugin
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	metricsNamespace = "botkube"
+	metricsSubsystem = "plugin"
+)
+
+var (
+	pluginStatusGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "status",
+		Help:      "Status of the plugin. The value is 1 for the current status, and 0 for other ones.",
+	}, []string{"plugin", "status"})
+
+	pluginRestartsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "restarts_total",
+		Help:      "Total number of plugin restarts.",
+	}, []string{"plugin"})
+
+	pluginHealthCheckFailuresTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "health_check_failures_total",
+		Help:      "Total number of failed plugin health checks.",
+	}, []string{"plugin"})
+)
+
+var allPluginStatuses = []string{pluginRunning, pluginRestarting, pluginDeactivated}
+
+func reportPluginStatus(plugin, status string) {
+	for _, s := range allPluginStatuses {
+		val := 0.0
+		if s == status {
+			val = 1
+		}
+		pluginStatusGauge.WithLabelValues(plugin, s).Set(val)
+	}
+}
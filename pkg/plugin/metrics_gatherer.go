@@ -0,0 +1,125 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+// pluginMetricsGatherTimeout is the maximum time to gather metrics from a single plugin.
+const pluginMetricsGatherTimeout = 5 * time.Second
+
+var invalidMetricNameChars = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+// metricsExporter is implemented by plugin clients which support exporting Prometheus metrics.
+type metricsExporter interface {
+	Metrics(ctx context.Context) ([]*dto.MetricFamily, error)
+}
+
+type metricsTarget struct {
+	key      string
+	prefix   string
+	exporter metricsExporter
+}
+
+// Gather returns metrics exported by running plugins. Metric names are prefixed with the plugin namespace,
+// e.g. `botkube_executor_botkube_helm_`, so plugin metrics don't collide with each other and with Botkube metrics.
+//
+// Plugins which fail to return metrics are skipped, so a single plugin cannot break the whole metrics endpoint.
+// Gather implements the prometheus.Gatherer interface.
+func (m *Manager) Gather() ([]*dto.MetricFamily, error) {
+	var targets []metricsTarget
+	targets = appendMetricsTargets(targets, TypeExecutor, m.executorsStore)
+	targets = appendMetricsTargets(targets, TypeSource, m.sourcesStore)
+	targets = appendMetricsTargets(targets, TypeProcessor, m.processorsStore)
+
+	var (
+		wg  sync.WaitGroup
+		mu  sync.Mutex
+		out []*dto.MetricFamily
+	)
+	for _, target := range targets {
+		wg.Add(1)
+		go func(target metricsTarget) {
+			defer wg.Done()
+
+			families, err := m.gatherPluginMetrics(target)
+			if err != nil {
+				m.log.WithFields(logrus.Fields{
+					"plugin": target.key,
+					"error":  err.Error(),
+				}).Debug("Cannot gather plugin metrics")
+				return
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			out = append(out, families...)
+		}(target)
+	}
+	wg.Wait()
+
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].GetName() < out[j].GetName()
+	})
+	return out, nil
+}
+
+func (m *Manager) gatherPluginMetrics(target metricsTarget) ([]*dto.MetricFamily, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), pluginMetricsGatherTimeout)
+	defer cancel()
+
+	families, err := target.exporter.Metrics(ctx)
+	if status.Code(err) == codes.Unimplemented {
+		// plugin built with an older Botkube API version
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, family := range families {
+		family.Name = proto.String(target.prefix + family.GetName())
+	}
+	return families, nil
+}
+
+func appendMetricsTargets[T any](out []metricsTarget, pluginType Type, s *store[T]) []metricsTarget {
+	s.EnabledPlugins.RLock()
+	defer s.EnabledPlugins.RUnlock()
+
+	for key, p := range s.EnabledPlugins.data {
+		exporter, ok := any(p.Client).(metricsExporter)
+		if !ok {
+			continue
+		}
+		out = append(out, metricsTarget{
+			key:      key,
+			prefix:   metricsPrefix(pluginType, key),
+			exporter: exporter,
+		})
+	}
+	return out
+}
+
+// metricsPrefix returns the metric name prefix for a given plugin, e.g. `botkube_executor_botkube_helm_` for the `botkube/helm@v1.0.0` executor.
+func metricsPrefix(pluginType Type, pluginKey string) string {
+	name := pluginKey
+	if repo, pluginName, _, err := config.DecomposePluginKey(pluginKey); err == nil {
+		name = repo + "_" + pluginName
+	}
+	name = invalidMetricNameChars.ReplaceAllString(strings.ToLower(name), "_")
+	return fmt.Sprintf("%s_%s_%s_", metricsNamespace, pluginType, name)
+}
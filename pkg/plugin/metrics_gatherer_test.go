@@ -0,0 +1,118 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/kubeshop/botkube/pkg/api/executor"
+	"github.com/kubeshop/botkube/pkg/api/processor"
+	"github.com/kubeshop/botkube/pkg/api/source"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+type fakeMetricsExecutor struct {
+	executor.Executor
+	families []*dto.MetricFamily
+	err      error
+}
+
+func (f *fakeMetricsExecutor) Metrics(context.Context) ([]*dto.MetricFamily, error) {
+	return f.families, f.err
+}
+
+type fakeMetricsSource struct {
+	source.Source
+	families []*dto.MetricFamily
+}
+
+func (f *fakeMetricsSource) Metrics(context.Context) ([]*dto.MetricFamily, error) {
+	return f.families, nil
+}
+
+func TestManagerGather(t *testing.T) {
+	// given
+	executorsStore := newStore[executor.Executor]()
+	executorsStore.EnabledPlugins.Insert("botkube/helm@v1.0.0", enabledPlugins[executor.Executor]{
+		Client: &fakeMetricsExecutor{families: []*dto.MetricFamily{testGaugeFamily("releases", 3)}},
+	})
+	executorsStore.EnabledPlugins.Insert("botkube/legacy", enabledPlugins[executor.Executor]{
+		Client: &fakeMetricsExecutor{err: status.Error(codes.Unimplemented, "method Metrics not implemented")},
+	})
+	executorsStore.EnabledPlugins.Insert("botkube/broken", enabledPlugins[executor.Executor]{
+		Client: &fakeMetricsExecutor{err: errors.New("connection refused")},
+	})
+
+	sourcesStore := newStore[source.Source]()
+	sourcesStore.EnabledPlugins.Insert("my-repo/cert-manager", enabledPlugins[source.Source]{
+		Client: &fakeMetricsSource{families: []*dto.MetricFamily{testGaugeFamily("go_goroutines", 12)}},
+	})
+
+	processorsStore := newStore[processor.Processor]()
+	manager := &Manager{
+		log:             loggerx.NewNoop(),
+		executorsStore:  &executorsStore,
+		sourcesStore:    &sourcesStore,
+		processorsStore: &processorsStore,
+	}
+
+	// when
+	families, err := manager.Gather()
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, families, 2)
+	assert.Equal(t, "botkube_executor_botkube_helm_releases", families[0].GetName())
+	assert.Equal(t, 3.0, families[0].GetMetric()[0].GetGauge().GetValue())
+	assert.Equal(t, "botkube_source_my_repo_cert_manager_go_goroutines", families[1].GetName())
+	assert.Equal(t, 12.0, families[1].GetMetric()[0].GetGauge().GetValue())
+}
+
+func TestMetricsPrefix(t *testing.T) {
+	tests := []struct {
+		Name       string
+		PluginType Type
+		PluginKey  string
+		Expected   string
+	}{
+		{
+			Name:       "Plugin with version",
+			PluginType: TypeExecutor,
+			PluginKey:  "botkube/helm@v1.0.0",
+			Expected:   "botkube_executor_botkube_helm_",
+		},
+		{
+			Name:       "Plugin with invalid characters",
+			PluginType: TypeSource,
+			PluginKey:  "My-Repo/cert.manager",
+			Expected:   "botkube_source_my_repo_cert_manager_",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			// when
+			out := metricsPrefix(tc.PluginType, tc.PluginKey)
+
+			// then
+			assert.Equal(t, tc.Expected, out)
+		})
+	}
+}
+
+func testGaugeFamily(name string, value float64) *dto.MetricFamily {
+	return &dto.MetricFamily{
+		Name: proto.String(name),
+		Help: proto.String("Test metric."),
+		Type: dto.MetricType_GAUGE.Enum(),
+		Metric: []*dto.Metric{
+			{Gauge: &dto.Gauge{Value: proto.Float64(value)}},
+		},
+	}
+}
@@ -0,0 +1,79 @@
+package plugin
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/multierror"
+)
+
+// urlMirror resolves URLs of plugin indexes, binaries and their dependencies against an internal mirror.
+type urlMirror struct {
+	base      *url.URL
+	airGapped bool
+}
+
+func newURLMirror(cfg config.PluginsMirror) (*urlMirror, error) {
+	out := &urlMirror{airGapped: cfg.AirGapped}
+	if cfg.URL == "" {
+		if cfg.AirGapped {
+			return nil, errors.New("mirror URL is required in the air-gapped mode")
+		}
+		return out, nil
+	}
+
+	base, err := url.Parse(strings.TrimSuffix(cfg.URL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("while parsing mirror URL %q: %w", cfg.URL, err)
+	}
+	out.base = base
+	return out, nil
+}
+
+// Candidates returns URLs from which a given URL should be fetched, in order. The mirrored URL goes first,
+// and the original one is returned only if Botkube doesn't run in the air-gapped mode.
+//
+// Only HTTP(S) URLs are mirrored. OCI URLs are resolved by the OCI client against mirror registries,
+// and other URLs, such as local paths, are returned as they are.
+func (m *urlMirror) Candidates(rawURL string) []string {
+	if m == nil || m.base == nil {
+		return []string{rawURL}
+	}
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || strings.EqualFold(parsed.Host, m.base.Host) {
+		return []string{rawURL}
+	}
+
+	mirrored := *m.base
+	mirrored.Path = path.Join(m.base.Path, parsed.Host, parsed.Path)
+	mirrored.RawPath = ""
+	mirrored.RawQuery = parsed.RawQuery
+
+	if m.airGapped {
+		return []string{mirrored.String()}
+	}
+	return []string{mirrored.String(), rawURL}
+}
+
+// fetchWithMirror calls a given fetch function with URLs returned by the mirror, until it succeeds.
+func fetchWithMirror(mirror *urlMirror, rawURL string, fetch func(candidate string) error) error {
+	candidates := mirror.Candidates(rawURL)
+	if len(candidates) == 1 {
+		return fetch(candidates[0])
+	}
+
+	errs := multierror.New()
+	for _, candidate := range candidates {
+		err := fetch(candidate)
+		if err == nil {
+			return nil
+		}
+		errs = multierror.Append(errs, fmt.Errorf("%s: %w", candidate, err))
+	}
+	return errs.ErrorOrNil()
+}
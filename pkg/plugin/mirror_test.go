@@ -0,0 +1,144 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+func TestURLMirrorCandidates(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      config.PluginsMirror
+		in       string
+		expected []string
+	}{
+		{
+			name:     "no mirror",
+			in:       "https://example.com/index.yaml",
+			expected: []string{"https://example.com/index.yaml"},
+		},
+		{
+			name: "mirror with fallback",
+			cfg:  config.PluginsMirror{URL: "https://mirror.internal/botkube/"},
+			in:   "https://example.com/v1.0.0/helm.tar.gz?checksum=abc",
+			expected: []string{
+				"https://mirror.internal/botkube/example.com/v1.0.0/helm.tar.gz?checksum=abc",
+				"https://example.com/v1.0.0/helm.tar.gz?checksum=abc",
+			},
+		},
+		{
+			name:     "air-gapped",
+			cfg:      config.PluginsMirror{URL: "http://mirror.internal", AirGapped: true},
+			in:       "https://example.com/index.yaml",
+			expected: []string{"http://mirror.internal/example.com/index.yaml"},
+		},
+		{
+			name:     "already mirrored",
+			cfg:      config.PluginsMirror{URL: "https://mirror.internal", AirGapped: true},
+			in:       "https://mirror.internal/example.com/index.yaml",
+			expected: []string{"https://mirror.internal/example.com/index.yaml"},
+		},
+		{
+			name:     "OCI URL",
+			cfg:      config.PluginsMirror{URL: "https://mirror.internal", AirGapped: true},
+			in:       "oci://ghcr.io/kubeshop/botkube-plugins/index:v1.0.0",
+			expected: []string{"oci://ghcr.io/kubeshop/botkube-plugins/index:v1.0.0"},
+		},
+		{
+			name:     "local path",
+			cfg:      config.PluginsMirror{URL: "https://mirror.internal", AirGapped: true},
+			in:       "/plugins/index.yaml",
+			expected: []string{"/plugins/index.yaml"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			mirror, err := newURLMirror(tc.cfg)
+			require.NoError(t, err)
+
+			// when
+			out := mirror.Candidates(tc.in)
+
+			// then
+			assert.Equal(t, tc.expected, out)
+		})
+	}
+}
+
+func TestURLMirrorAirGappedWithoutURL(t *testing.T) {
+	// when
+	_, err := newURLMirror(config.PluginsMirror{AirGapped: true})
+
+	// then
+	assert.EqualError(t, err, "mirror URL is required in the air-gapped mode")
+}
+
+func TestFetchWithMirror(t *testing.T) {
+	// given
+	mirror, err := newURLMirror(config.PluginsMirror{URL: "https://mirror.internal"})
+	require.NoError(t, err)
+
+	var fetched []string
+	fetch := func(candidate string) error {
+		fetched = append(fetched, candidate)
+		if candidate == "https://mirror.internal/example.com/index.yaml" {
+			return errors.New("not found")
+		}
+		return nil
+	}
+
+	// when
+	err = fetchWithMirror(mirror, "https://example.com/index.yaml", fetch)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://mirror.internal/example.com/index.yaml", "https://example.com/index.yaml"}, fetched)
+}
+
+func TestManagerAirGappedDownload(t *testing.T) {
+	// given
+	var requested []string
+	mirrorSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			requested = append(requested, r.URL.Path)
+		}
+		_, _ = w.Write([]byte("binary"))
+	}))
+	t.Cleanup(mirrorSrv.Close)
+
+	cacheDir := t.TempDir()
+	manager := &Manager{
+		log: loggerx.NewNoop(),
+		cfg: config.PluginManagement{CacheDir: cacheDir},
+	}
+	manager.mirror, _ = newURLMirror(config.PluginsMirror{URL: mirrorSrv.URL + "/mirror", AirGapped: true})
+
+	binPath := filepath.Join(cacheDir, "botkube", "executor_v1.0.0_echo")
+	entry := storeEntry{
+		URLs: map[string]URL{
+			wasmPlatformSelector: {URL: "https://plugins.example.com/executor_echo.wasm"},
+		},
+	}
+
+	// when
+	err := manager.ensurePluginDownloaded(context.Background(), binPath, entry, nil)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/mirror/plugins.example.com/executor_echo.wasm"}, requested)
+	data, err := os.ReadFile(binPath)
+	require.NoError(t, err)
+	assert.Equal(t, "binary", string(data))
+}
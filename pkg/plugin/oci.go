@@ -0,0 +1,328 @@
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/opencontainers/go-digest"
+	specs "github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"golang.org/x/exp/slices"
+	dockerauth "oras.land/oras-go/pkg/auth/docker"
+	"oras.land/oras-go/pkg/registry"
+	"oras.land/oras-go/pkg/registry/remote/auth"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/multierror"
+)
+
+const (
+	// OCIScheme is the scheme of plugin index and binary URLs pointing to OCI artifacts, e.g. `oci://ghcr.io/kubeshop/botkube-plugins/index:latest`.
+	OCIScheme = "oci://"
+
+	// OCIArtifactType is the artifact type of Botkube plugin binaries and indexes pushed to OCI registries.
+	OCIArtifactType = "application/vnd.kubeshop.botkube.plugin.v1"
+	// OCILayerMediaType is the media type of the single layer holding a plugin binary, archive or index.
+	OCILayerMediaType = "application/vnd.kubeshop.botkube.plugin.layer.v1"
+
+	ociEmptyConfig          = "{}"
+	ociDockerManifestV2Type = "application/vnd.docker.distribution.manifest.v2+json"
+)
+
+// IsOCIURL returns true if a given URL points to an OCI artifact.
+func IsOCIURL(in string) bool {
+	return strings.HasPrefix(in, OCIScheme)
+}
+
+// OCIClient pulls and pushes plugin binaries and indexes stored as single-layer OCI artifacts.
+type OCIClient struct {
+	cfg        config.PluginsOCI
+	cli        *auth.Client
+	credential func(ctx context.Context, host string) (auth.Credential, error)
+	// mirrorsOnly disables pulling artifacts from their registries, e.g. in air-gapped environments.
+	mirrorsOnly bool
+}
+
+// NewOCIClient returns a new OCIClient instance. Registry credentials are read from the Docker config files.
+func NewOCIClient(httpCli *http.Client, cfg config.PluginsOCI) (*OCIClient, error) {
+	var paths []string
+	if cfg.DockerConfigPath != "" {
+		paths = append(paths, cfg.DockerConfigPath)
+	}
+	store, err := dockerauth.NewClientWithDockerFallback(paths...)
+	if err != nil {
+		return nil, fmt.Errorf("while loading Docker config: %w", err)
+	}
+	dockerCli, ok := store.(*dockerauth.Client)
+	if !ok {
+		return nil, fmt.Errorf("unexpected Docker credentials store type %T", store)
+	}
+
+	return newOCIClient(httpCli, cfg, func(_ context.Context, host string) (auth.Credential, error) {
+		username, secret, err := dockerCli.Credential(host)
+		if err != nil {
+			// missing credentials are not an error, as public registries don't require them
+			return auth.EmptyCredential, nil
+		}
+		if username == "" {
+			return auth.Credential{RefreshToken: secret}, nil
+		}
+		return auth.Credential{Username: username, Password: secret}, nil
+	}), nil
+}
+
+func newOCIClient(httpCli *http.Client, cfg config.PluginsOCI, credential func(ctx context.Context, host string) (auth.Credential, error)) *OCIClient {
+	out := &OCIClient{
+		cfg:        cfg,
+		credential: credential,
+	}
+	out.cli = &auth.Client{
+		Client: httpCli,
+		Cache:  auth.NewCache(),
+		Credential: func(ctx context.Context, host string) (auth.Credential, error) {
+			return out.credential(ctx, host)
+		},
+	}
+	return out
+}
+
+// withCredentials returns a copy of the client which uses given credentials for a given registry host.
+func (c *OCIClient) withCredentials(host string, cred auth.Credential) *OCIClient {
+	fallback := c.credential
+	out := newOCIClient(c.cli.Client, c.cfg, func(ctx context.Context, reg string) (auth.Credential, error) {
+		if strings.EqualFold(reg, host) {
+			return cred, nil
+		}
+		return fallback(ctx, reg)
+	})
+	out.mirrorsOnly = c.mirrorsOnly
+	return out
+}
+
+// withMirrorsOnly returns a copy of the client which pulls artifacts only from mirror registries.
+func (c *OCIClient) withMirrorsOnly() *OCIClient {
+	out := newOCIClient(c.cli.Client, c.cfg, c.credential)
+	out.mirrorsOnly = true
+	return out
+}
+
+// Pull writes the content of the artifact with a given `oci://` URL to a given writer, and returns the name of the stored file.
+// Configured mirrors are tried in order before the artifact registry, which is skipped if the client pulls only from mirrors.
+func (c *OCIClient) Pull(ctx context.Context, ociURL string, w io.Writer) (string, error) {
+	ref, err := parseOCIURL(ociURL)
+	if err != nil {
+		return "", err
+	}
+
+	hosts := slices.Clone(c.cfg.Mirrors[ref.Registry])
+	if !c.mirrorsOnly {
+		hosts = append(hosts, ref.Registry)
+	}
+	if len(hosts) == 0 {
+		return "", fmt.Errorf("while pulling %q: no mirror registry is defined for %q", ociURL, ref.Registry)
+	}
+	errs := multierror.New()
+	for _, host := range hosts {
+		candidate := ref
+		candidate.Registry = host
+
+		// only a failed mirror can be skipped, as the content may be already partially written
+		name, written, err := c.pull(ctx, candidate, w)
+		if err == nil {
+			return name, nil
+		}
+		if written {
+			return "", err
+		}
+		errs = multierror.Append(errs, fmt.Errorf("%s: %w", host, err))
+	}
+	return "", fmt.Errorf("while pulling %q: %w", ociURL, errs.ErrorOrNil())
+}
+
+func (c *OCIClient) pull(ctx context.Context, ref registry.Reference, w io.Writer) (string, bool, error) {
+	ctx = auth.AppendScopes(ctx, auth.ScopeRepository(ref.Repository, "pull"))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.repoURL(ref, "manifests", ref.ReferenceOrDefault()), http.NoBody)
+	if err != nil {
+		return "", false, err
+	}
+	req.Header.Set("Accept", strings.Join([]string{ocispec.MediaTypeImageManifest, ociDockerManifestV2Type}, ", "))
+	res, err := c.cli.Do(req)
+	if err != nil {
+		return "", false, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return "", false, fmt.Errorf("while getting manifest: incorrect status code: %d", res.StatusCode)
+	}
+
+	var manifest ocispec.Manifest
+	if err := json.NewDecoder(res.Body).Decode(&manifest); err != nil {
+		return "", false, fmt.Errorf("while decoding manifest: %w", err)
+	}
+	if len(manifest.Layers) != 1 {
+		return "", false, fmt.Errorf("expected exactly one layer, got %d", len(manifest.Layers))
+	}
+	layer := manifest.Layers[0]
+	name := layer.Annotations[ocispec.AnnotationTitle]
+	if name == "" {
+		name = path.Base(ref.Repository)
+	}
+
+	req, err = http.NewRequestWithContext(ctx, http.MethodGet, c.repoURL(ref, "blobs", layer.Digest.String()), http.NoBody)
+	if err != nil {
+		return "", false, err
+	}
+	blob, err := c.cli.Do(req)
+	if err != nil {
+		return "", false, err
+	}
+	defer blob.Body.Close()
+	if blob.StatusCode != http.StatusOK {
+		return "", false, fmt.Errorf("while getting blob %s: incorrect status code: %d", layer.Digest, blob.StatusCode)
+	}
+
+	verifier := layer.Digest.Verifier()
+	n, err := io.Copy(io.MultiWriter(w, verifier), io.LimitReader(blob.Body, layer.Size))
+	if err != nil {
+		return "", n > 0, fmt.Errorf("while reading blob %s: %w", layer.Digest, err)
+	}
+	if n != layer.Size || !verifier.Verified() {
+		return "", true, fmt.Errorf("content of blob %s doesn't match its digest", layer.Digest)
+	}
+	return name, true, nil
+}
+
+// Push stores given content as a single-layer artifact with a given `oci://` URL. The name is stored as the layer title,
+// so that the archive type can be detected once the artifact is pulled.
+func (c *OCIClient) Push(ctx context.Context, ociURL, name string, data []byte) error {
+	ref, err := parseOCIURL(ociURL)
+	if err != nil {
+		return err
+	}
+	ctx = auth.AppendScopes(ctx, auth.ScopeRepository(ref.Repository, "pull", "push"))
+
+	emptyConfig := []byte(ociEmptyConfig)
+	configDesc := ocispec.Descriptor{MediaType: OCIArtifactType, Digest: digest.FromBytes(emptyConfig), Size: int64(len(emptyConfig))}
+	layerDesc := ocispec.Descriptor{
+		MediaType:   OCILayerMediaType,
+		Digest:      digest.FromBytes(data),
+		Size:        int64(len(data)),
+		Annotations: map[string]string{ocispec.AnnotationTitle: name},
+	}
+	for _, blob := range []struct {
+		desc ocispec.Descriptor
+		data []byte
+	}{
+		{desc: configDesc, data: emptyConfig},
+		{desc: layerDesc, data: data},
+	} {
+		if err := c.pushBlob(ctx, ref, blob.desc, blob.data); err != nil {
+			return fmt.Errorf("while pushing blob %s: %w", blob.desc.Digest, err)
+		}
+	}
+
+	manifest, err := json.Marshal(ocispec.Manifest{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ocispec.MediaTypeImageManifest,
+		Config:    configDesc,
+		Layers:    []ocispec.Descriptor{layerDesc},
+	})
+	if err != nil {
+		return fmt.Errorf("while marshaling manifest: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, c.repoURL(ref, "manifests", ref.ReferenceOrDefault()), bytes.NewReader(manifest))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", ocispec.MediaTypeImageManifest)
+	return c.expectStatus(req, http.StatusCreated, "while pushing manifest")
+}
+
+func (c *OCIClient) pushBlob(ctx context.Context, ref registry.Reference, desc ocispec.Descriptor, data []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, c.repoURL(ref, "blobs", desc.Digest.String()), http.NoBody)
+	if err != nil {
+		return err
+	}
+	res, err := c.cli.Do(req)
+	if err != nil {
+		return err
+	}
+	res.Body.Close()
+	if res.StatusCode == http.StatusOK {
+		// blob already exists
+		return nil
+	}
+
+	req, err = http.NewRequestWithContext(ctx, http.MethodPost, c.repoURL(ref, "blobs", "uploads")+"/", http.NoBody)
+	if err != nil {
+		return err
+	}
+	res, err = c.cli.Do(req)
+	if err != nil {
+		return err
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusAccepted {
+		return fmt.Errorf("while starting upload: incorrect status code: %d", res.StatusCode)
+	}
+
+	location, err := res.Request.URL.Parse(res.Header.Get("Location"))
+	if err != nil {
+		return fmt.Errorf("while parsing upload location: %w", err)
+	}
+	query := location.Query()
+	query.Set("digest", desc.Digest.String())
+	location.RawQuery = query.Encode()
+
+	req, err = http.NewRequestWithContext(ctx, http.MethodPut, location.String(), bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.ContentLength = desc.Size
+	req.Header.Set("Content-Type", "application/octet-stream")
+	return c.expectStatus(req, http.StatusCreated, "while uploading")
+}
+
+func (c *OCIClient) expectStatus(req *http.Request, status int, action string) error {
+	res, err := c.cli.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != status {
+		body, _ := io.ReadAll(io.LimitReader(res.Body, 512))
+		return fmt.Errorf("%s: incorrect status code: %d: %s", action, res.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+func (c *OCIClient) repoURL(ref registry.Reference, kind, suffix string) string {
+	scheme := "https"
+	if slices.Contains(c.cfg.PlainHTTP, ref.Registry) {
+		scheme = "http"
+	}
+	return fmt.Sprintf("%s://%s/v2/%s/%s/%s", scheme, ref.Host(), ref.Repository, kind, suffix)
+}
+
+func parseOCIURL(in string) (registry.Reference, error) {
+	if !IsOCIURL(in) {
+		return registry.Reference{}, fmt.Errorf("URL %q doesn't start with %q", in, OCIScheme)
+	}
+	raw := strings.TrimPrefix(in, OCIScheme)
+	if strings.Contains(raw, "?") {
+		return registry.Reference{}, errors.New("query parameters are not supported in OCI URLs")
+	}
+	ref, err := registry.ParseReference(raw)
+	if err != nil {
+		return registry.Reference{}, fmt.Errorf("while parsing OCI reference %q: %w", raw, err)
+	}
+	return ref, nil
+}
@@ -0,0 +1,299 @@
+package plugin
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"oras.land/oras-go/pkg/registry/remote/auth"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+func TestOCIClient_PushPull(t *testing.T) {
+	// given
+	srv, host := newFakeRegistry(t, "botkube", "secret")
+	cli := newOCIClient(srv.Client(), config.PluginsOCI{}, staticCredential(host, auth.Credential{Username: "botkube", Password: "secret"}))
+	ref := fmt.Sprintf("oci://%s/plugins/executor_echo_linux_amd64:v1.0.0", host)
+
+	// when
+	err := cli.Push(context.Background(), ref, "executor_echo_linux_amd64", []byte("#!/bin/sh\necho hello\n"))
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	title, err := cli.Pull(context.Background(), ref, &out)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "executor_echo_linux_amd64", title)
+	assert.Equal(t, "#!/bin/sh\necho hello\n", out.String())
+}
+
+func TestOCIClient_PullUnauthorized(t *testing.T) {
+	// given
+	srv, host := newFakeRegistry(t, "botkube", "secret")
+	cli := newOCIClient(srv.Client(), config.PluginsOCI{}, staticCredential(host, auth.EmptyCredential))
+
+	// when
+	_, err := cli.Pull(context.Background(), fmt.Sprintf("oci://%s/plugins/index:latest", host), io.Discard)
+
+	// then
+	assert.ErrorContains(t, err, "credential required for basic auth")
+}
+
+func TestOCIClient_PullMirrors(t *testing.T) {
+	// given
+	srv, host := newFakeRegistry(t, "", "")
+	mirrorSrv, mirrorHost := newFakeRegistry(t, "", "")
+	_, emptyMirrorHost := newFakeRegistry(t, "", "")
+
+	pushCli := newOCIClient(mirrorSrv.Client(), config.PluginsOCI{}, staticCredential(mirrorHost, auth.EmptyCredential))
+	require.NoError(t, pushCli.Push(context.Background(), fmt.Sprintf("oci://%s/plugins/index:latest", mirrorHost), "index.yaml", []byte("from mirror")))
+	pushCli = newOCIClient(srv.Client(), config.PluginsOCI{}, staticCredential(host, auth.EmptyCredential))
+	require.NoError(t, pushCli.Push(context.Background(), fmt.Sprintf("oci://%s/plugins/executor:latest", host), "executor", []byte("from registry")))
+
+	// all test servers share the same certificate, so a single client is trusted by every registry
+	cli := newOCIClient(srv.Client(), config.PluginsOCI{
+		Mirrors: map[string][]string{
+			host: {emptyMirrorHost, mirrorHost},
+		},
+	}, staticCredential(host, auth.EmptyCredential))
+
+	tests := []struct {
+		name            string
+		ref             string
+		expectedContent string
+	}{
+		{
+			name:            "served by mirror",
+			ref:             fmt.Sprintf("oci://%s/plugins/index:latest", host),
+			expectedContent: "from mirror",
+		},
+		{
+			name:            "fallback to registry",
+			ref:             fmt.Sprintf("oci://%s/plugins/executor:latest", host),
+			expectedContent: "from registry",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			var out bytes.Buffer
+			_, err := cli.Pull(context.Background(), tc.ref, &out)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedContent, out.String())
+		})
+	}
+}
+
+func TestOCIClient_PullMirrorsOnly(t *testing.T) {
+	// given
+	srv, host := newFakeRegistry(t, "", "")
+	mirrorSrv, mirrorHost := newFakeRegistry(t, "", "")
+
+	pushCli := newOCIClient(mirrorSrv.Client(), config.PluginsOCI{}, staticCredential(mirrorHost, auth.EmptyCredential))
+	require.NoError(t, pushCli.Push(context.Background(), fmt.Sprintf("oci://%s/plugins/index:latest", mirrorHost), "index.yaml", []byte("from mirror")))
+	pushCli = newOCIClient(srv.Client(), config.PluginsOCI{}, staticCredential(host, auth.EmptyCredential))
+	require.NoError(t, pushCli.Push(context.Background(), fmt.Sprintf("oci://%s/plugins/executor:latest", host), "executor", []byte("from registry")))
+
+	cli := newOCIClient(srv.Client(), config.PluginsOCI{
+		Mirrors: map[string][]string{
+			host: {mirrorHost},
+		},
+	}, staticCredential(host, auth.EmptyCredential)).withMirrorsOnly()
+
+	// when
+	var out bytes.Buffer
+	_, err := cli.Pull(context.Background(), fmt.Sprintf("oci://%s/plugins/index:latest", host), &out)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "from mirror", out.String())
+
+	// when
+	_, err = cli.Pull(context.Background(), fmt.Sprintf("oci://%s/plugins/executor:latest", host), io.Discard)
+
+	// then
+	assert.ErrorContains(t, err, mirrorHost)
+	assert.NotContains(t, err.Error(), fmt.Sprintf("\t* %s:", host))
+
+	// when
+	_, err = cli.Pull(context.Background(), "oci://ghcr.io/kubeshop/botkube-plugins/index:latest", io.Discard)
+
+	// then
+	assert.EqualError(t, err, `while pulling "oci://ghcr.io/kubeshop/botkube-plugins/index:latest": no mirror registry is defined for "ghcr.io"`)
+}
+
+func TestParseOCIURL(t *testing.T) {
+	tests := []struct {
+		name        string
+		in          string
+		expectedErr string
+	}{
+		{
+			name: "valid",
+			in:   "oci://ghcr.io/kubeshop/botkube-plugins/index:v1.0.0",
+		},
+		{
+			name:        "missing scheme",
+			in:          "ghcr.io/kubeshop/botkube-plugins/index:v1.0.0",
+			expectedErr: `URL "ghcr.io/kubeshop/botkube-plugins/index:v1.0.0" doesn't start with "oci://"`,
+		},
+		{
+			name:        "query parameters",
+			in:          "oci://ghcr.io/kubeshop/botkube-plugins/index:v1.0.0?archive=tar.gz",
+			expectedErr: "query parameters are not supported in OCI URLs",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			_, err := parseOCIURL(tc.in)
+
+			// then
+			if tc.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestDownloadBinaryFromOCI(t *testing.T) {
+	// given
+	srv, host := newFakeRegistry(t, "", "")
+	cli := newOCIClient(srv.Client(), config.PluginsOCI{}, staticCredential(host, auth.EmptyCredential))
+	ref := fmt.Sprintf("oci://%s/plugins/executor_echo_linux_amd64.tar.gz:v1.0.0", host)
+
+	archive := newTarGz(t, "executor_echo_linux_amd64", "#!/bin/sh\necho hello\n")
+	require.NoError(t, cli.Push(context.Background(), ref, "executor_echo_linux_amd64.tar.gz", archive))
+	checksum := sha256.Sum256(archive)
+
+	binPath := filepath.Join(t.TempDir(), "executor_v1.0.0_echo")
+
+	// when
+	err := downloadBinary(context.Background(), binPath, URL{URL: ref, Checksum: hex.EncodeToString(checksum[:])}, true, &repositoryClient{oci: cli})
+
+	// then
+	require.NoError(t, err)
+	data, err := os.ReadFile(binPath)
+	require.NoError(t, err)
+	assert.Equal(t, "#!/bin/sh\necho hello\n", string(data))
+
+	// when
+	err = downloadBinary(context.Background(), binPath, URL{URL: ref, Checksum: "d41d8cd98f00b204e9800998ecf8427e"}, true, &repositoryClient{oci: cli})
+
+	// then
+	assert.ErrorContains(t, err, "checksum")
+}
+
+func staticCredential(host string, cred auth.Credential) func(context.Context, string) (auth.Credential, error) {
+	return func(_ context.Context, reg string) (auth.Credential, error) {
+		if reg != host {
+			return auth.EmptyCredential, nil
+		}
+		return cred, nil
+	}
+}
+
+func newTarGz(t *testing.T, name, content string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0o755, Size: int64(len(content))}))
+	_, err := tw.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	require.NoError(t, gw.Close())
+	return buf.Bytes()
+}
+
+// newFakeRegistry starts an in-memory registry which implements the subset of the OCI distribution API used by OCIClient.
+// Basic authentication is required if a username is set.
+func newFakeRegistry(t *testing.T, username, password string) (*httptest.Server, string) {
+	t.Helper()
+
+	var (
+		mu        sync.Mutex
+		manifests = map[string][]byte{}
+		blobs     = map[string][]byte{}
+		uploads   int
+	)
+
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if username != "" {
+			user, pass, ok := r.BasicAuth()
+			if !ok || user != username || pass != password {
+				w.Header().Set("WWW-Authenticate", `Basic realm="fake"`)
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		p := strings.TrimPrefix(r.URL.Path, "/v2/")
+		switch {
+		case strings.Contains(p, "/blobs/uploads/") && r.Method == http.MethodPost:
+			uploads++
+			w.Header().Set("Location", fmt.Sprintf("/v2/%s%d", p, uploads))
+			w.WriteHeader(http.StatusAccepted)
+		case strings.Contains(p, "/blobs/uploads/") && r.Method == http.MethodPut:
+			data, _ := io.ReadAll(r.Body)
+			dgst := r.URL.Query().Get("digest")
+			if digest.FromBytes(data).String() != dgst {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			blobs[dgst] = data
+			w.WriteHeader(http.StatusCreated)
+		case strings.Contains(p, "/blobs/"):
+			data, found := blobs[p[strings.LastIndex(p, "/")+1:]]
+			if !found {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			if r.Method == http.MethodGet {
+				_, _ = w.Write(data)
+			}
+		case strings.Contains(p, "/manifests/") && r.Method == http.MethodPut:
+			manifests[p], _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusCreated)
+		case strings.Contains(p, "/manifests/"):
+			data, found := manifests[p]
+			if !found {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Header().Set("Content-Type", ocispec.MediaTypeImageManifest)
+			_, _ = w.Write(data)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, strings.TrimPrefix(srv.URL, "https://")
+}
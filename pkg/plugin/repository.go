@@ -0,0 +1,226 @@
+package plugin
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/go-getter"
+	"oras.land/oras-go/pkg/registry/remote/auth"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/httpx"
+	"github.com/kubeshop/botkube/pkg/tlsx"
+)
+
+// repositoryClient sends requests to a given plugin repository, applying its credentials and TLS settings.
+type repositoryClient struct {
+	auth    config.PluginsRepositoryAuth
+	host    string
+	httpCli *http.Client
+	// downloadCli has no timeout, as downloading plugin binaries may take long. It's always set, as go-getter default client
+	// doesn't follow the global TLS policy.
+	downloadCli *http.Client
+	oci         *OCIClient
+}
+
+// newRepositoryClient returns a client for a given repository. The default HTTP client is used if the repository has no TLS settings.
+// Repository credentials are also used for the OCI registry hosting the repository index.
+func newRepositoryClient(defaultCli *http.Client, repo config.PluginsRepository, ociCfg config.PluginsOCI) (*repositoryClient, error) {
+	if err := validateRepositoryAuth(repo.Auth); err != nil {
+		return nil, err
+	}
+
+	if IsOCIURL(repo.URL) && repo.Auth.Type == config.SignedURLPluginsRepositoryAuth {
+		return nil, errors.New("signed URL authentication is not supported for OCI registries")
+	}
+
+	parsed, err := url.Parse(repo.URL)
+	if err != nil {
+		return nil, fmt.Errorf("while parsing URL %q: %w", repo.URL, err)
+	}
+
+	// the cloned default transport follows the global TLS policy
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	out := &repositoryClient{
+		auth:    repo.Auth,
+		host:    parsed.Host,
+		httpCli: defaultCli,
+		downloadCli: &http.Client{
+			Transport: transport,
+		},
+	}
+	if !repo.TLS.IsZero() {
+		tlsCfg, err := newRepositoryTLSConfig(parsed.Host, repo.TLS)
+		if err != nil {
+			return nil, fmt.Errorf("while loading TLS settings: %w", err)
+		}
+		transport.TLSClientConfig = tlsCfg
+		out.httpCli = &http.Client{
+			Timeout:   httpx.DefaultTimeout,
+			Transport: transport,
+		}
+	}
+
+	out.oci, err = NewOCIClient(out.downloadCli, ociCfg)
+	if err != nil {
+		return nil, err
+	}
+	switch repo.Auth.Type {
+	case config.BasicPluginsRepositoryAuth:
+		out.oci = out.oci.withCredentials(out.host, auth.Credential{Username: repo.Auth.Username, Password: repo.Auth.Password})
+	case config.BearerPluginsRepositoryAuth:
+		out.oci = out.oci.withCredentials(out.host, auth.Credential{AccessToken: repo.Auth.Token})
+	}
+	return out, nil
+}
+
+// FetchIndex writes the index of a given repository to a given writer. Given headers are sent together with the credentials,
+// unless the index is stored in an OCI registry.
+func (c *repositoryClient) FetchIndex(ctx context.Context, indexURL string, headers map[string]string, w io.Writer) error {
+	if IsOCIURL(indexURL) {
+		_, err := c.oci.Pull(ctx, indexURL, w)
+		return err
+	}
+
+	signedURL, err := c.SignURL(indexURL)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, signedURL, http.NoBody)
+	if err != nil {
+		return fmt.Errorf("while creating request: %w", err)
+	}
+	req.Header, err = c.AuthHeaders(indexURL)
+	if err != nil {
+		return err
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	res, err := c.httpCli.Do(req)
+	if err != nil {
+		return fmt.Errorf("while executing request: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("incorrect status code: %d", res.StatusCode)
+	}
+	if _, err := io.Copy(w, res.Body); err != nil {
+		return fmt.Errorf("while reading index: %w", err)
+	}
+	return nil
+}
+
+// SignURL returns a given URL with the signed query appended, if the URL points to the repository host.
+func (c *repositoryClient) SignURL(rawURL string) (string, error) {
+	if c.auth.Type != config.SignedURLPluginsRepositoryAuth {
+		return rawURL, nil
+	}
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("while parsing URL %q: %w", rawURL, err)
+	}
+	if !c.isSameHost(parsed) {
+		return rawURL, nil
+	}
+
+	signature, err := url.ParseQuery(strings.TrimPrefix(c.auth.SignedQuery, "?"))
+	if err != nil {
+		return "", fmt.Errorf("while parsing signed query: %w", err)
+	}
+	query := parsed.Query()
+	for key, values := range signature {
+		query[key] = values
+	}
+	parsed.RawQuery = query.Encode()
+	return parsed.String(), nil
+}
+
+// AuthHeaders returns headers with credentials for a given URL. Credentials are sent only to the repository host,
+// so they are not leaked to third-party hosts serving plugin binaries.
+func (c *repositoryClient) AuthHeaders(rawURL string) (http.Header, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("while parsing URL %q: %w", rawURL, err)
+	}
+
+	out := http.Header{}
+	if !c.isSameHost(parsed) {
+		return out, nil
+	}
+
+	switch c.auth.Type {
+	case config.BasicPluginsRepositoryAuth:
+		req := http.Request{Header: out}
+		req.SetBasicAuth(c.auth.Username, c.auth.Password)
+	case config.BearerPluginsRepositoryAuth:
+		out.Set("Authorization", "Bearer "+c.auth.Token)
+	}
+	return out, nil
+}
+
+// Getters returns go-getter getters which download a given URL with the repository credentials and TLS settings.
+func (c *repositoryClient) Getters(rawURL string) (map[string]getter.Getter, error) {
+	headers, err := c.AuthHeaders(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(map[string]getter.Getter, len(getter.Getters))
+	for scheme, g := range getter.Getters {
+		out[scheme] = g
+	}
+	httpGetter := &getter.HttpGetter{
+		Client: c.downloadCli,
+		Header: headers,
+	}
+	out["http"] = httpGetter
+	out["https"] = httpGetter
+	return out, nil
+}
+
+func (c *repositoryClient) isSameHost(in *url.URL) bool {
+	return strings.EqualFold(in.Host, c.host)
+}
+
+func validateRepositoryAuth(auth config.PluginsRepositoryAuth) error {
+	switch auth.Type {
+	case "":
+		return nil
+	case config.BasicPluginsRepositoryAuth:
+		if auth.Username == "" {
+			return errors.New("username is required for the basic authentication")
+		}
+	case config.BearerPluginsRepositoryAuth:
+		if auth.Token == "" {
+			return errors.New("token is required for the bearer authentication")
+		}
+	case config.SignedURLPluginsRepositoryAuth:
+		if auth.SignedQuery == "" {
+			return errors.New("signed query is required for the signed URL authentication")
+		}
+	default:
+		return fmt.Errorf("unsupported authentication type %q, use one of: %s, %s, %s", auth.Type, config.BasicPluginsRepositoryAuth, config.BearerPluginsRepositoryAuth, config.SignedURLPluginsRepositoryAuth)
+	}
+	return nil
+}
+
+func newRepositoryTLSConfig(name string, cfg config.PluginsRepositoryTLS) (*tls.Config, error) {
+	return tlsx.NewClientConfig(tlsx.ClientOptions{
+		Name:               fmt.Sprintf("%s plugin repository", name),
+		CAFile:             cfg.CAFile,
+		CertFile:           cfg.CertFile,
+		KeyFile:            cfg.KeyFile,
+		MinVersion:         cfg.MinVersion,
+		CipherSuites:       cfg.CipherSuites,
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+	})
+}
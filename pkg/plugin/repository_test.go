@@ -0,0 +1,174 @@
+package plugin
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/httpx"
+)
+
+func TestRepositoryClient_AuthHeaders(t *testing.T) {
+	tests := []struct {
+		name          string
+		auth          config.PluginsRepositoryAuth
+		url           string
+		expectedValue string
+	}{
+		{
+			name:          "basic",
+			auth:          config.PluginsRepositoryAuth{Type: config.BasicPluginsRepositoryAuth, Username: "botkube", Password: "secret"},
+			url:           "https://plugins.example.com/executor_echo.tar.gz",
+			expectedValue: "Basic Ym90a3ViZTpzZWNyZXQ=",
+		},
+		{
+			name:          "bearer",
+			auth:          config.PluginsRepositoryAuth{Type: config.BearerPluginsRepositoryAuth, Token: "token"},
+			url:           "https://plugins.example.com/executor_echo.tar.gz",
+			expectedValue: "Bearer token",
+		},
+		{
+			name: "other host",
+			auth: config.PluginsRepositoryAuth{Type: config.BearerPluginsRepositoryAuth, Token: "token"},
+			url:  "https://github.com/kubeshop/botkube/releases/download/v1.0.0/executor_echo.tar.gz",
+		},
+		{
+			name: "no auth",
+			url:  "https://plugins.example.com/executor_echo.tar.gz",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			cli, err := newRepositoryClient(httpx.NewHTTPClient(), config.PluginsRepository{
+				URL:  "https://plugins.example.com/index.yaml",
+				Auth: tc.auth,
+			}, config.PluginsOCI{})
+			require.NoError(t, err)
+
+			// when
+			headers, err := cli.AuthHeaders(tc.url)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedValue, headers.Get("Authorization"))
+		})
+	}
+}
+
+func TestRepositoryClient_SignURL(t *testing.T) {
+	// given
+	cli, err := newRepositoryClient(httpx.NewHTTPClient(), config.PluginsRepository{
+		URL: "https://account.blob.core.windows.net/plugins/index.yaml",
+		Auth: config.PluginsRepositoryAuth{
+			Type:        config.SignedURLPluginsRepositoryAuth,
+			SignedQuery: "?sv=2022-11-02&sig=abc%2Bdef",
+		},
+	}, config.PluginsOCI{})
+	require.NoError(t, err)
+
+	// when
+	signed, err := cli.SignURL("https://account.blob.core.windows.net/plugins/executor_echo.tar.gz?archive=tar.gz")
+	require.NoError(t, err)
+	other, err := cli.SignURL("https://github.com/executor_echo.tar.gz")
+	require.NoError(t, err)
+
+	// then
+	assert.Equal(t, "https://account.blob.core.windows.net/plugins/executor_echo.tar.gz?archive=tar.gz&sig=abc%2Bdef&sv=2022-11-02", signed)
+	assert.Equal(t, "https://github.com/executor_echo.tar.gz", other)
+}
+
+func TestNewRepositoryClient_Invalid(t *testing.T) {
+	tests := []struct {
+		name        string
+		repo        config.PluginsRepository
+		expectedErr string
+	}{
+		{
+			name:        "missing token",
+			repo:        config.PluginsRepository{Auth: config.PluginsRepositoryAuth{Type: config.BearerPluginsRepositoryAuth}},
+			expectedErr: "token is required for the bearer authentication",
+		},
+		{
+			name:        "unknown type",
+			repo:        config.PluginsRepository{Auth: config.PluginsRepositoryAuth{Type: "oauth"}},
+			expectedErr: `unsupported authentication type "oauth", use one of: basic, bearer, signedURL`,
+		},
+		{
+			name:        "missing CA file",
+			repo:        config.PluginsRepository{TLS: config.PluginsRepositoryTLS{CAFile: "/not/existing/ca.crt"}},
+			expectedErr: "while loading TLS settings: while reading CA file: open /not/existing/ca.crt: no such file or directory",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			_, err := newRepositoryClient(httpx.NewHTTPClient(), tc.repo, config.PluginsOCI{})
+
+			// then
+			assert.EqualError(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestPrivateRepository(t *testing.T) {
+	// given
+	const token = "my-token"
+	mux := http.NewServeMux()
+	srv := httptest.NewTLSServer(mux)
+	defer srv.Close()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+token {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/index.yaml":
+			_, _ = w.Write([]byte("entries:\n  - name: echo\n    type: executor\n    version: v1.0.0\n"))
+		case "/executor_echo":
+			_, _ = w.Write([]byte("#!/bin/sh\necho hello\n"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	tmpDir := t.TempDir()
+	caFile := filepath.Join(tmpDir, "ca.crt")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	require.NoError(t, os.WriteFile(caFile, caPEM, 0o600))
+
+	repo := config.PluginsRepository{
+		URL:  srv.URL + "/index.yaml",
+		Auth: config.PluginsRepositoryAuth{Type: config.BearerPluginsRepositoryAuth, Token: token},
+		TLS:  config.PluginsRepositoryTLS{CAFile: caFile},
+	}
+
+	// when
+	index, err := fetchIndex(context.Background(), httpx.NewHTTPClient(), repo)
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, index.Entries, 1)
+	assert.Equal(t, "echo", index.Entries[0].Name)
+
+	// when
+	repoCli, err := newRepositoryClient(httpx.NewHTTPClient(), repo, config.PluginsOCI{})
+	require.NoError(t, err)
+	binPath := filepath.Join(tmpDir, "executor_v1.0.0_echo")
+	err = downloadBinary(context.Background(), binPath, URL{URL: srv.URL + "/executor_echo"}, false, repoCli)
+
+	// then
+	require.NoError(t, err)
+	data, err := os.ReadFile(binPath)
+	require.NoError(t, err)
+	assert.Equal(t, "#!/bin/sh\necho hello\n", string(data))
+}
@@ -0,0 +1,239 @@
+package plugin
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+const (
+	defaultPluginCertValidity = 24 * time.Hour
+	// pluginServerName is the name verified in plugin server certificates. go-plugin uses the same one for ephemeral certificates.
+	pluginServerName = "localhost"
+	// certClockSkew is subtracted from the start of the certificate validity, to tolerate clock differences.
+	certClockSkew    = time.Minute
+	authTokenLength  = 32
+	serialNumberBits = 128
+)
+
+// certRenewalFraction is the part of the plugin server certificate lifetime after which the plugin is restarted with a new certificate.
+var certRenewalFraction = 2.0 / 3
+
+// pluginCredentials holds credentials of a single plugin process.
+type pluginCredentials struct {
+	// env holds environment variables passed to the plugin process.
+	env         []string
+	autoMTLS    bool
+	tlsConfig   *tls.Config
+	dialOptions []grpc.DialOption
+	// serverCert is set if the plugin server certificate is issued from the CA, so it needs to be renewed.
+	serverCert *pluginServerCert
+}
+
+// pluginServerCert describes the certificate passed to a plugin process, which can't be replaced while the process runs.
+type pluginServerCert struct {
+	// renewAt is the time after which the plugin is restarted with a new certificate, well before the current one expires.
+	renewAt time.Time
+	// caFingerprint identifies the CA which issued the certificate, so the plugin is restarted once the CA is rotated.
+	caFingerprint string
+}
+
+// renewalDue returns true if the certificate should be replaced with a new one issued from a CA with a given fingerprint.
+func (c *pluginServerCert) renewalDue(caFingerprint string, now time.Time) bool {
+	if c == nil {
+		return false
+	}
+	return !now.Before(c.renewAt) || c.caFingerprint != caFingerprint
+}
+
+// newPluginCredentials generates credentials for a new plugin process, according to the security settings.
+func newPluginCredentials(cfg config.PluginSecurity) (pluginCredentials, error) {
+	var out pluginCredentials
+
+	switch cfg.MTLS.Mode {
+	case config.AutoPluginMTLSMode:
+		out.autoMTLS = true
+	case config.CAPluginMTLSMode:
+		tlsCfg, env, serverCert, err := caPluginTLS(cfg.MTLS)
+		if err != nil {
+			return pluginCredentials{}, err
+		}
+		out.tlsConfig = tlsCfg
+		out.serverCert = serverCert
+		out.env = append(out.env, env...)
+	}
+
+	if cfg.AuthTokens {
+		token, err := generateAuthToken()
+		if err != nil {
+			return pluginCredentials{}, fmt.Errorf("while generating authentication token: %w", err)
+		}
+		out.env = append(out.env, fmt.Sprintf("%s=%s", api.PluginAuthTokenEnvName, token))
+		out.dialOptions = append(out.dialOptions, grpc.WithPerRPCCredentials(api.TokenCredentials{Token: token}))
+	}
+
+	return out, nil
+}
+
+// caPluginTLS reads the CA from disk, issues the plugin server certificate, and returns the client TLS configuration
+// together with environment variables which pass the server certificate to the plugin.
+// A new client certificate is issued for each TLS handshake.
+func caPluginTLS(cfg config.PluginMTLS) (*tls.Config, []string, *pluginServerCert, error) {
+	ca, err := loadCA(cfg.CACertFile, cfg.CAKeyFile)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	validity := cfg.CertValidity
+	if validity <= 0 {
+		validity = defaultPluginCertValidity
+	}
+
+	now := time.Now()
+	serverCertPEM, serverKeyPEM, err := ca.issue(x509.ExtKeyUsageServerAuth, validity)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("while issuing plugin certificate: %w", err)
+	}
+	serverCert := &pluginServerCert{
+		renewAt:       now.Add(time.Duration(float64(ca.notAfter(now, validity).Sub(now)) * certRenewalFraction)),
+		caFingerprint: ca.fingerprint,
+	}
+
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(ca.cert)
+
+	tlsCfg := &tls.Config{
+		RootCAs:    rootCAs,
+		ServerName: pluginServerName,
+		MinVersion: tls.VersionTLS12,
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			certPEM, keyPEM, err := ca.issue(x509.ExtKeyUsageClientAuth, validity)
+			if err != nil {
+				return nil, fmt.Errorf("while issuing client certificate: %w", err)
+			}
+			cert, err := tls.X509KeyPair(certPEM, keyPEM)
+			if err != nil {
+				return nil, fmt.Errorf("while loading client certificate: %w", err)
+			}
+			return &cert, nil
+		},
+	}
+
+	env := []string{
+		fmt.Sprintf("%s=%s", api.PluginTLSCertEnvName, serverCertPEM),
+		fmt.Sprintf("%s=%s", api.PluginTLSKeyEnvName, serverKeyPEM),
+		fmt.Sprintf("%s=%s", api.PluginTLSCAEnvName, ca.certPEM),
+	}
+	return tlsCfg, env, serverCert, nil
+}
+
+// certAuthority issues short-lived certificates for plugin connections.
+type certAuthority struct {
+	cert    *x509.Certificate
+	certPEM []byte
+	key     any
+	// fingerprint is the SHA-256 hash of the CA certificate.
+	fingerprint string
+}
+
+func loadCA(certFile, keyFile string) (*certAuthority, error) {
+	certPEM, err := os.ReadFile(certFile)
+	if err != nil {
+		return nil, fmt.Errorf("while reading CA certificate: %w", err)
+	}
+	keyPEM, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("while reading CA private key: %w", err)
+	}
+
+	pair, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("while loading CA: %w", err)
+	}
+	cert, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		return nil, fmt.Errorf("while parsing CA certificate: %w", err)
+	}
+	if !cert.IsCA {
+		return nil, errors.New("while loading CA: certificate is not a CA")
+	}
+
+	fingerprint := sha256.Sum256(pair.Certificate[0])
+	return &certAuthority{
+		cert:        cert,
+		certPEM:     pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: pair.Certificate[0]}),
+		key:         pair.PrivateKey,
+		fingerprint: hex.EncodeToString(fingerprint[:]),
+	}, nil
+}
+
+// issue returns a PEM-encoded certificate and private key for a given usage. The certificate doesn't outlive the CA.
+func (ca *certAuthority) issue(usage x509.ExtKeyUsage, validity time.Duration) ([]byte, []byte, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("while generating private key: %w", err)
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), serialNumberBits))
+	if err != nil {
+		return nil, nil, fmt.Errorf("while generating serial number: %w", err)
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: pluginServerName, Organization: []string{"Botkube"}},
+		DNSNames:     []string{pluginServerName},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback},
+		NotBefore:    now.Add(-certClockSkew),
+		NotAfter:     ca.notAfter(now, validity),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("while creating certificate: %w", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("while marshaling private key: %w", err)
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return certPEM, keyPEM, nil
+}
+
+// notAfter returns the end of validity of a certificate issued at a given time.
+func (ca *certAuthority) notAfter(now time.Time, validity time.Duration) time.Time {
+	notAfter := now.Add(validity)
+	if notAfter.After(ca.cert.NotAfter) {
+		return ca.cert.NotAfter
+	}
+	return notAfter
+}
+
+func generateAuthToken() (string, error) {
+	buf := make([]byte, authTokenLength)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
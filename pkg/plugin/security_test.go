@@ -0,0 +1,155 @@
+package plugin
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+func TestNewPluginCredentialsCA(t *testing.T) {
+	// given
+	certFile, keyFile := writeTestCA(t)
+	cfg := config.PluginSecurity{
+		MTLS: config.PluginMTLS{
+			Mode:         config.CAPluginMTLSMode,
+			CACertFile:   certFile,
+			CAKeyFile:    keyFile,
+			CertValidity: time.Hour,
+		},
+	}
+
+	// when
+	creds, err := newPluginCredentials(cfg)
+
+	// then
+	require.NoError(t, err)
+	assert.False(t, creds.autoMTLS)
+	assert.Empty(t, creds.dialOptions)
+	require.NotNil(t, creds.tlsConfig)
+
+	env := envToMap(creds.env)
+	serverCert, err := tls.X509KeyPair([]byte(env[api.PluginTLSCertEnvName]), []byte(env[api.PluginTLSKeyEnvName]))
+	require.NoError(t, err)
+	leaf, err := x509.ParseCertificate(serverCert.Certificate[0])
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), leaf.NotAfter, time.Minute)
+	require.NotNil(t, creds.serverCert)
+	assert.WithinDuration(t, time.Now().Add(40*time.Minute), creds.serverCert.renewAt, time.Minute)
+	assert.NotEmpty(t, creds.serverCert.caFingerprint)
+
+	clientCAs := x509.NewCertPool()
+	require.True(t, clientCAs.AppendCertsFromPEM([]byte(env[api.PluginTLSCAEnvName])))
+
+	// the plugin server requires a client certificate issued by the same CA
+	clientConn, serverConn := net.Pipe()
+	defer clientConn.Close()
+	defer serverConn.Close()
+	server := tls.Server(serverConn, &tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		ClientCAs:    clientCAs,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS12,
+	})
+	client := tls.Client(clientConn, creds.tlsConfig)
+
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- server.Handshake()
+	}()
+	require.NoError(t, client.Handshake())
+	require.NoError(t, <-serverErr)
+	assert.Len(t, server.ConnectionState().PeerCertificates, 1)
+}
+
+func TestNewPluginCredentialsAutoWithTokens(t *testing.T) {
+	// given
+	cfg := config.PluginSecurity{
+		MTLS:       config.PluginMTLS{Mode: config.AutoPluginMTLSMode},
+		AuthTokens: true,
+	}
+
+	// when
+	first, err := newPluginCredentials(cfg)
+	require.NoError(t, err)
+	second, err := newPluginCredentials(cfg)
+	require.NoError(t, err)
+
+	// then
+	assert.True(t, first.autoMTLS)
+	assert.Nil(t, first.tlsConfig)
+	assert.Len(t, first.dialOptions, 1)
+
+	firstToken := envToMap(first.env)[api.PluginAuthTokenEnvName]
+	assert.Len(t, firstToken, 2*authTokenLength)
+	assert.NotEqual(t, firstToken, envToMap(second.env)[api.PluginAuthTokenEnvName])
+}
+
+func TestNewPluginCredentialsInvalidCA(t *testing.T) {
+	// given
+	cfg := config.PluginSecurity{
+		MTLS: config.PluginMTLS{
+			Mode:       config.CAPluginMTLSMode,
+			CACertFile: filepath.Join(t.TempDir(), "missing.crt"),
+			CAKeyFile:  filepath.Join(t.TempDir(), "missing.key"),
+		},
+	}
+
+	// when
+	_, err := newPluginCredentials(cfg)
+
+	// then
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "while reading CA certificate")
+}
+
+func writeTestCA(t *testing.T) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Botkube test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "ca.crt"), filepath.Join(dir, "ca.key")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+	return certFile, keyFile
+}
+
+func envToMap(env []string) map[string]string {
+	out := map[string]string{}
+	for _, e := range env {
+		name, val, _ := strings.Cut(e, "=")
+		out[name] = val
+	}
+	return out
+}
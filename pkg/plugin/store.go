@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"sort"
 	"sync"
+	"sync/atomic"
 
 	semver "github.com/hashicorp/go-version"
 	"gopkg.in/yaml.v3"
@@ -44,6 +45,14 @@ type (
 	enabledPlugins[T any] struct {
 		Client  T
 		Cleanup func()
+		// Version is the version of the running plugin.
+		Version string
+		// inFlight is the number of calls to the plugin which are being processed.
+		inFlight *atomic.Int64
+		// metadata describes the started plugin, so it can be started again, e.g. with a new certificate.
+		metadata pluginMetadata
+		// serverCert is set if the plugin server certificate needs to be renewed.
+		serverCert *pluginServerCert
 	}
 )
 
@@ -57,9 +66,20 @@ func newStore[T any]() store[T] {
 }
 
 func (p *storePlugins[T]) Insert(key string, plugin enabledPlugins[T]) {
+	p.Swap(key, plugin)
+}
+
+// Swap replaces the plugin stored under a given key, and returns the previous one.
+func (p *storePlugins[T]) Swap(key string, plugin enabledPlugins[T]) (enabledPlugins[T], bool) {
+	if plugin.inFlight == nil {
+		plugin.inFlight = &atomic.Int64{}
+	}
+
 	p.Lock()
 	defer p.Unlock()
+	old, found := p.data[key]
 	p.data[key] = plugin
+	return old, found
 }
 
 func (p *storePlugins[T]) Get(key string) (enabledPlugins[T], bool) {
@@ -69,26 +89,38 @@ func (p *storePlugins[T]) Get(key string) (enabledPlugins[T], bool) {
 	return plugin, found
 }
 
+// Snapshot returns a copy of stored plugins.
+func (p *storePlugins[T]) Snapshot() map[string]enabledPlugins[T] {
+	p.RLock()
+	defer p.RUnlock()
+	out := make(map[string]enabledPlugins[T], len(p.data))
+	for key, plugin := range p.data {
+		out[key] = plugin
+	}
+	return out
+}
+
 func (p *storePlugins[T]) Delete(key string) {
 	p.Lock()
 	defer p.Unlock()
 	delete(p.data, key)
 }
 
-func newStoreRepositories(indexes map[string][]byte) (storeRepository, storeRepository, error) {
+func newStoreRepositories(indexes map[string][]byte) (storeRepository, storeRepository, storeRepository, error) {
 	var (
-		executorsRepositories = storeRepository{}
-		sourcesRepositories   = storeRepository{}
+		executorsRepositories  = storeRepository{}
+		sourcesRepositories    = storeRepository{}
+		processorsRepositories = storeRepository{}
 	)
 
 	for repo, data := range indexes {
 		var index Index
 		if err := yaml.Unmarshal(data, &index); err != nil {
-			return nil, nil, fmt.Errorf("while unmarshaling index: %w", err)
+			return nil, nil, nil, fmt.Errorf("while unmarshaling index: %w", err)
 		}
 
 		if err := index.Validate(); err != nil {
-			return nil, nil, fmt.Errorf("while validating %s index: %w", repo, err)
+			return nil, nil, nil, fmt.Errorf("while validating %s index: %w", repo, err)
 		}
 
 		for _, entry := range index.Entries {
@@ -115,6 +147,16 @@ func newStoreRepositories(indexes map[string][]byte) (storeRepository, storeRepo
 					JSONSchema:       entry.JSONSchema,
 					Recommended:      entry.Recommended,
 				})
+			case TypeProcessor:
+				processorsRepositories.Insert(repo, entry.Name, storeEntry{
+					Description:      entry.Description,
+					DocumentationURL: entry.DocumentationURL,
+					Version:          entry.Version,
+					URLs:             binURLs,
+					Dependencies:     depURLs,
+					JSONSchema:       entry.JSONSchema,
+					Recommended:      entry.Recommended,
+				})
 			}
 		}
 	}
@@ -128,7 +170,11 @@ func newStoreRepositories(indexes map[string][]byte) (storeRepository, storeRepo
 		sort.Sort(byIndexEntryVersion(sourcesRepositories[key]))
 	}
 
-	return executorsRepositories, sourcesRepositories, nil
+	for key := range processorsRepositories {
+		sort.Sort(byIndexEntryVersion(processorsRepositories[key]))
+	}
+
+	return executorsRepositories, sourcesRepositories, processorsRepositories, nil
 }
 
 func (s storeRepository) Insert(repo, name string, entry storeEntry) {
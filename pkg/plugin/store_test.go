@@ -109,13 +109,26 @@ func TestNewStoreRepository(t *testing.T) {
 		},
 	}
 
+	expectedProcessors := storeRepository{
+		"mszostok/owner-enricher": {
+			{
+				Description: "Processor suitable for e2e testing. It adds the resource owner to messages.",
+				Version:     "v1.0.0",
+				URLs: map[string]URL{
+					"linux/amd64": {URL: "https://github.com/mszostok/botkube-plugins/releases/download/v1.0.0/processor_owner-enricher-linux-amd64"},
+				},
+			},
+		},
+	}
+
 	// when
-	executors, sources, err := newStoreRepositories(repositories)
+	executors, sources, processors, err := newStoreRepositories(repositories)
 
 	// then
 	require.NoError(t, err)
 	assert.Equal(t, expectedExecutors, executors)
 	assert.Equal(t, expectedSources, sources)
+	assert.Equal(t, expectedProcessors, processors)
 }
 
 func loadTestdataFile(t *testing.T, name string) []byte {
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/api/wasm"
+)
+
+type echo struct{}
+
+func (echo) Metadata() (api.MetadataOutput, error) {
+	return api.MetadataOutput{
+		Version:     "v1.0.0",
+		Description: "Echoes commands.",
+	}, nil
+}
+
+func (echo) Execute(in wasm.ExecuteInput) (wasm.ExecuteOutput, error) {
+	args := strings.Fields(in.Command)
+	switch {
+	case len(args) > 1 && args[1] == "read":
+		data, err := os.ReadFile(args[2])
+		if err != nil {
+			return wasm.ExecuteOutput{}, err
+		}
+		return wasm.ExecuteOutput{Message: api.NewCodeBlockMessage(string(data), true)}, nil
+	case len(args) > 1 && args[1] == "env":
+		return wasm.ExecuteOutput{Message: api.NewCodeBlockMessage(strings.Join(os.Environ(), ","), true)}, nil
+	case len(args) > 2 && args[1] == "repeat":
+		count, err := strconv.Atoi(args[2])
+		if err != nil {
+			return wasm.ExecuteOutput{}, err
+		}
+		return wasm.ExecuteOutput{Message: api.NewCodeBlockMessage(strings.Repeat("x", count), true)}, nil
+	case len(args) > 1 && args[1] == "fail":
+		return wasm.ExecuteOutput{}, errors.New("command failed")
+	}
+	return wasm.ExecuteOutput{
+		Message: api.NewCodeBlockMessage(in.Context.Message.User.Mention+": "+in.Command, true),
+	}, nil
+}
+
+func (echo) Help() (api.Message, error) {
+	return api.NewPlaintextMessage("Usage: echo <text>", false), nil
+}
+
+func main() {
+	wasm.ServeExecutor(echo{})
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/api/wasm"
+)
+
+type ticker struct{}
+
+func (ticker) Metadata() (api.MetadataOutput, error) {
+	return api.MetadataOutput{
+		Version:     "v1.0.0",
+		Description: "Emits numbered events.",
+	}, nil
+}
+
+func (ticker) Stream(in wasm.StreamInput, emit func(wasm.Event) error) error {
+	for i := 1; i <= 3; i++ {
+		msg := api.NewPlaintextMessage(fmt.Sprintf("%s: event %d", in.Context.SourceName, i), false)
+		if err := emit(wasm.Event{Message: msg}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (ticker) HandleExternalRequest(in wasm.ExternalRequestInput) (wasm.ExternalRequestOutput, error) {
+	return wasm.ExternalRequestOutput{
+		Event: wasm.Event{Message: api.NewPlaintextMessage(string(in.Payload), false)},
+	}, nil
+}
+
+func main() {
+	wasm.ServeSource(ticker{})
+}
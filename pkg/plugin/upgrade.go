@@ -0,0 +1,353 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/api/executor"
+	"github.com/kubeshop/botkube/pkg/api/processor"
+	"github.com/kubeshop/botkube/pkg/api/source"
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+const (
+	// upgradeStreamOverlap is the time both old and new versions of a source plugin stream events, so no event is dropped
+	// while the new streams are being opened. Events emitted during that time may be duplicated.
+	upgradeStreamOverlap = 5 * time.Second
+	// upgradeDrainTimeout is the maximum time to wait for in-flight calls to the old version of a plugin.
+	upgradeDrainTimeout      = 2 * time.Minute
+	upgradeDrainPollInterval = 100 * time.Millisecond
+)
+
+// ErrPluginUpToDate is returned when a plugin is already running the requested version.
+var ErrPluginUpToDate = errors.New("plugin is already running the requested version")
+
+// RunningPlugin holds details of a running plugin.
+type RunningPlugin struct {
+	// Key is the plugin key used in the configuration, e.g. `botkube/kubernetes`.
+	Key  string
+	Type Type
+	// Version is the running version.
+	Version string
+	// LatestVersion is the latest version available in the plugin repository index.
+	LatestVersion string
+}
+
+// UpgradeOutput holds the result of a plugin upgrade.
+type UpgradeOutput struct {
+	Key         string
+	Type        Type
+	FromVersion string
+	ToVersion   string
+}
+
+// RunningPlugins returns details of all running plugins, sorted by keys.
+func (m *Manager) RunningPlugins() []RunningPlugin {
+	m.upgradeMu.Lock()
+	defer m.upgradeMu.Unlock()
+
+	var out []RunningPlugin
+	out = appendRunningPlugins(out, TypeExecutor, m.executorsStore)
+	out = appendRunningPlugins(out, TypeSource, m.sourcesStore)
+	out = appendRunningPlugins(out, TypeProcessor, m.processorsStore)
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Key == out[j].Key {
+			return out[i].Type < out[j].Type
+		}
+		return out[i].Key < out[j].Key
+	})
+	return out
+}
+
+func appendRunningPlugins[T any](out []RunningPlugin, pluginType Type, s *store[T]) []RunningPlugin {
+	s.EnabledPlugins.RLock()
+	defer s.EnabledPlugins.RUnlock()
+
+	for key, p := range s.EnabledPlugins.data {
+		item := RunningPlugin{
+			Key:     key,
+			Type:    pluginType,
+			Version: p.Version,
+		}
+		if repo, name, _, err := config.DecomposePluginKey(key); err == nil {
+			if candidates, found := s.Repository.Get(repo, name); found && len(candidates) > 0 {
+				item.LatestVersion = candidates[0].Version
+			}
+		}
+		out = append(out, item)
+	}
+	return out
+}
+
+// Upgrade replaces a running plugin with a given version, or the latest one if the version is empty, without restarting Botkube.
+// The new version is downloaded and started first. Then, new calls and source streams are switched to it, and the old version
+// is stopped once its in-flight calls are processed. The plugin key may be specified without the version, e.g. `botkube/kubernetes`.
+//
+// The upgrade is kept until Botkube restarts. To make it permanent, the plugin version needs to be updated in the configuration.
+func (m *Manager) Upgrade(ctx context.Context, pluginKey, version string) (UpgradeOutput, error) {
+	if !m.isStarted.Load() {
+		return UpgradeOutput{}, ErrNotStartedPluginManager
+	}
+
+	m.upgradeMu.Lock()
+	defer m.upgradeMu.Unlock()
+
+	pluginType, key, err := m.resolveEnabledPlugin(pluginKey)
+	if err != nil {
+		return UpgradeOutput{}, err
+	}
+
+	// refresh indexes, so recently released versions are available
+	if err := m.loadRepositoriesMetadata(ctx, true); err != nil {
+		return UpgradeOutput{}, err
+	}
+
+	switch pluginType {
+	case TypeExecutor:
+		return upgradePlugin(ctx, m, m.executorsStore, TypeExecutor, key, version, m.executorSupervisorChan, nil)
+	case TypeSource:
+		return upgradePlugin(ctx, m, m.sourcesStore, TypeSource, key, version, m.sourceSupervisorChan, m.rescheduleStreams)
+	default:
+		return upgradePlugin(ctx, m, m.processorsStore, TypeProcessor, key, version, m.processorSupervisorChan, nil)
+	}
+}
+
+// resolveEnabledPlugin returns the type and the configured key of an enabled plugin.
+func (m *Manager) resolveEnabledPlugin(pluginKey string) (Type, string, error) {
+	repo, name, _, err := config.DecomposePluginKey(pluginKey)
+	if err != nil {
+		return "", "", err
+	}
+
+	for _, enabled := range []struct {
+		pluginType Type
+		keys       []string
+	}{
+		{pluginType: TypeExecutor, keys: m.executorsToEnable},
+		{pluginType: TypeSource, keys: m.sourcesToEnable},
+		{pluginType: TypeProcessor, keys: m.processorsToEnable},
+	} {
+		for _, key := range enabled.keys {
+			enabledRepo, enabledName, _, err := config.DecomposePluginKey(key)
+			if err == nil && enabledRepo == repo && enabledName == name {
+				return enabled.pluginType, key, nil
+			}
+		}
+	}
+	return "", "", NewNotFoundPluginError("plugin %q is not enabled", pluginKey)
+}
+
+// rescheduleStreams opens source streams with the new version of a given plugin, and keeps the old streams open
+// for the overlap period, so that no events are dropped in the meantime.
+func (m *Manager) rescheduleStreams(ctx context.Context, key string) {
+	select {
+	case m.schedulerChan <- key:
+	case <-ctx.Done():
+		return
+	}
+
+	select {
+	case <-time.After(upgradeStreamOverlap):
+	case <-ctx.Done():
+	}
+}
+
+func upgradePlugin[T any](ctx context.Context, m *Manager, s *store[T], pluginType Type, key, version string, supervisorChan chan pluginMetadata, switchOver func(ctx context.Context, key string)) (UpgradeOutput, error) {
+	current, found := s.EnabledPlugins.Get(key)
+	if !found {
+		return UpgradeOutput{}, NewNotFoundPluginError("%s plugin %q is not running", pluginType, key)
+	}
+
+	repoName, pluginName, _, err := config.DecomposePluginKey(key)
+	if err != nil {
+		return UpgradeOutput{}, err
+	}
+	candidates, _ := s.Repository.Get(repoName, pluginName)
+	info, found := findEntryVersion(candidates, version)
+	if !found {
+		return UpgradeOutput{}, NewNotFoundPluginError("version %q of %s plugin %q not found in %q repository", version, pluginType, pluginName, repoName)
+	}
+	if info.Version == current.Version {
+		return UpgradeOutput{}, fmt.Errorf("%w: %s", ErrPluginUpToDate, info.Version)
+	}
+
+	log := m.log.WithFields(logrus.Fields{
+		"plugin":      key,
+		"fromVersion": current.Version,
+		"toVersion":   info.Version,
+	})
+
+	binPath := filepath.Join(m.cfg.CacheDir, repoName, fmt.Sprintf("%s_%s_%s", pluginType, info.Version, pluginName))
+	if err := m.ensurePluginDownloaded(ctx, binPath, info, m.repoClients[repoName]); err != nil {
+		return UpgradeOutput{}, fmt.Errorf("while fetching plugin %q binary: %w", key, err)
+	}
+
+	log.Info("Starting new plugin version...")
+	pm := pluginMetadata{
+		binPath:   binPath,
+		pluginKey: key,
+		version:   info.Version,
+		resources: m.cfg.Resources.ForPlugin(key),
+		security:  m.cfg.Security,
+	}
+	if err := replacePlugin(m, s, pluginType, pm, supervisorChan, switchOver, log); err != nil {
+		return UpgradeOutput{}, err
+	}
+
+	return UpgradeOutput{
+		Key:         key,
+		Type:        pluginType,
+		FromVersion: current.Version,
+		ToVersion:   info.Version,
+	}, nil
+}
+
+// replacePlugin starts a given plugin, and switches calls of the running plugin with the same key to it.
+// The previous plugin process is stopped in the background, once its in-flight calls are processed.
+func replacePlugin[T any](m *Manager, s *store[T], pluginType Type, pm pluginMetadata, supervisorChan chan pluginMetadata, switchOver func(ctx context.Context, key string), log logrus.FieldLogger) error {
+	// the plugin runs until Botkube is stopped, so it doesn't use the request context
+	next, err := createPluginClient[T](m.ctx, m.log, m.logConfig, pm, pluginType, supervisorChan, m.healthCheckInterval)
+	if err != nil {
+		return err
+	}
+
+	old, _ := s.EnabledPlugins.Swap(pm.pluginKey, next)
+	log.Info("Switched to new plugin process. Draining old plugin process...")
+
+	go func() {
+		drainCtx, cancel := context.WithTimeout(m.ctx, upgradeDrainTimeout)
+		defer cancel()
+
+		if switchOver != nil {
+			switchOver(drainCtx, pm.pluginKey)
+		}
+		if err := waitForInFlight(drainCtx, old.inFlight); err != nil {
+			log.WithError(err).Warn("Not all in-flight calls were processed by old plugin process")
+		}
+		if old.Cleanup != nil {
+			old.Cleanup()
+		}
+		log.Info("Old plugin process stopped.")
+	}()
+	return nil
+}
+
+// findEntryVersion returns the entry with a given version, or the latest one if the version is empty.
+// Entries are sorted by version, so the first one is the latest.
+func findEntryVersion(candidates []storeEntry, version string) (storeEntry, bool) {
+	if len(candidates) == 0 {
+		return storeEntry{}, false
+	}
+	if version == "" {
+		return candidates[0], true
+	}
+	for _, entry := range candidates {
+		if entry.Version == version || entry.Version == "v"+version {
+			return entry, true
+		}
+	}
+	return storeEntry{}, false
+}
+
+func waitForInFlight(ctx context.Context, inFlight *atomic.Int64) error {
+	if inFlight == nil {
+		return nil
+	}
+
+	ticker := time.NewTicker(upgradeDrainPollInterval)
+	defer ticker.Stop()
+
+	for inFlight.Load() > 0 {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("while waiting for %d in-flight call(s): %w", inFlight.Load(), ctx.Err())
+		case <-ticker.C:
+		}
+	}
+	return nil
+}
+
+func track(inFlight *atomic.Int64) func() {
+	if inFlight == nil {
+		return func() {}
+	}
+	inFlight.Add(1)
+	return func() {
+		inFlight.Add(-1)
+	}
+}
+
+// trackedExecutor counts in-flight calls, so the executor isn't stopped while they are processed.
+type trackedExecutor struct {
+	executor.Executor
+	inFlight *atomic.Int64
+}
+
+func (e *trackedExecutor) Execute(ctx context.Context, in executor.ExecuteInput) (executor.ExecuteOutput, error) {
+	defer track(e.inFlight)()
+	return e.Executor.Execute(ctx, in)
+}
+
+func (e *trackedExecutor) Metadata(ctx context.Context) (api.MetadataOutput, error) {
+	defer track(e.inFlight)()
+	return e.Executor.Metadata(ctx)
+}
+
+func (e *trackedExecutor) Help(ctx context.Context) (api.Message, error) {
+	defer track(e.inFlight)()
+	return e.Executor.Help(ctx)
+}
+
+func (e *trackedExecutor) HandleInteraction(ctx context.Context, in executor.InteractionInput) (executor.InteractionOutput, error) {
+	handler, ok := e.Executor.(executor.InteractionHandler)
+	if !ok {
+		return executor.InteractionOutput{}, status.Errorf(codes.Unimplemented, "method HandleInteraction not implemented")
+	}
+
+	defer track(e.inFlight)()
+	return handler.HandleInteraction(ctx, in)
+}
+
+// trackedSource counts in-flight calls, so the source isn't stopped while they are processed.
+// Streams are not counted, as they are open until the plugin is stopped.
+type trackedSource struct {
+	source.Source
+	inFlight *atomic.Int64
+}
+
+func (s *trackedSource) HandleExternalRequest(ctx context.Context, in source.ExternalRequestInput) (source.ExternalRequestOutput, error) {
+	defer track(s.inFlight)()
+	return s.Source.HandleExternalRequest(ctx, in)
+}
+
+func (s *trackedSource) Metadata(ctx context.Context) (api.MetadataOutput, error) {
+	defer track(s.inFlight)()
+	return s.Source.Metadata(ctx)
+}
+
+// trackedProcessor counts in-flight calls, so the processor isn't stopped while they are processed.
+type trackedProcessor struct {
+	processor.Processor
+	inFlight *atomic.Int64
+}
+
+func (p *trackedProcessor) Process(ctx context.Context, in processor.ProcessInput) (processor.ProcessOutput, error) {
+	defer track(p.inFlight)()
+	return p.Processor.Process(ctx, in)
+}
+
+func (p *trackedProcessor) Metadata(ctx context.Context) (api.MetadataOutput, error) {
+	defer track(p.inFlight)()
+	return p.Processor.Metadata(ctx)
+}
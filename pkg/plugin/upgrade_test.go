@@ -0,0 +1,201 @@
+package plugin
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/kubeshop/botkube/pkg/api/executor"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+func TestManagerUpgrade(t *testing.T) {
+	// given
+	binPath := buildWASMModule(t, "executor")
+	bin, err := os.ReadFile(binPath)
+	require.NoError(t, err)
+
+	var index Index
+	for _, ver := range []string{"v1.1.0", "v1.0.0"} {
+		index.Entries = append(index.Entries, IndexEntry{
+			Name:    "echo",
+			Type:    TypeExecutor,
+			Version: ver,
+			URLs: []IndexURL{
+				{URL: "/echo.wasm?version=" + ver, Platform: IndexURLPlatform{OS: "wasip1", Arch: "wasm"}},
+			},
+		})
+	}
+	srv := newFakeRepositoryServer(t, index, bin)
+
+	manager := NewManager(loggerx.NewNoop(), config.Logger{}, config.PluginManagement{
+		CacheDir: t.TempDir(),
+		Repositories: map[string]config.PluginsRepository{
+			"botkube": {URL: srv.URL + "/index.yaml"},
+		},
+	}, []string{"botkube/echo@v1.0.0"}, nil, nil, make(chan string), NewHealthStats(1))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, manager.Start(ctx))
+	defer manager.Shutdown()
+
+	// when
+	out, err := manager.Upgrade(ctx, "botkube/echo", "")
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, UpgradeOutput{
+		Key:         "botkube/echo@v1.0.0",
+		Type:        TypeExecutor,
+		FromVersion: "v1.0.0",
+		ToVersion:   "v1.1.0",
+	}, out)
+	assert.Equal(t, []RunningPlugin{
+		{Key: "botkube/echo@v1.0.0", Type: TypeExecutor, Version: "v1.1.0", LatestVersion: "v1.1.0"},
+	}, manager.RunningPlugins())
+
+	cli, err := manager.GetExecutor("botkube/echo@v1.0.0")
+	require.NoError(t, err)
+	res, err := cli.Execute(ctx, executor.ExecuteInput{Command: "echo hello"})
+	require.NoError(t, err)
+	assert.Equal(t, ": echo hello", res.Message.BaseBody.CodeBlock)
+
+	// when
+	_, err = manager.Upgrade(ctx, "botkube/echo", "v1.1.0")
+
+	// then
+	assert.ErrorIs(t, err, ErrPluginUpToDate)
+
+	// when
+	_, err = manager.Upgrade(ctx, "botkube/echo", "v2.0.0")
+
+	// then
+	assert.True(t, IsNotFoundError(err))
+
+	// when
+	_, err = manager.Upgrade(ctx, "botkube/helm", "")
+
+	// then
+	assert.EqualError(t, err, `plugin "botkube/helm" is not enabled`)
+}
+
+func TestStorePluginsSwap(t *testing.T) {
+	// given
+	plugins := storePlugins[string]{data: map[string]enabledPlugins[string]{}}
+	plugins.Insert("botkube/echo", enabledPlugins[string]{Client: "old", Version: "v1.0.0"})
+
+	// when
+	old, found := plugins.Swap("botkube/echo", enabledPlugins[string]{Client: "new", Version: "v1.1.0"})
+
+	// then
+	require.True(t, found)
+	assert.Equal(t, "old", old.Client)
+	assert.NotNil(t, old.inFlight)
+
+	current, found := plugins.Get("botkube/echo")
+	require.True(t, found)
+	assert.Equal(t, "new", current.Client)
+	assert.NotSame(t, old.inFlight, current.inFlight)
+}
+
+func TestTrackedExecutor(t *testing.T) {
+	// given
+	inFlight := &atomic.Int64{}
+	release := make(chan struct{})
+	cli := &trackedExecutor{
+		Executor: &blockingExecutor{release: release},
+		inFlight: inFlight,
+	}
+
+	// when
+	go func() {
+		_, _ = cli.Execute(context.Background(), executor.ExecuteInput{})
+	}()
+
+	// then
+	require.Eventually(t, func() bool { return inFlight.Load() == 1 }, time.Second, 10*time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*upgradeDrainPollInterval)
+	defer cancel()
+	assert.ErrorIs(t, waitForInFlight(ctx, inFlight), context.DeadlineExceeded)
+
+	// when
+	close(release)
+
+	// then
+	assert.NoError(t, waitForInFlight(context.Background(), inFlight))
+	assert.Zero(t, inFlight.Load())
+}
+
+func TestFindEntryVersion(t *testing.T) {
+	candidates := []storeEntry{{Version: "v1.1.0"}, {Version: "v1.0.0"}}
+
+	tests := []struct {
+		name            string
+		version         string
+		expectedVersion string
+		expectedFound   bool
+	}{
+		{name: "latest", version: "", expectedVersion: "v1.1.0", expectedFound: true},
+		{name: "exact", version: "v1.0.0", expectedVersion: "v1.0.0", expectedFound: true},
+		{name: "without prefix", version: "1.0.0", expectedVersion: "v1.0.0", expectedFound: true},
+		{name: "unknown", version: "v2.0.0", expectedFound: false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			entry, found := findEntryVersion(candidates, tc.version)
+
+			// then
+			assert.Equal(t, tc.expectedFound, found)
+			assert.Equal(t, tc.expectedVersion, entry.Version)
+		})
+	}
+}
+
+type blockingExecutor struct {
+	executor.Executor
+	release chan struct{}
+}
+
+func (e *blockingExecutor) Execute(context.Context, executor.ExecuteInput) (executor.ExecuteOutput, error) {
+	<-e.release
+	return executor.ExecuteOutput{}, nil
+}
+
+// newFakeRepositoryServer serves a given index and the same binary for all URLs with the relative path.
+func newFakeRepositoryServer(t *testing.T, index Index, bin []byte) *httptest.Server {
+	t.Helper()
+
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/index.yaml" {
+			_, _ = w.Write(bin)
+			return
+		}
+
+		out := Index{}
+		for _, entry := range index.Entries {
+			urls := make([]IndexURL, 0, len(entry.URLs))
+			for _, u := range entry.URLs {
+				u.URL = srv.URL + u.URL
+				urls = append(urls, u)
+			}
+			entry.URLs = urls
+			out.Entries = append(out.Entries, entry)
+		}
+		require.NoError(t, yaml.NewEncoder(w).Encode(out))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
@@ -0,0 +1,374 @@
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+	"github.com/tetratelabs/wazero/sys"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/api/executor"
+	"github.com/kubeshop/botkube/pkg/api/source"
+	"github.com/kubeshop/botkube/pkg/api/wasm"
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+const (
+	// wasmPlatformSelector is the platform of WebAssembly modules in the plugin index. Such modules run on all platforms.
+	wasmPlatformSelector = "wasip1/wasm"
+
+	// wasmMemoryLimitPages limits the memory of a single module instance to 256 MiB by default, as each page has 64 KiB.
+	wasmMemoryLimitPages = 4096
+	wasmPageSize         = 64 * 1024
+	// wasmMaxMemoryPages is the maximum memory of 32-bit modules, which is 4 GiB.
+	wasmMaxMemoryPages = 65536
+	// wasmMaxErrorLength limits the size of the standard error used as the error message.
+	wasmMaxErrorLength = 1024
+	// wasmMaxOutputLength limits the size of the method output, the same as the default gRPC message size limits outputs of other plugins.
+	wasmMaxOutputLength = 4 * 1024 * 1024
+)
+
+var wasmMagic = []byte("\x00asm")
+
+// IsWASMModule returns true if a given file is a WebAssembly module.
+func IsWASMModule(path string) bool {
+	file, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+
+	header := make([]byte, len(wasmMagic))
+	if _, err := io.ReadFull(file, header); err != nil {
+		return false
+	}
+	return bytes.Equal(header, wasmMagic)
+}
+
+// wasmModule runs a WebAssembly plugin. Each call instantiates the module in a sandbox, which has no access to the filesystem,
+// network, environment variables and host processes.
+type wasmModule struct {
+	runtime  wazero.Runtime
+	compiled wazero.CompiledModule
+	name     string
+	stderr   io.Writer
+}
+
+func newWASMModule(ctx context.Context, binPath, name string, memoryLimitPages uint32, stderr io.Writer) (*wasmModule, error) {
+	bin, err := os.ReadFile(filepath.Clean(binPath))
+	if err != nil {
+		return nil, fmt.Errorf("while reading module: %w", err)
+	}
+
+	rt := wazero.NewRuntimeWithConfig(ctx, wazero.NewRuntimeConfig().
+		WithMemoryLimitPages(memoryLimitPages).
+		WithCloseOnContextDone(true))
+	if _, err := wasi_snapshot_preview1.Instantiate(ctx, rt); err != nil {
+		_ = rt.Close(ctx)
+		return nil, fmt.Errorf("while instantiating WASI: %w", err)
+	}
+	compiled, err := rt.CompileModule(ctx, bin)
+	if err != nil {
+		_ = rt.Close(ctx)
+		return nil, fmt.Errorf("while compiling module: %w", err)
+	}
+
+	return &wasmModule{
+		runtime:  rt,
+		compiled: compiled,
+		name:     name,
+		stderr:   stderr,
+	}, nil
+}
+
+// call runs a given method and decodes its JSON output.
+func (m *wasmModule) call(ctx context.Context, method wasm.Method, in, out any) error {
+	stdout := &limitedBuffer{limit: wasmMaxOutputLength}
+	err := m.run(ctx, method, in, stdout)
+	// the module may fail or ignore the failed write, so the limit is checked first
+	if stdout.exceeded {
+		return fmt.Errorf("while running %s: output exceeds the limit of %d bytes", method, stdout.limit)
+	}
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(stdout.Bytes(), out); err != nil {
+		return fmt.Errorf("while decoding %s output: %w", method, err)
+	}
+	return nil
+}
+
+// run runs a given method, writing its JSON-encoded input to the standard input of the module.
+func (m *wasmModule) run(ctx context.Context, method wasm.Method, in any, stdout io.Writer) error {
+	var stdin []byte
+	if in != nil {
+		var err error
+		stdin, err = json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("while encoding %s input: %w", method, err)
+		}
+	}
+
+	stderr := &cappedBuffer{limit: wasmMaxErrorLength}
+	cfg := wazero.NewModuleConfig().
+		// empty name allows running multiple instances at the same time
+		WithName("").
+		WithArgs(m.name, string(method)).
+		WithStdin(bytes.NewReader(stdin)).
+		WithStdout(stdout).
+		WithStderr(io.MultiWriter(m.stderr, stderr)).
+		WithSysWalltime().
+		WithSysNanotime().
+		WithSysNanosleep().
+		WithRandSource(rand.Reader)
+
+	mod, err := m.runtime.InstantiateModule(ctx, m.compiled, cfg)
+	if mod != nil {
+		_ = mod.Close(ctx)
+	}
+	if err == nil {
+		return nil
+	}
+
+	var exitErr *sys.ExitError
+	if errors.As(err, &exitErr) && ctx.Err() == nil && stderr.Len() > 0 {
+		return errors.New(strings.TrimSpace(stderr.String()))
+	}
+	return fmt.Errorf("while running %s: %w", method, err)
+}
+
+// Close releases all resources of the module.
+func (m *wasmModule) Close() {
+	_ = m.runtime.Close(context.Background())
+}
+
+// cappedBuffer stores up to limit bytes and discards the rest.
+type cappedBuffer struct {
+	bytes.Buffer
+	limit int
+}
+
+func (b *cappedBuffer) Write(p []byte) (int, error) {
+	if rest := b.limit - b.Len(); rest > 0 {
+		b.Buffer.Write(p[:min(len(p), rest)])
+	}
+	return len(p), nil
+}
+
+// limitedBuffer stores up to limit bytes and fails writes above it.
+type limitedBuffer struct {
+	bytes.Buffer
+	limit    int
+	exceeded bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if b.Len()+len(p) > b.limit {
+		b.exceeded = true
+		return 0, errors.New("output limit exceeded")
+	}
+	return b.Buffer.Write(p)
+}
+
+// createWASMClient returns a client for a given WebAssembly plugin. Only executor and source plugins are supported.
+func createWASMClient[C any](ctx context.Context, logger logrus.FieldLogger, logConfig config.Logger, pm pluginMetadata, pluginType Type, _ chan pluginMetadata, _ time.Duration) (enabledPlugins[C], error) {
+	if pluginType != TypeExecutor && pluginType != TypeSource {
+		return enabledPlugins[C]{}, fmt.Errorf("%s plugins are not supported as WebAssembly modules", pluginType)
+	}
+
+	limits, err := parseResourceLimits(pm.resources)
+	if err != nil {
+		return enabledPlugins[C]{}, fmt.Errorf("while configuring resource limits: %w", err)
+	}
+	memoryLimitPages := uint32(wasmMemoryLimitPages)
+	if limits.memoryBytes > 0 {
+		// modules are sandboxed, so only the memory limit applies to them
+		memoryLimitPages = uint32(min(max(limits.memoryBytes/wasmPageSize, 1), wasmMaxMemoryPages))
+	}
+
+	_, _, stderrLogger := NewPluginLoggers(logger, logConfig, pm.pluginKey, pluginType)
+	mod, err := newWASMModule(ctx, pm.binPath, pm.pluginKey, memoryLimitPages, stderrLogger)
+	if err != nil {
+		return enabledPlugins[C]{}, err
+	}
+
+	var raw any = &wasmExecutor{mod: mod}
+	if pluginType == TypeSource {
+		raw = &wasmSource{mod: mod, log: logger.WithField("plugin", pm.pluginKey)}
+	}
+
+	concreteCli, ok := raw.(C)
+	if !ok {
+		mod.Close()
+		return enabledPlugins[C]{}, fmt.Errorf("registered client doesn't implement required %s interface", pluginType.String())
+	}
+
+	return enabledPlugins[C]{
+		Client:   concreteCli,
+		Cleanup:  mod.Close,
+		Version:  pm.version,
+		metadata: pm,
+	}, nil
+}
+
+var _ executor.Executor = &wasmExecutor{}
+
+// wasmExecutor runs executor plugins distributed as WebAssembly modules.
+type wasmExecutor struct {
+	mod *wasmModule
+}
+
+func (e *wasmExecutor) Execute(ctx context.Context, in executor.ExecuteInput) (executor.ExecuteOutput, error) {
+	input := wasm.ExecuteInput{
+		Command: in.Command,
+		Configs: toWASMConfigs(in.Configs),
+		Context: wasm.ExecuteInputContext{
+			IsInteractivitySupported: in.Context.IsInteractivitySupported,
+			Message: wasm.Message{
+				Text: in.Context.Message.Text,
+				URL:  in.Context.Message.URL,
+				User: wasm.User{
+					Mention:     in.Context.Message.User.Mention,
+					DisplayName: in.Context.Message.User.DisplayName,
+				},
+				ParentActivityID: in.Context.Message.ParentActivityID,
+			},
+		},
+	}
+
+	var out wasm.ExecuteOutput
+	if err := e.mod.call(ctx, wasm.MethodExecute, input, &out); err != nil {
+		return executor.ExecuteOutput{}, err
+	}
+	return executor.ExecuteOutput{
+		Message:  out.Message,
+		Messages: out.Messages,
+	}, nil
+}
+
+func (e *wasmExecutor) Metadata(ctx context.Context) (api.MetadataOutput, error) {
+	var out api.MetadataOutput
+	err := e.mod.call(ctx, wasm.MethodMetadata, nil, &out)
+	return out, err
+}
+
+func (e *wasmExecutor) Help(ctx context.Context) (api.Message, error) {
+	var out api.Message
+	err := e.mod.call(ctx, wasm.MethodHelp, nil, &out)
+	return out, err
+}
+
+var _ source.Source = &wasmSource{}
+
+// wasmSource runs source plugins distributed as WebAssembly modules.
+type wasmSource struct {
+	mod *wasmModule
+	log logrus.FieldLogger
+}
+
+func (s *wasmSource) Stream(ctx context.Context, in source.StreamInput) (source.StreamOutput, error) {
+	input := wasm.StreamInput{
+		Configs: toWASMConfigs(in.Configs),
+		Context: toWASMSourceContext(in.Context.CommonSourceContext),
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		_ = pw.CloseWithError(s.mod.run(ctx, wasm.MethodStream, input, pw))
+	}()
+
+	out := source.StreamOutput{
+		Event: make(chan source.Event),
+	}
+	go func() {
+		defer close(out.Event)
+		dec := json.NewDecoder(pr)
+		for {
+			var event wasm.Event
+			err := dec.Decode(&event)
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				s.log.Errorf("canceling streaming: %s", err.Error())
+				// unblock the module if it's still writing events
+				_ = pr.CloseWithError(err)
+				return
+			}
+
+			select {
+			case out.Event <- toSourceEvent(event):
+			case <-ctx.Done():
+				_ = pr.CloseWithError(ctx.Err())
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+func (s *wasmSource) HandleExternalRequest(ctx context.Context, in source.ExternalRequestInput) (source.ExternalRequestOutput, error) {
+	input := wasm.ExternalRequestInput{
+		Payload: in.Payload,
+		Config:  wasm.Config{RawYAML: in.Config.GetRawYAML()},
+		Context: toWASMSourceContext(in.Context.CommonSourceContext),
+		HTTP: wasm.HTTPRequest{
+			Route:  in.Context.HTTP.Route,
+			Method: in.Context.HTTP.Method,
+			Header: in.Context.HTTP.Header,
+			Query:  in.Context.HTTP.Query.Encode(),
+		},
+	}
+
+	var out wasm.ExternalRequestOutput
+	if err := s.mod.call(ctx, wasm.MethodHandleExternalRequest, input, &out); err != nil {
+		return source.ExternalRequestOutput{}, err
+	}
+	return source.ExternalRequestOutput{
+		Event: toSourceEvent(out.Event),
+	}, nil
+}
+
+func (s *wasmSource) Metadata(ctx context.Context) (api.MetadataOutput, error) {
+	var out api.MetadataOutput
+	err := s.mod.call(ctx, wasm.MethodMetadata, nil, &out)
+	return out, err
+}
+
+func toWASMConfigs[T interface{ GetRawYAML() []byte }](in []T) []wasm.Config {
+	out := make([]wasm.Config, 0, len(in))
+	for _, cfg := range in {
+		out = append(out, wasm.Config{RawYAML: cfg.GetRawYAML()})
+	}
+	return out
+}
+
+func toWASMSourceContext(in source.CommonSourceContext) wasm.SourceContext {
+	return wasm.SourceContext{
+		IsInteractivitySupported: in.IsInteractivitySupported,
+		ClusterName:              in.ClusterName,
+		SourceName:               in.SourceName,
+	}
+}
+
+func toSourceEvent(in wasm.Event) source.Event {
+	return source.Event{
+		Message:         in.Message,
+		RawObject:       in.RawObject,
+		AnalyticsLabels: in.AnalyticsLabels,
+		Channels:        in.Channels,
+	}
+}
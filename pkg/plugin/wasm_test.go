@@ -0,0 +1,160 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/api/executor"
+	"github.com/kubeshop/botkube/pkg/api/source"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+func TestWASMExecutor(t *testing.T) {
+	// given
+	hostFile := filepath.Join(t.TempDir(), "secret")
+	require.NoError(t, os.WriteFile(hostFile, []byte("secret"), 0o600))
+	t.Setenv("BOTKUBE_TEST_SECRET", "secret")
+
+	binPath := buildWASMModule(t, "executor")
+	require.True(t, IsWASMModule(binPath))
+
+	cli, err := createWASMClient[executor.Executor](context.Background(), loggerx.NewNoop(), config.Logger{}, pluginMetadata{
+		binPath:   binPath,
+		pluginKey: "botkube/echo",
+	}, TypeExecutor, nil, 0)
+	require.NoError(t, err)
+	defer cli.Cleanup()
+
+	t.Run("metadata", func(t *testing.T) {
+		// when
+		meta, err := cli.Client.Metadata(context.Background())
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "v1.0.0", meta.Version)
+	})
+
+	t.Run("execute", func(t *testing.T) {
+		// when
+		out, err := cli.Client.Execute(context.Background(), executor.ExecuteInput{
+			Command: "echo hello",
+			Context: executor.ExecuteInputContext{
+				Message: executor.Message{User: executor.User{Mention: "@Joe"}},
+			},
+		})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "@Joe: echo hello", out.Message.BaseBody.CodeBlock)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		// when
+		_, err := cli.Client.Execute(context.Background(), executor.ExecuteInput{Command: "echo fail"})
+
+		// then
+		assert.EqualError(t, err, "command failed")
+	})
+
+	t.Run("output within limit", func(t *testing.T) {
+		// when
+		out, err := cli.Client.Execute(context.Background(), executor.ExecuteInput{Command: "echo repeat 1024"})
+
+		// then
+		require.NoError(t, err)
+		assert.Len(t, out.Message.BaseBody.CodeBlock, 1024)
+	})
+
+	t.Run("output exceeds limit", func(t *testing.T) {
+		// when
+		_, err := cli.Client.Execute(context.Background(), executor.ExecuteInput{Command: fmt.Sprintf("echo repeat %d", wasmMaxOutputLength)})
+
+		// then
+		assert.EqualError(t, err, fmt.Sprintf("while running execute: output exceeds the limit of %d bytes", wasmMaxOutputLength))
+	})
+
+	t.Run("no filesystem access", func(t *testing.T) {
+		// when
+		_, err := cli.Client.Execute(context.Background(), executor.ExecuteInput{Command: "echo read " + hostFile})
+
+		// then
+		assert.Error(t, err)
+	})
+
+	t.Run("no environment variables", func(t *testing.T) {
+		// when
+		out, err := cli.Client.Execute(context.Background(), executor.ExecuteInput{Command: "echo env"})
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, out.Message.BaseBody.CodeBlock)
+	})
+}
+
+func TestWASMSource(t *testing.T) {
+	// given
+	binPath := buildWASMModule(t, "source")
+	cli, err := createWASMClient[source.Source](context.Background(), loggerx.NewNoop(), config.Logger{}, pluginMetadata{
+		binPath:   binPath,
+		pluginKey: "botkube/ticker",
+	}, TypeSource, nil, 0)
+	require.NoError(t, err)
+	defer cli.Cleanup()
+
+	// when
+	out, err := cli.Client.Stream(context.Background(), source.StreamInput{
+		Context: source.StreamInputContext{
+			CommonSourceContext: source.CommonSourceContext{SourceName: "ticker"},
+		},
+	})
+	require.NoError(t, err)
+
+	var got []string
+	for event := range out.Event {
+		got = append(got, event.Message.BaseBody.Plaintext)
+	}
+
+	// then
+	assert.Equal(t, []string{"ticker: event 1", "ticker: event 2", "ticker: event 3"}, got)
+
+	// when
+	res, err := cli.Client.HandleExternalRequest(context.Background(), source.ExternalRequestInput{Payload: []byte("ping")})
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "ping", res.Event.Message.BaseBody.Plaintext)
+}
+
+func TestCreateWASMClientUnsupportedType(t *testing.T) {
+	// when
+	_, err := createWASMClient[metadataGetter](context.Background(), loggerx.NewNoop(), config.Logger{}, pluginMetadata{
+		binPath:   "processor.wasm",
+		pluginKey: "botkube/echo",
+	}, TypeProcessor, nil, 0)
+
+	// then
+	assert.EqualError(t, err, "processor plugins are not supported as WebAssembly modules")
+}
+
+// buildWASMModule builds a given plugin from the testdata directory as a WebAssembly module.
+func buildWASMModule(t *testing.T, name string) string {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("Skipping building WebAssembly modules in short mode")
+	}
+
+	out := filepath.Join(t.TempDir(), name+".wasm")
+	cmd := exec.Command("go", "build", "-o", out, "./testdata/wasm/"+name)
+	cmd.Env = append(os.Environ(), "GOOS=wasip1", "GOARCH=wasm")
+	output, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(output))
+	return out
+}
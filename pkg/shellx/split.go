@@ -0,0 +1,156 @@
+package shellx
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// promptPrefix is the shell prompt often copied together with commands from terminal.
+const promptPrefix = "$ "
+
+// ParseError describes why a given command cannot be split into arguments.
+type ParseError struct {
+	// Input is the command that was parsed.
+	Input string
+	// Pos is the byte offset in Input where the problem was detected.
+	Pos int
+	// Reason describes the problem.
+	Reason string
+}
+
+// Error returns the error message together with the problematic line and a caret pointing to the detected position.
+func (e *ParseError) Error() string {
+	lineStart := strings.LastIndex(e.Input[:e.Pos], "\n") + 1
+	lineEnd := strings.Index(e.Input[e.Pos:], "\n")
+	if lineEnd == -1 {
+		lineEnd = len(e.Input)
+	} else {
+		lineEnd += e.Pos
+	}
+
+	line := e.Input[lineStart:lineEnd]
+	lineNo := strings.Count(e.Input[:lineStart], "\n") + 1
+	col := utf8.RuneCountInString(e.Input[lineStart:e.Pos]) + 1
+
+	return fmt.Sprintf("%s (line %d, column %d)\n%s\n%s^", e.Reason, lineNo, col, line, strings.Repeat(" ", col-1))
+}
+
+// Split splits a given command into arguments using the POSIX shell quoting rules:
+//   - whitespaces, including new lines, separate arguments,
+//   - single quotes preserve the literal value of all characters,
+//   - double quotes preserve the literal value of all characters except '\' which escapes '"', '\', '$', '`' and a new line,
+//   - unquoted '\' escapes the next character, and '\' followed by a new line joins lines,
+//   - the "$ " prompt at the beginning of a line is ignored, so commands can be pasted directly from a terminal.
+//
+// Unlike a shell, it doesn't expand variables, execute subcommands, or interpret operators such as '|' or ';'.
+func Split(cmd string) ([]string, error) {
+	var (
+		args      []string
+		buf       strings.Builder
+		inArg     bool
+		lineStart = true
+	)
+
+	flush := func() {
+		if !inArg {
+			return
+		}
+		args = append(args, buf.String())
+		buf.Reset()
+		inArg = false
+	}
+
+	for i := 0; i < len(cmd); i++ {
+		c := cmd[i]
+
+		if lineStart && !inArg && strings.HasPrefix(cmd[i:], promptPrefix) {
+			i += len(promptPrefix) - 1
+			lineStart = false
+			continue
+		}
+
+		switch c {
+		case ' ', '\t', '\r', '\n':
+			flush()
+			lineStart = lineStart || c == '\n'
+			continue
+		}
+		lineStart = false
+
+		switch c {
+		case '\\':
+			if i+1 == len(cmd) {
+				return nil, &ParseError{Input: cmd, Pos: i, Reason: "trailing backslash, nothing to escape"}
+			}
+			if next, size := continuation(cmd, i+1); size > 0 {
+				i = next - 1
+				continue
+			}
+			inArg = true
+			r, size := utf8.DecodeRuneInString(cmd[i+1:])
+			buf.WriteRune(r)
+			i += size
+		case '\'':
+			end := strings.IndexByte(cmd[i+1:], '\'')
+			if end == -1 {
+				return nil, &ParseError{Input: cmd, Pos: i, Reason: "unterminated single-quoted string"}
+			}
+			inArg = true
+			buf.WriteString(cmd[i+1 : i+1+end])
+			i += end + 1
+		case '"':
+			next, err := readDoubleQuoted(cmd, i, &buf)
+			if err != nil {
+				return nil, err
+			}
+			inArg = true
+			i = next
+		default:
+			inArg = true
+			buf.WriteByte(c)
+		}
+	}
+	flush()
+
+	return args, nil
+}
+
+// readDoubleQuoted writes the content of a double-quoted string starting at a given position and returns the position of the closing quote.
+func readDoubleQuoted(cmd string, start int, buf *strings.Builder) (int, error) {
+	for i := start + 1; i < len(cmd); i++ {
+		switch cmd[i] {
+		case '"':
+			return i, nil
+		case '\\':
+			if i+1 == len(cmd) {
+				break
+			}
+			if next, size := continuation(cmd, i+1); size > 0 {
+				i = next - 1
+				continue
+			}
+			switch cmd[i+1] {
+			case '"', '\\', '$', '`':
+				buf.WriteByte(cmd[i+1])
+				i++
+				continue
+			}
+			buf.WriteByte('\\')
+		default:
+			buf.WriteByte(cmd[i])
+		}
+	}
+	return 0, &ParseError{Input: cmd, Pos: start, Reason: "unterminated double-quoted string"}
+}
+
+// continuation checks if there is a new line at a given position. If so, returns the position after it and its size.
+func continuation(cmd string, pos int) (int, int) {
+	switch {
+	case strings.HasPrefix(cmd[pos:], "\r\n"):
+		return pos + 2, 2
+	case strings.HasPrefix(cmd[pos:], "\n"):
+		return pos + 1, 1
+	}
+	return pos, 0
+}
@@ -0,0 +1,125 @@
+package shellx
+
+import (
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{
+			name:     "simple",
+			input:    "kubectl get  pods\t-A",
+			expected: []string{"kubectl", "get", "pods", "-A"},
+		},
+		{
+			name:     "jsonpath in single quotes",
+			input:    `kubectl get pods -o jsonpath='{range .items[*]}{.metadata.name}{"\n"}{end}'`,
+			expected: []string{"kubectl", "get", "pods", "-o", `jsonpath={range .items[*]}{.metadata.name}{"\n"}{end}`},
+		},
+		{
+			name:     "double quotes with escapes",
+			input:    `echo "say \"hi\" to \$USER" "a\b"`,
+			expected: []string{"echo", `say "hi" to $USER`, `a\b`},
+		},
+		{
+			name:     "escaped characters outside quotes",
+			input:    `kubectl get pods -l app\ name=foo\'s`,
+			expected: []string{"kubectl", "get", "pods", "-l", "app name=foo's"},
+		},
+		{
+			name:     "empty quoted argument",
+			input:    `helm install foo --set name='' ""`,
+			expected: []string{"helm", "install", "foo", "--set", "name=", ""},
+		},
+		{
+			name:     "adjacent quoted parts",
+			input:    `--filter="foo bar"'baz'`,
+			expected: []string{"--filter=foo barbaz"},
+		},
+		{
+			name:     "operators are not interpreted",
+			input:    `kubectl get pods | grep (foo); echo`,
+			expected: []string{"kubectl", "get", "pods", "|", "grep", "(foo);", "echo"},
+		},
+		{
+			name: "multi-line command pasted from terminal",
+			input: heredoc.Doc(`
+				$ kubectl get pods \
+				    -n kube-system \
+				    -o wide`),
+			expected: []string{"kubectl", "get", "pods", "-n", "kube-system", "-o", "wide"},
+		},
+		{
+			name:     "line continuation with CRLF and in double quotes",
+			input:    "kubectl get \\\r\npods \"-o\\\nwide\"",
+			expected: []string{"kubectl", "get", "pods", "-owide"},
+		},
+		{
+			name:     "dollar not treated as prompt inside line",
+			input:    "echo $ foo",
+			expected: []string{"echo", "$", "foo"},
+		},
+		{
+			name:     "empty",
+			input:    "   ",
+			expected: nil,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Split(tc.input)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+func TestSplitErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		expErr string
+	}{
+		{
+			name:  "unterminated single quote",
+			input: "kubectl get pods -o jsonpath='{.items",
+			expErr: heredoc.Doc(`
+				unterminated single-quoted string (line 1, column 30)
+				kubectl get pods -o jsonpath='{.items
+				                             ^`),
+		},
+		{
+			name:  "unterminated double quote in second line",
+			input: "kubectl get pods \\\n  --filter=\"foo",
+			expErr: heredoc.Doc(`
+				unterminated double-quoted string (line 2, column 12)
+				  --filter="foo
+				           ^`),
+		},
+		{
+			name:  "trailing backslash",
+			input: `kubectl get pods \`,
+			expErr: heredoc.Doc(`
+				trailing backslash, nothing to escape (line 1, column 18)
+				kubectl get pods \
+				                 ^`),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Split(tc.input)
+
+			var parseErr *ParseError
+			require.ErrorAs(t, err, &parseErr)
+			assert.EqualError(t, err, tc.expErr)
+		})
+	}
+}
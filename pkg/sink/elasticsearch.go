@@ -2,13 +2,13 @@ package sink
 
 import (
 	"context"
-	"crypto/tls"
 	"errors"
 	"fmt"
 	"net/http"
 	"os"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws/credentials"
@@ -25,6 +25,7 @@ import (
 	"github.com/kubeshop/botkube/pkg/config"
 	"github.com/kubeshop/botkube/pkg/multierror"
 	"github.com/kubeshop/botkube/pkg/sliceutil"
+	"github.com/kubeshop/botkube/pkg/tlsx"
 )
 
 var _ Sink = &Elasticsearch{}
@@ -43,16 +44,21 @@ const (
 	elasticErrorReasonResourceAlreadyExists = "resource_already_exists_exception"
 )
 
-// Elasticsearch provides integration with the Elasticsearch solution.
+// Elasticsearch provides integration with the Elasticsearch solution. It's compatible with OpenSearch.
 type Elasticsearch struct {
 	log            logrus.FieldLogger
 	reporter       AnalyticsReporter
 	client         *elastic.Client
 	indices        map[string]config.ELSIndex
+	distribution   config.ElasticsearchDistribution
 	clusterVersion string
 	status         health.PlatformStatusMsg
 	failureReason  health.FailureReasonMsg
 	errorMsg       string
+
+	// preparedIndices holds names of indices which have the lifecycle policy and index template in place.
+	preparedIndices    map[string]struct{}
+	preparedIndicesMux sync.Mutex
 }
 
 // NewElasticsearch creates a new Elasticsearch instance.
@@ -104,17 +110,25 @@ func NewElasticsearch(log logrus.FieldLogger, commGroupIdx int, c config.Elastic
 	} else {
 		elsOpts = append(elsOpts,
 			elastic.SetURL(c.Server),
-			elastic.SetBasicAuth(c.Username, c.Password),
 			elastic.SetSniff(false),
 			elastic.SetHealthcheck(false),
 			elastic.SetGzip(true),
 		)
+		if c.APIKey != "" {
+			elsOpts = append(elsOpts, elastic.SetHeaders(http.Header{
+				"Authorization": []string{"ApiKey " + c.APIKey},
+			}))
+		} else {
+			elsOpts = append(elsOpts, elastic.SetBasicAuth(c.Username, c.Password))
+		}
 
 		if c.SkipTLSVerify {
-			tr := &http.Transport{
-				// #nosec G402
-				TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+			tlsCfg, err := tlsx.NewClientConfig(tlsx.ClientOptions{Name: "Elasticsearch", InsecureSkipVerify: true})
+			if err != nil {
+				return nil, fmt.Errorf("while creating Elasticsearch TLS configuration: %w", err)
 			}
+			tr := http.DefaultTransport.(*http.Transport).Clone()
+			tr.TLSClientConfig = tlsCfg
 			httpClient := &http.Client{Transport: tr}
 			elsOpts = append(elsOpts, elastic.SetHttpClient(httpClient))
 		}
@@ -129,14 +143,21 @@ func NewElasticsearch(log logrus.FieldLogger, commGroupIdx int, c config.Elastic
 		return nil, fmt.Errorf("while pinging cluster: %w", err)
 	}
 
+	distribution := c.Distribution
+	if distribution == "" {
+		distribution = config.ElasticElasticsearchDistribution
+	}
+
 	esNotifier := &Elasticsearch{
-		log:            log,
-		reporter:       reporter,
-		client:         elsClient,
-		indices:        c.Indices,
-		clusterVersion: pong.Version.Number,
-		status:         health.StatusUnknown,
-		failureReason:  "",
+		log:             log,
+		reporter:        reporter,
+		client:          elsClient,
+		indices:         c.Indices,
+		distribution:    distribution,
+		clusterVersion:  pong.Version.Number,
+		status:          health.StatusUnknown,
+		failureReason:   "",
+		preparedIndices: map[string]struct{}{},
 	}
 
 	err = reporter.ReportSinkEnabled(esNotifier.IntegrationName(), commGroupIdx)
@@ -156,46 +177,52 @@ type settings struct {
 }
 
 type index struct {
-	Shards   int `json:"number_of_shards"`
-	Replicas int `json:"number_of_replicas"`
+	Shards    int             `json:"number_of_shards"`
+	Replicas  int             `json:"number_of_replicas"`
+	Lifecycle *indexLifecycle `json:"lifecycle,omitempty"`
+}
+
+type indexLifecycle struct {
+	Name string `json:"name"`
 }
 
 func (e *Elasticsearch) flushIndex(ctx context.Context, indexCfg config.ELSIndex, event interface{}) error {
-	// Construct the ELS Index Name with timestamp suffix
-	indexName := indexCfg.Name + "-" + time.Now().Format(indexSuffixFormat)
-	// Create index if not exists
-	exists, err := e.client.IndexExists(indexName).Do(ctx)
-	if err != nil {
-		return fmt.Errorf("while getting index: %w", err)
+	if err := e.prepareIndex(ctx, indexCfg); err != nil {
+		return err
 	}
-	if !exists {
-		// Create a new index.
-		mapping := mapping{
-			Settings: settings{
-				index{
-					Shards:   indexCfg.Shards,
-					Replicas: indexCfg.Replicas,
-				},
-			},
-		}
-		_, err := e.client.CreateIndex(indexName).BodyJson(mapping).Do(ctx)
-		if err != nil && elastic.ErrorReason(err) != elasticErrorReasonResourceAlreadyExists {
-			return fmt.Errorf("while creating index: %w", err)
+
+	// Data streams manage backing indices on their own
+	indexName := indexCfg.Name
+	if !indexCfg.DataStream {
+		// Construct the ELS Index Name with timestamp suffix
+		indexName = indexCfg.Name + "-" + time.Now().Format(indexSuffixFormat)
+		if err := e.createIndexIfNotExists(ctx, indexCfg, indexName); err != nil {
+			return err
 		}
 	}
 
 	// Send event to els
 	indexService := e.client.Index().Index(indexName)
-	majorVersion, err := esMajorClusterVersion(e.clusterVersion)
-	if err != nil {
-		return fmt.Errorf("while getting cluster major version: %w", err)
-	}
-	if majorVersion <= 7 && indexCfg.Type != "" {
-		// Only Elasticsearch <= 7.x supports Type parameter
-		// nolint:staticcheck
-		indexService.Type(indexCfg.Type)
+	if indexCfg.DataStream {
+		doc, err := withTimestampField(event)
+		if err != nil {
+			return fmt.Errorf("while preparing data stream document: %w", err)
+		}
+		event = doc
+		// Data streams accept only the create operation
+		indexService.OpType("create")
+	} else if indexCfg.Type != "" && e.distribution != config.OpenSearchElasticsearchDistribution {
+		majorVersion, err := esMajorClusterVersion(e.clusterVersion)
+		if err != nil {
+			return fmt.Errorf("while getting cluster major version: %w", err)
+		}
+		if majorVersion <= 7 {
+			// Only Elasticsearch <= 7.x supports Type parameter
+			// nolint:staticcheck
+			indexService.Type(indexCfg.Type)
+		}
 	}
-	_, err = indexService.BodyJson(event).Do(ctx)
+	_, err := indexService.BodyJson(event).Do(ctx)
 	if err != nil {
 		return fmt.Errorf("while posting data to ELS: %w", err)
 	}
@@ -207,6 +234,60 @@ func (e *Elasticsearch) flushIndex(ctx context.Context, indexCfg config.ELSIndex
 	return nil
 }
 
+func (e *Elasticsearch) createIndexIfNotExists(ctx context.Context, indexCfg config.ELSIndex, indexName string) error {
+	exists, err := e.client.IndexExists(indexName).Do(ctx)
+	if err != nil {
+		return fmt.Errorf("while getting index: %w", err)
+	}
+	if exists {
+		return nil
+	}
+
+	// Create a new index.
+	mapping := mapping{
+		Settings: settings{
+			index{
+				Shards:   indexCfg.Shards,
+				Replicas: indexCfg.Replicas,
+			},
+		},
+	}
+	if indexCfg.Lifecycle.Enabled && !indexCfg.Template.Enabled && e.distribution == config.ElasticElasticsearchDistribution {
+		// ISM policies are attached by their own templates, while ILM policies have to be set in index settings
+		mapping.Settings.Index.Lifecycle = &indexLifecycle{Name: lifecyclePolicyName(indexCfg)}
+	}
+	_, err = e.client.CreateIndex(indexName).BodyJson(mapping).Do(ctx)
+	if err != nil && elastic.ErrorReason(err) != elasticErrorReasonResourceAlreadyExists {
+		return fmt.Errorf("while creating index: %w", err)
+	}
+	return nil
+}
+
+// prepareIndex creates, or updates, the lifecycle policy and index template of a given index once.
+func (e *Elasticsearch) prepareIndex(ctx context.Context, indexCfg config.ELSIndex) error {
+	e.preparedIndicesMux.Lock()
+	defer e.preparedIndicesMux.Unlock()
+
+	if _, ok := e.preparedIndices[indexCfg.Name]; ok {
+		return nil
+	}
+
+	if indexCfg.Lifecycle.Enabled {
+		if err := e.putLifecyclePolicy(ctx, indexCfg); err != nil {
+			return fmt.Errorf("while putting lifecycle policy: %w", err)
+		}
+	}
+	if indexCfg.Template.Enabled {
+		_, err := e.client.IndexPutIndexTemplate(indexCfg.Name).BodyJson(e.indexTemplate(indexCfg)).Do(ctx)
+		if err != nil {
+			return fmt.Errorf("while putting index template: %w", err)
+		}
+	}
+
+	e.preparedIndices[indexCfg.Name] = struct{}{}
+	return nil
+}
+
 // SendEvent sends an event to a configured elasticsearch server.
 func (e *Elasticsearch) SendEvent(ctx context.Context, rawData any, sources []string) error {
 	e.log.Debugf(">> Sending to Elasticsearch: %+v", rawData)
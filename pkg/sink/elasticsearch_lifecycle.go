@@ -0,0 +1,245 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/olivere/elastic/v7"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+const (
+	// elasticTimestampField is the field required by data streams.
+	elasticTimestampField = "@timestamp"
+	// elasticDefaultTemplatePriority takes precedence over built-in templates, which use priorities up to 100.
+	elasticDefaultTemplatePriority = 200
+	elasticISMTemplatePriority     = 100
+	elasticManagedByMeta           = "botkube"
+)
+
+// lifecyclePolicyName returns the name of the lifecycle policy of a given index.
+func lifecyclePolicyName(indexCfg config.ELSIndex) string {
+	if indexCfg.Lifecycle.Policy != "" {
+		return indexCfg.Lifecycle.Policy
+	}
+	return indexCfg.Name + "-policy"
+}
+
+// indexPatterns returns patterns which match the data stream, or daily indices.
+func indexPatterns(indexCfg config.ELSIndex) []string {
+	if indexCfg.DataStream {
+		return []string{indexCfg.Name}
+	}
+	return []string{indexCfg.Name + "-*"}
+}
+
+// elasticDuration formats a duration using time units supported by both Elasticsearch and OpenSearch.
+func elasticDuration(d time.Duration) string {
+	return fmt.Sprintf("%ds", int64(d.Seconds()))
+}
+
+func (e *Elasticsearch) indexTemplate(indexCfg config.ELSIndex) map[string]any {
+	idxSettings := map[string]any{
+		"number_of_replicas": indexCfg.Replicas,
+	}
+	if indexCfg.Shards > 0 {
+		idxSettings["number_of_shards"] = indexCfg.Shards
+	}
+	if indexCfg.Lifecycle.Enabled && e.distribution == config.ElasticElasticsearchDistribution {
+		idxSettings["lifecycle"] = indexLifecycle{Name: lifecyclePolicyName(indexCfg)}
+	}
+
+	priority := indexCfg.Template.Priority
+	if priority == 0 {
+		priority = elasticDefaultTemplatePriority
+	}
+
+	tpl := map[string]any{
+		"settings": map[string]any{
+			"index": idxSettings,
+		},
+	}
+	out := map[string]any{
+		"index_patterns": indexPatterns(indexCfg),
+		"priority":       priority,
+		"template":       tpl,
+		"_meta": map[string]any{
+			"managed_by": elasticManagedByMeta,
+		},
+	}
+	if indexCfg.DataStream {
+		out["data_stream"] = map[string]any{}
+		tpl["mappings"] = map[string]any{
+			"properties": map[string]any{
+				elasticTimestampField: map[string]any{"type": "date"},
+			},
+		}
+	}
+	return out
+}
+
+func (e *Elasticsearch) putLifecyclePolicy(ctx context.Context, indexCfg config.ELSIndex) error {
+	name := lifecyclePolicyName(indexCfg)
+	if e.distribution == config.OpenSearchElasticsearchDistribution {
+		return e.putISMPolicy(ctx, name, indexCfg)
+	}
+
+	_, err := e.client.XPackIlmPutLifecycle().Policy(name).BodyJson(ilmPolicy(indexCfg)).Do(ctx)
+	return err
+}
+
+// ilmPolicy returns the Elasticsearch ILM policy. See https://www.elastic.co/guide/en/elasticsearch/reference/current/ilm-put-lifecycle.html.
+func ilmPolicy(indexCfg config.ELSIndex) map[string]any {
+	lifecycle := indexCfg.Lifecycle
+
+	hotActions := map[string]any{}
+	if rollover := rolloverConditions(indexCfg, "max_age", "max_size"); len(rollover) > 0 {
+		hotActions["rollover"] = rollover
+	}
+	phases := map[string]any{
+		"hot": map[string]any{
+			"actions": hotActions,
+		},
+	}
+	if lifecycle.DeleteAfter > 0 {
+		phases["delete"] = map[string]any{
+			"min_age": elasticDuration(lifecycle.DeleteAfter),
+			"actions": map[string]any{
+				"delete": map[string]any{},
+			},
+		}
+	}
+
+	return map[string]any{
+		"policy": map[string]any{
+			"phases": phases,
+		},
+	}
+}
+
+// ismPolicy returns the OpenSearch ISM policy. See https://opensearch.org/docs/latest/im-plugin/ism/policies/.
+// The policy is attached to new indices by its ISM template.
+func ismPolicy(indexCfg config.ELSIndex) map[string]any {
+	lifecycle := indexCfg.Lifecycle
+
+	hotActions := []any{}
+	if rollover := rolloverConditions(indexCfg, "min_index_age", "min_size"); len(rollover) > 0 {
+		hotActions = append(hotActions, map[string]any{"rollover": rollover})
+	}
+	hot := map[string]any{
+		"name":        "hot",
+		"actions":     hotActions,
+		"transitions": []any{},
+	}
+	states := []any{hot}
+	if lifecycle.DeleteAfter > 0 {
+		hot["transitions"] = []any{
+			map[string]any{
+				"state_name": "delete",
+				"conditions": map[string]any{
+					"min_index_age": elasticDuration(lifecycle.DeleteAfter),
+				},
+			},
+		}
+		states = append(states, map[string]any{
+			"name": "delete",
+			"actions": []any{
+				map[string]any{"delete": map[string]any{}},
+			},
+			"transitions": []any{},
+		})
+	}
+
+	return map[string]any{
+		"policy": map[string]any{
+			"description":   fmt.Sprintf("Managed by %s", elasticManagedByMeta),
+			"default_state": "hot",
+			"states":        states,
+			"ism_template": []any{
+				map[string]any{
+					"index_patterns": indexPatterns(indexCfg),
+					"priority":       elasticISMTemplatePriority,
+				},
+			},
+		},
+	}
+}
+
+// rolloverConditions returns conditions of the rollover action. Daily indices are not rolled over.
+func rolloverConditions(indexCfg config.ELSIndex, ageKey, sizeKey string) map[string]any {
+	out := map[string]any{}
+	if !indexCfg.DataStream {
+		return out
+	}
+	if indexCfg.Lifecycle.RolloverMaxAge > 0 {
+		out[ageKey] = elasticDuration(indexCfg.Lifecycle.RolloverMaxAge)
+	}
+	if indexCfg.Lifecycle.RolloverMaxSize != "" {
+		out[sizeKey] = indexCfg.Lifecycle.RolloverMaxSize
+	}
+	return out
+}
+
+// putISMPolicy creates, or updates, the ISM policy. ISM requires the sequence number of the existing policy to update it.
+func (e *Elasticsearch) putISMPolicy(ctx context.Context, name string, indexCfg config.ELSIndex) error {
+	path := "/_plugins/_ism/policies/" + url.PathEscape(name)
+
+	res, err := e.client.PerformRequest(ctx, elastic.PerformRequestOptions{
+		Method:       http.MethodGet,
+		Path:         path,
+		IgnoreErrors: []int{http.StatusNotFound},
+	})
+	if err != nil {
+		return fmt.Errorf("while getting ISM policy: %w", err)
+	}
+
+	params := url.Values{}
+	if res.StatusCode == http.StatusOK {
+		var existing struct {
+			SeqNo       *int64 `json:"_seq_no"`
+			PrimaryTerm *int64 `json:"_primary_term"`
+		}
+		if err := json.Unmarshal(res.Body, &existing); err != nil {
+			return fmt.Errorf("while decoding ISM policy: %w", err)
+		}
+		if existing.SeqNo == nil || existing.PrimaryTerm == nil {
+			return errors.New("ISM policy doesn't have the sequence number")
+		}
+		params.Set("if_seq_no", strconv.FormatInt(*existing.SeqNo, 10))
+		params.Set("if_primary_term", strconv.FormatInt(*existing.PrimaryTerm, 10))
+	}
+
+	_, err = e.client.PerformRequest(ctx, elastic.PerformRequestOptions{
+		Method: http.MethodPut,
+		Path:   path,
+		Params: params,
+		Body:   ismPolicy(indexCfg),
+	})
+	return err
+}
+
+// withTimestampField returns the event as a document with the timestamp field required by data streams.
+func withTimestampField(event any) (map[string]any, error) {
+	raw, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, fmt.Errorf("event is not a JSON object: %w", err)
+	}
+	if doc == nil {
+		doc = map[string]any{}
+	}
+	if _, ok := doc[elasticTimestampField]; !ok {
+		doc[elasticTimestampField] = time.Now().UTC().Format(time.RFC3339Nano)
+	}
+	return doc, nil
+}
@@ -1,10 +1,25 @@
 package sink
 
 import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
 	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/internal/analytics"
+	"github.com/kubeshop/botkube/internal/health"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
 )
 
 func TestElasticsearchVersion(t *testing.T) {
@@ -27,3 +42,204 @@ func TestElasticsearchVersion(t *testing.T) {
 		assert.Equal(t, test.err, err)
 	}
 }
+
+type elasticRequest struct {
+	Method string
+	Path   string
+	Query  string
+	Body   map[string]any
+}
+
+// fakeElasticServer records requests, and responds as a cluster in a given version without any indices and policies.
+func fakeElasticServer(t *testing.T, version string, ismPolicyExists bool) (*httptest.Server, *[]elasticRequest, *[]string) {
+	t.Helper()
+	var (
+		mu       sync.Mutex
+		requests []elasticRequest
+		auth     []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		auth = append(auth, r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/":
+			_, _ = w.Write([]byte(`{"version":{"number":"` + version + `"}}`))
+			return
+		case r.Method == http.MethodHead:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/_plugins/_ism/policies/"):
+			if !ismPolicyExists {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"error":{"type":"status_exception"},"status":404}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"_id":"botkube-policy","_seq_no":3,"_primary_term":1}`))
+			return
+		}
+
+		req := elasticRequest{Method: r.Method, Path: r.URL.Path, Query: r.URL.RawQuery}
+		if r.Body != nil {
+			var reader io.Reader = r.Body
+			if r.Header.Get("Content-Encoding") == "gzip" {
+				gz, err := gzip.NewReader(r.Body)
+				require.NoError(t, err)
+				reader = gz
+			}
+			body, err := io.ReadAll(reader)
+			require.NoError(t, err)
+			if len(body) > 0 {
+				require.NoError(t, json.Unmarshal(body, &req.Body))
+			}
+		}
+		requests = append(requests, req)
+		_, _ = w.Write([]byte(`{"acknowledged":true,"_index":"botkube","_id":"1","result":"created"}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &requests, &auth
+}
+
+func TestElasticsearch_SendEventDataStream(t *testing.T) {
+	// given
+	srv, requests, auth := fakeElasticServer(t, "8.11.1", false)
+	sink, err := NewElasticsearch(loggerx.NewNoop(), 1, config.Elasticsearch{
+		Server: srv.URL,
+		APIKey: "Zm9vOmJhcg==",
+		Indices: map[string]config.ELSIndex{
+			"default": {
+				Name:       "botkube",
+				Type:       "botkube-event",
+				Shards:     1,
+				DataStream: true,
+				Template:   config.ELSIndexTemplate{Enabled: true},
+				Lifecycle: config.ELSLifecycle{
+					Enabled:         true,
+					RolloverMaxAge:  24 * time.Hour,
+					RolloverMaxSize: "50gb",
+					DeleteAfter:     30 * 24 * time.Hour,
+				},
+				Bindings: config.SinkBindings{Sources: []string{"k8s-err-events"}},
+			},
+		},
+	}, analytics.NewNoopReporter())
+	require.NoError(t, err)
+
+	// when
+	for i := 0; i < 2; i++ {
+		err = sink.SendEvent(context.Background(), fixK8sPodErrorAlert(), []string{"k8s-err-events"})
+		require.NoError(t, err)
+	}
+
+	// then
+	for _, header := range *auth {
+		assert.Equal(t, "ApiKey Zm9vOmJhcg==", header)
+	}
+	require.Len(t, *requests, 6, "policy and template are put once")
+
+	policy := (*requests)[0]
+	assert.Equal(t, "/_ilm/policy/botkube-policy", policy.Path)
+	assert.Equal(t, map[string]any{
+		"policy": map[string]any{
+			"phases": map[string]any{
+				"hot": map[string]any{
+					"actions": map[string]any{
+						"rollover": map[string]any{"max_age": "86400s", "max_size": "50gb"},
+					},
+				},
+				"delete": map[string]any{
+					"min_age": "2592000s",
+					"actions": map[string]any{"delete": map[string]any{}},
+				},
+			},
+		},
+	}, policy.Body)
+
+	tpl := (*requests)[1]
+	assert.Equal(t, "/_index_template/botkube", tpl.Path)
+	assert.Equal(t, []any{"botkube"}, tpl.Body["index_patterns"])
+	assert.EqualValues(t, 200, tpl.Body["priority"])
+	assert.Equal(t, map[string]any{}, tpl.Body["data_stream"])
+	assert.Equal(t, map[string]any{
+		"index": map[string]any{
+			"number_of_shards":   float64(1),
+			"number_of_replicas": float64(0),
+			"lifecycle":          map[string]any{"name": "botkube-policy"},
+		},
+	}, tpl.Body["template"].(map[string]any)["settings"])
+
+	doc := (*requests)[2]
+	assert.Equal(t, http.MethodPost, doc.Method)
+	assert.Equal(t, "/botkube/_doc/", doc.Path)
+	assert.Equal(t, "op_type=create", doc.Query)
+	assert.Equal(t, "webapp", doc.Body["Name"])
+	assert.NotEmpty(t, doc.Body["@timestamp"])
+	assert.Equal(t, "/botkube/_flush", (*requests)[3].Path)
+	assert.Equal(t, health.StatusHealthy, sink.GetStatus().Status)
+}
+
+func TestElasticsearch_SendEventOpenSearch(t *testing.T) {
+	// given
+	srv, requests, _ := fakeElasticServer(t, "2.11.0", true)
+	sink, err := NewElasticsearch(loggerx.NewNoop(), 1, config.Elasticsearch{
+		Server:       srv.URL,
+		Distribution: config.OpenSearchElasticsearchDistribution,
+		Indices: map[string]config.ELSIndex{
+			"default": {
+				Name:      "botkube",
+				Type:      "botkube-event",
+				Shards:    1,
+				Lifecycle: config.ELSLifecycle{Enabled: true, Policy: "botkube-policy", DeleteAfter: time.Hour},
+				Bindings:  config.SinkBindings{Sources: []string{"k8s-err-events"}},
+			},
+		},
+	}, analytics.NewNoopReporter())
+	require.NoError(t, err)
+
+	// when
+	err = sink.SendEvent(context.Background(), fixK8sPodErrorAlert(), []string{"k8s-err-events"})
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, *requests, 4)
+	indexName := "botkube-" + time.Now().Format(indexSuffixFormat)
+
+	policy := (*requests)[0]
+	assert.Equal(t, http.MethodPut, policy.Method)
+	assert.Equal(t, "/_plugins/_ism/policies/botkube-policy", policy.Path)
+	assert.Equal(t, "if_primary_term=1&if_seq_no=3", policy.Query)
+	assert.Equal(t, map[string]any{
+		"description":   "Managed by botkube",
+		"default_state": "hot",
+		"states": []any{
+			map[string]any{
+				"name":    "hot",
+				"actions": []any{},
+				"transitions": []any{
+					map[string]any{"state_name": "delete", "conditions": map[string]any{"min_index_age": "3600s"}},
+				},
+			},
+			map[string]any{
+				"name":        "delete",
+				"actions":     []any{map[string]any{"delete": map[string]any{}}},
+				"transitions": []any{},
+			},
+		},
+		"ism_template": []any{
+			map[string]any{"index_patterns": []any{"botkube-*"}, "priority": float64(100)},
+		},
+	}, policy.Body["policy"])
+
+	create := (*requests)[1]
+	assert.Equal(t, "/"+indexName, create.Path)
+	assert.Equal(t, map[string]any{
+		"index": map[string]any{"number_of_shards": float64(1), "number_of_replicas": float64(0)},
+	}, create.Body["settings"], "ISM policy is attached by its template")
+
+	doc := (*requests)[2]
+	assert.Equal(t, "/"+indexName+"/_doc/", doc.Path, "mapping type is not sent")
+	assert.Empty(t, doc.Query)
+	assert.Nil(t, doc.Body["@timestamp"])
+}
@@ -0,0 +1,41 @@
+package sink
+
+import (
+	"strings"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+
+	"github.com/kubeshop/botkube/pkg/sliceutil"
+)
+
+// EventRecord is the event representation published to message brokers, such as Kafka or NATS.
+type EventRecord struct {
+	Source      string    `json:"source"`
+	ClusterName string    `json:"clusterName"`
+	Timestamp   time.Time `json:"timestamp"`
+	Namespace   string    `json:"namespace,omitempty"`
+	// Resource identifies the Kubernetes resource the event is about, e.g. `Pod/default/nginx`.
+	Resource string `json:"resource,omitempty"`
+	Data     any    `json:"data,omitempty"`
+}
+
+func newEventRecord(rawData any, sources []string, clusterName string) EventRecord {
+	out := EventRecord{
+		Source:      strings.Join(sources, ","),
+		ClusterName: clusterName,
+		Timestamp:   time.Now(),
+		Data:        rawData,
+	}
+
+	var ev k8sEventPayload
+	if err := mapstructure.Decode(rawData, &ev); err != nil {
+		// events from other sources don't have to describe Kubernetes resources
+		return out
+	}
+	out.Namespace = ev.Namespace
+	if ev.Kind != "" && ev.Name != "" {
+		out.Resource = strings.Join(sliceutil.FilterEmptyStrings([]string{ev.Kind, ev.Namespace, ev.Name}), "/")
+	}
+	return out
+}
@@ -0,0 +1,290 @@
+package sink
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/sasl"
+	"github.com/twmb/franz-go/pkg/sasl/plain"
+	"github.com/twmb/franz-go/pkg/sasl/scram"
+
+	"github.com/kubeshop/botkube/internal/health"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/multierror"
+	"github.com/kubeshop/botkube/pkg/sliceutil"
+	"github.com/kubeshop/botkube/pkg/tlsx"
+)
+
+var _ Sink = &Kafka{}
+
+const (
+	kafkaContentTypeHeader = "content-type"
+	kafkaSourceHeader      = "botkube-source"
+
+	kafkaDefaultClientID     = "botkube"
+	kafkaDefaultTimeout      = 10 * time.Second
+	kafkaDefaultRetryBackoff = 250 * time.Millisecond
+)
+
+var kafkaContentTypes = map[config.KafkaEncoding]string{
+	config.JSONKafkaEncoding: "application/json",
+	config.AvroKafkaEncoding: "avro/binary",
+}
+
+// KafkaProducer publishes records to Kafka topics.
+type KafkaProducer interface {
+	ProduceSync(ctx context.Context, rs ...*kgo.Record) kgo.ProduceResults
+}
+
+// Kafka publishes events as records to Kafka topics, so they can be consumed by existing streaming pipelines.
+type Kafka struct {
+	log      logrus.FieldLogger
+	reporter AnalyticsReporter
+
+	producer     KafkaProducer
+	topics       map[string]config.KafkaTopic
+	encoding     config.KafkaEncoding
+	partitionKey config.KafkaPartitionKey
+	avro         *kafkaAvroEncoder
+	clusterName  string
+
+	status        health.PlatformStatusMsg
+	failureReason health.FailureReasonMsg
+	errorMsg      string
+	statusMux     sync.Mutex
+}
+
+// NewKafka creates a new Kafka instance. Brokers are connected once the first event is published.
+func NewKafka(log logrus.FieldLogger, commGroupIdx int, c config.Kafka, clusterName string, reporter AnalyticsReporter) (*Kafka, error) {
+	opts, err := newKafkaClientOptions(c)
+	if err != nil {
+		return nil, err
+	}
+	producer, err := kgo.NewClient(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("while creating Kafka producer: %w", err)
+	}
+
+	notifier := newKafka(log, c, clusterName, producer)
+	notifier.reporter = reporter
+
+	err = reporter.ReportSinkEnabled(notifier.IntegrationName(), commGroupIdx)
+	if err != nil {
+		log.WithError(err).Error("Failed to report analytics")
+	}
+
+	return notifier, nil
+}
+
+func newKafka(log logrus.FieldLogger, c config.Kafka, clusterName string, producer KafkaProducer) *Kafka {
+	encoding := c.Encoding
+	if encoding == "" {
+		encoding = config.JSONKafkaEncoding
+	}
+
+	return &Kafka{
+		log:          log,
+		producer:     producer,
+		topics:       c.Topics,
+		encoding:     encoding,
+		partitionKey: c.PartitionKey,
+		avro:         newKafkaAvroEncoder(c.Avro),
+		clusterName:  clusterName,
+		status:       health.StatusUnknown,
+	}
+}
+
+// SendEvent publishes an event to Kafka topics bound to given sources.
+func (k *Kafka) SendEvent(ctx context.Context, rawData any, sources []string) error {
+	record := newEventRecord(rawData, sources, k.clusterName)
+	key := k.recordKey(record)
+
+	errs := multierror.New()
+	for _, topic := range k.topics {
+		if !sliceutil.Intersect(topic.Bindings.Sources, sources) {
+			continue
+		}
+
+		err := k.publish(ctx, topic.Name, key, record)
+		if err != nil {
+			k.setFailureReason(health.FailureReasonConnectionError, fmt.Sprintf("while publishing event to Kafka topic %q: %s", topic.Name, err.Error()))
+			errs = multierror.Append(errs, fmt.Errorf("while publishing event to Kafka topic %q: %w", topic.Name, err))
+			continue
+		}
+
+		k.markHealthy()
+		k.log.Debugf("Event successfully published to Kafka topic %q", topic.Name)
+	}
+
+	return errs.ErrorOrNil()
+}
+
+func (k *Kafka) publish(ctx context.Context, topic string, key []byte, record EventRecord) error {
+	var (
+		value []byte
+		err   error
+	)
+	switch k.encoding {
+	case config.AvroKafkaEncoding:
+		value, err = k.avro.Encode(ctx, topic, record)
+	default:
+		value, err = json.Marshal(record)
+	}
+	if err != nil {
+		return fmt.Errorf("while encoding event: %w", err)
+	}
+
+	return k.producer.ProduceSync(ctx, &kgo.Record{
+		Topic: topic,
+		Key:   key,
+		Value: value,
+		Headers: []kgo.RecordHeader{
+			{Key: kafkaContentTypeHeader, Value: []byte(kafkaContentTypes[k.encoding])},
+			{Key: kafkaSourceHeader, Value: []byte(record.Source)},
+		},
+		Timestamp: record.Timestamp,
+	}).FirstErr()
+}
+
+// recordKey returns the key which selects the topic partition. If the key is nil, records are distributed across partitions.
+func (k *Kafka) recordKey(record EventRecord) []byte {
+	var key string
+	switch k.partitionKey {
+	case config.NamespaceKafkaPartitionKey:
+		key = record.Namespace
+	case config.ResourceKafkaPartitionKey:
+		key = record.Resource
+	}
+	if key == "" {
+		return nil
+	}
+	return []byte(key)
+}
+
+// IntegrationName describes the notifier integration name.
+func (k *Kafka) IntegrationName() config.CommPlatformIntegration {
+	return config.KafkaCommPlatformIntegration
+}
+
+// Type describes the notifier type.
+func (k *Kafka) Type() config.IntegrationType {
+	return config.SinkIntegrationType
+}
+
+// GetStatus gets sink status.
+func (k *Kafka) GetStatus() health.PlatformStatus {
+	k.statusMux.Lock()
+	defer k.statusMux.Unlock()
+
+	return health.PlatformStatus{
+		Status:   k.status,
+		Restarts: "0/0",
+		Reason:   k.failureReason,
+		ErrorMsg: k.errorMsg,
+	}
+}
+
+func (k *Kafka) setFailureReason(reason health.FailureReasonMsg, errorMsg string) {
+	k.statusMux.Lock()
+	defer k.statusMux.Unlock()
+
+	k.status = health.StatusUnHealthy
+	k.failureReason = reason
+	k.errorMsg = errorMsg
+}
+
+func (k *Kafka) markHealthy() {
+	k.statusMux.Lock()
+	defer k.statusMux.Unlock()
+
+	k.status = health.StatusHealthy
+	k.failureReason = ""
+	k.errorMsg = ""
+}
+
+// newKafkaClientOptions returns options of a client which publishes records synchronously,
+// so the sink knows if a given record was acknowledged according to the configured delivery guarantees.
+func newKafkaClientOptions(c config.Kafka) ([]kgo.Opt, error) {
+	if len(c.Brokers) == 0 {
+		return nil, errors.New("at least one broker is required")
+	}
+	clientID := c.ClientID
+	if clientID == "" {
+		clientID = kafkaDefaultClientID
+	}
+	timeout := c.Timeout
+	if timeout <= 0 {
+		timeout = kafkaDefaultTimeout
+	}
+	retryBackoff := c.RetryBackoff
+	if retryBackoff <= 0 {
+		retryBackoff = kafkaDefaultRetryBackoff
+	}
+
+	out := []kgo.Opt{
+		kgo.SeedBrokers(c.Brokers...),
+		kgo.ClientID(clientID),
+		kgo.DialTimeout(timeout),
+		kgo.ProduceRequestTimeout(timeout),
+		kgo.RecordRetries(c.MaxRetries),
+		kgo.RetryBackoffFn(func(int) time.Duration { return retryBackoff }),
+	}
+	switch c.RequiredAcks {
+	case config.LeaderKafkaRequiredAcks:
+		// idempotent writes require acknowledgements from all in-sync replicas
+		out = append(out, kgo.RequiredAcks(kgo.LeaderAck()), kgo.DisableIdempotentWrite())
+	case config.NoneKafkaRequiredAcks:
+		out = append(out, kgo.RequiredAcks(kgo.NoAck()), kgo.DisableIdempotentWrite())
+	default:
+		out = append(out, kgo.RequiredAcks(kgo.AllISRAcks()))
+	}
+
+	if c.SASL.Enabled {
+		mechanism, err := newKafkaSASLMechanism(c.SASL)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, kgo.SASL(mechanism))
+	}
+
+	if c.TLS.Enabled {
+		tlsCfg, err := newKafkaTLSConfig(c.TLS)
+		if err != nil {
+			return nil, fmt.Errorf("while creating Kafka TLS configuration: %w", err)
+		}
+		out = append(out, kgo.DialTLSConfig(tlsCfg))
+	}
+	return out, nil
+}
+
+func newKafkaSASLMechanism(cfg config.KafkaSASL) (sasl.Mechanism, error) {
+	switch cfg.Mechanism {
+	case "", "PLAIN":
+		return plain.Auth{User: cfg.Username, Pass: cfg.Password}.AsMechanism(), nil
+	case "SCRAM-SHA-256":
+		return scram.Auth{User: cfg.Username, Pass: cfg.Password}.AsSha256Mechanism(), nil
+	case "SCRAM-SHA-512":
+		return scram.Auth{User: cfg.Username, Pass: cfg.Password}.AsSha512Mechanism(), nil
+	default:
+		return nil, fmt.Errorf("unsupported SASL mechanism %q", cfg.Mechanism)
+	}
+}
+
+func newKafkaTLSConfig(cfg config.KafkaTLS) (*tls.Config, error) {
+	return tlsx.NewClientConfig(tlsx.ClientOptions{
+		Name:               "Kafka",
+		CAFile:             cfg.CAFile,
+		CertFile:           cfg.CertFile,
+		KeyFile:            cfg.KeyFile,
+		MinVersion:         cfg.MinVersion,
+		CipherSuites:       cfg.CipherSuites,
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+	})
+}
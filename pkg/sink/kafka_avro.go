@@ -0,0 +1,169 @@
+package sink
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/multierror"
+)
+
+const (
+	// kafkaAvroSchema is the schema of Kafka records encoded with Avro. The data field holds the JSON-encoded event.
+	kafkaAvroSchema = `{"type":"record","name":"Event","namespace":"io.botkube","fields":[` +
+		`{"name":"source","type":"string"},` +
+		`{"name":"clusterName","type":"string"},` +
+		`{"name":"timestamp","type":{"type":"long","logicalType":"timestamp-millis"}},` +
+		`{"name":"namespace","type":["null","string"],"default":null},` +
+		`{"name":"resource","type":["null","string"],"default":null},` +
+		`{"name":"data","type":"string"}]}`
+	// kafkaAvroCanonicalSchema is the Parsing Canonical Form of the schema, used to compute its fingerprint.
+	kafkaAvroCanonicalSchema = `{"name":"io.botkube.Event","type":"record","fields":[` +
+		`{"name":"source","type":"string"},` +
+		`{"name":"clusterName","type":"string"},` +
+		`{"name":"timestamp","type":"long"},` +
+		`{"name":"namespace","type":["null","string"]},` +
+		`{"name":"resource","type":["null","string"]},` +
+		`{"name":"data","type":"string"}]}`
+
+	avroFingerprintEmpty  = 0xc15d213aa4d7a795
+	schemaRegistryCT      = "application/vnd.schemaregistry.v1+json"
+	confluentMagicByte    = 0x0
+	avroSingleObjectMagic = "\xc3\x01"
+)
+
+var avroFingerprintTable = func() [256]uint64 {
+	var out [256]uint64
+	for i := range out {
+		fp := uint64(i)
+		for j := 0; j < 8; j++ {
+			fp = (fp >> 1) ^ (avroFingerprintEmpty & -(fp & 1))
+		}
+		out[i] = fp
+	}
+	return out
+}()
+
+// kafkaAvroEncoder encodes Kafka records with Avro. If the schema registry is configured, records use the Confluent wire format
+// with the ID of the schema registered for the `{topic}-value` subject. Otherwise, records use the Avro single-object encoding.
+type kafkaAvroEncoder struct {
+	registry   config.KafkaSchemaRegistry
+	httpClient *http.Client
+
+	mu        sync.Mutex
+	schemaIDs map[string]int32
+}
+
+func newKafkaAvroEncoder(cfg config.KafkaAvro) *kafkaAvroEncoder {
+	return &kafkaAvroEncoder{
+		registry:   cfg.SchemaRegistry,
+		httpClient: &http.Client{Timeout: defaultHTTPCliTimeout},
+		schemaIDs:  map[string]int32{},
+	}
+}
+
+// Encode returns the Avro-encoded record for a given topic.
+func (e *kafkaAvroEncoder) Encode(ctx context.Context, topic string, record EventRecord) ([]byte, error) {
+	data, err := json.Marshal(record.Data)
+	if err != nil {
+		return nil, fmt.Errorf("while marshaling event data: %w", err)
+	}
+
+	var out []byte
+	if e.registry.URL != "" {
+		id, err := e.schemaID(ctx, topic)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, confluentMagicByte)
+		out = binary.BigEndian.AppendUint32(out, uint32(id))
+	} else {
+		out = append(out, avroSingleObjectMagic...)
+		out = binary.LittleEndian.AppendUint64(out, avroFingerprint([]byte(kafkaAvroCanonicalSchema)))
+	}
+
+	out = appendAvroString(out, record.Source)
+	out = appendAvroString(out, record.ClusterName)
+	out = binary.AppendVarint(out, record.Timestamp.UnixMilli())
+	out = appendAvroOptionalString(out, record.Namespace)
+	out = appendAvroOptionalString(out, record.Resource)
+	out = appendAvroString(out, string(data))
+	return out, nil
+}
+
+// schemaID registers the schema for a given topic, and returns its ID. Registering the same schema again returns the existing ID.
+func (e *kafkaAvroEncoder) schemaID(ctx context.Context, topic string) (_ int32, err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if id, found := e.schemaIDs[topic]; found {
+		return id, nil
+	}
+
+	body, err := json.Marshal(map[string]string{"schema": kafkaAvroSchema})
+	if err != nil {
+		return 0, err
+	}
+	endpoint, err := url.JoinPath(e.registry.URL, "subjects", topic+"-value", "versions")
+	if err != nil {
+		return 0, fmt.Errorf("while building schema registry URL: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", schemaRegistryCT)
+	if e.registry.Username != "" {
+		req.SetBasicAuth(e.registry.Username, e.registry.Password)
+	}
+
+	resp, err := e.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("while registering schema: %w", err)
+	}
+	defer func() {
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			err = multierror.Append(err, closeErr)
+		}
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("while registering schema: unexpected status code %d", resp.StatusCode)
+	}
+
+	var out struct {
+		ID int32 `json:"id"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return 0, fmt.Errorf("while decoding schema registry response: %w", err)
+	}
+	e.schemaIDs[topic] = out.ID
+	return out.ID, nil
+}
+
+// avroFingerprint returns the CRC-64-AVRO fingerprint of a given schema.
+func avroFingerprint(schema []byte) uint64 {
+	fp := uint64(avroFingerprintEmpty)
+	for _, b := range schema {
+		fp = (fp >> 8) ^ avroFingerprintTable[byte(fp)^b]
+	}
+	return fp
+}
+
+func appendAvroString(out []byte, in string) []byte {
+	out = binary.AppendVarint(out, int64(len(in)))
+	return append(out, in...)
+}
+
+// appendAvroOptionalString appends the ["null","string"] union. Empty strings are encoded as null.
+func appendAvroOptionalString(out []byte, in string) []byte {
+	if in == "" {
+		return binary.AppendVarint(out, 0)
+	}
+	out = binary.AppendVarint(out, 1)
+	return appendAvroString(out, in)
+}
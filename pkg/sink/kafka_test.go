@@ -0,0 +1,337 @@
+package sink
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/sasl"
+
+	"github.com/kubeshop/botkube/internal/health"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+type fakeKafkaProducer struct {
+	err      error
+	messages []*kgo.Record
+}
+
+func (f *fakeKafkaProducer) ProduceSync(_ context.Context, rs ...*kgo.Record) kgo.ProduceResults {
+	var out kgo.ProduceResults
+	for _, r := range rs {
+		if f.err == nil {
+			f.messages = append(f.messages, r)
+		}
+		out = append(out, kgo.ProduceResult{Record: r, Err: f.err})
+	}
+	return out
+}
+
+func TestKafka_SendEvent(t *testing.T) {
+	tests := []struct {
+		name         string
+		partitionKey config.KafkaPartitionKey
+		givenEvent   map[string]any
+		expKey       []byte
+	}{
+		{
+			name:         "namespace partition key",
+			partitionKey: config.NamespaceKafkaPartitionKey,
+			givenEvent:   fixK8sPodErrorAlert(),
+			expKey:       []byte("dev"),
+		},
+		{
+			name:         "resource partition key",
+			partitionKey: config.ResourceKafkaPartitionKey,
+			givenEvent:   fixK8sPodErrorAlert(),
+			expKey:       []byte("Pod/dev/webapp"),
+		},
+		{
+			name:         "no partition key",
+			partitionKey: config.NoneKafkaPartitionKey,
+			givenEvent:   fixK8sPodErrorAlert(),
+		},
+		{
+			name:         "event without namespace",
+			partitionKey: config.NamespaceKafkaPartitionKey,
+			givenEvent:   map[string]any{"message": "alert fired"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			producer := &fakeKafkaProducer{}
+			sink := newKafka(loggerx.NewNoop(), config.Kafka{
+				PartitionKey: tc.partitionKey,
+				Topics: map[string]config.KafkaTopic{
+					"errors": {
+						Name:     "botkube-errors",
+						Bindings: config.SinkBindings{Sources: []string{"k8s-err-events"}},
+					},
+					"all": {
+						Name:     "botkube-all",
+						Bindings: config.SinkBindings{Sources: []string{"k8s-err-events", "k8s-all-events"}},
+					},
+					"other": {
+						Name:     "botkube-other",
+						Bindings: config.SinkBindings{Sources: []string{"prometheus"}},
+					},
+				},
+			}, "labs", producer)
+
+			// when
+			err := sink.SendEvent(context.Background(), tc.givenEvent, []string{"k8s-err-events"})
+
+			// then
+			require.NoError(t, err)
+			require.Len(t, producer.messages, 2)
+
+			var topics []string
+			for _, msg := range producer.messages {
+				topics = append(topics, msg.Topic)
+				assert.Equal(t, tc.expKey, msg.Key)
+				assert.Equal(t, []kgo.RecordHeader{
+					{Key: "content-type", Value: []byte("application/json")},
+					{Key: "botkube-source", Value: []byte("k8s-err-events")},
+				}, msg.Headers)
+
+				var record map[string]any
+				require.NoError(t, json.Unmarshal(msg.Value, &record))
+				assert.Equal(t, "labs", record["clusterName"])
+				assert.Equal(t, "k8s-err-events", record["source"])
+				assert.NotEmpty(t, record["data"])
+			}
+			assert.ElementsMatch(t, []string{"botkube-errors", "botkube-all"}, topics)
+			assert.Equal(t, health.StatusHealthy, sink.GetStatus().Status)
+		})
+	}
+}
+
+func TestKafka_SendEventFailure(t *testing.T) {
+	// given
+	producer := &fakeKafkaProducer{err: errors.New("connection refused")}
+	sink := newKafka(loggerx.NewNoop(), config.Kafka{
+		Topics: map[string]config.KafkaTopic{
+			"default": {
+				Name:     "botkube",
+				Bindings: config.SinkBindings{Sources: []string{"k8s-err-events"}},
+			},
+		},
+	}, "labs", producer)
+
+	// when
+	err := sink.SendEvent(context.Background(), fixK8sPodErrorAlert(), []string{"k8s-err-events"})
+
+	// then
+	assert.ErrorContains(t, err, `while publishing event to Kafka topic "botkube": connection refused`)
+	status := sink.GetStatus()
+	assert.Equal(t, health.StatusUnHealthy, status.Status)
+	assert.Equal(t, health.FailureReasonConnectionError, status.Reason)
+}
+
+func TestKafka_SendEventAvro(t *testing.T) {
+	const schemaID = 42
+
+	registry := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/subjects/botkube-value/versions", r.URL.Path)
+		user, pass, _ := r.BasicAuth()
+		assert.Equal(t, "botkube", user)
+		assert.Equal(t, "secret", pass)
+
+		var req struct {
+			Schema string `json:"schema"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, kafkaAvroSchema, req.Schema)
+
+		_, _ = w.Write([]byte(`{"id":42}`))
+	}))
+	defer registry.Close()
+
+	tests := []struct {
+		name      string
+		givenAvro config.KafkaAvro
+		expPrefix []byte
+	}{
+		{
+			name:      "single-object encoding",
+			expPrefix: binary.LittleEndian.AppendUint64([]byte{0xc3, 0x01}, avroFingerprint([]byte(kafkaAvroCanonicalSchema))),
+		},
+		{
+			name: "schema registry",
+			givenAvro: config.KafkaAvro{
+				SchemaRegistry: config.KafkaSchemaRegistry{URL: registry.URL, Username: "botkube", Password: "secret"},
+			},
+			expPrefix: binary.BigEndian.AppendUint32([]byte{0x0}, schemaID),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			producer := &fakeKafkaProducer{}
+			sink := newKafka(loggerx.NewNoop(), config.Kafka{
+				Encoding:     config.AvroKafkaEncoding,
+				Avro:         tc.givenAvro,
+				PartitionKey: config.ResourceKafkaPartitionKey,
+				Topics: map[string]config.KafkaTopic{
+					"default": {
+						Name:     "botkube",
+						Bindings: config.SinkBindings{Sources: []string{"k8s-err-events"}},
+					},
+				},
+			}, "labs", producer)
+
+			// when
+			for i := 0; i < 2; i++ {
+				err := sink.SendEvent(context.Background(), fixK8sPodErrorAlert(), []string{"k8s-err-events"})
+				require.NoError(t, err)
+			}
+
+			// then
+			require.Len(t, producer.messages, 2)
+			msg := producer.messages[0]
+			assert.Equal(t, []byte("avro/binary"), msg.Headers[0].Value)
+			require.Greater(t, len(msg.Value), len(tc.expPrefix))
+			assert.Equal(t, tc.expPrefix, msg.Value[:len(tc.expPrefix)])
+
+			d := avroTestDecoder{buf: msg.Value[len(tc.expPrefix):]}
+			assert.Equal(t, "k8s-err-events", d.string())
+			assert.Equal(t, "labs", d.string())
+			assert.Equal(t, msg.Timestamp.UnixMilli(), d.long())
+			assert.Equal(t, int64(1), d.long())
+			assert.Equal(t, "dev", d.string())
+			assert.Equal(t, int64(1), d.long())
+			assert.Equal(t, "Pod/dev/webapp", d.string())
+			assert.JSONEq(t, mustMarshalJSON(t, fixK8sPodErrorAlert()), d.string())
+			assert.Empty(t, d.buf)
+		})
+	}
+}
+
+func TestNewKafkaClientOptions(t *testing.T) {
+	tests := []struct {
+		name         string
+		given        config.Kafka
+		expectedAcks kgo.Acks
+		expectedMech string
+	}{
+		{
+			name:         "defaults",
+			given:        config.Kafka{Brokers: []string{"kafka:9092"}},
+			expectedAcks: kgo.AllISRAcks(),
+		},
+		{
+			name:         "leader acknowledgements",
+			given:        config.Kafka{Brokers: []string{"kafka:9092"}, RequiredAcks: config.LeaderKafkaRequiredAcks},
+			expectedAcks: kgo.LeaderAck(),
+		},
+		{
+			name:         "no acknowledgements",
+			given:        config.Kafka{Brokers: []string{"kafka:9092"}, RequiredAcks: config.NoneKafkaRequiredAcks},
+			expectedAcks: kgo.NoAck(),
+		},
+		{
+			name: "SCRAM authentication",
+			given: config.Kafka{
+				Brokers: []string{"kafka:9092"},
+				SASL:    config.KafkaSASL{Enabled: true, Mechanism: "SCRAM-SHA-512", Username: "botkube", Password: "secret"},
+			},
+			expectedAcks: kgo.AllISRAcks(),
+			expectedMech: "SCRAM-SHA-512",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			opts, err := newKafkaClientOptions(tc.given)
+			require.NoError(t, err)
+
+			client, err := kgo.NewClient(opts...)
+			require.NoError(t, err)
+			defer client.Close()
+
+			// then
+			assert.Equal(t, []any{"botkube", true}, client.OptValues(kgo.ClientID))
+			assert.Equal(t, tc.expectedAcks, client.OptValue(kgo.RequiredAcks))
+
+			var mechanisms []string
+			for _, m := range client.OptValues(kgo.SASL) {
+				for _, mech := range m.([]sasl.Mechanism) {
+					mechanisms = append(mechanisms, mech.Name())
+				}
+			}
+			if tc.expectedMech == "" {
+				assert.Empty(t, mechanisms)
+				return
+			}
+			assert.Equal(t, []string{tc.expectedMech}, mechanisms)
+		})
+	}
+}
+
+func TestNewKafkaClientOptionsErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		given       config.Kafka
+		expectedErr string
+	}{
+		{
+			name:        "no brokers",
+			given:       config.Kafka{},
+			expectedErr: "at least one broker is required",
+		},
+		{
+			name:        "unknown SASL mechanism",
+			given:       config.Kafka{Brokers: []string{"kafka:9092"}, SASL: config.KafkaSASL{Enabled: true, Mechanism: "GSSAPI"}},
+			expectedErr: `unsupported SASL mechanism "GSSAPI"`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			_, err := newKafkaClientOptions(tc.given)
+
+			// then
+			assert.EqualError(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestAvroFingerprint(t *testing.T) {
+	// test vector from the Avro specification: the fingerprint of the "null" schema
+	assert.Equal(t, uint64(0x63dd24e7cc258f8a), avroFingerprint([]byte(`"null"`)))
+}
+
+type avroTestDecoder struct {
+	buf []byte
+}
+
+func (d *avroTestDecoder) long() int64 {
+	v, n := binary.Varint(d.buf)
+	d.buf = d.buf[n:]
+	return v
+}
+
+func (d *avroTestDecoder) string() string {
+	l := int(d.long())
+	out := string(d.buf[:l])
+	d.buf = d.buf[l:]
+	return out
+}
+
+func mustMarshalJSON(t *testing.T, in any) string {
+	t.Helper()
+	out, err := json.Marshal(in)
+	require.NoError(t, err)
+	return string(out)
+}
@@ -0,0 +1,276 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/nats-io/nats.go"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/exp/slices"
+
+	"github.com/kubeshop/botkube/internal/health"
+	natsx "github.com/kubeshop/botkube/internal/nats"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/multierror"
+	"github.com/kubeshop/botkube/pkg/sliceutil"
+)
+
+var _ Sink = &NATS{}
+
+const (
+	natsContentTypeHeader = "Content-Type"
+	natsSourceHeader      = "Botkube-Source"
+	natsDefaultReplicas   = 1
+)
+
+// NATSConn publishes messages to NATS subjects.
+type NATSConn interface {
+	PublishMsg(msg *nats.Msg) error
+	FlushWithContext(ctx context.Context) error
+	JetStream(opts ...nats.JSOpt) (nats.JetStreamContext, error)
+	IsClosed() bool
+	Close()
+}
+
+// NATS publishes events as JSON messages to NATS subjects. If JetStream is enabled, events are published only once a stream persists them.
+type NATS struct {
+	log      logrus.FieldLogger
+	reporter AnalyticsReporter
+
+	connect     func() (NATSConn, error)
+	subjects    map[string]config.NATSSubject
+	jetStream   config.NATSJetStream
+	jsOpts      []nats.JSOpt
+	clusterName string
+
+	connMux sync.Mutex
+	conn    NATSConn
+	js      nats.JetStreamContext
+
+	status        health.PlatformStatusMsg
+	failureReason health.FailureReasonMsg
+	errorMsg      string
+	statusMux     sync.Mutex
+}
+
+// NewNATS creates a new NATS instance. Servers are connected once the first event is published.
+func NewNATS(log logrus.FieldLogger, commGroupIdx int, c config.NATS, clusterName string, reporter AnalyticsReporter) (*NATS, error) {
+	connCfg, err := natsx.NewConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	notifier := newNATS(log, c, clusterName, func() (NATSConn, error) {
+		return natsx.Connect(connCfg)
+	})
+	notifier.reporter = reporter
+	notifier.jsOpts = []nats.JSOpt{nats.MaxWait(connCfg.Timeout)}
+
+	err = reporter.ReportSinkEnabled(notifier.IntegrationName(), commGroupIdx)
+	if err != nil {
+		log.WithError(err).Error("Failed to report analytics")
+	}
+
+	return notifier, nil
+}
+
+func newNATS(log logrus.FieldLogger, c config.NATS, clusterName string, connect func() (NATSConn, error)) *NATS {
+	return &NATS{
+		log:         log,
+		connect:     connect,
+		subjects:    c.Subjects,
+		jetStream:   c.JetStream,
+		clusterName: clusterName,
+		status:      health.StatusUnknown,
+	}
+}
+
+// SendEvent publishes an event to NATS subjects bound to given sources.
+func (n *NATS) SendEvent(ctx context.Context, rawData any, sources []string) error {
+	var subjects []string
+	for _, subject := range n.subjects {
+		if sliceutil.Intersect(subject.Bindings.Sources, sources) {
+			subjects = append(subjects, subject.Name)
+		}
+	}
+	if len(subjects) == 0 {
+		return nil
+	}
+
+	record := newEventRecord(rawData, sources, n.clusterName)
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("while marshaling event: %w", err)
+	}
+
+	conn, js, err := n.getConn()
+	if err != nil {
+		n.setFailureReason(health.FailureReasonConnectionError, fmt.Sprintf("while connecting to NATS: %s", err.Error()))
+		return fmt.Errorf("while connecting to NATS: %w", err)
+	}
+
+	errs := multierror.New()
+	for _, subject := range subjects {
+		msg := nats.NewMsg(subject)
+		msg.Header.Set(natsContentTypeHeader, "application/json")
+		msg.Header.Set(natsSourceHeader, record.Source)
+		msg.Data = data
+
+		err := n.publish(ctx, conn, js, msg)
+		if err != nil {
+			n.setFailureReason(health.FailureReasonConnectionError, fmt.Sprintf("while publishing event to NATS subject %q: %s", subject, err.Error()))
+			errs = multierror.Append(errs, fmt.Errorf("while publishing event to NATS subject %q: %w", subject, err))
+			continue
+		}
+
+		n.markHealthy()
+		n.log.Debugf("Event successfully published to NATS subject %q", subject)
+	}
+
+	return errs.ErrorOrNil()
+}
+
+func (n *NATS) publish(ctx context.Context, conn NATSConn, js nats.JetStreamContext, msg *nats.Msg) error {
+	if js != nil {
+		_, err := js.PublishMsg(msg, nats.Context(ctx))
+		return err
+	}
+
+	if err := conn.PublishMsg(msg); err != nil {
+		return err
+	}
+	return conn.FlushWithContext(ctx)
+}
+
+// getConn returns the current connection, or connects again if the previous connection was closed.
+// The JetStream context is nil if JetStream is disabled.
+func (n *NATS) getConn() (NATSConn, nats.JetStreamContext, error) {
+	n.connMux.Lock()
+	defer n.connMux.Unlock()
+
+	if n.conn != nil && !n.conn.IsClosed() {
+		return n.conn, n.js, nil
+	}
+
+	conn, err := n.connect()
+	if err != nil {
+		return nil, nil, err
+	}
+	var js nats.JetStreamContext
+	if n.jetStream.Enabled {
+		js, err = conn.JetStream(n.jsOpts...)
+		if err != nil {
+			conn.Close()
+			return nil, nil, fmt.Errorf("while creating JetStream context: %w", err)
+		}
+		if err := n.ensureStream(js); err != nil {
+			conn.Close()
+			return nil, nil, err
+		}
+	}
+
+	n.conn, n.js = conn, js
+	return conn, js, nil
+}
+
+// ensureStream creates the configured stream. If the stream already exists, subjects which it doesn't capture are added to it.
+// Other settings of the existing stream are preserved.
+func (n *NATS) ensureStream(js nats.JetStreamManager) error {
+	if n.jetStream.Stream == "" {
+		return nil
+	}
+
+	var subjects []string
+	for _, subject := range n.subjects {
+		subjects = append(subjects, subject.Name)
+	}
+	slices.Sort(subjects)
+	subjects = slices.Compact(subjects)
+	storage := nats.FileStorage
+	if n.jetStream.Storage == config.MemoryNATSStorage {
+		storage = nats.MemoryStorage
+	}
+	replicas := n.jetStream.Replicas
+	if replicas == 0 {
+		replicas = natsDefaultReplicas
+	}
+
+	info, err := js.StreamInfo(n.jetStream.Stream)
+	switch {
+	case errors.Is(err, nats.ErrStreamNotFound):
+		_, err := js.AddStream(&nats.StreamConfig{
+			Name:     n.jetStream.Stream,
+			Subjects: subjects,
+			Storage:  storage,
+			Replicas: replicas,
+			MaxAge:   n.jetStream.MaxAge,
+		})
+		if err != nil {
+			return fmt.Errorf("while creating stream %q: %w", n.jetStream.Stream, err)
+		}
+		return nil
+	case err != nil:
+		return fmt.Errorf("while getting stream %q: %w", n.jetStream.Stream, err)
+	}
+
+	cfg := info.Config
+	missing := false
+	for _, subject := range subjects {
+		if slices.Contains(cfg.Subjects, subject) {
+			continue
+		}
+		cfg.Subjects = append(cfg.Subjects, subject)
+		missing = true
+	}
+	if !missing {
+		return nil
+	}
+	if _, err := js.UpdateStream(&cfg); err != nil {
+		return fmt.Errorf("while updating stream %q: %w", n.jetStream.Stream, err)
+	}
+	return nil
+}
+
+// IntegrationName describes the notifier integration name.
+func (n *NATS) IntegrationName() config.CommPlatformIntegration {
+	return config.NATSCommPlatformIntegration
+}
+
+// Type describes the notifier type.
+func (n *NATS) Type() config.IntegrationType {
+	return config.SinkIntegrationType
+}
+
+// GetStatus gets sink status.
+func (n *NATS) GetStatus() health.PlatformStatus {
+	n.statusMux.Lock()
+	defer n.statusMux.Unlock()
+
+	return health.PlatformStatus{
+		Status:   n.status,
+		Restarts: "0/0",
+		Reason:   n.failureReason,
+		ErrorMsg: n.errorMsg,
+	}
+}
+
+func (n *NATS) setFailureReason(reason health.FailureReasonMsg, errorMsg string) {
+	n.statusMux.Lock()
+	defer n.statusMux.Unlock()
+
+	n.status = health.StatusUnHealthy
+	n.failureReason = reason
+	n.errorMsg = errorMsg
+}
+
+func (n *NATS) markHealthy() {
+	n.statusMux.Lock()
+	defer n.statusMux.Unlock()
+
+	n.status = health.StatusHealthy
+	n.failureReason = ""
+	n.errorMsg = ""
+}
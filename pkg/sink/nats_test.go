@@ -0,0 +1,241 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/internal/health"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+type fakeNATSConn struct {
+	closed     bool
+	publishErr error
+	flushes    int
+	published  []*nats.Msg
+	jetStream  []*nats.Msg
+	streams    map[string]nats.StreamConfig
+	created    []string
+	updated    []string
+}
+
+func (f *fakeNATSConn) PublishMsg(msg *nats.Msg) error {
+	if f.publishErr != nil {
+		return f.publishErr
+	}
+	f.published = append(f.published, msg)
+	return nil
+}
+
+func (f *fakeNATSConn) FlushWithContext(context.Context) error {
+	f.flushes++
+	return nil
+}
+
+func (f *fakeNATSConn) JetStream(...nats.JSOpt) (nats.JetStreamContext, error) {
+	return &fakeJetStream{conn: f}, nil
+}
+
+func (f *fakeNATSConn) IsClosed() bool {
+	return f.closed
+}
+
+func (f *fakeNATSConn) Close() {
+	f.closed = true
+}
+
+// fakeJetStream implements methods of the JetStream context used by the sink. Other methods panic.
+type fakeJetStream struct {
+	nats.JetStreamContext
+	conn *fakeNATSConn
+}
+
+func (f *fakeJetStream) PublishMsg(msg *nats.Msg, _ ...nats.PubOpt) (*nats.PubAck, error) {
+	if f.conn.publishErr != nil {
+		return nil, f.conn.publishErr
+	}
+	f.conn.jetStream = append(f.conn.jetStream, msg)
+	return &nats.PubAck{Stream: "BOTKUBE", Sequence: uint64(len(f.conn.jetStream))}, nil
+}
+
+func (f *fakeJetStream) StreamInfo(name string, _ ...nats.JSOpt) (*nats.StreamInfo, error) {
+	cfg, ok := f.conn.streams[name]
+	if !ok {
+		return nil, nats.ErrStreamNotFound
+	}
+	return &nats.StreamInfo{Config: cfg}, nil
+}
+
+func (f *fakeJetStream) AddStream(cfg *nats.StreamConfig, _ ...nats.JSOpt) (*nats.StreamInfo, error) {
+	if f.conn.streams == nil {
+		f.conn.streams = map[string]nats.StreamConfig{}
+	}
+	f.conn.streams[cfg.Name] = *cfg
+	f.conn.created = append(f.conn.created, cfg.Name)
+	return &nats.StreamInfo{Config: *cfg}, nil
+}
+
+func (f *fakeJetStream) UpdateStream(cfg *nats.StreamConfig, _ ...nats.JSOpt) (*nats.StreamInfo, error) {
+	f.conn.streams[cfg.Name] = *cfg
+	f.conn.updated = append(f.conn.updated, cfg.Name)
+	return &nats.StreamInfo{Config: *cfg}, nil
+}
+
+func fixNATSSubjects() map[string]config.NATSSubject {
+	return map[string]config.NATSSubject{
+		"errors": {
+			Name:     "botkube.errors",
+			Bindings: config.SinkBindings{Sources: []string{"k8s-err-events"}},
+		},
+		"all": {
+			Name:     "botkube.events",
+			Bindings: config.SinkBindings{Sources: []string{"k8s-err-events", "k8s-all-events"}},
+		},
+		"other": {
+			Name:     "botkube.prometheus",
+			Bindings: config.SinkBindings{Sources: []string{"prometheus"}},
+		},
+	}
+}
+
+func TestNATS_SendEvent(t *testing.T) {
+	// given
+	conn := &fakeNATSConn{}
+	sink := newNATS(loggerx.NewNoop(), config.NATS{Subjects: fixNATSSubjects()}, "labs", func() (NATSConn, error) {
+		return conn, nil
+	})
+
+	// when
+	err := sink.SendEvent(context.Background(), fixK8sPodErrorAlert(), []string{"k8s-err-events"})
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, conn.published, 2)
+	assert.Equal(t, 2, conn.flushes)
+	assert.Empty(t, conn.streams)
+
+	var subjects []string
+	for _, msg := range conn.published {
+		subjects = append(subjects, msg.Subject)
+		assert.Equal(t, nats.Header{"Content-Type": []string{"application/json"}, "Botkube-Source": []string{"k8s-err-events"}}, msg.Header)
+
+		var record EventRecord
+		require.NoError(t, json.Unmarshal(msg.Data, &record))
+		assert.Equal(t, "labs", record.ClusterName)
+		assert.Equal(t, "dev", record.Namespace)
+		assert.Equal(t, "Pod/dev/webapp", record.Resource)
+	}
+	assert.ElementsMatch(t, []string{"botkube.errors", "botkube.events"}, subjects)
+	assert.Equal(t, health.StatusHealthy, sink.GetStatus().Status)
+}
+
+func TestNATS_SendEventJetStream(t *testing.T) {
+	// given
+	var (
+		conn     = &fakeNATSConn{}
+		connects int
+	)
+	sink := newNATS(loggerx.NewNoop(), config.NATS{
+		Subjects: fixNATSSubjects(),
+		JetStream: config.NATSJetStream{
+			Enabled: true,
+			Stream:  "BOTKUBE",
+			MaxAge:  time.Hour,
+		},
+	}, "labs", func() (NATSConn, error) {
+		connects++
+		return conn, nil
+	})
+
+	// when
+	for i := 0; i < 2; i++ {
+		err := sink.SendEvent(context.Background(), fixK8sPodErrorAlert(), []string{"k8s-all-events"})
+		require.NoError(t, err)
+	}
+
+	// then
+	assert.Equal(t, 1, connects)
+	assert.Empty(t, conn.published)
+	require.Len(t, conn.jetStream, 2)
+	assert.Equal(t, "botkube.events", conn.jetStream[0].Subject)
+	assert.Equal(t, []string{"BOTKUBE"}, conn.created)
+	assert.Equal(t, map[string]nats.StreamConfig{
+		"BOTKUBE": {
+			Name:     "BOTKUBE",
+			Subjects: []string{"botkube.errors", "botkube.events", "botkube.prometheus"},
+			Storage:  nats.FileStorage,
+			Replicas: 1,
+			MaxAge:   time.Hour,
+		},
+	}, conn.streams)
+}
+
+func TestNATS_SendEventJetStreamExistingStream(t *testing.T) {
+	// given
+	conn := &fakeNATSConn{
+		streams: map[string]nats.StreamConfig{
+			"BOTKUBE": {
+				Name:      "BOTKUBE",
+				Subjects:  []string{"botkube.events", "other.events"},
+				Storage:   nats.MemoryStorage,
+				Replicas:  3,
+				Retention: nats.WorkQueuePolicy,
+			},
+		},
+	}
+	sink := newNATS(loggerx.NewNoop(), config.NATS{
+		Subjects: fixNATSSubjects(),
+		JetStream: config.NATSJetStream{
+			Enabled: true,
+			Stream:  "BOTKUBE",
+		},
+	}, "labs", func() (NATSConn, error) {
+		return conn, nil
+	})
+
+	// when
+	err := sink.SendEvent(context.Background(), fixK8sPodErrorAlert(), []string{"prometheus"})
+
+	// then
+	require.NoError(t, err)
+	assert.Empty(t, conn.created)
+	assert.Equal(t, []string{"BOTKUBE"}, conn.updated)
+	assert.Equal(t, nats.StreamConfig{
+		Name:      "BOTKUBE",
+		Subjects:  []string{"botkube.events", "other.events", "botkube.errors", "botkube.prometheus"},
+		Storage:   nats.MemoryStorage,
+		Replicas:  3,
+		Retention: nats.WorkQueuePolicy,
+	}, conn.streams["BOTKUBE"], "missing subjects are added, other settings are preserved")
+	require.Len(t, conn.jetStream, 1)
+	assert.Equal(t, "botkube.prometheus", conn.jetStream[0].Subject)
+}
+
+func TestNATS_SendEventFailure(t *testing.T) {
+	// given
+	var connects int
+	sink := newNATS(loggerx.NewNoop(), config.NATS{Subjects: fixNATSSubjects()}, "labs", func() (NATSConn, error) {
+		connects++
+		return &fakeNATSConn{closed: connects == 1, publishErr: errors.New("connection reset by peer")}, nil
+	})
+
+	// when
+	err := sink.SendEvent(context.Background(), fixK8sPodErrorAlert(), []string{"prometheus"})
+	require.Error(t, err)
+	err = sink.SendEvent(context.Background(), fixK8sPodErrorAlert(), []string{"prometheus"})
+
+	// then
+	assert.Equal(t, 2, connects, "closed connection is replaced")
+	assert.ErrorContains(t, err, `while publishing event to NATS subject "botkube.prometheus": connection reset by peer`)
+	status := sink.GetStatus()
+	assert.Equal(t, health.StatusUnHealthy, status.Status)
+	assert.Equal(t, health.FailureReasonConnectionError, status.Reason)
+}
@@ -132,8 +132,9 @@ func (w *PagerDuty) shouldNotify(sourceBindings []string) bool {
 
 func (w *PagerDuty) resolveEventMeta(in *incomingEvent) eventMetadata {
 	out := eventMetadata{
-		Summary: fmt.Sprintf("Event from %s source", in.Source),
-		IsAlert: true,
+		Summary:  fmt.Sprintf("Event from %s source", in.Source),
+		Severity: "error",
+		IsAlert:  true,
 	}
 
 	var ev eventPayload
@@ -182,7 +183,7 @@ func (w *PagerDuty) triggerAlert(ctx context.Context, in *incomingEvent, meta ev
 			// The unique location of the affected system, preferably a hostname or FQDN.
 			Source: fmt.Sprintf("%s/%s", w.clusterName, in.Source),
 			// The perceived severity of the status the event is describing with respect to the affected system. This can be critical, error, warning or info.
-			Severity: "error",
+			Severity: meta.Severity,
 
 			// optional
 			Timestamp: in.Timestamp.Format(time.RFC3339),
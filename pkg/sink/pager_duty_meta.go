@@ -60,14 +60,18 @@ type eventMetadata struct {
 	// Component of the source machine that is responsible for the event.
 	// source: https://developer.pagerduty.com/api-reference/368ae3d938c9e-send-an-event-to-pager-duty
 	Component string
-	IsAlert   bool
-	Links     []EventLink
+	// Severity is the perceived severity of the alert. This can be critical, error, warning or info.
+	Severity string
+	IsAlert  bool
+	Links    []EventLink
 }
 
 func enrichWithK8sEventMetadata(out eventMetadata, in k8sEventPayload) eventMetadata {
-	if in.Level == k8sconfig.Error {
+	switch in.Level {
+	case k8sconfig.Critical, k8sconfig.Error, k8sconfig.Warning:
 		out.IsAlert = true
-	} else {
+		out.Severity = string(in.Level)
+	default:
 		out.IsAlert = false
 	}
 
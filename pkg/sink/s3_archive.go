@@ -0,0 +1,436 @@
+package sink
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/klauspost/compress/zstd"
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/internal/health"
+	"github.com/kubeshop/botkube/internal/parquet"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/sliceutil"
+)
+
+var (
+	_ Sink    = &S3Archive{}
+	_ Starter = &S3Archive{}
+)
+
+const (
+	s3ArchiveDefaultPrefix        = "{{ .ClusterName }}/{{ .Year }}/{{ .Month }}/{{ .Day }}"
+	s3ArchiveDefaultFlushInterval = 5 * time.Minute
+	s3ArchiveDefaultMaxBatchBytes = 8 << 20
+	// s3ArchiveShutdownTimeout limits writing the last batch once the sink is stopped.
+	s3ArchiveShutdownTimeout = 30 * time.Second
+)
+
+var s3ArchiveParquetSchema = []parquet.Column{
+	{Name: "source", Kind: parquet.String},
+	{Name: "clusterName", Kind: parquet.String},
+	{Name: "timestamp", Kind: parquet.TimestampMillis},
+	{Name: "namespace", Kind: parquet.String, Optional: true},
+	{Name: "resource", Kind: parquet.String, Optional: true},
+	{Name: "data", Kind: parquet.JSON, Optional: true},
+}
+
+var s3ArchiveParquetCodecs = map[config.S3ArchiveCompression]parquet.Codec{
+	config.NoneS3ArchiveCompression: parquet.Uncompressed,
+	config.GzipS3ArchiveCompression: parquet.Gzip,
+	config.ZstdS3ArchiveCompression: parquet.Zstd,
+}
+
+// S3ObjectPutter writes objects to an S3-compatible object storage.
+type S3ObjectPutter interface {
+	PutObjectWithContext(ctx aws.Context, input *s3.PutObjectInput, opts ...request.Option) (*s3.PutObjectOutput, error)
+}
+
+// S3Archive batches events, and writes them as NDJSON or Parquet objects to an S3-compatible object storage, such as AWS S3, Google Cloud Storage or MinIO.
+// A batch is written once the flush interval elapses, or once it reaches the maximum size.
+type S3Archive struct {
+	log      logrus.FieldLogger
+	reporter AnalyticsReporter
+
+	client        S3ObjectPutter
+	bucket        string
+	prefix        *template.Template
+	format        config.S3ArchiveFormat
+	compression   config.S3ArchiveCompression
+	flushInterval time.Duration
+	maxBatchBytes int
+	bindings      config.SinkBindings
+	clusterName   string
+	now           func() time.Time
+
+	batchMux   sync.Mutex
+	batch      []s3ArchiveEntry
+	batchBytes int
+
+	status        health.PlatformStatusMsg
+	failureReason health.FailureReasonMsg
+	errorMsg      string
+	statusMux     sync.Mutex
+}
+
+// s3ArchiveEntry is a batched event together with its JSON representation, which is reused by encoders.
+type s3ArchiveEntry struct {
+	record EventRecord
+	data   []byte
+}
+
+// s3ArchivePrefixData holds fields available in the object key prefix template.
+type s3ArchivePrefixData struct {
+	ClusterName string
+	Date        string
+	Year        string
+	Month       string
+	Day         string
+	Hour        string
+}
+
+// NewS3Archive creates a new S3Archive instance.
+func NewS3Archive(log logrus.FieldLogger, commGroupIdx int, c config.S3Archive, clusterName string, reporter AnalyticsReporter) (*S3Archive, error) {
+	awsCfg := aws.NewConfig().WithS3ForcePathStyle(c.ForcePathStyle)
+	if c.Region != "" {
+		awsCfg = awsCfg.WithRegion(c.Region)
+	}
+	if c.Endpoint != "" {
+		awsCfg = awsCfg.WithEndpoint(c.Endpoint)
+	}
+	if c.AccessKeyID != "" {
+		awsCfg = awsCfg.WithCredentials(credentials.NewStaticCredentials(c.AccessKeyID, c.SecretAccessKey, ""))
+	}
+	sess, err := session.NewSession(awsCfg)
+	if err != nil {
+		return nil, fmt.Errorf("while creating AWS session: %w", err)
+	}
+
+	notifier, err := newS3Archive(log, c, clusterName, s3.New(sess))
+	if err != nil {
+		return nil, err
+	}
+	notifier.reporter = reporter
+
+	err = reporter.ReportSinkEnabled(notifier.IntegrationName(), commGroupIdx)
+	if err != nil {
+		log.WithError(err).Error("Failed to report analytics")
+	}
+
+	return notifier, nil
+}
+
+func newS3Archive(log logrus.FieldLogger, c config.S3Archive, clusterName string, client S3ObjectPutter) (*S3Archive, error) {
+	prefix := c.Prefix
+	if prefix == "" {
+		prefix = s3ArchiveDefaultPrefix
+	}
+	tpl, err := template.New("prefix").Option("missingkey=error").Parse(prefix)
+	if err != nil {
+		return nil, fmt.Errorf("while parsing object key prefix template: %w", err)
+	}
+
+	format := c.Format
+	if format == "" {
+		format = config.NDJSONS3ArchiveFormat
+	}
+	compression := c.Compression
+	if compression == "" {
+		compression = config.GzipS3ArchiveCompression
+	}
+	flushInterval := c.FlushInterval
+	if flushInterval == 0 {
+		flushInterval = s3ArchiveDefaultFlushInterval
+	}
+	maxBatchBytes := c.MaxBatchBytes
+	if maxBatchBytes == 0 {
+		maxBatchBytes = s3ArchiveDefaultMaxBatchBytes
+	}
+
+	return &S3Archive{
+		log:           log,
+		client:        client,
+		bucket:        c.Bucket,
+		prefix:        tpl,
+		format:        format,
+		compression:   compression,
+		flushInterval: flushInterval,
+		maxBatchBytes: maxBatchBytes,
+		bindings:      c.Bindings,
+		clusterName:   clusterName,
+		now:           time.Now,
+		status:        health.StatusUnknown,
+	}, nil
+}
+
+// Start writes batched events once the flush interval elapses. The last batch is written when the context is cancelled.
+func (s *S3Archive) Start(ctx context.Context) error {
+	ticker := time.NewTicker(s.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			flushCtx, cancel := context.WithTimeout(context.Background(), s3ArchiveShutdownTimeout)
+			defer cancel()
+			if err := s.flush(flushCtx, s.takeBatch()); err != nil {
+				s.log.WithError(err).Error("Failed to archive the last batch of events")
+			}
+			return nil
+		case <-ticker.C:
+			if err := s.flush(ctx, s.takeBatch()); err != nil {
+				s.log.WithError(err).Error("Failed to archive events")
+			}
+		}
+	}
+}
+
+// Flush writes batched events.
+func (s *S3Archive) Flush(ctx context.Context) error {
+	return s.flush(ctx, s.takeBatch())
+}
+
+// SendEvent adds an event to the batch. If the batch reaches the maximum size, it's written immediately.
+func (s *S3Archive) SendEvent(ctx context.Context, rawData any, sources []string) error {
+	if !sliceutil.Intersect(s.bindings.Sources, sources) {
+		return nil
+	}
+
+	record := newEventRecord(rawData, sources, s.clusterName)
+	record.Timestamp = s.now()
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("while marshaling event: %w", err)
+	}
+
+	s.batchMux.Lock()
+	s.batch = append(s.batch, s3ArchiveEntry{record: record, data: data})
+	s.batchBytes += len(data)
+	var full []s3ArchiveEntry
+	if s.batchBytes >= s.maxBatchBytes {
+		full = s.takeBatchLocked()
+	}
+	s.batchMux.Unlock()
+
+	return s.flush(ctx, full)
+}
+
+func (s *S3Archive) takeBatch() []s3ArchiveEntry {
+	s.batchMux.Lock()
+	defer s.batchMux.Unlock()
+	return s.takeBatchLocked()
+}
+
+func (s *S3Archive) takeBatchLocked() []s3ArchiveEntry {
+	batch := s.batch
+	s.batch = nil
+	s.batchBytes = 0
+	return batch
+}
+
+// flush writes a batch as a single object. Events of a failed batch are dropped, as requests are already retried by the S3 client.
+func (s *S3Archive) flush(ctx context.Context, batch []s3ArchiveEntry) error {
+	if len(batch) == 0 {
+		return nil
+	}
+
+	key, err := s.objectKey(batch[0].record.Timestamp)
+	if err != nil {
+		return err
+	}
+
+	body, contentType, contentEncoding, err := s.encode(batch)
+	if err != nil {
+		return fmt.Errorf("while encoding events: %w", err)
+	}
+
+	input := &s3.PutObjectInput{
+		Bucket:      aws.String(s.bucket),
+		Key:         aws.String(key),
+		Body:        bytes.NewReader(body),
+		ContentType: aws.String(contentType),
+	}
+	if contentEncoding != "" {
+		input.ContentEncoding = aws.String(contentEncoding)
+	}
+	if _, err := s.client.PutObjectWithContext(ctx, input); err != nil {
+		s.setFailureReason(health.FailureReasonConnectionError, fmt.Sprintf("while writing object %q: %s", key, err.Error()))
+		return fmt.Errorf("while writing object %q with %d events: %w", key, len(batch), err)
+	}
+
+	s.markHealthy()
+	s.log.Debugf("%d events successfully archived in object %q", len(batch), key)
+	return nil
+}
+
+// objectKey returns the key of the object which holds events batched since a given time.
+func (s *S3Archive) objectKey(batchStart time.Time) (string, error) {
+	batchStart = batchStart.UTC()
+
+	var prefix strings.Builder
+	err := s.prefix.Execute(&prefix, s3ArchivePrefixData{
+		ClusterName: s.clusterName,
+		Date:        batchStart.Format(time.DateOnly),
+		Year:        batchStart.Format("2006"),
+		Month:       batchStart.Format("01"),
+		Day:         batchStart.Format("02"),
+		Hour:        batchStart.Format("15"),
+	})
+	if err != nil {
+		return "", fmt.Errorf("while rendering object key prefix: %w", err)
+	}
+
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", fmt.Errorf("while generating object name: %w", err)
+	}
+
+	name := fmt.Sprintf("%s-%s%s", batchStart.Format("20060102T150405Z"), hex.EncodeToString(suffix), s.objectExtension())
+	return strings.TrimPrefix(path.Join(prefix.String(), name), "/"), nil
+}
+
+func (s *S3Archive) objectExtension() string {
+	if s.format == config.ParquetS3ArchiveFormat {
+		// Parquet pages are compressed, so the file is readable without decompressing it first.
+		return ".parquet"
+	}
+
+	switch s.compression {
+	case config.GzipS3ArchiveCompression:
+		return ".ndjson.gz"
+	case config.ZstdS3ArchiveCompression:
+		return ".ndjson.zst"
+	default:
+		return ".ndjson"
+	}
+}
+
+// encode returns the object body, together with its content type and encoding.
+func (s *S3Archive) encode(batch []s3ArchiveEntry) ([]byte, string, string, error) {
+	if s.format == config.ParquetS3ArchiveFormat {
+		body, err := encodeParquet(batch, s3ArchiveParquetCodecs[s.compression])
+		return body, "application/vnd.apache.parquet", "", err
+	}
+
+	buf := bytes.NewBuffer(nil)
+	out, contentEncoding, err := s.compressor(buf)
+	if err != nil {
+		return nil, "", "", err
+	}
+	for _, entry := range batch {
+		if _, err := out.Write(entry.data); err != nil {
+			return nil, "", "", err
+		}
+		if _, err := out.Write([]byte{'\n'}); err != nil {
+			return nil, "", "", err
+		}
+	}
+	if err := out.Close(); err != nil {
+		return nil, "", "", err
+	}
+	return buf.Bytes(), "application/x-ndjson", contentEncoding, nil
+}
+
+func (s *S3Archive) compressor(w io.Writer) (io.WriteCloser, string, error) {
+	switch s.compression {
+	case config.GzipS3ArchiveCompression:
+		return gzip.NewWriter(w), "gzip", nil
+	case config.ZstdS3ArchiveCompression:
+		enc, err := zstd.NewWriter(w)
+		return enc, "zstd", err
+	default:
+		return nopWriteCloser{w}, "", nil
+	}
+}
+
+func encodeParquet(batch []s3ArchiveEntry, codec parquet.Codec) ([]byte, error) {
+	rows := make([][]any, 0, len(batch))
+	for _, entry := range batch {
+		rec := entry.record
+		var data any
+		if rec.Data != nil {
+			raw, err := json.Marshal(rec.Data)
+			if err != nil {
+				return nil, err
+			}
+			data = raw
+		}
+		rows = append(rows, []any{rec.Source, rec.ClusterName, rec.Timestamp, optionalString(rec.Namespace), optionalString(rec.Resource), data})
+	}
+
+	buf := bytes.NewBuffer(nil)
+	if err := parquet.Write(buf, s3ArchiveParquetSchema, rows, codec); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func optionalString(in string) any {
+	if in == "" {
+		return nil
+	}
+	return in
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
+
+// IntegrationName describes the notifier integration name.
+func (s *S3Archive) IntegrationName() config.CommPlatformIntegration {
+	return config.S3ArchiveCommPlatformIntegration
+}
+
+// Type describes the notifier type.
+func (s *S3Archive) Type() config.IntegrationType {
+	return config.SinkIntegrationType
+}
+
+// GetStatus gets sink status.
+func (s *S3Archive) GetStatus() health.PlatformStatus {
+	s.statusMux.Lock()
+	defer s.statusMux.Unlock()
+
+	return health.PlatformStatus{
+		Status:   s.status,
+		Restarts: "0/0",
+		Reason:   s.failureReason,
+		ErrorMsg: s.errorMsg,
+	}
+}
+
+func (s *S3Archive) setFailureReason(reason health.FailureReasonMsg, errorMsg string) {
+	s.statusMux.Lock()
+	defer s.statusMux.Unlock()
+
+	s.status = health.StatusUnHealthy
+	s.failureReason = reason
+	s.errorMsg = errorMsg
+}
+
+func (s *S3Archive) markHealthy() {
+	s.statusMux.Lock()
+	defer s.statusMux.Unlock()
+
+	s.status = health.StatusHealthy
+	s.failureReason = ""
+	s.errorMsg = ""
+}
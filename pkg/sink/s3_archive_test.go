@@ -0,0 +1,199 @@
+package sink
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/internal/health"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+type fakeS3Object struct {
+	input *s3.PutObjectInput
+	body  []byte
+}
+
+type fakeS3ObjectPutter struct {
+	mu      sync.Mutex
+	err     error
+	objects []fakeS3Object
+}
+
+func (f *fakeS3ObjectPutter) PutObjectWithContext(_ aws.Context, input *s3.PutObjectInput, _ ...request.Option) (*s3.PutObjectOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return nil, f.err
+	}
+	body, err := io.ReadAll(input.Body)
+	if err != nil {
+		return nil, err
+	}
+	f.objects = append(f.objects, fakeS3Object{input: input, body: body})
+	return &s3.PutObjectOutput{}, nil
+}
+
+func (f *fakeS3ObjectPutter) getObjects() []fakeS3Object {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]fakeS3Object(nil), f.objects...)
+}
+
+func fixS3ArchiveTime() time.Time {
+	return time.Date(2024, 3, 1, 12, 30, 5, 0, time.UTC)
+}
+
+func TestS3Archive_Start(t *testing.T) {
+	// given
+	putter := &fakeS3ObjectPutter{}
+	sink, err := newS3Archive(loggerx.NewNoop(), config.S3Archive{
+		Bucket:   "botkube-events",
+		Bindings: config.SinkBindings{Sources: []string{"k8s-err-events"}},
+	}, "labs", putter)
+	require.NoError(t, err)
+	sink.now = fixS3ArchiveTime
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan error)
+	go func() {
+		stopped <- sink.Start(ctx)
+	}()
+
+	// when
+	require.NoError(t, sink.SendEvent(context.Background(), fixK8sPodErrorAlert(), []string{"k8s-err-events"}))
+	require.NoError(t, sink.SendEvent(context.Background(), fixK8sDeployUpdateAlert(), []string{"k8s-err-events"}))
+	require.NoError(t, sink.SendEvent(context.Background(), fixK8sDeployUpdateAlert(), []string{"prometheus"}))
+	assert.Empty(t, putter.getObjects(), "events are batched")
+	cancel()
+
+	// then
+	select {
+	case err := <-stopped:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("sink not stopped")
+	}
+
+	objects := putter.getObjects()
+	require.Len(t, objects, 1)
+	obj := objects[0]
+	assert.Equal(t, "botkube-events", aws.StringValue(obj.input.Bucket))
+	assert.Regexp(t, `^labs/2024/03/01/20240301T123005Z-[0-9a-f]{8}\.ndjson\.gz$`, aws.StringValue(obj.input.Key))
+	assert.Equal(t, "application/x-ndjson", aws.StringValue(obj.input.ContentType))
+	assert.Equal(t, "gzip", aws.StringValue(obj.input.ContentEncoding))
+
+	gz, err := gzip.NewReader(bytes.NewReader(obj.body))
+	require.NoError(t, err)
+	var resources []string
+	scanner := bufio.NewScanner(gz)
+	for scanner.Scan() {
+		var record EventRecord
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+		assert.Equal(t, "labs", record.ClusterName)
+		assert.Equal(t, fixS3ArchiveTime(), record.Timestamp)
+		resources = append(resources, record.Resource)
+	}
+	require.NoError(t, scanner.Err())
+	assert.Equal(t, []string{"Pod/dev/webapp", "Deployment/botkube/nginx-deployment"}, resources)
+	assert.Equal(t, health.StatusHealthy, sink.GetStatus().Status)
+}
+
+func TestS3Archive_Flush(t *testing.T) {
+	// given
+	putter := &fakeS3ObjectPutter{}
+	sink, err := newS3Archive(loggerx.NewNoop(), config.S3Archive{
+		Bucket:   "botkube-events",
+		Bindings: config.SinkBindings{Sources: []string{"k8s-err-events"}},
+	}, "labs", putter)
+	require.NoError(t, err)
+	sink.now = fixS3ArchiveTime
+
+	require.NoError(t, sink.SendEvent(context.Background(), fixK8sPodErrorAlert(), []string{"k8s-err-events"}))
+	require.Empty(t, putter.getObjects())
+
+	// when
+	err = sink.Flush(context.Background())
+
+	// then
+	require.NoError(t, err)
+	assert.Len(t, putter.getObjects(), 1)
+
+	// the batch is written only once
+	require.NoError(t, sink.Flush(context.Background()))
+	assert.Len(t, putter.getObjects(), 1)
+}
+
+func TestS3Archive_SendEventMaxBatchBytes(t *testing.T) {
+	// given
+	putter := &fakeS3ObjectPutter{}
+	sink, err := newS3Archive(loggerx.NewNoop(), config.S3Archive{
+		Bucket:        "botkube-events",
+		Prefix:        "archive/{{ .ClusterName }}/dt={{ .Date }}/hour={{ .Hour }}",
+		Format:        config.ParquetS3ArchiveFormat,
+		Compression:   config.ZstdS3ArchiveCompression,
+		MaxBatchBytes: 1,
+		Bindings:      config.SinkBindings{Sources: []string{"k8s-err-events"}},
+	}, "labs", putter)
+	require.NoError(t, err)
+	sink.now = fixS3ArchiveTime
+
+	// when
+	for i := 0; i < 2; i++ {
+		err := sink.SendEvent(context.Background(), fixK8sPodErrorAlert(), []string{"k8s-err-events"})
+		require.NoError(t, err)
+	}
+
+	// then
+	objects := putter.getObjects()
+	require.Len(t, objects, 2)
+	for _, obj := range objects {
+		assert.Regexp(t, `^archive/labs/dt=2024-03-01/hour=12/20240301T123005Z-[0-9a-f]{8}\.parquet$`, aws.StringValue(obj.input.Key))
+		assert.Equal(t, "application/vnd.apache.parquet", aws.StringValue(obj.input.ContentType))
+		assert.Nil(t, obj.input.ContentEncoding)
+		assert.Equal(t, []byte("PAR1"), obj.body[:4])
+	}
+	assert.NotEqual(t, aws.StringValue(objects[0].input.Key), aws.StringValue(objects[1].input.Key))
+}
+
+func TestS3Archive_SendEventFailure(t *testing.T) {
+	// given
+	putter := &fakeS3ObjectPutter{err: errors.New("AccessDenied: Access Denied")}
+	sink, err := newS3Archive(loggerx.NewNoop(), config.S3Archive{
+		Bucket:        "botkube-events",
+		Prefix:        "{{ .ClusterName }}",
+		Compression:   config.NoneS3ArchiveCompression,
+		MaxBatchBytes: 1,
+		Bindings:      config.SinkBindings{Sources: []string{"k8s-err-events"}},
+	}, "labs", putter)
+	require.NoError(t, err)
+	sink.now = fixS3ArchiveTime
+
+	// when
+	err = sink.SendEvent(context.Background(), fixK8sPodErrorAlert(), []string{"k8s-err-events"})
+
+	// then
+	assert.ErrorContains(t, err, "with 1 events: AccessDenied: Access Denied")
+	status := sink.GetStatus()
+	assert.Equal(t, health.StatusUnHealthy, status.Status)
+	assert.Equal(t, health.FailureReasonConnectionError, status.Reason)
+}
+
+func TestS3Archive_InvalidPrefix(t *testing.T) {
+	_, err := newS3Archive(loggerx.NewNoop(), config.S3Archive{Prefix: "{{ .ClusterName "}, "labs", &fakeS3ObjectPutter{})
+	assert.ErrorContains(t, err, "while parsing object key prefix template")
+}
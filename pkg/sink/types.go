@@ -1,6 +1,8 @@
 package sink
 
 import (
+	"context"
+
 	"github.com/kubeshop/botkube/pkg/config"
 	"github.com/kubeshop/botkube/pkg/notifier"
 )
@@ -10,6 +12,12 @@ type Sink interface {
 	notifier.Sink
 }
 
+// Starter is implemented by sinks which have to run in the background, e.g. to write batched events periodically.
+type Starter interface {
+	// Start runs the sink until the context is cancelled.
+	Start(ctx context.Context) error
+}
+
 // AnalyticsReporter defines a reporter that collects analytics data for sinks.
 type AnalyticsReporter interface {
 	// ReportSinkEnabled reports an enabled sink.
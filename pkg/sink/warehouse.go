@@ -0,0 +1,322 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/internal/health"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/sliceutil"
+)
+
+var (
+	_ Sink    = &Warehouse{}
+	_ Starter = &Warehouse{}
+)
+
+const (
+	warehouseDefaultTable         = "botkube_events"
+	warehouseDefaultFlushInterval = 10 * time.Second
+	warehouseDefaultMaxBatchSize  = 500
+	// warehouseShutdownTimeout limits inserting the last batch once the sink is stopped.
+	warehouseShutdownTimeout = 30 * time.Second
+)
+
+// warehouseColumn describes a column of the events table.
+type warehouseColumn struct {
+	Name           string
+	BigQueryType   string
+	ClickHouseType string
+	Description    string
+}
+
+// warehouseColumns are columns of the events table. New columns are added to existing tables, so they must be appended at the end.
+var warehouseColumns = []warehouseColumn{
+	{Name: "timestamp", BigQueryType: "TIMESTAMP", ClickHouseType: "DateTime64(3, 'UTC')", Description: "Time when the event was sent by Botkube."},
+	{Name: "cluster_name", BigQueryType: "STRING", ClickHouseType: "LowCardinality(String)", Description: "Name of the cluster."},
+	{Name: "source", BigQueryType: "STRING", ClickHouseType: "LowCardinality(String)", Description: "Names of source bindings which emitted the event."},
+	{Name: "namespace", BigQueryType: "STRING", ClickHouseType: "String", Description: "Namespace of the Kubernetes resource."},
+	{Name: "resource", BigQueryType: "STRING", ClickHouseType: "String", Description: "Kubernetes resource, e.g. `Pod/default/nginx`."},
+	{Name: "kind", BigQueryType: "STRING", ClickHouseType: "LowCardinality(String)", Description: "Kind of the Kubernetes resource."},
+	{Name: "type", BigQueryType: "STRING", ClickHouseType: "LowCardinality(String)", Description: "Type of the Kubernetes event, e.g. `create` or `error`."},
+	{Name: "level", BigQueryType: "STRING", ClickHouseType: "LowCardinality(String)", Description: "Level of the Kubernetes event, e.g. `info` or `error`."},
+	{Name: "data", BigQueryType: "JSON", ClickHouseType: "String", Description: "JSON-encoded event."},
+}
+
+// warehouseRow is an event stored in the events table.
+type warehouseRow struct {
+	InsertID    string
+	Timestamp   time.Time
+	ClusterName string
+	Source      string
+	Namespace   string
+	Resource    string
+	Kind        string
+	Type        string
+	Level       string
+	Data        string
+}
+
+// warehouseWriter manages the events table in a given data warehouse.
+type warehouseWriter interface {
+	// EnsureSchema creates the events table if it doesn't exist, and adds missing columns.
+	EnsureSchema(ctx context.Context) error
+	// Insert inserts rows into the events table.
+	Insert(ctx context.Context, rows []warehouseRow) error
+}
+
+// Warehouse batches events, and streams them into a data warehouse table, such as BigQuery or ClickHouse.
+// A batch is inserted once the flush interval elapses, or once it reaches the maximum size.
+type Warehouse struct {
+	log      logrus.FieldLogger
+	reporter AnalyticsReporter
+
+	writer        warehouseWriter
+	backend       config.WarehouseBackend
+	flushInterval time.Duration
+	maxBatchSize  int
+	bindings      config.SinkBindings
+	clusterName   string
+	now           func() time.Time
+
+	batchMux sync.Mutex
+	batch    []warehouseRow
+
+	schemaMux   sync.Mutex
+	schemaReady bool
+
+	status        health.PlatformStatusMsg
+	failureReason health.FailureReasonMsg
+	errorMsg      string
+	statusMux     sync.Mutex
+}
+
+// NewWarehouse creates a new Warehouse instance.
+func NewWarehouse(log logrus.FieldLogger, commGroupIdx int, c config.Warehouse, clusterName string, reporter AnalyticsReporter) (*Warehouse, error) {
+	table := c.Table
+	if table == "" {
+		table = warehouseDefaultTable
+	}
+
+	var (
+		writer warehouseWriter
+		err    error
+	)
+	switch c.Backend {
+	case config.ClickHouseWarehouseBackend:
+		writer = newClickHouseWriter(c.ClickHouse, table)
+	default:
+		writer, err = newBigQueryWriter(context.Background(), c.BigQuery, table)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	notifier := newWarehouse(log, c, clusterName, writer)
+	notifier.reporter = reporter
+
+	err = reporter.ReportSinkEnabled(notifier.IntegrationName(), commGroupIdx)
+	if err != nil {
+		log.WithError(err).Error("Failed to report analytics")
+	}
+
+	return notifier, nil
+}
+
+func newWarehouse(log logrus.FieldLogger, c config.Warehouse, clusterName string, writer warehouseWriter) *Warehouse {
+	backend := c.Backend
+	if backend == "" {
+		backend = config.BigQueryWarehouseBackend
+	}
+	flushInterval := c.FlushInterval
+	if flushInterval == 0 {
+		flushInterval = warehouseDefaultFlushInterval
+	}
+	maxBatchSize := c.MaxBatchSize
+	if maxBatchSize == 0 {
+		maxBatchSize = warehouseDefaultMaxBatchSize
+	}
+
+	return &Warehouse{
+		log:           log,
+		writer:        writer,
+		backend:       backend,
+		flushInterval: flushInterval,
+		maxBatchSize:  maxBatchSize,
+		bindings:      c.Bindings,
+		clusterName:   clusterName,
+		now:           time.Now,
+		status:        health.StatusUnknown,
+	}
+}
+
+// Start inserts batched events once the flush interval elapses. The last batch is inserted when the context is cancelled.
+func (w *Warehouse) Start(ctx context.Context) error {
+	ticker := time.NewTicker(w.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			flushCtx, cancel := context.WithTimeout(context.Background(), warehouseShutdownTimeout)
+			defer cancel()
+			if err := w.flush(flushCtx, w.takeBatch()); err != nil {
+				w.log.WithError(err).Error("Failed to insert the last batch of events")
+			}
+			return nil
+		case <-ticker.C:
+			if err := w.flush(ctx, w.takeBatch()); err != nil {
+				w.log.WithError(err).Error("Failed to insert events")
+			}
+		}
+	}
+}
+
+// Flush inserts batched events.
+func (w *Warehouse) Flush(ctx context.Context) error {
+	return w.flush(ctx, w.takeBatch())
+}
+
+// SendEvent adds an event to the batch. If the batch reaches the maximum size, it's inserted immediately.
+func (w *Warehouse) SendEvent(ctx context.Context, rawData any, sources []string) error {
+	if !sliceutil.Intersect(w.bindings.Sources, sources) {
+		return nil
+	}
+
+	row, err := w.newRow(rawData, sources)
+	if err != nil {
+		return err
+	}
+
+	w.batchMux.Lock()
+	w.batch = append(w.batch, row)
+	var full []warehouseRow
+	if len(w.batch) >= w.maxBatchSize {
+		full = w.takeBatchLocked()
+	}
+	w.batchMux.Unlock()
+
+	return w.flush(ctx, full)
+}
+
+func (w *Warehouse) newRow(rawData any, sources []string) (warehouseRow, error) {
+	record := newEventRecord(rawData, sources, w.clusterName)
+	data, err := json.Marshal(record.Data)
+	if err != nil {
+		return warehouseRow{}, fmt.Errorf("while marshaling event: %w", err)
+	}
+
+	row := warehouseRow{
+		InsertID:    uuid.NewString(),
+		Timestamp:   w.now().UTC(),
+		ClusterName: record.ClusterName,
+		Source:      record.Source,
+		Namespace:   record.Namespace,
+		Resource:    record.Resource,
+		Data:        string(data),
+	}
+
+	var ev k8sEventPayload
+	if err := mapstructure.Decode(rawData, &ev); err == nil {
+		row.Kind = ev.Kind
+		row.Type = ev.Type
+		row.Level = string(ev.Level)
+	}
+	return row, nil
+}
+
+func (w *Warehouse) takeBatch() []warehouseRow {
+	w.batchMux.Lock()
+	defer w.batchMux.Unlock()
+	return w.takeBatchLocked()
+}
+
+func (w *Warehouse) takeBatchLocked() []warehouseRow {
+	batch := w.batch
+	w.batch = nil
+	return batch
+}
+
+// flush inserts a batch. Events of a failed batch are dropped, so a broken warehouse doesn't make Botkube run out of memory.
+func (w *Warehouse) flush(ctx context.Context, batch []warehouseRow) error {
+	if len(batch) == 0 {
+		return nil
+	}
+
+	if err := w.ensureSchema(ctx); err != nil {
+		w.setFailureReason(health.FailureReasonConnectionError, fmt.Sprintf("while preparing %s table: %s", w.backend, err.Error()))
+		return fmt.Errorf("while preparing %s table: %w", w.backend, err)
+	}
+
+	if err := w.writer.Insert(ctx, batch); err != nil {
+		w.setFailureReason(health.FailureReasonConnectionError, fmt.Sprintf("while inserting events into %s: %s", w.backend, err.Error()))
+		return fmt.Errorf("while inserting %d events into %s: %w", len(batch), w.backend, err)
+	}
+
+	w.markHealthy()
+	w.log.Debugf("%d events successfully inserted into %s", len(batch), w.backend)
+	return nil
+}
+
+// ensureSchema prepares the events table once. It's retried with the next batch if it fails.
+func (w *Warehouse) ensureSchema(ctx context.Context) error {
+	w.schemaMux.Lock()
+	defer w.schemaMux.Unlock()
+
+	if w.schemaReady {
+		return nil
+	}
+	if err := w.writer.EnsureSchema(ctx); err != nil {
+		return err
+	}
+	w.schemaReady = true
+	return nil
+}
+
+// IntegrationName describes the notifier integration name.
+func (w *Warehouse) IntegrationName() config.CommPlatformIntegration {
+	return config.WarehouseCommPlatformIntegration
+}
+
+// Type describes the notifier type.
+func (w *Warehouse) Type() config.IntegrationType {
+	return config.SinkIntegrationType
+}
+
+// GetStatus gets sink status.
+func (w *Warehouse) GetStatus() health.PlatformStatus {
+	w.statusMux.Lock()
+	defer w.statusMux.Unlock()
+
+	return health.PlatformStatus{
+		Status:   w.status,
+		Restarts: "0/0",
+		Reason:   w.failureReason,
+		ErrorMsg: w.errorMsg,
+	}
+}
+
+func (w *Warehouse) setFailureReason(reason health.FailureReasonMsg, errorMsg string) {
+	w.statusMux.Lock()
+	defer w.statusMux.Unlock()
+
+	w.status = health.StatusUnHealthy
+	w.failureReason = reason
+	w.errorMsg = errorMsg
+}
+
+func (w *Warehouse) markHealthy() {
+	w.statusMux.Lock()
+	defer w.statusMux.Unlock()
+
+	w.status = health.StatusHealthy
+	w.failureReason = ""
+	w.errorMsg = ""
+}
@@ -0,0 +1,181 @@
+package sink
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	bigquery "google.golang.org/api/bigquery/v2"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+var _ warehouseWriter = &bigQueryWriter{}
+
+// bigQueryWriter streams events into a BigQuery table using the streaming inserts API.
+// The table is partitioned by day, and clustered by the cluster name and source, so queries of a given cluster scan less data.
+type bigQueryWriter struct {
+	svc      *bigquery.Service
+	project  string
+	dataset  string
+	location string
+	table    string
+}
+
+func newBigQueryWriter(ctx context.Context, cfg config.WarehouseBigQuery, table string, opts ...option.ClientOption) (*bigQueryWriter, error) {
+	if cfg.CredentialsFile != "" {
+		opts = append(opts, option.WithCredentialsFile(cfg.CredentialsFile))
+	}
+	svc, err := bigquery.NewService(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("while creating BigQuery client: %w", err)
+	}
+
+	return &bigQueryWriter{
+		svc:      svc,
+		project:  cfg.Project,
+		dataset:  cfg.Dataset,
+		location: cfg.Location,
+		table:    table,
+	}, nil
+}
+
+// EnsureSchema creates the dataset and the table if they don't exist, and adds missing columns to the existing table.
+func (b *bigQueryWriter) EnsureSchema(ctx context.Context) error {
+	_, err := b.svc.Datasets.Get(b.project, b.dataset).Context(ctx).Do()
+	switch {
+	case isGoogleAPINotFound(err):
+		_, err = b.svc.Datasets.Insert(b.project, &bigquery.Dataset{
+			DatasetReference: &bigquery.DatasetReference{ProjectId: b.project, DatasetId: b.dataset},
+			Location:         b.location,
+		}).Context(ctx).Do()
+		if err != nil && !isGoogleAPIConflict(err) {
+			return fmt.Errorf("while creating dataset %q: %w", b.dataset, err)
+		}
+	case err != nil:
+		return fmt.Errorf("while getting dataset %q: %w", b.dataset, err)
+	}
+
+	existing, err := b.svc.Tables.Get(b.project, b.dataset, b.table).Context(ctx).Do()
+	switch {
+	case isGoogleAPINotFound(err):
+		return b.createTable(ctx)
+	case err != nil:
+		return fmt.Errorf("while getting table %q: %w", b.table, err)
+	}
+
+	existingFields := map[string]struct{}{}
+	fields := []*bigquery.TableFieldSchema{}
+	if existing.Schema != nil {
+		for _, field := range existing.Schema.Fields {
+			existingFields[field.Name] = struct{}{}
+		}
+		fields = append(fields, existing.Schema.Fields...)
+	}
+
+	var missing bool
+	for _, field := range bigQueryFields() {
+		if _, ok := existingFields[field.Name]; ok {
+			continue
+		}
+		fields = append(fields, field)
+		missing = true
+	}
+	if !missing {
+		return nil
+	}
+
+	_, err = b.svc.Tables.Patch(b.project, b.dataset, b.table, &bigquery.Table{
+		Schema: &bigquery.TableSchema{Fields: fields},
+	}).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("while adding columns to table %q: %w", b.table, err)
+	}
+	return nil
+}
+
+func (b *bigQueryWriter) createTable(ctx context.Context) error {
+	_, err := b.svc.Tables.Insert(b.project, b.dataset, &bigquery.Table{
+		TableReference: &bigquery.TableReference{ProjectId: b.project, DatasetId: b.dataset, TableId: b.table},
+		Description:    "Events sent by Botkube.",
+		Schema:         &bigquery.TableSchema{Fields: bigQueryFields()},
+		TimePartitioning: &bigquery.TimePartitioning{
+			Type:  "DAY",
+			Field: "timestamp",
+		},
+		Clustering: &bigquery.Clustering{Fields: []string{"cluster_name", "source"}},
+	}).Context(ctx).Do()
+	if err != nil && !isGoogleAPIConflict(err) {
+		return fmt.Errorf("while creating table %q: %w", b.table, err)
+	}
+	return nil
+}
+
+// bigQueryFields returns the table schema. All columns are nullable, so they can be added to existing tables.
+func bigQueryFields() []*bigquery.TableFieldSchema {
+	out := make([]*bigquery.TableFieldSchema, 0, len(warehouseColumns))
+	for _, col := range warehouseColumns {
+		out = append(out, &bigquery.TableFieldSchema{
+			Name:        col.Name,
+			Type:        col.BigQueryType,
+			Mode:        "NULLABLE",
+			Description: col.Description,
+		})
+	}
+	return out
+}
+
+// Insert streams rows into the table. Insert IDs let BigQuery drop duplicates of retried requests.
+func (b *bigQueryWriter) Insert(ctx context.Context, rows []warehouseRow) error {
+	req := &bigquery.TableDataInsertAllRequest{
+		Rows: make([]*bigquery.TableDataInsertAllRequestRows, 0, len(rows)),
+	}
+	for _, row := range rows {
+		req.Rows = append(req.Rows, &bigquery.TableDataInsertAllRequestRows{
+			InsertId: row.InsertID,
+			Json: map[string]bigquery.JsonValue{
+				"timestamp":    row.Timestamp.Format(time.RFC3339Nano),
+				"cluster_name": row.ClusterName,
+				"source":       row.Source,
+				"namespace":    row.Namespace,
+				"resource":     row.Resource,
+				"kind":         row.Kind,
+				"type":         row.Type,
+				"level":        row.Level,
+				"data":         row.Data,
+			},
+		})
+	}
+
+	res, err := b.svc.Tabledata.InsertAll(b.project, b.dataset, b.table, req).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+	if len(res.InsertErrors) == 0 {
+		return nil
+	}
+
+	var msgs []string
+	for _, insertErr := range res.InsertErrors {
+		for _, e := range insertErr.Errors {
+			msgs = append(msgs, fmt.Sprintf("row %d: %s", insertErr.Index, e.Message))
+		}
+	}
+	return fmt.Errorf("%d rows not inserted: %s", len(res.InsertErrors), strings.Join(msgs, "; "))
+}
+
+func isGoogleAPINotFound(err error) bool {
+	var apiErr *googleapi.Error
+	return errors.As(err, &apiErr) && apiErr.Code == http.StatusNotFound
+}
+
+// isGoogleAPIConflict returns true if a resource was already created, e.g. by other Botkube instance.
+func isGoogleAPIConflict(err error) bool {
+	var apiErr *googleapi.Error
+	return errors.As(err, &apiErr) && apiErr.Code == http.StatusConflict
+}
@@ -0,0 +1,155 @@
+package sink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/multierror"
+)
+
+var _ warehouseWriter = &clickHouseWriter{}
+
+const (
+	clickHouseDefaultDatabase = "default"
+	clickHouseTimestampLayout = "2006-01-02 15:04:05.000"
+	// clickHouseMaxErrorLength limits the error message returned by the server, which may include the whole query.
+	clickHouseMaxErrorLength = 512
+)
+
+// clickHouseWriter inserts events into a ClickHouse table using the HTTP interface. See https://clickhouse.com/docs/en/interfaces/http.
+// The table uses the MergeTree engine, partitioned by month, and sorted by the cluster name, source and timestamp.
+type clickHouseWriter struct {
+	client   *http.Client
+	url      string
+	username string
+	password string
+	database string
+	table    string
+}
+
+func newClickHouseWriter(cfg config.WarehouseClickHouse, table string) *clickHouseWriter {
+	database := cfg.Database
+	if database == "" {
+		database = clickHouseDefaultDatabase
+	}
+	return &clickHouseWriter{
+		client:   &http.Client{Timeout: defaultHTTPCliTimeout},
+		url:      cfg.URL,
+		username: cfg.Username,
+		password: cfg.Password,
+		database: database,
+		table:    table,
+	}
+}
+
+// EnsureSchema creates the database and the table if they don't exist, and adds missing columns to the existing table.
+func (c *clickHouseWriter) EnsureSchema(ctx context.Context) error {
+	if err := c.exec(ctx, fmt.Sprintf("CREATE DATABASE IF NOT EXISTS %s", quoteClickHouseIdent(c.database)), nil); err != nil {
+		return fmt.Errorf("while creating database %q: %w", c.database, err)
+	}
+
+	columns := make([]string, 0, len(warehouseColumns))
+	addColumns := make([]string, 0, len(warehouseColumns))
+	for _, col := range warehouseColumns {
+		def := fmt.Sprintf("%s %s COMMENT %s", quoteClickHouseIdent(col.Name), col.ClickHouseType, quoteClickHouseString(col.Description))
+		columns = append(columns, def)
+		addColumns = append(addColumns, "ADD COLUMN IF NOT EXISTS "+def)
+	}
+
+	createTable := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (%s) ENGINE = MergeTree PARTITION BY toYYYYMM(timestamp) ORDER BY (cluster_name, source, timestamp)",
+		c.tableIdent(), strings.Join(columns, ", "))
+	if err := c.exec(ctx, createTable, nil); err != nil {
+		return fmt.Errorf("while creating table %q: %w", c.table, err)
+	}
+
+	alterTable := fmt.Sprintf("ALTER TABLE %s %s", c.tableIdent(), strings.Join(addColumns, ", "))
+	if err := c.exec(ctx, alterTable, nil); err != nil {
+		return fmt.Errorf("while adding columns to table %q: %w", c.table, err)
+	}
+	return nil
+}
+
+// Insert inserts rows in the JSONEachRow format.
+func (c *clickHouseWriter) Insert(ctx context.Context, rows []warehouseRow) error {
+	buf := bytes.NewBuffer(nil)
+	enc := json.NewEncoder(buf)
+	for _, row := range rows {
+		err := enc.Encode(map[string]any{
+			"timestamp":    row.Timestamp.Format(clickHouseTimestampLayout),
+			"cluster_name": row.ClusterName,
+			"source":       row.Source,
+			"namespace":    row.Namespace,
+			"resource":     row.Resource,
+			"kind":         row.Kind,
+			"type":         row.Type,
+			"level":        row.Level,
+			"data":         row.Data,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return c.exec(ctx, fmt.Sprintf("INSERT INTO %s FORMAT JSONEachRow", c.tableIdent()), buf.Bytes())
+}
+
+// exec runs a query. If data is given, the query is passed as a URL parameter, and the data is sent in the request body.
+func (c *clickHouseWriter) exec(ctx context.Context, query string, data []byte) (err error) {
+	endpoint, err := url.Parse(c.url)
+	if err != nil {
+		return fmt.Errorf("while parsing URL: %w", err)
+	}
+
+	body := []byte(query)
+	if data != nil {
+		params := endpoint.Query()
+		params.Set("query", query)
+		endpoint.RawQuery = params.Encode()
+		body = data
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.String(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	if c.username != "" {
+		req.Header.Set("X-ClickHouse-User", c.username)
+		req.Header.Set("X-ClickHouse-Key", c.password)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		deferredErr := resp.Body.Close()
+		if deferredErr != nil {
+			err = multierror.Append(err, deferredErr)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, clickHouseMaxErrorLength))
+		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
+
+func (c *clickHouseWriter) tableIdent() string {
+	return quoteClickHouseIdent(c.database) + "." + quoteClickHouseIdent(c.table)
+}
+
+func quoteClickHouseIdent(in string) string {
+	return "`" + strings.NewReplacer(`\`, `\\`, "`", "\\`").Replace(in) + "`"
+}
+
+func quoteClickHouseString(in string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, "'", `\'`).Replace(in) + "'"
+}
@@ -0,0 +1,274 @@
+package sink
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/option"
+
+	"github.com/kubeshop/botkube/internal/health"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+type fakeWarehouseRequest struct {
+	method string
+	path   string
+	query  string
+	body   []byte
+}
+
+// fakeWarehouseServer records requests, and responds with configured responses. By default, it responds with an empty JSON object.
+type fakeWarehouseServer struct {
+	mu        sync.Mutex
+	requests  []fakeWarehouseRequest
+	responses map[string]func(w http.ResponseWriter)
+}
+
+func (f *fakeWarehouseServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	f.mu.Lock()
+	f.requests = append(f.requests, fakeWarehouseRequest{method: r.Method, path: r.URL.Path, query: r.URL.Query().Get("query"), body: body})
+	respond, ok := f.responses[r.Method+" "+r.URL.Path]
+	f.mu.Unlock()
+
+	if ok {
+		respond(w)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write([]byte("{}"))
+}
+
+func (f *fakeWarehouseServer) getRequests() []fakeWarehouseRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]fakeWarehouseRequest(nil), f.requests...)
+}
+
+func respondWithStatus(code int, body string) func(w http.ResponseWriter) {
+	return func(w http.ResponseWriter) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		_, _ = w.Write([]byte(body))
+	}
+}
+
+func fixWarehouseTime() time.Time {
+	return time.Date(2024, 3, 1, 12, 30, 5, 0, time.UTC)
+}
+
+func TestWarehouse_BigQuery(t *testing.T) {
+	// given
+	notFound := respondWithStatus(http.StatusNotFound, `{"error": {"code": 404, "message": "Not found"}}`)
+	srv := &fakeWarehouseServer{responses: map[string]func(w http.ResponseWriter){
+		"GET /projects/botkube-labs/datasets/cluster_events":                       notFound,
+		"GET /projects/botkube-labs/datasets/cluster_events/tables/botkube_events": notFound,
+	}}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	writer, err := newBigQueryWriter(context.Background(), config.WarehouseBigQuery{
+		Project:  "botkube-labs",
+		Dataset:  "cluster_events",
+		Location: "EU",
+	}, warehouseDefaultTable, option.WithEndpoint(ts.URL+"/"), option.WithoutAuthentication())
+	require.NoError(t, err)
+
+	sink := newWarehouse(loggerx.NewNoop(), config.Warehouse{
+		MaxBatchSize: 2,
+		Bindings:     config.SinkBindings{Sources: []string{"k8s-err-events"}},
+	}, "labs", writer)
+	sink.now = fixWarehouseTime
+
+	// when
+	require.NoError(t, sink.SendEvent(context.Background(), fixK8sPodErrorAlert(), []string{"k8s-err-events"}))
+	require.NoError(t, sink.SendEvent(context.Background(), fixK8sDeployUpdateAlert(), []string{"prometheus"}))
+	assert.Empty(t, srv.getRequests(), "events are batched")
+	require.NoError(t, sink.SendEvent(context.Background(), fixK8sDeployUpdateAlert(), []string{"k8s-err-events"}))
+
+	// then
+	reqs := srv.getRequests()
+	require.Len(t, reqs, 5)
+	assert.Equal(t, "POST /projects/botkube-labs/datasets", reqs[1].method+" "+reqs[1].path)
+	assert.JSONEq(t, `{"datasetReference": {"projectId": "botkube-labs", "datasetId": "cluster_events"}, "location": "EU"}`, string(reqs[1].body))
+
+	assert.Equal(t, "POST /projects/botkube-labs/datasets/cluster_events/tables", reqs[3].method+" "+reqs[3].path)
+	var table struct {
+		Schema struct {
+			Fields []struct {
+				Name string `json:"name"`
+				Type string `json:"type"`
+			} `json:"fields"`
+		} `json:"schema"`
+		TimePartitioning map[string]string `json:"timePartitioning"`
+	}
+	require.NoError(t, json.Unmarshal(reqs[3].body, &table))
+	require.Len(t, table.Schema.Fields, len(warehouseColumns))
+	assert.Equal(t, "timestamp", table.Schema.Fields[0].Name)
+	assert.Equal(t, "TIMESTAMP", table.Schema.Fields[0].Type)
+	assert.Equal(t, map[string]string{"type": "DAY", "field": "timestamp"}, table.TimePartitioning)
+
+	assert.Equal(t, "POST /projects/botkube-labs/datasets/cluster_events/tables/botkube_events/insertAll", reqs[4].method+" "+reqs[4].path)
+	var insert struct {
+		Rows []struct {
+			InsertID string         `json:"insertId"`
+			JSON     map[string]any `json:"json"`
+		} `json:"rows"`
+	}
+	require.NoError(t, json.Unmarshal(reqs[4].body, &insert))
+	require.Len(t, insert.Rows, 2)
+	assert.NotEmpty(t, insert.Rows[0].InsertID)
+	assert.Equal(t, "2024-03-01T12:30:05Z", insert.Rows[0].JSON["timestamp"])
+	assert.Equal(t, "labs", insert.Rows[0].JSON["cluster_name"])
+	assert.Equal(t, "Pod/dev/webapp", insert.Rows[0].JSON["resource"])
+	assert.Equal(t, "Pod", insert.Rows[0].JSON["kind"])
+	assert.Equal(t, "error", insert.Rows[0].JSON["type"])
+	assert.Equal(t, "Deployment/botkube/nginx-deployment", insert.Rows[1].JSON["resource"])
+	assert.Equal(t, health.StatusHealthy, sink.GetStatus().Status)
+
+	// when the next batch is inserted, the schema isn't checked again
+	require.NoError(t, sink.SendEvent(context.Background(), fixK8sPodErrorAlert(), []string{"k8s-err-events"}))
+	require.NoError(t, sink.SendEvent(context.Background(), fixK8sPodErrorAlert(), []string{"k8s-err-events"}))
+	assert.Len(t, srv.getRequests(), 6)
+}
+
+func TestBigQueryWriter_EnsureSchemaAddsMissingColumns(t *testing.T) {
+	// given
+	srv := &fakeWarehouseServer{responses: map[string]func(w http.ResponseWriter){
+		"GET /projects/botkube-labs/datasets/cluster_events/tables/botkube_events": respondWithStatus(http.StatusOK,
+			`{"schema": {"fields": [{"name": "timestamp", "type": "TIMESTAMP"}, {"name": "cluster_name", "type": "STRING"}, {"name": "custom", "type": "STRING"}]}}`),
+	}}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	writer, err := newBigQueryWriter(context.Background(), config.WarehouseBigQuery{
+		Project: "botkube-labs",
+		Dataset: "cluster_events",
+	}, warehouseDefaultTable, option.WithEndpoint(ts.URL+"/"), option.WithoutAuthentication())
+	require.NoError(t, err)
+
+	// when
+	err = writer.EnsureSchema(context.Background())
+
+	// then
+	require.NoError(t, err)
+	reqs := srv.getRequests()
+	require.Len(t, reqs, 3)
+	assert.Equal(t, "PATCH /projects/botkube-labs/datasets/cluster_events/tables/botkube_events", reqs[2].method+" "+reqs[2].path)
+
+	var table struct {
+		Schema struct {
+			Fields []struct {
+				Name string `json:"name"`
+				Mode string `json:"mode"`
+			} `json:"fields"`
+		} `json:"schema"`
+	}
+	require.NoError(t, json.Unmarshal(reqs[2].body, &table))
+	var names []string
+	for _, field := range table.Schema.Fields {
+		names = append(names, field.Name)
+	}
+	assert.Equal(t, []string{"timestamp", "cluster_name", "custom", "source", "namespace", "resource", "kind", "type", "level", "data"}, names)
+	assert.Equal(t, "NULLABLE", table.Schema.Fields[3].Mode)
+}
+
+func TestWarehouse_ClickHouse(t *testing.T) {
+	// given
+	var auth []string
+	srv := &fakeWarehouseServer{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = []string{r.Header.Get("X-ClickHouse-User"), r.Header.Get("X-ClickHouse-Key")}
+		srv.ServeHTTP(w, r)
+	}))
+	defer ts.Close()
+
+	sink := newWarehouse(loggerx.NewNoop(), config.Warehouse{
+		Backend:  config.ClickHouseWarehouseBackend,
+		Bindings: config.SinkBindings{Sources: []string{"k8s-err-events"}},
+	}, "labs", newClickHouseWriter(config.WarehouseClickHouse{
+		URL:      ts.URL,
+		Database: "botkube",
+		Username: "botkube",
+		Password: "secret",
+	}, "events"))
+	sink.now = fixWarehouseTime
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan error)
+	go func() {
+		stopped <- sink.Start(ctx)
+	}()
+
+	// when
+	require.NoError(t, sink.SendEvent(context.Background(), fixK8sPodErrorAlert(), []string{"k8s-err-events"}))
+	require.NoError(t, sink.SendEvent(context.Background(), fixK8sDeployUpdateAlert(), []string{"k8s-err-events"}))
+	cancel()
+
+	// then
+	select {
+	case err := <-stopped:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("sink not stopped")
+	}
+
+	reqs := srv.getRequests()
+	require.Len(t, reqs, 4)
+	assert.Equal(t, "CREATE DATABASE IF NOT EXISTS `botkube`", string(reqs[0].body))
+	assert.True(t, strings.HasPrefix(string(reqs[1].body), "CREATE TABLE IF NOT EXISTS `botkube`.`events` (`timestamp` DateTime64(3, 'UTC') COMMENT"))
+	assert.Contains(t, string(reqs[1].body), "ENGINE = MergeTree PARTITION BY toYYYYMM(timestamp) ORDER BY (cluster_name, source, timestamp)")
+	assert.Contains(t, string(reqs[2].body), "ALTER TABLE `botkube`.`events` ADD COLUMN IF NOT EXISTS `timestamp`")
+
+	assert.Equal(t, "INSERT INTO `botkube`.`events` FORMAT JSONEachRow", reqs[3].query)
+	var resources []string
+	scanner := bufio.NewScanner(bytes.NewReader(reqs[3].body))
+	for scanner.Scan() {
+		var row map[string]any
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &row))
+		assert.Equal(t, "2024-03-01 12:30:05.000", row["timestamp"])
+		assert.Equal(t, "labs", row["cluster_name"])
+		resources = append(resources, row["resource"].(string))
+	}
+	require.NoError(t, scanner.Err())
+	assert.Equal(t, []string{"Pod/dev/webapp", "Deployment/botkube/nginx-deployment"}, resources)
+	assert.Equal(t, []string{"botkube", "secret"}, auth)
+	assert.Equal(t, health.StatusHealthy, sink.GetStatus().Status)
+}
+
+func TestWarehouse_ClickHouseFailure(t *testing.T) {
+	// given
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte("Code: 497. DB::Exception: botkube: Not enough privileges.\n"))
+	}))
+	defer ts.Close()
+
+	sink := newWarehouse(loggerx.NewNoop(), config.Warehouse{
+		Backend:      config.ClickHouseWarehouseBackend,
+		MaxBatchSize: 1,
+		Bindings:     config.SinkBindings{Sources: []string{"k8s-err-events"}},
+	}, "labs", newClickHouseWriter(config.WarehouseClickHouse{URL: ts.URL}, "events"))
+
+	// when
+	err := sink.SendEvent(context.Background(), fixK8sPodErrorAlert(), []string{"k8s-err-events"})
+
+	// then
+	assert.EqualError(t, err, "while preparing clickHouse table: while creating database \"default\": unexpected status code 403: Code: 497. DB::Exception: botkube: Not enough privileges.")
+	status := sink.GetStatus()
+	assert.Equal(t, health.StatusUnHealthy, status.Status)
+	assert.Equal(t, health.FailureReasonConnectionError, status.Reason)
+}
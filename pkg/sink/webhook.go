@@ -3,20 +3,36 @@ package sink
 import (
 	"bytes"
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
+	"text/template"
 	"time"
 
+	"github.com/avast/retry-go/v4"
+	sprig "github.com/go-task/slim-sprig"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 
 	"github.com/kubeshop/botkube/internal/health"
 	"github.com/kubeshop/botkube/pkg/config"
 	"github.com/kubeshop/botkube/pkg/multierror"
+	"github.com/kubeshop/botkube/pkg/tlsx"
 )
 
-const defaultHTTPCliTimeout = 30 * time.Second
+const (
+	defaultHTTPCliTimeout = 30 * time.Second
+
+	webhookDefaultContentType     = "application/json"
+	webhookDefaultSignatureHeader = "X-Botkube-Signature-256"
+	webhookSignaturePrefix        = "sha256="
+	webhookDefaultBackoff         = time.Second
+	webhookDefaultMaxBackoff      = 30 * time.Second
+)
 
 // Webhook provides functionality to notify external service about new events.
 type Webhook struct {
@@ -25,6 +41,15 @@ type Webhook struct {
 
 	URL           string
 	Bindings      config.SinkBindings
+	format        config.WebhookFormat
+	cloudEvents   config.WebhookCloudEvents
+	template      *template.Template
+	contentType   string
+	headers       map[string]string
+	signing       config.WebhookSigning
+	retry         config.WebhookRetry
+	client        *http.Client
+	clusterName   string
 	status        health.PlatformStatusMsg
 	failureReason health.FailureReasonMsg
 	errorMsg      string
@@ -38,17 +63,68 @@ type WebhookPayload struct {
 }
 
 // NewWebhook creates a new Webhook instance.
-func NewWebhook(log logrus.FieldLogger, commGroupIdx int, c config.Webhook, reporter AnalyticsReporter) (*Webhook, error) {
+func NewWebhook(log logrus.FieldLogger, commGroupIdx int, c config.Webhook, clusterName string, reporter AnalyticsReporter) (*Webhook, error) {
+	format := c.Format
+	if format == "" {
+		format = config.DefaultWebhookFormat
+	}
+	cloudEvents := c.CloudEvents
+	if cloudEvents.Mode == "" {
+		cloudEvents.Mode = config.BinaryCloudEventsMode
+	}
+	if cloudEvents.Source == "" {
+		cloudEvents.Source = "/botkube/" + clusterName
+	}
+
+	var tpl *template.Template
+	if c.Template != "" {
+		var err error
+		tpl, err = template.New("payload").Funcs(sprig.TxtFuncMap()).Parse(c.Template)
+		if err != nil {
+			return nil, fmt.Errorf("while parsing payload template: %w", err)
+		}
+	}
+	contentType := c.ContentType
+	if contentType == "" {
+		contentType = webhookDefaultContentType
+	}
+
+	signing := c.Signing
+	if signing.Header == "" {
+		signing.Header = webhookDefaultSignatureHeader
+	}
+	retryCfg := c.Retry
+	if retryCfg.Backoff == 0 {
+		retryCfg.Backoff = webhookDefaultBackoff
+	}
+	if retryCfg.MaxBackoff == 0 {
+		retryCfg.MaxBackoff = webhookDefaultMaxBackoff
+	}
+
+	client, err := newWebhookHTTPClient(c)
+	if err != nil {
+		return nil, err
+	}
+
 	whNotifier := &Webhook{
 		log:           log,
 		reporter:      reporter,
 		URL:           c.URL,
 		Bindings:      c.Bindings,
+		format:        format,
+		cloudEvents:   cloudEvents,
+		template:      tpl,
+		contentType:   contentType,
+		headers:       c.Headers,
+		signing:       signing,
+		retry:         retryCfg,
+		client:        client,
+		clusterName:   clusterName,
 		status:        health.StatusUnknown,
 		failureReason: "",
 	}
 
-	err := reporter.ReportSinkEnabled(whNotifier.IntegrationName(), commGroupIdx)
+	err = reporter.ReportSinkEnabled(whNotifier.IntegrationName(), commGroupIdx)
 	if err != nil {
 		log.Errorf("report analytics error: %s", err.Error())
 	}
@@ -56,15 +132,52 @@ func NewWebhook(log logrus.FieldLogger, commGroupIdx int, c config.Webhook, repo
 	return whNotifier, nil
 }
 
+func newWebhookHTTPClient(c config.Webhook) (*http.Client, error) {
+	timeout := c.Timeout
+	if timeout == 0 {
+		timeout = defaultHTTPCliTimeout
+	}
+
+	tlsCfg, err := tlsx.NewClientConfig(tlsx.ClientOptions{
+		Name:               "Webhook",
+		CAFile:             c.TLS.CAFile,
+		CertFile:           c.TLS.CertFile,
+		KeyFile:            c.TLS.KeyFile,
+		MinVersion:         c.TLS.MinVersion,
+		CipherSuites:       c.TLS.CipherSuites,
+		InsecureSkipVerify: c.TLS.InsecureSkipVerify,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("while creating webhook TLS configuration: %w", err)
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsCfg
+
+	// the transport propagates the trace context, so receivers can continue traces of events
+	return &http.Client{Timeout: timeout, Transport: otelhttp.NewTransport(transport)}, nil
+}
+
 // SendEvent sends an event to a configured server.
 func (w *Webhook) SendEvent(ctx context.Context, rawData any, sources []string) error {
-	jsonPayload := &WebhookPayload{
-		Source: strings.Join(sources, ","),
-		Data:   rawData,
+	var err error
+	switch {
+	case w.template != nil:
+		err = w.postTemplate(ctx, rawData, sources)
+	case w.format == config.CloudEventsWebhookFormat:
+		err = w.postCloudEvent(ctx, rawData, sources)
+	default:
+		err = w.PostWebhook(ctx, &WebhookPayload{
+			Source: strings.Join(sources, ","),
+			Data:   rawData,
+		})
 	}
-
-	err := w.PostWebhook(ctx, jsonPayload)
 	if err != nil {
+		w.log.WithFields(logrus.Fields{
+			"deadLetter": true,
+			"url":        w.URL,
+			"sources":    sources,
+			"event":      rawData,
+		}).WithError(err).Error("Dropping event which couldn't be delivered to Webhook.")
 		w.setFailureReason(health.FailureReasonConnectionError, fmt.Sprintf("while sending message to webhook: %s", err.Error()))
 		return fmt.Errorf("while sending message to webhook: %w", err)
 	}
@@ -81,16 +194,70 @@ func (w *Webhook) PostWebhook(ctx context.Context, jsonPayload *WebhookPayload)
 		return err
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewBuffer(message))
+	return w.post(ctx, message, http.Header{"Content-Type": []string{"application/json"}})
+}
+
+func (w *Webhook) postTemplate(ctx context.Context, rawData any, sources []string) error {
+	var body bytes.Buffer
+	err := w.template.Execute(&body, newEventRecord(rawData, sources, w.clusterName))
 	if err != nil {
-		return err
+		return fmt.Errorf("while rendering payload template: %w", err)
+	}
+
+	return w.post(ctx, body.Bytes(), http.Header{"Content-Type": []string{w.contentType}})
+}
+
+// post sends the request body with configured headers and signature. Requests which failed with a connection error,
+// a 429 or a 5xx status are retried with exponential backoff.
+func (w *Webhook) post(ctx context.Context, body []byte, header http.Header) error {
+	for name, value := range w.headers {
+		header.Set(name, value)
+	}
+	if w.signing.Enabled {
+		mac := hmac.New(sha256.New, []byte(w.signing.Secret))
+		mac.Write(body)
+		header.Set(w.signing.Header, webhookSignaturePrefix+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	return retry.Do(
+		func() error {
+			statusCode, err := w.doPost(ctx, body, header)
+			if err != nil && !isRetriableWebhookStatus(statusCode) {
+				return retry.Unrecoverable(err)
+			}
+			return err
+		},
+		retry.Attempts(uint(w.retry.MaxRetries)+1),
+		retry.Delay(w.retry.Backoff),
+		retry.MaxDelay(w.retry.MaxBackoff),
+		retry.DelayType(retry.BackOffDelay),
+		retry.LastErrorOnly(true),
+		retry.Context(ctx),
+		retry.OnRetry(func(n uint, err error) {
+			// OnRetry is called also after the last attempt
+			if int(n) >= w.retry.MaxRetries {
+				return
+			}
+			w.log.WithError(err).Warnf("Retrying webhook request (%d/%d)...", n+1, w.retry.MaxRetries)
+		}),
+	)
+}
+
+// isRetriableWebhookStatus returns true for connection errors, which don't have a status code, and for responses which may succeed later.
+func isRetriableWebhookStatus(statusCode int) bool {
+	return statusCode == 0 || statusCode == http.StatusTooManyRequests || statusCode >= http.StatusInternalServerError
+}
+
+func (w *Webhook) doPost(ctx context.Context, body []byte, header http.Header) (statusCode int, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewBuffer(body))
+	if err != nil {
+		return 0, retry.Unrecoverable(err)
 	}
-	req.Header.Add("Content-Type", "application/json")
+	req.Header = header.Clone()
 
-	client := &http.Client{Timeout: defaultHTTPCliTimeout}
-	resp, err := client.Do(req)
+	resp, err := w.httpClient().Do(req)
 	if err != nil {
-		return err
+		return 0, err
 	}
 	defer func() {
 		deferredErr := resp.Body.Close()
@@ -99,11 +266,19 @@ func (w *Webhook) PostWebhook(ctx context.Context, jsonPayload *WebhookPayload)
 		}
 	}()
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("Error Posting Webhook: %s", fmt.Sprint(resp.StatusCode))
+	// CloudEvents consumers, such as Knative brokers, respond with 202 Accepted
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return resp.StatusCode, fmt.Errorf("Error Posting Webhook: %s", fmt.Sprint(resp.StatusCode))
 	}
 
-	return nil
+	return resp.StatusCode, nil
+}
+
+func (w *Webhook) httpClient() *http.Client {
+	if w.client != nil {
+		return w.client
+	}
+	return &http.Client{Timeout: defaultHTTPCliTimeout}
 }
 
 // IntegrationName describes the notifier integration name.
@@ -0,0 +1,140 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+// Event types of CloudEvents sent by the webhook sink. They are stable, so consumers can filter events by their type.
+const (
+	CloudEventTypeResourceCreated = "io.botkube.kubernetes.resource.created"
+	CloudEventTypeResourceUpdated = "io.botkube.kubernetes.resource.updated"
+	CloudEventTypeResourceDeleted = "io.botkube.kubernetes.resource.deleted"
+	CloudEventTypeEventError      = "io.botkube.kubernetes.event.error"
+	CloudEventTypeEventWarning    = "io.botkube.kubernetes.event.warning"
+	CloudEventTypeEventNormal     = "io.botkube.kubernetes.event.normal"
+	CloudEventTypeEventInfo       = "io.botkube.kubernetes.event.info"
+	// CloudEventTypeKubernetesEvent is used for Kubernetes events of other types.
+	CloudEventTypeKubernetesEvent = "io.botkube.kubernetes.event"
+	// CloudEventTypeSourceEvent is used for events from other sources, such as Prometheus or ArgoCD.
+	CloudEventTypeSourceEvent = "io.botkube.source.event"
+)
+
+const (
+	cloudEventsSpecVersion        = "1.0"
+	cloudEventsStructuredMimeType = "application/cloudevents+json"
+	cloudEventsDataMimeType       = "application/json"
+	cloudEventsHeaderPrefix       = "ce-"
+)
+
+var cloudEventTypes = map[config.EventType]string{
+	config.CreateEvent:  CloudEventTypeResourceCreated,
+	config.UpdateEvent:  CloudEventTypeResourceUpdated,
+	config.DeleteEvent:  CloudEventTypeResourceDeleted,
+	config.ErrorEvent:   CloudEventTypeEventError,
+	config.WarningEvent: CloudEventTypeEventWarning,
+	config.NormalEvent:  CloudEventTypeEventNormal,
+	config.InfoEvent:    CloudEventTypeEventInfo,
+}
+
+// CloudEvent is a CloudEvents 1.0 event in the structured content mode. See https://github.com/cloudevents/spec/blob/v1.0.2/cloudevents/spec.md.
+type CloudEvent struct {
+	SpecVersion     string    `json:"specversion"`
+	ID              string    `json:"id"`
+	Source          string    `json:"source"`
+	Type            string    `json:"type"`
+	Subject         string    `json:"subject,omitempty"`
+	Time            time.Time `json:"time"`
+	DataContentType string    `json:"datacontenttype"`
+	// BotkubeSource and BotkubeCluster are extension attributes, which identify the source plugin configuration and the cluster.
+	BotkubeSource  string `json:"botkubesource,omitempty"`
+	BotkubeCluster string `json:"botkubecluster,omitempty"`
+	Data           any    `json:"data,omitempty"`
+}
+
+func (w *Webhook) newCloudEvent(rawData any, sources []string) CloudEvent {
+	record := newEventRecord(rawData, sources, w.clusterName)
+	return CloudEvent{
+		SpecVersion:     cloudEventsSpecVersion,
+		ID:              uuid.NewString(),
+		Source:          w.cloudEvents.Source,
+		Type:            cloudEventType(rawData),
+		Subject:         record.Resource,
+		Time:            record.Timestamp.UTC(),
+		DataContentType: cloudEventsDataMimeType,
+		BotkubeSource:   record.Source,
+		BotkubeCluster:  record.ClusterName,
+		Data:            rawData,
+	}
+}
+
+// cloudEventType returns the event type from the Botkube taxonomy.
+func cloudEventType(rawData any) string {
+	var ev k8sEventPayload
+	if err := mapstructure.Decode(rawData, &ev); err != nil || ev.Kind == "" {
+		return CloudEventTypeSourceEvent
+	}
+
+	typ, ok := cloudEventTypes[config.EventType(strings.ToLower(ev.Type))]
+	if !ok {
+		return CloudEventTypeKubernetesEvent
+	}
+	return typ
+}
+
+func (w *Webhook) postCloudEvent(ctx context.Context, rawData any, sources []string) error {
+	event := w.newCloudEvent(rawData, sources)
+
+	if w.cloudEvents.Mode == config.StructuredCloudEventsMode {
+		body, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("while marshaling CloudEvent: %w", err)
+		}
+		return w.post(ctx, body, http.Header{"Content-Type": []string{cloudEventsStructuredMimeType}})
+	}
+
+	body, err := json.Marshal(event.Data)
+	if err != nil {
+		return fmt.Errorf("while marshaling CloudEvent data: %w", err)
+	}
+	header := http.Header{"Content-Type": []string{event.DataContentType}}
+	for name, value := range map[string]string{
+		"specversion":    event.SpecVersion,
+		"id":             event.ID,
+		"source":         event.Source,
+		"type":           event.Type,
+		"subject":        event.Subject,
+		"time":           event.Time.Format(time.RFC3339Nano),
+		"botkubesource":  event.BotkubeSource,
+		"botkubecluster": event.BotkubeCluster,
+	} {
+		if value == "" {
+			continue
+		}
+		header.Set(cloudEventsHeaderPrefix+name, encodeCloudEventHeader(value))
+	}
+	return w.post(ctx, body, header)
+}
+
+// encodeCloudEventHeader percent-encodes the space, double-quote, percent, and characters outside the printable ASCII range,
+// as required by the HTTP protocol binding.
+func encodeCloudEventHeader(in string) string {
+	var out strings.Builder
+	for _, b := range []byte(in) {
+		if b <= ' ' || b > '~' || b == '"' || b == '%' {
+			fmt.Fprintf(&out, "%%%02X", b)
+			continue
+		}
+		out.WriteByte(b)
+	}
+	return out.String()
+}
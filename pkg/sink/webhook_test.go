@@ -2,12 +2,25 @@ package sink
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/internal/analytics"
+	"github.com/kubeshop/botkube/internal/health"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
 )
 
 // Unit test PostWebhook
@@ -44,3 +57,206 @@ func TestPostWebhook(t *testing.T) {
 		})
 	}
 }
+
+func TestWebhook_SendEventCloudEvents(t *testing.T) {
+	tests := map[string]struct {
+		mode         config.CloudEventsMode
+		assertHeader func(t *testing.T, header http.Header, body []byte)
+	}{
+		"Binary content mode": {
+			mode: config.BinaryCloudEventsMode,
+			assertHeader: func(t *testing.T, header http.Header, body []byte) {
+				assert.Equal(t, "application/json", header.Get("Content-Type"))
+				assert.Equal(t, "1.0", header.Get("ce-specversion"))
+				assert.NotEmpty(t, header.Get("ce-id"))
+				assert.Equal(t, "/botkube/labs", header.Get("ce-source"))
+				assert.Equal(t, CloudEventTypeEventError, header.Get("ce-type"))
+				assert.Equal(t, "Pod/dev/webapp", header.Get("ce-subject"))
+				assert.Equal(t, "k8s-err-events", header.Get("ce-botkubesource"))
+				assert.Equal(t, "labs", header.Get("ce-botkubecluster"))
+				_, err := time.Parse(time.RFC3339Nano, header.Get("ce-time"))
+				assert.NoError(t, err)
+
+				var data map[string]any
+				require.NoError(t, json.Unmarshal(body, &data))
+				assert.Equal(t, "webapp", data["Name"])
+			},
+		},
+		"Structured content mode": {
+			mode: config.StructuredCloudEventsMode,
+			assertHeader: func(t *testing.T, header http.Header, body []byte) {
+				assert.Equal(t, "application/cloudevents+json", header.Get("Content-Type"))
+				assert.Empty(t, header.Get("ce-type"))
+
+				var event map[string]any
+				require.NoError(t, json.Unmarshal(body, &event))
+				assert.Equal(t, "1.0", event["specversion"])
+				assert.NotEmpty(t, event["id"])
+				assert.Equal(t, "/botkube/labs", event["source"])
+				assert.Equal(t, CloudEventTypeEventError, event["type"])
+				assert.Equal(t, "Pod/dev/webapp", event["subject"])
+				assert.Equal(t, "application/json", event["datacontenttype"])
+				assert.Equal(t, "k8s-err-events", event["botkubesource"])
+				assert.Equal(t, "webapp", event["data"].(map[string]any)["Name"])
+			},
+		},
+	}
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			// given
+			var (
+				header http.Header
+				body   []byte
+			)
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				header = r.Header
+				var err error
+				body, err = io.ReadAll(r.Body)
+				require.NoError(t, err)
+				w.WriteHeader(http.StatusAccepted)
+			}))
+			defer ts.Close()
+
+			w, err := NewWebhook(loggerx.NewNoop(), 1, config.Webhook{
+				URL:         ts.URL,
+				Format:      config.CloudEventsWebhookFormat,
+				CloudEvents: config.WebhookCloudEvents{Mode: test.mode},
+			}, "labs", analytics.NewNoopReporter())
+			require.NoError(t, err)
+
+			// when
+			err = w.SendEvent(context.Background(), fixK8sPodErrorAlert(), []string{"k8s-err-events"})
+
+			// then
+			require.NoError(t, err)
+			test.assertHeader(t, header, body)
+			assert.Equal(t, health.StatusHealthy, w.GetStatus().Status)
+		})
+	}
+}
+
+func TestCloudEventType(t *testing.T) {
+	tests := map[string]struct {
+		rawData  any
+		expected string
+	}{
+		"Error event":      {rawData: fixK8sPodErrorAlert(), expected: CloudEventTypeEventError},
+		"Updated resource": {rawData: fixK8sDeployUpdateAlert(), expected: CloudEventTypeResourceUpdated},
+		"Unknown type":     {rawData: map[string]any{"Kind": "Pod", "Type": "custom"}, expected: CloudEventTypeKubernetesEvent},
+		"Other source":     {rawData: map[string]any{"alertname": "HighLoad"}, expected: CloudEventTypeSourceEvent},
+		"Plain text":       {rawData: "message", expected: CloudEventTypeSourceEvent},
+	}
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, cloudEventType(test.rawData))
+		})
+	}
+}
+
+func TestEncodeCloudEventHeader(t *testing.T) {
+	assert.Equal(t, "Euro%20%E2%82%AC%20%2250%25%22", encodeCloudEventHeader(`Euro € "50%"`))
+	assert.Equal(t, "/botkube/labs", encodeCloudEventHeader("/botkube/labs"))
+}
+
+func TestWebhook_SendEventTemplate(t *testing.T) {
+	// given
+	var (
+		header http.Header
+		body   []byte
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		var err error
+		body, err = io.ReadAll(r.Body)
+		require.NoError(t, err)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	w, err := NewWebhook(loggerx.NewNoop(), 1, config.Webhook{
+		URL:         ts.URL,
+		Template:    `{"text": "{{ .Resource }} in {{ .ClusterName | upper }}", "source": "{{ .Source }}"}`,
+		ContentType: "application/vnd.chat+json",
+		Headers:     map[string]string{"Authorization": "Bearer token"},
+		Signing:     config.WebhookSigning{Enabled: true, Secret: "s3cr3t"},
+	}, "labs", analytics.NewNoopReporter())
+	require.NoError(t, err)
+
+	// when
+	err = w.SendEvent(context.Background(), fixK8sPodErrorAlert(), []string{"k8s-err-events"})
+
+	// then
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"text": "Pod/dev/webapp in LABS", "source": "k8s-err-events"}`, string(body))
+	assert.Equal(t, "application/vnd.chat+json", header.Get("Content-Type"))
+	assert.Equal(t, "Bearer token", header.Get("Authorization"))
+
+	mac := hmac.New(sha256.New, []byte("s3cr3t"))
+	mac.Write(body)
+	assert.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), header.Get("X-Botkube-Signature-256"))
+}
+
+func TestWebhook_SendEventRetry(t *testing.T) {
+	tests := map[string]struct {
+		statusCodes      []int
+		expectedAttempts int32
+		expectedErr      string
+	}{
+		"Succeeds after retries": {
+			statusCodes:      []int{http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusOK},
+			expectedAttempts: 3,
+		},
+		"Fails after all retries": {
+			statusCodes:      []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway},
+			expectedAttempts: 3,
+			expectedErr:      "while sending message to webhook: Error Posting Webhook: 502",
+		},
+		"Doesn't retry client errors": {
+			statusCodes:      []int{http.StatusBadRequest, http.StatusOK},
+			expectedAttempts: 1,
+			expectedErr:      "while sending message to webhook: Error Posting Webhook: 400",
+		},
+	}
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			// given
+			var attempts atomic.Int32
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := attempts.Add(1)
+				w.WriteHeader(test.statusCodes[n-1])
+			}))
+			defer ts.Close()
+
+			w, err := NewWebhook(loggerx.NewNoop(), 1, config.Webhook{
+				URL: ts.URL,
+				Retry: config.WebhookRetry{
+					MaxRetries: 2,
+					Backoff:    time.Millisecond,
+					MaxBackoff: time.Millisecond,
+				},
+			}, "labs", analytics.NewNoopReporter())
+			require.NoError(t, err)
+
+			// when
+			err = w.SendEvent(context.Background(), fixK8sPodErrorAlert(), []string{"k8s-err-events"})
+
+			// then
+			assert.Equal(t, test.expectedAttempts, attempts.Load())
+			if test.expectedErr == "" {
+				require.NoError(t, err)
+				assert.Equal(t, health.StatusHealthy, w.GetStatus().Status)
+				return
+			}
+			assert.EqualError(t, err, test.expectedErr)
+			assert.Equal(t, health.StatusUnHealthy, w.GetStatus().Status)
+		})
+	}
+}
+
+func TestNewWebhook_InvalidTemplate(t *testing.T) {
+	_, err := NewWebhook(loggerx.NewNoop(), 1, config.Webhook{Template: "{{ .Resource "}, "labs", analytics.NewNoopReporter())
+	assert.ErrorContains(t, err, "while parsing payload template")
+}
@@ -0,0 +1,243 @@
+// Package tlsx holds the TLS policy of outbound connections, such as trusted CAs of TLS-intercepting proxies,
+// the minimum TLS version and allowed cipher suites.
+package tlsx
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/gorilla/websocket"
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+const defaultMinVersion = tls.VersionTLS12
+
+var versions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+var (
+	mu     sync.RWMutex
+	global                    = policy{minVersion: defaultMinVersion}
+	log    logrus.FieldLogger = loggerx.NewNoop()
+)
+
+// policy holds the parsed global TLS policy.
+type policy struct {
+	// caPEM holds CA certificates trusted in addition to the system ones.
+	caPEM              []byte
+	minVersion         uint16
+	cipherSuites       []uint16
+	insecureSkipVerify bool
+}
+
+// ClientOptions holds integration-specific TLS settings, which take precedence over the global policy.
+type ClientOptions struct {
+	// Name identifies the integration in logged warnings.
+	Name string
+	// CAFile is the path of PEM-encoded CA certificates trusted in addition to the system ones and the global ones.
+	CAFile string
+	// CertFile and KeyFile are paths of the PEM-encoded client certificate and its private key, used for mutual TLS.
+	CertFile           string
+	KeyFile            string
+	MinVersion         string
+	CipherSuites       []string
+	InsecureSkipVerify bool
+}
+
+// SetGlobalPolicy validates the global TLS policy and sets it for all clients created with NewClientConfig.
+// The policy is also applied to the default HTTP transport and WebSocket dialer, which are used by chat platform clients.
+func SetGlobalPolicy(logger logrus.FieldLogger, cfg config.TLSPolicy) error {
+	p, err := parsePolicy(cfg)
+	if err != nil {
+		return err
+	}
+
+	mu.Lock()
+	global = p
+	log = logger
+	mu.Unlock()
+
+	if cfg.InsecureSkipVerify {
+		logger.Warn("TLS certificate verification is disabled for ALL outbound connections! Connections can be intercepted and read. Use it only for testing.")
+	}
+
+	if cfg.CAFile == "" && cfg.MinVersion == "" && len(cfg.CipherSuites) == 0 && !cfg.InsecureSkipVerify {
+		// keep the defaults untouched
+		return nil
+	}
+
+	tlsCfg, err := NewClientConfig(ClientOptions{Name: "default"})
+	if err != nil {
+		return err
+	}
+	if tr, ok := http.DefaultTransport.(*http.Transport); ok {
+		tr.TLSClientConfig = tlsCfg
+	}
+	websocket.DefaultDialer.TLSClientConfig = tlsCfg.Clone()
+	return nil
+}
+
+// NewClientConfig returns the TLS configuration of a client, which follows the global policy overridden by given options.
+// Integration-specific CA certificates are trusted in addition to the system ones and the global ones.
+func NewClientConfig(opts ClientOptions) (*tls.Config, error) {
+	mu.RLock()
+	p, logger := global, log
+	mu.RUnlock()
+
+	out := &tls.Config{
+		MinVersion:   p.minVersion,
+		CipherSuites: p.cipherSuites,
+		// #nosec G402
+		InsecureSkipVerify: p.insecureSkipVerify || opts.InsecureSkipVerify,
+	}
+
+	if opts.MinVersion != "" {
+		ver, err := parseVersion(opts.MinVersion)
+		if err != nil {
+			return nil, err
+		}
+		out.MinVersion = ver
+	}
+	if len(opts.CipherSuites) > 0 {
+		suites, err := parseCipherSuites(opts.CipherSuites)
+		if err != nil {
+			return nil, err
+		}
+		out.CipherSuites = suites
+	}
+
+	if len(p.caPEM) > 0 || opts.CAFile != "" {
+		pool, err := RootCAs()
+		if err != nil {
+			return nil, err
+		}
+		if opts.CAFile != "" {
+			if err := appendCAFile(pool, opts.CAFile); err != nil {
+				return nil, err
+			}
+		}
+		out.RootCAs = pool
+	}
+
+	if opts.CertFile != "" || opts.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(opts.CertFile, opts.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("while loading client certificate: %w", err)
+		}
+		out.Certificates = []tls.Certificate{cert}
+	}
+
+	if opts.InsecureSkipVerify {
+		logger.Warnf("TLS certificate verification is disabled for %q connections! Connections can be intercepted and read. Use it only for testing.", opts.Name)
+	}
+	if out.MinVersion < tls.VersionTLS12 {
+		logger.Warnf("TLS versions older than 1.2 are allowed for %q connections. They are deprecated and insecure.", opts.Name)
+	}
+
+	return out, nil
+}
+
+// RootCAs returns the system certificate pool extended with CA certificates of the global policy.
+func RootCAs() (*x509.CertPool, error) {
+	mu.RLock()
+	caPEM := global.caPEM
+	mu.RUnlock()
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if len(caPEM) > 0 && !pool.AppendCertsFromPEM(caPEM) {
+		return nil, fmt.Errorf("no PEM-encoded certificates found in the global CA bundle")
+	}
+	return pool, nil
+}
+
+func parsePolicy(cfg config.TLSPolicy) (policy, error) {
+	out := policy{
+		minVersion:         defaultMinVersion,
+		insecureSkipVerify: cfg.InsecureSkipVerify,
+	}
+
+	if cfg.CAFile != "" {
+		data, err := os.ReadFile(filepath.Clean(cfg.CAFile))
+		if err != nil {
+			return policy{}, fmt.Errorf("while reading global CA file: %w", err)
+		}
+		if !x509.NewCertPool().AppendCertsFromPEM(data) {
+			return policy{}, fmt.Errorf("no PEM-encoded certificates found in %q", cfg.CAFile)
+		}
+		out.caPEM = data
+	}
+	if cfg.MinVersion != "" {
+		ver, err := parseVersion(cfg.MinVersion)
+		if err != nil {
+			return policy{}, err
+		}
+		out.minVersion = ver
+	}
+	if len(cfg.CipherSuites) > 0 {
+		suites, err := parseCipherSuites(cfg.CipherSuites)
+		if err != nil {
+			return policy{}, err
+		}
+		out.cipherSuites = suites
+	}
+	return out, nil
+}
+
+func parseVersion(in string) (uint16, error) {
+	ver, found := versions[in]
+	if !found {
+		return 0, fmt.Errorf("unsupported TLS version %q, use one of: 1.0, 1.1, 1.2, 1.3", in)
+	}
+	return ver, nil
+}
+
+// parseCipherSuites returns IDs of given cipher suites. Insecure cipher suites are rejected.
+func parseCipherSuites(names []string) ([]uint16, error) {
+	secure := map[string]uint16{}
+	for _, s := range tls.CipherSuites() {
+		secure[s.Name] = s.ID
+	}
+	insecure := map[string]struct{}{}
+	for _, s := range tls.InsecureCipherSuites() {
+		insecure[s.Name] = struct{}{}
+	}
+
+	out := make([]uint16, 0, len(names))
+	for _, name := range names {
+		if _, found := insecure[name]; found {
+			return nil, fmt.Errorf("cipher suite %q is insecure", name)
+		}
+		id, found := secure[name]
+		if !found {
+			return nil, fmt.Errorf("unknown cipher suite %q", name)
+		}
+		out = append(out, id)
+	}
+	return out, nil
+}
+
+func appendCAFile(pool *x509.CertPool, path string) error {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return fmt.Errorf("while reading CA file: %w", err)
+	}
+	if !pool.AppendCertsFromPEM(data) {
+		return fmt.Errorf("no PEM-encoded certificates found in %q", path)
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package tlsx
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+func TestSetGlobalPolicy(t *testing.T) {
+	// given
+	resetGlobalPolicy(t)
+	caFile, caCert := writeTestCA(t)
+
+	// when
+	err := SetGlobalPolicy(loggerx.NewNoop(), config.TLSPolicy{
+		CAFile:       caFile,
+		MinVersion:   "1.3",
+		CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"},
+	})
+
+	// then
+	require.NoError(t, err)
+
+	defaultTLS := http.DefaultTransport.(*http.Transport).TLSClientConfig
+	require.NotNil(t, defaultTLS)
+	assert.Equal(t, uint16(tls.VersionTLS13), defaultTLS.MinVersion)
+	assert.Equal(t, []uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256}, defaultTLS.CipherSuites)
+	assertTrusts(t, defaultTLS.RootCAs, caCert)
+	require.NotNil(t, websocket.DefaultDialer.TLSClientConfig)
+	assert.Equal(t, uint16(tls.VersionTLS13), websocket.DefaultDialer.TLSClientConfig.MinVersion)
+}
+
+func TestNewClientConfig(t *testing.T) {
+	// given
+	resetGlobalPolicy(t)
+	globalCAFile, globalCA := writeTestCA(t)
+	integrationCAFile, integrationCA := writeTestCA(t)
+	require.NoError(t, SetGlobalPolicy(loggerx.NewNoop(), config.TLSPolicy{CAFile: globalCAFile, MinVersion: "1.3"}))
+
+	// when
+	out, err := NewClientConfig(ClientOptions{
+		Name:               "test",
+		CAFile:             integrationCAFile,
+		MinVersion:         "1.2",
+		InsecureSkipVerify: true,
+	})
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, uint16(tls.VersionTLS12), out.MinVersion)
+	assert.True(t, out.InsecureSkipVerify)
+	// both the global and the integration CAs are trusted
+	assertTrusts(t, out.RootCAs, globalCA)
+	assertTrusts(t, out.RootCAs, integrationCA)
+}
+
+func TestNewClientConfigDefaults(t *testing.T) {
+	// given
+	resetGlobalPolicy(t)
+
+	// when
+	out, err := NewClientConfig(ClientOptions{Name: "test"})
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, uint16(tls.VersionTLS12), out.MinVersion)
+	assert.Nil(t, out.RootCAs)
+	assert.Empty(t, out.CipherSuites)
+	assert.False(t, out.InsecureSkipVerify)
+}
+
+func TestInvalidPolicy(t *testing.T) {
+	tests := []struct {
+		name   string
+		cfg    config.TLSPolicy
+		expErr string
+	}{
+		{
+			name:   "insecure cipher suite",
+			cfg:    config.TLSPolicy{CipherSuites: []string{"TLS_RSA_WITH_RC4_128_SHA"}},
+			expErr: `cipher suite "TLS_RSA_WITH_RC4_128_SHA" is insecure`,
+		},
+		{
+			name:   "unknown cipher suite",
+			cfg:    config.TLSPolicy{CipherSuites: []string{"TLS_FOO"}},
+			expErr: `unknown cipher suite "TLS_FOO"`,
+		},
+		{
+			name:   "unsupported version",
+			cfg:    config.TLSPolicy{MinVersion: "1.4"},
+			expErr: `unsupported TLS version "1.4"`,
+		},
+		{
+			name:   "missing CA file",
+			cfg:    config.TLSPolicy{CAFile: "/not/existing/ca.crt"},
+			expErr: "while reading global CA file",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			resetGlobalPolicy(t)
+
+			// when
+			err := SetGlobalPolicy(loggerx.NewNoop(), tc.cfg)
+
+			// then
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.expErr)
+		})
+	}
+}
+
+func resetGlobalPolicy(t *testing.T) {
+	t.Helper()
+
+	transport := http.DefaultTransport.(*http.Transport)
+	prevTransportTLS, prevDialerTLS := transport.TLSClientConfig, websocket.DefaultDialer.TLSClientConfig
+	mu.Lock()
+	prevPolicy, prevLog := global, log
+	global = policy{minVersion: defaultMinVersion}
+	mu.Unlock()
+
+	t.Cleanup(func() {
+		transport.TLSClientConfig = prevTransportTLS
+		websocket.DefaultDialer.TLSClientConfig = prevDialerTLS
+		mu.Lock()
+		global, log = prevPolicy, prevLog
+		mu.Unlock()
+	})
+}
+
+func assertTrusts(t *testing.T, pool *x509.CertPool, cert *x509.Certificate) {
+	t.Helper()
+
+	require.NotNil(t, pool)
+	_, err := cert.Verify(x509.VerifyOptions{Roots: pool})
+	assert.NoError(t, err)
+}
+
+func writeTestCA(t *testing.T) (string, *x509.Certificate) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Botkube test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "ca.crt")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	return path, cert
+}